@@ -10,6 +10,7 @@ import (
 	_ "github.com/otterscale/otterscale-agent/api"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	unsafe "unsafe"
 )
@@ -25,6 +26,7 @@ type Cluster struct {
 	state                   protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_Name         *string                `protobuf:"bytes,1,opt,name=name"`
 	xxx_hidden_AgentVersion *string                `protobuf:"bytes,2,opt,name=agent_version,json=agentVersion"`
+	xxx_hidden_OutOfDate    bool                   `protobuf:"varint,3,opt,name=out_of_date,json=outOfDate"`
 	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
 	XXX_presence            [1]uint32
 	unknownFields           protoimpl.UnknownFields
@@ -76,14 +78,26 @@ func (x *Cluster) GetAgentVersion() string {
 	return ""
 }
 
+func (x *Cluster) GetOutOfDate() bool {
+	if x != nil {
+		return x.xxx_hidden_OutOfDate
+	}
+	return false
+}
+
 func (x *Cluster) SetName(v string) {
 	x.xxx_hidden_Name = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
 }
 
 func (x *Cluster) SetAgentVersion(v string) {
 	x.xxx_hidden_AgentVersion = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *Cluster) SetOutOfDate(v bool) {
+	x.xxx_hidden_OutOfDate = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
 }
 
 func (x *Cluster) HasName() bool {
@@ -100,6 +114,13 @@ func (x *Cluster) HasAgentVersion() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
+func (x *Cluster) HasOutOfDate() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
 func (x *Cluster) ClearName() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Name = nil
@@ -110,6 +131,11 @@ func (x *Cluster) ClearAgentVersion() {
 	x.xxx_hidden_AgentVersion = nil
 }
 
+func (x *Cluster) ClearOutOfDate() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_OutOfDate = false
+}
+
 type Cluster_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -117,6 +143,10 @@ type Cluster_builder struct {
 	Name *string
 	// The version of the agent binary (e.g. "v1.2.3"), set at build time.
 	AgentVersion *string
+	// Whether the agent is an older semantic version than the server.
+	// Agents or servers with unparseable versions, such as development
+	// builds, are never reported as out of date.
+	OutOfDate *bool
 }
 
 func (b0 Cluster_builder) Build() *Cluster {
@@ -124,21 +154,28 @@ func (b0 Cluster_builder) Build() *Cluster {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Name != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
 		x.xxx_hidden_Name = b.Name
 	}
 	if b.AgentVersion != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
 		x.xxx_hidden_AgentVersion = b.AgentVersion
 	}
+	if b.OutOfDate != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_OutOfDate = *b.OutOfDate
+	}
 	return m0
 }
 
-// ListClustersRequest is an empty request message for listing clusters.
+// ListClustersRequest filters the clusters to list.
 type ListClustersRequest struct {
-	state         protoimpl.MessageState `protogen:"opaque.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_OutOfDateOnly bool                   `protobuf:"varint,1,opt,name=out_of_date_only,json=outOfDateOnly"`
+	XXX_raceDetectHookData   protoimpl.RaceDetectHookData
+	XXX_presence             [1]uint32
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
 }
 
 func (x *ListClustersRequest) Reset() {
@@ -166,15 +203,45 @@ func (x *ListClustersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
+func (x *ListClustersRequest) GetOutOfDateOnly() bool {
+	if x != nil {
+		return x.xxx_hidden_OutOfDateOnly
+	}
+	return false
+}
+
+func (x *ListClustersRequest) SetOutOfDateOnly(v bool) {
+	x.xxx_hidden_OutOfDateOnly = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *ListClustersRequest) HasOutOfDateOnly() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ListClustersRequest) ClearOutOfDateOnly() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_OutOfDateOnly = false
+}
+
 type ListClustersRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
+	// Only list clusters whose agent is out of date.
+	OutOfDateOnly *bool
 }
 
 func (b0 ListClustersRequest_builder) Build() *ListClustersRequest {
 	m0 := &ListClustersRequest{}
 	b, x := &b0, m0
 	_, _ = b, x
+	if b.OutOfDateOnly != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_OutOfDateOnly = *b.OutOfDateOnly
+	}
 	return m0
 }
 
@@ -621,23 +688,221 @@ func (b0 GetAgentManifestResponse_builder) Build() *GetAgentManifestResponse {
 	return m0
 }
 
+// GetKubeconfigRequest identifies the cluster the kubeconfig targets.
+type GetKubeconfigRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster     *string                `protobuf:"bytes,1,opt,name=cluster"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *GetKubeconfigRequest) Reset() {
+	*x = GetKubeconfigRequest{}
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKubeconfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKubeconfigRequest) ProtoMessage() {}
+
+func (x *GetKubeconfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetKubeconfigRequest) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GetKubeconfigRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *GetKubeconfigRequest) HasCluster() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *GetKubeconfigRequest) ClearCluster() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Cluster = nil
+}
+
+type GetKubeconfigRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The cluster name, used for the kubeconfig's cluster and context.
+	Cluster *string
+}
+
+func (b0 GetKubeconfigRequest_builder) Build() *GetKubeconfigRequest {
+	m0 := &GetKubeconfigRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Cluster != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Cluster = b.Cluster
+	}
+	return m0
+}
+
+// GetKubeconfigResponse contains the generated kubeconfig.
+type GetKubeconfigResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Kubeconfig  *string                `protobuf:"bytes,1,opt,name=kubeconfig"`
+	xxx_hidden_Url         *string                `protobuf:"bytes,2,opt,name=url"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *GetKubeconfigResponse) Reset() {
+	*x = GetKubeconfigResponse{}
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKubeconfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKubeconfigResponse) ProtoMessage() {}
+
+func (x *GetKubeconfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetKubeconfigResponse) GetKubeconfig() string {
+	if x != nil {
+		if x.xxx_hidden_Kubeconfig != nil {
+			return *x.xxx_hidden_Kubeconfig
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GetKubeconfigResponse) GetUrl() string {
+	if x != nil {
+		if x.xxx_hidden_Url != nil {
+			return *x.xxx_hidden_Url
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GetKubeconfigResponse) SetKubeconfig(v string) {
+	x.xxx_hidden_Kubeconfig = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *GetKubeconfigResponse) SetUrl(v string) {
+	x.xxx_hidden_Url = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *GetKubeconfigResponse) HasKubeconfig() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *GetKubeconfigResponse) HasUrl() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *GetKubeconfigResponse) ClearKubeconfig() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Kubeconfig = nil
+}
+
+func (x *GetKubeconfigResponse) ClearUrl() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Url = nil
+}
+
+type GetKubeconfigResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Kubeconfig YAML with the URL of the server's kube-API proxy for the
+	// cluster, a context named after the cluster, and an OIDC exec
+	// credential plugin stanza.
+	Kubeconfig *string
+	// URL with an embedded HMAC token that serves the kubeconfig as raw
+	// YAML. The URL expires shortly after it is issued.
+	Url *string
+}
+
+func (b0 GetKubeconfigResponse_builder) Build() *GetKubeconfigResponse {
+	m0 := &GetKubeconfigResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Kubeconfig != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Kubeconfig = b.Kubeconfig
+	}
+	if b.Url != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Url = b.Url
+	}
+	return m0
+}
+
 // RegisterResponse contains a CA-signed certificate and the CA
 // certificate so the agent can establish an mTLS tunnel connection.
 type RegisterResponse struct {
-	state                    protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Endpoint      *string                `protobuf:"bytes,1,opt,name=endpoint"`
-	xxx_hidden_Certificate   []byte                 `protobuf:"bytes,2,opt,name=certificate"`
-	xxx_hidden_CaCertificate []byte                 `protobuf:"bytes,3,opt,name=ca_certificate,json=caCertificate"`
-	xxx_hidden_ServerVersion *string                `protobuf:"bytes,4,opt,name=server_version,json=serverVersion"`
-	XXX_raceDetectHookData   protoimpl.RaceDetectHookData
-	XXX_presence             [1]uint32
-	unknownFields            protoimpl.UnknownFields
-	sizeCache                protoimpl.SizeCache
+	state                        protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Endpoint          *string                `protobuf:"bytes,1,opt,name=endpoint"`
+	xxx_hidden_Certificate       []byte                 `protobuf:"bytes,2,opt,name=certificate"`
+	xxx_hidden_CaCertificate     []byte                 `protobuf:"bytes,3,opt,name=ca_certificate,json=caCertificate"`
+	xxx_hidden_ServerVersion     *string                `protobuf:"bytes,4,opt,name=server_version,json=serverVersion"`
+	xxx_hidden_TunnelFingerprint *string                `protobuf:"bytes,5,opt,name=tunnel_fingerprint,json=tunnelFingerprint"`
+	XXX_raceDetectHookData       protoimpl.RaceDetectHookData
+	XXX_presence                 [1]uint32
+	unknownFields                protoimpl.UnknownFields
+	sizeCache                    protoimpl.SizeCache
 }
 
 func (x *RegisterResponse) Reset() {
 	*x = RegisterResponse{}
-	mi := &file_api_fleet_v1_fleet_proto_msgTypes[6]
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -649,7 +914,7 @@ func (x *RegisterResponse) String() string {
 func (*RegisterResponse) ProtoMessage() {}
 
 func (x *RegisterResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_fleet_v1_fleet_proto_msgTypes[6]
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -694,9 +959,19 @@ func (x *RegisterResponse) GetServerVersion() string {
 	return ""
 }
 
+func (x *RegisterResponse) GetTunnelFingerprint() string {
+	if x != nil {
+		if x.xxx_hidden_TunnelFingerprint != nil {
+			return *x.xxx_hidden_TunnelFingerprint
+		}
+		return ""
+	}
+	return ""
+}
+
 func (x *RegisterResponse) SetEndpoint(v string) {
 	x.xxx_hidden_Endpoint = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
 }
 
 func (x *RegisterResponse) SetCertificate(v []byte) {
@@ -704,7 +979,7 @@ func (x *RegisterResponse) SetCertificate(v []byte) {
 		v = []byte{}
 	}
 	x.xxx_hidden_Certificate = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 5)
 }
 
 func (x *RegisterResponse) SetCaCertificate(v []byte) {
@@ -712,12 +987,17 @@ func (x *RegisterResponse) SetCaCertificate(v []byte) {
 		v = []byte{}
 	}
 	x.xxx_hidden_CaCertificate = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 5)
 }
 
 func (x *RegisterResponse) SetServerVersion(v string) {
 	x.xxx_hidden_ServerVersion = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 5)
+}
+
+func (x *RegisterResponse) SetTunnelFingerprint(v string) {
+	x.xxx_hidden_TunnelFingerprint = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 5)
 }
 
 func (x *RegisterResponse) HasEndpoint() bool {
@@ -748,6 +1028,13 @@ func (x *RegisterResponse) HasServerVersion() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
 }
 
+func (x *RegisterResponse) HasTunnelFingerprint() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
 func (x *RegisterResponse) ClearEndpoint() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Endpoint = nil
@@ -768,6 +1055,11 @@ func (x *RegisterResponse) ClearServerVersion() {
 	x.xxx_hidden_ServerVersion = nil
 }
 
+func (x *RegisterResponse) ClearTunnelFingerprint() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_TunnelFingerprint = nil
+}
+
 type RegisterResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -781,6 +1073,10 @@ type RegisterResponse_builder struct {
 	// Agents compare this against their own version to decide whether a
 	// self-update is needed.
 	ServerVersion *string
+	// SHA256 fingerprint of the tunnel server's SSH host key. Agents pin
+	// it for the tunnel connection that follows this registration; when
+	// the server's key changes, re-registering returns the new value.
+	TunnelFingerprint *string
 }
 
 func (b0 RegisterResponse_builder) Build() *RegisterResponse {
@@ -788,81 +1084,2433 @@ func (b0 RegisterResponse_builder) Build() *RegisterResponse {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Endpoint != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
 		x.xxx_hidden_Endpoint = b.Endpoint
 	}
 	if b.Certificate != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 5)
 		x.xxx_hidden_Certificate = b.Certificate
 	}
 	if b.CaCertificate != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 5)
 		x.xxx_hidden_CaCertificate = b.CaCertificate
 	}
 	if b.ServerVersion != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 5)
 		x.xxx_hidden_ServerVersion = b.ServerVersion
 	}
+	if b.TunnelFingerprint != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 5)
+		x.xxx_hidden_TunnelFingerprint = b.TunnelFingerprint
+	}
 	return m0
 }
 
-var File_api_fleet_v1_fleet_proto protoreflect.FileDescriptor
+// AgentDiagnosticsRequest identifies the cluster whose agent to diagnose.
+type AgentDiagnosticsRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster     *string                `protobuf:"bytes,1,opt,name=cluster"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
 
-const file_api_fleet_v1_fleet_proto_rawDesc = "" +
-	"\n" +
-	"\x18api/fleet/v1/fleet.proto\x12\x13otterscale.fleet.v1\x1a\x15api/annotations.proto\"B\n" +
-	"\aCluster\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12#\n" +
-	"\ragent_version\x18\x02 \x01(\tR\fagentVersion\"\x15\n" +
-	"\x13ListClustersRequest\"P\n" +
-	"\x14ListClustersResponse\x128\n" +
-	"\bclusters\x18\x01 \x03(\v2\x1c.otterscale.fleet.v1.ClusterR\bclusters\"}\n" +
-	"\x0fRegisterRequest\x12\x18\n" +
-	"\acluster\x18\x01 \x01(\tR\acluster\x12\x10\n" +
-	"\x03csr\x18\x02 \x01(\fR\x03csr\x12\x19\n" +
-	"\bagent_id\x18\x03 \x01(\tR\aagentId\x12#\n" +
-	"\ragent_version\x18\x04 \x01(\tR\fagentVersion\"3\n" +
-	"\x17GetAgentManifestRequest\x12\x18\n" +
-	"\acluster\x18\x01 \x01(\tR\acluster\"H\n" +
-	"\x18GetAgentManifestResponse\x12\x1a\n" +
-	"\bmanifest\x18\x01 \x01(\tR\bmanifest\x12\x10\n" +
-	"\x03url\x18\x02 \x01(\tR\x03url\"\x9e\x01\n" +
-	"\x10RegisterResponse\x12\x1a\n" +
-	"\bendpoint\x18\x01 \x01(\tR\bendpoint\x12 \n" +
-	"\vcertificate\x18\x02 \x01(\fR\vcertificate\x12%\n" +
-	"\x0eca_certificate\x18\x03 \x01(\fR\rcaCertificate\x12%\n" +
-	"\x0eserver_version\x18\x04 \x01(\tR\rserverVersion2\x83\x03\n" +
-	"\fFleetService\x12y\n" +
-	"\fListClusters\x12(.otterscale.fleet.v1.ListClustersRequest\x1a).otterscale.fleet.v1.ListClustersResponse\"\x14\x8a\xdf\xd5\x1d\x0f\n" +
+func (x *AgentDiagnosticsRequest) Reset() {
+	*x = AgentDiagnosticsRequest{}
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentDiagnosticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentDiagnosticsRequest) ProtoMessage() {}
+
+func (x *AgentDiagnosticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *AgentDiagnosticsRequest) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AgentDiagnosticsRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *AgentDiagnosticsRequest) HasCluster() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *AgentDiagnosticsRequest) ClearCluster() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Cluster = nil
+}
+
+type AgentDiagnosticsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The cluster name.
+	Cluster *string
+}
+
+func (b0 AgentDiagnosticsRequest_builder) Build() *AgentDiagnosticsRequest {
+	m0 := &AgentDiagnosticsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Cluster != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Cluster = b.Cluster
+	}
+	return m0
+}
+
+// AgentSetting is one key of the agent's effective configuration.
+type AgentSetting struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Key         *string                `protobuf:"bytes,1,opt,name=key"`
+	xxx_hidden_Value       *string                `protobuf:"bytes,2,opt,name=value"`
+	xxx_hidden_Source      *string                `protobuf:"bytes,3,opt,name=source"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *AgentSetting) Reset() {
+	*x = AgentSetting{}
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentSetting) ProtoMessage() {}
+
+func (x *AgentSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *AgentSetting) GetKey() string {
+	if x != nil {
+		if x.xxx_hidden_Key != nil {
+			return *x.xxx_hidden_Key
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AgentSetting) GetValue() string {
+	if x != nil {
+		if x.xxx_hidden_Value != nil {
+			return *x.xxx_hidden_Value
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AgentSetting) GetSource() string {
+	if x != nil {
+		if x.xxx_hidden_Source != nil {
+			return *x.xxx_hidden_Source
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AgentSetting) SetKey(v string) {
+	x.xxx_hidden_Key = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *AgentSetting) SetValue(v string) {
+	x.xxx_hidden_Value = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *AgentSetting) SetSource(v string) {
+	x.xxx_hidden_Source = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *AgentSetting) HasKey() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *AgentSetting) HasValue() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *AgentSetting) HasSource() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *AgentSetting) ClearKey() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Key = nil
+}
+
+func (x *AgentSetting) ClearValue() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Value = nil
+}
+
+func (x *AgentSetting) ClearSource() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Source = nil
+}
+
+type AgentSetting_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The configuration key (e.g. "agent.server_url").
+	Key *string
+	// The value, with secrets redacted.
+	Value *string
+	// Where the value came from: "default", "file", "env" or "flag".
+	Source *string
+}
+
+func (b0 AgentSetting_builder) Build() *AgentSetting {
+	m0 := &AgentSetting{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Key != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Key = b.Key
+	}
+	if b.Value != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Value = b.Value
+	}
+	if b.Source != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Source = b.Source
+	}
+	return m0
+}
+
+// AgentTunnelStatus is the state of the agent's tunnel connection.
+type AgentTunnelStatus struct {
+	state                       protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Connected        bool                   `protobuf:"varint,1,opt,name=connected"`
+	xxx_hidden_TunnelServerUrl  *string                `protobuf:"bytes,2,opt,name=tunnel_server_url,json=tunnelServerUrl"`
+	xxx_hidden_Endpoint         *string                `protobuf:"bytes,3,opt,name=endpoint"`
+	xxx_hidden_LastRegistration *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_registration,json=lastRegistration"`
+	xxx_hidden_Reconnects       uint64                 `protobuf:"varint,5,opt,name=reconnects"`
+	XXX_raceDetectHookData      protoimpl.RaceDetectHookData
+	XXX_presence                [1]uint32
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
+}
+
+func (x *AgentTunnelStatus) Reset() {
+	*x = AgentTunnelStatus{}
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentTunnelStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentTunnelStatus) ProtoMessage() {}
+
+func (x *AgentTunnelStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *AgentTunnelStatus) GetConnected() bool {
+	if x != nil {
+		return x.xxx_hidden_Connected
+	}
+	return false
+}
+
+func (x *AgentTunnelStatus) GetTunnelServerUrl() string {
+	if x != nil {
+		if x.xxx_hidden_TunnelServerUrl != nil {
+			return *x.xxx_hidden_TunnelServerUrl
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AgentTunnelStatus) GetEndpoint() string {
+	if x != nil {
+		if x.xxx_hidden_Endpoint != nil {
+			return *x.xxx_hidden_Endpoint
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AgentTunnelStatus) GetLastRegistration() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_LastRegistration
+	}
+	return nil
+}
+
+func (x *AgentTunnelStatus) GetReconnects() uint64 {
+	if x != nil {
+		return x.xxx_hidden_Reconnects
+	}
+	return 0
+}
+
+func (x *AgentTunnelStatus) SetConnected(v bool) {
+	x.xxx_hidden_Connected = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
+}
+
+func (x *AgentTunnelStatus) SetTunnelServerUrl(v string) {
+	x.xxx_hidden_TunnelServerUrl = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 5)
+}
+
+func (x *AgentTunnelStatus) SetEndpoint(v string) {
+	x.xxx_hidden_Endpoint = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 5)
+}
+
+func (x *AgentTunnelStatus) SetLastRegistration(v *timestamppb.Timestamp) {
+	x.xxx_hidden_LastRegistration = v
+}
+
+func (x *AgentTunnelStatus) SetReconnects(v uint64) {
+	x.xxx_hidden_Reconnects = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 5)
+}
+
+func (x *AgentTunnelStatus) HasConnected() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *AgentTunnelStatus) HasTunnelServerUrl() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *AgentTunnelStatus) HasEndpoint() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *AgentTunnelStatus) HasLastRegistration() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_LastRegistration != nil
+}
+
+func (x *AgentTunnelStatus) HasReconnects() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *AgentTunnelStatus) ClearConnected() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Connected = false
+}
+
+func (x *AgentTunnelStatus) ClearTunnelServerUrl() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_TunnelServerUrl = nil
+}
+
+func (x *AgentTunnelStatus) ClearEndpoint() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Endpoint = nil
+}
+
+func (x *AgentTunnelStatus) ClearLastRegistration() {
+	x.xxx_hidden_LastRegistration = nil
+}
+
+func (x *AgentTunnelStatus) ClearReconnects() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Reconnects = 0
+}
+
+type AgentTunnelStatus_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Whether a connection to the tunnel server is open.
+	Connected *bool
+	// The tunnel server URL of the current or last connection.
+	TunnelServerUrl *string
+	// The loopback endpoint allocated at the most recent registration.
+	Endpoint *string
+	// When the agent last registered, unset if it never has.
+	LastRegistration *timestamppb.Timestamp
+	// Number of tunnel sessions started after a previous session ended.
+	Reconnects *uint64
+}
+
+func (b0 AgentTunnelStatus_builder) Build() *AgentTunnelStatus {
+	m0 := &AgentTunnelStatus{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Connected != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
+		x.xxx_hidden_Connected = *b.Connected
+	}
+	if b.TunnelServerUrl != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 5)
+		x.xxx_hidden_TunnelServerUrl = b.TunnelServerUrl
+	}
+	if b.Endpoint != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 5)
+		x.xxx_hidden_Endpoint = b.Endpoint
+	}
+	x.xxx_hidden_LastRegistration = b.LastRegistration
+	if b.Reconnects != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 5)
+		x.xxx_hidden_Reconnects = *b.Reconnects
+	}
+	return m0
+}
+
+// APIServerReachability is the outcome of the agent probing its
+// in-cluster API server.
+type APIServerReachability struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Reachable   bool                   `protobuf:"varint,1,opt,name=reachable"`
+	xxx_hidden_Version     *string                `protobuf:"bytes,2,opt,name=version"`
+	xxx_hidden_LatencyMs   int64                  `protobuf:"varint,3,opt,name=latency_ms,json=latencyMs"`
+	xxx_hidden_Error       *string                `protobuf:"bytes,4,opt,name=error"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *APIServerReachability) Reset() {
+	*x = APIServerReachability{}
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIServerReachability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIServerReachability) ProtoMessage() {}
+
+func (x *APIServerReachability) ProtoReflect() protoreflect.Message {
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *APIServerReachability) GetReachable() bool {
+	if x != nil {
+		return x.xxx_hidden_Reachable
+	}
+	return false
+}
+
+func (x *APIServerReachability) GetVersion() string {
+	if x != nil {
+		if x.xxx_hidden_Version != nil {
+			return *x.xxx_hidden_Version
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *APIServerReachability) GetLatencyMs() int64 {
+	if x != nil {
+		return x.xxx_hidden_LatencyMs
+	}
+	return 0
+}
+
+func (x *APIServerReachability) GetError() string {
+	if x != nil {
+		if x.xxx_hidden_Error != nil {
+			return *x.xxx_hidden_Error
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *APIServerReachability) SetReachable(v bool) {
+	x.xxx_hidden_Reachable = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *APIServerReachability) SetVersion(v string) {
+	x.xxx_hidden_Version = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *APIServerReachability) SetLatencyMs(v int64) {
+	x.xxx_hidden_LatencyMs = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *APIServerReachability) SetError(v string) {
+	x.xxx_hidden_Error = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+}
+
+func (x *APIServerReachability) HasReachable() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *APIServerReachability) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *APIServerReachability) HasLatencyMs() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *APIServerReachability) HasError() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *APIServerReachability) ClearReachable() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Reachable = false
+}
+
+func (x *APIServerReachability) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Version = nil
+}
+
+func (x *APIServerReachability) ClearLatencyMs() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_LatencyMs = 0
+}
+
+func (x *APIServerReachability) ClearError() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Error = nil
+}
+
+type APIServerReachability_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Whether the API server answered.
+	Reachable *bool
+	// The API server's version (e.g. "v1.31.2"), when reachable.
+	Version *string
+	// How long the probe took, in milliseconds.
+	LatencyMs *int64
+	// Why the probe failed, when not reachable.
+	Error *string
+}
+
+func (b0 APIServerReachability_builder) Build() *APIServerReachability {
+	m0 := &APIServerReachability{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Reachable != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Reachable = *b.Reachable
+	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_Version = b.Version
+	}
+	if b.LatencyMs != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_LatencyMs = *b.LatencyMs
+	}
+	if b.Error != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_Error = b.Error
+	}
+	return m0
+}
+
+// AgentDiagnosticsResponse is the agent's report of its own state.
+type AgentDiagnosticsResponse struct {
+	state                   protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_AgentVersion *string                `protobuf:"bytes,1,opt,name=agent_version,json=agentVersion"`
+	xxx_hidden_CollectedAt  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=collected_at,json=collectedAt"`
+	xxx_hidden_Config       *[]*AgentSetting       `protobuf:"bytes,3,rep,name=config"`
+	xxx_hidden_Tunnel       *AgentTunnelStatus     `protobuf:"bytes,4,opt,name=tunnel"`
+	xxx_hidden_ProxyErrors  uint64                 `protobuf:"varint,5,opt,name=proxy_errors,json=proxyErrors"`
+	xxx_hidden_ProxiedBytes uint64                 `protobuf:"varint,6,opt,name=proxied_bytes,json=proxiedBytes"`
+	xxx_hidden_ApiServer    *APIServerReachability `protobuf:"bytes,7,opt,name=api_server,json=apiServer"`
+	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
+	XXX_presence            [1]uint32
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *AgentDiagnosticsResponse) Reset() {
+	*x = AgentDiagnosticsResponse{}
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentDiagnosticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentDiagnosticsResponse) ProtoMessage() {}
+
+func (x *AgentDiagnosticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *AgentDiagnosticsResponse) GetAgentVersion() string {
+	if x != nil {
+		if x.xxx_hidden_AgentVersion != nil {
+			return *x.xxx_hidden_AgentVersion
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AgentDiagnosticsResponse) GetCollectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CollectedAt
+	}
+	return nil
+}
+
+func (x *AgentDiagnosticsResponse) GetConfig() []*AgentSetting {
+	if x != nil {
+		if x.xxx_hidden_Config != nil {
+			return *x.xxx_hidden_Config
+		}
+	}
+	return nil
+}
+
+func (x *AgentDiagnosticsResponse) GetTunnel() *AgentTunnelStatus {
+	if x != nil {
+		return x.xxx_hidden_Tunnel
+	}
+	return nil
+}
+
+func (x *AgentDiagnosticsResponse) GetProxyErrors() uint64 {
+	if x != nil {
+		return x.xxx_hidden_ProxyErrors
+	}
+	return 0
+}
+
+func (x *AgentDiagnosticsResponse) GetProxiedBytes() uint64 {
+	if x != nil {
+		return x.xxx_hidden_ProxiedBytes
+	}
+	return 0
+}
+
+func (x *AgentDiagnosticsResponse) GetApiServer() *APIServerReachability {
+	if x != nil {
+		return x.xxx_hidden_ApiServer
+	}
+	return nil
+}
+
+func (x *AgentDiagnosticsResponse) SetAgentVersion(v string) {
+	x.xxx_hidden_AgentVersion = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 7)
+}
+
+func (x *AgentDiagnosticsResponse) SetCollectedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CollectedAt = v
+}
+
+func (x *AgentDiagnosticsResponse) SetConfig(v []*AgentSetting) {
+	x.xxx_hidden_Config = &v
+}
+
+func (x *AgentDiagnosticsResponse) SetTunnel(v *AgentTunnelStatus) {
+	x.xxx_hidden_Tunnel = v
+}
+
+func (x *AgentDiagnosticsResponse) SetProxyErrors(v uint64) {
+	x.xxx_hidden_ProxyErrors = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 7)
+}
+
+func (x *AgentDiagnosticsResponse) SetProxiedBytes(v uint64) {
+	x.xxx_hidden_ProxiedBytes = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 7)
+}
+
+func (x *AgentDiagnosticsResponse) SetApiServer(v *APIServerReachability) {
+	x.xxx_hidden_ApiServer = v
+}
+
+func (x *AgentDiagnosticsResponse) HasAgentVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *AgentDiagnosticsResponse) HasCollectedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CollectedAt != nil
+}
+
+func (x *AgentDiagnosticsResponse) HasTunnel() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Tunnel != nil
+}
+
+func (x *AgentDiagnosticsResponse) HasProxyErrors() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *AgentDiagnosticsResponse) HasProxiedBytes() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *AgentDiagnosticsResponse) HasApiServer() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ApiServer != nil
+}
+
+func (x *AgentDiagnosticsResponse) ClearAgentVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_AgentVersion = nil
+}
+
+func (x *AgentDiagnosticsResponse) ClearCollectedAt() {
+	x.xxx_hidden_CollectedAt = nil
+}
+
+func (x *AgentDiagnosticsResponse) ClearTunnel() {
+	x.xxx_hidden_Tunnel = nil
+}
+
+func (x *AgentDiagnosticsResponse) ClearProxyErrors() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_ProxyErrors = 0
+}
+
+func (x *AgentDiagnosticsResponse) ClearProxiedBytes() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_ProxiedBytes = 0
+}
+
+func (x *AgentDiagnosticsResponse) ClearApiServer() {
+	x.xxx_hidden_ApiServer = nil
+}
+
+type AgentDiagnosticsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The version of the agent binary.
+	AgentVersion *string
+	// When the agent collected the report.
+	CollectedAt *timestamppb.Timestamp
+	// The agent's effective configuration.
+	Config []*AgentSetting
+	// The agent's tunnel status.
+	Tunnel *AgentTunnelStatus
+	// Number of requests the agent could not forward to its API server.
+	ProxyErrors *uint64
+	// Total bytes proxied through the tunnel in both directions.
+	ProxiedBytes *uint64
+	// The reachability of the in-cluster API server.
+	ApiServer *APIServerReachability
+}
+
+func (b0 AgentDiagnosticsResponse_builder) Build() *AgentDiagnosticsResponse {
+	m0 := &AgentDiagnosticsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.AgentVersion != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 7)
+		x.xxx_hidden_AgentVersion = b.AgentVersion
+	}
+	x.xxx_hidden_CollectedAt = b.CollectedAt
+	x.xxx_hidden_Config = &b.Config
+	x.xxx_hidden_Tunnel = b.Tunnel
+	if b.ProxyErrors != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 7)
+		x.xxx_hidden_ProxyErrors = *b.ProxyErrors
+	}
+	if b.ProxiedBytes != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 7)
+		x.xxx_hidden_ProxiedBytes = *b.ProxiedBytes
+	}
+	x.xxx_hidden_ApiServer = b.ApiServer
+	return m0
+}
+
+// WhoAmIRequest optionally names a cluster to review the identity on.
+type WhoAmIRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster     *string                `protobuf:"bytes,1,opt,name=cluster"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *WhoAmIRequest) Reset() {
+	*x = WhoAmIRequest{}
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WhoAmIRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhoAmIRequest) ProtoMessage() {}
+
+func (x *WhoAmIRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *WhoAmIRequest) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WhoAmIRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *WhoAmIRequest) HasCluster() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *WhoAmIRequest) ClearCluster() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Cluster = nil
+}
+
+type WhoAmIRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The cluster whose API server to ask, or empty to skip the review.
+	Cluster *string
+}
+
+func (b0 WhoAmIRequest_builder) Build() *WhoAmIRequest {
+	m0 := &WhoAmIRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Cluster != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Cluster = b.Cluster
+	}
+	return m0
+}
+
+// ClusterUserInfo is the identity a cluster's API server attributes to
+// the caller's requests.
+type ClusterUserInfo struct {
+	state                  protoimpl.MessageState       `protogen:"opaque.v1"`
+	xxx_hidden_Username    *string                      `protobuf:"bytes,1,opt,name=username"`
+	xxx_hidden_Uid         *string                      `protobuf:"bytes,2,opt,name=uid"`
+	xxx_hidden_Groups      []string                     `protobuf:"bytes,3,rep,name=groups"`
+	xxx_hidden_Extra       map[string]*ClusterUserExtra `protobuf:"bytes,4,rep,name=extra" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ClusterUserInfo) Reset() {
+	*x = ClusterUserInfo{}
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClusterUserInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClusterUserInfo) ProtoMessage() {}
+
+func (x *ClusterUserInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ClusterUserInfo) GetUsername() string {
+	if x != nil {
+		if x.xxx_hidden_Username != nil {
+			return *x.xxx_hidden_Username
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ClusterUserInfo) GetUid() string {
+	if x != nil {
+		if x.xxx_hidden_Uid != nil {
+			return *x.xxx_hidden_Uid
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ClusterUserInfo) GetGroups() []string {
+	if x != nil {
+		return x.xxx_hidden_Groups
+	}
+	return nil
+}
+
+func (x *ClusterUserInfo) GetExtra() map[string]*ClusterUserExtra {
+	if x != nil {
+		return x.xxx_hidden_Extra
+	}
+	return nil
+}
+
+func (x *ClusterUserInfo) SetUsername(v string) {
+	x.xxx_hidden_Username = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *ClusterUserInfo) SetUid(v string) {
+	x.xxx_hidden_Uid = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *ClusterUserInfo) SetGroups(v []string) {
+	x.xxx_hidden_Groups = v
+}
+
+func (x *ClusterUserInfo) SetExtra(v map[string]*ClusterUserExtra) {
+	x.xxx_hidden_Extra = v
+}
+
+func (x *ClusterUserInfo) HasUsername() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ClusterUserInfo) HasUid() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ClusterUserInfo) ClearUsername() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Username = nil
+}
+
+func (x *ClusterUserInfo) ClearUid() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Uid = nil
+}
+
+type ClusterUserInfo_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The user name.
+	Username *string
+	// The user's unique identifier, if the cluster assigns one.
+	Uid *string
+	// The groups the user belongs to, including those the cluster adds
+	// (e.g. "system:authenticated").
+	Groups []string
+	// Additional attributes of the user, by key.
+	Extra map[string]*ClusterUserExtra
+}
+
+func (b0 ClusterUserInfo_builder) Build() *ClusterUserInfo {
+	m0 := &ClusterUserInfo{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Username != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Username = b.Username
+	}
+	if b.Uid != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_Uid = b.Uid
+	}
+	x.xxx_hidden_Groups = b.Groups
+	x.xxx_hidden_Extra = b.Extra
+	return m0
+}
+
+// ClusterUserExtra holds the values of one extra user attribute.
+type ClusterUserExtra struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Values []string               `protobuf:"bytes,1,rep,name=values"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ClusterUserExtra) Reset() {
+	*x = ClusterUserExtra{}
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClusterUserExtra) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClusterUserExtra) ProtoMessage() {}
+
+func (x *ClusterUserExtra) ProtoReflect() protoreflect.Message {
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ClusterUserExtra) GetValues() []string {
+	if x != nil {
+		return x.xxx_hidden_Values
+	}
+	return nil
+}
+
+func (x *ClusterUserExtra) SetValues(v []string) {
+	x.xxx_hidden_Values = v
+}
+
+type ClusterUserExtra_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The values.
+	Values []string
+}
+
+func (b0 ClusterUserExtra_builder) Build() *ClusterUserExtra {
+	m0 := &ClusterUserExtra{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Values = b.Values
+	return m0
+}
+
+// WhoAmIResponse is the caller's effective identity.
+type WhoAmIResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Subject     *string                `protobuf:"bytes,1,opt,name=subject"`
+	xxx_hidden_Groups      []string               `protobuf:"bytes,2,rep,name=groups"`
+	xxx_hidden_ClusterUser *ClusterUserInfo       `protobuf:"bytes,3,opt,name=cluster_user,json=clusterUser"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *WhoAmIResponse) Reset() {
+	*x = WhoAmIResponse{}
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WhoAmIResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhoAmIResponse) ProtoMessage() {}
+
+func (x *WhoAmIResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *WhoAmIResponse) GetSubject() string {
+	if x != nil {
+		if x.xxx_hidden_Subject != nil {
+			return *x.xxx_hidden_Subject
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WhoAmIResponse) GetGroups() []string {
+	if x != nil {
+		return x.xxx_hidden_Groups
+	}
+	return nil
+}
+
+func (x *WhoAmIResponse) GetClusterUser() *ClusterUserInfo {
+	if x != nil {
+		return x.xxx_hidden_ClusterUser
+	}
+	return nil
+}
+
+func (x *WhoAmIResponse) SetSubject(v string) {
+	x.xxx_hidden_Subject = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *WhoAmIResponse) SetGroups(v []string) {
+	x.xxx_hidden_Groups = v
+}
+
+func (x *WhoAmIResponse) SetClusterUser(v *ClusterUserInfo) {
+	x.xxx_hidden_ClusterUser = v
+}
+
+func (x *WhoAmIResponse) HasSubject() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *WhoAmIResponse) HasClusterUser() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ClusterUser != nil
+}
+
+func (x *WhoAmIResponse) ClearSubject() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Subject = nil
+}
+
+func (x *WhoAmIResponse) ClearClusterUser() {
+	x.xxx_hidden_ClusterUser = nil
+}
+
+type WhoAmIResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The subject derived from the caller's token.
+	Subject *string
+	// The groups derived from the caller's token.
+	Groups []string
+	// The identity the requested cluster sees, unset if none was named.
+	ClusterUser *ClusterUserInfo
+}
+
+func (b0 WhoAmIResponse_builder) Build() *WhoAmIResponse {
+	m0 := &WhoAmIResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Subject != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Subject = b.Subject
+	}
+	x.xxx_hidden_Groups = b.Groups
+	x.xxx_hidden_ClusterUser = b.ClusterUser
+	return m0
+}
+
+// AgentPermissionsRequest names the cluster to review the agent's
+// permissions on.
+type AgentPermissionsRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster     *string                `protobuf:"bytes,1,opt,name=cluster"`
+	xxx_hidden_Namespace   *string                `protobuf:"bytes,2,opt,name=namespace"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *AgentPermissionsRequest) Reset() {
+	*x = AgentPermissionsRequest{}
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentPermissionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentPermissionsRequest) ProtoMessage() {}
+
+func (x *AgentPermissionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *AgentPermissionsRequest) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AgentPermissionsRequest) GetNamespace() string {
+	if x != nil {
+		if x.xxx_hidden_Namespace != nil {
+			return *x.xxx_hidden_Namespace
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AgentPermissionsRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *AgentPermissionsRequest) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *AgentPermissionsRequest) HasCluster() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *AgentPermissionsRequest) HasNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *AgentPermissionsRequest) ClearCluster() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Cluster = nil
+}
+
+func (x *AgentPermissionsRequest) ClearNamespace() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Namespace = nil
+}
+
+type AgentPermissionsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The cluster name.
+	Cluster *string
+	// The namespace to evaluate the rules in, which also determines the
+	// namespaced Roles included. Defaults to "default"; rules granted by
+	// ClusterRoleBindings are reported for every namespace.
+	Namespace *string
+}
+
+func (b0 AgentPermissionsRequest_builder) Build() *AgentPermissionsRequest {
+	m0 := &AgentPermissionsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Cluster != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Cluster = b.Cluster
+	}
+	if b.Namespace != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Namespace = b.Namespace
+	}
+	return m0
+}
+
+// ResourceRule is a set of API resources and the verbs allowed on them.
+type ResourceRule struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Verbs         []string               `protobuf:"bytes,1,rep,name=verbs"`
+	xxx_hidden_ApiGroups     []string               `protobuf:"bytes,2,rep,name=api_groups,json=apiGroups"`
+	xxx_hidden_Resources     []string               `protobuf:"bytes,3,rep,name=resources"`
+	xxx_hidden_ResourceNames []string               `protobuf:"bytes,4,rep,name=resource_names,json=resourceNames"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *ResourceRule) Reset() {
+	*x = ResourceRule{}
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceRule) ProtoMessage() {}
+
+func (x *ResourceRule) ProtoReflect() protoreflect.Message {
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ResourceRule) GetVerbs() []string {
+	if x != nil {
+		return x.xxx_hidden_Verbs
+	}
+	return nil
+}
+
+func (x *ResourceRule) GetApiGroups() []string {
+	if x != nil {
+		return x.xxx_hidden_ApiGroups
+	}
+	return nil
+}
+
+func (x *ResourceRule) GetResources() []string {
+	if x != nil {
+		return x.xxx_hidden_Resources
+	}
+	return nil
+}
+
+func (x *ResourceRule) GetResourceNames() []string {
+	if x != nil {
+		return x.xxx_hidden_ResourceNames
+	}
+	return nil
+}
+
+func (x *ResourceRule) SetVerbs(v []string) {
+	x.xxx_hidden_Verbs = v
+}
+
+func (x *ResourceRule) SetApiGroups(v []string) {
+	x.xxx_hidden_ApiGroups = v
+}
+
+func (x *ResourceRule) SetResources(v []string) {
+	x.xxx_hidden_Resources = v
+}
+
+func (x *ResourceRule) SetResourceNames(v []string) {
+	x.xxx_hidden_ResourceNames = v
+}
+
+type ResourceRule_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The allowed verbs, e.g. "get", or "*" for all.
+	Verbs []string
+	// The API groups of the resources, "" for the core group.
+	ApiGroups []string
+	// The resources, e.g. "pods" or "deployments/scale".
+	Resources []string
+	// The resource names the rule is restricted to, empty for all.
+	ResourceNames []string
+}
+
+func (b0 ResourceRule_builder) Build() *ResourceRule {
+	m0 := &ResourceRule{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Verbs = b.Verbs
+	x.xxx_hidden_ApiGroups = b.ApiGroups
+	x.xxx_hidden_Resources = b.Resources
+	x.xxx_hidden_ResourceNames = b.ResourceNames
+	return m0
+}
+
+// NonResourceRule is a set of non-resource URLs and the verbs allowed
+// on them.
+type NonResourceRule struct {
+	state                      protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Verbs           []string               `protobuf:"bytes,1,rep,name=verbs"`
+	xxx_hidden_NonResourceUrls []string               `protobuf:"bytes,2,rep,name=non_resource_urls,json=nonResourceUrls"`
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *NonResourceRule) Reset() {
+	*x = NonResourceRule{}
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NonResourceRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NonResourceRule) ProtoMessage() {}
+
+func (x *NonResourceRule) ProtoReflect() protoreflect.Message {
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *NonResourceRule) GetVerbs() []string {
+	if x != nil {
+		return x.xxx_hidden_Verbs
+	}
+	return nil
+}
+
+func (x *NonResourceRule) GetNonResourceUrls() []string {
+	if x != nil {
+		return x.xxx_hidden_NonResourceUrls
+	}
+	return nil
+}
+
+func (x *NonResourceRule) SetVerbs(v []string) {
+	x.xxx_hidden_Verbs = v
+}
+
+func (x *NonResourceRule) SetNonResourceUrls(v []string) {
+	x.xxx_hidden_NonResourceUrls = v
+}
+
+type NonResourceRule_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The allowed verbs, e.g. "get".
+	Verbs []string
+	// The URL paths, e.g. "/healthz", possibly ending in "*".
+	NonResourceUrls []string
+}
+
+func (b0 NonResourceRule_builder) Build() *NonResourceRule {
+	m0 := &NonResourceRule{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Verbs = b.Verbs
+	x.xxx_hidden_NonResourceUrls = b.NonResourceUrls
+	return m0
+}
+
+// AgentPermissionsResponse is what the agent may do on the cluster.
+type AgentPermissionsResponse struct {
+	state                       protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Namespace        *string                `protobuf:"bytes,1,opt,name=namespace"`
+	xxx_hidden_ResourceRules    *[]*ResourceRule       `protobuf:"bytes,2,rep,name=resource_rules,json=resourceRules"`
+	xxx_hidden_NonResourceRules *[]*NonResourceRule    `protobuf:"bytes,3,rep,name=non_resource_rules,json=nonResourceRules"`
+	xxx_hidden_Incomplete       bool                   `protobuf:"varint,4,opt,name=incomplete"`
+	xxx_hidden_EvaluationError  *string                `protobuf:"bytes,5,opt,name=evaluation_error,json=evaluationError"`
+	XXX_raceDetectHookData      protoimpl.RaceDetectHookData
+	XXX_presence                [1]uint32
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
+}
+
+func (x *AgentPermissionsResponse) Reset() {
+	*x = AgentPermissionsResponse{}
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentPermissionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentPermissionsResponse) ProtoMessage() {}
+
+func (x *AgentPermissionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *AgentPermissionsResponse) GetNamespace() string {
+	if x != nil {
+		if x.xxx_hidden_Namespace != nil {
+			return *x.xxx_hidden_Namespace
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AgentPermissionsResponse) GetResourceRules() []*ResourceRule {
+	if x != nil {
+		if x.xxx_hidden_ResourceRules != nil {
+			return *x.xxx_hidden_ResourceRules
+		}
+	}
+	return nil
+}
+
+func (x *AgentPermissionsResponse) GetNonResourceRules() []*NonResourceRule {
+	if x != nil {
+		if x.xxx_hidden_NonResourceRules != nil {
+			return *x.xxx_hidden_NonResourceRules
+		}
+	}
+	return nil
+}
+
+func (x *AgentPermissionsResponse) GetIncomplete() bool {
+	if x != nil {
+		return x.xxx_hidden_Incomplete
+	}
+	return false
+}
+
+func (x *AgentPermissionsResponse) GetEvaluationError() string {
+	if x != nil {
+		if x.xxx_hidden_EvaluationError != nil {
+			return *x.xxx_hidden_EvaluationError
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AgentPermissionsResponse) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
+}
+
+func (x *AgentPermissionsResponse) SetResourceRules(v []*ResourceRule) {
+	x.xxx_hidden_ResourceRules = &v
+}
+
+func (x *AgentPermissionsResponse) SetNonResourceRules(v []*NonResourceRule) {
+	x.xxx_hidden_NonResourceRules = &v
+}
+
+func (x *AgentPermissionsResponse) SetIncomplete(v bool) {
+	x.xxx_hidden_Incomplete = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 5)
+}
+
+func (x *AgentPermissionsResponse) SetEvaluationError(v string) {
+	x.xxx_hidden_EvaluationError = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 5)
+}
+
+func (x *AgentPermissionsResponse) HasNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *AgentPermissionsResponse) HasIncomplete() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *AgentPermissionsResponse) HasEvaluationError() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *AgentPermissionsResponse) ClearNamespace() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Namespace = nil
+}
+
+func (x *AgentPermissionsResponse) ClearIncomplete() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Incomplete = false
+}
+
+func (x *AgentPermissionsResponse) ClearEvaluationError() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_EvaluationError = nil
+}
+
+type AgentPermissionsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The namespace the rules were evaluated in.
+	Namespace *string
+	// The rules on API resources.
+	ResourceRules []*ResourceRule
+	// The rules on non-resource URLs.
+	NonResourceRules []*NonResourceRule
+	// Whether the list may be missing rules, e.g. because the cluster's
+	// authorizer cannot enumerate them.
+	Incomplete *bool
+	// Why the rules could not be fully evaluated, if they could not.
+	EvaluationError *string
+}
+
+func (b0 AgentPermissionsResponse_builder) Build() *AgentPermissionsResponse {
+	m0 := &AgentPermissionsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Namespace != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
+		x.xxx_hidden_Namespace = b.Namespace
+	}
+	x.xxx_hidden_ResourceRules = &b.ResourceRules
+	x.xxx_hidden_NonResourceRules = &b.NonResourceRules
+	if b.Incomplete != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 5)
+		x.xxx_hidden_Incomplete = *b.Incomplete
+	}
+	if b.EvaluationError != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 5)
+		x.xxx_hidden_EvaluationError = b.EvaluationError
+	}
+	return m0
+}
+
+// GetMaintenanceRequest is empty; the maintenance mode is fleet-wide.
+type GetMaintenanceRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMaintenanceRequest) Reset() {
+	*x = GetMaintenanceRequest{}
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMaintenanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMaintenanceRequest) ProtoMessage() {}
+
+func (x *GetMaintenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type GetMaintenanceRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 GetMaintenanceRequest_builder) Build() *GetMaintenanceRequest {
+	m0 := &GetMaintenanceRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+// SetMaintenanceRequest sets the fleet-wide maintenance mode.
+type SetMaintenanceRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Enabled     bool                   `protobuf:"varint,1,opt,name=enabled"`
+	xxx_hidden_Message     *string                `protobuf:"bytes,2,opt,name=message"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SetMaintenanceRequest) Reset() {
+	*x = SetMaintenanceRequest{}
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMaintenanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMaintenanceRequest) ProtoMessage() {}
+
+func (x *SetMaintenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SetMaintenanceRequest) GetEnabled() bool {
+	if x != nil {
+		return x.xxx_hidden_Enabled
+	}
+	return false
+}
+
+func (x *SetMaintenanceRequest) GetMessage() string {
+	if x != nil {
+		if x.xxx_hidden_Message != nil {
+			return *x.xxx_hidden_Message
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SetMaintenanceRequest) SetEnabled(v bool) {
+	x.xxx_hidden_Enabled = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *SetMaintenanceRequest) SetMessage(v string) {
+	x.xxx_hidden_Message = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *SetMaintenanceRequest) HasEnabled() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *SetMaintenanceRequest) HasMessage() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *SetMaintenanceRequest) ClearEnabled() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Enabled = false
+}
+
+func (x *SetMaintenanceRequest) ClearMessage() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Message = nil
+}
+
+type SetMaintenanceRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Whether mutating RPCs are rejected.
+	Enabled *bool
+	// The message returned with rejected RPCs. Defaults to a generic
+	// maintenance notice.
+	Message *string
+}
+
+func (b0 SetMaintenanceRequest_builder) Build() *SetMaintenanceRequest {
+	m0 := &SetMaintenanceRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Enabled != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Enabled = *b.Enabled
+	}
+	if b.Message != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Message = b.Message
+	}
+	return m0
+}
+
+// Maintenance is the fleet-wide maintenance mode.
+type Maintenance struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Enabled     bool                   `protobuf:"varint,1,opt,name=enabled"`
+	xxx_hidden_Message     *string                `protobuf:"bytes,2,opt,name=message"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Maintenance) Reset() {
+	*x = Maintenance{}
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Maintenance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Maintenance) ProtoMessage() {}
+
+func (x *Maintenance) ProtoReflect() protoreflect.Message {
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Maintenance) GetEnabled() bool {
+	if x != nil {
+		return x.xxx_hidden_Enabled
+	}
+	return false
+}
+
+func (x *Maintenance) GetMessage() string {
+	if x != nil {
+		if x.xxx_hidden_Message != nil {
+			return *x.xxx_hidden_Message
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Maintenance) SetEnabled(v bool) {
+	x.xxx_hidden_Enabled = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *Maintenance) SetMessage(v string) {
+	x.xxx_hidden_Message = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *Maintenance) HasEnabled() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *Maintenance) HasMessage() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *Maintenance) ClearEnabled() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Enabled = false
+}
+
+func (x *Maintenance) ClearMessage() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Message = nil
+}
+
+type Maintenance_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Whether mutating RPCs are rejected.
+	Enabled *bool
+	// The message returned with rejected RPCs, empty when disabled.
+	Message *string
+}
+
+func (b0 Maintenance_builder) Build() *Maintenance {
+	m0 := &Maintenance{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Enabled != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Enabled = *b.Enabled
+	}
+	if b.Message != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Message = b.Message
+	}
+	return m0
+}
+
+// CollectSupportBundleRequest names the cluster whose agent to collect
+// a support bundle from.
+type CollectSupportBundleRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster     *string                `protobuf:"bytes,1,opt,name=cluster"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CollectSupportBundleRequest) Reset() {
+	*x = CollectSupportBundleRequest{}
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectSupportBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectSupportBundleRequest) ProtoMessage() {}
+
+func (x *CollectSupportBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CollectSupportBundleRequest) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CollectSupportBundleRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *CollectSupportBundleRequest) HasCluster() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *CollectSupportBundleRequest) ClearCluster() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Cluster = nil
+}
+
+type CollectSupportBundleRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The cluster name.
+	Cluster *string
+}
+
+func (b0 CollectSupportBundleRequest_builder) Build() *CollectSupportBundleRequest {
+	m0 := &CollectSupportBundleRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Cluster != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Cluster = b.Cluster
+	}
+	return m0
+}
+
+// CollectSupportBundleResponse points to the collected support bundle.
+type CollectSupportBundleResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Url         *string                `protobuf:"bytes,1,opt,name=url"`
+	xxx_hidden_ExpireTime  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expire_time,json=expireTime"`
+	xxx_hidden_Errors      []string               `protobuf:"bytes,3,rep,name=errors"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CollectSupportBundleResponse) Reset() {
+	*x = CollectSupportBundleResponse{}
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectSupportBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectSupportBundleResponse) ProtoMessage() {}
+
+func (x *CollectSupportBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_fleet_v1_fleet_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CollectSupportBundleResponse) GetUrl() string {
+	if x != nil {
+		if x.xxx_hidden_Url != nil {
+			return *x.xxx_hidden_Url
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CollectSupportBundleResponse) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_ExpireTime
+	}
+	return nil
+}
+
+func (x *CollectSupportBundleResponse) GetErrors() []string {
+	if x != nil {
+		return x.xxx_hidden_Errors
+	}
+	return nil
+}
+
+func (x *CollectSupportBundleResponse) SetUrl(v string) {
+	x.xxx_hidden_Url = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *CollectSupportBundleResponse) SetExpireTime(v *timestamppb.Timestamp) {
+	x.xxx_hidden_ExpireTime = v
+}
+
+func (x *CollectSupportBundleResponse) SetErrors(v []string) {
+	x.xxx_hidden_Errors = v
+}
+
+func (x *CollectSupportBundleResponse) HasUrl() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *CollectSupportBundleResponse) HasExpireTime() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ExpireTime != nil
+}
+
+func (x *CollectSupportBundleResponse) ClearUrl() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Url = nil
+}
+
+func (x *CollectSupportBundleResponse) ClearExpireTime() {
+	x.xxx_hidden_ExpireTime = nil
+}
+
+type CollectSupportBundleResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// URL with an embedded HMAC token that serves the bundle as a zip
+	// archive until expire_time.
+	Url *string
+	// When the URL expires and the bundle is discarded.
+	ExpireTime *timestamppb.Timestamp
+	// The parts of the bundle that could not be collected, and why.
+	Errors []string
+}
+
+func (b0 CollectSupportBundleResponse_builder) Build() *CollectSupportBundleResponse {
+	m0 := &CollectSupportBundleResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Url != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Url = b.Url
+	}
+	x.xxx_hidden_ExpireTime = b.ExpireTime
+	x.xxx_hidden_Errors = b.Errors
+	return m0
+}
+
+var File_api_fleet_v1_fleet_proto protoreflect.FileDescriptor
+
+const file_api_fleet_v1_fleet_proto_rawDesc = "" +
+	"\n" +
+	"\x18api/fleet/v1/fleet.proto\x12\x13otterscale.fleet.v1\x1a\x15api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"b\n" +
+	"\aCluster\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12#\n" +
+	"\ragent_version\x18\x02 \x01(\tR\fagentVersion\x12\x1e\n" +
+	"\vout_of_date\x18\x03 \x01(\bR\toutOfDate\">\n" +
+	"\x13ListClustersRequest\x12'\n" +
+	"\x10out_of_date_only\x18\x01 \x01(\bR\routOfDateOnly\"P\n" +
+	"\x14ListClustersResponse\x128\n" +
+	"\bclusters\x18\x01 \x03(\v2\x1c.otterscale.fleet.v1.ClusterR\bclusters\"}\n" +
+	"\x0fRegisterRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x10\n" +
+	"\x03csr\x18\x02 \x01(\fR\x03csr\x12\x19\n" +
+	"\bagent_id\x18\x03 \x01(\tR\aagentId\x12#\n" +
+	"\ragent_version\x18\x04 \x01(\tR\fagentVersion\"3\n" +
+	"\x17GetAgentManifestRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\"H\n" +
+	"\x18GetAgentManifestResponse\x12\x1a\n" +
+	"\bmanifest\x18\x01 \x01(\tR\bmanifest\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\"0\n" +
+	"\x14GetKubeconfigRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\"I\n" +
+	"\x15GetKubeconfigResponse\x12\x1e\n" +
+	"\n" +
+	"kubeconfig\x18\x01 \x01(\tR\n" +
+	"kubeconfig\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\"\xcd\x01\n" +
+	"\x10RegisterResponse\x12\x1a\n" +
+	"\bendpoint\x18\x01 \x01(\tR\bendpoint\x12 \n" +
+	"\vcertificate\x18\x02 \x01(\fR\vcertificate\x12%\n" +
+	"\x0eca_certificate\x18\x03 \x01(\fR\rcaCertificate\x12%\n" +
+	"\x0eserver_version\x18\x04 \x01(\tR\rserverVersion\x12-\n" +
+	"\x12tunnel_fingerprint\x18\x05 \x01(\tR\x11tunnelFingerprint\"3\n" +
+	"\x17AgentDiagnosticsRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\"N\n" +
+	"\fAgentSetting\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\"\xe2\x01\n" +
+	"\x11AgentTunnelStatus\x12\x1c\n" +
+	"\tconnected\x18\x01 \x01(\bR\tconnected\x12*\n" +
+	"\x11tunnel_server_url\x18\x02 \x01(\tR\x0ftunnelServerUrl\x12\x1a\n" +
+	"\bendpoint\x18\x03 \x01(\tR\bendpoint\x12G\n" +
+	"\x11last_registration\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x10lastRegistration\x12\x1e\n" +
+	"\n" +
+	"reconnects\x18\x05 \x01(\x04R\n" +
+	"reconnects\"\x84\x01\n" +
+	"\x15APIServerReachability\x12\x1c\n" +
+	"\treachable\x18\x01 \x01(\bR\treachable\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\x03 \x01(\x03R\tlatencyMs\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\x8c\x03\n" +
+	"\x18AgentDiagnosticsResponse\x12#\n" +
+	"\ragent_version\x18\x01 \x01(\tR\fagentVersion\x12=\n" +
+	"\fcollected_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vcollectedAt\x129\n" +
+	"\x06config\x18\x03 \x03(\v2!.otterscale.fleet.v1.AgentSettingR\x06config\x12>\n" +
+	"\x06tunnel\x18\x04 \x01(\v2&.otterscale.fleet.v1.AgentTunnelStatusR\x06tunnel\x12!\n" +
+	"\fproxy_errors\x18\x05 \x01(\x04R\vproxyErrors\x12#\n" +
+	"\rproxied_bytes\x18\x06 \x01(\x04R\fproxiedBytes\x12I\n" +
+	"\n" +
+	"api_server\x18\a \x01(\v2*.otterscale.fleet.v1.APIServerReachabilityR\tapiServer\")\n" +
+	"\rWhoAmIRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\"\xff\x01\n" +
+	"\x0fClusterUserInfo\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\tR\x03uid\x12\x16\n" +
+	"\x06groups\x18\x03 \x03(\tR\x06groups\x12E\n" +
+	"\x05extra\x18\x04 \x03(\v2/.otterscale.fleet.v1.ClusterUserInfo.ExtraEntryR\x05extra\x1a_\n" +
+	"\n" +
+	"ExtraEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12;\n" +
+	"\x05value\x18\x02 \x01(\v2%.otterscale.fleet.v1.ClusterUserExtraR\x05value:\x028\x01\"*\n" +
+	"\x10ClusterUserExtra\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\"\x8b\x01\n" +
+	"\x0eWhoAmIResponse\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x16\n" +
+	"\x06groups\x18\x02 \x03(\tR\x06groups\x12G\n" +
+	"\fcluster_user\x18\x03 \x01(\v2$.otterscale.fleet.v1.ClusterUserInfoR\vclusterUser\"Q\n" +
+	"\x17AgentPermissionsRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"\x88\x01\n" +
+	"\fResourceRule\x12\x14\n" +
+	"\x05verbs\x18\x01 \x03(\tR\x05verbs\x12\x1d\n" +
+	"\n" +
+	"api_groups\x18\x02 \x03(\tR\tapiGroups\x12\x1c\n" +
+	"\tresources\x18\x03 \x03(\tR\tresources\x12%\n" +
+	"\x0eresource_names\x18\x04 \x03(\tR\rresourceNames\"S\n" +
+	"\x0fNonResourceRule\x12\x14\n" +
+	"\x05verbs\x18\x01 \x03(\tR\x05verbs\x12*\n" +
+	"\x11non_resource_urls\x18\x02 \x03(\tR\x0fnonResourceUrls\"\xa1\x02\n" +
+	"\x18AgentPermissionsResponse\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12H\n" +
+	"\x0eresource_rules\x18\x02 \x03(\v2!.otterscale.fleet.v1.ResourceRuleR\rresourceRules\x12R\n" +
+	"\x12non_resource_rules\x18\x03 \x03(\v2$.otterscale.fleet.v1.NonResourceRuleR\x10nonResourceRules\x12\x1e\n" +
+	"\n" +
+	"incomplete\x18\x04 \x01(\bR\n" +
+	"incomplete\x12)\n" +
+	"\x10evaluation_error\x18\x05 \x01(\tR\x0fevaluationError\"\x17\n" +
+	"\x15GetMaintenanceRequest\"K\n" +
+	"\x15SetMaintenanceRequest\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"A\n" +
+	"\vMaintenance\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"7\n" +
+	"\x1bCollectSupportBundleRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\"\x85\x01\n" +
+	"\x1cCollectSupportBundleResponse\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12;\n" +
+	"\vexpire_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expireTime\x12\x16\n" +
+	"\x06errors\x18\x03 \x03(\tR\x06errors2\x89\n" +
+	"\n" +
+	"\fFleetService\x12y\n" +
+	"\fListClusters\x12(.otterscale.fleet.v1.ListClustersRequest\x1a).otterscale.fleet.v1.ListClustersResponse\"\x14\x8a\xdf\xd5\x1d\x0f\n" +
 	"\rfleet-enabled\x12m\n" +
 	"\bRegister\x12$.otterscale.fleet.v1.RegisterRequest\x1a%.otterscale.fleet.v1.RegisterResponse\"\x14\x8a\xdf\xd5\x1d\x0f\n" +
 	"\rfleet-enabled\x12\x88\x01\n" +
 	"\x10GetAgentManifest\x12,.otterscale.fleet.v1.GetAgentManifestRequest\x1a-.otterscale.fleet.v1.GetAgentManifestResponse\"\x17\x8a\xdf\xd5\x1d\x0f\n" +
-	"\rfleet-enabled\x90\x02\x01B8Z6github.com/otterscale/otterscale-agent/api/fleet/v1;pbb\beditionsp\xe8\a"
-
-var file_api_fleet_v1_fleet_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+	"\rfleet-enabled\x90\x02\x01\x12\x7f\n" +
+	"\rGetKubeconfig\x12).otterscale.fleet.v1.GetKubeconfigRequest\x1a*.otterscale.fleet.v1.GetKubeconfigResponse\"\x17\x8a\xdf\xd5\x1d\x0f\n" +
+	"\rfleet-enabled\x90\x02\x01\x12\x88\x01\n" +
+	"\x10AgentDiagnostics\x12,.otterscale.fleet.v1.AgentDiagnosticsRequest\x1a-.otterscale.fleet.v1.AgentDiagnosticsResponse\"\x17\x8a\xdf\xd5\x1d\x0f\n" +
+	"\rfleet-enabled\x90\x02\x01\x12j\n" +
+	"\x06WhoAmI\x12\".otterscale.fleet.v1.WhoAmIRequest\x1a#.otterscale.fleet.v1.WhoAmIResponse\"\x17\x8a\xdf\xd5\x1d\x0f\n" +
+	"\rfleet-enabled\x90\x02\x01\x12\x88\x01\n" +
+	"\x10AgentPermissions\x12,.otterscale.fleet.v1.AgentPermissionsRequest\x1a-.otterscale.fleet.v1.AgentPermissionsResponse\"\x17\x8a\xdf\xd5\x1d\x0f\n" +
+	"\rfleet-enabled\x90\x02\x01\x12w\n" +
+	"\x0eGetMaintenance\x12*.otterscale.fleet.v1.GetMaintenanceRequest\x1a .otterscale.fleet.v1.Maintenance\"\x17\x8a\xdf\xd5\x1d\x0f\n" +
+	"\rfleet-enabled\x90\x02\x01\x12t\n" +
+	"\x0eSetMaintenance\x12*.otterscale.fleet.v1.SetMaintenanceRequest\x1a .otterscale.fleet.v1.Maintenance\"\x14\x8a\xdf\xd5\x1d\x0f\n" +
+	"\rfleet-enabled\x12\x91\x01\n" +
+	"\x14CollectSupportBundle\x120.otterscale.fleet.v1.CollectSupportBundleRequest\x1a1.otterscale.fleet.v1.CollectSupportBundleResponse\"\x14\x8a\xdf\xd5\x1d\x0f\n" +
+	"\rfleet-enabledB8Z6github.com/otterscale/otterscale-agent/api/fleet/v1;pbb\beditionsp\xe8\a"
+
+var file_api_fleet_v1_fleet_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_api_fleet_v1_fleet_proto_goTypes = []any{
-	(*Cluster)(nil),                  // 0: otterscale.fleet.v1.Cluster
-	(*ListClustersRequest)(nil),      // 1: otterscale.fleet.v1.ListClustersRequest
-	(*ListClustersResponse)(nil),     // 2: otterscale.fleet.v1.ListClustersResponse
-	(*RegisterRequest)(nil),          // 3: otterscale.fleet.v1.RegisterRequest
-	(*GetAgentManifestRequest)(nil),  // 4: otterscale.fleet.v1.GetAgentManifestRequest
-	(*GetAgentManifestResponse)(nil), // 5: otterscale.fleet.v1.GetAgentManifestResponse
-	(*RegisterResponse)(nil),         // 6: otterscale.fleet.v1.RegisterResponse
+	(*Cluster)(nil),                      // 0: otterscale.fleet.v1.Cluster
+	(*ListClustersRequest)(nil),          // 1: otterscale.fleet.v1.ListClustersRequest
+	(*ListClustersResponse)(nil),         // 2: otterscale.fleet.v1.ListClustersResponse
+	(*RegisterRequest)(nil),              // 3: otterscale.fleet.v1.RegisterRequest
+	(*GetAgentManifestRequest)(nil),      // 4: otterscale.fleet.v1.GetAgentManifestRequest
+	(*GetAgentManifestResponse)(nil),     // 5: otterscale.fleet.v1.GetAgentManifestResponse
+	(*GetKubeconfigRequest)(nil),         // 6: otterscale.fleet.v1.GetKubeconfigRequest
+	(*GetKubeconfigResponse)(nil),        // 7: otterscale.fleet.v1.GetKubeconfigResponse
+	(*RegisterResponse)(nil),             // 8: otterscale.fleet.v1.RegisterResponse
+	(*AgentDiagnosticsRequest)(nil),      // 9: otterscale.fleet.v1.AgentDiagnosticsRequest
+	(*AgentSetting)(nil),                 // 10: otterscale.fleet.v1.AgentSetting
+	(*AgentTunnelStatus)(nil),            // 11: otterscale.fleet.v1.AgentTunnelStatus
+	(*APIServerReachability)(nil),        // 12: otterscale.fleet.v1.APIServerReachability
+	(*AgentDiagnosticsResponse)(nil),     // 13: otterscale.fleet.v1.AgentDiagnosticsResponse
+	(*WhoAmIRequest)(nil),                // 14: otterscale.fleet.v1.WhoAmIRequest
+	(*ClusterUserInfo)(nil),              // 15: otterscale.fleet.v1.ClusterUserInfo
+	(*ClusterUserExtra)(nil),             // 16: otterscale.fleet.v1.ClusterUserExtra
+	(*WhoAmIResponse)(nil),               // 17: otterscale.fleet.v1.WhoAmIResponse
+	(*AgentPermissionsRequest)(nil),      // 18: otterscale.fleet.v1.AgentPermissionsRequest
+	(*ResourceRule)(nil),                 // 19: otterscale.fleet.v1.ResourceRule
+	(*NonResourceRule)(nil),              // 20: otterscale.fleet.v1.NonResourceRule
+	(*AgentPermissionsResponse)(nil),     // 21: otterscale.fleet.v1.AgentPermissionsResponse
+	(*GetMaintenanceRequest)(nil),        // 22: otterscale.fleet.v1.GetMaintenanceRequest
+	(*SetMaintenanceRequest)(nil),        // 23: otterscale.fleet.v1.SetMaintenanceRequest
+	(*Maintenance)(nil),                  // 24: otterscale.fleet.v1.Maintenance
+	(*CollectSupportBundleRequest)(nil),  // 25: otterscale.fleet.v1.CollectSupportBundleRequest
+	(*CollectSupportBundleResponse)(nil), // 26: otterscale.fleet.v1.CollectSupportBundleResponse
+	nil,                                  // 27: otterscale.fleet.v1.ClusterUserInfo.ExtraEntry
+	(*timestamppb.Timestamp)(nil),        // 28: google.protobuf.Timestamp
 }
 var file_api_fleet_v1_fleet_proto_depIdxs = []int32{
-	0, // 0: otterscale.fleet.v1.ListClustersResponse.clusters:type_name -> otterscale.fleet.v1.Cluster
-	1, // 1: otterscale.fleet.v1.FleetService.ListClusters:input_type -> otterscale.fleet.v1.ListClustersRequest
-	3, // 2: otterscale.fleet.v1.FleetService.Register:input_type -> otterscale.fleet.v1.RegisterRequest
-	4, // 3: otterscale.fleet.v1.FleetService.GetAgentManifest:input_type -> otterscale.fleet.v1.GetAgentManifestRequest
-	2, // 4: otterscale.fleet.v1.FleetService.ListClusters:output_type -> otterscale.fleet.v1.ListClustersResponse
-	6, // 5: otterscale.fleet.v1.FleetService.Register:output_type -> otterscale.fleet.v1.RegisterResponse
-	5, // 6: otterscale.fleet.v1.FleetService.GetAgentManifest:output_type -> otterscale.fleet.v1.GetAgentManifestResponse
-	4, // [4:7] is the sub-list for method output_type
-	1, // [1:4] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0,  // 0: otterscale.fleet.v1.ListClustersResponse.clusters:type_name -> otterscale.fleet.v1.Cluster
+	28, // 1: otterscale.fleet.v1.AgentTunnelStatus.last_registration:type_name -> google.protobuf.Timestamp
+	28, // 2: otterscale.fleet.v1.AgentDiagnosticsResponse.collected_at:type_name -> google.protobuf.Timestamp
+	10, // 3: otterscale.fleet.v1.AgentDiagnosticsResponse.config:type_name -> otterscale.fleet.v1.AgentSetting
+	11, // 4: otterscale.fleet.v1.AgentDiagnosticsResponse.tunnel:type_name -> otterscale.fleet.v1.AgentTunnelStatus
+	12, // 5: otterscale.fleet.v1.AgentDiagnosticsResponse.api_server:type_name -> otterscale.fleet.v1.APIServerReachability
+	27, // 6: otterscale.fleet.v1.ClusterUserInfo.extra:type_name -> otterscale.fleet.v1.ClusterUserInfo.ExtraEntry
+	15, // 7: otterscale.fleet.v1.WhoAmIResponse.cluster_user:type_name -> otterscale.fleet.v1.ClusterUserInfo
+	19, // 8: otterscale.fleet.v1.AgentPermissionsResponse.resource_rules:type_name -> otterscale.fleet.v1.ResourceRule
+	20, // 9: otterscale.fleet.v1.AgentPermissionsResponse.non_resource_rules:type_name -> otterscale.fleet.v1.NonResourceRule
+	28, // 10: otterscale.fleet.v1.CollectSupportBundleResponse.expire_time:type_name -> google.protobuf.Timestamp
+	16, // 11: otterscale.fleet.v1.ClusterUserInfo.ExtraEntry.value:type_name -> otterscale.fleet.v1.ClusterUserExtra
+	1,  // 12: otterscale.fleet.v1.FleetService.ListClusters:input_type -> otterscale.fleet.v1.ListClustersRequest
+	3,  // 13: otterscale.fleet.v1.FleetService.Register:input_type -> otterscale.fleet.v1.RegisterRequest
+	4,  // 14: otterscale.fleet.v1.FleetService.GetAgentManifest:input_type -> otterscale.fleet.v1.GetAgentManifestRequest
+	6,  // 15: otterscale.fleet.v1.FleetService.GetKubeconfig:input_type -> otterscale.fleet.v1.GetKubeconfigRequest
+	9,  // 16: otterscale.fleet.v1.FleetService.AgentDiagnostics:input_type -> otterscale.fleet.v1.AgentDiagnosticsRequest
+	14, // 17: otterscale.fleet.v1.FleetService.WhoAmI:input_type -> otterscale.fleet.v1.WhoAmIRequest
+	18, // 18: otterscale.fleet.v1.FleetService.AgentPermissions:input_type -> otterscale.fleet.v1.AgentPermissionsRequest
+	22, // 19: otterscale.fleet.v1.FleetService.GetMaintenance:input_type -> otterscale.fleet.v1.GetMaintenanceRequest
+	23, // 20: otterscale.fleet.v1.FleetService.SetMaintenance:input_type -> otterscale.fleet.v1.SetMaintenanceRequest
+	25, // 21: otterscale.fleet.v1.FleetService.CollectSupportBundle:input_type -> otterscale.fleet.v1.CollectSupportBundleRequest
+	2,  // 22: otterscale.fleet.v1.FleetService.ListClusters:output_type -> otterscale.fleet.v1.ListClustersResponse
+	8,  // 23: otterscale.fleet.v1.FleetService.Register:output_type -> otterscale.fleet.v1.RegisterResponse
+	5,  // 24: otterscale.fleet.v1.FleetService.GetAgentManifest:output_type -> otterscale.fleet.v1.GetAgentManifestResponse
+	7,  // 25: otterscale.fleet.v1.FleetService.GetKubeconfig:output_type -> otterscale.fleet.v1.GetKubeconfigResponse
+	13, // 26: otterscale.fleet.v1.FleetService.AgentDiagnostics:output_type -> otterscale.fleet.v1.AgentDiagnosticsResponse
+	17, // 27: otterscale.fleet.v1.FleetService.WhoAmI:output_type -> otterscale.fleet.v1.WhoAmIResponse
+	21, // 28: otterscale.fleet.v1.FleetService.AgentPermissions:output_type -> otterscale.fleet.v1.AgentPermissionsResponse
+	24, // 29: otterscale.fleet.v1.FleetService.GetMaintenance:output_type -> otterscale.fleet.v1.Maintenance
+	24, // 30: otterscale.fleet.v1.FleetService.SetMaintenance:output_type -> otterscale.fleet.v1.Maintenance
+	26, // 31: otterscale.fleet.v1.FleetService.CollectSupportBundle:output_type -> otterscale.fleet.v1.CollectSupportBundleResponse
+	22, // [22:32] is the sub-list for method output_type
+	12, // [12:22] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_api_fleet_v1_fleet_proto_init() }
@@ -876,7 +3524,7 @@ func file_api_fleet_v1_fleet_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_fleet_v1_fleet_proto_rawDesc), len(file_api_fleet_v1_fleet_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   28,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
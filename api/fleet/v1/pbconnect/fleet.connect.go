@@ -41,6 +41,26 @@ const (
 	// FleetServiceGetAgentManifestProcedure is the fully-qualified name of the FleetService's
 	// GetAgentManifest RPC.
 	FleetServiceGetAgentManifestProcedure = "/otterscale.fleet.v1.FleetService/GetAgentManifest"
+	// FleetServiceGetKubeconfigProcedure is the fully-qualified name of the FleetService's
+	// GetKubeconfig RPC.
+	FleetServiceGetKubeconfigProcedure = "/otterscale.fleet.v1.FleetService/GetKubeconfig"
+	// FleetServiceAgentDiagnosticsProcedure is the fully-qualified name of the FleetService's
+	// AgentDiagnostics RPC.
+	FleetServiceAgentDiagnosticsProcedure = "/otterscale.fleet.v1.FleetService/AgentDiagnostics"
+	// FleetServiceWhoAmIProcedure is the fully-qualified name of the FleetService's WhoAmI RPC.
+	FleetServiceWhoAmIProcedure = "/otterscale.fleet.v1.FleetService/WhoAmI"
+	// FleetServiceAgentPermissionsProcedure is the fully-qualified name of the FleetService's
+	// AgentPermissions RPC.
+	FleetServiceAgentPermissionsProcedure = "/otterscale.fleet.v1.FleetService/AgentPermissions"
+	// FleetServiceGetMaintenanceProcedure is the fully-qualified name of the FleetService's
+	// GetMaintenance RPC.
+	FleetServiceGetMaintenanceProcedure = "/otterscale.fleet.v1.FleetService/GetMaintenance"
+	// FleetServiceSetMaintenanceProcedure is the fully-qualified name of the FleetService's
+	// SetMaintenance RPC.
+	FleetServiceSetMaintenanceProcedure = "/otterscale.fleet.v1.FleetService/SetMaintenance"
+	// FleetServiceCollectSupportBundleProcedure is the fully-qualified name of the FleetService's
+	// CollectSupportBundle RPC.
+	FleetServiceCollectSupportBundleProcedure = "/otterscale.fleet.v1.FleetService/CollectSupportBundle"
 )
 
 // FleetServiceClient is a client for the otterscale.fleet.v1.FleetService service.
@@ -58,6 +78,46 @@ type FleetServiceClient interface {
 	// includes a Namespace, ServiceAccount, ClusterRoleBinding (binding the
 	// caller to cluster-admin), and a Deployment running the agent.
 	GetAgentManifest(context.Context, *v1.GetAgentManifestRequest) (*v1.GetAgentManifestResponse, error)
+	// GetKubeconfig returns a kubeconfig for accessing a cluster through
+	// the otterscale server's kube-API proxy at /clusters/<cluster>, which
+	// the caller must be allowed to access. The kubeconfig carries no
+	// credentials; users authenticate via an OIDC exec plugin against the
+	// server's identity provider. A short-lived signed URL serving the same
+	// YAML is included for direct download.
+	GetKubeconfig(context.Context, *v1.GetKubeconfigRequest) (*v1.GetKubeconfigResponse, error)
+	// AgentDiagnostics fetches a cluster's agent's report of its own state
+	// through the tunnel: its redacted configuration, tunnel status, proxy
+	// counters and in-cluster API server reachability. Agents that predate
+	// the report fail with FAILED_PRECONDITION.
+	AgentDiagnostics(context.Context, *v1.AgentDiagnosticsRequest) (*v1.AgentDiagnosticsResponse, error)
+	// WhoAmI returns the identity derived from the caller's token, which
+	// the server impersonates on every cluster, for debugging RBAC. When
+	// a cluster is named, it also returns the identity that cluster's API
+	// server sees, from a SelfSubjectReview.
+	WhoAmI(context.Context, *v1.WhoAmIRequest) (*v1.WhoAmIResponse, error)
+	// AgentPermissions returns the rules the agent's own ServiceAccount
+	// is allowed on a cluster, from a SelfSubjectRulesReview made without
+	// impersonation, so that admins can confirm the bootstrap ClusterRole
+	// is in effect. The caller must be allowed to access the cluster.
+	AgentPermissions(context.Context, *v1.AgentPermissionsRequest) (*v1.AgentPermissionsResponse, error)
+	// GetMaintenance returns the fleet-wide maintenance mode.
+	GetMaintenance(context.Context, *v1.GetMaintenanceRequest) (*v1.Maintenance, error)
+	// SetMaintenance enables or disables the fleet-wide maintenance mode,
+	// in which mutating RPCs fail with UNAVAILABLE and the configured
+	// message while reads are served as usual. Only members of the
+	// server's maintenance admin groups may call it; others get
+	// PERMISSION_DENIED. The mode lasts until changed or the server
+	// restarts.
+	SetMaintenance(context.Context, *v1.SetMaintenanceRequest) (*v1.Maintenance, error)
+	// CollectSupportBundle gathers, through the tunnel and with the
+	// caller's permissions, the agent's pod logs, its effective (redacted)
+	// configuration, its tunnel status and the recent events in its
+	// namespace, and returns a signed URL for downloading them as a zip
+	// archive. Parts that cannot be collected are listed in the response
+	// and in the archive instead of failing the whole bundle. The bundle
+	// is held in the memory of the server replica that collected it, so
+	// the URL only works on that replica.
+	CollectSupportBundle(context.Context, *v1.CollectSupportBundleRequest) (*v1.CollectSupportBundleResponse, error)
 }
 
 // NewFleetServiceClient constructs a client for the otterscale.fleet.v1.FleetService service. By
@@ -90,14 +150,68 @@ func NewFleetServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
 			connect.WithClientOptions(opts...),
 		),
+		getKubeconfig: connect.NewClient[v1.GetKubeconfigRequest, v1.GetKubeconfigResponse](
+			httpClient,
+			baseURL+FleetServiceGetKubeconfigProcedure,
+			connect.WithSchema(fleetServiceMethods.ByName("GetKubeconfig")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		agentDiagnostics: connect.NewClient[v1.AgentDiagnosticsRequest, v1.AgentDiagnosticsResponse](
+			httpClient,
+			baseURL+FleetServiceAgentDiagnosticsProcedure,
+			connect.WithSchema(fleetServiceMethods.ByName("AgentDiagnostics")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		whoAmI: connect.NewClient[v1.WhoAmIRequest, v1.WhoAmIResponse](
+			httpClient,
+			baseURL+FleetServiceWhoAmIProcedure,
+			connect.WithSchema(fleetServiceMethods.ByName("WhoAmI")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		agentPermissions: connect.NewClient[v1.AgentPermissionsRequest, v1.AgentPermissionsResponse](
+			httpClient,
+			baseURL+FleetServiceAgentPermissionsProcedure,
+			connect.WithSchema(fleetServiceMethods.ByName("AgentPermissions")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		getMaintenance: connect.NewClient[v1.GetMaintenanceRequest, v1.Maintenance](
+			httpClient,
+			baseURL+FleetServiceGetMaintenanceProcedure,
+			connect.WithSchema(fleetServiceMethods.ByName("GetMaintenance")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		setMaintenance: connect.NewClient[v1.SetMaintenanceRequest, v1.Maintenance](
+			httpClient,
+			baseURL+FleetServiceSetMaintenanceProcedure,
+			connect.WithSchema(fleetServiceMethods.ByName("SetMaintenance")),
+			connect.WithClientOptions(opts...),
+		),
+		collectSupportBundle: connect.NewClient[v1.CollectSupportBundleRequest, v1.CollectSupportBundleResponse](
+			httpClient,
+			baseURL+FleetServiceCollectSupportBundleProcedure,
+			connect.WithSchema(fleetServiceMethods.ByName("CollectSupportBundle")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // fleetServiceClient implements FleetServiceClient.
 type fleetServiceClient struct {
-	listClusters     *connect.Client[v1.ListClustersRequest, v1.ListClustersResponse]
-	register         *connect.Client[v1.RegisterRequest, v1.RegisterResponse]
-	getAgentManifest *connect.Client[v1.GetAgentManifestRequest, v1.GetAgentManifestResponse]
+	listClusters         *connect.Client[v1.ListClustersRequest, v1.ListClustersResponse]
+	register             *connect.Client[v1.RegisterRequest, v1.RegisterResponse]
+	getAgentManifest     *connect.Client[v1.GetAgentManifestRequest, v1.GetAgentManifestResponse]
+	getKubeconfig        *connect.Client[v1.GetKubeconfigRequest, v1.GetKubeconfigResponse]
+	agentDiagnostics     *connect.Client[v1.AgentDiagnosticsRequest, v1.AgentDiagnosticsResponse]
+	whoAmI               *connect.Client[v1.WhoAmIRequest, v1.WhoAmIResponse]
+	agentPermissions     *connect.Client[v1.AgentPermissionsRequest, v1.AgentPermissionsResponse]
+	getMaintenance       *connect.Client[v1.GetMaintenanceRequest, v1.Maintenance]
+	setMaintenance       *connect.Client[v1.SetMaintenanceRequest, v1.Maintenance]
+	collectSupportBundle *connect.Client[v1.CollectSupportBundleRequest, v1.CollectSupportBundleResponse]
 }
 
 // ListClusters calls otterscale.fleet.v1.FleetService.ListClusters.
@@ -127,6 +241,69 @@ func (c *fleetServiceClient) GetAgentManifest(ctx context.Context, req *v1.GetAg
 	return nil, err
 }
 
+// GetKubeconfig calls otterscale.fleet.v1.FleetService.GetKubeconfig.
+func (c *fleetServiceClient) GetKubeconfig(ctx context.Context, req *v1.GetKubeconfigRequest) (*v1.GetKubeconfigResponse, error) {
+	response, err := c.getKubeconfig.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// AgentDiagnostics calls otterscale.fleet.v1.FleetService.AgentDiagnostics.
+func (c *fleetServiceClient) AgentDiagnostics(ctx context.Context, req *v1.AgentDiagnosticsRequest) (*v1.AgentDiagnosticsResponse, error) {
+	response, err := c.agentDiagnostics.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// WhoAmI calls otterscale.fleet.v1.FleetService.WhoAmI.
+func (c *fleetServiceClient) WhoAmI(ctx context.Context, req *v1.WhoAmIRequest) (*v1.WhoAmIResponse, error) {
+	response, err := c.whoAmI.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// AgentPermissions calls otterscale.fleet.v1.FleetService.AgentPermissions.
+func (c *fleetServiceClient) AgentPermissions(ctx context.Context, req *v1.AgentPermissionsRequest) (*v1.AgentPermissionsResponse, error) {
+	response, err := c.agentPermissions.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// GetMaintenance calls otterscale.fleet.v1.FleetService.GetMaintenance.
+func (c *fleetServiceClient) GetMaintenance(ctx context.Context, req *v1.GetMaintenanceRequest) (*v1.Maintenance, error) {
+	response, err := c.getMaintenance.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// SetMaintenance calls otterscale.fleet.v1.FleetService.SetMaintenance.
+func (c *fleetServiceClient) SetMaintenance(ctx context.Context, req *v1.SetMaintenanceRequest) (*v1.Maintenance, error) {
+	response, err := c.setMaintenance.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// CollectSupportBundle calls otterscale.fleet.v1.FleetService.CollectSupportBundle.
+func (c *fleetServiceClient) CollectSupportBundle(ctx context.Context, req *v1.CollectSupportBundleRequest) (*v1.CollectSupportBundleResponse, error) {
+	response, err := c.collectSupportBundle.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
 // FleetServiceHandler is an implementation of the otterscale.fleet.v1.FleetService service.
 type FleetServiceHandler interface {
 	// ListClusters returns all cluster identifiers that the current agent
@@ -142,6 +319,46 @@ type FleetServiceHandler interface {
 	// includes a Namespace, ServiceAccount, ClusterRoleBinding (binding the
 	// caller to cluster-admin), and a Deployment running the agent.
 	GetAgentManifest(context.Context, *v1.GetAgentManifestRequest) (*v1.GetAgentManifestResponse, error)
+	// GetKubeconfig returns a kubeconfig for accessing a cluster through
+	// the otterscale server's kube-API proxy at /clusters/<cluster>, which
+	// the caller must be allowed to access. The kubeconfig carries no
+	// credentials; users authenticate via an OIDC exec plugin against the
+	// server's identity provider. A short-lived signed URL serving the same
+	// YAML is included for direct download.
+	GetKubeconfig(context.Context, *v1.GetKubeconfigRequest) (*v1.GetKubeconfigResponse, error)
+	// AgentDiagnostics fetches a cluster's agent's report of its own state
+	// through the tunnel: its redacted configuration, tunnel status, proxy
+	// counters and in-cluster API server reachability. Agents that predate
+	// the report fail with FAILED_PRECONDITION.
+	AgentDiagnostics(context.Context, *v1.AgentDiagnosticsRequest) (*v1.AgentDiagnosticsResponse, error)
+	// WhoAmI returns the identity derived from the caller's token, which
+	// the server impersonates on every cluster, for debugging RBAC. When
+	// a cluster is named, it also returns the identity that cluster's API
+	// server sees, from a SelfSubjectReview.
+	WhoAmI(context.Context, *v1.WhoAmIRequest) (*v1.WhoAmIResponse, error)
+	// AgentPermissions returns the rules the agent's own ServiceAccount
+	// is allowed on a cluster, from a SelfSubjectRulesReview made without
+	// impersonation, so that admins can confirm the bootstrap ClusterRole
+	// is in effect. The caller must be allowed to access the cluster.
+	AgentPermissions(context.Context, *v1.AgentPermissionsRequest) (*v1.AgentPermissionsResponse, error)
+	// GetMaintenance returns the fleet-wide maintenance mode.
+	GetMaintenance(context.Context, *v1.GetMaintenanceRequest) (*v1.Maintenance, error)
+	// SetMaintenance enables or disables the fleet-wide maintenance mode,
+	// in which mutating RPCs fail with UNAVAILABLE and the configured
+	// message while reads are served as usual. Only members of the
+	// server's maintenance admin groups may call it; others get
+	// PERMISSION_DENIED. The mode lasts until changed or the server
+	// restarts.
+	SetMaintenance(context.Context, *v1.SetMaintenanceRequest) (*v1.Maintenance, error)
+	// CollectSupportBundle gathers, through the tunnel and with the
+	// caller's permissions, the agent's pod logs, its effective (redacted)
+	// configuration, its tunnel status and the recent events in its
+	// namespace, and returns a signed URL for downloading them as a zip
+	// archive. Parts that cannot be collected are listed in the response
+	// and in the archive instead of failing the whole bundle. The bundle
+	// is held in the memory of the server replica that collected it, so
+	// the URL only works on that replica.
+	CollectSupportBundle(context.Context, *v1.CollectSupportBundleRequest) (*v1.CollectSupportBundleResponse, error)
 }
 
 // NewFleetServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -170,6 +387,53 @@ func NewFleetServiceHandler(svc FleetServiceHandler, opts ...connect.HandlerOpti
 		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
 		connect.WithHandlerOptions(opts...),
 	)
+	fleetServiceGetKubeconfigHandler := connect.NewUnaryHandlerSimple(
+		FleetServiceGetKubeconfigProcedure,
+		svc.GetKubeconfig,
+		connect.WithSchema(fleetServiceMethods.ByName("GetKubeconfig")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	fleetServiceAgentDiagnosticsHandler := connect.NewUnaryHandlerSimple(
+		FleetServiceAgentDiagnosticsProcedure,
+		svc.AgentDiagnostics,
+		connect.WithSchema(fleetServiceMethods.ByName("AgentDiagnostics")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	fleetServiceWhoAmIHandler := connect.NewUnaryHandlerSimple(
+		FleetServiceWhoAmIProcedure,
+		svc.WhoAmI,
+		connect.WithSchema(fleetServiceMethods.ByName("WhoAmI")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	fleetServiceAgentPermissionsHandler := connect.NewUnaryHandlerSimple(
+		FleetServiceAgentPermissionsProcedure,
+		svc.AgentPermissions,
+		connect.WithSchema(fleetServiceMethods.ByName("AgentPermissions")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	fleetServiceGetMaintenanceHandler := connect.NewUnaryHandlerSimple(
+		FleetServiceGetMaintenanceProcedure,
+		svc.GetMaintenance,
+		connect.WithSchema(fleetServiceMethods.ByName("GetMaintenance")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	fleetServiceSetMaintenanceHandler := connect.NewUnaryHandlerSimple(
+		FleetServiceSetMaintenanceProcedure,
+		svc.SetMaintenance,
+		connect.WithSchema(fleetServiceMethods.ByName("SetMaintenance")),
+		connect.WithHandlerOptions(opts...),
+	)
+	fleetServiceCollectSupportBundleHandler := connect.NewUnaryHandlerSimple(
+		FleetServiceCollectSupportBundleProcedure,
+		svc.CollectSupportBundle,
+		connect.WithSchema(fleetServiceMethods.ByName("CollectSupportBundle")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/otterscale.fleet.v1.FleetService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case FleetServiceListClustersProcedure:
@@ -178,6 +442,20 @@ func NewFleetServiceHandler(svc FleetServiceHandler, opts ...connect.HandlerOpti
 			fleetServiceRegisterHandler.ServeHTTP(w, r)
 		case FleetServiceGetAgentManifestProcedure:
 			fleetServiceGetAgentManifestHandler.ServeHTTP(w, r)
+		case FleetServiceGetKubeconfigProcedure:
+			fleetServiceGetKubeconfigHandler.ServeHTTP(w, r)
+		case FleetServiceAgentDiagnosticsProcedure:
+			fleetServiceAgentDiagnosticsHandler.ServeHTTP(w, r)
+		case FleetServiceWhoAmIProcedure:
+			fleetServiceWhoAmIHandler.ServeHTTP(w, r)
+		case FleetServiceAgentPermissionsProcedure:
+			fleetServiceAgentPermissionsHandler.ServeHTTP(w, r)
+		case FleetServiceGetMaintenanceProcedure:
+			fleetServiceGetMaintenanceHandler.ServeHTTP(w, r)
+		case FleetServiceSetMaintenanceProcedure:
+			fleetServiceSetMaintenanceHandler.ServeHTTP(w, r)
+		case FleetServiceCollectSupportBundleProcedure:
+			fleetServiceCollectSupportBundleHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -198,3 +476,31 @@ func (UnimplementedFleetServiceHandler) Register(context.Context, *v1.RegisterRe
 func (UnimplementedFleetServiceHandler) GetAgentManifest(context.Context, *v1.GetAgentManifestRequest) (*v1.GetAgentManifestResponse, error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.fleet.v1.FleetService.GetAgentManifest is not implemented"))
 }
+
+func (UnimplementedFleetServiceHandler) GetKubeconfig(context.Context, *v1.GetKubeconfigRequest) (*v1.GetKubeconfigResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.fleet.v1.FleetService.GetKubeconfig is not implemented"))
+}
+
+func (UnimplementedFleetServiceHandler) AgentDiagnostics(context.Context, *v1.AgentDiagnosticsRequest) (*v1.AgentDiagnosticsResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.fleet.v1.FleetService.AgentDiagnostics is not implemented"))
+}
+
+func (UnimplementedFleetServiceHandler) WhoAmI(context.Context, *v1.WhoAmIRequest) (*v1.WhoAmIResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.fleet.v1.FleetService.WhoAmI is not implemented"))
+}
+
+func (UnimplementedFleetServiceHandler) AgentPermissions(context.Context, *v1.AgentPermissionsRequest) (*v1.AgentPermissionsResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.fleet.v1.FleetService.AgentPermissions is not implemented"))
+}
+
+func (UnimplementedFleetServiceHandler) GetMaintenance(context.Context, *v1.GetMaintenanceRequest) (*v1.Maintenance, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.fleet.v1.FleetService.GetMaintenance is not implemented"))
+}
+
+func (UnimplementedFleetServiceHandler) SetMaintenance(context.Context, *v1.SetMaintenanceRequest) (*v1.Maintenance, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.fleet.v1.FleetService.SetMaintenance is not implemented"))
+}
+
+func (UnimplementedFleetServiceHandler) CollectSupportBundle(context.Context, *v1.CollectSupportBundleRequest) (*v1.CollectSupportBundleResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.fleet.v1.FleetService.CollectSupportBundle is not implemented"))
+}
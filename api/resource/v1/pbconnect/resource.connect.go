@@ -38,6 +38,9 @@ const (
 	// ResourceServiceDiscoveryProcedure is the fully-qualified name of the ResourceService's Discovery
 	// RPC.
 	ResourceServiceDiscoveryProcedure = "/otterscale.resource.v1.ResourceService/Discovery"
+	// ResourceServiceCapabilitiesProcedure is the fully-qualified name of the ResourceService's
+	// Capabilities RPC.
+	ResourceServiceCapabilitiesProcedure = "/otterscale.resource.v1.ResourceService/Capabilities"
 	// ResourceServiceSchemaProcedure is the fully-qualified name of the ResourceService's Schema RPC.
 	ResourceServiceSchemaProcedure = "/otterscale.resource.v1.ResourceService/Schema"
 	// ResourceServiceListProcedure is the fully-qualified name of the ResourceService's List RPC.
@@ -47,20 +50,73 @@ const (
 	// ResourceServiceDescribeProcedure is the fully-qualified name of the ResourceService's Describe
 	// RPC.
 	ResourceServiceDescribeProcedure = "/otterscale.resource.v1.ResourceService/Describe"
+	// ResourceServiceFieldOwnershipProcedure is the fully-qualified name of the ResourceService's
+	// FieldOwnership RPC.
+	ResourceServiceFieldOwnershipProcedure = "/otterscale.resource.v1.ResourceService/FieldOwnership"
+	// ResourceServiceGetRevisionProcedure is the fully-qualified name of the ResourceService's
+	// GetRevision RPC.
+	ResourceServiceGetRevisionProcedure = "/otterscale.resource.v1.ResourceService/GetRevision"
+	// ResourceServiceCompareAcrossClustersProcedure is the fully-qualified name of the
+	// ResourceService's CompareAcrossClusters RPC.
+	ResourceServiceCompareAcrossClustersProcedure = "/otterscale.resource.v1.ResourceService/CompareAcrossClusters"
+	// ResourceServiceNamespaceQuotaProcedure is the fully-qualified name of the ResourceService's
+	// NamespaceQuota RPC.
+	ResourceServiceNamespaceQuotaProcedure = "/otterscale.resource.v1.ResourceService/NamespaceQuota"
 	// ResourceServiceCreateProcedure is the fully-qualified name of the ResourceService's Create RPC.
 	ResourceServiceCreateProcedure = "/otterscale.resource.v1.ResourceService/Create"
 	// ResourceServiceApplyProcedure is the fully-qualified name of the ResourceService's Apply RPC.
 	ResourceServiceApplyProcedure = "/otterscale.resource.v1.ResourceService/Apply"
+	// ResourceServiceForceApplyProcedure is the fully-qualified name of the ResourceService's
+	// ForceApply RPC.
+	ResourceServiceForceApplyProcedure = "/otterscale.resource.v1.ResourceService/ForceApply"
+	// ResourceServiceApplyManifestProcedure is the fully-qualified name of the ResourceService's
+	// ApplyManifest RPC.
+	ResourceServiceApplyManifestProcedure = "/otterscale.resource.v1.ResourceService/ApplyManifest"
+	// ResourceServiceApplyFromSourceProcedure is the fully-qualified name of the ResourceService's
+	// ApplyFromSource RPC.
+	ResourceServiceApplyFromSourceProcedure = "/otterscale.resource.v1.ResourceService/ApplyFromSource"
+	// ResourceServiceApplyWithPruneProcedure is the fully-qualified name of the ResourceService's
+	// ApplyWithPrune RPC.
+	ResourceServiceApplyWithPruneProcedure = "/otterscale.resource.v1.ResourceService/ApplyWithPrune"
+	// ResourceServiceApplyHelmChartProcedure is the fully-qualified name of the ResourceService's
+	// ApplyHelmChart RPC.
+	ResourceServiceApplyHelmChartProcedure = "/otterscale.resource.v1.ResourceService/ApplyHelmChart"
+	// ResourceServiceApplyBootstrapProcedure is the fully-qualified name of the ResourceService's
+	// ApplyBootstrap RPC.
+	ResourceServiceApplyBootstrapProcedure = "/otterscale.resource.v1.ResourceService/ApplyBootstrap"
+	// ResourceServiceSetLabelProcedure is the fully-qualified name of the ResourceService's SetLabel
+	// RPC.
+	ResourceServiceSetLabelProcedure = "/otterscale.resource.v1.ResourceService/SetLabel"
+	// ResourceServiceRemoveLabelProcedure is the fully-qualified name of the ResourceService's
+	// RemoveLabel RPC.
+	ResourceServiceRemoveLabelProcedure = "/otterscale.resource.v1.ResourceService/RemoveLabel"
+	// ResourceServiceSetAnnotationProcedure is the fully-qualified name of the ResourceService's
+	// SetAnnotation RPC.
+	ResourceServiceSetAnnotationProcedure = "/otterscale.resource.v1.ResourceService/SetAnnotation"
+	// ResourceServiceRemoveAnnotationProcedure is the fully-qualified name of the ResourceService's
+	// RemoveAnnotation RPC.
+	ResourceServiceRemoveAnnotationProcedure = "/otterscale.resource.v1.ResourceService/RemoveAnnotation"
 	// ResourceServiceDeleteProcedure is the fully-qualified name of the ResourceService's Delete RPC.
 	ResourceServiceDeleteProcedure = "/otterscale.resource.v1.ResourceService/Delete"
 	// ResourceServiceWatchProcedure is the fully-qualified name of the ResourceService's Watch RPC.
 	ResourceServiceWatchProcedure = "/otterscale.resource.v1.ResourceService/Watch"
+	// ResourceServiceWatchAcrossClustersProcedure is the fully-qualified name of the ResourceService's
+	// WatchAcrossClusters RPC.
+	ResourceServiceWatchAcrossClustersProcedure = "/otterscale.resource.v1.ResourceService/WatchAcrossClusters"
+	// ResourceServiceWaitForConditionProcedure is the fully-qualified name of the ResourceService's
+	// WaitForCondition RPC.
+	ResourceServiceWaitForConditionProcedure = "/otterscale.resource.v1.ResourceService/WaitForCondition"
+	// ResourceServiceProxyProcedure is the fully-qualified name of the ResourceService's Proxy RPC.
+	ResourceServiceProxyProcedure = "/otterscale.resource.v1.ResourceService/Proxy"
 )
 
 // ResourceServiceClient is a client for the otterscale.resource.v1.ResourceService service.
 type ResourceServiceClient interface {
 	// Discovery retrieves the available API resources in the specified cluster.
 	Discovery(context.Context, *v1.DiscoveryRequest) (*v1.DiscoveryResponse, error)
+	// Capabilities reports which version-gated Kubernetes features the
+	// specified cluster supports, so that clients can adapt their UI.
+	Capabilities(context.Context, *v1.CapabilitiesRequest) (*v1.CapabilitiesResponse, error)
 	// Schema retrieves the structural definition (JSON Schema) for a specific resource type.
 	// It supports both native Kubernetes resources and installed CRDs.
 	// The raw JSON Schema (Draft 4/7 or 2020-12) describing the resource structure.
@@ -73,15 +129,104 @@ type ResourceServiceClient interface {
 	// Describe retrieves a resource along with its related Kubernetes events,
 	// equivalent to `kubectl describe`.
 	Describe(context.Context, *v1.DescribeRequest) (*v1.DescribeResponse, error)
+	// FieldOwnership reports which field managers own which fields of a
+	// resource, as recorded in its metadata.managedFields, e.g. to debug a
+	// server-side apply conflict.
+	FieldOwnership(context.Context, *v1.FieldOwnershipRequest) (*v1.FieldOwnershipResponse, error)
+	// GetRevision returns a revision of a Deployment, DaemonSet or
+	// StatefulSet pod template from the ReplicaSets or ControllerRevisions
+	// recording the workload's history, e.g. to diff or undo a rollout.
+	// Unknown revisions return NOT_FOUND.
+	GetRevision(context.Context, *v1.GetRevisionRequest) (*v1.Revision, error)
+	// CompareAcrossClusters diffs a resource between two clusters, e.g. to
+	// review a configuration before promoting it from staging to production.
+	// Server-managed fields and the status are ignored.
+	CompareAcrossClusters(context.Context, *v1.CompareAcrossClustersRequest) (*v1.CompareAcrossClustersResponse, error)
+	// NamespaceQuota summarises the ResourceQuota usage and LimitRange
+	// constraints of a namespace. Namespaces without either return empty lists.
+	NamespaceQuota(context.Context, *v1.NamespaceQuotaRequest) (*v1.NamespaceQuotaResponse, error)
 	// Create creates a new resource in the cluster using the provided manifest.
+	// The manifest must set exactly one of metadata.name and
+	// metadata.generateName; the returned resource carries the name the
+	// API server assigned.
 	Create(context.Context, *v1.CreateRequest) (*v1.Resource, error)
 	// Apply performs a Server-Side Apply (SSA) to update or create a resource.
 	// This is the recommended way to perform partial updates.
 	Apply(context.Context, *v1.ApplyRequest) (*v1.Resource, error)
+	// ForceApply is the explicit override step after Apply failed with
+	// FAILED_PRECONDITION and ApplyConflictDetails: it applies with force,
+	// taking over the contested fields from their field managers. The
+	// request's force flag is ignored. The overridden managers are returned
+	// and recorded in the server's audit log.
+	ForceApply(context.Context, *v1.ApplyRequest) (*v1.ForceApplyResponse, error)
+	// ApplyManifest applies every object of a multi-document YAML manifest
+	// with Server-Side Apply and streams one event per step as it happens.
+	// CustomResourceDefinitions are applied first and waited on until
+	// established, then Namespaces, then the remaining objects in document
+	// order. A failed object is reported and the rest are still applied
+	// unless stop_on_error is set, in which case the stream ends with the
+	// failing object's error. With wait_timeout_seconds, the applied objects
+	// are then waited on until ready.
+	ApplyManifest(context.Context, *v1.ApplyManifestRequest) (*connect.ServerStreamForClient[v1.ApplyManifestEvent], error)
+	// ApplyFromSource applies a manifest given by reference instead of
+	// inline, either an https URL on a host allowed by the server, fetched
+	// by the server, or a key of a ConfigMap on the target cluster, read
+	// with the caller's permissions. The manifest is applied and its
+	// progress streamed as by ApplyManifest.
+	ApplyFromSource(context.Context, *v1.ApplyFromSourceRequest) (*connect.ServerStreamForClient[v1.ApplyManifestEvent], error)
+	// ApplyWithPrune applies a manifest as ApplyManifest does, with
+	// prune_label set on every object, then deletes the objects within
+	// prune_scopes that bear the label but are no longer in the manifest,
+	// like kubectl apply --prune. Each deletion is streamed as a
+	// TYPE_PRUNED event. Nothing is pruned if any object failed to apply.
+	ApplyWithPrune(context.Context, *v1.ApplyWithPruneRequest) (*connect.ServerStreamForClient[v1.ApplyManifestEvent], error)
+	// ApplyHelmChart renders an uploaded Helm chart with the given values on
+	// the server, as `helm template` does, and applies the resulting objects
+	// as ApplyManifest does, streaming the same events. The chart's CRDs are
+	// included; hooks and tests are not. No Helm release is recorded on the
+	// cluster. Nothing is applied if the chart fails to render.
+	ApplyHelmChart(context.Context, *v1.ApplyHelmChartRequest) (*connect.ServerStreamForClient[v1.ApplyManifestEvent], error)
+	// ApplyBootstrap applies the agent's bootstrap manifests (FluxCD core
+	// components, the Module CRD, etc.) to a cluster, as the agent does when
+	// it starts, and streams the same events as ApplyManifest. It is the only
+	// procedure a bootstrap service token may call; such requests reach the
+	// cluster as the agent's own ServiceAccount instead of impersonating a
+	// user. Other callers apply with their own permissions.
+	ApplyBootstrap(context.Context, *v1.ApplyBootstrapRequest) (*connect.ServerStreamForClient[v1.ApplyManifestEvent], error)
+	// SetLabel sets a single label on a resource and returns the updated
+	// resource. Only that label is patched, so other labels and concurrent
+	// changes to the object are left untouched.
+	SetLabel(context.Context, *v1.SetLabelRequest) (*v1.Resource, error)
+	// RemoveLabel removes a single label from a resource and returns the
+	// updated resource. Removing an absent label succeeds.
+	RemoveLabel(context.Context, *v1.RemoveLabelRequest) (*v1.Resource, error)
+	// SetAnnotation sets a single annotation on a resource and returns the
+	// updated resource. Only that annotation is patched.
+	SetAnnotation(context.Context, *v1.SetAnnotationRequest) (*v1.Resource, error)
+	// RemoveAnnotation removes a single annotation from a resource and
+	// returns the updated resource. Removing an absent annotation succeeds.
+	RemoveAnnotation(context.Context, *v1.RemoveAnnotationRequest) (*v1.Resource, error)
 	// Delete removes a resource from the cluster by its name.
 	Delete(context.Context, *v1.DeleteRequest) (*emptypb.Empty, error)
 	// Watch initiates a server-side stream to monitor resource changes in real-time.
 	Watch(context.Context, *v1.WatchRequest) (*connect.ServerStreamForClient[v1.WatchEvent], error)
+	// WatchAcrossClusters watches a resource type on several clusters in one
+	// stream, e.g. for fleet dashboards. Each cluster's watch behaves like
+	// Watch and its events are tagged with the cluster. A cluster whose watch
+	// fails or ends is reported with a TYPE_ERROR event and, unless the
+	// failure is permanent (e.g. PERMISSION_DENIED), reopened after a backoff
+	// while the other clusters keep streaming.
+	WatchAcrossClusters(context.Context, *v1.WatchAcrossClustersRequest) (*connect.ServerStreamForClient[v1.ClusterWatchEvent], error)
+	// WaitForCondition blocks until a resource has a status condition with
+	// the requested status, such as Available=True on a Deployment, Ready=True
+	// on a Pod or Complete=True on a Job, and returns the resource. It fails
+	// with DEADLINE_EXCEEDED if the condition is not met within the timeout.
+	WaitForCondition(context.Context, *v1.WaitForConditionRequest) (*v1.Resource, error)
+	// Proxy forwards a raw read-only request to the cluster's API server,
+	// impersonating the caller. It covers endpoints the typed RPCs do not,
+	// such as arbitrary subresources. Only GET is supported, and only for
+	// paths under the server's configured allow-list of prefixes.
+	Proxy(context.Context, *v1.ProxyRequest) (*v1.ProxyResponse, error)
 }
 
 // NewResourceServiceClient constructs a client for the otterscale.resource.v1.ResourceService
@@ -101,6 +246,13 @@ func NewResourceServiceClient(httpClient connect.HTTPClient, baseURL string, opt
 			connect.WithSchema(resourceServiceMethods.ByName("Discovery")),
 			connect.WithClientOptions(opts...),
 		),
+		capabilities: connect.NewClient[v1.CapabilitiesRequest, v1.CapabilitiesResponse](
+			httpClient,
+			baseURL+ResourceServiceCapabilitiesProcedure,
+			connect.WithSchema(resourceServiceMethods.ByName("Capabilities")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
 		schema: connect.NewClient[v1.SchemaRequest, structpb.Struct](
 			httpClient,
 			baseURL+ResourceServiceSchemaProcedure,
@@ -125,6 +277,34 @@ func NewResourceServiceClient(httpClient connect.HTTPClient, baseURL string, opt
 			connect.WithSchema(resourceServiceMethods.ByName("Describe")),
 			connect.WithClientOptions(opts...),
 		),
+		fieldOwnership: connect.NewClient[v1.FieldOwnershipRequest, v1.FieldOwnershipResponse](
+			httpClient,
+			baseURL+ResourceServiceFieldOwnershipProcedure,
+			connect.WithSchema(resourceServiceMethods.ByName("FieldOwnership")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		getRevision: connect.NewClient[v1.GetRevisionRequest, v1.Revision](
+			httpClient,
+			baseURL+ResourceServiceGetRevisionProcedure,
+			connect.WithSchema(resourceServiceMethods.ByName("GetRevision")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		compareAcrossClusters: connect.NewClient[v1.CompareAcrossClustersRequest, v1.CompareAcrossClustersResponse](
+			httpClient,
+			baseURL+ResourceServiceCompareAcrossClustersProcedure,
+			connect.WithSchema(resourceServiceMethods.ByName("CompareAcrossClusters")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		namespaceQuota: connect.NewClient[v1.NamespaceQuotaRequest, v1.NamespaceQuotaResponse](
+			httpClient,
+			baseURL+ResourceServiceNamespaceQuotaProcedure,
+			connect.WithSchema(resourceServiceMethods.ByName("NamespaceQuota")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
 		create: connect.NewClient[v1.CreateRequest, v1.Resource](
 			httpClient,
 			baseURL+ResourceServiceCreateProcedure,
@@ -137,6 +317,66 @@ func NewResourceServiceClient(httpClient connect.HTTPClient, baseURL string, opt
 			connect.WithSchema(resourceServiceMethods.ByName("Apply")),
 			connect.WithClientOptions(opts...),
 		),
+		forceApply: connect.NewClient[v1.ApplyRequest, v1.ForceApplyResponse](
+			httpClient,
+			baseURL+ResourceServiceForceApplyProcedure,
+			connect.WithSchema(resourceServiceMethods.ByName("ForceApply")),
+			connect.WithClientOptions(opts...),
+		),
+		applyManifest: connect.NewClient[v1.ApplyManifestRequest, v1.ApplyManifestEvent](
+			httpClient,
+			baseURL+ResourceServiceApplyManifestProcedure,
+			connect.WithSchema(resourceServiceMethods.ByName("ApplyManifest")),
+			connect.WithClientOptions(opts...),
+		),
+		applyFromSource: connect.NewClient[v1.ApplyFromSourceRequest, v1.ApplyManifestEvent](
+			httpClient,
+			baseURL+ResourceServiceApplyFromSourceProcedure,
+			connect.WithSchema(resourceServiceMethods.ByName("ApplyFromSource")),
+			connect.WithClientOptions(opts...),
+		),
+		applyWithPrune: connect.NewClient[v1.ApplyWithPruneRequest, v1.ApplyManifestEvent](
+			httpClient,
+			baseURL+ResourceServiceApplyWithPruneProcedure,
+			connect.WithSchema(resourceServiceMethods.ByName("ApplyWithPrune")),
+			connect.WithClientOptions(opts...),
+		),
+		applyHelmChart: connect.NewClient[v1.ApplyHelmChartRequest, v1.ApplyManifestEvent](
+			httpClient,
+			baseURL+ResourceServiceApplyHelmChartProcedure,
+			connect.WithSchema(resourceServiceMethods.ByName("ApplyHelmChart")),
+			connect.WithClientOptions(opts...),
+		),
+		applyBootstrap: connect.NewClient[v1.ApplyBootstrapRequest, v1.ApplyManifestEvent](
+			httpClient,
+			baseURL+ResourceServiceApplyBootstrapProcedure,
+			connect.WithSchema(resourceServiceMethods.ByName("ApplyBootstrap")),
+			connect.WithClientOptions(opts...),
+		),
+		setLabel: connect.NewClient[v1.SetLabelRequest, v1.Resource](
+			httpClient,
+			baseURL+ResourceServiceSetLabelProcedure,
+			connect.WithSchema(resourceServiceMethods.ByName("SetLabel")),
+			connect.WithClientOptions(opts...),
+		),
+		removeLabel: connect.NewClient[v1.RemoveLabelRequest, v1.Resource](
+			httpClient,
+			baseURL+ResourceServiceRemoveLabelProcedure,
+			connect.WithSchema(resourceServiceMethods.ByName("RemoveLabel")),
+			connect.WithClientOptions(opts...),
+		),
+		setAnnotation: connect.NewClient[v1.SetAnnotationRequest, v1.Resource](
+			httpClient,
+			baseURL+ResourceServiceSetAnnotationProcedure,
+			connect.WithSchema(resourceServiceMethods.ByName("SetAnnotation")),
+			connect.WithClientOptions(opts...),
+		),
+		removeAnnotation: connect.NewClient[v1.RemoveAnnotationRequest, v1.Resource](
+			httpClient,
+			baseURL+ResourceServiceRemoveAnnotationProcedure,
+			connect.WithSchema(resourceServiceMethods.ByName("RemoveAnnotation")),
+			connect.WithClientOptions(opts...),
+		),
 		delete: connect.NewClient[v1.DeleteRequest, emptypb.Empty](
 			httpClient,
 			baseURL+ResourceServiceDeleteProcedure,
@@ -149,20 +389,57 @@ func NewResourceServiceClient(httpClient connect.HTTPClient, baseURL string, opt
 			connect.WithSchema(resourceServiceMethods.ByName("Watch")),
 			connect.WithClientOptions(opts...),
 		),
+		watchAcrossClusters: connect.NewClient[v1.WatchAcrossClustersRequest, v1.ClusterWatchEvent](
+			httpClient,
+			baseURL+ResourceServiceWatchAcrossClustersProcedure,
+			connect.WithSchema(resourceServiceMethods.ByName("WatchAcrossClusters")),
+			connect.WithClientOptions(opts...),
+		),
+		waitForCondition: connect.NewClient[v1.WaitForConditionRequest, v1.Resource](
+			httpClient,
+			baseURL+ResourceServiceWaitForConditionProcedure,
+			connect.WithSchema(resourceServiceMethods.ByName("WaitForCondition")),
+			connect.WithClientOptions(opts...),
+		),
+		proxy: connect.NewClient[v1.ProxyRequest, v1.ProxyResponse](
+			httpClient,
+			baseURL+ResourceServiceProxyProcedure,
+			connect.WithSchema(resourceServiceMethods.ByName("Proxy")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // resourceServiceClient implements ResourceServiceClient.
 type resourceServiceClient struct {
-	discovery *connect.Client[v1.DiscoveryRequest, v1.DiscoveryResponse]
-	schema    *connect.Client[v1.SchemaRequest, structpb.Struct]
-	list      *connect.Client[v1.ListRequest, v1.ListResponse]
-	get       *connect.Client[v1.GetRequest, v1.Resource]
-	describe  *connect.Client[v1.DescribeRequest, v1.DescribeResponse]
-	create    *connect.Client[v1.CreateRequest, v1.Resource]
-	apply     *connect.Client[v1.ApplyRequest, v1.Resource]
-	delete    *connect.Client[v1.DeleteRequest, emptypb.Empty]
-	watch     *connect.Client[v1.WatchRequest, v1.WatchEvent]
+	discovery             *connect.Client[v1.DiscoveryRequest, v1.DiscoveryResponse]
+	capabilities          *connect.Client[v1.CapabilitiesRequest, v1.CapabilitiesResponse]
+	schema                *connect.Client[v1.SchemaRequest, structpb.Struct]
+	list                  *connect.Client[v1.ListRequest, v1.ListResponse]
+	get                   *connect.Client[v1.GetRequest, v1.Resource]
+	describe              *connect.Client[v1.DescribeRequest, v1.DescribeResponse]
+	fieldOwnership        *connect.Client[v1.FieldOwnershipRequest, v1.FieldOwnershipResponse]
+	getRevision           *connect.Client[v1.GetRevisionRequest, v1.Revision]
+	compareAcrossClusters *connect.Client[v1.CompareAcrossClustersRequest, v1.CompareAcrossClustersResponse]
+	namespaceQuota        *connect.Client[v1.NamespaceQuotaRequest, v1.NamespaceQuotaResponse]
+	create                *connect.Client[v1.CreateRequest, v1.Resource]
+	apply                 *connect.Client[v1.ApplyRequest, v1.Resource]
+	forceApply            *connect.Client[v1.ApplyRequest, v1.ForceApplyResponse]
+	applyManifest         *connect.Client[v1.ApplyManifestRequest, v1.ApplyManifestEvent]
+	applyFromSource       *connect.Client[v1.ApplyFromSourceRequest, v1.ApplyManifestEvent]
+	applyWithPrune        *connect.Client[v1.ApplyWithPruneRequest, v1.ApplyManifestEvent]
+	applyHelmChart        *connect.Client[v1.ApplyHelmChartRequest, v1.ApplyManifestEvent]
+	applyBootstrap        *connect.Client[v1.ApplyBootstrapRequest, v1.ApplyManifestEvent]
+	setLabel              *connect.Client[v1.SetLabelRequest, v1.Resource]
+	removeLabel           *connect.Client[v1.RemoveLabelRequest, v1.Resource]
+	setAnnotation         *connect.Client[v1.SetAnnotationRequest, v1.Resource]
+	removeAnnotation      *connect.Client[v1.RemoveAnnotationRequest, v1.Resource]
+	delete                *connect.Client[v1.DeleteRequest, emptypb.Empty]
+	watch                 *connect.Client[v1.WatchRequest, v1.WatchEvent]
+	watchAcrossClusters   *connect.Client[v1.WatchAcrossClustersRequest, v1.ClusterWatchEvent]
+	waitForCondition      *connect.Client[v1.WaitForConditionRequest, v1.Resource]
+	proxy                 *connect.Client[v1.ProxyRequest, v1.ProxyResponse]
 }
 
 // Discovery calls otterscale.resource.v1.ResourceService.Discovery.
@@ -174,6 +451,15 @@ func (c *resourceServiceClient) Discovery(ctx context.Context, req *v1.Discovery
 	return nil, err
 }
 
+// Capabilities calls otterscale.resource.v1.ResourceService.Capabilities.
+func (c *resourceServiceClient) Capabilities(ctx context.Context, req *v1.CapabilitiesRequest) (*v1.CapabilitiesResponse, error) {
+	response, err := c.capabilities.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
 // Schema calls otterscale.resource.v1.ResourceService.Schema.
 func (c *resourceServiceClient) Schema(ctx context.Context, req *v1.SchemaRequest) (*structpb.Struct, error) {
 	response, err := c.schema.CallUnary(ctx, connect.NewRequest(req))
@@ -210,6 +496,42 @@ func (c *resourceServiceClient) Describe(ctx context.Context, req *v1.DescribeRe
 	return nil, err
 }
 
+// FieldOwnership calls otterscale.resource.v1.ResourceService.FieldOwnership.
+func (c *resourceServiceClient) FieldOwnership(ctx context.Context, req *v1.FieldOwnershipRequest) (*v1.FieldOwnershipResponse, error) {
+	response, err := c.fieldOwnership.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// GetRevision calls otterscale.resource.v1.ResourceService.GetRevision.
+func (c *resourceServiceClient) GetRevision(ctx context.Context, req *v1.GetRevisionRequest) (*v1.Revision, error) {
+	response, err := c.getRevision.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// CompareAcrossClusters calls otterscale.resource.v1.ResourceService.CompareAcrossClusters.
+func (c *resourceServiceClient) CompareAcrossClusters(ctx context.Context, req *v1.CompareAcrossClustersRequest) (*v1.CompareAcrossClustersResponse, error) {
+	response, err := c.compareAcrossClusters.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// NamespaceQuota calls otterscale.resource.v1.ResourceService.NamespaceQuota.
+func (c *resourceServiceClient) NamespaceQuota(ctx context.Context, req *v1.NamespaceQuotaRequest) (*v1.NamespaceQuotaResponse, error) {
+	response, err := c.namespaceQuota.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
 // Create calls otterscale.resource.v1.ResourceService.Create.
 func (c *resourceServiceClient) Create(ctx context.Context, req *v1.CreateRequest) (*v1.Resource, error) {
 	response, err := c.create.CallUnary(ctx, connect.NewRequest(req))
@@ -228,6 +550,76 @@ func (c *resourceServiceClient) Apply(ctx context.Context, req *v1.ApplyRequest)
 	return nil, err
 }
 
+// ForceApply calls otterscale.resource.v1.ResourceService.ForceApply.
+func (c *resourceServiceClient) ForceApply(ctx context.Context, req *v1.ApplyRequest) (*v1.ForceApplyResponse, error) {
+	response, err := c.forceApply.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// ApplyManifest calls otterscale.resource.v1.ResourceService.ApplyManifest.
+func (c *resourceServiceClient) ApplyManifest(ctx context.Context, req *v1.ApplyManifestRequest) (*connect.ServerStreamForClient[v1.ApplyManifestEvent], error) {
+	return c.applyManifest.CallServerStream(ctx, connect.NewRequest(req))
+}
+
+// ApplyFromSource calls otterscale.resource.v1.ResourceService.ApplyFromSource.
+func (c *resourceServiceClient) ApplyFromSource(ctx context.Context, req *v1.ApplyFromSourceRequest) (*connect.ServerStreamForClient[v1.ApplyManifestEvent], error) {
+	return c.applyFromSource.CallServerStream(ctx, connect.NewRequest(req))
+}
+
+// ApplyWithPrune calls otterscale.resource.v1.ResourceService.ApplyWithPrune.
+func (c *resourceServiceClient) ApplyWithPrune(ctx context.Context, req *v1.ApplyWithPruneRequest) (*connect.ServerStreamForClient[v1.ApplyManifestEvent], error) {
+	return c.applyWithPrune.CallServerStream(ctx, connect.NewRequest(req))
+}
+
+// ApplyHelmChart calls otterscale.resource.v1.ResourceService.ApplyHelmChart.
+func (c *resourceServiceClient) ApplyHelmChart(ctx context.Context, req *v1.ApplyHelmChartRequest) (*connect.ServerStreamForClient[v1.ApplyManifestEvent], error) {
+	return c.applyHelmChart.CallServerStream(ctx, connect.NewRequest(req))
+}
+
+// ApplyBootstrap calls otterscale.resource.v1.ResourceService.ApplyBootstrap.
+func (c *resourceServiceClient) ApplyBootstrap(ctx context.Context, req *v1.ApplyBootstrapRequest) (*connect.ServerStreamForClient[v1.ApplyManifestEvent], error) {
+	return c.applyBootstrap.CallServerStream(ctx, connect.NewRequest(req))
+}
+
+// SetLabel calls otterscale.resource.v1.ResourceService.SetLabel.
+func (c *resourceServiceClient) SetLabel(ctx context.Context, req *v1.SetLabelRequest) (*v1.Resource, error) {
+	response, err := c.setLabel.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// RemoveLabel calls otterscale.resource.v1.ResourceService.RemoveLabel.
+func (c *resourceServiceClient) RemoveLabel(ctx context.Context, req *v1.RemoveLabelRequest) (*v1.Resource, error) {
+	response, err := c.removeLabel.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// SetAnnotation calls otterscale.resource.v1.ResourceService.SetAnnotation.
+func (c *resourceServiceClient) SetAnnotation(ctx context.Context, req *v1.SetAnnotationRequest) (*v1.Resource, error) {
+	response, err := c.setAnnotation.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// RemoveAnnotation calls otterscale.resource.v1.ResourceService.RemoveAnnotation.
+func (c *resourceServiceClient) RemoveAnnotation(ctx context.Context, req *v1.RemoveAnnotationRequest) (*v1.Resource, error) {
+	response, err := c.removeAnnotation.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
 // Delete calls otterscale.resource.v1.ResourceService.Delete.
 func (c *resourceServiceClient) Delete(ctx context.Context, req *v1.DeleteRequest) (*emptypb.Empty, error) {
 	response, err := c.delete.CallUnary(ctx, connect.NewRequest(req))
@@ -242,11 +634,37 @@ func (c *resourceServiceClient) Watch(ctx context.Context, req *v1.WatchRequest)
 	return c.watch.CallServerStream(ctx, connect.NewRequest(req))
 }
 
+// WatchAcrossClusters calls otterscale.resource.v1.ResourceService.WatchAcrossClusters.
+func (c *resourceServiceClient) WatchAcrossClusters(ctx context.Context, req *v1.WatchAcrossClustersRequest) (*connect.ServerStreamForClient[v1.ClusterWatchEvent], error) {
+	return c.watchAcrossClusters.CallServerStream(ctx, connect.NewRequest(req))
+}
+
+// WaitForCondition calls otterscale.resource.v1.ResourceService.WaitForCondition.
+func (c *resourceServiceClient) WaitForCondition(ctx context.Context, req *v1.WaitForConditionRequest) (*v1.Resource, error) {
+	response, err := c.waitForCondition.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// Proxy calls otterscale.resource.v1.ResourceService.Proxy.
+func (c *resourceServiceClient) Proxy(ctx context.Context, req *v1.ProxyRequest) (*v1.ProxyResponse, error) {
+	response, err := c.proxy.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
 // ResourceServiceHandler is an implementation of the otterscale.resource.v1.ResourceService
 // service.
 type ResourceServiceHandler interface {
 	// Discovery retrieves the available API resources in the specified cluster.
 	Discovery(context.Context, *v1.DiscoveryRequest) (*v1.DiscoveryResponse, error)
+	// Capabilities reports which version-gated Kubernetes features the
+	// specified cluster supports, so that clients can adapt their UI.
+	Capabilities(context.Context, *v1.CapabilitiesRequest) (*v1.CapabilitiesResponse, error)
 	// Schema retrieves the structural definition (JSON Schema) for a specific resource type.
 	// It supports both native Kubernetes resources and installed CRDs.
 	// The raw JSON Schema (Draft 4/7 or 2020-12) describing the resource structure.
@@ -259,15 +677,104 @@ type ResourceServiceHandler interface {
 	// Describe retrieves a resource along with its related Kubernetes events,
 	// equivalent to `kubectl describe`.
 	Describe(context.Context, *v1.DescribeRequest) (*v1.DescribeResponse, error)
+	// FieldOwnership reports which field managers own which fields of a
+	// resource, as recorded in its metadata.managedFields, e.g. to debug a
+	// server-side apply conflict.
+	FieldOwnership(context.Context, *v1.FieldOwnershipRequest) (*v1.FieldOwnershipResponse, error)
+	// GetRevision returns a revision of a Deployment, DaemonSet or
+	// StatefulSet pod template from the ReplicaSets or ControllerRevisions
+	// recording the workload's history, e.g. to diff or undo a rollout.
+	// Unknown revisions return NOT_FOUND.
+	GetRevision(context.Context, *v1.GetRevisionRequest) (*v1.Revision, error)
+	// CompareAcrossClusters diffs a resource between two clusters, e.g. to
+	// review a configuration before promoting it from staging to production.
+	// Server-managed fields and the status are ignored.
+	CompareAcrossClusters(context.Context, *v1.CompareAcrossClustersRequest) (*v1.CompareAcrossClustersResponse, error)
+	// NamespaceQuota summarises the ResourceQuota usage and LimitRange
+	// constraints of a namespace. Namespaces without either return empty lists.
+	NamespaceQuota(context.Context, *v1.NamespaceQuotaRequest) (*v1.NamespaceQuotaResponse, error)
 	// Create creates a new resource in the cluster using the provided manifest.
+	// The manifest must set exactly one of metadata.name and
+	// metadata.generateName; the returned resource carries the name the
+	// API server assigned.
 	Create(context.Context, *v1.CreateRequest) (*v1.Resource, error)
 	// Apply performs a Server-Side Apply (SSA) to update or create a resource.
 	// This is the recommended way to perform partial updates.
 	Apply(context.Context, *v1.ApplyRequest) (*v1.Resource, error)
+	// ForceApply is the explicit override step after Apply failed with
+	// FAILED_PRECONDITION and ApplyConflictDetails: it applies with force,
+	// taking over the contested fields from their field managers. The
+	// request's force flag is ignored. The overridden managers are returned
+	// and recorded in the server's audit log.
+	ForceApply(context.Context, *v1.ApplyRequest) (*v1.ForceApplyResponse, error)
+	// ApplyManifest applies every object of a multi-document YAML manifest
+	// with Server-Side Apply and streams one event per step as it happens.
+	// CustomResourceDefinitions are applied first and waited on until
+	// established, then Namespaces, then the remaining objects in document
+	// order. A failed object is reported and the rest are still applied
+	// unless stop_on_error is set, in which case the stream ends with the
+	// failing object's error. With wait_timeout_seconds, the applied objects
+	// are then waited on until ready.
+	ApplyManifest(context.Context, *v1.ApplyManifestRequest, *connect.ServerStream[v1.ApplyManifestEvent]) error
+	// ApplyFromSource applies a manifest given by reference instead of
+	// inline, either an https URL on a host allowed by the server, fetched
+	// by the server, or a key of a ConfigMap on the target cluster, read
+	// with the caller's permissions. The manifest is applied and its
+	// progress streamed as by ApplyManifest.
+	ApplyFromSource(context.Context, *v1.ApplyFromSourceRequest, *connect.ServerStream[v1.ApplyManifestEvent]) error
+	// ApplyWithPrune applies a manifest as ApplyManifest does, with
+	// prune_label set on every object, then deletes the objects within
+	// prune_scopes that bear the label but are no longer in the manifest,
+	// like kubectl apply --prune. Each deletion is streamed as a
+	// TYPE_PRUNED event. Nothing is pruned if any object failed to apply.
+	ApplyWithPrune(context.Context, *v1.ApplyWithPruneRequest, *connect.ServerStream[v1.ApplyManifestEvent]) error
+	// ApplyHelmChart renders an uploaded Helm chart with the given values on
+	// the server, as `helm template` does, and applies the resulting objects
+	// as ApplyManifest does, streaming the same events. The chart's CRDs are
+	// included; hooks and tests are not. No Helm release is recorded on the
+	// cluster. Nothing is applied if the chart fails to render.
+	ApplyHelmChart(context.Context, *v1.ApplyHelmChartRequest, *connect.ServerStream[v1.ApplyManifestEvent]) error
+	// ApplyBootstrap applies the agent's bootstrap manifests (FluxCD core
+	// components, the Module CRD, etc.) to a cluster, as the agent does when
+	// it starts, and streams the same events as ApplyManifest. It is the only
+	// procedure a bootstrap service token may call; such requests reach the
+	// cluster as the agent's own ServiceAccount instead of impersonating a
+	// user. Other callers apply with their own permissions.
+	ApplyBootstrap(context.Context, *v1.ApplyBootstrapRequest, *connect.ServerStream[v1.ApplyManifestEvent]) error
+	// SetLabel sets a single label on a resource and returns the updated
+	// resource. Only that label is patched, so other labels and concurrent
+	// changes to the object are left untouched.
+	SetLabel(context.Context, *v1.SetLabelRequest) (*v1.Resource, error)
+	// RemoveLabel removes a single label from a resource and returns the
+	// updated resource. Removing an absent label succeeds.
+	RemoveLabel(context.Context, *v1.RemoveLabelRequest) (*v1.Resource, error)
+	// SetAnnotation sets a single annotation on a resource and returns the
+	// updated resource. Only that annotation is patched.
+	SetAnnotation(context.Context, *v1.SetAnnotationRequest) (*v1.Resource, error)
+	// RemoveAnnotation removes a single annotation from a resource and
+	// returns the updated resource. Removing an absent annotation succeeds.
+	RemoveAnnotation(context.Context, *v1.RemoveAnnotationRequest) (*v1.Resource, error)
 	// Delete removes a resource from the cluster by its name.
 	Delete(context.Context, *v1.DeleteRequest) (*emptypb.Empty, error)
 	// Watch initiates a server-side stream to monitor resource changes in real-time.
 	Watch(context.Context, *v1.WatchRequest, *connect.ServerStream[v1.WatchEvent]) error
+	// WatchAcrossClusters watches a resource type on several clusters in one
+	// stream, e.g. for fleet dashboards. Each cluster's watch behaves like
+	// Watch and its events are tagged with the cluster. A cluster whose watch
+	// fails or ends is reported with a TYPE_ERROR event and, unless the
+	// failure is permanent (e.g. PERMISSION_DENIED), reopened after a backoff
+	// while the other clusters keep streaming.
+	WatchAcrossClusters(context.Context, *v1.WatchAcrossClustersRequest, *connect.ServerStream[v1.ClusterWatchEvent]) error
+	// WaitForCondition blocks until a resource has a status condition with
+	// the requested status, such as Available=True on a Deployment, Ready=True
+	// on a Pod or Complete=True on a Job, and returns the resource. It fails
+	// with DEADLINE_EXCEEDED if the condition is not met within the timeout.
+	WaitForCondition(context.Context, *v1.WaitForConditionRequest) (*v1.Resource, error)
+	// Proxy forwards a raw read-only request to the cluster's API server,
+	// impersonating the caller. It covers endpoints the typed RPCs do not,
+	// such as arbitrary subresources. Only GET is supported, and only for
+	// paths under the server's configured allow-list of prefixes.
+	Proxy(context.Context, *v1.ProxyRequest) (*v1.ProxyResponse, error)
 }
 
 // NewResourceServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -283,6 +790,13 @@ func NewResourceServiceHandler(svc ResourceServiceHandler, opts ...connect.Handl
 		connect.WithSchema(resourceServiceMethods.ByName("Discovery")),
 		connect.WithHandlerOptions(opts...),
 	)
+	resourceServiceCapabilitiesHandler := connect.NewUnaryHandlerSimple(
+		ResourceServiceCapabilitiesProcedure,
+		svc.Capabilities,
+		connect.WithSchema(resourceServiceMethods.ByName("Capabilities")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
 	resourceServiceSchemaHandler := connect.NewUnaryHandlerSimple(
 		ResourceServiceSchemaProcedure,
 		svc.Schema,
@@ -307,6 +821,34 @@ func NewResourceServiceHandler(svc ResourceServiceHandler, opts ...connect.Handl
 		connect.WithSchema(resourceServiceMethods.ByName("Describe")),
 		connect.WithHandlerOptions(opts...),
 	)
+	resourceServiceFieldOwnershipHandler := connect.NewUnaryHandlerSimple(
+		ResourceServiceFieldOwnershipProcedure,
+		svc.FieldOwnership,
+		connect.WithSchema(resourceServiceMethods.ByName("FieldOwnership")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	resourceServiceGetRevisionHandler := connect.NewUnaryHandlerSimple(
+		ResourceServiceGetRevisionProcedure,
+		svc.GetRevision,
+		connect.WithSchema(resourceServiceMethods.ByName("GetRevision")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	resourceServiceCompareAcrossClustersHandler := connect.NewUnaryHandlerSimple(
+		ResourceServiceCompareAcrossClustersProcedure,
+		svc.CompareAcrossClusters,
+		connect.WithSchema(resourceServiceMethods.ByName("CompareAcrossClusters")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	resourceServiceNamespaceQuotaHandler := connect.NewUnaryHandlerSimple(
+		ResourceServiceNamespaceQuotaProcedure,
+		svc.NamespaceQuota,
+		connect.WithSchema(resourceServiceMethods.ByName("NamespaceQuota")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
 	resourceServiceCreateHandler := connect.NewUnaryHandlerSimple(
 		ResourceServiceCreateProcedure,
 		svc.Create,
@@ -319,6 +861,66 @@ func NewResourceServiceHandler(svc ResourceServiceHandler, opts ...connect.Handl
 		connect.WithSchema(resourceServiceMethods.ByName("Apply")),
 		connect.WithHandlerOptions(opts...),
 	)
+	resourceServiceForceApplyHandler := connect.NewUnaryHandlerSimple(
+		ResourceServiceForceApplyProcedure,
+		svc.ForceApply,
+		connect.WithSchema(resourceServiceMethods.ByName("ForceApply")),
+		connect.WithHandlerOptions(opts...),
+	)
+	resourceServiceApplyManifestHandler := connect.NewServerStreamHandlerSimple(
+		ResourceServiceApplyManifestProcedure,
+		svc.ApplyManifest,
+		connect.WithSchema(resourceServiceMethods.ByName("ApplyManifest")),
+		connect.WithHandlerOptions(opts...),
+	)
+	resourceServiceApplyFromSourceHandler := connect.NewServerStreamHandlerSimple(
+		ResourceServiceApplyFromSourceProcedure,
+		svc.ApplyFromSource,
+		connect.WithSchema(resourceServiceMethods.ByName("ApplyFromSource")),
+		connect.WithHandlerOptions(opts...),
+	)
+	resourceServiceApplyWithPruneHandler := connect.NewServerStreamHandlerSimple(
+		ResourceServiceApplyWithPruneProcedure,
+		svc.ApplyWithPrune,
+		connect.WithSchema(resourceServiceMethods.ByName("ApplyWithPrune")),
+		connect.WithHandlerOptions(opts...),
+	)
+	resourceServiceApplyHelmChartHandler := connect.NewServerStreamHandlerSimple(
+		ResourceServiceApplyHelmChartProcedure,
+		svc.ApplyHelmChart,
+		connect.WithSchema(resourceServiceMethods.ByName("ApplyHelmChart")),
+		connect.WithHandlerOptions(opts...),
+	)
+	resourceServiceApplyBootstrapHandler := connect.NewServerStreamHandlerSimple(
+		ResourceServiceApplyBootstrapProcedure,
+		svc.ApplyBootstrap,
+		connect.WithSchema(resourceServiceMethods.ByName("ApplyBootstrap")),
+		connect.WithHandlerOptions(opts...),
+	)
+	resourceServiceSetLabelHandler := connect.NewUnaryHandlerSimple(
+		ResourceServiceSetLabelProcedure,
+		svc.SetLabel,
+		connect.WithSchema(resourceServiceMethods.ByName("SetLabel")),
+		connect.WithHandlerOptions(opts...),
+	)
+	resourceServiceRemoveLabelHandler := connect.NewUnaryHandlerSimple(
+		ResourceServiceRemoveLabelProcedure,
+		svc.RemoveLabel,
+		connect.WithSchema(resourceServiceMethods.ByName("RemoveLabel")),
+		connect.WithHandlerOptions(opts...),
+	)
+	resourceServiceSetAnnotationHandler := connect.NewUnaryHandlerSimple(
+		ResourceServiceSetAnnotationProcedure,
+		svc.SetAnnotation,
+		connect.WithSchema(resourceServiceMethods.ByName("SetAnnotation")),
+		connect.WithHandlerOptions(opts...),
+	)
+	resourceServiceRemoveAnnotationHandler := connect.NewUnaryHandlerSimple(
+		ResourceServiceRemoveAnnotationProcedure,
+		svc.RemoveAnnotation,
+		connect.WithSchema(resourceServiceMethods.ByName("RemoveAnnotation")),
+		connect.WithHandlerOptions(opts...),
+	)
 	resourceServiceDeleteHandler := connect.NewUnaryHandlerSimple(
 		ResourceServiceDeleteProcedure,
 		svc.Delete,
@@ -331,10 +933,31 @@ func NewResourceServiceHandler(svc ResourceServiceHandler, opts ...connect.Handl
 		connect.WithSchema(resourceServiceMethods.ByName("Watch")),
 		connect.WithHandlerOptions(opts...),
 	)
+	resourceServiceWatchAcrossClustersHandler := connect.NewServerStreamHandlerSimple(
+		ResourceServiceWatchAcrossClustersProcedure,
+		svc.WatchAcrossClusters,
+		connect.WithSchema(resourceServiceMethods.ByName("WatchAcrossClusters")),
+		connect.WithHandlerOptions(opts...),
+	)
+	resourceServiceWaitForConditionHandler := connect.NewUnaryHandlerSimple(
+		ResourceServiceWaitForConditionProcedure,
+		svc.WaitForCondition,
+		connect.WithSchema(resourceServiceMethods.ByName("WaitForCondition")),
+		connect.WithHandlerOptions(opts...),
+	)
+	resourceServiceProxyHandler := connect.NewUnaryHandlerSimple(
+		ResourceServiceProxyProcedure,
+		svc.Proxy,
+		connect.WithSchema(resourceServiceMethods.ByName("Proxy")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/otterscale.resource.v1.ResourceService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case ResourceServiceDiscoveryProcedure:
 			resourceServiceDiscoveryHandler.ServeHTTP(w, r)
+		case ResourceServiceCapabilitiesProcedure:
+			resourceServiceCapabilitiesHandler.ServeHTTP(w, r)
 		case ResourceServiceSchemaProcedure:
 			resourceServiceSchemaHandler.ServeHTTP(w, r)
 		case ResourceServiceListProcedure:
@@ -343,14 +966,48 @@ func NewResourceServiceHandler(svc ResourceServiceHandler, opts ...connect.Handl
 			resourceServiceGetHandler.ServeHTTP(w, r)
 		case ResourceServiceDescribeProcedure:
 			resourceServiceDescribeHandler.ServeHTTP(w, r)
+		case ResourceServiceFieldOwnershipProcedure:
+			resourceServiceFieldOwnershipHandler.ServeHTTP(w, r)
+		case ResourceServiceGetRevisionProcedure:
+			resourceServiceGetRevisionHandler.ServeHTTP(w, r)
+		case ResourceServiceCompareAcrossClustersProcedure:
+			resourceServiceCompareAcrossClustersHandler.ServeHTTP(w, r)
+		case ResourceServiceNamespaceQuotaProcedure:
+			resourceServiceNamespaceQuotaHandler.ServeHTTP(w, r)
 		case ResourceServiceCreateProcedure:
 			resourceServiceCreateHandler.ServeHTTP(w, r)
 		case ResourceServiceApplyProcedure:
 			resourceServiceApplyHandler.ServeHTTP(w, r)
+		case ResourceServiceForceApplyProcedure:
+			resourceServiceForceApplyHandler.ServeHTTP(w, r)
+		case ResourceServiceApplyManifestProcedure:
+			resourceServiceApplyManifestHandler.ServeHTTP(w, r)
+		case ResourceServiceApplyFromSourceProcedure:
+			resourceServiceApplyFromSourceHandler.ServeHTTP(w, r)
+		case ResourceServiceApplyWithPruneProcedure:
+			resourceServiceApplyWithPruneHandler.ServeHTTP(w, r)
+		case ResourceServiceApplyHelmChartProcedure:
+			resourceServiceApplyHelmChartHandler.ServeHTTP(w, r)
+		case ResourceServiceApplyBootstrapProcedure:
+			resourceServiceApplyBootstrapHandler.ServeHTTP(w, r)
+		case ResourceServiceSetLabelProcedure:
+			resourceServiceSetLabelHandler.ServeHTTP(w, r)
+		case ResourceServiceRemoveLabelProcedure:
+			resourceServiceRemoveLabelHandler.ServeHTTP(w, r)
+		case ResourceServiceSetAnnotationProcedure:
+			resourceServiceSetAnnotationHandler.ServeHTTP(w, r)
+		case ResourceServiceRemoveAnnotationProcedure:
+			resourceServiceRemoveAnnotationHandler.ServeHTTP(w, r)
 		case ResourceServiceDeleteProcedure:
 			resourceServiceDeleteHandler.ServeHTTP(w, r)
 		case ResourceServiceWatchProcedure:
 			resourceServiceWatchHandler.ServeHTTP(w, r)
+		case ResourceServiceWatchAcrossClustersProcedure:
+			resourceServiceWatchAcrossClustersHandler.ServeHTTP(w, r)
+		case ResourceServiceWaitForConditionProcedure:
+			resourceServiceWaitForConditionHandler.ServeHTTP(w, r)
+		case ResourceServiceProxyProcedure:
+			resourceServiceProxyHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -364,6 +1021,10 @@ func (UnimplementedResourceServiceHandler) Discovery(context.Context, *v1.Discov
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.resource.v1.ResourceService.Discovery is not implemented"))
 }
 
+func (UnimplementedResourceServiceHandler) Capabilities(context.Context, *v1.CapabilitiesRequest) (*v1.CapabilitiesResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.resource.v1.ResourceService.Capabilities is not implemented"))
+}
+
 func (UnimplementedResourceServiceHandler) Schema(context.Context, *v1.SchemaRequest) (*structpb.Struct, error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.resource.v1.ResourceService.Schema is not implemented"))
 }
@@ -380,6 +1041,22 @@ func (UnimplementedResourceServiceHandler) Describe(context.Context, *v1.Describ
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.resource.v1.ResourceService.Describe is not implemented"))
 }
 
+func (UnimplementedResourceServiceHandler) FieldOwnership(context.Context, *v1.FieldOwnershipRequest) (*v1.FieldOwnershipResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.resource.v1.ResourceService.FieldOwnership is not implemented"))
+}
+
+func (UnimplementedResourceServiceHandler) GetRevision(context.Context, *v1.GetRevisionRequest) (*v1.Revision, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.resource.v1.ResourceService.GetRevision is not implemented"))
+}
+
+func (UnimplementedResourceServiceHandler) CompareAcrossClusters(context.Context, *v1.CompareAcrossClustersRequest) (*v1.CompareAcrossClustersResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.resource.v1.ResourceService.CompareAcrossClusters is not implemented"))
+}
+
+func (UnimplementedResourceServiceHandler) NamespaceQuota(context.Context, *v1.NamespaceQuotaRequest) (*v1.NamespaceQuotaResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.resource.v1.ResourceService.NamespaceQuota is not implemented"))
+}
+
 func (UnimplementedResourceServiceHandler) Create(context.Context, *v1.CreateRequest) (*v1.Resource, error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.resource.v1.ResourceService.Create is not implemented"))
 }
@@ -388,6 +1065,46 @@ func (UnimplementedResourceServiceHandler) Apply(context.Context, *v1.ApplyReque
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.resource.v1.ResourceService.Apply is not implemented"))
 }
 
+func (UnimplementedResourceServiceHandler) ForceApply(context.Context, *v1.ApplyRequest) (*v1.ForceApplyResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.resource.v1.ResourceService.ForceApply is not implemented"))
+}
+
+func (UnimplementedResourceServiceHandler) ApplyManifest(context.Context, *v1.ApplyManifestRequest, *connect.ServerStream[v1.ApplyManifestEvent]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.resource.v1.ResourceService.ApplyManifest is not implemented"))
+}
+
+func (UnimplementedResourceServiceHandler) ApplyFromSource(context.Context, *v1.ApplyFromSourceRequest, *connect.ServerStream[v1.ApplyManifestEvent]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.resource.v1.ResourceService.ApplyFromSource is not implemented"))
+}
+
+func (UnimplementedResourceServiceHandler) ApplyWithPrune(context.Context, *v1.ApplyWithPruneRequest, *connect.ServerStream[v1.ApplyManifestEvent]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.resource.v1.ResourceService.ApplyWithPrune is not implemented"))
+}
+
+func (UnimplementedResourceServiceHandler) ApplyHelmChart(context.Context, *v1.ApplyHelmChartRequest, *connect.ServerStream[v1.ApplyManifestEvent]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.resource.v1.ResourceService.ApplyHelmChart is not implemented"))
+}
+
+func (UnimplementedResourceServiceHandler) ApplyBootstrap(context.Context, *v1.ApplyBootstrapRequest, *connect.ServerStream[v1.ApplyManifestEvent]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.resource.v1.ResourceService.ApplyBootstrap is not implemented"))
+}
+
+func (UnimplementedResourceServiceHandler) SetLabel(context.Context, *v1.SetLabelRequest) (*v1.Resource, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.resource.v1.ResourceService.SetLabel is not implemented"))
+}
+
+func (UnimplementedResourceServiceHandler) RemoveLabel(context.Context, *v1.RemoveLabelRequest) (*v1.Resource, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.resource.v1.ResourceService.RemoveLabel is not implemented"))
+}
+
+func (UnimplementedResourceServiceHandler) SetAnnotation(context.Context, *v1.SetAnnotationRequest) (*v1.Resource, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.resource.v1.ResourceService.SetAnnotation is not implemented"))
+}
+
+func (UnimplementedResourceServiceHandler) RemoveAnnotation(context.Context, *v1.RemoveAnnotationRequest) (*v1.Resource, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.resource.v1.ResourceService.RemoveAnnotation is not implemented"))
+}
+
 func (UnimplementedResourceServiceHandler) Delete(context.Context, *v1.DeleteRequest) (*emptypb.Empty, error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.resource.v1.ResourceService.Delete is not implemented"))
 }
@@ -395,3 +1112,15 @@ func (UnimplementedResourceServiceHandler) Delete(context.Context, *v1.DeleteReq
 func (UnimplementedResourceServiceHandler) Watch(context.Context, *v1.WatchRequest, *connect.ServerStream[v1.WatchEvent]) error {
 	return connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.resource.v1.ResourceService.Watch is not implemented"))
 }
+
+func (UnimplementedResourceServiceHandler) WatchAcrossClusters(context.Context, *v1.WatchAcrossClustersRequest, *connect.ServerStream[v1.ClusterWatchEvent]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.resource.v1.ResourceService.WatchAcrossClusters is not implemented"))
+}
+
+func (UnimplementedResourceServiceHandler) WaitForCondition(context.Context, *v1.WaitForConditionRequest) (*v1.Resource, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.resource.v1.ResourceService.WaitForCondition is not implemented"))
+}
+
+func (UnimplementedResourceServiceHandler) Proxy(context.Context, *v1.ProxyRequest) (*v1.ProxyResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.resource.v1.ResourceService.Proxy is not implemented"))
+}
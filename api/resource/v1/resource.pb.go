@@ -12,6 +12,7 @@ import (
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	unsafe "unsafe"
 )
@@ -23,6 +24,124 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type FieldDifference_Type int32
+
+const (
+	FieldDifference_TYPE_UNSPECIFIED FieldDifference_Type = 0
+	// The field is only set in cluster B.
+	FieldDifference_TYPE_ADDED FieldDifference_Type = 1
+	// The field is only set in cluster A.
+	FieldDifference_TYPE_REMOVED FieldDifference_Type = 2
+	// The field is set in both clusters, to different values.
+	FieldDifference_TYPE_CHANGED FieldDifference_Type = 3
+)
+
+// Enum value maps for FieldDifference_Type.
+var (
+	FieldDifference_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "TYPE_ADDED",
+		2: "TYPE_REMOVED",
+		3: "TYPE_CHANGED",
+	}
+	FieldDifference_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"TYPE_ADDED":       1,
+		"TYPE_REMOVED":     2,
+		"TYPE_CHANGED":     3,
+	}
+)
+
+func (x FieldDifference_Type) Enum() *FieldDifference_Type {
+	p := new(FieldDifference_Type)
+	*p = x
+	return p
+}
+
+func (x FieldDifference_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FieldDifference_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_resource_v1_resource_proto_enumTypes[0].Descriptor()
+}
+
+func (FieldDifference_Type) Type() protoreflect.EnumType {
+	return &file_api_resource_v1_resource_proto_enumTypes[0]
+}
+
+func (x FieldDifference_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Type defines the steps reported for an object.
+type ApplyManifestEvent_Type int32
+
+const (
+	// Unspecified event type (default zero value).
+	ApplyManifestEvent_TYPE_UNSPECIFIED ApplyManifestEvent_Type = 0
+	// The object was applied.
+	ApplyManifestEvent_TYPE_APPLIED ApplyManifestEvent_Type = 1
+	// The object could not be applied, a CustomResourceDefinition did not
+	// become established, or the object did not become ready. See error.
+	ApplyManifestEvent_TYPE_FAILED ApplyManifestEvent_Type = 2
+	// A CustomResourceDefinition was applied and is being waited on until
+	// it is established, or with a wait timeout, an applied object is
+	// being waited on until it is ready.
+	ApplyManifestEvent_TYPE_WAITING ApplyManifestEvent_Type = 3
+	// A CustomResourceDefinition is established.
+	ApplyManifestEvent_TYPE_ESTABLISHED ApplyManifestEvent_Type = 4
+	// An object no longer in the manifest was deleted by ApplyWithPrune.
+	// Its index is -1.
+	ApplyManifestEvent_TYPE_PRUNED ApplyManifestEvent_Type = 5
+	// An applied object waited on with a wait timeout is ready.
+	ApplyManifestEvent_TYPE_READY ApplyManifestEvent_Type = 6
+)
+
+// Enum value maps for ApplyManifestEvent_Type.
+var (
+	ApplyManifestEvent_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "TYPE_APPLIED",
+		2: "TYPE_FAILED",
+		3: "TYPE_WAITING",
+		4: "TYPE_ESTABLISHED",
+		5: "TYPE_PRUNED",
+		6: "TYPE_READY",
+	}
+	ApplyManifestEvent_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"TYPE_APPLIED":     1,
+		"TYPE_FAILED":      2,
+		"TYPE_WAITING":     3,
+		"TYPE_ESTABLISHED": 4,
+		"TYPE_PRUNED":      5,
+		"TYPE_READY":       6,
+	}
+)
+
+func (x ApplyManifestEvent_Type) Enum() *ApplyManifestEvent_Type {
+	p := new(ApplyManifestEvent_Type)
+	*p = x
+	return p
+}
+
+func (x ApplyManifestEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ApplyManifestEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_resource_v1_resource_proto_enumTypes[1].Descriptor()
+}
+
+func (ApplyManifestEvent_Type) Type() protoreflect.EnumType {
+	return &file_api_resource_v1_resource_proto_enumTypes[1]
+}
+
+func (x ApplyManifestEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
 // Type defines the possible types of events from Kubernetes watch.
 type WatchEvent_Type int32
 
@@ -72,11 +191,11 @@ func (x WatchEvent_Type) String() string {
 }
 
 func (WatchEvent_Type) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_resource_v1_resource_proto_enumTypes[0].Descriptor()
+	return file_api_resource_v1_resource_proto_enumTypes[2].Descriptor()
 }
 
 func (WatchEvent_Type) Type() protoreflect.EnumType {
-	return &file_api_resource_v1_resource_proto_enumTypes[0]
+	return &file_api_resource_v1_resource_proto_enumTypes[2]
 }
 
 func (x WatchEvent_Type) Number() protoreflect.EnumNumber {
@@ -406,17 +525,132 @@ func (b0 DiscoveryRequest_builder) Build() *DiscoveryRequest {
 	return m0
 }
 
+// DiscoveryWarning reports an API group-version that could not be discovered.
+type DiscoveryWarning struct {
+	state                   protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_GroupVersion *string                `protobuf:"bytes,1,opt,name=group_version,json=groupVersion"`
+	xxx_hidden_Message      *string                `protobuf:"bytes,2,opt,name=message"`
+	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
+	XXX_presence            [1]uint32
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *DiscoveryWarning) Reset() {
+	*x = DiscoveryWarning{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscoveryWarning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscoveryWarning) ProtoMessage() {}
+
+func (x *DiscoveryWarning) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DiscoveryWarning) GetGroupVersion() string {
+	if x != nil {
+		if x.xxx_hidden_GroupVersion != nil {
+			return *x.xxx_hidden_GroupVersion
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DiscoveryWarning) GetMessage() string {
+	if x != nil {
+		if x.xxx_hidden_Message != nil {
+			return *x.xxx_hidden_Message
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DiscoveryWarning) SetGroupVersion(v string) {
+	x.xxx_hidden_GroupVersion = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *DiscoveryWarning) SetMessage(v string) {
+	x.xxx_hidden_Message = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *DiscoveryWarning) HasGroupVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *DiscoveryWarning) HasMessage() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *DiscoveryWarning) ClearGroupVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_GroupVersion = nil
+}
+
+func (x *DiscoveryWarning) ClearMessage() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Message = nil
+}
+
+type DiscoveryWarning_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The group-version that failed (e.g., "metrics.k8s.io/v1beta1").
+	GroupVersion *string
+	// The discovery error returned for the group-version.
+	Message *string
+}
+
+func (b0 DiscoveryWarning_builder) Build() *DiscoveryWarning {
+	m0 := &DiscoveryWarning{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.GroupVersion != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_GroupVersion = b.GroupVersion
+	}
+	if b.Message != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Message = b.Message
+	}
+	return m0
+}
+
 // DiscoveryResponse contains the list of available API resources in the cluster.
 type DiscoveryResponse struct {
 	state                   protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_ApiResources *[]*APIResource        `protobuf:"bytes,1,rep,name=api_resources,json=apiResources"`
+	xxx_hidden_Warnings     *[]*DiscoveryWarning   `protobuf:"bytes,2,rep,name=warnings"`
 	unknownFields           protoimpl.UnknownFields
 	sizeCache               protoimpl.SizeCache
 }
 
 func (x *DiscoveryResponse) Reset() {
 	*x = DiscoveryResponse{}
-	mi := &file_api_resource_v1_resource_proto_msgTypes[2]
+	mi := &file_api_resource_v1_resource_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -428,7 +662,7 @@ func (x *DiscoveryResponse) String() string {
 func (*DiscoveryResponse) ProtoMessage() {}
 
 func (x *DiscoveryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_resource_v1_resource_proto_msgTypes[2]
+	mi := &file_api_resource_v1_resource_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -448,15 +682,32 @@ func (x *DiscoveryResponse) GetApiResources() []*APIResource {
 	return nil
 }
 
+func (x *DiscoveryResponse) GetWarnings() []*DiscoveryWarning {
+	if x != nil {
+		if x.xxx_hidden_Warnings != nil {
+			return *x.xxx_hidden_Warnings
+		}
+	}
+	return nil
+}
+
 func (x *DiscoveryResponse) SetApiResources(v []*APIResource) {
 	x.xxx_hidden_ApiResources = &v
 }
 
+func (x *DiscoveryResponse) SetWarnings(v []*DiscoveryWarning) {
+	x.xxx_hidden_Warnings = &v
+}
+
 type DiscoveryResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
 	// The list of available API resources in the cluster.
 	ApiResources []*APIResource
+	// Group-versions whose discovery failed, typically aggregated APIs whose
+	// backing service is unavailable. Their resources are missing from
+	// api_resources; all other groups are still listed.
+	Warnings []*DiscoveryWarning
 }
 
 func (b0 DiscoveryResponse_builder) Build() *DiscoveryResponse {
@@ -464,37 +715,35 @@ func (b0 DiscoveryResponse_builder) Build() *DiscoveryResponse {
 	b, x := &b0, m0
 	_, _ = b, x
 	x.xxx_hidden_ApiResources = &b.ApiResources
+	x.xxx_hidden_Warnings = &b.Warnings
 	return m0
 }
 
-// SchemaRequest defines the parameters to retrieve the schema of a specific GVK.
-type SchemaRequest struct {
+// CapabilitiesRequest defines the parameters for querying cluster capabilities.
+type CapabilitiesRequest struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_Cluster     *string                `protobuf:"bytes,1,opt,name=cluster"`
-	xxx_hidden_Group       *string                `protobuf:"bytes,2,opt,name=group"`
-	xxx_hidden_Version     *string                `protobuf:"bytes,3,opt,name=version"`
-	xxx_hidden_Kind        *string                `protobuf:"bytes,4,opt,name=kind"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
-func (x *SchemaRequest) Reset() {
-	*x = SchemaRequest{}
-	mi := &file_api_resource_v1_resource_proto_msgTypes[3]
+func (x *CapabilitiesRequest) Reset() {
+	*x = CapabilitiesRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SchemaRequest) String() string {
+func (x *CapabilitiesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SchemaRequest) ProtoMessage() {}
+func (*CapabilitiesRequest) ProtoMessage() {}
 
-func (x *SchemaRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_resource_v1_resource_proto_msgTypes[3]
+func (x *CapabilitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -505,7 +754,7 @@ func (x *SchemaRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *SchemaRequest) GetCluster() string {
+func (x *CapabilitiesRequest) GetCluster() string {
 	if x != nil {
 		if x.xxx_hidden_Cluster != nil {
 			return *x.xxx_hidden_Cluster
@@ -515,78 +764,427 @@ func (x *SchemaRequest) GetCluster() string {
 	return ""
 }
 
-func (x *SchemaRequest) GetGroup() string {
-	if x != nil {
-		if x.xxx_hidden_Group != nil {
-			return *x.xxx_hidden_Group
-		}
-		return ""
+func (x *CapabilitiesRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *CapabilitiesRequest) HasCluster() bool {
+	if x == nil {
+		return false
 	}
-	return ""
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *SchemaRequest) GetVersion() string {
+func (x *CapabilitiesRequest) ClearCluster() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Cluster = nil
+}
+
+type CapabilitiesRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The target Kubernetes cluster identifier.
+	Cluster *string
+}
+
+func (b0 CapabilitiesRequest_builder) Build() *CapabilitiesRequest {
+	m0 := &CapabilitiesRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Cluster != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Cluster = b.Cluster
+	}
+	return m0
+}
+
+// CapabilitiesResponse lists the version-gated features supported by the cluster.
+type CapabilitiesResponse struct {
+	state                                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_KubernetesVersion         *string                `protobuf:"bytes,1,opt,name=kubernetes_version,json=kubernetesVersion"`
+	xxx_hidden_ServerSideApply           bool                   `protobuf:"varint,2,opt,name=server_side_apply,json=serverSideApply"`
+	xxx_hidden_EphemeralContainers       bool                   `protobuf:"varint,3,opt,name=ephemeral_containers,json=ephemeralContainers"`
+	xxx_hidden_SidecarContainers         bool                   `protobuf:"varint,4,opt,name=sidecar_containers,json=sidecarContainers"`
+	xxx_hidden_ValidatingAdmissionPolicy bool                   `protobuf:"varint,5,opt,name=validating_admission_policy,json=validatingAdmissionPolicy"`
+	xxx_hidden_InPlacePodResize          bool                   `protobuf:"varint,6,opt,name=in_place_pod_resize,json=inPlacePodResize"`
+	xxx_hidden_WatchList                 bool                   `protobuf:"varint,7,opt,name=watch_list,json=watchList"`
+	XXX_raceDetectHookData               protoimpl.RaceDetectHookData
+	XXX_presence                         [1]uint32
+	unknownFields                        protoimpl.UnknownFields
+	sizeCache                            protoimpl.SizeCache
+}
+
+func (x *CapabilitiesResponse) Reset() {
+	*x = CapabilitiesResponse{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapabilitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapabilitiesResponse) ProtoMessage() {}
+
+func (x *CapabilitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[5]
 	if x != nil {
-		if x.xxx_hidden_Version != nil {
-			return *x.xxx_hidden_Version
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		return ""
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *SchemaRequest) GetKind() string {
+func (x *CapabilitiesResponse) GetKubernetesVersion() string {
 	if x != nil {
-		if x.xxx_hidden_Kind != nil {
-			return *x.xxx_hidden_Kind
+		if x.xxx_hidden_KubernetesVersion != nil {
+			return *x.xxx_hidden_KubernetesVersion
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *SchemaRequest) SetCluster(v string) {
-	x.xxx_hidden_Cluster = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+func (x *CapabilitiesResponse) GetServerSideApply() bool {
+	if x != nil {
+		return x.xxx_hidden_ServerSideApply
+	}
+	return false
 }
 
-func (x *SchemaRequest) SetGroup(v string) {
-	x.xxx_hidden_Group = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+func (x *CapabilitiesResponse) GetEphemeralContainers() bool {
+	if x != nil {
+		return x.xxx_hidden_EphemeralContainers
+	}
+	return false
 }
 
-func (x *SchemaRequest) SetVersion(v string) {
-	x.xxx_hidden_Version = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+func (x *CapabilitiesResponse) GetSidecarContainers() bool {
+	if x != nil {
+		return x.xxx_hidden_SidecarContainers
+	}
+	return false
 }
 
-func (x *SchemaRequest) SetKind(v string) {
-	x.xxx_hidden_Kind = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+func (x *CapabilitiesResponse) GetValidatingAdmissionPolicy() bool {
+	if x != nil {
+		return x.xxx_hidden_ValidatingAdmissionPolicy
+	}
+	return false
 }
 
-func (x *SchemaRequest) HasCluster() bool {
-	if x == nil {
-		return false
+func (x *CapabilitiesResponse) GetInPlacePodResize() bool {
+	if x != nil {
+		return x.xxx_hidden_InPlacePodResize
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+	return false
 }
 
-func (x *SchemaRequest) HasGroup() bool {
-	if x == nil {
-		return false
+func (x *CapabilitiesResponse) GetWatchList() bool {
+	if x != nil {
+		return x.xxx_hidden_WatchList
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+	return false
 }
 
-func (x *SchemaRequest) HasVersion() bool {
-	if x == nil {
-		return false
-	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+func (x *CapabilitiesResponse) SetKubernetesVersion(v string) {
+	x.xxx_hidden_KubernetesVersion = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 7)
 }
 
-func (x *SchemaRequest) HasKind() bool {
+func (x *CapabilitiesResponse) SetServerSideApply(v bool) {
+	x.xxx_hidden_ServerSideApply = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
+}
+
+func (x *CapabilitiesResponse) SetEphemeralContainers(v bool) {
+	x.xxx_hidden_EphemeralContainers = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 7)
+}
+
+func (x *CapabilitiesResponse) SetSidecarContainers(v bool) {
+	x.xxx_hidden_SidecarContainers = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 7)
+}
+
+func (x *CapabilitiesResponse) SetValidatingAdmissionPolicy(v bool) {
+	x.xxx_hidden_ValidatingAdmissionPolicy = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 7)
+}
+
+func (x *CapabilitiesResponse) SetInPlacePodResize(v bool) {
+	x.xxx_hidden_InPlacePodResize = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 7)
+}
+
+func (x *CapabilitiesResponse) SetWatchList(v bool) {
+	x.xxx_hidden_WatchList = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 7)
+}
+
+func (x *CapabilitiesResponse) HasKubernetesVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *CapabilitiesResponse) HasServerSideApply() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *CapabilitiesResponse) HasEphemeralContainers() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *CapabilitiesResponse) HasSidecarContainers() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *CapabilitiesResponse) HasValidatingAdmissionPolicy() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *CapabilitiesResponse) HasInPlacePodResize() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *CapabilitiesResponse) HasWatchList() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *CapabilitiesResponse) ClearKubernetesVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_KubernetesVersion = nil
+}
+
+func (x *CapabilitiesResponse) ClearServerSideApply() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_ServerSideApply = false
+}
+
+func (x *CapabilitiesResponse) ClearEphemeralContainers() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_EphemeralContainers = false
+}
+
+func (x *CapabilitiesResponse) ClearSidecarContainers() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_SidecarContainers = false
+}
+
+func (x *CapabilitiesResponse) ClearValidatingAdmissionPolicy() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_ValidatingAdmissionPolicy = false
+}
+
+func (x *CapabilitiesResponse) ClearInPlacePodResize() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_InPlacePodResize = false
+}
+
+func (x *CapabilitiesResponse) ClearWatchList() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_WatchList = false
+}
+
+type CapabilitiesResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The Kubernetes version reported by the cluster (e.g., "v1.34.1").
+	KubernetesVersion *string
+	// Server-side apply is available (Kubernetes >= 1.22).
+	ServerSideApply *bool
+	// Ephemeral debug containers are available (Kubernetes >= 1.25).
+	EphemeralContainers *bool
+	// Native sidecar containers are available (Kubernetes >= 1.29).
+	SidecarContainers *bool
+	// ValidatingAdmissionPolicy is available (Kubernetes >= 1.30).
+	ValidatingAdmissionPolicy *bool
+	// In-place pod resize is available (Kubernetes >= 1.33).
+	InPlacePodResize *bool
+	// WatchList streaming of initial watch events is available (Kubernetes >= 1.34).
+	WatchList *bool
+}
+
+func (b0 CapabilitiesResponse_builder) Build() *CapabilitiesResponse {
+	m0 := &CapabilitiesResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.KubernetesVersion != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 7)
+		x.xxx_hidden_KubernetesVersion = b.KubernetesVersion
+	}
+	if b.ServerSideApply != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 7)
+		x.xxx_hidden_ServerSideApply = *b.ServerSideApply
+	}
+	if b.EphemeralContainers != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 7)
+		x.xxx_hidden_EphemeralContainers = *b.EphemeralContainers
+	}
+	if b.SidecarContainers != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 7)
+		x.xxx_hidden_SidecarContainers = *b.SidecarContainers
+	}
+	if b.ValidatingAdmissionPolicy != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 7)
+		x.xxx_hidden_ValidatingAdmissionPolicy = *b.ValidatingAdmissionPolicy
+	}
+	if b.InPlacePodResize != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 7)
+		x.xxx_hidden_InPlacePodResize = *b.InPlacePodResize
+	}
+	if b.WatchList != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 7)
+		x.xxx_hidden_WatchList = *b.WatchList
+	}
+	return m0
+}
+
+// SchemaRequest defines the parameters to retrieve the schema of a specific GVK.
+type SchemaRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster     *string                `protobuf:"bytes,1,opt,name=cluster"`
+	xxx_hidden_Group       *string                `protobuf:"bytes,2,opt,name=group"`
+	xxx_hidden_Version     *string                `protobuf:"bytes,3,opt,name=version"`
+	xxx_hidden_Kind        *string                `protobuf:"bytes,4,opt,name=kind"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SchemaRequest) Reset() {
+	*x = SchemaRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchemaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemaRequest) ProtoMessage() {}
+
+func (x *SchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SchemaRequest) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SchemaRequest) GetGroup() string {
+	if x != nil {
+		if x.xxx_hidden_Group != nil {
+			return *x.xxx_hidden_Group
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SchemaRequest) GetVersion() string {
+	if x != nil {
+		if x.xxx_hidden_Version != nil {
+			return *x.xxx_hidden_Version
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SchemaRequest) GetKind() string {
+	if x != nil {
+		if x.xxx_hidden_Kind != nil {
+			return *x.xxx_hidden_Kind
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SchemaRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *SchemaRequest) SetGroup(v string) {
+	x.xxx_hidden_Group = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *SchemaRequest) SetVersion(v string) {
+	x.xxx_hidden_Version = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *SchemaRequest) SetKind(v string) {
+	x.xxx_hidden_Kind = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+}
+
+func (x *SchemaRequest) HasCluster() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *SchemaRequest) HasGroup() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *SchemaRequest) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *SchemaRequest) HasKind() bool {
 	if x == nil {
 		return false
 	}
@@ -659,7 +1257,7 @@ type Resource struct {
 
 func (x *Resource) Reset() {
 	*x = Resource{}
-	mi := &file_api_resource_v1_resource_proto_msgTypes[4]
+	mi := &file_api_resource_v1_resource_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -671,7 +1269,7 @@ func (x *Resource) String() string {
 func (*Resource) ProtoMessage() {}
 
 func (x *Resource) ProtoReflect() protoreflect.Message {
-	mi := &file_api_resource_v1_resource_proto_msgTypes[4]
+	mi := &file_api_resource_v1_resource_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -732,6 +1330,7 @@ type ListRequest struct {
 	xxx_hidden_FieldSelector *string                `protobuf:"bytes,7,opt,name=field_selector,json=fieldSelector"`
 	xxx_hidden_Limit         int64                  `protobuf:"varint,8,opt,name=limit"`
 	xxx_hidden_Continue      *string                `protobuf:"bytes,9,opt,name=continue"`
+	xxx_hidden_AllNamespaces bool                   `protobuf:"varint,10,opt,name=all_namespaces,json=allNamespaces"`
 	XXX_raceDetectHookData   protoimpl.RaceDetectHookData
 	XXX_presence             [1]uint32
 	unknownFields            protoimpl.UnknownFields
@@ -740,7 +1339,7 @@ type ListRequest struct {
 
 func (x *ListRequest) Reset() {
 	*x = ListRequest{}
-	mi := &file_api_resource_v1_resource_proto_msgTypes[5]
+	mi := &file_api_resource_v1_resource_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -752,7 +1351,7 @@ func (x *ListRequest) String() string {
 func (*ListRequest) ProtoMessage() {}
 
 func (x *ListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_resource_v1_resource_proto_msgTypes[5]
+	mi := &file_api_resource_v1_resource_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -850,49 +1449,61 @@ func (x *ListRequest) GetContinue() string {
 	return ""
 }
 
+func (x *ListRequest) GetAllNamespaces() bool {
+	if x != nil {
+		return x.xxx_hidden_AllNamespaces
+	}
+	return false
+}
+
 func (x *ListRequest) SetCluster(v string) {
 	x.xxx_hidden_Cluster = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 9)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 10)
 }
 
 func (x *ListRequest) SetGroup(v string) {
 	x.xxx_hidden_Group = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 9)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 10)
 }
 
 func (x *ListRequest) SetVersion(v string) {
 	x.xxx_hidden_Version = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 9)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 10)
 }
 
 func (x *ListRequest) SetResource(v string) {
 	x.xxx_hidden_Resource = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 9)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 10)
 }
 
 func (x *ListRequest) SetNamespace(v string) {
 	x.xxx_hidden_Namespace = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 9)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 10)
 }
 
 func (x *ListRequest) SetLabelSelector(v string) {
 	x.xxx_hidden_LabelSelector = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 9)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 10)
 }
 
 func (x *ListRequest) SetFieldSelector(v string) {
 	x.xxx_hidden_FieldSelector = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 9)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 10)
 }
 
 func (x *ListRequest) SetLimit(v int64) {
 	x.xxx_hidden_Limit = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 9)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 10)
 }
 
 func (x *ListRequest) SetContinue(v string) {
 	x.xxx_hidden_Continue = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 9)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 10)
+}
+
+func (x *ListRequest) SetAllNamespaces(v bool) {
+	x.xxx_hidden_AllNamespaces = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 10)
 }
 
 func (x *ListRequest) HasCluster() bool {
@@ -958,6 +1569,13 @@ func (x *ListRequest) HasContinue() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
 }
 
+func (x *ListRequest) HasAllNamespaces() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
+}
+
 func (x *ListRequest) ClearCluster() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Cluster = nil
@@ -1003,6 +1621,11 @@ func (x *ListRequest) ClearContinue() {
 	x.xxx_hidden_Continue = nil
 }
 
+func (x *ListRequest) ClearAllNamespaces() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
+	x.xxx_hidden_AllNamespaces = false
+}
+
 type ListRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -1014,7 +1637,8 @@ type ListRequest_builder struct {
 	Version *string
 	// Kubernetes API Resource name in plural (e.g., "pods", "deployments").
 	Resource *string
-	// The namespace to query. If empty, the request may target all namespaces depending on cluster permissions.
+	// The namespace to query. If empty, namespaced resources are listed in
+	// the server's default namespace unless all_namespaces is set.
 	Namespace *string
 	// A selector to restrict the list of returned objects by their labels.
 	LabelSelector *string
@@ -1024,6 +1648,9 @@ type ListRequest_builder struct {
 	Limit *int64
 	// The continue token for pagination, retrieved from a previous ListResponse.
 	Continue *string
+	// List a namespaced resource across all namespaces. Requires namespace
+	// to be empty. Ignored for cluster-scoped resources.
+	AllNamespaces *bool
 }
 
 func (b0 ListRequest_builder) Build() *ListRequest {
@@ -1031,41 +1658,45 @@ func (b0 ListRequest_builder) Build() *ListRequest {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Cluster != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 9)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 10)
 		x.xxx_hidden_Cluster = b.Cluster
 	}
 	if b.Group != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 9)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 10)
 		x.xxx_hidden_Group = b.Group
 	}
 	if b.Version != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 9)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 10)
 		x.xxx_hidden_Version = b.Version
 	}
 	if b.Resource != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 9)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 10)
 		x.xxx_hidden_Resource = b.Resource
 	}
 	if b.Namespace != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 9)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 10)
 		x.xxx_hidden_Namespace = b.Namespace
 	}
 	if b.LabelSelector != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 9)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 10)
 		x.xxx_hidden_LabelSelector = b.LabelSelector
 	}
 	if b.FieldSelector != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 9)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 10)
 		x.xxx_hidden_FieldSelector = b.FieldSelector
 	}
 	if b.Limit != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 9)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 10)
 		x.xxx_hidden_Limit = *b.Limit
 	}
 	if b.Continue != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 9)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 10)
 		x.xxx_hidden_Continue = b.Continue
 	}
+	if b.AllNamespaces != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 10)
+		x.xxx_hidden_AllNamespaces = *b.AllNamespaces
+	}
 	return m0
 }
 
@@ -1084,7 +1715,7 @@ type ListResponse struct {
 
 func (x *ListResponse) Reset() {
 	*x = ListResponse{}
-	mi := &file_api_resource_v1_resource_proto_msgTypes[6]
+	mi := &file_api_resource_v1_resource_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1096,7 +1727,7 @@ func (x *ListResponse) String() string {
 func (*ListResponse) ProtoMessage() {}
 
 func (x *ListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_resource_v1_resource_proto_msgTypes[6]
+	mi := &file_api_resource_v1_resource_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1248,7 +1879,7 @@ type GetRequest struct {
 
 func (x *GetRequest) Reset() {
 	*x = GetRequest{}
-	mi := &file_api_resource_v1_resource_proto_msgTypes[7]
+	mi := &file_api_resource_v1_resource_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1260,7 +1891,7 @@ func (x *GetRequest) String() string {
 func (*GetRequest) ProtoMessage() {}
 
 func (x *GetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_resource_v1_resource_proto_msgTypes[7]
+	mi := &file_api_resource_v1_resource_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1491,6 +2122,7 @@ type DescribeRequest struct {
 	xxx_hidden_Resource    *string                `protobuf:"bytes,4,opt,name=resource"`
 	xxx_hidden_Namespace   *string                `protobuf:"bytes,5,opt,name=namespace"`
 	xxx_hidden_Name        *string                `protobuf:"bytes,6,opt,name=name"`
+	xxx_hidden_Since       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=since"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
@@ -1499,7 +2131,7 @@ type DescribeRequest struct {
 
 func (x *DescribeRequest) Reset() {
 	*x = DescribeRequest{}
-	mi := &file_api_resource_v1_resource_proto_msgTypes[8]
+	mi := &file_api_resource_v1_resource_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1511,7 +2143,7 @@ func (x *DescribeRequest) String() string {
 func (*DescribeRequest) ProtoMessage() {}
 
 func (x *DescribeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_resource_v1_resource_proto_msgTypes[8]
+	mi := &file_api_resource_v1_resource_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1582,34 +2214,45 @@ func (x *DescribeRequest) GetName() string {
 	return ""
 }
 
+func (x *DescribeRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_Since
+	}
+	return nil
+}
+
 func (x *DescribeRequest) SetCluster(v string) {
 	x.xxx_hidden_Cluster = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 6)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 7)
 }
 
 func (x *DescribeRequest) SetGroup(v string) {
 	x.xxx_hidden_Group = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 6)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
 }
 
 func (x *DescribeRequest) SetVersion(v string) {
 	x.xxx_hidden_Version = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 6)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 7)
 }
 
 func (x *DescribeRequest) SetResource(v string) {
 	x.xxx_hidden_Resource = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 6)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 7)
 }
 
 func (x *DescribeRequest) SetNamespace(v string) {
 	x.xxx_hidden_Namespace = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 7)
 }
 
 func (x *DescribeRequest) SetName(v string) {
 	x.xxx_hidden_Name = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 6)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 7)
+}
+
+func (x *DescribeRequest) SetSince(v *timestamppb.Timestamp) {
+	x.xxx_hidden_Since = v
 }
 
 func (x *DescribeRequest) HasCluster() bool {
@@ -1654,6 +2297,13 @@ func (x *DescribeRequest) HasName() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
 }
 
+func (x *DescribeRequest) HasSince() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Since != nil
+}
+
 func (x *DescribeRequest) ClearCluster() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Cluster = nil
@@ -1684,6 +2334,10 @@ func (x *DescribeRequest) ClearName() {
 	x.xxx_hidden_Name = nil
 }
 
+func (x *DescribeRequest) ClearSince() {
+	x.xxx_hidden_Since = nil
+}
+
 type DescribeRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -1699,6 +2353,11 @@ type DescribeRequest_builder struct {
 	Namespace *string
 	// The name of the resource.
 	Name *string
+	// Only return events last observed at or after this time, so that a
+	// live view can refresh incrementally. Events observed exactly at
+	// this time are included; de-duplicate them by metadata.uid. Unset
+	// returns all events.
+	Since *timestamppb.Timestamp
 }
 
 func (b0 DescribeRequest_builder) Build() *DescribeRequest {
@@ -1706,29 +2365,30 @@ func (b0 DescribeRequest_builder) Build() *DescribeRequest {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Cluster != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 6)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 7)
 		x.xxx_hidden_Cluster = b.Cluster
 	}
 	if b.Group != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 6)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 7)
 		x.xxx_hidden_Group = b.Group
 	}
 	if b.Version != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 6)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 7)
 		x.xxx_hidden_Version = b.Version
 	}
 	if b.Resource != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 6)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 7)
 		x.xxx_hidden_Resource = b.Resource
 	}
 	if b.Namespace != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 6)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 7)
 		x.xxx_hidden_Namespace = b.Namespace
 	}
 	if b.Name != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 6)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 7)
 		x.xxx_hidden_Name = b.Name
 	}
+	x.xxx_hidden_Since = b.Since
 	return m0
 }
 
@@ -1743,7 +2403,7 @@ type DescribeResponse struct {
 
 func (x *DescribeResponse) Reset() {
 	*x = DescribeResponse{}
-	mi := &file_api_resource_v1_resource_proto_msgTypes[9]
+	mi := &file_api_resource_v1_resource_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1755,7 +2415,7 @@ func (x *DescribeResponse) String() string {
 func (*DescribeResponse) ProtoMessage() {}
 
 func (x *DescribeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_resource_v1_resource_proto_msgTypes[9]
+	mi := &file_api_resource_v1_resource_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1806,7 +2466,9 @@ type DescribeResponse_builder struct {
 
 	// The full resource object.
 	Resource *Resource
-	// Kubernetes events related to this resource, filtered by involvedObject.uid.
+	// Kubernetes events related to this resource: those matching its
+	// involvedObject.uid in its namespace or, for a cluster-scoped resource,
+	// those about its kind and name in any namespace.
 	Events []*Resource
 }
 
@@ -1819,36 +2481,37 @@ func (b0 DescribeResponse_builder) Build() *DescribeResponse {
 	return m0
 }
 
-// CreateRequest defines the parameters for creating a new object.
-type CreateRequest struct {
+// FieldOwnershipRequest identifies the resource whose field ownership is
+// reported.
+type FieldOwnershipRequest struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_Cluster     *string                `protobuf:"bytes,1,opt,name=cluster"`
 	xxx_hidden_Group       *string                `protobuf:"bytes,2,opt,name=group"`
 	xxx_hidden_Version     *string                `protobuf:"bytes,3,opt,name=version"`
 	xxx_hidden_Resource    *string                `protobuf:"bytes,4,opt,name=resource"`
 	xxx_hidden_Namespace   *string                `protobuf:"bytes,5,opt,name=namespace"`
-	xxx_hidden_Manifest    []byte                 `protobuf:"bytes,6,opt,name=manifest"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,6,opt,name=name"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
-func (x *CreateRequest) Reset() {
-	*x = CreateRequest{}
-	mi := &file_api_resource_v1_resource_proto_msgTypes[10]
+func (x *FieldOwnershipRequest) Reset() {
+	*x = FieldOwnershipRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateRequest) String() string {
+func (x *FieldOwnershipRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateRequest) ProtoMessage() {}
+func (*FieldOwnershipRequest) ProtoMessage() {}
 
-func (x *CreateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_resource_v1_resource_proto_msgTypes[10]
+func (x *FieldOwnershipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1859,7 +2522,7 @@ func (x *CreateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *CreateRequest) GetCluster() string {
+func (x *FieldOwnershipRequest) GetCluster() string {
 	if x != nil {
 		if x.xxx_hidden_Cluster != nil {
 			return *x.xxx_hidden_Cluster
@@ -1869,7 +2532,7 @@ func (x *CreateRequest) GetCluster() string {
 	return ""
 }
 
-func (x *CreateRequest) GetGroup() string {
+func (x *FieldOwnershipRequest) GetGroup() string {
 	if x != nil {
 		if x.xxx_hidden_Group != nil {
 			return *x.xxx_hidden_Group
@@ -1879,7 +2542,7 @@ func (x *CreateRequest) GetGroup() string {
 	return ""
 }
 
-func (x *CreateRequest) GetVersion() string {
+func (x *FieldOwnershipRequest) GetVersion() string {
 	if x != nil {
 		if x.xxx_hidden_Version != nil {
 			return *x.xxx_hidden_Version
@@ -1889,7 +2552,7 @@ func (x *CreateRequest) GetVersion() string {
 	return ""
 }
 
-func (x *CreateRequest) GetResource() string {
+func (x *FieldOwnershipRequest) GetResource() string {
 	if x != nil {
 		if x.xxx_hidden_Resource != nil {
 			return *x.xxx_hidden_Resource
@@ -1899,7 +2562,7 @@ func (x *CreateRequest) GetResource() string {
 	return ""
 }
 
-func (x *CreateRequest) GetNamespace() string {
+func (x *FieldOwnershipRequest) GetNamespace() string {
 	if x != nil {
 		if x.xxx_hidden_Namespace != nil {
 			return *x.xxx_hidden_Namespace
@@ -1909,119 +2572,119 @@ func (x *CreateRequest) GetNamespace() string {
 	return ""
 }
 
-func (x *CreateRequest) GetManifest() []byte {
+func (x *FieldOwnershipRequest) GetName() string {
 	if x != nil {
-		return x.xxx_hidden_Manifest
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
 	}
-	return nil
+	return ""
 }
 
-func (x *CreateRequest) SetCluster(v string) {
+func (x *FieldOwnershipRequest) SetCluster(v string) {
 	x.xxx_hidden_Cluster = &v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 6)
 }
 
-func (x *CreateRequest) SetGroup(v string) {
+func (x *FieldOwnershipRequest) SetGroup(v string) {
 	x.xxx_hidden_Group = &v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 6)
 }
 
-func (x *CreateRequest) SetVersion(v string) {
+func (x *FieldOwnershipRequest) SetVersion(v string) {
 	x.xxx_hidden_Version = &v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 6)
 }
 
-func (x *CreateRequest) SetResource(v string) {
+func (x *FieldOwnershipRequest) SetResource(v string) {
 	x.xxx_hidden_Resource = &v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 6)
 }
 
-func (x *CreateRequest) SetNamespace(v string) {
+func (x *FieldOwnershipRequest) SetNamespace(v string) {
 	x.xxx_hidden_Namespace = &v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
 }
 
-func (x *CreateRequest) SetManifest(v []byte) {
-	if v == nil {
-		v = []byte{}
-	}
-	x.xxx_hidden_Manifest = v
+func (x *FieldOwnershipRequest) SetName(v string) {
+	x.xxx_hidden_Name = &v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 6)
 }
 
-func (x *CreateRequest) HasCluster() bool {
+func (x *FieldOwnershipRequest) HasCluster() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *CreateRequest) HasGroup() bool {
+func (x *FieldOwnershipRequest) HasGroup() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *CreateRequest) HasVersion() bool {
+func (x *FieldOwnershipRequest) HasVersion() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
 }
 
-func (x *CreateRequest) HasResource() bool {
+func (x *FieldOwnershipRequest) HasResource() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
 }
 
-func (x *CreateRequest) HasNamespace() bool {
+func (x *FieldOwnershipRequest) HasNamespace() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
 }
 
-func (x *CreateRequest) HasManifest() bool {
+func (x *FieldOwnershipRequest) HasName() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
 }
 
-func (x *CreateRequest) ClearCluster() {
+func (x *FieldOwnershipRequest) ClearCluster() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Cluster = nil
 }
 
-func (x *CreateRequest) ClearGroup() {
+func (x *FieldOwnershipRequest) ClearGroup() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
 	x.xxx_hidden_Group = nil
 }
 
-func (x *CreateRequest) ClearVersion() {
+func (x *FieldOwnershipRequest) ClearVersion() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
 	x.xxx_hidden_Version = nil
 }
 
-func (x *CreateRequest) ClearResource() {
+func (x *FieldOwnershipRequest) ClearResource() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
 	x.xxx_hidden_Resource = nil
 }
 
-func (x *CreateRequest) ClearNamespace() {
+func (x *FieldOwnershipRequest) ClearNamespace() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
 	x.xxx_hidden_Namespace = nil
 }
 
-func (x *CreateRequest) ClearManifest() {
+func (x *FieldOwnershipRequest) ClearName() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
-	x.xxx_hidden_Manifest = nil
+	x.xxx_hidden_Name = nil
 }
 
-type CreateRequest_builder struct {
+type FieldOwnershipRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
 	// The target Kubernetes cluster identifier.
@@ -2034,12 +2697,12 @@ type CreateRequest_builder struct {
 	Resource *string
 	// The namespace of the resource.
 	Namespace *string
-	// The full manifest of the object to be created in YAML format.
-	Manifest []byte
+	// The name of the resource.
+	Name *string
 }
 
-func (b0 CreateRequest_builder) Build() *CreateRequest {
-	m0 := &CreateRequest{}
+func (b0 FieldOwnershipRequest_builder) Build() *FieldOwnershipRequest {
+	m0 := &FieldOwnershipRequest{}
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Cluster != nil {
@@ -2062,46 +2725,43 @@ func (b0 CreateRequest_builder) Build() *CreateRequest {
 		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 6)
 		x.xxx_hidden_Namespace = b.Namespace
 	}
-	if b.Manifest != nil {
+	if b.Name != nil {
 		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 6)
-		x.xxx_hidden_Manifest = b.Manifest
+		x.xxx_hidden_Name = b.Name
 	}
 	return m0
 }
 
-// ApplyRequest defines the parameters for Server-Side Apply (SSA).
-type ApplyRequest struct {
-	state                   protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Cluster      *string                `protobuf:"bytes,1,opt,name=cluster"`
-	xxx_hidden_Group        *string                `protobuf:"bytes,2,opt,name=group"`
-	xxx_hidden_Version      *string                `protobuf:"bytes,3,opt,name=version"`
-	xxx_hidden_Resource     *string                `protobuf:"bytes,4,opt,name=resource"`
-	xxx_hidden_Namespace    *string                `protobuf:"bytes,5,opt,name=namespace"`
-	xxx_hidden_Name         *string                `protobuf:"bytes,6,opt,name=name"`
-	xxx_hidden_Manifest     []byte                 `protobuf:"bytes,7,opt,name=manifest"`
-	xxx_hidden_Force        bool                   `protobuf:"varint,8,opt,name=force"`
-	xxx_hidden_FieldManager *string                `protobuf:"bytes,9,opt,name=field_manager,json=fieldManager"`
-	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
-	XXX_presence            [1]uint32
-	unknownFields           protoimpl.UnknownFields
-	sizeCache               protoimpl.SizeCache
+// FieldOwner is a field manager owning a field, as recorded by one entry of
+// metadata.managedFields.
+type FieldOwner struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Manager     *string                `protobuf:"bytes,1,opt,name=manager"`
+	xxx_hidden_Operation   *string                `protobuf:"bytes,2,opt,name=operation"`
+	xxx_hidden_Time        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=time"`
+	xxx_hidden_Subresource *string                `protobuf:"bytes,4,opt,name=subresource"`
+	xxx_hidden_ApiVersion  *string                `protobuf:"bytes,5,opt,name=api_version,json=apiVersion"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
-func (x *ApplyRequest) Reset() {
-	*x = ApplyRequest{}
-	mi := &file_api_resource_v1_resource_proto_msgTypes[11]
+func (x *FieldOwner) Reset() {
+	*x = FieldOwner{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ApplyRequest) String() string {
+func (x *FieldOwner) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ApplyRequest) ProtoMessage() {}
+func (*FieldOwner) ProtoMessage() {}
 
-func (x *ApplyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_resource_v1_resource_proto_msgTypes[11]
+func (x *FieldOwner) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2112,343 +2772,368 @@ func (x *ApplyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *ApplyRequest) GetCluster() string {
-	if x != nil {
-		if x.xxx_hidden_Cluster != nil {
-			return *x.xxx_hidden_Cluster
-		}
-		return ""
-	}
-	return ""
-}
-
-func (x *ApplyRequest) GetGroup() string {
-	if x != nil {
-		if x.xxx_hidden_Group != nil {
-			return *x.xxx_hidden_Group
-		}
-		return ""
-	}
-	return ""
-}
-
-func (x *ApplyRequest) GetVersion() string {
+func (x *FieldOwner) GetManager() string {
 	if x != nil {
-		if x.xxx_hidden_Version != nil {
-			return *x.xxx_hidden_Version
+		if x.xxx_hidden_Manager != nil {
+			return *x.xxx_hidden_Manager
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *ApplyRequest) GetResource() string {
+func (x *FieldOwner) GetOperation() string {
 	if x != nil {
-		if x.xxx_hidden_Resource != nil {
-			return *x.xxx_hidden_Resource
+		if x.xxx_hidden_Operation != nil {
+			return *x.xxx_hidden_Operation
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *ApplyRequest) GetNamespace() string {
+func (x *FieldOwner) GetTime() *timestamppb.Timestamp {
 	if x != nil {
-		if x.xxx_hidden_Namespace != nil {
-			return *x.xxx_hidden_Namespace
-		}
-		return ""
+		return x.xxx_hidden_Time
 	}
-	return ""
+	return nil
 }
 
-func (x *ApplyRequest) GetName() string {
+func (x *FieldOwner) GetSubresource() string {
 	if x != nil {
-		if x.xxx_hidden_Name != nil {
-			return *x.xxx_hidden_Name
+		if x.xxx_hidden_Subresource != nil {
+			return *x.xxx_hidden_Subresource
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *ApplyRequest) GetManifest() []byte {
-	if x != nil {
-		return x.xxx_hidden_Manifest
-	}
-	return nil
-}
-
-func (x *ApplyRequest) GetForce() bool {
-	if x != nil {
-		return x.xxx_hidden_Force
-	}
-	return false
-}
-
-func (x *ApplyRequest) GetFieldManager() string {
+func (x *FieldOwner) GetApiVersion() string {
 	if x != nil {
-		if x.xxx_hidden_FieldManager != nil {
-			return *x.xxx_hidden_FieldManager
+		if x.xxx_hidden_ApiVersion != nil {
+			return *x.xxx_hidden_ApiVersion
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *ApplyRequest) SetCluster(v string) {
-	x.xxx_hidden_Cluster = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 9)
-}
-
-func (x *ApplyRequest) SetGroup(v string) {
-	x.xxx_hidden_Group = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 9)
-}
-
-func (x *ApplyRequest) SetVersion(v string) {
-	x.xxx_hidden_Version = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 9)
-}
-
-func (x *ApplyRequest) SetResource(v string) {
-	x.xxx_hidden_Resource = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 9)
-}
-
-func (x *ApplyRequest) SetNamespace(v string) {
-	x.xxx_hidden_Namespace = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 9)
+func (x *FieldOwner) SetManager(v string) {
+	x.xxx_hidden_Manager = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
 }
 
-func (x *ApplyRequest) SetName(v string) {
-	x.xxx_hidden_Name = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 9)
+func (x *FieldOwner) SetOperation(v string) {
+	x.xxx_hidden_Operation = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 5)
 }
 
-func (x *ApplyRequest) SetManifest(v []byte) {
-	if v == nil {
-		v = []byte{}
-	}
-	x.xxx_hidden_Manifest = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 9)
+func (x *FieldOwner) SetTime(v *timestamppb.Timestamp) {
+	x.xxx_hidden_Time = v
 }
 
-func (x *ApplyRequest) SetForce(v bool) {
-	x.xxx_hidden_Force = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 9)
+func (x *FieldOwner) SetSubresource(v string) {
+	x.xxx_hidden_Subresource = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 5)
 }
 
-func (x *ApplyRequest) SetFieldManager(v string) {
-	x.xxx_hidden_FieldManager = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 9)
+func (x *FieldOwner) SetApiVersion(v string) {
+	x.xxx_hidden_ApiVersion = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 5)
 }
 
-func (x *ApplyRequest) HasCluster() bool {
+func (x *FieldOwner) HasManager() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *ApplyRequest) HasGroup() bool {
+func (x *FieldOwner) HasOperation() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *ApplyRequest) HasVersion() bool {
+func (x *FieldOwner) HasTime() bool {
 	if x == nil {
 		return false
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+	return x.xxx_hidden_Time != nil
 }
 
-func (x *ApplyRequest) HasResource() bool {
+func (x *FieldOwner) HasSubresource() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
 }
 
-func (x *ApplyRequest) HasNamespace() bool {
+func (x *FieldOwner) HasApiVersion() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
 }
 
-func (x *ApplyRequest) HasName() bool {
-	if x == nil {
-		return false
-	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+func (x *FieldOwner) ClearManager() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Manager = nil
 }
 
-func (x *ApplyRequest) HasManifest() bool {
-	if x == nil {
-		return false
-	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+func (x *FieldOwner) ClearOperation() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Operation = nil
 }
 
-func (x *ApplyRequest) HasForce() bool {
-	if x == nil {
-		return false
-	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+func (x *FieldOwner) ClearTime() {
+	x.xxx_hidden_Time = nil
 }
 
-func (x *ApplyRequest) HasFieldManager() bool {
-	if x == nil {
-		return false
+func (x *FieldOwner) ClearSubresource() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Subresource = nil
+}
+
+func (x *FieldOwner) ClearApiVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_ApiVersion = nil
+}
+
+type FieldOwner_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The field manager, e.g. "kubectl-client-side-apply".
+	Manager *string
+	// How the manager last changed its fields: "Apply" for server-side apply
+	// or "Update" for any other write.
+	Operation *string
+	// When the manager last changed its fields. Unset if not recorded.
+	Time *timestamppb.Timestamp
+	// The subresource the manager wrote through, e.g. "status". Empty for the
+	// main resource.
+	Subresource *string
+	// The API version of the fields, e.g. "apps/v1".
+	ApiVersion *string
+}
+
+func (b0 FieldOwner_builder) Build() *FieldOwner {
+	m0 := &FieldOwner{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Manager != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
+		x.xxx_hidden_Manager = b.Manager
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+	if b.Operation != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 5)
+		x.xxx_hidden_Operation = b.Operation
+	}
+	x.xxx_hidden_Time = b.Time
+	if b.Subresource != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 5)
+		x.xxx_hidden_Subresource = b.Subresource
+	}
+	if b.ApiVersion != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 5)
+		x.xxx_hidden_ApiVersion = b.ApiVersion
+	}
+	return m0
 }
 
-func (x *ApplyRequest) ClearCluster() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
-	x.xxx_hidden_Cluster = nil
+// OwnedField is a field of the resource and the managers owning it.
+type OwnedField struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Path        *string                `protobuf:"bytes,1,opt,name=path"`
+	xxx_hidden_Owners      *[]*FieldOwner         `protobuf:"bytes,2,rep,name=owners"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
-func (x *ApplyRequest) ClearGroup() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
-	x.xxx_hidden_Group = nil
+func (x *OwnedField) Reset() {
+	*x = OwnedField{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *ApplyRequest) ClearVersion() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
-	x.xxx_hidden_Version = nil
+func (x *OwnedField) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *ApplyRequest) ClearResource() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
-	x.xxx_hidden_Resource = nil
+func (*OwnedField) ProtoMessage() {}
+
+func (x *OwnedField) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *ApplyRequest) ClearNamespace() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
-	x.xxx_hidden_Namespace = nil
+func (x *OwnedField) GetPath() string {
+	if x != nil {
+		if x.xxx_hidden_Path != nil {
+			return *x.xxx_hidden_Path
+		}
+		return ""
+	}
+	return ""
 }
 
-func (x *ApplyRequest) ClearName() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
-	x.xxx_hidden_Name = nil
+func (x *OwnedField) GetOwners() []*FieldOwner {
+	if x != nil {
+		if x.xxx_hidden_Owners != nil {
+			return *x.xxx_hidden_Owners
+		}
+	}
+	return nil
 }
 
-func (x *ApplyRequest) ClearManifest() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
-	x.xxx_hidden_Manifest = nil
+func (x *OwnedField) SetPath(v string) {
+	x.xxx_hidden_Path = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
 }
 
-func (x *ApplyRequest) ClearForce() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
-	x.xxx_hidden_Force = false
+func (x *OwnedField) SetOwners(v []*FieldOwner) {
+	x.xxx_hidden_Owners = &v
 }
 
-func (x *ApplyRequest) ClearFieldManager() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
-	x.xxx_hidden_FieldManager = nil
+func (x *OwnedField) HasPath() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-type ApplyRequest_builder struct {
+func (x *OwnedField) ClearPath() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Path = nil
+}
+
+type OwnedField_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	// The target Kubernetes cluster identifier.
-	Cluster *string
-	// Kubernetes API Group (e.g., "apps" for Deployments, "" for core resources like Pods).
-	Group *string
-	// Kubernetes API Version (e.g., "v1").
-	Version *string
-	// Kubernetes API Resource name in plural (e.g., "pods", "deployments").
-	Resource *string
-	// The namespace of the resource.
-	Namespace *string
-	// The name of the resource.
-	Name *string
-	// A partial or YAML manifest in JSON format to be merged by the API server.
-	Manifest []byte
-	// If true, conflicts are resolved in favour of the caller's field manager.
-	Force *bool
-	// Identifies the entity managing the fields (e.g., "otterscale-web-ui"). Required for SSA.
-	FieldManager *string
+	// The path of the field in the notation of server-side apply conflicts,
+	// e.g. ".spec.template.spec.containers[name=\"web\"].image".
+	Path *string
+	// The managers owning the field. Several managers share a field that
+	// each of them applied with the same value.
+	Owners []*FieldOwner
 }
 
-func (b0 ApplyRequest_builder) Build() *ApplyRequest {
-	m0 := &ApplyRequest{}
+func (b0 OwnedField_builder) Build() *OwnedField {
+	m0 := &OwnedField{}
 	b, x := &b0, m0
 	_, _ = b, x
-	if b.Cluster != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 9)
-		x.xxx_hidden_Cluster = b.Cluster
-	}
-	if b.Group != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 9)
-		x.xxx_hidden_Group = b.Group
-	}
-	if b.Version != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 9)
-		x.xxx_hidden_Version = b.Version
-	}
-	if b.Resource != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 9)
-		x.xxx_hidden_Resource = b.Resource
-	}
-	if b.Namespace != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 9)
-		x.xxx_hidden_Namespace = b.Namespace
-	}
-	if b.Name != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 9)
-		x.xxx_hidden_Name = b.Name
-	}
-	if b.Manifest != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 9)
-		x.xxx_hidden_Manifest = b.Manifest
+	if b.Path != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Path = b.Path
 	}
-	if b.Force != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 9)
-		x.xxx_hidden_Force = *b.Force
+	x.xxx_hidden_Owners = &b.Owners
+	return m0
+}
+
+// FieldOwnershipResponse maps the fields of a resource to their owners.
+type FieldOwnershipResponse struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Fields *[]*OwnedField         `protobuf:"bytes,1,rep,name=fields"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *FieldOwnershipResponse) Reset() {
+	*x = FieldOwnershipResponse{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldOwnershipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldOwnershipResponse) ProtoMessage() {}
+
+func (x *FieldOwnershipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	if b.FieldManager != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 9)
-		x.xxx_hidden_FieldManager = b.FieldManager
+	return mi.MessageOf(x)
+}
+
+func (x *FieldOwnershipResponse) GetFields() []*OwnedField {
+	if x != nil {
+		if x.xxx_hidden_Fields != nil {
+			return *x.xxx_hidden_Fields
+		}
 	}
+	return nil
+}
+
+func (x *FieldOwnershipResponse) SetFields(v []*OwnedField) {
+	x.xxx_hidden_Fields = &v
+}
+
+type FieldOwnershipResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The owned fields, ordered by path.
+	Fields []*OwnedField
+}
+
+func (b0 FieldOwnershipResponse_builder) Build() *FieldOwnershipResponse {
+	m0 := &FieldOwnershipResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Fields = &b.Fields
 	return m0
 }
 
-// DeleteRequest defines the parameters to remove an object.
-type DeleteRequest struct {
-	state                         protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Cluster            *string                `protobuf:"bytes,1,opt,name=cluster"`
-	xxx_hidden_Group              *string                `protobuf:"bytes,2,opt,name=group"`
-	xxx_hidden_Version            *string                `protobuf:"bytes,3,opt,name=version"`
-	xxx_hidden_Resource           *string                `protobuf:"bytes,4,opt,name=resource"`
-	xxx_hidden_Namespace          *string                `protobuf:"bytes,5,opt,name=namespace"`
-	xxx_hidden_Name               *string                `protobuf:"bytes,6,opt,name=name"`
-	xxx_hidden_GracePeriodSeconds int64                  `protobuf:"varint,7,opt,name=grace_period_seconds,json=gracePeriodSeconds"`
-	XXX_raceDetectHookData        protoimpl.RaceDetectHookData
-	XXX_presence                  [1]uint32
-	unknownFields                 protoimpl.UnknownFields
-	sizeCache                     protoimpl.SizeCache
+// GetRevisionRequest identifies a revision of a workload.
+type GetRevisionRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster     *string                `protobuf:"bytes,1,opt,name=cluster"`
+	xxx_hidden_Group       *string                `protobuf:"bytes,2,opt,name=group"`
+	xxx_hidden_Version     *string                `protobuf:"bytes,3,opt,name=version"`
+	xxx_hidden_Resource    *string                `protobuf:"bytes,4,opt,name=resource"`
+	xxx_hidden_Namespace   *string                `protobuf:"bytes,5,opt,name=namespace"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,6,opt,name=name"`
+	xxx_hidden_Revision    int64                  `protobuf:"varint,7,opt,name=revision"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
-func (x *DeleteRequest) Reset() {
-	*x = DeleteRequest{}
-	mi := &file_api_resource_v1_resource_proto_msgTypes[12]
+func (x *GetRevisionRequest) Reset() {
+	*x = GetRevisionRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteRequest) String() string {
+func (x *GetRevisionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteRequest) ProtoMessage() {}
+func (*GetRevisionRequest) ProtoMessage() {}
 
-func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_resource_v1_resource_proto_msgTypes[12]
+func (x *GetRevisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2459,7 +3144,7 @@ func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *DeleteRequest) GetCluster() string {
+func (x *GetRevisionRequest) GetCluster() string {
 	if x != nil {
 		if x.xxx_hidden_Cluster != nil {
 			return *x.xxx_hidden_Cluster
@@ -2469,7 +3154,7 @@ func (x *DeleteRequest) GetCluster() string {
 	return ""
 }
 
-func (x *DeleteRequest) GetGroup() string {
+func (x *GetRevisionRequest) GetGroup() string {
 	if x != nil {
 		if x.xxx_hidden_Group != nil {
 			return *x.xxx_hidden_Group
@@ -2479,7 +3164,7 @@ func (x *DeleteRequest) GetGroup() string {
 	return ""
 }
 
-func (x *DeleteRequest) GetVersion() string {
+func (x *GetRevisionRequest) GetVersion() string {
 	if x != nil {
 		if x.xxx_hidden_Version != nil {
 			return *x.xxx_hidden_Version
@@ -2489,7 +3174,7 @@ func (x *DeleteRequest) GetVersion() string {
 	return ""
 }
 
-func (x *DeleteRequest) GetResource() string {
+func (x *GetRevisionRequest) GetResource() string {
 	if x != nil {
 		if x.xxx_hidden_Resource != nil {
 			return *x.xxx_hidden_Resource
@@ -2499,7 +3184,7 @@ func (x *DeleteRequest) GetResource() string {
 	return ""
 }
 
-func (x *DeleteRequest) GetNamespace() string {
+func (x *GetRevisionRequest) GetNamespace() string {
 	if x != nil {
 		if x.xxx_hidden_Namespace != nil {
 			return *x.xxx_hidden_Namespace
@@ -2509,7 +3194,7 @@ func (x *DeleteRequest) GetNamespace() string {
 	return ""
 }
 
-func (x *DeleteRequest) GetName() string {
+func (x *GetRevisionRequest) GetName() string {
 	if x != nil {
 		if x.xxx_hidden_Name != nil {
 			return *x.xxx_hidden_Name
@@ -2519,153 +3204,156 @@ func (x *DeleteRequest) GetName() string {
 	return ""
 }
 
-func (x *DeleteRequest) GetGracePeriodSeconds() int64 {
+func (x *GetRevisionRequest) GetRevision() int64 {
 	if x != nil {
-		return x.xxx_hidden_GracePeriodSeconds
+		return x.xxx_hidden_Revision
 	}
 	return 0
 }
 
-func (x *DeleteRequest) SetCluster(v string) {
+func (x *GetRevisionRequest) SetCluster(v string) {
 	x.xxx_hidden_Cluster = &v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 7)
 }
 
-func (x *DeleteRequest) SetGroup(v string) {
+func (x *GetRevisionRequest) SetGroup(v string) {
 	x.xxx_hidden_Group = &v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
 }
 
-func (x *DeleteRequest) SetVersion(v string) {
+func (x *GetRevisionRequest) SetVersion(v string) {
 	x.xxx_hidden_Version = &v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 7)
 }
 
-func (x *DeleteRequest) SetResource(v string) {
+func (x *GetRevisionRequest) SetResource(v string) {
 	x.xxx_hidden_Resource = &v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 7)
 }
 
-func (x *DeleteRequest) SetNamespace(v string) {
+func (x *GetRevisionRequest) SetNamespace(v string) {
 	x.xxx_hidden_Namespace = &v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 7)
 }
 
-func (x *DeleteRequest) SetName(v string) {
+func (x *GetRevisionRequest) SetName(v string) {
 	x.xxx_hidden_Name = &v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 7)
 }
 
-func (x *DeleteRequest) SetGracePeriodSeconds(v int64) {
-	x.xxx_hidden_GracePeriodSeconds = v
+func (x *GetRevisionRequest) SetRevision(v int64) {
+	x.xxx_hidden_Revision = v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 7)
 }
 
-func (x *DeleteRequest) HasCluster() bool {
+func (x *GetRevisionRequest) HasCluster() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *DeleteRequest) HasGroup() bool {
+func (x *GetRevisionRequest) HasGroup() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *DeleteRequest) HasVersion() bool {
+func (x *GetRevisionRequest) HasVersion() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
 }
 
-func (x *DeleteRequest) HasResource() bool {
+func (x *GetRevisionRequest) HasResource() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
 }
 
-func (x *DeleteRequest) HasNamespace() bool {
+func (x *GetRevisionRequest) HasNamespace() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
 }
 
-func (x *DeleteRequest) HasName() bool {
+func (x *GetRevisionRequest) HasName() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
 }
 
-func (x *DeleteRequest) HasGracePeriodSeconds() bool {
+func (x *GetRevisionRequest) HasRevision() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
 }
 
-func (x *DeleteRequest) ClearCluster() {
+func (x *GetRevisionRequest) ClearCluster() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Cluster = nil
 }
 
-func (x *DeleteRequest) ClearGroup() {
+func (x *GetRevisionRequest) ClearGroup() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
 	x.xxx_hidden_Group = nil
 }
 
-func (x *DeleteRequest) ClearVersion() {
+func (x *GetRevisionRequest) ClearVersion() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
 	x.xxx_hidden_Version = nil
 }
 
-func (x *DeleteRequest) ClearResource() {
+func (x *GetRevisionRequest) ClearResource() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
 	x.xxx_hidden_Resource = nil
 }
 
-func (x *DeleteRequest) ClearNamespace() {
+func (x *GetRevisionRequest) ClearNamespace() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
 	x.xxx_hidden_Namespace = nil
 }
 
-func (x *DeleteRequest) ClearName() {
+func (x *GetRevisionRequest) ClearName() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
 	x.xxx_hidden_Name = nil
 }
 
-func (x *DeleteRequest) ClearGracePeriodSeconds() {
+func (x *GetRevisionRequest) ClearRevision() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
-	x.xxx_hidden_GracePeriodSeconds = 0
+	x.xxx_hidden_Revision = 0
 }
 
-type DeleteRequest_builder struct {
+type GetRevisionRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
 	// The target Kubernetes cluster identifier.
 	Cluster *string
-	// Kubernetes API Group (e.g., "apps" for Deployments, "" for core resources like Pods).
+	// Kubernetes API Group, "apps".
 	Group *string
 	// Kubernetes API Version (e.g., "v1").
 	Version *string
-	// Kubernetes API Resource name in plural (e.g., "pods", "deployments").
+	// Kubernetes API Resource name in plural: "deployments", "daemonsets" or
+	// "statefulsets".
 	Resource *string
-	// The namespace of the resource.
+	// The namespace of the workload.
 	Namespace *string
-	// The name of the resource.
+	// The name of the workload.
 	Name *string
-	// The duration in seconds before the object should be deleted. Overrides the default grace period.
-	GracePeriodSeconds *int64
+	// The revision number, as listed by `kubectl rollout history`. Zero
+	// selects the revision before the latest, which `kubectl rollout undo`
+	// rolls back to.
+	Revision *int64
 }
 
-func (b0 DeleteRequest_builder) Build() *DeleteRequest {
-	m0 := &DeleteRequest{}
+func (b0 GetRevisionRequest_builder) Build() *GetRevisionRequest {
+	m0 := &GetRevisionRequest{}
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Cluster != nil {
@@ -2692,45 +3380,43 @@ func (b0 DeleteRequest_builder) Build() *DeleteRequest {
 		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 7)
 		x.xxx_hidden_Name = b.Name
 	}
-	if b.GracePeriodSeconds != nil {
+	if b.Revision != nil {
 		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 7)
-		x.xxx_hidden_GracePeriodSeconds = *b.GracePeriodSeconds
+		x.xxx_hidden_Revision = *b.Revision
 	}
 	return m0
 }
 
-// WatchRequest defines the parameters to start a streaming watch.
-type WatchRequest struct {
-	state                      protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Cluster         *string                `protobuf:"bytes,1,opt,name=cluster"`
-	xxx_hidden_Group           *string                `protobuf:"bytes,2,opt,name=group"`
-	xxx_hidden_Version         *string                `protobuf:"bytes,3,opt,name=version"`
-	xxx_hidden_Resource        *string                `protobuf:"bytes,4,opt,name=resource"`
-	xxx_hidden_Namespace       *string                `protobuf:"bytes,5,opt,name=namespace"`
-	xxx_hidden_LabelSelector   *string                `protobuf:"bytes,6,opt,name=label_selector,json=labelSelector"`
-	xxx_hidden_FieldSelector   *string                `protobuf:"bytes,7,opt,name=field_selector,json=fieldSelector"`
-	xxx_hidden_ResourceVersion *string                `protobuf:"bytes,8,opt,name=resource_version,json=resourceVersion"`
-	XXX_raceDetectHookData     protoimpl.RaceDetectHookData
-	XXX_presence               [1]uint32
-	unknownFields              protoimpl.UnknownFields
-	sizeCache                  protoimpl.SizeCache
+// Revision is a recorded revision of a workload's pod template.
+type Revision struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Revision    int64                  `protobuf:"varint,1,opt,name=revision"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,2,opt,name=name"`
+	xxx_hidden_CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt"`
+	xxx_hidden_ChangeCause *string                `protobuf:"bytes,4,opt,name=change_cause,json=changeCause"`
+	xxx_hidden_Current     bool                   `protobuf:"varint,5,opt,name=current"`
+	xxx_hidden_Template    *structpb.Struct       `protobuf:"bytes,6,opt,name=template"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
-func (x *WatchRequest) Reset() {
-	*x = WatchRequest{}
-	mi := &file_api_resource_v1_resource_proto_msgTypes[13]
+func (x *Revision) Reset() {
+	*x = Revision{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WatchRequest) String() string {
+func (x *Revision) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WatchRequest) ProtoMessage() {}
+func (*Revision) ProtoMessage() {}
 
-func (x *WatchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_resource_v1_resource_proto_msgTypes[13]
+func (x *Revision) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2741,309 +3427,8227 @@ func (x *WatchRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *WatchRequest) GetCluster() string {
+func (x *Revision) GetRevision() int64 {
 	if x != nil {
-		if x.xxx_hidden_Cluster != nil {
-			return *x.xxx_hidden_Cluster
+		return x.xxx_hidden_Revision
+	}
+	return 0
+}
+
+func (x *Revision) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *WatchRequest) GetGroup() string {
+func (x *Revision) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		if x.xxx_hidden_Group != nil {
-			return *x.xxx_hidden_Group
+		return x.xxx_hidden_CreatedAt
+	}
+	return nil
+}
+
+func (x *Revision) GetChangeCause() string {
+	if x != nil {
+		if x.xxx_hidden_ChangeCause != nil {
+			return *x.xxx_hidden_ChangeCause
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *WatchRequest) GetVersion() string {
+func (x *Revision) GetCurrent() bool {
 	if x != nil {
-		if x.xxx_hidden_Version != nil {
-			return *x.xxx_hidden_Version
+		return x.xxx_hidden_Current
+	}
+	return false
+}
+
+func (x *Revision) GetTemplate() *structpb.Struct {
+	if x != nil {
+		return x.xxx_hidden_Template
+	}
+	return nil
+}
+
+func (x *Revision) SetRevision(v int64) {
+	x.xxx_hidden_Revision = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 6)
+}
+
+func (x *Revision) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 6)
+}
+
+func (x *Revision) SetCreatedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CreatedAt = v
+}
+
+func (x *Revision) SetChangeCause(v string) {
+	x.xxx_hidden_ChangeCause = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 6)
+}
+
+func (x *Revision) SetCurrent(v bool) {
+	x.xxx_hidden_Current = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
+}
+
+func (x *Revision) SetTemplate(v *structpb.Struct) {
+	x.xxx_hidden_Template = v
+}
+
+func (x *Revision) HasRevision() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *Revision) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *Revision) HasCreatedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CreatedAt != nil
+}
+
+func (x *Revision) HasChangeCause() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *Revision) HasCurrent() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *Revision) HasTemplate() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Template != nil
+}
+
+func (x *Revision) ClearRevision() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Revision = 0
+}
+
+func (x *Revision) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *Revision) ClearCreatedAt() {
+	x.xxx_hidden_CreatedAt = nil
+}
+
+func (x *Revision) ClearChangeCause() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_ChangeCause = nil
+}
+
+func (x *Revision) ClearCurrent() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Current = false
+}
+
+func (x *Revision) ClearTemplate() {
+	x.xxx_hidden_Template = nil
+}
+
+type Revision_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The revision number.
+	Revision *int64
+	// The ReplicaSet or ControllerRevision recording the revision.
+	Name *string
+	// When the revision was recorded.
+	CreatedAt *timestamppb.Timestamp
+	// The kubernetes.io/change-cause annotation of the revision, if any.
+	ChangeCause *string
+	// Whether this is the workload's latest revision.
+	Current *bool
+	// The pod template of the revision.
+	Template *structpb.Struct
+}
+
+func (b0 Revision_builder) Build() *Revision {
+	m0 := &Revision{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Revision != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 6)
+		x.xxx_hidden_Revision = *b.Revision
+	}
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 6)
+		x.xxx_hidden_Name = b.Name
+	}
+	x.xxx_hidden_CreatedAt = b.CreatedAt
+	if b.ChangeCause != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 6)
+		x.xxx_hidden_ChangeCause = b.ChangeCause
+	}
+	if b.Current != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 6)
+		x.xxx_hidden_Current = *b.Current
+	}
+	x.xxx_hidden_Template = b.Template
+	return m0
+}
+
+// CompareAcrossClustersRequest identifies the resource to compare between
+// two clusters.
+type CompareAcrossClustersRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_ClusterA    *string                `protobuf:"bytes,1,opt,name=cluster_a,json=clusterA"`
+	xxx_hidden_ClusterB    *string                `protobuf:"bytes,2,opt,name=cluster_b,json=clusterB"`
+	xxx_hidden_Group       *string                `protobuf:"bytes,3,opt,name=group"`
+	xxx_hidden_Version     *string                `protobuf:"bytes,4,opt,name=version"`
+	xxx_hidden_Resource    *string                `protobuf:"bytes,5,opt,name=resource"`
+	xxx_hidden_Namespace   *string                `protobuf:"bytes,6,opt,name=namespace"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,7,opt,name=name"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CompareAcrossClustersRequest) Reset() {
+	*x = CompareAcrossClustersRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareAcrossClustersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareAcrossClustersRequest) ProtoMessage() {}
+
+func (x *CompareAcrossClustersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CompareAcrossClustersRequest) GetClusterA() string {
+	if x != nil {
+		if x.xxx_hidden_ClusterA != nil {
+			return *x.xxx_hidden_ClusterA
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *WatchRequest) GetResource() string {
+func (x *CompareAcrossClustersRequest) GetClusterB() string {
 	if x != nil {
-		if x.xxx_hidden_Resource != nil {
-			return *x.xxx_hidden_Resource
+		if x.xxx_hidden_ClusterB != nil {
+			return *x.xxx_hidden_ClusterB
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *WatchRequest) GetNamespace() string {
+func (x *CompareAcrossClustersRequest) GetGroup() string {
 	if x != nil {
-		if x.xxx_hidden_Namespace != nil {
-			return *x.xxx_hidden_Namespace
+		if x.xxx_hidden_Group != nil {
+			return *x.xxx_hidden_Group
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *WatchRequest) GetLabelSelector() string {
+func (x *CompareAcrossClustersRequest) GetVersion() string {
 	if x != nil {
-		if x.xxx_hidden_LabelSelector != nil {
-			return *x.xxx_hidden_LabelSelector
+		if x.xxx_hidden_Version != nil {
+			return *x.xxx_hidden_Version
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *WatchRequest) GetFieldSelector() string {
+func (x *CompareAcrossClustersRequest) GetResource() string {
 	if x != nil {
-		if x.xxx_hidden_FieldSelector != nil {
-			return *x.xxx_hidden_FieldSelector
+		if x.xxx_hidden_Resource != nil {
+			return *x.xxx_hidden_Resource
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *WatchRequest) GetResourceVersion() string {
+func (x *CompareAcrossClustersRequest) GetNamespace() string {
 	if x != nil {
-		if x.xxx_hidden_ResourceVersion != nil {
-			return *x.xxx_hidden_ResourceVersion
+		if x.xxx_hidden_Namespace != nil {
+			return *x.xxx_hidden_Namespace
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *WatchRequest) SetCluster(v string) {
-	x.xxx_hidden_Cluster = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 8)
+func (x *CompareAcrossClustersRequest) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
 }
 
-func (x *WatchRequest) SetGroup(v string) {
+func (x *CompareAcrossClustersRequest) SetClusterA(v string) {
+	x.xxx_hidden_ClusterA = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 7)
+}
+
+func (x *CompareAcrossClustersRequest) SetClusterB(v string) {
+	x.xxx_hidden_ClusterB = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
+}
+
+func (x *CompareAcrossClustersRequest) SetGroup(v string) {
 	x.xxx_hidden_Group = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 8)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 7)
+}
+
+func (x *CompareAcrossClustersRequest) SetVersion(v string) {
+	x.xxx_hidden_Version = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 7)
+}
+
+func (x *CompareAcrossClustersRequest) SetResource(v string) {
+	x.xxx_hidden_Resource = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 7)
+}
+
+func (x *CompareAcrossClustersRequest) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 7)
+}
+
+func (x *CompareAcrossClustersRequest) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 7)
+}
+
+func (x *CompareAcrossClustersRequest) HasClusterA() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *CompareAcrossClustersRequest) HasClusterB() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *CompareAcrossClustersRequest) HasGroup() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *CompareAcrossClustersRequest) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *CompareAcrossClustersRequest) HasResource() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *CompareAcrossClustersRequest) HasNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *CompareAcrossClustersRequest) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *CompareAcrossClustersRequest) ClearClusterA() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_ClusterA = nil
+}
+
+func (x *CompareAcrossClustersRequest) ClearClusterB() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_ClusterB = nil
+}
+
+func (x *CompareAcrossClustersRequest) ClearGroup() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Group = nil
+}
+
+func (x *CompareAcrossClustersRequest) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Version = nil
+}
+
+func (x *CompareAcrossClustersRequest) ClearResource() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Resource = nil
+}
+
+func (x *CompareAcrossClustersRequest) ClearNamespace() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Namespace = nil
+}
+
+func (x *CompareAcrossClustersRequest) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_Name = nil
+}
+
+type CompareAcrossClustersRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The cluster the differences are reported from, e.g. staging.
+	ClusterA *string
+	// The cluster the differences are reported to, e.g. production.
+	ClusterB *string
+	// Kubernetes API Group (e.g., "apps" for Deployments, "" for core resources like Pods).
+	Group *string
+	// Kubernetes API Version (e.g., "v1").
+	Version *string
+	// Kubernetes API Resource name in plural (e.g., "pods", "deployments").
+	Resource *string
+	// The namespace of the resource.
+	Namespace *string
+	// The name of the resource.
+	Name *string
+}
+
+func (b0 CompareAcrossClustersRequest_builder) Build() *CompareAcrossClustersRequest {
+	m0 := &CompareAcrossClustersRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.ClusterA != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 7)
+		x.xxx_hidden_ClusterA = b.ClusterA
+	}
+	if b.ClusterB != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 7)
+		x.xxx_hidden_ClusterB = b.ClusterB
+	}
+	if b.Group != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 7)
+		x.xxx_hidden_Group = b.Group
+	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 7)
+		x.xxx_hidden_Version = b.Version
+	}
+	if b.Resource != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 7)
+		x.xxx_hidden_Resource = b.Resource
+	}
+	if b.Namespace != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 7)
+		x.xxx_hidden_Namespace = b.Namespace
+	}
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 7)
+		x.xxx_hidden_Name = b.Name
+	}
+	return m0
+}
+
+// FieldDifference is one field that differs between the compared objects.
+type FieldDifference struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Type        FieldDifference_Type   `protobuf:"varint,1,opt,name=type,enum=otterscale.resource.v1.FieldDifference_Type"`
+	xxx_hidden_Path        *string                `protobuf:"bytes,2,opt,name=path"`
+	xxx_hidden_A           *structpb.Value        `protobuf:"bytes,3,opt,name=a"`
+	xxx_hidden_B           *structpb.Value        `protobuf:"bytes,4,opt,name=b"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *FieldDifference) Reset() {
+	*x = FieldDifference{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldDifference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldDifference) ProtoMessage() {}
+
+func (x *FieldDifference) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *FieldDifference) GetType() FieldDifference_Type {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 0) {
+			return x.xxx_hidden_Type
+		}
+	}
+	return FieldDifference_TYPE_UNSPECIFIED
+}
+
+func (x *FieldDifference) GetPath() string {
+	if x != nil {
+		if x.xxx_hidden_Path != nil {
+			return *x.xxx_hidden_Path
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *FieldDifference) GetA() *structpb.Value {
+	if x != nil {
+		return x.xxx_hidden_A
+	}
+	return nil
+}
+
+func (x *FieldDifference) GetB() *structpb.Value {
+	if x != nil {
+		return x.xxx_hidden_B
+	}
+	return nil
+}
+
+func (x *FieldDifference) SetType(v FieldDifference_Type) {
+	x.xxx_hidden_Type = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *FieldDifference) SetPath(v string) {
+	x.xxx_hidden_Path = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *FieldDifference) SetA(v *structpb.Value) {
+	x.xxx_hidden_A = v
+}
+
+func (x *FieldDifference) SetB(v *structpb.Value) {
+	x.xxx_hidden_B = v
+}
+
+func (x *FieldDifference) HasType() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *FieldDifference) HasPath() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *FieldDifference) HasA() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_A != nil
+}
+
+func (x *FieldDifference) HasB() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_B != nil
+}
+
+func (x *FieldDifference) ClearType() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Type = FieldDifference_TYPE_UNSPECIFIED
+}
+
+func (x *FieldDifference) ClearPath() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Path = nil
+}
+
+func (x *FieldDifference) ClearA() {
+	x.xxx_hidden_A = nil
+}
+
+func (x *FieldDifference) ClearB() {
+	x.xxx_hidden_B = nil
+}
+
+type FieldDifference_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Type *FieldDifference_Type
+	// The JSON Pointer (RFC 6901) of the field, e.g.
+	// "/spec/template/spec/containers/0/image".
+	Path *string
+	// The value in cluster A, unset when the field is added.
+	A *structpb.Value
+	// The value in cluster B, unset when the field is removed.
+	B *structpb.Value
+}
+
+func (b0 FieldDifference_builder) Build() *FieldDifference {
+	m0 := &FieldDifference{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Type != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Type = *b.Type
+	}
+	if b.Path != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_Path = b.Path
+	}
+	x.xxx_hidden_A = b.A
+	x.xxx_hidden_B = b.B
+	return m0
+}
+
+// CompareAcrossClustersResponse holds the normalized objects and their
+// differences.
+type CompareAcrossClustersResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_A           *Resource              `protobuf:"bytes,1,opt,name=a"`
+	xxx_hidden_B           *Resource              `protobuf:"bytes,2,opt,name=b"`
+	xxx_hidden_Differences *[]*FieldDifference    `protobuf:"bytes,3,rep,name=differences"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CompareAcrossClustersResponse) Reset() {
+	*x = CompareAcrossClustersResponse{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareAcrossClustersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareAcrossClustersResponse) ProtoMessage() {}
+
+func (x *CompareAcrossClustersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CompareAcrossClustersResponse) GetA() *Resource {
+	if x != nil {
+		return x.xxx_hidden_A
+	}
+	return nil
+}
+
+func (x *CompareAcrossClustersResponse) GetB() *Resource {
+	if x != nil {
+		return x.xxx_hidden_B
+	}
+	return nil
+}
+
+func (x *CompareAcrossClustersResponse) GetDifferences() []*FieldDifference {
+	if x != nil {
+		if x.xxx_hidden_Differences != nil {
+			return *x.xxx_hidden_Differences
+		}
+	}
+	return nil
+}
+
+func (x *CompareAcrossClustersResponse) SetA(v *Resource) {
+	x.xxx_hidden_A = v
+}
+
+func (x *CompareAcrossClustersResponse) SetB(v *Resource) {
+	x.xxx_hidden_B = v
+}
+
+func (x *CompareAcrossClustersResponse) SetDifferences(v []*FieldDifference) {
+	x.xxx_hidden_Differences = &v
+}
+
+func (x *CompareAcrossClustersResponse) HasA() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_A != nil
+}
+
+func (x *CompareAcrossClustersResponse) HasB() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_B != nil
+}
+
+func (x *CompareAcrossClustersResponse) ClearA() {
+	x.xxx_hidden_A = nil
+}
+
+func (x *CompareAcrossClustersResponse) ClearB() {
+	x.xxx_hidden_B = nil
+}
+
+type CompareAcrossClustersResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The object in cluster A, without server-managed fields and status.
+	// Unset when the object does not exist in cluster A.
+	A *Resource
+	// The object in cluster B, normalized likewise. Unset when the object
+	// does not exist in cluster B.
+	B *Resource
+	// The fields that differ, ordered by key and list index. Empty when the
+	// object is missing from either cluster.
+	Differences []*FieldDifference
+}
+
+func (b0 CompareAcrossClustersResponse_builder) Build() *CompareAcrossClustersResponse {
+	m0 := &CompareAcrossClustersResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_A = b.A
+	x.xxx_hidden_B = b.B
+	x.xxx_hidden_Differences = &b.Differences
+	return m0
+}
+
+// NamespaceQuotaRequest identifies the namespace to summarise.
+type NamespaceQuotaRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster     *string                `protobuf:"bytes,1,opt,name=cluster"`
+	xxx_hidden_Namespace   *string                `protobuf:"bytes,2,opt,name=namespace"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *NamespaceQuotaRequest) Reset() {
+	*x = NamespaceQuotaRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NamespaceQuotaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamespaceQuotaRequest) ProtoMessage() {}
+
+func (x *NamespaceQuotaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *NamespaceQuotaRequest) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *NamespaceQuotaRequest) GetNamespace() string {
+	if x != nil {
+		if x.xxx_hidden_Namespace != nil {
+			return *x.xxx_hidden_Namespace
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *NamespaceQuotaRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *NamespaceQuotaRequest) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *NamespaceQuotaRequest) HasCluster() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *NamespaceQuotaRequest) HasNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *NamespaceQuotaRequest) ClearCluster() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Cluster = nil
+}
+
+func (x *NamespaceQuotaRequest) ClearNamespace() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Namespace = nil
+}
+
+type NamespaceQuotaRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The target Kubernetes cluster identifier.
+	Cluster *string
+	// The namespace whose quotas and limit ranges are summarised.
+	Namespace *string
+}
+
+func (b0 NamespaceQuotaRequest_builder) Build() *NamespaceQuotaRequest {
+	m0 := &NamespaceQuotaRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Cluster != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Cluster = b.Cluster
+	}
+	if b.Namespace != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Namespace = b.Namespace
+	}
+	return m0
+}
+
+// QuotaUsage is the usage of one resource under a ResourceQuota.
+// Quantities use the canonical Kubernetes form (e.g., "500m", "2Gi").
+type QuotaUsage struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Resource    *string                `protobuf:"bytes,1,opt,name=resource"`
+	xxx_hidden_Used        *string                `protobuf:"bytes,2,opt,name=used"`
+	xxx_hidden_Hard        *string                `protobuf:"bytes,3,opt,name=hard"`
+	xxx_hidden_Utilization float64                `protobuf:"fixed64,4,opt,name=utilization"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *QuotaUsage) Reset() {
+	*x = QuotaUsage{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuotaUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuotaUsage) ProtoMessage() {}
+
+func (x *QuotaUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *QuotaUsage) GetResource() string {
+	if x != nil {
+		if x.xxx_hidden_Resource != nil {
+			return *x.xxx_hidden_Resource
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *QuotaUsage) GetUsed() string {
+	if x != nil {
+		if x.xxx_hidden_Used != nil {
+			return *x.xxx_hidden_Used
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *QuotaUsage) GetHard() string {
+	if x != nil {
+		if x.xxx_hidden_Hard != nil {
+			return *x.xxx_hidden_Hard
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *QuotaUsage) GetUtilization() float64 {
+	if x != nil {
+		return x.xxx_hidden_Utilization
+	}
+	return 0
+}
+
+func (x *QuotaUsage) SetResource(v string) {
+	x.xxx_hidden_Resource = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *QuotaUsage) SetUsed(v string) {
+	x.xxx_hidden_Used = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *QuotaUsage) SetHard(v string) {
+	x.xxx_hidden_Hard = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *QuotaUsage) SetUtilization(v float64) {
+	x.xxx_hidden_Utilization = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+}
+
+func (x *QuotaUsage) HasResource() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *QuotaUsage) HasUsed() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *QuotaUsage) HasHard() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *QuotaUsage) HasUtilization() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *QuotaUsage) ClearResource() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Resource = nil
+}
+
+func (x *QuotaUsage) ClearUsed() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Used = nil
+}
+
+func (x *QuotaUsage) ClearHard() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Hard = nil
+}
+
+func (x *QuotaUsage) ClearUtilization() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Utilization = 0
+}
+
+type QuotaUsage_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The constrained resource (e.g., "requests.cpu", "pods").
+	Resource *string
+	// The amount currently in use.
+	Used *string
+	// The hard limit enforced by the quota.
+	Hard *string
+	// used / hard, or 0 when hard is zero.
+	Utilization *float64
+}
+
+func (b0 QuotaUsage_builder) Build() *QuotaUsage {
+	m0 := &QuotaUsage{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Resource != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Resource = b.Resource
+	}
+	if b.Used != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_Used = b.Used
+	}
+	if b.Hard != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_Hard = b.Hard
+	}
+	if b.Utilization != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_Utilization = *b.Utilization
+	}
+	return m0
+}
+
+// ResourceQuotaSummary is the usage of every resource in one ResourceQuota.
+type ResourceQuotaSummary struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_Resources   *[]*QuotaUsage         `protobuf:"bytes,2,rep,name=resources"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ResourceQuotaSummary) Reset() {
+	*x = ResourceQuotaSummary{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceQuotaSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceQuotaSummary) ProtoMessage() {}
+
+func (x *ResourceQuotaSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ResourceQuotaSummary) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ResourceQuotaSummary) GetResources() []*QuotaUsage {
+	if x != nil {
+		if x.xxx_hidden_Resources != nil {
+			return *x.xxx_hidden_Resources
+		}
+	}
+	return nil
+}
+
+func (x *ResourceQuotaSummary) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *ResourceQuotaSummary) SetResources(v []*QuotaUsage) {
+	x.xxx_hidden_Resources = &v
+}
+
+func (x *ResourceQuotaSummary) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ResourceQuotaSummary) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Name = nil
+}
+
+type ResourceQuotaSummary_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The name of the ResourceQuota.
+	Name *string
+	// Per-resource usage, sorted by resource name.
+	Resources []*QuotaUsage
+}
+
+func (b0 ResourceQuotaSummary_builder) Build() *ResourceQuotaSummary {
+	m0 := &ResourceQuotaSummary{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Name = b.Name
+	}
+	x.xxx_hidden_Resources = &b.Resources
+	return m0
+}
+
+// LimitRangeItem is the set of constraints a LimitRange places on one
+// resource for one object type. Unset constraints are empty.
+type LimitRangeItem struct {
+	state                           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Type                 *string                `protobuf:"bytes,1,opt,name=type"`
+	xxx_hidden_Resource             *string                `protobuf:"bytes,2,opt,name=resource"`
+	xxx_hidden_Default              *string                `protobuf:"bytes,3,opt,name=default"`
+	xxx_hidden_DefaultRequest       *string                `protobuf:"bytes,4,opt,name=default_request,json=defaultRequest"`
+	xxx_hidden_Min                  *string                `protobuf:"bytes,5,opt,name=min"`
+	xxx_hidden_Max                  *string                `protobuf:"bytes,6,opt,name=max"`
+	xxx_hidden_MaxLimitRequestRatio *string                `protobuf:"bytes,7,opt,name=max_limit_request_ratio,json=maxLimitRequestRatio"`
+	XXX_raceDetectHookData          protoimpl.RaceDetectHookData
+	XXX_presence                    [1]uint32
+	unknownFields                   protoimpl.UnknownFields
+	sizeCache                       protoimpl.SizeCache
+}
+
+func (x *LimitRangeItem) Reset() {
+	*x = LimitRangeItem{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LimitRangeItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LimitRangeItem) ProtoMessage() {}
+
+func (x *LimitRangeItem) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *LimitRangeItem) GetType() string {
+	if x != nil {
+		if x.xxx_hidden_Type != nil {
+			return *x.xxx_hidden_Type
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *LimitRangeItem) GetResource() string {
+	if x != nil {
+		if x.xxx_hidden_Resource != nil {
+			return *x.xxx_hidden_Resource
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *LimitRangeItem) GetDefault() string {
+	if x != nil {
+		if x.xxx_hidden_Default != nil {
+			return *x.xxx_hidden_Default
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *LimitRangeItem) GetDefaultRequest() string {
+	if x != nil {
+		if x.xxx_hidden_DefaultRequest != nil {
+			return *x.xxx_hidden_DefaultRequest
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *LimitRangeItem) GetMin() string {
+	if x != nil {
+		if x.xxx_hidden_Min != nil {
+			return *x.xxx_hidden_Min
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *LimitRangeItem) GetMax() string {
+	if x != nil {
+		if x.xxx_hidden_Max != nil {
+			return *x.xxx_hidden_Max
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *LimitRangeItem) GetMaxLimitRequestRatio() string {
+	if x != nil {
+		if x.xxx_hidden_MaxLimitRequestRatio != nil {
+			return *x.xxx_hidden_MaxLimitRequestRatio
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *LimitRangeItem) SetType(v string) {
+	x.xxx_hidden_Type = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 7)
+}
+
+func (x *LimitRangeItem) SetResource(v string) {
+	x.xxx_hidden_Resource = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
+}
+
+func (x *LimitRangeItem) SetDefault(v string) {
+	x.xxx_hidden_Default = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 7)
+}
+
+func (x *LimitRangeItem) SetDefaultRequest(v string) {
+	x.xxx_hidden_DefaultRequest = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 7)
+}
+
+func (x *LimitRangeItem) SetMin(v string) {
+	x.xxx_hidden_Min = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 7)
+}
+
+func (x *LimitRangeItem) SetMax(v string) {
+	x.xxx_hidden_Max = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 7)
+}
+
+func (x *LimitRangeItem) SetMaxLimitRequestRatio(v string) {
+	x.xxx_hidden_MaxLimitRequestRatio = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 7)
+}
+
+func (x *LimitRangeItem) HasType() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *LimitRangeItem) HasResource() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *LimitRangeItem) HasDefault() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *LimitRangeItem) HasDefaultRequest() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *LimitRangeItem) HasMin() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *LimitRangeItem) HasMax() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *LimitRangeItem) HasMaxLimitRequestRatio() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *LimitRangeItem) ClearType() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Type = nil
+}
+
+func (x *LimitRangeItem) ClearResource() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Resource = nil
+}
+
+func (x *LimitRangeItem) ClearDefault() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Default = nil
+}
+
+func (x *LimitRangeItem) ClearDefaultRequest() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_DefaultRequest = nil
+}
+
+func (x *LimitRangeItem) ClearMin() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Min = nil
+}
+
+func (x *LimitRangeItem) ClearMax() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Max = nil
+}
+
+func (x *LimitRangeItem) ClearMaxLimitRequestRatio() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_MaxLimitRequestRatio = nil
+}
+
+type LimitRangeItem_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The object type the limit applies to ("Container", "Pod" or "PersistentVolumeClaim").
+	Type *string
+	// The constrained resource (e.g., "cpu", "memory").
+	Resource *string
+	// The default limit applied when none is specified.
+	Default *string
+	// The default request applied when none is specified.
+	DefaultRequest *string
+	// The minimum allowed value.
+	Min *string
+	// The maximum allowed value.
+	Max *string
+	// The maximum allowed ratio of limit to request.
+	MaxLimitRequestRatio *string
+}
+
+func (b0 LimitRangeItem_builder) Build() *LimitRangeItem {
+	m0 := &LimitRangeItem{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Type != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 7)
+		x.xxx_hidden_Type = b.Type
+	}
+	if b.Resource != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 7)
+		x.xxx_hidden_Resource = b.Resource
+	}
+	if b.Default != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 7)
+		x.xxx_hidden_Default = b.Default
+	}
+	if b.DefaultRequest != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 7)
+		x.xxx_hidden_DefaultRequest = b.DefaultRequest
+	}
+	if b.Min != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 7)
+		x.xxx_hidden_Min = b.Min
+	}
+	if b.Max != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 7)
+		x.xxx_hidden_Max = b.Max
+	}
+	if b.MaxLimitRequestRatio != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 7)
+		x.xxx_hidden_MaxLimitRequestRatio = b.MaxLimitRequestRatio
+	}
+	return m0
+}
+
+// LimitRangeSummary is the flattened set of constraints of one LimitRange.
+type LimitRangeSummary struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_Limits      *[]*LimitRangeItem     `protobuf:"bytes,2,rep,name=limits"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *LimitRangeSummary) Reset() {
+	*x = LimitRangeSummary{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LimitRangeSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LimitRangeSummary) ProtoMessage() {}
+
+func (x *LimitRangeSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *LimitRangeSummary) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *LimitRangeSummary) GetLimits() []*LimitRangeItem {
+	if x != nil {
+		if x.xxx_hidden_Limits != nil {
+			return *x.xxx_hidden_Limits
+		}
+	}
+	return nil
+}
+
+func (x *LimitRangeSummary) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *LimitRangeSummary) SetLimits(v []*LimitRangeItem) {
+	x.xxx_hidden_Limits = &v
+}
+
+func (x *LimitRangeSummary) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *LimitRangeSummary) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Name = nil
+}
+
+type LimitRangeSummary_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The name of the LimitRange.
+	Name *string
+	// Constraints sorted by type and resource.
+	Limits []*LimitRangeItem
+}
+
+func (b0 LimitRangeSummary_builder) Build() *LimitRangeSummary {
+	m0 := &LimitRangeSummary{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Name = b.Name
+	}
+	x.xxx_hidden_Limits = &b.Limits
+	return m0
+}
+
+// NamespaceQuotaResponse summarises the quotas and limit ranges of a namespace.
+type NamespaceQuotaResponse struct {
+	state                  protoimpl.MessageState   `protogen:"opaque.v1"`
+	xxx_hidden_Quotas      *[]*ResourceQuotaSummary `protobuf:"bytes,1,rep,name=quotas"`
+	xxx_hidden_LimitRanges *[]*LimitRangeSummary    `protobuf:"bytes,2,rep,name=limit_ranges,json=limitRanges"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *NamespaceQuotaResponse) Reset() {
+	*x = NamespaceQuotaResponse{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NamespaceQuotaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamespaceQuotaResponse) ProtoMessage() {}
+
+func (x *NamespaceQuotaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *NamespaceQuotaResponse) GetQuotas() []*ResourceQuotaSummary {
+	if x != nil {
+		if x.xxx_hidden_Quotas != nil {
+			return *x.xxx_hidden_Quotas
+		}
+	}
+	return nil
+}
+
+func (x *NamespaceQuotaResponse) GetLimitRanges() []*LimitRangeSummary {
+	if x != nil {
+		if x.xxx_hidden_LimitRanges != nil {
+			return *x.xxx_hidden_LimitRanges
+		}
+	}
+	return nil
+}
+
+func (x *NamespaceQuotaResponse) SetQuotas(v []*ResourceQuotaSummary) {
+	x.xxx_hidden_Quotas = &v
+}
+
+func (x *NamespaceQuotaResponse) SetLimitRanges(v []*LimitRangeSummary) {
+	x.xxx_hidden_LimitRanges = &v
+}
+
+type NamespaceQuotaResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// One entry per ResourceQuota in the namespace.
+	Quotas []*ResourceQuotaSummary
+	// One entry per LimitRange in the namespace.
+	LimitRanges []*LimitRangeSummary
+}
+
+func (b0 NamespaceQuotaResponse_builder) Build() *NamespaceQuotaResponse {
+	m0 := &NamespaceQuotaResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Quotas = &b.Quotas
+	x.xxx_hidden_LimitRanges = &b.LimitRanges
+	return m0
+}
+
+// CreateRequest defines the parameters for creating a new object.
+type CreateRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster        *string                `protobuf:"bytes,1,opt,name=cluster"`
+	xxx_hidden_Group          *string                `protobuf:"bytes,2,opt,name=group"`
+	xxx_hidden_Version        *string                `protobuf:"bytes,3,opt,name=version"`
+	xxx_hidden_Resource       *string                `protobuf:"bytes,4,opt,name=resource"`
+	xxx_hidden_Namespace      *string                `protobuf:"bytes,5,opt,name=namespace"`
+	xxx_hidden_Manifest       []byte                 `protobuf:"bytes,6,opt,name=manifest"`
+	xxx_hidden_CheckNamespace bool                   `protobuf:"varint,7,opt,name=check_namespace,json=checkNamespace"`
+	xxx_hidden_SkipManagedBy  bool                   `protobuf:"varint,8,opt,name=skip_managed_by,json=skipManagedBy"`
+	xxx_hidden_IdempotencyKey *string                `protobuf:"bytes,9,opt,name=idempotency_key,json=idempotencyKey"`
+	XXX_raceDetectHookData    protoimpl.RaceDetectHookData
+	XXX_presence              [1]uint32
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *CreateRequest) Reset() {
+	*x = CreateRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRequest) ProtoMessage() {}
+
+func (x *CreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CreateRequest) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CreateRequest) GetGroup() string {
+	if x != nil {
+		if x.xxx_hidden_Group != nil {
+			return *x.xxx_hidden_Group
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CreateRequest) GetVersion() string {
+	if x != nil {
+		if x.xxx_hidden_Version != nil {
+			return *x.xxx_hidden_Version
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CreateRequest) GetResource() string {
+	if x != nil {
+		if x.xxx_hidden_Resource != nil {
+			return *x.xxx_hidden_Resource
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CreateRequest) GetNamespace() string {
+	if x != nil {
+		if x.xxx_hidden_Namespace != nil {
+			return *x.xxx_hidden_Namespace
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CreateRequest) GetManifest() []byte {
+	if x != nil {
+		return x.xxx_hidden_Manifest
+	}
+	return nil
+}
+
+func (x *CreateRequest) GetCheckNamespace() bool {
+	if x != nil {
+		return x.xxx_hidden_CheckNamespace
+	}
+	return false
+}
+
+func (x *CreateRequest) GetSkipManagedBy() bool {
+	if x != nil {
+		return x.xxx_hidden_SkipManagedBy
+	}
+	return false
+}
+
+func (x *CreateRequest) GetIdempotencyKey() string {
+	if x != nil {
+		if x.xxx_hidden_IdempotencyKey != nil {
+			return *x.xxx_hidden_IdempotencyKey
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CreateRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 9)
+}
+
+func (x *CreateRequest) SetGroup(v string) {
+	x.xxx_hidden_Group = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 9)
+}
+
+func (x *CreateRequest) SetVersion(v string) {
+	x.xxx_hidden_Version = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 9)
+}
+
+func (x *CreateRequest) SetResource(v string) {
+	x.xxx_hidden_Resource = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 9)
+}
+
+func (x *CreateRequest) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 9)
+}
+
+func (x *CreateRequest) SetManifest(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Manifest = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 9)
+}
+
+func (x *CreateRequest) SetCheckNamespace(v bool) {
+	x.xxx_hidden_CheckNamespace = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 9)
+}
+
+func (x *CreateRequest) SetSkipManagedBy(v bool) {
+	x.xxx_hidden_SkipManagedBy = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 9)
+}
+
+func (x *CreateRequest) SetIdempotencyKey(v string) {
+	x.xxx_hidden_IdempotencyKey = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 9)
+}
+
+func (x *CreateRequest) HasCluster() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *CreateRequest) HasGroup() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *CreateRequest) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *CreateRequest) HasResource() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *CreateRequest) HasNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *CreateRequest) HasManifest() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *CreateRequest) HasCheckNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *CreateRequest) HasSkipManagedBy() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *CreateRequest) HasIdempotencyKey() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *CreateRequest) ClearCluster() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Cluster = nil
+}
+
+func (x *CreateRequest) ClearGroup() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Group = nil
+}
+
+func (x *CreateRequest) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Version = nil
+}
+
+func (x *CreateRequest) ClearResource() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Resource = nil
+}
+
+func (x *CreateRequest) ClearNamespace() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Namespace = nil
+}
+
+func (x *CreateRequest) ClearManifest() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Manifest = nil
+}
+
+func (x *CreateRequest) ClearCheckNamespace() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_CheckNamespace = false
+}
+
+func (x *CreateRequest) ClearSkipManagedBy() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_SkipManagedBy = false
+}
+
+func (x *CreateRequest) ClearIdempotencyKey() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_IdempotencyKey = nil
+}
+
+type CreateRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The target Kubernetes cluster identifier.
+	Cluster *string
+	// Kubernetes API Group (e.g., "apps" for Deployments, "" for core resources like Pods).
+	Group *string
+	// Kubernetes API Version (e.g., "v1").
+	Version *string
+	// Kubernetes API Resource name in plural (e.g., "pods", "deployments").
+	Resource *string
+	// The namespace of the resource.
+	Namespace *string
+	// The full manifest of the object to be created in YAML format. It must
+	// set exactly one of metadata.name and metadata.generateName.
+	Manifest []byte
+	// If true, the server first checks that the namespace exists and
+	// reports a missing namespace as InvalidArgument rather than as a
+	// NotFound for the resource. Leave unset when the namespace is known
+	// to exist to avoid the extra lookup.
+	CheckNamespace *bool
+	// If true, the server does not tag the object with its managed-by
+	// label and the otterscale.io/created-by annotation naming the caller.
+	SkipManagedBy *bool
+	// A client-chosen key, at most 256 characters, identifying this
+	// create across retries. A retry with the same key and request within
+	// a few minutes of a successful create returns the object it created
+	// instead of AlreadyExists; reusing the key for a different request
+	// fails with InvalidArgument. Keys are scoped to the caller. Each
+	// server replica remembers the keys it has seen, so a retry is only
+	// deduplicated when it reaches the same replica as the first attempt.
+	IdempotencyKey *string
+}
+
+func (b0 CreateRequest_builder) Build() *CreateRequest {
+	m0 := &CreateRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Cluster != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 9)
+		x.xxx_hidden_Cluster = b.Cluster
+	}
+	if b.Group != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 9)
+		x.xxx_hidden_Group = b.Group
+	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 9)
+		x.xxx_hidden_Version = b.Version
+	}
+	if b.Resource != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 9)
+		x.xxx_hidden_Resource = b.Resource
+	}
+	if b.Namespace != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 9)
+		x.xxx_hidden_Namespace = b.Namespace
+	}
+	if b.Manifest != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 9)
+		x.xxx_hidden_Manifest = b.Manifest
+	}
+	if b.CheckNamespace != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 9)
+		x.xxx_hidden_CheckNamespace = *b.CheckNamespace
+	}
+	if b.SkipManagedBy != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 9)
+		x.xxx_hidden_SkipManagedBy = *b.SkipManagedBy
+	}
+	if b.IdempotencyKey != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 9)
+		x.xxx_hidden_IdempotencyKey = b.IdempotencyKey
+	}
+	return m0
+}
+
+// ApplyRequest defines the parameters for Server-Side Apply (SSA).
+type ApplyRequest struct {
+	state                         protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster            *string                `protobuf:"bytes,1,opt,name=cluster"`
+	xxx_hidden_Group              *string                `protobuf:"bytes,2,opt,name=group"`
+	xxx_hidden_Version            *string                `protobuf:"bytes,3,opt,name=version"`
+	xxx_hidden_Resource           *string                `protobuf:"bytes,4,opt,name=resource"`
+	xxx_hidden_Namespace          *string                `protobuf:"bytes,5,opt,name=namespace"`
+	xxx_hidden_Name               *string                `protobuf:"bytes,6,opt,name=name"`
+	xxx_hidden_Manifest           []byte                 `protobuf:"bytes,7,opt,name=manifest"`
+	xxx_hidden_Force              bool                   `protobuf:"varint,8,opt,name=force"`
+	xxx_hidden_FieldManager       *string                `protobuf:"bytes,9,opt,name=field_manager,json=fieldManager"`
+	xxx_hidden_CheckNamespace     bool                   `protobuf:"varint,10,opt,name=check_namespace,json=checkNamespace"`
+	xxx_hidden_SkipManagedBy      bool                   `protobuf:"varint,11,opt,name=skip_managed_by,json=skipManagedBy"`
+	xxx_hidden_WaitTimeoutSeconds int64                  `protobuf:"varint,12,opt,name=wait_timeout_seconds,json=waitTimeoutSeconds"`
+	XXX_raceDetectHookData        protoimpl.RaceDetectHookData
+	XXX_presence                  [1]uint32
+	unknownFields                 protoimpl.UnknownFields
+	sizeCache                     protoimpl.SizeCache
+}
+
+func (x *ApplyRequest) Reset() {
+	*x = ApplyRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyRequest) ProtoMessage() {}
+
+func (x *ApplyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ApplyRequest) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyRequest) GetGroup() string {
+	if x != nil {
+		if x.xxx_hidden_Group != nil {
+			return *x.xxx_hidden_Group
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyRequest) GetVersion() string {
+	if x != nil {
+		if x.xxx_hidden_Version != nil {
+			return *x.xxx_hidden_Version
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyRequest) GetResource() string {
+	if x != nil {
+		if x.xxx_hidden_Resource != nil {
+			return *x.xxx_hidden_Resource
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyRequest) GetNamespace() string {
+	if x != nil {
+		if x.xxx_hidden_Namespace != nil {
+			return *x.xxx_hidden_Namespace
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyRequest) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyRequest) GetManifest() []byte {
+	if x != nil {
+		return x.xxx_hidden_Manifest
+	}
+	return nil
+}
+
+func (x *ApplyRequest) GetForce() bool {
+	if x != nil {
+		return x.xxx_hidden_Force
+	}
+	return false
+}
+
+func (x *ApplyRequest) GetFieldManager() string {
+	if x != nil {
+		if x.xxx_hidden_FieldManager != nil {
+			return *x.xxx_hidden_FieldManager
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyRequest) GetCheckNamespace() bool {
+	if x != nil {
+		return x.xxx_hidden_CheckNamespace
+	}
+	return false
+}
+
+func (x *ApplyRequest) GetSkipManagedBy() bool {
+	if x != nil {
+		return x.xxx_hidden_SkipManagedBy
+	}
+	return false
+}
+
+func (x *ApplyRequest) GetWaitTimeoutSeconds() int64 {
+	if x != nil {
+		return x.xxx_hidden_WaitTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *ApplyRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 12)
+}
+
+func (x *ApplyRequest) SetGroup(v string) {
+	x.xxx_hidden_Group = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 12)
+}
+
+func (x *ApplyRequest) SetVersion(v string) {
+	x.xxx_hidden_Version = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 12)
+}
+
+func (x *ApplyRequest) SetResource(v string) {
+	x.xxx_hidden_Resource = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 12)
+}
+
+func (x *ApplyRequest) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 12)
+}
+
+func (x *ApplyRequest) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 12)
+}
+
+func (x *ApplyRequest) SetManifest(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Manifest = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 12)
+}
+
+func (x *ApplyRequest) SetForce(v bool) {
+	x.xxx_hidden_Force = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 12)
+}
+
+func (x *ApplyRequest) SetFieldManager(v string) {
+	x.xxx_hidden_FieldManager = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 12)
+}
+
+func (x *ApplyRequest) SetCheckNamespace(v bool) {
+	x.xxx_hidden_CheckNamespace = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 12)
+}
+
+func (x *ApplyRequest) SetSkipManagedBy(v bool) {
+	x.xxx_hidden_SkipManagedBy = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 10, 12)
+}
+
+func (x *ApplyRequest) SetWaitTimeoutSeconds(v int64) {
+	x.xxx_hidden_WaitTimeoutSeconds = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 11, 12)
+}
+
+func (x *ApplyRequest) HasCluster() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ApplyRequest) HasGroup() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ApplyRequest) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ApplyRequest) HasResource() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *ApplyRequest) HasNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *ApplyRequest) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *ApplyRequest) HasManifest() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *ApplyRequest) HasForce() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *ApplyRequest) HasFieldManager() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *ApplyRequest) HasCheckNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
+}
+
+func (x *ApplyRequest) HasSkipManagedBy() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 10)
+}
+
+func (x *ApplyRequest) HasWaitTimeoutSeconds() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 11)
+}
+
+func (x *ApplyRequest) ClearCluster() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Cluster = nil
+}
+
+func (x *ApplyRequest) ClearGroup() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Group = nil
+}
+
+func (x *ApplyRequest) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Version = nil
+}
+
+func (x *ApplyRequest) ClearResource() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Resource = nil
+}
+
+func (x *ApplyRequest) ClearNamespace() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Namespace = nil
+}
+
+func (x *ApplyRequest) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *ApplyRequest) ClearManifest() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_Manifest = nil
+}
+
+func (x *ApplyRequest) ClearForce() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_Force = false
+}
+
+func (x *ApplyRequest) ClearFieldManager() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_FieldManager = nil
+}
+
+func (x *ApplyRequest) ClearCheckNamespace() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
+	x.xxx_hidden_CheckNamespace = false
+}
+
+func (x *ApplyRequest) ClearSkipManagedBy() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 10)
+	x.xxx_hidden_SkipManagedBy = false
+}
+
+func (x *ApplyRequest) ClearWaitTimeoutSeconds() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 11)
+	x.xxx_hidden_WaitTimeoutSeconds = 0
+}
+
+type ApplyRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The target Kubernetes cluster identifier.
+	Cluster *string
+	// Kubernetes API Group (e.g., "apps" for Deployments, "" for core resources like Pods).
+	Group *string
+	// Kubernetes API Version (e.g., "v1").
+	Version *string
+	// Kubernetes API Resource name in plural (e.g., "pods", "deployments").
+	Resource *string
+	// The namespace of the resource.
+	Namespace *string
+	// The name of the resource.
+	Name *string
+	// A partial or YAML manifest in JSON format to be merged by the API server.
+	Manifest []byte
+	// If true, conflicts are resolved in favour of the caller's field manager.
+	Force *bool
+	// Identifies the entity managing the fields (e.g., "otterscale-web-ui").
+	// Defaults to the server's configured prefix followed by the caller's
+	// subject (e.g., "otterscale:alice"), so that ownership is per user.
+	FieldManager *string
+	// If true, the server first checks that the namespace exists. See
+	// CreateRequest.check_namespace.
+	CheckNamespace *bool
+	// If true, the server does not tag the object with its managed-by
+	// label and created-by annotation. See CreateRequest.skip_managed_by;
+	// objects that already exist keep the subject that created them.
+	SkipManagedBy *bool
+	// How long to wait, in seconds, for the applied object to become ready
+	// before it is returned, at most 300. See
+	// ApplyManifestRequest.wait_timeout_seconds. A timeout returns
+	// DEADLINE_EXCEEDED although the apply took effect. Zero, the default,
+	// does not wait. Ignored by ForceApply.
+	WaitTimeoutSeconds *int64
+}
+
+func (b0 ApplyRequest_builder) Build() *ApplyRequest {
+	m0 := &ApplyRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Cluster != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 12)
+		x.xxx_hidden_Cluster = b.Cluster
+	}
+	if b.Group != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 12)
+		x.xxx_hidden_Group = b.Group
+	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 12)
+		x.xxx_hidden_Version = b.Version
+	}
+	if b.Resource != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 12)
+		x.xxx_hidden_Resource = b.Resource
+	}
+	if b.Namespace != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 12)
+		x.xxx_hidden_Namespace = b.Namespace
+	}
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 12)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.Manifest != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 12)
+		x.xxx_hidden_Manifest = b.Manifest
+	}
+	if b.Force != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 12)
+		x.xxx_hidden_Force = *b.Force
+	}
+	if b.FieldManager != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 12)
+		x.xxx_hidden_FieldManager = b.FieldManager
+	}
+	if b.CheckNamespace != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 12)
+		x.xxx_hidden_CheckNamespace = *b.CheckNamespace
+	}
+	if b.SkipManagedBy != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 10, 12)
+		x.xxx_hidden_SkipManagedBy = *b.SkipManagedBy
+	}
+	if b.WaitTimeoutSeconds != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 11, 12)
+		x.xxx_hidden_WaitTimeoutSeconds = *b.WaitTimeoutSeconds
+	}
+	return m0
+}
+
+// ApplyConflict is a field that an apply would change but that is owned
+// by another field manager.
+type ApplyConflict struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Manager     *string                `protobuf:"bytes,1,opt,name=manager"`
+	xxx_hidden_Field       *string                `protobuf:"bytes,2,opt,name=field"`
+	xxx_hidden_Message     *string                `protobuf:"bytes,3,opt,name=message"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ApplyConflict) Reset() {
+	*x = ApplyConflict{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyConflict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyConflict) ProtoMessage() {}
+
+func (x *ApplyConflict) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ApplyConflict) GetManager() string {
+	if x != nil {
+		if x.xxx_hidden_Manager != nil {
+			return *x.xxx_hidden_Manager
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyConflict) GetField() string {
+	if x != nil {
+		if x.xxx_hidden_Field != nil {
+			return *x.xxx_hidden_Field
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyConflict) GetMessage() string {
+	if x != nil {
+		if x.xxx_hidden_Message != nil {
+			return *x.xxx_hidden_Message
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyConflict) SetManager(v string) {
+	x.xxx_hidden_Manager = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *ApplyConflict) SetField(v string) {
+	x.xxx_hidden_Field = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *ApplyConflict) SetMessage(v string) {
+	x.xxx_hidden_Message = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *ApplyConflict) HasManager() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ApplyConflict) HasField() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ApplyConflict) HasMessage() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ApplyConflict) ClearManager() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Manager = nil
+}
+
+func (x *ApplyConflict) ClearField() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Field = nil
+}
+
+func (x *ApplyConflict) ClearMessage() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Message = nil
+}
+
+type ApplyConflict_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The field manager that owns the field.
+	Manager *string
+	// The path of the field (e.g., ".spec.replicas").
+	Field *string
+	// The API server's description of the conflict.
+	Message *string
+}
+
+func (b0 ApplyConflict_builder) Build() *ApplyConflict {
+	m0 := &ApplyConflict{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Manager != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Manager = b.Manager
+	}
+	if b.Field != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Field = b.Field
+	}
+	if b.Message != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Message = b.Message
+	}
+	return m0
+}
+
+// ApplyConflictDetails is attached as an error detail to the
+// FAILED_PRECONDITION error returned by Apply when the apply would change
+// fields owned by other field managers.
+type ApplyConflictDetails struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Conflicts *[]*ApplyConflict      `protobuf:"bytes,1,rep,name=conflicts"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ApplyConflictDetails) Reset() {
+	*x = ApplyConflictDetails{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyConflictDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyConflictDetails) ProtoMessage() {}
+
+func (x *ApplyConflictDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ApplyConflictDetails) GetConflicts() []*ApplyConflict {
+	if x != nil {
+		if x.xxx_hidden_Conflicts != nil {
+			return *x.xxx_hidden_Conflicts
+		}
+	}
+	return nil
+}
+
+func (x *ApplyConflictDetails) SetConflicts(v []*ApplyConflict) {
+	x.xxx_hidden_Conflicts = &v
+}
+
+type ApplyConflictDetails_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The contested fields.
+	Conflicts []*ApplyConflict
+}
+
+func (b0 ApplyConflictDetails_builder) Build() *ApplyConflictDetails {
+	m0 := &ApplyConflictDetails{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Conflicts = &b.Conflicts
+	return m0
+}
+
+// AdmissionDenial is attached as an error detail to errors returned by
+// Create, Apply and Delete when an admission webhook rejected the request.
+// The error code is the one the webhook chose, typically INVALID_ARGUMENT
+// or PERMISSION_DENIED.
+type AdmissionDenial struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Webhook     *string                `protobuf:"bytes,1,opt,name=webhook"`
+	xxx_hidden_Reason      *string                `protobuf:"bytes,2,opt,name=reason"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *AdmissionDenial) Reset() {
+	*x = AdmissionDenial{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdmissionDenial) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdmissionDenial) ProtoMessage() {}
+
+func (x *AdmissionDenial) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *AdmissionDenial) GetWebhook() string {
+	if x != nil {
+		if x.xxx_hidden_Webhook != nil {
+			return *x.xxx_hidden_Webhook
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AdmissionDenial) GetReason() string {
+	if x != nil {
+		if x.xxx_hidden_Reason != nil {
+			return *x.xxx_hidden_Reason
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *AdmissionDenial) SetWebhook(v string) {
+	x.xxx_hidden_Webhook = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *AdmissionDenial) SetReason(v string) {
+	x.xxx_hidden_Reason = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *AdmissionDenial) HasWebhook() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *AdmissionDenial) HasReason() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *AdmissionDenial) ClearWebhook() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Webhook = nil
+}
+
+func (x *AdmissionDenial) ClearReason() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Reason = nil
+}
+
+type AdmissionDenial_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The name of the webhook that rejected the request (e.g., "validate.kyverno.svc").
+	Webhook *string
+	// The webhook's explanation. Empty if it gave none.
+	Reason *string
+}
+
+func (b0 AdmissionDenial_builder) Build() *AdmissionDenial {
+	m0 := &AdmissionDenial{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Webhook != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Webhook = b.Webhook
+	}
+	if b.Reason != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Reason = b.Reason
+	}
+	return m0
+}
+
+// ForceApplyResponse is the result of ForceApply.
+type ForceApplyResponse struct {
+	state                 protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Resource   *Resource              `protobuf:"bytes,1,opt,name=resource"`
+	xxx_hidden_Overridden *[]*ApplyConflict      `protobuf:"bytes,2,rep,name=overridden"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ForceApplyResponse) Reset() {
+	*x = ForceApplyResponse{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForceApplyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceApplyResponse) ProtoMessage() {}
+
+func (x *ForceApplyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ForceApplyResponse) GetResource() *Resource {
+	if x != nil {
+		return x.xxx_hidden_Resource
+	}
+	return nil
+}
+
+func (x *ForceApplyResponse) GetOverridden() []*ApplyConflict {
+	if x != nil {
+		if x.xxx_hidden_Overridden != nil {
+			return *x.xxx_hidden_Overridden
+		}
+	}
+	return nil
+}
+
+func (x *ForceApplyResponse) SetResource(v *Resource) {
+	x.xxx_hidden_Resource = v
+}
+
+func (x *ForceApplyResponse) SetOverridden(v []*ApplyConflict) {
+	x.xxx_hidden_Overridden = &v
+}
+
+func (x *ForceApplyResponse) HasResource() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Resource != nil
+}
+
+func (x *ForceApplyResponse) ClearResource() {
+	x.xxx_hidden_Resource = nil
+}
+
+type ForceApplyResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The applied resource.
+	Resource *Resource
+	// The fields taken over from other field managers; empty if the apply
+	// did not conflict.
+	Overridden []*ApplyConflict
+}
+
+func (b0 ForceApplyResponse_builder) Build() *ForceApplyResponse {
+	m0 := &ForceApplyResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Resource = b.Resource
+	x.xxx_hidden_Overridden = &b.Overridden
+	return m0
+}
+
+// SetLabelRequest identifies a resource and the label to set.
+type SetLabelRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster     *string                `protobuf:"bytes,1,opt,name=cluster"`
+	xxx_hidden_Group       *string                `protobuf:"bytes,2,opt,name=group"`
+	xxx_hidden_Version     *string                `protobuf:"bytes,3,opt,name=version"`
+	xxx_hidden_Resource    *string                `protobuf:"bytes,4,opt,name=resource"`
+	xxx_hidden_Namespace   *string                `protobuf:"bytes,5,opt,name=namespace"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,6,opt,name=name"`
+	xxx_hidden_Key         *string                `protobuf:"bytes,7,opt,name=key"`
+	xxx_hidden_Value       *string                `protobuf:"bytes,8,opt,name=value"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SetLabelRequest) Reset() {
+	*x = SetLabelRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLabelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLabelRequest) ProtoMessage() {}
+
+func (x *SetLabelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SetLabelRequest) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SetLabelRequest) GetGroup() string {
+	if x != nil {
+		if x.xxx_hidden_Group != nil {
+			return *x.xxx_hidden_Group
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SetLabelRequest) GetVersion() string {
+	if x != nil {
+		if x.xxx_hidden_Version != nil {
+			return *x.xxx_hidden_Version
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SetLabelRequest) GetResource() string {
+	if x != nil {
+		if x.xxx_hidden_Resource != nil {
+			return *x.xxx_hidden_Resource
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SetLabelRequest) GetNamespace() string {
+	if x != nil {
+		if x.xxx_hidden_Namespace != nil {
+			return *x.xxx_hidden_Namespace
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SetLabelRequest) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SetLabelRequest) GetKey() string {
+	if x != nil {
+		if x.xxx_hidden_Key != nil {
+			return *x.xxx_hidden_Key
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SetLabelRequest) GetValue() string {
+	if x != nil {
+		if x.xxx_hidden_Value != nil {
+			return *x.xxx_hidden_Value
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SetLabelRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 8)
+}
+
+func (x *SetLabelRequest) SetGroup(v string) {
+	x.xxx_hidden_Group = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 8)
+}
+
+func (x *SetLabelRequest) SetVersion(v string) {
+	x.xxx_hidden_Version = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 8)
+}
+
+func (x *SetLabelRequest) SetResource(v string) {
+	x.xxx_hidden_Resource = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 8)
+}
+
+func (x *SetLabelRequest) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 8)
+}
+
+func (x *SetLabelRequest) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 8)
+}
+
+func (x *SetLabelRequest) SetKey(v string) {
+	x.xxx_hidden_Key = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 8)
+}
+
+func (x *SetLabelRequest) SetValue(v string) {
+	x.xxx_hidden_Value = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 8)
+}
+
+func (x *SetLabelRequest) HasCluster() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *SetLabelRequest) HasGroup() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *SetLabelRequest) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *SetLabelRequest) HasResource() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *SetLabelRequest) HasNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *SetLabelRequest) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *SetLabelRequest) HasKey() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *SetLabelRequest) HasValue() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *SetLabelRequest) ClearCluster() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Cluster = nil
+}
+
+func (x *SetLabelRequest) ClearGroup() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Group = nil
+}
+
+func (x *SetLabelRequest) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Version = nil
+}
+
+func (x *SetLabelRequest) ClearResource() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Resource = nil
+}
+
+func (x *SetLabelRequest) ClearNamespace() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Namespace = nil
+}
+
+func (x *SetLabelRequest) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *SetLabelRequest) ClearKey() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_Key = nil
+}
+
+func (x *SetLabelRequest) ClearValue() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_Value = nil
+}
+
+type SetLabelRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The target Kubernetes cluster identifier.
+	Cluster *string
+	// Kubernetes API Group (e.g., "apps" for Deployments, "" for core resources like Pods).
+	Group *string
+	// Kubernetes API Version (e.g., "v1").
+	Version *string
+	// Kubernetes API Resource name in plural (e.g., "pods", "deployments").
+	Resource *string
+	// The namespace of the resource.
+	Namespace *string
+	// The name of the resource.
+	Name *string
+	// The label key (e.g., "app.kubernetes.io/name").
+	Key *string
+	// The label value.
+	Value *string
+}
+
+func (b0 SetLabelRequest_builder) Build() *SetLabelRequest {
+	m0 := &SetLabelRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Cluster != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 8)
+		x.xxx_hidden_Cluster = b.Cluster
+	}
+	if b.Group != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 8)
+		x.xxx_hidden_Group = b.Group
+	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 8)
+		x.xxx_hidden_Version = b.Version
+	}
+	if b.Resource != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 8)
+		x.xxx_hidden_Resource = b.Resource
+	}
+	if b.Namespace != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 8)
+		x.xxx_hidden_Namespace = b.Namespace
+	}
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 8)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.Key != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 8)
+		x.xxx_hidden_Key = b.Key
+	}
+	if b.Value != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 8)
+		x.xxx_hidden_Value = b.Value
+	}
+	return m0
+}
+
+// RemoveLabelRequest identifies a resource and the label to remove.
+type RemoveLabelRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster     *string                `protobuf:"bytes,1,opt,name=cluster"`
+	xxx_hidden_Group       *string                `protobuf:"bytes,2,opt,name=group"`
+	xxx_hidden_Version     *string                `protobuf:"bytes,3,opt,name=version"`
+	xxx_hidden_Resource    *string                `protobuf:"bytes,4,opt,name=resource"`
+	xxx_hidden_Namespace   *string                `protobuf:"bytes,5,opt,name=namespace"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,6,opt,name=name"`
+	xxx_hidden_Key         *string                `protobuf:"bytes,7,opt,name=key"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RemoveLabelRequest) Reset() {
+	*x = RemoveLabelRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveLabelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveLabelRequest) ProtoMessage() {}
+
+func (x *RemoveLabelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RemoveLabelRequest) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RemoveLabelRequest) GetGroup() string {
+	if x != nil {
+		if x.xxx_hidden_Group != nil {
+			return *x.xxx_hidden_Group
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RemoveLabelRequest) GetVersion() string {
+	if x != nil {
+		if x.xxx_hidden_Version != nil {
+			return *x.xxx_hidden_Version
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RemoveLabelRequest) GetResource() string {
+	if x != nil {
+		if x.xxx_hidden_Resource != nil {
+			return *x.xxx_hidden_Resource
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RemoveLabelRequest) GetNamespace() string {
+	if x != nil {
+		if x.xxx_hidden_Namespace != nil {
+			return *x.xxx_hidden_Namespace
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RemoveLabelRequest) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RemoveLabelRequest) GetKey() string {
+	if x != nil {
+		if x.xxx_hidden_Key != nil {
+			return *x.xxx_hidden_Key
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RemoveLabelRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 7)
+}
+
+func (x *RemoveLabelRequest) SetGroup(v string) {
+	x.xxx_hidden_Group = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
+}
+
+func (x *RemoveLabelRequest) SetVersion(v string) {
+	x.xxx_hidden_Version = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 7)
+}
+
+func (x *RemoveLabelRequest) SetResource(v string) {
+	x.xxx_hidden_Resource = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 7)
+}
+
+func (x *RemoveLabelRequest) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 7)
+}
+
+func (x *RemoveLabelRequest) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 7)
+}
+
+func (x *RemoveLabelRequest) SetKey(v string) {
+	x.xxx_hidden_Key = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 7)
+}
+
+func (x *RemoveLabelRequest) HasCluster() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RemoveLabelRequest) HasGroup() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *RemoveLabelRequest) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *RemoveLabelRequest) HasResource() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *RemoveLabelRequest) HasNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *RemoveLabelRequest) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *RemoveLabelRequest) HasKey() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *RemoveLabelRequest) ClearCluster() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Cluster = nil
+}
+
+func (x *RemoveLabelRequest) ClearGroup() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Group = nil
+}
+
+func (x *RemoveLabelRequest) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Version = nil
+}
+
+func (x *RemoveLabelRequest) ClearResource() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Resource = nil
+}
+
+func (x *RemoveLabelRequest) ClearNamespace() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Namespace = nil
+}
+
+func (x *RemoveLabelRequest) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *RemoveLabelRequest) ClearKey() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_Key = nil
+}
+
+type RemoveLabelRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The target Kubernetes cluster identifier.
+	Cluster *string
+	// Kubernetes API Group (e.g., "apps" for Deployments, "" for core resources like Pods).
+	Group *string
+	// Kubernetes API Version (e.g., "v1").
+	Version *string
+	// Kubernetes API Resource name in plural (e.g., "pods", "deployments").
+	Resource *string
+	// The namespace of the resource.
+	Namespace *string
+	// The name of the resource.
+	Name *string
+	// The label key (e.g., "app.kubernetes.io/name").
+	Key *string
+}
+
+func (b0 RemoveLabelRequest_builder) Build() *RemoveLabelRequest {
+	m0 := &RemoveLabelRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Cluster != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 7)
+		x.xxx_hidden_Cluster = b.Cluster
+	}
+	if b.Group != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 7)
+		x.xxx_hidden_Group = b.Group
+	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 7)
+		x.xxx_hidden_Version = b.Version
+	}
+	if b.Resource != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 7)
+		x.xxx_hidden_Resource = b.Resource
+	}
+	if b.Namespace != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 7)
+		x.xxx_hidden_Namespace = b.Namespace
+	}
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 7)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.Key != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 7)
+		x.xxx_hidden_Key = b.Key
+	}
+	return m0
+}
+
+// SetAnnotationRequest identifies a resource and the annotation to set.
+type SetAnnotationRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster     *string                `protobuf:"bytes,1,opt,name=cluster"`
+	xxx_hidden_Group       *string                `protobuf:"bytes,2,opt,name=group"`
+	xxx_hidden_Version     *string                `protobuf:"bytes,3,opt,name=version"`
+	xxx_hidden_Resource    *string                `protobuf:"bytes,4,opt,name=resource"`
+	xxx_hidden_Namespace   *string                `protobuf:"bytes,5,opt,name=namespace"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,6,opt,name=name"`
+	xxx_hidden_Key         *string                `protobuf:"bytes,7,opt,name=key"`
+	xxx_hidden_Value       *string                `protobuf:"bytes,8,opt,name=value"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SetAnnotationRequest) Reset() {
+	*x = SetAnnotationRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAnnotationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAnnotationRequest) ProtoMessage() {}
+
+func (x *SetAnnotationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SetAnnotationRequest) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SetAnnotationRequest) GetGroup() string {
+	if x != nil {
+		if x.xxx_hidden_Group != nil {
+			return *x.xxx_hidden_Group
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SetAnnotationRequest) GetVersion() string {
+	if x != nil {
+		if x.xxx_hidden_Version != nil {
+			return *x.xxx_hidden_Version
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SetAnnotationRequest) GetResource() string {
+	if x != nil {
+		if x.xxx_hidden_Resource != nil {
+			return *x.xxx_hidden_Resource
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SetAnnotationRequest) GetNamespace() string {
+	if x != nil {
+		if x.xxx_hidden_Namespace != nil {
+			return *x.xxx_hidden_Namespace
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SetAnnotationRequest) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SetAnnotationRequest) GetKey() string {
+	if x != nil {
+		if x.xxx_hidden_Key != nil {
+			return *x.xxx_hidden_Key
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SetAnnotationRequest) GetValue() string {
+	if x != nil {
+		if x.xxx_hidden_Value != nil {
+			return *x.xxx_hidden_Value
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SetAnnotationRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 8)
+}
+
+func (x *SetAnnotationRequest) SetGroup(v string) {
+	x.xxx_hidden_Group = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 8)
+}
+
+func (x *SetAnnotationRequest) SetVersion(v string) {
+	x.xxx_hidden_Version = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 8)
+}
+
+func (x *SetAnnotationRequest) SetResource(v string) {
+	x.xxx_hidden_Resource = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 8)
+}
+
+func (x *SetAnnotationRequest) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 8)
+}
+
+func (x *SetAnnotationRequest) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 8)
+}
+
+func (x *SetAnnotationRequest) SetKey(v string) {
+	x.xxx_hidden_Key = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 8)
+}
+
+func (x *SetAnnotationRequest) SetValue(v string) {
+	x.xxx_hidden_Value = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 8)
+}
+
+func (x *SetAnnotationRequest) HasCluster() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *SetAnnotationRequest) HasGroup() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *SetAnnotationRequest) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *SetAnnotationRequest) HasResource() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *SetAnnotationRequest) HasNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *SetAnnotationRequest) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *SetAnnotationRequest) HasKey() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *SetAnnotationRequest) HasValue() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *SetAnnotationRequest) ClearCluster() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Cluster = nil
+}
+
+func (x *SetAnnotationRequest) ClearGroup() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Group = nil
+}
+
+func (x *SetAnnotationRequest) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Version = nil
+}
+
+func (x *SetAnnotationRequest) ClearResource() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Resource = nil
+}
+
+func (x *SetAnnotationRequest) ClearNamespace() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Namespace = nil
+}
+
+func (x *SetAnnotationRequest) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *SetAnnotationRequest) ClearKey() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_Key = nil
+}
+
+func (x *SetAnnotationRequest) ClearValue() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_Value = nil
+}
+
+type SetAnnotationRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The target Kubernetes cluster identifier.
+	Cluster *string
+	// Kubernetes API Group (e.g., "apps" for Deployments, "" for core resources like Pods).
+	Group *string
+	// Kubernetes API Version (e.g., "v1").
+	Version *string
+	// Kubernetes API Resource name in plural (e.g., "pods", "deployments").
+	Resource *string
+	// The namespace of the resource.
+	Namespace *string
+	// The name of the resource.
+	Name *string
+	// The annotation key (e.g., "app.kubernetes.io/name").
+	Key *string
+	// The annotation value.
+	Value *string
+}
+
+func (b0 SetAnnotationRequest_builder) Build() *SetAnnotationRequest {
+	m0 := &SetAnnotationRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Cluster != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 8)
+		x.xxx_hidden_Cluster = b.Cluster
+	}
+	if b.Group != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 8)
+		x.xxx_hidden_Group = b.Group
+	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 8)
+		x.xxx_hidden_Version = b.Version
+	}
+	if b.Resource != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 8)
+		x.xxx_hidden_Resource = b.Resource
+	}
+	if b.Namespace != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 8)
+		x.xxx_hidden_Namespace = b.Namespace
+	}
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 8)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.Key != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 8)
+		x.xxx_hidden_Key = b.Key
+	}
+	if b.Value != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 8)
+		x.xxx_hidden_Value = b.Value
+	}
+	return m0
+}
+
+// RemoveAnnotationRequest identifies a resource and the annotation to remove.
+type RemoveAnnotationRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster     *string                `protobuf:"bytes,1,opt,name=cluster"`
+	xxx_hidden_Group       *string                `protobuf:"bytes,2,opt,name=group"`
+	xxx_hidden_Version     *string                `protobuf:"bytes,3,opt,name=version"`
+	xxx_hidden_Resource    *string                `protobuf:"bytes,4,opt,name=resource"`
+	xxx_hidden_Namespace   *string                `protobuf:"bytes,5,opt,name=namespace"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,6,opt,name=name"`
+	xxx_hidden_Key         *string                `protobuf:"bytes,7,opt,name=key"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RemoveAnnotationRequest) Reset() {
+	*x = RemoveAnnotationRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveAnnotationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveAnnotationRequest) ProtoMessage() {}
+
+func (x *RemoveAnnotationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RemoveAnnotationRequest) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RemoveAnnotationRequest) GetGroup() string {
+	if x != nil {
+		if x.xxx_hidden_Group != nil {
+			return *x.xxx_hidden_Group
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RemoveAnnotationRequest) GetVersion() string {
+	if x != nil {
+		if x.xxx_hidden_Version != nil {
+			return *x.xxx_hidden_Version
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RemoveAnnotationRequest) GetResource() string {
+	if x != nil {
+		if x.xxx_hidden_Resource != nil {
+			return *x.xxx_hidden_Resource
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RemoveAnnotationRequest) GetNamespace() string {
+	if x != nil {
+		if x.xxx_hidden_Namespace != nil {
+			return *x.xxx_hidden_Namespace
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RemoveAnnotationRequest) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RemoveAnnotationRequest) GetKey() string {
+	if x != nil {
+		if x.xxx_hidden_Key != nil {
+			return *x.xxx_hidden_Key
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RemoveAnnotationRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 7)
+}
+
+func (x *RemoveAnnotationRequest) SetGroup(v string) {
+	x.xxx_hidden_Group = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
+}
+
+func (x *RemoveAnnotationRequest) SetVersion(v string) {
+	x.xxx_hidden_Version = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 7)
+}
+
+func (x *RemoveAnnotationRequest) SetResource(v string) {
+	x.xxx_hidden_Resource = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 7)
+}
+
+func (x *RemoveAnnotationRequest) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 7)
+}
+
+func (x *RemoveAnnotationRequest) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 7)
+}
+
+func (x *RemoveAnnotationRequest) SetKey(v string) {
+	x.xxx_hidden_Key = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 7)
+}
+
+func (x *RemoveAnnotationRequest) HasCluster() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RemoveAnnotationRequest) HasGroup() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *RemoveAnnotationRequest) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *RemoveAnnotationRequest) HasResource() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *RemoveAnnotationRequest) HasNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *RemoveAnnotationRequest) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *RemoveAnnotationRequest) HasKey() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *RemoveAnnotationRequest) ClearCluster() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Cluster = nil
+}
+
+func (x *RemoveAnnotationRequest) ClearGroup() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Group = nil
+}
+
+func (x *RemoveAnnotationRequest) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Version = nil
+}
+
+func (x *RemoveAnnotationRequest) ClearResource() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Resource = nil
+}
+
+func (x *RemoveAnnotationRequest) ClearNamespace() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Namespace = nil
+}
+
+func (x *RemoveAnnotationRequest) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *RemoveAnnotationRequest) ClearKey() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_Key = nil
+}
+
+type RemoveAnnotationRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The target Kubernetes cluster identifier.
+	Cluster *string
+	// Kubernetes API Group (e.g., "apps" for Deployments, "" for core resources like Pods).
+	Group *string
+	// Kubernetes API Version (e.g., "v1").
+	Version *string
+	// Kubernetes API Resource name in plural (e.g., "pods", "deployments").
+	Resource *string
+	// The namespace of the resource.
+	Namespace *string
+	// The name of the resource.
+	Name *string
+	// The annotation key (e.g., "app.kubernetes.io/name").
+	Key *string
+}
+
+func (b0 RemoveAnnotationRequest_builder) Build() *RemoveAnnotationRequest {
+	m0 := &RemoveAnnotationRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Cluster != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 7)
+		x.xxx_hidden_Cluster = b.Cluster
+	}
+	if b.Group != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 7)
+		x.xxx_hidden_Group = b.Group
+	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 7)
+		x.xxx_hidden_Version = b.Version
+	}
+	if b.Resource != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 7)
+		x.xxx_hidden_Resource = b.Resource
+	}
+	if b.Namespace != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 7)
+		x.xxx_hidden_Namespace = b.Namespace
+	}
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 7)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.Key != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 7)
+		x.xxx_hidden_Key = b.Key
+	}
+	return m0
+}
+
+// DeleteRequest defines the parameters to remove an object.
+type DeleteRequest struct {
+	state                         protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster            *string                `protobuf:"bytes,1,opt,name=cluster"`
+	xxx_hidden_Group              *string                `protobuf:"bytes,2,opt,name=group"`
+	xxx_hidden_Version            *string                `protobuf:"bytes,3,opt,name=version"`
+	xxx_hidden_Resource           *string                `protobuf:"bytes,4,opt,name=resource"`
+	xxx_hidden_Namespace          *string                `protobuf:"bytes,5,opt,name=namespace"`
+	xxx_hidden_Name               *string                `protobuf:"bytes,6,opt,name=name"`
+	xxx_hidden_GracePeriodSeconds int64                  `protobuf:"varint,7,opt,name=grace_period_seconds,json=gracePeriodSeconds"`
+	XXX_raceDetectHookData        protoimpl.RaceDetectHookData
+	XXX_presence                  [1]uint32
+	unknownFields                 protoimpl.UnknownFields
+	sizeCache                     protoimpl.SizeCache
+}
+
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DeleteRequest) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DeleteRequest) GetGroup() string {
+	if x != nil {
+		if x.xxx_hidden_Group != nil {
+			return *x.xxx_hidden_Group
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DeleteRequest) GetVersion() string {
+	if x != nil {
+		if x.xxx_hidden_Version != nil {
+			return *x.xxx_hidden_Version
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DeleteRequest) GetResource() string {
+	if x != nil {
+		if x.xxx_hidden_Resource != nil {
+			return *x.xxx_hidden_Resource
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DeleteRequest) GetNamespace() string {
+	if x != nil {
+		if x.xxx_hidden_Namespace != nil {
+			return *x.xxx_hidden_Namespace
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DeleteRequest) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DeleteRequest) GetGracePeriodSeconds() int64 {
+	if x != nil {
+		return x.xxx_hidden_GracePeriodSeconds
+	}
+	return 0
+}
+
+func (x *DeleteRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 7)
+}
+
+func (x *DeleteRequest) SetGroup(v string) {
+	x.xxx_hidden_Group = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
+}
+
+func (x *DeleteRequest) SetVersion(v string) {
+	x.xxx_hidden_Version = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 7)
+}
+
+func (x *DeleteRequest) SetResource(v string) {
+	x.xxx_hidden_Resource = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 7)
+}
+
+func (x *DeleteRequest) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 7)
+}
+
+func (x *DeleteRequest) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 7)
+}
+
+func (x *DeleteRequest) SetGracePeriodSeconds(v int64) {
+	x.xxx_hidden_GracePeriodSeconds = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 7)
+}
+
+func (x *DeleteRequest) HasCluster() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *DeleteRequest) HasGroup() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *DeleteRequest) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *DeleteRequest) HasResource() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *DeleteRequest) HasNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *DeleteRequest) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *DeleteRequest) HasGracePeriodSeconds() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *DeleteRequest) ClearCluster() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Cluster = nil
+}
+
+func (x *DeleteRequest) ClearGroup() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Group = nil
+}
+
+func (x *DeleteRequest) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Version = nil
+}
+
+func (x *DeleteRequest) ClearResource() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Resource = nil
+}
+
+func (x *DeleteRequest) ClearNamespace() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Namespace = nil
+}
+
+func (x *DeleteRequest) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *DeleteRequest) ClearGracePeriodSeconds() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_GracePeriodSeconds = 0
+}
+
+type DeleteRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The target Kubernetes cluster identifier.
+	Cluster *string
+	// Kubernetes API Group (e.g., "apps" for Deployments, "" for core resources like Pods).
+	Group *string
+	// Kubernetes API Version (e.g., "v1").
+	Version *string
+	// Kubernetes API Resource name in plural (e.g., "pods", "deployments").
+	Resource *string
+	// The namespace of the resource.
+	Namespace *string
+	// The name of the resource.
+	Name *string
+	// The duration in seconds before the object should be deleted. Overrides the default grace period.
+	GracePeriodSeconds *int64
+}
+
+func (b0 DeleteRequest_builder) Build() *DeleteRequest {
+	m0 := &DeleteRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Cluster != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 7)
+		x.xxx_hidden_Cluster = b.Cluster
+	}
+	if b.Group != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 7)
+		x.xxx_hidden_Group = b.Group
+	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 7)
+		x.xxx_hidden_Version = b.Version
+	}
+	if b.Resource != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 7)
+		x.xxx_hidden_Resource = b.Resource
+	}
+	if b.Namespace != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 7)
+		x.xxx_hidden_Namespace = b.Namespace
+	}
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 7)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.GracePeriodSeconds != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 7)
+		x.xxx_hidden_GracePeriodSeconds = *b.GracePeriodSeconds
+	}
+	return m0
+}
+
+// ApplyManifestRequest carries a multi-document manifest to apply.
+type ApplyManifestRequest struct {
+	state                         protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster            *string                `protobuf:"bytes,1,opt,name=cluster"`
+	xxx_hidden_Manifest           []byte                 `protobuf:"bytes,2,opt,name=manifest"`
+	xxx_hidden_Force              bool                   `protobuf:"varint,3,opt,name=force"`
+	xxx_hidden_FieldManager       *string                `protobuf:"bytes,4,opt,name=field_manager,json=fieldManager"`
+	xxx_hidden_StopOnError        bool                   `protobuf:"varint,5,opt,name=stop_on_error,json=stopOnError"`
+	xxx_hidden_CrdTimeoutSeconds  int64                  `protobuf:"varint,6,opt,name=crd_timeout_seconds,json=crdTimeoutSeconds"`
+	xxx_hidden_WaitTimeoutSeconds int64                  `protobuf:"varint,7,opt,name=wait_timeout_seconds,json=waitTimeoutSeconds"`
+	XXX_raceDetectHookData        protoimpl.RaceDetectHookData
+	XXX_presence                  [1]uint32
+	unknownFields                 protoimpl.UnknownFields
+	sizeCache                     protoimpl.SizeCache
+}
+
+func (x *ApplyManifestRequest) Reset() {
+	*x = ApplyManifestRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyManifestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyManifestRequest) ProtoMessage() {}
+
+func (x *ApplyManifestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ApplyManifestRequest) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyManifestRequest) GetManifest() []byte {
+	if x != nil {
+		return x.xxx_hidden_Manifest
+	}
+	return nil
+}
+
+func (x *ApplyManifestRequest) GetForce() bool {
+	if x != nil {
+		return x.xxx_hidden_Force
+	}
+	return false
+}
+
+func (x *ApplyManifestRequest) GetFieldManager() string {
+	if x != nil {
+		if x.xxx_hidden_FieldManager != nil {
+			return *x.xxx_hidden_FieldManager
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyManifestRequest) GetStopOnError() bool {
+	if x != nil {
+		return x.xxx_hidden_StopOnError
+	}
+	return false
+}
+
+func (x *ApplyManifestRequest) GetCrdTimeoutSeconds() int64 {
+	if x != nil {
+		return x.xxx_hidden_CrdTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *ApplyManifestRequest) GetWaitTimeoutSeconds() int64 {
+	if x != nil {
+		return x.xxx_hidden_WaitTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *ApplyManifestRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 7)
+}
+
+func (x *ApplyManifestRequest) SetManifest(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Manifest = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
+}
+
+func (x *ApplyManifestRequest) SetForce(v bool) {
+	x.xxx_hidden_Force = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 7)
+}
+
+func (x *ApplyManifestRequest) SetFieldManager(v string) {
+	x.xxx_hidden_FieldManager = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 7)
+}
+
+func (x *ApplyManifestRequest) SetStopOnError(v bool) {
+	x.xxx_hidden_StopOnError = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 7)
+}
+
+func (x *ApplyManifestRequest) SetCrdTimeoutSeconds(v int64) {
+	x.xxx_hidden_CrdTimeoutSeconds = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 7)
+}
+
+func (x *ApplyManifestRequest) SetWaitTimeoutSeconds(v int64) {
+	x.xxx_hidden_WaitTimeoutSeconds = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 7)
+}
+
+func (x *ApplyManifestRequest) HasCluster() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ApplyManifestRequest) HasManifest() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ApplyManifestRequest) HasForce() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ApplyManifestRequest) HasFieldManager() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *ApplyManifestRequest) HasStopOnError() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *ApplyManifestRequest) HasCrdTimeoutSeconds() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *ApplyManifestRequest) HasWaitTimeoutSeconds() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *ApplyManifestRequest) ClearCluster() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Cluster = nil
+}
+
+func (x *ApplyManifestRequest) ClearManifest() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Manifest = nil
+}
+
+func (x *ApplyManifestRequest) ClearForce() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Force = false
+}
+
+func (x *ApplyManifestRequest) ClearFieldManager() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_FieldManager = nil
+}
+
+func (x *ApplyManifestRequest) ClearStopOnError() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_StopOnError = false
+}
+
+func (x *ApplyManifestRequest) ClearCrdTimeoutSeconds() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_CrdTimeoutSeconds = 0
+}
+
+func (x *ApplyManifestRequest) ClearWaitTimeoutSeconds() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_WaitTimeoutSeconds = 0
+}
+
+type ApplyManifestRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The target Kubernetes cluster identifier.
+	Cluster *string
+	// One or more YAML or JSON documents separated by "---". Every object
+	// must have an apiVersion, a kind and a name; namespaced objects without
+	// a namespace are placed in "default".
+	Manifest []byte
+	// If true, conflicts are resolved in favour of the caller's field manager.
+	Force *bool
+	// Identifies the entity managing the fields. Defaults to one derived from
+	// the caller.
+	FieldManager *string
+	// If true, the first failed object ends the stream and the remaining
+	// objects are not applied.
+	StopOnError *bool
+	// How long to wait, in seconds, for each CustomResourceDefinition to
+	// become established, at most 300. Defaults to 60.
+	CrdTimeoutSeconds *int64
+	// How long to wait, in seconds, once every object is applied, for the
+	// applied objects to become ready, at most 300, as `kubectl apply --wait`
+	// would. Each object waited on is reported with TYPE_WAITING, then
+	// TYPE_READY, or TYPE_FAILED if it is not ready in time. Kinds with a
+	// ready condition configured on the server are ready when it is True;
+	// Deployments, StatefulSets and DaemonSets otherwise once rolled out.
+	// Other kinds are not waited on. Zero, the default, does not wait.
+	WaitTimeoutSeconds *int64
+}
+
+func (b0 ApplyManifestRequest_builder) Build() *ApplyManifestRequest {
+	m0 := &ApplyManifestRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Cluster != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 7)
+		x.xxx_hidden_Cluster = b.Cluster
+	}
+	if b.Manifest != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 7)
+		x.xxx_hidden_Manifest = b.Manifest
+	}
+	if b.Force != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 7)
+		x.xxx_hidden_Force = *b.Force
+	}
+	if b.FieldManager != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 7)
+		x.xxx_hidden_FieldManager = b.FieldManager
+	}
+	if b.StopOnError != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 7)
+		x.xxx_hidden_StopOnError = *b.StopOnError
+	}
+	if b.CrdTimeoutSeconds != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 7)
+		x.xxx_hidden_CrdTimeoutSeconds = *b.CrdTimeoutSeconds
+	}
+	if b.WaitTimeoutSeconds != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 7)
+		x.xxx_hidden_WaitTimeoutSeconds = *b.WaitTimeoutSeconds
+	}
+	return m0
+}
+
+// ConfigMapKeyRef selects a key of a ConfigMap.
+type ConfigMapKeyRef struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Namespace   *string                `protobuf:"bytes,1,opt,name=namespace"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,2,opt,name=name"`
+	xxx_hidden_Key         *string                `protobuf:"bytes,3,opt,name=key"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ConfigMapKeyRef) Reset() {
+	*x = ConfigMapKeyRef{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigMapKeyRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigMapKeyRef) ProtoMessage() {}
+
+func (x *ConfigMapKeyRef) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ConfigMapKeyRef) GetNamespace() string {
+	if x != nil {
+		if x.xxx_hidden_Namespace != nil {
+			return *x.xxx_hidden_Namespace
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ConfigMapKeyRef) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ConfigMapKeyRef) GetKey() string {
+	if x != nil {
+		if x.xxx_hidden_Key != nil {
+			return *x.xxx_hidden_Key
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ConfigMapKeyRef) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *ConfigMapKeyRef) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *ConfigMapKeyRef) SetKey(v string) {
+	x.xxx_hidden_Key = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *ConfigMapKeyRef) HasNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ConfigMapKeyRef) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ConfigMapKeyRef) HasKey() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ConfigMapKeyRef) ClearNamespace() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Namespace = nil
+}
+
+func (x *ConfigMapKeyRef) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *ConfigMapKeyRef) ClearKey() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Key = nil
+}
+
+type ConfigMapKeyRef_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The namespace of the ConfigMap.
+	Namespace *string
+	// The name of the ConfigMap.
+	Name *string
+	// The key whose value holds the manifest.
+	Key *string
+}
+
+func (b0 ConfigMapKeyRef_builder) Build() *ConfigMapKeyRef {
+	m0 := &ConfigMapKeyRef{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Namespace != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Namespace = b.Namespace
+	}
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.Key != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Key = b.Key
+	}
+	return m0
+}
+
+// ApplyFromSourceRequest locates a multi-document manifest to apply.
+type ApplyFromSourceRequest struct {
+	state                        protoimpl.MessageState          `protogen:"opaque.v1"`
+	xxx_hidden_Cluster           *string                         `protobuf:"bytes,1,opt,name=cluster"`
+	xxx_hidden_Source            isApplyFromSourceRequest_Source `protobuf_oneof:"source"`
+	xxx_hidden_Force             bool                            `protobuf:"varint,4,opt,name=force"`
+	xxx_hidden_FieldManager      *string                         `protobuf:"bytes,5,opt,name=field_manager,json=fieldManager"`
+	xxx_hidden_StopOnError       bool                            `protobuf:"varint,6,opt,name=stop_on_error,json=stopOnError"`
+	xxx_hidden_CrdTimeoutSeconds int64                           `protobuf:"varint,7,opt,name=crd_timeout_seconds,json=crdTimeoutSeconds"`
+	XXX_raceDetectHookData       protoimpl.RaceDetectHookData
+	XXX_presence                 [1]uint32
+	unknownFields                protoimpl.UnknownFields
+	sizeCache                    protoimpl.SizeCache
+}
+
+func (x *ApplyFromSourceRequest) Reset() {
+	*x = ApplyFromSourceRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyFromSourceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyFromSourceRequest) ProtoMessage() {}
+
+func (x *ApplyFromSourceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ApplyFromSourceRequest) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyFromSourceRequest) GetUrl() string {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Source.(*applyFromSourceRequest_Url); ok {
+			return x.Url
+		}
+	}
+	return ""
+}
+
+func (x *ApplyFromSourceRequest) GetConfigMap() *ConfigMapKeyRef {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Source.(*applyFromSourceRequest_ConfigMap); ok {
+			return x.ConfigMap
+		}
+	}
+	return nil
+}
+
+func (x *ApplyFromSourceRequest) GetForce() bool {
+	if x != nil {
+		return x.xxx_hidden_Force
+	}
+	return false
+}
+
+func (x *ApplyFromSourceRequest) GetFieldManager() string {
+	if x != nil {
+		if x.xxx_hidden_FieldManager != nil {
+			return *x.xxx_hidden_FieldManager
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyFromSourceRequest) GetStopOnError() bool {
+	if x != nil {
+		return x.xxx_hidden_StopOnError
+	}
+	return false
+}
+
+func (x *ApplyFromSourceRequest) GetCrdTimeoutSeconds() int64 {
+	if x != nil {
+		return x.xxx_hidden_CrdTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *ApplyFromSourceRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 6)
+}
+
+func (x *ApplyFromSourceRequest) SetUrl(v string) {
+	x.xxx_hidden_Source = &applyFromSourceRequest_Url{v}
+}
+
+func (x *ApplyFromSourceRequest) SetConfigMap(v *ConfigMapKeyRef) {
+	if v == nil {
+		x.xxx_hidden_Source = nil
+		return
+	}
+	x.xxx_hidden_Source = &applyFromSourceRequest_ConfigMap{v}
+}
+
+func (x *ApplyFromSourceRequest) SetForce(v bool) {
+	x.xxx_hidden_Force = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 6)
+}
+
+func (x *ApplyFromSourceRequest) SetFieldManager(v string) {
+	x.xxx_hidden_FieldManager = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 6)
+}
+
+func (x *ApplyFromSourceRequest) SetStopOnError(v bool) {
+	x.xxx_hidden_StopOnError = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
+}
+
+func (x *ApplyFromSourceRequest) SetCrdTimeoutSeconds(v int64) {
+	x.xxx_hidden_CrdTimeoutSeconds = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 6)
+}
+
+func (x *ApplyFromSourceRequest) HasCluster() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ApplyFromSourceRequest) HasSource() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Source != nil
+}
+
+func (x *ApplyFromSourceRequest) HasUrl() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Source.(*applyFromSourceRequest_Url)
+	return ok
+}
+
+func (x *ApplyFromSourceRequest) HasConfigMap() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Source.(*applyFromSourceRequest_ConfigMap)
+	return ok
+}
+
+func (x *ApplyFromSourceRequest) HasForce() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ApplyFromSourceRequest) HasFieldManager() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *ApplyFromSourceRequest) HasStopOnError() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *ApplyFromSourceRequest) HasCrdTimeoutSeconds() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *ApplyFromSourceRequest) ClearCluster() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Cluster = nil
+}
+
+func (x *ApplyFromSourceRequest) ClearSource() {
+	x.xxx_hidden_Source = nil
+}
+
+func (x *ApplyFromSourceRequest) ClearUrl() {
+	if _, ok := x.xxx_hidden_Source.(*applyFromSourceRequest_Url); ok {
+		x.xxx_hidden_Source = nil
+	}
+}
+
+func (x *ApplyFromSourceRequest) ClearConfigMap() {
+	if _, ok := x.xxx_hidden_Source.(*applyFromSourceRequest_ConfigMap); ok {
+		x.xxx_hidden_Source = nil
+	}
+}
+
+func (x *ApplyFromSourceRequest) ClearForce() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Force = false
+}
+
+func (x *ApplyFromSourceRequest) ClearFieldManager() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_FieldManager = nil
+}
+
+func (x *ApplyFromSourceRequest) ClearStopOnError() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_StopOnError = false
+}
+
+func (x *ApplyFromSourceRequest) ClearCrdTimeoutSeconds() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_CrdTimeoutSeconds = 0
+}
+
+const ApplyFromSourceRequest_Source_not_set_case case_ApplyFromSourceRequest_Source = 0
+const ApplyFromSourceRequest_Url_case case_ApplyFromSourceRequest_Source = 2
+const ApplyFromSourceRequest_ConfigMap_case case_ApplyFromSourceRequest_Source = 3
+
+func (x *ApplyFromSourceRequest) WhichSource() case_ApplyFromSourceRequest_Source {
+	if x == nil {
+		return ApplyFromSourceRequest_Source_not_set_case
+	}
+	switch x.xxx_hidden_Source.(type) {
+	case *applyFromSourceRequest_Url:
+		return ApplyFromSourceRequest_Url_case
+	case *applyFromSourceRequest_ConfigMap:
+		return ApplyFromSourceRequest_ConfigMap_case
+	default:
+		return ApplyFromSourceRequest_Source_not_set_case
+	}
+}
+
+type ApplyFromSourceRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The target Kubernetes cluster identifier.
+	Cluster *string
+	// Where to read the manifest from. Exactly one must be set.
+
+	// Fields of oneof xxx_hidden_Source:
+	// An https URL on a host allowed by the server.
+	Url *string
+	// A ConfigMap key on the target cluster.
+	ConfigMap *ConfigMapKeyRef
+	// -- end of xxx_hidden_Source
+	// If true, conflicts are resolved in favour of the caller's field manager.
+	Force *bool
+	// Identifies the entity managing the fields. Defaults to one derived from
+	// the caller.
+	FieldManager *string
+	// If true, the first failed object ends the stream and the remaining
+	// objects are not applied.
+	StopOnError *bool
+	// How long to wait, in seconds, for each CustomResourceDefinition to
+	// become established, at most 300. Defaults to 60.
+	CrdTimeoutSeconds *int64
+}
+
+func (b0 ApplyFromSourceRequest_builder) Build() *ApplyFromSourceRequest {
+	m0 := &ApplyFromSourceRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Cluster != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 6)
+		x.xxx_hidden_Cluster = b.Cluster
+	}
+	if b.Url != nil {
+		x.xxx_hidden_Source = &applyFromSourceRequest_Url{*b.Url}
+	}
+	if b.ConfigMap != nil {
+		x.xxx_hidden_Source = &applyFromSourceRequest_ConfigMap{b.ConfigMap}
+	}
+	if b.Force != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 6)
+		x.xxx_hidden_Force = *b.Force
+	}
+	if b.FieldManager != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 6)
+		x.xxx_hidden_FieldManager = b.FieldManager
+	}
+	if b.StopOnError != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 6)
+		x.xxx_hidden_StopOnError = *b.StopOnError
+	}
+	if b.CrdTimeoutSeconds != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 6)
+		x.xxx_hidden_CrdTimeoutSeconds = *b.CrdTimeoutSeconds
+	}
+	return m0
+}
+
+type case_ApplyFromSourceRequest_Source protoreflect.FieldNumber
+
+func (x case_ApplyFromSourceRequest_Source) String() string {
+	md := file_api_resource_v1_resource_proto_msgTypes[41].Descriptor()
+	if x == 0 {
+		return "not set"
+	}
+	return protoimpl.X.MessageFieldStringOf(md, protoreflect.FieldNumber(x))
+}
+
+type isApplyFromSourceRequest_Source interface {
+	isApplyFromSourceRequest_Source()
+}
+
+type applyFromSourceRequest_Url struct {
+	// An https URL on a host allowed by the server.
+	Url string `protobuf:"bytes,2,opt,name=url,oneof"`
+}
+
+type applyFromSourceRequest_ConfigMap struct {
+	// A ConfigMap key on the target cluster.
+	ConfigMap *ConfigMapKeyRef `protobuf:"bytes,3,opt,name=config_map,json=configMap,oneof"`
+}
+
+func (*applyFromSourceRequest_Url) isApplyFromSourceRequest_Source() {}
+
+func (*applyFromSourceRequest_ConfigMap) isApplyFromSourceRequest_Source() {}
+
+// PruneScope bounds the objects ApplyWithPrune may delete.
+type PruneScope struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Group       *string                `protobuf:"bytes,1,opt,name=group"`
+	xxx_hidden_Version     *string                `protobuf:"bytes,2,opt,name=version"`
+	xxx_hidden_Resource    *string                `protobuf:"bytes,3,opt,name=resource"`
+	xxx_hidden_Namespaces  []string               `protobuf:"bytes,4,rep,name=namespaces"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *PruneScope) Reset() {
+	*x = PruneScope{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PruneScope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PruneScope) ProtoMessage() {}
+
+func (x *PruneScope) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *PruneScope) GetGroup() string {
+	if x != nil {
+		if x.xxx_hidden_Group != nil {
+			return *x.xxx_hidden_Group
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *PruneScope) GetVersion() string {
+	if x != nil {
+		if x.xxx_hidden_Version != nil {
+			return *x.xxx_hidden_Version
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *PruneScope) GetResource() string {
+	if x != nil {
+		if x.xxx_hidden_Resource != nil {
+			return *x.xxx_hidden_Resource
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *PruneScope) GetNamespaces() []string {
+	if x != nil {
+		return x.xxx_hidden_Namespaces
+	}
+	return nil
+}
+
+func (x *PruneScope) SetGroup(v string) {
+	x.xxx_hidden_Group = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *PruneScope) SetVersion(v string) {
+	x.xxx_hidden_Version = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *PruneScope) SetResource(v string) {
+	x.xxx_hidden_Resource = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *PruneScope) SetNamespaces(v []string) {
+	x.xxx_hidden_Namespaces = v
+}
+
+func (x *PruneScope) HasGroup() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *PruneScope) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *PruneScope) HasResource() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *PruneScope) ClearGroup() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Group = nil
+}
+
+func (x *PruneScope) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Version = nil
+}
+
+func (x *PruneScope) ClearResource() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Resource = nil
+}
+
+type PruneScope_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The API group of the resource; empty for the core group.
+	Group *string
+	// The API version of the resource.
+	Version *string
+	// The plural resource name (e.g., "configmaps").
+	Resource *string
+	// The namespaces pruned for a namespaced resource. Required for a
+	// namespaced resource and not allowed for a cluster-scoped one.
+	Namespaces []string
+}
+
+func (b0 PruneScope_builder) Build() *PruneScope {
+	m0 := &PruneScope{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Group != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Group = b.Group
+	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_Version = b.Version
+	}
+	if b.Resource != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_Resource = b.Resource
+	}
+	x.xxx_hidden_Namespaces = b.Namespaces
+	return m0
+}
+
+// ApplyWithPruneRequest carries a manifest to apply and the bounds of the
+// prune that follows.
+type ApplyWithPruneRequest struct {
+	state                        protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster           *string                `protobuf:"bytes,1,opt,name=cluster"`
+	xxx_hidden_Manifest          []byte                 `protobuf:"bytes,2,opt,name=manifest"`
+	xxx_hidden_PruneLabel        *string                `protobuf:"bytes,3,opt,name=prune_label,json=pruneLabel"`
+	xxx_hidden_PruneScopes       *[]*PruneScope         `protobuf:"bytes,4,rep,name=prune_scopes,json=pruneScopes"`
+	xxx_hidden_Force             bool                   `protobuf:"varint,5,opt,name=force"`
+	xxx_hidden_FieldManager      *string                `protobuf:"bytes,6,opt,name=field_manager,json=fieldManager"`
+	xxx_hidden_StopOnError       bool                   `protobuf:"varint,7,opt,name=stop_on_error,json=stopOnError"`
+	xxx_hidden_CrdTimeoutSeconds int64                  `protobuf:"varint,8,opt,name=crd_timeout_seconds,json=crdTimeoutSeconds"`
+	XXX_raceDetectHookData       protoimpl.RaceDetectHookData
+	XXX_presence                 [1]uint32
+	unknownFields                protoimpl.UnknownFields
+	sizeCache                    protoimpl.SizeCache
+}
+
+func (x *ApplyWithPruneRequest) Reset() {
+	*x = ApplyWithPruneRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyWithPruneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyWithPruneRequest) ProtoMessage() {}
+
+func (x *ApplyWithPruneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ApplyWithPruneRequest) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyWithPruneRequest) GetManifest() []byte {
+	if x != nil {
+		return x.xxx_hidden_Manifest
+	}
+	return nil
+}
+
+func (x *ApplyWithPruneRequest) GetPruneLabel() string {
+	if x != nil {
+		if x.xxx_hidden_PruneLabel != nil {
+			return *x.xxx_hidden_PruneLabel
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyWithPruneRequest) GetPruneScopes() []*PruneScope {
+	if x != nil {
+		if x.xxx_hidden_PruneScopes != nil {
+			return *x.xxx_hidden_PruneScopes
+		}
+	}
+	return nil
+}
+
+func (x *ApplyWithPruneRequest) GetForce() bool {
+	if x != nil {
+		return x.xxx_hidden_Force
+	}
+	return false
+}
+
+func (x *ApplyWithPruneRequest) GetFieldManager() string {
+	if x != nil {
+		if x.xxx_hidden_FieldManager != nil {
+			return *x.xxx_hidden_FieldManager
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyWithPruneRequest) GetStopOnError() bool {
+	if x != nil {
+		return x.xxx_hidden_StopOnError
+	}
+	return false
+}
+
+func (x *ApplyWithPruneRequest) GetCrdTimeoutSeconds() int64 {
+	if x != nil {
+		return x.xxx_hidden_CrdTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *ApplyWithPruneRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 8)
+}
+
+func (x *ApplyWithPruneRequest) SetManifest(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Manifest = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 8)
+}
+
+func (x *ApplyWithPruneRequest) SetPruneLabel(v string) {
+	x.xxx_hidden_PruneLabel = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 8)
+}
+
+func (x *ApplyWithPruneRequest) SetPruneScopes(v []*PruneScope) {
+	x.xxx_hidden_PruneScopes = &v
+}
+
+func (x *ApplyWithPruneRequest) SetForce(v bool) {
+	x.xxx_hidden_Force = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 8)
+}
+
+func (x *ApplyWithPruneRequest) SetFieldManager(v string) {
+	x.xxx_hidden_FieldManager = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 8)
+}
+
+func (x *ApplyWithPruneRequest) SetStopOnError(v bool) {
+	x.xxx_hidden_StopOnError = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 8)
+}
+
+func (x *ApplyWithPruneRequest) SetCrdTimeoutSeconds(v int64) {
+	x.xxx_hidden_CrdTimeoutSeconds = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 8)
+}
+
+func (x *ApplyWithPruneRequest) HasCluster() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ApplyWithPruneRequest) HasManifest() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ApplyWithPruneRequest) HasPruneLabel() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ApplyWithPruneRequest) HasForce() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *ApplyWithPruneRequest) HasFieldManager() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *ApplyWithPruneRequest) HasStopOnError() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *ApplyWithPruneRequest) HasCrdTimeoutSeconds() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *ApplyWithPruneRequest) ClearCluster() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Cluster = nil
+}
+
+func (x *ApplyWithPruneRequest) ClearManifest() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Manifest = nil
+}
+
+func (x *ApplyWithPruneRequest) ClearPruneLabel() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_PruneLabel = nil
+}
+
+func (x *ApplyWithPruneRequest) ClearForce() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Force = false
+}
+
+func (x *ApplyWithPruneRequest) ClearFieldManager() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_FieldManager = nil
+}
+
+func (x *ApplyWithPruneRequest) ClearStopOnError() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_StopOnError = false
+}
+
+func (x *ApplyWithPruneRequest) ClearCrdTimeoutSeconds() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_CrdTimeoutSeconds = 0
+}
+
+type ApplyWithPruneRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The target Kubernetes cluster identifier.
+	Cluster *string
+	// One or more YAML or JSON documents separated by "---", as in
+	// ApplyManifestRequest.
+	Manifest []byte
+	// The "key=value" label set on every applied object and selecting the
+	// objects considered for pruning (e.g., "gitops.example.com/app=shop").
+	PruneLabel *string
+	// The resources and namespaces pruned. At least one is required.
+	PruneScopes []*PruneScope
+	// If true, conflicts are resolved in favour of the caller's field manager.
+	Force *bool
+	// Identifies the entity managing the fields. Defaults to one derived from
+	// the caller.
+	FieldManager *string
+	// If true, the first failed object ends the stream and the remaining
+	// objects are not applied.
+	StopOnError *bool
+	// How long to wait, in seconds, for each CustomResourceDefinition to
+	// become established, at most 300. Defaults to 60.
+	CrdTimeoutSeconds *int64
+}
+
+func (b0 ApplyWithPruneRequest_builder) Build() *ApplyWithPruneRequest {
+	m0 := &ApplyWithPruneRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Cluster != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 8)
+		x.xxx_hidden_Cluster = b.Cluster
+	}
+	if b.Manifest != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 8)
+		x.xxx_hidden_Manifest = b.Manifest
+	}
+	if b.PruneLabel != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 8)
+		x.xxx_hidden_PruneLabel = b.PruneLabel
+	}
+	x.xxx_hidden_PruneScopes = &b.PruneScopes
+	if b.Force != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 8)
+		x.xxx_hidden_Force = *b.Force
+	}
+	if b.FieldManager != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 8)
+		x.xxx_hidden_FieldManager = b.FieldManager
+	}
+	if b.StopOnError != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 8)
+		x.xxx_hidden_StopOnError = *b.StopOnError
+	}
+	if b.CrdTimeoutSeconds != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 8)
+		x.xxx_hidden_CrdTimeoutSeconds = *b.CrdTimeoutSeconds
+	}
+	return m0
+}
+
+// ApplyBootstrapRequest names the cluster to apply the bootstrap manifests
+// to.
+type ApplyBootstrapRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster     *string                `protobuf:"bytes,1,opt,name=cluster"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ApplyBootstrapRequest) Reset() {
+	*x = ApplyBootstrapRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyBootstrapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyBootstrapRequest) ProtoMessage() {}
+
+func (x *ApplyBootstrapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ApplyBootstrapRequest) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyBootstrapRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *ApplyBootstrapRequest) HasCluster() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ApplyBootstrapRequest) ClearCluster() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Cluster = nil
+}
+
+type ApplyBootstrapRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The target Kubernetes cluster identifier. A service token only admits
+	// the cluster it was issued for.
+	Cluster *string
+}
+
+func (b0 ApplyBootstrapRequest_builder) Build() *ApplyBootstrapRequest {
+	m0 := &ApplyBootstrapRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Cluster != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Cluster = b.Cluster
+	}
+	return m0
+}
+
+// ApplyHelmChartRequest carries a packaged Helm chart and the values to
+// render it with.
+type ApplyHelmChartRequest struct {
+	state                        protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster           *string                `protobuf:"bytes,1,opt,name=cluster"`
+	xxx_hidden_Chart             []byte                 `protobuf:"bytes,2,opt,name=chart"`
+	xxx_hidden_Values            []byte                 `protobuf:"bytes,3,opt,name=values"`
+	xxx_hidden_ReleaseName       *string                `protobuf:"bytes,4,opt,name=release_name,json=releaseName"`
+	xxx_hidden_Namespace         *string                `protobuf:"bytes,5,opt,name=namespace"`
+	xxx_hidden_Force             bool                   `protobuf:"varint,6,opt,name=force"`
+	xxx_hidden_FieldManager      *string                `protobuf:"bytes,7,opt,name=field_manager,json=fieldManager"`
+	xxx_hidden_StopOnError       bool                   `protobuf:"varint,8,opt,name=stop_on_error,json=stopOnError"`
+	xxx_hidden_CrdTimeoutSeconds int64                  `protobuf:"varint,9,opt,name=crd_timeout_seconds,json=crdTimeoutSeconds"`
+	XXX_raceDetectHookData       protoimpl.RaceDetectHookData
+	XXX_presence                 [1]uint32
+	unknownFields                protoimpl.UnknownFields
+	sizeCache                    protoimpl.SizeCache
+}
+
+func (x *ApplyHelmChartRequest) Reset() {
+	*x = ApplyHelmChartRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyHelmChartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyHelmChartRequest) ProtoMessage() {}
+
+func (x *ApplyHelmChartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ApplyHelmChartRequest) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyHelmChartRequest) GetChart() []byte {
+	if x != nil {
+		return x.xxx_hidden_Chart
+	}
+	return nil
+}
+
+func (x *ApplyHelmChartRequest) GetValues() []byte {
+	if x != nil {
+		return x.xxx_hidden_Values
+	}
+	return nil
+}
+
+func (x *ApplyHelmChartRequest) GetReleaseName() string {
+	if x != nil {
+		if x.xxx_hidden_ReleaseName != nil {
+			return *x.xxx_hidden_ReleaseName
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyHelmChartRequest) GetNamespace() string {
+	if x != nil {
+		if x.xxx_hidden_Namespace != nil {
+			return *x.xxx_hidden_Namespace
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyHelmChartRequest) GetForce() bool {
+	if x != nil {
+		return x.xxx_hidden_Force
+	}
+	return false
+}
+
+func (x *ApplyHelmChartRequest) GetFieldManager() string {
+	if x != nil {
+		if x.xxx_hidden_FieldManager != nil {
+			return *x.xxx_hidden_FieldManager
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyHelmChartRequest) GetStopOnError() bool {
+	if x != nil {
+		return x.xxx_hidden_StopOnError
+	}
+	return false
+}
+
+func (x *ApplyHelmChartRequest) GetCrdTimeoutSeconds() int64 {
+	if x != nil {
+		return x.xxx_hidden_CrdTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *ApplyHelmChartRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 9)
+}
+
+func (x *ApplyHelmChartRequest) SetChart(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Chart = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 9)
+}
+
+func (x *ApplyHelmChartRequest) SetValues(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Values = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 9)
+}
+
+func (x *ApplyHelmChartRequest) SetReleaseName(v string) {
+	x.xxx_hidden_ReleaseName = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 9)
+}
+
+func (x *ApplyHelmChartRequest) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 9)
+}
+
+func (x *ApplyHelmChartRequest) SetForce(v bool) {
+	x.xxx_hidden_Force = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 9)
+}
+
+func (x *ApplyHelmChartRequest) SetFieldManager(v string) {
+	x.xxx_hidden_FieldManager = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 9)
+}
+
+func (x *ApplyHelmChartRequest) SetStopOnError(v bool) {
+	x.xxx_hidden_StopOnError = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 9)
+}
+
+func (x *ApplyHelmChartRequest) SetCrdTimeoutSeconds(v int64) {
+	x.xxx_hidden_CrdTimeoutSeconds = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 9)
+}
+
+func (x *ApplyHelmChartRequest) HasCluster() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ApplyHelmChartRequest) HasChart() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ApplyHelmChartRequest) HasValues() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ApplyHelmChartRequest) HasReleaseName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *ApplyHelmChartRequest) HasNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *ApplyHelmChartRequest) HasForce() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *ApplyHelmChartRequest) HasFieldManager() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *ApplyHelmChartRequest) HasStopOnError() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *ApplyHelmChartRequest) HasCrdTimeoutSeconds() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *ApplyHelmChartRequest) ClearCluster() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Cluster = nil
+}
+
+func (x *ApplyHelmChartRequest) ClearChart() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Chart = nil
+}
+
+func (x *ApplyHelmChartRequest) ClearValues() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Values = nil
+}
+
+func (x *ApplyHelmChartRequest) ClearReleaseName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_ReleaseName = nil
+}
+
+func (x *ApplyHelmChartRequest) ClearNamespace() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Namespace = nil
+}
+
+func (x *ApplyHelmChartRequest) ClearForce() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Force = false
+}
+
+func (x *ApplyHelmChartRequest) ClearFieldManager() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_FieldManager = nil
+}
+
+func (x *ApplyHelmChartRequest) ClearStopOnError() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_StopOnError = false
+}
+
+func (x *ApplyHelmChartRequest) ClearCrdTimeoutSeconds() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_CrdTimeoutSeconds = 0
+}
+
+type ApplyHelmChartRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The target Kubernetes cluster identifier.
+	Cluster *string
+	// The chart as a gzipped tar archive, as produced by `helm package`.
+	Chart []byte
+	// A YAML document of values overriding the chart's defaults.
+	Values []byte
+	// The release name the chart is rendered for (.Release.Name).
+	ReleaseName *string
+	// The release namespace (.Release.Namespace), also used for rendered
+	// namespaced objects that do not name one. Defaults to "default".
+	Namespace *string
+	// If true, conflicts are resolved in favour of the caller's field manager.
+	Force *bool
+	// Identifies the entity managing the fields. Defaults to one derived from
+	// the caller.
+	FieldManager *string
+	// If true, the first failed object ends the stream and the remaining
+	// objects are not applied.
+	StopOnError *bool
+	// How long to wait, in seconds, for each CustomResourceDefinition to
+	// become established, at most 300. Defaults to 60.
+	CrdTimeoutSeconds *int64
+}
+
+func (b0 ApplyHelmChartRequest_builder) Build() *ApplyHelmChartRequest {
+	m0 := &ApplyHelmChartRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Cluster != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 9)
+		x.xxx_hidden_Cluster = b.Cluster
+	}
+	if b.Chart != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 9)
+		x.xxx_hidden_Chart = b.Chart
+	}
+	if b.Values != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 9)
+		x.xxx_hidden_Values = b.Values
+	}
+	if b.ReleaseName != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 9)
+		x.xxx_hidden_ReleaseName = b.ReleaseName
+	}
+	if b.Namespace != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 9)
+		x.xxx_hidden_Namespace = b.Namespace
+	}
+	if b.Force != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 9)
+		x.xxx_hidden_Force = *b.Force
+	}
+	if b.FieldManager != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 9)
+		x.xxx_hidden_FieldManager = b.FieldManager
+	}
+	if b.StopOnError != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 9)
+		x.xxx_hidden_StopOnError = *b.StopOnError
+	}
+	if b.CrdTimeoutSeconds != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 9)
+		x.xxx_hidden_CrdTimeoutSeconds = *b.CrdTimeoutSeconds
+	}
+	return m0
+}
+
+// ApplyManifestEvent reports the progress of ApplyManifest on one object.
+type ApplyManifestEvent struct {
+	state                  protoimpl.MessageState  `protogen:"opaque.v1"`
+	xxx_hidden_Type        ApplyManifestEvent_Type `protobuf:"varint,1,opt,name=type,enum=otterscale.resource.v1.ApplyManifestEvent_Type"`
+	xxx_hidden_Index       int32                   `protobuf:"varint,2,opt,name=index"`
+	xxx_hidden_ApiVersion  *string                 `protobuf:"bytes,3,opt,name=api_version,json=apiVersion"`
+	xxx_hidden_Kind        *string                 `protobuf:"bytes,4,opt,name=kind"`
+	xxx_hidden_Namespace   *string                 `protobuf:"bytes,5,opt,name=namespace"`
+	xxx_hidden_Name        *string                 `protobuf:"bytes,6,opt,name=name"`
+	xxx_hidden_Error       *string                 `protobuf:"bytes,7,opt,name=error"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ApplyManifestEvent) Reset() {
+	*x = ApplyManifestEvent{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyManifestEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyManifestEvent) ProtoMessage() {}
+
+func (x *ApplyManifestEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ApplyManifestEvent) GetType() ApplyManifestEvent_Type {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 0) {
+			return x.xxx_hidden_Type
+		}
+	}
+	return ApplyManifestEvent_TYPE_UNSPECIFIED
+}
+
+func (x *ApplyManifestEvent) GetIndex() int32 {
+	if x != nil {
+		return x.xxx_hidden_Index
+	}
+	return 0
+}
+
+func (x *ApplyManifestEvent) GetApiVersion() string {
+	if x != nil {
+		if x.xxx_hidden_ApiVersion != nil {
+			return *x.xxx_hidden_ApiVersion
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyManifestEvent) GetKind() string {
+	if x != nil {
+		if x.xxx_hidden_Kind != nil {
+			return *x.xxx_hidden_Kind
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyManifestEvent) GetNamespace() string {
+	if x != nil {
+		if x.xxx_hidden_Namespace != nil {
+			return *x.xxx_hidden_Namespace
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyManifestEvent) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyManifestEvent) GetError() string {
+	if x != nil {
+		if x.xxx_hidden_Error != nil {
+			return *x.xxx_hidden_Error
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ApplyManifestEvent) SetType(v ApplyManifestEvent_Type) {
+	x.xxx_hidden_Type = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 7)
+}
+
+func (x *ApplyManifestEvent) SetIndex(v int32) {
+	x.xxx_hidden_Index = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
+}
+
+func (x *ApplyManifestEvent) SetApiVersion(v string) {
+	x.xxx_hidden_ApiVersion = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 7)
+}
+
+func (x *ApplyManifestEvent) SetKind(v string) {
+	x.xxx_hidden_Kind = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 7)
+}
+
+func (x *ApplyManifestEvent) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 7)
+}
+
+func (x *ApplyManifestEvent) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 7)
+}
+
+func (x *ApplyManifestEvent) SetError(v string) {
+	x.xxx_hidden_Error = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 7)
+}
+
+func (x *ApplyManifestEvent) HasType() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ApplyManifestEvent) HasIndex() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ApplyManifestEvent) HasApiVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ApplyManifestEvent) HasKind() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *ApplyManifestEvent) HasNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *ApplyManifestEvent) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *ApplyManifestEvent) HasError() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *ApplyManifestEvent) ClearType() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Type = ApplyManifestEvent_TYPE_UNSPECIFIED
+}
+
+func (x *ApplyManifestEvent) ClearIndex() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Index = 0
+}
+
+func (x *ApplyManifestEvent) ClearApiVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_ApiVersion = nil
+}
+
+func (x *ApplyManifestEvent) ClearKind() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Kind = nil
+}
+
+func (x *ApplyManifestEvent) ClearNamespace() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Namespace = nil
+}
+
+func (x *ApplyManifestEvent) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *ApplyManifestEvent) ClearError() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_Error = nil
+}
+
+type ApplyManifestEvent_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The step reported.
+	Type *ApplyManifestEvent_Type
+	// The position of the object among the manifest's non-empty documents,
+	// starting at zero.
+	Index *int32
+	// The apiVersion of the object (e.g., "apps/v1").
+	ApiVersion *string
+	// The kind of the object (e.g., "Deployment").
+	Kind *string
+	// The namespace of the object; empty for cluster-scoped objects.
+	Namespace *string
+	// The name of the object.
+	Name *string
+	// Why the object failed, set on TYPE_FAILED events.
+	Error *string
+}
+
+func (b0 ApplyManifestEvent_builder) Build() *ApplyManifestEvent {
+	m0 := &ApplyManifestEvent{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Type != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 7)
+		x.xxx_hidden_Type = *b.Type
+	}
+	if b.Index != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 7)
+		x.xxx_hidden_Index = *b.Index
+	}
+	if b.ApiVersion != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 7)
+		x.xxx_hidden_ApiVersion = b.ApiVersion
+	}
+	if b.Kind != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 7)
+		x.xxx_hidden_Kind = b.Kind
+	}
+	if b.Namespace != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 7)
+		x.xxx_hidden_Namespace = b.Namespace
+	}
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 7)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.Error != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 7)
+		x.xxx_hidden_Error = b.Error
+	}
+	return m0
+}
+
+// WaitForConditionRequest identifies a resource and the status condition to
+// wait for.
+type WaitForConditionRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster        *string                `protobuf:"bytes,1,opt,name=cluster"`
+	xxx_hidden_Group          *string                `protobuf:"bytes,2,opt,name=group"`
+	xxx_hidden_Version        *string                `protobuf:"bytes,3,opt,name=version"`
+	xxx_hidden_Resource       *string                `protobuf:"bytes,4,opt,name=resource"`
+	xxx_hidden_Namespace      *string                `protobuf:"bytes,5,opt,name=namespace"`
+	xxx_hidden_Name           *string                `protobuf:"bytes,6,opt,name=name"`
+	xxx_hidden_ConditionType  *string                `protobuf:"bytes,7,opt,name=condition_type,json=conditionType"`
+	xxx_hidden_Status         *string                `protobuf:"bytes,8,opt,name=status"`
+	xxx_hidden_TimeoutSeconds int64                  `protobuf:"varint,9,opt,name=timeout_seconds,json=timeoutSeconds"`
+	XXX_raceDetectHookData    protoimpl.RaceDetectHookData
+	XXX_presence              [1]uint32
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *WaitForConditionRequest) Reset() {
+	*x = WaitForConditionRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitForConditionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitForConditionRequest) ProtoMessage() {}
+
+func (x *WaitForConditionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *WaitForConditionRequest) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WaitForConditionRequest) GetGroup() string {
+	if x != nil {
+		if x.xxx_hidden_Group != nil {
+			return *x.xxx_hidden_Group
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WaitForConditionRequest) GetVersion() string {
+	if x != nil {
+		if x.xxx_hidden_Version != nil {
+			return *x.xxx_hidden_Version
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WaitForConditionRequest) GetResource() string {
+	if x != nil {
+		if x.xxx_hidden_Resource != nil {
+			return *x.xxx_hidden_Resource
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WaitForConditionRequest) GetNamespace() string {
+	if x != nil {
+		if x.xxx_hidden_Namespace != nil {
+			return *x.xxx_hidden_Namespace
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WaitForConditionRequest) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WaitForConditionRequest) GetConditionType() string {
+	if x != nil {
+		if x.xxx_hidden_ConditionType != nil {
+			return *x.xxx_hidden_ConditionType
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WaitForConditionRequest) GetStatus() string {
+	if x != nil {
+		if x.xxx_hidden_Status != nil {
+			return *x.xxx_hidden_Status
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WaitForConditionRequest) GetTimeoutSeconds() int64 {
+	if x != nil {
+		return x.xxx_hidden_TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *WaitForConditionRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 9)
+}
+
+func (x *WaitForConditionRequest) SetGroup(v string) {
+	x.xxx_hidden_Group = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 9)
+}
+
+func (x *WaitForConditionRequest) SetVersion(v string) {
+	x.xxx_hidden_Version = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 9)
+}
+
+func (x *WaitForConditionRequest) SetResource(v string) {
+	x.xxx_hidden_Resource = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 9)
+}
+
+func (x *WaitForConditionRequest) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 9)
+}
+
+func (x *WaitForConditionRequest) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 9)
+}
+
+func (x *WaitForConditionRequest) SetConditionType(v string) {
+	x.xxx_hidden_ConditionType = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 9)
+}
+
+func (x *WaitForConditionRequest) SetStatus(v string) {
+	x.xxx_hidden_Status = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 9)
+}
+
+func (x *WaitForConditionRequest) SetTimeoutSeconds(v int64) {
+	x.xxx_hidden_TimeoutSeconds = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 9)
+}
+
+func (x *WaitForConditionRequest) HasCluster() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *WaitForConditionRequest) HasGroup() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *WaitForConditionRequest) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *WaitForConditionRequest) HasResource() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *WaitForConditionRequest) HasNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *WaitForConditionRequest) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *WaitForConditionRequest) HasConditionType() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *WaitForConditionRequest) HasStatus() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *WaitForConditionRequest) HasTimeoutSeconds() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *WaitForConditionRequest) ClearCluster() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Cluster = nil
+}
+
+func (x *WaitForConditionRequest) ClearGroup() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Group = nil
+}
+
+func (x *WaitForConditionRequest) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Version = nil
+}
+
+func (x *WaitForConditionRequest) ClearResource() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Resource = nil
+}
+
+func (x *WaitForConditionRequest) ClearNamespace() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Namespace = nil
+}
+
+func (x *WaitForConditionRequest) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *WaitForConditionRequest) ClearConditionType() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_ConditionType = nil
+}
+
+func (x *WaitForConditionRequest) ClearStatus() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_Status = nil
+}
+
+func (x *WaitForConditionRequest) ClearTimeoutSeconds() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_TimeoutSeconds = 0
+}
+
+type WaitForConditionRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The target Kubernetes cluster identifier.
+	Cluster *string
+	// Kubernetes API Group (e.g., "apps" for Deployments, "" for core resources like Pods).
+	Group *string
+	// Kubernetes API Version (e.g., "v1").
+	Version *string
+	// Kubernetes API Resource name in plural (e.g., "pods", "deployments").
+	Resource *string
+	// The namespace of the resource.
+	Namespace *string
+	// The name of the resource.
+	Name *string
+	// The type of the status condition to wait for (e.g., "Available", "Ready", "Complete").
+	ConditionType *string
+	// The status the condition must have: "True", "False" or "Unknown".
+	// Defaults to "True".
+	Status *string
+	// How long to wait, in seconds, at most 300.
+	TimeoutSeconds *int64
+}
+
+func (b0 WaitForConditionRequest_builder) Build() *WaitForConditionRequest {
+	m0 := &WaitForConditionRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Cluster != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 9)
+		x.xxx_hidden_Cluster = b.Cluster
+	}
+	if b.Group != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 9)
+		x.xxx_hidden_Group = b.Group
+	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 9)
+		x.xxx_hidden_Version = b.Version
+	}
+	if b.Resource != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 9)
+		x.xxx_hidden_Resource = b.Resource
+	}
+	if b.Namespace != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 9)
+		x.xxx_hidden_Namespace = b.Namespace
+	}
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 9)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.ConditionType != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 9)
+		x.xxx_hidden_ConditionType = b.ConditionType
+	}
+	if b.Status != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 9)
+		x.xxx_hidden_Status = b.Status
+	}
+	if b.TimeoutSeconds != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 9)
+		x.xxx_hidden_TimeoutSeconds = *b.TimeoutSeconds
+	}
+	return m0
+}
+
+// WatchRequest defines the parameters to start a streaming watch.
+type WatchRequest struct {
+	state                            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster               *string                `protobuf:"bytes,1,opt,name=cluster"`
+	xxx_hidden_Group                 *string                `protobuf:"bytes,2,opt,name=group"`
+	xxx_hidden_Version               *string                `protobuf:"bytes,3,opt,name=version"`
+	xxx_hidden_Resource              *string                `protobuf:"bytes,4,opt,name=resource"`
+	xxx_hidden_Namespace             *string                `protobuf:"bytes,5,opt,name=namespace"`
+	xxx_hidden_LabelSelector         *string                `protobuf:"bytes,6,opt,name=label_selector,json=labelSelector"`
+	xxx_hidden_FieldSelector         *string                `protobuf:"bytes,7,opt,name=field_selector,json=fieldSelector"`
+	xxx_hidden_ResourceVersion       *string                `protobuf:"bytes,8,opt,name=resource_version,json=resourceVersion"`
+	xxx_hidden_SkipUnchanged         bool                   `protobuf:"varint,9,opt,name=skip_unchanged,json=skipUnchanged"`
+	xxx_hidden_RetainFields          []string               `protobuf:"bytes,10,rep,name=retain_fields,json=retainFields"`
+	xxx_hidden_ResumeToken           *string                `protobuf:"bytes,11,opt,name=resume_token,json=resumeToken"`
+	xxx_hidden_AllNamespaces         bool                   `protobuf:"varint,12,opt,name=all_namespaces,json=allNamespaces"`
+	xxx_hidden_ResyncIntervalSeconds int64                  `protobuf:"varint,13,opt,name=resync_interval_seconds,json=resyncIntervalSeconds"`
+	xxx_hidden_SendInitialEvents     bool                   `protobuf:"varint,14,opt,name=send_initial_events,json=sendInitialEvents"`
+	XXX_raceDetectHookData           protoimpl.RaceDetectHookData
+	XXX_presence                     [1]uint32
+	unknownFields                    protoimpl.UnknownFields
+	sizeCache                        protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *WatchRequest) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetGroup() string {
+	if x != nil {
+		if x.xxx_hidden_Group != nil {
+			return *x.xxx_hidden_Group
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetVersion() string {
+	if x != nil {
+		if x.xxx_hidden_Version != nil {
+			return *x.xxx_hidden_Version
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetResource() string {
+	if x != nil {
+		if x.xxx_hidden_Resource != nil {
+			return *x.xxx_hidden_Resource
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetNamespace() string {
+	if x != nil {
+		if x.xxx_hidden_Namespace != nil {
+			return *x.xxx_hidden_Namespace
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetLabelSelector() string {
+	if x != nil {
+		if x.xxx_hidden_LabelSelector != nil {
+			return *x.xxx_hidden_LabelSelector
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetFieldSelector() string {
+	if x != nil {
+		if x.xxx_hidden_FieldSelector != nil {
+			return *x.xxx_hidden_FieldSelector
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetResourceVersion() string {
+	if x != nil {
+		if x.xxx_hidden_ResourceVersion != nil {
+			return *x.xxx_hidden_ResourceVersion
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetSkipUnchanged() bool {
+	if x != nil {
+		return x.xxx_hidden_SkipUnchanged
+	}
+	return false
+}
+
+func (x *WatchRequest) GetRetainFields() []string {
+	if x != nil {
+		return x.xxx_hidden_RetainFields
+	}
+	return nil
+}
+
+func (x *WatchRequest) GetResumeToken() string {
+	if x != nil {
+		if x.xxx_hidden_ResumeToken != nil {
+			return *x.xxx_hidden_ResumeToken
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetAllNamespaces() bool {
+	if x != nil {
+		return x.xxx_hidden_AllNamespaces
+	}
+	return false
+}
+
+func (x *WatchRequest) GetResyncIntervalSeconds() int64 {
+	if x != nil {
+		return x.xxx_hidden_ResyncIntervalSeconds
+	}
+	return 0
+}
+
+func (x *WatchRequest) GetSendInitialEvents() bool {
+	if x != nil {
+		return x.xxx_hidden_SendInitialEvents
+	}
+	return false
+}
+
+func (x *WatchRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 14)
+}
+
+func (x *WatchRequest) SetGroup(v string) {
+	x.xxx_hidden_Group = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 14)
+}
+
+func (x *WatchRequest) SetVersion(v string) {
+	x.xxx_hidden_Version = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 14)
+}
+
+func (x *WatchRequest) SetResource(v string) {
+	x.xxx_hidden_Resource = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 14)
+}
+
+func (x *WatchRequest) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 14)
+}
+
+func (x *WatchRequest) SetLabelSelector(v string) {
+	x.xxx_hidden_LabelSelector = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 14)
+}
+
+func (x *WatchRequest) SetFieldSelector(v string) {
+	x.xxx_hidden_FieldSelector = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 14)
+}
+
+func (x *WatchRequest) SetResourceVersion(v string) {
+	x.xxx_hidden_ResourceVersion = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 14)
+}
+
+func (x *WatchRequest) SetSkipUnchanged(v bool) {
+	x.xxx_hidden_SkipUnchanged = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 14)
+}
+
+func (x *WatchRequest) SetRetainFields(v []string) {
+	x.xxx_hidden_RetainFields = v
+}
+
+func (x *WatchRequest) SetResumeToken(v string) {
+	x.xxx_hidden_ResumeToken = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 10, 14)
+}
+
+func (x *WatchRequest) SetAllNamespaces(v bool) {
+	x.xxx_hidden_AllNamespaces = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 11, 14)
+}
+
+func (x *WatchRequest) SetResyncIntervalSeconds(v int64) {
+	x.xxx_hidden_ResyncIntervalSeconds = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 12, 14)
+}
+
+func (x *WatchRequest) SetSendInitialEvents(v bool) {
+	x.xxx_hidden_SendInitialEvents = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 13, 14)
+}
+
+func (x *WatchRequest) HasCluster() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *WatchRequest) HasGroup() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *WatchRequest) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *WatchRequest) HasResource() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *WatchRequest) HasNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *WatchRequest) HasLabelSelector() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *WatchRequest) HasFieldSelector() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *WatchRequest) HasResourceVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *WatchRequest) HasSkipUnchanged() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *WatchRequest) HasResumeToken() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 10)
+}
+
+func (x *WatchRequest) HasAllNamespaces() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 11)
+}
+
+func (x *WatchRequest) HasResyncIntervalSeconds() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 12)
+}
+
+func (x *WatchRequest) HasSendInitialEvents() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 13)
+}
+
+func (x *WatchRequest) ClearCluster() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Cluster = nil
+}
+
+func (x *WatchRequest) ClearGroup() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Group = nil
+}
+
+func (x *WatchRequest) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Version = nil
+}
+
+func (x *WatchRequest) ClearResource() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Resource = nil
+}
+
+func (x *WatchRequest) ClearNamespace() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Namespace = nil
+}
+
+func (x *WatchRequest) ClearLabelSelector() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_LabelSelector = nil
+}
+
+func (x *WatchRequest) ClearFieldSelector() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_FieldSelector = nil
+}
+
+func (x *WatchRequest) ClearResourceVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_ResourceVersion = nil
+}
+
+func (x *WatchRequest) ClearSkipUnchanged() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_SkipUnchanged = false
+}
+
+func (x *WatchRequest) ClearResumeToken() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 10)
+	x.xxx_hidden_ResumeToken = nil
+}
+
+func (x *WatchRequest) ClearAllNamespaces() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 11)
+	x.xxx_hidden_AllNamespaces = false
+}
+
+func (x *WatchRequest) ClearResyncIntervalSeconds() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 12)
+	x.xxx_hidden_ResyncIntervalSeconds = 0
+}
+
+func (x *WatchRequest) ClearSendInitialEvents() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 13)
+	x.xxx_hidden_SendInitialEvents = false
+}
+
+type WatchRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The target Kubernetes cluster identifier.
+	Cluster *string
+	// Kubernetes API Group (e.g., "apps" for Deployments, "" for core resources like Pods).
+	Group *string
+	// Kubernetes API Version (e.g., "v1").
+	Version *string
+	// Kubernetes API Resource name in plural (e.g., "pods", "deployments").
+	Resource *string
+	// The namespace to watch. If empty, namespaced resources are watched in
+	// the server's default namespace unless all_namespaces is set.
+	Namespace *string
+	// A selector to restrict watched objects by their labels.
+	LabelSelector *string
+	// A selector to restrict watched objects by their fields.
+	FieldSelector *string
+	// Start the watch from this specific resource version.
+	ResourceVersion *string
+	// Suppress MODIFIED events in which the object is semantically
+	// unchanged from the version last sent on this stream, i.e. only
+	// metadata.resourceVersion or metadata.managedFields differ.
+	SkipUnchanged *bool
+	// Dotted field paths to keep on each object (e.g. "spec.interval",
+	// "status.conditions"), intended for custom resources with large
+	// specs or statuses. apiVersion, kind and metadata are always kept;
+	// paths not declared by the kind's OpenAPI schema are dropped. Paths
+	// continue through arrays into their elements. If the schema cannot
+	// be resolved, full objects are sent. Empty sends full objects.
+	RetainFields []string
+	// Resume from a resume_token returned on an earlier stream for the
+	// same cluster, resource and namespace, instead of from
+	// resource_version (which must then be unset). If the token's
+	// resourceVersion has expired, the server relists instead of failing:
+	// it sends a TYPE_BOOKMARK event with relisted set, then a fresh
+	// snapshot of TYPE_ADDED events ending with a TYPE_BOOKMARK that
+	// carries a new resume_token, and then continues with changes.
+	ResumeToken *string
+	// Watch a namespaced resource across all namespaces. Requires namespace
+	// to be empty. Ignored for cluster-scoped resources.
+	AllNamespaces *bool
+	// If positive, the server relists and rewatches every this many
+	// seconds, so that changes the upstream watch missed are eventually
+	// reflected. Each resync sends a TYPE_BOOKMARK event with resynced
+	// set, followed by a fresh snapshot of TYPE_ADDED events ending with a
+	// TYPE_BOOKMARK event that has initial_events_end set. Must not be
+	// shorter than the server's minimum resync interval.
+	ResyncIntervalSeconds *int64
+	// Whether a watch without resource_version asks a cluster that supports
+	// WatchList (Kubernetes >= 1.34) to stream the initial state, ending
+	// with a TYPE_BOOKMARK event that has initial_events_end set. Clients
+	// that already hold the state can set it to false. Unset uses the
+	// server's default, which streams them whenever the cluster supports
+	// it unless configured otherwise. Ignored on other clusters.
+	SendInitialEvents *bool
+}
+
+func (b0 WatchRequest_builder) Build() *WatchRequest {
+	m0 := &WatchRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Cluster != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 14)
+		x.xxx_hidden_Cluster = b.Cluster
+	}
+	if b.Group != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 14)
+		x.xxx_hidden_Group = b.Group
+	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 14)
+		x.xxx_hidden_Version = b.Version
+	}
+	if b.Resource != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 14)
+		x.xxx_hidden_Resource = b.Resource
+	}
+	if b.Namespace != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 14)
+		x.xxx_hidden_Namespace = b.Namespace
+	}
+	if b.LabelSelector != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 14)
+		x.xxx_hidden_LabelSelector = b.LabelSelector
+	}
+	if b.FieldSelector != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 14)
+		x.xxx_hidden_FieldSelector = b.FieldSelector
+	}
+	if b.ResourceVersion != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 14)
+		x.xxx_hidden_ResourceVersion = b.ResourceVersion
+	}
+	if b.SkipUnchanged != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 14)
+		x.xxx_hidden_SkipUnchanged = *b.SkipUnchanged
+	}
+	x.xxx_hidden_RetainFields = b.RetainFields
+	if b.ResumeToken != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 10, 14)
+		x.xxx_hidden_ResumeToken = b.ResumeToken
+	}
+	if b.AllNamespaces != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 11, 14)
+		x.xxx_hidden_AllNamespaces = *b.AllNamespaces
+	}
+	if b.ResyncIntervalSeconds != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 12, 14)
+		x.xxx_hidden_ResyncIntervalSeconds = *b.ResyncIntervalSeconds
+	}
+	if b.SendInitialEvents != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 13, 14)
+		x.xxx_hidden_SendInitialEvents = *b.SendInitialEvents
+	}
+	return m0
+}
+
+// WatchEvent represents a single change notification from the Kubernetes API.
+type WatchEvent struct {
+	state                       protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Type             WatchEvent_Type        `protobuf:"varint,1,opt,name=type,enum=otterscale.resource.v1.WatchEvent_Type"`
+	xxx_hidden_Resource         *Resource              `protobuf:"bytes,2,opt,name=resource"`
+	xxx_hidden_ResourceVersion  *string                `protobuf:"bytes,3,opt,name=resource_version,json=resourceVersion"`
+	xxx_hidden_RelistRequired   bool                   `protobuf:"varint,4,opt,name=relist_required,json=relistRequired"`
+	xxx_hidden_ResumeToken      *string                `protobuf:"bytes,5,opt,name=resume_token,json=resumeToken"`
+	xxx_hidden_Relisted         bool                   `protobuf:"varint,6,opt,name=relisted"`
+	xxx_hidden_Reconnect        bool                   `protobuf:"varint,7,opt,name=reconnect"`
+	xxx_hidden_InitialEventsEnd bool                   `protobuf:"varint,8,opt,name=initial_events_end,json=initialEventsEnd"`
+	xxx_hidden_Resynced         bool                   `protobuf:"varint,9,opt,name=resynced"`
+	XXX_raceDetectHookData      protoimpl.RaceDetectHookData
+	XXX_presence                [1]uint32
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
+}
+
+func (x *WatchEvent) Reset() {
+	*x = WatchEvent{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEvent) ProtoMessage() {}
+
+func (x *WatchEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *WatchEvent) GetType() WatchEvent_Type {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 0) {
+			return x.xxx_hidden_Type
+		}
+	}
+	return WatchEvent_TYPE_UNSPECIFIED
+}
+
+func (x *WatchEvent) GetResource() *Resource {
+	if x != nil {
+		return x.xxx_hidden_Resource
+	}
+	return nil
+}
+
+func (x *WatchEvent) GetResourceVersion() string {
+	if x != nil {
+		if x.xxx_hidden_ResourceVersion != nil {
+			return *x.xxx_hidden_ResourceVersion
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WatchEvent) GetRelistRequired() bool {
+	if x != nil {
+		return x.xxx_hidden_RelistRequired
+	}
+	return false
+}
+
+func (x *WatchEvent) GetResumeToken() string {
+	if x != nil {
+		if x.xxx_hidden_ResumeToken != nil {
+			return *x.xxx_hidden_ResumeToken
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WatchEvent) GetRelisted() bool {
+	if x != nil {
+		return x.xxx_hidden_Relisted
+	}
+	return false
+}
+
+func (x *WatchEvent) GetReconnect() bool {
+	if x != nil {
+		return x.xxx_hidden_Reconnect
+	}
+	return false
+}
+
+func (x *WatchEvent) GetInitialEventsEnd() bool {
+	if x != nil {
+		return x.xxx_hidden_InitialEventsEnd
+	}
+	return false
+}
+
+func (x *WatchEvent) GetResynced() bool {
+	if x != nil {
+		return x.xxx_hidden_Resynced
+	}
+	return false
+}
+
+func (x *WatchEvent) SetType(v WatchEvent_Type) {
+	x.xxx_hidden_Type = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 9)
+}
+
+func (x *WatchEvent) SetResource(v *Resource) {
+	x.xxx_hidden_Resource = v
+}
+
+func (x *WatchEvent) SetResourceVersion(v string) {
+	x.xxx_hidden_ResourceVersion = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 9)
+}
+
+func (x *WatchEvent) SetRelistRequired(v bool) {
+	x.xxx_hidden_RelistRequired = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 9)
+}
+
+func (x *WatchEvent) SetResumeToken(v string) {
+	x.xxx_hidden_ResumeToken = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 9)
+}
+
+func (x *WatchEvent) SetRelisted(v bool) {
+	x.xxx_hidden_Relisted = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 9)
+}
+
+func (x *WatchEvent) SetReconnect(v bool) {
+	x.xxx_hidden_Reconnect = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 9)
+}
+
+func (x *WatchEvent) SetInitialEventsEnd(v bool) {
+	x.xxx_hidden_InitialEventsEnd = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 9)
+}
+
+func (x *WatchEvent) SetResynced(v bool) {
+	x.xxx_hidden_Resynced = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 9)
+}
+
+func (x *WatchEvent) HasType() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *WatchEvent) HasResource() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Resource != nil
+}
+
+func (x *WatchEvent) HasResourceVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *WatchEvent) HasRelistRequired() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *WatchEvent) HasResumeToken() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *WatchEvent) HasRelisted() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *WatchEvent) HasReconnect() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *WatchEvent) HasInitialEventsEnd() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *WatchEvent) HasResynced() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *WatchEvent) ClearType() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Type = WatchEvent_TYPE_UNSPECIFIED
+}
+
+func (x *WatchEvent) ClearResource() {
+	x.xxx_hidden_Resource = nil
+}
+
+func (x *WatchEvent) ClearResourceVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_ResourceVersion = nil
+}
+
+func (x *WatchEvent) ClearRelistRequired() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_RelistRequired = false
+}
+
+func (x *WatchEvent) ClearResumeToken() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_ResumeToken = nil
+}
+
+func (x *WatchEvent) ClearRelisted() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Relisted = false
+}
+
+func (x *WatchEvent) ClearReconnect() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_Reconnect = false
+}
+
+func (x *WatchEvent) ClearInitialEventsEnd() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_InitialEventsEnd = false
+}
+
+func (x *WatchEvent) ClearResynced() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_Resynced = false
+}
+
+type WatchEvent_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The type of the watch event.
+	Type *WatchEvent_Type
+	// The state of the resource associated with the event.
+	Resource *Resource
+	// The resourceVersion of the watch event, used to initiate a Watch from a specific point in time.
+	ResourceVersion *string
+	// Set on TYPE_ERROR events when the requested resourceVersion is too old
+	// for the API server to serve (HTTP 410 Gone). The client must relist and
+	// start a new Watch from the resourceVersion of the fresh list. The stream
+	// ends after this event.
+	RelistRequired *bool
+	// An opaque token from which a later Watch can resume the stream
+	// after this event. It is not set while the stream sends an initial
+	// snapshot, since resuming from the middle of a snapshot would skip
+	// the objects not yet sent.
+	ResumeToken *string
+	// Set on a TYPE_BOOKMARK event when a resumed watch had to relist
+	// because its resourceVersion expired. The client must discard its
+	// cached objects; a fresh snapshot follows.
+	Relisted *bool
+	// Set on the final TYPE_BOOKMARK event when the server ends the stream
+	// because it reached the server's maximum watch duration. The client
+	// should start a new Watch, resuming from resume_token when it is set.
+	Reconnect *bool
+	// Set on the TYPE_BOOKMARK event that ends the initial snapshot of
+	// objects, sent when the watch starts without a resourceVersion on a
+	// cluster that supports streaming lists, and after a relist. Once it
+	// arrives, the client holds the complete state of the watched
+	// resources.
+	InitialEventsEnd *bool
+	// Set on a TYPE_BOOKMARK event when the watch resyncs periodically, as
+	// requested by resync_interval_seconds. A fresh snapshot follows;
+	// objects the client holds that are absent from it no longer exist.
+	Resynced *bool
+}
+
+func (b0 WatchEvent_builder) Build() *WatchEvent {
+	m0 := &WatchEvent{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Type != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 9)
+		x.xxx_hidden_Type = *b.Type
+	}
+	x.xxx_hidden_Resource = b.Resource
+	if b.ResourceVersion != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 9)
+		x.xxx_hidden_ResourceVersion = b.ResourceVersion
+	}
+	if b.RelistRequired != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 9)
+		x.xxx_hidden_RelistRequired = *b.RelistRequired
+	}
+	if b.ResumeToken != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 9)
+		x.xxx_hidden_ResumeToken = b.ResumeToken
+	}
+	if b.Relisted != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 9)
+		x.xxx_hidden_Relisted = *b.Relisted
+	}
+	if b.Reconnect != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 9)
+		x.xxx_hidden_Reconnect = *b.Reconnect
+	}
+	if b.InitialEventsEnd != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 9)
+		x.xxx_hidden_InitialEventsEnd = *b.InitialEventsEnd
+	}
+	if b.Resynced != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 9)
+		x.xxx_hidden_Resynced = *b.Resynced
+	}
+	return m0
+}
+
+// WatchAcrossClustersRequest defines the resource type to watch and the
+// clusters to watch it on.
+type WatchAcrossClustersRequest struct {
+	state                        protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Clusters          []string               `protobuf:"bytes,1,rep,name=clusters"`
+	xxx_hidden_Group             *string                `protobuf:"bytes,2,opt,name=group"`
+	xxx_hidden_Version           *string                `protobuf:"bytes,3,opt,name=version"`
+	xxx_hidden_Resource          *string                `protobuf:"bytes,4,opt,name=resource"`
+	xxx_hidden_Namespace         *string                `protobuf:"bytes,5,opt,name=namespace"`
+	xxx_hidden_LabelSelector     *string                `protobuf:"bytes,6,opt,name=label_selector,json=labelSelector"`
+	xxx_hidden_FieldSelector     *string                `protobuf:"bytes,7,opt,name=field_selector,json=fieldSelector"`
+	xxx_hidden_AllNamespaces     bool                   `protobuf:"varint,8,opt,name=all_namespaces,json=allNamespaces"`
+	xxx_hidden_SendInitialEvents bool                   `protobuf:"varint,9,opt,name=send_initial_events,json=sendInitialEvents"`
+	XXX_raceDetectHookData       protoimpl.RaceDetectHookData
+	XXX_presence                 [1]uint32
+	unknownFields                protoimpl.UnknownFields
+	sizeCache                    protoimpl.SizeCache
+}
+
+func (x *WatchAcrossClustersRequest) Reset() {
+	*x = WatchAcrossClustersRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchAcrossClustersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchAcrossClustersRequest) ProtoMessage() {}
+
+func (x *WatchAcrossClustersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *WatchAcrossClustersRequest) GetClusters() []string {
+	if x != nil {
+		return x.xxx_hidden_Clusters
+	}
+	return nil
+}
+
+func (x *WatchAcrossClustersRequest) GetGroup() string {
+	if x != nil {
+		if x.xxx_hidden_Group != nil {
+			return *x.xxx_hidden_Group
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WatchAcrossClustersRequest) GetVersion() string {
+	if x != nil {
+		if x.xxx_hidden_Version != nil {
+			return *x.xxx_hidden_Version
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WatchAcrossClustersRequest) GetResource() string {
+	if x != nil {
+		if x.xxx_hidden_Resource != nil {
+			return *x.xxx_hidden_Resource
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WatchAcrossClustersRequest) GetNamespace() string {
+	if x != nil {
+		if x.xxx_hidden_Namespace != nil {
+			return *x.xxx_hidden_Namespace
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WatchAcrossClustersRequest) GetLabelSelector() string {
+	if x != nil {
+		if x.xxx_hidden_LabelSelector != nil {
+			return *x.xxx_hidden_LabelSelector
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WatchAcrossClustersRequest) GetFieldSelector() string {
+	if x != nil {
+		if x.xxx_hidden_FieldSelector != nil {
+			return *x.xxx_hidden_FieldSelector
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WatchAcrossClustersRequest) GetAllNamespaces() bool {
+	if x != nil {
+		return x.xxx_hidden_AllNamespaces
+	}
+	return false
+}
+
+func (x *WatchAcrossClustersRequest) GetSendInitialEvents() bool {
+	if x != nil {
+		return x.xxx_hidden_SendInitialEvents
+	}
+	return false
+}
+
+func (x *WatchAcrossClustersRequest) SetClusters(v []string) {
+	x.xxx_hidden_Clusters = v
+}
+
+func (x *WatchAcrossClustersRequest) SetGroup(v string) {
+	x.xxx_hidden_Group = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 9)
+}
+
+func (x *WatchAcrossClustersRequest) SetVersion(v string) {
+	x.xxx_hidden_Version = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 9)
+}
+
+func (x *WatchAcrossClustersRequest) SetResource(v string) {
+	x.xxx_hidden_Resource = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 9)
+}
+
+func (x *WatchAcrossClustersRequest) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 9)
+}
+
+func (x *WatchAcrossClustersRequest) SetLabelSelector(v string) {
+	x.xxx_hidden_LabelSelector = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 9)
+}
+
+func (x *WatchAcrossClustersRequest) SetFieldSelector(v string) {
+	x.xxx_hidden_FieldSelector = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 9)
+}
+
+func (x *WatchAcrossClustersRequest) SetAllNamespaces(v bool) {
+	x.xxx_hidden_AllNamespaces = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 9)
+}
+
+func (x *WatchAcrossClustersRequest) SetSendInitialEvents(v bool) {
+	x.xxx_hidden_SendInitialEvents = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 9)
+}
+
+func (x *WatchAcrossClustersRequest) HasGroup() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *WatchAcrossClustersRequest) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *WatchAcrossClustersRequest) HasResource() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *WatchAcrossClustersRequest) HasNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *WatchAcrossClustersRequest) HasLabelSelector() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *WatchAcrossClustersRequest) HasFieldSelector() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *WatchAcrossClustersRequest) HasAllNamespaces() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *WatchAcrossClustersRequest) HasSendInitialEvents() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *WatchAcrossClustersRequest) ClearGroup() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Group = nil
+}
+
+func (x *WatchAcrossClustersRequest) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Version = nil
+}
+
+func (x *WatchAcrossClustersRequest) ClearResource() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Resource = nil
+}
+
+func (x *WatchAcrossClustersRequest) ClearNamespace() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Namespace = nil
+}
+
+func (x *WatchAcrossClustersRequest) ClearLabelSelector() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_LabelSelector = nil
+}
+
+func (x *WatchAcrossClustersRequest) ClearFieldSelector() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_FieldSelector = nil
+}
+
+func (x *WatchAcrossClustersRequest) ClearAllNamespaces() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_AllNamespaces = false
+}
+
+func (x *WatchAcrossClustersRequest) ClearSendInitialEvents() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_SendInitialEvents = false
+}
+
+type WatchAcrossClustersRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The clusters to watch, at most 100, each listed once.
+	Clusters []string
+	// Kubernetes API Group (e.g., "apps" for Deployments, "" for core resources like Pods).
+	Group *string
+	// Kubernetes API Version (e.g., "v1").
+	Version *string
+	// Kubernetes API Resource name in plural (e.g., "pods", "deployments").
+	Resource *string
+	// The namespace to watch on every cluster, as for Watch.
+	Namespace *string
+	// A selector to restrict watched objects by their labels.
+	LabelSelector *string
+	// A selector to restrict watched objects by their fields.
+	FieldSelector *string
+	// Watch a namespaced resource across all namespaces. Requires namespace
+	// to be empty. Ignored for cluster-scoped resources.
+	AllNamespaces *bool
+	// Whether each cluster's watch streams the initial state, as for Watch.
+	SendInitialEvents *bool
+}
+
+func (b0 WatchAcrossClustersRequest_builder) Build() *WatchAcrossClustersRequest {
+	m0 := &WatchAcrossClustersRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Clusters = b.Clusters
+	if b.Group != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 9)
+		x.xxx_hidden_Group = b.Group
+	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 9)
+		x.xxx_hidden_Version = b.Version
+	}
+	if b.Resource != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 9)
+		x.xxx_hidden_Resource = b.Resource
+	}
+	if b.Namespace != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 9)
+		x.xxx_hidden_Namespace = b.Namespace
+	}
+	if b.LabelSelector != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 9)
+		x.xxx_hidden_LabelSelector = b.LabelSelector
+	}
+	if b.FieldSelector != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 9)
+		x.xxx_hidden_FieldSelector = b.FieldSelector
+	}
+	if b.AllNamespaces != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 9)
+		x.xxx_hidden_AllNamespaces = *b.AllNamespaces
+	}
+	if b.SendInitialEvents != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 9)
+		x.xxx_hidden_SendInitialEvents = *b.SendInitialEvents
+	}
+	return m0
+}
+
+// ClusterWatchEvent is an event of one of the watched clusters.
+type ClusterWatchEvent struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster     *string                `protobuf:"bytes,1,opt,name=cluster"`
+	xxx_hidden_Event       *WatchEvent            `protobuf:"bytes,2,opt,name=event"`
+	xxx_hidden_Error       *string                `protobuf:"bytes,3,opt,name=error"`
+	xxx_hidden_Retrying    bool                   `protobuf:"varint,4,opt,name=retrying"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ClusterWatchEvent) Reset() {
+	*x = ClusterWatchEvent{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClusterWatchEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClusterWatchEvent) ProtoMessage() {}
+
+func (x *ClusterWatchEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ClusterWatchEvent) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ClusterWatchEvent) GetEvent() *WatchEvent {
+	if x != nil {
+		return x.xxx_hidden_Event
+	}
+	return nil
+}
+
+func (x *ClusterWatchEvent) GetError() string {
+	if x != nil {
+		if x.xxx_hidden_Error != nil {
+			return *x.xxx_hidden_Error
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ClusterWatchEvent) GetRetrying() bool {
+	if x != nil {
+		return x.xxx_hidden_Retrying
+	}
+	return false
+}
+
+func (x *ClusterWatchEvent) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *ClusterWatchEvent) SetEvent(v *WatchEvent) {
+	x.xxx_hidden_Event = v
+}
+
+func (x *ClusterWatchEvent) SetError(v string) {
+	x.xxx_hidden_Error = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *ClusterWatchEvent) SetRetrying(v bool) {
+	x.xxx_hidden_Retrying = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+}
+
+func (x *ClusterWatchEvent) HasCluster() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ClusterWatchEvent) HasEvent() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Event != nil
+}
+
+func (x *ClusterWatchEvent) HasError() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ClusterWatchEvent) HasRetrying() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *ClusterWatchEvent) ClearCluster() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Cluster = nil
+}
+
+func (x *ClusterWatchEvent) ClearEvent() {
+	x.xxx_hidden_Event = nil
+}
+
+func (x *ClusterWatchEvent) ClearError() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Error = nil
+}
+
+func (x *ClusterWatchEvent) ClearRetrying() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Retrying = false
+}
+
+type ClusterWatchEvent_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The cluster the event belongs to. Unset on the final TYPE_BOOKMARK
+	// event with reconnect set that ends the stream at the server's maximum
+	// watch duration.
+	Cluster *string
+	// The event, as Watch would send it for the cluster. Resume tokens are
+	// not set.
+	Event *WatchEvent
+	// Set on a TYPE_ERROR event when the cluster's watch could not be
+	// opened or ended: why.
+	Error *string
+	// Whether the server reopens the cluster's watch after the error. Watches
+	// reopened after the last event delivered resume from it; others restart
+	// with the initial state. If false, the cluster is no longer watched on
+	// this stream.
+	Retrying *bool
+}
+
+func (b0 ClusterWatchEvent_builder) Build() *ClusterWatchEvent {
+	m0 := &ClusterWatchEvent{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Cluster != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Cluster = b.Cluster
+	}
+	x.xxx_hidden_Event = b.Event
+	if b.Error != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_Error = b.Error
+	}
+	if b.Retrying != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_Retrying = *b.Retrying
+	}
+	return m0
+}
+
+// ProxyRequest describes a raw API server request to forward.
+type ProxyRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster     *string                `protobuf:"bytes,1,opt,name=cluster"`
+	xxx_hidden_Method      *string                `protobuf:"bytes,2,opt,name=method"`
+	xxx_hidden_Path        *string                `protobuf:"bytes,3,opt,name=path"`
+	xxx_hidden_Query       *string                `protobuf:"bytes,4,opt,name=query"`
+	xxx_hidden_Body        []byte                 `protobuf:"bytes,5,opt,name=body"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ProxyRequest) Reset() {
+	*x = ProxyRequest{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProxyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProxyRequest) ProtoMessage() {}
+
+func (x *ProxyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ProxyRequest) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ProxyRequest) GetMethod() string {
+	if x != nil {
+		if x.xxx_hidden_Method != nil {
+			return *x.xxx_hidden_Method
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ProxyRequest) GetPath() string {
+	if x != nil {
+		if x.xxx_hidden_Path != nil {
+			return *x.xxx_hidden_Path
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ProxyRequest) GetQuery() string {
+	if x != nil {
+		if x.xxx_hidden_Query != nil {
+			return *x.xxx_hidden_Query
+		}
+		return ""
+	}
+	return ""
 }
 
-func (x *WatchRequest) SetVersion(v string) {
-	x.xxx_hidden_Version = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 8)
+func (x *ProxyRequest) GetBody() []byte {
+	if x != nil {
+		return x.xxx_hidden_Body
+	}
+	return nil
 }
 
-func (x *WatchRequest) SetResource(v string) {
-	x.xxx_hidden_Resource = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 8)
+func (x *ProxyRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
 }
 
-func (x *WatchRequest) SetNamespace(v string) {
-	x.xxx_hidden_Namespace = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 8)
+func (x *ProxyRequest) SetMethod(v string) {
+	x.xxx_hidden_Method = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 5)
 }
 
-func (x *WatchRequest) SetLabelSelector(v string) {
-	x.xxx_hidden_LabelSelector = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 8)
+func (x *ProxyRequest) SetPath(v string) {
+	x.xxx_hidden_Path = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 5)
 }
 
-func (x *WatchRequest) SetFieldSelector(v string) {
-	x.xxx_hidden_FieldSelector = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 8)
+func (x *ProxyRequest) SetQuery(v string) {
+	x.xxx_hidden_Query = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 5)
 }
 
-func (x *WatchRequest) SetResourceVersion(v string) {
-	x.xxx_hidden_ResourceVersion = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 8)
+func (x *ProxyRequest) SetBody(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Body = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 5)
 }
 
-func (x *WatchRequest) HasCluster() bool {
+func (x *ProxyRequest) HasCluster() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *WatchRequest) HasGroup() bool {
+func (x *ProxyRequest) HasMethod() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *WatchRequest) HasVersion() bool {
+func (x *ProxyRequest) HasPath() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
 }
 
-func (x *WatchRequest) HasResource() bool {
+func (x *ProxyRequest) HasQuery() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
 }
 
-func (x *WatchRequest) HasNamespace() bool {
+func (x *ProxyRequest) HasBody() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
 }
 
-func (x *WatchRequest) HasLabelSelector() bool {
-	if x == nil {
-		return false
-	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
-}
-
-func (x *WatchRequest) HasFieldSelector() bool {
-	if x == nil {
-		return false
-	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
-}
-
-func (x *WatchRequest) HasResourceVersion() bool {
-	if x == nil {
-		return false
-	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
-}
-
-func (x *WatchRequest) ClearCluster() {
+func (x *ProxyRequest) ClearCluster() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Cluster = nil
 }
 
-func (x *WatchRequest) ClearGroup() {
+func (x *ProxyRequest) ClearMethod() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
-	x.xxx_hidden_Group = nil
+	x.xxx_hidden_Method = nil
 }
 
-func (x *WatchRequest) ClearVersion() {
+func (x *ProxyRequest) ClearPath() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
-	x.xxx_hidden_Version = nil
+	x.xxx_hidden_Path = nil
 }
 
-func (x *WatchRequest) ClearResource() {
+func (x *ProxyRequest) ClearQuery() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
-	x.xxx_hidden_Resource = nil
+	x.xxx_hidden_Query = nil
 }
 
-func (x *WatchRequest) ClearNamespace() {
+func (x *ProxyRequest) ClearBody() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
-	x.xxx_hidden_Namespace = nil
-}
-
-func (x *WatchRequest) ClearLabelSelector() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
-	x.xxx_hidden_LabelSelector = nil
-}
-
-func (x *WatchRequest) ClearFieldSelector() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
-	x.xxx_hidden_FieldSelector = nil
-}
-
-func (x *WatchRequest) ClearResourceVersion() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
-	x.xxx_hidden_ResourceVersion = nil
+	x.xxx_hidden_Body = nil
 }
 
-type WatchRequest_builder struct {
+type ProxyRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
 	// The target Kubernetes cluster identifier.
 	Cluster *string
-	// Kubernetes API Group (e.g., "apps" for Deployments, "" for core resources like Pods).
-	Group *string
-	// Kubernetes API Version (e.g., "v1").
-	Version *string
-	// Kubernetes API Resource name in plural (e.g., "pods", "deployments").
-	Resource *string
-	// The namespace to watch.
-	Namespace *string
-	// A selector to restrict watched objects by their labels.
-	LabelSelector *string
-	// A selector to restrict watched objects by their fields.
-	FieldSelector *string
-	// Start the watch from this specific resource version.
-	ResourceVersion *string
-}
-
-func (b0 WatchRequest_builder) Build() *WatchRequest {
-	m0 := &WatchRequest{}
+	// The HTTP method. Only "GET" is accepted; empty defaults to "GET".
+	Method *string
+	// The absolute request path (e.g., "/apis/apps/v1/namespaces/default/deployments/web/status").
+	Path *string
+	// The raw URL query string, without the leading "?" (e.g., "pretty=true").
+	Query *string
+	// The request body. Must be empty for GET requests.
+	Body []byte
+}
+
+func (b0 ProxyRequest_builder) Build() *ProxyRequest {
+	m0 := &ProxyRequest{}
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Cluster != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 8)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
 		x.xxx_hidden_Cluster = b.Cluster
 	}
-	if b.Group != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 8)
-		x.xxx_hidden_Group = b.Group
-	}
-	if b.Version != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 8)
-		x.xxx_hidden_Version = b.Version
-	}
-	if b.Resource != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 8)
-		x.xxx_hidden_Resource = b.Resource
-	}
-	if b.Namespace != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 8)
-		x.xxx_hidden_Namespace = b.Namespace
+	if b.Method != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 5)
+		x.xxx_hidden_Method = b.Method
 	}
-	if b.LabelSelector != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 8)
-		x.xxx_hidden_LabelSelector = b.LabelSelector
+	if b.Path != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 5)
+		x.xxx_hidden_Path = b.Path
 	}
-	if b.FieldSelector != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 8)
-		x.xxx_hidden_FieldSelector = b.FieldSelector
+	if b.Query != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 5)
+		x.xxx_hidden_Query = b.Query
 	}
-	if b.ResourceVersion != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 8)
-		x.xxx_hidden_ResourceVersion = b.ResourceVersion
+	if b.Body != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 5)
+		x.xxx_hidden_Body = b.Body
 	}
 	return m0
 }
 
-// WatchEvent represents a single change notification from the Kubernetes API.
-type WatchEvent struct {
-	state                      protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Type            WatchEvent_Type        `protobuf:"varint,1,opt,name=type,enum=otterscale.resource.v1.WatchEvent_Type"`
-	xxx_hidden_Resource        *Resource              `protobuf:"bytes,2,opt,name=resource"`
-	xxx_hidden_ResourceVersion *string                `protobuf:"bytes,3,opt,name=resource_version,json=resourceVersion"`
-	XXX_raceDetectHookData     protoimpl.RaceDetectHookData
-	XXX_presence               [1]uint32
-	unknownFields              protoimpl.UnknownFields
-	sizeCache                  protoimpl.SizeCache
+// ProxyResponse carries the API server's raw response.
+type ProxyResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_StatusCode  int32                  `protobuf:"varint,1,opt,name=status_code,json=statusCode"`
+	xxx_hidden_ContentType *string                `protobuf:"bytes,2,opt,name=content_type,json=contentType"`
+	xxx_hidden_Body        []byte                 `protobuf:"bytes,3,opt,name=body"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
-func (x *WatchEvent) Reset() {
-	*x = WatchEvent{}
-	mi := &file_api_resource_v1_resource_proto_msgTypes[14]
+func (x *ProxyResponse) Reset() {
+	*x = ProxyResponse{}
+	mi := &file_api_resource_v1_resource_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WatchEvent) String() string {
+func (x *ProxyResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WatchEvent) ProtoMessage() {}
+func (*ProxyResponse) ProtoMessage() {}
 
-func (x *WatchEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_api_resource_v1_resource_proto_msgTypes[14]
+func (x *ProxyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_resource_v1_resource_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3054,104 +11658,110 @@ func (x *WatchEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *WatchEvent) GetType() WatchEvent_Type {
+func (x *ProxyResponse) GetStatusCode() int32 {
 	if x != nil {
-		if protoimpl.X.Present(&(x.XXX_presence[0]), 0) {
-			return x.xxx_hidden_Type
-		}
+		return x.xxx_hidden_StatusCode
 	}
-	return WatchEvent_TYPE_UNSPECIFIED
+	return 0
 }
 
-func (x *WatchEvent) GetResource() *Resource {
+func (x *ProxyResponse) GetContentType() string {
 	if x != nil {
-		return x.xxx_hidden_Resource
+		if x.xxx_hidden_ContentType != nil {
+			return *x.xxx_hidden_ContentType
+		}
+		return ""
 	}
-	return nil
+	return ""
 }
 
-func (x *WatchEvent) GetResourceVersion() string {
+func (x *ProxyResponse) GetBody() []byte {
 	if x != nil {
-		if x.xxx_hidden_ResourceVersion != nil {
-			return *x.xxx_hidden_ResourceVersion
-		}
-		return ""
+		return x.xxx_hidden_Body
 	}
-	return ""
+	return nil
 }
 
-func (x *WatchEvent) SetType(v WatchEvent_Type) {
-	x.xxx_hidden_Type = v
+func (x *ProxyResponse) SetStatusCode(v int32) {
+	x.xxx_hidden_StatusCode = v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
 }
 
-func (x *WatchEvent) SetResource(v *Resource) {
-	x.xxx_hidden_Resource = v
+func (x *ProxyResponse) SetContentType(v string) {
+	x.xxx_hidden_ContentType = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
 }
 
-func (x *WatchEvent) SetResourceVersion(v string) {
-	x.xxx_hidden_ResourceVersion = &v
+func (x *ProxyResponse) SetBody(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Body = v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
 }
 
-func (x *WatchEvent) HasType() bool {
+func (x *ProxyResponse) HasStatusCode() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *WatchEvent) HasResource() bool {
+func (x *ProxyResponse) HasContentType() bool {
 	if x == nil {
 		return false
 	}
-	return x.xxx_hidden_Resource != nil
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *WatchEvent) HasResourceVersion() bool {
+func (x *ProxyResponse) HasBody() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
 }
 
-func (x *WatchEvent) ClearType() {
+func (x *ProxyResponse) ClearStatusCode() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
-	x.xxx_hidden_Type = WatchEvent_TYPE_UNSPECIFIED
+	x.xxx_hidden_StatusCode = 0
 }
 
-func (x *WatchEvent) ClearResource() {
-	x.xxx_hidden_Resource = nil
+func (x *ProxyResponse) ClearContentType() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_ContentType = nil
 }
 
-func (x *WatchEvent) ClearResourceVersion() {
+func (x *ProxyResponse) ClearBody() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
-	x.xxx_hidden_ResourceVersion = nil
+	x.xxx_hidden_Body = nil
 }
 
-type WatchEvent_builder struct {
+type ProxyResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	// The type of the watch event.
-	Type *WatchEvent_Type
-	// The state of the resource associated with the event.
-	Resource *Resource
-	// The resourceVersion of the watch event, used to initiate a Watch from a specific point in time.
-	ResourceVersion *string
+	// The HTTP status code returned by the API server.
+	StatusCode *int32
+	// The Content-Type of the response body.
+	ContentType *string
+	// The raw response body.
+	Body []byte
 }
 
-func (b0 WatchEvent_builder) Build() *WatchEvent {
-	m0 := &WatchEvent{}
+func (b0 ProxyResponse_builder) Build() *ProxyResponse {
+	m0 := &ProxyResponse{}
 	b, x := &b0, m0
 	_, _ = b, x
-	if b.Type != nil {
+	if b.StatusCode != nil {
 		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
-		x.xxx_hidden_Type = *b.Type
+		x.xxx_hidden_StatusCode = *b.StatusCode
 	}
-	x.xxx_hidden_Resource = b.Resource
-	if b.ResourceVersion != nil {
+	if b.ContentType != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_ContentType = b.ContentType
+	}
+	if b.Body != nil {
 		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
-		x.xxx_hidden_ResourceVersion = b.ResourceVersion
+		x.xxx_hidden_Body = b.Body
 	}
 	return m0
 }
@@ -3160,7 +11770,7 @@ var File_api_resource_v1_resource_proto protoreflect.FileDescriptor
 
 const file_api_resource_v1_resource_proto_rawDesc = "" +
 	"\n" +
-	"\x1eapi/resource/v1/resource.proto\x12\x16otterscale.resource.v1\x1a\x15api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xc4\x01\n" +
+	"\x1eapi/resource/v1/resource.proto\x12\x16otterscale.resource.v1\x1a\x15api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc4\x01\n" +
 	"\vAPIResource\x12\x14\n" +
 	"\x05group\x18\x01 \x01(\tR\x05group\x12\x18\n" +
 	"\aversion\x18\x02 \x01(\tR\aversion\x12\x1a\n" +
@@ -3173,16 +11783,31 @@ const file_api_resource_v1_resource_proto_rawDesc = "" +
 	"\vshort_names\x18\a \x03(\tR\n" +
 	"shortNames\",\n" +
 	"\x10DiscoveryRequest\x12\x18\n" +
-	"\acluster\x18\x01 \x01(\tR\acluster\"]\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\"Q\n" +
+	"\x10DiscoveryWarning\x12#\n" +
+	"\rgroup_version\x18\x01 \x01(\tR\fgroupVersion\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xa3\x01\n" +
 	"\x11DiscoveryResponse\x12H\n" +
-	"\rapi_resources\x18\x01 \x03(\v2#.otterscale.resource.v1.APIResourceR\fapiResources\"m\n" +
+	"\rapi_resources\x18\x01 \x03(\v2#.otterscale.resource.v1.APIResourceR\fapiResources\x12D\n" +
+	"\bwarnings\x18\x02 \x03(\v2(.otterscale.resource.v1.DiscoveryWarningR\bwarnings\"/\n" +
+	"\x13CapabilitiesRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\"\xe1\x02\n" +
+	"\x14CapabilitiesResponse\x12-\n" +
+	"\x12kubernetes_version\x18\x01 \x01(\tR\x11kubernetesVersion\x12*\n" +
+	"\x11server_side_apply\x18\x02 \x01(\bR\x0fserverSideApply\x121\n" +
+	"\x14ephemeral_containers\x18\x03 \x01(\bR\x13ephemeralContainers\x12-\n" +
+	"\x12sidecar_containers\x18\x04 \x01(\bR\x11sidecarContainers\x12>\n" +
+	"\x1bvalidating_admission_policy\x18\x05 \x01(\bR\x19validatingAdmissionPolicy\x12-\n" +
+	"\x13in_place_pod_resize\x18\x06 \x01(\bR\x10inPlacePodResize\x12\x1d\n" +
+	"\n" +
+	"watch_list\x18\a \x01(\bR\twatchList\"m\n" +
 	"\rSchemaRequest\x12\x18\n" +
 	"\acluster\x18\x01 \x01(\tR\acluster\x12\x14\n" +
 	"\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n" +
 	"\aversion\x18\x03 \x01(\tR\aversion\x12\x12\n" +
 	"\x04kind\x18\x04 \x01(\tR\x04kind\";\n" +
 	"\bResource\x12/\n" +
-	"\x06object\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x06object\"\x91\x02\n" +
+	"\x06object\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x06object\"\xb8\x02\n" +
 	"\vListRequest\x12\x18\n" +
 	"\acluster\x18\x01 \x01(\tR\acluster\x12\x14\n" +
 	"\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n" +
@@ -3192,7 +11817,9 @@ const file_api_resource_v1_resource_proto_rawDesc = "" +
 	"\x0elabel_selector\x18\x06 \x01(\tR\rlabelSelector\x12%\n" +
 	"\x0efield_selector\x18\a \x01(\tR\rfieldSelector\x12\x14\n" +
 	"\x05limit\x18\b \x01(\x03R\x05limit\x12\x1a\n" +
-	"\bcontinue\x18\t \x01(\tR\bcontinue\"\xbf\x01\n" +
+	"\bcontinue\x18\t \x01(\tR\bcontinue\x12%\n" +
+	"\x0eall_namespaces\x18\n" +
+	" \x01(\bR\rallNamespaces\"\xbf\x01\n" +
 	"\fListResponse\x12)\n" +
 	"\x10resource_version\x18\x01 \x01(\tR\x0fresourceVersion\x12\x1a\n" +
 	"\bcontinue\x18\x02 \x01(\tR\bcontinue\x120\n" +
@@ -3205,24 +11832,114 @@ const file_api_resource_v1_resource_proto_rawDesc = "" +
 	"\aversion\x18\x03 \x01(\tR\aversion\x12\x1a\n" +
 	"\bresource\x18\x04 \x01(\tR\bresource\x12\x1c\n" +
 	"\tnamespace\x18\x05 \x01(\tR\tnamespace\x12\x12\n" +
-	"\x04name\x18\x06 \x01(\tR\x04name\"\xa9\x01\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\"\xdb\x01\n" +
 	"\x0fDescribeRequest\x12\x18\n" +
 	"\acluster\x18\x01 \x01(\tR\acluster\x12\x14\n" +
 	"\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n" +
 	"\aversion\x18\x03 \x01(\tR\aversion\x12\x1a\n" +
 	"\bresource\x18\x04 \x01(\tR\bresource\x12\x1c\n" +
 	"\tnamespace\x18\x05 \x01(\tR\tnamespace\x12\x12\n" +
-	"\x04name\x18\x06 \x01(\tR\x04name\"\x8a\x01\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\x120\n" +
+	"\x05since\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x05since\"\x8a\x01\n" +
 	"\x10DescribeResponse\x12<\n" +
 	"\bresource\x18\x01 \x01(\v2 .otterscale.resource.v1.ResourceR\bresource\x128\n" +
 	"\x06events\x18\x02 \x03(\v2 .otterscale.resource.v1.ResourceR\x06events\"\xaf\x01\n" +
+	"\x15FieldOwnershipRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x1a\n" +
+	"\bresource\x18\x04 \x01(\tR\bresource\x12\x1c\n" +
+	"\tnamespace\x18\x05 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\"\xb7\x01\n" +
+	"\n" +
+	"FieldOwner\x12\x18\n" +
+	"\amanager\x18\x01 \x01(\tR\amanager\x12\x1c\n" +
+	"\toperation\x18\x02 \x01(\tR\toperation\x12.\n" +
+	"\x04time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12 \n" +
+	"\vsubresource\x18\x04 \x01(\tR\vsubresource\x12\x1f\n" +
+	"\vapi_version\x18\x05 \x01(\tR\n" +
+	"apiVersion\"\\\n" +
+	"\n" +
+	"OwnedField\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12:\n" +
+	"\x06owners\x18\x02 \x03(\v2\".otterscale.resource.v1.FieldOwnerR\x06owners\"T\n" +
+	"\x16FieldOwnershipResponse\x12:\n" +
+	"\x06fields\x18\x01 \x03(\v2\".otterscale.resource.v1.OwnedFieldR\x06fields\"\xc8\x01\n" +
+	"\x12GetRevisionRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x1a\n" +
+	"\bresource\x18\x04 \x01(\tR\bresource\x12\x1c\n" +
+	"\tnamespace\x18\x05 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\x12\x1a\n" +
+	"\brevision\x18\a \x01(\x03R\brevision\"\xe7\x01\n" +
+	"\bRevision\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x03R\brevision\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12!\n" +
+	"\fchange_cause\x18\x04 \x01(\tR\vchangeCause\x12\x18\n" +
+	"\acurrent\x18\x05 \x01(\bR\acurrent\x123\n" +
+	"\btemplate\x18\x06 \x01(\v2\x17.google.protobuf.StructR\btemplate\"\xd6\x01\n" +
+	"\x1cCompareAcrossClustersRequest\x12\x1b\n" +
+	"\tcluster_a\x18\x01 \x01(\tR\bclusterA\x12\x1b\n" +
+	"\tcluster_b\x18\x02 \x01(\tR\bclusterB\x12\x14\n" +
+	"\x05group\x18\x03 \x01(\tR\x05group\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\x12\x1a\n" +
+	"\bresource\x18\x05 \x01(\tR\bresource\x12\x1c\n" +
+	"\tnamespace\x18\x06 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\a \x01(\tR\x04name\"\x85\x02\n" +
+	"\x0fFieldDifference\x12@\n" +
+	"\x04type\x18\x01 \x01(\x0e2,.otterscale.resource.v1.FieldDifference.TypeR\x04type\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12$\n" +
+	"\x01a\x18\x03 \x01(\v2\x16.google.protobuf.ValueR\x01a\x12$\n" +
+	"\x01b\x18\x04 \x01(\v2\x16.google.protobuf.ValueR\x01b\"P\n" +
+	"\x04Type\x12\x14\n" +
+	"\x10TYPE_UNSPECIFIED\x10\x00\x12\x0e\n" +
+	"\n" +
+	"TYPE_ADDED\x10\x01\x12\x10\n" +
+	"\fTYPE_REMOVED\x10\x02\x12\x10\n" +
+	"\fTYPE_CHANGED\x10\x03\"\xca\x01\n" +
+	"\x1dCompareAcrossClustersResponse\x12.\n" +
+	"\x01a\x18\x01 \x01(\v2 .otterscale.resource.v1.ResourceR\x01a\x12.\n" +
+	"\x01b\x18\x02 \x01(\v2 .otterscale.resource.v1.ResourceR\x01b\x12I\n" +
+	"\vdifferences\x18\x03 \x03(\v2'.otterscale.resource.v1.FieldDifferenceR\vdifferences\"O\n" +
+	"\x15NamespaceQuotaRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"r\n" +
+	"\n" +
+	"QuotaUsage\x12\x1a\n" +
+	"\bresource\x18\x01 \x01(\tR\bresource\x12\x12\n" +
+	"\x04used\x18\x02 \x01(\tR\x04used\x12\x12\n" +
+	"\x04hard\x18\x03 \x01(\tR\x04hard\x12 \n" +
+	"\vutilization\x18\x04 \x01(\x01R\vutilization\"l\n" +
+	"\x14ResourceQuotaSummary\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12@\n" +
+	"\tresources\x18\x02 \x03(\v2\".otterscale.resource.v1.QuotaUsageR\tresources\"\xde\x01\n" +
+	"\x0eLimitRangeItem\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1a\n" +
+	"\bresource\x18\x02 \x01(\tR\bresource\x12\x18\n" +
+	"\adefault\x18\x03 \x01(\tR\adefault\x12'\n" +
+	"\x0fdefault_request\x18\x04 \x01(\tR\x0edefaultRequest\x12\x10\n" +
+	"\x03min\x18\x05 \x01(\tR\x03min\x12\x10\n" +
+	"\x03max\x18\x06 \x01(\tR\x03max\x125\n" +
+	"\x17max_limit_request_ratio\x18\a \x01(\tR\x14maxLimitRequestRatio\"g\n" +
+	"\x11LimitRangeSummary\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12>\n" +
+	"\x06limits\x18\x02 \x03(\v2&.otterscale.resource.v1.LimitRangeItemR\x06limits\"\xac\x01\n" +
+	"\x16NamespaceQuotaResponse\x12D\n" +
+	"\x06quotas\x18\x01 \x03(\v2,.otterscale.resource.v1.ResourceQuotaSummaryR\x06quotas\x12L\n" +
+	"\flimit_ranges\x18\x02 \x03(\v2).otterscale.resource.v1.LimitRangeSummaryR\vlimitRanges\"\xa9\x02\n" +
 	"\rCreateRequest\x12\x18\n" +
 	"\acluster\x18\x01 \x01(\tR\acluster\x12\x14\n" +
 	"\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n" +
 	"\aversion\x18\x03 \x01(\tR\aversion\x12\x1a\n" +
 	"\bresource\x18\x04 \x01(\tR\bresource\x12\x1c\n" +
 	"\tnamespace\x18\x05 \x01(\tR\tnamespace\x12\x1a\n" +
-	"\bmanifest\x18\x06 \x01(\fR\bmanifest\"\xfd\x01\n" +
+	"\bmanifest\x18\x06 \x01(\fR\bmanifest\x12'\n" +
+	"\x0fcheck_namespace\x18\a \x01(\bR\x0echeckNamespace\x12&\n" +
+	"\x0fskip_managed_by\x18\b \x01(\bR\rskipManagedBy\x12'\n" +
+	"\x0fidempotency_key\x18\t \x01(\tR\x0eidempotencyKey\"\x80\x03\n" +
 	"\fApplyRequest\x12\x18\n" +
 	"\acluster\x18\x01 \x01(\tR\acluster\x12\x14\n" +
 	"\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n" +
@@ -3232,7 +11949,59 @@ const file_api_resource_v1_resource_proto_rawDesc = "" +
 	"\x04name\x18\x06 \x01(\tR\x04name\x12\x1a\n" +
 	"\bmanifest\x18\a \x01(\fR\bmanifest\x12\x14\n" +
 	"\x05force\x18\b \x01(\bR\x05force\x12#\n" +
-	"\rfield_manager\x18\t \x01(\tR\ffieldManager\"\xd9\x01\n" +
+	"\rfield_manager\x18\t \x01(\tR\ffieldManager\x12'\n" +
+	"\x0fcheck_namespace\x18\n" +
+	" \x01(\bR\x0echeckNamespace\x12&\n" +
+	"\x0fskip_managed_by\x18\v \x01(\bR\rskipManagedBy\x120\n" +
+	"\x14wait_timeout_seconds\x18\f \x01(\x03R\x12waitTimeoutSeconds\"Y\n" +
+	"\rApplyConflict\x12\x18\n" +
+	"\amanager\x18\x01 \x01(\tR\amanager\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"[\n" +
+	"\x14ApplyConflictDetails\x12C\n" +
+	"\tconflicts\x18\x01 \x03(\v2%.otterscale.resource.v1.ApplyConflictR\tconflicts\"C\n" +
+	"\x0fAdmissionDenial\x12\x18\n" +
+	"\awebhook\x18\x01 \x01(\tR\awebhook\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\x99\x01\n" +
+	"\x12ForceApplyResponse\x12<\n" +
+	"\bresource\x18\x01 \x01(\v2 .otterscale.resource.v1.ResourceR\bresource\x12E\n" +
+	"\n" +
+	"overridden\x18\x02 \x03(\v2%.otterscale.resource.v1.ApplyConflictR\n" +
+	"overridden\"\xd1\x01\n" +
+	"\x0fSetLabelRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x1a\n" +
+	"\bresource\x18\x04 \x01(\tR\bresource\x12\x1c\n" +
+	"\tnamespace\x18\x05 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\x12\x10\n" +
+	"\x03key\x18\a \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\b \x01(\tR\x05value\"\xbe\x01\n" +
+	"\x12RemoveLabelRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x1a\n" +
+	"\bresource\x18\x04 \x01(\tR\bresource\x12\x1c\n" +
+	"\tnamespace\x18\x05 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\x12\x10\n" +
+	"\x03key\x18\a \x01(\tR\x03key\"\xd6\x01\n" +
+	"\x14SetAnnotationRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x1a\n" +
+	"\bresource\x18\x04 \x01(\tR\bresource\x12\x1c\n" +
+	"\tnamespace\x18\x05 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\x12\x10\n" +
+	"\x03key\x18\a \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\b \x01(\tR\x05value\"\xc3\x01\n" +
+	"\x17RemoveAnnotationRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x1a\n" +
+	"\bresource\x18\x04 \x01(\tR\bresource\x12\x1c\n" +
+	"\tnamespace\x18\x05 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\x12\x10\n" +
+	"\x03key\x18\a \x01(\tR\x03key\"\xd9\x01\n" +
 	"\rDeleteRequest\x12\x18\n" +
 	"\acluster\x18\x01 \x01(\tR\acluster\x12\x14\n" +
 	"\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n" +
@@ -3240,7 +12009,87 @@ const file_api_resource_v1_resource_proto_rawDesc = "" +
 	"\bresource\x18\x04 \x01(\tR\bresource\x12\x1c\n" +
 	"\tnamespace\x18\x05 \x01(\tR\tnamespace\x12\x12\n" +
 	"\x04name\x18\x06 \x01(\tR\x04name\x120\n" +
-	"\x14grace_period_seconds\x18\a \x01(\x03R\x12gracePeriodSeconds\"\x8b\x02\n" +
+	"\x14grace_period_seconds\x18\a \x01(\x03R\x12gracePeriodSeconds\"\x8d\x02\n" +
+	"\x14ApplyManifestRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x1a\n" +
+	"\bmanifest\x18\x02 \x01(\fR\bmanifest\x12\x14\n" +
+	"\x05force\x18\x03 \x01(\bR\x05force\x12#\n" +
+	"\rfield_manager\x18\x04 \x01(\tR\ffieldManager\x12\"\n" +
+	"\rstop_on_error\x18\x05 \x01(\bR\vstopOnError\x12.\n" +
+	"\x13crd_timeout_seconds\x18\x06 \x01(\x03R\x11crdTimeoutSeconds\x120\n" +
+	"\x14wait_timeout_seconds\x18\a \x01(\x03R\x12waitTimeoutSeconds\"U\n" +
+	"\x0fConfigMapKeyRef\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
+	"\x03key\x18\x03 \x01(\tR\x03key\"\xa9\x02\n" +
+	"\x16ApplyFromSourceRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x12\n" +
+	"\x03url\x18\x02 \x01(\tH\x00R\x03url\x12H\n" +
+	"\n" +
+	"config_map\x18\x03 \x01(\v2'.otterscale.resource.v1.ConfigMapKeyRefH\x00R\tconfigMap\x12\x14\n" +
+	"\x05force\x18\x04 \x01(\bR\x05force\x12#\n" +
+	"\rfield_manager\x18\x05 \x01(\tR\ffieldManager\x12\"\n" +
+	"\rstop_on_error\x18\x06 \x01(\bR\vstopOnError\x12.\n" +
+	"\x13crd_timeout_seconds\x18\a \x01(\x03R\x11crdTimeoutSecondsB\b\n" +
+	"\x06source\"x\n" +
+	"\n" +
+	"PruneScope\x12\x14\n" +
+	"\x05group\x18\x01 \x01(\tR\x05group\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x1a\n" +
+	"\bresource\x18\x03 \x01(\tR\bresource\x12\x1e\n" +
+	"\n" +
+	"namespaces\x18\x04 \x03(\tR\n" +
+	"namespaces\"\xc4\x02\n" +
+	"\x15ApplyWithPruneRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x1a\n" +
+	"\bmanifest\x18\x02 \x01(\fR\bmanifest\x12\x1f\n" +
+	"\vprune_label\x18\x03 \x01(\tR\n" +
+	"pruneLabel\x12E\n" +
+	"\fprune_scopes\x18\x04 \x03(\v2\".otterscale.resource.v1.PruneScopeR\vpruneScopes\x12\x14\n" +
+	"\x05force\x18\x05 \x01(\bR\x05force\x12#\n" +
+	"\rfield_manager\x18\x06 \x01(\tR\ffieldManager\x12\"\n" +
+	"\rstop_on_error\x18\a \x01(\bR\vstopOnError\x12.\n" +
+	"\x13crd_timeout_seconds\x18\b \x01(\x03R\x11crdTimeoutSeconds\"1\n" +
+	"\x15ApplyBootstrapRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\"\xaf\x02\n" +
+	"\x15ApplyHelmChartRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x14\n" +
+	"\x05chart\x18\x02 \x01(\fR\x05chart\x12\x16\n" +
+	"\x06values\x18\x03 \x01(\fR\x06values\x12!\n" +
+	"\frelease_name\x18\x04 \x01(\tR\vreleaseName\x12\x1c\n" +
+	"\tnamespace\x18\x05 \x01(\tR\tnamespace\x12\x14\n" +
+	"\x05force\x18\x06 \x01(\bR\x05force\x12#\n" +
+	"\rfield_manager\x18\a \x01(\tR\ffieldManager\x12\"\n" +
+	"\rstop_on_error\x18\b \x01(\bR\vstopOnError\x12.\n" +
+	"\x13crd_timeout_seconds\x18\t \x01(\x03R\x11crdTimeoutSeconds\"\xf7\x02\n" +
+	"\x12ApplyManifestEvent\x12C\n" +
+	"\x04type\x18\x01 \x01(\x0e2/.otterscale.resource.v1.ApplyManifestEvent.TypeR\x04type\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\x05R\x05index\x12\x1f\n" +
+	"\vapi_version\x18\x03 \x01(\tR\n" +
+	"apiVersion\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\x12\x1c\n" +
+	"\tnamespace\x18\x05 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\"\x88\x01\n" +
+	"\x04Type\x12\x14\n" +
+	"\x10TYPE_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fTYPE_APPLIED\x10\x01\x12\x0f\n" +
+	"\vTYPE_FAILED\x10\x02\x12\x10\n" +
+	"\fTYPE_WAITING\x10\x03\x12\x14\n" +
+	"\x10TYPE_ESTABLISHED\x10\x04\x12\x0f\n" +
+	"\vTYPE_PRUNED\x10\x05\x12\x0e\n" +
+	"\n" +
+	"TYPE_READY\x10\x06\"\x99\x02\n" +
+	"\x17WaitForConditionRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x1a\n" +
+	"\bresource\x18\x04 \x01(\tR\bresource\x12\x1c\n" +
+	"\tnamespace\x18\x05 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\x12%\n" +
+	"\x0econdition_type\x18\a \x01(\tR\rconditionType\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12'\n" +
+	"\x0ftimeout_seconds\x18\t \x01(\x03R\x0etimeoutSeconds\"\x89\x04\n" +
 	"\fWatchRequest\x12\x18\n" +
 	"\acluster\x18\x01 \x01(\tR\acluster\x12\x14\n" +
 	"\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n" +
@@ -3249,12 +12098,25 @@ const file_api_resource_v1_resource_proto_rawDesc = "" +
 	"\tnamespace\x18\x05 \x01(\tR\tnamespace\x12%\n" +
 	"\x0elabel_selector\x18\x06 \x01(\tR\rlabelSelector\x12%\n" +
 	"\x0efield_selector\x18\a \x01(\tR\rfieldSelector\x12)\n" +
-	"\x10resource_version\x18\b \x01(\tR\x0fresourceVersion\"\xa8\x02\n" +
+	"\x10resource_version\x18\b \x01(\tR\x0fresourceVersion\x12%\n" +
+	"\x0eskip_unchanged\x18\t \x01(\bR\rskipUnchanged\x12#\n" +
+	"\rretain_fields\x18\n" +
+	" \x03(\tR\fretainFields\x12!\n" +
+	"\fresume_token\x18\v \x01(\tR\vresumeToken\x12%\n" +
+	"\x0eall_namespaces\x18\f \x01(\bR\rallNamespaces\x126\n" +
+	"\x17resync_interval_seconds\x18\r \x01(\x03R\x15resyncIntervalSeconds\x12.\n" +
+	"\x13send_initial_events\x18\x0e \x01(\bR\x11sendInitialEvents\"\xf8\x03\n" +
 	"\n" +
 	"WatchEvent\x12;\n" +
 	"\x04type\x18\x01 \x01(\x0e2'.otterscale.resource.v1.WatchEvent.TypeR\x04type\x12<\n" +
 	"\bresource\x18\x02 \x01(\v2 .otterscale.resource.v1.ResourceR\bresource\x12)\n" +
-	"\x10resource_version\x18\x03 \x01(\tR\x0fresourceVersion\"t\n" +
+	"\x10resource_version\x18\x03 \x01(\tR\x0fresourceVersion\x12'\n" +
+	"\x0frelist_required\x18\x04 \x01(\bR\x0erelistRequired\x12!\n" +
+	"\fresume_token\x18\x05 \x01(\tR\vresumeToken\x12\x1a\n" +
+	"\brelisted\x18\x06 \x01(\bR\brelisted\x12\x1c\n" +
+	"\treconnect\x18\a \x01(\bR\treconnect\x12,\n" +
+	"\x12initial_events_end\x18\b \x01(\bR\x10initialEventsEnd\x12\x1a\n" +
+	"\bresynced\x18\t \x01(\bR\bresynced\"t\n" +
 	"\x04Type\x12\x14\n" +
 	"\x10TYPE_UNSPECIFIED\x10\x00\x12\x0e\n" +
 	"\n" +
@@ -3263,10 +12125,38 @@ const file_api_resource_v1_resource_proto_rawDesc = "" +
 	"\fTYPE_DELETED\x10\x03\x12\x11\n" +
 	"\rTYPE_BOOKMARK\x10\x04\x12\x0e\n" +
 	"\n" +
-	"TYPE_ERROR\x10\x052\xdf\a\n" +
+	"TYPE_ERROR\x10\x05\"\xc7\x02\n" +
+	"\x1aWatchAcrossClustersRequest\x12\x1a\n" +
+	"\bclusters\x18\x01 \x03(\tR\bclusters\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x1a\n" +
+	"\bresource\x18\x04 \x01(\tR\bresource\x12\x1c\n" +
+	"\tnamespace\x18\x05 \x01(\tR\tnamespace\x12%\n" +
+	"\x0elabel_selector\x18\x06 \x01(\tR\rlabelSelector\x12%\n" +
+	"\x0efield_selector\x18\a \x01(\tR\rfieldSelector\x12%\n" +
+	"\x0eall_namespaces\x18\b \x01(\bR\rallNamespaces\x12.\n" +
+	"\x13send_initial_events\x18\t \x01(\bR\x11sendInitialEvents\"\x99\x01\n" +
+	"\x11ClusterWatchEvent\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x128\n" +
+	"\x05event\x18\x02 \x01(\v2\".otterscale.resource.v1.WatchEventR\x05event\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1a\n" +
+	"\bretrying\x18\x04 \x01(\bR\bretrying\"~\n" +
+	"\fProxyRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x16\n" +
+	"\x06method\x18\x02 \x01(\tR\x06method\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\x12\x14\n" +
+	"\x05query\x18\x04 \x01(\tR\x05query\x12\x12\n" +
+	"\x04body\x18\x05 \x01(\fR\x04body\"g\n" +
+	"\rProxyResponse\x12\x1f\n" +
+	"\vstatus_code\x18\x01 \x01(\x05R\n" +
+	"statusCode\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\fR\x04body2\xa9\x1a\n" +
 	"\x0fResourceService\x12y\n" +
 	"\tDiscovery\x12(.otterscale.resource.v1.DiscoveryRequest\x1a).otterscale.resource.v1.DiscoveryResponse\"\x17\x8a\xdf\xd5\x1d\x12\n" +
-	"\x10resource-enabled\x12a\n" +
+	"\x10resource-enabled\x12\x85\x01\n" +
+	"\fCapabilities\x12+.otterscale.resource.v1.CapabilitiesRequest\x1a,.otterscale.resource.v1.CapabilitiesResponse\"\x1a\x8a\xdf\xd5\x1d\x12\n" +
+	"\x10resource-enabled\x90\x02\x01\x12a\n" +
 	"\x06Schema\x12%.otterscale.resource.v1.SchemaRequest\x1a\x17.google.protobuf.Struct\"\x17\x8a\xdf\xd5\x1d\x12\n" +
 	"\x10resource-enabled\x12j\n" +
 	"\x04List\x12#.otterscale.resource.v1.ListRequest\x1a$.otterscale.resource.v1.ListResponse\"\x17\x8a\xdf\xd5\x1d\x12\n" +
@@ -3274,69 +12164,207 @@ const file_api_resource_v1_resource_proto_rawDesc = "" +
 	"\x03Get\x12\".otterscale.resource.v1.GetRequest\x1a .otterscale.resource.v1.Resource\"\x17\x8a\xdf\xd5\x1d\x12\n" +
 	"\x10resource-enabled\x12v\n" +
 	"\bDescribe\x12'.otterscale.resource.v1.DescribeRequest\x1a(.otterscale.resource.v1.DescribeResponse\"\x17\x8a\xdf\xd5\x1d\x12\n" +
-	"\x10resource-enabled\x12j\n" +
+	"\x10resource-enabled\x12\x8b\x01\n" +
+	"\x0eFieldOwnership\x12-.otterscale.resource.v1.FieldOwnershipRequest\x1a..otterscale.resource.v1.FieldOwnershipResponse\"\x1a\x8a\xdf\xd5\x1d\x12\n" +
+	"\x10resource-enabled\x90\x02\x01\x12w\n" +
+	"\vGetRevision\x12*.otterscale.resource.v1.GetRevisionRequest\x1a .otterscale.resource.v1.Revision\"\x1a\x8a\xdf\xd5\x1d\x12\n" +
+	"\x10resource-enabled\x90\x02\x01\x12\xa0\x01\n" +
+	"\x15CompareAcrossClusters\x124.otterscale.resource.v1.CompareAcrossClustersRequest\x1a5.otterscale.resource.v1.CompareAcrossClustersResponse\"\x1a\x8a\xdf\xd5\x1d\x12\n" +
+	"\x10resource-enabled\x90\x02\x01\x12\x8b\x01\n" +
+	"\x0eNamespaceQuota\x12-.otterscale.resource.v1.NamespaceQuotaRequest\x1a..otterscale.resource.v1.NamespaceQuotaResponse\"\x1a\x8a\xdf\xd5\x1d\x12\n" +
+	"\x10resource-enabled\x90\x02\x01\x12j\n" +
 	"\x06Create\x12%.otterscale.resource.v1.CreateRequest\x1a .otterscale.resource.v1.Resource\"\x17\x8a\xdf\xd5\x1d\x12\n" +
 	"\x10resource-enabled\x12h\n" +
 	"\x05Apply\x12$.otterscale.resource.v1.ApplyRequest\x1a .otterscale.resource.v1.Resource\"\x17\x8a\xdf\xd5\x1d\x12\n" +
+	"\x10resource-enabled\x12w\n" +
+	"\n" +
+	"ForceApply\x12$.otterscale.resource.v1.ApplyRequest\x1a*.otterscale.resource.v1.ForceApplyResponse\"\x17\x8a\xdf\xd5\x1d\x12\n" +
+	"\x10resource-enabled\x12\x84\x01\n" +
+	"\rApplyManifest\x12,.otterscale.resource.v1.ApplyManifestRequest\x1a*.otterscale.resource.v1.ApplyManifestEvent\"\x17\x8a\xdf\xd5\x1d\x12\n" +
+	"\x10resource-enabled0\x01\x12\x88\x01\n" +
+	"\x0fApplyFromSource\x12..otterscale.resource.v1.ApplyFromSourceRequest\x1a*.otterscale.resource.v1.ApplyManifestEvent\"\x17\x8a\xdf\xd5\x1d\x12\n" +
+	"\x10resource-enabled0\x01\x12\x86\x01\n" +
+	"\x0eApplyWithPrune\x12-.otterscale.resource.v1.ApplyWithPruneRequest\x1a*.otterscale.resource.v1.ApplyManifestEvent\"\x17\x8a\xdf\xd5\x1d\x12\n" +
+	"\x10resource-enabled0\x01\x12\x86\x01\n" +
+	"\x0eApplyHelmChart\x12-.otterscale.resource.v1.ApplyHelmChartRequest\x1a*.otterscale.resource.v1.ApplyManifestEvent\"\x17\x8a\xdf\xd5\x1d\x12\n" +
+	"\x10resource-enabled0\x01\x12\x86\x01\n" +
+	"\x0eApplyBootstrap\x12-.otterscale.resource.v1.ApplyBootstrapRequest\x1a*.otterscale.resource.v1.ApplyManifestEvent\"\x17\x8a\xdf\xd5\x1d\x12\n" +
+	"\x10resource-enabled0\x01\x12n\n" +
+	"\bSetLabel\x12'.otterscale.resource.v1.SetLabelRequest\x1a .otterscale.resource.v1.Resource\"\x17\x8a\xdf\xd5\x1d\x12\n" +
+	"\x10resource-enabled\x12t\n" +
+	"\vRemoveLabel\x12*.otterscale.resource.v1.RemoveLabelRequest\x1a .otterscale.resource.v1.Resource\"\x17\x8a\xdf\xd5\x1d\x12\n" +
+	"\x10resource-enabled\x12x\n" +
+	"\rSetAnnotation\x12,.otterscale.resource.v1.SetAnnotationRequest\x1a .otterscale.resource.v1.Resource\"\x17\x8a\xdf\xd5\x1d\x12\n" +
+	"\x10resource-enabled\x12~\n" +
+	"\x10RemoveAnnotation\x12/.otterscale.resource.v1.RemoveAnnotationRequest\x1a .otterscale.resource.v1.Resource\"\x17\x8a\xdf\xd5\x1d\x12\n" +
 	"\x10resource-enabled\x12`\n" +
 	"\x06Delete\x12%.otterscale.resource.v1.DeleteRequest\x1a\x16.google.protobuf.Empty\"\x17\x8a\xdf\xd5\x1d\x12\n" +
 	"\x10resource-enabled\x12l\n" +
 	"\x05Watch\x12$.otterscale.resource.v1.WatchRequest\x1a\".otterscale.resource.v1.WatchEvent\"\x17\x8a\xdf\xd5\x1d\x12\n" +
-	"\x10resource-enabled0\x01B;Z9github.com/otterscale/otterscale-agent/api/resource/v1;pbb\beditionsp\xe8\a"
-
-var file_api_resource_v1_resource_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_api_resource_v1_resource_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+	"\x10resource-enabled0\x01\x12\x8f\x01\n" +
+	"\x13WatchAcrossClusters\x122.otterscale.resource.v1.WatchAcrossClustersRequest\x1a).otterscale.resource.v1.ClusterWatchEvent\"\x17\x8a\xdf\xd5\x1d\x12\n" +
+	"\x10resource-enabled0\x01\x12~\n" +
+	"\x10WaitForCondition\x12/.otterscale.resource.v1.WaitForConditionRequest\x1a .otterscale.resource.v1.Resource\"\x17\x8a\xdf\xd5\x1d\x12\n" +
+	"\x10resource-enabled\x12p\n" +
+	"\x05Proxy\x12$.otterscale.resource.v1.ProxyRequest\x1a%.otterscale.resource.v1.ProxyResponse\"\x1a\x8a\xdf\xd5\x1d\x12\n" +
+	"\x10resource-enabled\x90\x02\x01B;Z9github.com/otterscale/otterscale-agent/api/resource/v1;pbb\beditionsp\xe8\a"
+
+var file_api_resource_v1_resource_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_api_resource_v1_resource_proto_msgTypes = make([]protoimpl.MessageInfo, 54)
 var file_api_resource_v1_resource_proto_goTypes = []any{
-	(WatchEvent_Type)(0),      // 0: otterscale.resource.v1.WatchEvent.Type
-	(*APIResource)(nil),       // 1: otterscale.resource.v1.APIResource
-	(*DiscoveryRequest)(nil),  // 2: otterscale.resource.v1.DiscoveryRequest
-	(*DiscoveryResponse)(nil), // 3: otterscale.resource.v1.DiscoveryResponse
-	(*SchemaRequest)(nil),     // 4: otterscale.resource.v1.SchemaRequest
-	(*Resource)(nil),          // 5: otterscale.resource.v1.Resource
-	(*ListRequest)(nil),       // 6: otterscale.resource.v1.ListRequest
-	(*ListResponse)(nil),      // 7: otterscale.resource.v1.ListResponse
-	(*GetRequest)(nil),        // 8: otterscale.resource.v1.GetRequest
-	(*DescribeRequest)(nil),   // 9: otterscale.resource.v1.DescribeRequest
-	(*DescribeResponse)(nil),  // 10: otterscale.resource.v1.DescribeResponse
-	(*CreateRequest)(nil),     // 11: otterscale.resource.v1.CreateRequest
-	(*ApplyRequest)(nil),      // 12: otterscale.resource.v1.ApplyRequest
-	(*DeleteRequest)(nil),     // 13: otterscale.resource.v1.DeleteRequest
-	(*WatchRequest)(nil),      // 14: otterscale.resource.v1.WatchRequest
-	(*WatchEvent)(nil),        // 15: otterscale.resource.v1.WatchEvent
-	(*structpb.Struct)(nil),   // 16: google.protobuf.Struct
-	(*emptypb.Empty)(nil),     // 17: google.protobuf.Empty
+	(FieldDifference_Type)(0),             // 0: otterscale.resource.v1.FieldDifference.Type
+	(ApplyManifestEvent_Type)(0),          // 1: otterscale.resource.v1.ApplyManifestEvent.Type
+	(WatchEvent_Type)(0),                  // 2: otterscale.resource.v1.WatchEvent.Type
+	(*APIResource)(nil),                   // 3: otterscale.resource.v1.APIResource
+	(*DiscoveryRequest)(nil),              // 4: otterscale.resource.v1.DiscoveryRequest
+	(*DiscoveryWarning)(nil),              // 5: otterscale.resource.v1.DiscoveryWarning
+	(*DiscoveryResponse)(nil),             // 6: otterscale.resource.v1.DiscoveryResponse
+	(*CapabilitiesRequest)(nil),           // 7: otterscale.resource.v1.CapabilitiesRequest
+	(*CapabilitiesResponse)(nil),          // 8: otterscale.resource.v1.CapabilitiesResponse
+	(*SchemaRequest)(nil),                 // 9: otterscale.resource.v1.SchemaRequest
+	(*Resource)(nil),                      // 10: otterscale.resource.v1.Resource
+	(*ListRequest)(nil),                   // 11: otterscale.resource.v1.ListRequest
+	(*ListResponse)(nil),                  // 12: otterscale.resource.v1.ListResponse
+	(*GetRequest)(nil),                    // 13: otterscale.resource.v1.GetRequest
+	(*DescribeRequest)(nil),               // 14: otterscale.resource.v1.DescribeRequest
+	(*DescribeResponse)(nil),              // 15: otterscale.resource.v1.DescribeResponse
+	(*FieldOwnershipRequest)(nil),         // 16: otterscale.resource.v1.FieldOwnershipRequest
+	(*FieldOwner)(nil),                    // 17: otterscale.resource.v1.FieldOwner
+	(*OwnedField)(nil),                    // 18: otterscale.resource.v1.OwnedField
+	(*FieldOwnershipResponse)(nil),        // 19: otterscale.resource.v1.FieldOwnershipResponse
+	(*GetRevisionRequest)(nil),            // 20: otterscale.resource.v1.GetRevisionRequest
+	(*Revision)(nil),                      // 21: otterscale.resource.v1.Revision
+	(*CompareAcrossClustersRequest)(nil),  // 22: otterscale.resource.v1.CompareAcrossClustersRequest
+	(*FieldDifference)(nil),               // 23: otterscale.resource.v1.FieldDifference
+	(*CompareAcrossClustersResponse)(nil), // 24: otterscale.resource.v1.CompareAcrossClustersResponse
+	(*NamespaceQuotaRequest)(nil),         // 25: otterscale.resource.v1.NamespaceQuotaRequest
+	(*QuotaUsage)(nil),                    // 26: otterscale.resource.v1.QuotaUsage
+	(*ResourceQuotaSummary)(nil),          // 27: otterscale.resource.v1.ResourceQuotaSummary
+	(*LimitRangeItem)(nil),                // 28: otterscale.resource.v1.LimitRangeItem
+	(*LimitRangeSummary)(nil),             // 29: otterscale.resource.v1.LimitRangeSummary
+	(*NamespaceQuotaResponse)(nil),        // 30: otterscale.resource.v1.NamespaceQuotaResponse
+	(*CreateRequest)(nil),                 // 31: otterscale.resource.v1.CreateRequest
+	(*ApplyRequest)(nil),                  // 32: otterscale.resource.v1.ApplyRequest
+	(*ApplyConflict)(nil),                 // 33: otterscale.resource.v1.ApplyConflict
+	(*ApplyConflictDetails)(nil),          // 34: otterscale.resource.v1.ApplyConflictDetails
+	(*AdmissionDenial)(nil),               // 35: otterscale.resource.v1.AdmissionDenial
+	(*ForceApplyResponse)(nil),            // 36: otterscale.resource.v1.ForceApplyResponse
+	(*SetLabelRequest)(nil),               // 37: otterscale.resource.v1.SetLabelRequest
+	(*RemoveLabelRequest)(nil),            // 38: otterscale.resource.v1.RemoveLabelRequest
+	(*SetAnnotationRequest)(nil),          // 39: otterscale.resource.v1.SetAnnotationRequest
+	(*RemoveAnnotationRequest)(nil),       // 40: otterscale.resource.v1.RemoveAnnotationRequest
+	(*DeleteRequest)(nil),                 // 41: otterscale.resource.v1.DeleteRequest
+	(*ApplyManifestRequest)(nil),          // 42: otterscale.resource.v1.ApplyManifestRequest
+	(*ConfigMapKeyRef)(nil),               // 43: otterscale.resource.v1.ConfigMapKeyRef
+	(*ApplyFromSourceRequest)(nil),        // 44: otterscale.resource.v1.ApplyFromSourceRequest
+	(*PruneScope)(nil),                    // 45: otterscale.resource.v1.PruneScope
+	(*ApplyWithPruneRequest)(nil),         // 46: otterscale.resource.v1.ApplyWithPruneRequest
+	(*ApplyBootstrapRequest)(nil),         // 47: otterscale.resource.v1.ApplyBootstrapRequest
+	(*ApplyHelmChartRequest)(nil),         // 48: otterscale.resource.v1.ApplyHelmChartRequest
+	(*ApplyManifestEvent)(nil),            // 49: otterscale.resource.v1.ApplyManifestEvent
+	(*WaitForConditionRequest)(nil),       // 50: otterscale.resource.v1.WaitForConditionRequest
+	(*WatchRequest)(nil),                  // 51: otterscale.resource.v1.WatchRequest
+	(*WatchEvent)(nil),                    // 52: otterscale.resource.v1.WatchEvent
+	(*WatchAcrossClustersRequest)(nil),    // 53: otterscale.resource.v1.WatchAcrossClustersRequest
+	(*ClusterWatchEvent)(nil),             // 54: otterscale.resource.v1.ClusterWatchEvent
+	(*ProxyRequest)(nil),                  // 55: otterscale.resource.v1.ProxyRequest
+	(*ProxyResponse)(nil),                 // 56: otterscale.resource.v1.ProxyResponse
+	(*structpb.Struct)(nil),               // 57: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil),         // 58: google.protobuf.Timestamp
+	(*structpb.Value)(nil),                // 59: google.protobuf.Value
+	(*emptypb.Empty)(nil),                 // 60: google.protobuf.Empty
 }
 var file_api_resource_v1_resource_proto_depIdxs = []int32{
-	1,  // 0: otterscale.resource.v1.DiscoveryResponse.api_resources:type_name -> otterscale.resource.v1.APIResource
-	16, // 1: otterscale.resource.v1.Resource.object:type_name -> google.protobuf.Struct
-	5,  // 2: otterscale.resource.v1.ListResponse.items:type_name -> otterscale.resource.v1.Resource
-	5,  // 3: otterscale.resource.v1.DescribeResponse.resource:type_name -> otterscale.resource.v1.Resource
-	5,  // 4: otterscale.resource.v1.DescribeResponse.events:type_name -> otterscale.resource.v1.Resource
-	0,  // 5: otterscale.resource.v1.WatchEvent.type:type_name -> otterscale.resource.v1.WatchEvent.Type
-	5,  // 6: otterscale.resource.v1.WatchEvent.resource:type_name -> otterscale.resource.v1.Resource
-	2,  // 7: otterscale.resource.v1.ResourceService.Discovery:input_type -> otterscale.resource.v1.DiscoveryRequest
-	4,  // 8: otterscale.resource.v1.ResourceService.Schema:input_type -> otterscale.resource.v1.SchemaRequest
-	6,  // 9: otterscale.resource.v1.ResourceService.List:input_type -> otterscale.resource.v1.ListRequest
-	8,  // 10: otterscale.resource.v1.ResourceService.Get:input_type -> otterscale.resource.v1.GetRequest
-	9,  // 11: otterscale.resource.v1.ResourceService.Describe:input_type -> otterscale.resource.v1.DescribeRequest
-	11, // 12: otterscale.resource.v1.ResourceService.Create:input_type -> otterscale.resource.v1.CreateRequest
-	12, // 13: otterscale.resource.v1.ResourceService.Apply:input_type -> otterscale.resource.v1.ApplyRequest
-	13, // 14: otterscale.resource.v1.ResourceService.Delete:input_type -> otterscale.resource.v1.DeleteRequest
-	14, // 15: otterscale.resource.v1.ResourceService.Watch:input_type -> otterscale.resource.v1.WatchRequest
-	3,  // 16: otterscale.resource.v1.ResourceService.Discovery:output_type -> otterscale.resource.v1.DiscoveryResponse
-	16, // 17: otterscale.resource.v1.ResourceService.Schema:output_type -> google.protobuf.Struct
-	7,  // 18: otterscale.resource.v1.ResourceService.List:output_type -> otterscale.resource.v1.ListResponse
-	5,  // 19: otterscale.resource.v1.ResourceService.Get:output_type -> otterscale.resource.v1.Resource
-	10, // 20: otterscale.resource.v1.ResourceService.Describe:output_type -> otterscale.resource.v1.DescribeResponse
-	5,  // 21: otterscale.resource.v1.ResourceService.Create:output_type -> otterscale.resource.v1.Resource
-	5,  // 22: otterscale.resource.v1.ResourceService.Apply:output_type -> otterscale.resource.v1.Resource
-	17, // 23: otterscale.resource.v1.ResourceService.Delete:output_type -> google.protobuf.Empty
-	15, // 24: otterscale.resource.v1.ResourceService.Watch:output_type -> otterscale.resource.v1.WatchEvent
-	16, // [16:25] is the sub-list for method output_type
-	7,  // [7:16] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	3,  // 0: otterscale.resource.v1.DiscoveryResponse.api_resources:type_name -> otterscale.resource.v1.APIResource
+	5,  // 1: otterscale.resource.v1.DiscoveryResponse.warnings:type_name -> otterscale.resource.v1.DiscoveryWarning
+	57, // 2: otterscale.resource.v1.Resource.object:type_name -> google.protobuf.Struct
+	10, // 3: otterscale.resource.v1.ListResponse.items:type_name -> otterscale.resource.v1.Resource
+	58, // 4: otterscale.resource.v1.DescribeRequest.since:type_name -> google.protobuf.Timestamp
+	10, // 5: otterscale.resource.v1.DescribeResponse.resource:type_name -> otterscale.resource.v1.Resource
+	10, // 6: otterscale.resource.v1.DescribeResponse.events:type_name -> otterscale.resource.v1.Resource
+	58, // 7: otterscale.resource.v1.FieldOwner.time:type_name -> google.protobuf.Timestamp
+	17, // 8: otterscale.resource.v1.OwnedField.owners:type_name -> otterscale.resource.v1.FieldOwner
+	18, // 9: otterscale.resource.v1.FieldOwnershipResponse.fields:type_name -> otterscale.resource.v1.OwnedField
+	58, // 10: otterscale.resource.v1.Revision.created_at:type_name -> google.protobuf.Timestamp
+	57, // 11: otterscale.resource.v1.Revision.template:type_name -> google.protobuf.Struct
+	0,  // 12: otterscale.resource.v1.FieldDifference.type:type_name -> otterscale.resource.v1.FieldDifference.Type
+	59, // 13: otterscale.resource.v1.FieldDifference.a:type_name -> google.protobuf.Value
+	59, // 14: otterscale.resource.v1.FieldDifference.b:type_name -> google.protobuf.Value
+	10, // 15: otterscale.resource.v1.CompareAcrossClustersResponse.a:type_name -> otterscale.resource.v1.Resource
+	10, // 16: otterscale.resource.v1.CompareAcrossClustersResponse.b:type_name -> otterscale.resource.v1.Resource
+	23, // 17: otterscale.resource.v1.CompareAcrossClustersResponse.differences:type_name -> otterscale.resource.v1.FieldDifference
+	26, // 18: otterscale.resource.v1.ResourceQuotaSummary.resources:type_name -> otterscale.resource.v1.QuotaUsage
+	28, // 19: otterscale.resource.v1.LimitRangeSummary.limits:type_name -> otterscale.resource.v1.LimitRangeItem
+	27, // 20: otterscale.resource.v1.NamespaceQuotaResponse.quotas:type_name -> otterscale.resource.v1.ResourceQuotaSummary
+	29, // 21: otterscale.resource.v1.NamespaceQuotaResponse.limit_ranges:type_name -> otterscale.resource.v1.LimitRangeSummary
+	33, // 22: otterscale.resource.v1.ApplyConflictDetails.conflicts:type_name -> otterscale.resource.v1.ApplyConflict
+	10, // 23: otterscale.resource.v1.ForceApplyResponse.resource:type_name -> otterscale.resource.v1.Resource
+	33, // 24: otterscale.resource.v1.ForceApplyResponse.overridden:type_name -> otterscale.resource.v1.ApplyConflict
+	43, // 25: otterscale.resource.v1.ApplyFromSourceRequest.config_map:type_name -> otterscale.resource.v1.ConfigMapKeyRef
+	45, // 26: otterscale.resource.v1.ApplyWithPruneRequest.prune_scopes:type_name -> otterscale.resource.v1.PruneScope
+	1,  // 27: otterscale.resource.v1.ApplyManifestEvent.type:type_name -> otterscale.resource.v1.ApplyManifestEvent.Type
+	2,  // 28: otterscale.resource.v1.WatchEvent.type:type_name -> otterscale.resource.v1.WatchEvent.Type
+	10, // 29: otterscale.resource.v1.WatchEvent.resource:type_name -> otterscale.resource.v1.Resource
+	52, // 30: otterscale.resource.v1.ClusterWatchEvent.event:type_name -> otterscale.resource.v1.WatchEvent
+	4,  // 31: otterscale.resource.v1.ResourceService.Discovery:input_type -> otterscale.resource.v1.DiscoveryRequest
+	7,  // 32: otterscale.resource.v1.ResourceService.Capabilities:input_type -> otterscale.resource.v1.CapabilitiesRequest
+	9,  // 33: otterscale.resource.v1.ResourceService.Schema:input_type -> otterscale.resource.v1.SchemaRequest
+	11, // 34: otterscale.resource.v1.ResourceService.List:input_type -> otterscale.resource.v1.ListRequest
+	13, // 35: otterscale.resource.v1.ResourceService.Get:input_type -> otterscale.resource.v1.GetRequest
+	14, // 36: otterscale.resource.v1.ResourceService.Describe:input_type -> otterscale.resource.v1.DescribeRequest
+	16, // 37: otterscale.resource.v1.ResourceService.FieldOwnership:input_type -> otterscale.resource.v1.FieldOwnershipRequest
+	20, // 38: otterscale.resource.v1.ResourceService.GetRevision:input_type -> otterscale.resource.v1.GetRevisionRequest
+	22, // 39: otterscale.resource.v1.ResourceService.CompareAcrossClusters:input_type -> otterscale.resource.v1.CompareAcrossClustersRequest
+	25, // 40: otterscale.resource.v1.ResourceService.NamespaceQuota:input_type -> otterscale.resource.v1.NamespaceQuotaRequest
+	31, // 41: otterscale.resource.v1.ResourceService.Create:input_type -> otterscale.resource.v1.CreateRequest
+	32, // 42: otterscale.resource.v1.ResourceService.Apply:input_type -> otterscale.resource.v1.ApplyRequest
+	32, // 43: otterscale.resource.v1.ResourceService.ForceApply:input_type -> otterscale.resource.v1.ApplyRequest
+	42, // 44: otterscale.resource.v1.ResourceService.ApplyManifest:input_type -> otterscale.resource.v1.ApplyManifestRequest
+	44, // 45: otterscale.resource.v1.ResourceService.ApplyFromSource:input_type -> otterscale.resource.v1.ApplyFromSourceRequest
+	46, // 46: otterscale.resource.v1.ResourceService.ApplyWithPrune:input_type -> otterscale.resource.v1.ApplyWithPruneRequest
+	48, // 47: otterscale.resource.v1.ResourceService.ApplyHelmChart:input_type -> otterscale.resource.v1.ApplyHelmChartRequest
+	47, // 48: otterscale.resource.v1.ResourceService.ApplyBootstrap:input_type -> otterscale.resource.v1.ApplyBootstrapRequest
+	37, // 49: otterscale.resource.v1.ResourceService.SetLabel:input_type -> otterscale.resource.v1.SetLabelRequest
+	38, // 50: otterscale.resource.v1.ResourceService.RemoveLabel:input_type -> otterscale.resource.v1.RemoveLabelRequest
+	39, // 51: otterscale.resource.v1.ResourceService.SetAnnotation:input_type -> otterscale.resource.v1.SetAnnotationRequest
+	40, // 52: otterscale.resource.v1.ResourceService.RemoveAnnotation:input_type -> otterscale.resource.v1.RemoveAnnotationRequest
+	41, // 53: otterscale.resource.v1.ResourceService.Delete:input_type -> otterscale.resource.v1.DeleteRequest
+	51, // 54: otterscale.resource.v1.ResourceService.Watch:input_type -> otterscale.resource.v1.WatchRequest
+	53, // 55: otterscale.resource.v1.ResourceService.WatchAcrossClusters:input_type -> otterscale.resource.v1.WatchAcrossClustersRequest
+	50, // 56: otterscale.resource.v1.ResourceService.WaitForCondition:input_type -> otterscale.resource.v1.WaitForConditionRequest
+	55, // 57: otterscale.resource.v1.ResourceService.Proxy:input_type -> otterscale.resource.v1.ProxyRequest
+	6,  // 58: otterscale.resource.v1.ResourceService.Discovery:output_type -> otterscale.resource.v1.DiscoveryResponse
+	8,  // 59: otterscale.resource.v1.ResourceService.Capabilities:output_type -> otterscale.resource.v1.CapabilitiesResponse
+	57, // 60: otterscale.resource.v1.ResourceService.Schema:output_type -> google.protobuf.Struct
+	12, // 61: otterscale.resource.v1.ResourceService.List:output_type -> otterscale.resource.v1.ListResponse
+	10, // 62: otterscale.resource.v1.ResourceService.Get:output_type -> otterscale.resource.v1.Resource
+	15, // 63: otterscale.resource.v1.ResourceService.Describe:output_type -> otterscale.resource.v1.DescribeResponse
+	19, // 64: otterscale.resource.v1.ResourceService.FieldOwnership:output_type -> otterscale.resource.v1.FieldOwnershipResponse
+	21, // 65: otterscale.resource.v1.ResourceService.GetRevision:output_type -> otterscale.resource.v1.Revision
+	24, // 66: otterscale.resource.v1.ResourceService.CompareAcrossClusters:output_type -> otterscale.resource.v1.CompareAcrossClustersResponse
+	30, // 67: otterscale.resource.v1.ResourceService.NamespaceQuota:output_type -> otterscale.resource.v1.NamespaceQuotaResponse
+	10, // 68: otterscale.resource.v1.ResourceService.Create:output_type -> otterscale.resource.v1.Resource
+	10, // 69: otterscale.resource.v1.ResourceService.Apply:output_type -> otterscale.resource.v1.Resource
+	36, // 70: otterscale.resource.v1.ResourceService.ForceApply:output_type -> otterscale.resource.v1.ForceApplyResponse
+	49, // 71: otterscale.resource.v1.ResourceService.ApplyManifest:output_type -> otterscale.resource.v1.ApplyManifestEvent
+	49, // 72: otterscale.resource.v1.ResourceService.ApplyFromSource:output_type -> otterscale.resource.v1.ApplyManifestEvent
+	49, // 73: otterscale.resource.v1.ResourceService.ApplyWithPrune:output_type -> otterscale.resource.v1.ApplyManifestEvent
+	49, // 74: otterscale.resource.v1.ResourceService.ApplyHelmChart:output_type -> otterscale.resource.v1.ApplyManifestEvent
+	49, // 75: otterscale.resource.v1.ResourceService.ApplyBootstrap:output_type -> otterscale.resource.v1.ApplyManifestEvent
+	10, // 76: otterscale.resource.v1.ResourceService.SetLabel:output_type -> otterscale.resource.v1.Resource
+	10, // 77: otterscale.resource.v1.ResourceService.RemoveLabel:output_type -> otterscale.resource.v1.Resource
+	10, // 78: otterscale.resource.v1.ResourceService.SetAnnotation:output_type -> otterscale.resource.v1.Resource
+	10, // 79: otterscale.resource.v1.ResourceService.RemoveAnnotation:output_type -> otterscale.resource.v1.Resource
+	60, // 80: otterscale.resource.v1.ResourceService.Delete:output_type -> google.protobuf.Empty
+	52, // 81: otterscale.resource.v1.ResourceService.Watch:output_type -> otterscale.resource.v1.WatchEvent
+	54, // 82: otterscale.resource.v1.ResourceService.WatchAcrossClusters:output_type -> otterscale.resource.v1.ClusterWatchEvent
+	10, // 83: otterscale.resource.v1.ResourceService.WaitForCondition:output_type -> otterscale.resource.v1.Resource
+	56, // 84: otterscale.resource.v1.ResourceService.Proxy:output_type -> otterscale.resource.v1.ProxyResponse
+	58, // [58:85] is the sub-list for method output_type
+	31, // [31:58] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
 }
 
 func init() { file_api_resource_v1_resource_proto_init() }
@@ -3344,13 +12372,17 @@ func file_api_resource_v1_resource_proto_init() {
 	if File_api_resource_v1_resource_proto != nil {
 		return
 	}
+	file_api_resource_v1_resource_proto_msgTypes[41].OneofWrappers = []any{
+		(*applyFromSourceRequest_Url)(nil),
+		(*applyFromSourceRequest_ConfigMap)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_resource_v1_resource_proto_rawDesc), len(file_api_resource_v1_resource_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   15,
+			NumEnums:      3,
+			NumMessages:   54,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
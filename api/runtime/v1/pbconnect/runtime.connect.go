@@ -52,8 +52,18 @@ const (
 	RuntimeServiceWritePortForwardProcedure = "/otterscale.runtime.v1.RuntimeService/WritePortForward"
 	// RuntimeServiceScaleProcedure is the fully-qualified name of the RuntimeService's Scale RPC.
 	RuntimeServiceScaleProcedure = "/otterscale.runtime.v1.RuntimeService/Scale"
+	// RuntimeServiceSuspendProcedure is the fully-qualified name of the RuntimeService's Suspend RPC.
+	RuntimeServiceSuspendProcedure = "/otterscale.runtime.v1.RuntimeService/Suspend"
+	// RuntimeServiceResumeProcedure is the fully-qualified name of the RuntimeService's Resume RPC.
+	RuntimeServiceResumeProcedure = "/otterscale.runtime.v1.RuntimeService/Resume"
 	// RuntimeServiceRestartProcedure is the fully-qualified name of the RuntimeService's Restart RPC.
 	RuntimeServiceRestartProcedure = "/otterscale.runtime.v1.RuntimeService/Restart"
+	// RuntimeServiceRestartAndWaitProcedure is the fully-qualified name of the RuntimeService's
+	// RestartAndWait RPC.
+	RuntimeServiceRestartAndWaitProcedure = "/otterscale.runtime.v1.RuntimeService/RestartAndWait"
+	// RuntimeServiceRestartAndWatchProcedure is the fully-qualified name of the RuntimeService's
+	// RestartAndWatch RPC.
+	RuntimeServiceRestartAndWatchProcedure = "/otterscale.runtime.v1.RuntimeService/RestartAndWatch"
 )
 
 // RuntimeServiceClient is a client for the otterscale.runtime.v1.RuntimeService service.
@@ -64,7 +74,8 @@ type RuntimeServiceClient interface {
 	// stdout/stderr back. Due to browser limitations, bidirectional streaming
 	// cannot be used; stdin is sent via the separate WriteTTY RPC.
 	ExecuteTTY(context.Context, *v1.ExecuteTTYRequest) (*connect.ServerStreamForClient[v1.ExecuteTTYResponse], error)
-	// WriteTTY sends stdin data to an active exec session identified by session_id.
+	// WriteTTY sends stdin data to an active exec session identified by
+	// session_id, optionally closing stdin afterwards.
 	WriteTTY(context.Context, *v1.WriteTTYRequest) (*emptypb.Empty, error)
 	// ResizeTTY updates the terminal dimensions of an active exec session.
 	ResizeTTY(context.Context, *v1.ResizeTTYRequest) (*emptypb.Empty, error)
@@ -77,9 +88,26 @@ type RuntimeServiceClient interface {
 	// Scale updates the replica count of a scalable workload
 	// (Deployment, StatefulSet, ReplicaSet) via the /scale subresource.
 	Scale(context.Context, *v1.ScaleRequest) (*v1.ScaleResponse, error)
+	// Suspend scales a scalable workload to zero replicas, recording its
+	// replica count in the otterscale.io/suspended-replicas annotation so
+	// that Resume can restore it. A workload already at zero is left as is.
+	Suspend(context.Context, *v1.SuspendRequest) (*v1.SuspendResponse, error)
+	// Resume scales a workload suspended by Suspend back to its recorded
+	// replica count and removes the annotation. A workload that was not
+	// suspended is left as is.
+	Resume(context.Context, *v1.ResumeRequest) (*v1.ScaleResponse, error)
 	// Restart triggers a rolling restart of a workload by patching the
 	// pod template annotation, equivalent to `kubectl rollout restart`.
 	Restart(context.Context, *v1.RestartRequest) (*emptypb.Empty, error)
+	// RestartAndWait triggers a rolling restart like Restart, then waits until
+	// the rollout completes, equivalent to `kubectl rollout restart` followed
+	// by `kubectl rollout status`. Supported for Deployments, StatefulSets and
+	// DaemonSets. It fails with DEADLINE_EXCEEDED if the new pods are not ready
+	// within the timeout.
+	RestartAndWait(context.Context, *v1.RestartAndWaitRequest) (*v1.RolloutStatus, error)
+	// RestartAndWatch is the streaming variant of RestartAndWait: it sends
+	// the rollout status each time it changes, the last one with done set.
+	RestartAndWatch(context.Context, *v1.RestartAndWaitRequest) (*connect.ServerStreamForClient[v1.RolloutStatus], error)
 }
 
 // NewRuntimeServiceClient constructs a client for the otterscale.runtime.v1.RuntimeService service.
@@ -135,12 +163,36 @@ func NewRuntimeServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(runtimeServiceMethods.ByName("Scale")),
 			connect.WithClientOptions(opts...),
 		),
+		suspend: connect.NewClient[v1.SuspendRequest, v1.SuspendResponse](
+			httpClient,
+			baseURL+RuntimeServiceSuspendProcedure,
+			connect.WithSchema(runtimeServiceMethods.ByName("Suspend")),
+			connect.WithClientOptions(opts...),
+		),
+		resume: connect.NewClient[v1.ResumeRequest, v1.ScaleResponse](
+			httpClient,
+			baseURL+RuntimeServiceResumeProcedure,
+			connect.WithSchema(runtimeServiceMethods.ByName("Resume")),
+			connect.WithClientOptions(opts...),
+		),
 		restart: connect.NewClient[v1.RestartRequest, emptypb.Empty](
 			httpClient,
 			baseURL+RuntimeServiceRestartProcedure,
 			connect.WithSchema(runtimeServiceMethods.ByName("Restart")),
 			connect.WithClientOptions(opts...),
 		),
+		restartAndWait: connect.NewClient[v1.RestartAndWaitRequest, v1.RolloutStatus](
+			httpClient,
+			baseURL+RuntimeServiceRestartAndWaitProcedure,
+			connect.WithSchema(runtimeServiceMethods.ByName("RestartAndWait")),
+			connect.WithClientOptions(opts...),
+		),
+		restartAndWatch: connect.NewClient[v1.RestartAndWaitRequest, v1.RolloutStatus](
+			httpClient,
+			baseURL+RuntimeServiceRestartAndWatchProcedure,
+			connect.WithSchema(runtimeServiceMethods.ByName("RestartAndWatch")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -153,7 +205,11 @@ type runtimeServiceClient struct {
 	portForward      *connect.Client[v1.PortForwardRequest, v1.PortForwardResponse]
 	writePortForward *connect.Client[v1.WritePortForwardRequest, emptypb.Empty]
 	scale            *connect.Client[v1.ScaleRequest, v1.ScaleResponse]
+	suspend          *connect.Client[v1.SuspendRequest, v1.SuspendResponse]
+	resume           *connect.Client[v1.ResumeRequest, v1.ScaleResponse]
 	restart          *connect.Client[v1.RestartRequest, emptypb.Empty]
+	restartAndWait   *connect.Client[v1.RestartAndWaitRequest, v1.RolloutStatus]
+	restartAndWatch  *connect.Client[v1.RestartAndWaitRequest, v1.RolloutStatus]
 }
 
 // PodLog calls otterscale.runtime.v1.RuntimeService.PodLog.
@@ -207,6 +263,24 @@ func (c *runtimeServiceClient) Scale(ctx context.Context, req *v1.ScaleRequest)
 	return nil, err
 }
 
+// Suspend calls otterscale.runtime.v1.RuntimeService.Suspend.
+func (c *runtimeServiceClient) Suspend(ctx context.Context, req *v1.SuspendRequest) (*v1.SuspendResponse, error) {
+	response, err := c.suspend.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// Resume calls otterscale.runtime.v1.RuntimeService.Resume.
+func (c *runtimeServiceClient) Resume(ctx context.Context, req *v1.ResumeRequest) (*v1.ScaleResponse, error) {
+	response, err := c.resume.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
 // Restart calls otterscale.runtime.v1.RuntimeService.Restart.
 func (c *runtimeServiceClient) Restart(ctx context.Context, req *v1.RestartRequest) (*emptypb.Empty, error) {
 	response, err := c.restart.CallUnary(ctx, connect.NewRequest(req))
@@ -216,6 +290,20 @@ func (c *runtimeServiceClient) Restart(ctx context.Context, req *v1.RestartReque
 	return nil, err
 }
 
+// RestartAndWait calls otterscale.runtime.v1.RuntimeService.RestartAndWait.
+func (c *runtimeServiceClient) RestartAndWait(ctx context.Context, req *v1.RestartAndWaitRequest) (*v1.RolloutStatus, error) {
+	response, err := c.restartAndWait.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// RestartAndWatch calls otterscale.runtime.v1.RuntimeService.RestartAndWatch.
+func (c *runtimeServiceClient) RestartAndWatch(ctx context.Context, req *v1.RestartAndWaitRequest) (*connect.ServerStreamForClient[v1.RolloutStatus], error) {
+	return c.restartAndWatch.CallServerStream(ctx, connect.NewRequest(req))
+}
+
 // RuntimeServiceHandler is an implementation of the otterscale.runtime.v1.RuntimeService service.
 type RuntimeServiceHandler interface {
 	// PodLog streams log output from a container, similar to `kubectl logs -f`.
@@ -224,7 +312,8 @@ type RuntimeServiceHandler interface {
 	// stdout/stderr back. Due to browser limitations, bidirectional streaming
 	// cannot be used; stdin is sent via the separate WriteTTY RPC.
 	ExecuteTTY(context.Context, *v1.ExecuteTTYRequest, *connect.ServerStream[v1.ExecuteTTYResponse]) error
-	// WriteTTY sends stdin data to an active exec session identified by session_id.
+	// WriteTTY sends stdin data to an active exec session identified by
+	// session_id, optionally closing stdin afterwards.
 	WriteTTY(context.Context, *v1.WriteTTYRequest) (*emptypb.Empty, error)
 	// ResizeTTY updates the terminal dimensions of an active exec session.
 	ResizeTTY(context.Context, *v1.ResizeTTYRequest) (*emptypb.Empty, error)
@@ -237,9 +326,26 @@ type RuntimeServiceHandler interface {
 	// Scale updates the replica count of a scalable workload
 	// (Deployment, StatefulSet, ReplicaSet) via the /scale subresource.
 	Scale(context.Context, *v1.ScaleRequest) (*v1.ScaleResponse, error)
+	// Suspend scales a scalable workload to zero replicas, recording its
+	// replica count in the otterscale.io/suspended-replicas annotation so
+	// that Resume can restore it. A workload already at zero is left as is.
+	Suspend(context.Context, *v1.SuspendRequest) (*v1.SuspendResponse, error)
+	// Resume scales a workload suspended by Suspend back to its recorded
+	// replica count and removes the annotation. A workload that was not
+	// suspended is left as is.
+	Resume(context.Context, *v1.ResumeRequest) (*v1.ScaleResponse, error)
 	// Restart triggers a rolling restart of a workload by patching the
 	// pod template annotation, equivalent to `kubectl rollout restart`.
 	Restart(context.Context, *v1.RestartRequest) (*emptypb.Empty, error)
+	// RestartAndWait triggers a rolling restart like Restart, then waits until
+	// the rollout completes, equivalent to `kubectl rollout restart` followed
+	// by `kubectl rollout status`. Supported for Deployments, StatefulSets and
+	// DaemonSets. It fails with DEADLINE_EXCEEDED if the new pods are not ready
+	// within the timeout.
+	RestartAndWait(context.Context, *v1.RestartAndWaitRequest) (*v1.RolloutStatus, error)
+	// RestartAndWatch is the streaming variant of RestartAndWait: it sends
+	// the rollout status each time it changes, the last one with done set.
+	RestartAndWatch(context.Context, *v1.RestartAndWaitRequest, *connect.ServerStream[v1.RolloutStatus]) error
 }
 
 // NewRuntimeServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -291,12 +397,36 @@ func NewRuntimeServiceHandler(svc RuntimeServiceHandler, opts ...connect.Handler
 		connect.WithSchema(runtimeServiceMethods.ByName("Scale")),
 		connect.WithHandlerOptions(opts...),
 	)
+	runtimeServiceSuspendHandler := connect.NewUnaryHandlerSimple(
+		RuntimeServiceSuspendProcedure,
+		svc.Suspend,
+		connect.WithSchema(runtimeServiceMethods.ByName("Suspend")),
+		connect.WithHandlerOptions(opts...),
+	)
+	runtimeServiceResumeHandler := connect.NewUnaryHandlerSimple(
+		RuntimeServiceResumeProcedure,
+		svc.Resume,
+		connect.WithSchema(runtimeServiceMethods.ByName("Resume")),
+		connect.WithHandlerOptions(opts...),
+	)
 	runtimeServiceRestartHandler := connect.NewUnaryHandlerSimple(
 		RuntimeServiceRestartProcedure,
 		svc.Restart,
 		connect.WithSchema(runtimeServiceMethods.ByName("Restart")),
 		connect.WithHandlerOptions(opts...),
 	)
+	runtimeServiceRestartAndWaitHandler := connect.NewUnaryHandlerSimple(
+		RuntimeServiceRestartAndWaitProcedure,
+		svc.RestartAndWait,
+		connect.WithSchema(runtimeServiceMethods.ByName("RestartAndWait")),
+		connect.WithHandlerOptions(opts...),
+	)
+	runtimeServiceRestartAndWatchHandler := connect.NewServerStreamHandlerSimple(
+		RuntimeServiceRestartAndWatchProcedure,
+		svc.RestartAndWatch,
+		connect.WithSchema(runtimeServiceMethods.ByName("RestartAndWatch")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/otterscale.runtime.v1.RuntimeService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case RuntimeServicePodLogProcedure:
@@ -313,8 +443,16 @@ func NewRuntimeServiceHandler(svc RuntimeServiceHandler, opts ...connect.Handler
 			runtimeServiceWritePortForwardHandler.ServeHTTP(w, r)
 		case RuntimeServiceScaleProcedure:
 			runtimeServiceScaleHandler.ServeHTTP(w, r)
+		case RuntimeServiceSuspendProcedure:
+			runtimeServiceSuspendHandler.ServeHTTP(w, r)
+		case RuntimeServiceResumeProcedure:
+			runtimeServiceResumeHandler.ServeHTTP(w, r)
 		case RuntimeServiceRestartProcedure:
 			runtimeServiceRestartHandler.ServeHTTP(w, r)
+		case RuntimeServiceRestartAndWaitProcedure:
+			runtimeServiceRestartAndWaitHandler.ServeHTTP(w, r)
+		case RuntimeServiceRestartAndWatchProcedure:
+			runtimeServiceRestartAndWatchHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -352,6 +490,22 @@ func (UnimplementedRuntimeServiceHandler) Scale(context.Context, *v1.ScaleReques
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.runtime.v1.RuntimeService.Scale is not implemented"))
 }
 
+func (UnimplementedRuntimeServiceHandler) Suspend(context.Context, *v1.SuspendRequest) (*v1.SuspendResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.runtime.v1.RuntimeService.Suspend is not implemented"))
+}
+
+func (UnimplementedRuntimeServiceHandler) Resume(context.Context, *v1.ResumeRequest) (*v1.ScaleResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.runtime.v1.RuntimeService.Resume is not implemented"))
+}
+
 func (UnimplementedRuntimeServiceHandler) Restart(context.Context, *v1.RestartRequest) (*emptypb.Empty, error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.runtime.v1.RuntimeService.Restart is not implemented"))
 }
+
+func (UnimplementedRuntimeServiceHandler) RestartAndWait(context.Context, *v1.RestartAndWaitRequest) (*v1.RolloutStatus, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.runtime.v1.RuntimeService.RestartAndWait is not implemented"))
+}
+
+func (UnimplementedRuntimeServiceHandler) RestartAndWatch(context.Context, *v1.RestartAndWaitRequest, *connect.ServerStream[v1.RolloutStatus]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("otterscale.runtime.v1.RuntimeService.RestartAndWatch is not implemented"))
+}
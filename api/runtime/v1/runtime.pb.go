@@ -420,12 +420,14 @@ func (b0 PodLogRequest_builder) Build() *PodLogRequest {
 
 // PodLogResponse contains a chunk of log data.
 type PodLogResponse struct {
-	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Data        []byte                 `protobuf:"bytes,1,opt,name=data"`
-	XXX_raceDetectHookData protoimpl.RaceDetectHookData
-	XXX_presence           [1]uint32
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	state                      protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Data            []byte                 `protobuf:"bytes,1,opt,name=data"`
+	xxx_hidden_DroppedLines    uint64                 `protobuf:"varint,2,opt,name=dropped_lines,json=droppedLines"`
+	xxx_hidden_DroppedFromLine uint64                 `protobuf:"varint,3,opt,name=dropped_from_line,json=droppedFromLine"`
+	XXX_raceDetectHookData     protoimpl.RaceDetectHookData
+	XXX_presence               [1]uint32
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
 }
 
 func (x *PodLogResponse) Reset() {
@@ -460,12 +462,36 @@ func (x *PodLogResponse) GetData() []byte {
 	return nil
 }
 
+func (x *PodLogResponse) GetDroppedLines() uint64 {
+	if x != nil {
+		return x.xxx_hidden_DroppedLines
+	}
+	return 0
+}
+
+func (x *PodLogResponse) GetDroppedFromLine() uint64 {
+	if x != nil {
+		return x.xxx_hidden_DroppedFromLine
+	}
+	return 0
+}
+
 func (x *PodLogResponse) SetData(v []byte) {
 	if v == nil {
 		v = []byte{}
 	}
 	x.xxx_hidden_Data = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *PodLogResponse) SetDroppedLines(v uint64) {
+	x.xxx_hidden_DroppedLines = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *PodLogResponse) SetDroppedFromLine(v uint64) {
+	x.xxx_hidden_DroppedFromLine = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
 }
 
 func (x *PodLogResponse) HasData() bool {
@@ -475,16 +501,52 @@ func (x *PodLogResponse) HasData() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
+func (x *PodLogResponse) HasDroppedLines() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *PodLogResponse) HasDroppedFromLine() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
 func (x *PodLogResponse) ClearData() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Data = nil
 }
 
+func (x *PodLogResponse) ClearDroppedLines() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_DroppedLines = 0
+}
+
+func (x *PodLogResponse) ClearDroppedFromLine() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_DroppedFromLine = 0
+}
+
 type PodLogResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
 	// Raw log data bytes.
 	Data []byte
+	// Set on a message without data when the client could not keep up and
+	// the server's log buffer overflowed: the number of the oldest lines
+	// dropped since the previous message. Clients typically show a marker
+	// in place of the missing lines. Lines are only dropped from followed
+	// logs.
+	DroppedLines *uint64
+	// Set with dropped_lines: the 0-based index, counting from the first
+	// line of this stream, of the first dropped line, so the dropped
+	// range is [dropped_from_line, dropped_from_line + dropped_lines). A
+	// line longer than the buffer is counted as dropped although its end
+	// is still sent.
+	DroppedFromLine *uint64
 }
 
 func (b0 PodLogResponse_builder) Build() *PodLogResponse {
@@ -492,9 +554,17 @@ func (b0 PodLogResponse_builder) Build() *PodLogResponse {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Data != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
 		x.xxx_hidden_Data = b.Data
 	}
+	if b.DroppedLines != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_DroppedLines = *b.DroppedLines
+	}
+	if b.DroppedFromLine != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_DroppedFromLine = *b.DroppedFromLine
+	}
 	return m0
 }
 
@@ -943,6 +1013,7 @@ type WriteTTYRequest struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_SessionId   *string                `protobuf:"bytes,1,opt,name=session_id,json=sessionId"`
 	xxx_hidden_Stdin       []byte                 `protobuf:"bytes,2,opt,name=stdin"`
+	xxx_hidden_CloseStdin  bool                   `protobuf:"varint,3,opt,name=close_stdin,json=closeStdin"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
@@ -991,9 +1062,16 @@ func (x *WriteTTYRequest) GetStdin() []byte {
 	return nil
 }
 
+func (x *WriteTTYRequest) GetCloseStdin() bool {
+	if x != nil {
+		return x.xxx_hidden_CloseStdin
+	}
+	return false
+}
+
 func (x *WriteTTYRequest) SetSessionId(v string) {
 	x.xxx_hidden_SessionId = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
 }
 
 func (x *WriteTTYRequest) SetStdin(v []byte) {
@@ -1001,7 +1079,12 @@ func (x *WriteTTYRequest) SetStdin(v []byte) {
 		v = []byte{}
 	}
 	x.xxx_hidden_Stdin = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *WriteTTYRequest) SetCloseStdin(v bool) {
+	x.xxx_hidden_CloseStdin = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
 }
 
 func (x *WriteTTYRequest) HasSessionId() bool {
@@ -1018,6 +1101,13 @@ func (x *WriteTTYRequest) HasStdin() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
+func (x *WriteTTYRequest) HasCloseStdin() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
 func (x *WriteTTYRequest) ClearSessionId() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_SessionId = nil
@@ -1028,6 +1118,11 @@ func (x *WriteTTYRequest) ClearStdin() {
 	x.xxx_hidden_Stdin = nil
 }
 
+func (x *WriteTTYRequest) ClearCloseStdin() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_CloseStdin = false
+}
+
 type WriteTTYRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -1035,6 +1130,10 @@ type WriteTTYRequest_builder struct {
 	SessionId *string
 	// Stdin data to write.
 	Stdin []byte
+	// Close stdin after writing the data, so that the command reads EOF.
+	// Use it for non-interactive commands that consume their input, such
+	// as piping a file into `tar x`. Later writes to the session fail.
+	CloseStdin *bool
 }
 
 func (b0 WriteTTYRequest_builder) Build() *WriteTTYRequest {
@@ -1042,13 +1141,17 @@ func (b0 WriteTTYRequest_builder) Build() *WriteTTYRequest {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.SessionId != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
 		x.xxx_hidden_SessionId = b.SessionId
 	}
 	if b.Stdin != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
 		x.xxx_hidden_Stdin = b.Stdin
 	}
+	if b.CloseStdin != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_CloseStdin = *b.CloseStdin
+	}
 	return m0
 }
 
@@ -1201,6 +1304,7 @@ type PortForwardRequest struct {
 	xxx_hidden_Namespace   *string                `protobuf:"bytes,2,opt,name=namespace"`
 	xxx_hidden_Name        *string                `protobuf:"bytes,3,opt,name=name"`
 	xxx_hidden_Port        int32                  `protobuf:"varint,4,opt,name=port"`
+	xxx_hidden_Ports       []int32                `protobuf:"varint,5,rep,packed,name=ports"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
@@ -1269,24 +1373,35 @@ func (x *PortForwardRequest) GetPort() int32 {
 	return 0
 }
 
+func (x *PortForwardRequest) GetPorts() []int32 {
+	if x != nil {
+		return x.xxx_hidden_Ports
+	}
+	return nil
+}
+
 func (x *PortForwardRequest) SetCluster(v string) {
 	x.xxx_hidden_Cluster = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
 }
 
 func (x *PortForwardRequest) SetNamespace(v string) {
 	x.xxx_hidden_Namespace = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 5)
 }
 
 func (x *PortForwardRequest) SetName(v string) {
 	x.xxx_hidden_Name = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 5)
 }
 
 func (x *PortForwardRequest) SetPort(v int32) {
 	x.xxx_hidden_Port = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 5)
+}
+
+func (x *PortForwardRequest) SetPorts(v []int32) {
+	x.xxx_hidden_Ports = v
 }
 
 func (x *PortForwardRequest) HasCluster() bool {
@@ -1346,8 +1461,11 @@ type PortForwardRequest_builder struct {
 	Namespace *string
 	// The name of the pod.
 	Name *string
-	// The container port to forward to.
+	// The container port to forward to. Ignored when ports is set.
 	Port *int32
+	// The container ports to forward to. All ports share one connection
+	// to the pod and are addressed individually by port number.
+	Ports []int32
 }
 
 func (b0 PortForwardRequest_builder) Build() *PortForwardRequest {
@@ -1355,29 +1473,31 @@ func (b0 PortForwardRequest_builder) Build() *PortForwardRequest {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Cluster != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
 		x.xxx_hidden_Cluster = b.Cluster
 	}
 	if b.Namespace != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 5)
 		x.xxx_hidden_Namespace = b.Namespace
 	}
 	if b.Name != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 5)
 		x.xxx_hidden_Name = b.Name
 	}
 	if b.Port != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 5)
 		x.xxx_hidden_Port = *b.Port
 	}
+	x.xxx_hidden_Ports = b.Ports
 	return m0
 }
 
-// PortForwardResponse streams data received from the forwarded port.
+// PortForwardResponse streams data received from the forwarded ports.
 type PortForwardResponse struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_SessionId   *string                `protobuf:"bytes,1,opt,name=session_id,json=sessionId"`
 	xxx_hidden_Data        []byte                 `protobuf:"bytes,2,opt,name=data"`
+	xxx_hidden_Port        int32                  `protobuf:"varint,3,opt,name=port"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
@@ -1426,9 +1546,16 @@ func (x *PortForwardResponse) GetData() []byte {
 	return nil
 }
 
+func (x *PortForwardResponse) GetPort() int32 {
+	if x != nil {
+		return x.xxx_hidden_Port
+	}
+	return 0
+}
+
 func (x *PortForwardResponse) SetSessionId(v string) {
 	x.xxx_hidden_SessionId = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
 }
 
 func (x *PortForwardResponse) SetData(v []byte) {
@@ -1436,7 +1563,12 @@ func (x *PortForwardResponse) SetData(v []byte) {
 		v = []byte{}
 	}
 	x.xxx_hidden_Data = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *PortForwardResponse) SetPort(v int32) {
+	x.xxx_hidden_Port = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
 }
 
 func (x *PortForwardResponse) HasSessionId() bool {
@@ -1453,6 +1585,13 @@ func (x *PortForwardResponse) HasData() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
+func (x *PortForwardResponse) HasPort() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
 func (x *PortForwardResponse) ClearSessionId() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_SessionId = nil
@@ -1463,6 +1602,11 @@ func (x *PortForwardResponse) ClearData() {
 	x.xxx_hidden_Data = nil
 }
 
+func (x *PortForwardResponse) ClearPort() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Port = 0
+}
+
 type PortForwardResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -1471,6 +1615,8 @@ type PortForwardResponse_builder struct {
 	SessionId *string
 	// Data received from the forwarded port on the pod.
 	Data []byte
+	// The pod port the data was received from.
+	Port *int32
 }
 
 func (b0 PortForwardResponse_builder) Build() *PortForwardResponse {
@@ -1478,13 +1624,17 @@ func (b0 PortForwardResponse_builder) Build() *PortForwardResponse {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.SessionId != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
 		x.xxx_hidden_SessionId = b.SessionId
 	}
 	if b.Data != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
 		x.xxx_hidden_Data = b.Data
 	}
+	if b.Port != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Port = *b.Port
+	}
 	return m0
 }
 
@@ -1493,6 +1643,7 @@ type WritePortForwardRequest struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_SessionId   *string                `protobuf:"bytes,1,opt,name=session_id,json=sessionId"`
 	xxx_hidden_Data        []byte                 `protobuf:"bytes,2,opt,name=data"`
+	xxx_hidden_Port        int32                  `protobuf:"varint,3,opt,name=port"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
@@ -1541,9 +1692,16 @@ func (x *WritePortForwardRequest) GetData() []byte {
 	return nil
 }
 
+func (x *WritePortForwardRequest) GetPort() int32 {
+	if x != nil {
+		return x.xxx_hidden_Port
+	}
+	return 0
+}
+
 func (x *WritePortForwardRequest) SetSessionId(v string) {
 	x.xxx_hidden_SessionId = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
 }
 
 func (x *WritePortForwardRequest) SetData(v []byte) {
@@ -1551,7 +1709,12 @@ func (x *WritePortForwardRequest) SetData(v []byte) {
 		v = []byte{}
 	}
 	x.xxx_hidden_Data = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *WritePortForwardRequest) SetPort(v int32) {
+	x.xxx_hidden_Port = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
 }
 
 func (x *WritePortForwardRequest) HasSessionId() bool {
@@ -1568,6 +1731,13 @@ func (x *WritePortForwardRequest) HasData() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
+func (x *WritePortForwardRequest) HasPort() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
 func (x *WritePortForwardRequest) ClearSessionId() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_SessionId = nil
@@ -1578,6 +1748,11 @@ func (x *WritePortForwardRequest) ClearData() {
 	x.xxx_hidden_Data = nil
 }
 
+func (x *WritePortForwardRequest) ClearPort() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Port = 0
+}
+
 type WritePortForwardRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -1585,6 +1760,9 @@ type WritePortForwardRequest_builder struct {
 	SessionId *string
 	// Data to send to the forwarded port on the pod.
 	Data []byte
+	// The pod port to send the data to. May be omitted when the session
+	// forwards a single port.
+	Port *int32
 }
 
 func (b0 WritePortForwardRequest_builder) Build() *WritePortForwardRequest {
@@ -1592,13 +1770,17 @@ func (b0 WritePortForwardRequest_builder) Build() *WritePortForwardRequest {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.SessionId != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
 		x.xxx_hidden_SessionId = b.SessionId
 	}
 	if b.Data != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
 		x.xxx_hidden_Data = b.Data
 	}
+	if b.Port != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Port = *b.Port
+	}
 	return m0
 }
 
@@ -1961,10 +2143,8 @@ func (b0 ScaleResponse_builder) Build() *ScaleResponse {
 	return m0
 }
 
-// RestartRequest defines the parameters for triggering a rolling restart.
-// This patches the pod template annotation with kubectl.kubernetes.io/restartedAt,
-// equivalent to `kubectl rollout restart`.
-type RestartRequest struct {
+// SuspendRequest defines the parameters for suspending a workload.
+type SuspendRequest struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_Cluster     *string                `protobuf:"bytes,1,opt,name=cluster"`
 	xxx_hidden_Group       *string                `protobuf:"bytes,2,opt,name=group"`
@@ -1978,20 +2158,20 @@ type RestartRequest struct {
 	sizeCache              protoimpl.SizeCache
 }
 
-func (x *RestartRequest) Reset() {
-	*x = RestartRequest{}
+func (x *SuspendRequest) Reset() {
+	*x = SuspendRequest{}
 	mi := &file_api_runtime_v1_runtime_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RestartRequest) String() string {
+func (x *SuspendRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RestartRequest) ProtoMessage() {}
+func (*SuspendRequest) ProtoMessage() {}
 
-func (x *RestartRequest) ProtoReflect() protoreflect.Message {
+func (x *SuspendRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_runtime_v1_runtime_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2003,7 +2183,7 @@ func (x *RestartRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *RestartRequest) GetCluster() string {
+func (x *SuspendRequest) GetCluster() string {
 	if x != nil {
 		if x.xxx_hidden_Cluster != nil {
 			return *x.xxx_hidden_Cluster
@@ -2013,7 +2193,7 @@ func (x *RestartRequest) GetCluster() string {
 	return ""
 }
 
-func (x *RestartRequest) GetGroup() string {
+func (x *SuspendRequest) GetGroup() string {
 	if x != nil {
 		if x.xxx_hidden_Group != nil {
 			return *x.xxx_hidden_Group
@@ -2023,7 +2203,7 @@ func (x *RestartRequest) GetGroup() string {
 	return ""
 }
 
-func (x *RestartRequest) GetVersion() string {
+func (x *SuspendRequest) GetVersion() string {
 	if x != nil {
 		if x.xxx_hidden_Version != nil {
 			return *x.xxx_hidden_Version
@@ -2033,7 +2213,7 @@ func (x *RestartRequest) GetVersion() string {
 	return ""
 }
 
-func (x *RestartRequest) GetResource() string {
+func (x *SuspendRequest) GetResource() string {
 	if x != nil {
 		if x.xxx_hidden_Resource != nil {
 			return *x.xxx_hidden_Resource
@@ -2043,7 +2223,7 @@ func (x *RestartRequest) GetResource() string {
 	return ""
 }
 
-func (x *RestartRequest) GetNamespace() string {
+func (x *SuspendRequest) GetNamespace() string {
 	if x != nil {
 		if x.xxx_hidden_Namespace != nil {
 			return *x.xxx_hidden_Namespace
@@ -2053,7 +2233,7 @@ func (x *RestartRequest) GetNamespace() string {
 	return ""
 }
 
-func (x *RestartRequest) GetName() string {
+func (x *SuspendRequest) GetName() string {
 	if x != nil {
 		if x.xxx_hidden_Name != nil {
 			return *x.xxx_hidden_Name
@@ -2063,109 +2243,109 @@ func (x *RestartRequest) GetName() string {
 	return ""
 }
 
-func (x *RestartRequest) SetCluster(v string) {
+func (x *SuspendRequest) SetCluster(v string) {
 	x.xxx_hidden_Cluster = &v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 6)
 }
 
-func (x *RestartRequest) SetGroup(v string) {
+func (x *SuspendRequest) SetGroup(v string) {
 	x.xxx_hidden_Group = &v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 6)
 }
 
-func (x *RestartRequest) SetVersion(v string) {
+func (x *SuspendRequest) SetVersion(v string) {
 	x.xxx_hidden_Version = &v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 6)
 }
 
-func (x *RestartRequest) SetResource(v string) {
+func (x *SuspendRequest) SetResource(v string) {
 	x.xxx_hidden_Resource = &v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 6)
 }
 
-func (x *RestartRequest) SetNamespace(v string) {
+func (x *SuspendRequest) SetNamespace(v string) {
 	x.xxx_hidden_Namespace = &v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
 }
 
-func (x *RestartRequest) SetName(v string) {
+func (x *SuspendRequest) SetName(v string) {
 	x.xxx_hidden_Name = &v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 6)
 }
 
-func (x *RestartRequest) HasCluster() bool {
+func (x *SuspendRequest) HasCluster() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *RestartRequest) HasGroup() bool {
+func (x *SuspendRequest) HasGroup() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *RestartRequest) HasVersion() bool {
+func (x *SuspendRequest) HasVersion() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
 }
 
-func (x *RestartRequest) HasResource() bool {
+func (x *SuspendRequest) HasResource() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
 }
 
-func (x *RestartRequest) HasNamespace() bool {
+func (x *SuspendRequest) HasNamespace() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
 }
 
-func (x *RestartRequest) HasName() bool {
+func (x *SuspendRequest) HasName() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
 }
 
-func (x *RestartRequest) ClearCluster() {
+func (x *SuspendRequest) ClearCluster() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Cluster = nil
 }
 
-func (x *RestartRequest) ClearGroup() {
+func (x *SuspendRequest) ClearGroup() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
 	x.xxx_hidden_Group = nil
 }
 
-func (x *RestartRequest) ClearVersion() {
+func (x *SuspendRequest) ClearVersion() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
 	x.xxx_hidden_Version = nil
 }
 
-func (x *RestartRequest) ClearResource() {
+func (x *SuspendRequest) ClearResource() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
 	x.xxx_hidden_Resource = nil
 }
 
-func (x *RestartRequest) ClearNamespace() {
+func (x *SuspendRequest) ClearNamespace() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
 	x.xxx_hidden_Namespace = nil
 }
 
-func (x *RestartRequest) ClearName() {
+func (x *SuspendRequest) ClearName() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
 	x.xxx_hidden_Name = nil
 }
 
-type RestartRequest_builder struct {
+type SuspendRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
 	// The target Kubernetes cluster identifier.
@@ -2182,8 +2362,8 @@ type RestartRequest_builder struct {
 	Name *string
 }
 
-func (b0 RestartRequest_builder) Build() *RestartRequest {
-	m0 := &RestartRequest{}
+func (b0 SuspendRequest_builder) Build() *SuspendRequest {
+	m0 := &SuspendRequest{}
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Cluster != nil {
@@ -2213,84 +2393,1221 @@ func (b0 RestartRequest_builder) Build() *RestartRequest {
 	return m0
 }
 
-var File_api_runtime_v1_runtime_proto protoreflect.FileDescriptor
+// SuspendResponse contains the replica count recorded for Resume.
+type SuspendResponse struct {
+	state                        protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_SuspendedReplicas int32                  `protobuf:"varint,1,opt,name=suspended_replicas,json=suspendedReplicas"`
+	XXX_raceDetectHookData       protoimpl.RaceDetectHookData
+	XXX_presence                 [1]uint32
+	unknownFields                protoimpl.UnknownFields
+	sizeCache                    protoimpl.SizeCache
+}
 
-const file_api_runtime_v1_runtime_proto_rawDesc = "" +
-	"\n" +
-	"\x1capi/runtime/v1/runtime.proto\x12\x15otterscale.runtime.v1\x1a\x15api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xed\x02\n" +
-	"\rPodLogRequest\x12\x18\n" +
-	"\acluster\x18\x01 \x01(\tR\acluster\x12\x1c\n" +
-	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1c\n" +
-	"\tcontainer\x18\x04 \x01(\tR\tcontainer\x12\x16\n" +
-	"\x06follow\x18\x05 \x01(\bR\x06follow\x12\x1d\n" +
-	"\n" +
-	"tail_lines\x18\x06 \x01(\x03R\ttailLines\x12#\n" +
-	"\rsince_seconds\x18\a \x01(\x03R\fsinceSeconds\x129\n" +
-	"\n" +
-	"since_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tsinceTime\x12\x1a\n" +
-	"\bprevious\x18\t \x01(\bR\bprevious\x12\x1e\n" +
-	"\n" +
-	"timestamps\x18\n" +
-	" \x01(\bR\n" +
-	"timestamps\x12\x1f\n" +
-	"\vlimit_bytes\x18\v \x01(\x03R\n" +
-	"limitBytes\"$\n" +
-	"\x0ePodLogResponse\x12\x12\n" +
-	"\x04data\x18\x01 \x01(\fR\x04data\"\xd1\x01\n" +
-	"\x11ExecuteTTYRequest\x12\x18\n" +
-	"\acluster\x18\x01 \x01(\tR\acluster\x12\x1c\n" +
-	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1c\n" +
-	"\tcontainer\x18\x04 \x01(\tR\tcontainer\x12\x18\n" +
-	"\acommand\x18\x05 \x03(\tR\acommand\x12\x10\n" +
-	"\x03tty\x18\x06 \x01(\bR\x03tty\x12\x12\n" +
-	"\x04rows\x18\a \x01(\rR\x04rows\x12\x12\n" +
-	"\x04cols\x18\b \x01(\rR\x04cols\"c\n" +
-	"\x12ExecuteTTYResponse\x12\x1d\n" +
-	"\n" +
-	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x16\n" +
-	"\x06stdout\x18\x02 \x01(\fR\x06stdout\x12\x16\n" +
-	"\x06stderr\x18\x03 \x01(\fR\x06stderr\"F\n" +
-	"\x0fWriteTTYRequest\x12\x1d\n" +
-	"\n" +
-	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x14\n" +
-	"\x05stdin\x18\x02 \x01(\fR\x05stdin\"Y\n" +
-	"\x10ResizeTTYRequest\x12\x1d\n" +
-	"\n" +
-	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x12\n" +
-	"\x04rows\x18\x02 \x01(\rR\x04rows\x12\x12\n" +
-	"\x04cols\x18\x03 \x01(\rR\x04cols\"t\n" +
-	"\x12PortForwardRequest\x12\x18\n" +
-	"\acluster\x18\x01 \x01(\tR\acluster\x12\x1c\n" +
-	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
-	"\x04port\x18\x04 \x01(\x05R\x04port\"H\n" +
-	"\x13PortForwardResponse\x12\x1d\n" +
-	"\n" +
-	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x12\n" +
-	"\x04data\x18\x02 \x01(\fR\x04data\"L\n" +
-	"\x17WritePortForwardRequest\x12\x1d\n" +
-	"\n" +
-	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x12\n" +
-	"\x04data\x18\x02 \x01(\fR\x04data\"\xc2\x01\n" +
-	"\fScaleRequest\x12\x18\n" +
-	"\acluster\x18\x01 \x01(\tR\acluster\x12\x14\n" +
-	"\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n" +
-	"\aversion\x18\x03 \x01(\tR\aversion\x12\x1a\n" +
-	"\bresource\x18\x04 \x01(\tR\bresource\x12\x1c\n" +
-	"\tnamespace\x18\x05 \x01(\tR\tnamespace\x12\x12\n" +
-	"\x04name\x18\x06 \x01(\tR\x04name\x12\x1a\n" +
-	"\breplicas\x18\a \x01(\x05R\breplicas\"+\n" +
-	"\rScaleResponse\x12\x1a\n" +
-	"\breplicas\x18\x01 \x01(\x05R\breplicas\"\xa8\x01\n" +
-	"\x0eRestartRequest\x12\x18\n" +
-	"\acluster\x18\x01 \x01(\tR\acluster\x12\x14\n" +
-	"\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n" +
-	"\aversion\x18\x03 \x01(\tR\aversion\x12\x1a\n" +
-	"\bresource\x18\x04 \x01(\tR\bresource\x12\x1c\n" +
-	"\tnamespace\x18\x05 \x01(\tR\tnamespace\x12\x12\n" +
-	"\x04name\x18\x06 \x01(\tR\x04name2\x8a\a\n" +
+func (x *SuspendResponse) Reset() {
+	*x = SuspendResponse{}
+	mi := &file_api_runtime_v1_runtime_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuspendResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuspendResponse) ProtoMessage() {}
+
+func (x *SuspendResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_runtime_v1_runtime_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SuspendResponse) GetSuspendedReplicas() int32 {
+	if x != nil {
+		return x.xxx_hidden_SuspendedReplicas
+	}
+	return 0
+}
+
+func (x *SuspendResponse) SetSuspendedReplicas(v int32) {
+	x.xxx_hidden_SuspendedReplicas = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *SuspendResponse) HasSuspendedReplicas() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *SuspendResponse) ClearSuspendedReplicas() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_SuspendedReplicas = 0
+}
+
+type SuspendResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The number of replicas the workload had before it was suspended,
+	// or 0 if it was already at zero without having been suspended.
+	SuspendedReplicas *int32
+}
+
+func (b0 SuspendResponse_builder) Build() *SuspendResponse {
+	m0 := &SuspendResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.SuspendedReplicas != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_SuspendedReplicas = *b.SuspendedReplicas
+	}
+	return m0
+}
+
+// ResumeRequest defines the parameters for resuming a suspended workload.
+type ResumeRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster     *string                `protobuf:"bytes,1,opt,name=cluster"`
+	xxx_hidden_Group       *string                `protobuf:"bytes,2,opt,name=group"`
+	xxx_hidden_Version     *string                `protobuf:"bytes,3,opt,name=version"`
+	xxx_hidden_Resource    *string                `protobuf:"bytes,4,opt,name=resource"`
+	xxx_hidden_Namespace   *string                `protobuf:"bytes,5,opt,name=namespace"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,6,opt,name=name"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ResumeRequest) Reset() {
+	*x = ResumeRequest{}
+	mi := &file_api_runtime_v1_runtime_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeRequest) ProtoMessage() {}
+
+func (x *ResumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_runtime_v1_runtime_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ResumeRequest) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ResumeRequest) GetGroup() string {
+	if x != nil {
+		if x.xxx_hidden_Group != nil {
+			return *x.xxx_hidden_Group
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ResumeRequest) GetVersion() string {
+	if x != nil {
+		if x.xxx_hidden_Version != nil {
+			return *x.xxx_hidden_Version
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ResumeRequest) GetResource() string {
+	if x != nil {
+		if x.xxx_hidden_Resource != nil {
+			return *x.xxx_hidden_Resource
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ResumeRequest) GetNamespace() string {
+	if x != nil {
+		if x.xxx_hidden_Namespace != nil {
+			return *x.xxx_hidden_Namespace
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ResumeRequest) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ResumeRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 6)
+}
+
+func (x *ResumeRequest) SetGroup(v string) {
+	x.xxx_hidden_Group = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 6)
+}
+
+func (x *ResumeRequest) SetVersion(v string) {
+	x.xxx_hidden_Version = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 6)
+}
+
+func (x *ResumeRequest) SetResource(v string) {
+	x.xxx_hidden_Resource = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 6)
+}
+
+func (x *ResumeRequest) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
+}
+
+func (x *ResumeRequest) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 6)
+}
+
+func (x *ResumeRequest) HasCluster() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ResumeRequest) HasGroup() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ResumeRequest) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ResumeRequest) HasResource() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *ResumeRequest) HasNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *ResumeRequest) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *ResumeRequest) ClearCluster() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Cluster = nil
+}
+
+func (x *ResumeRequest) ClearGroup() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Group = nil
+}
+
+func (x *ResumeRequest) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Version = nil
+}
+
+func (x *ResumeRequest) ClearResource() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Resource = nil
+}
+
+func (x *ResumeRequest) ClearNamespace() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Namespace = nil
+}
+
+func (x *ResumeRequest) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Name = nil
+}
+
+type ResumeRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The target Kubernetes cluster identifier.
+	Cluster *string
+	// Kubernetes API Group (e.g., "apps").
+	Group *string
+	// Kubernetes API Version (e.g., "v1").
+	Version *string
+	// Kubernetes API Resource name in plural (e.g., "deployments").
+	Resource *string
+	// The namespace of the workload.
+	Namespace *string
+	// The name of the workload.
+	Name *string
+}
+
+func (b0 ResumeRequest_builder) Build() *ResumeRequest {
+	m0 := &ResumeRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Cluster != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 6)
+		x.xxx_hidden_Cluster = b.Cluster
+	}
+	if b.Group != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 6)
+		x.xxx_hidden_Group = b.Group
+	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 6)
+		x.xxx_hidden_Version = b.Version
+	}
+	if b.Resource != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 6)
+		x.xxx_hidden_Resource = b.Resource
+	}
+	if b.Namespace != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 6)
+		x.xxx_hidden_Namespace = b.Namespace
+	}
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 6)
+		x.xxx_hidden_Name = b.Name
+	}
+	return m0
+}
+
+// RestartRequest defines the parameters for triggering a rolling restart.
+// This patches the pod template annotation with kubectl.kubernetes.io/restartedAt,
+// equivalent to `kubectl rollout restart`.
+type RestartRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster     *string                `protobuf:"bytes,1,opt,name=cluster"`
+	xxx_hidden_Group       *string                `protobuf:"bytes,2,opt,name=group"`
+	xxx_hidden_Version     *string                `protobuf:"bytes,3,opt,name=version"`
+	xxx_hidden_Resource    *string                `protobuf:"bytes,4,opt,name=resource"`
+	xxx_hidden_Namespace   *string                `protobuf:"bytes,5,opt,name=namespace"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,6,opt,name=name"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RestartRequest) Reset() {
+	*x = RestartRequest{}
+	mi := &file_api_runtime_v1_runtime_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestartRequest) ProtoMessage() {}
+
+func (x *RestartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_runtime_v1_runtime_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RestartRequest) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RestartRequest) GetGroup() string {
+	if x != nil {
+		if x.xxx_hidden_Group != nil {
+			return *x.xxx_hidden_Group
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RestartRequest) GetVersion() string {
+	if x != nil {
+		if x.xxx_hidden_Version != nil {
+			return *x.xxx_hidden_Version
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RestartRequest) GetResource() string {
+	if x != nil {
+		if x.xxx_hidden_Resource != nil {
+			return *x.xxx_hidden_Resource
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RestartRequest) GetNamespace() string {
+	if x != nil {
+		if x.xxx_hidden_Namespace != nil {
+			return *x.xxx_hidden_Namespace
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RestartRequest) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RestartRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 6)
+}
+
+func (x *RestartRequest) SetGroup(v string) {
+	x.xxx_hidden_Group = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 6)
+}
+
+func (x *RestartRequest) SetVersion(v string) {
+	x.xxx_hidden_Version = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 6)
+}
+
+func (x *RestartRequest) SetResource(v string) {
+	x.xxx_hidden_Resource = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 6)
+}
+
+func (x *RestartRequest) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
+}
+
+func (x *RestartRequest) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 6)
+}
+
+func (x *RestartRequest) HasCluster() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RestartRequest) HasGroup() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *RestartRequest) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *RestartRequest) HasResource() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *RestartRequest) HasNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *RestartRequest) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *RestartRequest) ClearCluster() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Cluster = nil
+}
+
+func (x *RestartRequest) ClearGroup() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Group = nil
+}
+
+func (x *RestartRequest) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Version = nil
+}
+
+func (x *RestartRequest) ClearResource() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Resource = nil
+}
+
+func (x *RestartRequest) ClearNamespace() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Namespace = nil
+}
+
+func (x *RestartRequest) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Name = nil
+}
+
+type RestartRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The target Kubernetes cluster identifier.
+	Cluster *string
+	// Kubernetes API Group (e.g., "apps").
+	Group *string
+	// Kubernetes API Version (e.g., "v1").
+	Version *string
+	// Kubernetes API Resource name in plural (e.g., "deployments").
+	Resource *string
+	// The namespace of the workload.
+	Namespace *string
+	// The name of the workload.
+	Name *string
+}
+
+func (b0 RestartRequest_builder) Build() *RestartRequest {
+	m0 := &RestartRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Cluster != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 6)
+		x.xxx_hidden_Cluster = b.Cluster
+	}
+	if b.Group != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 6)
+		x.xxx_hidden_Group = b.Group
+	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 6)
+		x.xxx_hidden_Version = b.Version
+	}
+	if b.Resource != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 6)
+		x.xxx_hidden_Resource = b.Resource
+	}
+	if b.Namespace != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 6)
+		x.xxx_hidden_Namespace = b.Namespace
+	}
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 6)
+		x.xxx_hidden_Name = b.Name
+	}
+	return m0
+}
+
+// RestartAndWaitRequest defines the parameters for a rolling restart that
+// waits for the rollout to complete.
+type RestartAndWaitRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cluster        *string                `protobuf:"bytes,1,opt,name=cluster"`
+	xxx_hidden_Group          *string                `protobuf:"bytes,2,opt,name=group"`
+	xxx_hidden_Version        *string                `protobuf:"bytes,3,opt,name=version"`
+	xxx_hidden_Resource       *string                `protobuf:"bytes,4,opt,name=resource"`
+	xxx_hidden_Namespace      *string                `protobuf:"bytes,5,opt,name=namespace"`
+	xxx_hidden_Name           *string                `protobuf:"bytes,6,opt,name=name"`
+	xxx_hidden_TimeoutSeconds int64                  `protobuf:"varint,7,opt,name=timeout_seconds,json=timeoutSeconds"`
+	XXX_raceDetectHookData    protoimpl.RaceDetectHookData
+	XXX_presence              [1]uint32
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *RestartAndWaitRequest) Reset() {
+	*x = RestartAndWaitRequest{}
+	mi := &file_api_runtime_v1_runtime_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestartAndWaitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestartAndWaitRequest) ProtoMessage() {}
+
+func (x *RestartAndWaitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_runtime_v1_runtime_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RestartAndWaitRequest) GetCluster() string {
+	if x != nil {
+		if x.xxx_hidden_Cluster != nil {
+			return *x.xxx_hidden_Cluster
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RestartAndWaitRequest) GetGroup() string {
+	if x != nil {
+		if x.xxx_hidden_Group != nil {
+			return *x.xxx_hidden_Group
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RestartAndWaitRequest) GetVersion() string {
+	if x != nil {
+		if x.xxx_hidden_Version != nil {
+			return *x.xxx_hidden_Version
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RestartAndWaitRequest) GetResource() string {
+	if x != nil {
+		if x.xxx_hidden_Resource != nil {
+			return *x.xxx_hidden_Resource
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RestartAndWaitRequest) GetNamespace() string {
+	if x != nil {
+		if x.xxx_hidden_Namespace != nil {
+			return *x.xxx_hidden_Namespace
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RestartAndWaitRequest) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RestartAndWaitRequest) GetTimeoutSeconds() int64 {
+	if x != nil {
+		return x.xxx_hidden_TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *RestartAndWaitRequest) SetCluster(v string) {
+	x.xxx_hidden_Cluster = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 7)
+}
+
+func (x *RestartAndWaitRequest) SetGroup(v string) {
+	x.xxx_hidden_Group = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
+}
+
+func (x *RestartAndWaitRequest) SetVersion(v string) {
+	x.xxx_hidden_Version = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 7)
+}
+
+func (x *RestartAndWaitRequest) SetResource(v string) {
+	x.xxx_hidden_Resource = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 7)
+}
+
+func (x *RestartAndWaitRequest) SetNamespace(v string) {
+	x.xxx_hidden_Namespace = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 7)
+}
+
+func (x *RestartAndWaitRequest) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 7)
+}
+
+func (x *RestartAndWaitRequest) SetTimeoutSeconds(v int64) {
+	x.xxx_hidden_TimeoutSeconds = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 7)
+}
+
+func (x *RestartAndWaitRequest) HasCluster() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RestartAndWaitRequest) HasGroup() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *RestartAndWaitRequest) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *RestartAndWaitRequest) HasResource() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *RestartAndWaitRequest) HasNamespace() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *RestartAndWaitRequest) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *RestartAndWaitRequest) HasTimeoutSeconds() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *RestartAndWaitRequest) ClearCluster() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Cluster = nil
+}
+
+func (x *RestartAndWaitRequest) ClearGroup() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Group = nil
+}
+
+func (x *RestartAndWaitRequest) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Version = nil
+}
+
+func (x *RestartAndWaitRequest) ClearResource() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Resource = nil
+}
+
+func (x *RestartAndWaitRequest) ClearNamespace() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Namespace = nil
+}
+
+func (x *RestartAndWaitRequest) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *RestartAndWaitRequest) ClearTimeoutSeconds() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_TimeoutSeconds = 0
+}
+
+type RestartAndWaitRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The target Kubernetes cluster identifier.
+	Cluster *string
+	// Kubernetes API Group (e.g., "apps").
+	Group *string
+	// Kubernetes API Version (e.g., "v1").
+	Version *string
+	// Kubernetes API Resource name in plural (e.g., "deployments").
+	Resource *string
+	// The namespace of the workload.
+	Namespace *string
+	// The name of the workload.
+	Name *string
+	// How long to wait for the rollout, in seconds, at most 300.
+	TimeoutSeconds *int64
+}
+
+func (b0 RestartAndWaitRequest_builder) Build() *RestartAndWaitRequest {
+	m0 := &RestartAndWaitRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Cluster != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 7)
+		x.xxx_hidden_Cluster = b.Cluster
+	}
+	if b.Group != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 7)
+		x.xxx_hidden_Group = b.Group
+	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 7)
+		x.xxx_hidden_Version = b.Version
+	}
+	if b.Resource != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 7)
+		x.xxx_hidden_Resource = b.Resource
+	}
+	if b.Namespace != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 7)
+		x.xxx_hidden_Namespace = b.Namespace
+	}
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 7)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.TimeoutSeconds != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 7)
+		x.xxx_hidden_TimeoutSeconds = *b.TimeoutSeconds
+	}
+	return m0
+}
+
+// RolloutStatus reports the progress of a workload rollout.
+type RolloutStatus struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Desired     int64                  `protobuf:"varint,1,opt,name=desired"`
+	xxx_hidden_Updated     int64                  `protobuf:"varint,2,opt,name=updated"`
+	xxx_hidden_Ready       int64                  `protobuf:"varint,3,opt,name=ready"`
+	xxx_hidden_Available   int64                  `protobuf:"varint,4,opt,name=available"`
+	xxx_hidden_Done        bool                   `protobuf:"varint,5,opt,name=done"`
+	xxx_hidden_Message     *string                `protobuf:"bytes,6,opt,name=message"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RolloutStatus) Reset() {
+	*x = RolloutStatus{}
+	mi := &file_api_runtime_v1_runtime_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RolloutStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RolloutStatus) ProtoMessage() {}
+
+func (x *RolloutStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_runtime_v1_runtime_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RolloutStatus) GetDesired() int64 {
+	if x != nil {
+		return x.xxx_hidden_Desired
+	}
+	return 0
+}
+
+func (x *RolloutStatus) GetUpdated() int64 {
+	if x != nil {
+		return x.xxx_hidden_Updated
+	}
+	return 0
+}
+
+func (x *RolloutStatus) GetReady() int64 {
+	if x != nil {
+		return x.xxx_hidden_Ready
+	}
+	return 0
+}
+
+func (x *RolloutStatus) GetAvailable() int64 {
+	if x != nil {
+		return x.xxx_hidden_Available
+	}
+	return 0
+}
+
+func (x *RolloutStatus) GetDone() bool {
+	if x != nil {
+		return x.xxx_hidden_Done
+	}
+	return false
+}
+
+func (x *RolloutStatus) GetMessage() string {
+	if x != nil {
+		if x.xxx_hidden_Message != nil {
+			return *x.xxx_hidden_Message
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RolloutStatus) SetDesired(v int64) {
+	x.xxx_hidden_Desired = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 6)
+}
+
+func (x *RolloutStatus) SetUpdated(v int64) {
+	x.xxx_hidden_Updated = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 6)
+}
+
+func (x *RolloutStatus) SetReady(v int64) {
+	x.xxx_hidden_Ready = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 6)
+}
+
+func (x *RolloutStatus) SetAvailable(v int64) {
+	x.xxx_hidden_Available = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 6)
+}
+
+func (x *RolloutStatus) SetDone(v bool) {
+	x.xxx_hidden_Done = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
+}
+
+func (x *RolloutStatus) SetMessage(v string) {
+	x.xxx_hidden_Message = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 6)
+}
+
+func (x *RolloutStatus) HasDesired() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RolloutStatus) HasUpdated() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *RolloutStatus) HasReady() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *RolloutStatus) HasAvailable() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *RolloutStatus) HasDone() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *RolloutStatus) HasMessage() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *RolloutStatus) ClearDesired() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Desired = 0
+}
+
+func (x *RolloutStatus) ClearUpdated() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Updated = 0
+}
+
+func (x *RolloutStatus) ClearReady() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Ready = 0
+}
+
+func (x *RolloutStatus) ClearAvailable() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Available = 0
+}
+
+func (x *RolloutStatus) ClearDone() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Done = false
+}
+
+func (x *RolloutStatus) ClearMessage() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Message = nil
+}
+
+type RolloutStatus_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The number of pods the workload should run.
+	Desired *int64
+	// The number of pods running the current pod template.
+	Updated *int64
+	// The number of ready pods.
+	Ready *int64
+	// The number of pods ready for at least the workload's minReadySeconds.
+	Available *int64
+	// Whether the rollout has completed.
+	Done *bool
+	// A human-readable description of the progress, as printed by
+	// `kubectl rollout status`.
+	Message *string
+}
+
+func (b0 RolloutStatus_builder) Build() *RolloutStatus {
+	m0 := &RolloutStatus{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Desired != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 6)
+		x.xxx_hidden_Desired = *b.Desired
+	}
+	if b.Updated != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 6)
+		x.xxx_hidden_Updated = *b.Updated
+	}
+	if b.Ready != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 6)
+		x.xxx_hidden_Ready = *b.Ready
+	}
+	if b.Available != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 6)
+		x.xxx_hidden_Available = *b.Available
+	}
+	if b.Done != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 6)
+		x.xxx_hidden_Done = *b.Done
+	}
+	if b.Message != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 6)
+		x.xxx_hidden_Message = b.Message
+	}
+	return m0
+}
+
+var File_api_runtime_v1_runtime_proto protoreflect.FileDescriptor
+
+const file_api_runtime_v1_runtime_proto_rawDesc = "" +
+	"\n" +
+	"\x1capi/runtime/v1/runtime.proto\x12\x15otterscale.runtime.v1\x1a\x15api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xed\x02\n" +
+	"\rPodLogRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1c\n" +
+	"\tcontainer\x18\x04 \x01(\tR\tcontainer\x12\x16\n" +
+	"\x06follow\x18\x05 \x01(\bR\x06follow\x12\x1d\n" +
+	"\n" +
+	"tail_lines\x18\x06 \x01(\x03R\ttailLines\x12#\n" +
+	"\rsince_seconds\x18\a \x01(\x03R\fsinceSeconds\x129\n" +
+	"\n" +
+	"since_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tsinceTime\x12\x1a\n" +
+	"\bprevious\x18\t \x01(\bR\bprevious\x12\x1e\n" +
+	"\n" +
+	"timestamps\x18\n" +
+	" \x01(\bR\n" +
+	"timestamps\x12\x1f\n" +
+	"\vlimit_bytes\x18\v \x01(\x03R\n" +
+	"limitBytes\"u\n" +
+	"\x0ePodLogResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12#\n" +
+	"\rdropped_lines\x18\x02 \x01(\x04R\fdroppedLines\x12*\n" +
+	"\x11dropped_from_line\x18\x03 \x01(\x04R\x0fdroppedFromLine\"\xd1\x01\n" +
+	"\x11ExecuteTTYRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1c\n" +
+	"\tcontainer\x18\x04 \x01(\tR\tcontainer\x12\x18\n" +
+	"\acommand\x18\x05 \x03(\tR\acommand\x12\x10\n" +
+	"\x03tty\x18\x06 \x01(\bR\x03tty\x12\x12\n" +
+	"\x04rows\x18\a \x01(\rR\x04rows\x12\x12\n" +
+	"\x04cols\x18\b \x01(\rR\x04cols\"c\n" +
+	"\x12ExecuteTTYResponse\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x16\n" +
+	"\x06stdout\x18\x02 \x01(\fR\x06stdout\x12\x16\n" +
+	"\x06stderr\x18\x03 \x01(\fR\x06stderr\"g\n" +
+	"\x0fWriteTTYRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x14\n" +
+	"\x05stdin\x18\x02 \x01(\fR\x05stdin\x12\x1f\n" +
+	"\vclose_stdin\x18\x03 \x01(\bR\n" +
+	"closeStdin\"Y\n" +
+	"\x10ResizeTTYRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x12\n" +
+	"\x04rows\x18\x02 \x01(\rR\x04rows\x12\x12\n" +
+	"\x04cols\x18\x03 \x01(\rR\x04cols\"\x8a\x01\n" +
+	"\x12PortForwardRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04port\x18\x04 \x01(\x05R\x04port\x12\x14\n" +
+	"\x05ports\x18\x05 \x03(\x05R\x05ports\"\\\n" +
+	"\x13PortForwardResponse\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12\x12\n" +
+	"\x04port\x18\x03 \x01(\x05R\x04port\"`\n" +
+	"\x17WritePortForwardRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12\x12\n" +
+	"\x04port\x18\x03 \x01(\x05R\x04port\"\xc2\x01\n" +
+	"\fScaleRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x1a\n" +
+	"\bresource\x18\x04 \x01(\tR\bresource\x12\x1c\n" +
+	"\tnamespace\x18\x05 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\x12\x1a\n" +
+	"\breplicas\x18\a \x01(\x05R\breplicas\"+\n" +
+	"\rScaleResponse\x12\x1a\n" +
+	"\breplicas\x18\x01 \x01(\x05R\breplicas\"\xa8\x01\n" +
+	"\x0eSuspendRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x1a\n" +
+	"\bresource\x18\x04 \x01(\tR\bresource\x12\x1c\n" +
+	"\tnamespace\x18\x05 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\"@\n" +
+	"\x0fSuspendResponse\x12-\n" +
+	"\x12suspended_replicas\x18\x01 \x01(\x05R\x11suspendedReplicas\"\xa7\x01\n" +
+	"\rResumeRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x1a\n" +
+	"\bresource\x18\x04 \x01(\tR\bresource\x12\x1c\n" +
+	"\tnamespace\x18\x05 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\"\xa8\x01\n" +
+	"\x0eRestartRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x1a\n" +
+	"\bresource\x18\x04 \x01(\tR\bresource\x12\x1c\n" +
+	"\tnamespace\x18\x05 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\"\xd8\x01\n" +
+	"\x15RestartAndWaitRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x1a\n" +
+	"\bresource\x18\x04 \x01(\tR\bresource\x12\x1c\n" +
+	"\tnamespace\x18\x05 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\x12'\n" +
+	"\x0ftimeout_seconds\x18\a \x01(\x03R\x0etimeoutSeconds\"\xa5\x01\n" +
+	"\rRolloutStatus\x12\x18\n" +
+	"\adesired\x18\x01 \x01(\x03R\adesired\x12\x18\n" +
+	"\aupdated\x18\x02 \x01(\x03R\aupdated\x12\x14\n" +
+	"\x05ready\x18\x03 \x01(\x03R\x05ready\x12\x1c\n" +
+	"\tavailable\x18\x04 \x01(\x03R\tavailable\x12\x12\n" +
+	"\x04done\x18\x05 \x01(\bR\x04done\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage2\xe9\n" +
+	"\n" +
 	"\x0eRuntimeService\x12o\n" +
 	"\x06PodLog\x12$.otterscale.runtime.v1.PodLogRequest\x1a%.otterscale.runtime.v1.PodLogResponse\"\x16\x8a\xdf\xd5\x1d\x11\n" +
 	"\x0fruntime-enabled0\x01\x12{\n" +
@@ -2306,11 +3623,19 @@ const file_api_runtime_v1_runtime_proto_rawDesc = "" +
 	"\x10WritePortForward\x12..otterscale.runtime.v1.WritePortForwardRequest\x1a\x16.google.protobuf.Empty\"\x16\x8a\xdf\xd5\x1d\x11\n" +
 	"\x0fruntime-enabled\x12j\n" +
 	"\x05Scale\x12#.otterscale.runtime.v1.ScaleRequest\x1a$.otterscale.runtime.v1.ScaleResponse\"\x16\x8a\xdf\xd5\x1d\x11\n" +
+	"\x0fruntime-enabled\x12p\n" +
+	"\aSuspend\x12%.otterscale.runtime.v1.SuspendRequest\x1a&.otterscale.runtime.v1.SuspendResponse\"\x16\x8a\xdf\xd5\x1d\x11\n" +
+	"\x0fruntime-enabled\x12l\n" +
+	"\x06Resume\x12$.otterscale.runtime.v1.ResumeRequest\x1a$.otterscale.runtime.v1.ScaleResponse\"\x16\x8a\xdf\xd5\x1d\x11\n" +
 	"\x0fruntime-enabled\x12`\n" +
 	"\aRestart\x12%.otterscale.runtime.v1.RestartRequest\x1a\x16.google.protobuf.Empty\"\x16\x8a\xdf\xd5\x1d\x11\n" +
-	"\x0fruntime-enabledB:Z8github.com/otterscale/otterscale-agent/api/runtime/v1;pbb\beditionsp\xe8\a"
+	"\x0fruntime-enabled\x12|\n" +
+	"\x0eRestartAndWait\x12,.otterscale.runtime.v1.RestartAndWaitRequest\x1a$.otterscale.runtime.v1.RolloutStatus\"\x16\x8a\xdf\xd5\x1d\x11\n" +
+	"\x0fruntime-enabled\x12\x7f\n" +
+	"\x0fRestartAndWatch\x12,.otterscale.runtime.v1.RestartAndWaitRequest\x1a$.otterscale.runtime.v1.RolloutStatus\"\x16\x8a\xdf\xd5\x1d\x11\n" +
+	"\x0fruntime-enabled0\x01B:Z8github.com/otterscale/otterscale-agent/api/runtime/v1;pbb\beditionsp\xe8\a"
 
-var file_api_runtime_v1_runtime_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_api_runtime_v1_runtime_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_api_runtime_v1_runtime_proto_goTypes = []any{
 	(*PodLogRequest)(nil),           // 0: otterscale.runtime.v1.PodLogRequest
 	(*PodLogResponse)(nil),          // 1: otterscale.runtime.v1.PodLogResponse
@@ -2323,12 +3648,17 @@ var file_api_runtime_v1_runtime_proto_goTypes = []any{
 	(*WritePortForwardRequest)(nil), // 8: otterscale.runtime.v1.WritePortForwardRequest
 	(*ScaleRequest)(nil),            // 9: otterscale.runtime.v1.ScaleRequest
 	(*ScaleResponse)(nil),           // 10: otterscale.runtime.v1.ScaleResponse
-	(*RestartRequest)(nil),          // 11: otterscale.runtime.v1.RestartRequest
-	(*timestamppb.Timestamp)(nil),   // 12: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),           // 13: google.protobuf.Empty
+	(*SuspendRequest)(nil),          // 11: otterscale.runtime.v1.SuspendRequest
+	(*SuspendResponse)(nil),         // 12: otterscale.runtime.v1.SuspendResponse
+	(*ResumeRequest)(nil),           // 13: otterscale.runtime.v1.ResumeRequest
+	(*RestartRequest)(nil),          // 14: otterscale.runtime.v1.RestartRequest
+	(*RestartAndWaitRequest)(nil),   // 15: otterscale.runtime.v1.RestartAndWaitRequest
+	(*RolloutStatus)(nil),           // 16: otterscale.runtime.v1.RolloutStatus
+	(*timestamppb.Timestamp)(nil),   // 17: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),           // 18: google.protobuf.Empty
 }
 var file_api_runtime_v1_runtime_proto_depIdxs = []int32{
-	12, // 0: otterscale.runtime.v1.PodLogRequest.since_time:type_name -> google.protobuf.Timestamp
+	17, // 0: otterscale.runtime.v1.PodLogRequest.since_time:type_name -> google.protobuf.Timestamp
 	0,  // 1: otterscale.runtime.v1.RuntimeService.PodLog:input_type -> otterscale.runtime.v1.PodLogRequest
 	2,  // 2: otterscale.runtime.v1.RuntimeService.ExecuteTTY:input_type -> otterscale.runtime.v1.ExecuteTTYRequest
 	4,  // 3: otterscale.runtime.v1.RuntimeService.WriteTTY:input_type -> otterscale.runtime.v1.WriteTTYRequest
@@ -2336,17 +3666,25 @@ var file_api_runtime_v1_runtime_proto_depIdxs = []int32{
 	6,  // 5: otterscale.runtime.v1.RuntimeService.PortForward:input_type -> otterscale.runtime.v1.PortForwardRequest
 	8,  // 6: otterscale.runtime.v1.RuntimeService.WritePortForward:input_type -> otterscale.runtime.v1.WritePortForwardRequest
 	9,  // 7: otterscale.runtime.v1.RuntimeService.Scale:input_type -> otterscale.runtime.v1.ScaleRequest
-	11, // 8: otterscale.runtime.v1.RuntimeService.Restart:input_type -> otterscale.runtime.v1.RestartRequest
-	1,  // 9: otterscale.runtime.v1.RuntimeService.PodLog:output_type -> otterscale.runtime.v1.PodLogResponse
-	3,  // 10: otterscale.runtime.v1.RuntimeService.ExecuteTTY:output_type -> otterscale.runtime.v1.ExecuteTTYResponse
-	13, // 11: otterscale.runtime.v1.RuntimeService.WriteTTY:output_type -> google.protobuf.Empty
-	13, // 12: otterscale.runtime.v1.RuntimeService.ResizeTTY:output_type -> google.protobuf.Empty
-	7,  // 13: otterscale.runtime.v1.RuntimeService.PortForward:output_type -> otterscale.runtime.v1.PortForwardResponse
-	13, // 14: otterscale.runtime.v1.RuntimeService.WritePortForward:output_type -> google.protobuf.Empty
-	10, // 15: otterscale.runtime.v1.RuntimeService.Scale:output_type -> otterscale.runtime.v1.ScaleResponse
-	13, // 16: otterscale.runtime.v1.RuntimeService.Restart:output_type -> google.protobuf.Empty
-	9,  // [9:17] is the sub-list for method output_type
-	1,  // [1:9] is the sub-list for method input_type
+	11, // 8: otterscale.runtime.v1.RuntimeService.Suspend:input_type -> otterscale.runtime.v1.SuspendRequest
+	13, // 9: otterscale.runtime.v1.RuntimeService.Resume:input_type -> otterscale.runtime.v1.ResumeRequest
+	14, // 10: otterscale.runtime.v1.RuntimeService.Restart:input_type -> otterscale.runtime.v1.RestartRequest
+	15, // 11: otterscale.runtime.v1.RuntimeService.RestartAndWait:input_type -> otterscale.runtime.v1.RestartAndWaitRequest
+	15, // 12: otterscale.runtime.v1.RuntimeService.RestartAndWatch:input_type -> otterscale.runtime.v1.RestartAndWaitRequest
+	1,  // 13: otterscale.runtime.v1.RuntimeService.PodLog:output_type -> otterscale.runtime.v1.PodLogResponse
+	3,  // 14: otterscale.runtime.v1.RuntimeService.ExecuteTTY:output_type -> otterscale.runtime.v1.ExecuteTTYResponse
+	18, // 15: otterscale.runtime.v1.RuntimeService.WriteTTY:output_type -> google.protobuf.Empty
+	18, // 16: otterscale.runtime.v1.RuntimeService.ResizeTTY:output_type -> google.protobuf.Empty
+	7,  // 17: otterscale.runtime.v1.RuntimeService.PortForward:output_type -> otterscale.runtime.v1.PortForwardResponse
+	18, // 18: otterscale.runtime.v1.RuntimeService.WritePortForward:output_type -> google.protobuf.Empty
+	10, // 19: otterscale.runtime.v1.RuntimeService.Scale:output_type -> otterscale.runtime.v1.ScaleResponse
+	12, // 20: otterscale.runtime.v1.RuntimeService.Suspend:output_type -> otterscale.runtime.v1.SuspendResponse
+	10, // 21: otterscale.runtime.v1.RuntimeService.Resume:output_type -> otterscale.runtime.v1.ScaleResponse
+	18, // 22: otterscale.runtime.v1.RuntimeService.Restart:output_type -> google.protobuf.Empty
+	16, // 23: otterscale.runtime.v1.RuntimeService.RestartAndWait:output_type -> otterscale.runtime.v1.RolloutStatus
+	16, // 24: otterscale.runtime.v1.RuntimeService.RestartAndWatch:output_type -> otterscale.runtime.v1.RolloutStatus
+	13, // [13:25] is the sub-list for method output_type
+	1,  // [1:13] is the sub-list for method input_type
 	1,  // [1:1] is the sub-list for extension type_name
 	1,  // [1:1] is the sub-list for extension extendee
 	0,  // [0:1] is the sub-list for field type_name
@@ -2363,7 +3701,7 @@ func file_api_runtime_v1_runtime_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_runtime_v1_runtime_proto_rawDesc), len(file_api_runtime_v1_runtime_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1,21 +1,29 @@
 // Package main is the entry point for the otterscale binary. It
-// supports two subcommands:
+// supports the following subcommands:
 //
-//   - server: runs the control-plane (gRPC API + tunnel listener)
-//   - agent:  runs inside a Kubernetes cluster and reverse-proxies
+//   - server:   runs the control-plane (gRPC API + tunnel listener)
+//   - agent:    runs inside a Kubernetes cluster and reverse-proxies
 //     API requests through the tunnel
+//   - manifest: renders the agent installation manifest offline
+//   - service-token: issues a token for the server's own automation
+//   - config:   prints the effective configuration and value sources
 //
 // Dependencies are assembled via Google Wire; see wire.go.
 package main
 
 import (
 	"context"
+	"crypto/x509/pkix"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	"github.com/spf13/cobra"
+	clientcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
 
 	"github.com/otterscale/otterscale-agent/internal/cmd"
 	"github.com/otterscale/otterscale-agent/internal/cmd/agent"
@@ -23,6 +31,9 @@ import (
 	"github.com/otterscale/otterscale-agent/internal/config"
 	"github.com/otterscale/otterscale-agent/internal/core"
 	"github.com/otterscale/otterscale-agent/internal/pki"
+	"github.com/otterscale/otterscale-agent/internal/providers/kubernetes"
+	"github.com/otterscale/otterscale-agent/internal/providers/manifest"
+	"github.com/otterscale/otterscale-agent/internal/providers/otterscale"
 )
 
 // version is injected at build time via -ldflags
@@ -54,9 +65,10 @@ func run(ctx context.Context) error {
 }
 
 // newCmd is a Wire provider that constructs the root Cobra command and
-// registers the server and agent subcommands. The version is captured
-// by closures passed to the Wire injectors so that the Injector type
-// signatures remain unchanged.
+// registers the server, agent, manifest, service-token and config
+// subcommands. The
+// version is captured by closures passed to the Wire injectors so that
+// the Injector type signatures remain unchanged.
 func newCmd(conf *config.Config) (*cobra.Command, error) {
 	c := &cobra.Command{
 		Use:           "otterscale",
@@ -76,20 +88,115 @@ func newCmd(conf *config.Config) (*cobra.Command, error) {
 	}
 
 	agentCmd, err := cmd.NewAgentCommand(conf, func() (*agent.Agent, func(), error) {
-		return wireAgent(v)
+		return wireAgent(v, conf)
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	c.AddCommand(serverCmd, agentCmd)
+	manifestCmd := cmd.NewManifestCommand(v, manifest.NewRenderer())
+
+	serviceTokenCmd, err := cmd.NewServiceTokenCommand(conf, func() (*core.ServiceTokens, error) {
+		return loadServiceTokens(conf)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	configCmd, err := cmd.NewConfigCommand(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	c.AddCommand(serverCmd, agentCmd, manifestCmd, serviceTokenCmd, configCmd)
 
 	return c, nil
 }
 
-// provideCA is a thin Wire provider that extracts the CA directory
-// from the config and delegates to pki.ProvideCA for the actual
-// CA loading/generation logic.
+// provideCA is a thin Wire provider that extracts the CA store, CSR
+// key policy and CA subject from the config and delegates to
+// pki.ProvideCA for the actual CA loading/generation logic.
 func provideCA(conf *config.Config) (*pki.CA, error) {
-	return pki.ProvideCA(conf.ServerTunnelCADir())
+	policy, err := pki.NewKeyPolicy(conf.ServerTunnelCSRKeyTypes(), conf.ServerTunnelCSRMinRSABits())
+	if err != nil {
+		return nil, err
+	}
+	store, err := caStoreFor(conf)
+	if err != nil {
+		return nil, err
+	}
+	subject := pkix.Name{
+		Organization:       conf.ServerTunnelCASubjectOrganization(),
+		OrganizationalUnit: conf.ServerTunnelCASubjectOrganizationalUnit(),
+		CommonName:         conf.ServerTunnelCASubjectCommonName(),
+	}
+	return pki.ProvideCA(store, policy, subject)
+}
+
+// provideServiceTokens builds the issuer and verifier of the tokens
+// that authenticate the server's own automation. Like manifest tokens,
+// their HMAC key is derived from the CA so that it survives restarts,
+// but under a separate label so the two can never be exchanged.
+func provideServiceTokens(ca *pki.CA, clock core.Clock) (*core.ServiceTokens, error) {
+	hmacKey, err := ca.DeriveHMACKey("service-token")
+	if err != nil {
+		return nil, fmt.Errorf("derive HMAC key: %w", err)
+	}
+	return core.NewServiceTokens(hmacKey, clock)
+}
+
+// loadServiceTokens builds the server's ServiceTokens outside the
+// server, from the CA in the configured store. Unlike provideCA it
+// never generates a CA: tokens signed with a key the server does not
+// have would be useless.
+func loadServiceTokens(conf *config.Config) (*core.ServiceTokens, error) {
+	store, err := caStoreFor(conf)
+	if err != nil {
+		return nil, err
+	}
+	certPEM, keyPEM, err := store.Load(context.Background())
+	if errors.Is(err, pki.ErrCANotFound) {
+		return nil, fmt.Errorf("no CA in %s; start the server first", store)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load CA: %w", err)
+	}
+	ca, err := pki.LoadCA(certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	return provideServiceTokens(ca, core.NewRealClock())
+}
+
+// caStoreFor maps the configured CA store kind to its pki.CAStore.
+// The kubernetes store reaches the API server of the cluster the
+// server runs in, with the server's service account.
+func caStoreFor(conf *config.Config) (pki.CAStore, error) {
+	switch kind := conf.ServerTunnelCAStore(); kind {
+	case "", "file":
+		return pki.NewFileCAStore(conf.ServerTunnelCADir()), nil
+	case "kubernetes":
+		namespace, name, ok := strings.Cut(conf.ServerTunnelCASecret(), "/")
+		if !ok || namespace == "" || name == "" {
+			return nil, fmt.Errorf("CA secret %q must be of the form namespace/name", conf.ServerTunnelCASecret())
+		}
+		cfg, err := kubernetes.ProvideInClusterConfig()
+		if err != nil {
+			return nil, err
+		}
+		client, err := clientcorev1.NewForConfig(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("create CA secret client: %w", err)
+		}
+		return pki.NewSecretCAStore(client, namespace, name), nil
+	default:
+		return nil, fmt.Errorf("unknown CA store %q", kind)
+	}
+}
+
+// provideAgentID is a thin Wire provider that extracts the agent ID
+// settings from the config and delegates to otterscale.ResolveAgentID,
+// deriving the ID from the cluster that restConfig points at.
+func provideAgentID(conf *config.Config, restConfig *rest.Config) (otterscale.AgentID, error) {
+	return otterscale.ResolveAgentID(conf.AgentID(), conf.AgentIDFile(), otterscale.KubeSystemUID(restConfig))
 }
@@ -28,12 +28,13 @@ func wireCmd() (*cobra.Command, func(), error) {
 // The config parameter provides the CA directory for persistent CA
 // material via provideCA.
 func wireServer(v core.Version, conf *config.Config) (*server.Server, func(), error) {
-	panic(wire.Build(cmd.ProviderSet, handler.ProviderSet, core.ProviderSet, providers.ProviderSet, provideCA, manifest.ProvideAgentManifestConfig))
+	panic(wire.Build(cmd.ProviderSet, handler.ProviderSet, core.ProviderSet, providers.ProviderSet, provideCA, provideServiceTokens, manifest.ProvideAgentManifestConfig))
 }
 
 // wireAgent assembles a fully wired Agent with its handler, fleet
 // registrar, and bootstrapper. The version parameter is provided by
 // the caller and flows through Wire to both FleetRegistrar and Agent.
-func wireAgent(v core.Version) (*agent.Agent, func(), error) {
-	panic(wire.Build(cmd.ProviderSet, providers.ProviderSet, bootstrap.ProviderSet, kubernetes.ProvideInClusterConfig))
+// The config parameter provides the agent ID via provideAgentID.
+func wireAgent(v core.Version, conf *config.Config) (*agent.Agent, func(), error) {
+	panic(wire.Build(cmd.ProviderSet, providers.ProviderSet, bootstrap.ProviderSet, kubernetes.ProvideInClusterConfig, provideAgentID))
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/otterscale/otterscale-agent/internal/providers/kubernetes"
 	"github.com/otterscale/otterscale-agent/internal/providers/manifest"
 	"github.com/otterscale/otterscale-agent/internal/providers/otterscale"
+	"github.com/otterscale/otterscale-agent/internal/providers/webhook"
 	"github.com/spf13/cobra"
 )
 
@@ -47,31 +48,103 @@ func wireServer(v core.Version, conf *config.Config) (*server.Server, func(), er
 	if err != nil {
 		return nil, nil, err
 	}
-	service := chisel.NewService(ca)
+	fleetNotifier, err := webhook.ProvideFleetNotifier(conf)
+	if err != nil {
+		return nil, nil, err
+	}
+	jitter, err := providers.ProvideJitter(conf)
+	if err != nil {
+		return nil, nil, err
+	}
+	chiselConfig, err := providers.ProvideTunnelConfig(conf, jitter)
+	if err != nil {
+		return nil, nil, err
+	}
+	service := chisel.NewService(ca, fleetNotifier, chiselConfig)
 	agentManifestConfig, err := manifest.ProvideAgentManifestConfig(conf, ca)
 	if err != nil {
 		return nil, nil, err
 	}
-	renderer := manifest.NewRenderer()
-	fleetUseCase, err := core.NewFleetUseCase(service, v, agentManifestConfig, renderer)
+	renderer, err := manifest.ProvideRenderer(conf)
 	if err != nil {
 		return nil, nil, err
 	}
-	fleetService := handler.NewFleetService(fleetUseCase)
-	kubernetesKubernetes := kubernetes.New(service)
-	discoveryClient := kubernetes.NewDiscoveryClient(kubernetesKubernetes)
+	clusterAccessPolicy, err := providers.ProvideClusterAuthorizer(conf)
+	if err != nil {
+		return nil, nil, err
+	}
+	clock := core.NewRealClock()
+	fleetUseCase, err := core.NewFleetUseCase(service, v, agentManifestConfig, renderer, clusterAccessPolicy, clock)
+	if err != nil {
+		return nil, nil, err
+	}
+	clusterTimeouts, err := providers.ProvideClusterTimeouts(conf)
+	if err != nil {
+		return nil, nil, err
+	}
+	transportConfig, err := providers.ProvideTransportConfig(conf, clusterTimeouts)
+	if err != nil {
+		return nil, nil, err
+	}
+	kubernetesKubernetes := providers.ProvideKubernetes(service, clusterAccessPolicy, transportConfig)
+	agentDiagnosticsRepo := kubernetes.NewAgentDiagnosticsRepo(kubernetesKubernetes)
+	diagnosticsUseCase := core.NewDiagnosticsUseCase(agentDiagnosticsRepo)
 	resourceRepo := kubernetes.NewResourceRepo(kubernetesKubernetes)
-	discoveryCache := providers.ProvideDiscoveryCache(discoveryClient)
-	resourceUseCase := core.NewResourceUseCase(discoveryClient, resourceRepo, discoveryCache)
-	resourceService := handler.NewResourceService(resourceUseCase)
 	runtimeRepo := kubernetes.NewRuntimeRepo(kubernetesKubernetes)
-	sessionStore := core.NewSessionStore()
-	runtimeUseCase := core.NewRuntimeUseCase(discoveryClient, runtimeRepo, sessionStore)
-	runtimeService := handler.NewRuntimeService(runtimeUseCase)
-	manifestHandler := handler.NewManifestHandler(fleetUseCase)
-	serverHandler := server.NewHandler(fleetService, resourceService, runtimeService, manifestHandler)
-	backgroundListeners := server.ProvideBackgroundListeners(runtimeUseCase, discoveryCache)
-	serverServer := server.NewServer(serverHandler, service, backgroundListeners)
+	supportBundleUseCase, err := core.NewSupportBundleUseCase(agentDiagnosticsRepo, resourceRepo, runtimeRepo, agentManifestConfig, clock)
+	if err != nil {
+		return nil, nil, err
+	}
+	identityRepo := kubernetes.NewIdentityRepo(kubernetesKubernetes)
+	identityUseCase := core.NewIdentityUseCase(identityRepo)
+	maintenanceConfig := providers.ProvideMaintenanceConfig(conf)
+	maintenanceUseCase := core.NewMaintenanceUseCase(maintenanceConfig)
+	fleetService := handler.NewFleetService(fleetUseCase, diagnosticsUseCase, supportBundleUseCase, identityUseCase, maintenanceUseCase)
+	discoveryClient := kubernetes.NewDiscoveryClient(kubernetesKubernetes)
+	cacheConfig := providers.ProvideCacheConfig(conf)
+	discoveryCache, err := providers.ProvideDiscoveryCache(discoveryClient, clusterTimeouts, cacheConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	namespaceCache := providers.ProvideNamespaceCache(resourceRepo)
+	applyConfig, err := providers.ProvideApplyConfig(conf)
+	if err != nil {
+		return nil, nil, err
+	}
+	namespaceConfig := providers.ProvideNamespaceConfig(conf)
+	idempotencyCache := providers.ProvideIdempotencyCache(conf)
+	v2 := providers.ProvideResourceOptions(idempotencyCache)
+	resourceUseCase := core.NewResourceUseCase(discoveryClient, resourceRepo, discoveryCache, discoveryCache, namespaceCache, applyConfig, namespaceConfig, v2...)
+	manifestFetcher := providers.ProvideManifestFetcher()
+	manifestSourceConfig := providers.ProvideManifestSourceConfig(conf)
+	manifestSourceUseCase := core.NewManifestSourceUseCase(resourceUseCase, manifestFetcher, manifestSourceConfig)
+	helmRenderer := providers.ProvideHelmRenderer(conf)
+	helmChartConfig := providers.ProvideHelmChartConfig(conf)
+	helmChartUseCase := core.NewHelmChartUseCase(resourceUseCase, helmRenderer, helmChartConfig)
+	proxyRepo := kubernetes.NewProxyRepo(kubernetesKubernetes)
+	proxyConfig := providers.ProvideProxyConfig(conf)
+	proxyUseCase := core.NewProxyUseCase(proxyRepo, proxyConfig)
+	watchConfig := providers.ProvideWatchConfig(conf)
+	resourceService := handler.NewResourceService(resourceUseCase, manifestSourceUseCase, helmChartUseCase, proxyUseCase, watchConfig)
+	sessionMetrics, err := providers.ProvideSessionMetrics()
+	if err != nil {
+		return nil, nil, err
+	}
+	sessionStore := core.NewSessionStore(clock, sessionMetrics)
+	runtimeUseCase := core.NewRuntimeUseCase(discoveryClient, runtimeRepo, sessionStore, resourceUseCase)
+	podLogConfig := providers.ProvidePodLogConfig(conf)
+	runtimeService := handler.NewRuntimeService(runtimeUseCase, podLogConfig)
+	manifestHandler := handler.NewManifestHandler(fleetUseCase, supportBundleUseCase)
+	readinessConfig := providers.ProvideReadinessConfig(conf)
+	readinessUseCase := core.NewReadinessUseCase(service, readinessConfig)
+	apiProxy := kubernetes.NewAPIProxy(kubernetesKubernetes)
+	serverHandler := server.NewHandler(fleetService, resourceService, runtimeService, manifestHandler, readinessUseCase, maintenanceUseCase, apiProxy)
+	serviceTokens, err := provideServiceTokens(ca, clock)
+	if err != nil {
+		return nil, nil, err
+	}
+	backgroundListeners := server.ProvideBackgroundListeners(runtimeUseCase, discoveryCache, cacheConfig, jitter)
+	serverServer := server.NewServer(serverHandler, service, serviceTokens, backgroundListeners)
 	return serverServer, func() {
 	}, nil
 }
@@ -79,16 +152,18 @@ func wireServer(v core.Version, conf *config.Config) (*server.Server, func(), er
 // wireAgent assembles a fully wired Agent with its handler, fleet
 // registrar, and bootstrapper. The version parameter is provided by
 // the caller and flows through Wire to both FleetRegistrar and Agent.
-func wireAgent(v core.Version) (*agent.Agent, func(), error) {
+// The config parameter provides the agent ID via provideAgentID.
+func wireAgent(v core.Version, conf *config.Config) (*agent.Agent, func(), error) {
 	restConfig, err := kubernetes.ProvideInClusterConfig()
 	if err != nil {
 		return nil, nil, err
 	}
 	agentHandler := agent.NewHandler(restConfig)
-	tunnelConsumer, err := otterscale.NewFleetRegistrar(v)
+	agentID, err := provideAgentID(conf, restConfig)
 	if err != nil {
 		return nil, nil, err
 	}
+	tunnelConsumer := otterscale.NewFleetRegistrar(v, agentID)
 	bootstrapper, err := bootstrap.New(restConfig)
 	if err != nil {
 		return nil, nil, err
@@ -4,28 +4,36 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"time"
 
+	"golang.org/x/sync/errgroup"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/wait"
 	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/restmapper"
 )
 
+// applyConcurrency bounds the number of concurrent Server-Side Apply
+// requests issued for non-CRD resources.
+const applyConcurrency = 8
+
 // applyManifest parses a multi-document YAML byte slice and applies
 // every object to the cluster via Server-Side Apply. CRDs are applied
 // first and the function blocks until each CRD reaches the
-// Established condition, ensuring that subsequent resources whose GVR
-// depends on those CRDs can be resolved.
-func (b *Bootstrapper) applyManifest(ctx context.Context, data []byte) error {
+// Established condition (as configured by crdWait), ensuring that
+// subsequent resources whose GVR depends on those CRDs can be resolved.
+// The remaining resources are applied in dependency order, so that
+// e.g. a Namespace exists before the objects placed in it.
+func (b *Bootstrapper) applyManifest(ctx context.Context, data []byte, crdWait CRDWait) error {
 	objects, err := parseMultiDoc(data)
 	if err != nil {
 		return fmt.Errorf("parse multi-doc YAML: %w", err)
@@ -55,31 +63,147 @@ func (b *Bootstrapper) applyManifest(ctx context.Context, data []byte) error {
 			b.log.Info("applied CRD", "name", crd.GetName())
 		}
 
-		if err := b.waitForCRDs(ctx, crds); err != nil {
+		if err := b.waitForCRDs(ctx, crds, crdWait); err != nil {
 			return err
 		}
 	}
 
 	// Phase 2: Apply remaining resources with a fresh mapper that
-	// knows about the newly established CRDs.
+	// knows about the newly established CRDs. Resources are applied
+	// in dependency tiers (see orderByDependency); objects within a
+	// tier are independent and applied concurrently.
 	if len(rest) > 0 {
 		mapper := b.newMapper()
-		for _, obj := range rest {
-			if err := b.applyObject(ctx, mapper, obj); err != nil {
-				return fmt.Errorf("apply %s %s/%s: %w",
-					obj.GetKind(), obj.GetNamespace(), obj.GetName(), err)
+		var errs []error
+		for _, tier := range orderByDependency(rest) {
+			// Keep going after a failed tier so that every failure is
+			// reported, not just the first.
+			if err := b.applyObjects(ctx, mapper, tier); err != nil {
+				errs = append(errs, err)
 			}
-			b.log.Info("applied resource",
-				"kind", obj.GetKind(),
-				"namespace", obj.GetNamespace(),
-				"name", obj.GetName(),
-			)
+		}
+		if err := errors.Join(errs...); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+// kindPrecedence assigns well-known kinds to dependency tiers:
+// Namespaces first, then the identities, configuration, and RBAC that
+// workloads reference, then everything else. Kinds not listed fall in
+// defaultTier.
+var kindPrecedence = map[string]int{
+	"Namespace": 0,
+
+	"ServiceAccount":        1,
+	"Secret":                1,
+	"ConfigMap":             1,
+	"ClusterRole":           1,
+	"ClusterRoleBinding":    1,
+	"Role":                  1,
+	"RoleBinding":           1,
+	"PersistentVolumeClaim": 1,
+	"Service":               1,
+}
+
+// defaultTier is the tier of kinds not listed in kindPrecedence, which
+// includes workloads and custom resources.
+const defaultTier = 2
+
+// orderByDependency groups objs into tiers that must be applied in
+// order. An object's tier comes from its kind (kindPrecedence), and is
+// raised past the tier of any owner in objs that it references via
+// ownerReferences. Objects keep their document order within a tier.
+func orderByDependency(objs []*unstructured.Unstructured) [][]*unstructured.Unstructured {
+	type objKey struct{ kind, namespace, name string }
+	keyOf := func(obj *unstructured.Unstructured) objKey {
+		return objKey{obj.GetKind(), obj.GetNamespace(), obj.GetName()}
+	}
+
+	tiers := make([]int, len(objs))
+	index := make(map[objKey]int, len(objs))
+	for i, obj := range objs {
+		tier, ok := kindPrecedence[obj.GetKind()]
+		if !ok {
+			tier = defaultTier
+		}
+		tiers[i] = tier
+		index[keyOf(obj)] = i
+	}
+
+	// Relax owner edges until stable. Each pass can only raise tiers,
+	// and an acyclic chain settles within len(objs) passes; the bound
+	// keeps an ownership cycle from looping forever.
+	for range objs {
+		changed := false
+		for i, obj := range objs {
+			for _, ref := range obj.GetOwnerReferences() {
+				// Owners are either cluster-scoped or in the same
+				// namespace as the dependent.
+				owner, ok := index[objKey{ref.Kind, obj.GetNamespace(), ref.Name}]
+				if !ok {
+					owner, ok = index[objKey{ref.Kind, "", ref.Name}]
+				}
+				if ok && owner != i && tiers[i] <= tiers[owner] {
+					tiers[i] = tiers[owner] + 1
+					changed = true
+				}
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	maxTier := 0
+	for _, t := range tiers {
+		maxTier = max(maxTier, t)
+	}
+	grouped := make([][]*unstructured.Unstructured, maxTier+1)
+	for i, obj := range objs {
+		grouped[tiers[i]] = append(grouped[tiers[i]], obj)
+	}
+	return slices.DeleteFunc(grouped, func(tier []*unstructured.Unstructured) bool {
+		return len(tier) == 0
+	})
+}
+
+// applyObjects applies objs concurrently with at most
+// applyConcurrency requests in flight. A failure does not stop the
+// remaining applies; all failures are returned together. Results are
+// logged in document order once every apply has finished, so the log
+// is deterministic regardless of completion order.
+func (b *Bootstrapper) applyObjects(ctx context.Context, mapper meta.RESTMapper, objs []*unstructured.Unstructured) error {
+	errs := make([]error, len(objs))
+
+	var g errgroup.Group
+	g.SetLimit(applyConcurrency)
+	for i, obj := range objs {
+		g.Go(func() error {
+			errs[i] = b.applyObject(ctx, mapper, obj)
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	var failed []error
+	for i, obj := range objs {
+		if errs[i] != nil {
+			failed = append(failed, fmt.Errorf("apply %s %s/%s: %w",
+				obj.GetKind(), obj.GetNamespace(), obj.GetName(), errs[i]))
+			continue
+		}
+		b.log.Info("applied resource",
+			"kind", obj.GetKind(),
+			"namespace", obj.GetNamespace(),
+			"name", obj.GetName(),
+		)
+	}
+	return errors.Join(failed...)
+}
+
 // applyObject performs a Server-Side Apply for a single unstructured
 // object. It uses the REST mapper to resolve the GVK into a GVR and
 // then issues a PATCH with ApplyPatchType.
@@ -117,47 +241,112 @@ func (b *Bootstrapper) applyObject(
 }
 
 // waitForCRDs blocks until every CRD in the slice has the
-// Established condition set to True. It polls with a 2-second
-// interval and gives up after 60 seconds.
-func (b *Bootstrapper) waitForCRDs(ctx context.Context, crds []*unstructured.Unstructured) error {
+// Established condition set to True. Each CRD is polled with an
+// interval that starts at w.PollInterval and doubles up to
+// w.MaxPollInterval, and the wait gives up after w.Timeout. On timeout
+// the error names the condition that was still missing.
+func (b *Bootstrapper) waitForCRDs(ctx context.Context, crds []*unstructured.Unstructured, w CRDWait) error {
 	for _, crd := range crds {
 		name := crd.GetName()
 		b.log.Info("waiting for CRD to be established", "name", name)
 
-		err := wait.PollUntilContextTimeout(ctx, 2*time.Second, 60*time.Second, true,
-			func(ctx context.Context) (bool, error) {
-				obj, err := b.dynamic.Resource(crdGVR).Get(ctx, name, metav1.GetOptions{})
-				if err != nil {
-					return false, nil // retry on transient errors
-				}
-				return isCRDEstablished(obj), nil
-			},
-		)
-		if err != nil {
-			return fmt.Errorf("CRD %s did not become established: %w", name, err)
+		if err := b.waitForCRD(ctx, name, w); err != nil {
+			return err
 		}
 		b.log.Info("CRD established", "name", name)
 	}
 	return nil
 }
 
-// isCRDEstablished inspects the CRD status conditions for
-// type=Established, status=True.
-func isCRDEstablished(obj *unstructured.Unstructured) bool {
-	conditions, found, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
-	if err != nil || !found {
-		return false
+// waitForCRD polls a single CRD until it is established or w.Timeout
+// elapses.
+func (b *Bootstrapper) waitForCRD(ctx context.Context, name string, w CRDWait) error {
+	waitCtx, cancel := context.WithTimeout(ctx, w.Timeout)
+	defer cancel()
+
+	interval := w.PollInterval
+	for {
+		var pending string
+		obj, err := b.dynamic.Resource(crdGVR).Get(waitCtx, name, metav1.GetOptions{})
+		if err != nil {
+			// Retry on transient errors, but remember the last one so
+			// that a timeout can report it.
+			pending = fmt.Sprintf("get failed: %v", err)
+		} else {
+			var established bool
+			established, pending = crdEstablishment(obj)
+			if established {
+				return nil
+			}
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-waitCtx.Done():
+			timer.Stop()
+			if ctx.Err() != nil {
+				return fmt.Errorf("CRD %s did not become established: %w", name, ctx.Err())
+			}
+			return fmt.Errorf("CRD %s did not become established within %s: %s", name, w.Timeout, pending)
+		case <-timer.C:
+		}
+		interval = min(interval*2, w.MaxPollInterval)
+	}
+}
+
+// crdEstablishment inspects the CRD status conditions. It reports
+// whether the CRD is established and, if not, which condition is
+// holding it back. NamesAccepted is checked first because a CRD whose
+// names conflict with another CRD never becomes established.
+func crdEstablishment(obj *unstructured.Unstructured) (bool, string) {
+	conditions, _, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if err != nil {
+		return false, fmt.Sprintf("malformed status conditions: %v", err)
 	}
+
+	byType := make(map[string]map[string]interface{}, len(conditions))
 	for _, c := range conditions {
 		m, ok := c.(map[string]interface{})
 		if !ok {
 			continue
 		}
-		if m["type"] == "Established" && m["status"] == "True" {
-			return true
+		if t, ok := m["type"].(string); ok {
+			byType[t] = m
 		}
 	}
-	return false
+
+	for _, condType := range []string{"NamesAccepted", "Established"} {
+		cond, ok := byType[condType]
+		if !ok {
+			if condType == "NamesAccepted" {
+				// Older API servers may omit NamesAccepted; rely on
+				// Established alone in that case.
+				continue
+			}
+			return false, condType + " condition not reported yet"
+		}
+		if cond["status"] != "True" {
+			return false, describeCondition(condType, cond)
+		}
+	}
+	return true, ""
+}
+
+// describeCondition formats a non-True condition with its reason and
+// message, e.g. "NamesAccepted is False (MultipleNamesNotAllowed: ...)".
+func describeCondition(condType string, cond map[string]interface{}) string {
+	desc := fmt.Sprintf("%s is %v", condType, cond["status"])
+	reason, _ := cond["reason"].(string)
+	message, _ := cond["message"].(string)
+	switch {
+	case reason != "" && message != "":
+		desc += fmt.Sprintf(" (%s: %s)", reason, message)
+	case reason != "":
+		desc += fmt.Sprintf(" (%s)", reason)
+	case message != "":
+		desc += fmt.Sprintf(" (%s)", message)
+	}
+	return desc
 }
 
 // newMapper creates a fresh REST mapper backed by a cached discovery
@@ -0,0 +1,313 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	discoveryfake "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// testCRDWait keeps polling fast so tests finish quickly.
+var testCRDWait = CRDWait{
+	PollInterval:    time.Millisecond,
+	MaxPollInterval: 4 * time.Millisecond,
+	Timeout:         200 * time.Millisecond,
+}
+
+func newTestCRD(name string, conditions ...map[string]interface{}) *unstructured.Unstructured {
+	crd := &unstructured.Unstructured{}
+	crd.SetAPIVersion("apiextensions.k8s.io/v1")
+	crd.SetKind("CustomResourceDefinition")
+	crd.SetName(name)
+	if len(conditions) > 0 {
+		list := make([]interface{}, len(conditions))
+		for i, c := range conditions {
+			list[i] = c
+		}
+		_ = unstructured.SetNestedSlice(crd.Object, list, "status", "conditions")
+	}
+	return crd
+}
+
+func condition(condType, status, reason, message string) map[string]interface{} {
+	return map[string]interface{}{"type": condType, "status": status, "reason": reason, "message": message}
+}
+
+func newTestBootstrapper(objs ...runtime.Object) (*Bootstrapper, *dynamicfake.FakeDynamicClient) {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{crdGVR: "CustomResourceDefinitionList"}, objs...)
+	return &Bootstrapper{
+		dynamic: client,
+		log:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}, client
+}
+
+func TestWaitForCRDs_EstablishesLate(t *testing.T) {
+	name := "modules.otterscale.io"
+	b, client := newTestBootstrapper()
+
+	// The CRD is pending for the first few polls, then established.
+	var polls atomic.Int32
+	client.PrependReactor("get", "customresourcedefinitions", func(k8stesting.Action) (bool, runtime.Object, error) {
+		if polls.Add(1) < 4 {
+			return true, newTestCRD(name, condition("NamesAccepted", "True", "NoConflicts", "")), nil
+		}
+		return true, newTestCRD(name,
+			condition("NamesAccepted", "True", "NoConflicts", ""),
+			condition("Established", "True", "InitialNamesAccepted", ""),
+		), nil
+	})
+
+	if err := b.waitForCRDs(context.Background(), []*unstructured.Unstructured{newTestCRD(name)}, testCRDWait); err != nil {
+		t.Fatalf("waitForCRDs: %v", err)
+	}
+	if got := polls.Load(); got != 4 {
+		t.Errorf("polls = %d, want 4", got)
+	}
+}
+
+func TestWaitForCRDs_TimeoutReportsMissingCondition(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []map[string]interface{}
+		want       string
+	}{
+		{
+			name: "names not accepted",
+			conditions: []map[string]interface{}{
+				condition("NamesAccepted", "False", "MultipleNamesNotAllowed", `"module" is already in use`),
+				condition("Established", "False", "NotAccepted", "not all names are accepted"),
+			},
+			want: `NamesAccepted is False (MultipleNamesNotAllowed: "module" is already in use)`,
+		},
+		{
+			name: "not yet established",
+			conditions: []map[string]interface{}{
+				condition("NamesAccepted", "True", "NoConflicts", ""),
+				condition("Established", "False", "Installing", "the initial names have not been accepted"),
+			},
+			want: "Established is False (Installing: the initial names have not been accepted)",
+		},
+		{
+			name: "no conditions",
+			want: "Established condition not reported yet",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crd := newTestCRD("modules.otterscale.io", tt.conditions...)
+			b, _ := newTestBootstrapper(crd)
+
+			err := b.waitForCRDs(context.Background(), []*unstructured.Unstructured{crd}, testCRDWait)
+			if err == nil {
+				t.Fatal("expected timeout error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %q, want it to contain %q", err, tt.want)
+			}
+			if !strings.Contains(err.Error(), "within 200ms") {
+				t.Errorf("error = %q, want it to mention the timeout", err)
+			}
+		})
+	}
+}
+
+func TestCRDWait_WithDefaults(t *testing.T) {
+	got := CRDWait{PollInterval: 30 * time.Second}.withDefaults()
+	want := CRDWait{PollInterval: 30 * time.Second, MaxPollInterval: 30 * time.Second, Timeout: DefaultCRDWait.Timeout}
+	if got != want {
+		t.Errorf("withDefaults() = %+v, want %+v", got, want)
+	}
+	if got := (CRDWait{}).withDefaults(); got != DefaultCRDWait {
+		t.Errorf("zero CRDWait = %+v, want %+v", got, DefaultCRDWait)
+	}
+}
+
+// newTestDiscovery advertises core/v1 ConfigMaps and Namespaces and
+// apps/v1 Deployments so that the REST mapper can resolve them.
+func newTestDiscovery() *discoveryfake.FakeDiscovery {
+	return &discoveryfake.FakeDiscovery{Fake: &k8stesting.Fake{Resources: []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: metav1.Verbs{"get", "patch"}},
+				{Name: "namespaces", Kind: "Namespace", Namespaced: false, Verbs: metav1.Verbs{"get", "patch"}},
+			},
+		},
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "deployments", Kind: "Deployment", Namespaced: true, Verbs: metav1.Verbs{"get", "patch"}},
+			},
+		},
+	}}}
+}
+
+func newTestConfigMap(name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetNamespace("otterscale-system")
+	obj.SetName(name)
+	return obj
+}
+
+func TestApplyObjects_AppliesAllAndAggregatesErrors(t *testing.T) {
+	b, client := newTestBootstrapper()
+	b.disc = newTestDiscovery()
+
+	failing := map[string]bool{"cm-07": true, "cm-31": true}
+	var (
+		mu       sync.Mutex
+		applied  = map[string]bool{}
+		inFlight atomic.Int32
+		peak     atomic.Int32
+	)
+	client.PrependReactor("patch", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+
+		name := action.(k8stesting.PatchAction).GetName()
+		mu.Lock()
+		applied[name] = true
+		mu.Unlock()
+		if failing[name] {
+			return true, nil, errors.New("admission webhook denied the request")
+		}
+		return true, newTestConfigMap(name), nil
+	})
+
+	var objs []*unstructured.Unstructured
+	for i := range 40 {
+		objs = append(objs, newTestConfigMap(fmt.Sprintf("cm-%02d", i)))
+	}
+
+	err := b.applyObjects(context.Background(), b.newMapper(), objs)
+	if err == nil {
+		t.Fatal("expected aggregated error")
+	}
+	if len(applied) != len(objs) {
+		t.Errorf("applied %d objects, want %d (a failure must not abort the others)", len(applied), len(objs))
+	}
+	for name := range failing {
+		if !strings.Contains(err.Error(), "otterscale-system/"+name) {
+			t.Errorf("error %q does not mention %s", err, name)
+		}
+	}
+	// Errors are reported in document order.
+	if i, j := strings.Index(err.Error(), "cm-07"), strings.Index(err.Error(), "cm-31"); i > j {
+		t.Errorf("errors not in document order: %q", err)
+	}
+	if p := peak.Load(); p > applyConcurrency {
+		t.Errorf("peak concurrency = %d, want at most %d", p, applyConcurrency)
+	}
+}
+
+func TestApplyManifest_NamespaceBeforeNamespacedObjects(t *testing.T) {
+	b, client := newTestBootstrapper()
+	b.disc = newTestDiscovery()
+
+	// The Deployment precedes its Namespace in the document.
+	manifest := []byte(`---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: controller
+  namespace: flux-system
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: flux-system
+`)
+
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+	client.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		resource := action.GetResource().Resource
+		if action.GetNamespace() == "flux-system" && !slices.Contains(order, "namespaces") {
+			return true, nil, fmt.Errorf("namespaces %q not found", "flux-system")
+		}
+		order = append(order, resource)
+		return true, &unstructured.Unstructured{}, nil
+	})
+
+	if err := b.applyManifest(context.Background(), manifest, testCRDWait); err != nil {
+		t.Fatalf("applyManifest: %v", err)
+	}
+	if want := []string{"namespaces", "deployments"}; !slices.Equal(order, want) {
+		t.Errorf("apply order = %v, want %v", order, want)
+	}
+}
+
+func TestOrderByDependency(t *testing.T) {
+	obj := func(kind, name string, owners ...string) *unstructured.Unstructured {
+		o := &unstructured.Unstructured{}
+		o.SetKind(kind)
+		o.SetNamespace("ns")
+		o.SetName(name)
+		if kind == "Namespace" {
+			o.SetNamespace("")
+		}
+		var refs []metav1.OwnerReference
+		for _, owner := range owners {
+			kind, name, _ := strings.Cut(owner, "/")
+			refs = append(refs, metav1.OwnerReference{Kind: kind, Name: name})
+		}
+		o.SetOwnerReferences(refs)
+		return o
+	}
+
+	objs := []*unstructured.Unstructured{
+		obj("Widget", "w", "Gadget/g"),
+		obj("Deployment", "app"),
+		obj("Gadget", "g"),
+		obj("ConfigMap", "cfg", "Deployment/app"),
+		obj("ServiceAccount", "sa"),
+		obj("Namespace", "ns"),
+	}
+
+	var got [][]string
+	for _, tier := range orderByDependency(objs) {
+		var names []string
+		for _, o := range tier {
+			names = append(names, o.GetKind()+"/"+o.GetName())
+		}
+		got = append(got, names)
+	}
+	want := [][]string{
+		{"Namespace/ns"},
+		{"ServiceAccount/sa"},
+		{"Deployment/app", "Gadget/g"},
+		{"Widget/w", "ConfigMap/cfg"},
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("tiers = %v, want %v", got, want)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"log/slog"
 	"sort"
+	"time"
 
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
@@ -27,6 +28,41 @@ import (
 // see which fields are owned by the agent's bootstrap process.
 const fieldManager = "otterscale-agent"
 
+// CRDWait configures how bootstrap waits for applied CRDs to become
+// established before applying resources that depend on them.
+type CRDWait struct {
+	// PollInterval is the delay after the first status poll. It
+	// doubles after each subsequent poll.
+	PollInterval time.Duration
+	// MaxPollInterval caps the growing poll interval.
+	MaxPollInterval time.Duration
+	// Timeout bounds the wait for each CRD.
+	Timeout time.Duration
+}
+
+// DefaultCRDWait is used for any CRDWait field left at zero.
+var DefaultCRDWait = CRDWait{
+	PollInterval:    2 * time.Second,
+	MaxPollInterval: 15 * time.Second,
+	Timeout:         60 * time.Second,
+}
+
+// withDefaults fills zero fields from DefaultCRDWait and ensures the
+// cap is not below the initial interval.
+func (w CRDWait) withDefaults() CRDWait {
+	if w.PollInterval <= 0 {
+		w.PollInterval = DefaultCRDWait.PollInterval
+	}
+	if w.MaxPollInterval <= 0 {
+		w.MaxPollInterval = DefaultCRDWait.MaxPollInterval
+	}
+	if w.Timeout <= 0 {
+		w.Timeout = DefaultCRDWait.Timeout
+	}
+	w.MaxPollInterval = max(w.MaxPollInterval, w.PollInterval)
+	return w
+}
+
 // Bootstrapper applies embedded infrastructure manifests to the local
 // Kubernetes cluster. It is injected into the Agent via Wire and
 // called during agent startup.
@@ -61,9 +97,12 @@ func New(cfg *rest.Config) (*Bootstrapper, error) {
 // Run reads every embedded YAML manifest and applies it to the
 // cluster. Files are processed in lexicographic order so that
 // ordering can be controlled via file-name prefixes if needed.
-// The method is idempotent and safe to call on every agent restart.
-func (b *Bootstrapper) Run(ctx context.Context) error {
+// crdWait controls how long each applied CRD is awaited; zero fields
+// fall back to DefaultCRDWait. The method is idempotent and safe to
+// call on every agent restart.
+func (b *Bootstrapper) Run(ctx context.Context, crdWait CRDWait) error {
 	b.log.Info("starting Layer 0 bootstrap")
+	crdWait = crdWait.withDefaults()
 
 	entries, err := manifests.Bootstrap.ReadDir("bootstrap")
 	if err != nil {
@@ -88,7 +127,7 @@ func (b *Bootstrapper) Run(ctx context.Context) error {
 		}
 
 		b.log.Info("applying manifest", "file", name)
-		if err := b.applyManifest(ctx, data); err != nil {
+		if err := b.applyManifest(ctx, data, crdWait); err != nil {
 			return fmt.Errorf("apply manifest %s: %w", name, err)
 		}
 	}
@@ -2,11 +2,14 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/otterscale/otterscale-agent/internal/bootstrap"
 	"github.com/otterscale/otterscale-agent/internal/cmd/agent"
 	"github.com/otterscale/otterscale-agent/internal/config"
+	"github.com/otterscale/otterscale-agent/internal/core"
 )
 
 // AgentInjector is a Wire-generated factory that creates a fully
@@ -30,10 +33,30 @@ func NewAgentCommand(conf *config.Config, newAgent AgentInjector) (*cobra.Comman
 			defer cleanup()
 
 			cfg := agent.Config{
-				Cluster:         conf.AgentCluster(),
-				ServerURL:       conf.AgentServerURL(),
-				TunnelServerURL: conf.AgentTunnelServerURL(),
-				Bootstrap:       conf.AgentBootstrap(),
+				Cluster:                  conf.AgentCluster(),
+				ServerURL:                conf.AgentServerURL(),
+				TunnelServerURL:          conf.AgentTunnelServerURL(),
+				TunnelFallbackServerURLs: conf.AgentTunnelFallbackServerURLs(),
+				Bootstrap:                conf.AgentBootstrap(),
+
+				BootstrapCRDWait: bootstrap.CRDWait{
+					PollInterval:    conf.BootstrapCRDPollInterval(),
+					MaxPollInterval: conf.BootstrapCRDMaxPollInterval(),
+					Timeout:         conf.BootstrapCRDTimeout(),
+				},
+
+				TunnelFingerprint: conf.AgentTunnelFingerprint(),
+
+				RegisterMaxAttempts: conf.AgentRegisterMaxAttempts(),
+
+				ProxyAllowHeaders:       conf.AgentProxyAllowHeaders(),
+				ProxyStripHeaders:       conf.AgentProxyStripHeaders(),
+				ProxyCAFile:             conf.AgentProxyCAFile(),
+				ProxyInsecureSkipVerify: conf.AgentProxyInsecureSkipVerify(),
+
+				HealthAddress: conf.AgentHealthAddress(),
+
+				Settings: agentSettings(conf),
 			}
 
 			return agt.Run(cmd.Context(), cfg)
@@ -46,3 +69,18 @@ func NewAgentCommand(conf *config.Config, newAgent AgentInjector) (*cobra.Comman
 
 	return cmd, nil
 }
+
+// agentSettings returns the agent's effective configuration, redacted
+// as in the config command's output, for its diagnostics report.
+func agentSettings(conf *config.Config) []core.AgentSetting {
+	effective := conf.Effective(config.AgentOptions)
+	settings := make([]core.AgentSetting, 0, len(effective))
+	for _, s := range effective {
+		value := fmt.Sprint(s.Value)
+		if list, ok := s.Value.([]string); ok {
+			value = strings.Join(list, ",")
+		}
+		settings = append(settings, core.AgentSetting{Key: s.Key, Value: value, Source: string(s.Source)})
+	}
+	return settings
+}
@@ -25,6 +25,57 @@ type Config struct {
 	ServerURL       string
 	TunnelServerURL string
 	Bootstrap       bool
+
+	// TunnelFallbackServerURLs are tried in order after
+	// TunnelServerURL when the agent cannot connect to it, for
+	// servers reachable through several networks.
+	TunnelFallbackServerURLs []string
+
+	// BootstrapCRDWait controls how long bootstrap waits for CRDs to
+	// become established. Zero fields use bootstrap.DefaultCRDWait.
+	BootstrapCRDWait bootstrap.CRDWait
+
+	// TunnelFingerprint statically pins the tunnel server's SSH
+	// fingerprint. When empty, the fingerprint returned at
+	// registration is used, so a server key change is recovered by
+	// re-registering.
+	TunnelFingerprint string
+
+	// RegisterMaxAttempts is the number of consecutive failed
+	// registrations after which Run returns an error, so that the
+	// agent exits instead of retrying silently. Zero retries forever.
+	RegisterMaxAttempts int
+
+	// ProxyAllowHeaders, when set, turns the proxy's header filter
+	// into an allow list: only these response headers and the ones
+	// clients need to read a response (Content-Type, Warning, ...)
+	// leave the cluster. When empty, every header is forwarded except
+	// hop-by-hop headers and ProxyStripHeaders.
+	ProxyAllowHeaders []string
+
+	// ProxyStripHeaders lists response headers removed from proxied
+	// kube-apiserver responses, in addition to hop-by-hop headers.
+	ProxyStripHeaders []string
+
+	// ProxyCAFile is a PEM bundle with which the proxy verifies the
+	// kube-apiserver's serving certificate instead of the in-cluster
+	// CA, for clusters whose API server uses a self-signed CA.
+	ProxyCAFile string
+
+	// ProxyInsecureSkipVerify disables verification of the
+	// kube-apiserver's serving certificate by the proxy. It is meant
+	// for development clusters only and cannot be combined with
+	// ProxyCAFile.
+	ProxyInsecureSkipVerify bool
+
+	// HealthAddress is the listen address for the /healthz and
+	// /metrics endpoint. It is served on its own port, outside the
+	// tunnel. An empty value disables the endpoint.
+	HealthAddress string
+
+	// Settings is the agent's effective configuration, with secrets
+	// already redacted, reported in its diagnostics.
+	Settings []core.AgentSetting
 }
 
 // SelfUpdater abstracts the self-update mechanism so it can be
@@ -58,7 +109,7 @@ func NewAgent(cfg *rest.Config, handler *Handler, tunnel core.TunnelConsumer, ve
 // then blocks until ctx is cancelled.
 func (a *Agent) Run(ctx context.Context, cfg Config) error {
 	if cfg.Bootstrap {
-		if err := a.bootstrapper.Run(ctx); err != nil {
+		if err := a.bootstrapper.Run(ctx, cfg.BootstrapCRDWait); err != nil {
 			return fmt.Errorf("bootstrap: %w", err)
 		}
 	}
@@ -70,28 +121,58 @@ func (a *Agent) Run(ctx context.Context, cfg Config) error {
 		return fmt.Errorf("failed to create tunnel bridge: %w", err)
 	}
 
-	httpSrv, err := http.NewServer(
-		http.WithListener(pl),
-		http.WithMount(a.handler.Mount),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create HTTP server: %w", err)
-	}
-
 	tunnelClt, err := tunnel.NewClient(
 		tunnel.WithServerURL(cfg.ServerURL),
 		tunnel.WithTunnelServerURL(cfg.TunnelServerURL),
+		tunnel.WithFallbackTunnelServerURLs(cfg.TunnelFallbackServerURLs...),
+		tunnel.WithFingerprint(cfg.TunnelFingerprint),
 		tunnel.WithCluster(cfg.Cluster),
 		tunnel.WithLocalPort(bridge.Port()),
 		tunnel.WithKeepAlive(30*time.Second),
 		tunnel.WithMaxRetryCount(6),
 		tunnel.WithMaxRetryInterval(10*time.Second),
+		tunnel.WithMaxRegisterAttempts(cfg.RegisterMaxAttempts),
 		tunnel.WithRegister(a.register()),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create tunnel client: %w", err)
 	}
-	return transport.Serve(ctx, httpSrv, bridge, tunnelClt)
+
+	probe, err := newAPIServerProbe(a.cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create API server probe: %w", err)
+	}
+	diag := &diagnostics{
+		version:     a.version,
+		settings:    cfg.Settings,
+		status:      tunnelClt,
+		proxy:       bridge,
+		proxyErrors: &a.handler.proxyErrors,
+		probe:       probe,
+	}
+
+	httpSrv, err := http.NewServer(
+		http.WithListener(pl),
+		http.WithMount(a.handler.Mount(cfg, diag)),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP server: %w", err)
+	}
+
+	listeners := []transport.Listener{httpSrv, bridge, tunnelClt}
+
+	if cfg.HealthAddress != "" {
+		healthSrv, err := http.NewServer(
+			http.WithAddress(cfg.HealthAddress),
+			http.WithMount(healthMount(tunnelClt, bridge)),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create health server: %w", err)
+		}
+		listeners = append(listeners, healthSrv)
+	}
+
+	return transport.Serve(ctx, listeners...)
 }
 
 // register wraps the TunnelConsumer so that it returns a
@@ -118,11 +199,13 @@ func (a *Agent) register() tunnel.RegisterFunc {
 		}
 
 		return &tunnel.RegisterResult{
-			Endpoint:  reg.Endpoint,
-			Auth:      auth,
-			CACertPEM: reg.CACertificate,
-			CertPEM:   reg.Certificate,
-			KeyPEM:    reg.PrivateKeyPEM,
+			AgentID:     reg.AgentID,
+			Endpoint:    reg.Endpoint,
+			Auth:        auth,
+			CACertPEM:   reg.CACertificate,
+			CertPEM:     reg.Certificate,
+			KeyPEM:      reg.PrivateKeyPEM,
+			Fingerprint: reg.TunnelFingerprint,
 		}, nil
 	}
 }
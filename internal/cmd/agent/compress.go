@@ -0,0 +1,150 @@
+package agent
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// minCompressSize is the smallest response with a known length that is
+// worth compressing. Below it the gzip header and trailer outweigh the
+// savings. Streamed responses have no known length and are always
+// compressed when requested.
+const minCompressSize = 1024
+
+// compressBufferSize bounds how much upstream data is read before it is
+// compressed and flushed.
+const compressBufferSize = 32 * 1024
+
+// streamCompressionTransport wraps an http.RoundTripper and gzips
+// response bodies when the server asked for it via
+// core.StreamEncodingHeader. Every chunk read from upstream is flushed
+// as soon as it is compressed, so small log lines are not held back
+// waiting for a full deflate block.
+type streamCompressionTransport struct {
+	base http.RoundTripper
+}
+
+func newStreamCompressionTransport(base http.RoundTripper) *streamCompressionTransport {
+	return &streamCompressionTransport{base: base}
+}
+
+func (t *streamCompressionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	encoding := req.Header.Get(core.StreamEncodingHeader)
+	if encoding != "" {
+		// The negotiation header is meant for the agent only.
+		req = req.Clone(req.Context())
+		req.Header.Del(core.StreamEncodingHeader)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	// An upgraded connection carries a raw byte stream in both
+	// directions, which must not be wrapped in gzip.
+	if encoding != "gzip" || httpstream.IsUpgradeRequest(req) || !acceptsGzip(req) || !compressible(resp) {
+		return resp, nil
+	}
+
+	resp.Body = newGzipStreamBody(resp.Body)
+	resp.Header.Set("Content-Encoding", "gzip")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	return resp, nil
+}
+
+// WrappedRoundTripper returns the transport t wraps, so that helpers
+// such as utilnet.TLSClientConfig can see through it.
+func (t *streamCompressionTransport) WrappedRoundTripper() http.RoundTripper {
+	return t.base
+}
+
+// CloseIdleConnections closes the idle connections of the wrapped
+// transport.
+func (t *streamCompressionTransport) CloseIdleConnections() {
+	utilnet.CloseIdleConnectionsFor(t.base)
+}
+
+// acceptsGzip reports whether req lists gzip in Accept-Encoding.
+func acceptsGzip(req *http.Request) bool {
+	for _, v := range req.Header.Values("Accept-Encoding") {
+		for enc := range strings.SplitSeq(v, ",") {
+			enc, _, _ = strings.Cut(enc, ";")
+			if strings.EqualFold(strings.TrimSpace(enc), "gzip") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// compressible reports whether resp is a successful, not yet encoded
+// response large enough (or open-ended enough) to be worth gzipping.
+func compressible(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Encoding") != "" {
+		return false
+	}
+	return resp.ContentLength < 0 || resp.ContentLength >= minCompressSize
+}
+
+// gzipStreamBody compresses an upstream body on the fly. A goroutine
+// reads upstream, writes each chunk to a gzip.Writer and flushes it
+// into a pipe that the reader side consumes.
+type gzipStreamBody struct {
+	pr       *io.PipeReader
+	upstream io.ReadCloser
+}
+
+func newGzipStreamBody(upstream io.ReadCloser) *gzipStreamBody {
+	pr, pw := io.Pipe()
+	go compressStream(pw, upstream)
+	return &gzipStreamBody{pr: pr, upstream: upstream}
+}
+
+func (b *gzipStreamBody) Read(p []byte) (int, error) {
+	return b.pr.Read(p)
+}
+
+// Close closes both the upstream body and the pipe, which unblocks the
+// compressing goroutine.
+func (b *gzipStreamBody) Close() error {
+	err := b.upstream.Close()
+	b.pr.Close()
+	return err
+}
+
+// compressStream copies upstream into pw through gzip, flushing after
+// every read. It closes pw with the upstream error, if any, so the
+// reader observes a truncated stream rather than a clean EOF.
+func compressStream(pw *io.PipeWriter, upstream io.Reader) {
+	gz := gzip.NewWriter(pw)
+	buf := make([]byte, compressBufferSize)
+	for {
+		n, err := upstream.Read(buf)
+		if n > 0 {
+			if _, werr := gz.Write(buf[:n]); werr != nil {
+				pw.CloseWithError(werr)
+				return
+			}
+			if werr := gz.Flush(); werr != nil {
+				pw.CloseWithError(werr)
+				return
+			}
+		}
+		if err == io.EOF {
+			pw.CloseWithError(gz.Close())
+			return
+		}
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+	}
+}
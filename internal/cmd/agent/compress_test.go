@@ -0,0 +1,182 @@
+package agent
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/rest"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+func newTestLogProxy(t *testing.T, log []byte) (*httptest.Server, *http.Header) {
+	t.Helper()
+	var upstreamHeader http.Header
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstreamHeader = r.Header.Clone()
+		w.Header().Set("Content-Type", "text/plain")
+		// Stream in chunks like a followed log.
+		for chunk := range slices.Chunk(log, 4096) {
+			_, _ = w.Write(chunk)
+			w.(http.Flusher).Flush()
+		}
+	}))
+	t.Cleanup(upstream.Close)
+
+	proxy, err := newKubeAPIProxy(&rest.Config{Host: upstream.URL}, nil, nil, new(atomic.Uint64))
+	if err != nil {
+		t.Fatalf("newKubeAPIProxy: %v", err)
+	}
+	srv := httptest.NewServer(proxy)
+	t.Cleanup(srv.Close)
+	return srv, &upstreamHeader
+}
+
+// getRaw issues a log request without transparent decompression and
+// returns the response and its body exactly as sent on the wire.
+func getRaw(t *testing.T, url string, compress bool) (*http.Response, []byte) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url+"/api/v1/namespaces/default/pods/p/log", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	req.Header.Set("Accept-Encoding", "gzip")
+	if compress {
+		req.Header.Set(core.StreamEncodingHeader, "gzip")
+	}
+
+	client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	return resp, body
+}
+
+func TestStreamCompression_CompressibleLogIsSmallerOnWire(t *testing.T) {
+	log := []byte(strings.Repeat("2026-01-02T03:04:05Z INFO reconciled object namespace=default\n", 2000))
+	srv, upstreamHeader := newTestLogProxy(t, log)
+
+	resp, plain := getRaw(t, srv.URL, false)
+	if resp.Header.Get("Content-Encoding") != "" {
+		t.Fatalf("uncompressed request got Content-Encoding %q", resp.Header.Get("Content-Encoding"))
+	}
+	if !bytes.Equal(plain, log) {
+		t.Fatalf("uncompressed body differs from upstream log")
+	}
+
+	resp, wire := getRaw(t, srv.URL, true)
+	if got := resp.Header.Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("Content-Encoding = %q, want gzip", got)
+	}
+	if len(wire) >= len(plain)/10 {
+		t.Errorf("compressed size = %d, want well below %d", len(wire), len(plain))
+	}
+	if upstreamHeader.Get(core.StreamEncodingHeader) != "" {
+		t.Error("negotiation header leaked to the API server")
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(wire))
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+	decoded, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("decompress: %v", err)
+	}
+	if !bytes.Equal(decoded, log) {
+		t.Error("decompressed body differs from upstream log")
+	}
+}
+
+func TestStreamCompression_BinarySafeWithTransparentDecoding(t *testing.T) {
+	log := make([]byte, 64*1024)
+	for i := range log {
+		log[i] = byte(i * 31)
+	}
+	srv, _ := newTestLogProxy(t, log)
+
+	// The server's per-cluster transport relies on net/http to add
+	// Accept-Encoding and decompress the response.
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/api/v1/namespaces/default/pods/p/log", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	req.Header.Set(core.StreamEncodingHeader, "gzip")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if !resp.Uncompressed {
+		t.Error("response was not transparently decompressed")
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	if !bytes.Equal(body, log) {
+		t.Error("decoded body differs from upstream bytes")
+	}
+}
+
+func TestStreamCompression_SkipsUpgradeRequests(t *testing.T) {
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode:    http.StatusOK,
+			Header:        http.Header{},
+			Body:          io.NopCloser(strings.NewReader("raw stream")),
+			ContentLength: -1,
+		}, nil
+	})
+	req := httptest.NewRequest(http.MethodGet, "https://kube-apiserver.test/api/v1/namespaces/default/pods/p/exec", nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "SPDY/3.1")
+	req.Header.Set("Accept-Encoding", "gzip")
+	req.Header.Set(core.StreamEncodingHeader, "gzip")
+
+	resp, err := newStreamCompressionTransport(base).RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	defer resp.Body.Close()
+	if got := resp.Header.Get("Content-Encoding"); got != "" {
+		t.Errorf("Content-Encoding = %q, want none for an upgrade", got)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "raw stream" {
+		t.Errorf("body = %q, want the raw stream", body)
+	}
+}
+
+func TestStreamCompression_UnwrapsToTLSConfig(t *testing.T) {
+	cfg := &rest.Config{Host: "https://kube-apiserver.test", TLSClientConfig: rest.TLSClientConfig{ServerName: "kubernetes.default"}}
+	transport, err := rest.TransportFor(cfg)
+	if err != nil {
+		t.Fatalf("TransportFor: %v", err)
+	}
+
+	tlsConfig, err := utilnet.TLSClientConfig(newStreamCompressionTransport(newHeaderStrippingTransport(transport, nil, nil)))
+	if err != nil || tlsConfig == nil {
+		t.Fatalf("TLS config = %v, %v, want the rest config's", tlsConfig, err)
+	}
+	if tlsConfig.ServerName != "kubernetes.default" {
+		t.Errorf("ServerName = %q, want kubernetes.default", tlsConfig.ServerName)
+	}
+}
@@ -0,0 +1,96 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// apiServerProbeTimeout bounds the API server reachability probe of a
+// diagnostics report.
+const apiServerProbeTimeout = 5 * time.Second
+
+// apiServerProbe returns the git version of the API server.
+type apiServerProbe func(ctx context.Context) (string, error)
+
+// newAPIServerProbe returns an apiServerProbe that requests /version
+// from the API server described by cfg.
+func newAPIServerProbe(cfg *rest.Config) (apiServerProbe, error) {
+	client, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context) (string, error) {
+		body, err := client.RESTClient().Get().AbsPath("/version").Do(ctx).Raw()
+		if err != nil {
+			return "", err
+		}
+		var info version.Info
+		if err := json.Unmarshal(body, &info); err != nil {
+			return "", err
+		}
+		return info.GitVersion, nil
+	}, nil
+}
+
+// diagnostics assembles the agent's diagnostics report from the
+// tunnel, the proxy and a live probe of the API server.
+type diagnostics struct {
+	version     core.Version
+	settings    []core.AgentSetting
+	status      tunnelStatus
+	proxy       proxyCounter
+	proxyErrors *atomic.Uint64
+	probe       apiServerProbe
+}
+
+// collect builds a report.
+func (d *diagnostics) collect(ctx context.Context) core.AgentDiagnostics {
+	s := d.status.Status()
+	report := core.AgentDiagnostics{
+		AgentVersion: string(d.version),
+		CollectedAt:  time.Now().UTC(),
+		Config:       d.settings,
+		Tunnel: core.AgentTunnelStatus{
+			Connected:        s.Connected,
+			TunnelServerURL:  s.TunnelServerURL,
+			Endpoint:         s.Endpoint,
+			LastRegistration: s.LastRegistration,
+			Reconnects:       s.Reconnects,
+		},
+		Proxy: core.AgentProxyStats{
+			Errors:       d.proxyErrors.Load(),
+			BytesProxied: d.proxy.BytesProxied(),
+		},
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, apiServerProbeTimeout)
+	defer cancel()
+	start := time.Now()
+	v, err := d.probe(probeCtx)
+	report.APIServer.Latency = time.Since(start)
+	if err != nil {
+		report.APIServer.Error = err.Error()
+	} else {
+		report.APIServer.Reachable = true
+		report.APIServer.Version = v
+	}
+	return report
+}
+
+// ServeHTTP writes the report as JSON.
+func (d *diagnostics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(d.collect(r.Context())); err != nil {
+		slog.Warn("failed to write diagnostics response", "error", err)
+	}
+}
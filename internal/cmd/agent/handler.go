@@ -1,19 +1,67 @@
 package agent
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/textproto"
 	"net/url"
+	"strings"
+	"sync/atomic"
 
+	utilnet "k8s.io/apimachinery/pkg/util/net"
 	utilproxy "k8s.io/apimachinery/pkg/util/proxy"
 	"k8s.io/client-go/rest"
+	clienttransport "k8s.io/client-go/transport"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
 )
 
-// Handler sets up the HTTP routes served by the agent. Its sole route
-// is a reverse proxy to the local Kubernetes API server.
+// hopByHopHeaders are the response headers defined by RFC 9110 §7.6.1
+// as connection-specific. They are meaningful only for a single
+// transport-level hop and must not be forwarded through the tunnel.
+var hopByHopHeaders = []string{
+	"Connection",
+	"Proxy-Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Trailer",
+	"Upgrade",
+}
+
+// essentialHeaders are the response headers that clients need to read
+// API server responses. They are always forwarded when an allow list
+// is configured.
+var essentialHeaders = []string{
+	"Cache-Control",
+	"Content-Encoding",
+	"Content-Length",
+	"Content-Type",
+	"Date",
+	"Location",
+	"Retry-After",
+	"Vary",
+	"Warning",
+	"Www-Authenticate",
+	"X-Content-Type-Options",
+}
+
+// protectedHeaders are never stripped, even when configured, because
+// clients rely on them to decode the (possibly streaming) body.
+var protectedHeaders = map[string]struct{}{
+	"Content-Type":   {},
+	"Content-Length": {},
+}
+
+// Handler sets up the HTTP routes served by the agent: a reverse proxy
+// to the local Kubernetes API server and the diagnostics report.
 type Handler struct {
 	cfg *rest.Config
+
+	// proxyErrors counts requests the proxy could not forward.
+	proxyErrors atomic.Uint64
 }
 
 // NewHandler returns a new agent Handler.
@@ -21,32 +69,215 @@ func NewHandler(cfg *rest.Config) *Handler {
 	return &Handler{cfg: cfg}
 }
 
-// Mount registers a catch-all reverse proxy to the Kubernetes API
-// server on the given mux. The proxy uses the in-cluster service
-// account credentials (or falls back to KUBECONFIG) and rewrites
-// the Host header so that the upstream kube-apiserver recognises
-// the request.
-func (h *Handler) Mount(mux *http.ServeMux) error {
-	targetURL, err := url.Parse(h.cfg.Host)
+// Mount returns a function that registers a catch-all reverse proxy
+// to the Kubernetes API server on the given mux. The proxy uses the
+// in-cluster service account credentials (or falls back to
+// KUBECONFIG) and rewrites the Host header so that the upstream
+// kube-apiserver recognises the request. Response headers are
+// sanitised according to cfg before they leave the cluster. A non-nil
+// diag serves the diagnostics report at core.AgentDiagnosticsPath.
+func (h *Handler) Mount(cfg Config, diag http.Handler) func(mux *http.ServeMux) error {
+	return func(mux *http.ServeMux) error {
+		restCfg, err := proxyTLSConfig(h.cfg, cfg.ProxyCAFile, cfg.ProxyInsecureSkipVerify)
+		if err != nil {
+			return err
+		}
+		proxy, err := newKubeAPIProxy(restCfg, cfg.ProxyAllowHeaders, cfg.ProxyStripHeaders, &h.proxyErrors)
+		if err != nil {
+			return err
+		}
+		mux.Handle("/", proxy)
+		if diag != nil {
+			mux.Handle("GET "+core.AgentDiagnosticsPath, diag)
+		}
+		return nil
+	}
+}
+
+// proxyTLSConfig returns cfg with the proxy's TLS overrides applied:
+// caFile replaces the CA with which the kube-apiserver is verified, and
+// insecure disables verification altogether. cfg is returned as is
+// when neither is set.
+func proxyTLSConfig(cfg *rest.Config, caFile string, insecure bool) (*rest.Config, error) {
+	switch {
+	case insecure && caFile != "":
+		return nil, errors.New("proxy CA file cannot be combined with insecure TLS verification")
+	case insecure:
+		slog.Warn("proxy does not verify the kube-apiserver certificate; use this only on development clusters", "host", cfg.Host, "insecure_skip_verify", true)
+		cfg = rest.CopyConfig(cfg)
+		cfg.Insecure = true
+		cfg.CAFile = ""
+		cfg.CAData = nil
+	case caFile != "":
+		cfg = rest.CopyConfig(cfg)
+		cfg.CAFile = caFile
+		cfg.CAData = nil
+	}
+	return cfg, nil
+}
+
+// newKubeAPIProxy builds an upgrade-aware reverse proxy to the
+// kube-apiserver described by cfg. Every non-upgrade response passes
+// through a headerStrippingTransport that removes hop-by-hop headers
+// and stripHeaders and, when allowHeaders is set, every header other
+// than those and essentialHeaders. The body is left as is unless the
+// server negotiated stream compression, in which case it is gzipped
+// chunk by chunk, so watch and log streams keep flowing unbuffered.
+// Requests that cannot be forwarded are counted in errs.
+func newKubeAPIProxy(cfg *rest.Config, allowHeaders, stripHeaders []string, errs *atomic.Uint64) (http.Handler, error) {
+	targetURL, err := url.Parse(cfg.Host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse k8s host URL: %w", err)
+	}
+
+	transport, err := rest.TransportFor(cfg)
 	if err != nil {
-		return fmt.Errorf("failed to parse k8s host URL: %w", err)
+		return nil, fmt.Errorf("failed to create rest transport: %w", err)
 	}
 
-	transport, err := rest.TransportFor(h.cfg)
+	upgradeTransport, err := newUpgradeTransport(cfg)
 	if err != nil {
-		return fmt.Errorf("failed to create rest transport: %w", err)
+		return nil, err
+	}
+
+	transport = newHeaderStrippingTransport(transport, allowHeaders, stripHeaders)
+	transport = newStreamCompressionTransport(transport)
+
+	// An empty location path makes the handler answer every GET with
+	// a redirect to a trailing-slash URL instead of proxying it.
+	if targetURL.Path == "" {
+		targetURL.Path = "/"
 	}
 
-	proxy := utilproxy.NewUpgradeAwareHandler(targetURL, transport, false, false, &errorResponder{})
-	mux.Handle("/", proxy)
-	return nil
+	proxy := utilproxy.NewUpgradeAwareHandler(targetURL, transport, false, false, &errorResponder{errs: errs})
+	proxy.UpgradeTransport = upgradeTransport
+	// Forward the request's own path and query beneath the API
+	// server's path (the handler otherwise sends every request to
+	// targetURL itself) and present the API server's host.
+	proxy.UseRequestLocation = true
+	proxy.AppendLocationPath = true
+	proxy.UseLocationHost = true
+	return proxy, nil
+}
+
+// newUpgradeTransport returns the transport for exec, attach and
+// port-forward upgrades. The upgrade handler writes these requests
+// straight to a connection it dials itself, bypassing RoundTrip, so
+// the credentials of cfg are added by its request wrappers and the
+// connection is dialed with cfg's TLS settings, as `kubectl proxy`
+// does.
+func newUpgradeTransport(cfg *rest.Config) (utilproxy.UpgradeRequestRoundTripper, error) {
+	transportConfig, err := cfg.TransportConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build upgrade transport config: %w", err)
+	}
+	tlsConfig, err := clienttransport.TLSConfigFor(transportConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build upgrade TLS config: %w", err)
+	}
+	rt := utilnet.SetOldTransportDefaults(&http.Transport{TLSClientConfig: tlsConfig})
+	upgrader, err := clienttransport.HTTPWrappersForConfig(transportConfig, utilproxy.MirrorRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build upgrade request wrappers: %w", err)
+	}
+	return utilproxy.NewUpgradeRequestRoundTripper(rt, upgrader), nil
+}
+
+// headerStrippingTransport wraps an http.RoundTripper and removes
+// hop-by-hop, stripped and, with an allow list, unlisted headers from
+// every response.
+type headerStrippingTransport struct {
+	base  http.RoundTripper
+	allow map[string]struct{} // canonical header names; nil allows all
+	strip map[string]struct{} // canonical header names
+}
+
+// newHeaderStrippingTransport returns base wrapped so that hop-by-hop
+// headers and the headers in strip are removed from responses. If
+// allow is non-empty, responses also keep only the headers in allow
+// and essentialHeaders. Protected headers such as Content-Type are
+// skipped from strip with a warning.
+func newHeaderStrippingTransport(base http.RoundTripper, allow, strip []string) *headerStrippingTransport {
+	t := &headerStrippingTransport{base: base, strip: map[string]struct{}{}}
+	for _, name := range hopByHopHeaders {
+		t.strip[name] = struct{}{}
+	}
+	for _, name := range strip {
+		name = textproto.CanonicalMIMEHeaderKey(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if _, ok := protectedHeaders[name]; ok {
+			slog.Warn("ignoring protected header in proxy strip list", "header", name)
+			continue
+		}
+		t.strip[name] = struct{}{}
+	}
+	if len(allow) > 0 {
+		t.allow = make(map[string]struct{}, len(essentialHeaders)+len(allow))
+		for _, name := range essentialHeaders {
+			t.allow[name] = struct{}{}
+		}
+		for _, name := range allow {
+			if name = textproto.CanonicalMIMEHeaderKey(strings.TrimSpace(name)); name != "" {
+				t.allow[name] = struct{}{}
+			}
+		}
+	}
+	return t
+}
+
+func (t *headerStrippingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	// A 101 response completes a protocol upgrade; its Connection
+	// and Upgrade headers must reach the client intact.
+	if resp.StatusCode == http.StatusSwitchingProtocols {
+		return resp, nil
+	}
+	// Headers nominated by the Connection header are also
+	// hop-by-hop (RFC 9110 §7.6.1).
+	for _, v := range resp.Header.Values("Connection") {
+		for name := range strings.SplitSeq(v, ",") {
+			if name = textproto.TrimString(name); name != "" {
+				resp.Header.Del(name)
+			}
+		}
+	}
+	for name := range resp.Header {
+		_, stripped := t.strip[name]
+		_, allowed := t.allow[name]
+		if stripped || (t.allow != nil && !allowed) {
+			delete(resp.Header, name)
+		}
+	}
+	return resp, nil
+}
+
+// WrappedRoundTripper returns the transport t wraps, so that helpers
+// such as utilnet.TLSClientConfig can reach the TLS settings of the
+// rest config beneath it.
+func (t *headerStrippingTransport) WrappedRoundTripper() http.RoundTripper {
+	return t.base
+}
+
+// CloseIdleConnections closes the idle connections of the wrapped
+// transport.
+func (t *headerStrippingTransport) CloseIdleConnections() {
+	utilnet.CloseIdleConnectionsFor(t.base)
 }
 
 // errorResponder implements k8s.io/apimachinery/pkg/util/proxy.ErrorResponder.
-// It logs errors and returns a 502 Bad Gateway response to the client.
-type errorResponder struct{}
+// It counts and logs errors and returns a 502 Bad Gateway response to
+// the client.
+type errorResponder struct {
+	errs *atomic.Uint64
+}
 
 func (r *errorResponder) Error(w http.ResponseWriter, _ *http.Request, err error) {
+	r.errs.Add(1)
 	slog.Error("proxy error", "error", err)
 	http.Error(w, "bad gateway", http.StatusBadGateway)
 }
@@ -0,0 +1,324 @@
+package agent
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/rest"
+)
+
+// proxiedHeaders returns the response headers of a GET through a
+// proxy with the given header lists to an API server that sets a mix
+// of standard, Kubernetes-specific and hop-by-hop headers.
+func proxiedHeaders(t *testing.T, allow, strip []string) http.Header {
+	t.Helper()
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Audit-Id", "f1b4c2d0")
+		w.Header().Set("X-Kubernetes-Pf-Flowschema-Uid", "abc")
+		w.Header().Set("Content-Disposition", "attachment")
+		w.Header().Set("X-Custom", "kept")
+		w.Header().Set("Warning", `299 - "deprecated"`)
+		w.Header().Set("Cache-Control", "no-cache, private")
+		w.Header().Set("Keep-Alive", "timeout=5")
+		_, _ = io.WriteString(w, `{"kind":"Status"}`)
+	}))
+	defer upstream.Close()
+
+	proxy, err := newKubeAPIProxy(&rest.Config{Host: upstream.URL}, allow, strip, new(atomic.Uint64))
+	if err != nil {
+		t.Fatalf("newKubeAPIProxy: %v", err)
+	}
+	srv := httptest.NewServer(proxy)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/api")
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	if string(body) != `{"kind":"Status"}` {
+		t.Errorf("body = %q, want %q", body, `{"kind":"Status"}`)
+	}
+	return resp.Header
+}
+
+func TestKubeAPIProxy_StripsHopByHopAndConfiguredHeaders(t *testing.T) {
+	header := proxiedHeaders(t, nil, []string{"cache-control", "Content-Type"})
+
+	for _, name := range []string{"Keep-Alive", "Cache-Control"} {
+		if v := header.Get(name); v != "" {
+			t.Errorf("header %s = %q, want stripped", name, v)
+		}
+	}
+	// Every other header is forwarded, and Content-Type is protected
+	// from the strip list.
+	for name, want := range map[string]string{
+		"Content-Type":                   "application/json",
+		"Audit-Id":                       "f1b4c2d0",
+		"X-Kubernetes-Pf-Flowschema-Uid": "abc",
+		"Content-Disposition":            "attachment",
+		"X-Custom":                       "kept",
+	} {
+		if got := header.Get(name); got != want {
+			t.Errorf("header %s = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestKubeAPIProxy_ForwardsOnlyAllowedHeaders(t *testing.T) {
+	header := proxiedHeaders(t, []string{" x-custom "}, []string{"cache-control"})
+
+	for _, name := range []string{"Audit-Id", "X-Kubernetes-Pf-Flowschema-Uid", "Content-Disposition", "Keep-Alive", "Cache-Control"} {
+		if v := header.Get(name); v != "" {
+			t.Errorf("header %s = %q, want stripped", name, v)
+		}
+	}
+	// Allowed and essential headers are forwarded.
+	for name, want := range map[string]string{
+		"Content-Type": "application/json",
+		"X-Custom":     "kept",
+		"Warning":      `299 - "deprecated"`,
+	} {
+		if got := header.Get(name); got != want {
+			t.Errorf("header %s = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestKubeAPIProxy_ForwardsRequestPath(t *testing.T) {
+	var got []string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.URL.RequestURI())
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer upstream.Close()
+
+	for _, tt := range []struct {
+		host string
+		want string
+	}{
+		{upstream.URL, "/api/v1/namespaces/default/pods?limit=10"},
+		{upstream.URL + "/k8s/clusters/c-1", "/k8s/clusters/c-1/api/v1/namespaces/default/pods?limit=10"},
+	} {
+		got = nil
+		proxy, err := newKubeAPIProxy(&rest.Config{Host: tt.host}, nil, nil, new(atomic.Uint64))
+		if err != nil {
+			t.Fatalf("newKubeAPIProxy: %v", err)
+		}
+		srv := httptest.NewServer(proxy)
+
+		resp, err := http.Get(srv.URL + "/api/v1/namespaces/default/pods?limit=10")
+		srv.Close()
+		if err != nil {
+			t.Fatalf("GET: %v", err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK || len(got) != 1 || got[0] != tt.want {
+			t.Errorf("host %s: status %d, upstream requests %v; want one request for %s", tt.host, resp.StatusCode, got, tt.want)
+		}
+	}
+}
+
+func TestHeaderStrippingTransport_PreservesUpgradeHeaders(t *testing.T) {
+	base := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		h := http.Header{}
+		h.Set("Connection", "Upgrade")
+		h.Set("Upgrade", "SPDY/3.1")
+		return &http.Response{StatusCode: http.StatusSwitchingProtocols, Header: h}, nil
+	})
+
+	rt := newHeaderStrippingTransport(base, nil, nil)
+	resp, err := rt.RoundTrip(httptest.NewRequest(http.MethodGet, "/", nil))
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	if got := resp.Header.Get("Upgrade"); got != "SPDY/3.1" {
+		t.Errorf("Upgrade = %q, want %q", got, "SPDY/3.1")
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestProxyTLSConfig_LoadsCAFile(t *testing.T) {
+	upstream := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `{"kind":"Status"}`)
+	}))
+	defer upstream.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: upstream.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0o600); err != nil {
+		t.Fatalf("write CA file: %v", err)
+	}
+
+	cfg, err := proxyTLSConfig(&rest.Config{Host: upstream.URL}, caFile, false)
+	if err != nil {
+		t.Fatalf("proxyTLSConfig: %v", err)
+	}
+	transport, err := rest.TransportFor(cfg)
+	if err != nil {
+		t.Fatalf("TransportFor: %v", err)
+	}
+	tlsConfig, err := utilnet.TLSClientConfig(transport)
+	if err != nil || tlsConfig == nil {
+		t.Fatalf("transport TLS config = %v, %v", tlsConfig, err)
+	}
+	want := x509.NewCertPool()
+	want.AddCert(upstream.Certificate())
+	if tlsConfig.RootCAs == nil || !tlsConfig.RootCAs.Equal(want) {
+		t.Error("root pool does not hold exactly the CA file's certificate")
+	}
+
+	proxy, err := newKubeAPIProxy(cfg, nil, nil, new(atomic.Uint64))
+	if err != nil {
+		t.Fatalf("newKubeAPIProxy: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	proxy.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("proxied status = %d, want 200 with the API server verified against the CA file", rec.Code)
+	}
+}
+
+func TestKubeAPIProxy_UpgradeUsesRestConfigTLSAndCredentials(t *testing.T) {
+	var authorization atomic.Value
+	upstream := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization.Store(r.Header.Get("Authorization"))
+		if r.Header.Get("Upgrade") != "test-stream" {
+			http.Error(w, "upgrade required", http.StatusBadRequest)
+			return
+		}
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: test-stream\r\n\r\n")
+		_ = rw.Flush()
+		line, _ := rw.ReadString('\n')
+		_, _ = rw.WriteString("echo: " + line)
+		_ = rw.Flush()
+	}))
+	defer upstream.Close()
+
+	// The upstream certificate is signed by httptest's private CA,
+	// which is known only through the rest config.
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: upstream.Certificate().Raw})
+	cfg := &rest.Config{
+		Host:            upstream.URL,
+		BearerToken:     "agent-token",
+		TLSClientConfig: rest.TLSClientConfig{CAData: caPEM},
+	}
+	proxy, err := newKubeAPIProxy(cfg, nil, nil, new(atomic.Uint64))
+	if err != nil {
+		t.Fatalf("newKubeAPIProxy: %v", err)
+	}
+	srv := httptest.NewServer(proxy)
+	defer srv.Close()
+
+	conn, err := net.Dial("tcp", srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("dial proxy: %v", err)
+	}
+	defer conn.Close()
+	_, err = io.WriteString(conn, "GET /api/v1/namespaces/default/pods/web/exec HTTP/1.1\r\nHost: agent\r\nConnection: Upgrade\r\nUpgrade: test-stream\r\n\r\n")
+	if err != nil {
+		t.Fatalf("write upgrade request: %v", err)
+	}
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	if err != nil {
+		t.Fatalf("read upgrade response: %v", err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("status = %d, want 101", resp.StatusCode)
+	}
+	if _, err := io.WriteString(conn, "ping\n"); err != nil {
+		t.Fatalf("write stream: %v", err)
+	}
+	line, err := br.ReadString('\n')
+	if err != nil {
+		t.Fatalf("read stream: %v", err)
+	}
+	if line != "echo: ping\n" {
+		t.Errorf("stream = %q, want %q", line, "echo: ping\n")
+	}
+	if got, _ := authorization.Load().(string); got != "Bearer agent-token" {
+		t.Errorf("upgrade Authorization = %q, want the rest config's bearer token", got)
+	}
+}
+
+func TestHeaderStrippingTransport_UnwrapsToTLSConfig(t *testing.T) {
+	cfg := &rest.Config{Host: "https://kube-apiserver.test", TLSClientConfig: rest.TLSClientConfig{ServerName: "kubernetes.default"}}
+	transport, err := rest.TransportFor(cfg)
+	if err != nil {
+		t.Fatalf("TransportFor: %v", err)
+	}
+
+	tlsConfig, err := utilnet.TLSClientConfig(newHeaderStrippingTransport(transport, nil, nil))
+	if err != nil || tlsConfig == nil {
+		t.Fatalf("TLS config = %v, %v, want the rest config's", tlsConfig, err)
+	}
+	if tlsConfig.ServerName != "kubernetes.default" {
+		t.Errorf("ServerName = %q, want kubernetes.default", tlsConfig.ServerName)
+	}
+}
+
+func TestProxyTLSConfig_Insecure(t *testing.T) {
+	var logs bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+
+	base := &rest.Config{Host: "https://kube-apiserver.test", TLSClientConfig: rest.TLSClientConfig{CAData: []byte("in-cluster CA")}}
+
+	cfg, err := proxyTLSConfig(base, "", true)
+	if err != nil {
+		t.Fatalf("proxyTLSConfig: %v", err)
+	}
+	if !cfg.Insecure || cfg.CAData != nil {
+		t.Errorf("TLS config = %+v, want insecure without a CA", cfg.TLSClientConfig)
+	}
+	if base.Insecure || base.CAData == nil {
+		t.Error("proxyTLSConfig modified the agent's rest config")
+	}
+	if !strings.Contains(logs.String(), "level=WARN") || !strings.Contains(logs.String(), "insecure_skip_verify=true") {
+		t.Errorf("logs = %q, want a warning with insecure_skip_verify=true", logs.String())
+	}
+
+	if cfg, _ := proxyTLSConfig(base, "", false); cfg != base {
+		t.Error("proxyTLSConfig without overrides did not return the config as is")
+	}
+}
+
+func TestProxyTLSConfig_RejectsInsecureWithCAFile(t *testing.T) {
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	if err := os.WriteFile(caFile, []byte("unused"), 0o600); err != nil {
+		t.Fatalf("write CA file: %v", err)
+	}
+
+	if _, err := proxyTLSConfig(&rest.Config{Host: "https://kube-apiserver.test"}, caFile, true); err == nil {
+		t.Error("proxyTLSConfig accepted both a CA file and insecure verification")
+	}
+}
@@ -0,0 +1,140 @@
+package agent
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/otterscale/otterscale-agent/internal/transport/tunnel"
+)
+
+// tunnelStatus reports the state of the agent's tunnel connection.
+// It is satisfied by *tunnel.Client.
+type tunnelStatus interface {
+	Status() tunnel.Status
+}
+
+// proxyCounter reports the number of bytes proxied through the
+// tunnel. It is satisfied by *tunnel.Bridge.
+type proxyCounter interface {
+	BytesProxied() uint64
+}
+
+// healthResponse is the JSON body returned by /healthz.
+type healthResponse struct {
+	Connected        bool       `json:"connected"`
+	TunnelServerURL  string     `json:"tunnel_server_url,omitempty"`
+	Endpoint         string     `json:"endpoint,omitempty"`
+	LastRegistration *time.Time `json:"last_registration,omitempty"`
+	Reconnects       uint64     `json:"reconnects"`
+}
+
+// healthMount returns a MountFunc that serves /healthz and /metrics
+// for the agent. /healthz responds 200 while the tunnel is connected
+// and 503 otherwise. Metrics are registered on a private registry so
+// that they do not depend on global state.
+func healthMount(status tunnelStatus, proxy proxyCounter) func(mux *http.ServeMux) error {
+	return func(mux *http.ServeMux) error {
+		reg := prometheus.NewRegistry()
+		if err := reg.Register(newTunnelCollector(status, proxy)); err != nil {
+			return err
+		}
+
+		mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+			s := status.Status()
+
+			resp := healthResponse{
+				Connected:       s.Connected,
+				TunnelServerURL: s.TunnelServerURL,
+				Endpoint:        s.Endpoint,
+				Reconnects:      s.Reconnects,
+			}
+			if !s.LastRegistration.IsZero() {
+				resp.LastRegistration = &s.LastRegistration
+			}
+
+			code := http.StatusOK
+			if !s.Connected {
+				code = http.StatusServiceUnavailable
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(code)
+			if err := json.NewEncoder(w).Encode(resp); err != nil {
+				slog.Warn("failed to write health response", "error", err)
+			}
+		})
+		mux.Handle("GET /metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+
+		return nil
+	}
+}
+
+// tunnelCollector exposes tunnel state as Prometheus metrics. Values
+// are read at scrape time, so the tunnel package does not depend on
+// Prometheus.
+type tunnelCollector struct {
+	status tunnelStatus
+	proxy  proxyCounter
+
+	bytesProxied *prometheus.Desc
+	reconnects   *prometheus.Desc
+	connected    *prometheus.Desc
+	endpoint     *prometheus.Desc
+}
+
+func newTunnelCollector(status tunnelStatus, proxy proxyCounter) *tunnelCollector {
+	return &tunnelCollector{
+		status: status,
+		proxy:  proxy,
+		bytesProxied: prometheus.NewDesc(
+			"otterscale_agent_tunnel_proxied_bytes_total",
+			"Total bytes proxied through the tunnel in both directions.",
+			nil, nil,
+		),
+		reconnects: prometheus.NewDesc(
+			"otterscale_agent_tunnel_reconnects_total",
+			"Number of tunnel sessions started after a previous session ended.",
+			nil, nil,
+		),
+		connected: prometheus.NewDesc(
+			"otterscale_agent_tunnel_connected",
+			"Whether a connection to the tunnel server is open (1) or not (0).",
+			nil, nil,
+		),
+		endpoint: prometheus.NewDesc(
+			"otterscale_agent_tunnel_endpoint_info",
+			"Tunnel endpoint allocated at the most recent registration.",
+			[]string{"endpoint"}, nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *tunnelCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.bytesProxied
+	ch <- c.reconnects
+	ch <- c.connected
+	ch <- c.endpoint
+}
+
+// Collect implements prometheus.Collector.
+func (c *tunnelCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.status.Status()
+
+	connected := 0.0
+	if s.Connected {
+		connected = 1
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.bytesProxied, prometheus.CounterValue, float64(c.proxy.BytesProxied()))
+	ch <- prometheus.MustNewConstMetric(c.reconnects, prometheus.CounterValue, float64(s.Reconnects))
+	ch <- prometheus.MustNewConstMetric(c.connected, prometheus.GaugeValue, connected)
+	if s.Endpoint != "" {
+		ch <- prometheus.MustNewConstMetric(c.endpoint, prometheus.GaugeValue, 1, s.Endpoint)
+	}
+}
@@ -0,0 +1,121 @@
+package agent
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/otterscale/otterscale-agent/internal/transport/tunnel"
+)
+
+type fakeTunnelStatus struct {
+	mu     sync.Mutex
+	status tunnel.Status
+}
+
+func (f *fakeTunnelStatus) Status() tunnel.Status {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.status
+}
+
+func (f *fakeTunnelStatus) set(s tunnel.Status) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.status = s
+}
+
+type fakeProxyCounter uint64
+
+func (f fakeProxyCounter) BytesProxied() uint64 { return uint64(f) }
+
+func newTestHealthServer(t *testing.T, status tunnelStatus, proxy proxyCounter) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	if err := healthMount(status, proxy)(mux); err != nil {
+		t.Fatalf("healthMount: %v", err)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func getHealth(t *testing.T, url string) (int, healthResponse) {
+	t.Helper()
+	resp, err := http.Get(url + "/healthz")
+	if err != nil {
+		t.Fatalf("GET /healthz: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var body healthResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode /healthz: %v", err)
+	}
+	return resp.StatusCode, body
+}
+
+func TestHealthz_ReflectsTunnelConnection(t *testing.T) {
+	status := &fakeTunnelStatus{}
+	srv := newTestHealthServer(t, status, fakeProxyCounter(0))
+
+	code, body := getHealth(t, srv.URL)
+	if code != http.StatusServiceUnavailable {
+		t.Errorf("before connect: status = %d, want %d", code, http.StatusServiceUnavailable)
+	}
+	if body.Connected || body.LastRegistration != nil {
+		t.Errorf("before connect: body = %+v, want disconnected without registration", body)
+	}
+
+	registeredAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	status.set(tunnel.Status{
+		Connected:        true,
+		Endpoint:         "127.0.0.2:16598",
+		LastRegistration: registeredAt,
+		Reconnects:       2,
+	})
+
+	code, body = getHealth(t, srv.URL)
+	if code != http.StatusOK {
+		t.Errorf("after connect: status = %d, want %d", code, http.StatusOK)
+	}
+	if !body.Connected || body.Endpoint != "127.0.0.2:16598" || body.Reconnects != 2 {
+		t.Errorf("after connect: body = %+v", body)
+	}
+	if body.LastRegistration == nil || !body.LastRegistration.Equal(registeredAt) {
+		t.Errorf("after connect: last_registration = %v, want %v", body.LastRegistration, registeredAt)
+	}
+}
+
+func TestMetrics_ExposesTunnelState(t *testing.T) {
+	status := &fakeTunnelStatus{status: tunnel.Status{Connected: true, Endpoint: "127.0.0.2:16598", Reconnects: 3}}
+	srv := newTestHealthServer(t, status, fakeProxyCounter(4096))
+
+	resp, err := http.Get(srv.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("GET /metrics: %v", err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read /metrics: %v", err)
+	}
+	body := string(raw)
+
+	for _, want := range []string{
+		"otterscale_agent_tunnel_proxied_bytes_total 4096",
+		"otterscale_agent_tunnel_reconnects_total 3",
+		"otterscale_agent_tunnel_connected 1",
+		`otterscale_agent_tunnel_endpoint_info{endpoint="127.0.0.2:16598"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/otterscale/otterscale-agent/internal/config"
+)
+
+// NewConfigCommand returns the "config" Cobra subcommand. It prints
+// the fully-resolved effective configuration as YAML, annotating each
+// key with the source its value came from (default, file, env or
+// flag) and redacting secrets. The "server" and "agent" subcommands
+// scope the output to one mode and accept that mode's flags so that
+// flag overrides can be inspected too.
+func NewConfigCommand(conf *config.Config) (*cobra.Command, error) {
+	cmd := &cobra.Command{
+		Use:     "config",
+		Short:   "Print the effective configuration and the source of each value",
+		Example: "OTTERSCALE_AGENT_CLUSTER=edge otterscale config agent --server-url=https://api.otterscale.io",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			options := append(append([]config.Option{}, config.ServerOptions...), config.AgentOptions...)
+			return writeEffectiveConfig(cmd.OutOrStdout(), conf, options)
+		},
+	}
+
+	for _, mode := range []struct {
+		name    string
+		options []config.Option
+	}{
+		{"server", config.ServerOptions},
+		{"agent", config.AgentOptions},
+	} {
+		sub := &cobra.Command{
+			Use:   mode.name,
+			Short: fmt.Sprintf("Print the effective %s configuration", mode.name),
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				// Bind lazily: the server and agent commands own the
+				// viper bindings for these keys unless this command
+				// is the one executing.
+				if err := conf.BindRegisteredFlags(cmd.Flags(), mode.options); err != nil {
+					return err
+				}
+				return writeEffectiveConfig(cmd.OutOrStdout(), conf, mode.options)
+			},
+		}
+		if err := config.RegisterFlags(sub.Flags(), mode.options); err != nil {
+			return nil, err
+		}
+		cmd.AddCommand(sub)
+	}
+
+	return cmd, nil
+}
+
+// writeEffectiveConfig marshals the effective settings for options as
+// a YAML mapping keyed by viper key.
+func writeEffectiveConfig(w io.Writer, conf *config.Config, options []config.Option) error {
+	settings := map[string]config.Setting{}
+	for _, s := range conf.Effective(options) {
+		settings[s.Key] = s
+	}
+
+	out, err := yaml.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("failed to marshal effective config: %w", err)
+	}
+
+	if _, err := w.Write(out); err != nil {
+		return fmt.Errorf("failed to write effective config: %w", err)
+	}
+	return nil
+}
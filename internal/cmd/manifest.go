@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// NewManifestCommand returns the "manifest" Cobra subcommand. It
+// renders the agent installation manifest locally and writes it to
+// stdout, so operators without API access can produce install YAML
+// without a running server. Inputs are validated with the same rules
+// as the GetAgentManifest RPC.
+func NewManifestCommand(version core.Version, renderer core.ManifestRenderer) *cobra.Command {
+	params := core.ManifestParams{}
+
+	cmd := &cobra.Command{
+		Use:     "manifest",
+		Short:   "Render the agent installation manifest to stdout",
+		Example: "otterscale manifest --cluster=default --user=admin@example.com --server-url=https://api.otterscale.io --tunnel-url=https://tunnel.otterscale.io | kubectl apply -f -",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return renderManifest(cmd.OutOrStdout(), renderer, params)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&params.Cluster, "cluster", "", "Cluster name the agent will register under")
+	f.StringVar(&params.UserName, "user", "", "User name bound to cluster-admin on the target cluster")
+	f.StringVar(&params.ServerURL, "server-url", "", "Externally reachable control-plane server URL")
+	f.StringVar(&params.TunnelURL, "tunnel-url", "", "Externally reachable tunnel server URL")
+	f.StringVar(&params.Image, "image", core.AgentImage(version), "Agent container image")
+
+	return cmd
+}
+
+// renderManifest validates params, renders the manifest and writes
+// it to w.
+func renderManifest(w io.Writer, renderer core.ManifestRenderer, params core.ManifestParams) error {
+	if err := core.ValidateManifestParams(params); err != nil {
+		return err
+	}
+
+	manifest, err := renderer.RenderAgentManifest(params)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, manifest); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+	"github.com/otterscale/otterscale-agent/internal/providers/manifest"
+)
+
+func TestManifestCommand_RendersMultiDocYAML(t *testing.T) {
+	cmd := NewManifestCommand(core.Version("v1.2.3"), manifest.NewRenderer())
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{
+		"--cluster=edge-1",
+		"--user=admin@example.com",
+		"--server-url=https://api.example.com",
+		"--tunnel-url=https://tunnel.example.com",
+	})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	dec := utilyaml.NewYAMLOrJSONDecoder(&out, 4096)
+	kinds := map[string]int{}
+	var image string
+	for {
+		var obj map[string]any
+		if err := dec.Decode(&obj); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			t.Fatalf("decode: %v", err)
+		}
+		if obj == nil {
+			continue
+		}
+		kind, _ := obj["kind"].(string)
+		kinds[kind]++
+		if kind == "Deployment" {
+			image = deploymentImage(obj)
+		}
+	}
+
+	for _, kind := range []string{"Namespace", "ServiceAccount", "ClusterRoleBinding", "Deployment"} {
+		if kinds[kind] == 0 {
+			t.Errorf("manifest missing %s document", kind)
+		}
+	}
+	if want := "ghcr.io/otterscale/otterscale:v1.2.3"; image != want {
+		t.Errorf("image = %q, want %q", image, want)
+	}
+}
+
+func TestManifestCommand_Validation(t *testing.T) {
+	valid := []string{
+		"--cluster=edge-1",
+		"--user=admin@example.com",
+		"--server-url=https://api.example.com",
+		"--tunnel-url=https://tunnel.example.com",
+	}
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"invalid cluster", append([]string{"--cluster=INVALID!"}, valid[1:]...)},
+		{"missing cluster", valid[1:]},
+		{"missing user", []string{valid[0], valid[2], valid[3]}},
+		{"missing server url", []string{valid[0], valid[1], valid[3]}},
+		{"missing tunnel url", valid[:3]},
+		{"empty image", append([]string{"--image="}, valid...)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewManifestCommand(core.Version("v1.2.3"), manifest.NewRenderer())
+			// Mirror the root command, which silences usage on errors.
+			cmd.SilenceUsage = true
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			var invalid *core.ErrInvalidInput
+			if !errors.As(err, &invalid) {
+				t.Fatalf("expected *core.ErrInvalidInput, got %v", err)
+			}
+			if out.Len() != 0 {
+				t.Errorf("expected no output on validation failure, got %q", out.String())
+			}
+		})
+	}
+}
+
+// deploymentImage extracts the first container image from a decoded
+// Deployment object.
+func deploymentImage(obj map[string]any) string {
+	spec, _ := obj["spec"].(map[string]any)
+	tmpl, _ := spec["template"].(map[string]any)
+	podSpec, _ := tmpl["spec"].(map[string]any)
+	containers, _ := podSpec["containers"].([]any)
+	if len(containers) == 0 {
+		return ""
+	}
+	c, _ := containers[0].(map[string]any)
+	image, _ := c["image"].(string)
+	return image
+}
@@ -32,6 +32,10 @@ func NewServerCommand(conf *config.Config, newServer ServerInjector) (*cobra.Com
 			cfg := server.Config{
 				Address:          conf.ServerAddress(),
 				AllowedOrigins:   conf.ServerAllowedOrigins(),
+				AllowedHeaders:   conf.ServerAllowedHeaders(),
+				ExposedHeaders:   conf.ServerExposedHeaders(),
+				StreamKeepAlive:  conf.ServerStreamKeepAlive(),
+				TrustedProxies:   conf.ServerTrustedProxies(),
 				TunnelAddress:    conf.ServerTunnelAddress(),
 				KeycloakRealmURL: conf.ServerKeycloakRealmURL(),
 				KeycloakClientID: conf.ServerKeycloakClientID(),
@@ -11,19 +11,25 @@ import (
 // scans for and removes stale sessions.
 const sessionReapInterval = 30 * time.Second
 
-// cacheEvictionInterval is the interval at which the discovery cache
-// evictor removes expired schema and version entries.
-const cacheEvictionInterval = 5 * time.Minute
+// defaultCacheEvictionInterval is the interval at which the discovery
+// cache evictor removes expired schema and version entries when none
+// is configured.
+const defaultCacheEvictionInterval = 5 * time.Minute
 
 // ProvideBackgroundListeners constructs the background transport
 // listeners (session reaper, cache evictor) that participate in the
 // server's managed lifecycle. The CacheEvictor interface decouples
 // this function from the concrete cache implementation, keeping the
-// application layer free of infrastructure dependencies.
-func ProvideBackgroundListeners(runtime *core.RuntimeUseCase, evictor core.CacheEvictor) BackgroundListeners {
+// application layer free of infrastructure dependencies. Both loops
+// vary their intervals by jitter.
+func ProvideBackgroundListeners(runtime *core.RuntimeUseCase, evictor core.CacheEvictor, cache core.CacheConfig, jitter core.Jitter) BackgroundListeners {
+	interval := cache.EvictionInterval
+	if interval <= 0 {
+		interval = defaultCacheEvictionInterval
+	}
 	return BackgroundListeners{
-		&sessionReaperListener{runtime: runtime},
-		&cacheEvictorListener{cache: evictor},
+		&sessionReaperListener{runtime: runtime, jitter: jitter},
+		&cacheEvictorListener{cache: evictor, interval: interval, jitter: jitter},
 	}
 }
 
@@ -32,10 +38,11 @@ func ProvideBackgroundListeners(runtime *core.RuntimeUseCase, evictor core.Cache
 // lifecycle alongside other servers.
 type sessionReaperListener struct {
 	runtime *core.RuntimeUseCase
+	jitter  core.Jitter
 }
 
 func (l *sessionReaperListener) Start(ctx context.Context) error {
-	l.runtime.StartSessionReaper(ctx, sessionReapInterval)
+	l.runtime.StartSessionReaper(ctx, sessionReapInterval, l.jitter)
 	return nil
 }
 
@@ -47,11 +54,13 @@ func (l *sessionReaperListener) Stop(_ context.Context) error {
 // transport.Listener interface so it participates in the managed
 // lifecycle alongside other servers.
 type cacheEvictorListener struct {
-	cache core.CacheEvictor
+	cache    core.CacheEvictor
+	interval time.Duration
+	jitter   core.Jitter
 }
 
 func (l *cacheEvictorListener) Start(ctx context.Context) error {
-	l.cache.StartEvictionLoop(ctx, cacheEvictionInterval)
+	l.cache.StartEvictionLoop(ctx, l.interval, l.jitter)
 	return nil
 }
 
@@ -1,8 +1,12 @@
 package server
 
 import (
+	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"path"
+	"strings"
 
 	"connectrpc.com/connect"
 	"connectrpc.com/grpchealth"
@@ -18,32 +22,48 @@ import (
 	fleetv1 "github.com/otterscale/otterscale-agent/api/fleet/v1/pbconnect"
 	resourcev1 "github.com/otterscale/otterscale-agent/api/resource/v1/pbconnect"
 	runtimev1 "github.com/otterscale/otterscale-agent/api/runtime/v1/pbconnect"
+	"github.com/otterscale/otterscale-agent/internal/core"
 	"github.com/otterscale/otterscale-agent/internal/handler"
 )
 
 // Handler is responsible for mounting all gRPC service handlers,
 // interceptors, and operational endpoints (health, reflection,
-// metrics) onto an HTTP mux.
+// metrics, readiness) onto an HTTP mux.
 type Handler struct {
-	fleet    *handler.FleetService
-	resource *handler.ResourceService
-	runtime  *handler.RuntimeService
-	manifest *handler.ManifestHandler
+	fleet       *handler.FleetService
+	resource    *handler.ResourceService
+	runtime     *handler.RuntimeService
+	manifest    *handler.ManifestHandler
+	readiness   *core.ReadinessUseCase
+	maintenance *core.MaintenanceUseCase
+	kubeAPI     core.KubeAPIProxy
 }
 
-// NewHandler returns a Handler for the given gRPC services and the
-// raw HTTP manifest handler.
-func NewHandler(fleet *handler.FleetService, resource *handler.ResourceService, runtime *handler.RuntimeService, manifest *handler.ManifestHandler) *Handler {
+// NewHandler returns a Handler for the given gRPC services, the raw
+// HTTP manifest handler, the readiness check behind /readyz, the
+// maintenance mode enforced on mutating requests and the kube-API
+// proxy for kubectl.
+func NewHandler(fleet *handler.FleetService, resource *handler.ResourceService, runtime *handler.RuntimeService, manifest *handler.ManifestHandler, readiness *core.ReadinessUseCase, maintenance *core.MaintenanceUseCase, kubeAPI core.KubeAPIProxy) *Handler {
 	return &Handler{
-		fleet:    fleet,
-		resource: resource,
-		runtime:  runtime,
-		manifest: manifest,
+		fleet:       fleet,
+		resource:    resource,
+		runtime:     runtime,
+		manifest:    manifest,
+		readiness:   readiness,
+		maintenance: maintenance,
+		kubeAPI:     kubeAPI,
 	}
 }
 
-// Mount registers all gRPC service handlers, OTel interceptors, and
-// operational endpoints onto the provided mux.
+// readyzResponse is the JSON body returned by /readyz.
+type readyzResponse struct {
+	Ready             bool `json:"ready"`
+	ConnectedClusters int  `json:"connected_clusters"`
+	MinClusters       int  `json:"min_clusters"`
+}
+
+// Mount registers all gRPC service handlers, OTel and panic-recovery
+// interceptors, and operational endpoints onto the provided mux.
 func (h *Handler) Mount(mux *http.ServeMux) error {
 	// OpenTelemetry interceptor for automatic tracing and metrics.
 	otelInterceptor, err := otelconnect.NewInterceptor()
@@ -51,8 +71,13 @@ func (h *Handler) Mount(mux *http.ServeMux) error {
 		return err
 	}
 
+	// Panics are recovered innermost so that the OTel interceptor
+	// records them as Internal errors. Mutations rejected in
+	// maintenance mode are recorded as well.
 	interceptors := connect.WithInterceptors(
 		otelInterceptor,
+		handler.NewMaintenanceInterceptor(h.maintenance),
+		handler.NewRecoverInterceptor(),
 	)
 
 	// Operational endpoints: gRPC reflection, health checks, Prometheus.
@@ -74,14 +99,63 @@ func (h *Handler) Mount(mux *http.ServeMux) error {
 	mux.Handle(resourcev1.NewResourceServiceHandler(h.resource, interceptors))
 	mux.Handle(runtimev1.NewRuntimeServiceHandler(h.runtime, interceptors))
 
-	// Raw YAML endpoint for kubectl apply -f. Authentication is
-	// handled by the HMAC token embedded in the URL path, so this
-	// route is registered as a public path prefix in server.go.
+	// Raw endpoints for kubectl apply -f, kubeconfig and support
+	// bundle downloads. Authentication is handled by the HMAC token
+	// embedded in the URL path, so these routes are registered as
+	// public path prefixes in server.go.
 	mux.HandleFunc("GET /fleet/manifest/{token}", h.handleRawManifest)
+	mux.HandleFunc("GET /fleet/kubeconfig/{token}", h.handleRawKubeconfig)
+	mux.HandleFunc("GET /fleet/support-bundle/{token}", h.handleSupportBundle)
+
+	// The kube-API of each cluster, for kubectl. It is authenticated
+	// like the RPCs.
+	mux.HandleFunc(core.KubeAPIPathPrefix+"{cluster}/{path...}", h.handleKubeAPI)
 
 	return nil
 }
 
+// handleKubeAPI forwards a kube-API request to the cluster's API
+// server. While maintenance mode is enabled it rejects every request
+// that can change the cluster, see isKubeAPIMutation.
+func (h *Handler) handleKubeAPI(w http.ResponseWriter, r *http.Request) {
+	if isKubeAPIMutation(r) {
+		if err := h.maintenance.CheckMutation(); err != nil {
+			h.kubeAPI.WriteError(w, r.PathValue("cluster"), err)
+			return
+		}
+	}
+	h.kubeAPI.ServeHTTP(w, r)
+}
+
+// kubeAPISessionSubresources are the subresources that open a session
+// in a pod or reach into the cluster network. kubectl requests them
+// with a GET when it uses WebSockets.
+var kubeAPISessionSubresources = map[string]bool{
+	"exec":        true,
+	"attach":      true,
+	"portforward": true,
+	"proxy":       true,
+}
+
+// isKubeAPIMutation reports whether r may change the cluster: any
+// method other than GET, HEAD and OPTIONS, any upgrade request, and
+// any request for a session subresource.
+func isKubeAPIMutation(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+	default:
+		return true
+	}
+	for _, value := range r.Header.Values("Connection") {
+		for token := range strings.SplitSeq(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(token), "upgrade") {
+				return true
+			}
+		}
+	}
+	return kubeAPISessionSubresources[path.Base(r.URL.Path)]
+}
+
 // handleRawManifest verifies the HMAC token in the URL path and
 // returns the agent installation manifest as raw YAML. This enables
 // `kubectl apply -f <url>` without additional authentication headers.
@@ -108,8 +182,46 @@ func (h *Handler) handleRawManifest(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// registerOpsHandlers sets up gRPC reflection, health checks, and
-// Prometheus metrics scraping.
+// handleRawKubeconfig verifies the HMAC token in the URL path and
+// returns the cluster kubeconfig as a YAML attachment.
+func (h *Handler) handleRawKubeconfig(w http.ResponseWriter, r *http.Request) {
+	token := r.PathValue("token")
+
+	cluster, kubeconfig, err := h.manifest.DownloadKubeconfig(r.Context(), token)
+	if err != nil {
+		slog.Debug("kubeconfig download failed", "error", err)
+		http.Error(w, "invalid or expired token", http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/yaml; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", cluster+".kubeconfig"))
+	if _, err := w.Write([]byte(kubeconfig)); err != nil {
+		slog.Warn("failed to write kubeconfig response", "error", err)
+	}
+}
+
+// handleSupportBundle verifies the HMAC token in the URL path and
+// returns the collected support bundle as a zip attachment.
+func (h *Handler) handleSupportBundle(w http.ResponseWriter, r *http.Request) {
+	token := r.PathValue("token")
+
+	name, data, err := h.manifest.DownloadSupportBundle(r.Context(), token)
+	if err != nil {
+		slog.Debug("support bundle download failed", "error", err)
+		http.Error(w, "invalid or expired token", http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	if _, err := w.Write(data); err != nil {
+		slog.Warn("failed to write support bundle response", "error", err)
+	}
+}
+
+// registerOpsHandlers sets up gRPC reflection, health checks,
+// Prometheus metrics scraping, and the /readyz readiness endpoint.
 func (h *Handler) registerOpsHandlers(mux *http.ServeMux, serviceNames []string) error {
 	reflector := grpcreflect.NewStaticReflector(serviceNames...)
 	mux.Handle(grpcreflect.NewHandlerV1(reflector))
@@ -128,6 +240,29 @@ func (h *Handler) registerOpsHandlers(mux *http.ServeMux, serviceNames []string)
 	// via Wire, but otelconnect relies on the global provider.
 	otel.SetMeterProvider(metric.NewMeterProvider(metric.WithReader(exporter)))
 	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("GET /readyz", h.handleReadyz)
 
 	return nil
 }
+
+// handleReadyz responds 200 once the server is ready to serve and 503
+// until then, so that load balancers hold traffic back until enough
+// clusters are connected.
+func (h *Handler) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	readiness := h.readiness.Check(r.Context())
+
+	code := http.StatusOK
+	if !readiness.Ready {
+		code = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(readyzResponse{
+		Ready:             readiness.Ready,
+		ConnectedClusters: readiness.ConnectedClusters,
+		MinClusters:       readiness.MinClusters,
+	}); err != nil {
+		slog.Warn("failed to write readiness response", "error", err)
+	}
+}
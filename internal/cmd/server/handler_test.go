@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// fakeTunnel reports a fixed set of registered clusters.
+type fakeTunnel struct {
+	core.TunnelProvider
+	clusters map[string]core.Cluster
+}
+
+func (f *fakeTunnel) ListClusters() map[string]core.Cluster { return f.clusters }
+
+func getReadyz(t *testing.T, h *Handler) (int, readyzResponse) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.handleReadyz(rec, httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/readyz", nil))
+
+	var body readyzResponse
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode /readyz: %v", err)
+	}
+	return rec.Code, body
+}
+
+func TestHandleReadyz_RequiresMinClusters(t *testing.T) {
+	tunnel := &fakeTunnel{clusters: map[string]core.Cluster{}}
+	h := &Handler{readiness: core.NewReadinessUseCase(tunnel, core.ReadinessConfig{MinClusters: 1})}
+
+	code, body := getReadyz(t, h)
+	if code != http.StatusServiceUnavailable || body.Ready {
+		t.Fatalf("without clusters: %d %+v, want 503 and not ready", code, body)
+	}
+
+	tunnel.clusters["edge-1"] = core.Cluster{Host: "127.0.0.2"}
+	code, body = getReadyz(t, h)
+	if code != http.StatusOK || !body.Ready || body.ConnectedClusters != 1 || body.MinClusters != 1 {
+		t.Fatalf("with a cluster: %d %+v, want 200 and ready", code, body)
+	}
+}
+
+// fakeKubeAPI records the requests it forwards and the errors it
+// writes.
+type fakeKubeAPI struct {
+	forwarded []string
+	errs      []error
+}
+
+func (f *fakeKubeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.forwarded = append(f.forwarded, r.Method+" "+r.URL.Path)
+}
+
+func (f *fakeKubeAPI) WriteError(w http.ResponseWriter, cluster string, err error) {
+	f.errs = append(f.errs, err)
+	w.WriteHeader(http.StatusServiceUnavailable)
+}
+
+func TestHandleKubeAPI_MaintenanceBlocksMutations(t *testing.T) {
+	kubeAPI := &fakeKubeAPI{}
+	h := &Handler{
+		maintenance: core.NewMaintenanceUseCase(core.MaintenanceConfig{Enabled: true, Message: "upgrading"}),
+		kubeAPI:     kubeAPI,
+	}
+
+	websocketExec := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/clusters/edge-1/api/v1/namespaces/default/pods/web/exec?command=sh", nil)
+	websocketExec.Header.Set("Connection", "Upgrade")
+	websocketExec.Header.Set("Upgrade", "websocket")
+
+	for _, tt := range []struct {
+		name    string
+		req     *http.Request
+		blocked bool
+	}{
+		{"list", httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/clusters/edge-1/api/v1/pods", nil), false},
+		{"create", httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/clusters/edge-1/api/v1/namespaces/default/pods", nil), true},
+		{"delete", httptest.NewRequestWithContext(context.Background(), http.MethodDelete, "/clusters/edge-1/api/v1/namespaces/default/pods/web", nil), true},
+		{"websocket exec", websocketExec, true},
+		{"port-forward", httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/clusters/edge-1/api/v1/namespaces/default/pods/web/portforward", nil), true},
+	} {
+		kubeAPI.forwarded, kubeAPI.errs = nil, nil
+		rec := httptest.NewRecorder()
+		h.handleKubeAPI(rec, tt.req)
+
+		if tt.blocked {
+			var domainErr *core.DomainError
+			if len(kubeAPI.forwarded) != 0 || len(kubeAPI.errs) != 1 || !errors.As(kubeAPI.errs[0], &domainErr) || domainErr.Code != core.ErrorCodeUnavailable {
+				t.Errorf("%s: forwarded %q, wrote %v; want an Unavailable error", tt.name, kubeAPI.forwarded, kubeAPI.errs)
+			}
+			continue
+		}
+		if len(kubeAPI.forwarded) != 1 || len(kubeAPI.errs) != 0 {
+			t.Errorf("%s: forwarded %q, wrote %v; want it forwarded", tt.name, kubeAPI.forwarded, kubeAPI.errs)
+		}
+	}
+}
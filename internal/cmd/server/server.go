@@ -6,8 +6,11 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	fleetv1 "github.com/otterscale/otterscale-agent/api/fleet/v1/pbconnect"
+	resourcev1 "github.com/otterscale/otterscale-agent/api/resource/v1/pbconnect"
+	"github.com/otterscale/otterscale-agent/internal/core"
 	"github.com/otterscale/otterscale-agent/internal/transport"
 	"github.com/otterscale/otterscale-agent/internal/transport/http"
 )
@@ -16,6 +19,10 @@ import (
 type Config struct {
 	Address          string
 	AllowedOrigins   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	StreamKeepAlive  time.Duration
+	TrustedProxies   []string
 	TunnelAddress    string
 	KeycloakRealmURL string
 	KeycloakClientID string
@@ -28,25 +35,39 @@ type Config struct {
 // without the Server depending on their concrete types.
 type BackgroundListeners []transport.Listener
 
+// serviceProcedures lists, for each service operation, the procedures
+// a service token issued for it may call. Service requests reach the
+// cluster without impersonation, so each operation only gets the
+// dedicated procedure whose content the server controls, never the
+// generic resource procedures.
+var serviceProcedures = map[core.ServiceOperation][]string{
+	core.ServiceOperationBootstrap: {
+		resourcev1.ResourceServiceApplyBootstrapProcedure,
+	},
+}
+
 // Server binds an HTTP server (gRPC + REST) and a chisel tunnel
 // listener, running them in parallel via transport.Serve.
 type Server struct {
-	handler    *Handler
-	tunnel     transport.TunnelService
-	background BackgroundListeners
+	handler       *Handler
+	tunnel        transport.TunnelService
+	serviceTokens *core.ServiceTokens
+	background    BackgroundListeners
 }
 
 // NewServer returns a Server wired to the given handler, tunnel
 // service, and background listeners. The TunnelService interface
 // decouples the server from concrete tunnel implementations, keeping
-// infrastructure details behind the interface boundary.
-func NewServer(handler *Handler, tunnel transport.TunnelService, background BackgroundListeners) *Server {
-	return &Server{handler: handler, tunnel: tunnel, background: background}
+// infrastructure details behind the interface boundary. Service
+// tokens authenticate the server's own automation in place of OIDC.
+func NewServer(handler *Handler, tunnel transport.TunnelService, serviceTokens *core.ServiceTokens, background BackgroundListeners) *Server {
+	return &Server{handler: handler, tunnel: tunnel, serviceTokens: serviceTokens, background: background}
 }
 
 // Run starts both the HTTP and tunnel servers. It blocks until ctx
-// is cancelled or an unrecoverable error occurs. Health, reflection,
-// and fleet-registration endpoints are marked as public (no auth).
+// is cancelled or an unrecoverable error occurs. Health, readiness,
+// reflection, and fleet-registration endpoints are marked as public
+// (no auth).
 func (s *Server) Run(ctx context.Context, cfg Config) error {
 	if cfg.KeycloakRealmURL == "" {
 		return fmt.Errorf("keycloak realm URL is required but not configured")
@@ -67,15 +88,23 @@ func (s *Server) Run(ctx context.Context, cfg Config) error {
 	httpSrv, err := http.NewServer(
 		http.WithAddress(cfg.Address),
 		http.WithAllowedOrigins(cfg.AllowedOrigins),
+		http.WithAllowedHeaders(cfg.AllowedHeaders),
+		http.WithExposedHeaders(cfg.ExposedHeaders),
+		http.WithStreamKeepAlive(cfg.StreamKeepAlive),
+		http.WithTrustedProxies(cfg.TrustedProxies),
 		http.WithAuthMiddleware(oidc),
+		http.WithServiceTokens(s.serviceTokens, serviceProcedures),
 		http.WithPublicPaths([]string{
 			"/grpc.health.v1.Health/Check",
 			"/grpc.health.v1.Health/Watch",
 			"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo",
+			"/readyz",
 			fleetv1.FleetServiceRegisterProcedure,
 		}),
 		http.WithPublicPathPrefixes([]string{
 			"/fleet/manifest/",
+			"/fleet/kubeconfig/",
+			"/fleet/support-bundle/",
 		}),
 		http.WithMount(s.handler.Mount),
 	)
@@ -0,0 +1,15 @@
+package server
+
+import (
+	"testing"
+
+	resourcev1 "github.com/otterscale/otterscale-agent/api/resource/v1/pbconnect"
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+func TestServiceProcedures_BootstrapOnlyAppliesBootstrap(t *testing.T) {
+	got := serviceProcedures[core.ServiceOperationBootstrap]
+	if len(got) != 1 || got[0] != resourcev1.ResourceServiceApplyBootstrapProcedure {
+		t.Fatalf("bootstrap service procedures = %q, want only %s", got, resourcev1.ResourceServiceApplyBootstrapProcedure)
+	}
+}
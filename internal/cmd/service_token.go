@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/otterscale/otterscale-agent/internal/config"
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// ServiceTokenIssuer is a factory for the server's ServiceTokens. It is
+// called lazily inside RunE so that the CA is only loaded when the
+// command actually executes.
+type ServiceTokenIssuer func() (*core.ServiceTokens, error)
+
+// NewServiceTokenCommand returns the "service-token" Cobra subcommand.
+// It issues a service token for one operation on one cluster and
+// writes it to stdout. The token's key is derived from the server's
+// CA, so the command accepts the server's flags and must run where the
+// server's CA store is reachable; the API offers no way to obtain a
+// service token.
+func NewServiceTokenCommand(conf *config.Config, newIssuer ServiceTokenIssuer) (*cobra.Command, error) {
+	var (
+		operation string
+		cluster   string
+		ttl       time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:     "service-token",
+		Short:   "Issue a token for the server's own automation on one cluster",
+		Example: "otterscale service-token --cluster=edge-1 --tunnel-ca-store=kubernetes",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			// Bind lazily: the server command owns the viper bindings
+			// for these keys unless this command is the one executing.
+			if err := conf.BindRegisteredFlags(cmd.Flags(), config.ServerOptions); err != nil {
+				return err
+			}
+			issuer, err := newIssuer()
+			if err != nil {
+				return fmt.Errorf("failed to load service token key: %w", err)
+			}
+			return issueServiceToken(cmd.OutOrStdout(), issuer, core.ServiceOperation(operation), cluster, ttl)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&operation, "operation", string(core.ServiceOperationBootstrap), "Service operation the token is issued for")
+	f.StringVar(&cluster, "cluster", "", "Cluster the token may reach")
+	f.DurationVar(&ttl, "ttl", 10*time.Minute, "Lifetime of the token, at most 1h")
+	if err := config.RegisterFlags(f, config.ServerOptions); err != nil {
+		return nil, err
+	}
+
+	return cmd, nil
+}
+
+// issueServiceToken issues a service token and writes it to w.
+func issueServiceToken(w io.Writer, issuer *core.ServiceTokens, op core.ServiceOperation, cluster string, ttl time.Duration) error {
+	token, err := issuer.Issue(op, cluster, ttl)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, token); err != nil {
+		return fmt.Errorf("failed to write token: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/otterscale/otterscale-agent/internal/config"
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+func TestServiceTokenCommand_IssuesClusterBoundToken(t *testing.T) {
+	conf, err := config.New()
+	if err != nil {
+		t.Fatalf("config.New: %v", err)
+	}
+	tokens, err := core.NewServiceTokens([]byte("service-key"), core.NewRealClock())
+	if err != nil {
+		t.Fatalf("NewServiceTokens: %v", err)
+	}
+	cmd, err := NewServiceTokenCommand(conf, func() (*core.ServiceTokens, error) { return tokens, nil })
+	if err != nil {
+		t.Fatalf("NewServiceTokenCommand: %v", err)
+	}
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--cluster=edge-1", "--ttl=5m"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	id, err := tokens.Verify(strings.TrimSpace(out.String()))
+	want := core.ServiceIdentity{Operation: core.ServiceOperationBootstrap, Cluster: "edge-1"}
+	if err != nil || id != want {
+		t.Fatalf("Verify = %+v, %v, want %+v", id, err, want)
+	}
+}
+
+func TestServiceTokenCommand_RejectsInvalidRequests(t *testing.T) {
+	tokens, err := core.NewServiceTokens([]byte("service-key"), core.NewRealClock())
+	if err != nil {
+		t.Fatalf("NewServiceTokens: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		op      core.ServiceOperation
+		cluster string
+		ttl     time.Duration
+	}{
+		{"missing cluster", core.ServiceOperationBootstrap, "", time.Minute},
+		{"unknown operation", "impersonate", "edge-1", time.Minute},
+		{"lifetime too long", core.ServiceOperationBootstrap, "edge-1", 2 * time.Hour},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := issueServiceToken(&out, tokens, tc.op, tc.cluster, tc.ttl)
+			var invalid *core.ErrInvalidInput
+			if !errors.As(err, &invalid) {
+				t.Fatalf("error = %v, want *core.ErrInvalidInput", err)
+			}
+			if out.Len() != 0 {
+				t.Errorf("token written despite the error: %q", out.String())
+			}
+		})
+	}
+}
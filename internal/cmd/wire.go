@@ -1,6 +1,6 @@
-// Package cmd defines the Cobra subcommands (server, agent) and their
-// Wire provider sets. It bridges configuration, dependency injection,
-// and the transport/application layers.
+// Package cmd defines the Cobra subcommands (server, agent, manifest,
+// config) and their Wire provider sets. It bridges configuration,
+// dependency injection, and the transport/application layers.
 package cmd
 
 import (
@@ -11,10 +11,21 @@ import (
 	"github.com/spf13/viper"
 )
 
+// envPrefix is prepended to environment variable names, and
+// envKeyReplacer maps viper keys onto them (e.g.
+// OTTERSCALE_SERVER_ADDRESS for "server.address").
+const envPrefix = "OTTERSCALE"
+
+var envKeyReplacer = strings.NewReplacer(".", "_")
+
 // Config wraps a viper instance and provides typed accessors for every
 // configuration key. Create one via New().
 type Config struct {
 	v *viper.Viper
+
+	// flags records the CLI flag bound to each viper key so that
+	// Effective can report whether a value came from the command line.
+	flags map[string]*pflag.Flag
 }
 
 // New initialises a Config by loading values from the config file,
@@ -47,17 +58,28 @@ func New() (*Config, error) {
 
 	// Environment variables are prefixed with OTTERSCALE_ and use
 	// underscores in place of dots (e.g. OTTERSCALE_SERVER_ADDRESS).
-	v.SetEnvPrefix("OTTERSCALE")
+	v.SetEnvPrefix(envPrefix)
 	v.AutomaticEnv()
-	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.SetEnvKeyReplacer(envKeyReplacer)
 
-	return &Config{v: v}, nil
+	return &Config{v: v, flags: map[string]*pflag.Flag{}}, nil
 }
 
 // BindFlags registers CLI flags for the given option slice and binds
 // them to the underlying viper keys so that flag values override file
 // and environment sources.
 func (c *Config) BindFlags(fs *pflag.FlagSet, options []Option) error {
+	if err := RegisterFlags(fs, options); err != nil {
+		return err
+	}
+	return c.BindRegisteredFlags(fs, options)
+}
+
+// RegisterFlags registers CLI flags for the given option slice
+// without binding them to viper. Commands that share flags with
+// another command call BindRegisteredFlags from their RunE instead,
+// so that only the command actually executing owns the binding.
+func RegisterFlags(fs *pflag.FlagSet, options []Option) error {
 	for _, o := range options {
 		switch v := o.Default.(type) {
 		case string:
@@ -73,12 +95,20 @@ func (c *Config) BindFlags(fs *pflag.FlagSet, options []Option) error {
 		default:
 			return fmt.Errorf("unsupported flag type for key: %s", o.Key)
 		}
+	}
+	return nil
+}
 
-		if err := c.v.BindPFlag(o.Key, fs.Lookup(o.Flag)); err != nil {
+// BindRegisteredFlags binds flags previously registered on fs via
+// RegisterFlags to the underlying viper keys.
+func (c *Config) BindRegisteredFlags(fs *pflag.FlagSet, options []Option) error {
+	for _, o := range options {
+		f := fs.Lookup(o.Flag)
+		if err := c.v.BindPFlag(o.Key, f); err != nil {
 			return fmt.Errorf("failed to bind flag %s: %w", o.Flag, err)
 		}
+		c.flags[o.Key] = f
 	}
-
 	return nil
 }
 
@@ -96,12 +126,49 @@ func (c *Config) ServerAllowedOrigins() []string {
 	return c.v.GetStringSlice(keyServerAllowedOrigins)
 }
 
+// ServerAllowedHeaders returns the request headers allowed by CORS in
+// addition to the Connect protocol defaults.
+func (c *Config) ServerAllowedHeaders() []string {
+	return c.v.GetStringSlice(keyServerAllowedHeaders)
+}
+
+// ServerExposedHeaders returns the response headers exposed by CORS in
+// addition to the Connect protocol defaults.
+func (c *Config) ServerExposedHeaders() []string {
+	return c.v.GetStringSlice(keyServerExposedHeaders)
+}
+
+// ServerTrustedProxies returns the CIDRs or IP addresses of the
+// proxies trusted to report the client IP in forwarding headers.
+func (c *Config) ServerTrustedProxies() []string {
+	return c.v.GetStringSlice(keyServerTrustedProxies)
+}
+
 // ServerTunnelAddress returns the listen address for the chisel tunnel
 // server.
 func (c *Config) ServerTunnelAddress() string {
 	return c.v.GetString(keyServerTunnelAddress)
 }
 
+// ServerTunnelInternalPort returns the loopback port shared by every
+// cluster's reverse tunnel endpoint.
+func (c *Config) ServerTunnelInternalPort() int {
+	return c.v.GetInt(keyServerTunnelInternalPort)
+}
+
+// ServerTunnelCAStore returns the kind of store the CA certificate and
+// private key are persisted in: "file" or "kubernetes".
+func (c *Config) ServerTunnelCAStore() string {
+	return c.v.GetString(keyServerTunnelCAStore)
+}
+
+// ServerTunnelCASecret returns the namespace and name, separated by a
+// slash, of the Kubernetes Secret holding the CA when the CA store is
+// "kubernetes".
+func (c *Config) ServerTunnelCASecret() string {
+	return c.v.GetString(keyServerTunnelCASecret)
+}
+
 // ServerTunnelCADir returns the directory path where the CA
 // certificate and private key are persisted. On first startup the
 // server generates a new CA and writes the material to this
@@ -110,6 +177,55 @@ func (c *Config) ServerTunnelCADir() string {
 	return c.v.GetString(keyServerTunnelCADir)
 }
 
+// ServerTunnelCASubjectOrganization returns the organization (O) of a
+// newly generated CA certificate.
+func (c *Config) ServerTunnelCASubjectOrganization() []string {
+	return c.v.GetStringSlice(keyServerTunnelCASubjectO)
+}
+
+// ServerTunnelCASubjectOrganizationalUnit returns the organizational
+// unit (OU) of a newly generated CA certificate.
+func (c *Config) ServerTunnelCASubjectOrganizationalUnit() []string {
+	return c.v.GetStringSlice(keyServerTunnelCASubjectOU)
+}
+
+// ServerTunnelCASubjectCommonName returns the common name (CN) of a
+// newly generated CA certificate.
+func (c *Config) ServerTunnelCASubjectCommonName() string {
+	return c.v.GetString(keyServerTunnelCASubjectCN)
+}
+
+// ServerTunnelCSRKeyTypes returns the public key types the CA accepts
+// in agent CSRs.
+func (c *Config) ServerTunnelCSRKeyTypes() []string {
+	return c.v.GetStringSlice(keyServerTunnelCSRKeyTypes)
+}
+
+// ServerTunnelCSRMinRSABits returns the minimum RSA key size the CA
+// accepts in agent CSRs.
+func (c *Config) ServerTunnelCSRMinRSABits() int {
+	return c.v.GetInt(keyServerTunnelCSRMinRSABits)
+}
+
+// ServerTunnelTLSMinVersion returns the minimum TLS version the tunnel
+// listener accepts, "1.2" or "1.3".
+func (c *Config) ServerTunnelTLSMinVersion() string {
+	return c.v.GetString(keyServerTunnelTLSMinVersion)
+}
+
+// ServerTunnelTLSCipherSuites returns the names of the TLS 1.2 cipher
+// suites the tunnel listener accepts.
+func (c *Config) ServerTunnelTLSCipherSuites() []string {
+	return c.v.GetStringSlice(keyServerTunnelTLSCiphers)
+}
+
+// ServerTunnelDrainTimeout returns how long a deregistered cluster's
+// in-flight requests are given to finish before its tunnel is torn
+// down.
+func (c *Config) ServerTunnelDrainTimeout() time.Duration {
+	return c.v.GetDuration(keyServerTunnelDrainTimeout)
+}
+
 // ServerKeycloakRealmURL returns the Keycloak realm issuer URL used
 // for OIDC token verification.
 func (c *Config) ServerKeycloakRealmURL() string {
@@ -134,6 +250,298 @@ func (c *Config) ServerExternalTunnelURL() string {
 	return c.v.GetString(keyServerExternalTunnelURL)
 }
 
+// ServerManifestClockSkew returns the tolerated difference between the
+// clocks of the server that issues a signed URL and the one that
+// verifies it.
+func (c *Config) ServerManifestClockSkew() time.Duration {
+	return c.v.GetDuration(keyServerManifestClockSkew)
+}
+
+// ServerManifestNameStrategy returns the name of the strategy used to
+// derive RBAC object names from user identities in generated agent
+// manifests.
+func (c *Config) ServerManifestNameStrategy() string {
+	return c.v.GetString(keyServerManifestNameStrategy)
+}
+
+// ServerClusterAccess returns the group-to-cluster access rules, each
+// of the form "group=cluster". An empty list allows every
+// authenticated user to reach every registered cluster.
+func (c *Config) ServerClusterAccess() []string {
+	return c.v.GetStringSlice(keyServerClusterAccess)
+}
+
+// ServerProxyAllowedPaths returns the API server path prefixes that
+// may be requested through the raw Proxy RPC. An empty list disables
+// the passthrough.
+func (c *Config) ServerProxyAllowedPaths() []string {
+	return c.v.GetStringSlice(keyServerProxyAllowedPaths)
+}
+
+// ServerClusterTimeout returns the default timeout of each API server
+// request to a cluster.
+func (c *Config) ServerClusterTimeout() time.Duration {
+	return c.v.GetDuration(keyServerClusterTimeout)
+}
+
+// ServerClusterTimeouts returns the per-cluster overrides of
+// ServerClusterTimeout, read from server.cluster.<name>.timeout
+// entries of the config file. Keys are cluster names, lower-cased as
+// viper lower-cases all keys.
+func (c *Config) ServerClusterTimeouts() (map[string]time.Duration, error) {
+	overrides := map[string]time.Duration{}
+	for name, entry := range c.v.GetStringMap(keyServerCluster) {
+		settings, ok := entry.(map[string]any)
+		if !ok {
+			continue
+		}
+		if _, ok := settings["timeout"]; !ok {
+			continue
+		}
+		key := keyServerCluster + "." + name + ".timeout"
+		timeout := c.v.GetDuration(key)
+		if timeout <= 0 {
+			return nil, fmt.Errorf("%s: must be a positive duration, got %v", key, settings["timeout"])
+		}
+		overrides[name] = timeout
+	}
+	return overrides, nil
+}
+
+// ServerClusterTransportMaxIdleConns returns the maximum number of
+// idle connections kept by each cluster's HTTP transport.
+func (c *Config) ServerClusterTransportMaxIdleConns() int {
+	return c.v.GetInt(keyServerClusterTransportMaxIdleConns)
+}
+
+// ServerClusterTransportMaxIdleConnsPerHost returns the maximum number
+// of idle connections kept to a cluster's tunnel endpoint.
+func (c *Config) ServerClusterTransportMaxIdleConnsPerHost() int {
+	return c.v.GetInt(keyServerClusterTransportMaxIdleConnsPerHost)
+}
+
+// ServerClusterTransportMaxConnsPerHost returns the maximum number of
+// concurrent connections to a cluster's tunnel endpoint. Zero means
+// unlimited.
+func (c *Config) ServerClusterTransportMaxConnsPerHost() int {
+	return c.v.GetInt(keyServerClusterTransportMaxConnsPerHost)
+}
+
+// ServerClusterTransportIdleConnTimeout returns how long an idle
+// connection to a cluster's tunnel endpoint is kept open.
+func (c *Config) ServerClusterTransportIdleConnTimeout() time.Duration {
+	return c.v.GetDuration(keyServerClusterTransportIdleConnTimeout)
+}
+
+// ServerClusterTransportReconnectWait returns how long a request waits
+// for a cluster's agent to reconnect its tunnel before failing.
+func (c *Config) ServerClusterTransportReconnectWait() time.Duration {
+	return c.v.GetDuration(keyServerClusterTransportReconnectWait)
+}
+
+// ServerClusterTransportReconnectQueueSize returns the maximum number
+// of requests per cluster waiting for its agent to reconnect.
+func (c *Config) ServerClusterTransportReconnectQueueSize() int {
+	return c.v.GetInt(keyServerClusterTransportReconnectQueueSize)
+}
+
+// ServerStreamCompression returns the compression the server
+// negotiates with agents for pod log streams ("gzip" or "none").
+func (c *Config) ServerStreamCompression() string {
+	return c.v.GetString(keyServerStreamCompression)
+}
+
+// ServerStreamKeepAlive returns how long a client connection may be
+// idle before the server pings it. Zero disables pings.
+func (c *Config) ServerStreamKeepAlive() time.Duration {
+	return c.v.GetDuration(keyServerStreamKeepAlive)
+}
+
+// ServerWatchMaxDuration returns how long a Watch stream may run before
+// the server ends it so that the client reconnects. Zero means no
+// limit.
+func (c *Config) ServerWatchMaxDuration() time.Duration {
+	return c.v.GetDuration(keyServerWatchMaxDuration)
+}
+
+// ServerWatchMinResyncInterval returns the shortest periodic resync
+// interval a Watch request may ask for.
+func (c *Config) ServerWatchMinResyncInterval() time.Duration {
+	return c.v.GetDuration(keyServerWatchMinResyncInterval)
+}
+
+// ServerWatchBufferSize returns how many watch events are buffered
+// for a slow client. Zero disables buffering.
+func (c *Config) ServerWatchBufferSize() int {
+	return c.v.GetInt(keyServerWatchBufferSize)
+}
+
+// ServerWatchInitialEvents reports whether watches whose client states
+// no preference stream the initial state on clusters supporting
+// WatchList.
+func (c *Config) ServerWatchInitialEvents() bool {
+	return c.v.GetBool(keyServerWatchInitialEvents)
+}
+
+// ServerWatchOverflow returns what a watch does when its buffer is
+// full ("block" or "drop").
+func (c *Config) ServerWatchOverflow() string {
+	return c.v.GetString(keyServerWatchOverflow)
+}
+
+// ServerPodLogBufferSize returns how many bytes of pod log output are
+// buffered for a slow client. Zero disables buffering.
+func (c *Config) ServerPodLogBufferSize() int {
+	return c.v.GetInt(keyServerPodLogBufferSize)
+}
+
+// ServerMaintenanceEnabled reports whether the server starts in
+// maintenance mode.
+func (c *Config) ServerMaintenanceEnabled() bool {
+	return c.v.GetBool(keyServerMaintenanceEnabled)
+}
+
+// ServerMaintenanceMessage returns the message returned with mutating
+// RPCs rejected in maintenance mode.
+func (c *Config) ServerMaintenanceMessage() string {
+	return c.v.GetString(keyServerMaintenanceMessage)
+}
+
+// ServerMaintenanceAdminGroups returns the groups whose members may
+// toggle maintenance mode at runtime.
+func (c *Config) ServerMaintenanceAdminGroups() []string {
+	return c.v.GetStringSlice(keyServerMaintenanceAdminGroups)
+}
+
+// ServerApplySourceAllowedHosts returns the hosts from which manifests
+// may be applied by URL.
+func (c *Config) ServerApplySourceAllowedHosts() []string {
+	return c.v.GetStringSlice(keyServerApplySourceAllowedHosts)
+}
+
+// ServerApplySourceMaxBytes returns the maximum size of a manifest
+// applied by URL.
+func (c *Config) ServerApplySourceMaxBytes() int64 {
+	return c.v.GetInt64(keyServerApplySourceMaxBytes)
+}
+
+// ServerApplyManifestMaxBytes returns the maximum size of a manifest
+// created or applied.
+func (c *Config) ServerApplyManifestMaxBytes() int64 {
+	return c.v.GetInt64(keyServerApplyManifestMaxBytes)
+}
+
+// ServerApplyManifestMaxDepth returns the maximum nesting depth of the
+// objects of a manifest.
+func (c *Config) ServerApplyManifestMaxDepth() int {
+	return c.v.GetInt(keyServerApplyManifestMaxDepth)
+}
+
+// ServerApplyManifestMaxDocuments returns the maximum number of objects
+// in a multi-document manifest.
+func (c *Config) ServerApplyManifestMaxDocuments() int {
+	return c.v.GetInt(keyServerApplyManifestMaxDocuments)
+}
+
+// ServerApplyReadyConditions returns the status conditions, as
+// Kind.group=Condition, that report applied objects ready.
+func (c *Config) ServerApplyReadyConditions() []string {
+	return c.v.GetStringSlice(keyServerApplyReadyConditions)
+}
+
+// ServerApplyHelmMaxChartBytes returns the maximum size of an uploaded
+// Helm chart archive and of its values.
+func (c *Config) ServerApplyHelmMaxChartBytes() int64 {
+	return c.v.GetInt64(keyServerApplyHelmMaxChartBytes)
+}
+
+// ServerApplyHelmMaxRenderedBytes returns the maximum size of the
+// manifest rendered from a Helm chart.
+func (c *Config) ServerApplyHelmMaxRenderedBytes() int64 {
+	return c.v.GetInt64(keyServerApplyHelmMaxRenderedBytes)
+}
+
+// ServerApplyHelmMaxDecompressedBytes returns the maximum size of an
+// uploaded Helm chart archive once decompressed.
+func (c *Config) ServerApplyHelmMaxDecompressedBytes() int64 {
+	return c.v.GetInt64(keyServerApplyHelmMaxDecompressedBytes)
+}
+
+// ServerApplyHelmRenderTimeout returns how long a request waits for a
+// Helm chart to render.
+func (c *Config) ServerApplyHelmRenderTimeout() time.Duration {
+	return c.v.GetDuration(keyServerApplyHelmRenderTimeout)
+}
+
+// ServerApplyHelmMaxConcurrentRenders returns the maximum number of
+// Helm charts rendering at once.
+func (c *Config) ServerApplyHelmMaxConcurrentRenders() int {
+	return c.v.GetInt(keyServerApplyHelmMaxConcurrentRenders)
+}
+
+// ServerDiscoveryEvictionInterval returns how often expired entries are
+// evicted from the discovery cache.
+func (c *Config) ServerDiscoveryEvictionInterval() time.Duration {
+	return c.v.GetDuration(keyServerDiscoveryEvictionInterval)
+}
+
+// ServerDiscoveryOpenAPITTL returns how long the OpenAPI v3 document
+// of a group-version is cached.
+func (c *Config) ServerDiscoveryOpenAPITTL() time.Duration {
+	return c.v.GetDuration(keyServerDiscoveryOpenAPITTL)
+}
+
+// ServerDefaultNamespace returns the namespace listed or watched when
+// a request for a namespaced resource names none. Empty treats an
+// omitted namespace as all namespaces.
+func (c *Config) ServerDefaultNamespace() string {
+	return c.v.GetString(keyServerDefaultNamespace)
+}
+
+// ServerApplyFieldManagerPrefix returns the prefix of the field manager
+// derived from the caller's subject for server-side applies that do not
+// name one.
+func (c *Config) ServerApplyFieldManagerPrefix() string {
+	return c.v.GetString(keyServerApplyFieldManagerPrefix)
+}
+
+// ServerApplyManagedByLabel returns the label key with which objects
+// created or applied through otterscale are tagged. Empty disables the
+// tagging.
+func (c *Config) ServerApplyManagedByLabel() string {
+	return c.v.GetString(keyServerApplyManagedByLabel)
+}
+
+// ServerApplyIdempotencyTTL returns how long the result of a create
+// with an idempotency key is remembered.
+func (c *Config) ServerApplyIdempotencyTTL() time.Duration {
+	return c.v.GetDuration(keyServerApplyIdempotencyTTL)
+}
+
+// ServerFleetWebhookURL returns the URL notified of cluster
+// registrations and deregistrations. Empty disables the webhook.
+func (c *Config) ServerFleetWebhookURL() string {
+	return c.v.GetString(keyServerFleetWebhookURL)
+}
+
+// ServerFleetMaxClusters returns the maximum number of registered
+// clusters. Zero means unlimited.
+func (c *Config) ServerFleetMaxClusters() int {
+	return c.v.GetInt(keyServerFleetMaxClusters)
+}
+
+// ServerBackgroundJitterPercent returns the percentage by which the
+// intervals of periodic background loops randomly vary.
+func (c *Config) ServerBackgroundJitterPercent() int {
+	return c.v.GetInt(keyServerBackgroundJitterPercent)
+}
+
+// ServerReadyzMinClusters returns the number of connected clusters
+// required before the server reports ready. Zero means none.
+func (c *Config) ServerReadyzMinClusters() int {
+	return c.v.GetInt(keyServerReadyzMinClusters)
+}
+
 // ---------------------------------------------------------------------------
 // Agent-mode accessors
 // ---------------------------------------------------------------------------
@@ -143,6 +551,18 @@ func (c *Config) AgentCluster() string {
 	return c.v.GetString(keyAgentCluster)
 }
 
+// AgentID returns the configured agent ID. An empty value means the
+// ID is resolved from the persisted ID file or the hostname.
+func (c *Config) AgentID() string {
+	return c.v.GetString(keyAgentID)
+}
+
+// AgentIDFile returns the path of the file in which the agent ID is
+// persisted across restarts.
+func (c *Config) AgentIDFile() string {
+	return c.v.GetString(keyAgentIDFile)
+}
+
 // AgentServerURL returns the fleet server URL the agent registers
 // against.
 func (c *Config) AgentServerURL() string {
@@ -155,8 +575,79 @@ func (c *Config) AgentTunnelServerURL() string {
 	return c.v.GetString(keyAgentTunnelServerURL)
 }
 
+// AgentTunnelFallbackServerURLs returns the tunnel server URLs the
+// agent tries, in order, when it cannot connect to the primary
+// tunnel server URL.
+func (c *Config) AgentTunnelFallbackServerURLs() []string {
+	return c.v.GetStringSlice(keyAgentTunnelFallbackServerURLs)
+}
+
+// AgentTunnelFingerprint returns the statically pinned SSH fingerprint
+// of the tunnel server. An empty value means the agent trusts the
+// fingerprint reported by the fleet server at registration.
+func (c *Config) AgentTunnelFingerprint() string {
+	return c.v.GetString(keyAgentTunnelFingerprint)
+}
+
+// AgentRegisterMaxAttempts returns how many consecutive registrations
+// may fail before the agent gives up and exits. Zero retries forever.
+func (c *Config) AgentRegisterMaxAttempts() int {
+	return c.v.GetInt(keyAgentRegisterMaxAttempts)
+}
+
 // AgentBootstrap returns whether the agent should run the Layer 0
 // bootstrap process on startup, installing FluxCD and the Module CRD.
 func (c *Config) AgentBootstrap() bool {
 	return c.v.GetBool(keyAgentBootstrap)
 }
+
+// BootstrapCRDPollInterval returns the initial interval between CRD
+// status polls during bootstrap. The interval doubles after each poll
+// up to BootstrapCRDMaxPollInterval.
+func (c *Config) BootstrapCRDPollInterval() time.Duration {
+	return c.v.GetDuration(keyBootstrapCRDPollInterval)
+}
+
+// BootstrapCRDMaxPollInterval returns the upper bound of the CRD poll
+// interval during bootstrap.
+func (c *Config) BootstrapCRDMaxPollInterval() time.Duration {
+	return c.v.GetDuration(keyBootstrapCRDMaxPollInterval)
+}
+
+// BootstrapCRDTimeout returns how long bootstrap waits for each CRD to
+// become established.
+func (c *Config) BootstrapCRDTimeout() time.Duration {
+	return c.v.GetDuration(keyBootstrapCRDTimeout)
+}
+
+// AgentProxyAllowHeaders returns the response header names the agent
+// forwards from proxied kube-apiserver responses. When non-empty, all
+// other headers except the ones clients need are stripped.
+func (c *Config) AgentProxyAllowHeaders() []string {
+	return c.v.GetStringSlice(keyAgentProxyAllowHeaders)
+}
+
+// AgentProxyStripHeaders returns the response header names the agent
+// strips from proxied kube-apiserver responses in addition to
+// hop-by-hop headers.
+func (c *Config) AgentProxyStripHeaders() []string {
+	return c.v.GetStringSlice(keyAgentProxyStripHeaders)
+}
+
+// AgentProxyCAFile returns the CA bundle with which the agent verifies
+// the kube-apiserver, or "" to use the in-cluster CA.
+func (c *Config) AgentProxyCAFile() string {
+	return c.v.GetString(keyAgentProxyCAFile)
+}
+
+// AgentProxyInsecureSkipVerify reports whether the agent skips
+// verification of the kube-apiserver's certificate.
+func (c *Config) AgentProxyInsecureSkipVerify() bool {
+	return c.v.GetBool(keyAgentProxyInsecureSkipVerify)
+}
+
+// AgentHealthAddress returns the listen address for the agent's
+// /healthz and /metrics endpoint. An empty value disables it.
+func (c *Config) AgentHealthAddress() string {
+	return c.v.GetString(keyAgentHealthAddress)
+}
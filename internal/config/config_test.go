@@ -0,0 +1,60 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestServerClusterTimeouts(t *testing.T) {
+	t.Chdir(t.TempDir())
+	file := `server:
+  cluster:
+    timeout: 20s
+    transport:
+      max_idle_conns: 10
+    ap-south:
+      timeout: 2m
+    edge-1:
+      timeout: 45s
+`
+	if err := os.WriteFile("config.yaml", []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	c, err := New()
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if got := c.ServerClusterTimeout(); got != 20*time.Second {
+		t.Errorf("ServerClusterTimeout = %v, want 20s", got)
+	}
+	overrides, err := c.ServerClusterTimeouts()
+	if err != nil {
+		t.Fatalf("ServerClusterTimeouts: %v", err)
+	}
+	want := map[string]time.Duration{"ap-south": 2 * time.Minute, "edge-1": 45 * time.Second}
+	if len(overrides) != len(want) {
+		t.Fatalf("overrides = %v, want %v", overrides, want)
+	}
+	for name, timeout := range want {
+		if overrides[name] != timeout {
+			t.Errorf("overrides[%q] = %v, want %v", name, overrides[name], timeout)
+		}
+	}
+}
+
+func TestServerClusterTimeouts_RejectsInvalid(t *testing.T) {
+	t.Chdir(t.TempDir())
+	file := "server:\n  cluster:\n    edge-1:\n      timeout: soon\n"
+	if err := os.WriteFile("config.yaml", []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	c, err := New()
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, err := c.ServerClusterTimeouts(); err == nil {
+		t.Fatal("expected an error for an unparsable timeout")
+	}
+}
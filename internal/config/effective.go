@@ -0,0 +1,130 @@
+package config
+
+import (
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Source identifies where the effective value of a configuration key
+// came from.
+type Source string
+
+// Sources in ascending order of precedence.
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceEnv     Source = "env"
+	SourceFlag    Source = "flag"
+)
+
+// redactedValue replaces the value of secret options in effective
+// configuration dumps.
+const redactedValue = "<redacted>"
+
+// Setting is the fully-resolved value of a single configuration key
+// together with the source it was resolved from.
+type Setting struct {
+	Key    string `json:"-"`
+	Value  any    `json:"value"`
+	Source Source `json:"source"`
+}
+
+// Effective returns the resolved value and source of every option in
+// options, in the same order. Values of options marked Secret are
+// redacted unless they are empty, and so are passwords embedded in
+// URL values.
+func (c *Config) Effective(options []Option) []Setting {
+	settings := make([]Setting, 0, len(options))
+	for _, o := range options {
+		value := redactURLPasswords(c.typedValue(o))
+		if o.Secret && !isZero(value) {
+			value = redactedValue
+		}
+		settings = append(settings, Setting{
+			Key:    o.Key,
+			Value:  value,
+			Source: c.source(o.Key),
+		})
+	}
+	return settings
+}
+
+// typedValue reads the option's value using the getter matching the
+// type of its compiled default, so that environment strings are
+// reported in the same shape as the accessors return them.
+func (c *Config) typedValue(o Option) any {
+	switch o.Default.(type) {
+	case string:
+		return c.v.GetString(o.Key)
+	case int:
+		return c.v.GetInt(o.Key)
+	case bool:
+		return c.v.GetBool(o.Key)
+	case []string:
+		return c.v.GetStringSlice(o.Key)
+	case time.Duration:
+		return c.v.GetDuration(o.Key).String()
+	default:
+		return c.v.Get(o.Key)
+	}
+}
+
+// source reports which layer supplies the value of key, mirroring
+// viper's resolution order: flag, env, file, default.
+func (c *Config) source(key string) Source {
+	if f, ok := c.flags[key]; ok && f.Changed {
+		return SourceFlag
+	}
+	if _, ok := os.LookupEnv(envName(key)); ok {
+		return SourceEnv
+	}
+	if c.v.InConfig(key) {
+		return SourceFile
+	}
+	return SourceDefault
+}
+
+// envName returns the environment variable viper consults for key
+// (e.g. "server.tunnel.address" -> "OTTERSCALE_SERVER_TUNNEL_ADDRESS").
+func envName(key string) string {
+	return envPrefix + "_" + strings.ToUpper(envKeyReplacer.Replace(key))
+}
+
+// redactURLPasswords replaces the password of string values, or
+// elements of string slice values, that are URLs with userinfo, such
+// as a server URL carrying basic auth credentials.
+func redactURLPasswords(v any) any {
+	redact := func(s string) string {
+		u, err := url.Parse(s)
+		if err != nil || u.User == nil {
+			return s
+		}
+		return u.Redacted()
+	}
+	switch v := v.(type) {
+	case string:
+		return redact(v)
+	case []string:
+		redacted := make([]string, len(v))
+		for i, s := range v {
+			redacted[i] = redact(s)
+		}
+		return redacted
+	default:
+		return v
+	}
+}
+
+// isZero reports whether v is an empty string or slice.
+func isZero(v any) bool {
+	switch v := v.(type) {
+	case string:
+		return v == ""
+	case []string:
+		return len(v) == 0
+	default:
+		return v == nil
+	}
+}
@@ -10,20 +10,97 @@ package config
 
 // Viper keys for server-mode configuration.
 const (
-	keyServerAddress          = "server.address"
-	keyServerAllowedOrigins   = "server.allowed_origins"
-	keyServerTunnelAddress    = "server.tunnel.address"
-	keyServerTunnelCADir      = "server.tunnel.ca_dir"
-	keyServerKeycloakRealmURL  = "server.keycloak.realm_url"
-	keyServerKeycloakClientID  = "server.keycloak.client_id"
-	keyServerExternalURL       = "server.external_url"
-	keyServerExternalTunnelURL = "server.external_tunnel_url"
+	keyServerAddress             = "server.address"
+	keyServerAllowedOrigins      = "server.allowed_origins"
+	keyServerAllowedHeaders      = "server.allowed_headers"
+	keyServerExposedHeaders      = "server.exposed_headers"
+	keyServerTrustedProxies      = "server.trusted_proxies"
+	keyServerTunnelAddress       = "server.tunnel.address"
+	keyServerTunnelInternalPort  = "server.tunnel.internal_port"
+	keyServerTunnelCAStore       = "server.tunnel.ca_store"
+	keyServerTunnelCADir         = "server.tunnel.ca_dir"
+	keyServerTunnelCASecret      = "server.tunnel.ca_secret"
+	keyServerTunnelCASubjectO    = "server.tunnel.ca_subject.organization"
+	keyServerTunnelCASubjectOU   = "server.tunnel.ca_subject.organizational_unit"
+	keyServerTunnelCASubjectCN   = "server.tunnel.ca_subject.common_name"
+	keyServerTunnelCSRKeyTypes   = "server.tunnel.csr_key_types"
+	keyServerTunnelCSRMinRSABits = "server.tunnel.csr_min_rsa_bits"
+	keyServerTunnelTLSMinVersion = "server.tunnel.tls.min_version"
+	keyServerTunnelTLSCiphers    = "server.tunnel.tls.cipher_suites"
+	keyServerTunnelDrainTimeout  = "server.tunnel.drain_timeout"
+	keyServerKeycloakRealmURL    = "server.keycloak.realm_url"
+	keyServerKeycloakClientID    = "server.keycloak.client_id"
+	keyServerExternalURL         = "server.external_url"
+	keyServerExternalTunnelURL   = "server.external_tunnel_url"
+
+	keyServerManifestNameStrategy = "server.manifest.name_strategy"
+	keyServerManifestClockSkew    = "server.manifest.clock_skew"
+	keyServerClusterAccess        = "server.cluster_access"
+	keyServerProxyAllowedPaths    = "server.proxy.allowed_paths"
+
+	keyServerCluster                             = "server.cluster"
+	keyServerClusterTimeout                      = "server.cluster.timeout"
+	keyServerClusterTransportMaxIdleConns        = "server.cluster.transport.max_idle_conns"
+	keyServerClusterTransportMaxIdleConnsPerHost = "server.cluster.transport.max_idle_conns_per_host"
+	keyServerClusterTransportMaxConnsPerHost     = "server.cluster.transport.max_conns_per_host"
+	keyServerClusterTransportIdleConnTimeout     = "server.cluster.transport.idle_conn_timeout"
+	keyServerClusterTransportReconnectWait       = "server.cluster.transport.reconnect_wait"
+	keyServerClusterTransportReconnectQueueSize  = "server.cluster.transport.reconnect_queue_size"
+	keyServerStreamCompression                   = "server.stream.compression"
+	keyServerStreamKeepAlive                     = "server.stream.keepalive"
+	keyServerWatchMaxDuration                    = "server.watch.max_duration"
+	keyServerWatchMinResyncInterval              = "server.watch.min_resync_interval"
+	keyServerWatchBufferSize                     = "server.watch.buffer_size"
+	keyServerWatchOverflow                       = "server.watch.overflow"
+	keyServerWatchInitialEvents                  = "server.watch.initial_events"
+	keyServerPodLogBufferSize                    = "server.pod_log.buffer_size"
+	keyServerMaintenanceEnabled                  = "server.maintenance.enabled"
+	keyServerMaintenanceMessage                  = "server.maintenance.message"
+	keyServerMaintenanceAdminGroups              = "server.maintenance.admin_groups"
+	keyServerDiscoveryEvictionInterval           = "server.discovery.eviction_interval"
+	keyServerDiscoveryOpenAPITTL                 = "server.discovery.openapi_ttl"
+	keyServerApplyFieldManagerPrefix             = "server.apply.field_manager_prefix"
+	keyServerApplyManagedByLabel                 = "server.apply.managed_by_label"
+	keyServerApplyIdempotencyTTL                 = "server.apply.idempotency_ttl"
+	keyServerApplySourceAllowedHosts             = "server.apply.source.allowed_hosts"
+	keyServerApplySourceMaxBytes                 = "server.apply.source.max_bytes"
+	keyServerApplyManifestMaxBytes               = "server.apply.manifest.max_bytes"
+	keyServerApplyManifestMaxDepth               = "server.apply.manifest.max_depth"
+	keyServerApplyManifestMaxDocuments           = "server.apply.manifest.max_documents"
+	keyServerApplyReadyConditions                = "server.apply.ready_conditions"
+	keyServerApplyHelmMaxChartBytes              = "server.apply.helm.max_chart_bytes"
+	keyServerApplyHelmMaxRenderedBytes           = "server.apply.helm.max_rendered_bytes"
+	keyServerApplyHelmMaxDecompressedBytes       = "server.apply.helm.max_decompressed_bytes"
+	keyServerApplyHelmRenderTimeout              = "server.apply.helm.render_timeout"
+	keyServerApplyHelmMaxConcurrentRenders       = "server.apply.helm.max_concurrent_renders"
+	keyServerDefaultNamespace                    = "server.default_namespace"
+	keyServerFleetWebhookURL                     = "server.fleet.webhook_url"
+	keyServerFleetMaxClusters                    = "server.fleet.max_clusters"
+	keyServerReadyzMinClusters                   = "server.readyz.min_clusters"
+	keyServerBackgroundJitterPercent             = "server.background.jitter_percent"
 )
 
 // Viper keys for agent-mode configuration.
 const (
-	keyAgentCluster         = "agent.cluster"
-	keyAgentServerURL       = "agent.server_url"
-	keyAgentTunnelServerURL = "agent.tunnel.server_url"
-	keyAgentBootstrap       = "agent.bootstrap"
+	keyAgentCluster                  = "agent.cluster"
+	keyAgentID                       = "agent.id"
+	keyAgentIDFile                   = "agent.id_file"
+	keyAgentServerURL                = "agent.server_url"
+	keyAgentTunnelServerURL          = "agent.tunnel.server_url"
+	keyAgentTunnelFallbackServerURLs = "agent.tunnel.fallback_server_urls"
+	keyAgentTunnelFingerprint        = "agent.tunnel.fingerprint"
+	keyAgentRegisterMaxAttempts      = "agent.register.max_attempts"
+	keyAgentBootstrap                = "agent.bootstrap"
+	keyAgentProxyAllowHeaders        = "agent.proxy.allow_headers"
+	keyAgentProxyStripHeaders        = "agent.proxy.strip_headers"
+	keyAgentProxyCAFile              = "agent.proxy.ca_file"
+	keyAgentProxyInsecureSkipVerify  = "agent.proxy.insecure_skip_verify"
+	keyAgentHealthAddress            = "agent.health.address"
+)
+
+// Viper keys for the agent's Layer 0 bootstrap.
+const (
+	keyBootstrapCRDPollInterval    = "bootstrap.crd_poll_interval"
+	keyBootstrapCRDMaxPollInterval = "bootstrap.crd_max_poll_interval"
+	keyBootstrapCRDTimeout         = "bootstrap.crd_timeout"
 )
@@ -2,16 +2,19 @@ package config
 
 import (
 	"strings"
+	"time"
 )
 
 // Option describes a single configuration entry: its viper key, the
 // corresponding CLI flag name, the compiled default, and a
-// human-readable description shown in --help output.
+// human-readable description shown in --help output. Secret options
+// have their values redacted from effective configuration dumps.
 type Option struct {
 	Key         string
 	Flag        string
 	Default     any
 	Description string
+	Secret      bool
 }
 
 // ServerOptions defines the configuration entries available in server
@@ -19,21 +22,91 @@ type Option struct {
 var ServerOptions = []Option{
 	{Key: keyServerAddress, Flag: toFlag(keyServerAddress), Default: ":8299", Description: "Server listen address"},
 	{Key: keyServerAllowedOrigins, Flag: toFlag(keyServerAllowedOrigins), Default: []string{}, Description: "Server allowed origins"},
+	{Key: keyServerAllowedHeaders, Flag: toFlag(keyServerAllowedHeaders), Default: []string{}, Description: "Request headers allowed by CORS in addition to the Connect, gRPC and gRPC-Web headers (e.g. X-Request-Id)"},
+	{Key: keyServerExposedHeaders, Flag: toFlag(keyServerExposedHeaders), Default: []string{}, Description: "Response headers exposed by CORS in addition to the Connect, gRPC and gRPC-Web headers"},
+	{Key: keyServerTrustedProxies, Flag: toFlag(keyServerTrustedProxies), Default: []string{}, Description: "CIDRs or IP addresses of proxies whose X-Forwarded-For and Forwarded headers are trusted to carry the client IP (headers are ignored when empty)"},
 	{Key: keyServerTunnelAddress, Flag: toFlag(keyServerTunnelAddress), Default: "127.0.0.1:8300", Description: "Server tunnel address"},
-	{Key: keyServerTunnelCADir, Flag: toFlag(keyServerTunnelCADir), Default: "/var/lib/otterscale/ca", Description: "Directory for persistent CA certificate and key"},
+	{Key: keyServerTunnelInternalPort, Flag: toFlag(keyServerTunnelInternalPort), Default: 16598, Description: "Loopback port on which the server reaches every cluster's reverse tunnel"},
+	{Key: keyServerTunnelCAStore, Flag: toFlag(keyServerTunnelCAStore), Default: "file", Description: "Where the CA certificate and key are persisted (file, kubernetes)"},
+	{Key: keyServerTunnelCADir, Flag: toFlag(keyServerTunnelCADir), Default: "/var/lib/otterscale/ca", Description: "Directory for persistent CA certificate and key when the CA store is file"},
+	{Key: keyServerTunnelCASecret, Flag: toFlag(keyServerTunnelCASecret), Default: "otterscale-system/otterscale-ca", Description: "Kubernetes Secret, as namespace/name, holding the CA certificate and key when the CA store is kubernetes"},
+	{Key: keyServerTunnelCASubjectO, Flag: toFlag(keyServerTunnelCASubjectO), Default: []string{"otterscale"}, Description: "Organization (O) of the tunnel CA certificate, used when a new CA is generated"},
+	{Key: keyServerTunnelCASubjectOU, Flag: toFlag(keyServerTunnelCASubjectOU), Default: []string{}, Description: "Organizational unit (OU) of the tunnel CA certificate, used when a new CA is generated"},
+	{Key: keyServerTunnelCASubjectCN, Flag: toFlag(keyServerTunnelCASubjectCN), Default: "otterscale-ca", Description: "Common name (CN) of the tunnel CA certificate, used when a new CA is generated"},
+	{Key: keyServerTunnelCSRKeyTypes, Flag: toFlag(keyServerTunnelCSRKeyTypes), Default: []string{"ecdsa-p256", "ecdsa-p384", "ed25519", "rsa"}, Description: "Public key types accepted in agent CSRs (ecdsa-p256, ecdsa-p384, ed25519, rsa)"},
+	{Key: keyServerTunnelCSRMinRSABits, Flag: toFlag(keyServerTunnelCSRMinRSABits), Default: 2048, Description: "Minimum RSA key size accepted in agent CSRs when rsa is allowed (at least 2048)"},
+	{Key: keyServerTunnelTLSMinVersion, Flag: toFlag(keyServerTunnelTLSMinVersion), Default: "1.2", Description: "Minimum TLS version accepted from agents on the tunnel listener (1.2 or 1.3)"},
+	{Key: keyServerTunnelDrainTimeout, Flag: toFlag(keyServerTunnelDrainTimeout), Default: 5 * time.Second, Description: "How long requests in flight through a deregistered cluster's tunnel may finish, while new ones are refused, before the tunnel is torn down (0 = immediately)"},
+	{Key: keyServerTunnelTLSCiphers, Flag: toFlag(keyServerTunnelTLSCiphers), Default: []string{}, Description: "TLS 1.2 cipher suites accepted on the tunnel listener by IANA name (e.g. TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384); empty selects Go's secure defaults"},
 	{Key: keyServerKeycloakRealmURL, Flag: toFlag(keyServerKeycloakRealmURL), Default: "", Description: "Server keycloak realm url (required)"},
 	{Key: keyServerKeycloakClientID, Flag: toFlag(keyServerKeycloakClientID), Default: "otterscale-server", Description: "Server keycloak client id"},
 	{Key: keyServerExternalURL, Flag: toFlag(keyServerExternalURL), Default: "", Description: "Externally reachable server URL for agent connections (required for manifest generation)"},
 	{Key: keyServerExternalTunnelURL, Flag: toFlag(keyServerExternalTunnelURL), Default: "", Description: "Externally reachable tunnel URL for agent tunnel connections (required for manifest generation)"},
+	{Key: keyServerManifestNameStrategy, Flag: toFlag(keyServerManifestNameStrategy), Default: "sanitize", Description: "Strategy for deriving manifest RBAC names from user identities (sanitize, email-localpart)"},
+	{Key: keyServerManifestClockSkew, Flag: toFlag(keyServerManifestClockSkew), Default: 5 * time.Minute, Description: "Tolerated clock difference between server replicas when verifying signed manifest and kubeconfig URLs"},
+	{Key: keyServerClusterAccess, Flag: toFlag(keyServerClusterAccess), Default: []string{}, Description: "Group-based cluster access rules as group=cluster (cluster may be *); empty allows all users to reach all clusters; service tokens are checked as the group otterscale:services"},
+	{Key: keyServerProxyAllowedPaths, Flag: toFlag(keyServerProxyAllowedPaths), Default: []string{}, Description: "API server path prefixes reachable through the read-only Proxy RPC; empty disables it"},
+	{Key: keyServerClusterTimeout, Flag: toFlag(keyServerClusterTimeout), Default: 30 * time.Second, Description: "Timeout of each API server request to a cluster; override it per cluster with server.cluster.<name>.timeout in the config file"},
+	{Key: keyServerClusterTransportMaxIdleConns, Flag: toFlag(keyServerClusterTransportMaxIdleConns), Default: 100, Description: "Maximum idle connections kept per cluster transport"},
+	{Key: keyServerClusterTransportMaxIdleConnsPerHost, Flag: toFlag(keyServerClusterTransportMaxIdleConnsPerHost), Default: 32, Description: "Maximum idle connections kept to each cluster's tunnel endpoint"},
+	{Key: keyServerClusterTransportMaxConnsPerHost, Flag: toFlag(keyServerClusterTransportMaxConnsPerHost), Default: 0, Description: "Maximum concurrent connections to each cluster's tunnel endpoint (0 = unlimited)"},
+	{Key: keyServerClusterTransportIdleConnTimeout, Flag: toFlag(keyServerClusterTransportIdleConnTimeout), Default: 90 * time.Second, Description: "How long an idle cluster connection is kept before closing"},
+	{Key: keyServerClusterTransportReconnectWait, Flag: toFlag(keyServerClusterTransportReconnectWait), Default: 5 * time.Second, Description: "How long a non-streaming request waits for a cluster's agent to reconnect its tunnel before failing (0 = fail immediately)"},
+	{Key: keyServerClusterTransportReconnectQueueSize, Flag: toFlag(keyServerClusterTransportReconnectQueueSize), Default: 64, Description: "Maximum requests per cluster waiting for its agent to reconnect; further requests fail with unavailable"},
+	{Key: keyServerStreamCompression, Flag: toFlag(keyServerStreamCompression), Default: "gzip", Description: "Compression negotiated with agents for pod log streams over the tunnel (gzip or none)"},
+	{Key: keyServerStreamKeepAlive, Flag: toFlag(keyServerStreamKeepAlive), Default: 30 * time.Second, Description: "Interval of HTTP/2 pings sent on idle client connections so that proxies keep long-lived streams open (0 = disabled)"},
+	{Key: keyServerWatchMaxDuration, Flag: toFlag(keyServerWatchMaxDuration), Default: 30 * time.Minute, Description: "Maximum lifetime of a Watch stream before the server ends it with a resumable bookmark (0 = unlimited)"},
+	{Key: keyServerWatchMinResyncInterval, Flag: toFlag(keyServerWatchMinResyncInterval), Default: time.Minute, Description: "Shortest periodic resync interval a Watch request may ask for"},
+	{Key: keyServerWatchBufferSize, Flag: toFlag(keyServerWatchBufferSize), Default: 64, Description: "Watch events buffered between a cluster's API server and a slow client (0 = unbuffered)"},
+	{Key: keyServerWatchInitialEvents, Flag: toFlag(keyServerWatchInitialEvents), Default: true, Description: "Whether watches without a resource version stream the initial state on clusters supporting WatchList, unless the client states a preference"},
+	{Key: keyServerWatchOverflow, Flag: toFlag(keyServerWatchOverflow), Default: "block", Description: "What a watch does when its buffer is full: block (slow down reading from the API server) or drop (end the watch as expired, so that the client relists; requires a positive buffer size)"},
+	{Key: keyServerPodLogBufferSize, Flag: toFlag(keyServerPodLogBufferSize), Default: 1 << 20, Description: "Bytes of followed pod log output buffered for a slow client before the oldest lines are dropped (0 = unbuffered)"},
+	{Key: keyServerMaintenanceEnabled, Flag: toFlag(keyServerMaintenanceEnabled), Default: false, Description: "Start in maintenance mode, rejecting mutating RPCs fleet-wide while reads are served"},
+	{Key: keyServerMaintenanceMessage, Flag: toFlag(keyServerMaintenanceMessage), Default: "", Description: "Message returned with mutating RPCs rejected in maintenance mode (empty = a generic notice)"},
+	{Key: keyServerMaintenanceAdminGroups, Flag: toFlag(keyServerMaintenanceAdminGroups), Default: []string{}, Description: "Groups whose members may toggle maintenance mode at runtime with SetMaintenance (empty = configuration only)"},
+	{Key: keyServerDefaultNamespace, Flag: toFlag(keyServerDefaultNamespace), Default: "default", Description: "Namespace listed or watched when a request for a namespaced resource names none and does not set all_namespaces (empty treats an omitted namespace as all namespaces)"},
+	{Key: keyServerDiscoveryEvictionInterval, Flag: toFlag(keyServerDiscoveryEvictionInterval), Default: 5 * time.Minute, Description: "Interval at which expired entries are evicted from the discovery cache"},
+	{Key: keyServerDiscoveryOpenAPITTL, Flag: toFlag(keyServerDiscoveryOpenAPITTL), Default: 10 * time.Minute, Description: "How long the OpenAPI v3 document of each cluster group-version is cached"},
+	{Key: keyServerApplyFieldManagerPrefix, Flag: toFlag(keyServerApplyFieldManagerPrefix), Default: "otterscale:", Description: "Prefix of the field manager derived from the caller's subject for server-side applies that do not name one"},
+	{Key: keyServerApplyManagedByLabel, Flag: toFlag(keyServerApplyManagedByLabel), Default: "app.kubernetes.io/managed-by", Description: "Label set to otterscale on objects created or applied through otterscale, alongside an annotation naming the creating subject, unless the request opts out (empty = disabled)"},
+	{Key: keyServerApplyIdempotencyTTL, Flag: toFlag(keyServerApplyIdempotencyTTL), Default: 10 * time.Minute, Description: "How long the object created by a Create with an idempotency key is returned to retries with the same key"},
+	{Key: keyServerApplySourceAllowedHosts, Flag: toFlag(keyServerApplySourceAllowedHosts), Default: []string{}, Description: "Hosts from which manifests may be applied by URL (e.g. \"raw.githubusercontent.com\", \"*.example.com\"); empty disables URL sources"},
+	{Key: keyServerApplySourceMaxBytes, Flag: toFlag(keyServerApplySourceMaxBytes), Default: 4 << 20, Description: "Maximum size in bytes of a manifest applied by URL"},
+	{Key: keyServerApplyManifestMaxBytes, Flag: toFlag(keyServerApplyManifestMaxBytes), Default: 16 << 20, Description: "Maximum size in bytes of a manifest created or applied, checked before it is decoded"},
+	{Key: keyServerApplyManifestMaxDepth, Flag: toFlag(keyServerApplyManifestMaxDepth), Default: 100, Description: "Maximum nesting depth of maps and lists in each object of a manifest"},
+	{Key: keyServerApplyManifestMaxDocuments, Flag: toFlag(keyServerApplyManifestMaxDocuments), Default: 1000, Description: "Maximum number of objects in a multi-document manifest"},
+	{Key: keyServerApplyReadyConditions, Flag: toFlag(keyServerApplyReadyConditions), Default: []string{"Job.batch=Complete"}, Description: "Status conditions, as Kind.group=Condition, that report applied objects of a kind ready when an apply waits for readiness (Deployments, StatefulSets and DaemonSets otherwise wait for their rollout)"},
+	{Key: keyServerApplyHelmMaxChartBytes, Flag: toFlag(keyServerApplyHelmMaxChartBytes), Default: 4 << 20, Description: "Maximum size in bytes of an uploaded Helm chart archive, and of its values"},
+	{Key: keyServerApplyHelmMaxRenderedBytes, Flag: toFlag(keyServerApplyHelmMaxRenderedBytes), Default: 16 << 20, Description: "Maximum size in bytes of the manifest rendered from a Helm chart"},
+	{Key: keyServerApplyHelmMaxDecompressedBytes, Flag: toFlag(keyServerApplyHelmMaxDecompressedBytes), Default: 32 << 20, Description: "Maximum size in bytes of an uploaded Helm chart archive once decompressed"},
+	{Key: keyServerApplyHelmRenderTimeout, Flag: toFlag(keyServerApplyHelmRenderTimeout), Default: 30 * time.Second, Description: "Maximum time a request waits for a Helm chart to render"},
+	{Key: keyServerApplyHelmMaxConcurrentRenders, Flag: toFlag(keyServerApplyHelmMaxConcurrentRenders), Default: 4, Description: "Maximum number of Helm charts rendering at once, counting renders whose request timed out but that are still running"},
+	{Key: keyServerFleetWebhookURL, Flag: toFlag(keyServerFleetWebhookURL), Default: "", Description: "URL that receives a JSON POST whenever a cluster registers or deregisters (empty = disabled)", Secret: true},
+	{Key: keyServerReadyzMinClusters, Flag: toFlag(keyServerReadyzMinClusters), Default: 0, Description: "Number of connected clusters required before /readyz reports ready (0 = ready without any)"},
+	{Key: keyServerFleetMaxClusters, Flag: toFlag(keyServerFleetMaxClusters), Default: 10000, Description: "Maximum number of registered clusters; registrations of further clusters fail with resource exhausted (0 = unlimited)"},
+	{Key: keyServerBackgroundJitterPercent, Flag: toFlag(keyServerBackgroundJitterPercent), Default: 10, Description: "Percentage by which the intervals of the session reaper, discovery cache evictor and tunnel health checks randomly vary, so that replicas do not run them in lockstep (0 = fixed, at most 50)"},
 }
 
 // AgentOptions defines the configuration entries available in agent
 // mode.
 var AgentOptions = []Option{
 	{Key: keyAgentCluster, Flag: toFlag(keyAgentCluster), Default: "default", Description: "Agent cluster"},
+	{Key: keyAgentID, Flag: toFlag(keyAgentID), Default: "", Description: "Agent ID reported at registration; empty uses the persisted ID, then the hostname"},
+	{Key: keyAgentIDFile, Flag: toFlag(keyAgentIDFile), Default: "/var/lib/otterscale/agent-id", Description: "File in which the agent ID is persisted across restarts"},
 	{Key: keyAgentServerURL, Flag: toFlag(keyAgentServerURL), Default: "http://127.0.0.1:8299", Description: "Agent control-plane server url"},
 	{Key: keyAgentTunnelServerURL, Flag: toFlag(keyAgentTunnelServerURL), Default: "https://127.0.0.1:8300", Description: "Agent tunnel server url"},
+	{Key: keyAgentTunnelFallbackServerURLs, Flag: toFlag(keyAgentTunnelFallbackServerURLs), Default: []string{}, Description: "Tunnel server urls tried in order when the agent cannot connect to the tunnel server url (e.g. for multi-homed servers)"},
+	{Key: keyAgentTunnelFingerprint, Flag: toFlag(keyAgentTunnelFingerprint), Default: "", Description: "Statically pinned tunnel server SSH fingerprint; empty trusts the fingerprint returned at registration"},
+	{Key: keyAgentRegisterMaxAttempts, Flag: toFlag(keyAgentRegisterMaxAttempts), Default: 0, Description: "Consecutive failed registrations after which the agent exits with an error; 0 retries forever"},
 	{Key: keyAgentBootstrap, Flag: toFlag(keyAgentBootstrap), Default: true, Description: "Run Layer 0 bootstrap on startup (install FluxCD + Module CRD)"},
+	{Key: keyAgentProxyAllowHeaders, Flag: toFlag(keyAgentProxyAllowHeaders), Default: []string{}, Description: "Response headers forwarded from proxied kube-apiserver responses; when set, all others except Content-Type, Warning and the other headers clients need are stripped (empty = forward all but hop-by-hop and stripped headers)"},
+	{Key: keyAgentProxyStripHeaders, Flag: toFlag(keyAgentProxyStripHeaders), Default: []string{}, Description: "Response headers stripped from proxied kube-apiserver responses in addition to hop-by-hop headers"},
+	{Key: keyAgentProxyCAFile, Flag: toFlag(keyAgentProxyCAFile), Default: "", Description: "PEM CA bundle used to verify the kube-apiserver's certificate instead of the in-cluster CA"},
+	{Key: keyAgentProxyInsecureSkipVerify, Flag: toFlag(keyAgentProxyInsecureSkipVerify), Default: false, Description: "Skip verification of the kube-apiserver's certificate (insecure, development clusters only)"},
+	{Key: keyAgentHealthAddress, Flag: toFlag(keyAgentHealthAddress), Default: "", Description: "Listen address for the agent health and metrics endpoint (e.g. \":8081\"); empty disables it"},
+	{Key: keyBootstrapCRDPollInterval, Flag: toFlag(keyBootstrapCRDPollInterval), Default: 2 * time.Second, Description: "Initial interval between CRD status polls during bootstrap"},
+	{Key: keyBootstrapCRDMaxPollInterval, Flag: toFlag(keyBootstrapCRDMaxPollInterval), Default: 15 * time.Second, Description: "Upper bound of the exponentially growing CRD poll interval during bootstrap"},
+	{Key: keyBootstrapCRDTimeout, Flag: toFlag(keyBootstrapCRDTimeout), Default: 60 * time.Second, Description: "How long bootstrap waits for each CRD to become established"},
 }
 
 // toFlag converts a viper key like "server.tunnel.key_seed" into a
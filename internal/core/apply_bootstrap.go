@@ -0,0 +1,39 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/otterscale/otterscale-agent/manifests"
+)
+
+// bootstrapFieldManager is the field manager of ApplyBootstrap. It is
+// the one the agent's own bootstrap applies with, so that both share
+// ownership of the bootstrap objects instead of conflicting.
+const bootstrapFieldManager = "otterscale-agent"
+
+// ApplyBootstrap applies the agent's embedded bootstrap manifests to
+// cluster, one file at a time in file-name order as the agent does
+// when it starts, reporting progress through emit as ApplyManifest
+// does. Conflicts are resolved in favour of the bootstrap, and the
+// first object that fails stops the apply.
+func (uc *ResourceUseCase) ApplyBootstrap(ctx context.Context, cluster string, emit func(ManifestEvent) error) error {
+	entries, err := manifests.Bootstrap.ReadDir("bootstrap")
+	if err != nil {
+		return fmt.Errorf("read bootstrap manifests: %w", err)
+	}
+	opts := ApplyManifestOptions{Force: true, FieldManager: bootstrapFieldManager, StopOnError: true}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		data, err := manifests.Bootstrap.ReadFile("bootstrap/" + entry.Name())
+		if err != nil {
+			return fmt.Errorf("read bootstrap manifest %s: %w", entry.Name(), err)
+		}
+		if err := uc.ApplyManifest(ctx, cluster, data, opts, emit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,373 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// DefaultCRDEstablishTimeout is how long ApplyManifest waits for each
+// CustomResourceDefinition to become established when the options do
+// not say otherwise.
+const DefaultCRDEstablishTimeout = time.Minute
+
+// crdGVR is the GroupVersionResource of CustomResourceDefinitions,
+// watched by ApplyManifest until they are established.
+var crdGVR = schema.GroupVersionResource{
+	Group:    "apiextensions.k8s.io",
+	Version:  "v1",
+	Resource: "customresourcedefinitions",
+}
+
+// ManifestEventType is the kind of progress reported by ApplyManifest.
+type ManifestEventType string
+
+const (
+	// ManifestObjectApplied reports that an object was applied.
+	ManifestObjectApplied ManifestEventType = "applied"
+	// ManifestObjectFailed reports that an object could not be applied,
+	// or, for a CRD, did not become established.
+	ManifestObjectFailed ManifestEventType = "failed"
+	// ManifestObjectWaiting reports that an applied CRD is being
+	// waited on until it is established or, with a wait timeout, that
+	// an applied object is being waited on until it is ready.
+	ManifestObjectWaiting ManifestEventType = "waiting"
+	// ManifestCRDEstablished reports that a CRD is established, so
+	// objects of its kind can now be applied.
+	ManifestCRDEstablished ManifestEventType = "established"
+	// ManifestObjectReady reports that an applied object waited on
+	// with a wait timeout is ready.
+	ManifestObjectReady ManifestEventType = "ready"
+)
+
+// ManifestObject identifies an object of a multi-document manifest.
+// Index is the object's position among the manifest's non-empty
+// documents, starting at zero.
+type ManifestObject struct {
+	Index      int
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+}
+
+// ManifestEvent reports the progress of ApplyManifest on one object.
+// Err is set on ManifestObjectFailed events.
+type ManifestEvent struct {
+	Type   ManifestEventType
+	Object ManifestObject
+	Err    error
+}
+
+// ApplyManifestOptions configures ApplyManifest.
+type ApplyManifestOptions struct {
+	// Force and FieldManager apply to every object as in ApplyOptions.
+	Force        bool
+	FieldManager string
+	// StopOnError stops at the first object that fails instead of
+	// reporting it and continuing with the rest.
+	StopOnError bool
+	// CRDTimeout bounds the wait for each CRD to become established.
+	// Zero means DefaultCRDEstablishTimeout.
+	CRDTimeout time.Duration
+	// Namespace is the namespace of namespaced objects that do not
+	// name one. Empty means "default".
+	Namespace string
+	// WaitTimeout, if not zero, has the apply wait up to this long,
+	// after every object is applied, for the applied objects to become
+	// ready, as `kubectl apply --wait` would. Objects are ready as
+	// decided by ApplyConfig.ReadyConditions or, for workloads, once
+	// rolled out; other kinds are not waited on. An object that is not
+	// ready in time is reported as failed.
+	WaitTimeout time.Duration
+}
+
+// ApplyManifest applies every object of a multi-document YAML manifest
+// to cluster with server-side apply, reporting each step to emit as it
+// happens so that callers can show progress.
+//
+// CustomResourceDefinitions are applied first and waited on until
+// established, so that custom resources in the same manifest can be
+// resolved; Namespaces follow, then the remaining objects in document
+// order. A namespaced object without a namespace is placed in
+// opts.Namespace, or "default".
+//
+// An object that fails is reported with a ManifestObjectFailed event
+// and, unless opts.StopOnError is set, the rest are still applied. With
+// StopOnError the failing object's error is returned. A manifest that
+// does not parse is rejected before anything is applied. If emit
+// returns an error, ApplyManifest stops and returns it.
+//
+// With opts.WaitTimeout, the applied objects are then waited on until
+// ready, each reported with ManifestObjectWaiting and then
+// ManifestObjectReady or, like objects that fail to apply,
+// ManifestObjectFailed.
+func (uc *ResourceUseCase) ApplyManifest(
+	ctx context.Context,
+	cluster string,
+	manifest []byte,
+	opts ApplyManifestOptions,
+	emit func(ManifestEvent) error,
+) error {
+	objects, err := parseManifest(manifest, uc.apply.Manifest)
+	if err != nil {
+		return err
+	}
+	_, err = uc.applyManifestObjects(ctx, cluster, objects, opts, emit)
+	return err
+}
+
+// applyManifestObjects applies the parsed objects of a manifest as
+// described by ApplyManifest and returns the applier, whose state
+// tells which objects failed.
+func (uc *ResourceUseCase) applyManifestObjects(
+	ctx context.Context,
+	cluster string,
+	objects []manifestObject,
+	opts ApplyManifestOptions,
+	emit func(ManifestEvent) error,
+) (*manifestApplier, error) {
+	if opts.CRDTimeout == 0 {
+		opts.CRDTimeout = DefaultCRDEstablishTimeout
+	}
+	if err := validateWaitTimeout(opts.CRDTimeout); err != nil {
+		return nil, err
+	}
+	if err := validateReadyTimeout(opts.WaitTimeout); err != nil {
+		return nil, err
+	}
+	opts.FieldManager = uc.apply.fieldManager(ctx, opts.FieldManager)
+
+	a := &manifestApplier{uc: uc, cluster: cluster, opts: opts, emit: emit}
+	crds, namespaces, rest := partitionManifest(objects)
+
+	// Discovery is refreshed after the CRDs are established so that
+	// their kinds resolve.
+	if len(crds) > 0 {
+		if err := a.refreshKinds(ctx); err != nil {
+			return nil, err
+		}
+		for _, crd := range crds {
+			if err := a.applyCRD(ctx, crd); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := a.refreshKinds(ctx); err != nil {
+		return nil, err
+	}
+	for _, obj := range append(namespaces, rest...) {
+		if _, err := a.applyObject(ctx, obj); err != nil {
+			return nil, err
+		}
+	}
+	if opts.WaitTimeout > 0 {
+		if err := a.waitReady(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// manifestObject is a parsed document of a manifest with its index.
+type manifestObject struct {
+	index int
+	obj   *unstructured.Unstructured
+}
+
+// ref returns the ManifestObject identifying o in events.
+func (o manifestObject) ref() ManifestObject {
+	return ManifestObject{
+		Index:      o.index,
+		APIVersion: o.obj.GetAPIVersion(),
+		Kind:       o.obj.GetKind(),
+		Namespace:  o.obj.GetNamespace(),
+		Name:       o.obj.GetName(),
+	}
+}
+
+// kindMapping is the resource serving a kind and whether it is
+// namespaced.
+type kindMapping struct {
+	gvr        schema.GroupVersionResource
+	namespaced bool
+}
+
+// manifestApplier holds the state of a single ApplyManifest call.
+type manifestApplier struct {
+	uc      *ResourceUseCase
+	cluster string
+	opts    ApplyManifestOptions
+	emit    func(ManifestEvent) error
+	kinds   map[schema.GroupVersionKind]kindMapping
+	failed  int              // objects reported with ManifestObjectFailed
+	applied []manifestObject // objects applied, in apply order
+}
+
+// refreshKinds indexes the cluster's API resources by kind.
+func (a *manifestApplier) refreshKinds(ctx context.Context) error {
+	lists, _, err := a.uc.discovery.ServerResources(ctx, a.cluster)
+	if err != nil {
+		return err
+	}
+	a.kinds = make(map[schema.GroupVersionKind]kindMapping)
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, r := range list.APIResources {
+			if strings.Contains(r.Name, "/") {
+				continue
+			}
+			a.kinds[gv.WithKind(r.Kind)] = kindMapping{gvr: gv.WithResource(r.Name), namespaced: r.Namespaced}
+		}
+	}
+	return nil
+}
+
+// applyCRD applies crd and waits for it to become established.
+func (a *manifestApplier) applyCRD(ctx context.Context, crd manifestObject) error {
+	applied, err := a.applyObject(ctx, crd)
+	if err != nil || !applied {
+		return err
+	}
+	if err := a.emit(ManifestEvent{Type: ManifestObjectWaiting, Object: crd.ref()}); err != nil {
+		return err
+	}
+	id := ResourceIdentifier{
+		Cluster:  a.cluster,
+		Group:    crdGVR.Group,
+		Version:  crdGVR.Version,
+		Resource: crdGVR.Resource,
+		Name:     crd.obj.GetName(),
+	}
+	_, err = a.uc.waitFor(ctx, id, a.opts.CRDTimeout, "CRD to be established",
+		func(obj *unstructured.Unstructured) (bool, error) {
+			return hasCondition(obj, "Established", "True"), nil
+		})
+	if err != nil {
+		return a.fail(ctx, crd, err)
+	}
+	return a.emit(ManifestEvent{Type: ManifestCRDEstablished, Object: crd.ref()})
+}
+
+// applyObject applies o and reports the outcome, returning whether it
+// was applied. A failure is only returned as an error with
+// StopOnError.
+func (a *manifestApplier) applyObject(ctx context.Context, o manifestObject) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	gvk := o.obj.GroupVersionKind()
+	mapping, ok := a.kinds[gvk]
+	if !ok {
+		return false, a.fail(ctx, o, &DomainError{
+			Code:    ErrorCodeNotFound,
+			Message: fmt.Sprintf("kind %s is not served by cluster %s", gvk, a.cluster),
+		})
+	}
+	switch {
+	case !mapping.namespaced:
+		o.obj.SetNamespace("")
+	case o.obj.GetNamespace() == "" && a.opts.Namespace != "":
+		o.obj.SetNamespace(a.opts.Namespace)
+	case o.obj.GetNamespace() == "":
+		o.obj.SetNamespace(metav1.NamespaceDefault)
+	}
+
+	data, err := o.obj.MarshalJSON()
+	if err != nil {
+		return false, a.fail(ctx, o, &DomainError{Code: ErrorCodeInternal, Message: "marshal object to JSON", Cause: err})
+	}
+	_, err = a.uc.resource.Apply(ctx, a.cluster, mapping.gvr, o.obj.GetNamespace(), o.obj.GetName(), data, ApplyOptions{
+		Force:        a.opts.Force,
+		FieldManager: a.opts.FieldManager,
+	})
+	if err != nil {
+		return false, a.fail(ctx, o, err)
+	}
+	a.applied = append(a.applied, o)
+	return true, a.emit(ManifestEvent{Type: ManifestObjectApplied, Object: o.ref()})
+}
+
+// fail reports that o failed with err. It returns err if the apply
+// should stop, either because StopOnError is set or ctx has ended, and
+// otherwise the result of emitting the event.
+func (a *manifestApplier) fail(ctx context.Context, o manifestObject, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	a.failed++
+	if emitErr := a.emit(ManifestEvent{Type: ManifestObjectFailed, Object: o.ref(), Err: err}); emitErr != nil {
+		return emitErr
+	}
+	if a.opts.StopOnError {
+		return err
+	}
+	return nil
+}
+
+// partitionManifest splits objects into CRDs, Namespaces and the
+// rest, each in document order.
+func partitionManifest(objects []manifestObject) (crds, namespaces, rest []manifestObject) {
+	for _, o := range objects {
+		switch gvk := o.obj.GroupVersionKind(); {
+		case gvk.Group == crdGVR.Group && gvk.Kind == "CustomResourceDefinition":
+			crds = append(crds, o)
+		case gvk.Group == "" && gvk.Kind == "Namespace":
+			namespaces = append(namespaces, o)
+		default:
+			rest = append(rest, o)
+		}
+	}
+	return crds, namespaces, rest
+}
+
+// parseManifest splits a multi-document YAML or JSON manifest into its
+// objects, skipping empty documents. Every object must have an
+// apiVersion, a kind and a name. A manifest beyond limits is rejected:
+// its size before decoding, its number of objects as they are decoded
+// and the nesting of each object once it is.
+func parseManifest(manifest []byte, limits ManifestLimits) ([]manifestObject, error) {
+	if err := limits.checkSize(manifest); err != nil {
+		return nil, err
+	}
+	var objects []manifestObject
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(manifest), 4096)
+	for doc := 1; ; doc++ {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, &ErrInvalidInput{Field: "manifest", Message: fmt.Sprintf("document %d: %v", doc, err)}
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+		if len(objects) == limits.maxDocuments() {
+			return nil, &ErrInvalidInput{Field: "manifest", Message: fmt.Sprintf("must not contain more than %d objects", len(objects))}
+		}
+		if err := limits.checkDepth(doc, obj.Object); err != nil {
+			return nil, err
+		}
+		if obj.GetAPIVersion() == "" || obj.GetKind() == "" || obj.GetName() == "" {
+			return nil, &ErrInvalidInput{Field: "manifest", Message: fmt.Sprintf("document %d: apiVersion, kind and metadata.name are required", doc)}
+		}
+		objects = append(objects, manifestObject{index: len(objects), obj: obj})
+	}
+	if len(objects) == 0 {
+		return nil, &ErrInvalidInput{Field: "manifest", Message: "contains no objects"}
+	}
+	return objects, nil
+}
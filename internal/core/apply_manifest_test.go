@@ -0,0 +1,200 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// manifestDiscovery serves the core and apps kinds, and the Widget
+// kind once the widgets CRD has been applied.
+type manifestDiscovery struct {
+	mockWatchDiscovery
+	repo *manifestRepo
+}
+
+func (d *manifestDiscovery) ServerResources(context.Context, string) ([]*metav1.APIResourceList, []DiscoveryFailure, error) {
+	lists := []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "namespaces", Kind: "Namespace"},
+			{Name: "configmaps", Kind: "ConfigMap", Namespaced: true},
+		}},
+		{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{
+			{Name: "deployments", Kind: "Deployment", Namespaced: true},
+			{Name: "deployments/scale", Kind: "Scale", Namespaced: true},
+		}},
+		{GroupVersion: "apiextensions.k8s.io/v1", APIResources: []metav1.APIResource{
+			{Name: "customresourcedefinitions", Kind: "CustomResourceDefinition"},
+		}},
+	}
+	if d.repo.hasApplied("customresourcedefinitions") {
+		lists = append(lists, &metav1.APIResourceList{GroupVersion: "example.com/v1", APIResources: []metav1.APIResource{
+			{Name: "widgets", Kind: "Widget", Namespaced: true},
+		}})
+	}
+	return lists, nil, nil
+}
+
+func (d *manifestDiscovery) IsNamespaced(ctx context.Context, cluster string, gvr schema.GroupVersionResource) (bool, error) {
+	lists, _, _ := d.ServerResources(ctx, cluster)
+	for _, list := range lists {
+		for _, r := range list.APIResources {
+			if list.GroupVersion == gvr.GroupVersion().String() && r.Name == gvr.Resource {
+				return r.Namespaced, nil
+			}
+		}
+	}
+	return d.mockWatchDiscovery.IsNamespaced(ctx, cluster, gvr)
+}
+
+// manifestRepo records applies, fails those of resources in failing,
+// and reports applied CRDs as established.
+type manifestRepo struct {
+	ResourceRepo
+	failing map[string]bool
+
+	mu      sync.Mutex
+	applied []string
+}
+
+func (r *manifestRepo) Apply(_ context.Context, _ string, gvr schema.GroupVersionResource, namespace, name string, _ []byte, _ ApplyOptions) (*unstructured.Unstructured, error) {
+	if r.failing[gvr.Resource] {
+		return nil, &DomainError{Code: ErrorCodeInvalidArgument, Message: "denied"}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.applied = append(r.applied, gvr.Resource+"/"+namespace+"/"+name)
+	return &unstructured.Unstructured{}, nil
+}
+
+func (r *manifestRepo) hasApplied(resource string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, a := range r.applied {
+		if strings.HasPrefix(a, resource+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *manifestRepo) List(_ context.Context, _ string, _ schema.GroupVersionResource, _ string, _ ListOptions) (*unstructured.UnstructuredList, error) {
+	crd := unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]any{"name": "widgets.example.com", "resourceVersion": "5"},
+		"status": map[string]any{"conditions": []any{
+			map[string]any{"type": "Established", "status": "True"},
+		}},
+	}}
+	list := &unstructured.UnstructuredList{Items: []unstructured.Unstructured{crd}}
+	list.SetResourceVersion("5")
+	return list, nil
+}
+
+func (r *manifestRepo) Watch(context.Context, string, schema.GroupVersionResource, string, WatchOptions) (Watcher, error) {
+	return newChanWatcher(), nil
+}
+
+// threeDocManifest holds a Deployment, the CRD of Widgets, and a
+// Widget, so the CRD must be moved ahead of both.
+const threeDocManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: apps
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: gadget
+`
+
+// manifestStep is the part of a ManifestEvent the tests compare.
+type manifestStep struct {
+	Type  ManifestEventType
+	Index int
+	Kind  string
+}
+
+func applyThreeDocManifest(t *testing.T, repo *manifestRepo, opts ApplyManifestOptions) ([]manifestStep, error) {
+	t.Helper()
+	uc := NewResourceUseCase(&manifestDiscovery{repo: repo}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+	var steps []manifestStep
+	err := uc.ApplyManifest(context.Background(), "edge-1", []byte(threeDocManifest), opts, func(e ManifestEvent) error {
+		if (e.Type == ManifestObjectFailed) != (e.Err != nil) {
+			t.Errorf("event %s for %s has error %v", e.Type, e.Object.Kind, e.Err)
+		}
+		steps = append(steps, manifestStep{Type: e.Type, Index: e.Object.Index, Kind: e.Object.Kind})
+		return nil
+	})
+	return steps, err
+}
+
+func TestResourceUseCase_ApplyManifest_ReportsEachObject(t *testing.T) {
+	repo := &manifestRepo{failing: map[string]bool{"deployments": true}}
+
+	steps, err := applyThreeDocManifest(t, repo, ApplyManifestOptions{FieldManager: "installer"})
+	if err != nil {
+		t.Fatalf("ApplyManifest: %v", err)
+	}
+
+	want := []manifestStep{
+		{ManifestObjectApplied, 1, "CustomResourceDefinition"},
+		{ManifestObjectWaiting, 1, "CustomResourceDefinition"},
+		{ManifestCRDEstablished, 1, "CustomResourceDefinition"},
+		{ManifestObjectFailed, 0, "Deployment"},
+		{ManifestObjectApplied, 2, "Widget"},
+	}
+	if !reflect.DeepEqual(steps, want) {
+		t.Errorf("events = %v, want %v", steps, want)
+	}
+	wantApplied := []string{"customresourcedefinitions//widgets.example.com", "widgets/default/gadget"}
+	if !reflect.DeepEqual(repo.applied, wantApplied) {
+		t.Errorf("applied = %v, want %v", repo.applied, wantApplied)
+	}
+}
+
+func TestResourceUseCase_ApplyManifest_StopOnError(t *testing.T) {
+	repo := &manifestRepo{failing: map[string]bool{"deployments": true}}
+
+	steps, err := applyThreeDocManifest(t, repo, ApplyManifestOptions{FieldManager: "installer", StopOnError: true})
+	if code, ok := DomainErrorCode(err); !ok || code != ErrorCodeInvalidArgument {
+		t.Fatalf("ApplyManifest error = %v, want the Deployment's error", err)
+	}
+	if last := steps[len(steps)-1]; last != (manifestStep{ManifestObjectFailed, 0, "Deployment"}) {
+		t.Errorf("last event = %v, want the Deployment failure", last)
+	}
+	if repo.hasApplied("widgets") {
+		t.Error("objects after the failure were applied")
+	}
+}
+
+func TestResourceUseCase_ApplyManifest_RejectsInvalidManifest(t *testing.T) {
+	repo := &manifestRepo{}
+	uc := NewResourceUseCase(&manifestDiscovery{repo: repo}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+
+	manifest := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n---\nkind: ConfigMap\n"
+	err := uc.ApplyManifest(context.Background(), "edge-1", []byte(manifest), ApplyManifestOptions{}, func(ManifestEvent) error {
+		return errors.New("no events expected")
+	})
+	var invalid *ErrInvalidInput
+	if !isErrInvalidInput(err, &invalid) || invalid.Field != "manifest" {
+		t.Fatalf("ApplyManifest error = %v, want invalid input", err)
+	}
+	if len(repo.applied) != 0 {
+		t.Errorf("applied = %v, want nothing applied", repo.applied)
+	}
+}
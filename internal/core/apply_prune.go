@@ -0,0 +1,224 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ManifestObjectPruned reports that an object bearing the prune label
+// but absent from the manifest was deleted by ApplyWithPrune. Its
+// Index is -1, since it is not part of the manifest.
+const ManifestObjectPruned ManifestEventType = "pruned"
+
+// PruneScope bounds the objects ApplyWithPrune may delete to one
+// resource type and, for a namespaced type, to the listed namespaces.
+type PruneScope struct {
+	Group    string
+	Version  string
+	Resource string
+	// Namespaces lists the namespaces pruned for a namespaced
+	// resource, and must be empty for a cluster-scoped one.
+	Namespaces []string
+}
+
+// ApplyWithPruneOptions configures ApplyWithPrune.
+type ApplyWithPruneOptions struct {
+	ApplyManifestOptions
+	// PruneLabel is the "key=value" label set on every applied object
+	// and selecting the objects considered for pruning. It identifies
+	// the set of objects managed by one caller, e.g. one GitOps
+	// application.
+	PruneLabel string
+	// Scopes bound the prune. Objects bearing the label outside of
+	// them are never deleted.
+	Scopes []PruneScope
+}
+
+// prunedResource is a resolved PruneScope.
+type prunedResource struct {
+	gvr        schema.GroupVersionResource
+	namespaces []string // [""] for a cluster-scoped resource
+}
+
+// pruneKey identifies an object in the set applied by ApplyWithPrune.
+type pruneKey struct {
+	gvr             schema.GroupVersionResource
+	namespace, name string
+}
+
+// ApplyWithPrune applies a manifest like ApplyManifest, with the prune
+// label set on every object, then deletes the objects within
+// opts.Scopes that bear the label but are no longer in the manifest,
+// like kubectl apply --prune. Each deletion is reported with a
+// ManifestObjectPruned event, and a deletion that fails as a
+// ManifestObjectFailed event.
+//
+// Pruning is skipped if any object failed to apply, so that an object
+// is never removed while its replacement is missing. The label and the
+// scopes are validated before anything is applied.
+func (uc *ResourceUseCase) ApplyWithPrune(
+	ctx context.Context,
+	cluster string,
+	manifest []byte,
+	opts ApplyWithPruneOptions,
+	emit func(ManifestEvent) error,
+) error {
+	key, value, err := parsePruneLabel(opts.PruneLabel)
+	if err != nil {
+		return err
+	}
+	resources, err := uc.resolvePruneScopes(ctx, cluster, opts.Scopes)
+	if err != nil {
+		return err
+	}
+	objects, err := parseManifest(manifest, uc.apply.Manifest)
+	if err != nil {
+		return err
+	}
+	for _, o := range objects {
+		labels := o.obj.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[key] = value
+		o.obj.SetLabels(labels)
+	}
+
+	a, err := uc.applyManifestObjects(ctx, cluster, objects, opts.ApplyManifestOptions, emit)
+	if err != nil {
+		return err
+	}
+	if a.failed > 0 {
+		slog.WarnContext(ctx, "skipping prune after failed applies",
+			"cluster", cluster,
+			"prune_label", opts.PruneLabel,
+			"failed", a.failed,
+		)
+		return nil
+	}
+
+	// applyObject has set the namespace every object was applied in.
+	keep := make(map[pruneKey]bool, len(objects))
+	for _, o := range objects {
+		mapping := a.kinds[o.obj.GroupVersionKind()]
+		keep[pruneKey{gvr: mapping.gvr, namespace: o.obj.GetNamespace(), name: o.obj.GetName()}] = true
+	}
+	selector := key + "=" + value
+	for _, r := range resources {
+		for _, namespace := range r.namespaces {
+			if err := a.prune(ctx, r.gvr, namespace, selector, keep); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// prune deletes the objects of gvr in namespace matching selector that
+// are not in keep.
+func (a *manifestApplier) prune(ctx context.Context, gvr schema.GroupVersionResource, namespace, selector string, keep map[pruneKey]bool) error {
+	var stale []unstructured.Unstructured
+	for cont := ""; ; {
+		list, err := a.uc.resource.List(ctx, a.cluster, gvr, namespace, ListOptions{
+			LabelSelector: selector,
+			Limit:         relistPageSize,
+			Continue:      cont,
+		})
+		if err != nil {
+			return err
+		}
+		for _, item := range list.Items {
+			if item.GetDeletionTimestamp() == nil && !keep[pruneKey{gvr: gvr, namespace: item.GetNamespace(), name: item.GetName()}] {
+				stale = append(stale, item)
+			}
+		}
+		if cont = list.GetContinue(); cont == "" {
+			break
+		}
+	}
+
+	for i := range stale {
+		o := manifestObject{index: -1, obj: &stale[i]}
+		err := a.uc.resource.Delete(ctx, a.cluster, gvr, o.obj.GetNamespace(), o.obj.GetName(), DeleteOptions{})
+		if code, ok := DomainErrorCode(err); ok && code == ErrorCodeNotFound {
+			err = nil
+		}
+		if err != nil {
+			if err := a.fail(ctx, o, err); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := a.emit(ManifestEvent{Type: ManifestObjectPruned, Object: o.ref()}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parsePruneLabel splits a "key=value" prune label. Both parts are
+// required, since an empty value would select every object carrying
+// the key.
+func parsePruneLabel(label string) (key, value string, err error) {
+	key, value, ok := strings.Cut(label, "=")
+	if !ok || value == "" {
+		return "", "", &ErrInvalidInput{Field: "prune_label", Message: `must have the form "key=value"`}
+	}
+	if errs := utilvalidation.IsQualifiedName(key); len(errs) > 0 {
+		return "", "", &ErrInvalidInput{Field: "prune_label", Message: strings.Join(errs, "; ")}
+	}
+	if errs := utilvalidation.IsValidLabelValue(value); len(errs) > 0 {
+		return "", "", &ErrInvalidInput{Field: "prune_label", Message: strings.Join(errs, "; ")}
+	}
+	return key, value, nil
+}
+
+// resolvePruneScopes validates scopes against the cluster's API
+// resources. At least one scope is required, and a namespaced
+// resource must list its namespaces, so that a prune never spans more
+// than the caller named.
+func (uc *ResourceUseCase) resolvePruneScopes(ctx context.Context, cluster string, scopes []PruneScope) ([]prunedResource, error) {
+	if len(scopes) == 0 {
+		return nil, &ErrInvalidInput{Field: "prune_scopes", Message: "at least one scope is required"}
+	}
+	resources := make([]prunedResource, 0, len(scopes))
+	for i, scope := range scopes {
+		id := ResourceIdentifier{Cluster: cluster, Group: scope.Group, Version: scope.Version, Resource: scope.Resource}
+		gvr, err := id.lookupGVR(ctx, uc.discovery)
+		if err != nil {
+			return nil, err
+		}
+		namespaced, err := uc.discovery.IsNamespaced(ctx, cluster, gvr)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case namespaced && len(scope.Namespaces) == 0:
+			return nil, &ErrInvalidInput{
+				Field:   fmt.Sprintf("prune_scopes[%d].namespaces", i),
+				Message: fmt.Sprintf("%s is namespaced, so the namespaces to prune are required", gvr.Resource),
+			}
+		case !namespaced && len(scope.Namespaces) > 0:
+			return nil, &ErrInvalidInput{
+				Field:   fmt.Sprintf("prune_scopes[%d].namespaces", i),
+				Message: fmt.Sprintf("%s is cluster-scoped and cannot be pruned by namespace", gvr.Resource),
+			}
+		case !namespaced:
+			resources = append(resources, prunedResource{gvr: gvr, namespaces: []string{""}})
+		default:
+			for _, ns := range scope.Namespaces {
+				if ns == "" {
+					return nil, &ErrInvalidInput{Field: fmt.Sprintf("prune_scopes[%d].namespaces", i), Message: "must not be empty"}
+				}
+			}
+			resources = append(resources, prunedResource{gvr: gvr, namespaces: scope.Namespaces})
+		}
+	}
+	return resources, nil
+}
@@ -0,0 +1,155 @@
+package core
+
+import (
+	"context"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// pruneRepo holds ConfigMaps in memory, keyed by "namespace/name",
+// applying, listing by an equality label selector and deleting them.
+type pruneRepo struct {
+	ResourceRepo
+	objects map[string]*unstructured.Unstructured
+	failing bool
+	deleted []string
+}
+
+func (r *pruneRepo) Apply(_ context.Context, _ string, _ schema.GroupVersionResource, namespace, name string, manifest []byte, _ ApplyOptions) (*unstructured.Unstructured, error) {
+	if r.failing {
+		return nil, &DomainError{Code: ErrorCodeInvalidArgument, Message: "denied"}
+	}
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(manifest); err != nil {
+		return nil, err
+	}
+	r.objects[namespace+"/"+name] = obj
+	return obj, nil
+}
+
+func (r *pruneRepo) List(_ context.Context, _ string, _ schema.GroupVersionResource, namespace string, opts ListOptions) (*unstructured.UnstructuredList, error) {
+	key, value, _ := strings.Cut(opts.LabelSelector, "=")
+	list := &unstructured.UnstructuredList{}
+	for _, obj := range r.objects {
+		if obj.GetNamespace() == namespace && obj.GetLabels()[key] == value {
+			list.Items = append(list.Items, *obj)
+		}
+	}
+	return list, nil
+}
+
+func (r *pruneRepo) Delete(_ context.Context, _ string, _ schema.GroupVersionResource, namespace, name string, _ DeleteOptions) error {
+	delete(r.objects, namespace+"/"+name)
+	r.deleted = append(r.deleted, namespace+"/"+name)
+	return nil
+}
+
+func configMap(namespace, name string, labels map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetLabels(labels)
+	return obj
+}
+
+func TestResourceUseCase_ApplyWithPrune(t *testing.T) {
+	managed := map[string]string{"gitops.example.com/app": "shop"}
+	newRepo := func() *pruneRepo {
+		return &pruneRepo{objects: map[string]*unstructured.Unstructured{
+			"apps/retained":  configMap("apps", "retained", managed),
+			"apps/removed":   configMap("apps", "removed", managed),
+			"apps/unmanaged": configMap("apps", "unmanaged", nil),
+			// Outside of the prune scope, so it survives even though
+			// it bears the label.
+			"other/removed": configMap("other", "removed", managed),
+		}}
+	}
+	manifest := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: retained
+  namespace: apps
+data:
+  version: "2"
+`)
+	opts := ApplyWithPruneOptions{
+		PruneLabel: "gitops.example.com/app=shop",
+		Scopes:     []PruneScope{{Version: "v1", Resource: "configmaps", Namespaces: []string{"apps"}}},
+	}
+	apply := func(repo *pruneRepo) ([]ManifestEventType, error) {
+		uc := NewResourceUseCase(&manifestDiscovery{repo: &manifestRepo{}}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+		var events []ManifestEventType
+		err := uc.ApplyWithPrune(context.Background(), "edge-1", manifest, opts, func(e ManifestEvent) error {
+			events = append(events, e.Type)
+			return nil
+		})
+		return events, err
+	}
+
+	repo := newRepo()
+	events, err := apply(repo)
+	if err != nil {
+		t.Fatalf("ApplyWithPrune: %v", err)
+	}
+	if want := []ManifestEventType{ManifestObjectApplied, ManifestObjectPruned}; !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %v, want %v", events, want)
+	}
+	if want := []string{"apps/removed"}; !reflect.DeepEqual(repo.deleted, want) {
+		t.Errorf("deleted = %v, want %v", repo.deleted, want)
+	}
+	if remaining, want := slices.Sorted(maps.Keys(repo.objects)), []string{"apps/retained", "apps/unmanaged", "other/removed"}; !reflect.DeepEqual(remaining, want) {
+		t.Errorf("remaining = %v, want %v", remaining, want)
+	}
+	if got := repo.objects["apps/retained"].GetLabels(); got["gitops.example.com/app"] != "shop" {
+		t.Errorf("retained labels = %v, want the prune label", got)
+	}
+
+	// Nothing is pruned when an object fails to apply.
+	repo = newRepo()
+	repo.failing = true
+	if _, err := apply(repo); err != nil {
+		t.Fatalf("ApplyWithPrune with a failing apply: %v", err)
+	}
+	if len(repo.deleted) != 0 {
+		t.Errorf("deleted = %v after a failed apply, want nothing", repo.deleted)
+	}
+}
+
+func TestResourceUseCase_ApplyWithPruneValidation(t *testing.T) {
+	configMaps := PruneScope{Version: "v1", Resource: "configmaps", Namespaces: []string{"apps"}}
+	tests := []struct {
+		name      string
+		label     string
+		scopes    []PruneScope
+		wantField string
+	}{
+		{"label without value", "gitops.example.com/app", []PruneScope{configMaps}, "prune_label"},
+		{"invalid label key", "not a key=shop", []PruneScope{configMaps}, "prune_label"},
+		{"no scopes", "app=shop", nil, "prune_scopes"},
+		{"namespaced scope without namespaces", "app=shop", []PruneScope{{Version: "v1", Resource: "configmaps"}}, "prune_scopes[0].namespaces"},
+		{"cluster-scoped scope with namespaces", "app=shop", []PruneScope{configMaps, {Version: "v1", Resource: "nodes", Namespaces: []string{"apps"}}}, "prune_scopes[1].namespaces"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &pruneRepo{objects: map[string]*unstructured.Unstructured{}}
+			uc := NewResourceUseCase(&manifestDiscovery{repo: &manifestRepo{}}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+			opts := ApplyWithPruneOptions{PruneLabel: tt.label, Scopes: tt.scopes}
+			err := uc.ApplyWithPrune(context.Background(), "edge-1", []byte(threeDocManifest), opts, func(ManifestEvent) error { return nil })
+			var invalid *ErrInvalidInput
+			if !isErrInvalidInput(err, &invalid) || invalid.Field != tt.wantField {
+				t.Fatalf("err = %v, want invalid input on %s", err, tt.wantField)
+			}
+			if len(repo.objects) != 0 {
+				t.Errorf("applied %d objects before validation failed", len(repo.objects))
+			}
+		})
+	}
+}
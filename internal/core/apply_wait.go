@@ -0,0 +1,144 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ReadyConditions maps kinds to the status condition that reports an
+// applied object of the kind ready when True, such as Complete for a
+// Job. It is consulted when an apply is asked to wait for readiness.
+type ReadyConditions map[schema.GroupKind]string
+
+// ParseReadyConditions parses entries of the form
+// "Kind.group=Condition", e.g. "Job.batch=Complete" or
+// "Certificate.cert-manager.io=Ready". Kinds of the core group omit
+// the group.
+func ParseReadyConditions(entries []string) (ReadyConditions, error) {
+	conditions := make(ReadyConditions, len(entries))
+	for _, entry := range entries {
+		kind, condition, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		gk := schema.ParseGroupKind(kind)
+		if !ok || gk.Kind == "" || condition == "" {
+			return nil, fmt.Errorf("invalid ready condition %q: want Kind.group=Condition", entry)
+		}
+		conditions[gk] = condition
+	}
+	return conditions, nil
+}
+
+// workloadKinds are the kinds waited on, unless a ready condition is
+// configured for them, until their rollout completes as reported by
+// RolloutStatusOf.
+var workloadKinds = map[schema.GroupKind]bool{
+	{Group: "apps", Kind: "Deployment"}:  true,
+	{Group: "apps", Kind: "StatefulSet"}: true,
+	{Group: "apps", Kind: "DaemonSet"}:   true,
+}
+
+// readiness returns the check that reports an applied object of kind
+// gk ready, and a description of the awaited state for timeout errors.
+// A configured ready condition takes precedence; otherwise workloads
+// are ready once rolled out, like `kubectl rollout status`. It returns
+// ok=false for kinds that are ready as soon as they are applied.
+func (c ApplyConfig) readiness(gk schema.GroupKind, name string) (check func(*unstructured.Unstructured) (bool, error), what string, ok bool) {
+	if condition, found := c.ReadyConditions[gk]; found {
+		return func(obj *unstructured.Unstructured) (bool, error) {
+			return hasCondition(obj, condition, "True"), nil
+		}, fmt.Sprintf("%s %q to be %s", gk.Kind, name, condition), true
+	}
+	if workloadKinds[gk] {
+		return func(obj *unstructured.Unstructured) (bool, error) {
+			status, err := RolloutStatusOf(obj)
+			return status.Done, err
+		}, fmt.Sprintf("rollout of %s %q", gk.Kind, name), true
+	}
+	return nil, "", false
+}
+
+// validateReadyTimeout accepts a zero timeout, which does not wait,
+// and otherwise the timeouts accepted by WaitForCondition.
+func validateReadyTimeout(timeout time.Duration) error {
+	if timeout < 0 || timeout > MaxConditionTimeout {
+		return &ErrInvalidInput{Field: "wait_timeout", Message: fmt.Sprintf("must not be negative or more than %s", MaxConditionTimeout)}
+	}
+	return nil
+}
+
+// waitReady waits until obj, just applied as id, is ready as reported
+// by ApplyConfig.readiness, and returns the ready object. Objects of
+// kinds without readiness are returned as they are.
+func (uc *ResourceUseCase) waitReady(ctx context.Context, id ResourceIdentifier, obj *unstructured.Unstructured, timeout time.Duration) (*unstructured.Unstructured, error) {
+	check, what, ok := uc.apply.readiness(obj.GroupVersionKind().GroupKind(), id.Name)
+	if !ok {
+		return obj, nil
+	}
+	return uc.waitFor(ctx, id, timeout, what, check)
+}
+
+// waitReady waits, once the whole manifest is applied, for each
+// applied object whose kind has a readiness check, in apply order,
+// reporting ManifestObjectWaiting and then ManifestObjectReady or
+// ManifestObjectFailed. All waits share a deadline of
+// opts.WaitTimeout after the first begins.
+func (a *manifestApplier) waitReady(ctx context.Context) error {
+	deadline := time.Now().Add(a.opts.WaitTimeout)
+	for _, o := range a.applied {
+		check, what, ok := a.uc.apply.readiness(o.obj.GroupVersionKind().GroupKind(), o.obj.GetName())
+		if !ok {
+			continue
+		}
+		if err := a.emit(ManifestEvent{Type: ManifestObjectWaiting, Object: o.ref()}); err != nil {
+			return err
+		}
+		gvr := a.kinds[o.obj.GroupVersionKind()].gvr
+		id := ResourceIdentifier{
+			Cluster:   a.cluster,
+			Group:     gvr.Group,
+			Version:   gvr.Version,
+			Resource:  gvr.Resource,
+			Namespace: o.obj.GetNamespace(),
+			Name:      o.obj.GetName(),
+		}
+		if err := a.awaitReady(ctx, id, deadline, what, check); err != nil {
+			if err := a.fail(ctx, o, err); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := a.emit(ManifestEvent{Type: ManifestObjectReady, Object: o.ref()}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// awaitReady waits for the object identified by id until check reports
+// it ready or deadline passes. Timeouts report the whole wait timeout
+// rather than what was left of it for this object.
+func (a *manifestApplier) awaitReady(
+	ctx context.Context,
+	id ResourceIdentifier,
+	deadline time.Time,
+	what string,
+	check func(*unstructured.Unstructured) (bool, error),
+) error {
+	timedOut := &DomainError{
+		Code:    ErrorCodeDeadlineExceeded,
+		Message: fmt.Sprintf("timed out after %s waiting for %s", a.opts.WaitTimeout, what),
+	}
+	remaining := time.Until(deadline)
+	if remaining <= 0 {
+		return timedOut
+	}
+	_, err := a.uc.waitFor(ctx, id, remaining, what, check)
+	if code, ok := DomainErrorCode(err); ok && code == ErrorCodeDeadlineExceeded && ctx.Err() == nil {
+		return timedOut
+	}
+	return err
+}
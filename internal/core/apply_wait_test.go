@@ -0,0 +1,189 @@
+package core
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// rolloutRepo applies like manifestRepo and serves the web Deployment
+// as it rolls out: listed mid-rollout, then reported Available by the
+// events queued on each watch.
+type rolloutRepo struct {
+	manifestRepo
+	events []WatchEvent
+}
+
+// webDeployment returns the web Deployment with available of its two
+// replicas updated and available.
+func webDeployment(available int64) map[string]any {
+	status := "False"
+	if available == 2 {
+		status = "True"
+	}
+	return map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": "web", "namespace": "apps", "generation": int64(1), "resourceVersion": "7"},
+		"spec":       map[string]any{"replicas": int64(2)},
+		"status": map[string]any{
+			"observedGeneration": int64(1),
+			"replicas":           int64(2),
+			"updatedReplicas":    available,
+			"availableReplicas":  available,
+			"conditions": []any{
+				map[string]any{"type": "Available", "status": status},
+			},
+		},
+	}
+}
+
+func (r *rolloutRepo) Apply(ctx context.Context, cluster string, gvr schema.GroupVersionResource, namespace, name string, manifest []byte, opts ApplyOptions) (*unstructured.Unstructured, error) {
+	if _, err := r.manifestRepo.Apply(ctx, cluster, gvr, namespace, name, manifest, opts); err != nil {
+		return nil, err
+	}
+	return &unstructured.Unstructured{Object: webDeployment(0)}, nil
+}
+
+func (r *rolloutRepo) List(context.Context, string, schema.GroupVersionResource, string, ListOptions) (*unstructured.UnstructuredList, error) {
+	list := &unstructured.UnstructuredList{Items: []unstructured.Unstructured{{Object: webDeployment(1)}}}
+	list.SetResourceVersion("7")
+	return list, nil
+}
+
+func (r *rolloutRepo) Watch(context.Context, string, schema.GroupVersionResource, string, WatchOptions) (Watcher, error) {
+	w := newChanWatcher()
+	for _, e := range r.events {
+		w.ch <- e
+	}
+	return w, nil
+}
+
+const webManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: apps
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web-config
+  namespace: apps
+`
+
+func applyWebManifest(t *testing.T, repo *rolloutRepo, waitTimeout time.Duration) ([]manifestStep, []error) {
+	t.Helper()
+	uc := NewResourceUseCase(&manifestDiscovery{repo: &repo.manifestRepo}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+	var (
+		steps []manifestStep
+		errs  []error
+	)
+	err := uc.ApplyManifest(context.Background(), "edge-1", []byte(webManifest), ApplyManifestOptions{WaitTimeout: waitTimeout}, func(e ManifestEvent) error {
+		steps = append(steps, manifestStep{Type: e.Type, Index: e.Object.Index, Kind: e.Object.Kind})
+		if e.Err != nil {
+			errs = append(errs, e.Err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ApplyManifest: %v", err)
+	}
+	return steps, errs
+}
+
+func TestResourceUseCase_ApplyManifest_WaitsUntilReady(t *testing.T) {
+	repo := &rolloutRepo{events: []WatchEvent{{Type: WatchEventModified, Object: webDeployment(2)}}}
+
+	steps, errs := applyWebManifest(t, repo, time.Minute)
+	if len(errs) > 0 {
+		t.Fatalf("failures = %v, want none", errs)
+	}
+	want := []manifestStep{
+		{ManifestObjectApplied, 0, "Deployment"},
+		{ManifestObjectApplied, 1, "ConfigMap"},
+		{ManifestObjectWaiting, 0, "Deployment"},
+		{ManifestObjectReady, 0, "Deployment"},
+	}
+	if !reflect.DeepEqual(steps, want) {
+		t.Errorf("events = %v, want %v", steps, want)
+	}
+}
+
+func TestResourceUseCase_ApplyManifest_WaitTimesOut(t *testing.T) {
+	repo := &rolloutRepo{}
+
+	steps, errs := applyWebManifest(t, repo, 50*time.Millisecond)
+	if last := steps[len(steps)-1]; last != (manifestStep{ManifestObjectFailed, 0, "Deployment"}) {
+		t.Errorf("last event = %v, want the Deployment failure", last)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("failures = %v, want one", errs)
+	}
+	if code, _ := DomainErrorCode(errs[0]); code != ErrorCodeDeadlineExceeded {
+		t.Errorf("failure = %v, want DeadlineExceeded", errs[0])
+	}
+}
+
+func TestResourceUseCase_ApplyResource_WaitsUntilReady(t *testing.T) {
+	repo := &rolloutRepo{events: []WatchEvent{{Type: WatchEventModified, Object: webDeployment(2)}}}
+	uc := NewResourceUseCase(&manifestDiscovery{repo: &repo.manifestRepo}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+	id := ResourceIdentifier{Cluster: "edge-1", Group: "apps", Version: "v1", Resource: "deployments", Namespace: "apps", Name: "web"}
+
+	obj, err := uc.ApplyResource(context.Background(), id, []byte(`{"kind":"Deployment"}`), ApplyOptions{
+		FieldManager:  "installer",
+		SkipManagedBy: true,
+		WaitTimeout:   time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("ApplyResource: %v", err)
+	}
+	if !hasCondition(obj, "Available", "True") {
+		t.Errorf("object = %v, want it Available", obj.Object["status"])
+	}
+
+	_, err = uc.ApplyResource(context.Background(), id, []byte(`{"kind":"Deployment"}`), ApplyOptions{WaitTimeout: -time.Second})
+	var invalid *ErrInvalidInput
+	if !isErrInvalidInput(err, &invalid) || invalid.Field != "wait_timeout" {
+		t.Errorf("ApplyResource error = %v, want invalid wait_timeout", err)
+	}
+}
+
+func TestApplyConfig_Readiness(t *testing.T) {
+	conditions, err := ParseReadyConditions([]string{"Job.batch=Complete", "Deployment.apps=Available"})
+	if err != nil {
+		t.Fatalf("ParseReadyConditions: %v", err)
+	}
+	c := ApplyConfig{ReadyConditions: conditions}
+
+	complete := &unstructured.Unstructured{Object: map[string]any{"status": map[string]any{"conditions": []any{
+		map[string]any{"type": "Complete", "status": "True"},
+	}}}}
+	check, _, ok := c.readiness(schema.GroupKind{Group: "batch", Kind: "Job"}, "migrate")
+	if !ok {
+		t.Fatal("Job has no readiness, want its ready condition")
+	}
+	if ready, _ := check(complete); !ready {
+		t.Error("complete Job is not ready")
+	}
+
+	if _, what, _ := c.readiness(schema.GroupKind{Group: "apps", Kind: "Deployment"}, "web"); what != `Deployment "web" to be Available` {
+		t.Errorf("Deployment awaits %q, want its configured condition", what)
+	}
+	if _, what, _ := c.readiness(schema.GroupKind{Group: "apps", Kind: "DaemonSet"}, "agent"); what != `rollout of DaemonSet "agent"` {
+		t.Errorf("DaemonSet awaits %q, want its rollout", what)
+	}
+	if _, _, ok := c.readiness(schema.GroupKind{Kind: "ConfigMap"}, "web-config"); ok {
+		t.Error("ConfigMap has readiness, want none")
+	}
+
+	for _, entry := range []string{"Job.batch", "=Complete", "Job.batch="} {
+		if _, err := ParseReadyConditions([]string{entry}); err == nil {
+			t.Errorf("ParseReadyConditions(%q) error = nil, want invalid", entry)
+		}
+	}
+}
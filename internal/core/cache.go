@@ -10,5 +10,15 @@ import (
 // (e.g. providers/cache). Defining the interface here decouples the
 // application layer from concrete cache implementations.
 type CacheEvictor interface {
-	StartEvictionLoop(ctx context.Context, interval time.Duration)
+	StartEvictionLoop(ctx context.Context, interval time.Duration, jitter Jitter)
+}
+
+// CacheConfig holds the server-wide cache maintenance settings.
+type CacheConfig struct {
+	// EvictionInterval is how often expired entries are removed from
+	// the discovery cache. Zero uses the server's default.
+	EvictionInterval time.Duration
+	// OpenAPITTL is how long the OpenAPI v3 document of a
+	// group-version is cached. Zero uses the server's default.
+	OpenAPITTL time.Duration
 }
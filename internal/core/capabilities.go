@@ -0,0 +1,93 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+// Minimum Kubernetes versions at which version-gated features are
+// available by default. Keeping the thresholds in one place ensures
+// that server-side decisions (e.g. whether to request initial watch
+// events) and the capabilities reported to clients never disagree.
+var (
+	// minServerSideApplyVersion: server-side apply GA.
+	minServerSideApplyVersion = semver.MustParse("v1.22.0")
+	// minEphemeralContainersVersion: ephemeral containers GA.
+	minEphemeralContainersVersion = semver.MustParse("v1.25.0")
+	// minSidecarContainersVersion: native sidecar containers (beta,
+	// default-on).
+	minSidecarContainersVersion = semver.MustParse("v1.29.0")
+	// minValidatingAdmissionPolicyVersion: ValidatingAdmissionPolicy GA.
+	minValidatingAdmissionPolicyVersion = semver.MustParse("v1.30.0")
+	// minInPlacePodResizeVersion: in-place pod resize (beta,
+	// default-on).
+	minInPlacePodResizeVersion = semver.MustParse("v1.33.0")
+	// minWatchListVersion: WatchList streaming (beta, default-on).
+	minWatchListVersion = semver.MustParse("v1.34.0")
+)
+
+// ClusterCapabilities reports which version-gated Kubernetes features
+// a cluster supports, so that clients can adapt their behaviour and UI.
+type ClusterCapabilities struct {
+	// KubernetesVersion is the cluster's reported git version
+	// (e.g. "v1.34.1").
+	KubernetesVersion         string
+	ServerSideApply           bool
+	EphemeralContainers       bool
+	SidecarContainers         bool
+	ValidatingAdmissionPolicy bool
+	InPlacePodResize          bool
+	WatchList                 bool
+}
+
+// kubeVersionCore matches the major.minor.patch prefix of a
+// Kubernetes git version, ahead of any distribution suffix.
+var kubeVersionCore = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)`)
+
+// parseKubeVersion returns the major.minor.patch of a cluster's server
+// version. Distribution suffixes (e.g. "v1.34.0-gke.100",
+// "v1.30.2+k3s1", "v1.29.1+rke2r1", "v1.30.14-eks-ecaa3a6") are not
+// always valid semver, so only the leading version core is parsed.
+// When the git version is missing or unrecognised, the Major and Minor
+// fields are used instead; managed distributions report the latter
+// with a trailing "+" (e.g. "28+").
+func parseKubeVersion(info *version.Info) (*semver.Version, error) {
+	if m := kubeVersionCore.FindString(info.GitVersion); m != "" {
+		return semver.NewVersion(m)
+	}
+	if info.Major != "" && info.Minor != "" {
+		if v, err := semver.NewVersion(info.Major + "." + strings.TrimSuffix(info.Minor, "+")); err == nil {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("parse server version %q: unrecognised format", info.String())
+}
+
+// CapabilitiesForVersion derives the ClusterCapabilities of a cluster
+// from its server version. Pre-release and build metadata (e.g.
+// "v1.34.1+k3s1") are ignored when comparing against thresholds, so
+// pre-releases of a supported minor count as supported.
+func CapabilitiesForVersion(info *version.Info) (ClusterCapabilities, error) {
+	if info == nil {
+		return ClusterCapabilities{}, fmt.Errorf("server version is unavailable")
+	}
+
+	base, err := parseKubeVersion(info)
+	if err != nil {
+		return ClusterCapabilities{}, err
+	}
+
+	return ClusterCapabilities{
+		KubernetesVersion:         info.String(),
+		ServerSideApply:           base.GreaterThanEqual(minServerSideApplyVersion),
+		EphemeralContainers:       base.GreaterThanEqual(minEphemeralContainersVersion),
+		SidecarContainers:         base.GreaterThanEqual(minSidecarContainersVersion),
+		ValidatingAdmissionPolicy: base.GreaterThanEqual(minValidatingAdmissionPolicyVersion),
+		InPlacePodResize:          base.GreaterThanEqual(minInPlacePodResizeVersion),
+		WatchList:                 base.GreaterThanEqual(minWatchListVersion),
+	}, nil
+}
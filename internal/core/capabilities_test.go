@@ -0,0 +1,177 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/version"
+)
+
+func TestCapabilitiesForVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		gitVersion string
+		want       ClusterCapabilities
+	}{
+		{
+			name:       "1.28",
+			gitVersion: "v1.28.9",
+			want: ClusterCapabilities{
+				KubernetesVersion:   "v1.28.9",
+				ServerSideApply:     true,
+				EphemeralContainers: true,
+			},
+		},
+		{
+			name:       "1.34 with distribution suffix",
+			gitVersion: "v1.34.1+k3s1",
+			want: ClusterCapabilities{
+				KubernetesVersion:         "v1.34.1+k3s1",
+				ServerSideApply:           true,
+				EphemeralContainers:       true,
+				SidecarContainers:         true,
+				ValidatingAdmissionPolicy: true,
+				InPlacePodResize:          true,
+				WatchList:                 true,
+			},
+		},
+		{
+			name:       "1.34 vanilla",
+			gitVersion: "v1.34.1",
+			want: ClusterCapabilities{
+				KubernetesVersion:         "v1.34.1",
+				ServerSideApply:           true,
+				EphemeralContainers:       true,
+				SidecarContainers:         true,
+				ValidatingAdmissionPolicy: true,
+				InPlacePodResize:          true,
+				WatchList:                 true,
+			},
+		},
+		{
+			name:       "1.34 GKE",
+			gitVersion: "v1.34.0-gke.100",
+			want: ClusterCapabilities{
+				KubernetesVersion:         "v1.34.0-gke.100",
+				ServerSideApply:           true,
+				EphemeralContainers:       true,
+				SidecarContainers:         true,
+				ValidatingAdmissionPolicy: true,
+				InPlacePodResize:          true,
+				WatchList:                 true,
+			},
+		},
+		{
+			name:       "1.33 GKE with non-semver build number",
+			gitVersion: "v1.33.5-gke.0100",
+			want: ClusterCapabilities{
+				KubernetesVersion:         "v1.33.5-gke.0100",
+				ServerSideApply:           true,
+				EphemeralContainers:       true,
+				SidecarContainers:         true,
+				ValidatingAdmissionPolicy: true,
+				InPlacePodResize:          true,
+			},
+		},
+		{
+			name:       "1.30 k3s",
+			gitVersion: "v1.30.2+k3s1",
+			want: ClusterCapabilities{
+				KubernetesVersion:         "v1.30.2+k3s1",
+				ServerSideApply:           true,
+				EphemeralContainers:       true,
+				SidecarContainers:         true,
+				ValidatingAdmissionPolicy: true,
+			},
+		},
+		{
+			name:       "1.29 rke2",
+			gitVersion: "v1.29.1+rke2r1",
+			want: ClusterCapabilities{
+				KubernetesVersion:   "v1.29.1+rke2r1",
+				ServerSideApply:     true,
+				EphemeralContainers: true,
+				SidecarContainers:   true,
+			},
+		},
+		{
+			name:       "1.30 EKS",
+			gitVersion: "v1.30.14-eks-ecaa3a6",
+			want: ClusterCapabilities{
+				KubernetesVersion:         "v1.30.14-eks-ecaa3a6",
+				ServerSideApply:           true,
+				EphemeralContainers:       true,
+				SidecarContainers:         true,
+				ValidatingAdmissionPolicy: true,
+			},
+		},
+		{
+			name:       "1.34 pre-release",
+			gitVersion: "v1.34.0-rc.1",
+			want: ClusterCapabilities{
+				KubernetesVersion:         "v1.34.0-rc.1",
+				ServerSideApply:           true,
+				EphemeralContainers:       true,
+				SidecarContainers:         true,
+				ValidatingAdmissionPolicy: true,
+				InPlacePodResize:          true,
+				WatchList:                 true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CapabilitiesForVersion(&version.Info{GitVersion: tt.gitVersion})
+			if err != nil {
+				t.Fatalf("CapabilitiesForVersion: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCapabilitiesForVersion_FallsBackToMajorMinor(t *testing.T) {
+	got, err := CapabilitiesForVersion(&version.Info{Major: "1", Minor: "34+"})
+	if err != nil {
+		t.Fatalf("CapabilitiesForVersion: %v", err)
+	}
+	if !got.WatchList || !got.InPlacePodResize {
+		t.Errorf("1.34+ must report WatchList and in-place pod resize: %+v", got)
+	}
+}
+
+func TestCapabilitiesForVersion_Invalid(t *testing.T) {
+	if _, err := CapabilitiesForVersion(&version.Info{GitVersion: "not-a-version"}); err == nil {
+		t.Error("expected error for unparsable version")
+	}
+	if _, err := CapabilitiesForVersion(nil); err == nil {
+		t.Error("expected error for nil version")
+	}
+}
+
+// staticVersionResolver implements ServerVersionResolver for testing.
+type staticVersionResolver struct {
+	info *version.Info
+}
+
+func (s staticVersionResolver) ServerVersion(_ context.Context, _ string) (*version.Info, error) {
+	return s.info, nil
+}
+
+func TestResourceUseCase_ClusterCapabilities(t *testing.T) {
+	uc := NewResourceUseCase(nil, nil, nil, staticVersionResolver{info: &version.Info{GitVersion: "v1.28.0"}}, nil, ApplyConfig{}, NamespaceConfig{})
+
+	caps, err := uc.ClusterCapabilities(context.Background(), "edge-1")
+	if err != nil {
+		t.Fatalf("ClusterCapabilities: %v", err)
+	}
+	if caps.WatchList || caps.SidecarContainers {
+		t.Errorf("1.28 must not report WatchList or sidecar containers: %+v", caps)
+	}
+	if !caps.EphemeralContainers {
+		t.Errorf("1.28 must report ephemeral containers: %+v", caps)
+	}
+}
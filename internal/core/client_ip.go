@@ -0,0 +1,34 @@
+package core
+
+import (
+	"context"
+	"net/netip"
+)
+
+// clientIPKey is the context key for the resolved client IP address.
+type clientIPKey struct{}
+
+// WithClientIP returns a derived context that carries the IP address
+// of the client that sent the request. It is set by the HTTP server,
+// which takes the address from forwarding headers only when they were
+// added by a trusted proxy, so the value can be used for audit logs
+// and rate limiting.
+func WithClientIP(ctx context.Context, ip netip.Addr) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// ClientIPFromContext returns the address stored by WithClientIP.
+// Returns false if the context does not carry a valid address.
+func ClientIPFromContext(ctx context.Context) (netip.Addr, bool) {
+	ip, ok := ctx.Value(clientIPKey{}).(netip.Addr)
+	return ip, ok && ip.IsValid()
+}
+
+// clientIP returns the client address of ctx for audit log entries,
+// or an empty string when it is unknown.
+func clientIP(ctx context.Context) string {
+	if ip, ok := ClientIPFromContext(ctx); ok {
+		return ip.String()
+	}
+	return ""
+}
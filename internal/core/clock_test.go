@@ -0,0 +1,247 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced Clock. Timers and tickers fire
+// only when Advance moves the clock past their deadline.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+type fakeWaiter struct {
+	clock    *fakeClock
+	ch       chan time.Time
+	deadline time.Time
+	period   time.Duration // zero for one-shot timers
+	stopped  bool
+}
+
+func (w *fakeWaiter) C() <-chan time.Time { return w.ch }
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) Timer {
+	return &fakeTimer{c.addWaiter(d, 0)}
+}
+
+func (c *fakeClock) NewTicker(d time.Duration) Ticker {
+	return &fakeTicker{c.addWaiter(d, d)}
+}
+
+func (c *fakeClock) addWaiter(d, period time.Duration) *fakeWaiter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w := &fakeWaiter{clock: c, ch: make(chan time.Time, 1), deadline: c.now.Add(d), period: period}
+	c.waiters = append(c.waiters, w)
+	return w
+}
+
+// Advance moves the clock forward by d and fires every timer and
+// ticker whose deadline has been reached.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	for _, w := range c.waiters {
+		for !w.stopped && !w.deadline.After(c.now) {
+			select {
+			case w.ch <- c.now:
+			default: // drop the tick, as time.Ticker does for slow receivers
+			}
+			if w.period == 0 {
+				w.stopped = true
+				break
+			}
+			w.deadline = w.deadline.Add(w.period)
+		}
+	}
+}
+
+// waitForWaiters blocks until at least n timers or tickers have been
+// created, so that Advance is not called before the code under test
+// has started waiting.
+func (c *fakeClock) waitForWaiters(t *testing.T, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		c.mu.Lock()
+		got := len(c.waiters)
+		c.mu.Unlock()
+		if got >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d timers", n)
+}
+
+type fakeTimer struct{ *fakeWaiter }
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	was := !t.stopped
+	t.stopped = true
+	return was
+}
+
+type fakeTicker struct{ *fakeWaiter }
+
+func (t *fakeTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.stopped = true
+}
+
+func TestManifestToken_ExpiresAfterTTL(t *testing.T) {
+	clock := newFakeClock(time.Unix(1_700_000_000, 0))
+	uc, err := NewFleetUseCase(&mockTunnelProvider{}, "v1.0.0", testFleetConfig(), &mockManifestRenderer{}, &ClusterAccessPolicy{}, clock)
+	if err != nil {
+		t.Fatalf("NewFleetUseCase: %v", err)
+	}
+	ctx := context.Background()
+
+	url, err := uc.IssueManifestURL(ctx, "test-cluster", "user@example.com")
+	if err != nil {
+		t.Fatalf("IssueManifestURL: %v", err)
+	}
+	token := url[strings.LastIndex(url, "/")+1:]
+
+	// Exactly at the expiry boundary the token is still valid.
+	clock.Advance(manifestTokenTTL)
+	if _, _, err := uc.VerifyManifestToken(ctx, token); err != nil {
+		t.Fatalf("token should be valid at exactly manifestTokenTTL: %v", err)
+	}
+
+	// One second past the boundary it is rejected.
+	clock.Advance(time.Second)
+	if _, _, err := uc.VerifyManifestToken(ctx, token); err == nil {
+		t.Fatal("token should be rejected after manifestTokenTTL")
+	}
+}
+
+func TestManifestToken_RejectsFutureIssuedAt(t *testing.T) {
+	clock := newFakeClock(time.Unix(1_700_000_000, 0))
+	signer, err := NewURLSigner("https://server.example.com", testFleetConfig().HMACKey, clock)
+	if err != nil {
+		t.Fatalf("NewURLSigner: %v", err)
+	}
+
+	url, err := signer.IssueSignedURL(manifestResourcePath, SignedURLClaims{Subject: "user@example.com"}, manifestTokenTTL)
+	if err != nil {
+		t.Fatalf("IssueSignedURL: %v", err)
+	}
+	token := url[strings.LastIndex(url, "/")+1:]
+
+	// Rewind the clock beyond the default 5-minute skew allowance.
+	clock.Advance(-6 * time.Minute)
+	if _, err := signer.verifyDetailed(manifestResourcePath, token, manifestTokenTTL); err == nil || !strings.Contains(err.Error(), "future") {
+		t.Fatalf("expected future-issued error, got %v", err)
+	}
+}
+
+func TestManifestToken_AcceptsIssuedAtWithinSkew(t *testing.T) {
+	clock := newFakeClock(time.Unix(1_700_000_000, 0))
+	cfg := testFleetConfig()
+	cfg.ClockSkew = 2 * time.Minute
+	uc, err := NewFleetUseCase(&mockTunnelProvider{}, "v1.0.0", cfg, &mockManifestRenderer{}, &ClusterAccessPolicy{}, clock)
+	if err != nil {
+		t.Fatalf("NewFleetUseCase: %v", err)
+	}
+	ctx := context.Background()
+
+	url, err := uc.IssueManifestURL(ctx, "test-cluster", "user@example.com")
+	if err != nil {
+		t.Fatalf("IssueManifestURL: %v", err)
+	}
+	token := url[strings.LastIndex(url, "/")+1:]
+
+	// A verifier whose clock runs behind the issuer's by less than
+	// the configured skew accepts the token.
+	clock.Advance(-90 * time.Second)
+	if _, _, err := uc.VerifyManifestToken(ctx, token); err != nil {
+		t.Fatalf("token issued within the clock skew should be valid: %v", err)
+	}
+
+	// Beyond the configured skew it is rejected, even though the
+	// default skew would have allowed it.
+	clock.Advance(-time.Minute)
+	if _, err := uc.signer.verifyDetailed(manifestResourcePath, token, manifestTokenTTL); err == nil || !strings.Contains(err.Error(), "future") {
+		t.Fatalf("expected future-issued error beyond the configured skew, got %v", err)
+	}
+}
+
+func TestManifestToken_RejectsLifetimeBeyondTTL(t *testing.T) {
+	clock := newFakeClock(time.Unix(1_700_000_000, 0))
+	uc, err := NewFleetUseCase(&mockTunnelProvider{}, "v1.0.0", testFleetConfig(), &mockManifestRenderer{}, &ClusterAccessPolicy{}, clock)
+	if err != nil {
+		t.Fatalf("NewFleetUseCase: %v", err)
+	}
+
+	// A token for the manifest path signed with the right key but a
+	// longer TTL than manifests are issued with must not be honoured
+	// past manifestTokenTTL.
+	url, err := uc.signer.IssueSignedURL(manifestResourcePath, SignedURLClaims{Subject: "user@example.com"}, 12*time.Hour)
+	if err != nil {
+		t.Fatalf("IssueSignedURL: %v", err)
+	}
+	token := url[strings.LastIndex(url, "/")+1:]
+
+	clock.Advance(2 * manifestTokenTTL)
+	if _, err := uc.signer.verifyDetailed(manifestResourcePath, token, manifestTokenTTL); err == nil || !strings.Contains(err.Error(), "too old") {
+		t.Fatalf("expected too-old error, got %v", err)
+	}
+}
+
+func TestSessionStore_ReaperRunsOnTick(t *testing.T) {
+	clock := newFakeClock(time.Unix(1_700_000_000, 0))
+	store := NewSessionStore(clock, nil)
+
+	done := make(chan error)
+	close(done)
+	reaped := make(chan struct{})
+	if err := store.PutExec(&ExecSession{
+		ID:     "stale-exec",
+		Done:   done,
+		Cancel: func() { close(reaped) },
+		Stdin:  &nopCloser{},
+	}); err != nil {
+		t.Fatalf("PutExec: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go store.StartReaper(ctx, 30*time.Second, 0)
+	clock.waitForWaiters(t, 1)
+
+	clock.Advance(29 * time.Second)
+	if _, ok := store.GetExec("stale-exec"); !ok {
+		t.Fatal("session reaped before the interval elapsed")
+	}
+
+	clock.Advance(time.Second)
+	select {
+	case <-reaped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("reaper did not run after the interval elapsed")
+	}
+	if _, ok := store.GetExec("stale-exec"); ok {
+		t.Error("stale session should have been removed")
+	}
+}
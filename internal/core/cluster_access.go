@@ -0,0 +1,74 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// clusterWildcard grants access to every cluster when used as the
+// cluster in a ClusterAccessPolicy rule.
+const clusterWildcard = "*"
+
+// ClusterAuthorizer decides whether an authenticated user may reach a
+// named cluster at all. It gates tunnel address resolution; per-object
+// RBAC is still enforced by the target kube-apiserver via
+// impersonation.
+type ClusterAuthorizer interface {
+	// AuthorizeCluster returns a *DomainError with
+	// ErrorCodePermissionDenied if user may not access cluster.
+	AuthorizeCluster(ctx context.Context, user UserInfo, cluster string) error
+}
+
+// ClusterAccessPolicy is a group-based ClusterAuthorizer. Each group
+// maps to the clusters its members may access; "*" matches any
+// cluster. An empty policy allows every user to access every cluster,
+// preserving the behaviour of deployments that do not configure one.
+type ClusterAccessPolicy struct {
+	groups map[string][]string // group -> allowed clusters
+}
+
+var _ ClusterAuthorizer = (*ClusterAccessPolicy)(nil)
+
+// NewClusterAccessPolicy builds a policy from "group=cluster" rules.
+// Multiple rules for the same group accumulate. It returns an
+// *ErrInvalidInput if a rule is malformed.
+func NewClusterAccessPolicy(rules []string) (*ClusterAccessPolicy, error) {
+	p := &ClusterAccessPolicy{groups: make(map[string][]string)}
+	for _, rule := range rules {
+		group, cluster, ok := strings.Cut(rule, "=")
+		group, cluster = strings.TrimSpace(group), strings.TrimSpace(cluster)
+		if !ok || group == "" || cluster == "" {
+			return nil, &ErrInvalidInput{
+				Field:   "cluster_access",
+				Message: fmt.Sprintf("rule must have the form group=cluster, got %q", rule),
+			}
+		}
+		if cluster != clusterWildcard {
+			if err := ValidateClusterName(cluster); err != nil {
+				return nil, err
+			}
+		}
+		p.groups[group] = append(p.groups[group], cluster)
+	}
+	return p, nil
+}
+
+// AuthorizeCluster allows the request if the policy is empty or if any
+// of the user's groups is granted cluster (or "*").
+func (p *ClusterAccessPolicy) AuthorizeCluster(_ context.Context, user UserInfo, cluster string) error {
+	if len(p.groups) == 0 {
+		return nil
+	}
+	for _, g := range user.Groups {
+		allowed := p.groups[g]
+		if slices.Contains(allowed, cluster) || slices.Contains(allowed, clusterWildcard) {
+			return nil
+		}
+	}
+	return &DomainError{
+		Code:    ErrorCodePermissionDenied,
+		Message: fmt.Sprintf("user %q may not access cluster %s", user.Subject, cluster),
+	}
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"context"
+	"testing"
+)
+
+func TestClusterAccessPolicy_GroupRules(t *testing.T) {
+	policy, err := NewClusterAccessPolicy([]string{
+		"edge-ops=edge-1",
+		"edge-ops = edge-2",
+		"platform-admins=*",
+	})
+	if err != nil {
+		t.Fatalf("NewClusterAccessPolicy: %v", err)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		groups  []string
+		cluster string
+		allowed bool
+	}{
+		{"group granted cluster", []string{"edge-ops"}, "edge-1", true},
+		{"group granted second cluster", []string{"edge-ops"}, "edge-2", true},
+		{"group denied other cluster", []string{"edge-ops"}, "prod", false},
+		{"wildcard group", []string{"viewers", "platform-admins"}, "prod", true},
+		{"no matching group", []string{"viewers"}, "edge-1", false},
+		{"no groups", nil, "edge-1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := UserInfo{Subject: "alice", Groups: tt.groups}
+			err := policy.AuthorizeCluster(ctx, user, tt.cluster)
+			if tt.allowed {
+				if err != nil {
+					t.Fatalf("expected access, got %v", err)
+				}
+				return
+			}
+			code, ok := DomainErrorCode(err)
+			if !ok || code != ErrorCodePermissionDenied {
+				t.Fatalf("expected ErrorCodePermissionDenied, got %v", err)
+			}
+		})
+	}
+}
+
+func TestClusterAccessPolicy_EmptyAllowsAll(t *testing.T) {
+	policy, err := NewClusterAccessPolicy(nil)
+	if err != nil {
+		t.Fatalf("NewClusterAccessPolicy: %v", err)
+	}
+	if err := policy.AuthorizeCluster(context.Background(), UserInfo{Subject: "bob"}, "any"); err != nil {
+		t.Errorf("expected empty policy to allow access, got %v", err)
+	}
+}
+
+func TestNewClusterAccessPolicy_InvalidRules(t *testing.T) {
+	for _, rule := range []string{"no-separator", "=edge-1", "group=", "group=INVALID!"} {
+		t.Run(rule, func(t *testing.T) {
+			_, err := NewClusterAccessPolicy([]string{rule})
+			var target *ErrInvalidInput
+			if !isErrInvalidInput(err, &target) {
+				t.Errorf("expected *ErrInvalidInput for %q, got %v", rule, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package core
+
+import (
+	"strings"
+	"time"
+)
+
+// ClusterTimeouts holds the timeout of each API server request,
+// configurable per cluster so that distant or slow clusters can be
+// given more time than the rest.
+type ClusterTimeouts struct {
+	// Default applies to clusters without an override. Zero means the
+	// caller's own default.
+	Default time.Duration
+	// Overrides maps lower-cased cluster names to their timeout.
+	Overrides map[string]time.Duration
+}
+
+// For returns the request timeout of cluster. Cluster names are
+// matched case-insensitively.
+func (t ClusterTimeouts) For(cluster string) time.Duration {
+	if timeout, ok := t.Overrides[strings.ToLower(cluster)]; ok {
+		return timeout
+	}
+	return t.Default
+}
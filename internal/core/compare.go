@@ -0,0 +1,186 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// FieldDifferenceType classifies a FieldDifference.
+type FieldDifferenceType string
+
+const (
+	// FieldAdded is a field present in cluster B only.
+	FieldAdded FieldDifferenceType = "added"
+	// FieldRemoved is a field present in cluster A only.
+	FieldRemoved FieldDifferenceType = "removed"
+	// FieldChanged is a field present in both clusters with different
+	// values.
+	FieldChanged FieldDifferenceType = "changed"
+)
+
+// FieldDifference is one field that differs between the two objects
+// compared by CompareAcrossClusters.
+type FieldDifference struct {
+	Type FieldDifferenceType
+	// Path is the JSON Pointer (RFC 6901) of the field, e.g.
+	// "/spec/template/spec/containers/0/image".
+	Path string
+	// A and B are the values in clusters A and B, nil on the side
+	// missing the field.
+	A, B any
+}
+
+// ClusterComparison is the result of CompareAcrossClusters.
+type ClusterComparison struct {
+	// A and B are the normalized objects of clusters A and B, nil on
+	// the side where the object does not exist.
+	A, B *unstructured.Unstructured
+	// Differences lists the fields that differ, ordered by key and list
+	// index. It is empty when the object is missing from either
+	// cluster.
+	Differences []FieldDifference
+}
+
+// serverManagedMetadata lists the metadata fields set by the API server,
+// which differ between clusters whatever the object's desired state.
+var serverManagedMetadata = []string{
+	"uid",
+	"resourceVersion",
+	"generation",
+	"creationTimestamp",
+	"deletionTimestamp",
+	"deletionGracePeriodSeconds",
+	"selfLink",
+}
+
+// CompareAcrossClusters fetches the object identified by id from
+// clusterA and clusterB and reports how they differ, e.g. to check a
+// configuration before promoting it from staging to production.
+// id.Cluster is ignored.
+//
+// Both objects are normalized before they are compared: CleanObject
+// strips the noisy metadata, and the server-managed metadata and the
+// status are removed, so that only the desired state is compared. An
+// object missing from a cluster, or whose resource type that cluster
+// does not serve, is reported as a nil side rather than an error.
+func (uc *ResourceUseCase) CompareAcrossClusters(
+	ctx context.Context,
+	clusterA, clusterB string,
+	id ResourceIdentifier,
+) (*ClusterComparison, error) {
+	for _, c := range []struct{ field, cluster string }{{"cluster_a", clusterA}, {"cluster_b", clusterB}} {
+		if err := ValidateClusterName(c.cluster); err != nil {
+			var invalid *ErrInvalidInput
+			if errors.As(err, &invalid) {
+				invalid.Field = c.field
+			}
+			return nil, err
+		}
+	}
+	if id.Name == "" {
+		return nil, &ErrInvalidInput{Field: "name", Message: "is required"}
+	}
+
+	a, err := uc.getNormalized(ctx, clusterA, id)
+	if err != nil {
+		return nil, err
+	}
+	b, err := uc.getNormalized(ctx, clusterB, id)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &ClusterComparison{A: a, B: b}
+	if a != nil && b != nil {
+		comparison.Differences = diffValues("", a.Object, b.Object, nil)
+	}
+	return comparison, nil
+}
+
+// getNormalized fetches the object identified by id from cluster and
+// normalizes it for comparison. It returns nil if the object is not
+// found.
+func (uc *ResourceUseCase) getNormalized(ctx context.Context, cluster string, id ResourceIdentifier) (*unstructured.Unstructured, error) {
+	id.Cluster = cluster
+	obj, err := uc.GetResource(ctx, id)
+	if code, ok := DomainErrorCode(err); ok && code == ErrorCodeNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	obj = obj.DeepCopy()
+	CleanObject(obj.Object)
+	delete(obj.Object, "status")
+	if metadata, ok := obj.Object["metadata"].(map[string]any); ok {
+		for _, field := range serverManagedMetadata {
+			delete(metadata, field)
+		}
+	}
+	return obj, nil
+}
+
+// diffValues appends the differences between a and b, found at path,
+// to diffs. Maps are compared key by key and lists index by index, so
+// that a difference is reported at the deepest path where it occurs.
+func diffValues(path string, a, b any, diffs []FieldDifference) []FieldDifference {
+	switch a := a.(type) {
+	case map[string]any:
+		if b, ok := b.(map[string]any); ok {
+			keys := slices.Collect(maps.Keys(a))
+			for k := range b {
+				if _, ok := a[k]; !ok {
+					keys = append(keys, k)
+				}
+			}
+			slices.Sort(keys)
+			for _, k := range keys {
+				child := path + "/" + escapeJSONPointer(k)
+				av, inA := a[k]
+				bv, inB := b[k]
+				switch {
+				case !inA:
+					diffs = append(diffs, FieldDifference{Type: FieldAdded, Path: child, B: bv})
+				case !inB:
+					diffs = append(diffs, FieldDifference{Type: FieldRemoved, Path: child, A: av})
+				default:
+					diffs = diffValues(child, av, bv, diffs)
+				}
+			}
+			return diffs
+		}
+	case []any:
+		if b, ok := b.([]any); ok {
+			for i := range max(len(a), len(b)) {
+				child := fmt.Sprintf("%s/%d", path, i)
+				switch {
+				case i >= len(a):
+					diffs = append(diffs, FieldDifference{Type: FieldAdded, Path: child, B: b[i]})
+				case i >= len(b):
+					diffs = append(diffs, FieldDifference{Type: FieldRemoved, Path: child, A: a[i]})
+				default:
+					diffs = diffValues(child, a[i], b[i], diffs)
+				}
+			}
+			return diffs
+		}
+	}
+	if !reflect.DeepEqual(a, b) {
+		diffs = append(diffs, FieldDifference{Type: FieldChanged, Path: path, A: a, B: b})
+	}
+	return diffs
+}
+
+// escapeJSONPointer escapes a map key as a JSON Pointer reference
+// token, e.g. for annotation keys containing "/".
+func escapeJSONPointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
@@ -0,0 +1,117 @@
+package core
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// clustersRepo serves one object per cluster, as fetched from the API
+// server, and NotFound for clusters without one.
+type clustersRepo struct {
+	ResourceRepo
+	objects map[string]map[string]any
+}
+
+func (r *clustersRepo) Get(_ context.Context, cluster string, _ schema.GroupVersionResource, _, _ string) (*unstructured.Unstructured, error) {
+	obj, ok := r.objects[cluster]
+	if !ok {
+		return nil, &DomainError{Code: ErrorCodeNotFound, Message: "not found"}
+	}
+	return &unstructured.Unstructured{Object: obj}, nil
+}
+
+// deployment returns a Deployment as served by cluster, with its own
+// server-managed fields and status.
+func deployment(uid string, replicas int64, image string, annotations map[string]any) map[string]any {
+	metadata := map[string]any{
+		"name":              "shop",
+		"namespace":         "apps",
+		"uid":               uid,
+		"resourceVersion":   uid + "-1",
+		"generation":        int64(3),
+		"creationTimestamp": "2026-01-02T03:04:05Z",
+		"managedFields":     []any{map[string]any{"manager": "kubectl"}},
+	}
+	if annotations != nil {
+		metadata["annotations"] = annotations
+	}
+	return map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   metadata,
+		"spec": map[string]any{
+			"replicas": replicas,
+			"template": map[string]any{"spec": map[string]any{"containers": []any{
+				map[string]any{"name": "web", "image": image},
+			}}},
+		},
+		"status": map[string]any{"readyReplicas": replicas},
+	}
+}
+
+func TestResourceUseCase_CompareAcrossClusters(t *testing.T) {
+	repo := &clustersRepo{objects: map[string]map[string]any{
+		"staging": deployment("a1", 1, "shop:v2", map[string]any{
+			"kubectl.kubernetes.io/last-applied-configuration": "{}",
+		}),
+		"prod": deployment("b2", 3, "shop:v1", map[string]any{
+			"example.com/owner": "team-shop",
+		}),
+	}}
+	uc := NewResourceUseCase(&mockWatchDiscovery{}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+	id := ResourceIdentifier{Group: "apps", Version: "v1", Resource: "deployments", Namespace: "apps", Name: "shop"}
+
+	got, err := uc.CompareAcrossClusters(context.Background(), "staging", "prod", id)
+	if err != nil {
+		t.Fatalf("CompareAcrossClusters: %v", err)
+	}
+	want := []FieldDifference{
+		{Type: FieldAdded, Path: "/metadata/annotations", B: map[string]any{"example.com/owner": "team-shop"}},
+		{Type: FieldChanged, Path: "/spec/replicas", A: int64(1), B: int64(3)},
+		{Type: FieldChanged, Path: "/spec/template/spec/containers/0/image", A: "shop:v2", B: "shop:v1"},
+	}
+	if !reflect.DeepEqual(got.Differences, want) {
+		t.Errorf("differences = %+v, want %+v", got.Differences, want)
+	}
+	if got.A == nil || got.B == nil {
+		t.Fatalf("A, B = %v, %v, want both objects", got.A, got.B)
+	}
+	if got.A.GetUID() != "" || got.A.GetResourceVersion() != "" || got.A.Object["status"] != nil {
+		t.Errorf("A was not normalized: %v", got.A.Object)
+	}
+	if repo.objects["staging"]["status"] == nil {
+		t.Error("normalization modified the fetched object")
+	}
+
+	// An object missing from one cluster is not an error.
+	got, err = uc.CompareAcrossClusters(context.Background(), "staging", "dev", id)
+	if err != nil {
+		t.Fatalf("CompareAcrossClusters with a missing object: %v", err)
+	}
+	if got.A == nil || got.B != nil || len(got.Differences) != 0 {
+		t.Errorf("comparison = %+v, want only A and no differences", got)
+	}
+
+	_, err = uc.CompareAcrossClusters(context.Background(), "staging", "", id)
+	var invalid *ErrInvalidInput
+	if !isErrInvalidInput(err, &invalid) || invalid.Field != "cluster_b" {
+		t.Errorf("err = %v, want invalid input on cluster_b", err)
+	}
+}
+
+func TestDiffValues_EscapesKeysAndComparesLists(t *testing.T) {
+	a := map[string]any{"labels": map[string]any{"app.kubernetes.io/name": "shop"}, "args": []any{"-v", "-x"}}
+	b := map[string]any{"labels": map[string]any{"app.kubernetes.io/name": "cart"}, "args": []any{"-v"}}
+	got := diffValues("", a, b, nil)
+	want := []FieldDifference{
+		{Type: FieldRemoved, Path: "/args/1", A: "-x"},
+		{Type: FieldChanged, Path: "/labels/app.kubernetes.io~1name", A: "shop", B: "cart"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffValues = %+v, want %+v", got, want)
+	}
+}
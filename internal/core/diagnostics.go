@@ -0,0 +1,84 @@
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// AgentDiagnosticsPath is the path of the diagnostics report on an
+// agent's tunnel endpoint. It lies outside every Kubernetes API path,
+// so the agent answers it itself instead of proxying it to the API
+// server.
+const AgentDiagnosticsPath = "/.otterscale/diagnostics"
+
+// AgentDiagnostics is an agent's report of its own state, for
+// troubleshooting an agent without shelling into its cluster. It is
+// served by the agent as JSON.
+type AgentDiagnostics struct {
+	AgentVersion string    `json:"agent_version"`
+	CollectedAt  time.Time `json:"collected_at"`
+	// Config is the agent's effective configuration. Secret values
+	// are redacted by the agent before they leave the cluster.
+	Config    []AgentSetting        `json:"config"`
+	Tunnel    AgentTunnelStatus     `json:"tunnel"`
+	Proxy     AgentProxyStats       `json:"proxy"`
+	APIServer APIServerReachability `json:"api_server"`
+}
+
+// AgentSetting is one key of an agent's effective configuration and
+// the source its value came from (default, file, env or flag).
+type AgentSetting struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// AgentTunnelStatus is the state of an agent's tunnel connection.
+type AgentTunnelStatus struct {
+	Connected        bool      `json:"connected"`
+	TunnelServerURL  string    `json:"tunnel_server_url,omitempty"`
+	Endpoint         string    `json:"endpoint,omitempty"`
+	LastRegistration time.Time `json:"last_registration,omitzero"`
+	Reconnects       uint64    `json:"reconnects"`
+}
+
+// AgentProxyStats counts the traffic an agent proxied to its API
+// server. Errors counts requests the agent could not forward.
+type AgentProxyStats struct {
+	Errors       uint64 `json:"errors"`
+	BytesProxied uint64 `json:"bytes_proxied"`
+}
+
+// APIServerReachability is the outcome of an agent probing its
+// in-cluster API server.
+type APIServerReachability struct {
+	Reachable bool          `json:"reachable"`
+	Version   string        `json:"version,omitempty"`
+	Latency   time.Duration `json:"latency"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// AgentDiagnosticsRepo fetches the diagnostics report of a cluster's
+// agent through the tunnel.
+type AgentDiagnosticsRepo interface {
+	AgentDiagnostics(ctx context.Context, cluster string) (*AgentDiagnostics, error)
+}
+
+// DiagnosticsUseCase retrieves agent diagnostics for operators.
+type DiagnosticsUseCase struct {
+	repo AgentDiagnosticsRepo
+}
+
+// NewDiagnosticsUseCase returns a DiagnosticsUseCase backed by repo.
+func NewDiagnosticsUseCase(repo AgentDiagnosticsRepo) *DiagnosticsUseCase {
+	return &DiagnosticsUseCase{repo: repo}
+}
+
+// AgentDiagnostics returns the diagnostics report of cluster's agent.
+// The caller must be allowed to access the cluster.
+func (uc *DiagnosticsUseCase) AgentDiagnostics(ctx context.Context, cluster string) (*AgentDiagnostics, error) {
+	if err := ValidateClusterName(cluster); err != nil {
+		return nil, err
+	}
+	return uc.repo.AgentDiagnostics(ctx, cluster)
+}
@@ -3,6 +3,8 @@ package core
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 )
 
 // ErrorCode represents a domain-level error category that abstracts
@@ -12,17 +14,17 @@ import (
 type ErrorCode int
 
 const (
-	ErrorCodeInternal          ErrorCode = iota // catch-all
-	ErrorCodeInvalidArgument                    // bad input
-	ErrorCodeNotFound                           // resource missing
-	ErrorCodeAlreadyExists                      // duplicate
-	ErrorCodeUnauthenticated                    // no/invalid creds
-	ErrorCodePermissionDenied                   // forbidden
-	ErrorCodeFailedPrecondition                 // conflict / precondition
-	ErrorCodeDeadlineExceeded                   // timeout
-	ErrorCodeResourceExhausted                  // rate-limit / quota
-	ErrorCodeUnimplemented                      // method not allowed
-	ErrorCodeUnavailable                        // service unavailable
+	ErrorCodeInternal           ErrorCode = iota // catch-all
+	ErrorCodeInvalidArgument                     // bad input
+	ErrorCodeNotFound                            // resource missing
+	ErrorCodeAlreadyExists                       // duplicate
+	ErrorCodeUnauthenticated                     // no/invalid creds
+	ErrorCodePermissionDenied                    // forbidden
+	ErrorCodeFailedPrecondition                  // conflict / precondition
+	ErrorCodeDeadlineExceeded                    // timeout
+	ErrorCodeResourceExhausted                   // rate-limit / quota
+	ErrorCodeUnimplemented                       // method not allowed
+	ErrorCodeUnavailable                         // service unavailable
 )
 
 // DomainError is a generic domain error carrying an ErrorCode and an
@@ -64,6 +66,140 @@ func (e *ErrClusterNotFound) Error() string {
 	return fmt.Sprintf("cluster %s not registered", e.Cluster)
 }
 
+// ErrClusterNotReady indicates that the cluster is registered but its
+// agent cannot be reached through the tunnel, typically because the
+// agent disconnected and the tunnel listener is gone. It is distinct
+// from ErrClusterNotFound, which means the cluster name is unknown.
+type ErrClusterNotReady struct {
+	Cluster string
+	Cause   error
+}
+
+func (e *ErrClusterNotReady) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("cluster %s is registered but its agent is offline: %v", e.Cluster, e.Cause)
+	}
+	return fmt.Sprintf("cluster %s is registered but its agent is offline", e.Cluster)
+}
+
+func (e *ErrClusterNotReady) Unwrap() error { return e.Cause }
+
+// ErrResourceVersionExpired indicates that a watch was requested from
+// a resourceVersion the API server no longer retains (HTTP 410 Gone).
+// The client must relist to obtain a current resourceVersion.
+type ErrResourceVersionExpired struct {
+	ResourceVersion string
+	Message         string
+}
+
+func (e *ErrResourceVersionExpired) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("resource version %q expired: %s", e.ResourceVersion, e.Message)
+	}
+	return fmt.Sprintf("resource version %q expired", e.ResourceVersion)
+}
+
+// ApplyConflict is a field that a server-side apply would change but
+// that is owned by another field manager.
+type ApplyConflict struct {
+	// Manager is the field manager that owns the field.
+	Manager string
+	// Field is the path of the field, e.g. ".spec.replicas".
+	Field string
+	// Message is the API server's description of the conflict.
+	Message string
+}
+
+// ErrApplyConflict indicates that a server-side apply was rejected
+// because it would change fields owned by other field managers. The
+// caller may take the fields over with ResourceUseCase.ForceApplyResource.
+type ErrApplyConflict struct {
+	Conflicts []ApplyConflict
+	Message   string
+}
+
+func (e *ErrApplyConflict) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("apply conflicts with fields owned by %s: %s", strings.Join(e.Managers(), ", "), e.Message)
+	}
+	return fmt.Sprintf("apply conflicts with fields owned by %s", strings.Join(e.Managers(), ", "))
+}
+
+// Managers returns the distinct field managers involved, sorted.
+func (e *ErrApplyConflict) Managers() []string {
+	return conflictManagers(e.Conflicts)
+}
+
+// conflictManagers returns the distinct managers of conflicts, sorted.
+func conflictManagers(conflicts []ApplyConflict) []string {
+	managers := make([]string, 0, len(conflicts))
+	for _, c := range conflicts {
+		managers = append(managers, c.Manager)
+	}
+	slices.Sort(managers)
+	return slices.Compact(managers)
+}
+
+// ErrAdmissionDenied indicates that an admission webhook rejected a
+// request. Code is the category of the API server's response, which
+// the webhook chooses.
+type ErrAdmissionDenied struct {
+	// Webhook is the name of the rejecting webhook configuration entry.
+	Webhook string
+	// Reason is the webhook's explanation, if it gave one.
+	Reason string
+	Code   ErrorCode
+	Cause  error
+}
+
+func (e *ErrAdmissionDenied) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("rejected by webhook %s: %s", e.Webhook, e.Reason)
+	}
+	return fmt.Sprintf("rejected by webhook %s", e.Webhook)
+}
+
+func (e *ErrAdmissionDenied) Unwrap() error { return e.Cause }
+
+// ErrListTooLarge indicates that the API server rejected a list as too
+// large (HTTP 413), either unpaginated or even after retrying with
+// smaller pages. Callers should paginate with at most SuggestedLimit
+// items per page.
+type ErrListTooLarge struct {
+	// Limit is the smallest page size that was attempted, or zero if
+	// the list was not paginated.
+	Limit          int64
+	SuggestedLimit int64
+	Cause          error
+}
+
+func (e *ErrListTooLarge) Error() string {
+	if e.Limit <= 0 {
+		return fmt.Sprintf("list response too large; paginate with limit %d or less", e.SuggestedLimit)
+	}
+	return fmt.Sprintf("list response too large even with limit %d; paginate with limit %d or less", e.Limit, e.SuggestedLimit)
+}
+
+func (e *ErrListTooLarge) Unwrap() error { return e.Cause }
+
+// ErrNamespaceTerminating indicates that an object could not be
+// created because its namespace is being deleted. The API server
+// reports this as Forbidden, which otherwise reads as missing RBAC.
+// Namespace is empty if the API server did not name it.
+type ErrNamespaceTerminating struct {
+	Namespace string
+	Cause     error
+}
+
+func (e *ErrNamespaceTerminating) Error() string {
+	if e.Namespace == "" {
+		return "namespace is terminating"
+	}
+	return fmt.Sprintf("namespace %q is terminating", e.Namespace)
+}
+
+func (e *ErrNamespaceTerminating) Unwrap() error { return e.Cause }
+
 // ErrNotReady indicates that a required subsystem (e.g. the tunnel
 // server) has not been initialized yet.
 type ErrNotReady struct {
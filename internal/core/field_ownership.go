@@ -0,0 +1,82 @@
+package core
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/structured-merge-diff/v6/fieldpath"
+)
+
+// FieldOwner is a field manager owning a field, as recorded by one
+// entry of metadata.managedFields.
+type FieldOwner struct {
+	Manager string
+	// Operation is "Apply" for server-side applies and "Update" for
+	// any other write.
+	Operation string
+	// Time is when the manager last changed its fields, or zero if not
+	// recorded.
+	Time        time.Time
+	Subresource string
+	APIVersion  string
+}
+
+// OwnedField is a field of an object and the managers owning it. Path
+// uses the notation of server-side apply conflicts, e.g.
+// `.spec.containers[name="web"].image`.
+type OwnedField struct {
+	Path   string
+	Owners []FieldOwner
+}
+
+// FieldOwnership fetches the resource identified by id with its
+// managedFields intact and returns who owns which of its fields,
+// sorted by path.
+func (uc *ResourceUseCase) FieldOwnership(ctx context.Context, id ResourceIdentifier) ([]OwnedField, error) {
+	obj, err := uc.GetResource(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return parseManagedFields(obj.GetManagedFields())
+}
+
+// parseManagedFields inverts managedFields entries, which list the
+// fields each manager owns, into the owners of each field. Fields
+// several managers share list them in the order of the entries.
+func parseManagedFields(entries []metav1.ManagedFieldsEntry) ([]OwnedField, error) {
+	owners := map[string][]FieldOwner{}
+	for _, entry := range entries {
+		if entry.FieldsType != "FieldsV1" || entry.FieldsV1 == nil {
+			continue
+		}
+		set := &fieldpath.Set{}
+		if err := set.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			return nil, fmt.Errorf("parse managed fields of %q: %w", entry.Manager, err)
+		}
+		owner := FieldOwner{
+			Manager:     entry.Manager,
+			Operation:   string(entry.Operation),
+			Subresource: entry.Subresource,
+			APIVersion:  entry.APIVersion,
+		}
+		if entry.Time != nil {
+			owner.Time = entry.Time.Time
+		}
+		set.Iterate(func(p fieldpath.Path) {
+			path := p.String()
+			owners[path] = append(owners[path], owner)
+		})
+	}
+
+	fields := make([]OwnedField, 0, len(owners))
+	for path, o := range owners {
+		fields = append(fields, OwnedField{Path: path, Owners: o})
+	}
+	slices.SortFunc(fields, func(a, b OwnedField) int { return cmp.Compare(a.Path, b.Path) })
+	return fields, nil
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// sampleManagedFields is the managedFields of a Deployment created by
+// kubectl, then server-side applied by the web UI and scaled by an
+// autoscaler, trimmed to a few fields.
+const sampleManagedFields = `[
+  {
+    "manager": "kubectl-create",
+    "operation": "Update",
+    "apiVersion": "apps/v1",
+    "time": "2026-10-01T10:00:00Z",
+    "fieldsType": "FieldsV1",
+    "fieldsV1": {"f:metadata": {"f:labels": {".": {}, "f:app": {}}}}
+  },
+  {
+    "manager": "otterscale-web-ui",
+    "operation": "Apply",
+    "apiVersion": "apps/v1",
+    "time": "2026-10-02T10:00:00Z",
+    "fieldsType": "FieldsV1",
+    "fieldsV1": {
+      "f:metadata": {"f:labels": {"f:app": {}}},
+      "f:spec": {"f:template": {"f:spec": {"f:containers": {
+        "k:{\"name\":\"web\"}": {".": {}, "f:image": {}, "f:name": {}}
+      }}}}
+    }
+  },
+  {
+    "manager": "autoscaler",
+    "operation": "Update",
+    "apiVersion": "apps/v1",
+    "time": "2026-10-03T10:00:00Z",
+    "fieldsType": "FieldsV1",
+    "fieldsV1": {"f:spec": {"f:replicas": {}}},
+    "subresource": "scale"
+  }
+]`
+
+func TestParseManagedFields(t *testing.T) {
+	var entries []metav1.ManagedFieldsEntry
+	if err := json.Unmarshal([]byte(sampleManagedFields), &entries); err != nil {
+		t.Fatalf("decode sample: %v", err)
+	}
+
+	fields, err := parseManagedFields(entries)
+	if err != nil {
+		t.Fatalf("parseManagedFields: %v", err)
+	}
+
+	got := map[string][]string{}
+	var paths []string
+	for _, f := range fields {
+		paths = append(paths, f.Path)
+		for _, o := range f.Owners {
+			got[f.Path] = append(got[f.Path], o.Manager+"/"+o.Operation)
+		}
+	}
+	want := map[string][]string{
+		".metadata.labels":                                 {"kubectl-create/Update"},
+		".metadata.labels.app":                             {"kubectl-create/Update", "otterscale-web-ui/Apply"},
+		`.spec.replicas`:                                   {"autoscaler/Update"},
+		`.spec.template.spec.containers[name="web"]`:       {"otterscale-web-ui/Apply"},
+		`.spec.template.spec.containers[name="web"].image`: {"otterscale-web-ui/Apply"},
+		`.spec.template.spec.containers[name="web"].name`:  {"otterscale-web-ui/Apply"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("owned paths = %v, want %d paths", paths, len(want))
+	}
+	for path, managers := range want {
+		if !slices.Equal(got[path], managers) {
+			t.Errorf("owners of %s = %v, want %v", path, got[path], managers)
+		}
+	}
+	if !slices.IsSorted(paths) {
+		t.Errorf("paths = %v, want them sorted", paths)
+	}
+
+	for _, f := range fields {
+		if f.Path != ".spec.replicas" {
+			continue
+		}
+		owner := f.Owners[0]
+		if owner.Subresource != "scale" || owner.APIVersion != "apps/v1" || !owner.Time.Equal(time.Date(2026, 10, 3, 10, 0, 0, 0, time.UTC)) {
+			t.Errorf("owner of .spec.replicas = %+v, want the autoscaler's scale update of 2026-10-03", owner)
+		}
+	}
+}
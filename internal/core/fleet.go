@@ -9,6 +9,9 @@ import (
 	"log/slog"
 	"regexp"
 	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
 )
 
 // maxClusterNameLength is the maximum allowed length for a cluster
@@ -51,6 +54,9 @@ type TunnelProvider interface {
 	// agents can verify the tunnel server and the server can
 	// configure mTLS.
 	CACertPEM() []byte
+	// Fingerprint returns the SSH host key fingerprint of the tunnel
+	// server, or an empty string if the server is not running yet.
+	Fingerprint() string
 	// ListClusters returns the names of all registered clusters.
 	ListClusters() map[string]Cluster
 	// RegisterCluster validates and signs the agent's CSR, creates
@@ -96,6 +102,10 @@ type Registration struct {
 	// compare this against their own version to decide whether a
 	// self-update is needed.
 	ServerVersion string
+	// TunnelFingerprint is the SSH host key fingerprint of the tunnel
+	// server. Agents pin it when connecting; it may be empty if the
+	// tunnel server has not started yet.
+	TunnelFingerprint string
 }
 
 // Cluster holds the per-cluster tunnel state: the allocated
@@ -104,6 +114,45 @@ type Cluster struct {
 	Host         string // unique 127.x.x.x loopback address
 	User         string // chisel user name
 	AgentVersion string // agent binary version
+	// OutOfDate reports whether the agent is older than the server. It
+	// is derived by FleetUseCase.ListClusters, not stored.
+	OutOfDate bool
+}
+
+// ListClustersOptions filters the clusters returned by
+// FleetUseCase.ListClusters.
+type ListClustersOptions struct {
+	// OutOfDateOnly restricts the result to clusters whose agent is
+	// older than the server.
+	OutOfDateOnly bool
+}
+
+// Fleet membership events, reported to a FleetNotifier.
+const (
+	// FleetEventRegistered is reported when an agent registers a
+	// cluster, including when it registers again.
+	FleetEventRegistered = "cluster_registered"
+	// FleetEventDeregistered is reported when a cluster is removed
+	// from the fleet, explicitly or after failing health probes.
+	FleetEventDeregistered = "cluster_deregistered"
+)
+
+// FleetEvent describes a cluster joining or leaving the fleet.
+type FleetEvent struct {
+	// Event is FleetEventRegistered or FleetEventDeregistered.
+	Event        string    `json:"event"`
+	Cluster      string    `json:"cluster"`
+	AgentID      string    `json:"agent_id"`
+	AgentVersion string    `json:"agent_version,omitempty"`
+	Endpoint     string    `json:"endpoint"`
+	Reason       string    `json:"reason,omitempty"`
+	Time         time.Time `json:"time"`
+}
+
+// FleetNotifier is told about fleet membership changes. Notify is
+// called on the registration path and must not block on delivery.
+type FleetNotifier interface {
+	Notify(event FleetEvent)
 }
 
 // AgentManifestConfig holds the external URLs and HMAC key needed to
@@ -118,6 +167,15 @@ type AgentManifestConfig struct {
 	// HMACKey is a 32-byte key derived from the CA seed via HKDF.
 	// It is used to sign and verify stateless manifest tokens.
 	HMACKey []byte
+	// OIDCIssuerURL is the OIDC issuer (Keycloak realm URL) that
+	// generated kubeconfigs authenticate against.
+	OIDCIssuerURL string
+	// OIDCClientID is the OIDC client ID used by generated kubeconfigs.
+	OIDCClientID string
+	// ClockSkew is the tolerated difference between the clocks of the
+	// server replica that issues a signed manifest or kubeconfig URL
+	// and the one that serves it. Zero selects a default of 5 minutes.
+	ClockSkew time.Duration
 }
 
 // ManifestParams holds the parameters needed to render an agent
@@ -131,53 +189,176 @@ type ManifestParams struct {
 	TunnelURL string
 }
 
-// ManifestRenderer renders agent installation manifests from the given
-// parameters. Implementations live in the providers layer and own the
-// template and formatting details.
+// AgentImage returns the container image reference of the agent
+// matching the given binary version.
+func AgentImage(v Version) string {
+	return fmt.Sprintf("ghcr.io/otterscale/otterscale:%s", v)
+}
+
+// ValidateManifestParams checks that params are complete and that the
+// cluster name is well-formed. It is shared by the GetAgentManifest
+// RPC and the offline manifest command so both apply the same rules.
+// It returns an *ErrInvalidInput on failure.
+func ValidateManifestParams(params ManifestParams) error {
+	if err := validateManifestRequest(params.Cluster, params.UserName); err != nil {
+		return err
+	}
+	if field := missingManifestSetting(params); field != "" {
+		return &ErrInvalidInput{Field: field, Message: "must not be empty"}
+	}
+	return nil
+}
+
+// validateManifestRequest checks the caller-supplied manifest
+// parameters: the cluster name and the user bound to cluster-admin.
+func validateManifestRequest(cluster, userName string) error {
+	if err := ValidateClusterName(cluster); err != nil {
+		return err
+	}
+	if userName == "" {
+		return &ErrInvalidInput{Field: "user_name", Message: "must not be empty"}
+	}
+	return nil
+}
+
+// missingManifestSetting returns the name of the first empty
+// deployment setting of params (image, server or tunnel URL), or ""
+// when all are set.
+func missingManifestSetting(params ManifestParams) string {
+	switch {
+	case params.Image == "":
+		return "image"
+	case params.ServerURL == "":
+		return "server_url"
+	case params.TunnelURL == "":
+		return "tunnel_url"
+	}
+	return ""
+}
+
+// KubeconfigParams holds the parameters needed to render a kubeconfig
+// for a cluster. Like ManifestParams it is a pure value object; the
+// rendering logic lives in the providers layer.
+type KubeconfigParams struct {
+	Cluster string
+	// APIServerURL is the kube-API endpoint kubectl talks to: the
+	// server's proxy for Cluster, see KubeAPIServerURL.
+	APIServerURL  string
+	OIDCIssuerURL string
+	OIDCClientID  string
+}
+
+// ManifestRenderer renders agent installation manifests and user
+// kubeconfigs from the given parameters. Implementations live in the
+// providers layer and own the template and formatting details.
 type ManifestRenderer interface {
 	RenderAgentManifest(params ManifestParams) (string, error)
+	RenderKubeconfig(params KubeconfigParams) (string, error)
+}
+
+// manifestResourcePath is the server path under which signed agent
+// manifest URLs are served.
+const manifestResourcePath = "/fleet/manifest"
+
+// manifestTokenTTL is the validity period of signed manifest URLs.
+// After this duration the token expires and a new one must be issued
+// via the GetAgentManifest RPC.
+const manifestTokenTTL = 1 * time.Hour
+
+// kubeconfigResourcePath is the server path under which signed
+// kubeconfig URLs are served.
+const kubeconfigResourcePath = "/fleet/kubeconfig"
+
+// kubeconfigTokenTTL is the validity period of signed kubeconfig URLs.
+// It is kept short because the link is meant to be fetched right
+// after it is issued via the GetKubeconfig RPC.
+const kubeconfigTokenTTL = 10 * time.Minute
+
+// KubeAPIServerURL returns the URL at which the server at serverURL
+// proxies the kube-API of cluster.
+func KubeAPIServerURL(serverURL, cluster string) string {
+	return strings.TrimRight(serverURL, "/") + KubeAPIPathPrefix + cluster
 }
 
 // FleetUseCase orchestrates cluster registration on the server side.
 // It delegates CSR signing and tunnel setup to the TunnelProvider,
-// and token management to the ManifestTokenIssuer.
+// and manifest URL signing to the URLSigner.
 type FleetUseCase struct {
 	tunnel      TunnelProvider
 	version     Version
 	manifestCfg AgentManifestConfig
 	renderer    ManifestRenderer
-	tokenIssuer *ManifestTokenIssuer
+	signer      *URLSigner
+	authz       ClusterAuthorizer
 }
 
 // NewFleetUseCase returns a FleetUseCase backed by the given
 // TunnelProvider. version is the server binary version, included in
 // registration responses so agents can detect mismatches.
 // manifestCfg provides the external URLs embedded in generated agent
-// installation manifests. It returns an error if any required
-// manifest configuration field is missing.
-func NewFleetUseCase(tunnel TunnelProvider, version Version, manifestCfg AgentManifestConfig, renderer ManifestRenderer) (*FleetUseCase, error) {
+// installation manifests. authz decides which clusters a user may get
+// a kubeconfig for. clock is the time source for manifest token
+// expiry. It returns an error if any required manifest configuration
+// field is missing.
+func NewFleetUseCase(tunnel TunnelProvider, version Version, manifestCfg AgentManifestConfig, renderer ManifestRenderer, authz ClusterAuthorizer, clock Clock) (*FleetUseCase, error) {
 	if manifestCfg.ServerURL == "" {
 		return nil, fmt.Errorf("manifest config: server URL is required")
 	}
 	if manifestCfg.TunnelURL == "" {
 		return nil, fmt.Errorf("manifest config: tunnel URL is required")
 	}
-	tokenIssuer, err := NewManifestTokenIssuer(manifestCfg.HMACKey)
+	if manifestCfg.ClockSkew < 0 {
+		return nil, fmt.Errorf("manifest config: clock skew must not be negative")
+	}
+	signer, err := NewURLSigner(manifestCfg.ServerURL, manifestCfg.HMACKey, clock)
 	if err != nil {
 		return nil, err
 	}
+	if manifestCfg.ClockSkew > 0 {
+		signer.clockSkew = manifestCfg.ClockSkew
+	}
+	// Manifest tokens issued before URLs were bound to a resource
+	// path carry no path claim; keep accepting them here.
+	signer.legacyResourcePath = manifestResourcePath
 	return &FleetUseCase{
 		tunnel:      tunnel,
 		version:     version,
 		manifestCfg: manifestCfg,
 		renderer:    renderer,
-		tokenIssuer: tokenIssuer,
+		signer:      signer,
+		authz:       authz,
 	}, nil
 }
 
-// ListClusters returns the names of all currently registered clusters.
-func (uc *FleetUseCase) ListClusters(ctx context.Context) map[string]Cluster {
-	return uc.tunnel.ListClusters()
+// ListClusters returns all currently registered clusters, each marked
+// OutOfDate if its agent is older than the server, keeping only the
+// out-of-date ones if opts.OutOfDateOnly is set.
+func (uc *FleetUseCase) ListClusters(ctx context.Context, opts ListClustersOptions) map[string]Cluster {
+	clusters := uc.tunnel.ListClusters()
+	ret := make(map[string]Cluster, len(clusters))
+	for name, c := range clusters {
+		c.OutOfDate = agentOutOfDate(c.AgentVersion, string(uc.version))
+		if opts.OutOfDateOnly && !c.OutOfDate {
+			continue
+		}
+		ret[name] = c
+	}
+	return ret
+}
+
+// agentOutOfDate reports whether agentVersion is an older semantic
+// version than serverVersion. Versions that do not parse, such as
+// "dev" builds on either side, are never reported as out of date.
+func agentOutOfDate(agentVersion, serverVersion string) bool {
+	agent, err := semver.NewVersion(agentVersion)
+	if err != nil {
+		return false
+	}
+	server, err := semver.NewVersion(serverVersion)
+	if err != nil {
+		return false
+	}
+	return agent.LessThan(server)
 }
 
 // RegisterCluster validates the inputs, forwards the agent's CSR to
@@ -199,37 +380,40 @@ func (uc *FleetUseCase) RegisterCluster(ctx context.Context, cluster, agentID, a
 		return Registration{}, err
 	}
 	return Registration{
-		Endpoint:      endpoint,
-		Certificate:   certPEM,
-		CACertificate: uc.tunnel.CACertPEM(),
-		ServerVersion: string(uc.version),
+		Endpoint:          endpoint,
+		Certificate:       certPEM,
+		CACertificate:     uc.tunnel.CACertPEM(),
+		ServerVersion:     string(uc.version),
+		TunnelFingerprint: uc.tunnel.Fingerprint(),
 	}, nil
 }
 
-// IssueManifestURL generates an HMAC-signed token that encodes the
-// cluster name and user identity, and returns a full URL that serves
-// the agent manifest as raw YAML. The token is valid for
-// manifestTokenTTL.
+// IssueManifestURL returns a signed URL that serves the agent
+// manifest for cluster as raw YAML, issued on behalf of userName. The
+// URL is valid for manifestTokenTTL.
 func (uc *FleetUseCase) IssueManifestURL(ctx context.Context, cluster, userName string) (string, error) {
-	token, err := uc.tokenIssuer.Issue(cluster, userName)
+	url, err := uc.signer.IssueSignedURL(manifestResourcePath, SignedURLClaims{
+		Subject: userName,
+		Cluster: cluster,
+	}, manifestTokenTTL)
 	if err != nil {
 		return "", fmt.Errorf("issue manifest token: %w", err)
 	}
-	return strings.TrimRight(uc.manifestCfg.ServerURL, "/") + "/fleet/manifest/" + token, nil
+	return url, nil
 }
 
-// VerifyManifestToken validates the HMAC signature and expiry of a
-// manifest token and returns the embedded cluster name and user
-// identity. All verification failures return a generic error to
-// avoid leaking which stage failed; detailed reasons are logged at
-// debug level.
+// VerifyManifestToken validates the HMAC signature, expiry and
+// issued-at time of a manifest token and returns the embedded cluster
+// name and user identity. All verification failures return a generic
+// error to avoid leaking which stage failed; detailed reasons are
+// logged at debug level.
 func (uc *FleetUseCase) VerifyManifestToken(ctx context.Context, token string) (cluster, userName string, err error) {
-	cluster, userName, err = uc.tokenIssuer.Verify(token)
+	claims, err := uc.signer.verifyDetailed(manifestResourcePath, token, manifestTokenTTL)
 	if err != nil {
 		slog.Debug("manifest token verification failed", "error", err)
-		return "", "", err
+		return "", "", errInvalidToken
 	}
-	return cluster, userName, nil
+	return claims.Cluster, claims.Subject, nil
 }
 
 // GenerateAgentManifest produces a multi-document YAML manifest for
@@ -238,18 +422,108 @@ func (uc *FleetUseCase) VerifyManifestToken(ctx context.Context, token string) (
 // ClusterRoleBinding (binding userName to cluster-admin), and a
 // Deployment that runs the agent with the correct server/tunnel URLs.
 func (uc *FleetUseCase) GenerateAgentManifest(ctx context.Context, cluster, userName string) (string, error) {
-	if err := ValidateClusterName(cluster); err != nil {
+	if err := validateManifestRequest(cluster, userName); err != nil {
 		return "", err
 	}
-	if userName == "" {
-		return "", &ErrInvalidInput{Field: "user_name", Message: "must not be empty"}
-	}
 
-	return uc.renderer.RenderAgentManifest(ManifestParams{
+	params := ManifestParams{
 		Cluster:   cluster,
 		UserName:  userName,
-		Image:     fmt.Sprintf("ghcr.io/otterscale/otterscale:%s", uc.version),
+		Image:     AgentImage(uc.version),
 		ServerURL: uc.manifestCfg.ServerURL,
 		TunnelURL: uc.manifestCfg.TunnelURL,
+	}
+	// The remaining parameters come from the server's configuration,
+	// so a gap there is a server fault rather than a bad request.
+	if field := missingManifestSetting(params); field != "" {
+		return "", &DomainError{
+			Code:    ErrorCodeFailedPrecondition,
+			Message: fmt.Sprintf("server is not configured to render agent manifests: %s is empty", field),
+		}
+	}
+
+	return uc.renderer.RenderAgentManifest(params)
+}
+
+// GenerateKubeconfig produces a kubeconfig YAML for cluster, which the
+// calling user must be allowed to access. The kubeconfig points at the
+// server's kube-API proxy for the cluster, names its cluster and
+// context after the target cluster, and authenticates users via an
+// OIDC exec plugin configured for the server's identity provider. It
+// embeds no credentials, so it is safe to hand out over a signed URL.
+func (uc *FleetUseCase) GenerateKubeconfig(ctx context.Context, cluster string) (string, error) {
+	if err := uc.authorizeKubeconfig(ctx, cluster); err != nil {
+		return "", err
+	}
+	return uc.renderKubeconfig(cluster)
+}
+
+// IssueKubeconfigURL returns a signed URL that serves the kubeconfig
+// for cluster as raw YAML, issued on behalf of userName, who must be
+// allowed to access cluster. The URL is valid for kubeconfigTokenTTL
+// and may be fetched repeatedly until then, from any server replica;
+// the kubeconfig embeds no credentials.
+func (uc *FleetUseCase) IssueKubeconfigURL(ctx context.Context, cluster, userName string) (string, error) {
+	if err := uc.authorizeKubeconfig(ctx, cluster); err != nil {
+		return "", err
+	}
+	url, err := uc.signer.IssueSignedURL(kubeconfigResourcePath, SignedURLClaims{
+		Subject: userName,
+		Cluster: cluster,
+	}, kubeconfigTokenTTL)
+	if err != nil {
+		return "", fmt.Errorf("issue kubeconfig token: %w", err)
+	}
+	return url, nil
+}
+
+// DownloadKubeconfig validates a kubeconfig token and returns the
+// cluster and kubeconfig it was issued for. Access to the cluster was
+// checked when the URL was issued. As with VerifyManifestToken,
+// failures return a generic error and detailed reasons are logged at
+// debug level.
+func (uc *FleetUseCase) DownloadKubeconfig(ctx context.Context, token string) (cluster, kubeconfig string, err error) {
+	claims, err := uc.signer.verifyDetailed(kubeconfigResourcePath, token, kubeconfigTokenTTL)
+	if err != nil {
+		slog.Debug("kubeconfig token verification failed", "error", err)
+		return "", "", errInvalidToken
+	}
+
+	kubeconfig, err = uc.renderKubeconfig(claims.Cluster)
+	if err != nil {
+		return "", "", err
+	}
+	return claims.Cluster, kubeconfig, nil
+}
+
+// authorizeKubeconfig checks that the calling user in ctx may access
+// cluster.
+func (uc *FleetUseCase) authorizeKubeconfig(ctx context.Context, cluster string) error {
+	if err := ValidateClusterName(cluster); err != nil {
+		return err
+	}
+	user, ok := UserInfoFromContext(ctx)
+	if !ok {
+		return &DomainError{Code: ErrorCodeUnauthenticated, Message: "user info not found in context"}
+	}
+	return uc.authz.AuthorizeCluster(ctx, user, cluster)
+}
+
+// renderKubeconfig renders the kubeconfig for cluster.
+func (uc *FleetUseCase) renderKubeconfig(cluster string) (string, error) {
+	// As for agent manifests, these come from the server's
+	// configuration, so a gap is a server fault.
+	if uc.manifestCfg.OIDCIssuerURL == "" || uc.manifestCfg.OIDCClientID == "" {
+		return "", &DomainError{
+			Code:    ErrorCodeFailedPrecondition,
+			Message: "server is not configured to render kubeconfigs: OIDC issuer URL and client ID are required",
+		}
+	}
+
+	return uc.renderer.RenderKubeconfig(KubeconfigParams{
+		Cluster:       cluster,
+		APIServerURL:  KubeAPIServerURL(uc.manifestCfg.ServerURL, cluster),
+		OIDCIssuerURL: uc.manifestCfg.OIDCIssuerURL,
+		OIDCClientID:  uc.manifestCfg.OIDCClientID,
 	})
 }
@@ -5,18 +5,21 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 )
 
 // mockTunnelProvider implements TunnelProvider for testing.
 type mockTunnelProvider struct {
 	clusters    map[string]Cluster
 	caCertPEM   []byte
+	fingerprint string
 	regEndpoint string
 	regCertPEM  []byte
 	regErr      error
 }
 
-func (m *mockTunnelProvider) CACertPEM() []byte { return m.caCertPEM }
+func (m *mockTunnelProvider) CACertPEM() []byte   { return m.caCertPEM }
+func (m *mockTunnelProvider) Fingerprint() string { return m.fingerprint }
 func (m *mockTunnelProvider) ListClusters() map[string]Cluster {
 	if m.clusters == nil {
 		return map[string]Cluster{}
@@ -32,25 +35,33 @@ func (m *mockTunnelProvider) ResolveAddress(_ context.Context, _ string) (string
 
 // mockManifestRenderer implements ManifestRenderer for testing.
 type mockManifestRenderer struct {
-	result string
-	err    error
+	result     string
+	err        error
+	kubeconfig KubeconfigParams
 }
 
 func (m *mockManifestRenderer) RenderAgentManifest(_ ManifestParams) (string, error) {
 	return m.result, m.err
 }
 
+func (m *mockManifestRenderer) RenderKubeconfig(params KubeconfigParams) (string, error) {
+	m.kubeconfig = params
+	return m.result, m.err
+}
+
 func testFleetConfig() AgentManifestConfig {
 	return AgentManifestConfig{
-		ServerURL: "https://server.example.com",
-		TunnelURL: "https://tunnel.example.com:8300",
-		HMACKey:   []byte("test-hmac-key-must-be-32-bytes!!"),
+		ServerURL:     "https://server.example.com",
+		TunnelURL:     "https://tunnel.example.com:8300",
+		HMACKey:       []byte("test-hmac-key-must-be-32-bytes!!"),
+		OIDCIssuerURL: "https://sso.example.com/realms/otterscale",
+		OIDCClientID:  "otterscale-server",
 	}
 }
 
 func newTestFleetUseCase(t *testing.T, tp TunnelProvider, renderer ManifestRenderer) *FleetUseCase {
 	t.Helper()
-	uc, err := NewFleetUseCase(tp, "v1.0.0", testFleetConfig(), renderer)
+	uc, err := NewFleetUseCase(tp, "v1.0.0", testFleetConfig(), renderer, &ClusterAccessPolicy{}, NewRealClock())
 	if err != nil {
 		t.Fatalf("NewFleetUseCase: %v", err)
 	}
@@ -81,11 +92,16 @@ func TestNewFleetUseCase_ValidationErrors(t *testing.T) {
 			cfg:     AgentManifestConfig{ServerURL: "x", TunnelURL: "x"},
 			wantErr: "HMAC key is required",
 		},
+		{
+			name:    "negative clock skew",
+			cfg:     AgentManifestConfig{ServerURL: "x", TunnelURL: "x", HMACKey: []byte("k"), ClockSkew: -time.Second},
+			wantErr: "clock skew must not be negative",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := NewFleetUseCase(tp, "v1.0.0", tt.cfg, renderer)
+			_, err := NewFleetUseCase(tp, "v1.0.0", tt.cfg, renderer, &ClusterAccessPolicy{}, NewRealClock())
 			if err == nil {
 				t.Fatal("expected error, got nil")
 			}
@@ -104,12 +120,41 @@ func TestFleetUseCase_ListClusters(t *testing.T) {
 	tp := &mockTunnelProvider{clusters: clusters}
 	uc := newTestFleetUseCase(t, tp, &mockManifestRenderer{})
 
-	got := uc.ListClusters(context.Background())
+	got := uc.ListClusters(context.Background(), ListClustersOptions{})
 	if len(got) != 2 {
 		t.Fatalf("expected 2 clusters, got %d", len(got))
 	}
 }
 
+func TestFleetUseCase_ListClusters_OutOfDate(t *testing.T) {
+	// The server under test runs v1.0.0.
+	tp := &mockTunnelProvider{clusters: map[string]Cluster{
+		"current": {AgentVersion: "v1.0.0"},
+		"newer":   {AgentVersion: "v1.1.0"},
+		"old":     {AgentVersion: "v0.9.3"},
+		"rc":      {AgentVersion: "v1.0.0-rc.1"},
+		"dev":     {AgentVersion: "dev"},
+		"unknown": {AgentVersion: ""},
+	}}
+	uc := newTestFleetUseCase(t, tp, &mockManifestRenderer{})
+
+	all := uc.ListClusters(context.Background(), ListClustersOptions{})
+	if len(all) != 6 {
+		t.Fatalf("expected 6 clusters, got %d", len(all))
+	}
+	want := map[string]bool{"old": true, "rc": true}
+	for name, c := range all {
+		if c.OutOfDate != want[name] {
+			t.Errorf("%s (agent %q): OutOfDate = %v, want %v", name, c.AgentVersion, c.OutOfDate, want[name])
+		}
+	}
+
+	outdated := uc.ListClusters(context.Background(), ListClustersOptions{OutOfDateOnly: true})
+	if len(outdated) != 2 || !outdated["old"].OutOfDate || !outdated["rc"].OutOfDate {
+		t.Errorf("out-of-date clusters = %v, want old and rc", outdated)
+	}
+}
+
 func TestFleetUseCase_RegisterCluster_Validation(t *testing.T) {
 	tp := &mockTunnelProvider{regEndpoint: "127.0.0.1:8080", regCertPEM: []byte("cert")}
 	uc := newTestFleetUseCase(t, tp, &mockManifestRenderer{})
@@ -151,6 +196,7 @@ func TestFleetUseCase_RegisterCluster_Success(t *testing.T) {
 		regEndpoint: "127.0.0.1:8080",
 		regCertPEM:  []byte("signed-cert"),
 		caCertPEM:   []byte("ca-cert"),
+		fingerprint: "SHA256:abc",
 	}
 	uc := newTestFleetUseCase(t, tp, &mockManifestRenderer{})
 
@@ -170,6 +216,9 @@ func TestFleetUseCase_RegisterCluster_Success(t *testing.T) {
 	if reg.ServerVersion != "v1.0.0" {
 		t.Errorf("server version = %q, want %q", reg.ServerVersion, "v1.0.0")
 	}
+	if reg.TunnelFingerprint != "SHA256:abc" {
+		t.Errorf("tunnel fingerprint = %q, want %q", reg.TunnelFingerprint, "SHA256:abc")
+	}
 }
 
 func TestFleetUseCase_ManifestToken_IssueAndVerify(t *testing.T) {
@@ -293,8 +342,117 @@ func TestFleetUseCase_GenerateAgentManifest_Success(t *testing.T) {
 	}
 }
 
+func TestFleetUseCase_GenerateAgentManifest_MisconfiguredServer(t *testing.T) {
+	uc := newTestFleetUseCase(t, &mockTunnelProvider{}, &mockManifestRenderer{result: "manifest-yaml"})
+	uc.manifestCfg.TunnelURL = ""
+
+	_, err := uc.GenerateAgentManifest(context.Background(), "my-cluster", "admin@example.com")
+	var invalid *ErrInvalidInput
+	if isErrInvalidInput(err, &invalid) {
+		t.Fatalf("expected a server-side error, got invalid input %v", err)
+	}
+	if code, ok := DomainErrorCode(err); !ok || code != ErrorCodeFailedPrecondition {
+		t.Errorf("error = %v, want ErrorCodeFailedPrecondition", err)
+	}
+}
+
 // isErrInvalidInput checks if err is *ErrInvalidInput using the
 // standard errors.As mechanism.
 func isErrInvalidInput(err error, target **ErrInvalidInput) bool {
 	return errors.As(err, target)
 }
+
+func TestFleetUseCase_KubeconfigToken(t *testing.T) {
+	uc := newTestFleetUseCase(t, &mockTunnelProvider{}, &mockManifestRenderer{result: "kubeconfig"})
+	ctx := WithUserInfo(context.Background(), UserInfo{Subject: "user@example.com"})
+
+	url, err := uc.IssueKubeconfigURL(ctx, "test-cluster", "user@example.com")
+	if err != nil {
+		t.Fatalf("IssueKubeconfigURL: %v", err)
+	}
+	parts := strings.SplitN(url, "/fleet/kubeconfig/", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		t.Fatalf("unexpected URL format: %q", url)
+	}
+	token := parts[1]
+
+	// A kubeconfig link must not be usable to fetch the agent manifest.
+	if _, _, err := uc.VerifyManifestToken(ctx, token); err == nil {
+		t.Error("kubeconfig token should be rejected by the manifest endpoint")
+	}
+
+	cluster, kubeconfig, err := uc.DownloadKubeconfig(context.Background(), token)
+	if err != nil {
+		t.Fatalf("DownloadKubeconfig: %v", err)
+	}
+	if cluster != "test-cluster" || kubeconfig != "kubeconfig" {
+		t.Errorf("DownloadKubeconfig = %q, %q, want test-cluster and the rendered kubeconfig", cluster, kubeconfig)
+	}
+
+	// The link carries no credentials, so it stays valid until it
+	// expires rather than being revoked on first use.
+	if _, _, err := uc.DownloadKubeconfig(context.Background(), token); err != nil {
+		t.Errorf("second download: %v", err)
+	}
+}
+
+func TestFleetUseCase_GenerateKubeconfig_MisconfiguredServer(t *testing.T) {
+	uc := newTestFleetUseCase(t, &mockTunnelProvider{}, &mockManifestRenderer{result: "kubeconfig"})
+	uc.manifestCfg.OIDCClientID = ""
+	ctx := WithUserInfo(context.Background(), UserInfo{Subject: "user@example.com"})
+
+	_, err := uc.GenerateKubeconfig(ctx, "test-cluster")
+	if code, ok := DomainErrorCode(err); !ok || code != ErrorCodeFailedPrecondition {
+		t.Errorf("error = %v, want ErrorCodeFailedPrecondition", err)
+	}
+}
+
+func TestFleetUseCase_GenerateKubeconfig(t *testing.T) {
+	renderer := &mockManifestRenderer{result: "kubeconfig"}
+	uc := newTestFleetUseCase(t, &mockTunnelProvider{}, renderer)
+	ctx := WithUserInfo(context.Background(), UserInfo{Subject: "user@example.com"})
+
+	var invalidInput *ErrInvalidInput
+	if _, err := uc.GenerateKubeconfig(ctx, "Bad_Name"); !isErrInvalidInput(err, &invalidInput) {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
+	if got, err := uc.GenerateKubeconfig(ctx, "test-cluster"); err != nil || got != "kubeconfig" {
+		t.Fatalf("GenerateKubeconfig = %q, %v", got, err)
+	}
+
+	want := KubeconfigParams{
+		Cluster:       "test-cluster",
+		APIServerURL:  "https://server.example.com/clusters/test-cluster",
+		OIDCIssuerURL: "https://sso.example.com/realms/otterscale",
+		OIDCClientID:  "otterscale-server",
+	}
+	if renderer.kubeconfig != want {
+		t.Errorf("kubeconfig params = %+v, want %+v", renderer.kubeconfig, want)
+	}
+}
+
+func TestFleetUseCase_Kubeconfig_ChecksClusterAccess(t *testing.T) {
+	policy, err := NewClusterAccessPolicy([]string{"ops=edge-1"})
+	if err != nil {
+		t.Fatalf("NewClusterAccessPolicy: %v", err)
+	}
+	uc, err := NewFleetUseCase(&mockTunnelProvider{}, "v1.0.0", testFleetConfig(), &mockManifestRenderer{result: "kubeconfig"}, policy, NewRealClock())
+	if err != nil {
+		t.Fatalf("NewFleetUseCase: %v", err)
+	}
+	ctx := WithUserInfo(context.Background(), UserInfo{Subject: "alice", Groups: []string{"ops"}})
+
+	if _, err := uc.GenerateKubeconfig(ctx, "edge-1"); err != nil {
+		t.Fatalf("GenerateKubeconfig for an allowed cluster: %v", err)
+	}
+	var domainErr *DomainError
+	if _, err := uc.GenerateKubeconfig(ctx, "edge-2"); !errors.As(err, &domainErr) || domainErr.Code != ErrorCodePermissionDenied {
+		t.Errorf("GenerateKubeconfig for another cluster = %v, want permission denied", err)
+	}
+	if _, err := uc.IssueKubeconfigURL(ctx, "edge-2", "alice"); !errors.As(err, &domainErr) || domainErr.Code != ErrorCodePermissionDenied {
+		t.Errorf("IssueKubeconfigURL for another cluster = %v, want permission denied", err)
+	}
+	if _, err := uc.GenerateKubeconfig(context.Background(), "edge-1"); !errors.As(err, &domainErr) || domainErr.Code != ErrorCodeUnauthenticated {
+		t.Errorf("GenerateKubeconfig without a user = %v, want unauthenticated", err)
+	}
+}
@@ -0,0 +1,195 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Default limits of ApplyHelmChart.
+const (
+	DefaultHelmChartMaxBytes    = 4 << 20  // 4 MiB, the packaged chart and the values each
+	DefaultHelmRenderedMaxBytes = 16 << 20 // 16 MiB
+	DefaultHelmRenderTimeout    = 30 * time.Second
+)
+
+// maxHelmReleaseNameLength is the longest release name Helm accepts,
+// leaving room for the suffixes charts append to it in object names.
+const maxHelmReleaseNameLength = 53
+
+// HelmRelease names the release a chart is rendered for, exposed to
+// its templates as .Release.Name and .Release.Namespace.
+type HelmRelease struct {
+	Name string
+	// Namespace is also the namespace of the rendered namespaced
+	// objects that do not name one. Empty means "default".
+	Namespace string
+}
+
+// HelmRenderer renders Helm charts. Implementations live in the
+// infrastructure layer.
+type HelmRenderer interface {
+	// RenderHelm renders chart, a gzipped tar archive as produced by
+	// `helm package`, with values, a YAML document overriding the
+	// chart's defaults, and returns the resulting objects as a
+	// multi-document YAML manifest: the chart's CRDs first, then its
+	// templates in Helm's install order. Hooks are not rendered into
+	// the manifest, and templates cannot query the cluster. It returns
+	// ctx.Err() once ctx is done.
+	RenderHelm(ctx context.Context, chart, values []byte, release HelmRelease) ([]byte, error)
+}
+
+// HelmChartConfig holds the server-wide limits of charts applied by
+// ApplyHelmChart.
+type HelmChartConfig struct {
+	// MaxChartBytes caps the size of the uploaded chart archive and of
+	// the values, each. Zero means DefaultHelmChartMaxBytes.
+	MaxChartBytes int64
+	// MaxRenderedBytes caps the size of the rendered manifest. Zero
+	// means DefaultHelmRenderedMaxBytes.
+	MaxRenderedBytes int64
+	// RenderTimeout bounds how long a request waits for a chart to
+	// render. The renderer may keep working on a chart after the
+	// request gave up; HelmRenderer implementations bound how many
+	// charts they render at once. Zero means DefaultHelmRenderTimeout.
+	RenderTimeout time.Duration
+}
+
+// maxChartBytes returns the size limit of charts and values.
+func (c HelmChartConfig) maxChartBytes() int64 {
+	if c.MaxChartBytes > 0 {
+		return c.MaxChartBytes
+	}
+	return DefaultHelmChartMaxBytes
+}
+
+// maxRenderedBytes returns the size limit of rendered manifests.
+func (c HelmChartConfig) maxRenderedBytes() int64 {
+	if c.MaxRenderedBytes > 0 {
+		return c.MaxRenderedBytes
+	}
+	return DefaultHelmRenderedMaxBytes
+}
+
+// renderTimeout returns the time limit of rendering a chart.
+func (c HelmChartConfig) renderTimeout() time.Duration {
+	if c.RenderTimeout > 0 {
+		return c.RenderTimeout
+	}
+	return DefaultHelmRenderTimeout
+}
+
+// HelmChartUseCase renders Helm charts and applies the result.
+type HelmChartUseCase struct {
+	resource *ResourceUseCase
+	renderer HelmRenderer
+	config   HelmChartConfig
+}
+
+// NewHelmChartUseCase returns a HelmChartUseCase that renders charts
+// with renderer, within the limits of config, and applies them with
+// resource.
+func NewHelmChartUseCase(resource *ResourceUseCase, renderer HelmRenderer, config HelmChartConfig) *HelmChartUseCase {
+	return &HelmChartUseCase{
+		resource: resource,
+		renderer: renderer,
+		config:   config,
+	}
+}
+
+// RenderHelm validates the release and the sizes of chart and values,
+// then renders the chart into a multi-document manifest (see
+// HelmRenderer). A manifest larger than the configured limit is
+// rejected, and so is a chart that does not render within the
+// configured timeout.
+func (uc *HelmChartUseCase) RenderHelm(ctx context.Context, chart, values []byte, release HelmRelease) ([]byte, error) {
+	if err := validateHelmRelease(release); err != nil {
+		return nil, err
+	}
+	limit := uc.config.maxChartBytes()
+	switch {
+	case len(chart) == 0:
+		return nil, &ErrInvalidInput{Field: "chart", Message: "is required"}
+	case int64(len(chart)) > limit:
+		return nil, &ErrInvalidInput{Field: "chart", Message: fmt.Sprintf("must not exceed %d bytes", limit)}
+	case int64(len(values)) > limit:
+		return nil, &ErrInvalidInput{Field: "values", Message: fmt.Sprintf("must not exceed %d bytes", limit)}
+	}
+
+	timeout := uc.config.renderTimeout()
+	renderCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	manifest, err := uc.renderer.RenderHelm(renderCtx, chart, values, release)
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		return nil, &DomainError{
+			Code:    ErrorCodeDeadlineExceeded,
+			Message: fmt.Sprintf("chart did not render within %s", timeout),
+			Cause:   err,
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if limit := uc.config.maxRenderedBytes(); int64(len(manifest)) > limit {
+		return nil, &DomainError{
+			Code:    ErrorCodeResourceExhausted,
+			Message: fmt.Sprintf("rendered chart is %d bytes, more than the limit of %d", len(manifest), limit),
+		}
+	}
+	return manifest, nil
+}
+
+// ApplyHelmChart renders chart with values as RenderHelm does and
+// applies the manifest to cluster as ApplyManifest does, reporting
+// each step to emit. Rendered namespaced objects that do not name a
+// namespace are placed in the release's namespace. Nothing is applied
+// if the chart fails to render.
+func (uc *HelmChartUseCase) ApplyHelmChart(
+	ctx context.Context,
+	cluster string,
+	chart, values []byte,
+	release HelmRelease,
+	opts ApplyManifestOptions,
+	emit func(ManifestEvent) error,
+) error {
+	if err := ValidateClusterName(cluster); err != nil {
+		return err
+	}
+	if release.Namespace == "" {
+		release.Namespace = "default"
+	}
+
+	manifest, err := uc.RenderHelm(ctx, chart, values, release)
+	if err != nil {
+		return err
+	}
+	opts.Namespace = release.Namespace
+	return uc.resource.ApplyManifest(ctx, cluster, manifest, opts, emit)
+}
+
+// validateHelmRelease checks the release name and namespace against
+// the rules Helm applies.
+func validateHelmRelease(release HelmRelease) error {
+	if release.Name == "" {
+		return &ErrInvalidInput{Field: "release_name", Message: "is required"}
+	}
+	if len(release.Name) > maxHelmReleaseNameLength {
+		return &ErrInvalidInput{
+			Field:   "release_name",
+			Message: fmt.Sprintf("must not exceed %d characters", maxHelmReleaseNameLength),
+		}
+	}
+	if errs := utilvalidation.IsDNS1123Subdomain(release.Name); len(errs) > 0 {
+		return &ErrInvalidInput{Field: "release_name", Message: strings.Join(errs, "; ")}
+	}
+	if release.Namespace != "" {
+		if errs := utilvalidation.IsDNS1123Label(release.Namespace); len(errs) > 0 {
+			return &ErrInvalidInput{Field: "namespace", Message: strings.Join(errs, "; ")}
+		}
+	}
+	return nil
+}
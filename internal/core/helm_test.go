@@ -0,0 +1,124 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// stubHelmRenderer renders every chart to manifest and records the
+// release it was rendered for.
+type stubHelmRenderer struct {
+	manifest string
+	release  HelmRelease
+}
+
+func (r *stubHelmRenderer) RenderHelm(_ context.Context, _, _ []byte, release HelmRelease) ([]byte, error) {
+	r.release = release
+	return []byte(r.manifest), nil
+}
+
+func TestHelmChartUseCase_ApplyHelmChart(t *testing.T) {
+	renderer := &stubHelmRenderer{manifest: `---
+# Source: shop/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: shop-config
+---
+# Source: shop/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: shop
+  namespace: other
+`}
+	repo := &manifestRepo{}
+	resource := NewResourceUseCase(&manifestDiscovery{repo: repo}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+	uc := NewHelmChartUseCase(resource, renderer, HelmChartConfig{})
+
+	var events []ManifestEventType
+	err := uc.ApplyHelmChart(context.Background(), "edge-1", []byte("chart"), nil,
+		HelmRelease{Name: "shop", Namespace: "apps"}, ApplyManifestOptions{},
+		func(e ManifestEvent) error {
+			events = append(events, e.Type)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("ApplyHelmChart: %v", err)
+	}
+	if renderer.release != (HelmRelease{Name: "shop", Namespace: "apps"}) {
+		t.Errorf("rendered for release %+v", renderer.release)
+	}
+	// The ConfigMap lands in the release namespace, while the
+	// Deployment keeps the one it names.
+	if want := []string{"configmaps/apps/shop-config", "deployments/other/shop"}; !reflect.DeepEqual(repo.applied, want) {
+		t.Errorf("applied = %v, want %v", repo.applied, want)
+	}
+	if want := []ManifestEventType{ManifestObjectApplied, ManifestObjectApplied}; !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %v, want %v", events, want)
+	}
+}
+
+// blockingHelmRenderer renders until ctx is done.
+type blockingHelmRenderer struct{}
+
+func (blockingHelmRenderer) RenderHelm(ctx context.Context, _, _ []byte, _ HelmRelease) ([]byte, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestHelmChartUseCase_RenderHelmTimeout(t *testing.T) {
+	uc := NewHelmChartUseCase(nil, blockingHelmRenderer{}, HelmChartConfig{RenderTimeout: 10 * time.Millisecond})
+	_, err := uc.RenderHelm(context.Background(), []byte("chart"), nil, HelmRelease{Name: "shop"})
+	if code, ok := DomainErrorCode(err); !ok || code != ErrorCodeDeadlineExceeded {
+		t.Fatalf("err = %v, want ErrorCodeDeadlineExceeded", err)
+	}
+
+	// A caller that gives up first sees its own context's error.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	uc = NewHelmChartUseCase(nil, blockingHelmRenderer{}, HelmChartConfig{})
+	if _, err := uc.RenderHelm(ctx, []byte("chart"), nil, HelmRelease{Name: "shop"}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestHelmChartUseCase_RenderHelmLimits(t *testing.T) {
+	tests := []struct {
+		name      string
+		chart     []byte
+		values    []byte
+		release   HelmRelease
+		rendered  string
+		wantField string
+		wantCode  ErrorCode
+	}{
+		{name: "missing release name", chart: []byte("chart"), wantField: "release_name"},
+		{name: "invalid release name", chart: []byte("chart"), release: HelmRelease{Name: "Shop"}, wantField: "release_name"},
+		{name: "invalid namespace", chart: []byte("chart"), release: HelmRelease{Name: "shop", Namespace: "apps_1"}, wantField: "namespace"},
+		{name: "missing chart", release: HelmRelease{Name: "shop"}, wantField: "chart"},
+		{name: "chart too large", chart: make([]byte, 65), release: HelmRelease{Name: "shop"}, wantField: "chart"},
+		{name: "values too large", chart: []byte("chart"), values: make([]byte, 65), release: HelmRelease{Name: "shop"}, wantField: "values"},
+		{name: "rendered too large", chart: []byte("chart"), release: HelmRelease{Name: "shop"}, rendered: strings.Repeat("#", 129), wantCode: ErrorCodeResourceExhausted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewHelmChartUseCase(nil, &stubHelmRenderer{manifest: tt.rendered}, HelmChartConfig{MaxChartBytes: 64, MaxRenderedBytes: 128})
+			_, err := uc.RenderHelm(context.Background(), tt.chart, tt.values, tt.release)
+			if tt.wantField != "" {
+				var invalid *ErrInvalidInput
+				if !isErrInvalidInput(err, &invalid) || invalid.Field != tt.wantField {
+					t.Fatalf("err = %v, want invalid input on %s", err, tt.wantField)
+				}
+				return
+			}
+			if code, ok := DomainErrorCode(err); !ok || code != tt.wantCode {
+				t.Fatalf("err = %v, want code %v", err, tt.wantCode)
+			}
+		})
+	}
+}
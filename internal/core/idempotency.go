@@ -0,0 +1,58 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// maxIdempotencyKeyLength bounds the client-supplied idempotency key
+// of a create.
+const maxIdempotencyKeyLength = 256
+
+// IdempotencyCache remembers, for a short while, the objects created
+// with client-supplied idempotency keys, so that a create retried
+// after its response was lost returns the object the first attempt
+// created instead of AlreadyExists. Implementations live in the
+// infrastructure layer (e.g. providers/cache).
+type IdempotencyCache interface {
+	// Do returns the object recorded under key if it was created by a
+	// request with the same fingerprint, and an *ErrInvalidInput if
+	// key was used for a different request. Otherwise it calls create
+	// and records its result if it succeeds. Calls with a key whose
+	// create is in progress wait for it and share its outcome.
+	Do(ctx context.Context, key, fingerprint string, create func() (*unstructured.Unstructured, error)) (*unstructured.Unstructured, error)
+}
+
+// validateIdempotencyKey returns an *ErrInvalidInput if key is too
+// long.
+func validateIdempotencyKey(key string) error {
+	if len(key) > maxIdempotencyKeyLength {
+		return &ErrInvalidInput{Field: "idempotency_key", Message: fmt.Sprintf("must not exceed %d characters", maxIdempotencyKeyLength)}
+	}
+	return nil
+}
+
+// idempotencyCacheKey scopes a client's idempotency key to the calling
+// subject, so that callers cannot read each other's results by
+// guessing keys.
+func idempotencyCacheKey(ctx context.Context, key string) string {
+	return callerSubject(ctx) + "\x00" + key
+}
+
+// createFingerprint identifies a create request, so that an
+// idempotency key reused for a different one is detected.
+func createFingerprint(id ResourceIdentifier, gvr schema.GroupVersionResource, manifest []byte, opts CreateOptions) string {
+	h := sha256.New()
+	for _, part := range []string{id.Cluster, gvr.String(), id.Namespace, strconv.FormatBool(opts.CheckNamespace), strconv.FormatBool(opts.SkipManagedBy)} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	h.Write(manifest)
+	return hex.EncodeToString(h.Sum(nil))
+}
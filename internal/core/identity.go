@@ -0,0 +1,120 @@
+package core
+
+import (
+	"context"
+	"strings"
+
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ClusterUserInfo is the identity a cluster's API server attributes to
+// the caller's requests, as reported by a SelfSubjectReview. It
+// reflects the impersonation the server applies, so it differs from
+// the caller's token when a cluster maps or augments identities.
+type ClusterUserInfo struct {
+	Username string
+	UID      string
+	Groups   []string
+	Extra    map[string][]string
+}
+
+// IdentityRepo asks a cluster's API server who the caller is.
+type IdentityRepo interface {
+	// SelfSubjectReview returns the identity cluster's API server
+	// sees for requests made on behalf of the caller in ctx.
+	SelfSubjectReview(ctx context.Context, cluster string) (*ClusterUserInfo, error)
+	// AgentRulesReview returns the rules the agent's own identity is
+	// allowed in namespace on cluster, without impersonating the
+	// caller in ctx.
+	AgentRulesReview(ctx context.Context, cluster, namespace string) (*AgentPermissions, error)
+}
+
+// ResourceRule is a set of API resources and the verbs allowed on them,
+// as resolved by a SelfSubjectRulesReview.
+type ResourceRule struct {
+	Verbs         []string
+	APIGroups     []string
+	Resources     []string
+	ResourceNames []string
+}
+
+// NonResourceRule is a set of non-resource URLs and the verbs allowed
+// on them.
+type NonResourceRule struct {
+	Verbs           []string
+	NonResourceURLs []string
+}
+
+// AgentPermissions is what the agent may do in a namespace of a
+// cluster. Incomplete is set, with EvaluationError explaining why, when
+// the cluster's authorizer cannot enumerate every rule.
+type AgentPermissions struct {
+	Namespace        string
+	ResourceRules    []ResourceRule
+	NonResourceRules []NonResourceRule
+	Incomplete       bool
+	EvaluationError  string
+}
+
+// defaultRulesNamespace is the namespace agent permissions are
+// evaluated in when none is given.
+const defaultRulesNamespace = "default"
+
+// Identity is the caller's effective identity: the user derived from
+// their token, which the server impersonates on every cluster, and,
+// when a cluster was named, what that cluster's API server sees.
+type Identity struct {
+	User    UserInfo
+	Cluster *ClusterUserInfo
+}
+
+// IdentityUseCase reports callers' effective identities, for debugging
+// RBAC.
+type IdentityUseCase struct {
+	repo IdentityRepo
+}
+
+// NewIdentityUseCase returns an IdentityUseCase backed by repo.
+func NewIdentityUseCase(repo IdentityRepo) *IdentityUseCase {
+	return &IdentityUseCase{repo: repo}
+}
+
+// WhoAmI returns the identity of the caller in ctx. If cluster is not
+// empty, it also asks the cluster's API server, which requires the
+// caller to be allowed to access the cluster.
+func (uc *IdentityUseCase) WhoAmI(ctx context.Context, cluster string) (*Identity, error) {
+	user, ok := UserInfoFromContext(ctx)
+	if !ok {
+		return nil, &DomainError{Code: ErrorCodeUnauthenticated, Message: "user info not found in context"}
+	}
+	identity := &Identity{User: user}
+	if cluster == "" {
+		return identity, nil
+	}
+
+	if err := ValidateClusterName(cluster); err != nil {
+		return nil, err
+	}
+	review, err := uc.repo.SelfSubjectReview(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	identity.Cluster = review
+	return identity, nil
+}
+
+// AgentPermissions returns what the agent's ServiceAccount may do in
+// namespace, "default" if empty, on cluster. The caller must be allowed
+// to access the cluster, but the rules are the agent's, not theirs.
+func (uc *IdentityUseCase) AgentPermissions(ctx context.Context, cluster, namespace string) (*AgentPermissions, error) {
+	if err := ValidateClusterName(cluster); err != nil {
+		return nil, err
+	}
+	if namespace == "" {
+		namespace = defaultRulesNamespace
+	}
+	if errs := utilvalidation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return nil, &ErrInvalidInput{Field: "namespace", Message: strings.Join(errs, "; ")}
+	}
+	return uc.repo.AgentRulesReview(ctx, cluster, namespace)
+}
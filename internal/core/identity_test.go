@@ -0,0 +1,88 @@
+package core
+
+import (
+	"context"
+	"testing"
+)
+
+// reviewRepo reports the caller as the cluster's API server would,
+// recording the clusters and namespaces asked.
+type reviewRepo struct {
+	clusters   []string
+	namespaces []string
+}
+
+func (r *reviewRepo) SelfSubjectReview(ctx context.Context, cluster string) (*ClusterUserInfo, error) {
+	r.clusters = append(r.clusters, cluster)
+	user, _ := UserInfoFromContext(ctx)
+	return &ClusterUserInfo{Username: user.Subject, Groups: append(user.Groups, "system:authenticated")}, nil
+}
+
+func (r *reviewRepo) AgentRulesReview(_ context.Context, cluster, namespace string) (*AgentPermissions, error) {
+	r.clusters = append(r.clusters, cluster)
+	r.namespaces = append(r.namespaces, namespace)
+	return &AgentPermissions{Namespace: namespace}, nil
+}
+
+func TestIdentityUseCase_WhoAmI(t *testing.T) {
+	repo := &reviewRepo{}
+	uc := NewIdentityUseCase(repo)
+	ctx := WithUserInfo(context.Background(), UserInfo{Subject: "alice", Groups: []string{"platform"}})
+
+	identity, err := uc.WhoAmI(ctx, "")
+	if err != nil {
+		t.Fatalf("WhoAmI: %v", err)
+	}
+	if identity.User.Subject != "alice" || identity.Cluster != nil || len(repo.clusters) != 0 {
+		t.Errorf("identity = %+v, want alice without asking a cluster", identity)
+	}
+
+	identity, err = uc.WhoAmI(ctx, "edge-1")
+	if err != nil {
+		t.Fatalf("WhoAmI on edge-1: %v", err)
+	}
+	if identity.Cluster == nil || identity.Cluster.Username != "alice" || len(repo.clusters) != 1 {
+		t.Errorf("identity = %+v, want alice as seen by edge-1", identity)
+	}
+
+	if _, err := uc.WhoAmI(context.Background(), ""); err == nil {
+		t.Error("WhoAmI without a user succeeded")
+	} else if code, _ := DomainErrorCode(err); code != ErrorCodeUnauthenticated {
+		t.Errorf("WhoAmI without a user: err = %v, want unauthenticated", err)
+	}
+	var invalid *ErrInvalidInput
+	if _, err := uc.WhoAmI(ctx, "Edge_1"); !isErrInvalidInput(err, &invalid) || invalid.Field != "cluster" {
+		t.Errorf("WhoAmI on an invalid cluster: err = %v, want invalid input on cluster", err)
+	}
+}
+
+func TestIdentityUseCase_AgentPermissions(t *testing.T) {
+	repo := &reviewRepo{}
+	uc := NewIdentityUseCase(repo)
+	ctx := WithUserInfo(context.Background(), UserInfo{Subject: "alice"})
+
+	perms, err := uc.AgentPermissions(ctx, "edge-1", "")
+	if err != nil {
+		t.Fatalf("AgentPermissions: %v", err)
+	}
+	if perms.Namespace != "default" {
+		t.Errorf("namespace = %q, want default when none is given", perms.Namespace)
+	}
+	if _, err := uc.AgentPermissions(ctx, "edge-1", "otterscale-system"); err != nil {
+		t.Fatalf("AgentPermissions in otterscale-system: %v", err)
+	}
+	if len(repo.namespaces) != 2 || repo.namespaces[1] != "otterscale-system" {
+		t.Errorf("reviewed namespaces = %q, want default and otterscale-system", repo.namespaces)
+	}
+
+	var invalid *ErrInvalidInput
+	if _, err := uc.AgentPermissions(ctx, "", ""); !isErrInvalidInput(err, &invalid) || invalid.Field != "cluster" {
+		t.Errorf("AgentPermissions without a cluster: err = %v, want invalid input on cluster", err)
+	}
+	if _, err := uc.AgentPermissions(ctx, "edge-1", "Kube_System"); !isErrInvalidInput(err, &invalid) || invalid.Field != "namespace" {
+		t.Errorf("AgentPermissions in an invalid namespace: err = %v, want invalid input on namespace", err)
+	}
+	if len(repo.clusters) != 2 {
+		t.Errorf("repo asked %d times, want invalid input rejected before asking", len(repo.clusters))
+	}
+}
@@ -0,0 +1,106 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// inventoryConcurrency bounds the number of list requests
+// ResourceInventory has in flight against one cluster.
+const inventoryConcurrency = 8
+
+// ResourceInventory counts the objects of every listable resource in
+// cluster, across all namespaces, for capacity planning. Each resource
+// is counted once under the first version discovery reports for it,
+// so a resource served in several versions is not counted twice.
+// Subresources and virtual resources that cannot be listed (such as
+// TokenReviews) are skipped, as are resources the caller may not list
+// and group-versions whose discovery failed.
+//
+// Counting does not fetch whole collections where it can avoid it: a
+// list limited to one item carries the number of remaining items. Only
+// when the API server omits that number are the items paged through.
+func (uc *ResourceUseCase) ResourceInventory(ctx context.Context, cluster string) (map[schema.GroupVersionResource]int64, error) {
+	lists, _, err := uc.discovery.ServerResources(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	var gvrs []schema.GroupVersionResource
+	seen := map[schema.GroupResource]bool{}
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, r := range list.APIResources {
+			if strings.Contains(r.Name, "/") || !slices.Contains(r.Verbs, "list") {
+				continue
+			}
+			gr := gv.WithResource(r.Name).GroupResource()
+			if seen[gr] {
+				continue
+			}
+			seen[gr] = true
+			gvrs = append(gvrs, gv.WithResource(r.Name))
+		}
+	}
+
+	var mu sync.Mutex
+	counts := make(map[schema.GroupVersionResource]int64, len(gvrs))
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(inventoryConcurrency)
+	for _, gvr := range gvrs {
+		g.Go(func() error {
+			n, err := uc.countResource(ctx, cluster, gvr)
+			if code, ok := DomainErrorCode(err); ok && (code == ErrorCodePermissionDenied || code == ErrorCodeNotFound) {
+				// Not visible to the caller, or removed (e.g. a CRD
+				// deleted) since discovery.
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("count %s: %w", gvr, err)
+			}
+			mu.Lock()
+			counts[gvr] = n
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// countResource returns the number of objects of gvr in all
+// namespaces of cluster.
+func (uc *ResourceUseCase) countResource(ctx context.Context, cluster string, gvr schema.GroupVersionResource) (int64, error) {
+	list, err := uc.resource.List(ctx, cluster, gvr, "", ListOptions{Limit: 1})
+	if err != nil {
+		return 0, err
+	}
+	n := int64(len(list.Items))
+	if list.GetContinue() == "" {
+		return n, nil
+	}
+	if remaining := list.GetRemainingItemCount(); remaining != nil {
+		return n + *remaining, nil
+	}
+
+	for cont := list.GetContinue(); cont != ""; cont = list.GetContinue() {
+		list, err = uc.resource.List(ctx, cluster, gvr, "", ListOptions{Limit: relistPageSize, Continue: cont})
+		if err != nil {
+			return 0, err
+		}
+		n += int64(len(list.Items))
+	}
+	return n, nil
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"context"
+	"maps"
+	"strconv"
+	"sync"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// inventoryDiscovery advertises a fixed set of API resources.
+type inventoryDiscovery struct {
+	DiscoveryClient
+	lists []*metav1.APIResourceList
+}
+
+func (d *inventoryDiscovery) ServerResources(context.Context, string) ([]*metav1.APIResourceList, []DiscoveryFailure, error) {
+	return d.lists, nil, nil
+}
+
+// inventoryRepo serves lists of a fixed number of objects per resource,
+// paginated like the API server. Resources in withoutRemaining omit
+// remainingItemCount, and resources in forbidden fail as the API server
+// does for callers without list permission.
+type inventoryRepo struct {
+	ResourceRepo
+	counts           map[string]int
+	withoutRemaining map[string]bool
+	forbidden        map[string]bool
+
+	mu    sync.Mutex
+	lists map[string]int
+}
+
+func (r *inventoryRepo) List(_ context.Context, _ string, gvr schema.GroupVersionResource, namespace string, opts ListOptions) (*unstructured.UnstructuredList, error) {
+	r.mu.Lock()
+	r.lists[gvr.Resource]++
+	r.mu.Unlock()
+
+	if r.forbidden[gvr.Resource] {
+		return nil, &DomainError{Code: ErrorCodePermissionDenied, Message: "forbidden"}
+	}
+	total := r.counts[gvr.Resource]
+	start := 0
+	if opts.Continue != "" {
+		start, _ = strconv.Atoi(opts.Continue)
+	}
+	end := total
+	if opts.Limit > 0 {
+		end = min(start+int(opts.Limit), total)
+	}
+
+	list := &unstructured.UnstructuredList{}
+	for range end - start {
+		list.Items = append(list.Items, unstructured.Unstructured{Object: map[string]any{}})
+	}
+	if end < total {
+		list.SetContinue(strconv.Itoa(end))
+		if !r.withoutRemaining[gvr.Resource] {
+			remaining := int64(total - end)
+			list.SetRemainingItemCount(&remaining)
+		}
+	}
+	return list, nil
+}
+
+func TestResourceUseCase_ResourceInventory(t *testing.T) {
+	discovery := &inventoryDiscovery{lists: []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "pods", Namespaced: true, Verbs: []string{"get", "list", "watch"}},
+			{Name: "pods/log", Namespaced: true, Verbs: []string{"get"}},
+			{Name: "secrets", Namespaced: true, Verbs: []string{"get", "list"}},
+			{Name: "namespaces", Verbs: []string{"get", "list"}},
+			{Name: "configmaps", Namespaced: true, Verbs: []string{"list"}},
+		}},
+		{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{
+			{Name: "deployments", Namespaced: true, Verbs: []string{"list"}},
+		}},
+		{GroupVersion: "authentication.k8s.io/v1", APIResources: []metav1.APIResource{
+			{Name: "tokenreviews", Verbs: []string{"create"}},
+		}},
+		{GroupVersion: "autoscaling/v2", APIResources: []metav1.APIResource{
+			{Name: "horizontalpodautoscalers", Namespaced: true, Verbs: []string{"list"}},
+		}},
+		{GroupVersion: "autoscaling/v1", APIResources: []metav1.APIResource{
+			{Name: "horizontalpodautoscalers", Namespaced: true, Verbs: []string{"list"}},
+		}},
+	}}
+	repo := &inventoryRepo{
+		counts: map[string]int{
+			"pods":                     1234,
+			"secrets":                  1201,
+			"namespaces":               0,
+			"deployments":              1,
+			"horizontalpodautoscalers": 3,
+		},
+		withoutRemaining: map[string]bool{"secrets": true},
+		forbidden:        map[string]bool{"configmaps": true},
+		lists:            map[string]int{},
+	}
+	uc := NewResourceUseCase(discovery, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+
+	got, err := uc.ResourceInventory(context.Background(), "edge-1")
+	if err != nil {
+		t.Fatalf("ResourceInventory: %v", err)
+	}
+
+	want := map[schema.GroupVersionResource]int64{
+		{Version: "v1", Resource: "pods"}:                                           1234,
+		{Version: "v1", Resource: "secrets"}:                                        1201,
+		{Version: "v1", Resource: "namespaces"}:                                     0,
+		{Group: "apps", Version: "v1", Resource: "deployments"}:                     1,
+		{Group: "autoscaling", Version: "v2", Resource: "horizontalpodautoscalers"}: 3,
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("inventory = %v, want %v", got, want)
+	}
+
+	// Pods are counted from remainingItemCount with a single request;
+	// secrets, without it, are paged through.
+	if n := repo.lists["pods"]; n != 1 {
+		t.Errorf("pods listed %d times, want 1", n)
+	}
+	if n := repo.lists["secrets"]; n != 4 {
+		t.Errorf("secrets listed %d times, want 4 (1 probe + 3 pages)", n)
+	}
+	if n := repo.lists["horizontalpodautoscalers"]; n != 1 {
+		t.Errorf("horizontalpodautoscalers listed %d times, want once across versions", n)
+	}
+}
@@ -0,0 +1,28 @@
+package core
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// Jitter is the fraction of its interval by which a periodic background
+// loop randomly varies each wait, so that the loops of several server
+// replicas started together do not keep running in lockstep.
+type Jitter float64
+
+// DefaultJitter varies intervals by up to 10% either way.
+const DefaultJitter Jitter = 0.1
+
+// MaxJitter bounds Jitter so that a jittered interval is always at
+// least half the configured one.
+const MaxJitter Jitter = 0.5
+
+// Apply returns d varied uniformly at random within ±j·d. A
+// non-positive j or d returns d unchanged.
+func (j Jitter) Apply(d time.Duration) time.Duration {
+	if j <= 0 || d <= 0 {
+		return d
+	}
+	delta := float64(d) * float64(min(j, MaxJitter))
+	return d + time.Duration((rand.Float64()*2-1)*delta)
+}
@@ -0,0 +1,32 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJitter_Apply(t *testing.T) {
+	const interval = 30 * time.Second
+	lo, hi := 27*time.Second, 33*time.Second
+
+	seen := map[time.Duration]bool{}
+	for range 100 {
+		d := DefaultJitter.Apply(interval)
+		if d < lo || d > hi {
+			t.Fatalf("jittered interval = %s, want within [%s, %s]", d, lo, hi)
+		}
+		seen[d] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("100 jittered intervals were all %s, want them to vary", interval)
+	}
+
+	if d := Jitter(0).Apply(interval); d != interval {
+		t.Errorf("zero jitter = %s, want %s", d, interval)
+	}
+	for range 100 {
+		if d := Jitter(5).Apply(interval); d < interval/2 || d > interval*3/2 {
+			t.Fatalf("excessive jitter = %s, want it capped at ±50%%", d)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package core
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMaintenanceUseCase_SetMaintenance(t *testing.T) {
+	uc := NewMaintenanceUseCase(MaintenanceConfig{AdminGroups: []string{"ops"}})
+	if err := uc.CheckMutation(); err != nil {
+		t.Fatalf("CheckMutation before maintenance: %v", err)
+	}
+
+	user := WithUserInfo(context.Background(), UserInfo{Subject: "bob", Groups: []string{"dev"}})
+	if _, err := uc.SetMaintenance(user, true, ""); err == nil {
+		t.Error("SetMaintenance by a non-admin succeeded")
+	} else if code, _ := DomainErrorCode(err); code != ErrorCodePermissionDenied {
+		t.Errorf("SetMaintenance by a non-admin: err = %v, want permission denied", err)
+	}
+
+	admin := WithUserInfo(context.Background(), UserInfo{Subject: "alice", Groups: []string{"ops"}})
+	got, err := uc.SetMaintenance(admin, true, "")
+	if err != nil {
+		t.Fatalf("SetMaintenance: %v", err)
+	}
+	if want := (Maintenance{Enabled: true, Message: DefaultMaintenanceMessage}); got != want || uc.Status() != want {
+		t.Errorf("maintenance = %+v, status %+v, want %+v", got, uc.Status(), want)
+	}
+	if code, _ := DomainErrorCode(uc.CheckMutation()); code != ErrorCodeUnavailable {
+		t.Errorf("CheckMutation in maintenance = %v, want unavailable", uc.CheckMutation())
+	}
+
+	if got, err := uc.SetMaintenance(admin, false, "ignored"); err != nil || got != (Maintenance{}) {
+		t.Errorf("disable: maintenance = %+v, %v, want disabled", got, err)
+	}
+}
+
+func TestMaintenanceUseCase_ConfigOnlyWithoutAdminGroups(t *testing.T) {
+	uc := NewMaintenanceUseCase(MaintenanceConfig{Enabled: true, Message: "incident"})
+	if code, _ := DomainErrorCode(uc.CheckMutation()); code != ErrorCodeUnavailable {
+		t.Errorf("CheckMutation = %v, want unavailable from configuration", uc.CheckMutation())
+	}
+	admin := WithUserInfo(context.Background(), UserInfo{Subject: "alice", Groups: []string{"ops"}})
+	if _, err := uc.SetMaintenance(admin, false, ""); err == nil {
+		t.Error("SetMaintenance without admin groups succeeded")
+	}
+}
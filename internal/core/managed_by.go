@@ -0,0 +1,127 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// ManagedByValue is the value of the managed-by label set on objects
+// created or applied through otterscale.
+const ManagedByValue = "otterscale"
+
+// CreatedByAnnotation names, on an object tagged as managed by
+// otterscale, the subject that created it.
+const CreatedByAnnotation = "otterscale.io/created-by"
+
+// callerSubject returns the subject of the calling user in ctx, or
+// "service:<operation>" for the server's own requests, or "" if
+// neither is known.
+func callerSubject(ctx context.Context) string {
+	if id, ok := ServiceIdentityFromContext(ctx); ok {
+		return id.UserInfo().Subject
+	}
+	if user, ok := UserInfoFromContext(ctx); ok {
+		return user.Subject
+	}
+	return ""
+}
+
+// tagManagedBy returns manifest with the managed-by label, unless the
+// manifest already sets it, and, if subject is known, the created-by
+// annotation set on its object. The manifest is returned unchanged if
+// the label is disabled or the manifest is empty, which is rejected
+// where it is applied.
+func (c ApplyConfig) tagManagedBy(manifest []byte, subject string) ([]byte, error) {
+	if c.ManagedByLabel == "" {
+		return manifest, nil
+	}
+	var obj map[string]any
+	err := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(manifest), 4096).Decode(&obj)
+	if errors.Is(err, io.EOF) || (err == nil && obj == nil) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, &ErrInvalidInput{Field: "manifest", Message: fmt.Sprintf("invalid YAML: %v", err)}
+	}
+
+	u := &unstructured.Unstructured{Object: obj}
+	labels := u.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	if _, ok := labels[c.ManagedByLabel]; !ok {
+		labels[c.ManagedByLabel] = ManagedByValue
+	}
+	u.SetLabels(labels)
+	if subject != "" {
+		annotations := u.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[CreatedByAnnotation] = subject
+		u.SetAnnotations(annotations)
+	}
+	return json.Marshal(u.Object)
+}
+
+// tagAppliedManagedBy tags obj, the result of an apply, like
+// tagManagedBy, but only with the label and annotation it lacks: an
+// object that is already labelled keeps its manager, and one that
+// already records its creator keeps it, so that re-applying does not
+// rewrite its history. The tags are added with a merge patch rather
+// than as part of the apply, so that they are not owned by the
+// applying field manager and cannot conflict with another manager's
+// value. For a dry run obj is only tagged in memory.
+func (uc *ResourceUseCase) tagAppliedManagedBy(ctx context.Context, cluster string, gvr schema.GroupVersionResource, obj *unstructured.Unstructured, dryRun bool) (*unstructured.Unstructured, error) {
+	key := uc.apply.ManagedByLabel
+	if key == "" {
+		return obj, nil
+	}
+	labels := map[string]string{}
+	if _, ok := obj.GetLabels()[key]; !ok {
+		labels[key] = ManagedByValue
+	}
+	annotations := map[string]string{}
+	if subject := callerSubject(ctx); subject != "" && obj.GetAnnotations()[CreatedByAnnotation] == "" {
+		annotations[CreatedByAnnotation] = subject
+	}
+	if len(labels) == 0 && len(annotations) == 0 {
+		return obj, nil
+	}
+
+	if dryRun {
+		obj = obj.DeepCopy()
+		obj.SetLabels(mergeStringMaps(obj.GetLabels(), labels))
+		obj.SetAnnotations(mergeStringMaps(obj.GetAnnotations(), annotations))
+		return obj, nil
+	}
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"labels":      labels,
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal managed-by patch: %w", err)
+	}
+	return uc.resource.MergePatch(ctx, cluster, gvr, obj.GetNamespace(), obj.GetName(), patch)
+}
+
+// mergeStringMaps returns dst with the entries of src added, allocating
+// dst if it is nil.
+func mergeStringMaps(dst, src map[string]string) map[string]string {
+	if dst == nil {
+		dst = make(map[string]string, len(src))
+	}
+	maps.Copy(dst, src)
+	return dst
+}
@@ -0,0 +1,209 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// mockManagedByRepo records the manifests reaching Create and Apply
+// and the merge patches reaching MergePatch. Apply returns the applied
+// object with the labels and annotations of existing, if set, added
+// as if they were already on the live object.
+type mockManagedByRepo struct {
+	ResourceRepo
+	existing  *unstructured.Unstructured
+	manifests [][]byte
+	patches   []map[string]any
+}
+
+func (m *mockManagedByRepo) Create(_ context.Context, _ string, _ schema.GroupVersionResource, _ string, manifest []byte) (*unstructured.Unstructured, error) {
+	m.manifests = append(m.manifests, manifest)
+	return &unstructured.Unstructured{}, nil
+}
+
+func (m *mockManagedByRepo) Apply(_ context.Context, _ string, _ schema.GroupVersionResource, _, _ string, manifest []byte, _ ApplyOptions) (*unstructured.Unstructured, error) {
+	m.manifests = append(m.manifests, manifest)
+	obj := map[string]any{}
+	if err := utilyaml.Unmarshal(manifest, &obj); err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{Object: obj}
+	if m.existing != nil {
+		u.SetLabels(mergeStringMaps(u.GetLabels(), m.existing.GetLabels()))
+		u.SetAnnotations(mergeStringMaps(u.GetAnnotations(), m.existing.GetAnnotations()))
+	}
+	return u, nil
+}
+
+func (m *mockManagedByRepo) MergePatch(_ context.Context, _ string, _ schema.GroupVersionResource, _, _ string, patch []byte) (*unstructured.Unstructured, error) {
+	p := map[string]any{}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, err
+	}
+	m.patches = append(m.patches, p)
+	return &unstructured.Unstructured{}, nil
+}
+
+// patchedMetadata returns the metadata.field map of a recorded merge
+// patch.
+func patchedMetadata(patch map[string]any, field string) map[string]any {
+	metadata, _ := patch["metadata"].(map[string]any)
+	values, _ := metadata[field].(map[string]any)
+	return values
+}
+
+// decodeManifest decodes the object of a manifest sent to the repo.
+func decodeManifest(t *testing.T, manifest []byte) *unstructured.Unstructured {
+	t.Helper()
+	obj := map[string]any{}
+	if err := utilyaml.Unmarshal(manifest, &obj); err != nil {
+		t.Fatalf("decode manifest %q: %v", manifest, err)
+	}
+	return &unstructured.Unstructured{Object: obj}
+}
+
+const managedByManifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web
+  labels:
+    app: web
+data:
+  key: value
+`
+
+func TestResourceUseCase_TagsManagedBy(t *testing.T) {
+	tests := []struct {
+		name string
+		skip bool
+	}{
+		{"tagged by default", false},
+		{"omitted when opted out", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockManagedByRepo{}
+			uc := NewResourceUseCase(&mockWatchDiscovery{}, repo, nil, nil, nil, ApplyConfig{ManagedByLabel: "app.kubernetes.io/managed-by"}, NamespaceConfig{})
+			ctx := WithUserInfo(context.Background(), UserInfo{Subject: "alice"})
+			id := ResourceIdentifier{Cluster: "c1", Version: "v1", Resource: "configmaps", Namespace: "default", Name: "web"}
+
+			if _, err := uc.CreateResource(ctx, id, []byte(managedByManifest), CreateOptions{SkipManagedBy: tt.skip}); err != nil {
+				t.Fatalf("CreateResource: %v", err)
+			}
+			if _, err := uc.ApplyResource(ctx, id, []byte(managedByManifest), ApplyOptions{SkipManagedBy: tt.skip}); err != nil {
+				t.Fatalf("ApplyResource: %v", err)
+			}
+
+			created := decodeManifest(t, repo.manifests[0])
+			label, hasLabel := created.GetLabels()["app.kubernetes.io/managed-by"]
+			creator, hasCreator := created.GetAnnotations()[CreatedByAnnotation]
+			if tt.skip {
+				if hasLabel || hasCreator || len(repo.patches) > 0 {
+					t.Errorf("labels = %v, annotations = %v, patches = %v, want no managed-by tagging", created.GetLabels(), created.GetAnnotations(), repo.patches)
+				}
+				return
+			}
+			if label != ManagedByValue || creator != "alice" {
+				t.Errorf("created managed-by = %q, created-by = %q, want %q by alice", label, creator, ManagedByValue)
+			}
+			if created.GetLabels()["app"] != "web" {
+				t.Errorf("created labels = %v, want existing labels kept", created.GetLabels())
+			}
+
+			// The apply itself is left alone; the tags it lacks are
+			// patched in afterwards.
+			applied := decodeManifest(t, repo.manifests[1])
+			if _, ok := applied.GetLabels()["app.kubernetes.io/managed-by"]; ok {
+				t.Errorf("applied labels = %v, want the managed-by label outside the apply", applied.GetLabels())
+			}
+			if len(repo.patches) != 1 {
+				t.Fatalf("patches = %v, want one managed-by patch", repo.patches)
+			}
+			if got := patchedMetadata(repo.patches[0], "labels")["app.kubernetes.io/managed-by"]; got != ManagedByValue {
+				t.Errorf("patched managed-by = %v, want %q", got, ManagedByValue)
+			}
+			if got := patchedMetadata(repo.patches[0], "annotations")[CreatedByAnnotation]; got != "alice" {
+				t.Errorf("patched created-by = %v, want alice", got)
+			}
+		})
+	}
+}
+
+func TestResourceUseCase_ApplyResource_KeepsCreator(t *testing.T) {
+	existing := &unstructured.Unstructured{}
+	existing.SetAnnotations(map[string]string{CreatedByAnnotation: "bob"})
+	repo := &mockManagedByRepo{existing: existing}
+	uc := NewResourceUseCase(&mockWatchDiscovery{}, repo, nil, nil, nil, ApplyConfig{ManagedByLabel: "example.com/managed-by"}, NamespaceConfig{})
+	ctx := WithUserInfo(context.Background(), UserInfo{Subject: "alice"})
+	id := ResourceIdentifier{Cluster: "c1", Version: "v1", Resource: "configmaps", Namespace: "default", Name: "web"}
+
+	if _, err := uc.ApplyResource(ctx, id, []byte(managedByManifest), ApplyOptions{}); err != nil {
+		t.Fatalf("ApplyResource: %v", err)
+	}
+	if len(repo.patches) != 1 {
+		t.Fatalf("patches = %v, want one managed-by patch", repo.patches)
+	}
+	if got := patchedMetadata(repo.patches[0], "labels")["example.com/managed-by"]; got != ManagedByValue {
+		t.Errorf("managed-by = %v, want %q under the configured key", got, ManagedByValue)
+	}
+	if got, ok := patchedMetadata(repo.patches[0], "annotations")[CreatedByAnnotation]; ok {
+		t.Errorf("created-by patched to %v, want the original creator bob kept", got)
+	}
+}
+
+func TestResourceUseCase_ManagedByKeepsExistingLabel(t *testing.T) {
+	const helmManifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web
+  labels:
+    app.kubernetes.io/managed-by: Helm
+`
+	existing := &unstructured.Unstructured{}
+	existing.SetAnnotations(map[string]string{CreatedByAnnotation: "bob"})
+	repo := &mockManagedByRepo{existing: existing}
+	uc := NewResourceUseCase(&mockWatchDiscovery{}, repo, nil, nil, nil, ApplyConfig{ManagedByLabel: "app.kubernetes.io/managed-by"}, NamespaceConfig{})
+	ctx := WithUserInfo(context.Background(), UserInfo{Subject: "alice"})
+	id := ResourceIdentifier{Cluster: "c1", Version: "v1", Resource: "configmaps", Namespace: "default", Name: "web"}
+
+	if _, err := uc.CreateResource(ctx, id, []byte(helmManifest), CreateOptions{}); err != nil {
+		t.Fatalf("CreateResource: %v", err)
+	}
+	if got := decodeManifest(t, repo.manifests[0]).GetLabels()["app.kubernetes.io/managed-by"]; got != "Helm" {
+		t.Errorf("created managed-by = %q, want Helm kept", got)
+	}
+
+	obj, err := uc.ApplyResource(ctx, id, []byte(helmManifest), ApplyOptions{})
+	if err != nil {
+		t.Fatalf("ApplyResource: %v", err)
+	}
+	if len(repo.patches) != 0 {
+		t.Errorf("patches = %v, want none for a labelled object with a known creator", repo.patches)
+	}
+	if got := obj.GetLabels()["app.kubernetes.io/managed-by"]; got != "Helm" {
+		t.Errorf("applied managed-by = %q, want Helm kept", got)
+	}
+}
+
+func TestResourceUseCase_ApplyResource_DryRunTagsInMemory(t *testing.T) {
+	repo := &mockManagedByRepo{}
+	uc := NewResourceUseCase(&mockWatchDiscovery{}, repo, nil, nil, nil, ApplyConfig{ManagedByLabel: "app.kubernetes.io/managed-by"}, NamespaceConfig{})
+	ctx := WithUserInfo(context.Background(), UserInfo{Subject: "alice"})
+	id := ResourceIdentifier{Cluster: "c1", Version: "v1", Resource: "configmaps", Namespace: "default", Name: "web"}
+
+	obj, err := uc.ApplyResource(ctx, id, []byte(managedByManifest), ApplyOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("ApplyResource: %v", err)
+	}
+	if len(repo.patches) != 0 {
+		t.Errorf("patches = %v, want none for a dry run", repo.patches)
+	}
+	if obj.GetLabels()["app.kubernetes.io/managed-by"] != ManagedByValue || obj.GetAnnotations()[CreatedByAnnotation] != "alice" {
+		t.Errorf("labels = %v, annotations = %v, want the tags previewed", obj.GetLabels(), obj.GetAnnotations())
+	}
+}
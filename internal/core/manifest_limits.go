@@ -0,0 +1,118 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// Default limits on the manifests accepted by Create, Apply and
+// ApplyManifest.
+const (
+	DefaultManifestMaxBytes     = 16 << 20 // 16 MiB, enough for any rendered Helm chart
+	DefaultManifestMaxDepth     = 100
+	DefaultManifestMaxDocuments = 1000
+)
+
+// ManifestLimits bounds the manifests the server decodes, so that a
+// huge or deeply nested one is rejected before it costs much memory.
+// Zero fields mean the defaults.
+type ManifestLimits struct {
+	// MaxBytes caps the size of a manifest, checked before it is
+	// decoded.
+	MaxBytes int64
+	// MaxDepth caps the nesting of maps and lists in each object.
+	MaxDepth int
+	// MaxDocuments caps the number of objects in a multi-document
+	// manifest. Decoding stops at the first object past the limit.
+	MaxDocuments int
+}
+
+// maxBytes returns the size limit of manifests.
+func (l ManifestLimits) maxBytes() int64 {
+	if l.MaxBytes > 0 {
+		return l.MaxBytes
+	}
+	return DefaultManifestMaxBytes
+}
+
+// maxDepth returns the nesting limit of objects.
+func (l ManifestLimits) maxDepth() int {
+	if l.MaxDepth > 0 {
+		return l.MaxDepth
+	}
+	return DefaultManifestMaxDepth
+}
+
+// maxDocuments returns the limit on the number of objects.
+func (l ManifestLimits) maxDocuments() int {
+	if l.MaxDocuments > 0 {
+		return l.MaxDocuments
+	}
+	return DefaultManifestMaxDocuments
+}
+
+// checkSize rejects a manifest larger than the size limit.
+func (l ManifestLimits) checkSize(manifest []byte) error {
+	if limit := l.maxBytes(); int64(len(manifest)) > limit {
+		return &ErrInvalidInput{Field: "manifest", Message: fmt.Sprintf("must not exceed %d bytes", limit)}
+	}
+	return nil
+}
+
+// checkDepth rejects an object nested deeper than the depth limit.
+// doc is the object's position in its manifest, for the message.
+func (l ManifestLimits) checkDepth(doc int, obj map[string]any) error {
+	if limit := l.maxDepth(); exceedsDepth(obj, limit) {
+		return &ErrInvalidInput{Field: "manifest", Message: fmt.Sprintf("document %d: must not nest deeper than %d levels", doc, limit)}
+	}
+	return nil
+}
+
+// checkObject checks the single-object manifest of Create and Apply
+// against the size and depth limits. The manifest is decoded here only
+// after its size is known to be within bounds, and only its first
+// document, the one that is applied, is checked.
+func (l ManifestLimits) checkObject(manifest []byte) error {
+	if err := l.checkSize(manifest); err != nil {
+		return err
+	}
+	var obj map[string]any
+	err := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(manifest), 4096).Decode(&obj)
+	if errors.Is(err, io.EOF) {
+		return nil // an empty manifest is rejected where it is applied
+	}
+	if err != nil {
+		return &ErrInvalidInput{Field: "manifest", Message: fmt.Sprintf("invalid YAML: %v", err)}
+	}
+	return l.checkDepth(1, obj)
+}
+
+// exceedsDepth reports whether v nests maps and lists more than limit
+// levels deep, stopping as soon as it does.
+func exceedsDepth(v any, limit int) bool {
+	switch v := v.(type) {
+	case map[string]any:
+		if limit == 0 {
+			return true
+		}
+		for _, child := range v {
+			if exceedsDepth(child, limit-1) {
+				return true
+			}
+		}
+	case []any:
+		if limit == 0 {
+			return true
+		}
+		for _, child := range v {
+			if exceedsDepth(child, limit-1) {
+				return true
+			}
+		}
+	}
+	return false
+}
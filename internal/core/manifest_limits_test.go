@@ -0,0 +1,93 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// nestedConfigMap returns a ConfigMap manifest whose data nests depth
+// levels of lists below the top-level object.
+func nestedConfigMap(depth int) string {
+	return "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: deep\ndata: " +
+		strings.Repeat("[", depth-1) + strings.Repeat("]", depth-1) + "\n"
+}
+
+func TestResourceUseCase_ApplyManifest_EnforcesLimits(t *testing.T) {
+	limits := ManifestLimits{MaxBytes: 1024, MaxDepth: 8, MaxDocuments: 2}
+	configMaps := func(n int) string {
+		docs := make([]string, n)
+		for i := range docs {
+			docs[i] = "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n"
+		}
+		return strings.Join(docs, "---\n")
+	}
+
+	tests := []struct {
+		name     string
+		manifest string
+		wantErr  string
+	}{
+		{"within limits", configMaps(1) + "---\n" + nestedConfigMap(8), ""},
+		{"too large", "# " + strings.Repeat("x", 1024) + "\n" + configMaps(1), "must not exceed 1024 bytes"},
+		{"too deeply nested", nestedConfigMap(9), "document 1: must not nest deeper than 8 levels"},
+		{"too many documents", configMaps(3), "must not contain more than 2 objects"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &manifestRepo{}
+			uc := NewResourceUseCase(&manifestDiscovery{repo: repo}, repo, nil, nil, nil, ApplyConfig{Manifest: limits}, NamespaceConfig{})
+			err := uc.ApplyManifest(context.Background(), "edge-1", []byte(tt.manifest), ApplyManifestOptions{FieldManager: "test"}, func(ManifestEvent) error {
+				if tt.wantErr != "" {
+					return errors.New("no events expected")
+				}
+				return nil
+			})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ApplyManifest: %v", err)
+				}
+				return
+			}
+			var invalid *ErrInvalidInput
+			if !isErrInvalidInput(err, &invalid) || invalid.Field != "manifest" || !strings.Contains(invalid.Message, tt.wantErr) {
+				t.Fatalf("ApplyManifest error = %v, want invalid manifest: %s", err, tt.wantErr)
+			}
+			if len(repo.applied) != 0 {
+				t.Errorf("applied = %v, want nothing applied", repo.applied)
+			}
+		})
+	}
+}
+
+func TestResourceUseCase_ApplyResource_EnforcesLimits(t *testing.T) {
+	repo := &manifestRepo{}
+	uc := NewResourceUseCase(&manifestDiscovery{repo: repo}, repo, nil, nil, nil,
+		ApplyConfig{Manifest: ManifestLimits{MaxBytes: 1024, MaxDepth: 8}}, NamespaceConfig{})
+	id := ResourceIdentifier{Cluster: "edge-1", Version: "v1", Resource: "configmaps", Namespace: "default", Name: "deep"}
+
+	if _, err := uc.ApplyResource(context.Background(), id, []byte(nestedConfigMap(8)), ApplyOptions{FieldManager: "test"}); err != nil {
+		t.Fatalf("ApplyResource within limits: %v", err)
+	}
+	for name, manifest := range map[string]string{
+		"too large":         nestedConfigMap(2) + "# " + strings.Repeat("x", 1024) + "\n",
+		"too deeply nested": nestedConfigMap(9),
+	} {
+		_, err := uc.ApplyResource(context.Background(), id, []byte(manifest), ApplyOptions{FieldManager: "test"})
+		var invalid *ErrInvalidInput
+		if !isErrInvalidInput(err, &invalid) || invalid.Field != "manifest" {
+			t.Errorf("%s: ApplyResource error = %v, want invalid manifest", name, err)
+		}
+	}
+}
+
+func TestExceedsDepth(t *testing.T) {
+	obj := map[string]any{"spec": map[string]any{"args": []any{"-v"}}}
+	if exceedsDepth(obj, 3) {
+		t.Error("an object 3 levels deep exceeds a limit of 3")
+	}
+	if !exceedsDepth(obj, 2) {
+		t.Error("an object 3 levels deep does not exceed a limit of 2")
+	}
+}
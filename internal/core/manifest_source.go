@@ -0,0 +1,182 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// DefaultManifestSourceMaxBytes caps the size of a manifest fetched
+// from a URL when the configuration does not say otherwise.
+const DefaultManifestSourceMaxBytes = 4 << 20 // 4 MiB
+
+// configMapsGVR is the GroupVersionResource of ConfigMaps, from which
+// ApplyFromSource reads manifests.
+var configMapsGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+// ManifestSource locates a manifest applied by reference instead of
+// inline. Exactly one of URL and ConfigMap must be set.
+type ManifestSource struct {
+	// URL is an https URL on an allow-listed host, fetched by the
+	// server.
+	URL string
+	// ConfigMap is a key of a ConfigMap on the target cluster, read
+	// through the tunnel with the caller's permissions.
+	ConfigMap *ConfigMapKeyRef
+}
+
+// ConfigMapKeyRef selects a key of a ConfigMap.
+type ConfigMapKeyRef struct {
+	Namespace string
+	Name      string
+	Key       string
+}
+
+// ManifestFetcher downloads manifests from URLs. Implementations live
+// in the infrastructure layer.
+type ManifestFetcher interface {
+	// Fetch returns the body served at u. allow is called with the
+	// target of every redirect and the download is aborted with its
+	// error if it returns one. A body larger than maxBytes is
+	// rejected.
+	Fetch(ctx context.Context, u *url.URL, maxBytes int64, allow func(*url.URL) error) ([]byte, error)
+}
+
+// ManifestSourceConfig holds the server-wide restrictions on manifest
+// sources.
+type ManifestSourceConfig struct {
+	// AllowedHosts lists the hosts from which manifests may be
+	// fetched, guarding against requests to internal services (SSRF).
+	// An entry "*.example.com" matches every subdomain of example.com.
+	// When empty, URL sources are refused.
+	AllowedHosts []string
+	// MaxBytes caps the size of a fetched manifest. Zero means
+	// DefaultManifestSourceMaxBytes.
+	MaxBytes int64
+}
+
+// checkURL reports whether manifests may be fetched from u.
+func (c ManifestSourceConfig) checkURL(u *url.URL) error {
+	if u.Scheme != "https" || u.Host == "" {
+		return &ErrInvalidInput{Field: "url", Message: "must be an absolute https URL"}
+	}
+	if u.User != nil {
+		return &ErrInvalidInput{Field: "url", Message: "must not contain credentials"}
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range c.AllowedHosts {
+		allowed = strings.ToLower(allowed)
+		if host == allowed {
+			return nil
+		}
+		if suffix, ok := strings.CutPrefix(allowed, "*"); ok && strings.HasPrefix(suffix, ".") && strings.HasSuffix(host, suffix) {
+			return nil
+		}
+	}
+	return &DomainError{
+		Code:    ErrorCodePermissionDenied,
+		Message: fmt.Sprintf("host %q is not allowed as a manifest source", host),
+	}
+}
+
+// maxBytes returns the size limit of fetched manifests.
+func (c ManifestSourceConfig) maxBytes() int64 {
+	if c.MaxBytes > 0 {
+		return c.MaxBytes
+	}
+	return DefaultManifestSourceMaxBytes
+}
+
+// ManifestSourceUseCase applies manifests given by reference.
+type ManifestSourceUseCase struct {
+	resource *ResourceUseCase
+	fetcher  ManifestFetcher
+	config   ManifestSourceConfig
+}
+
+// NewManifestSourceUseCase returns a ManifestSourceUseCase that applies
+// manifests with resource, fetching those given by URL with fetcher
+// under the restrictions of config.
+func NewManifestSourceUseCase(resource *ResourceUseCase, fetcher ManifestFetcher, config ManifestSourceConfig) *ManifestSourceUseCase {
+	return &ManifestSourceUseCase{
+		resource: resource,
+		fetcher:  fetcher,
+		config:   config,
+	}
+}
+
+// ApplyFromSource loads the manifest located by source and applies it
+// to cluster as ApplyManifest does, reporting each step to emit.
+func (uc *ManifestSourceUseCase) ApplyFromSource(
+	ctx context.Context,
+	cluster string,
+	source ManifestSource,
+	opts ApplyManifestOptions,
+	emit func(ManifestEvent) error,
+) error {
+	if err := ValidateClusterName(cluster); err != nil {
+		return err
+	}
+
+	var (
+		manifest []byte
+		err      error
+	)
+	switch {
+	case source.URL != "" && source.ConfigMap != nil:
+		return &ErrInvalidInput{Field: "source", Message: "set either a URL or a ConfigMap, not both"}
+	case source.URL != "":
+		manifest, err = uc.fetchURL(ctx, source.URL)
+	case source.ConfigMap != nil:
+		manifest, err = uc.readConfigMap(ctx, cluster, *source.ConfigMap)
+	default:
+		return &ErrInvalidInput{Field: "source", Message: "a URL or a ConfigMap is required"}
+	}
+	if err != nil {
+		return err
+	}
+
+	return uc.resource.ApplyManifest(ctx, cluster, manifest, opts, emit)
+}
+
+// fetchURL downloads the manifest at raw, which, like every redirect
+// followed, must be on an allow-listed host.
+func (uc *ManifestSourceUseCase) fetchURL(ctx context.Context, raw string) ([]byte, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, &ErrInvalidInput{Field: "url", Message: err.Error()}
+	}
+	if err := uc.config.checkURL(u); err != nil {
+		return nil, err
+	}
+	return uc.fetcher.Fetch(ctx, u, uc.config.maxBytes(), uc.config.checkURL)
+}
+
+// readConfigMap returns the manifest stored under ref's key.
+func (uc *ManifestSourceUseCase) readConfigMap(ctx context.Context, cluster string, ref ConfigMapKeyRef) ([]byte, error) {
+	switch {
+	case ref.Namespace == "":
+		return nil, &ErrInvalidInput{Field: "config_map.namespace", Message: "is required"}
+	case ref.Name == "":
+		return nil, &ErrInvalidInput{Field: "config_map.name", Message: "is required"}
+	case ref.Key == "":
+		return nil, &ErrInvalidInput{Field: "config_map.key", Message: "is required"}
+	}
+
+	cm, err := uc.resource.resource.Get(ctx, cluster, configMapsGVR, ref.Namespace, ref.Name)
+	if err != nil {
+		return nil, err
+	}
+	data, found, err := unstructured.NestedString(cm.Object, "data", ref.Key)
+	if err != nil || !found {
+		return nil, &DomainError{
+			Code:    ErrorCodeNotFound,
+			Message: fmt.Sprintf("configmap %s/%s has no key %q", ref.Namespace, ref.Name, ref.Key),
+		}
+	}
+	return []byte(data), nil
+}
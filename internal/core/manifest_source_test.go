@@ -0,0 +1,130 @@
+package core
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// configMapManifestRepo serves the three-document manifest from the
+// "manifest.yaml" key of the installer/widgets ConfigMap.
+type configMapManifestRepo struct {
+	*manifestRepo
+}
+
+func (r *configMapManifestRepo) Get(_ context.Context, _ string, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+	if gvr != configMapsGVR || namespace != "installer" || name != "widgets" {
+		return nil, &DomainError{Code: ErrorCodeNotFound, Message: "not found"}
+	}
+	return &unstructured.Unstructured{Object: map[string]any{
+		"data": map[string]any{"manifest.yaml": threeDocManifest},
+	}}, nil
+}
+
+// recordingFetcher serves a fixed manifest after following a redirect
+// to redirectTo, if set, and records the URLs it was asked for.
+type recordingFetcher struct {
+	redirectTo string
+	fetched    []string
+}
+
+func (f *recordingFetcher) Fetch(_ context.Context, u *url.URL, _ int64, allow func(*url.URL) error) ([]byte, error) {
+	f.fetched = append(f.fetched, u.String())
+	if f.redirectTo != "" {
+		target, _ := url.Parse(f.redirectTo)
+		if err := allow(target); err != nil {
+			return nil, err
+		}
+	}
+	return []byte(threeDocManifest), nil
+}
+
+func TestManifestSourceUseCase_ApplyFromConfigMap(t *testing.T) {
+	repo := &configMapManifestRepo{&manifestRepo{}}
+	resource := NewResourceUseCase(&manifestDiscovery{repo: repo.manifestRepo}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+	uc := NewManifestSourceUseCase(resource, &recordingFetcher{}, ManifestSourceConfig{})
+
+	source := ManifestSource{ConfigMap: &ConfigMapKeyRef{Namespace: "installer", Name: "widgets", Key: "manifest.yaml"}}
+	var applied int
+	err := uc.ApplyFromSource(context.Background(), "edge-1", source, ApplyManifestOptions{FieldManager: "installer"}, func(e ManifestEvent) error {
+		if e.Type == ManifestObjectApplied {
+			applied++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ApplyFromSource: %v", err)
+	}
+	if applied != 3 {
+		t.Errorf("applied %d objects, want 3", applied)
+	}
+	want := []string{"customresourcedefinitions//widgets.example.com", "deployments/apps/web", "widgets/default/gadget"}
+	if !reflect.DeepEqual(repo.applied, want) {
+		t.Errorf("applied = %v, want %v", repo.applied, want)
+	}
+
+	source.ConfigMap.Key = "missing.yaml"
+	err = uc.ApplyFromSource(context.Background(), "edge-1", source, ApplyManifestOptions{}, func(ManifestEvent) error { return nil })
+	if code, ok := DomainErrorCode(err); !ok || code != ErrorCodeNotFound {
+		t.Errorf("ApplyFromSource of a missing key: err = %v, want NotFound", err)
+	}
+}
+
+func TestManifestSourceUseCase_RejectsDisallowedURLHosts(t *testing.T) {
+	config := ManifestSourceConfig{AllowedHosts: []string{"manifests.example.com", "*.cdn.example.com"}}
+
+	tests := []struct {
+		name       string
+		url        string
+		redirectTo string
+		wantCode   ErrorCode
+		wantFetch  bool
+	}{
+		{"cloud metadata address", "https://169.254.169.254/latest/meta-data", "", ErrorCodePermissionDenied, false},
+		{"lookalike host", "https://manifests.example.com.evil.test/app.yaml", "", ErrorCodePermissionDenied, false},
+		{"redirect to a disallowed host", "https://manifests.example.com/app.yaml", "https://internal.svc/app.yaml", ErrorCodePermissionDenied, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &manifestRepo{}
+			resource := NewResourceUseCase(&manifestDiscovery{repo: repo}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+			fetcher := &recordingFetcher{redirectTo: tt.redirectTo}
+			uc := NewManifestSourceUseCase(resource, fetcher, config)
+
+			err := uc.ApplyFromSource(context.Background(), "edge-1", ManifestSource{URL: tt.url}, ApplyManifestOptions{}, func(ManifestEvent) error { return nil })
+			if code, ok := DomainErrorCode(err); !ok || code != tt.wantCode {
+				t.Fatalf("ApplyFromSource error = %v, want code %v", err, tt.wantCode)
+			}
+			if fetched := len(fetcher.fetched) > 0; fetched != tt.wantFetch {
+				t.Errorf("fetched = %v, want %v", fetcher.fetched, tt.wantFetch)
+			}
+			if len(repo.applied) != 0 {
+				t.Errorf("applied = %v, want nothing applied", repo.applied)
+			}
+		})
+	}
+
+	for _, allowed := range []string{"https://manifests.example.com/app.yaml", "https://eu.cdn.example.com/app.yaml"} {
+		if err := config.checkURL(mustParseURL(t, allowed)); err != nil {
+			t.Errorf("checkURL(%s): %v", allowed, err)
+		}
+	}
+	var invalid *ErrInvalidInput
+	if err := config.checkURL(mustParseURL(t, "http://manifests.example.com/app.yaml")); !isErrInvalidInput(err, &invalid) {
+		t.Errorf("checkURL of a plain http URL: err = %v, want invalid input", err)
+	}
+}
+
+func mustParseURL(t *testing.T, raw string) *url.URL {
+	t.Helper()
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("parse %s: %v", raw, err)
+	}
+	return u
+}
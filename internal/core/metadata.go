@@ -0,0 +1,94 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
+)
+
+// SetLabel sets the label key to value on the resource identified by
+// id and returns the updated resource. Only that label is sent, as a
+// JSON merge patch of metadata.labels, so concurrent changes to the
+// rest of the object neither conflict nor get overwritten.
+func (uc *ResourceUseCase) SetLabel(ctx context.Context, id ResourceIdentifier, key, value string) (*unstructured.Unstructured, error) {
+	if err := validateMetadataKey(key); err != nil {
+		return nil, err
+	}
+	if errs := utilvalidation.IsValidLabelValue(value); len(errs) > 0 {
+		return nil, &ErrInvalidInput{Field: "value", Message: strings.Join(errs, "; ")}
+	}
+	return uc.patchMetadata(ctx, id, "labels", key, &value)
+}
+
+// RemoveLabel removes the label key from the resource identified by id
+// and returns the updated resource. Removing an absent label succeeds.
+func (uc *ResourceUseCase) RemoveLabel(ctx context.Context, id ResourceIdentifier, key string) (*unstructured.Unstructured, error) {
+	if err := validateMetadataKey(key); err != nil {
+		return nil, err
+	}
+	return uc.patchMetadata(ctx, id, "labels", key, nil)
+}
+
+// SetAnnotation sets the annotation key to value on the resource
+// identified by id and returns the updated resource, patching only
+// that annotation as SetLabel does.
+func (uc *ResourceUseCase) SetAnnotation(ctx context.Context, id ResourceIdentifier, key, value string) (*unstructured.Unstructured, error) {
+	if err := validateMetadataKey(key); err != nil {
+		return nil, err
+	}
+	// The API server bounds the total size of all annotations; a
+	// single value above the bound can be rejected without a request.
+	if len(key)+len(value) > validation.TotalAnnotationSizeLimitB {
+		return nil, &ErrInvalidInput{Field: "value", Message: fmt.Sprintf("must be at most %d bytes including the key", validation.TotalAnnotationSizeLimitB)}
+	}
+	return uc.patchMetadata(ctx, id, "annotations", key, &value)
+}
+
+// RemoveAnnotation removes the annotation key from the resource
+// identified by id and returns the updated resource. Removing an
+// absent annotation succeeds.
+func (uc *ResourceUseCase) RemoveAnnotation(ctx context.Context, id ResourceIdentifier, key string) (*unstructured.Unstructured, error) {
+	if err := validateMetadataKey(key); err != nil {
+		return nil, err
+	}
+	return uc.patchMetadata(ctx, id, "annotations", key, nil)
+}
+
+// patchMetadata sets key in metadata.field of the resource to value,
+// or removes it if value is nil, with a JSON merge patch.
+func (uc *ResourceUseCase) patchMetadata(ctx context.Context, id ResourceIdentifier, field, key string, value *string) (*unstructured.Unstructured, error) {
+	if id.Name == "" {
+		return nil, &ErrInvalidInput{Field: "name", Message: "is required"}
+	}
+
+	gvr, err := id.lookupScopedGVR(ctx, uc.discovery)
+	if err != nil {
+		return nil, err
+	}
+
+	// A null value removes the key (RFC 7386).
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			field: map[string]*string{key: value},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal %s patch: %w", field, err)
+	}
+	return uc.resource.MergePatch(ctx, id.Cluster, gvr, id.Namespace, id.Name, patch)
+}
+
+// validateMetadataKey checks a label or annotation key. Both must be
+// qualified names: an optional DNS subdomain prefix and a slash,
+// followed by a name of at most 63 characters.
+func validateMetadataKey(key string) error {
+	if errs := utilvalidation.IsQualifiedName(key); len(errs) > 0 {
+		return &ErrInvalidInput{Field: "key", Message: strings.Join(errs, "; ")}
+	}
+	return nil
+}
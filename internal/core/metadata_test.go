@@ -0,0 +1,146 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// mockPatchRepo records the merge patches it receives.
+type mockPatchRepo struct {
+	ResourceRepo
+	patches []string
+}
+
+func (m *mockPatchRepo) MergePatch(_ context.Context, _ string, _ schema.GroupVersionResource, namespace, name string, patch []byte) (*unstructured.Unstructured, error) {
+	m.patches = append(m.patches, string(patch))
+	obj := &unstructured.Unstructured{}
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj, nil
+}
+
+func TestResourceUseCase_MetadataEdits_SendMergePatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		edit  func(*ResourceUseCase) (*unstructured.Unstructured, error)
+		patch string
+	}{
+		{
+			name: "set label",
+			edit: func(uc *ResourceUseCase) (*unstructured.Unstructured, error) {
+				return uc.SetLabel(context.Background(), waitID, "app.kubernetes.io/name", "web")
+			},
+			patch: `{"metadata":{"labels":{"app.kubernetes.io/name":"web"}}}`,
+		},
+		{
+			name: "remove label",
+			edit: func(uc *ResourceUseCase) (*unstructured.Unstructured, error) {
+				return uc.RemoveLabel(context.Background(), waitID, "tier")
+			},
+			patch: `{"metadata":{"labels":{"tier":null}}}`,
+		},
+		{
+			name: "set annotation",
+			edit: func(uc *ResourceUseCase) (*unstructured.Unstructured, error) {
+				return uc.SetAnnotation(context.Background(), waitID, "example.com/note", "owned by the edge team")
+			},
+			patch: `{"metadata":{"annotations":{"example.com/note":"owned by the edge team"}}}`,
+		},
+		{
+			name: "remove annotation",
+			edit: func(uc *ResourceUseCase) (*unstructured.Unstructured, error) {
+				return uc.RemoveAnnotation(context.Background(), waitID, "example.com/note")
+			},
+			patch: `{"metadata":{"annotations":{"example.com/note":null}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockPatchRepo{}
+			uc := NewResourceUseCase(&mockWatchDiscovery{}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+
+			obj, err := tt.edit(uc)
+			if err != nil {
+				t.Fatalf("edit: %v", err)
+			}
+			if len(repo.patches) != 1 || repo.patches[0] != tt.patch {
+				t.Errorf("patches = %v, want [%s]", repo.patches, tt.patch)
+			}
+			if obj.GetName() != "web" {
+				t.Errorf("returned object = %v, want the patched resource", obj)
+			}
+		})
+	}
+}
+
+func TestResourceUseCase_MetadataEdits_RejectInvalidInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		edit  func(*ResourceUseCase) (*unstructured.Unstructured, error)
+		field string
+	}{
+		{
+			name: "label key with spaces",
+			edit: func(uc *ResourceUseCase) (*unstructured.Unstructured, error) {
+				return uc.SetLabel(context.Background(), waitID, "not a key", "web")
+			},
+			field: "key",
+		},
+		{
+			name: "label value too long",
+			edit: func(uc *ResourceUseCase) (*unstructured.Unstructured, error) {
+				return uc.SetLabel(context.Background(), waitID, "app", strings.Repeat("a", 64))
+			},
+			field: "value",
+		},
+		{
+			name: "empty label key on remove",
+			edit: func(uc *ResourceUseCase) (*unstructured.Unstructured, error) {
+				return uc.RemoveLabel(context.Background(), waitID, "")
+			},
+			field: "key",
+		},
+		{
+			name: "annotation key with bad prefix",
+			edit: func(uc *ResourceUseCase) (*unstructured.Unstructured, error) {
+				return uc.SetAnnotation(context.Background(), waitID, "Example_Com/note", "x")
+			},
+			field: "key",
+		},
+		{
+			name: "annotation value too large",
+			edit: func(uc *ResourceUseCase) (*unstructured.Unstructured, error) {
+				return uc.SetAnnotation(context.Background(), waitID, "note", strings.Repeat("a", 256*1024))
+			},
+			field: "value",
+		},
+		{
+			name: "missing name",
+			edit: func(uc *ResourceUseCase) (*unstructured.Unstructured, error) {
+				id := waitID
+				id.Name = ""
+				return uc.RemoveAnnotation(context.Background(), id, "note")
+			},
+			field: "name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockPatchRepo{}
+			uc := NewResourceUseCase(&mockWatchDiscovery{}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+
+			_, err := tt.edit(uc)
+			var invalid *ErrInvalidInput
+			if !isErrInvalidInput(err, &invalid) || invalid.Field != tt.field {
+				t.Fatalf("err = %v, want ErrInvalidInput for %s", err, tt.field)
+			}
+			if len(repo.patches) != 0 {
+				t.Errorf("patches = %v, want none for invalid input", repo.patches)
+			}
+		})
+	}
+}
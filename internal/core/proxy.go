@@ -0,0 +1,138 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// ProxyConfig holds the server-side restrictions for raw API server
+// passthrough requests.
+type ProxyConfig struct {
+	// AllowedPathPrefixes lists the API server path prefixes that may
+	// be requested (e.g. "/apis/apps/v1", "/livez"). A prefix matches
+	// the path itself and anything below it on a segment boundary. An
+	// empty list disables the passthrough entirely.
+	AllowedPathPrefixes []string
+}
+
+// ProxyRequest describes a raw, read-only API server request.
+type ProxyRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Body   []byte
+}
+
+// ProxyResponse is the API server's raw reply to a ProxyRequest.
+type ProxyResponse struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// ProxyRepo forwards raw requests to a cluster's API server through
+// the tunnel, impersonating the calling user.
+type ProxyRepo interface {
+	Do(ctx context.Context, cluster string, req ProxyRequest) (*ProxyResponse, error)
+}
+
+// KubeAPIPathPrefix is the server path under which the kube-API of
+// each registered cluster is proxied, as KubeAPIPathPrefix + cluster.
+const KubeAPIPathPrefix = "/clusters/"
+
+// KubeAPIProxy serves the kube-API of registered clusters under
+// KubeAPIPathPrefix, authorising and impersonating the calling user as
+// for every other request to a cluster, so that kubectl can use the
+// server as the API server of any cluster the user may access.
+type KubeAPIProxy interface {
+	http.Handler
+	// WriteError writes err, returned for a request to cluster, in
+	// the form kubectl displays.
+	WriteError(w http.ResponseWriter, cluster string, err error)
+}
+
+// ProxyUseCase guards raw API server passthrough so that it cannot be
+// used as an open proxy: only GET requests with an empty body are
+// forwarded, and only for paths under an allow-listed prefix.
+type ProxyUseCase struct {
+	repo     ProxyRepo
+	prefixes []string
+}
+
+// NewProxyUseCase returns a ProxyUseCase that forwards allowed
+// requests to repo.
+func NewProxyUseCase(repo ProxyRepo, cfg ProxyConfig) *ProxyUseCase {
+	prefixes := make([]string, 0, len(cfg.AllowedPathPrefixes))
+	for _, p := range cfg.AllowedPathPrefixes {
+		if p = strings.TrimRight(strings.TrimSpace(p), "/"); p != "" {
+			prefixes = append(prefixes, p)
+		}
+	}
+	return &ProxyUseCase{
+		repo:     repo,
+		prefixes: prefixes,
+	}
+}
+
+// Proxy validates req and forwards it to the cluster's API server.
+func (uc *ProxyUseCase) Proxy(ctx context.Context, cluster string, req ProxyRequest) (*ProxyResponse, error) {
+	if err := ValidateClusterName(cluster); err != nil {
+		return nil, err
+	}
+	if req.Method == "" {
+		req.Method = http.MethodGet
+	}
+	if req.Method != http.MethodGet {
+		return nil, &ErrInvalidInput{Field: "method", Message: fmt.Sprintf("only GET is supported, got %q", req.Method)}
+	}
+	if len(req.Body) > 0 {
+		return nil, &ErrInvalidInput{Field: "body", Message: "must be empty for GET requests"}
+	}
+	if err := validateProxyPath(req.Path); err != nil {
+		return nil, err
+	}
+	if _, err := url.ParseQuery(req.Query); err != nil {
+		return nil, &ErrInvalidInput{Field: "query", Message: err.Error()}
+	}
+	if !uc.allowed(req.Path) {
+		return nil, &DomainError{
+			Code:    ErrorCodePermissionDenied,
+			Message: fmt.Sprintf("path %q is not allowed for proxying", req.Path),
+		}
+	}
+
+	return uc.repo.Do(ctx, cluster, req)
+}
+
+// allowed reports whether p falls under one of the allow-listed
+// prefixes on a path-segment boundary, so that "/api/v1" does not
+// also admit "/api/v1beta".
+func (uc *ProxyUseCase) allowed(p string) bool {
+	for _, prefix := range uc.prefixes {
+		if p == prefix || strings.HasPrefix(p, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// validateProxyPath rejects paths that are relative or not in
+// canonical form. Requiring path.Clean(p) == p rules out "..", "."
+// and duplicate slashes, which could otherwise be used to escape an
+// allow-listed prefix.
+func validateProxyPath(p string) error {
+	if !strings.HasPrefix(p, "/") {
+		return &ErrInvalidInput{Field: "path", Message: "must be absolute"}
+	}
+	if path.Clean(p) != p {
+		return &ErrInvalidInput{Field: "path", Message: fmt.Sprintf("must be in canonical form, got %q", p)}
+	}
+	if strings.ContainsAny(p, "?#") {
+		return &ErrInvalidInput{Field: "path", Message: "must not contain a query or fragment"}
+	}
+	return nil
+}
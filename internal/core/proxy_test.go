@@ -0,0 +1,90 @@
+package core
+
+import (
+	"context"
+	"testing"
+)
+
+// mockProxyRepo implements ProxyRepo for testing and records the last
+// forwarded request.
+type mockProxyRepo struct {
+	called bool
+	req    ProxyRequest
+}
+
+func (m *mockProxyRepo) Do(_ context.Context, _ string, req ProxyRequest) (*ProxyResponse, error) {
+	m.called = true
+	m.req = req
+	return &ProxyResponse{StatusCode: 200, ContentType: "application/json", Body: []byte(`{}`)}, nil
+}
+
+func TestProxyUseCase_AllowedSubresourceGet(t *testing.T) {
+	repo := &mockProxyRepo{}
+	uc := NewProxyUseCase(repo, ProxyConfig{AllowedPathPrefixes: []string{"/apis/apps/v1/"}})
+
+	resp, err := uc.Proxy(context.Background(), "edge-1", ProxyRequest{
+		Path:  "/apis/apps/v1/namespaces/default/deployments/web/status",
+		Query: "pretty=true",
+	})
+	if err != nil {
+		t.Fatalf("Proxy: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Errorf("status = %d, want 200", resp.StatusCode)
+	}
+	if repo.req.Method != "GET" {
+		t.Errorf("forwarded method = %q, want GET default", repo.req.Method)
+	}
+}
+
+func TestProxyUseCase_Rejected(t *testing.T) {
+	cfg := ProxyConfig{AllowedPathPrefixes: []string{"/apis/apps/v1", "/livez"}}
+
+	tests := []struct {
+		name     string
+		req      ProxyRequest
+		wantCode ErrorCode
+	}{
+		{"path outside allow-list", ProxyRequest{Path: "/api/v1/secrets"}, ErrorCodePermissionDenied},
+		{"prefix without segment boundary", ProxyRequest{Path: "/livezz"}, ErrorCodePermissionDenied},
+		{"dot-dot escape", ProxyRequest{Path: "/apis/apps/v1/../../api/v1/secrets"}, ErrorCodeInvalidArgument},
+		{"duplicate slash", ProxyRequest{Path: "/apis/apps/v1//deployments"}, ErrorCodeInvalidArgument},
+		{"relative path", ProxyRequest{Path: "apis/apps/v1"}, ErrorCodeInvalidArgument},
+		{"non-GET method", ProxyRequest{Method: "DELETE", Path: "/apis/apps/v1/deployments"}, ErrorCodeInvalidArgument},
+		{"body on GET", ProxyRequest{Path: "/livez", Body: []byte("x")}, ErrorCodeInvalidArgument},
+		{"malformed query", ProxyRequest{Path: "/livez", Query: "a=%zz"}, ErrorCodeInvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockProxyRepo{}
+			uc := NewProxyUseCase(repo, cfg)
+
+			_, err := uc.Proxy(context.Background(), "edge-1", tt.req)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			var invalid *ErrInvalidInput
+			gotCode, _ := DomainErrorCode(err)
+			if isErrInvalidInput(err, &invalid) {
+				gotCode = ErrorCodeInvalidArgument
+			}
+			if gotCode != tt.wantCode {
+				t.Errorf("error code = %v, want %v (err: %v)", gotCode, tt.wantCode, err)
+			}
+			if repo.called {
+				t.Error("rejected request must not reach the repo")
+			}
+		})
+	}
+}
+
+func TestProxyUseCase_EmptyAllowListDisables(t *testing.T) {
+	repo := &mockProxyRepo{}
+	uc := NewProxyUseCase(repo, ProxyConfig{})
+
+	_, err := uc.Proxy(context.Background(), "edge-1", ProxyRequest{Path: "/livez"})
+	if code, _ := DomainErrorCode(err); code != ErrorCodePermissionDenied {
+		t.Fatalf("expected ErrorCodePermissionDenied, got %v", err)
+	}
+}
@@ -0,0 +1,237 @@
+package core
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GVRs of the core/v1 policy objects summarised by NamespaceQuota.
+var (
+	resourceQuotasGVR = schema.GroupVersionResource{Version: "v1", Resource: "resourcequotas"}
+	limitRangesGVR    = schema.GroupVersionResource{Version: "v1", Resource: "limitranges"}
+)
+
+// QuotaUsage is the usage of one resource under a ResourceQuota.
+// Quantities are in canonical Kubernetes form (e.g. "500m", "2Gi").
+type QuotaUsage struct {
+	Resource string
+	Used     string
+	Hard     string
+	// Utilization is Used/Hard, or 0 when Hard is zero.
+	Utilization float64
+}
+
+// ResourceQuotaSummary is the usage of every resource constrained by a
+// single ResourceQuota, sorted by resource name.
+type ResourceQuotaSummary struct {
+	Name      string
+	Resources []QuotaUsage
+}
+
+// LimitRangeItem holds the constraints a LimitRange places on one
+// resource for one object type (Container, Pod or
+// PersistentVolumeClaim). Unset constraints are empty.
+type LimitRangeItem struct {
+	Type                 string
+	Resource             string
+	Default              string
+	DefaultRequest       string
+	Min                  string
+	Max                  string
+	MaxLimitRequestRatio string
+}
+
+// LimitRangeSummary is the flattened set of constraints of a single
+// LimitRange, sorted by type and resource.
+type LimitRangeSummary struct {
+	Name   string
+	Limits []LimitRangeItem
+}
+
+// NamespaceQuota summarises the ResourceQuotas and LimitRanges of a
+// namespace. A namespace without either yields empty slices.
+type NamespaceQuota struct {
+	Quotas      []ResourceQuotaSummary
+	LimitRanges []LimitRangeSummary
+}
+
+// NamespaceQuota fetches the ResourceQuotas and LimitRanges in
+// namespace and returns used/hard per quota resource and the
+// default/min/max limits per LimitRange, computed server-side.
+func (uc *ResourceUseCase) NamespaceQuota(ctx context.Context, cluster, namespace string) (*NamespaceQuota, error) {
+	if namespace == "" {
+		return nil, &ErrInvalidInput{Field: "namespace", Message: "namespace is required"}
+	}
+
+	quotas, err := uc.resource.List(ctx, cluster, resourceQuotasGVR, namespace, ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	limitRanges, err := uc.resource.List(ctx, cluster, limitRangesGVR, namespace, ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &NamespaceQuota{
+		Quotas:      make([]ResourceQuotaSummary, 0, len(quotas.Items)),
+		LimitRanges: make([]LimitRangeSummary, 0, len(limitRanges.Items)),
+	}
+	for i := range quotas.Items {
+		summary, err := summarizeResourceQuota(&quotas.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		result.Quotas = append(result.Quotas, summary)
+	}
+	for i := range limitRanges.Items {
+		summary, err := summarizeLimitRange(&limitRanges.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		result.LimitRanges = append(result.LimitRanges, summary)
+	}
+	return result, nil
+}
+
+// summarizeResourceQuota computes the usage of every resource in a
+// ResourceQuota. The hard limits are taken from status, which reflects
+// what the quota controller enforces, falling back to spec when the
+// controller has not yet reconciled the quota. Resources without a
+// recorded usage are reported as unused.
+func summarizeResourceQuota(obj *unstructured.Unstructured) (ResourceQuotaSummary, error) {
+	hard, err := quantityMap(obj, "status", "hard")
+	if err != nil {
+		return ResourceQuotaSummary{}, err
+	}
+	if len(hard) == 0 {
+		if hard, err = quantityMap(obj, "spec", "hard"); err != nil {
+			return ResourceQuotaSummary{}, err
+		}
+	}
+	used, err := quantityMap(obj, "status", "used")
+	if err != nil {
+		return ResourceQuotaSummary{}, err
+	}
+
+	summary := ResourceQuotaSummary{
+		Name:      obj.GetName(),
+		Resources: make([]QuotaUsage, 0, len(hard)),
+	}
+	for _, name := range slices.Sorted(maps.Keys(hard)) {
+		h := hard[name]
+		u := used[name]
+
+		var utilization float64
+		if !h.IsZero() {
+			utilization = u.AsApproximateFloat64() / h.AsApproximateFloat64()
+		}
+
+		summary.Resources = append(summary.Resources, QuotaUsage{
+			Resource:    name,
+			Used:        u.String(),
+			Hard:        h.String(),
+			Utilization: utilization,
+		})
+	}
+	return summary, nil
+}
+
+// summarizeLimitRange flattens the spec.limits of a LimitRange into one
+// item per (type, resource) pair.
+func summarizeLimitRange(obj *unstructured.Unstructured) (LimitRangeSummary, error) {
+	limits, _, err := unstructured.NestedSlice(obj.Object, "spec", "limits")
+	if err != nil {
+		return LimitRangeSummary{}, limitRangeError(obj, err)
+	}
+
+	summary := LimitRangeSummary{Name: obj.GetName()}
+	for _, raw := range limits {
+		item, ok := raw.(map[string]any)
+		if !ok {
+			return LimitRangeSummary{}, limitRangeError(obj, fmt.Errorf("spec.limits entry is %T, not an object", raw))
+		}
+		typ, _, _ := unstructured.NestedString(item, "type")
+
+		fields := map[string]map[string]resource.Quantity{}
+		for _, field := range []string{"default", "defaultRequest", "min", "max", "maxLimitRequestRatio"} {
+			values, err := quantityMapIn(item, field)
+			if err != nil {
+				return LimitRangeSummary{}, limitRangeError(obj, err)
+			}
+			fields[field] = values
+		}
+
+		resources := map[string]struct{}{}
+		for _, values := range fields {
+			for name := range values {
+				resources[name] = struct{}{}
+			}
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(resources)) {
+			summary.Limits = append(summary.Limits, LimitRangeItem{
+				Type:                 typ,
+				Resource:             name,
+				Default:              quantityString(fields["default"], name),
+				DefaultRequest:       quantityString(fields["defaultRequest"], name),
+				Min:                  quantityString(fields["min"], name),
+				Max:                  quantityString(fields["max"], name),
+				MaxLimitRequestRatio: quantityString(fields["maxLimitRequestRatio"], name),
+			})
+		}
+	}
+
+	slices.SortStableFunc(summary.Limits, func(a, b LimitRangeItem) int {
+		return cmp.Compare(a.Type, b.Type)
+	})
+	return summary, nil
+}
+
+func limitRangeError(obj *unstructured.Unstructured, err error) error {
+	return &DomainError{Code: ErrorCodeInternal, Message: fmt.Sprintf("parse LimitRange %q", obj.GetName()), Cause: err}
+}
+
+// quantityMap parses the resource-name-to-quantity map at the given
+// field path of obj.
+func quantityMap(obj *unstructured.Unstructured, fields ...string) (map[string]resource.Quantity, error) {
+	values, err := quantityMapIn(obj.Object, fields...)
+	if err != nil {
+		return nil, &DomainError{Code: ErrorCodeInternal, Message: fmt.Sprintf("parse ResourceQuota %q", obj.GetName()), Cause: err}
+	}
+	return values, nil
+}
+
+// quantityMapIn parses the resource-name-to-quantity map at the given
+// field path of m. A missing field yields an empty map.
+func quantityMapIn(m map[string]any, fields ...string) (map[string]resource.Quantity, error) {
+	raw, _, err := unstructured.NestedStringMap(m, fields...)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]resource.Quantity, len(raw))
+	for name, s := range raw {
+		q, err := resource.ParseQuantity(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", name, s, err)
+		}
+		values[name] = q
+	}
+	return values, nil
+}
+
+// quantityString returns the canonical form of values[name], or "" if
+// it is unset.
+func quantityString(values map[string]resource.Quantity, name string) string {
+	q, ok := values[name]
+	if !ok {
+		return ""
+	}
+	return q.String()
+}
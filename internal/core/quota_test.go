@@ -0,0 +1,107 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// mockQuotaRepo serves fixed ResourceQuota and LimitRange lists.
+type mockQuotaRepo struct {
+	ResourceRepo
+	lists map[string][]unstructured.Unstructured // keyed by resource
+}
+
+func (m *mockQuotaRepo) List(_ context.Context, _ string, gvr schema.GroupVersionResource, _ string, _ ListOptions) (*unstructured.UnstructuredList, error) {
+	return &unstructured.UnstructuredList{Items: m.lists[gvr.Resource]}, nil
+}
+
+func TestResourceUseCase_NamespaceQuota_UsedAndHard(t *testing.T) {
+	quota := unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ResourceQuota",
+		"metadata":   map[string]any{"name": "compute", "namespace": "team-a"},
+		"spec":       map[string]any{"hard": map[string]any{"requests.cpu": "4", "requests.memory": "8Gi", "pods": "10"}},
+		"status": map[string]any{
+			"hard": map[string]any{"requests.cpu": "4", "requests.memory": "8Gi", "pods": "10"},
+			"used": map[string]any{"requests.cpu": "1500m", "requests.memory": "2Gi"},
+		},
+	}}
+	limitRange := unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "LimitRange",
+		"metadata":   map[string]any{"name": "defaults", "namespace": "team-a"},
+		"spec": map[string]any{"limits": []any{
+			map[string]any{
+				"type":           "Container",
+				"default":        map[string]any{"cpu": "500m"},
+				"defaultRequest": map[string]any{"cpu": "250m"},
+				"max":            map[string]any{"cpu": "2", "memory": "4Gi"},
+			},
+		}},
+	}}
+	repo := &mockQuotaRepo{lists: map[string][]unstructured.Unstructured{
+		"resourcequotas": {quota},
+		"limitranges":    {limitRange},
+	}}
+	uc := NewResourceUseCase(nil, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+
+	got, err := uc.NamespaceQuota(context.Background(), "c1", "team-a")
+	if err != nil {
+		t.Fatalf("NamespaceQuota: %v", err)
+	}
+
+	if len(got.Quotas) != 1 || got.Quotas[0].Name != "compute" {
+		t.Fatalf("quotas = %+v, want one quota named compute", got.Quotas)
+	}
+	want := []QuotaUsage{
+		{Resource: "pods", Used: "0", Hard: "10", Utilization: 0},
+		{Resource: "requests.cpu", Used: "1500m", Hard: "4", Utilization: 0.375},
+		{Resource: "requests.memory", Used: "2Gi", Hard: "8Gi", Utilization: 0.25},
+	}
+	if len(got.Quotas[0].Resources) != len(want) {
+		t.Fatalf("resources = %+v, want %+v", got.Quotas[0].Resources, want)
+	}
+	for i, w := range want {
+		if g := got.Quotas[0].Resources[i]; g != w {
+			t.Errorf("resources[%d] = %+v, want %+v", i, g, w)
+		}
+	}
+
+	wantLimits := []LimitRangeItem{
+		{Type: "Container", Resource: "cpu", Default: "500m", DefaultRequest: "250m", Max: "2"},
+		{Type: "Container", Resource: "memory", Max: "4Gi"},
+	}
+	if len(got.LimitRanges) != 1 || len(got.LimitRanges[0].Limits) != len(wantLimits) {
+		t.Fatalf("limit ranges = %+v, want %+v", got.LimitRanges, wantLimits)
+	}
+	for i, w := range wantLimits {
+		if g := got.LimitRanges[0].Limits[i]; g != w {
+			t.Errorf("limits[%d] = %+v, want %+v", i, g, w)
+		}
+	}
+}
+
+func TestResourceUseCase_NamespaceQuota_Empty(t *testing.T) {
+	uc := NewResourceUseCase(nil, &mockQuotaRepo{}, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+
+	got, err := uc.NamespaceQuota(context.Background(), "c1", "team-b")
+	if err != nil {
+		t.Fatalf("NamespaceQuota: %v", err)
+	}
+	if got.Quotas == nil || got.LimitRanges == nil || len(got.Quotas) != 0 || len(got.LimitRanges) != 0 {
+		t.Errorf("result = %+v, want empty, non-nil lists", got)
+	}
+}
+
+func TestResourceUseCase_NamespaceQuota_RequiresNamespace(t *testing.T) {
+	uc := NewResourceUseCase(nil, &mockQuotaRepo{}, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+
+	_, err := uc.NamespaceQuota(context.Background(), "c1", "")
+	var invalidInput *ErrInvalidInput
+	if !isErrInvalidInput(err, &invalidInput) {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
+}
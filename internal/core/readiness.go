@@ -0,0 +1,45 @@
+package core
+
+import "context"
+
+// ReadinessConfig holds the conditions the server must meet before it
+// reports ready to serve, and so is added to its load balancer.
+type ReadinessConfig struct {
+	// MinClusters is the number of clusters that must be connected.
+	// Zero means the server is ready without any.
+	MinClusters int
+}
+
+// Readiness is the outcome of a readiness check.
+type Readiness struct {
+	Ready             bool
+	ConnectedClusters int
+	MinClusters       int
+}
+
+// ReadinessUseCase decides whether the server is ready to serve.
+type ReadinessUseCase struct {
+	tunnel TunnelProvider
+	config ReadinessConfig
+}
+
+// NewReadinessUseCase returns a ReadinessUseCase that counts the
+// clusters registered with tunnel against config.
+func NewReadinessUseCase(tunnel TunnelProvider, config ReadinessConfig) *ReadinessUseCase {
+	return &ReadinessUseCase{
+		tunnel: tunnel,
+		config: config,
+	}
+}
+
+// Check reports whether at least the configured number of clusters
+// are connected. Clusters are counted on every call, so the result
+// flips as agents connect and disconnect.
+func (uc *ReadinessUseCase) Check(_ context.Context) Readiness {
+	connected := len(uc.tunnel.ListClusters())
+	return Readiness{
+		Ready:             connected >= uc.config.MinClusters,
+		ConnectedClusters: connected,
+		MinClusters:       uc.config.MinClusters,
+	}
+}
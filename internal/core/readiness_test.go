@@ -0,0 +1,33 @@
+package core
+
+import (
+	"context"
+	"testing"
+)
+
+func TestReadinessUseCase_Check(t *testing.T) {
+	tp := &mockTunnelProvider{clusters: map[string]Cluster{}}
+	uc := NewReadinessUseCase(tp, ReadinessConfig{MinClusters: 2})
+
+	tp.clusters["edge-1"] = Cluster{Host: "127.0.0.2"}
+	if got := uc.Check(context.Background()); got.Ready || got.ConnectedClusters != 1 || got.MinClusters != 2 {
+		t.Fatalf("with 1 of 2 clusters: %+v, want not ready", got)
+	}
+
+	tp.clusters["edge-2"] = Cluster{Host: "127.0.0.3"}
+	if got := uc.Check(context.Background()); !got.Ready || got.ConnectedClusters != 2 {
+		t.Fatalf("with 2 of 2 clusters: %+v, want ready", got)
+	}
+
+	delete(tp.clusters, "edge-1")
+	if got := uc.Check(context.Background()); got.Ready {
+		t.Fatalf("after a cluster disconnects: %+v, want not ready", got)
+	}
+}
+
+func TestReadinessUseCase_CheckWithoutMinimum(t *testing.T) {
+	uc := NewReadinessUseCase(&mockTunnelProvider{}, ReadinessConfig{})
+	if got := uc.Check(context.Background()); !got.Ready {
+		t.Fatalf("Check = %+v, want ready without clusters", got)
+	}
+}
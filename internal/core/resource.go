@@ -20,10 +20,17 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/kube-openapi/pkg/validation/spec"
@@ -40,10 +47,20 @@ type DiscoveryClient interface {
 	// LookupResource validates that a group/version/resource triple
 	// exists on the target cluster.
 	LookupResource(ctx context.Context, cluster, group, version, resource string) (schema.GroupVersionResource, error)
-	// ServerResources returns all API resources advertised by the cluster.
-	ServerResources(ctx context.Context, cluster string) ([]*metav1.APIResourceList, error)
+	// IsNamespaced reports whether a resource validated by
+	// LookupResource is namespace-scoped.
+	IsNamespaced(ctx context.Context, cluster string, gvr schema.GroupVersionResource) (bool, error)
+	// ServerResources returns all API resources advertised by the
+	// cluster. Group-versions whose discovery failed (e.g. a broken
+	// aggregated API) are reported as failures alongside the resources
+	// that were discovered, rather than as an error.
+	ServerResources(ctx context.Context, cluster string) ([]*metav1.APIResourceList, []DiscoveryFailure, error)
 	// ResolveSchema fetches the OpenAPI schema for a given GVK.
 	ResolveSchema(ctx context.Context, cluster, group, version, kind string) (*spec.Schema, error)
+	// OpenAPIV3 fetches the OpenAPI v3 document the cluster serves for
+	// a group-version, as JSON, covering all of its kinds. A cluster
+	// that does not serve one returns an ErrorCodeNotFound DomainError.
+	OpenAPIV3(ctx context.Context, cluster, group, version string) ([]byte, error)
 	// ServerVersion returns the Kubernetes version of the cluster.
 	ServerVersion(ctx context.Context, cluster string) (*version.Info, error)
 	// SupportsWatchList reports whether the target cluster supports
@@ -66,7 +83,8 @@ type ResourceRepo interface {
 		namespace, name string,
 	) (*unstructured.Unstructured, error)
 
-	// Create decodes a YAML manifest and creates a new resource.
+	// Create decodes a YAML manifest and creates a new resource. The
+	// manifest may use metadata.generateName instead of a name.
 	Create(ctx context.Context, cluster string, gvr schema.GroupVersionResource,
 		namespace string, manifest []byte,
 	) (*unstructured.Unstructured, error)
@@ -77,6 +95,11 @@ type ResourceRepo interface {
 		namespace, name string, manifest []byte, opts ApplyOptions,
 	) (*unstructured.Unstructured, error)
 
+	// MergePatch applies a JSON merge patch (RFC 7386) to a resource.
+	MergePatch(ctx context.Context, cluster string, gvr schema.GroupVersionResource,
+		namespace, name string, patch []byte,
+	) (*unstructured.Unstructured, error)
+
 	// Delete removes a resource.
 	Delete(ctx context.Context, cluster string, gvr schema.GroupVersionResource,
 		namespace, name string, opts DeleteOptions,
@@ -88,11 +111,19 @@ type ResourceRepo interface {
 		namespace string, opts WatchOptions,
 	) (Watcher, error)
 
-	// ListEvents returns events matching the given options.
-	// Used by DescribeResource to fetch events via involvedObject.uid.
+	// ListEvents returns events matching the given options, across
+	// all namespaces when namespace is empty. Used by DescribeResource
+	// to fetch the events about an object.
 	ListEvents(ctx context.Context, cluster, namespace string, opts ListOptions) (*unstructured.UnstructuredList, error)
 }
 
+// DiscoveryFailure records an API group-version whose resources could
+// not be discovered.
+type DiscoveryFailure struct {
+	GroupVersion string
+	Message      string
+}
+
 // ---------------------------------------------------------------------------
 // Options types
 // ---------------------------------------------------------------------------
@@ -104,6 +135,35 @@ type ListOptions struct {
 	FieldSelector string
 	Limit         int64
 	Continue      string
+	// AllNamespaces lists a namespaced resource across all namespaces
+	// when the namespace is omitted, instead of in the default
+	// namespace. It cannot be combined with a namespace.
+	AllNamespaces bool
+}
+
+// DescribeOptions configures a describe request.
+type DescribeOptions struct {
+	// Since, when non-zero, restricts the returned events to those
+	// last observed at or after it, so that a live view can refresh
+	// without refetching events it already has. Events observed at
+	// exactly Since are included because event timestamps may only
+	// have second precision; clients de-duplicate by UID.
+	Since time.Time
+}
+
+// CreateOptions configures a resource creation.
+type CreateOptions struct {
+	// CheckNamespace enables a pre-flight check that the target
+	// namespace exists, so that a missing namespace is reported as
+	// such instead of as a 404 for the resource itself.
+	CheckNamespace bool
+	// SkipManagedBy creates the object without the managed-by label
+	// and created-by annotation (see ApplyConfig.ManagedByLabel).
+	SkipManagedBy bool
+	// IdempotencyKey, if set, makes a retry of the create with the
+	// same key and request return the object the first attempt
+	// created; see IdempotencyCache.
+	IdempotencyKey string
 }
 
 // ApplyOptions configures a server-side apply operation.
@@ -111,6 +171,79 @@ type ListOptions struct {
 type ApplyOptions struct {
 	Force        bool
 	FieldManager string
+	// CheckNamespace enables the same pre-flight namespace check as
+	// CreateOptions.CheckNamespace. It is evaluated by the use case and
+	// not sent to the API server.
+	CheckNamespace bool
+	// DryRun has the API server validate the apply, including field
+	// ownership, without persisting it.
+	DryRun bool
+	// SkipManagedBy is CreateOptions.SkipManagedBy for applies. Like
+	// CheckNamespace, it is evaluated by the use case.
+	SkipManagedBy bool
+	// WaitTimeout is ApplyManifestOptions.WaitTimeout for a single
+	// object. It is evaluated by the use case.
+	WaitTimeout time.Duration
+}
+
+// ApplyConfig holds the server-wide defaults for server-side apply.
+type ApplyConfig struct {
+	// FieldManagerPrefix is prepended to the caller's subject to form
+	// the field manager of applies that do not name one, so that field
+	// ownership is attributable to the user who applied.
+	FieldManagerPrefix string
+	// ManagedByLabel is the label key set to ManagedByValue on objects
+	// created or applied through otterscale, which are also annotated
+	// with the subject that created them, so that they can later be
+	// told apart for pruning and audits. Empty disables the tagging.
+	ManagedByLabel string
+	// Manifest bounds the manifests accepted by Create, Apply and
+	// ApplyManifest.
+	Manifest ManifestLimits
+	// ReadyConditions decides when applied objects are ready for
+	// applies that wait for readiness.
+	ReadyConditions ReadyConditions
+}
+
+// NamespaceConfig holds the server-wide namespace defaults.
+type NamespaceConfig struct {
+	// Default is the namespace listed or watched when a request for a
+	// namespaced resource names none and does not ask for all
+	// namespaces, so that a forgotten namespace does not silently
+	// span the whole cluster. Empty treats an omitted namespace as all
+	// namespaces.
+	Default string
+}
+
+// maxFieldManagerLength is the longest field manager the API server
+// accepts.
+const maxFieldManagerLength = 128
+
+// fieldManager returns requested, or if it is empty, the field manager
+// derived from the calling user in ctx, or "service:<operation>" for
+// the server's own requests. It returns "" if neither is known,
+// leaving the API server to reject the apply.
+func (c ApplyConfig) fieldManager(ctx context.Context, requested string) string {
+	if requested != "" {
+		return requested
+	}
+	subject := callerSubject(ctx)
+	if subject == "" {
+		return ""
+	}
+	manager := c.FieldManagerPrefix + subject
+	if len(manager) > maxFieldManagerLength {
+		manager = manager[:maxFieldManagerLength]
+	}
+	return manager
+}
+
+// ForceApplyResult is the outcome of ForceApplyResource.
+type ForceApplyResult struct {
+	Object *unstructured.Unstructured
+	// Overridden lists the fields taken over from other field
+	// managers. It is empty if the apply did not conflict.
+	Overridden []ApplyConflict
 }
 
 // DeleteOptions configures a resource deletion.
@@ -126,6 +259,20 @@ type WatchOptions struct {
 	FieldSelector     string
 	ResourceVersion   string
 	SendInitialEvents bool
+	// InitialEvents, if set, is the caller's preference for initial
+	// events: false keeps WatchResource from setting SendInitialEvents
+	// even on clusters that support WatchList. Nil sets it whenever
+	// they do. Repositories ignore it.
+	InitialEvents *bool
+	// AllNamespaces watches a namespaced resource across all
+	// namespaces when the namespace is omitted, instead of in the
+	// default namespace. It cannot be combined with a namespace.
+	AllNamespaces bool
+	// ResyncInterval, if positive, makes the watch relist and rewatch
+	// at this interval, announcing each resync with a BOOKMARK event
+	// that has Resynced set, so that events the upstream watch missed
+	// are eventually reflected. Repositories ignore it.
+	ResyncInterval time.Duration
 }
 
 // SchemaResolver resolves OpenAPI schemas for Kubernetes GVKs.
@@ -136,6 +283,21 @@ type SchemaResolver interface {
 	ResolveSchema(ctx context.Context, cluster, group, version, kind string) (*spec.Schema, error)
 }
 
+// ServerVersionResolver resolves the Kubernetes version of a cluster.
+// Implementations may cache results, since the version only changes
+// when the cluster is upgraded.
+type ServerVersionResolver interface {
+	ServerVersion(ctx context.Context, cluster string) (*version.Info, error)
+}
+
+// NamespaceChecker reports whether a namespace exists on a cluster.
+// Implementations may cache positive results, since namespaces are
+// rarely deleted and a stale positive only defers the error to the
+// API server.
+type NamespaceChecker interface {
+	NamespaceExists(ctx context.Context, cluster, namespace string) (bool, error)
+}
+
 // ---------------------------------------------------------------------------
 // Identifiers
 // ---------------------------------------------------------------------------
@@ -158,6 +320,37 @@ func (id ResourceIdentifier) lookupGVR(ctx context.Context, dc DiscoveryClient)
 	return dc.LookupResource(ctx, id.Cluster, id.Group, id.Version, id.Resource)
 }
 
+// lookupScopedGVR validates the resource triple like lookupGVR and
+// checks that id names a namespace exactly if the resource is
+// namespaced. Otherwise the dynamic client would send the request to a
+// path that the API server rejects with a confusing NotFound.
+func (id ResourceIdentifier) lookupScopedGVR(ctx context.Context, dc DiscoveryClient) (schema.GroupVersionResource, error) {
+	gvr, err := id.lookupGVR(ctx, dc)
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	namespaced, err := dc.IsNamespaced(ctx, id.Cluster, gvr)
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	if err := checkScope(gvr, namespaced, id.Namespace); err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	return gvr, nil
+}
+
+// checkScope returns an *ErrInvalidInput if namespace is set for a
+// cluster-scoped resource or empty for a namespaced one.
+func checkScope(gvr schema.GroupVersionResource, namespaced bool, namespace string) error {
+	switch {
+	case namespaced && namespace == "":
+		return &ErrInvalidInput{Field: "namespace", Message: fmt.Sprintf("resource %s is namespaced; namespace is required", gvr.GroupResource())}
+	case !namespaced && namespace != "":
+		return &ErrInvalidInput{Field: "namespace", Message: fmt.Sprintf("resource %s is cluster-scoped; namespace must be empty", gvr.GroupResource())}
+	}
+	return nil
+}
+
 // ---------------------------------------------------------------------------
 // Use case
 // ---------------------------------------------------------------------------
@@ -170,25 +363,67 @@ type ResourceUseCase struct {
 	discovery      DiscoveryClient
 	resource       ResourceRepo
 	schemaResolver SchemaResolver
+	versions       ServerVersionResolver
+	namespaces     NamespaceChecker
+	idempotency    IdempotencyCache
+	apply          ApplyConfig
+	namespace      NamespaceConfig
+}
+
+// ResourceOption configures optional backends of a ResourceUseCase at
+// construction time.
+type ResourceOption func(*ResourceUseCase)
+
+// WithIdempotencyCache makes CreateResource honour idempotency keys
+// with cache. Without it, idempotency keys are validated but ignored.
+func WithIdempotencyCache(cache IdempotencyCache) ResourceOption {
+	return func(uc *ResourceUseCase) {
+		uc.idempotency = cache
+	}
 }
 
 // NewResourceUseCase returns a ResourceUseCase wired to the given
-// discovery, resource, and schema resolver backends. The
-// SchemaResolver is injected to decouple caching infrastructure
-// from the domain use-case.
-func NewResourceUseCase(discovery DiscoveryClient, resource ResourceRepo, schemaResolver SchemaResolver) *ResourceUseCase {
-	return &ResourceUseCase{
+// discovery, resource, schema resolver, server version resolver, and
+// namespace checker backends. The resolvers are injected to decouple
+// caching infrastructure from the domain use-case. apply supplies the
+// defaults for server-side apply, and namespace the namespace of lists
+// and watches that omit one.
+func NewResourceUseCase(discovery DiscoveryClient, resource ResourceRepo, schemaResolver SchemaResolver, versions ServerVersionResolver, namespaces NamespaceChecker, apply ApplyConfig, namespace NamespaceConfig, opts ...ResourceOption) *ResourceUseCase {
+	uc := &ResourceUseCase{
 		discovery:      discovery,
 		resource:       resource,
 		schemaResolver: schemaResolver,
+		versions:       versions,
+		namespaces:     namespaces,
+		apply:          apply,
+		namespace:      namespace,
+	}
+	for _, opt := range opts {
+		opt(uc)
 	}
+	return uc
 }
 
-// ServerResources returns all API resource lists from the target cluster.
-func (uc *ResourceUseCase) ServerResources(ctx context.Context, cluster string) ([]*metav1.APIResourceList, error) {
+// ServerResources returns all API resource lists from the target
+// cluster, together with the group-versions whose discovery failed.
+func (uc *ResourceUseCase) ServerResources(ctx context.Context, cluster string) ([]*metav1.APIResourceList, []DiscoveryFailure, error) {
 	return uc.discovery.ServerResources(ctx, cluster)
 }
 
+// ClusterCapabilities reports the version-gated features supported by
+// the target cluster, derived from its (cached) server version.
+func (uc *ResourceUseCase) ClusterCapabilities(ctx context.Context, cluster string) (ClusterCapabilities, error) {
+	info, err := uc.versions.ServerVersion(ctx, cluster)
+	if err != nil {
+		return ClusterCapabilities{}, err
+	}
+	caps, err := CapabilitiesForVersion(info)
+	if err != nil {
+		return ClusterCapabilities{}, &DomainError{Code: ErrorCodeInternal, Message: "determine cluster capabilities", Cause: err}
+	}
+	return caps, nil
+}
+
 // ResolveSchema fetches the OpenAPI schema for the given GVK via the
 // injected SchemaResolver.
 func (uc *ResourceUseCase) ResolveSchema(
@@ -199,6 +434,8 @@ func (uc *ResourceUseCase) ResolveSchema(
 }
 
 // ListResources validates the GVR and fetches a paged resource list.
+// A namespaced resource whose namespace is omitted is listed in the
+// default namespace unless opts.AllNamespaces is set.
 func (uc *ResourceUseCase) ListResources(
 	ctx context.Context,
 	id ResourceIdentifier,
@@ -209,7 +446,40 @@ func (uc *ResourceUseCase) ListResources(
 		return nil, err
 	}
 
-	return uc.resource.List(ctx, id.Cluster, gvr, id.Namespace, opts)
+	namespace, err := uc.resolveNamespace(ctx, id, gvr, opts.AllNamespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.resource.List(ctx, id.Cluster, gvr, namespace, opts)
+}
+
+// resolveNamespace returns the namespace a list or watch of id
+// targets: the requested one, all namespaces ("") if allNamespaces is
+// set, or the configured default for a namespaced resource. A
+// namespace requested for a cluster-scoped resource is rejected.
+func (uc *ResourceUseCase) resolveNamespace(ctx context.Context, id ResourceIdentifier, gvr schema.GroupVersionResource, allNamespaces bool) (string, error) {
+	if allNamespaces {
+		if id.Namespace != "" {
+			return "", &ErrInvalidInput{Field: "all_namespaces", Message: "cannot be combined with namespace"}
+		}
+		return "", nil
+	}
+	if id.Namespace == "" && uc.namespace.Default == "" {
+		return "", nil
+	}
+
+	namespaced, err := uc.discovery.IsNamespaced(ctx, id.Cluster, gvr)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case id.Namespace != "":
+		return id.Namespace, checkScope(gvr, namespaced, id.Namespace)
+	case !namespaced:
+		return "", nil
+	}
+	return uc.namespace.Default, nil
 }
 
 // GetResource validates the GVR and fetches a single resource.
@@ -217,7 +487,7 @@ func (uc *ResourceUseCase) GetResource(
 	ctx context.Context,
 	id ResourceIdentifier,
 ) (*unstructured.Unstructured, error) {
-	gvr, err := id.lookupGVR(ctx, uc.discovery)
+	gvr, err := id.lookupScopedGVR(ctx, uc.discovery)
 	if err != nil {
 		return nil, err
 	}
@@ -225,15 +495,16 @@ func (uc *ResourceUseCase) GetResource(
 	return uc.resource.Get(ctx, id.Cluster, gvr, id.Namespace, id.Name)
 }
 
-// DescribeResource validates the GVR, fetches the resource, extracts
-// its UID, then queries related Kubernetes events filtered by
-// involvedObject.uid. This is the backend equivalent of
-// `kubectl describe`.
+// DescribeResource validates the GVR, fetches the resource, then
+// queries the Kubernetes events about it (see involvedEvents). This is
+// the backend equivalent of `kubectl describe`. When opts.Since is
+// set, only events observed since then are returned.
 func (uc *ResourceUseCase) DescribeResource(
 	ctx context.Context,
 	id ResourceIdentifier,
+	opts DescribeOptions,
 ) (*unstructured.Unstructured, *unstructured.UnstructuredList, error) {
-	gvr, err := id.lookupGVR(ctx, uc.discovery)
+	gvr, err := id.lookupScopedGVR(ctx, uc.discovery)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -243,49 +514,278 @@ func (uc *ResourceUseCase) DescribeResource(
 		return nil, nil, err
 	}
 
-	uid := string(obj.GetUID())
-
-	events, err := uc.resource.ListEvents(ctx, id.Cluster, id.Namespace, ListOptions{
-		FieldSelector: fmt.Sprintf("involvedObject.uid=%s", uid),
-	})
+	events, err := uc.involvedEvents(ctx, id.Cluster, obj)
 	if err != nil {
 		// Events are supplementary; return the resource even if event
-		// listing fails (e.g. RBAC restrictions on events).
+		// listing fails (e.g. RBAC restrictions on events), unless the
+		// request itself was cancelled or timed out.
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
 		return obj, &unstructured.UnstructuredList{}, nil
 	}
 
+	if !opts.Since.IsZero() {
+		events.Items = slices.DeleteFunc(events.Items, func(event unstructured.Unstructured) bool {
+			return eventObservedAt(&event).Before(opts.Since)
+		})
+	}
+
 	return obj, events, nil
 }
 
+// involvedEvents lists the events about obj.
+//
+// Events about a namespaced object are recorded in its namespace and
+// matched by involvedObject.uid. Events about a cluster-scoped object,
+// such as a Node or a PersistentVolume, may be recorded in any
+// namespace, usually "default", and some recorders, notably the
+// kubelet for its Node, set involvedObject.uid to the object's name.
+// They are therefore listed across all namespaces by kind and name,
+// and kept if their UID is the object's, its name, or unset.
+func (uc *ResourceUseCase) involvedEvents(ctx context.Context, cluster string, obj *unstructured.Unstructured) (*unstructured.UnstructuredList, error) {
+	uid := string(obj.GetUID())
+	if obj.GetNamespace() != "" {
+		return uc.resource.ListEvents(ctx, cluster, obj.GetNamespace(), ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("involvedObject.uid", uid).String(),
+		})
+	}
+
+	events, err := uc.resource.ListEvents(ctx, cluster, metav1.NamespaceAll, ListOptions{
+		FieldSelector: fields.Set{
+			"involvedObject.kind":      obj.GetKind(),
+			"involvedObject.name":      obj.GetName(),
+			"involvedObject.namespace": "",
+		}.AsSelector().String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	events.Items = slices.DeleteFunc(events.Items, func(event unstructured.Unstructured) bool {
+		involved, _, _ := unstructured.NestedString(event.Object, "involvedObject", "uid")
+		return involved != "" && involved != uid && involved != obj.GetName()
+	})
+	return events, nil
+}
+
+// eventObservedAt returns the time an event was last observed. The API
+// server cannot select events by time, so DescribeResource filters on
+// it after listing. The most specific timestamp set is used: the
+// series' last observation, then lastTimestamp (core/v1), eventTime
+// (events.k8s.io/v1), and finally the creation timestamp.
+func eventObservedAt(event *unstructured.Unstructured) time.Time {
+	for _, path := range [][]string{
+		{"series", "lastObservedTime"},
+		{"lastTimestamp"},
+		{"eventTime"},
+	} {
+		value, _, _ := unstructured.NestedString(event.Object, path...)
+		if value == "" {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			return t
+		}
+	}
+	return event.GetCreationTimestamp().Time
+}
+
 // CreateResource validates the GVR and creates the resource on the
-// target cluster from the given YAML manifest.
+// target cluster from the given YAML manifest, which names the object
+// with either metadata.name or metadata.generateName; the returned
+// object carries the assigned name. When
+// opts.CheckNamespace is set, a missing target namespace is reported
+// as an *ErrInvalidInput. Unless opts.SkipManagedBy is set, the object
+// is tagged as managed by otterscale and created by the caller. With
+// opts.IdempotencyKey set, a retry of a create that succeeded returns
+// the object it created.
 func (uc *ResourceUseCase) CreateResource(
 	ctx context.Context,
 	id ResourceIdentifier,
 	manifest []byte,
+	opts CreateOptions,
 ) (*unstructured.Unstructured, error) {
-	gvr, err := id.lookupGVR(ctx, uc.discovery)
+	if err := uc.apply.Manifest.checkObject(manifest); err != nil {
+		return nil, err
+	}
+	if err := validateIdempotencyKey(opts.IdempotencyKey); err != nil {
+		return nil, err
+	}
+
+	gvr, err := id.lookupScopedGVR(ctx, uc.discovery)
 	if err != nil {
 		return nil, err
 	}
 
+	if opts.IdempotencyKey == "" || uc.idempotency == nil {
+		return uc.create(ctx, id, gvr, manifest, opts)
+	}
+	return uc.idempotency.Do(ctx, idempotencyCacheKey(ctx, opts.IdempotencyKey), createFingerprint(id, gvr, manifest, opts), func() (*unstructured.Unstructured, error) {
+		return uc.create(ctx, id, gvr, manifest, opts)
+	})
+}
+
+// create performs CreateResource once its inputs are validated.
+func (uc *ResourceUseCase) create(ctx context.Context, id ResourceIdentifier, gvr schema.GroupVersionResource, manifest []byte, opts CreateOptions) (*unstructured.Unstructured, error) {
+	var err error
+	if opts.CheckNamespace {
+		if err := uc.checkNamespace(ctx, id.Cluster, id.Namespace); err != nil {
+			return nil, err
+		}
+	}
+
+	if !opts.SkipManagedBy {
+		manifest, err = uc.apply.tagManagedBy(manifest, callerSubject(ctx))
+		if err != nil {
+			return nil, err
+		}
+	}
 	return uc.resource.Create(ctx, id.Cluster, gvr, id.Namespace, manifest)
 }
 
 // ApplyResource validates the GVR and performs a server-side apply on
-// the target cluster from the given YAML manifest.
+// the target cluster from the given YAML manifest. When
+// opts.CheckNamespace is set, a missing target namespace is reported
+// as an *ErrInvalidInput. An empty opts.FieldManager defaults to the
+// configured prefix followed by the caller's subject. Unless
+// opts.SkipManagedBy is set, the applied object is given the
+// managed-by label and created-by annotation it lacks. With opts.WaitTimeout, the applied object is waited
+// on until ready and returned as it then is; a timeout is reported
+// with ErrorCodeDeadlineExceeded, although the apply took effect.
 func (uc *ResourceUseCase) ApplyResource(
 	ctx context.Context,
 	id ResourceIdentifier,
 	manifest []byte,
 	opts ApplyOptions,
 ) (*unstructured.Unstructured, error) {
-	gvr, err := id.lookupGVR(ctx, uc.discovery)
+	if err := uc.apply.Manifest.checkObject(manifest); err != nil {
+		return nil, err
+	}
+	if err := validateReadyTimeout(opts.WaitTimeout); err != nil {
+		return nil, err
+	}
+
+	gvr, err := id.lookupScopedGVR(ctx, uc.discovery)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.CheckNamespace {
+		if err := uc.checkNamespace(ctx, id.Cluster, id.Namespace); err != nil {
+			return nil, err
+		}
+	}
+
+	opts.FieldManager = uc.apply.fieldManager(ctx, opts.FieldManager)
+	obj, err := uc.resource.Apply(ctx, id.Cluster, gvr, id.Namespace, id.Name, manifest, opts)
+	if err != nil {
+		return nil, err
+	}
+	if !opts.SkipManagedBy {
+		obj, err = uc.tagAppliedManagedBy(ctx, id.Cluster, gvr, obj, opts.DryRun)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if opts.WaitTimeout == 0 {
+		return obj, nil
+	}
+	return uc.waitReady(ctx, id, obj, opts.WaitTimeout)
+}
+
+// ForceApplyResource is the explicit override step after
+// ApplyResource reported an *ErrApplyConflict. It first repeats the
+// apply as a dry run to find the conflicts still in effect, then
+// applies with force, and records an audit log entry naming the caller
+// and the field managers whose fields were taken over. The field
+// manager defaults as for ApplyResource.
+func (uc *ResourceUseCase) ForceApplyResource(
+	ctx context.Context,
+	id ResourceIdentifier,
+	manifest []byte,
+	opts ApplyOptions,
+) (*ForceApplyResult, error) {
+	if err := uc.apply.Manifest.checkObject(manifest); err != nil {
+		return nil, err
+	}
+
+	gvr, err := id.lookupScopedGVR(ctx, uc.discovery)
 	if err != nil {
 		return nil, err
 	}
 
-	return uc.resource.Apply(ctx, id.Cluster, gvr, id.Namespace, id.Name, manifest, opts)
+	if opts.CheckNamespace {
+		if err := uc.checkNamespace(ctx, id.Cluster, id.Namespace); err != nil {
+			return nil, err
+		}
+	}
+
+	opts.FieldManager = uc.apply.fieldManager(ctx, opts.FieldManager)
+	probe := opts
+	probe.Force = false
+	probe.DryRun = true
+	var overridden []ApplyConflict
+	_, err = uc.resource.Apply(ctx, id.Cluster, gvr, id.Namespace, id.Name, manifest, probe)
+	var conflict *ErrApplyConflict
+	switch {
+	case errors.As(err, &conflict):
+		overridden = conflict.Conflicts
+	case err != nil:
+		return nil, err
+	}
+
+	opts.Force = true
+	opts.DryRun = false
+	obj, err := uc.resource.Apply(ctx, id.Cluster, gvr, id.Namespace, id.Name, manifest, opts)
+	if err != nil {
+		return nil, err
+	}
+	if !opts.SkipManagedBy {
+		obj, err = uc.tagAppliedManagedBy(ctx, id.Cluster, gvr, obj, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(overridden) > 0 {
+		user, _ := UserInfoFromContext(ctx)
+		slog.Info("audit: forced apply took over fields from other managers",
+			"user", user.Subject,
+			"client_ip", clientIP(ctx),
+			"cluster", id.Cluster,
+			"group", id.Group,
+			"version", id.Version,
+			"resource", id.Resource,
+			"namespace", id.Namespace,
+			"name", id.Name,
+			"field_manager", opts.FieldManager,
+			"overridden_managers", conflictManagers(overridden),
+			"fields", len(overridden),
+		)
+	}
+	return &ForceApplyResult{Object: obj, Overridden: overridden}, nil
+}
+
+// checkNamespace returns an *ErrInvalidInput if namespace does not
+// exist on cluster. Cluster-scoped requests (empty namespace) are not
+// checked. If the caller may not read namespaces, the check is skipped
+// and the API server's own response for the mutation is returned
+// instead.
+func (uc *ResourceUseCase) checkNamespace(ctx context.Context, cluster, namespace string) error {
+	if namespace == "" {
+		return nil
+	}
+	exists, err := uc.namespaces.NamespaceExists(ctx, cluster, namespace)
+	if err != nil {
+		if code, ok := DomainErrorCode(err); ok && code == ErrorCodePermissionDenied {
+			return nil
+		}
+		return err
+	}
+	if !exists {
+		return &ErrInvalidInput{Message: fmt.Sprintf("namespace %q does not exist", namespace)}
+	}
+	return nil
 }
 
 // DeleteResource validates the GVR and deletes the named resource.
@@ -294,7 +794,7 @@ func (uc *ResourceUseCase) DeleteResource(
 	id ResourceIdentifier,
 	opts DeleteOptions,
 ) error {
-	gvr, err := id.lookupGVR(ctx, uc.discovery)
+	gvr, err := id.lookupScopedGVR(ctx, uc.discovery)
 	if err != nil {
 		return err
 	}
@@ -303,23 +803,62 @@ func (uc *ResourceUseCase) DeleteResource(
 }
 
 // WatchResource validates the GVR and opens a long-lived watch stream.
-// If the cluster supports the WatchList feature (Kubernetes >= 1.34),
-// initial events are streamed before switching to change notifications.
+// When the client does not resume from a specific resourceVersion
+// (unset or "0") and the cluster supports the WatchList feature
+// (Kubernetes >= 1.34), initial events are streamed before switching
+// to change notifications. A client that resumes from an explicit
+// resourceVersion only receives changes after that version. Like
+// ListResources, an omitted namespace means the default namespace
+// unless opts.AllNamespaces is set. With opts.ResyncInterval set, the
+// watch is replaced at that interval by a fresh snapshot and watch,
+// introduced by a BOOKMARK event with Resynced set.
 func (uc *ResourceUseCase) WatchResource(
 	ctx context.Context,
 	id ResourceIdentifier,
 	opts WatchOptions,
 ) (Watcher, error) {
+	if err := validateResourceVersion(opts.ResourceVersion); err != nil {
+		return nil, err
+	}
+
 	gvr, err := id.lookupGVR(ctx, uc.discovery)
 	if err != nil {
 		return nil, err
 	}
+	namespace, err := uc.resolveNamespace(ctx, id, gvr, opts.AllNamespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.SendInitialEvents = false
+	wantInitial := opts.InitialEvents == nil || *opts.InitialEvents
+	if wantInitial && (opts.ResourceVersion == "" || opts.ResourceVersion == "0") {
+		watchList, err := uc.discovery.SupportsWatchList(ctx, id.Cluster)
+		if err != nil {
+			return nil, err
+		}
+		opts.SendInitialEvents = watchList
+	}
 
-	watchList, err := uc.discovery.SupportsWatchList(ctx, id.Cluster)
+	if opts.ResyncInterval <= 0 {
+		return uc.resource.Watch(ctx, id.Cluster, gvr, namespace, opts)
+	}
+	inner, err := uc.resource.Watch(ctx, id.Cluster, gvr, namespace, opts)
 	if err != nil {
 		return nil, err
 	}
+	relist := func(ctx context.Context) ([]WatchEvent, Watcher, error) {
+		return uc.snapshotWatch(ctx, id.Cluster, gvr, namespace, opts)
+	}
+	return newResumingWatcher(ctx, inner, relist, opts.ResyncInterval), nil
+}
 
-	opts.SendInitialEvents = watchList
-	return uc.resource.Watch(ctx, id.Cluster, gvr, id.Namespace, opts)
+// validateResourceVersion rejects resourceVersions that cannot have
+// come from the API server. The value is otherwise treated as opaque,
+// as required by the Kubernetes API conventions.
+func validateResourceVersion(rv string) error {
+	if strings.ContainsFunc(rv, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) {
+		return &ErrInvalidInput{Field: "resource_version", Message: fmt.Sprintf("malformed resource version %q", rv)}
+	}
+	return nil
 }
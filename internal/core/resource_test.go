@@ -0,0 +1,535 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// mockWatchDiscovery implements the DiscoveryClient methods used by
+// WatchResource.
+type mockWatchDiscovery struct {
+	DiscoveryClient
+	watchList bool
+}
+
+func (m *mockWatchDiscovery) LookupResource(_ context.Context, _, group, version, resource string) (schema.GroupVersionResource, error) {
+	return schema.GroupVersionResource{Group: group, Version: version, Resource: resource}, nil
+}
+
+func (m *mockWatchDiscovery) SupportsWatchList(_ context.Context, _ string) (bool, error) {
+	return m.watchList, nil
+}
+
+func (m *mockWatchDiscovery) IsNamespaced(_ context.Context, _ string, gvr schema.GroupVersionResource) (bool, error) {
+	return gvr.Resource != "nodes", nil
+}
+
+// mockWatchRepo records the options passed to Watch.
+type mockWatchRepo struct {
+	ResourceRepo
+	called bool
+	opts   WatchOptions
+}
+
+func (m *mockWatchRepo) Watch(_ context.Context, _ string, _ schema.GroupVersionResource, _ string, opts WatchOptions) (Watcher, error) {
+	m.called = true
+	m.opts = opts
+	return nil, nil
+}
+
+func TestResourceUseCase_WatchResource_InitialEvents(t *testing.T) {
+	optIn, optOut := true, false
+	tests := []struct {
+		name            string
+		watchList       bool
+		resourceVersion string
+		preference      *bool
+		wantInitial     bool
+	}{
+		{"unset RV on WatchList cluster", true, "", nil, true},
+		{"RV 0 on WatchList cluster", true, "0", nil, true},
+		{"explicit RV resumes without initial events", true, "12345", nil, false},
+		{"unset RV on legacy cluster", false, "", nil, false},
+		{"explicit RV on legacy cluster", false, "12345", nil, false},
+		{"client opts out on WatchList cluster", true, "", &optOut, false},
+		{"client opts in on WatchList cluster", true, "", &optIn, true},
+		{"client opts in on legacy cluster", false, "", &optIn, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockWatchRepo{}
+			uc := NewResourceUseCase(&mockWatchDiscovery{watchList: tt.watchList}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+
+			_, err := uc.WatchResource(context.Background(),
+				ResourceIdentifier{Cluster: "edge-1", Version: "v1", Resource: "pods"},
+				WatchOptions{ResourceVersion: tt.resourceVersion, SendInitialEvents: !tt.wantInitial, InitialEvents: tt.preference},
+			)
+			if err != nil {
+				t.Fatalf("WatchResource: %v", err)
+			}
+			if repo.opts.SendInitialEvents != tt.wantInitial {
+				t.Errorf("SendInitialEvents = %v, want %v", repo.opts.SendInitialEvents, tt.wantInitial)
+			}
+			if repo.opts.ResourceVersion != tt.resourceVersion {
+				t.Errorf("ResourceVersion = %q, want %q", repo.opts.ResourceVersion, tt.resourceVersion)
+			}
+		})
+	}
+}
+
+func TestResourceUseCase_WatchResource_MalformedResourceVersion(t *testing.T) {
+	repo := &mockWatchRepo{}
+	uc := NewResourceUseCase(&mockWatchDiscovery{watchList: true}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+
+	_, err := uc.WatchResource(context.Background(),
+		ResourceIdentifier{Cluster: "edge-1", Version: "v1", Resource: "pods"},
+		WatchOptions{ResourceVersion: "123 45"},
+	)
+	var invalid *ErrInvalidInput
+	if !isErrInvalidInput(err, &invalid) || invalid.Field != "resource_version" {
+		t.Fatalf("expected ErrInvalidInput on resource_version, got %v", err)
+	}
+	if repo.called {
+		t.Error("malformed request must not reach the repo")
+	}
+}
+
+// mockScopeRepo records the namespace of every List and Watch.
+type mockScopeRepo struct {
+	ResourceRepo
+	namespaces []string
+}
+
+func (m *mockScopeRepo) List(_ context.Context, _ string, _ schema.GroupVersionResource, namespace string, _ ListOptions) (*unstructured.UnstructuredList, error) {
+	m.namespaces = append(m.namespaces, namespace)
+	return &unstructured.UnstructuredList{}, nil
+}
+
+func (m *mockScopeRepo) Watch(_ context.Context, _ string, _ schema.GroupVersionResource, namespace string, _ WatchOptions) (Watcher, error) {
+	m.namespaces = append(m.namespaces, namespace)
+	return nil, nil
+}
+
+func TestResourceUseCase_DefaultNamespace(t *testing.T) {
+	tests := []struct {
+		name          string
+		resource      string
+		namespace     string
+		allNamespaces bool
+		want          string
+	}{
+		{"omitted namespace uses default", "pods", "", false, "team-a"},
+		{"explicit namespace is kept", "pods", "apps", false, "apps"},
+		{"all namespaces", "pods", "", true, ""},
+		{"cluster-scoped resource ignores default", "nodes", "", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockScopeRepo{}
+			uc := NewResourceUseCase(&mockWatchDiscovery{}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{Default: "team-a"})
+			id := ResourceIdentifier{Cluster: "edge-1", Version: "v1", Resource: tt.resource, Namespace: tt.namespace}
+
+			if _, err := uc.ListResources(context.Background(), id, ListOptions{AllNamespaces: tt.allNamespaces}); err != nil {
+				t.Fatalf("ListResources: %v", err)
+			}
+			if _, err := uc.WatchResource(context.Background(), id, WatchOptions{ResourceVersion: "1", AllNamespaces: tt.allNamespaces}); err != nil {
+				t.Fatalf("WatchResource: %v", err)
+			}
+			if len(repo.namespaces) != 2 || repo.namespaces[0] != tt.want || repo.namespaces[1] != tt.want {
+				t.Errorf("namespaces = %q, want %q for both list and watch", repo.namespaces, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceUseCase_AllNamespacesWithNamespace(t *testing.T) {
+	repo := &mockScopeRepo{}
+	uc := NewResourceUseCase(&mockWatchDiscovery{}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{Default: "team-a"})
+
+	_, err := uc.ListResources(context.Background(),
+		ResourceIdentifier{Cluster: "edge-1", Version: "v1", Resource: "pods", Namespace: "apps"},
+		ListOptions{AllNamespaces: true},
+	)
+	var invalid *ErrInvalidInput
+	if !isErrInvalidInput(err, &invalid) || invalid.Field != "all_namespaces" {
+		t.Fatalf("expected ErrInvalidInput on all_namespaces, got %v", err)
+	}
+	if len(repo.namespaces) != 0 {
+		t.Error("conflicting request must not reach the repo")
+	}
+}
+
+// mockNamespaceChecker reports a fixed set of existing namespaces.
+type mockNamespaceChecker struct {
+	existing map[string]bool
+	err      error
+	calls    int
+}
+
+func (m *mockNamespaceChecker) NamespaceExists(_ context.Context, _, namespace string) (bool, error) {
+	m.calls++
+	return m.existing[namespace], m.err
+}
+
+// mockMutationRepo records whether Create or Apply reached the repo.
+type mockMutationRepo struct {
+	ResourceRepo
+	called bool
+}
+
+func (m *mockMutationRepo) Create(_ context.Context, _ string, _ schema.GroupVersionResource, _ string, _ []byte) (*unstructured.Unstructured, error) {
+	m.called = true
+	return &unstructured.Unstructured{}, nil
+}
+
+func (m *mockMutationRepo) Apply(_ context.Context, _ string, _ schema.GroupVersionResource, _, _ string, _ []byte, _ ApplyOptions) (*unstructured.Unstructured, error) {
+	m.called = true
+	return &unstructured.Unstructured{}, nil
+}
+
+// mockConflictRepo rejects unforced applies with a conflict on a
+// field owned by kubectl-edit, recording the options of every apply.
+type mockConflictRepo struct {
+	ResourceRepo
+	applies []ApplyOptions
+}
+
+func (m *mockConflictRepo) Apply(_ context.Context, _ string, _ schema.GroupVersionResource, _, name string, _ []byte, opts ApplyOptions) (*unstructured.Unstructured, error) {
+	m.applies = append(m.applies, opts)
+	if !opts.Force {
+		return nil, &ErrApplyConflict{Conflicts: []ApplyConflict{
+			{Manager: "kubectl-edit", Field: ".spec.replicas", Message: `conflict with "kubectl-edit" using apps/v1`},
+		}}
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetName(name)
+	return obj, nil
+}
+
+func TestResourceUseCase_ApplyResource_DefaultsFieldManagerToSubject(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested string
+		want      string
+	}{
+		{"derived from subject", "", "otterscale:alice@example.com"},
+		{"explicit kept", "otterscale-web-ui", "otterscale-web-ui"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockConflictRepo{}
+			uc := NewResourceUseCase(&mockWatchDiscovery{}, repo, nil, nil, nil, ApplyConfig{FieldManagerPrefix: "otterscale:"}, NamespaceConfig{})
+			ctx := WithUserInfo(context.Background(), UserInfo{Subject: "alice@example.com"})
+			id := ResourceIdentifier{Cluster: "c1", Group: "apps", Version: "v1", Resource: "deployments", Namespace: "default", Name: "web"}
+
+			_, _ = uc.ApplyResource(ctx, id, nil, ApplyOptions{FieldManager: tt.requested})
+			if _, err := uc.ForceApplyResource(ctx, id, nil, ApplyOptions{FieldManager: tt.requested}); err != nil {
+				t.Fatalf("ForceApplyResource: %v", err)
+			}
+
+			// One plain apply, then the dry-run probe and the forced apply.
+			if len(repo.applies) != 3 {
+				t.Fatalf("got %d applies, want 3", len(repo.applies))
+			}
+			for i, opts := range repo.applies {
+				if opts.FieldManager != tt.want {
+					t.Errorf("apply %d: field manager = %q, want %q", i, opts.FieldManager, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestResourceUseCase_ForceApplyResource_OverridesConflict(t *testing.T) {
+	var logs bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+
+	repo := &mockConflictRepo{}
+	uc := NewResourceUseCase(&mockWatchDiscovery{}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+	ctx := WithUserInfo(context.Background(), UserInfo{Subject: "alice"})
+	id := ResourceIdentifier{Cluster: "c1", Group: "apps", Version: "v1", Resource: "deployments", Namespace: "default", Name: "web"}
+	opts := ApplyOptions{FieldManager: "otterscale-web-ui"}
+
+	// Step one: the plain apply reports who owns the contested field.
+	_, err := uc.ApplyResource(ctx, id, nil, opts)
+	var conflict *ErrApplyConflict
+	if !errors.As(err, &conflict) || conflict.Managers()[0] != "kubectl-edit" {
+		t.Fatalf("ApplyResource error = %v, want a conflict with kubectl-edit", err)
+	}
+
+	// Step two: the user chooses to override.
+	result, err := uc.ForceApplyResource(ctx, id, nil, opts)
+	if err != nil {
+		t.Fatalf("ForceApplyResource: %v", err)
+	}
+	if result.Object.GetName() != "web" {
+		t.Errorf("object = %v, want the applied deployment", result.Object)
+	}
+	if len(result.Overridden) != 1 || result.Overridden[0].Manager != "kubectl-edit" || result.Overridden[0].Field != ".spec.replicas" {
+		t.Errorf("overridden = %+v, want .spec.replicas of kubectl-edit", result.Overridden)
+	}
+
+	probe, forced := repo.applies[1], repo.applies[2]
+	if !probe.DryRun || probe.Force {
+		t.Errorf("probe options = %+v, want an unforced dry run", probe)
+	}
+	if forced.DryRun || !forced.Force || forced.FieldManager != "otterscale-web-ui" {
+		t.Errorf("forced options = %+v, want a forced apply as otterscale-web-ui", forced)
+	}
+
+	var audit map[string]any
+	if err := json.Unmarshal(logs.Bytes(), &audit); err != nil {
+		t.Fatalf("decode audit record %q: %v", logs.String(), err)
+	}
+	if audit["user"] != "alice" || fmt.Sprint(audit["overridden_managers"]) != "[kubectl-edit]" {
+		t.Errorf("audit record = %v, want alice overriding kubectl-edit", audit)
+	}
+}
+
+func TestResourceUseCase_CreateResource_MissingNamespace(t *testing.T) {
+	repo := &mockMutationRepo{}
+	namespaces := &mockNamespaceChecker{existing: map[string]bool{"default": true}}
+	uc := NewResourceUseCase(&mockWatchDiscovery{}, repo, nil, nil, namespaces, ApplyConfig{}, NamespaceConfig{})
+	id := ResourceIdentifier{Cluster: "c1", Version: "v1", Resource: "pods", Namespace: "missing"}
+
+	_, err := uc.CreateResource(context.Background(), id, nil, CreateOptions{CheckNamespace: true})
+	var invalidInput *ErrInvalidInput
+	if !isErrInvalidInput(err, &invalidInput) {
+		t.Fatalf("expected ErrInvalidInput, got %v", err)
+	}
+	if want := `namespace "missing" does not exist`; err.Error() != want {
+		t.Errorf("error = %q, want %q", err.Error(), want)
+	}
+	if repo.called {
+		t.Error("Create must not be attempted when the namespace is missing")
+	}
+}
+
+func TestResourceUseCase_ChecksNamespaceScope(t *testing.T) {
+	pods := ResourceIdentifier{Cluster: "c1", Version: "v1", Resource: "pods", Name: "web-0"}
+	nodes := ResourceIdentifier{Cluster: "c1", Version: "v1", Resource: "nodes", Namespace: "default", Name: "node-1"}
+	tests := []struct {
+		name    string
+		call    func(*ResourceUseCase, *RuntimeUseCase) error
+		wantMsg string
+	}{
+		{"create namespaced without namespace", func(uc *ResourceUseCase, _ *RuntimeUseCase) error {
+			_, err := uc.CreateResource(context.Background(), pods, nil, CreateOptions{})
+			return err
+		}, "resource pods is namespaced; namespace is required"},
+		{"apply cluster-scoped with namespace", func(uc *ResourceUseCase, _ *RuntimeUseCase) error {
+			_, err := uc.ApplyResource(context.Background(), nodes, nil, ApplyOptions{})
+			return err
+		}, "resource nodes is cluster-scoped; namespace must be empty"},
+		{"list cluster-scoped with namespace", func(uc *ResourceUseCase, _ *RuntimeUseCase) error {
+			_, err := uc.ListResources(context.Background(), nodes, ListOptions{})
+			return err
+		}, "resource nodes is cluster-scoped; namespace must be empty"},
+		{"scale namespaced without namespace", func(_ *ResourceUseCase, runtime *RuntimeUseCase) error {
+			id := ResourceIdentifier{Cluster: "c1", Group: "apps", Version: "v1", Resource: "deployments", Name: "web"}
+			_, err := runtime.Scale(context.Background(), id, 3)
+			return err
+		}, "resource deployments.apps is namespaced; namespace is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockMutationRepo{}
+			uc := NewResourceUseCase(&mockWatchDiscovery{}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+			runtime := NewRuntimeUseCase(&mockWatchDiscovery{}, nil, NewSessionStore(NewRealClock(), nil), uc)
+
+			err := tt.call(uc, runtime)
+			var invalid *ErrInvalidInput
+			if !isErrInvalidInput(err, &invalid) || invalid.Field != "namespace" || invalid.Message != tt.wantMsg {
+				t.Fatalf("err = %v, want invalid namespace: %s", err, tt.wantMsg)
+			}
+			if repo.called {
+				t.Error("repo called despite the scope mismatch")
+			}
+		})
+	}
+}
+
+func TestResourceUseCase_NamespaceCheck(t *testing.T) {
+	forbidden := &DomainError{Code: ErrorCodePermissionDenied, Message: "forbidden"}
+	tests := []struct {
+		name       string
+		namespace  string
+		check      bool
+		err        error
+		wantErr    bool
+		wantChecks int
+	}{
+		{"existing namespace", "default", true, nil, false, 1},
+		{"missing namespace", "missing", true, nil, true, 1},
+		{"check disabled", "missing", false, nil, false, 0},
+		{"cluster-scoped", "", true, nil, false, 0},
+		{"namespaces not readable", "missing", true, forbidden, false, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockMutationRepo{}
+			namespaces := &mockNamespaceChecker{existing: map[string]bool{"default": true}, err: tt.err}
+			uc := NewResourceUseCase(&mockWatchDiscovery{}, repo, nil, nil, namespaces, ApplyConfig{}, NamespaceConfig{})
+			id := ResourceIdentifier{Cluster: "c1", Group: "apps", Version: "v1", Resource: "deployments", Namespace: tt.namespace, Name: "web"}
+			if tt.namespace == "" {
+				id = ResourceIdentifier{Cluster: "c1", Version: "v1", Resource: "nodes", Name: "node-1"}
+			}
+
+			_, err := uc.ApplyResource(context.Background(), id, nil, ApplyOptions{CheckNamespace: tt.check})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyResource error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if repo.called == tt.wantErr {
+				t.Errorf("repo called = %v, want %v", repo.called, !tt.wantErr)
+			}
+			if namespaces.calls != tt.wantChecks {
+				t.Errorf("namespace checks = %d, want %d", namespaces.calls, tt.wantChecks)
+			}
+		})
+	}
+}
+
+// mockDescribeRepo serves a fixed object and its events.
+type mockDescribeRepo struct {
+	ResourceRepo
+	events []unstructured.Unstructured
+}
+
+func (m *mockDescribeRepo) Get(_ context.Context, _ string, _ schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{Object: map[string]any{"apiVersion": "v1", "kind": "Pod"}}
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetUID("u1")
+	return obj, nil
+}
+
+func (m *mockDescribeRepo) ListEvents(_ context.Context, _, _ string, _ ListOptions) (*unstructured.UnstructuredList, error) {
+	return &unstructured.UnstructuredList{Items: m.events}, nil
+}
+
+// mockNodeDescribeRepo serves a Node and records how its events are
+// listed.
+type mockNodeDescribeRepo struct {
+	ResourceRepo
+	events    []unstructured.Unstructured
+	namespace string
+	selector  string
+}
+
+func (m *mockNodeDescribeRepo) Get(_ context.Context, _ string, _ schema.GroupVersionResource, _, name string) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{Object: map[string]any{"apiVersion": "v1", "kind": "Node"}}
+	obj.SetName(name)
+	obj.SetUID("node-uid")
+	return obj, nil
+}
+
+func (m *mockNodeDescribeRepo) ListEvents(_ context.Context, _, namespace string, opts ListOptions) (*unstructured.UnstructuredList, error) {
+	m.namespace, m.selector = namespace, opts.FieldSelector
+	return &unstructured.UnstructuredList{Items: m.events}, nil
+}
+
+func testEvent(name string, fields map[string]any) unstructured.Unstructured {
+	obj := map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Event",
+		"metadata":   map[string]any{"name": name, "creationTimestamp": "2026-01-01T00:00:00Z"},
+	}
+	for k, v := range fields {
+		obj[k] = v
+	}
+	return unstructured.Unstructured{Object: obj}
+}
+
+func TestResourceUseCase_DescribeResource_ClusterScoped(t *testing.T) {
+	involved := func(uid string) map[string]any {
+		return map[string]any{"involvedObject": map[string]any{"kind": "Node", "name": "worker-1", "uid": uid}}
+	}
+	repo := &mockNodeDescribeRepo{events: []unstructured.Unstructured{
+		// The kubelet records Node events with the node's name as UID.
+		testEvent("kubelet-starting", involved("worker-1")),
+		testEvent("node-controller", involved("node-uid")),
+		// A previous Node of the same name.
+		testEvent("replaced-node", involved("old-uid")),
+	}}
+	uc := NewResourceUseCase(&mockWatchDiscovery{}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+	id := ResourceIdentifier{Cluster: "c1", Version: "v1", Resource: "nodes", Name: "worker-1"}
+
+	_, events, err := uc.DescribeResource(context.Background(), id, DescribeOptions{})
+	if err != nil {
+		t.Fatalf("DescribeResource: %v", err)
+	}
+	if repo.namespace != "" {
+		t.Errorf("events listed in namespace %q, want all namespaces", repo.namespace)
+	}
+	for _, term := range []string{"involvedObject.kind=Node", "involvedObject.name=worker-1", "involvedObject.namespace="} {
+		if !strings.Contains(repo.selector, term) {
+			t.Errorf("field selector %q does not contain %q", repo.selector, term)
+		}
+	}
+	var got []string
+	for _, event := range events.Items {
+		got = append(got, event.GetName())
+	}
+	if want := []string{"kubelet-starting", "node-controller"}; !slices.Equal(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+}
+
+func TestResourceUseCase_DescribeResource_Since(t *testing.T) {
+	repo := &mockDescribeRepo{events: []unstructured.Unstructured{
+		testEvent("old", map[string]any{"lastTimestamp": "2026-01-01T10:00:00Z"}),
+		testEvent("same-second", map[string]any{"lastTimestamp": "2026-01-01T10:05:00Z"}),
+		testEvent("new", map[string]any{"lastTimestamp": "2026-01-01T10:06:00Z"}),
+		testEvent("new-event-time", map[string]any{"eventTime": "2026-01-01T10:07:00.123456Z"}),
+		testEvent("old-series-recurred", map[string]any{
+			"lastTimestamp": "2026-01-01T09:00:00Z",
+			"series":        map[string]any{"count": int64(3), "lastObservedTime": "2026-01-01T10:08:00.000000Z"},
+		}),
+		testEvent("old-created-only", nil),
+	}}
+	uc := NewResourceUseCase(&mockWatchDiscovery{}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+	id := ResourceIdentifier{Cluster: "c1", Version: "v1", Resource: "pods", Namespace: "default", Name: "web-0"}
+
+	_, all, err := uc.DescribeResource(context.Background(), id, DescribeOptions{})
+	if err != nil {
+		t.Fatalf("DescribeResource: %v", err)
+	}
+	if len(all.Items) != len(repo.events) {
+		t.Errorf("without since: %d events, want %d", len(all.Items), len(repo.events))
+	}
+
+	since := time.Date(2026, 1, 1, 10, 5, 0, 0, time.UTC)
+	_, events, err := uc.DescribeResource(context.Background(), id, DescribeOptions{Since: since})
+	if err != nil {
+		t.Fatalf("DescribeResource: %v", err)
+	}
+	var got []string
+	for _, event := range events.Items {
+		got = append(got, event.GetName())
+	}
+	want := []string{"same-second", "new", "new-event-time", "old-series-recurred"}
+	if len(got) != len(want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("events = %v, want %v", got, want)
+			break
+		}
+	}
+}
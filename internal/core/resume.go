@@ -0,0 +1,319 @@
+package core
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// relistPageSize is the page size used to list a snapshot when a
+// resumed watch relists on a cluster without WatchList support.
+const relistPageSize = 500
+
+// ResumeToken records a position in a watch stream so that a client,
+// typically a UI reconnecting after its tab slept, can resume where it
+// left off. Clients treat the encoded token as opaque.
+type ResumeToken struct {
+	Cluster         string `json:"c"`
+	Group           string `json:"g,omitempty"`
+	Version         string `json:"v"`
+	Resource        string `json:"r"`
+	Namespace       string `json:"n,omitempty"`
+	ResourceVersion string `json:"rv"`
+}
+
+// NewResumeToken returns the token resuming a watch of id after
+// resourceVersion.
+func NewResumeToken(id ResourceIdentifier, resourceVersion string) ResumeToken {
+	return ResumeToken{
+		Cluster:         id.Cluster,
+		Group:           id.Group,
+		Version:         id.Version,
+		Resource:        id.Resource,
+		Namespace:       id.Namespace,
+		ResourceVersion: resourceVersion,
+	}
+}
+
+// Encode returns the opaque, URL-safe form of the token.
+func (t ResumeToken) Encode() string {
+	data, _ := json.Marshal(t) // a struct of strings always marshals
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// ParseResumeToken decodes a token produced by ResumeToken.Encode.
+func ParseResumeToken(s string) (ResumeToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return ResumeToken{}, &ErrInvalidInput{Field: "resume_token", Message: "malformed token"}
+	}
+	var t ResumeToken
+	if err := json.Unmarshal(data, &t); err != nil || t.ResourceVersion == "" {
+		return ResumeToken{}, &ErrInvalidInput{Field: "resume_token", Message: "malformed token"}
+	}
+	return t, nil
+}
+
+// matches reports whether the token was issued for a watch of id.
+func (t ResumeToken) matches(id ResourceIdentifier) bool {
+	return t.Cluster == id.Cluster &&
+		t.Group == id.Group &&
+		t.Version == id.Version &&
+		t.Resource == id.Resource &&
+		t.Namespace == id.Namespace
+}
+
+// ResumeWatchResource resumes a watch of id from a token returned on an
+// earlier stream. opts.ResourceVersion must be unset; the token
+// supplies it.
+//
+// If the API server no longer retains the token's resourceVersion
+// (HTTP 410 Gone), whether when the watch is opened or later while it
+// runs, the watcher relists instead of failing: it emits a BOOKMARK
+// event with Relisted set, a fresh snapshot of ADDED events, and a
+// BOOKMARK carrying the snapshot's resourceVersion, then continues
+// with change notifications. opts.ResyncInterval applies as for
+// WatchResource.
+func (uc *ResourceUseCase) ResumeWatchResource(
+	ctx context.Context,
+	id ResourceIdentifier,
+	token string,
+	opts WatchOptions,
+) (Watcher, error) {
+	if opts.ResourceVersion != "" {
+		return nil, &ErrInvalidInput{Field: "resource_version", Message: "cannot be combined with resume_token"}
+	}
+	t, err := ParseResumeToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if !t.matches(id) {
+		return nil, &ErrInvalidInput{Field: "resume_token", Message: "token was issued for a different watch"}
+	}
+	if err := validateResourceVersion(t.ResourceVersion); err != nil {
+		return nil, err
+	}
+
+	gvr, err := id.lookupGVR(ctx, uc.discovery)
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := uc.resolveNamespace(ctx, id, gvr, opts.AllNamespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.ResourceVersion = t.ResourceVersion
+	opts.SendInitialEvents = false
+	inner, err := uc.resource.Watch(ctx, id.Cluster, gvr, namespace, opts)
+	var expired *ErrResourceVersionExpired
+	if err != nil && !errors.As(err, &expired) {
+		return nil, err
+	}
+
+	relist := func(ctx context.Context) ([]WatchEvent, Watcher, error) {
+		return uc.snapshotWatch(ctx, id.Cluster, gvr, namespace, opts)
+	}
+	return newResumingWatcher(ctx, inner, relist, opts.ResyncInterval), nil
+}
+
+// snapshotWatch opens a watch that starts with the current state of
+// the matching resources. On clusters supporting WatchList the API
+// server streams the snapshot itself; otherwise the resources are
+// listed and returned as ADDED events, followed by a BOOKMARK at the
+// list's resourceVersion from which the watch continues.
+func (uc *ResourceUseCase) snapshotWatch(
+	ctx context.Context,
+	cluster string,
+	gvr schema.GroupVersionResource,
+	namespace string,
+	opts WatchOptions,
+) ([]WatchEvent, Watcher, error) {
+	watchList, err := uc.discovery.SupportsWatchList(ctx, cluster)
+	if err != nil {
+		return nil, nil, err
+	}
+	if watchList {
+		opts.ResourceVersion = ""
+		opts.SendInitialEvents = true
+		w, err := uc.resource.Watch(ctx, cluster, gvr, namespace, opts)
+		return nil, w, err
+	}
+
+	var (
+		snapshot        []WatchEvent
+		resourceVersion string
+		cont            string
+	)
+	for {
+		list, err := uc.resource.List(ctx, cluster, gvr, namespace, ListOptions{
+			LabelSelector: opts.LabelSelector,
+			FieldSelector: opts.FieldSelector,
+			Limit:         relistPageSize,
+			Continue:      cont,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		// Every page of a paginated list is served from the
+		// snapshot of the first.
+		if resourceVersion == "" {
+			resourceVersion = list.GetResourceVersion()
+		}
+		for i := range list.Items {
+			snapshot = append(snapshot, WatchEvent{Type: WatchEventAdded, Object: list.Items[i].Object})
+		}
+		if cont = list.GetContinue(); cont == "" {
+			break
+		}
+	}
+	snapshot = append(snapshot, WatchEvent{
+		Type:             WatchEventBookmark,
+		Object:           map[string]any{"metadata": map[string]any{"resourceVersion": resourceVersion}},
+		InitialEventsEnd: true,
+	})
+
+	opts.ResourceVersion = resourceVersion
+	opts.SendInitialEvents = false
+	w, err := uc.resource.Watch(ctx, cluster, gvr, namespace, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return snapshot, w, nil
+}
+
+// relistFunc opens a fresh watch, returning the snapshot events to
+// send before those of the watch.
+type relistFunc func(ctx context.Context) ([]WatchEvent, Watcher, error)
+
+// relayEnd tells why resumingWatcher.relay returned.
+type relayEnd int
+
+const (
+	// relayDone means the watch ended or was stopped.
+	relayDone relayEnd = iota
+	// relayExpired means the inner watch's resourceVersion expired.
+	relayExpired
+	// relayResync means the resync interval elapsed.
+	relayResync
+)
+
+// resumingWatcher relays an inner watch and replaces it with a fresh
+// snapshot and watch whenever its resourceVersion expires and, if
+// resync is positive, every resync.
+type resumingWatcher struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	relist   relistFunc
+	resync   time.Duration
+	ch       chan WatchEvent
+	stopOnce sync.Once
+}
+
+// newResumingWatcher relays inner, or relists right away if inner is
+// nil because its resourceVersion had already expired.
+func newResumingWatcher(ctx context.Context, inner Watcher, relist relistFunc, resync time.Duration) *resumingWatcher {
+	ctx, cancel := context.WithCancel(ctx)
+	w := &resumingWatcher{
+		ctx:    ctx,
+		cancel: cancel,
+		relist: relist,
+		resync: resync,
+		ch:     make(chan WatchEvent),
+	}
+	go w.run(inner)
+	return w
+}
+
+func (w *resumingWatcher) ResultChan() <-chan WatchEvent {
+	return w.ch
+}
+
+func (w *resumingWatcher) Stop() {
+	w.stopOnce.Do(w.cancel)
+}
+
+func (w *resumingWatcher) run(inner Watcher) {
+	defer close(w.ch)
+
+	var (
+		snapshot []WatchEvent
+		marker   = WatchEvent{Type: WatchEventBookmark, Relisted: true}
+	)
+	for {
+		if inner == nil {
+			if !w.send(marker) {
+				return
+			}
+			var err error
+			snapshot, inner, err = w.relist(w.ctx)
+			if err != nil {
+				slog.Warn("watch: relist failed", "resync", marker.Resynced, "error", err)
+				return
+			}
+		}
+
+		end := w.relay(snapshot, inner)
+		inner.Stop()
+		switch end {
+		case relayExpired:
+			marker = WatchEvent{Type: WatchEventBookmark, Relisted: true}
+		case relayResync:
+			marker = WatchEvent{Type: WatchEventBookmark, Resynced: true}
+		default:
+			return
+		}
+		inner, snapshot = nil, nil
+	}
+}
+
+// relay sends snapshot and then the events of inner until inner ends,
+// the watcher is stopped, inner reports an expired resourceVersion, or
+// the resync interval elapses.
+func (w *resumingWatcher) relay(snapshot []WatchEvent, inner Watcher) relayEnd {
+	var resync <-chan time.Time
+	if w.resync > 0 {
+		timer := time.NewTimer(w.resync)
+		defer timer.Stop()
+		resync = timer.C
+	}
+
+	for _, event := range snapshot {
+		if !w.send(event) {
+			return relayDone
+		}
+	}
+	for {
+		select {
+		case <-w.ctx.Done():
+			return relayDone
+		case <-resync:
+			return relayResync
+		case event, ok := <-inner.ResultChan():
+			if !ok {
+				return relayDone
+			}
+			if event.Type == WatchEventError && event.Expired {
+				return relayExpired
+			}
+			if !w.send(event) {
+				return relayDone
+			}
+		}
+	}
+}
+
+func (w *resumingWatcher) send(event WatchEvent) bool {
+	select {
+	case w.ch <- event:
+		return true
+	case <-w.ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,246 @@
+package core
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// chanWatcher is a Watcher fed by the test.
+type chanWatcher struct {
+	ch      chan WatchEvent
+	stopped chan struct{}
+}
+
+func newChanWatcher() *chanWatcher {
+	return &chanWatcher{ch: make(chan WatchEvent, 8), stopped: make(chan struct{})}
+}
+
+func (w *chanWatcher) ResultChan() <-chan WatchEvent { return w.ch }
+func (w *chanWatcher) Stop()                         { close(w.stopped) }
+
+// mockResumeRepo serves Watch from a queue of results and List from a
+// fixed set of pods, recording the resourceVersion of each watch.
+type mockResumeRepo struct {
+	ResourceRepo
+	watches  []*chanWatcher
+	expired  map[string]bool // resourceVersions that are too old
+	watchRVs []string
+	pods     []string
+}
+
+func (m *mockResumeRepo) Watch(_ context.Context, _ string, _ schema.GroupVersionResource, _ string, opts WatchOptions) (Watcher, error) {
+	m.watchRVs = append(m.watchRVs, opts.ResourceVersion)
+	if m.expired[opts.ResourceVersion] {
+		return nil, &ErrResourceVersionExpired{ResourceVersion: opts.ResourceVersion}
+	}
+	w := m.watches[0]
+	m.watches = m.watches[1:]
+	return w, nil
+}
+
+func (m *mockResumeRepo) List(_ context.Context, _ string, _ schema.GroupVersionResource, _ string, _ ListOptions) (*unstructured.UnstructuredList, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetResourceVersion("500")
+	for _, name := range m.pods {
+		list.Items = append(list.Items, testPod(name, "400"))
+	}
+	return list, nil
+}
+
+func testPod(name, rv string) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]any{"name": name, "namespace": "default", "resourceVersion": rv},
+	}}
+}
+
+// recv returns the next event of w, failing the test if none arrives.
+func recv(t *testing.T, w Watcher) WatchEvent {
+	t.Helper()
+	select {
+	case event, ok := <-w.ResultChan():
+		if !ok {
+			t.Fatal("watch closed")
+		}
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for watch event")
+	}
+	return WatchEvent{}
+}
+
+func podName(event WatchEvent) string {
+	return event.Object["metadata"].(map[string]any)["name"].(string)
+}
+
+var resumeID = ResourceIdentifier{Cluster: "edge-1", Version: "v1", Resource: "pods", Namespace: "default"}
+
+func TestResumeToken_RoundTrip(t *testing.T) {
+	token := NewResumeToken(resumeID, "12345")
+	got, err := ParseResumeToken(token.Encode())
+	if err != nil {
+		t.Fatalf("ParseResumeToken: %v", err)
+	}
+	if !reflect.DeepEqual(got, token) {
+		t.Errorf("token = %+v, want %+v", got, token)
+	}
+
+	for _, s := range []string{"", "not base64!", "e30"} { // e30 = "{}"
+		var invalid *ErrInvalidInput
+		if _, err := ParseResumeToken(s); !isErrInvalidInput(err, &invalid) {
+			t.Errorf("ParseResumeToken(%q) = %v, want ErrInvalidInput", s, err)
+		}
+	}
+}
+
+func TestResourceUseCase_ResumeWatchResource_Valid(t *testing.T) {
+	live := newChanWatcher()
+	repo := &mockResumeRepo{watches: []*chanWatcher{live}}
+	uc := NewResourceUseCase(&mockWatchDiscovery{}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+
+	w, err := uc.ResumeWatchResource(context.Background(), resumeID, NewResumeToken(resumeID, "300").Encode(), WatchOptions{})
+	if err != nil {
+		t.Fatalf("ResumeWatchResource: %v", err)
+	}
+	defer w.Stop()
+
+	live.ch <- WatchEvent{Type: WatchEventModified, Object: testPod("web-0", "301").Object}
+	if event := recv(t, w); event.Type != WatchEventModified || podName(event) != "web-0" {
+		t.Errorf("event = %+v, want MODIFIED web-0", event)
+	}
+	if !reflect.DeepEqual(repo.watchRVs, []string{"300"}) {
+		t.Errorf("watched from %v, want [300]", repo.watchRVs)
+	}
+}
+
+func TestResourceUseCase_ResumeWatchResource_ExpiredRelists(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiredAt string // "open" or "stream"
+	}{
+		{"expired when opened", "open"},
+		{"expired while streaming", "stream"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, relisted := newChanWatcher(), newChanWatcher()
+			repo := &mockResumeRepo{pods: []string{"web-0", "web-1"}}
+			if tt.expiredAt == "open" {
+				repo.expired = map[string]bool{"300": true}
+				repo.watches = []*chanWatcher{relisted}
+			} else {
+				repo.watches = []*chanWatcher{first, relisted}
+				first.ch <- WatchEvent{Type: WatchEventError, Expired: true}
+			}
+			uc := NewResourceUseCase(&mockWatchDiscovery{}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+
+			w, err := uc.ResumeWatchResource(context.Background(), resumeID, NewResumeToken(resumeID, "300").Encode(), WatchOptions{})
+			if err != nil {
+				t.Fatalf("ResumeWatchResource: %v", err)
+			}
+			defer w.Stop()
+
+			if event := recv(t, w); event.Type != WatchEventBookmark || !event.Relisted {
+				t.Fatalf("first event = %+v, want relisted BOOKMARK", event)
+			}
+			for _, name := range repo.pods {
+				if event := recv(t, w); event.Type != WatchEventAdded || podName(event) != name {
+					t.Errorf("snapshot event = %+v, want ADDED %s", event, name)
+				}
+			}
+			event := recv(t, w)
+			if event.Type != WatchEventBookmark || event.Relisted {
+				t.Fatalf("event after snapshot = %+v, want BOOKMARK", event)
+			}
+			if rv := event.Object["metadata"].(map[string]any)["resourceVersion"]; rv != "500" {
+				t.Errorf("bookmark resourceVersion = %v, want 500", rv)
+			}
+
+			relisted.ch <- WatchEvent{Type: WatchEventDeleted, Object: testPod("web-1", "501").Object}
+			if event := recv(t, w); event.Type != WatchEventDeleted || podName(event) != "web-1" {
+				t.Errorf("event = %+v, want DELETED web-1", event)
+			}
+			if got := repo.watchRVs[len(repo.watchRVs)-1]; got != "500" {
+				t.Errorf("relisted watch started at %q, want the list's resourceVersion 500", got)
+			}
+			if tt.expiredAt == "stream" {
+				select {
+				case <-first.stopped:
+				default:
+					t.Error("expired watch was not stopped")
+				}
+			}
+		})
+	}
+}
+
+func TestResourceUseCase_ResumeWatchResource_RejectsForeignToken(t *testing.T) {
+	repo := &mockResumeRepo{}
+	uc := NewResourceUseCase(&mockWatchDiscovery{}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+
+	other := resumeID
+	other.Namespace = "kube-system"
+	_, err := uc.ResumeWatchResource(context.Background(), resumeID, NewResumeToken(other, "300").Encode(), WatchOptions{})
+	var invalid *ErrInvalidInput
+	if !isErrInvalidInput(err, &invalid) || invalid.Field != "resume_token" {
+		t.Fatalf("expected ErrInvalidInput on resume_token, got %v", err)
+	}
+	if len(repo.watchRVs) != 0 {
+		t.Error("rejected token must not reach the repo")
+	}
+}
+
+func TestResourceUseCase_WatchResource_Resyncs(t *testing.T) {
+	const interval = 100 * time.Millisecond
+	first, resynced := newChanWatcher(), newChanWatcher()
+	repo := &mockResumeRepo{watches: []*chanWatcher{first, resynced}, pods: []string{"web-0", "web-1"}}
+	uc := NewResourceUseCase(&mockWatchDiscovery{}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+
+	start := time.Now()
+	w, err := uc.WatchResource(context.Background(), resumeID, WatchOptions{ResyncInterval: interval})
+	if err != nil {
+		t.Fatalf("WatchResource: %v", err)
+	}
+	defer w.Stop()
+
+	first.ch <- WatchEvent{Type: WatchEventModified, Object: testPod("web-0", "301").Object}
+	if event := recv(t, w); event.Type != WatchEventModified || podName(event) != "web-0" {
+		t.Errorf("event = %+v, want MODIFIED web-0", event)
+	}
+
+	event := recv(t, w)
+	if event.Type != WatchEventBookmark || !event.Resynced || event.Relisted {
+		t.Fatalf("event = %+v, want resynced BOOKMARK", event)
+	}
+	if elapsed := time.Since(start); elapsed < interval {
+		t.Errorf("resynced after %v, want at least the %v interval", elapsed, interval)
+	}
+	select {
+	case <-first.stopped:
+	default:
+		t.Error("watch was not stopped on resync")
+	}
+
+	for _, name := range repo.pods {
+		if event := recv(t, w); event.Type != WatchEventAdded || podName(event) != name {
+			t.Errorf("snapshot event = %+v, want ADDED %s", event, name)
+		}
+	}
+	if event := recv(t, w); event.Type != WatchEventBookmark || !event.InitialEventsEnd {
+		t.Fatalf("event after snapshot = %+v, want BOOKMARK ending the snapshot", event)
+	}
+	if got := repo.watchRVs[len(repo.watchRVs)-1]; got != "500" {
+		t.Errorf("resynced watch started at %q, want the list's resourceVersion 500", got)
+	}
+
+	resynced.ch <- WatchEvent{Type: WatchEventDeleted, Object: testPod("web-1", "501").Object}
+	if event := recv(t, w); event.Type != WatchEventDeleted || podName(event) != "web-1" {
+		t.Errorf("event = %+v, want DELETED web-1", event)
+	}
+}
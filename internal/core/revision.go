@@ -0,0 +1,209 @@
+package core
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GVRs of the objects recording the revision history of workloads.
+var (
+	replicaSetsGVR         = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "replicasets"}
+	controllerRevisionsGVR = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "controllerrevisions"}
+)
+
+const (
+	// deploymentRevisionAnnotation numbers the ReplicaSets of a
+	// Deployment, one per revision.
+	deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+	// changeCauseAnnotation records why a revision was made, as shown
+	// by `kubectl rollout history`.
+	changeCauseAnnotation = "kubernetes.io/change-cause"
+	// podTemplateHashLabel is added to the pod template of every
+	// ReplicaSet by the Deployment controller and is not part of the
+	// Deployment's own template.
+	podTemplateHashLabel = "pod-template-hash"
+)
+
+// Revision is a revision of a workload's pod template, as recorded by
+// its controller.
+type Revision struct {
+	Revision int64
+	// Name is the name of the ReplicaSet or ControllerRevision that
+	// records the revision.
+	Name        string
+	CreatedAt   time.Time
+	ChangeCause string
+	// Current reports whether this is the workload's latest revision.
+	Current bool
+	// Template is the pod template of the revision.
+	Template map[string]any
+}
+
+// GetRevision returns a revision of the Deployment, DaemonSet or
+// StatefulSet identified by id, as `kubectl rollout history
+// --revision` does, e.g. to diff it against the current spec or to
+// undo a rollout. Deployment revisions are read from the ReplicaSets
+// the Deployment controls, the others from their ControllerRevisions.
+// Revision 0 selects the revision before the latest, the one `kubectl
+// rollout undo` rolls back to. Revisions no longer recorded, because
+// they were never made or were pruned by revisionHistoryLimit, return
+// ErrorCodeNotFound.
+func (uc *ResourceUseCase) GetRevision(ctx context.Context, id ResourceIdentifier, revision int64) (*Revision, error) {
+	if revision < 0 {
+		return nil, &ErrInvalidInput{Field: "revision", Message: "must not be negative"}
+	}
+	if id.Group != "apps" || !slices.Contains([]string{"deployments", "daemonsets", "statefulsets"}, id.Resource) {
+		return nil, &ErrInvalidInput{Field: "resource", Message: "revisions are only available for deployments, daemonsets and statefulsets"}
+	}
+
+	obj, err := uc.GetResource(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	history, err := uc.revisionHistory(ctx, id.Cluster, id.Resource, obj)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) == 0 {
+		return nil, &DomainError{
+			Code:    ErrorCodeNotFound,
+			Message: fmt.Sprintf("%s %q has no recorded revisions", id.Resource, id.Name),
+		}
+	}
+	slices.SortFunc(history, func(a, b Revision) int { return cmp.Compare(a.Revision, b.Revision) })
+	history[len(history)-1].Current = true
+
+	if revision == 0 {
+		if len(history) < 2 {
+			return nil, &DomainError{
+				Code:    ErrorCodeNotFound,
+				Message: fmt.Sprintf("%s %q has no previous revision", id.Resource, id.Name),
+			}
+		}
+		return &history[len(history)-2], nil
+	}
+	i, found := slices.BinarySearchFunc(history, revision, func(r Revision, target int64) int {
+		return cmp.Compare(r.Revision, target)
+	})
+	if !found {
+		return nil, &DomainError{
+			Code:    ErrorCodeNotFound,
+			Message: fmt.Sprintf("revision %d of %s %q not found", revision, id.Resource, id.Name),
+		}
+	}
+	return &history[i], nil
+}
+
+// revisionHistory lists the revisions recorded for obj, a workload of
+// the given resource, in no particular order. Objects matching the
+// workload's selector but controlled by another owner are ignored.
+func (uc *ResourceUseCase) revisionHistory(ctx context.Context, cluster, resource string, obj *unstructured.Unstructured) ([]Revision, error) {
+	selector, err := workloadSelector(obj)
+	if err != nil {
+		return nil, err
+	}
+	gvr := controllerRevisionsGVR
+	if resource == "deployments" {
+		gvr = replicaSetsGVR
+	}
+	list, err := uc.resource.List(ctx, cluster, gvr, obj.GetNamespace(), ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]Revision, 0, len(list.Items))
+	for i := range list.Items {
+		item := &list.Items[i]
+		if !metav1.IsControlledBy(item, obj) {
+			continue
+		}
+		var (
+			rev Revision
+			ok  bool
+		)
+		if resource == "deployments" {
+			rev, ok = replicaSetRevision(item)
+		} else {
+			rev, ok = controllerRevision(item)
+		}
+		if ok {
+			history = append(history, rev)
+		}
+	}
+	return history, nil
+}
+
+// workloadSelector returns spec.selector of a workload in the string
+// form accepted by label selector queries.
+func workloadSelector(obj *unstructured.Unstructured) (string, error) {
+	raw, found, err := unstructured.NestedMap(obj.Object, "spec", "selector")
+	if err != nil || !found {
+		return "", &ErrInvalidInput{Field: "resource", Message: fmt.Sprintf("%s %q has no spec.selector", obj.GetKind(), obj.GetName())}
+	}
+	var ls metav1.LabelSelector
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw, &ls); err != nil {
+		return "", fmt.Errorf("decode selector of %q: %w", obj.GetName(), err)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&ls)
+	if err != nil {
+		return "", fmt.Errorf("parse selector of %q: %w", obj.GetName(), err)
+	}
+	return selector.String(), nil
+}
+
+// replicaSetRevision returns the revision a Deployment's ReplicaSet
+// records. The pod-template-hash label is removed from its template
+// so that it matches the Deployment's. ReplicaSets without a valid
+// revision annotation are skipped.
+func replicaSetRevision(rs *unstructured.Unstructured) (Revision, bool) {
+	n, err := strconv.ParseInt(rs.GetAnnotations()[deploymentRevisionAnnotation], 10, 64)
+	if err != nil || n <= 0 {
+		return Revision{}, false
+	}
+	template, found, err := unstructured.NestedMap(rs.Object, "spec", "template")
+	if err != nil || !found {
+		return Revision{}, false
+	}
+	unstructured.RemoveNestedField(template, "metadata", "labels", podTemplateHashLabel)
+	if labels, found, _ := unstructured.NestedMap(template, "metadata", "labels"); found && len(labels) == 0 {
+		unstructured.RemoveNestedField(template, "metadata", "labels")
+	}
+	return Revision{
+		Revision:    n,
+		Name:        rs.GetName(),
+		CreatedAt:   rs.GetCreationTimestamp().Time,
+		ChangeCause: rs.GetAnnotations()[changeCauseAnnotation],
+		Template:    template,
+	}, true
+}
+
+// controllerRevision returns the revision a ControllerRevision of a
+// DaemonSet or StatefulSet records. Its data is a strategic merge
+// patch replacing spec.template, from which the template is taken.
+func controllerRevision(cr *unstructured.Unstructured) (Revision, bool) {
+	n, found, err := unstructured.NestedInt64(cr.Object, "revision")
+	if err != nil || !found || n <= 0 {
+		return Revision{}, false
+	}
+	template, found, err := unstructured.NestedMap(cr.Object, "data", "spec", "template")
+	if err != nil || !found {
+		return Revision{}, false
+	}
+	delete(template, "$patch")
+	return Revision{
+		Revision:    n,
+		Name:        cr.GetName(),
+		CreatedAt:   cr.GetCreationTimestamp().Time,
+		ChangeCause: cr.GetAnnotations()[changeCauseAnnotation],
+		Template:    template,
+	}, true
+}
@@ -0,0 +1,185 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// revisionRepo serves a workload and the objects recording its
+// revisions, and records the selector they were listed with.
+type revisionRepo struct {
+	ResourceRepo
+	workload map[string]any
+	history  []unstructured.Unstructured
+	selector string
+}
+
+func (r *revisionRepo) Get(_ context.Context, _ string, _ schema.GroupVersionResource, _, _ string) (*unstructured.Unstructured, error) {
+	return &unstructured.Unstructured{Object: r.workload}, nil
+}
+
+func (r *revisionRepo) List(_ context.Context, _ string, _ schema.GroupVersionResource, _ string, opts ListOptions) (*unstructured.UnstructuredList, error) {
+	r.selector = opts.LabelSelector
+	return &unstructured.UnstructuredList{Items: r.history}, nil
+}
+
+// shopReplicaSet returns a ReplicaSet recording a revision of the shop
+// Deployment, controlled by the Deployment with ownerUID.
+func shopReplicaSet(name, revision, ownerUID, image string) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "ReplicaSet",
+		"metadata": map[string]any{
+			"name":              name,
+			"namespace":         "apps",
+			"creationTimestamp": "2026-01-02T03:04:05Z",
+			"annotations": map[string]any{
+				deploymentRevisionAnnotation: revision,
+				changeCauseAnnotation:        "deploy " + image,
+			},
+			"ownerReferences": []any{map[string]any{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"name":       "shop",
+				"uid":        ownerUID,
+				"controller": true,
+			}},
+		},
+		"spec": map[string]any{"template": map[string]any{
+			"metadata": map[string]any{"labels": map[string]any{"app": "shop", podTemplateHashLabel: name}},
+			"spec":     map[string]any{"containers": []any{map[string]any{"name": "web", "image": image}}},
+		}},
+	}}
+}
+
+func TestResourceUseCase_GetRevision_Deployment(t *testing.T) {
+	workload := deployment("d1", 2, "shop:v3", nil)
+	workload["spec"].(map[string]any)["selector"] = map[string]any{"matchLabels": map[string]any{"app": "shop"}}
+	repo := &revisionRepo{
+		workload: workload,
+		history: []unstructured.Unstructured{
+			shopReplicaSet("shop-1", "1", "d1", "shop:v1"),
+			shopReplicaSet("shop-3", "3", "d1", "shop:v3"),
+			shopReplicaSet("shop-2", "2", "d1", "shop:v2"),
+			// Matches the selector but belongs to another Deployment.
+			shopReplicaSet("other-4", "4", "d2", "other:v4"),
+		},
+	}
+	uc := NewResourceUseCase(&mockWatchDiscovery{}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+	id := ResourceIdentifier{Cluster: "c1", Group: "apps", Version: "v1", Resource: "deployments", Namespace: "apps", Name: "shop"}
+
+	got, err := uc.GetRevision(context.Background(), id, 2)
+	if err != nil {
+		t.Fatalf("GetRevision: %v", err)
+	}
+	if repo.selector != "app=shop" {
+		t.Errorf("selector = %q, want app=shop", repo.selector)
+	}
+	if got.Revision != 2 || got.Name != "shop-2" || got.ChangeCause != "deploy shop:v2" || got.Current {
+		t.Errorf("revision = %+v, want non-current revision 2 from shop-2", got)
+	}
+	wantTemplate := map[string]any{
+		"metadata": map[string]any{"labels": map[string]any{"app": "shop"}},
+		"spec":     map[string]any{"containers": []any{map[string]any{"name": "web", "image": "shop:v2"}}},
+	}
+	if !reflect.DeepEqual(got.Template, wantTemplate) {
+		t.Errorf("template = %v, want %v", got.Template, wantTemplate)
+	}
+
+	t.Run("previous", func(t *testing.T) {
+		got, err := uc.GetRevision(context.Background(), id, 0)
+		if err != nil {
+			t.Fatalf("GetRevision: %v", err)
+		}
+		if got.Revision != 2 {
+			t.Errorf("revision = %d, want 2", got.Revision)
+		}
+	})
+
+	t.Run("current", func(t *testing.T) {
+		got, err := uc.GetRevision(context.Background(), id, 3)
+		if err != nil {
+			t.Fatalf("GetRevision: %v", err)
+		}
+		if !got.Current {
+			t.Error("Current = false, want true for the latest revision")
+		}
+	})
+
+	t.Run("unknown revision", func(t *testing.T) {
+		_, err := uc.GetRevision(context.Background(), id, 4)
+		if code, _ := DomainErrorCode(err); code != ErrorCodeNotFound {
+			t.Errorf("error = %v, want NotFound", err)
+		}
+	})
+}
+
+func TestResourceUseCase_GetRevision_DaemonSet(t *testing.T) {
+	repo := &revisionRepo{
+		workload: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "DaemonSet",
+			"metadata":   map[string]any{"name": "agent", "namespace": "kube-system", "uid": "ds1"},
+			"spec":       map[string]any{"selector": map[string]any{"matchLabels": map[string]any{"app": "agent"}}},
+		},
+		history: []unstructured.Unstructured{{Object: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "ControllerRevision",
+			"metadata": map[string]any{
+				"name":      "agent-7c9d",
+				"namespace": "kube-system",
+				"ownerReferences": []any{map[string]any{
+					"apiVersion": "apps/v1", "kind": "DaemonSet", "name": "agent", "uid": "ds1", "controller": true,
+				}},
+			},
+			"revision": int64(1),
+			"data": map[string]any{"spec": map[string]any{"template": map[string]any{
+				"$patch": "replace",
+				"spec":   map[string]any{"containers": []any{map[string]any{"name": "agent", "image": "agent:v1"}}},
+			}}},
+		}}},
+	}
+	uc := NewResourceUseCase(&mockWatchDiscovery{}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+	id := ResourceIdentifier{Cluster: "c1", Group: "apps", Version: "v1", Resource: "daemonsets", Namespace: "kube-system", Name: "agent"}
+
+	got, err := uc.GetRevision(context.Background(), id, 1)
+	if err != nil {
+		t.Fatalf("GetRevision: %v", err)
+	}
+	wantTemplate := map[string]any{
+		"spec": map[string]any{"containers": []any{map[string]any{"name": "agent", "image": "agent:v1"}}},
+	}
+	if got.Name != "agent-7c9d" || !got.Current || !reflect.DeepEqual(got.Template, wantTemplate) {
+		t.Errorf("revision = %+v, want current agent-7c9d with template %v", got, wantTemplate)
+	}
+
+	if _, err := uc.GetRevision(context.Background(), id, 0); err == nil {
+		t.Error("GetRevision(0) error = nil, want NotFound without a previous revision")
+	}
+}
+
+func TestResourceUseCase_GetRevision_Validation(t *testing.T) {
+	uc := NewResourceUseCase(&mockWatchDiscovery{}, &revisionRepo{}, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+	tests := []struct {
+		name     string
+		id       ResourceIdentifier
+		revision int64
+	}{
+		{"negative revision", ResourceIdentifier{Group: "apps", Version: "v1", Resource: "deployments", Namespace: "apps", Name: "shop"}, -1},
+		{"unsupported resource", ResourceIdentifier{Version: "v1", Resource: "pods", Namespace: "apps", Name: "shop"}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.GetRevision(context.Background(), tt.id, tt.revision)
+			var invalid *ErrInvalidInput
+			if !errors.As(err, &invalid) {
+				t.Errorf("error = %v, want ErrInvalidInput", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// RolloutStatus summarises the progress of a workload rollout. It
+// follows the rules of `kubectl rollout status`.
+type RolloutStatus struct {
+	// Desired is the number of pods the workload should run.
+	Desired int64
+	// Updated is the number of pods running the current template.
+	Updated int64
+	// Ready is the number of ready pods.
+	Ready int64
+	// Available is the number of pods ready for at least the
+	// workload's minReadySeconds.
+	Available int64
+	// Done reports whether the rollout has completed.
+	Done bool
+	// Message describes the progress in kubectl's words.
+	Message string
+}
+
+// RolloutStatusOf evaluates the rollout of a Deployment, StatefulSet
+// or DaemonSet. It returns an error for other kinds, for workloads
+// whose update strategy does not roll out automatically, and for
+// Deployments that exceeded their progress deadline.
+func RolloutStatusOf(obj *unstructured.Unstructured) (RolloutStatus, error) {
+	switch kind := obj.GetKind(); kind {
+	case "Deployment":
+		return deploymentRolloutStatus(obj)
+	case "StatefulSet":
+		return statefulSetRolloutStatus(obj)
+	case "DaemonSet":
+		return daemonSetRolloutStatus(obj)
+	default:
+		return RolloutStatus{}, &ErrInvalidInput{Field: "resource", Message: fmt.Sprintf("rollout status is not supported for %s", kind)}
+	}
+}
+
+// specObserved reports whether the workload's controller has observed
+// its latest spec.
+func specObserved(obj *unstructured.Unstructured) bool {
+	observed, _, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+	return observed > 0 && obj.GetGeneration() <= observed
+}
+
+func statusInt(obj *unstructured.Unstructured, field string) int64 {
+	v, _, _ := unstructured.NestedInt64(obj.Object, "status", field)
+	return v
+}
+
+func deploymentRolloutStatus(obj *unstructured.Unstructured) (RolloutStatus, error) {
+	name := obj.GetName()
+	desired, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	if !found {
+		desired = 1
+	}
+	s := RolloutStatus{
+		Desired:   desired,
+		Updated:   statusInt(obj, "updatedReplicas"),
+		Ready:     statusInt(obj, "readyReplicas"),
+		Available: statusInt(obj, "availableReplicas"),
+	}
+	replicas := statusInt(obj, "replicas")
+
+	if !specObserved(obj) {
+		s.Message = "Waiting for deployment spec update to be observed..."
+		return s, nil
+	}
+	if progressDeadlineExceeded(obj) {
+		return s, &DomainError{Code: ErrorCodeFailedPrecondition, Message: fmt.Sprintf("deployment %q exceeded its progress deadline", name)}
+	}
+	switch {
+	case s.Updated < s.Desired:
+		s.Message = fmt.Sprintf("Waiting for deployment %q rollout to finish: %d out of %d new replicas have been updated...", name, s.Updated, s.Desired)
+	case replicas > s.Updated:
+		s.Message = fmt.Sprintf("Waiting for deployment %q rollout to finish: %d old replicas are pending termination...", name, replicas-s.Updated)
+	case s.Available < s.Updated:
+		s.Message = fmt.Sprintf("Waiting for deployment %q rollout to finish: %d of %d updated replicas are available...", name, s.Available, s.Updated)
+	default:
+		s.Done = true
+		s.Message = fmt.Sprintf("deployment %q successfully rolled out", name)
+	}
+	return s, nil
+}
+
+// progressDeadlineExceeded reports whether a Deployment's Progressing
+// condition reports that the rollout stalled.
+func progressDeadlineExceeded(obj *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]any)
+		if ok && condition["type"] == "Progressing" {
+			return condition["reason"] == "ProgressDeadlineExceeded"
+		}
+	}
+	return false
+}
+
+func statefulSetRolloutStatus(obj *unstructured.Unstructured) (RolloutStatus, error) {
+	if strategy, _, _ := unstructured.NestedString(obj.Object, "spec", "updateStrategy", "type"); strategy != "" && strategy != "RollingUpdate" {
+		return RolloutStatus{}, &ErrInvalidInput{Field: "resource", Message: fmt.Sprintf("rollout status is only available for the RollingUpdate strategy, not %s", strategy)}
+	}
+	desired, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	if !found {
+		desired = 1
+	}
+	s := RolloutStatus{
+		Desired:   desired,
+		Updated:   statusInt(obj, "updatedReplicas"),
+		Ready:     statusInt(obj, "readyReplicas"),
+		Available: statusInt(obj, "availableReplicas"),
+	}
+
+	if !specObserved(obj) {
+		s.Message = "Waiting for statefulset spec update to be observed..."
+		return s, nil
+	}
+	if s.Ready < s.Desired {
+		s.Message = fmt.Sprintf("Waiting for %d pods to be ready...", s.Desired-s.Ready)
+		return s, nil
+	}
+	if partition, found, _ := unstructured.NestedInt64(obj.Object, "spec", "updateStrategy", "rollingUpdate", "partition"); found && partition > 0 {
+		if s.Updated < s.Desired-partition {
+			s.Message = fmt.Sprintf("Waiting for partitioned roll out to finish: %d out of %d new pods have been updated...", s.Updated, s.Desired-partition)
+			return s, nil
+		}
+		s.Done = true
+		s.Message = fmt.Sprintf("partitioned roll out complete: %d new pods have been updated...", s.Updated)
+		return s, nil
+	}
+	current, _, _ := unstructured.NestedString(obj.Object, "status", "currentRevision")
+	update, _, _ := unstructured.NestedString(obj.Object, "status", "updateRevision")
+	if current != update {
+		s.Message = fmt.Sprintf("waiting for statefulset rolling update to complete %d pods at revision %s...", s.Updated, update)
+		return s, nil
+	}
+	s.Done = true
+	s.Message = fmt.Sprintf("statefulset rolling update complete %d pods at revision %s...", s.Ready, current)
+	return s, nil
+}
+
+func daemonSetRolloutStatus(obj *unstructured.Unstructured) (RolloutStatus, error) {
+	if strategy, _, _ := unstructured.NestedString(obj.Object, "spec", "updateStrategy", "type"); strategy != "" && strategy != "RollingUpdate" {
+		return RolloutStatus{}, &ErrInvalidInput{Field: "resource", Message: fmt.Sprintf("rollout status is only available for the RollingUpdate strategy, not %s", strategy)}
+	}
+	name := obj.GetName()
+	s := RolloutStatus{
+		Desired:   statusInt(obj, "desiredNumberScheduled"),
+		Updated:   statusInt(obj, "updatedNumberScheduled"),
+		Ready:     statusInt(obj, "numberReady"),
+		Available: statusInt(obj, "numberAvailable"),
+	}
+
+	switch {
+	case !specObserved(obj):
+		s.Message = "Waiting for daemon set spec update to be observed..."
+	case s.Updated < s.Desired:
+		s.Message = fmt.Sprintf("Waiting for daemon set %q rollout to finish: %d out of %d new pods have been updated...", name, s.Updated, s.Desired)
+	case s.Available < s.Desired:
+		s.Message = fmt.Sprintf("Waiting for daemon set %q rollout to finish: %d of %d updated pods are available...", name, s.Available, s.Desired)
+	default:
+		s.Done = true
+		s.Message = fmt.Sprintf("daemon set %q successfully rolled out", name)
+	}
+	return s, nil
+}
+
+// WaitForRollout watches the workload identified by id until its
+// rollout completes, as reported by RolloutStatusOf, and returns the
+// final status. onProgress, if not nil, is called with every distinct
+// status observed, including the final one. Timeouts and watch
+// handling are as for WaitForCondition.
+func (uc *ResourceUseCase) WaitForRollout(
+	ctx context.Context,
+	id ResourceIdentifier,
+	timeout time.Duration,
+	onProgress func(RolloutStatus),
+) (RolloutStatus, error) {
+	if id.Name == "" {
+		return RolloutStatus{}, &ErrInvalidInput{Field: "name", Message: "is required"}
+	}
+	if err := validateWaitTimeout(timeout); err != nil {
+		return RolloutStatus{}, err
+	}
+
+	var latest RolloutStatus
+	_, err := uc.waitFor(ctx, id, timeout, fmt.Sprintf("rollout of %s %q", id.Resource, id.Name),
+		func(obj *unstructured.Unstructured) (bool, error) {
+			status, err := RolloutStatusOf(obj)
+			if err != nil {
+				return false, err
+			}
+			if status != latest && onProgress != nil {
+				onProgress(status)
+			}
+			latest = status
+			return status.Done, nil
+		})
+	return latest, err
+}
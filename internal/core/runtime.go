@@ -2,8 +2,9 @@ package core
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
-	"log/slog"
 	"time"
 
 	"github.com/google/uuid"
@@ -40,6 +41,14 @@ type RuntimeRepo interface {
 // Options types
 // ---------------------------------------------------------------------------
 
+// StreamEncodingHeader is the request header with which the server
+// asks the agent to compress a streamed response body before it
+// crosses the tunnel. Its only supported value is "gzip"; the agent
+// answers with a standard Content-Encoding response header and only
+// compresses when the request also accepts gzip, so agents that do not
+// understand the header stay compatible.
+const StreamEncodingHeader = "X-Otterscale-Stream-Encoding"
+
 // PodLogOptions mirrors the fields of corev1.PodLogOptions that are
 // exposed through the RuntimeService proto.
 type PodLogOptions struct {
@@ -53,6 +62,16 @@ type PodLogOptions struct {
 	LimitBytes   *int64
 }
 
+// PodLogConfig holds the server-wide settings of pod log streams.
+type PodLogConfig struct {
+	// BufferSize is how many bytes of a followed log are buffered for
+	// a client that cannot keep up. When the buffer is full the oldest
+	// lines are dropped and the client is told which. Zero disables
+	// buffering, so that a slow client slows down reading the log, as
+	// it always does for a log that is not followed.
+	BufferSize int
+}
+
 // ExecOptions holds parameters for an interactive exec session.
 type ExecOptions struct {
 	Container string
@@ -78,13 +97,25 @@ type StartExecParams struct {
 	Cols      uint16
 }
 
-// PortForwardOptions holds parameters for a port-forward session.
+// PortForwardOptions holds parameters for a port-forward session. All
+// streams share a single connection to the pod.
 type PortForwardOptions struct {
+	Streams []PortForwardStream
+}
+
+// PortForwardStream carries the data of one forwarded port. Data read
+// from Stdin is sent to Port on the pod; data received from it is
+// written to Stdout.
+type PortForwardStream struct {
 	Port   int32
 	Stdin  io.Reader
 	Stdout io.Writer
 }
 
+// maxPortForwardPorts bounds the number of ports a single
+// port-forward session may forward.
+const maxPortForwardPorts = 16
+
 // ---------------------------------------------------------------------------
 // Use case
 // ---------------------------------------------------------------------------
@@ -95,17 +126,20 @@ type RuntimeUseCase struct {
 	discovery DiscoveryClient
 	runtime   RuntimeRepo
 	sessions  *SessionStore
+	resources *ResourceUseCase
 }
 
 // NewRuntimeUseCase returns a RuntimeUseCase wired to the given
 // discovery, runtime, and session store backends. The SessionStore is
 // injected rather than created internally so that callers can supply
-// alternative implementations for testing or monitoring.
-func NewRuntimeUseCase(discovery DiscoveryClient, runtime RuntimeRepo, sessions *SessionStore) *RuntimeUseCase {
+// alternative implementations for testing or monitoring. resources
+// is used to follow rollouts after a restart.
+func NewRuntimeUseCase(discovery DiscoveryClient, runtime RuntimeRepo, sessions *SessionStore, resources *ResourceUseCase) *RuntimeUseCase {
 	return &RuntimeUseCase{
 		discovery: discovery,
 		runtime:   runtime,
 		sessions:  sessions,
+		resources: resources,
 	}
 }
 
@@ -169,15 +203,15 @@ func (uc *RuntimeUseCase) StartExec(ctx context.Context, params StartExecParams)
 
 		var stderr io.Writer
 		if !params.TTY {
-			stderr = stderrW
+			stderr = &countingWriter{w: stderrW, n: &sess.bytes}
 		}
 
 		errCh <- uc.runtime.Exec(ctx, params.Cluster, params.Namespace, params.Name, ExecOptions{
 			Container: params.Container,
 			Command:   params.Command,
 			TTY:       params.TTY,
-			Stdin:     stdinR,
-			Stdout:    stdoutW,
+			Stdin:     &countingReader{r: stdinR, n: &sess.bytes},
+			Stdout:    &countingWriter{w: stdoutW, n: &sess.bytes},
 			Stderr:    stderr,
 			SizeQueue: sizeQueue,
 		})
@@ -215,10 +249,25 @@ func (uc *RuntimeUseCase) WriteExec(ctx context.Context, sessionID string, data
 	case <-ctx.Done():
 		return ctx.Err()
 	case err := <-errCh:
+		if errors.Is(err, io.ErrClosedPipe) {
+			return &DomainError{Code: ErrorCodeFailedPrecondition, Message: fmt.Sprintf("stdin of exec session %s is closed", sessionID)}
+		}
 		return err
 	}
 }
 
+// CloseExecStdin closes the stdin of an active exec session, so that
+// the command reads EOF once it has consumed the data written so far.
+// The session's output keeps streaming until the command exits.
+// Closing stdin again is a no-op.
+func (uc *RuntimeUseCase) CloseExecStdin(_ context.Context, sessionID string) error {
+	sess, ok := uc.sessions.GetExec(sessionID)
+	if !ok {
+		return &ErrSessionNotFound{Resource: "exec-session", ID: sessionID}
+	}
+	return sess.Stdin.Close()
+}
+
 // ResizeExec sends a terminal resize event to an active exec session.
 func (uc *RuntimeUseCase) ResizeExec(_ context.Context, sessionID string, rows, cols uint16) error {
 	sess, ok := uc.sessions.GetExec(sessionID)
@@ -242,62 +291,119 @@ func (uc *RuntimeUseCase) CleanupExec(_ context.Context, sessionID string) {
 	sess.Stdin.Close()
 }
 
-// StartPortForward creates a port-forward session, starts the
-// forwarding in a background goroutine, and returns the session
-// together with a reader for data coming from the pod.
-func (uc *RuntimeUseCase) StartPortForward(ctx context.Context, cluster, namespace, name string, port int32) (*PortForwardSession, io.ReadCloser, error) {
+// StartPortForward creates a port-forward session for one or more
+// ports, starts the forwarding in a background goroutine, and returns
+// the session together with a reader per port for data coming from
+// the pod. All ports share one connection; closing the session closes
+// every port.
+func (uc *RuntimeUseCase) StartPortForward(ctx context.Context, cluster, namespace, name string, ports []int32) (*PortForwardSession, map[int32]io.ReadCloser, error) {
 	if name == "" {
 		return nil, nil, &ErrInvalidInput{Field: "name", Message: "pod name is required"}
 	}
-	if port <= 0 || port > 65535 {
-		return nil, nil, &ErrInvalidInput{Field: "port", Message: "must be between 1 and 65535"}
+	if err := validatePorts(ports); err != nil {
+		return nil, nil, err
 	}
 
-	dataInR, dataInW := io.Pipe()
-	dataOutR, dataOutW := io.Pipe()
+	var (
+		streams = make([]PortForwardStream, 0, len(ports))
+		writers = make(map[int32]io.WriteCloser, len(ports))
+		readers = make(map[int32]io.ReadCloser, len(ports))
+		closers []io.Closer // pipe ends owned by the forwarding goroutine
+	)
+	for _, port := range ports {
+		dataInR, dataInW := io.Pipe()
+		dataOutR, dataOutW := io.Pipe()
+		streams = append(streams, PortForwardStream{Port: port, Stdin: dataInR, Stdout: dataOutW})
+		writers[port] = dataInW
+		readers[port] = dataOutR
+		closers = append(closers, dataInR, dataOutW)
+	}
+	closeAll := func() {
+		for _, c := range closers {
+			c.Close()
+		}
+	}
 
 	ctx, cancel := context.WithCancel(ctx)
 	errCh := make(chan error, 1)
 
 	sess := &PortForwardSession{
-		ID:     uuid.New().String(),
-		Writer: dataInW,
-		Cancel: cancel,
-		Done:   errCh,
+		ID:      uuid.New().String(),
+		Ports:   ports,
+		Writers: writers,
+		Cancel:  cancel,
+		Done:    errCh,
 	}
 
 	// Register the session BEFORE launching the goroutine to avoid
 	// wasting resources if the session store is full.
 	if err := uc.sessions.PutPortForward(sess); err != nil {
 		cancel()
-		dataInW.Close()
-		dataInR.Close()
-		dataOutW.Close()
+		_ = sess.closeWriters()
+		for _, r := range readers {
+			r.Close()
+		}
+		closeAll()
 		return nil, nil, err
 	}
 
+	// Count the session's traffic for its metrics.
+	for i := range streams {
+		streams[i].Stdin = &countingReader{r: streams[i].Stdin, n: &sess.bytes}
+		streams[i].Stdout = &countingWriter{w: streams[i].Stdout, n: &sess.bytes}
+	}
+
 	go func() {
-		defer dataInR.Close()
-		defer dataOutW.Close()
+		defer closeAll()
 		errCh <- uc.runtime.PortForward(ctx, cluster, namespace, name, PortForwardOptions{
-			Port:   port,
-			Stdin:  dataInR,
-			Stdout: dataOutW,
+			Streams: streams,
 		})
 	}()
 
-	return sess, dataOutR, nil
+	return sess, readers, nil
+}
+
+// validatePorts checks that ports is a non-empty, bounded list of
+// distinct, valid port numbers.
+func validatePorts(ports []int32) error {
+	if len(ports) == 0 {
+		return &ErrInvalidInput{Field: "ports", Message: "at least one port is required"}
+	}
+	if len(ports) > maxPortForwardPorts {
+		return &ErrInvalidInput{Field: "ports", Message: fmt.Sprintf("must not exceed %d ports", maxPortForwardPorts)}
+	}
+	seen := make(map[int32]bool, len(ports))
+	for _, port := range ports {
+		if port <= 0 || port > 65535 {
+			return &ErrInvalidInput{Field: "port", Message: "must be between 1 and 65535"}
+		}
+		if seen[port] {
+			return &ErrInvalidInput{Field: "ports", Message: fmt.Sprintf("port %d is listed more than once", port)}
+		}
+		seen[port] = true
+	}
+	return nil
 }
 
-// WritePortForward writes data to an active port-forward session. The
-// write is performed in a background goroutine so that the caller's
-// context can cancel a blocking pipe write during graceful shutdown.
-func (uc *RuntimeUseCase) WritePortForward(ctx context.Context, sessionID string, data []byte) error {
+// WritePortForward writes data to the given port of an active
+// port-forward session. port may be zero when the session forwards a
+// single port. The write is performed in a background goroutine so
+// that the caller's context can cancel a blocking pipe write during
+// graceful shutdown.
+func (uc *RuntimeUseCase) WritePortForward(ctx context.Context, sessionID string, port int32, data []byte) error {
 	sess, ok := uc.sessions.GetPortForward(sessionID)
 	if !ok {
 		return &ErrSessionNotFound{Resource: "portforward-session", ID: sessionID}
 	}
 
+	if port == 0 && len(sess.Ports) == 1 {
+		port = sess.Ports[0]
+	}
+	w, ok := sess.Writers[port]
+	if !ok {
+		return &ErrInvalidInput{Field: "port", Message: fmt.Sprintf("port %d is not forwarded by session %s", port, sessionID)}
+	}
+
 	// Fast-path: if the session goroutine has already exited, the
 	// pipe reader is closed and Write would return immediately with
 	// an error. Check Done first to return a clearer error.
@@ -309,7 +415,7 @@ func (uc *RuntimeUseCase) WritePortForward(ctx context.Context, sessionID string
 
 	errCh := make(chan error, 1)
 	go func() {
-		_, err := sess.Writer.Write(data)
+		_, err := w.Write(data)
 		errCh <- err
 	}()
 
@@ -331,7 +437,7 @@ func (uc *RuntimeUseCase) CleanupPortForward(_ context.Context, sessionID string
 		return
 	}
 	sess.Cancel()
-	sess.Writer.Close()
+	_ = sess.closeWriters()
 }
 
 // GetScale validates the inputs, looks up the GVR, and returns the
@@ -340,7 +446,7 @@ func (uc *RuntimeUseCase) GetScale(ctx context.Context, id ResourceIdentifier) (
 	if id.Name == "" {
 		return 0, &ErrInvalidInput{Field: "name", Message: "resource name is required"}
 	}
-	gvr, err := id.lookupGVR(ctx, uc.discovery)
+	gvr, err := id.lookupScopedGVR(ctx, uc.discovery)
 	if err != nil {
 		return 0, err
 	}
@@ -356,31 +462,18 @@ func (uc *RuntimeUseCase) Scale(ctx context.Context, id ResourceIdentifier, repl
 	if replicas < 0 {
 		return 0, &ErrInvalidInput{Field: "replicas", Message: "must be non-negative"}
 	}
-	gvr, err := id.lookupGVR(ctx, uc.discovery)
+	gvr, err := id.lookupScopedGVR(ctx, uc.discovery)
 	if err != nil {
 		return 0, err
 	}
 	return uc.runtime.UpdateScale(ctx, id.Cluster, gvr, id.Namespace, id.Name, replicas)
 }
 
-// StartSessionReaper launches a background goroutine that
-// periodically scans for stale sessions (finished but not cleaned up)
-// and removes them. It blocks until ctx is cancelled.
-func (uc *RuntimeUseCase) StartSessionReaper(ctx context.Context, interval time.Duration) {
-	log := slog.Default().With("component", "session-reaper")
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case <-ticker.C:
-			if n := uc.sessions.ReapStaleSessions(); n > 0 {
-				log.Info("reaped stale sessions", "count", n)
-			}
-		}
-	}
+// StartSessionReaper periodically scans for stale sessions (finished
+// but not cleaned up) and removes them, every interval varied by
+// jitter. It blocks until ctx is cancelled.
+func (uc *RuntimeUseCase) StartSessionReaper(ctx context.Context, interval time.Duration, jitter Jitter) {
+	uc.sessions.StartReaper(ctx, interval, jitter)
 }
 
 // Restart validates the inputs, looks up the GVR, and triggers a
@@ -389,9 +482,31 @@ func (uc *RuntimeUseCase) Restart(ctx context.Context, id ResourceIdentifier) er
 	if id.Name == "" {
 		return &ErrInvalidInput{Field: "name", Message: "resource name is required"}
 	}
-	gvr, err := id.lookupGVR(ctx, uc.discovery)
+	gvr, err := id.lookupScopedGVR(ctx, uc.discovery)
 	if err != nil {
 		return err
 	}
 	return uc.runtime.Restart(ctx, id.Cluster, gvr, id.Namespace, id.Name)
 }
+
+// RestartAndWait triggers a rolling restart like Restart, then waits
+// for the rollout to complete like ResourceUseCase.WaitForRollout,
+// calling onProgress with each distinct status. It returns the final
+// status, or a DomainError of code ErrorCodeDeadlineExceeded if the
+// new pods are not ready within timeout.
+func (uc *RuntimeUseCase) RestartAndWait(
+	ctx context.Context,
+	id ResourceIdentifier,
+	timeout time.Duration,
+	onProgress func(RolloutStatus),
+) (RolloutStatus, error) {
+	// Validate before restarting so that a bad timeout does not leave
+	// a restart the caller believes failed.
+	if err := validateWaitTimeout(timeout); err != nil {
+		return RolloutStatus{}, err
+	}
+	if err := uc.Restart(ctx, id); err != nil {
+		return RolloutStatus{}, err
+	}
+	return uc.resources.WaitForRollout(ctx, id, timeout, onProgress)
+}
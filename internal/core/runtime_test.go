@@ -0,0 +1,296 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// echoPortForwardRepo echoes every chunk written to a port back on the
+// same port, tagged with the port number.
+type echoPortForwardRepo struct {
+	RuntimeRepo
+}
+
+func (echoPortForwardRepo) PortForward(ctx context.Context, _, _, _ string, opts PortForwardOptions) error {
+	for _, s := range opts.Streams {
+		go func() {
+			buf := make([]byte, 1024)
+			for {
+				n, err := s.Stdin.Read(buf)
+				if err != nil {
+					return
+				}
+				fmt.Fprintf(s.Stdout, "[%d]%s", s.Port, buf[:n])
+			}
+		}()
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestRuntimeUseCase_PortForward_RoutesWritesByPort(t *testing.T) {
+	uc := NewRuntimeUseCase(nil, echoPortForwardRepo{}, NewSessionStore(NewRealClock(), nil), nil)
+	ctx := context.Background()
+
+	sess, readers, err := uc.StartPortForward(ctx, "c1", "default", "db-0", []int32{5432, 6379})
+	if err != nil {
+		t.Fatalf("StartPortForward: %v", err)
+	}
+	defer uc.CleanupPortForward(ctx, sess.ID)
+
+	for _, port := range []int32{6379, 5432} {
+		if err := uc.WritePortForward(ctx, sess.ID, port, []byte("hi")); err != nil {
+			t.Fatalf("WritePortForward(%d): %v", port, err)
+		}
+		want := fmt.Sprintf("[%d]hi", port)
+		got := make([]byte, len(want))
+		if _, err := io.ReadFull(readers[port], got); err != nil {
+			t.Fatalf("read port %d: %v", port, err)
+		}
+		if string(got) != want {
+			t.Errorf("port %d received %q, want %q", port, got, want)
+		}
+	}
+
+	// Multi-port sessions require an explicit port.
+	var invalidInput *ErrInvalidInput
+	if err := uc.WritePortForward(ctx, sess.ID, 0, []byte("x")); !isErrInvalidInput(err, &invalidInput) {
+		t.Errorf("write without port: expected ErrInvalidInput, got %v", err)
+	}
+	if err := uc.WritePortForward(ctx, sess.ID, 8080, []byte("x")); !isErrInvalidInput(err, &invalidInput) {
+		t.Errorf("write to unforwarded port: expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestValidatePorts(t *testing.T) {
+	tests := []struct {
+		name    string
+		ports   []int32
+		wantErr bool
+	}{
+		{"single", []int32{8080}, false},
+		{"multiple", []int32{5432, 6379}, false},
+		{"empty", nil, true},
+		{"out of range", []int32{70000}, true},
+		{"duplicate", []int32{80, 80}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validatePorts(tt.ports); (err != nil) != tt.wantErr {
+				t.Errorf("validatePorts(%v) = %v, wantErr %v", tt.ports, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// restartRepo records the workloads restarted.
+type restartRepo struct {
+	RuntimeRepo
+	restarted []string
+}
+
+func (r *restartRepo) Restart(_ context.Context, _ string, _ schema.GroupVersionResource, _, name string) error {
+	r.restarted = append(r.restarted, name)
+	return nil
+}
+
+// rolloutDeployment returns a 3-replica deployment at generation 2
+// whose controller has observed generation observed and brought the
+// given number of pods up to date.
+func rolloutDeployment(observed, replicas, updated, available int64) map[string]any {
+	return map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": "web", "namespace": "default", "generation": int64(2)},
+		"spec":       map[string]any{"replicas": int64(3)},
+		"status": map[string]any{
+			"observedGeneration": observed,
+			"replicas":           replicas,
+			"updatedReplicas":    updated,
+			"readyReplicas":      available,
+			"availableReplicas":  available,
+		},
+	}
+}
+
+func TestRuntimeUseCase_RestartAndWait_CompletesRollout(t *testing.T) {
+	// The snapshot taken after the restart still shows the previous
+	// generation; the watch then follows the rollout to completion.
+	resources := &mockConditionRepo{
+		deployment: unstructured.Unstructured{Object: rolloutDeployment(1, 3, 3, 3)},
+		watch:      newChanWatcher(),
+	}
+	for _, obj := range []map[string]any{
+		rolloutDeployment(2, 4, 1, 3),
+		rolloutDeployment(2, 3, 3, 2),
+		rolloutDeployment(2, 3, 3, 3),
+	} {
+		resources.watch.ch <- WatchEvent{Type: WatchEventModified, Object: obj}
+	}
+	runtime := &restartRepo{}
+	discovery := &mockWatchDiscovery{}
+	uc := NewRuntimeUseCase(discovery, runtime, NewSessionStore(NewRealClock(), nil),
+		NewResourceUseCase(discovery, resources, nil, nil, nil, ApplyConfig{}, NamespaceConfig{}))
+
+	var progress []string
+	status, err := uc.RestartAndWait(context.Background(), waitID, 5*time.Second, func(s RolloutStatus) {
+		progress = append(progress, s.Message)
+	})
+	if err != nil {
+		t.Fatalf("RestartAndWait: %v", err)
+	}
+	if len(runtime.restarted) != 1 || runtime.restarted[0] != "web" {
+		t.Errorf("restarted = %v, want [web]", runtime.restarted)
+	}
+	if !status.Done || status.Updated != 3 || status.Available != 3 {
+		t.Errorf("final status = %+v, want done with 3 updated and available", status)
+	}
+	want := []string{
+		"Waiting for deployment spec update to be observed...",
+		`Waiting for deployment "web" rollout to finish: 1 out of 3 new replicas have been updated...`,
+		`Waiting for deployment "web" rollout to finish: 2 of 3 updated replicas are available...`,
+		`deployment "web" successfully rolled out`,
+	}
+	if strings.Join(progress, "\n") != strings.Join(want, "\n") {
+		t.Errorf("progress =\n%s\nwant\n%s", strings.Join(progress, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestRuntimeUseCase_RestartAndWait_InvalidTimeoutDoesNotRestart(t *testing.T) {
+	runtime := &restartRepo{}
+	uc := NewRuntimeUseCase(&mockWatchDiscovery{}, runtime, NewSessionStore(NewRealClock(), nil), nil)
+
+	var invalid *ErrInvalidInput
+	if _, err := uc.RestartAndWait(context.Background(), waitID, 0, nil); !isErrInvalidInput(err, &invalid) || invalid.Field != "timeout" {
+		t.Fatalf("expected ErrInvalidInput on timeout, got %v", err)
+	}
+	if len(runtime.restarted) != 0 {
+		t.Error("workload must not be restarted when the request is invalid")
+	}
+}
+
+// catExecRepo runs every exec like `cat`: it copies stdin to stdout
+// until stdin reaches EOF, then exits.
+type catExecRepo struct {
+	RuntimeRepo
+}
+
+func (catExecRepo) Exec(_ context.Context, _, _, _ string, opts ExecOptions) error {
+	_, err := io.Copy(opts.Stdout, opts.Stdin)
+	return err
+}
+
+func TestRuntimeUseCase_CloseExecStdin_CompletesCommand(t *testing.T) {
+	uc := NewRuntimeUseCase(nil, catExecRepo{}, NewSessionStore(NewRealClock(), nil), nil)
+	ctx := context.Background()
+
+	sess, stdout, _, err := uc.StartExec(ctx, StartExecParams{Cluster: "c1", Namespace: "default", Name: "web-0", Command: []string{"cat"}})
+	if err != nil {
+		t.Fatalf("StartExec: %v", err)
+	}
+	defer uc.CleanupExec(ctx, sess.ID)
+
+	output := make(chan string, 1)
+	go func() {
+		b, _ := io.ReadAll(stdout)
+		output <- string(b)
+	}()
+
+	if err := uc.WriteExec(ctx, sess.ID, []byte("hello\n")); err != nil {
+		t.Fatalf("WriteExec: %v", err)
+	}
+	if err := uc.CloseExecStdin(ctx, sess.ID); err != nil {
+		t.Fatalf("CloseExecStdin: %v", err)
+	}
+
+	select {
+	case err := <-sess.Done:
+		if err != nil {
+			t.Fatalf("exec finished with %v, want success", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("command did not finish after stdin was closed")
+	}
+	if got := <-output; got != "hello\n" {
+		t.Errorf("stdout = %q, want %q", got, "hello\n")
+	}
+
+	if err := uc.WriteExec(ctx, sess.ID, []byte("late")); err == nil {
+		t.Error("write after closing stdin succeeded, want an error")
+	}
+}
+
+// recordedSession is a session recorded by recordingSessionMetrics.
+type recordedSession struct {
+	kind     SessionKind
+	duration time.Duration
+	bytes    int64
+}
+
+// recordingSessionMetrics records every ended session.
+type recordingSessionMetrics struct {
+	mu       sync.Mutex
+	sessions []recordedSession
+}
+
+func (m *recordingSessionMetrics) SessionEnded(kind SessionKind, duration time.Duration, bytes int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions = append(m.sessions, recordedSession{kind: kind, duration: duration, bytes: bytes})
+}
+
+func TestRuntimeUseCase_RecordsEndedSessions(t *testing.T) {
+	clock := newFakeClock(time.Unix(1_700_000_000, 0))
+	metrics := &recordingSessionMetrics{}
+	store := NewSessionStore(clock, metrics)
+	uc := NewRuntimeUseCase(nil, catExecRepo{}, store, nil)
+	ctx := context.Background()
+
+	sess, stdout, _, err := uc.StartExec(ctx, StartExecParams{Cluster: "c1", Namespace: "default", Name: "web-0", Command: []string{"cat"}})
+	if err != nil {
+		t.Fatalf("StartExec: %v", err)
+	}
+	go func() { _, _ = io.Copy(io.Discard, stdout) }()
+	if err := uc.WriteExec(ctx, sess.ID, []byte("hello\n")); err != nil {
+		t.Fatalf("WriteExec: %v", err)
+	}
+	if err := uc.CloseExecStdin(ctx, sess.ID); err != nil {
+		t.Fatalf("CloseExecStdin: %v", err)
+	}
+	<-sess.Done
+
+	clock.Advance(90 * time.Second)
+	uc.CleanupExec(ctx, sess.ID)
+	uc.CleanupExec(ctx, sess.ID) // removed already, so not recorded twice
+
+	// A port-forward whose client went away is recorded by the reaper.
+	done := make(chan error)
+	close(done)
+	if err := store.PutPortForward(&PortForwardSession{ID: "pf-1", Done: done, Cancel: func() {}}); err != nil {
+		t.Fatalf("PutPortForward: %v", err)
+	}
+	clock.Advance(time.Minute)
+	if n := store.ReapStaleSessions(); n != 1 {
+		t.Fatalf("reaped %d sessions, want 1", n)
+	}
+
+	want := []recordedSession{
+		// "hello\n" in on stdin and out again on stdout.
+		{kind: SessionKindExec, duration: 90 * time.Second, bytes: 12},
+		{kind: SessionKindPortForward, duration: time.Minute},
+	}
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	if !slices.Equal(metrics.sessions, want) {
+		t.Errorf("recorded %+v, want %+v", metrics.sessions, want)
+	}
+}
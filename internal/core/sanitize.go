@@ -1,14 +1,13 @@
-package handler
+package core
 
-// cleanObject strips noisy metadata from a raw Kubernetes object map:
+// CleanObject strips noisy metadata from a raw Kubernetes object map:
 //   - metadata.managedFields (server-side apply bookkeeping)
 //   - the kubectl.kubernetes.io/last-applied-configuration annotation
 //
-// This is a presentation concern: the domain layer returns raw
-// Kubernetes objects and the handler sanitises them before serialising
-// to protobuf. Operating on map[string]any keeps the handler layer
-// free of k8s.io/apimachinery imports.
-func cleanObject(obj map[string]any) {
+// The handler applies it to listed objects before serialising them to
+// protobuf, and CompareAcrossClusters before diffing objects, since
+// neither field says anything about the object's desired state.
+func CleanObject(obj map[string]any) {
 	metadata, ok := obj["metadata"].(map[string]any)
 	if !ok {
 		return
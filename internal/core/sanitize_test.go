@@ -1,4 +1,4 @@
-package handler
+package core
 
 import (
 	"testing"
@@ -14,7 +14,7 @@ func TestCleanObject_RemovesManagedFields(t *testing.T) {
 		},
 	}
 
-	cleanObject(obj)
+	CleanObject(obj)
 
 	metadata := obj["metadata"].(map[string]any)
 	if _, exists := metadata["managedFields"]; exists {
@@ -35,7 +35,7 @@ func TestCleanObject_RemovesLastAppliedAnnotation(t *testing.T) {
 		},
 	}
 
-	cleanObject(obj)
+	CleanObject(obj)
 
 	annotations := obj["metadata"].(map[string]any)["annotations"].(map[string]any)
 	if _, exists := annotations["kubectl.kubernetes.io/last-applied-configuration"]; exists {
@@ -58,7 +58,7 @@ func TestCleanObject_RemovesAnnotationsFieldWhenEmpty(t *testing.T) {
 		},
 	}
 
-	cleanObject(obj)
+	CleanObject(obj)
 
 	metadata := obj["metadata"].(map[string]any)
 	if _, exists := metadata["annotations"]; exists {
@@ -76,7 +76,7 @@ func TestCleanObject_NoOpWhenClean(t *testing.T) {
 	}
 
 	// Should not panic or modify anything.
-	cleanObject(obj)
+	CleanObject(obj)
 
 	metadata := obj["metadata"].(map[string]any)
 	if metadata["name"] != "test-pod" {
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ServiceOperation names an operation that the server performs as
+// itself rather than on behalf of an end user. Requests authenticated
+// with a service token skip impersonation and reach the cluster with
+// the agent's own ServiceAccount, so each operation is limited to the
+// RPCs it needs by the transport layer.
+type ServiceOperation string
+
+// ServiceOperationBootstrap applies the bootstrap manifests that
+// install the agent's infrastructure on a cluster.
+const ServiceOperationBootstrap ServiceOperation = "bootstrap"
+
+// serviceOperations is the allow-list of operations for which service
+// tokens are issued and accepted.
+var serviceOperations = map[ServiceOperation]bool{
+	ServiceOperationBootstrap: true,
+}
+
+// ServiceGroup is the group of every service identity as seen by the
+// ClusterAuthorizer. A non-empty cluster access policy must grant it
+// the clusters service tokens may reach, e.g. "otterscale:services=*".
+const ServiceGroup = "otterscale:services"
+
+// ServiceTokenPrefix starts every service token, so that the
+// authentication middleware can tell them apart from OIDC tokens
+// without attempting to verify either.
+const ServiceTokenPrefix = "otterscale-service."
+
+// maxServiceTokenTTL bounds the lifetime of a service token.
+const maxServiceTokenTTL = time.Hour
+
+// serviceTokenPath is the resource path service tokens are bound to,
+// so that they can never be replayed as signed URLs and vice versa.
+const serviceTokenPath = "/service"
+
+// ServiceIdentity is the caller of a request authenticated with a
+// service token: the operation and the only cluster it was issued for.
+type ServiceIdentity struct {
+	Operation ServiceOperation
+	Cluster   string
+}
+
+// UserInfo returns the identity under which the ClusterAuthorizer
+// checks the service's access to a cluster.
+func (id ServiceIdentity) UserInfo() UserInfo {
+	return UserInfo{
+		Subject: "service:" + string(id.Operation),
+		Groups:  []string{ServiceGroup},
+	}
+}
+
+// serviceIdentityKey is the context key for the ServiceIdentity of a
+// request authenticated with a service token.
+type serviceIdentityKey struct{}
+
+// WithServiceIdentity returns a derived context marking the request as
+// performed by the server itself as id. It must only be called by the
+// authentication middleware after verifying a service token, never
+// with values taken from the request.
+func WithServiceIdentity(ctx context.Context, id ServiceIdentity) context.Context {
+	return context.WithValue(ctx, serviceIdentityKey{}, id)
+}
+
+// ServiceIdentityFromContext returns the identity stored by
+// WithServiceIdentity. It returns false for end-user requests.
+func ServiceIdentityFromContext(ctx context.Context) (ServiceIdentity, bool) {
+	id, ok := ctx.Value(serviceIdentityKey{}).(ServiceIdentity)
+	return id, ok
+}
+
+// ServiceTokens issues and verifies the short-lived, HMAC-signed
+// tokens that authenticate the server's own automation.
+type ServiceTokens struct {
+	signer *URLSigner
+}
+
+// NewServiceTokens returns a ServiceTokens that signs with hmacKey.
+// The key must be non-empty and should not be shared with other
+// signers.
+func NewServiceTokens(hmacKey []byte, clock Clock) (*ServiceTokens, error) {
+	signer, err := NewURLSigner("", hmacKey, clock)
+	if err != nil {
+		return nil, fmt.Errorf("service tokens: %w", err)
+	}
+	return &ServiceTokens{signer: signer}, nil
+}
+
+// Issue returns a service token for op on cluster that expires after
+// ttl, at most one hour.
+func (t *ServiceTokens) Issue(op ServiceOperation, cluster string, ttl time.Duration) (string, error) {
+	if !serviceOperations[op] {
+		return "", &ErrInvalidInput{Field: "operation", Message: fmt.Sprintf("unknown service operation %q", op)}
+	}
+	if err := ValidateClusterName(cluster); err != nil {
+		return "", err
+	}
+	if ttl <= 0 || ttl > maxServiceTokenTTL {
+		return "", &ErrInvalidInput{Field: "ttl", Message: fmt.Sprintf("must be between 0 and %s", maxServiceTokenTTL)}
+	}
+	token, err := t.signer.issueToken(serviceTokenPath, SignedURLClaims{Subject: string(op), Cluster: cluster}, ttl)
+	if err != nil {
+		return "", err
+	}
+	return ServiceTokenPrefix + token, nil
+}
+
+// Verify checks the signature and expiry of token and returns the
+// identity it was issued for. All failures return the same error.
+func (t *ServiceTokens) Verify(token string) (ServiceIdentity, error) {
+	raw, ok := strings.CutPrefix(token, ServiceTokenPrefix)
+	if !ok {
+		return ServiceIdentity{}, errInvalidToken
+	}
+	claims, err := t.signer.verifyDetailed(serviceTokenPath, raw, maxServiceTokenTTL)
+	if err != nil {
+		return ServiceIdentity{}, errInvalidToken
+	}
+	id := ServiceIdentity{Operation: ServiceOperation(claims.Subject), Cluster: claims.Cluster}
+	if !serviceOperations[id.Operation] || id.Cluster == "" {
+		return ServiceIdentity{}, errInvalidToken
+	}
+	return id, nil
+}
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServiceTokens_RoundTrip(t *testing.T) {
+	clock := newFakeClock(time.Unix(1700000000, 0))
+	tokens, err := NewServiceTokens([]byte("service-key"), clock)
+	if err != nil {
+		t.Fatalf("NewServiceTokens: %v", err)
+	}
+
+	token, err := tokens.Issue(ServiceOperationBootstrap, "edge-1", 10*time.Minute)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	if !strings.HasPrefix(token, ServiceTokenPrefix) {
+		t.Errorf("token %q lacks the service token prefix", token)
+	}
+	id, err := tokens.Verify(token)
+	want := ServiceIdentity{Operation: ServiceOperationBootstrap, Cluster: "edge-1"}
+	if err != nil || id != want {
+		t.Fatalf("Verify = %+v, %v, want %+v", id, err, want)
+	}
+
+	clock.Advance(11 * time.Minute)
+	if _, err := tokens.Verify(token); err == nil {
+		t.Error("expired token was accepted")
+	}
+}
+
+func TestServiceTokens_Rejects(t *testing.T) {
+	clock := newFakeClock(time.Unix(1700000000, 0))
+	tokens, _ := NewServiceTokens([]byte("service-key"), clock)
+	other, _ := NewServiceTokens([]byte("other-key"), clock)
+
+	if _, err := tokens.Issue("delete-everything", "edge-1", time.Minute); err == nil {
+		t.Error("token issued for an operation outside the allow-list")
+	}
+	if _, err := tokens.Issue(ServiceOperationBootstrap, "", time.Minute); err == nil {
+		t.Error("token issued without a cluster")
+	}
+	if _, err := tokens.Issue(ServiceOperationBootstrap, "edge-1", 2*time.Hour); err == nil {
+		t.Error("token issued with a lifetime above the maximum")
+	}
+
+	forged, _ := other.Issue(ServiceOperationBootstrap, "edge-1", time.Minute)
+	if _, err := tokens.Verify(forged); err == nil {
+		t.Error("token signed with another key was accepted")
+	}
+
+	// A signed URL token from the same key is bound to another path.
+	signer, _ := NewURLSigner("https://example.com", []byte("service-key"), clock)
+	urlToken, _ := signer.issueToken("/fleet/manifest", SignedURLClaims{Subject: string(ServiceOperationBootstrap), Cluster: "edge-1"}, time.Minute)
+	if _, err := tokens.Verify(ServiceTokenPrefix + urlToken); err == nil {
+		t.Error("signed URL token was accepted as a service token")
+	}
+}
+
+func TestApplyConfig_FieldManager_ServiceOperation(t *testing.T) {
+	c := ApplyConfig{FieldManagerPrefix: "otterscale:"}
+	ctx := WithServiceIdentity(context.Background(), ServiceIdentity{Operation: ServiceOperationBootstrap, Cluster: "edge-1"})
+	if got := c.fieldManager(ctx, ""); got != "otterscale:service:bootstrap" {
+		t.Errorf("field manager = %q, want otterscale:service:bootstrap", got)
+	}
+}
@@ -2,10 +2,13 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // ---------------------------------------------------------------------------
@@ -102,18 +105,84 @@ type ExecSession struct {
 	Cancel context.CancelFunc
 	// Done receives the error (or nil) when the exec goroutine finishes.
 	Done <-chan error
+
+	startedAt time.Time    // set by SessionStore.PutExec
+	bytes     atomic.Int64 // transferred in either direction
 }
 
 // PortForwardSession represents an active port-forward session.
 type PortForwardSession struct {
 	// ID is the unique session identifier.
 	ID string
-	// Writer is the writer side of the data pipe. WritePortForward writes here.
-	Writer io.WriteCloser
+	// Ports lists the forwarded pod ports in request order.
+	Ports []int32
+	// Writers holds the writer side of each port's data pipe, keyed
+	// by port. WritePortForward writes here.
+	Writers map[int32]io.WriteCloser
 	// Cancel stops the port-forward session.
 	Cancel context.CancelFunc
 	// Done receives the error (or nil) when the port-forward goroutine finishes.
 	Done <-chan error
+
+	startedAt time.Time    // set by SessionStore.PutPortForward
+	bytes     atomic.Int64 // transferred in either direction, over all ports
+}
+
+// closeWriters closes the writer of every forwarded port.
+func (s *PortForwardSession) closeWriters() error {
+	var errs []error
+	for _, w := range s.Writers {
+		if err := w.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// countingReader adds the number of bytes read through it to n.
+type countingReader struct {
+	r io.Reader
+	n *atomic.Int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+// countingWriter adds the number of bytes written through it to n.
+type countingWriter struct {
+	w io.Writer
+	n *atomic.Int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+// ---------------------------------------------------------------------------
+// Session metrics
+// ---------------------------------------------------------------------------
+
+// SessionKind distinguishes the kinds of sessions in metrics.
+type SessionKind string
+
+const (
+	// SessionKindExec is an exec session.
+	SessionKindExec SessionKind = "exec"
+	// SessionKindPortForward is a port-forward session.
+	SessionKindPortForward SessionKind = "port_forward"
+)
+
+// SessionMetrics records sessions as they end. Implementations live in
+// the infrastructure layer and must be safe for concurrent use.
+type SessionMetrics interface {
+	// SessionEnded records a session of kind that lasted duration and
+	// transferred bytes between the client and the pod.
+	SessionEnded(kind SessionKind, duration time.Duration, bytes int64)
 }
 
 // ---------------------------------------------------------------------------
@@ -132,18 +201,34 @@ const maxPortForwardSessions = 100
 // SessionStore manages active exec and port-forward sessions.
 type SessionStore struct {
 	mu       sync.RWMutex
+	clock    Clock
+	metrics  SessionMetrics
 	execSess map[string]*ExecSession
 	pfSess   map[string]*PortForwardSession
 }
 
-// NewSessionStore returns an initialised SessionStore.
-func NewSessionStore() *SessionStore {
+// NewSessionStore returns an initialised SessionStore. clock drives
+// the reaper started by StartReaper and times sessions. metrics, if
+// not nil, records every session removed from the store, whether by
+// its cleanup or by the reaper.
+func NewSessionStore(clock Clock, metrics SessionMetrics) *SessionStore {
 	return &SessionStore{
+		clock:    clock,
+		metrics:  metrics,
 		execSess: make(map[string]*ExecSession),
 		pfSess:   make(map[string]*PortForwardSession),
 	}
 }
 
+// ended records a removed session of kind that started at startedAt
+// and transferred bytes.
+func (s *SessionStore) ended(kind SessionKind, startedAt time.Time, bytes int64) {
+	if s.metrics == nil {
+		return
+	}
+	s.metrics.SessionEnded(kind, s.clock.Now().Sub(startedAt), bytes)
+}
+
 // PutExec stores an exec session. It returns an error if the maximum
 // number of concurrent exec sessions has been reached.
 func (s *SessionStore) PutExec(sess *ExecSession) error {
@@ -155,6 +240,7 @@ func (s *SessionStore) PutExec(sess *ExecSession) error {
 			Message: fmt.Sprintf("max concurrent exec sessions (%d) reached", maxExecSessions),
 		}
 	}
+	sess.startedAt = s.clock.Now()
 	s.execSess[sess.ID] = sess
 	return nil
 }
@@ -173,12 +259,15 @@ func (s *SessionStore) GetExec(id string) (*ExecSession, bool) {
 // ensuring only one caller can claim ownership of a session.
 func (s *SessionStore) RemoveExec(id string) *ExecSession {
 	s.mu.Lock()
-	defer s.mu.Unlock()
 	sess, ok := s.execSess[id]
+	if ok {
+		delete(s.execSess, id)
+	}
+	s.mu.Unlock()
 	if !ok {
 		return nil
 	}
-	delete(s.execSess, id)
+	s.ended(SessionKindExec, sess.startedAt, sess.bytes.Load())
 	return sess
 }
 
@@ -194,6 +283,7 @@ func (s *SessionStore) PutPortForward(sess *PortForwardSession) error {
 			Message: fmt.Sprintf("max concurrent port-forward sessions (%d) reached", maxPortForwardSessions),
 		}
 	}
+	sess.startedAt = s.clock.Now()
 	s.pfSess[sess.ID] = sess
 	return nil
 }
@@ -212,12 +302,15 @@ func (s *SessionStore) GetPortForward(id string) (*PortForwardSession, bool) {
 // ReapStaleSessions by ensuring only one caller can claim ownership.
 func (s *SessionStore) RemovePortForward(id string) *PortForwardSession {
 	s.mu.Lock()
-	defer s.mu.Unlock()
 	sess, ok := s.pfSess[id]
+	if ok {
+		delete(s.pfSess, id)
+	}
+	s.mu.Unlock()
 	if !ok {
 		return nil
 	}
-	delete(s.pfSess, id)
+	s.ended(SessionKindPortForward, sess.startedAt, sess.bytes.Load())
 	return sess
 }
 
@@ -258,17 +351,39 @@ func (s *SessionStore) ReapStaleSessions() int {
 
 	// Phase 2: cancel and close resources outside the lock.
 	for _, sess := range staleExec {
+		s.ended(SessionKindExec, sess.startedAt, sess.bytes.Load())
 		sess.Cancel()
 		if err := sess.Stdin.Close(); err != nil {
 			slog.Warn("failed to close exec stdin", "session", sess.ID, "error", err)
 		}
 	}
 	for _, sess := range stalePF {
+		s.ended(SessionKindPortForward, sess.startedAt, sess.bytes.Load())
 		sess.Cancel()
-		if err := sess.Writer.Close(); err != nil {
-			slog.Warn("failed to close port-forward writer", "session", sess.ID, "error", err)
+		if err := sess.closeWriters(); err != nil {
+			slog.Warn("failed to close port-forward writers", "session", sess.ID, "error", err)
 		}
 	}
 
 	return len(staleExec) + len(stalePF)
 }
+
+// StartReaper calls ReapStaleSessions every interval, varied by
+// jitter and measured by the store's clock. It blocks until ctx is
+// cancelled.
+func (s *SessionStore) StartReaper(ctx context.Context, interval time.Duration, jitter Jitter) {
+	log := slog.Default().With("component", "session-reaper")
+
+	for {
+		timer := s.clock.NewTimer(jitter.Apply(interval))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C():
+			if n := s.ReapStaleSessions(); n > 0 {
+				log.Info("reaped stale sessions", "count", n)
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"io"
 	"testing"
 )
 
@@ -62,7 +63,7 @@ func TestTerminalSizeQueue_SetAfterClose(t *testing.T) {
 }
 
 func TestSessionStore_ExecCRUD(t *testing.T) {
-	store := NewSessionStore()
+	store := NewSessionStore(NewRealClock(), nil)
 	done := make(chan error, 1)
 	done <- nil
 
@@ -104,7 +105,7 @@ func TestSessionStore_ExecCRUD(t *testing.T) {
 }
 
 func TestSessionStore_PortForwardCRUD(t *testing.T) {
-	store := NewSessionStore()
+	store := NewSessionStore(NewRealClock(), nil)
 	done := make(chan error, 1)
 	done <- nil
 
@@ -141,7 +142,7 @@ func TestSessionStore_PortForwardCRUD(t *testing.T) {
 }
 
 func TestSessionStore_ReapStaleSessions(t *testing.T) {
-	store := NewSessionStore()
+	store := NewSessionStore(NewRealClock(), nil)
 
 	// Create a "stale" exec session (Done already received a value).
 	execDone := make(chan error, 1)
@@ -174,10 +175,10 @@ func TestSessionStore_ReapStaleSessions(t *testing.T) {
 	close(pfDone)
 
 	if err := store.PutPortForward(&PortForwardSession{
-		ID:     "stale-pf",
-		Done:   pfDone,
-		Cancel: func() {},
-		Writer: &nopCloser{},
+		ID:      "stale-pf",
+		Done:    pfDone,
+		Cancel:  func() {},
+		Writers: map[int32]io.WriteCloser{8080: &nopCloser{}},
 	}); err != nil {
 		t.Fatalf("PutPortForward stale: %v", err)
 	}
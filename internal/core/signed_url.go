@@ -0,0 +1,221 @@
+package core
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// maxSignedURLTTL bounds the lifetime of any signed URL so that a
+// leaked link cannot grant long-term access.
+const maxSignedURLTTL = 24 * time.Hour
+
+// defaultSignedURLClockSkew is the tolerated difference between the
+// issuer's and the verifier's clocks when checking the issued-at claim,
+// unless configured otherwise.
+const defaultSignedURLClockSkew = 5 * time.Minute
+
+// errInvalidToken is the generic error returned for all token
+// verification failures. Using a single message prevents attackers
+// from inferring the verification stage that failed (e.g. decode vs
+// signature vs expiry).
+var errInvalidToken = errors.New("invalid or expired token")
+
+// SignedURLClaims is the identity and context bound to a signed URL.
+type SignedURLClaims struct {
+	// Subject is the user on whose behalf the URL was issued.
+	Subject string
+	// Cluster is the target cluster, if the resource is cluster-scoped.
+	Cluster string
+	// Attributes carries resource-specific parameters (e.g. the pod
+	// and container of a log export). They are covered by the
+	// signature, so the verifier can trust them.
+	Attributes map[string]string
+}
+
+// signedTokenClaims is the JSON payload embedded in signed tokens.
+// Res is omitted from tokens that predate resource-bound URLs; such
+// tokens are only honoured for legacyResourcePath.
+type signedTokenClaims struct {
+	Sub     string            `json:"sub"`
+	Cluster string            `json:"cluster"`
+	Res     string            `json:"res,omitempty"`
+	Attrs   map[string]string `json:"attrs,omitempty"`
+	Iat     int64             `json:"iat"`
+	Exp     int64             `json:"exp"`
+}
+
+// URLSigner issues and verifies short-lived, HMAC-signed URLs. A
+// signed URL has the form <baseURL><resourcePath>/<token>, where the
+// token binds the claims, the resource path, and an expiry. Features
+// that need to hand out download links (agent manifests, log exports,
+// one-time kubeconfigs) can use it without additional auth plumbing:
+// the token itself is the credential.
+//
+// The Clock is injected to decouple from wall-clock time, enabling
+// deterministic tests without time.Sleep or reflect hacks.
+type URLSigner struct {
+	baseURL string
+	hmacKey []byte
+	clock   Clock
+
+	// clockSkew is the tolerated difference between the issuer's and
+	// the verifier's clocks.
+	clockSkew time.Duration
+
+	// legacyResourcePath is the only resource path for which tokens
+	// without an embedded path are accepted.
+	legacyResourcePath string
+}
+
+// NewURLSigner returns a URLSigner that issues URLs under baseURL and
+// signs them with hmacKey. The key must be non-empty.
+func NewURLSigner(baseURL string, hmacKey []byte, clock Clock) (*URLSigner, error) {
+	if len(hmacKey) == 0 {
+		return nil, fmt.Errorf("url signer: HMAC key is required")
+	}
+	return &URLSigner{
+		baseURL:   strings.TrimRight(baseURL, "/"),
+		hmacKey:   hmacKey,
+		clock:     clock,
+		clockSkew: defaultSignedURLClockSkew,
+	}, nil
+}
+
+// IssueSignedURL returns a URL for resourcePath (e.g. "/fleet/manifest")
+// carrying a token that binds claims to that path and expires after
+// ttl.
+func (s *URLSigner) IssueSignedURL(resourcePath string, claims SignedURLClaims, ttl time.Duration) (string, error) {
+	token, err := s.issueToken(resourcePath, claims, ttl)
+	if err != nil {
+		return "", err
+	}
+	return s.baseURL + resourcePath + "/" + token, nil
+}
+
+// issueToken returns the token of a signed URL for resourcePath
+// without the URL around it.
+func (s *URLSigner) issueToken(resourcePath string, claims SignedURLClaims, ttl time.Duration) (string, error) {
+	if err := validateResourcePath(resourcePath); err != nil {
+		return "", err
+	}
+	if ttl <= 0 || ttl > maxSignedURLTTL {
+		return "", &ErrInvalidInput{Field: "ttl", Message: fmt.Sprintf("must be between 0 and %s", maxSignedURLTTL)}
+	}
+
+	now := s.clock.Now()
+	payload, err := json.Marshal(signedTokenClaims{
+		Sub:     claims.Subject,
+		Cluster: claims.Cluster,
+		Res:     resourcePath,
+		Attrs:   claims.Attributes,
+		Iat:     now.Unix(),
+		Exp:     now.Add(ttl).Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal token claims: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(s.sign(payload)), nil
+}
+
+// VerifySignedURL validates the signature and expiry of token and
+// checks that it was issued for resourcePath. All verification
+// failures return a generic error to avoid leaking which stage
+// failed; detailed reasons are available via verifyDetailed.
+func (s *URLSigner) VerifySignedURL(resourcePath, token string) (SignedURLClaims, error) {
+	claims, err := s.verifyDetailed(resourcePath, token, maxSignedURLTTL)
+	if err != nil {
+		return SignedURLClaims{}, errInvalidToken
+	}
+	return claims, nil
+}
+
+// verifyDetailed performs the actual token verification with detailed
+// error messages for logging. The public VerifySignedURL method wraps
+// failures into a generic error before returning to the caller.
+//
+// maxAge is the longest lifetime a token for resourcePath may have:
+// tokens issued more than maxAge ago, or with a longer lifetime, are
+// rejected even if they have not expired, so that tokens minted with
+// a longer TTL (or by an issuer whose clock ran behind) cannot be
+// replayed beyond the intended window.
+func (s *URLSigner) verifyDetailed(resourcePath, token string, maxAge time.Duration) (SignedURLClaims, error) {
+	parts := strings.SplitN(token, ".", 2)
+	if len(parts) != 2 {
+		return SignedURLClaims{}, fmt.Errorf("malformed token")
+	}
+
+	payloadBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return SignedURLClaims{}, fmt.Errorf("decode payload: %w", err)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return SignedURLClaims{}, fmt.Errorf("decode signature: %w", err)
+	}
+
+	// Verify HMAC before trusting any payload content.
+	if !hmac.Equal(sig, s.sign(payloadBytes)) {
+		return SignedURLClaims{}, fmt.Errorf("invalid token signature")
+	}
+
+	var claims signedTokenClaims
+	if err := json.Unmarshal(payloadBytes, &claims); err != nil {
+		return SignedURLClaims{}, fmt.Errorf("parse token claims: %w", err)
+	}
+
+	// Bind the token to the resource it was issued for, so that a
+	// link for one artifact cannot be replayed against another.
+	if claims.Res != resourcePath && (claims.Res != "" || resourcePath != s.legacyResourcePath) {
+		return SignedURLClaims{}, fmt.Errorf("token issued for a different resource")
+	}
+
+	now := s.clock.Now().Unix()
+
+	if now > claims.Exp {
+		return SignedURLClaims{}, fmt.Errorf("token expired")
+	}
+
+	// Sanity-check iat: reject tokens that claim to be issued in
+	// the future (beyond the clock skew allowance) or that are older
+	// than maxAge. This limits the replay window for leaked tokens.
+	skew := int64(s.clockSkew.Seconds())
+	ttl := int64(maxAge.Seconds())
+	if claims.Iat > now+skew {
+		return SignedURLClaims{}, fmt.Errorf("token issued in the future (iat %d, now %d, allowed skew %s)", claims.Iat, now, s.clockSkew)
+	}
+	if claims.Exp-claims.Iat > ttl || now-claims.Iat > ttl+skew {
+		return SignedURLClaims{}, fmt.Errorf("token too old (iat %d, now %d, max age %s)", claims.Iat, now, maxAge)
+	}
+
+	return SignedURLClaims{
+		Subject:    claims.Sub,
+		Cluster:    claims.Cluster,
+		Attributes: claims.Attrs,
+	}, nil
+}
+
+func (s *URLSigner) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, s.hmacKey)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// validateResourcePath requires an absolute, canonical path without a
+// trailing slash, so that the path embedded in a token matches the one
+// the verifier checks byte for byte.
+func validateResourcePath(p string) error {
+	if !strings.HasPrefix(p, "/") || p == "/" || path.Clean(p) != p {
+		return &ErrInvalidInput{Field: "resource_path", Message: fmt.Sprintf("must be an absolute, canonical path, got %q", p)}
+	}
+	return nil
+}
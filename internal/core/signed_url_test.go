@@ -0,0 +1,113 @@
+package core
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestURLSigner(t *testing.T, clock Clock) *URLSigner {
+	t.Helper()
+	signer, err := NewURLSigner("https://server.example.com/", testFleetConfig().HMACKey, clock)
+	if err != nil {
+		t.Fatalf("NewURLSigner: %v", err)
+	}
+	return signer
+}
+
+func tokenFromURL(t *testing.T, url, resourcePath string) string {
+	t.Helper()
+	prefix := "https://server.example.com" + resourcePath + "/"
+	if !strings.HasPrefix(url, prefix) {
+		t.Fatalf("URL %q does not start with %q", url, prefix)
+	}
+	return strings.TrimPrefix(url, prefix)
+}
+
+func TestURLSigner_IssueAndVerify_LogExport(t *testing.T) {
+	clock := newFakeClock(time.Unix(1_700_000_000, 0))
+	signer := newTestURLSigner(t, clock)
+
+	claims := SignedURLClaims{
+		Subject:    "alice",
+		Cluster:    "edge-1",
+		Attributes: map[string]string{"namespace": "default", "pod": "web-0"},
+	}
+	url, err := signer.IssueSignedURL("/runtime/logs/export", claims, 10*time.Minute)
+	if err != nil {
+		t.Fatalf("IssueSignedURL: %v", err)
+	}
+	token := tokenFromURL(t, url, "/runtime/logs/export")
+
+	got, err := signer.VerifySignedURL("/runtime/logs/export", token)
+	if err != nil {
+		t.Fatalf("VerifySignedURL: %v", err)
+	}
+	if got.Subject != "alice" || got.Cluster != "edge-1" || got.Attributes["pod"] != "web-0" {
+		t.Errorf("unexpected claims: %+v", got)
+	}
+
+	// The token is bound to its resource path.
+	if _, err := signer.VerifySignedURL(manifestResourcePath, token); err == nil {
+		t.Error("token must not verify for a different resource path")
+	}
+
+	// And to its TTL.
+	clock.Advance(10*time.Minute + time.Second)
+	if _, err := signer.VerifySignedURL("/runtime/logs/export", token); err == nil {
+		t.Error("token must not verify after its TTL")
+	}
+}
+
+func TestURLSigner_IssueValidation(t *testing.T) {
+	signer := newTestURLSigner(t, NewRealClock())
+
+	tests := []struct {
+		name string
+		path string
+		ttl  time.Duration
+	}{
+		{"relative path", "runtime/logs", time.Minute},
+		{"trailing slash", "/runtime/logs/", time.Minute},
+		{"dot-dot", "/runtime/../fleet/manifest", time.Minute},
+		{"root", "/", time.Minute},
+		{"zero ttl", "/runtime/logs", 0},
+		{"ttl above maximum", "/runtime/logs", maxSignedURLTTL + time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := signer.IssueSignedURL(tt.path, SignedURLClaims{Subject: "alice"}, tt.ttl)
+			var invalid *ErrInvalidInput
+			if !isErrInvalidInput(err, &invalid) {
+				t.Fatalf("expected ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+}
+
+func TestURLSigner_LegacyTokenOnlyForLegacyPath(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	signer := newTestURLSigner(t, newFakeClock(now))
+	signer.legacyResourcePath = manifestResourcePath
+
+	// A token in the pre-existing manifest format: no resource path.
+	payload := []byte(`{"sub":"alice","cluster":"edge-1","iat":1700000000,"exp":1700003600}`)
+	mac := hmac.New(sha256.New, testFleetConfig().HMACKey)
+	mac.Write(payload)
+	token := base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+
+	got, err := signer.VerifySignedURL(manifestResourcePath, token)
+	if err != nil {
+		t.Fatalf("legacy manifest token should verify: %v", err)
+	}
+	if got.Subject != "alice" || got.Cluster != "edge-1" {
+		t.Errorf("unexpected claims: %+v", got)
+	}
+
+	if _, err := signer.VerifySignedURL("/runtime/logs/export", token); err == nil {
+		t.Error("legacy token must not verify for other resource paths")
+	}
+}
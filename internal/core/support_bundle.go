@@ -0,0 +1,358 @@
+package core
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// AgentNamespace is the namespace the agent is installed in by the
+// generated agent manifest.
+const AgentNamespace = "otterscale-system"
+
+// agentPodSelector selects the agent's pods in AgentNamespace.
+const agentPodSelector = "app=otterscale-agent"
+
+// supportBundleResourcePath is the server path under which signed
+// support bundle URLs are served.
+const supportBundleResourcePath = "/fleet/support-bundle"
+
+// supportBundleTTL is how long a collected bundle is kept and its
+// signed URL is valid.
+const supportBundleTTL = 15 * time.Minute
+
+// Limits on the log output collected per agent pod, so that a chatty
+// agent cannot blow up the bundle.
+const (
+	supportBundleLogTailLines = 10000
+	supportBundleLogBytes     = 8 << 20 // 8 MiB
+)
+
+// maxSupportBundlePods caps the agent pods whose logs are collected.
+// The agent runs a single replica, so more than one pod only shows up
+// during a rollout. A bundle is thus at most 16 MiB of logs plus a few
+// hundred KiB of reports.
+const maxSupportBundlePods = 2
+
+// Bounds on the bundles held in memory. When either is reached, the
+// bundles closest to expiry are discarded first. The byte bound caps
+// memory use at 64 MiB, or four bundles of the worst case above; the
+// count only matters for the far more common small bundles.
+const (
+	maxSupportBundles          = 16
+	maxSupportBundleStoreBytes = 64 << 20 // 64 MiB
+)
+
+// supportBundleEventLimit caps the number of events collected from
+// AgentNamespace.
+const supportBundleEventLimit = 500
+
+var podsGVR = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+// SupportBundle is a collected support bundle, ready for download.
+type SupportBundle struct {
+	URL       string
+	ExpiresAt time.Time
+	// Errors lists the parts that could not be collected. They are
+	// also recorded in the archive's errors.txt.
+	Errors []string
+}
+
+// storedSupportBundle is a bundle archive held until it expires.
+type storedSupportBundle struct {
+	cluster   string
+	data      []byte
+	createdAt time.Time
+	expiresAt time.Time
+}
+
+// SupportBundleUseCase collects support bundles from agents and keeps
+// them in memory for download through short-lived signed URLs. A
+// bundle combines, in one zip archive, what is otherwise gathered by
+// hand when troubleshooting an agent: its diagnostics report, its pod
+// logs and the recent events in its namespace. It is safe for
+// concurrent use.
+//
+// Bundles are not shared between server replicas: a download URL only
+// works when it reaches the replica that collected the bundle, so
+// deployments with several replicas need session affinity for it.
+type SupportBundleUseCase struct {
+	diagnostics AgentDiagnosticsRepo
+	resource    ResourceRepo
+	runtime     RuntimeRepo
+	signer      *URLSigner
+	clock       Clock
+
+	mu      sync.Mutex
+	bundles map[string]storedSupportBundle
+	// size is the total size of the archives in bundles.
+	size int
+}
+
+// NewSupportBundleUseCase returns a SupportBundleUseCase collecting
+// through the given repositories. Download URLs are issued under the
+// server URL of manifestCfg and signed with its HMAC key.
+func NewSupportBundleUseCase(diagnostics AgentDiagnosticsRepo, resource ResourceRepo, runtime RuntimeRepo, manifestCfg AgentManifestConfig, clock Clock) (*SupportBundleUseCase, error) {
+	signer, err := NewURLSigner(manifestCfg.ServerURL, manifestCfg.HMACKey, clock)
+	if err != nil {
+		return nil, err
+	}
+	if manifestCfg.ClockSkew > 0 {
+		signer.clockSkew = manifestCfg.ClockSkew
+	}
+	return &SupportBundleUseCase{
+		diagnostics: diagnostics,
+		resource:    resource,
+		runtime:     runtime,
+		signer:      signer,
+		clock:       clock,
+		bundles:     map[string]storedSupportBundle{},
+	}, nil
+}
+
+// CollectSupportBundle collects a support bundle from cluster's agent
+// with the caller's permissions and returns a signed URL for
+// downloading it. Parts that cannot be collected, for instance because
+// the caller may not read pod logs, are reported in the bundle's
+// Errors instead of failing the collection.
+func (uc *SupportBundleUseCase) CollectSupportBundle(ctx context.Context, cluster string) (*SupportBundle, error) {
+	if err := ValidateClusterName(cluster); err != nil {
+		return nil, err
+	}
+	user, ok := UserInfoFromContext(ctx)
+	if !ok {
+		return nil, &DomainError{Code: ErrorCodeUnauthenticated, Message: "user info not found in context"}
+	}
+
+	now := uc.clock.Now()
+	data, errs, err := uc.collect(ctx, cluster, user.Subject, now)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := newSupportBundleID()
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := now.Add(supportBundleTTL)
+	url, err := uc.signer.IssueSignedURL(supportBundleResourcePath, SignedURLClaims{
+		Subject:    user.Subject,
+		Cluster:    cluster,
+		Attributes: map[string]string{"bundle": id},
+	}, supportBundleTTL)
+	if err != nil {
+		return nil, fmt.Errorf("issue support bundle token: %w", err)
+	}
+	uc.store(id, storedSupportBundle{cluster: cluster, data: data, createdAt: now, expiresAt: expiresAt})
+
+	slog.Info("audit: support bundle collected",
+		"user", user.Subject,
+		"client_ip", clientIP(ctx),
+		"cluster", cluster,
+		"bytes", len(data),
+		"errors", len(errs),
+	)
+	return &SupportBundle{URL: url, ExpiresAt: expiresAt, Errors: errs}, nil
+}
+
+// DownloadSupportBundle validates a support bundle token and returns
+// the file name and zip archive of the bundle it was issued for. As
+// with VerifyManifestToken, failures return a generic error and
+// detailed reasons are logged at debug level.
+func (uc *SupportBundleUseCase) DownloadSupportBundle(ctx context.Context, token string) (name string, data []byte, err error) {
+	claims, err := uc.signer.verifyDetailed(supportBundleResourcePath, token, supportBundleTTL)
+	if err != nil {
+		slog.Debug("support bundle token verification failed", "error", err)
+		return "", nil, errInvalidToken
+	}
+
+	uc.mu.Lock()
+	bundle, ok := uc.bundles[claims.Attributes["bundle"]]
+	uc.mu.Unlock()
+	if !ok || bundle.cluster != claims.Cluster || !uc.clock.Now().Before(bundle.expiresAt) {
+		slog.Debug("support bundle not found", "cluster", claims.Cluster, "bundle", claims.Attributes["bundle"])
+		return "", nil, errInvalidToken
+	}
+
+	name = fmt.Sprintf("%s-support-bundle-%s.zip", bundle.cluster, bundle.createdAt.UTC().Format("20060102T150405Z"))
+	return name, bundle.data, nil
+}
+
+// store adds a bundle, first discarding expired bundles and, while
+// the store is full, the bundles closest to expiry.
+func (uc *SupportBundleUseCase) store(id string, bundle storedSupportBundle) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	now := uc.clock.Now()
+	for k, b := range uc.bundles {
+		if !now.Before(b.expiresAt) {
+			uc.discard(k)
+		}
+	}
+	for len(uc.bundles) > 0 && (len(uc.bundles) >= maxSupportBundles || uc.size+len(bundle.data) > maxSupportBundleStoreBytes) {
+		oldest := ""
+		for k, b := range uc.bundles {
+			if oldest == "" || b.expiresAt.Before(uc.bundles[oldest].expiresAt) {
+				oldest = k
+			}
+		}
+		uc.discard(oldest)
+	}
+	uc.bundles[id] = bundle
+	uc.size += len(bundle.data)
+}
+
+// discard removes the bundle id. The caller must hold uc.mu.
+func (uc *SupportBundleUseCase) discard(id string) {
+	uc.size -= len(uc.bundles[id].data)
+	delete(uc.bundles, id)
+}
+
+// supportBundleInfo is written to bundle.json and identifies the
+// bundle.
+type supportBundleInfo struct {
+	Cluster     string    `json:"cluster"`
+	CollectedAt time.Time `json:"collected_at"`
+	CollectedBy string    `json:"collected_by"`
+	Namespace   string    `json:"namespace"`
+}
+
+// collect gathers the bundle's parts from cluster and returns the zip
+// archive together with the parts that could not be collected.
+func (uc *SupportBundleUseCase) collect(ctx context.Context, cluster, userName string, now time.Time) ([]byte, []string, error) {
+	var (
+		buf  bytes.Buffer
+		errs []string
+	)
+	zw := zip.NewWriter(&buf)
+	failed := func(part string, err error) {
+		errs = append(errs, fmt.Sprintf("%s: %v", part, err))
+	}
+
+	if err := writeJSONEntry(zw, now, "bundle.json", supportBundleInfo{
+		Cluster:     cluster,
+		CollectedAt: now,
+		CollectedBy: userName,
+		Namespace:   AgentNamespace,
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	// The agent redacts secret configuration values before its report
+	// leaves the cluster, so the report can be archived as is.
+	diag, err := uc.diagnostics.AgentDiagnostics(ctx, cluster)
+	if err != nil {
+		failed("diagnostics", err)
+	} else {
+		entries := []struct {
+			name string
+			v    any
+		}{
+			{"diagnostics.json", diag},
+			{"config.json", diag.Config},
+			{"tunnel.json", diag.Tunnel},
+		}
+		for _, e := range entries {
+			if err := writeJSONEntry(zw, now, e.name, e.v); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	events, err := uc.resource.ListEvents(ctx, cluster, AgentNamespace, ListOptions{Limit: supportBundleEventLimit})
+	if err != nil {
+		failed("events", err)
+	} else if err := writeJSONEntry(zw, now, "events.json", events); err != nil {
+		return nil, nil, err
+	}
+
+	pods, err := uc.resource.List(ctx, cluster, podsGVR, AgentNamespace, ListOptions{LabelSelector: agentPodSelector})
+	if err != nil {
+		failed("logs", err)
+	} else {
+		items := pods.Items
+		if len(items) > maxSupportBundlePods {
+			failed("logs", fmt.Errorf("%d of %d agent pods skipped", len(items)-maxSupportBundlePods, len(items)))
+			items = items[:maxSupportBundlePods]
+		}
+		for _, pod := range items {
+			if err := uc.collectPodLogs(ctx, zw, now, cluster, pod.GetName()); err != nil {
+				failed("logs/"+pod.GetName(), err)
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: "errors.txt", Method: zip.Deflate, Modified: now})
+		if err != nil {
+			return nil, nil, fmt.Errorf("write support bundle: %w", err)
+		}
+		if _, err := io.WriteString(w, strings.Join(errs, "\n")+"\n"); err != nil {
+			return nil, nil, fmt.Errorf("write support bundle: %w", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, nil, fmt.Errorf("write support bundle: %w", err)
+	}
+	return buf.Bytes(), errs, nil
+}
+
+// collectPodLogs writes the tail of an agent pod's log to
+// logs/<pod>.log. Output already copied is kept if reading the log
+// fails midway.
+func (uc *SupportBundleUseCase) collectPodLogs(ctx context.Context, zw *zip.Writer, now time.Time, cluster, pod string) error {
+	tail, limit := int64(supportBundleLogTailLines), int64(supportBundleLogBytes)
+	logs, err := uc.runtime.PodLogs(ctx, cluster, AgentNamespace, pod, PodLogOptions{
+		TailLines:  &tail,
+		LimitBytes: &limit,
+		Timestamps: true,
+	})
+	if err != nil {
+		return err
+	}
+	defer logs.Close()
+
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: "logs/" + pod + ".log", Method: zip.Deflate, Modified: now})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, io.LimitReader(logs, supportBundleLogBytes))
+	return err
+}
+
+// writeJSONEntry writes v as indented JSON to the archive entry name.
+func writeJSONEntry(zw *zip.Writer, now time.Time, name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", name, err)
+	}
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: now})
+	if err != nil {
+		return fmt.Errorf("write support bundle: %w", err)
+	}
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write support bundle: %w", err)
+	}
+	return nil
+}
+
+// newSupportBundleID returns a random identifier for a stored bundle.
+func newSupportBundleID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate support bundle id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,198 @@
+package core
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// bundleDiagnostics serves a fixed diagnostics report.
+type bundleDiagnostics struct {
+	diag *AgentDiagnostics
+}
+
+func (d *bundleDiagnostics) AgentDiagnostics(_ context.Context, _ string) (*AgentDiagnostics, error) {
+	return d.diag, nil
+}
+
+// bundleRepo serves the agent's pods, their logs and its namespace's
+// events. Logs of pods missing from logs fail.
+type bundleRepo struct {
+	ResourceRepo
+	RuntimeRepo
+	pods []string
+	logs map[string]string
+}
+
+func (r *bundleRepo) List(_ context.Context, _ string, gvr schema.GroupVersionResource, namespace string, opts ListOptions) (*unstructured.UnstructuredList, error) {
+	if gvr != podsGVR || namespace != AgentNamespace || opts.LabelSelector != agentPodSelector {
+		return nil, errors.New("unexpected list")
+	}
+	list := &unstructured.UnstructuredList{}
+	for _, name := range r.pods {
+		list.Items = append(list.Items, testPod(name, "1"))
+	}
+	return list, nil
+}
+
+func (r *bundleRepo) ListEvents(_ context.Context, _, namespace string, _ ListOptions) (*unstructured.UnstructuredList, error) {
+	event := unstructured.Unstructured{}
+	event.SetAPIVersion("v1")
+	event.SetKind("Event")
+	event.SetNamespace(namespace)
+	event.SetName("otterscale-agent.1")
+	return &unstructured.UnstructuredList{
+		Object: map[string]any{"apiVersion": "v1", "kind": "EventList"},
+		Items:  []unstructured.Unstructured{event},
+	}, nil
+}
+
+func (r *bundleRepo) PodLogs(_ context.Context, _, _, name string, _ PodLogOptions) (io.ReadCloser, error) {
+	logs, ok := r.logs[name]
+	if !ok {
+		return nil, errors.New("forbidden")
+	}
+	return io.NopCloser(strings.NewReader(logs)), nil
+}
+
+func TestSupportBundleUseCase_CollectAndDownload(t *testing.T) {
+	repo := &bundleRepo{
+		pods: []string{"agent-a", "agent-b"},
+		logs: map[string]string{"agent-a": "tunnel connected\n"},
+	}
+	diag := &bundleDiagnostics{diag: &AgentDiagnostics{
+		AgentVersion: "v1.2.3",
+		Config:       []AgentSetting{{Key: "agent.cluster", Value: "edge-1", Source: "env"}},
+		Tunnel:       AgentTunnelStatus{Connected: true, Reconnects: 2},
+	}}
+	clock := newFakeClock(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	uc, err := NewSupportBundleUseCase(diag, repo, repo, testFleetConfig(), clock)
+	if err != nil {
+		t.Fatalf("NewSupportBundleUseCase: %v", err)
+	}
+	ctx := WithUserInfo(context.Background(), UserInfo{Subject: "alice"})
+
+	bundle, err := uc.CollectSupportBundle(ctx, "edge-1")
+	if err != nil {
+		t.Fatalf("CollectSupportBundle: %v", err)
+	}
+	if !strings.HasPrefix(bundle.URL, "https://server.example.com/fleet/support-bundle/") {
+		t.Errorf("URL = %q, want a signed support bundle URL", bundle.URL)
+	}
+	if len(bundle.Errors) != 1 || !strings.HasPrefix(bundle.Errors[0], "logs/agent-b:") {
+		t.Errorf("errors = %q, want only the logs of agent-b", bundle.Errors)
+	}
+
+	token := bundle.URL[strings.LastIndex(bundle.URL, "/")+1:]
+	name, data, err := uc.DownloadSupportBundle(context.Background(), token)
+	if err != nil {
+		t.Fatalf("DownloadSupportBundle: %v", err)
+	}
+	if name != "edge-1-support-bundle-20260102T030405Z.zip" {
+		t.Errorf("name = %q", name)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("open archive: %v", err)
+	}
+	entries := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		entries[f.Name] = string(content)
+	}
+	var names []string
+	for name := range entries {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	want := []string{"bundle.json", "config.json", "diagnostics.json", "errors.txt", "events.json", "logs/agent-a.log", "tunnel.json"}
+	if !slices.Equal(names, want) {
+		t.Fatalf("entries = %v, want %v", names, want)
+	}
+	for name, substr := range map[string]string{
+		"bundle.json":      `"collected_by": "alice"`,
+		"config.json":      `"key": "agent.cluster"`,
+		"diagnostics.json": `"agent_version": "v1.2.3"`,
+		"errors.txt":       "logs/agent-b: forbidden",
+		"events.json":      `"otterscale-agent.1"`,
+		"logs/agent-a.log": "tunnel connected",
+		"tunnel.json":      `"reconnects": 2`,
+	} {
+		if !strings.Contains(entries[name], substr) {
+			t.Errorf("%s = %q, want it to contain %q", name, entries[name], substr)
+		}
+	}
+
+	clock.Advance(supportBundleTTL)
+	if _, _, err := uc.DownloadSupportBundle(context.Background(), token); err == nil {
+		t.Error("download of an expired bundle succeeded")
+	}
+}
+
+func TestSupportBundleUseCase_StoreIsBounded(t *testing.T) {
+	clock := newFakeClock(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	uc, err := NewSupportBundleUseCase(nil, nil, nil, testFleetConfig(), clock)
+	if err != nil {
+		t.Fatalf("NewSupportBundleUseCase: %v", err)
+	}
+	for i := range maxSupportBundles + 1 {
+		clock.Advance(time.Second)
+		uc.store(string(rune('a'+i)), storedSupportBundle{expiresAt: clock.Now().Add(supportBundleTTL)})
+	}
+	if len(uc.bundles) != maxSupportBundles {
+		t.Errorf("stored %d bundles, want %d", len(uc.bundles), maxSupportBundles)
+	}
+	if _, ok := uc.bundles["a"]; ok {
+		t.Error("the bundle closest to expiry was kept")
+	}
+	// Large bundles are bounded by their total size instead.
+	large := make([]byte, maxSupportBundleStoreBytes/2)
+	for _, id := range []string{"x", "y", "z"} {
+		clock.Advance(time.Second)
+		uc.store(id, storedSupportBundle{data: large, expiresAt: clock.Now().Add(supportBundleTTL)})
+	}
+	if uc.size > maxSupportBundleStoreBytes {
+		t.Errorf("stored %d bytes, more than %d", uc.size, maxSupportBundleStoreBytes)
+	}
+	if _, ok := uc.bundles["x"]; ok {
+		t.Error("the large bundle closest to expiry was kept")
+	}
+	if _, ok := uc.bundles["z"]; !ok {
+		t.Error("the newest bundle was not stored")
+	}
+}
+
+func TestSupportBundleUseCase_CapsCollectedPods(t *testing.T) {
+	repo := &bundleRepo{
+		pods: []string{"agent-a", "agent-b", "agent-c"},
+		logs: map[string]string{"agent-a": "a\n", "agent-b": "b\n", "agent-c": "c\n"},
+	}
+	diag := &bundleDiagnostics{diag: &AgentDiagnostics{}}
+	uc, err := NewSupportBundleUseCase(diag, repo, repo, testFleetConfig(), NewRealClock())
+	if err != nil {
+		t.Fatalf("NewSupportBundleUseCase: %v", err)
+	}
+
+	bundle, err := uc.CollectSupportBundle(WithUserInfo(context.Background(), UserInfo{Subject: "alice"}), "edge-1")
+	if err != nil {
+		t.Fatalf("CollectSupportBundle: %v", err)
+	}
+	if want := "logs: 1 of 3 agent pods skipped"; !slices.Contains(bundle.Errors, want) {
+		t.Errorf("errors = %q, want %q", bundle.Errors, want)
+	}
+}
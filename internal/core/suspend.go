@@ -0,0 +1,90 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// SuspendedReplicasAnnotation records, on a suspended workload, the
+// replica count it had before Suspend scaled it to zero.
+const SuspendedReplicasAnnotation = "otterscale.io/suspended-replicas"
+
+// Suspend scales the workload identified by id to zero replicas,
+// remembering its current count in SuspendedReplicasAnnotation so that
+// Resume can restore it, and returns the remembered count.
+//
+// A workload that is already at zero is left untouched: if it was
+// suspended before, the count saved then is returned; otherwise there
+// is nothing to remember and zero is returned.
+func (uc *RuntimeUseCase) Suspend(ctx context.Context, id ResourceIdentifier) (int32, error) {
+	current, err := uc.GetScale(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	if current == 0 {
+		obj, err := uc.resources.GetResource(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		saved, _, err := suspendedReplicas(obj.GetAnnotations())
+		return saved, err
+	}
+
+	// Record the count before scaling, so that a failed scale leaves
+	// a harmless annotation rather than a workload nobody can resume.
+	if _, err := uc.resources.SetAnnotation(ctx, id, SuspendedReplicasAnnotation, strconv.FormatInt(int64(current), 10)); err != nil {
+		return 0, err
+	}
+	if _, err := uc.Scale(ctx, id, 0); err != nil {
+		return 0, err
+	}
+	return current, nil
+}
+
+// Resume scales the workload identified by id back to the replica
+// count saved by Suspend, removes SuspendedReplicasAnnotation, and
+// returns the new count. A workload without the annotation was not
+// suspended and is left as it is; its current count is returned.
+func (uc *RuntimeUseCase) Resume(ctx context.Context, id ResourceIdentifier) (int32, error) {
+	if id.Name == "" {
+		return 0, &ErrInvalidInput{Field: "name", Message: "resource name is required"}
+	}
+	obj, err := uc.resources.GetResource(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	saved, ok, err := suspendedReplicas(obj.GetAnnotations())
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return uc.GetScale(ctx, id)
+	}
+
+	replicas, err := uc.Scale(ctx, id, saved)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := uc.resources.RemoveAnnotation(ctx, id, SuspendedReplicasAnnotation); err != nil {
+		return 0, err
+	}
+	return replicas, nil
+}
+
+// suspendedReplicas parses SuspendedReplicasAnnotation from
+// annotations, reporting whether it is present.
+func suspendedReplicas(annotations map[string]string) (int32, bool, error) {
+	v, ok := annotations[SuspendedReplicasAnnotation]
+	if !ok {
+		return 0, false, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 32)
+	if err != nil || n < 0 {
+		return 0, false, &DomainError{
+			Code:    ErrorCodeFailedPrecondition,
+			Message: fmt.Sprintf("annotation %s has invalid replica count %q", SuspendedReplicasAnnotation, v),
+		}
+	}
+	return int32(n), true, nil
+}
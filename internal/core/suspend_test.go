@@ -0,0 +1,134 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// fakeWorkload is a single scalable workload served both as a resource
+// (Get and annotation merge patches) and through its /scale
+// subresource.
+type fakeWorkload struct {
+	ResourceRepo
+	RuntimeRepo
+	replicas    int32
+	annotations map[string]string
+}
+
+func (f *fakeWorkload) Get(_ context.Context, _ string, _ schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetAnnotations(f.annotations)
+	return obj, nil
+}
+
+func (f *fakeWorkload) MergePatch(ctx context.Context, cluster string, gvr schema.GroupVersionResource, namespace, name string, patch []byte) (*unstructured.Unstructured, error) {
+	var p struct {
+		Metadata struct {
+			Annotations map[string]*string `json:"annotations"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, err
+	}
+	for k, v := range p.Metadata.Annotations {
+		if v == nil {
+			delete(f.annotations, k)
+			continue
+		}
+		if f.annotations == nil {
+			f.annotations = map[string]string{}
+		}
+		f.annotations[k] = *v
+	}
+	return f.Get(ctx, cluster, gvr, namespace, name)
+}
+
+func (f *fakeWorkload) GetScale(context.Context, string, schema.GroupVersionResource, string, string) (int32, error) {
+	return f.replicas, nil
+}
+
+func (f *fakeWorkload) UpdateScale(_ context.Context, _ string, _ schema.GroupVersionResource, _, _ string, replicas int32) (int32, error) {
+	f.replicas = replicas
+	return replicas, nil
+}
+
+func newSuspendUseCase(w *fakeWorkload) *RuntimeUseCase {
+	discovery := &mockWatchDiscovery{}
+	return NewRuntimeUseCase(discovery, w, NewSessionStore(NewRealClock(), nil),
+		NewResourceUseCase(discovery, w, nil, nil, nil, ApplyConfig{}, NamespaceConfig{}))
+}
+
+func TestRuntimeUseCase_SuspendThenResume_RestoresReplicas(t *testing.T) {
+	w := &fakeWorkload{replicas: 3}
+	uc := newSuspendUseCase(w)
+	ctx := context.Background()
+
+	saved, err := uc.Suspend(ctx, waitID)
+	if err != nil {
+		t.Fatalf("Suspend: %v", err)
+	}
+	if saved != 3 || w.replicas != 0 {
+		t.Fatalf("after Suspend: saved %d, replicas %d, want 3 and 0", saved, w.replicas)
+	}
+	if got := w.annotations[SuspendedReplicasAnnotation]; got != "3" {
+		t.Errorf("annotation = %q, want 3", got)
+	}
+
+	// Suspending again keeps the originally saved count.
+	if saved, err := uc.Suspend(ctx, waitID); err != nil || saved != 3 {
+		t.Errorf("second Suspend = %d, %v, want 3", saved, err)
+	}
+
+	replicas, err := uc.Resume(ctx, waitID)
+	if err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+	if replicas != 3 || w.replicas != 3 {
+		t.Errorf("after Resume: returned %d, replicas %d, want 3", replicas, w.replicas)
+	}
+	if _, ok := w.annotations[SuspendedReplicasAnnotation]; ok {
+		t.Error("annotation was not removed on Resume")
+	}
+}
+
+func TestRuntimeUseCase_Suspend_AlreadyAtZero(t *testing.T) {
+	w := &fakeWorkload{replicas: 0}
+	uc := newSuspendUseCase(w)
+
+	saved, err := uc.Suspend(context.Background(), waitID)
+	if err != nil || saved != 0 {
+		t.Fatalf("Suspend = %d, %v, want 0", saved, err)
+	}
+	if _, ok := w.annotations[SuspendedReplicasAnnotation]; ok {
+		t.Error("a workload already at zero must not be annotated")
+	}
+}
+
+func TestRuntimeUseCase_Resume_WithoutAnnotation(t *testing.T) {
+	w := &fakeWorkload{replicas: 2}
+	uc := newSuspendUseCase(w)
+
+	replicas, err := uc.Resume(context.Background(), waitID)
+	if err != nil || replicas != 2 {
+		t.Fatalf("Resume = %d, %v, want the current count 2", replicas, err)
+	}
+}
+
+func TestRuntimeUseCase_Resume_InvalidAnnotation(t *testing.T) {
+	w := &fakeWorkload{annotations: map[string]string{SuspendedReplicasAnnotation: "many"}}
+	uc := newSuspendUseCase(w)
+
+	_, err := uc.Resume(context.Background(), waitID)
+	if code, ok := DomainErrorCode(err); !ok || code != ErrorCodeFailedPrecondition {
+		t.Fatalf("error = %v, want a DomainError with ErrorCodeFailedPrecondition", err)
+	}
+	if w.replicas != 0 {
+		t.Errorf("replicas = %d, want the workload left at 0", w.replicas)
+	}
+}
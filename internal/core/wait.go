@@ -0,0 +1,159 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// MaxConditionTimeout bounds how long WaitForCondition may block, so
+// that a request cannot hold a watch open indefinitely.
+const MaxConditionTimeout = 5 * time.Minute
+
+// conditionRewatchDelay is how long WaitForCondition waits before
+// reopening a watch that ended, so that a failing API server is not
+// hammered.
+const conditionRewatchDelay = time.Second
+
+// WaitForCondition blocks until the object identified by id has a
+// status condition of conditionType with the given status ("True",
+// "False" or "Unknown"), such as Available=True on a Deployment or
+// Complete=True on a Job, and returns the object.
+//
+// The object is watched rather than polled, from a snapshot of its
+// current state, so a condition that already holds returns at once.
+// If the watch's resourceVersion expires it relists and continues.
+// An object that is deleted while waiting is waited for until it is
+// recreated.
+//
+// If timeout elapses first, the last observed object (nil if none) is
+// returned with a DomainError of code ErrorCodeDeadlineExceeded. If
+// ctx ends first, ctx's error is returned.
+func (uc *ResourceUseCase) WaitForCondition(
+	ctx context.Context,
+	id ResourceIdentifier,
+	conditionType, status string,
+	timeout time.Duration,
+) (*unstructured.Unstructured, error) {
+	if id.Name == "" {
+		return nil, &ErrInvalidInput{Field: "name", Message: "is required"}
+	}
+	if conditionType == "" {
+		return nil, &ErrInvalidInput{Field: "condition_type", Message: "is required"}
+	}
+	switch status {
+	case "True", "False", "Unknown":
+	default:
+		return nil, &ErrInvalidInput{Field: "status", Message: fmt.Sprintf("must be True, False or Unknown, got %q", status)}
+	}
+	if err := validateWaitTimeout(timeout); err != nil {
+		return nil, err
+	}
+
+	return uc.waitFor(ctx, id, timeout, fmt.Sprintf("condition %s=%s", conditionType, status),
+		func(obj *unstructured.Unstructured) (bool, error) {
+			return hasCondition(obj, conditionType, status), nil
+		})
+}
+
+// validateWaitTimeout rejects timeouts outside (0, MaxConditionTimeout].
+func validateWaitTimeout(timeout time.Duration) error {
+	if timeout <= 0 || timeout > MaxConditionTimeout {
+		return &ErrInvalidInput{Field: "timeout", Message: fmt.Sprintf("must be positive and at most %s", MaxConditionTimeout)}
+	}
+	return nil
+}
+
+// waitFor watches the object identified by id until check reports it
+// done or fails, as described on WaitForCondition. what names the
+// awaited state in the timeout error.
+func (uc *ResourceUseCase) waitFor(
+	ctx context.Context,
+	id ResourceIdentifier,
+	timeout time.Duration,
+	what string,
+	check func(*unstructured.Unstructured) (bool, error),
+) (*unstructured.Unstructured, error) {
+	gvr, err := id.lookupScopedGVR(ctx, uc.discovery)
+	if err != nil {
+		return nil, err
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	opts := WatchOptions{FieldSelector: fields.OneTermEqualSelector("metadata.name", id.Name).String()}
+	relist := func(ctx context.Context) ([]WatchEvent, Watcher, error) {
+		return uc.snapshotWatch(ctx, id.Cluster, gvr, id.Namespace, opts)
+	}
+
+	var last *unstructured.Unstructured
+	for {
+		// A nil inner watcher makes the resumingWatcher start from a
+		// snapshot; the watch is reopened the same way if the API
+		// server ends it before the object is done.
+		w := newResumingWatcher(waitCtx, nil, relist, 0)
+		obj, done, err := awaitObject(w, check, &last)
+		w.Stop()
+		if done {
+			return obj, err
+		}
+
+		select {
+		case <-time.After(conditionRewatchDelay):
+		case <-waitCtx.Done():
+			if ctx.Err() != nil {
+				return last, ctx.Err()
+			}
+			return last, &DomainError{
+				Code:    ErrorCodeDeadlineExceeded,
+				Message: fmt.Sprintf("timed out after %s waiting for %s", timeout, what),
+			}
+		}
+	}
+}
+
+// awaitObject consumes events of w until check reports the watched
+// object done, reporting done with the object, or check or the watch
+// fails, reporting done with the error. It records the latest object
+// in last and returns done=false if w ends first.
+func awaitObject(w Watcher, check func(*unstructured.Unstructured) (bool, error), last **unstructured.Unstructured) (*unstructured.Unstructured, bool, error) {
+	for event := range w.ResultChan() {
+		switch event.Type {
+		case WatchEventAdded, WatchEventModified:
+			obj := &unstructured.Unstructured{Object: event.Object}
+			*last = obj
+			ok, err := check(obj)
+			if err != nil {
+				return obj, true, err
+			}
+			if ok {
+				return obj, true, nil
+			}
+		case WatchEventDeleted:
+			*last = nil
+		case WatchEventError:
+			return nil, true, &DomainError{Code: ErrorCodeInternal, Message: fmt.Sprintf("watch failed: %v", event.Object["message"])}
+		}
+	}
+	return nil, false, nil
+}
+
+// hasCondition reports whether obj has a status condition of
+// conditionType with the given status.
+func hasCondition(obj *unstructured.Unstructured, conditionType, status string) bool {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		if condition["type"] == conditionType {
+			return condition["status"] == status
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// mockConditionRepo lists a single deployment and watches it through a
+// chanWatcher fed by the test.
+type mockConditionRepo struct {
+	ResourceRepo
+	deployment unstructured.Unstructured
+	watch      *chanWatcher
+	listOpts   ListOptions
+}
+
+func (m *mockConditionRepo) List(_ context.Context, _ string, _ schema.GroupVersionResource, _ string, opts ListOptions) (*unstructured.UnstructuredList, error) {
+	m.listOpts = opts
+	list := &unstructured.UnstructuredList{Items: []unstructured.Unstructured{m.deployment}}
+	list.SetResourceVersion("100")
+	return list, nil
+}
+
+func (m *mockConditionRepo) Watch(_ context.Context, _ string, _ schema.GroupVersionResource, _ string, _ WatchOptions) (Watcher, error) {
+	return m.watch, nil
+}
+
+func testDeployment(rv, available string) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": "web", "namespace": "default", "resourceVersion": rv},
+		"status": map[string]any{"conditions": []any{
+			map[string]any{"type": "Progressing", "status": "True"},
+			map[string]any{"type": "Available", "status": available},
+		}},
+	}}
+}
+
+var waitID = ResourceIdentifier{Cluster: "edge-1", Group: "apps", Version: "v1", Resource: "deployments", Namespace: "default", Name: "web"}
+
+func TestResourceUseCase_WaitForCondition_BecomesTrue(t *testing.T) {
+	repo := &mockConditionRepo{deployment: testDeployment("90", "False"), watch: newChanWatcher()}
+	repo.watch.ch <- WatchEvent{Type: WatchEventModified, Object: testDeployment("101", "False").Object}
+	repo.watch.ch <- WatchEvent{Type: WatchEventModified, Object: testDeployment("102", "True").Object}
+	uc := NewResourceUseCase(&mockWatchDiscovery{}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+
+	obj, err := uc.WaitForCondition(context.Background(), waitID, "Available", "True", 5*time.Second)
+	if err != nil {
+		t.Fatalf("WaitForCondition: %v", err)
+	}
+	if obj.GetResourceVersion() != "102" {
+		t.Errorf("resourceVersion = %q, want the first version with Available=True (102)", obj.GetResourceVersion())
+	}
+	if repo.listOpts.FieldSelector != "metadata.name=web" {
+		t.Errorf("field selector = %q, want metadata.name=web", repo.listOpts.FieldSelector)
+	}
+}
+
+func TestResourceUseCase_WaitForCondition_TimesOut(t *testing.T) {
+	repo := &mockConditionRepo{deployment: testDeployment("90", "False"), watch: newChanWatcher()}
+	uc := NewResourceUseCase(&mockWatchDiscovery{}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+
+	start := time.Now()
+	obj, err := uc.WaitForCondition(context.Background(), waitID, "Available", "True", 100*time.Millisecond)
+	if code, ok := DomainErrorCode(err); !ok || code != ErrorCodeDeadlineExceeded {
+		t.Fatalf("error = %v, want a DomainError with ErrorCodeDeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("returned after %s, want shortly after the timeout", elapsed)
+	}
+	if obj == nil || obj.GetResourceVersion() != "90" {
+		t.Errorf("object = %v, want the last observed version (90)", obj)
+	}
+	select {
+	case <-repo.watch.stopped:
+	default:
+		t.Error("watch was not stopped")
+	}
+}
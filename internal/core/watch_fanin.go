@@ -0,0 +1,267 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// maxFanInClusters bounds the clusters of one WatchAcrossClusters.
+const maxFanInClusters = 100
+
+// fanInRetryMin and fanInRetryMax bound the delay before a cluster's
+// failed watch is reopened by WatchAcrossClusters. The delay doubles
+// with every consecutive failure and resets once a watch delivers an
+// event.
+var (
+	fanInRetryMin = time.Second
+	fanInRetryMax = 30 * time.Second
+)
+
+// errWatchClosed reports that a cluster's watch ended without an error
+// of its own, e.g. because the API server closed it.
+var errWatchClosed = errors.New("watch closed")
+
+// errWatchExpired reports that a cluster's watch ended because its
+// resourceVersion expired; it is reopened with a fresh snapshot.
+var errWatchExpired = errors.New("watch expired; restarting with a fresh snapshot")
+
+// ClusterWatchEvent is an event of one of the clusters watched by
+// WatchAcrossClusters.
+type ClusterWatchEvent struct {
+	Cluster string
+	WatchEvent
+	// Err is set on a WatchEventError event without an object when the
+	// cluster's watch could not be opened or ended.
+	Err error
+	// Retrying reports, with Err, whether the watch is reopened after
+	// a backoff. If not, the cluster is no longer watched.
+	Retrying bool
+}
+
+// FanInWatcher merges the watches of several clusters into one stream.
+type FanInWatcher struct {
+	uc     *ResourceUseCase
+	ctx    context.Context
+	cancel context.CancelFunc
+	id     ResourceIdentifier
+	opts   WatchOptions
+	// retryMin and retryMax are fanInRetryMin and fanInRetryMax when
+	// the watcher was created.
+	retryMin, retryMax time.Duration
+	ch                 chan ClusterWatchEvent
+	wg                 sync.WaitGroup
+}
+
+// ResultChan returns the merged events. It is closed once Stop is
+// called or no cluster is watched any more.
+func (w *FanInWatcher) ResultChan() <-chan ClusterWatchEvent {
+	return w.ch
+}
+
+// Stop ends the watches of all clusters.
+func (w *FanInWatcher) Stop() {
+	w.cancel()
+}
+
+// WatchAcrossClusters watches the resource type of id, whose Cluster is
+// ignored, on each of clusters and merges their events, tagged with
+// their cluster, into one stream in the order they arrive. Each
+// cluster's watch behaves as one opened by WatchResource and is
+// handled independently: when it cannot be opened or ends, an error
+// event is sent for the cluster and the watch is reopened after a
+// backoff, resuming after the last event delivered (see
+// ResumeWatchResource). Errors that a retry cannot fix, such as
+// PermissionDenied, stop only that cluster's watch. opts.ResourceVersion
+// must be unset, since resource versions are specific to a cluster.
+func (uc *ResourceUseCase) WatchAcrossClusters(
+	ctx context.Context,
+	clusters []string,
+	id ResourceIdentifier,
+	opts WatchOptions,
+) (*FanInWatcher, error) {
+	if len(clusters) == 0 {
+		return nil, &ErrInvalidInput{Field: "clusters", Message: "at least one cluster is required"}
+	}
+	if len(clusters) > maxFanInClusters {
+		return nil, &ErrInvalidInput{Field: "clusters", Message: fmt.Sprintf("must not exceed %d clusters", maxFanInClusters)}
+	}
+	seen := make(map[string]bool, len(clusters))
+	for _, cluster := range clusters {
+		if err := ValidateClusterName(cluster); err != nil {
+			return nil, err
+		}
+		if seen[cluster] {
+			return nil, &ErrInvalidInput{Field: "clusters", Message: fmt.Sprintf("cluster %s is listed twice", cluster)}
+		}
+		seen[cluster] = true
+	}
+	if opts.ResourceVersion != "" {
+		return nil, &ErrInvalidInput{Field: "resource_version", Message: "cannot be set when watching several clusters"}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	w := &FanInWatcher{
+		uc:       uc,
+		ctx:      ctx,
+		cancel:   cancel,
+		id:       id,
+		opts:     opts,
+		retryMin: fanInRetryMin,
+		retryMax: fanInRetryMax,
+		ch:       make(chan ClusterWatchEvent),
+	}
+	w.wg.Add(len(clusters))
+	for _, cluster := range clusters {
+		go w.watchCluster(cluster)
+	}
+	go func() {
+		w.wg.Wait()
+		cancel()
+		close(w.ch)
+	}()
+	return w, nil
+}
+
+// watchCluster relays the watch of one cluster, reopening it with
+// backoff until the watcher is stopped or an error is not retryable.
+func (w *FanInWatcher) watchCluster(cluster string) {
+	defer w.wg.Done()
+
+	id := w.id
+	id.Cluster = cluster
+	pos := &fanInPosition{snapshot: true}
+	delay := w.retryMin
+	for {
+		watcher, err := w.open(id, pos)
+		if err == nil {
+			var delivered bool
+			delivered, err = w.relay(cluster, watcher, pos)
+			watcher.Stop()
+			if delivered {
+				delay = w.retryMin
+			}
+		}
+		if w.ctx.Err() != nil {
+			return
+		}
+
+		retry := retryableWatchError(err)
+		if !w.send(ClusterWatchEvent{Cluster: cluster, WatchEvent: WatchEvent{Type: WatchEventError}, Err: err, Retrying: retry}) || !retry {
+			return
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-w.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		delay = min(delay*2, w.retryMax)
+	}
+}
+
+// open opens the watch of id, resuming after pos if it has a
+// resourceVersion.
+func (w *FanInWatcher) open(id ResourceIdentifier, pos *fanInPosition) (Watcher, error) {
+	if pos.rv == "" {
+		return w.uc.WatchResource(w.ctx, id, w.opts)
+	}
+	return w.uc.ResumeWatchResource(w.ctx, id, NewResumeToken(id, pos.rv).Encode(), w.opts)
+}
+
+// relay sends the events of watcher tagged with cluster until it ends
+// or the fan-in is stopped, and reports whether any event was sent and
+// why the watch ended.
+func (w *FanInWatcher) relay(cluster string, watcher Watcher, pos *fanInPosition) (bool, error) {
+	delivered := false
+	for {
+		select {
+		case <-w.ctx.Done():
+			return delivered, w.ctx.Err()
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return delivered, errWatchClosed
+			}
+			if event.Type == WatchEventError && event.Expired {
+				// Only a watch opened without a resume position
+				// reports this; the next one starts afresh.
+				pos.rv, pos.snapshot = "", true
+				return delivered, errWatchExpired
+			}
+			pos.observe(event)
+			if !w.send(ClusterWatchEvent{Cluster: cluster, WatchEvent: event}) {
+				return delivered, w.ctx.Err()
+			}
+			delivered = true
+		}
+	}
+}
+
+func (w *FanInWatcher) send(event ClusterWatchEvent) bool {
+	select {
+	case w.ch <- event:
+		return true
+	case <-w.ctx.Done():
+		return false
+	}
+}
+
+// retryableWatchError reports whether reopening a watch that failed
+// with err may succeed. Invalid requests and missing permissions or
+// resource types are not retried.
+func retryableWatchError(err error) bool {
+	var invalid *ErrInvalidInput
+	if errors.As(err, &invalid) {
+		return false
+	}
+	code, ok := DomainErrorCode(err)
+	if !ok {
+		return true
+	}
+	switch code {
+	case ErrorCodeInvalidArgument, ErrorCodeNotFound, ErrorCodeUnauthenticated, ErrorCodePermissionDenied, ErrorCodeUnimplemented:
+		return false
+	default:
+		return true
+	}
+}
+
+// fanInPosition tracks where a cluster's watch can be reopened: after
+// the resourceVersion of the last event delivered, except while an
+// initial snapshot is sent, since resuming from its middle would skip
+// the objects not yet sent.
+type fanInPosition struct {
+	snapshot bool
+	rv       string
+}
+
+// observe advances the position past event.
+func (p *fanInPosition) observe(event WatchEvent) {
+	switch event.Type {
+	case WatchEventBookmark:
+		if event.Relisted {
+			p.snapshot, p.rv = true, ""
+			return
+		}
+		// The position before a resync stays valid until the fresh
+		// snapshot has been sent.
+		if event.Resynced {
+			p.snapshot = true
+			return
+		}
+		p.snapshot = false
+	case WatchEventAdded, WatchEventModified, WatchEventDeleted:
+		if p.snapshot {
+			return
+		}
+	default:
+		return
+	}
+	metadata, _ := event.Object["metadata"].(map[string]any)
+	if rv, _ := metadata["resourceVersion"].(string); rv != "" {
+		p.rv = rv
+	}
+}
@@ -0,0 +1,164 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// fanInRepo serves the watches of each cluster from a queue, failing
+// with the cluster's error once the queue is empty, and records the
+// resourceVersion each watch was opened at.
+type fanInRepo struct {
+	ResourceRepo
+
+	mu       sync.Mutex
+	watches  map[string][]*chanWatcher
+	errs     map[string]error
+	watchRVs map[string][]string
+}
+
+func (r *fanInRepo) Watch(_ context.Context, cluster string, _ schema.GroupVersionResource, _ string, opts WatchOptions) (Watcher, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.watchRVs[cluster] = append(r.watchRVs[cluster], opts.ResourceVersion)
+	if len(r.watches[cluster]) == 0 {
+		return nil, r.errs[cluster]
+	}
+	w := r.watches[cluster][0]
+	r.watches[cluster] = r.watches[cluster][1:]
+	return w, nil
+}
+
+func (r *fanInRepo) openedAt(cluster string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.watchRVs[cluster]...)
+}
+
+// recvCluster returns the next event of w, failing the test if none
+// arrives.
+func recvCluster(t *testing.T, w *FanInWatcher) ClusterWatchEvent {
+	t.Helper()
+	select {
+	case event, ok := <-w.ResultChan():
+		if !ok {
+			t.Fatal("fan-in closed")
+		}
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for fan-in event")
+	}
+	return ClusterWatchEvent{}
+}
+
+func TestResourceUseCase_WatchAcrossClusters(t *testing.T) {
+	retryMin := fanInRetryMin
+	fanInRetryMin = time.Millisecond
+	t.Cleanup(func() { fanInRetryMin = retryMin })
+
+	edge1, edge1Again, edge2 := newChanWatcher(), newChanWatcher(), newChanWatcher()
+	repo := &fanInRepo{
+		watches: map[string][]*chanWatcher{
+			"edge-1": {edge1, edge1Again},
+			"edge-2": {edge2},
+		},
+		errs: map[string]error{
+			"edge-2": &DomainError{Code: ErrorCodePermissionDenied, Message: "forbidden"},
+		},
+		watchRVs: map[string][]string{},
+	}
+	uc := NewResourceUseCase(&mockWatchDiscovery{}, repo, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+	w, err := uc.WatchAcrossClusters(context.Background(), []string{"edge-1", "edge-2"},
+		ResourceIdentifier{Version: "v1", Resource: "pods", Namespace: "default"}, WatchOptions{})
+	if err != nil {
+		t.Fatalf("WatchAcrossClusters: %v", err)
+	}
+	defer w.Stop()
+
+	bookmark := func(rv string) WatchEvent {
+		return WatchEvent{Type: WatchEventBookmark, Object: map[string]any{"metadata": map[string]any{"resourceVersion": rv}}}
+	}
+	added := func(name, rv string) WatchEvent {
+		return WatchEvent{Type: WatchEventAdded, Object: testPod(name, rv).Object}
+	}
+
+	// Events of both clusters arrive interleaved, in order, each tagged
+	// with its cluster.
+	steps := []struct {
+		watcher *chanWatcher
+		cluster string
+		event   WatchEvent
+	}{
+		{edge1, "edge-1", bookmark("100")},
+		{edge2, "edge-2", bookmark("7")},
+		{edge1, "edge-1", added("web", "101")},
+		{edge2, "edge-2", added("db", "8")},
+		{edge2, "edge-2", added("cache", "9")},
+		{edge1, "edge-1", added("api", "102")},
+	}
+	for _, step := range steps {
+		step.watcher.ch <- step.event
+		got := recvCluster(t, w)
+		if got.Cluster != step.cluster || got.Type != step.event.Type || got.Object["metadata"].(map[string]any)["resourceVersion"] != step.event.Object["metadata"].(map[string]any)["resourceVersion"] {
+			t.Fatalf("event = %s %s %v, want %s %s %v", got.Cluster, got.Type, got.Object, step.cluster, step.event.Type, step.event.Object)
+		}
+	}
+
+	// edge-1's watch ends: an error is reported for edge-1 alone and
+	// its watch resumes after the last event delivered.
+	close(edge1.ch)
+	got := recvCluster(t, w)
+	if got.Cluster != "edge-1" || got.Type != WatchEventError || got.Err == nil || !got.Retrying {
+		t.Fatalf("event = %+v, want a retrying error for edge-1", got)
+	}
+	edge1Again.ch <- added("worker", "103")
+	if got := recvCluster(t, w); got.Cluster != "edge-1" || podName(got.WatchEvent) != "worker" {
+		t.Fatalf("event = %+v, want worker from the reopened edge-1 watch", got)
+	}
+	if rvs := repo.openedAt("edge-1"); len(rvs) != 2 || rvs[0] != "" || rvs[1] != "102" {
+		t.Errorf("edge-1 watches opened at %q, want the second resuming at 102", rvs)
+	}
+
+	// edge-2 loses access: its watch stops for good while edge-1 goes on.
+	close(edge2.ch)
+	for _, want := range []bool{true, false} {
+		got := recvCluster(t, w)
+		if got.Cluster != "edge-2" || got.Type != WatchEventError || got.Retrying != want {
+			t.Fatalf("event = %+v, want an error for edge-2 with retrying %v", got, want)
+		}
+	}
+	edge1Again.ch <- added("batch", "104")
+	if got := recvCluster(t, w); got.Cluster != "edge-1" || podName(got.WatchEvent) != "batch" {
+		t.Fatalf("event = %+v, want edge-1 to keep streaming", got)
+	}
+}
+
+func TestResourceUseCase_WatchAcrossClusters_Validation(t *testing.T) {
+	uc := NewResourceUseCase(&mockWatchDiscovery{}, &fanInRepo{}, nil, nil, nil, ApplyConfig{}, NamespaceConfig{})
+	id := ResourceIdentifier{Version: "v1", Resource: "pods"}
+
+	tests := []struct {
+		name     string
+		clusters []string
+		opts     WatchOptions
+		field    string
+	}{
+		{"no clusters", nil, WatchOptions{}, "clusters"},
+		{"duplicate cluster", []string{"edge-1", "edge-1"}, WatchOptions{}, "clusters"},
+		{"invalid cluster", []string{"Edge_1"}, WatchOptions{}, "cluster"},
+		{"resource version", []string{"edge-1"}, WatchOptions{ResourceVersion: "5"}, "resource_version"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var invalid *ErrInvalidInput
+			if _, err := uc.WatchAcrossClusters(context.Background(), tt.clusters, id, tt.opts); !errors.As(err, &invalid) || invalid.Field != tt.field {
+				t.Errorf("err = %v, want invalid input on %s", err, tt.field)
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package core
 
+import "time"
+
 // WatchEventType represents the type of a resource watch event.
 // This is a domain-level type that decouples the core layer from
 // k8s.io/apimachinery/pkg/watch.EventType.
@@ -16,9 +18,24 @@ const (
 // WatchEvent represents a single event from a resource watch stream.
 // Object carries the raw Kubernetes resource as a generic map so that
 // the domain layer does not depend on unstructured.Unstructured.
+// Expired is set on ERROR events reporting that the watch's
+// resourceVersion is too old (HTTP 410 Gone), or that events were
+// dropped because the consumer fell behind; the client must relist.
+// Relisted is set on the BOOKMARK event with which a resumed watch
+// announces that it relisted after such an error (see
+// ResourceUseCase.ResumeWatchResource). Resynced is set on the BOOKMARK
+// event with which a watch announces a periodic resync (see
+// WatchOptions.ResyncInterval); a fresh snapshot follows, and objects
+// absent from it no longer exist. InitialEventsEnd is set on the
+// BOOKMARK event that ends the initial snapshot of a watch, after
+// which the client holds the complete state of the watched resources.
 type WatchEvent struct {
-	Type   WatchEventType
-	Object map[string]any
+	Type             WatchEventType
+	Object           map[string]any
+	Expired          bool
+	Relisted         bool
+	Resynced         bool
+	InitialEventsEnd bool
 }
 
 // Watcher provides a channel of WatchEvents and a way to stop the
@@ -33,3 +50,19 @@ type Watcher interface {
 	// Stop terminates the watch and closes the result channel.
 	Stop()
 }
+
+// WatchConfig holds the server-wide limits on watch streams.
+type WatchConfig struct {
+	// MaxDuration is how long a watch stream may run before the server
+	// ends it with a resumable bookmark, so that watches of clients
+	// that vanished without cancelling are eventually recycled. Zero
+	// means no limit.
+	MaxDuration time.Duration
+	// MinResyncInterval is the shortest resync interval a client may
+	// request, so that periodic relists cannot overload API servers.
+	MinResyncInterval time.Duration
+	// NoInitialEvents makes watches whose client states no preference
+	// skip the initial events of WatchList, as if the client had opted
+	// out (see WatchOptions.InitialEvents).
+	NoInitialEvents bool
+}
@@ -6,8 +6,17 @@ import (
 
 // ProviderSet is the Wire provider set for all domain use-cases.
 var ProviderSet = wire.NewSet(
+	NewDiagnosticsUseCase,
 	NewFleetUseCase,
+	NewHelmChartUseCase,
+	NewIdentityUseCase,
+	NewMaintenanceUseCase,
+	NewManifestSourceUseCase,
+	NewRealClock,
+	NewProxyUseCase,
+	NewReadinessUseCase,
 	NewResourceUseCase,
 	NewRuntimeUseCase,
 	NewSessionStore,
+	NewSupportBundleUseCase,
 )
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"errors"
 
 	"connectrpc.com/connect"
@@ -11,25 +12,35 @@ import (
 // domainCodeToConnectCode maps domain-level error codes to their
 // ConnectRPC equivalents.
 var domainCodeToConnectCode = map[core.ErrorCode]connect.Code{
-	core.ErrorCodeInternal:          connect.CodeInternal,
-	core.ErrorCodeInvalidArgument:   connect.CodeInvalidArgument,
-	core.ErrorCodeNotFound:          connect.CodeNotFound,
-	core.ErrorCodeAlreadyExists:     connect.CodeAlreadyExists,
-	core.ErrorCodeUnauthenticated:   connect.CodeUnauthenticated,
-	core.ErrorCodePermissionDenied:  connect.CodePermissionDenied,
+	core.ErrorCodeInternal:           connect.CodeInternal,
+	core.ErrorCodeInvalidArgument:    connect.CodeInvalidArgument,
+	core.ErrorCodeNotFound:           connect.CodeNotFound,
+	core.ErrorCodeAlreadyExists:      connect.CodeAlreadyExists,
+	core.ErrorCodeUnauthenticated:    connect.CodeUnauthenticated,
+	core.ErrorCodePermissionDenied:   connect.CodePermissionDenied,
 	core.ErrorCodeFailedPrecondition: connect.CodeFailedPrecondition,
-	core.ErrorCodeDeadlineExceeded:  connect.CodeDeadlineExceeded,
-	core.ErrorCodeResourceExhausted: connect.CodeResourceExhausted,
-	core.ErrorCodeUnimplemented:     connect.CodeUnimplemented,
-	core.ErrorCodeUnavailable:       connect.CodeUnavailable,
+	core.ErrorCodeDeadlineExceeded:   connect.CodeDeadlineExceeded,
+	core.ErrorCodeResourceExhausted:  connect.CodeResourceExhausted,
+	core.ErrorCodeUnimplemented:      connect.CodeUnimplemented,
+	core.ErrorCodeUnavailable:        connect.CodeUnavailable,
 }
 
 // domainErrorToConnectError converts a domain error into a ConnectRPC
-// error with a semantically equivalent code. Domain-specific error
-// types (ErrInvalidInput, ErrClusterNotFound, etc.) are checked first,
-// then DomainError codes are mapped. Unrecognised errors fall back to
+// error with a semantically equivalent code. Context errors are checked
+// first, wherever they sit in the chain, so that clients can tell a
+// cancelled request (CodeCanceled) from one that ran out of time
+// (CodeDeadlineExceeded). Domain-specific error types
+// (ErrInvalidInput, ErrClusterNotFound, etc.) come next, then
+// DomainError codes are mapped. Unrecognised errors fall back to
 // connect.CodeInternal.
 func domainErrorToConnectError(err error) error {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return connect.NewError(connect.CodeCanceled, err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return connect.NewError(connect.CodeDeadlineExceeded, err)
+	}
+
 	// Concrete domain error types.
 	var invalidInput *core.ErrInvalidInput
 	if errors.As(err, &invalidInput) {
@@ -43,6 +54,26 @@ func domainErrorToConnectError(err error) error {
 	if errors.As(err, &clusterNotFound) {
 		return connect.NewError(connect.CodeNotFound, err)
 	}
+	var clusterNotReady *core.ErrClusterNotReady
+	if errors.As(err, &clusterNotReady) {
+		return connect.NewError(connect.CodeUnavailable, err)
+	}
+	var listTooLarge *core.ErrListTooLarge
+	if errors.As(err, &listTooLarge) {
+		return connect.NewError(connect.CodeResourceExhausted, err)
+	}
+	var applyConflict *core.ErrApplyConflict
+	if errors.As(err, &applyConflict) {
+		return applyConflictError(applyConflict)
+	}
+	var admissionDenied *core.ErrAdmissionDenied
+	if errors.As(err, &admissionDenied) {
+		return admissionDeniedError(admissionDenied)
+	}
+	var namespaceTerminating *core.ErrNamespaceTerminating
+	if errors.As(err, &namespaceTerminating) {
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	}
 	var notReady *core.ErrNotReady
 	if errors.As(err, &notReady) {
 		return connect.NewError(connect.CodeUnavailable, err)
@@ -60,3 +91,14 @@ func domainErrorToConnectError(err error) error {
 
 	return connect.NewError(connect.CodeInternal, err)
 }
+
+// contextError returns the ConnectRPC error for ctx having ended, or
+// nil while it is live. Streaming loops return it when they stop
+// because of ctx, rather than nil, so that a client sees whether the
+// stream was cancelled or timed out.
+func contextError(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return domainErrorToConnectError(err)
+	}
+	return nil
+}
@@ -1,11 +1,15 @@
 package handler
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"connectrpc.com/connect"
 
+	pb "github.com/otterscale/otterscale-agent/api/resource/v1"
 	"github.com/otterscale/otterscale-agent/internal/core"
 )
 
@@ -30,6 +34,16 @@ func TestDomainErrorToConnectError_ConcreteTypes(t *testing.T) {
 			err:      &core.ErrClusterNotFound{Cluster: "test"},
 			wantCode: connect.CodeNotFound,
 		},
+		{
+			name:     "ErrClusterNotReady",
+			err:      &core.ErrClusterNotReady{Cluster: "test"},
+			wantCode: connect.CodeUnavailable,
+		},
+		{
+			name:     "ErrListTooLarge",
+			err:      &core.ErrListTooLarge{Limit: 10, SuggestedLimit: 5},
+			wantCode: connect.CodeResourceExhausted,
+		},
 		{
 			name:     "ErrNotReady",
 			err:      &core.ErrNotReady{Subsystem: "chisel"},
@@ -51,6 +65,31 @@ func TestDomainErrorToConnectError_ConcreteTypes(t *testing.T) {
 	}
 }
 
+func TestDomainErrorToConnectError_AdmissionDenied(t *testing.T) {
+	err := &core.ErrAdmissionDenied{Webhook: "validate.policy.example.com", Reason: "missing label team", Code: core.ErrorCodePermissionDenied}
+	var connectErr *connect.Error
+	if !errors.As(domainErrorToConnectError(err), &connectErr) {
+		t.Fatal("expected *connect.Error")
+	}
+	if connectErr.Code() != connect.CodePermissionDenied {
+		t.Errorf("expected code %v, got %v", connect.CodePermissionDenied, connectErr.Code())
+	}
+	if connectErr.Message() != "rejected by webhook validate.policy.example.com: missing label team" {
+		t.Errorf("unexpected message %q", connectErr.Message())
+	}
+	if len(connectErr.Details()) != 1 {
+		t.Fatalf("expected 1 error detail, got %d", len(connectErr.Details()))
+	}
+	value, detailErr := connectErr.Details()[0].Value()
+	denial, ok := value.(*pb.AdmissionDenial)
+	if detailErr != nil || !ok {
+		t.Fatalf("expected *pb.AdmissionDenial detail, got %T (%v)", value, detailErr)
+	}
+	if denial.GetWebhook() != "validate.policy.example.com" || denial.GetReason() != "missing label team" {
+		t.Errorf("unexpected detail %v", denial)
+	}
+}
+
 func TestDomainErrorToConnectError_DomainErrorCodes(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -102,3 +141,43 @@ func TestDomainCodeToConnectCode_Completeness(t *testing.T) {
 		t.Errorf("expected at least 11 domain code mappings, got %d", len(domainCodeToConnectCode))
 	}
 }
+
+func TestDomainErrorToConnectError_ContextErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want connect.Code
+	}{
+		{"canceled", context.Canceled, connect.CodeCanceled},
+		{"deadline exceeded", context.DeadlineExceeded, connect.CodeDeadlineExceeded},
+		{"wrapped canceled", fmt.Errorf("list pods: %w", context.Canceled), connect.CodeCanceled},
+		{"domain error caused by deadline", &core.DomainError{Code: core.ErrorCodeInternal, Message: "get", Cause: context.DeadlineExceeded}, connect.CodeDeadlineExceeded},
+		{"cluster not ready after cancel", &core.ErrClusterNotReady{Cluster: "edge-1", Cause: context.Canceled}, connect.CodeCanceled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := connect.CodeOf(domainErrorToConnectError(tt.err)); got != tt.want {
+				t.Errorf("code = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContextError(t *testing.T) {
+	live := context.Background()
+	if err := contextError(live); err != nil {
+		t.Errorf("contextError(live) = %v, want nil", err)
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if got := connect.CodeOf(contextError(canceled)); got != connect.CodeCanceled {
+		t.Errorf("cancelled context: code = %v, want %v", got, connect.CodeCanceled)
+	}
+
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	if got := connect.CodeOf(contextError(expired)); got != connect.CodeDeadlineExceeded {
+		t.Errorf("expired context: code = %v, want %v", got, connect.CodeDeadlineExceeded)
+	}
+}
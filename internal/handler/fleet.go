@@ -10,6 +10,7 @@ import (
 	"slices"
 
 	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/otterscale/otterscale-agent/api/fleet/v1"
 	"github.com/otterscale/otterscale-agent/api/fleet/v1/pbconnect"
@@ -17,26 +18,37 @@ import (
 )
 
 // FleetService implements the Fleet gRPC service. It handles cluster
-// listing and agent registration.
+// listing, agent registration, agent diagnostics and support bundles,
+// identity review and the maintenance mode.
 type FleetService struct {
 	pbconnect.UnimplementedFleetServiceHandler
 
-	fleet *core.FleetUseCase
+	fleet         *core.FleetUseCase
+	diagnostics   *core.DiagnosticsUseCase
+	supportBundle *core.SupportBundleUseCase
+	identity      *core.IdentityUseCase
+	maintenance   *core.MaintenanceUseCase
 }
 
-// NewFleetService returns a FleetService backed by the given use-case.
-func NewFleetService(fleet *core.FleetUseCase) *FleetService {
+// NewFleetService returns a FleetService backed by the given use-cases.
+func NewFleetService(fleet *core.FleetUseCase, diagnostics *core.DiagnosticsUseCase, supportBundle *core.SupportBundleUseCase, identity *core.IdentityUseCase, maintenance *core.MaintenanceUseCase) *FleetService {
 	return &FleetService{
-		fleet: fleet,
+		fleet:         fleet,
+		diagnostics:   diagnostics,
+		supportBundle: supportBundle,
+		identity:      identity,
+		maintenance:   maintenance,
 	}
 }
 
 var _ pbconnect.FleetServiceHandler = (*FleetService)(nil)
 
-// ListClusters returns the names of all clusters that have a
-// registered agent.
+// ListClusters returns all clusters that have a registered agent,
+// optionally only those whose agent is out of date.
 func (s *FleetService) ListClusters(ctx context.Context, req *pb.ListClustersRequest) (*pb.ListClustersResponse, error) {
-	clusters := s.fleet.ListClusters(ctx)
+	clusters := s.fleet.ListClusters(ctx, core.ListClustersOptions{
+		OutOfDateOnly: req.GetOutOfDateOnly(),
+	})
 
 	resp := &pb.ListClustersResponse{}
 	resp.SetClusters(toProtoClusters(clusters))
@@ -58,6 +70,7 @@ func (s *FleetService) Register(ctx context.Context, req *pb.RegisterRequest) (*
 	resp.SetCertificate(reg.Certificate)
 	resp.SetCaCertificate(reg.CACertificate)
 	resp.SetServerVersion(reg.ServerVersion)
+	resp.SetTunnelFingerprint(reg.TunnelFingerprint)
 	return resp, nil
 }
 
@@ -89,6 +102,105 @@ func (s *FleetService) GetAgentManifest(ctx context.Context, req *pb.GetAgentMan
 	return resp, nil
 }
 
+// GetKubeconfig returns a kubeconfig for the requested cluster
+// together with a signed URL for downloading it as raw YAML.
+func (s *FleetService) GetKubeconfig(ctx context.Context, req *pb.GetKubeconfigRequest) (*pb.GetKubeconfigResponse, error) {
+	userInfo, ok := core.UserInfoFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("user info not found in context"))
+	}
+
+	cluster := req.GetCluster()
+
+	kubeconfig, err := s.fleet.GenerateKubeconfig(ctx, cluster)
+	if err != nil {
+		return nil, domainErrorToConnectError(err)
+	}
+
+	url, err := s.fleet.IssueKubeconfigURL(ctx, cluster, userInfo.Subject)
+	if err != nil {
+		return nil, domainErrorToConnectError(err)
+	}
+
+	resp := &pb.GetKubeconfigResponse{}
+	resp.SetKubeconfig(kubeconfig)
+	resp.SetUrl(url)
+	return resp, nil
+}
+
+// AgentDiagnostics returns the diagnostics report of the requested
+// cluster's agent.
+func (s *FleetService) AgentDiagnostics(ctx context.Context, req *pb.AgentDiagnosticsRequest) (*pb.AgentDiagnosticsResponse, error) {
+	diag, err := s.diagnostics.AgentDiagnostics(ctx, req.GetCluster())
+	if err != nil {
+		return nil, domainErrorToConnectError(err)
+	}
+	return toProtoAgentDiagnostics(diag), nil
+}
+
+// CollectSupportBundle collects a support bundle from the requested
+// cluster's agent and returns a signed URL for downloading it.
+func (s *FleetService) CollectSupportBundle(ctx context.Context, req *pb.CollectSupportBundleRequest) (*pb.CollectSupportBundleResponse, error) {
+	bundle, err := s.supportBundle.CollectSupportBundle(ctx, req.GetCluster())
+	if err != nil {
+		return nil, domainErrorToConnectError(err)
+	}
+
+	resp := &pb.CollectSupportBundleResponse{}
+	resp.SetUrl(bundle.URL)
+	resp.SetExpireTime(timestamppb.New(bundle.ExpiresAt))
+	resp.SetErrors(bundle.Errors)
+	return resp, nil
+}
+
+// WhoAmI returns the caller's identity and, if a cluster is named, the
+// identity that cluster's API server sees.
+func (s *FleetService) WhoAmI(ctx context.Context, req *pb.WhoAmIRequest) (*pb.WhoAmIResponse, error) {
+	identity, err := s.identity.WhoAmI(ctx, req.GetCluster())
+	if err != nil {
+		return nil, domainErrorToConnectError(err)
+	}
+
+	resp := &pb.WhoAmIResponse{}
+	resp.SetSubject(identity.User.Subject)
+	resp.SetGroups(identity.User.Groups)
+	if identity.Cluster != nil {
+		resp.SetClusterUser(toProtoClusterUserInfo(identity.Cluster))
+	}
+	return resp, nil
+}
+
+// AgentPermissions returns the rules the agent's own ServiceAccount is
+// allowed on a cluster.
+func (s *FleetService) AgentPermissions(ctx context.Context, req *pb.AgentPermissionsRequest) (*pb.AgentPermissionsResponse, error) {
+	perms, err := s.identity.AgentPermissions(ctx, req.GetCluster(), req.GetNamespace())
+	if err != nil {
+		return nil, domainErrorToConnectError(err)
+	}
+	return toProtoAgentPermissions(perms), nil
+}
+
+// GetMaintenance returns the fleet-wide maintenance mode.
+func (s *FleetService) GetMaintenance(_ context.Context, _ *pb.GetMaintenanceRequest) (*pb.Maintenance, error) {
+	return toProtoMaintenance(s.maintenance.Status()), nil
+}
+
+// SetMaintenance enables or disables the fleet-wide maintenance mode.
+func (s *FleetService) SetMaintenance(ctx context.Context, req *pb.SetMaintenanceRequest) (*pb.Maintenance, error) {
+	current, err := s.maintenance.SetMaintenance(ctx, req.GetEnabled(), req.GetMessage())
+	if err != nil {
+		return nil, domainErrorToConnectError(err)
+	}
+	return toProtoMaintenance(current), nil
+}
+
+func toProtoMaintenance(m core.Maintenance) *pb.Maintenance {
+	ret := &pb.Maintenance{}
+	ret.SetEnabled(m.Enabled)
+	ret.SetMessage(m.Message)
+	return ret
+}
+
 // toProtoClusters converts a map of cluster names to Cluster domain
 // objects into a sorted slice of protobuf Cluster messages. Results
 // are sorted by name to ensure deterministic ordering.
@@ -109,5 +221,93 @@ func toProtoCluster(name string, cluster core.Cluster) *pb.Cluster {
 	ret := &pb.Cluster{}
 	ret.SetName(name)
 	ret.SetAgentVersion(cluster.AgentVersion)
+	ret.SetOutOfDate(cluster.OutOfDate)
+	return ret
+}
+
+// toProtoAgentDiagnostics converts an agent diagnostics report into
+// its protobuf message.
+func toProtoAgentDiagnostics(diag *core.AgentDiagnostics) *pb.AgentDiagnosticsResponse {
+	settings := make([]*pb.AgentSetting, 0, len(diag.Config))
+	for _, s := range diag.Config {
+		setting := &pb.AgentSetting{}
+		setting.SetKey(s.Key)
+		setting.SetValue(s.Value)
+		setting.SetSource(s.Source)
+		settings = append(settings, setting)
+	}
+
+	tunnel := &pb.AgentTunnelStatus{}
+	tunnel.SetConnected(diag.Tunnel.Connected)
+	tunnel.SetTunnelServerUrl(diag.Tunnel.TunnelServerURL)
+	tunnel.SetEndpoint(diag.Tunnel.Endpoint)
+	if !diag.Tunnel.LastRegistration.IsZero() {
+		tunnel.SetLastRegistration(timestamppb.New(diag.Tunnel.LastRegistration))
+	}
+	tunnel.SetReconnects(diag.Tunnel.Reconnects)
+
+	apiServer := &pb.APIServerReachability{}
+	apiServer.SetReachable(diag.APIServer.Reachable)
+	apiServer.SetVersion(diag.APIServer.Version)
+	apiServer.SetLatencyMs(diag.APIServer.Latency.Milliseconds())
+	apiServer.SetError(diag.APIServer.Error)
+
+	ret := &pb.AgentDiagnosticsResponse{}
+	ret.SetAgentVersion(diag.AgentVersion)
+	if !diag.CollectedAt.IsZero() {
+		ret.SetCollectedAt(timestamppb.New(diag.CollectedAt))
+	}
+	ret.SetConfig(settings)
+	ret.SetTunnel(tunnel)
+	ret.SetProxyErrors(diag.Proxy.Errors)
+	ret.SetProxiedBytes(diag.Proxy.BytesProxied)
+	ret.SetApiServer(apiServer)
+	return ret
+}
+
+// toProtoClusterUserInfo converts the identity a cluster reports into
+// its protobuf message.
+func toProtoClusterUserInfo(info *core.ClusterUserInfo) *pb.ClusterUserInfo {
+	extra := make(map[string]*pb.ClusterUserExtra, len(info.Extra))
+	for key, values := range info.Extra {
+		e := &pb.ClusterUserExtra{}
+		e.SetValues(values)
+		extra[key] = e
+	}
+
+	ret := &pb.ClusterUserInfo{}
+	ret.SetUsername(info.Username)
+	ret.SetUid(info.UID)
+	ret.SetGroups(info.Groups)
+	ret.SetExtra(extra)
+	return ret
+}
+
+// toProtoAgentPermissions converts the agent's resolved rules into
+// their protobuf message.
+func toProtoAgentPermissions(perms *core.AgentPermissions) *pb.AgentPermissionsResponse {
+	resourceRules := make([]*pb.ResourceRule, 0, len(perms.ResourceRules))
+	for _, rule := range perms.ResourceRules {
+		r := &pb.ResourceRule{}
+		r.SetVerbs(rule.Verbs)
+		r.SetApiGroups(rule.APIGroups)
+		r.SetResources(rule.Resources)
+		r.SetResourceNames(rule.ResourceNames)
+		resourceRules = append(resourceRules, r)
+	}
+	nonResourceRules := make([]*pb.NonResourceRule, 0, len(perms.NonResourceRules))
+	for _, rule := range perms.NonResourceRules {
+		r := &pb.NonResourceRule{}
+		r.SetVerbs(rule.Verbs)
+		r.SetNonResourceUrls(rule.NonResourceURLs)
+		nonResourceRules = append(nonResourceRules, r)
+	}
+
+	ret := &pb.AgentPermissionsResponse{}
+	ret.SetNamespace(perms.Namespace)
+	ret.SetResourceRules(resourceRules)
+	ret.SetNonResourceRules(nonResourceRules)
+	ret.SetIncomplete(perms.Incomplete)
+	ret.SetEvaluationError(perms.EvaluationError)
 	return ret
 }
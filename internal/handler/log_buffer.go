@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+)
+
+// logBuffer is a bounded buffer between a pod log reader and a client
+// that may be slower than the log is written. Bursts are absorbed as
+// long as they fit; beyond that the oldest whole lines are dropped and
+// counted, so that the reader never stalls on the client and the
+// client catches up with the most recent output.
+//
+// A logBuffer has a single writer (fill) and a single reader (next).
+type logBuffer struct {
+	mu    sync.Mutex
+	data  []byte
+	size  int
+	head  uint64 // index of the line data starts in
+	gap   logGap // lines dropped since the last next
+	err   error
+	ready chan struct{} // signalled when data, drops or err arrive
+}
+
+// logGap is a run of consecutive lines dropped from a logBuffer.
+type logGap struct {
+	From  uint64 // index of the first dropped line, counting from 0
+	Lines uint64
+}
+
+func newLogBuffer(size int) *logBuffer {
+	return &logBuffer{size: size, ready: make(chan struct{}, 1)}
+}
+
+// fill copies r into the buffer until r fails, recording the error
+// (io.EOF at the end of the log) for next to return once the buffer
+// is drained.
+func (b *logBuffer) fill(r io.Reader) {
+	buf := make([]byte, streamChunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			b.write(buf[:n])
+		}
+		if err != nil {
+			b.mu.Lock()
+			b.err = err
+			b.mu.Unlock()
+			b.signal()
+			return
+		}
+	}
+}
+
+// write appends p, dropping the oldest lines if the buffer overflows.
+func (b *logBuffer) write(p []byte) {
+	b.mu.Lock()
+	b.data = append(b.data, p...)
+	if excess := len(b.data) - b.size; excess > 0 {
+		// Drop up to the end of the line holding the last byte that
+		// does not fit. A line longer than the buffer is cut and
+		// counted as dropped.
+		// Drops between two calls of next all happen at the head of
+		// the buffer, so they form a single run.
+		if b.gap.Lines == 0 {
+			b.gap.From = b.head
+		}
+		cut := len(b.data)
+		if i := bytes.IndexByte(b.data[excess-1:], '\n'); i >= 0 {
+			cut = excess + i
+		} else {
+			cut = excess
+			b.gap.Lines++
+		}
+		lines := uint64(bytes.Count(b.data[:cut], []byte{'\n'}))
+		b.gap.Lines += lines
+		b.head += lines
+		b.data = append(b.data[:0], b.data[cut:]...)
+	}
+	b.mu.Unlock()
+	b.signal()
+}
+
+func (b *logBuffer) signal() {
+	select {
+	case b.ready <- struct{}{}:
+	default:
+	}
+}
+
+// next blocks until the buffer holds data or drops, or the reader
+// failed, and returns the lines dropped since the last call, which
+// precede data, and up to streamChunkSize bytes of data. Once the
+// buffer is drained it returns the reader's error.
+func (b *logBuffer) next(ctx context.Context) (data []byte, gap logGap, err error) {
+	for {
+		b.mu.Lock()
+		if len(b.data) > 0 || b.gap.Lines > 0 {
+			n := min(len(b.data), streamChunkSize)
+			data = append([]byte(nil), b.data[:n]...)
+			b.data = b.data[n:]
+			b.head += uint64(bytes.Count(data, []byte{'\n'}))
+			gap, b.gap = b.gap, logGap{}
+			b.mu.Unlock()
+			return data, gap, nil
+		}
+		err = b.err
+		b.mu.Unlock()
+		if err != nil {
+			return nil, logGap{}, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, logGap{}, ctx.Err()
+		case <-b.ready:
+		}
+	}
+}
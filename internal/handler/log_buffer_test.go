@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	pb "github.com/otterscale/otterscale-agent/api/runtime/v1"
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// lineReader returns one line per Read, like a container writing its
+// log line by line, and closes done once every line has been read.
+type lineReader struct {
+	lines []string
+	done  chan struct{}
+}
+
+func (r *lineReader) Read(p []byte) (int, error) {
+	if len(r.lines) == 0 {
+		select {
+		case <-r.done:
+		default:
+			close(r.done)
+		}
+		return 0, io.EOF
+	}
+	n := copy(p, r.lines[0])
+	r.lines = r.lines[1:]
+	return n, nil
+}
+
+func TestLogBuffer_DropsOldestWholeLines(t *testing.T) {
+	b := newLogBuffer(10)
+	b.write([]byte("aaa\nbbb\n"))
+	b.write([]byte("ccc\nddd\n"))
+
+	data, gap, err := b.next(context.Background())
+	if err != nil {
+		t.Fatalf("next: %v", err)
+	}
+	if string(data) != "ccc\nddd\n" || gap != (logGap{From: 0, Lines: 2}) {
+		t.Errorf("next = %q, gap %+v; want the 2 newest lines and lines 0-1 dropped", data, gap)
+	}
+
+	// A later overflow reports the lines it dropped by index.
+	b.write([]byte("eee\nfff\nggg\n"))
+	if _, gap, _ = b.next(context.Background()); gap != (logGap{From: 4, Lines: 1}) {
+		t.Errorf("gap = %+v, want line 4 dropped", gap)
+	}
+}
+
+func TestStreamPodLog_DropsLinesForSlowClient(t *testing.T) {
+	const total = 200
+	reader := &lineReader{done: make(chan struct{})}
+	for i := range total {
+		reader.lines = append(reader.lines, fmt.Sprintf("line %03d\n", i))
+	}
+
+	// The client stalls on the first message until the whole burst
+	// has been read, so that the 90-byte buffer overflows.
+	var (
+		received bytes.Buffer
+		dropped  uint64
+		markers  int
+	)
+	send := func(msg *pb.PodLogResponse) error {
+		if received.Len() == 0 && markers == 0 {
+			<-reader.done
+		}
+		if msg.GetDroppedLines() > 0 {
+			// The range starts right after the lines received so far.
+			if from, want := msg.GetDroppedFromLine(), uint64(strings.Count(received.String(), "\n"))+dropped; from != want {
+				t.Errorf("dropped range starts at line %d, want %d", from, want)
+			}
+			dropped += msg.GetDroppedLines()
+			markers++
+		}
+		received.Write(msg.GetData())
+		return nil
+	}
+	if err := streamPodLog(context.Background(), reader, 90, send); err != nil {
+		t.Fatalf("streamPodLog: %v", err)
+	}
+
+	if markers == 0 {
+		t.Fatal("no drop marker sent although the buffer overflowed")
+	}
+	lines := strings.Count(received.String(), "\n")
+	if uint64(lines)+dropped != total {
+		t.Errorf("received %d lines and %d dropped, want %d in all", lines, dropped, total)
+	}
+	if !strings.HasSuffix(received.String(), fmt.Sprintf("line %03d\n", total-1)) {
+		t.Errorf("received %q, want it to end with the newest line", received.String())
+	}
+}
+
+func TestStreamPodLog_Unbuffered(t *testing.T) {
+	reader := &lineReader{lines: []string{"a\n", "b\n"}, done: make(chan struct{})}
+	var received bytes.Buffer
+	send := func(msg *pb.PodLogResponse) error {
+		if msg.GetDroppedLines() > 0 {
+			t.Error("unbuffered stream dropped lines")
+		}
+		received.Write(msg.GetData())
+		return nil
+	}
+	if err := streamPodLog(context.Background(), reader, 0, send); err != nil {
+		t.Fatalf("streamPodLog: %v", err)
+	}
+	if received.String() != "a\nb\n" {
+		t.Errorf("received %q, want %q", received.String(), "a\nb\n")
+	}
+}
+
+func TestRuntimeService_BuffersOnlyFollowedLogs(t *testing.T) {
+	svc := NewRuntimeService(nil, core.PodLogConfig{BufferSize: 1 << 20})
+	if got := svc.podLogBufferSize(true); got != 1<<20 {
+		t.Errorf("followed log buffer = %d, want %d", got, 1<<20)
+	}
+	if got := svc.podLogBufferSize(false); got != 0 {
+		t.Errorf("finite log buffer = %d, want 0 so that no line is dropped", got)
+	}
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+
+	resourcev1 "github.com/otterscale/otterscale-agent/api/resource/v1/pbconnect"
+	runtimev1 "github.com/otterscale/otterscale-agent/api/runtime/v1/pbconnect"
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// mutatingProcedures are the RPCs rejected in maintenance mode: those
+// that change cluster state. Starting an exec or port-forward session
+// is included, since commands run in it, or requests sent through it,
+// may change anything; input to a session that is already open is not.
+var mutatingProcedures = map[string]struct{}{
+	resourcev1.ResourceServiceCreateProcedure:           {},
+	resourcev1.ResourceServiceApplyProcedure:            {},
+	resourcev1.ResourceServiceForceApplyProcedure:       {},
+	resourcev1.ResourceServiceApplyManifestProcedure:    {},
+	resourcev1.ResourceServiceApplyBootstrapProcedure:   {},
+	resourcev1.ResourceServiceApplyFromSourceProcedure:  {},
+	resourcev1.ResourceServiceApplyWithPruneProcedure:   {},
+	resourcev1.ResourceServiceApplyHelmChartProcedure:   {},
+	resourcev1.ResourceServiceSetLabelProcedure:         {},
+	resourcev1.ResourceServiceRemoveLabelProcedure:      {},
+	resourcev1.ResourceServiceSetAnnotationProcedure:    {},
+	resourcev1.ResourceServiceRemoveAnnotationProcedure: {},
+	resourcev1.ResourceServiceDeleteProcedure:           {},
+	runtimev1.RuntimeServiceExecuteTTYProcedure:         {},
+	runtimev1.RuntimeServicePortForwardProcedure:        {},
+	runtimev1.RuntimeServiceScaleProcedure:              {},
+	runtimev1.RuntimeServiceSuspendProcedure:            {},
+	runtimev1.RuntimeServiceResumeProcedure:             {},
+	runtimev1.RuntimeServiceRestartProcedure:            {},
+	runtimev1.RuntimeServiceRestartAndWaitProcedure:     {},
+	runtimev1.RuntimeServiceRestartAndWatchProcedure:    {},
+}
+
+// maintenanceInterceptor rejects mutating RPCs with UNAVAILABLE and
+// the maintenance message while maintenance mode is enabled. Other
+// RPCs pass through.
+type maintenanceInterceptor struct {
+	maintenance *core.MaintenanceUseCase
+}
+
+// NewMaintenanceInterceptor returns an interceptor enforcing the
+// maintenance mode of maintenance.
+func NewMaintenanceInterceptor(maintenance *core.MaintenanceUseCase) connect.Interceptor {
+	return &maintenanceInterceptor{maintenance: maintenance}
+}
+
+func (i *maintenanceInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if err := i.check(req.Spec().Procedure); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (i *maintenanceInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *maintenanceInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if err := i.check(conn.Spec().Procedure); err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
+
+// check returns the error rejecting procedure, or nil if it may run.
+func (i *maintenanceInterceptor) check(procedure string) error {
+	if _, ok := mutatingProcedures[procedure]; !ok {
+		return nil
+	}
+	if err := i.maintenance.CheckMutation(); err != nil {
+		return domainErrorToConnectError(err)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	pb "github.com/otterscale/otterscale-agent/api/resource/v1"
+	"github.com/otterscale/otterscale-agent/api/resource/v1/pbconnect"
+	runtimev1 "github.com/otterscale/otterscale-agent/api/runtime/v1/pbconnect"
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// listRepo lists nothing and records whether a mutation reached it.
+type listRepo struct {
+	core.ResourceRepo
+	created bool
+}
+
+func (r *listRepo) List(context.Context, string, schema.GroupVersionResource, string, core.ListOptions) (*unstructured.UnstructuredList, error) {
+	return &unstructured.UnstructuredList{}, nil
+}
+
+func (r *listRepo) Create(context.Context, string, schema.GroupVersionResource, string, []byte) (*unstructured.Unstructured, error) {
+	r.created = true
+	return &unstructured.Unstructured{}, nil
+}
+
+func TestMaintenanceInterceptor_BlocksMutations(t *testing.T) {
+	maintenance := core.NewMaintenanceUseCase(core.MaintenanceConfig{AdminGroups: []string{"ops"}})
+	repo := &listRepo{}
+	uc := core.NewResourceUseCase(stubDiscovery{}, repo, nil, nil, nil, core.ApplyConfig{}, core.NamespaceConfig{})
+	svc := NewResourceService(uc, nil, nil, nil, core.WatchConfig{})
+
+	mux := http.NewServeMux()
+	mux.Handle(pbconnect.NewResourceServiceHandler(svc, connect.WithInterceptors(NewMaintenanceInterceptor(maintenance))))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	client := pbconnect.NewResourceServiceClient(srv.Client(), srv.URL)
+
+	list := &pb.ListRequest{}
+	list.SetCluster("edge-1")
+	list.SetVersion("v1")
+	list.SetResource("nodes")
+	create := &pb.CreateRequest{}
+	create.SetCluster("edge-1")
+	create.SetVersion("v1")
+	create.SetResource("nodes")
+	ctx := context.Background()
+
+	admin := core.WithUserInfo(ctx, core.UserInfo{Subject: "alice", Groups: []string{"ops"}})
+	if _, err := maintenance.SetMaintenance(admin, true, "upgrading the fleet"); err != nil {
+		t.Fatalf("enable maintenance: %v", err)
+	}
+
+	_, err := client.Create(ctx, create)
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) || connectErr.Code() != connect.CodeUnavailable || connectErr.Message() != "upgrading the fleet" {
+		t.Fatalf("Create in maintenance: err = %v, want unavailable with the maintenance message", err)
+	}
+	if repo.created {
+		t.Error("Create reached the repo in maintenance mode")
+	}
+	if _, err := client.List(ctx, list); err != nil {
+		t.Errorf("List in maintenance: %v", err)
+	}
+
+	if _, err := maintenance.SetMaintenance(admin, false, ""); err != nil {
+		t.Fatalf("disable maintenance: %v", err)
+	}
+	if _, err := client.Create(ctx, create); err != nil {
+		t.Errorf("Create after maintenance: %v", err)
+	}
+	if !repo.created {
+		t.Error("Create did not reach the repo after maintenance")
+	}
+}
+
+func TestMaintenanceInterceptor_BlocksSessionStarts(t *testing.T) {
+	maintenance := core.NewMaintenanceUseCase(core.MaintenanceConfig{AdminGroups: []string{"ops"}})
+	i := &maintenanceInterceptor{maintenance: maintenance}
+
+	admin := core.WithUserInfo(context.Background(), core.UserInfo{Subject: "alice", Groups: []string{"ops"}})
+	if _, err := maintenance.SetMaintenance(admin, true, "upgrading the fleet"); err != nil {
+		t.Fatalf("enable maintenance: %v", err)
+	}
+
+	for procedure, blocked := range map[string]bool{
+		runtimev1.RuntimeServiceExecuteTTYProcedure:       true,
+		runtimev1.RuntimeServicePortForwardProcedure:      true,
+		runtimev1.RuntimeServiceWriteTTYProcedure:         false,
+		runtimev1.RuntimeServiceWritePortForwardProcedure: false,
+	} {
+		err := i.check(procedure)
+		var connectErr *connect.Error
+		if blocked && (!errors.As(err, &connectErr) || connectErr.Code() != connect.CodeUnavailable) {
+			t.Errorf("%s in maintenance: err = %v, want unavailable", procedure, err)
+		}
+		if !blocked && err != nil {
+			t.Errorf("%s in maintenance: err = %v, want it to pass", procedure, err)
+		}
+	}
+}
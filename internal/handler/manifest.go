@@ -6,19 +6,21 @@ import (
 	"github.com/otterscale/otterscale-agent/internal/core"
 )
 
-// ManifestHandler provides token verification and manifest rendering
-// for the raw HTTP manifest endpoint (kubectl apply -f <url>). It is
+// ManifestHandler provides token verification and rendering for the
+// raw HTTP manifest (kubectl apply -f <url>), kubeconfig and support
+// bundle download endpoints. It is
 // separated from FleetService to keep the gRPC handler focused on
 // ConnectRPC concerns and avoid coupling the transport layer to the
 // handler layer for non-RPC operations.
 type ManifestHandler struct {
-	fleet *core.FleetUseCase
+	fleet         *core.FleetUseCase
+	supportBundle *core.SupportBundleUseCase
 }
 
 // NewManifestHandler returns a ManifestHandler backed by the given
-// FleetUseCase.
-func NewManifestHandler(fleet *core.FleetUseCase) *ManifestHandler {
-	return &ManifestHandler{fleet: fleet}
+// FleetUseCase and SupportBundleUseCase.
+func NewManifestHandler(fleet *core.FleetUseCase, supportBundle *core.SupportBundleUseCase) *ManifestHandler {
+	return &ManifestHandler{fleet: fleet, supportBundle: supportBundle}
 }
 
 // VerifyManifestToken validates an HMAC-signed manifest token and
@@ -32,3 +34,15 @@ func (h *ManifestHandler) VerifyManifestToken(ctx context.Context, token string)
 func (h *ManifestHandler) RenderManifest(ctx context.Context, cluster, userName string) (string, error) {
 	return h.fleet.GenerateAgentManifest(ctx, cluster, userName)
 }
+
+// DownloadKubeconfig validates an HMAC-signed kubeconfig token and
+// returns the cluster and kubeconfig it was issued for.
+func (h *ManifestHandler) DownloadKubeconfig(ctx context.Context, token string) (cluster, kubeconfig string, err error) {
+	return h.fleet.DownloadKubeconfig(ctx, token)
+}
+
+// DownloadSupportBundle validates an HMAC-signed support bundle token
+// and returns the file name and zip archive of the bundle.
+func (h *ManifestHandler) DownloadSupportBundle(ctx context.Context, token string) (name string, data []byte, err error) {
+	return h.supportBundle.DownloadSupportBundle(ctx, token)
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+
+	"connectrpc.com/connect"
+)
+
+// PanicReporter receives the panics recovered from RPC handlers, e.g.
+// to forward them to an error tracker. Implementations must be safe for
+// concurrent use and should not block.
+type PanicReporter interface {
+	ReportPanic(ctx context.Context, procedure string, value any, stack []byte)
+}
+
+// recoverInterceptor turns a panic in a unary or streaming handler into
+// a CodeInternal error, so that a bug in one RPC fails that RPC instead
+// of crashing the server. Deferred cleanup in the handler (e.g. of exec
+// and port-forward sessions) runs while the panic unwinds, before it
+// is recovered here.
+type recoverInterceptor struct {
+	reporters []PanicReporter
+}
+
+// NewRecoverInterceptor returns an interceptor that recovers panics in
+// handlers, logs them with their stack and passes them to reporters.
+// It should be the innermost interceptor, so that the others observe
+// the resulting error.
+func NewRecoverInterceptor(reporters ...PanicReporter) connect.Interceptor {
+	return &recoverInterceptor{reporters: reporters}
+}
+
+func (i *recoverInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (resp connect.AnyResponse, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				resp, err = nil, i.recovered(ctx, req.Spec().Procedure, r)
+			}
+		}()
+		return next(ctx, req)
+	}
+}
+
+func (i *recoverInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *recoverInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = i.recovered(ctx, conn.Spec().Procedure, r)
+			}
+		}()
+		return next(ctx, conn)
+	}
+}
+
+// recovered logs and reports the panic value r of procedure and
+// returns the error sent to the client in its place. The panic value
+// itself is not sent, since it may expose internals. An
+// http.ErrAbortHandler panic, by which a handler deliberately aborts
+// its response, is propagated.
+func (i *recoverInterceptor) recovered(ctx context.Context, procedure string, r any) error {
+	if r == http.ErrAbortHandler {
+		panic(r)
+	}
+	stack := debug.Stack()
+	slog.ErrorContext(ctx, "rpc handler panic recovered",
+		"procedure", procedure,
+		"panic", r,
+		"stack", string(stack),
+	)
+	for _, reporter := range i.reporters {
+		reporter.ReportPanic(ctx, procedure, r, stack)
+	}
+	return connect.NewError(connect.CodeInternal, errors.New("internal error"))
+}
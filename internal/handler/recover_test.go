@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// recordingReporter records the procedures of reported panics.
+type recordingReporter struct {
+	mu         sync.Mutex
+	procedures []string
+}
+
+func (r *recordingReporter) ReportPanic(_ context.Context, procedure string, value any, stack []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if value == "boom" && len(stack) > 0 {
+		r.procedures = append(r.procedures, procedure)
+	}
+}
+
+func TestRecoverInterceptor(t *testing.T) {
+	reporter := &recordingReporter{}
+	interceptors := connect.WithInterceptors(NewRecoverInterceptor(reporter))
+
+	var cleanedUp atomic.Bool
+	mux := http.NewServeMux()
+	mux.Handle("/test.v1.Panic/Unary", connect.NewUnaryHandler("/test.v1.Panic/Unary",
+		func(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+			panic("boom")
+		}, interceptors))
+	mux.Handle("/test.v1.Panic/Stream", connect.NewServerStreamHandler("/test.v1.Panic/Stream",
+		func(_ context.Context, _ *connect.Request[emptypb.Empty], stream *connect.ServerStream[emptypb.Empty]) error {
+			defer cleanedUp.Store(true)
+			if err := stream.Send(&emptypb.Empty{}); err != nil {
+				return err
+			}
+			panic("boom")
+		}, interceptors))
+	mux.Handle("/test.v1.Panic/Healthy", connect.NewUnaryHandler("/test.v1.Panic/Healthy",
+		func(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+			return connect.NewResponse(&emptypb.Empty{}), nil
+		}, interceptors))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	ctx := context.Background()
+
+	unary := connect.NewClient[emptypb.Empty, emptypb.Empty](server.Client(), server.URL+"/test.v1.Panic/Unary")
+	_, err := unary.CallUnary(ctx, connect.NewRequest(&emptypb.Empty{}))
+	if connect.CodeOf(err) != connect.CodeInternal || strings.Contains(err.Error(), "boom") {
+		t.Errorf("unary error = %v, want Internal without the panic value", err)
+	}
+
+	streaming := connect.NewClient[emptypb.Empty, emptypb.Empty](server.Client(), server.URL+"/test.v1.Panic/Stream")
+	stream, err := streaming.CallServerStream(ctx, connect.NewRequest(&emptypb.Empty{}))
+	if err != nil {
+		t.Fatalf("CallServerStream: %v", err)
+	}
+	received := 0
+	for stream.Receive() {
+		received++
+	}
+	if received != 1 || connect.CodeOf(stream.Err()) != connect.CodeInternal {
+		t.Errorf("stream received %d messages and ended with %v, want 1 message then Internal", received, stream.Err())
+	}
+	_ = stream.Close()
+	if !cleanedUp.Load() {
+		t.Error("deferred cleanup of the streaming handler did not run")
+	}
+
+	healthy := connect.NewClient[emptypb.Empty, emptypb.Empty](server.Client(), server.URL+"/test.v1.Panic/Healthy")
+	if _, err := healthy.CallUnary(ctx, connect.NewRequest(&emptypb.Empty{})); err != nil {
+		t.Errorf("server did not survive the panics: %v", err)
+	}
+
+	reporter.mu.Lock()
+	defer reporter.mu.Unlock()
+	if want := []string{"/test.v1.Panic/Unary", "/test.v1.Panic/Stream"}; len(reporter.procedures) != 2 ||
+		reporter.procedures[0] != want[0] || reporter.procedures[1] != want[1] {
+		t.Errorf("reported = %v, want %v", reporter.procedures, want)
+	}
+}
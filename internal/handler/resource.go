@@ -6,11 +6,14 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
+	"time"
 
 	"connectrpc.com/connect"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -28,13 +31,21 @@ type ResourceService struct {
 	pbconnect.UnimplementedResourceServiceHandler
 
 	resource *core.ResourceUseCase
+	source   *core.ManifestSourceUseCase
+	helm     *core.HelmChartUseCase
+	proxy    *core.ProxyUseCase
+	watch    core.WatchConfig
 }
 
 // NewResourceService returns a ResourceService backed by the given
-// use-case.
-func NewResourceService(resource *core.ResourceUseCase) *ResourceService {
+// use-cases, applying watch to every watch stream.
+func NewResourceService(resource *core.ResourceUseCase, source *core.ManifestSourceUseCase, helm *core.HelmChartUseCase, proxy *core.ProxyUseCase, watch core.WatchConfig) *ResourceService {
 	return &ResourceService{
 		resource: resource,
+		source:   source,
+		helm:     helm,
+		proxy:    proxy,
+		watch:    watch,
 	}
 }
 
@@ -45,9 +56,10 @@ var _ pbconnect.ResourceServiceHandler = (*ResourceService)(nil)
 // ---------------------------------------------------------------------------
 
 // Discovery returns the full list of API resources available on the
-// target cluster.
+// target cluster. Group-versions that could not be discovered are
+// reported as warnings instead of failing the whole request.
 func (s *ResourceService) Discovery(ctx context.Context, req *pb.DiscoveryRequest) (*pb.DiscoveryResponse, error) {
-	apiResources, err := s.resource.ServerResources(ctx, req.GetCluster())
+	apiResources, failures, err := s.resource.ServerResources(ctx, req.GetCluster())
 	if err != nil {
 		return nil, domainErrorToConnectError(err)
 	}
@@ -57,8 +69,36 @@ func (s *ResourceService) Discovery(ctx context.Context, req *pb.DiscoveryReques
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
+	warnings := make([]*pb.DiscoveryWarning, 0, len(failures))
+	for _, f := range failures {
+		warning := &pb.DiscoveryWarning{}
+		warning.SetGroupVersion(f.GroupVersion)
+		warning.SetMessage(f.Message)
+		warnings = append(warnings, warning)
+	}
+
 	resp := &pb.DiscoveryResponse{}
 	resp.SetApiResources(pbAPIResources)
+	resp.SetWarnings(warnings)
+	return resp, nil
+}
+
+// Capabilities reports the version-gated features supported by the
+// cluster.
+func (s *ResourceService) Capabilities(ctx context.Context, req *pb.CapabilitiesRequest) (*pb.CapabilitiesResponse, error) {
+	caps, err := s.resource.ClusterCapabilities(ctx, req.GetCluster())
+	if err != nil {
+		return nil, domainErrorToConnectError(err)
+	}
+
+	resp := &pb.CapabilitiesResponse{}
+	resp.SetKubernetesVersion(caps.KubernetesVersion)
+	resp.SetServerSideApply(caps.ServerSideApply)
+	resp.SetEphemeralContainers(caps.EphemeralContainers)
+	resp.SetSidecarContainers(caps.SidecarContainers)
+	resp.SetValidatingAdmissionPolicy(caps.ValidatingAdmissionPolicy)
+	resp.SetInPlacePodResize(caps.InPlacePodResize)
+	resp.SetWatchList(caps.WatchList)
 	return resp, nil
 }
 
@@ -102,6 +142,7 @@ func (s *ResourceService) List(ctx context.Context, req *pb.ListRequest) (*pb.Li
 			FieldSelector: req.GetFieldSelector(),
 			Limit:         req.GetLimit(),
 			Continue:      req.GetContinue(),
+			AllNamespaces: req.GetAllNamespaces(),
 		},
 	)
 	if err != nil {
@@ -109,10 +150,9 @@ func (s *ResourceService) List(ctx context.Context, req *pb.ListRequest) (*pb.Li
 	}
 
 	// Strip noisy metadata (managedFields, last-applied-configuration)
-	// before serialising to protobuf. This is a presentation concern
-	// that belongs in the handler layer, not the domain use-case.
+	// before serialising to protobuf to reduce the payload size.
 	for i := range resources.Items {
-		cleanObject(resources.Items[i].Object)
+		core.CleanObject(resources.Items[i].Object)
 	}
 
 	pbResources, err := toProtoResources(resources.Items)
@@ -163,6 +203,11 @@ func (s *ResourceService) Create(ctx context.Context, req *pb.CreateRequest) (*p
 			Namespace: req.GetNamespace(),
 		},
 		req.GetManifest(),
+		core.CreateOptions{
+			CheckNamespace: req.GetCheckNamespace(),
+			SkipManagedBy:  req.GetSkipManagedBy(),
+			IdempotencyKey: req.GetIdempotencyKey(),
+		},
 	)
 	if err != nil {
 		return nil, domainErrorToConnectError(err)
@@ -188,9 +233,257 @@ func (s *ResourceService) Apply(ctx context.Context, req *pb.ApplyRequest) (*pb.
 		},
 		req.GetManifest(),
 		core.ApplyOptions{
-			Force:        req.GetForce(),
-			FieldManager: req.GetFieldManager(),
+			Force:          req.GetForce(),
+			FieldManager:   req.GetFieldManager(),
+			CheckNamespace: req.GetCheckNamespace(),
+			SkipManagedBy:  req.GetSkipManagedBy(),
+			WaitTimeout:    time.Duration(min(req.GetWaitTimeoutSeconds(), math.MaxInt64/int64(time.Second))) * time.Second,
+		},
+	)
+	if err != nil {
+		return nil, domainErrorToConnectError(err)
+	}
+	result, err := toProtoResource(resource.Object)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return result, nil
+}
+
+// ForceApply performs a Server-Side Apply with force after Apply
+// reported conflicts, returning the fields taken over from other field
+// managers.
+func (s *ResourceService) ForceApply(ctx context.Context, req *pb.ApplyRequest) (*pb.ForceApplyResponse, error) {
+	result, err := s.resource.ForceApplyResource(
+		ctx,
+		core.ResourceIdentifier{
+			Cluster:   req.GetCluster(),
+			Group:     req.GetGroup(),
+			Version:   req.GetVersion(),
+			Resource:  req.GetResource(),
+			Namespace: req.GetNamespace(),
+			Name:      req.GetName(),
+		},
+		req.GetManifest(),
+		core.ApplyOptions{
+			FieldManager:   req.GetFieldManager(),
+			CheckNamespace: req.GetCheckNamespace(),
+			SkipManagedBy:  req.GetSkipManagedBy(),
+		},
+	)
+	if err != nil {
+		return nil, domainErrorToConnectError(err)
+	}
+	resource, err := toProtoResource(result.Object.Object)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	resp := &pb.ForceApplyResponse{}
+	resp.SetResource(resource)
+	resp.SetOverridden(toProtoApplyConflicts(result.Overridden))
+	return resp, nil
+}
+
+// ApplyManifest applies a multi-document manifest, streaming one event
+// per object as it is applied, fails or, for CRDs, is established.
+func (s *ResourceService) ApplyManifest(ctx context.Context, req *pb.ApplyManifestRequest, stream *connect.ServerStream[pb.ApplyManifestEvent]) error {
+	opts := toApplyManifestOptions(req.GetForce(), req.GetFieldManager(), req.GetStopOnError(), req.GetCrdTimeoutSeconds())
+	opts.WaitTimeout = time.Duration(min(req.GetWaitTimeoutSeconds(), math.MaxInt64/int64(time.Second))) * time.Second
+	err := s.resource.ApplyManifest(
+		ctx,
+		req.GetCluster(),
+		req.GetManifest(),
+		opts,
+		func(event core.ManifestEvent) error {
+			return stream.Send(toProtoManifestEvent(event))
+		},
+	)
+	if err != nil {
+		return domainErrorToConnectError(err)
+	}
+	return nil
+}
+
+// ApplyBootstrap applies the agent's bootstrap manifests, streaming
+// their progress as ApplyManifest does.
+func (s *ResourceService) ApplyBootstrap(ctx context.Context, req *pb.ApplyBootstrapRequest, stream *connect.ServerStream[pb.ApplyManifestEvent]) error {
+	err := s.resource.ApplyBootstrap(ctx, req.GetCluster(), func(event core.ManifestEvent) error {
+		return stream.Send(toProtoManifestEvent(event))
+	})
+	if err != nil {
+		return domainErrorToConnectError(err)
+	}
+	return nil
+}
+
+// ApplyFromSource applies a manifest read from a URL or a ConfigMap,
+// streaming its progress as ApplyManifest does.
+func (s *ResourceService) ApplyFromSource(ctx context.Context, req *pb.ApplyFromSourceRequest, stream *connect.ServerStream[pb.ApplyManifestEvent]) error {
+	source := core.ManifestSource{URL: req.GetUrl()}
+	if ref := req.GetConfigMap(); ref != nil {
+		source.ConfigMap = &core.ConfigMapKeyRef{
+			Namespace: ref.GetNamespace(),
+			Name:      ref.GetName(),
+			Key:       ref.GetKey(),
+		}
+	}
+
+	err := s.source.ApplyFromSource(
+		ctx,
+		req.GetCluster(),
+		source,
+		toApplyManifestOptions(req.GetForce(), req.GetFieldManager(), req.GetStopOnError(), req.GetCrdTimeoutSeconds()),
+		func(event core.ManifestEvent) error {
+			return stream.Send(toProtoManifestEvent(event))
+		},
+	)
+	if err != nil {
+		return domainErrorToConnectError(err)
+	}
+	return nil
+}
+
+// ApplyHelmChart renders an uploaded Helm chart and applies the result,
+// streaming its progress as ApplyManifest does.
+func (s *ResourceService) ApplyHelmChart(ctx context.Context, req *pb.ApplyHelmChartRequest, stream *connect.ServerStream[pb.ApplyManifestEvent]) error {
+	err := s.helm.ApplyHelmChart(
+		ctx,
+		req.GetCluster(),
+		req.GetChart(),
+		req.GetValues(),
+		core.HelmRelease{Name: req.GetReleaseName(), Namespace: req.GetNamespace()},
+		toApplyManifestOptions(req.GetForce(), req.GetFieldManager(), req.GetStopOnError(), req.GetCrdTimeoutSeconds()),
+		func(event core.ManifestEvent) error {
+			return stream.Send(toProtoManifestEvent(event))
+		},
+	)
+	if err != nil {
+		return domainErrorToConnectError(err)
+	}
+	return nil
+}
+
+// ApplyWithPrune applies a manifest and prunes the labelled objects it
+// no longer contains, streaming its progress as ApplyManifest does.
+func (s *ResourceService) ApplyWithPrune(ctx context.Context, req *pb.ApplyWithPruneRequest, stream *connect.ServerStream[pb.ApplyManifestEvent]) error {
+	scopes := make([]core.PruneScope, 0, len(req.GetPruneScopes()))
+	for _, scope := range req.GetPruneScopes() {
+		scopes = append(scopes, core.PruneScope{
+			Group:      scope.GetGroup(),
+			Version:    scope.GetVersion(),
+			Resource:   scope.GetResource(),
+			Namespaces: scope.GetNamespaces(),
+		})
+	}
+
+	err := s.resource.ApplyWithPrune(
+		ctx,
+		req.GetCluster(),
+		req.GetManifest(),
+		core.ApplyWithPruneOptions{
+			ApplyManifestOptions: toApplyManifestOptions(req.GetForce(), req.GetFieldManager(), req.GetStopOnError(), req.GetCrdTimeoutSeconds()),
+			PruneLabel:           req.GetPruneLabel(),
+			Scopes:               scopes,
+		},
+		func(event core.ManifestEvent) error {
+			return stream.Send(toProtoManifestEvent(event))
+		},
+	)
+	if err != nil {
+		return domainErrorToConnectError(err)
+	}
+	return nil
+}
+
+// SetLabel sets a single label on a resource and returns it.
+func (s *ResourceService) SetLabel(ctx context.Context, req *pb.SetLabelRequest) (*pb.Resource, error) {
+	resource, err := s.resource.SetLabel(
+		ctx,
+		core.ResourceIdentifier{
+			Cluster:   req.GetCluster(),
+			Group:     req.GetGroup(),
+			Version:   req.GetVersion(),
+			Resource:  req.GetResource(),
+			Namespace: req.GetNamespace(),
+			Name:      req.GetName(),
+		},
+		req.GetKey(),
+		req.GetValue(),
+	)
+	if err != nil {
+		return nil, domainErrorToConnectError(err)
+	}
+	result, err := toProtoResource(resource.Object)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return result, nil
+}
+
+// RemoveLabel removes a single label from a resource and returns it.
+func (s *ResourceService) RemoveLabel(ctx context.Context, req *pb.RemoveLabelRequest) (*pb.Resource, error) {
+	resource, err := s.resource.RemoveLabel(
+		ctx,
+		core.ResourceIdentifier{
+			Cluster:   req.GetCluster(),
+			Group:     req.GetGroup(),
+			Version:   req.GetVersion(),
+			Resource:  req.GetResource(),
+			Namespace: req.GetNamespace(),
+			Name:      req.GetName(),
+		},
+		req.GetKey(),
+	)
+	if err != nil {
+		return nil, domainErrorToConnectError(err)
+	}
+	result, err := toProtoResource(resource.Object)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return result, nil
+}
+
+// SetAnnotation sets a single annotation on a resource and returns it.
+func (s *ResourceService) SetAnnotation(ctx context.Context, req *pb.SetAnnotationRequest) (*pb.Resource, error) {
+	resource, err := s.resource.SetAnnotation(
+		ctx,
+		core.ResourceIdentifier{
+			Cluster:   req.GetCluster(),
+			Group:     req.GetGroup(),
+			Version:   req.GetVersion(),
+			Resource:  req.GetResource(),
+			Namespace: req.GetNamespace(),
+			Name:      req.GetName(),
+		},
+		req.GetKey(),
+		req.GetValue(),
+	)
+	if err != nil {
+		return nil, domainErrorToConnectError(err)
+	}
+	result, err := toProtoResource(resource.Object)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return result, nil
+}
+
+// RemoveAnnotation removes a single annotation from a resource and returns
+// it.
+func (s *ResourceService) RemoveAnnotation(ctx context.Context, req *pb.RemoveAnnotationRequest) (*pb.Resource, error) {
+	resource, err := s.resource.RemoveAnnotation(
+		ctx,
+		core.ResourceIdentifier{
+			Cluster:   req.GetCluster(),
+			Group:     req.GetGroup(),
+			Version:   req.GetVersion(),
+			Resource:  req.GetResource(),
+			Namespace: req.GetNamespace(),
+			Name:      req.GetName(),
 		},
+		req.GetKey(),
 	)
 	if err != nil {
 		return nil, domainErrorToConnectError(err)
@@ -228,6 +521,41 @@ func (s *ResourceService) Delete(ctx context.Context, req *pb.DeleteRequest) (*e
 	return &emptypb.Empty{}, nil
 }
 
+// WaitForCondition blocks until the resource has the requested status
+// condition and returns it. The status defaults to "True".
+func (s *ResourceService) WaitForCondition(ctx context.Context, req *pb.WaitForConditionRequest) (*pb.Resource, error) {
+	status := req.GetStatus()
+	if status == "" {
+		status = "True"
+	}
+	// Clamp before converting so that huge values are rejected as out
+	// of range instead of overflowing.
+	seconds := min(req.GetTimeoutSeconds(), math.MaxInt64/int64(time.Second))
+
+	resource, err := s.resource.WaitForCondition(
+		ctx,
+		core.ResourceIdentifier{
+			Cluster:   req.GetCluster(),
+			Group:     req.GetGroup(),
+			Version:   req.GetVersion(),
+			Resource:  req.GetResource(),
+			Namespace: req.GetNamespace(),
+			Name:      req.GetName(),
+		},
+		req.GetConditionType(),
+		status,
+		time.Duration(seconds)*time.Second,
+	)
+	if err != nil {
+		return nil, domainErrorToConnectError(err)
+	}
+	result, err := toProtoResource(resource.Object)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return result, nil
+}
+
 // ---------------------------------------------------------------------------
 // Describe
 // ---------------------------------------------------------------------------
@@ -245,6 +573,7 @@ func (s *ResourceService) Describe(ctx context.Context, req *pb.DescribeRequest)
 			Namespace: req.GetNamespace(),
 			Name:      req.GetName(),
 		},
+		describeOptions(req),
 	)
 	if err != nil {
 		return nil, domainErrorToConnectError(err)
@@ -266,15 +595,70 @@ func (s *ResourceService) Describe(ctx context.Context, req *pb.DescribeRequest)
 	return resp, nil
 }
 
+// describeOptions converts the optional since timestamp of a
+// DescribeRequest into core.DescribeOptions.
+func describeOptions(req *pb.DescribeRequest) core.DescribeOptions {
+	var opts core.DescribeOptions
+	if req.HasSince() {
+		opts.Since = req.GetSince().AsTime()
+	}
+	return opts
+}
+
 // ---------------------------------------------------------------------------
-// Watch
+// FieldOwnership
 // ---------------------------------------------------------------------------
 
-// Watch opens a server-streaming RPC that forwards Kubernetes watch
-// events to the client. The stream ends when the client cancels the
-// context or the upstream watcher closes.
-func (s *ResourceService) Watch(ctx context.Context, req *pb.WatchRequest, stream *connect.ServerStream[pb.WatchEvent]) error {
-	watcher, err := s.resource.WatchResource(
+// FieldOwnership returns the field managers owning each field of a
+// resource, from its metadata.managedFields.
+func (s *ResourceService) FieldOwnership(ctx context.Context, req *pb.FieldOwnershipRequest) (*pb.FieldOwnershipResponse, error) {
+	fields, err := s.resource.FieldOwnership(
+		ctx,
+		core.ResourceIdentifier{
+			Cluster:   req.GetCluster(),
+			Group:     req.GetGroup(),
+			Version:   req.GetVersion(),
+			Resource:  req.GetResource(),
+			Namespace: req.GetNamespace(),
+			Name:      req.GetName(),
+		},
+	)
+	if err != nil {
+		return nil, domainErrorToConnectError(err)
+	}
+
+	pbFields := make([]*pb.OwnedField, 0, len(fields))
+	for _, f := range fields {
+		owners := make([]*pb.FieldOwner, 0, len(f.Owners))
+		for _, o := range f.Owners {
+			owner := &pb.FieldOwner{}
+			owner.SetManager(o.Manager)
+			owner.SetOperation(o.Operation)
+			if !o.Time.IsZero() {
+				owner.SetTime(timestamppb.New(o.Time))
+			}
+			owner.SetSubresource(o.Subresource)
+			owner.SetApiVersion(o.APIVersion)
+			owners = append(owners, owner)
+		}
+		field := &pb.OwnedField{}
+		field.SetPath(f.Path)
+		field.SetOwners(owners)
+		pbFields = append(pbFields, field)
+	}
+
+	resp := &pb.FieldOwnershipResponse{}
+	resp.SetFields(pbFields)
+	return resp, nil
+}
+
+// ---------------------------------------------------------------------------
+// GetRevision
+// ---------------------------------------------------------------------------
+
+// GetRevision returns a recorded revision of a workload's pod template.
+func (s *ResourceService) GetRevision(ctx context.Context, req *pb.GetRevisionRequest) (*pb.Revision, error) {
+	rev, err := s.resource.GetRevision(
 		ctx,
 		core.ResourceIdentifier{
 			Cluster:   req.GetCluster(),
@@ -282,41 +666,392 @@ func (s *ResourceService) Watch(ctx context.Context, req *pb.WatchRequest, strea
 			Version:   req.GetVersion(),
 			Resource:  req.GetResource(),
 			Namespace: req.GetNamespace(),
+			Name:      req.GetName(),
 		},
-		core.WatchOptions{
-			LabelSelector:   req.GetLabelSelector(),
-			FieldSelector:   req.GetFieldSelector(),
-			ResourceVersion: req.GetResourceVersion(),
+		req.GetRevision(),
+	)
+	if err != nil {
+		return nil, domainErrorToConnectError(err)
+	}
+
+	template, err := structpb.NewStruct(rev.Template)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	ret := &pb.Revision{}
+	ret.SetRevision(rev.Revision)
+	ret.SetName(rev.Name)
+	if !rev.CreatedAt.IsZero() {
+		ret.SetCreatedAt(timestamppb.New(rev.CreatedAt))
+	}
+	ret.SetChangeCause(rev.ChangeCause)
+	ret.SetCurrent(rev.Current)
+	ret.SetTemplate(template)
+	return ret, nil
+}
+
+// ---------------------------------------------------------------------------
+// CompareAcrossClusters
+// ---------------------------------------------------------------------------
+
+// CompareAcrossClusters diffs a resource between two clusters.
+func (s *ResourceService) CompareAcrossClusters(ctx context.Context, req *pb.CompareAcrossClustersRequest) (*pb.CompareAcrossClustersResponse, error) {
+	comparison, err := s.resource.CompareAcrossClusters(
+		ctx,
+		req.GetClusterA(),
+		req.GetClusterB(),
+		core.ResourceIdentifier{
+			Group:     req.GetGroup(),
+			Version:   req.GetVersion(),
+			Resource:  req.GetResource(),
+			Namespace: req.GetNamespace(),
+			Name:      req.GetName(),
 		},
 	)
 	if err != nil {
+		return nil, domainErrorToConnectError(err)
+	}
+
+	resp := &pb.CompareAcrossClustersResponse{}
+	if comparison.A != nil {
+		a, err := toProtoResource(comparison.A.Object)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		resp.SetA(a)
+	}
+	if comparison.B != nil {
+		b, err := toProtoResource(comparison.B.Object)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		resp.SetB(b)
+	}
+	differences := make([]*pb.FieldDifference, 0, len(comparison.Differences))
+	for _, d := range comparison.Differences {
+		diff, err := toProtoFieldDifference(d)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		differences = append(differences, diff)
+	}
+	resp.SetDifferences(differences)
+	return resp, nil
+}
+
+// toProtoFieldDifference converts a field difference, leaving the value
+// of the side missing the field unset.
+func toProtoFieldDifference(d core.FieldDifference) (*pb.FieldDifference, error) {
+	ret := &pb.FieldDifference{}
+	ret.SetType(toProtoFieldDifferenceType(d.Type))
+	ret.SetPath(d.Path)
+	if d.Type != core.FieldAdded {
+		a, err := structpb.NewValue(d.A)
+		if err != nil {
+			return nil, err
+		}
+		ret.SetA(a)
+	}
+	if d.Type != core.FieldRemoved {
+		b, err := structpb.NewValue(d.B)
+		if err != nil {
+			return nil, err
+		}
+		ret.SetB(b)
+	}
+	return ret, nil
+}
+
+// toProtoFieldDifferenceType maps a core difference type to its proto
+// enum.
+func toProtoFieldDifferenceType(t core.FieldDifferenceType) pb.FieldDifference_Type {
+	switch t {
+	case core.FieldAdded:
+		return pb.FieldDifference_TYPE_ADDED
+	case core.FieldRemoved:
+		return pb.FieldDifference_TYPE_REMOVED
+	case core.FieldChanged:
+		return pb.FieldDifference_TYPE_CHANGED
+	default:
+		return pb.FieldDifference_TYPE_UNSPECIFIED
+	}
+}
+
+// ---------------------------------------------------------------------------
+// NamespaceQuota
+// ---------------------------------------------------------------------------
+
+// NamespaceQuota returns the ResourceQuota usage and LimitRange
+// constraints of a namespace.
+func (s *ResourceService) NamespaceQuota(ctx context.Context, req *pb.NamespaceQuotaRequest) (*pb.NamespaceQuotaResponse, error) {
+	summary, err := s.resource.NamespaceQuota(ctx, req.GetCluster(), req.GetNamespace())
+	if err != nil {
+		return nil, domainErrorToConnectError(err)
+	}
+
+	quotas := make([]*pb.ResourceQuotaSummary, 0, len(summary.Quotas))
+	for _, q := range summary.Quotas {
+		usages := make([]*pb.QuotaUsage, 0, len(q.Resources))
+		for _, u := range q.Resources {
+			usage := &pb.QuotaUsage{}
+			usage.SetResource(u.Resource)
+			usage.SetUsed(u.Used)
+			usage.SetHard(u.Hard)
+			usage.SetUtilization(u.Utilization)
+			usages = append(usages, usage)
+		}
+		quota := &pb.ResourceQuotaSummary{}
+		quota.SetName(q.Name)
+		quota.SetResources(usages)
+		quotas = append(quotas, quota)
+	}
+
+	limitRanges := make([]*pb.LimitRangeSummary, 0, len(summary.LimitRanges))
+	for _, lr := range summary.LimitRanges {
+		items := make([]*pb.LimitRangeItem, 0, len(lr.Limits))
+		for _, l := range lr.Limits {
+			item := &pb.LimitRangeItem{}
+			item.SetType(l.Type)
+			item.SetResource(l.Resource)
+			item.SetDefault(l.Default)
+			item.SetDefaultRequest(l.DefaultRequest)
+			item.SetMin(l.Min)
+			item.SetMax(l.Max)
+			item.SetMaxLimitRequestRatio(l.MaxLimitRequestRatio)
+			items = append(items, item)
+		}
+		limitRange := &pb.LimitRangeSummary{}
+		limitRange.SetName(lr.Name)
+		limitRange.SetLimits(items)
+		limitRanges = append(limitRanges, limitRange)
+	}
+
+	resp := &pb.NamespaceQuotaResponse{}
+	resp.SetQuotas(quotas)
+	resp.SetLimitRanges(limitRanges)
+	return resp, nil
+}
+
+// ---------------------------------------------------------------------------
+// Watch
+// ---------------------------------------------------------------------------
+
+// Watch opens a server-streaming RPC that forwards Kubernetes watch
+// events to the client. The stream ends when the client cancels the
+// context or the upstream watcher closes. With skip_unchanged set,
+// MODIFIED events that do not change the object semantically are
+// dropped. With resync_interval_seconds set, the stream periodically
+// resyncs with a fresh snapshot.
+func (s *ResourceService) Watch(ctx context.Context, req *pb.WatchRequest, stream *connect.ServerStream[pb.WatchEvent]) error {
+	retainPaths, err := parseRetainFields(req.GetRetainFields())
+	if err != nil {
+		return domainErrorToConnectError(err)
+	}
+	resync, err := s.resyncInterval(req.GetResyncIntervalSeconds())
+	if err != nil {
+		return domainErrorToConnectError(err)
+	}
+
+	id := core.ResourceIdentifier{
+		Cluster:   req.GetCluster(),
+		Group:     req.GetGroup(),
+		Version:   req.GetVersion(),
+		Resource:  req.GetResource(),
+		Namespace: req.GetNamespace(),
+	}
+	opts := core.WatchOptions{
+		LabelSelector:   req.GetLabelSelector(),
+		FieldSelector:   req.GetFieldSelector(),
+		ResourceVersion: req.GetResourceVersion(),
+		AllNamespaces:   req.GetAllNamespaces(),
+		ResyncInterval:  resync,
+		InitialEvents:   s.initialEvents(req),
+	}
+
+	var watcher core.Watcher
+	if req.GetResumeToken() != "" {
+		watcher, err = s.resource.ResumeWatchResource(ctx, id, req.GetResumeToken(), opts)
+	} else {
+		watcher, err = s.resource.WatchResource(ctx, id, opts)
+	}
+	if err != nil {
+		// A too-old resourceVersion is reported in-band so the client
+		// can tell it apart from other failures and relist.
+		var expired *core.ErrResourceVersionExpired
+		if errors.As(err, &expired) {
+			return stream.Send(relistRequiredEvent())
+		}
 		return domainErrorToConnectError(err)
 	}
 	defer watcher.Stop()
 
+	var filter *transitionFilter
+	if req.GetSkipUnchanged() {
+		filter = newTransitionFilter(defaultTransitionCacheSize)
+	}
+
+	var retainer *fieldRetainer
+	if len(retainPaths) > 0 {
+		retainer = newFieldRetainer(retainPaths, func(kind string) (*spec.Schema, error) {
+			return s.resource.ResolveSchema(ctx, req.GetCluster(), req.GetGroup(), req.GetVersion(), kind)
+		})
+	}
+
+	resume := newResumeTracker(id, req.GetResumeToken(), req.GetResourceVersion())
+
+	// Bound the stream's lifetime so that watches of clients that
+	// vanished without cancelling are recycled; the deferred Stop
+	// releases the upstream watch and its relay goroutine.
+	var expire <-chan time.Time
+	if s.watch.MaxDuration > 0 {
+		timer := time.NewTimer(s.watch.MaxDuration)
+		defer timer.Stop()
+		expire = timer.C
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
-			return nil
+			return contextError(ctx)
+
+		case <-expire:
+			return stream.Send(reconnectEvent(resume.last()))
 
 		case event, ok := <-watcher.ResultChan():
 			if !ok {
 				return connect.NewError(connect.CodeUnavailable, errors.New("watch closed"))
 			}
 
+			// Prune before filtering so that changes confined to
+			// dropped fields are suppressed as well.
+			if retainer != nil {
+				event = retainer.retain(event)
+			}
+			if filter != nil && filter.suppress(event) {
+				continue
+			}
+
 			msg, err := processEvent(event)
 			if err != nil {
 				slog.Warn("watch: skipping event", "error", err)
 				continue
 			}
+			msg.SetResumeToken(resume.token(event))
 
 			if err := stream.Send(msg); err != nil {
 				return err
 			}
+			if msg.GetRelistRequired() {
+				return nil
+			}
 		}
 	}
 }
 
+// ---------------------------------------------------------------------------
+// WatchAcrossClusters
+// ---------------------------------------------------------------------------
+
+// WatchAcrossClusters streams the merged watch events of several
+// clusters, each tagged with its cluster. Failures of a cluster's
+// watch are sent as its TYPE_ERROR events; the stream only ends when
+// the client cancels it, the maximum watch duration is reached, or no
+// cluster is watched any more.
+func (s *ResourceService) WatchAcrossClusters(ctx context.Context, req *pb.WatchAcrossClustersRequest, stream *connect.ServerStream[pb.ClusterWatchEvent]) error {
+	id := core.ResourceIdentifier{
+		Group:     req.GetGroup(),
+		Version:   req.GetVersion(),
+		Resource:  req.GetResource(),
+		Namespace: req.GetNamespace(),
+	}
+	opts := core.WatchOptions{
+		LabelSelector: req.GetLabelSelector(),
+		FieldSelector: req.GetFieldSelector(),
+		AllNamespaces: req.GetAllNamespaces(),
+		InitialEvents: s.initialEvents(req),
+	}
+	watcher, err := s.resource.WatchAcrossClusters(ctx, req.GetClusters(), id, opts)
+	if err != nil {
+		return domainErrorToConnectError(err)
+	}
+	defer watcher.Stop()
+
+	var expire <-chan time.Time
+	if s.watch.MaxDuration > 0 {
+		timer := time.NewTimer(s.watch.MaxDuration)
+		defer timer.Stop()
+		expire = timer.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return contextError(ctx)
+
+		case <-expire:
+			msg := &pb.ClusterWatchEvent{}
+			msg.SetEvent(reconnectEvent(""))
+			return stream.Send(msg)
+
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return nil
+			}
+			msg, err := processClusterEvent(event)
+			if err != nil {
+				slog.Warn("watch across clusters: skipping event", "cluster", event.Cluster, "error", err)
+				continue
+			}
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// processClusterEvent converts a core.ClusterWatchEvent into its
+// protobuf message, as processEvent does for single-cluster events.
+func processClusterEvent(event core.ClusterWatchEvent) (*pb.ClusterWatchEvent, error) {
+	inner, err := processEvent(event.WatchEvent)
+	if err != nil {
+		return nil, err
+	}
+	ret := &pb.ClusterWatchEvent{}
+	ret.SetCluster(event.Cluster)
+	ret.SetEvent(inner)
+	if event.Err != nil {
+		ret.SetError(event.Err.Error())
+		ret.SetRetrying(event.Retrying)
+	}
+	return ret, nil
+}
+
+// ---------------------------------------------------------------------------
+// Proxy
+// ---------------------------------------------------------------------------
+
+// Proxy forwards a raw read-only request to the cluster's API server.
+// The use-case enforces the method and path allow-list; the API
+// server's status code and body are returned verbatim.
+func (s *ResourceService) Proxy(ctx context.Context, req *pb.ProxyRequest) (*pb.ProxyResponse, error) {
+	result, err := s.proxy.Proxy(ctx, req.GetCluster(), core.ProxyRequest{
+		Method: req.GetMethod(),
+		Path:   req.GetPath(),
+		Query:  req.GetQuery(),
+		Body:   req.GetBody(),
+	})
+	if err != nil {
+		return nil, domainErrorToConnectError(err)
+	}
+
+	resp := &pb.ProxyResponse{}
+	resp.SetStatusCode(int32(result.StatusCode))
+	resp.SetContentType(result.ContentType)
+	resp.SetBody(result.Body)
+	return resp, nil
+}
+
 // ---------------------------------------------------------------------------
 // Internal helpers
 // ---------------------------------------------------------------------------
@@ -345,6 +1080,9 @@ func processEvent(event core.WatchEvent) (*pb.WatchEvent, error) {
 	case core.WatchEventBookmark:
 		ret := &pb.WatchEvent{}
 		ret.SetType(pb.WatchEvent_TYPE_BOOKMARK)
+		ret.SetRelisted(event.Relisted)
+		ret.SetResynced(event.Resynced)
+		ret.SetInitialEventsEnd(event.InitialEventsEnd)
 		// Extract resourceVersion from the bookmark object.
 		if event.Object != nil {
 			if metadata, ok := event.Object["metadata"].(map[string]any); ok {
@@ -358,6 +1096,7 @@ func processEvent(event core.WatchEvent) (*pb.WatchEvent, error) {
 	case core.WatchEventError:
 		ret := &pb.WatchEvent{}
 		ret.SetType(pb.WatchEvent_TYPE_ERROR)
+		ret.SetRelistRequired(event.Expired)
 		if event.Object != nil {
 			if resource, err := toProtoResource(event.Object); err == nil {
 				ret.SetResource(resource)
@@ -370,6 +1109,111 @@ func processEvent(event core.WatchEvent) (*pb.WatchEvent, error) {
 	}
 }
 
+// relistRequiredEvent returns the ERROR event sent when the watch
+// cannot start because the requested resourceVersion has expired.
+func relistRequiredEvent() *pb.WatchEvent {
+	ret := &pb.WatchEvent{}
+	ret.SetType(pb.WatchEvent_TYPE_ERROR)
+	ret.SetRelistRequired(true)
+	return ret
+}
+
+// applyConflictError returns the FAILED_PRECONDITION error for an
+// apply conflict, with the contested fields attached as
+// ApplyConflictDetails.
+func applyConflictError(conflict *core.ErrApplyConflict) *connect.Error {
+	connectErr := connect.NewError(connect.CodeFailedPrecondition, conflict)
+	details := &pb.ApplyConflictDetails{}
+	details.SetConflicts(toProtoApplyConflicts(conflict.Conflicts))
+	if detail, err := connect.NewErrorDetail(details); err == nil {
+		connectErr.AddDetail(detail)
+	}
+	return connectErr
+}
+
+func toProtoApplyConflicts(conflicts []core.ApplyConflict) []*pb.ApplyConflict {
+	ret := make([]*pb.ApplyConflict, 0, len(conflicts))
+	for _, c := range conflicts {
+		conflict := &pb.ApplyConflict{}
+		conflict.SetManager(c.Manager)
+		conflict.SetField(c.Field)
+		conflict.SetMessage(c.Message)
+		ret = append(ret, conflict)
+	}
+	return ret
+}
+
+// admissionDeniedError converts a webhook rejection into a connect
+// error carrying an AdmissionDenial detail, with the code the webhook
+// chose.
+func admissionDeniedError(denied *core.ErrAdmissionDenied) *connect.Error {
+	code, ok := domainCodeToConnectCode[denied.Code]
+	if !ok {
+		code = connect.CodeInternal
+	}
+	connectErr := connect.NewError(code, denied)
+	details := &pb.AdmissionDenial{}
+	details.SetWebhook(denied.Webhook)
+	details.SetReason(denied.Reason)
+	if detail, err := connect.NewErrorDetail(details); err == nil {
+		connectErr.AddDetail(detail)
+	}
+	return connectErr
+}
+
+// initialEventsRequest is a watch request that may state whether it
+// wants initial events.
+type initialEventsRequest interface {
+	HasSendInitialEvents() bool
+	GetSendInitialEvents() bool
+}
+
+// initialEvents returns the preference for initial events of a watch
+// request: the client's if it states one, otherwise the server's
+// default.
+func (s *ResourceService) initialEvents(req initialEventsRequest) *bool {
+	if req.HasSendInitialEvents() {
+		send := req.GetSendInitialEvents()
+		return &send
+	}
+	if s.watch.NoInitialEvents {
+		send := false
+		return &send
+	}
+	return nil
+}
+
+// resyncInterval converts a requested resync interval, rejecting
+// negative intervals and those shorter than the server's minimum.
+func (s *ResourceService) resyncInterval(seconds int64) (time.Duration, error) {
+	if seconds == 0 {
+		return 0, nil
+	}
+	if seconds < 0 {
+		return 0, &core.ErrInvalidInput{Field: "resync_interval_seconds", Message: "must not be negative"}
+	}
+	// Clamp before converting so that huge values cannot overflow.
+	interval := time.Duration(min(seconds, math.MaxInt64/int64(time.Second))) * time.Second
+	if interval < s.watch.MinResyncInterval {
+		return 0, &core.ErrInvalidInput{
+			Field:   "resync_interval_seconds",
+			Message: fmt.Sprintf("must be at least %s", s.watch.MinResyncInterval),
+		}
+	}
+	return interval, nil
+}
+
+// reconnectEvent returns the final BOOKMARK event sent when a watch
+// reaches the maximum duration, carrying the stream's last resume
+// token, if any.
+func reconnectEvent(resumeToken string) *pb.WatchEvent {
+	ret := &pb.WatchEvent{}
+	ret.SetType(pb.WatchEvent_TYPE_BOOKMARK)
+	ret.SetResumeToken(resumeToken)
+	ret.SetReconnect(true)
+	return ret
+}
+
 // toProtoAPIResources flattens the Kubernetes APIResourceList slice
 // into a single []*pb.APIResource list, embedding the parsed
 // group/version into each entry.
@@ -456,6 +1300,55 @@ func toProtoResource(obj map[string]any) (*pb.Resource, error) {
 	return ret, nil
 }
 
+// toApplyManifestOptions converts the options shared by ApplyManifest,
+// ApplyFromSource, ApplyWithPrune and ApplyHelmChart requests, capping
+// the CRD timeout so that it cannot overflow.
+func toApplyManifestOptions(force bool, fieldManager string, stopOnError bool, crdTimeoutSeconds int64) core.ApplyManifestOptions {
+	seconds := min(crdTimeoutSeconds, math.MaxInt64/int64(time.Second))
+	return core.ApplyManifestOptions{
+		Force:        force,
+		FieldManager: fieldManager,
+		StopOnError:  stopOnError,
+		CRDTimeout:   time.Duration(seconds) * time.Second,
+	}
+}
+
+// toProtoManifestEvent converts an ApplyManifest progress event.
+func toProtoManifestEvent(event core.ManifestEvent) *pb.ApplyManifestEvent {
+	ret := &pb.ApplyManifestEvent{}
+	ret.SetType(toProtoManifestEventType(event.Type))
+	ret.SetIndex(int32(event.Object.Index))
+	ret.SetApiVersion(event.Object.APIVersion)
+	ret.SetKind(event.Object.Kind)
+	ret.SetNamespace(event.Object.Namespace)
+	ret.SetName(event.Object.Name)
+	if event.Err != nil {
+		ret.SetError(event.Err.Error())
+	}
+	return ret
+}
+
+// toProtoManifestEventType maps a core ManifestEventType to its
+// protobuf enum value.
+func toProtoManifestEventType(t core.ManifestEventType) pb.ApplyManifestEvent_Type {
+	switch t {
+	case core.ManifestObjectApplied:
+		return pb.ApplyManifestEvent_TYPE_APPLIED
+	case core.ManifestObjectFailed:
+		return pb.ApplyManifestEvent_TYPE_FAILED
+	case core.ManifestObjectWaiting:
+		return pb.ApplyManifestEvent_TYPE_WAITING
+	case core.ManifestCRDEstablished:
+		return pb.ApplyManifestEvent_TYPE_ESTABLISHED
+	case core.ManifestObjectPruned:
+		return pb.ApplyManifestEvent_TYPE_PRUNED
+	case core.ManifestObjectReady:
+		return pb.ApplyManifestEvent_TYPE_READY
+	default:
+		return pb.ApplyManifestEvent_TYPE_UNSPECIFIED
+	}
+}
+
 // toProtoWatchEventType maps a domain WatchEventType to the protobuf
 // WatchEvent_Type enum.
 func toProtoWatchEventType(t core.WatchEventType) pb.WatchEvent_Type {
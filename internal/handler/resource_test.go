@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	pb "github.com/otterscale/otterscale-agent/api/resource/v1"
+	"github.com/otterscale/otterscale-agent/api/resource/v1/pbconnect"
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+func TestProcessEvent_ErrorRelistRequired(t *testing.T) {
+	tests := []struct {
+		name    string
+		expired bool
+	}{
+		{"expired resource version", true},
+		{"other error", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := processEvent(core.WatchEvent{
+				Type:    core.WatchEventError,
+				Object:  map[string]any{"kind": "Status", "apiVersion": "v1", "code": int64(410)},
+				Expired: tt.expired,
+			})
+			if err != nil {
+				t.Fatalf("processEvent: %v", err)
+			}
+			if msg.GetRelistRequired() != tt.expired {
+				t.Errorf("RelistRequired = %v, want %v", msg.GetRelistRequired(), tt.expired)
+			}
+		})
+	}
+}
+
+func TestProcessEvent_InitialEventsEnd(t *testing.T) {
+	msg, err := processEvent(core.WatchEvent{
+		Type:             core.WatchEventBookmark,
+		Object:           map[string]any{"metadata": map[string]any{"resourceVersion": "42"}},
+		InitialEventsEnd: true,
+	})
+	if err != nil {
+		t.Fatalf("processEvent: %v", err)
+	}
+	if !msg.GetInitialEventsEnd() || msg.GetResourceVersion() != "42" {
+		t.Errorf("got initial events end %v at %q, want true at 42", msg.GetInitialEventsEnd(), msg.GetResourceVersion())
+	}
+}
+
+func TestProcessClusterEvent_Error(t *testing.T) {
+	msg, err := processClusterEvent(core.ClusterWatchEvent{
+		Cluster:    "edge-2",
+		WatchEvent: core.WatchEvent{Type: core.WatchEventError},
+		Err:        errors.New("watch closed"),
+		Retrying:   true,
+	})
+	if err != nil {
+		t.Fatalf("processClusterEvent: %v", err)
+	}
+	if msg.GetCluster() != "edge-2" || msg.GetEvent().GetType() != pb.WatchEvent_TYPE_ERROR || msg.GetError() != "watch closed" || !msg.GetRetrying() {
+		t.Errorf("got %s %s %q retrying=%v, want a retrying TYPE_ERROR for edge-2", msg.GetCluster(), msg.GetEvent().GetType(), msg.GetError(), msg.GetRetrying())
+	}
+}
+
+func TestRelistRequiredEvent(t *testing.T) {
+	msg := relistRequiredEvent()
+	if !msg.GetRelistRequired() {
+		t.Error("RelistRequired should be set")
+	}
+	if msg.GetType() != pb.WatchEvent_TYPE_ERROR {
+		t.Errorf("type = %s, want TYPE_ERROR", msg.GetType())
+	}
+}
+
+// stubDiscovery accepts every resource, all namespaced except nodes;
+// the cluster lacks WatchList.
+type stubDiscovery struct {
+	core.DiscoveryClient
+}
+
+func (stubDiscovery) LookupResource(_ context.Context, _, group, version, resource string) (schema.GroupVersionResource, error) {
+	return schema.GroupVersionResource{Group: group, Version: version, Resource: resource}, nil
+}
+
+func (stubDiscovery) SupportsWatchList(context.Context, string) (bool, error) {
+	return false, nil
+}
+
+func (stubDiscovery) IsNamespaced(_ context.Context, _ string, gvr schema.GroupVersionResource) (bool, error) {
+	return gvr.Resource != "nodes", nil
+}
+
+// idleWatchRepo opens watches that only emit a single bookmark.
+type idleWatchRepo struct {
+	core.ResourceRepo
+	watcher *idleWatcher
+}
+
+func (r *idleWatchRepo) Watch(context.Context, string, schema.GroupVersionResource, string, core.WatchOptions) (core.Watcher, error) {
+	return r.watcher, nil
+}
+
+type idleWatcher struct {
+	ch      chan core.WatchEvent
+	stopped chan struct{}
+}
+
+func (w *idleWatcher) ResultChan() <-chan core.WatchEvent { return w.ch }
+func (w *idleWatcher) Stop()                              { close(w.stopped) }
+
+func TestWatch_EndsAtMaxDuration(t *testing.T) {
+	watcher := &idleWatcher{ch: make(chan core.WatchEvent, 1), stopped: make(chan struct{})}
+	watcher.ch <- core.WatchEvent{Type: core.WatchEventBookmark, Object: map[string]any{
+		"metadata": map[string]any{"resourceVersion": "42"},
+	}}
+	uc := core.NewResourceUseCase(stubDiscovery{}, &idleWatchRepo{watcher: watcher}, nil, nil, nil, core.ApplyConfig{}, core.NamespaceConfig{})
+	svc := NewResourceService(uc, nil, nil, nil, core.WatchConfig{MaxDuration: 200 * time.Millisecond})
+
+	mux := http.NewServeMux()
+	mux.Handle(pbconnect.NewResourceServiceHandler(svc))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	req := &pb.WatchRequest{}
+	req.SetCluster("edge-1")
+	req.SetVersion("v1")
+	req.SetResource("pods")
+	client := pbconnect.NewResourceServiceClient(srv.Client(), srv.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	stream, err := client.Watch(ctx, req)
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+
+	var events []*pb.WatchEvent
+	for stream.Receive() {
+		events = append(events, stream.Msg())
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("stream error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond || elapsed > 3*time.Second {
+		t.Errorf("stream ended after %v, want about the 200ms max duration", elapsed)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want the bookmark and the reconnect event", len(events))
+	}
+	last := events[1]
+	if last.GetType() != pb.WatchEvent_TYPE_BOOKMARK || !last.GetReconnect() {
+		t.Errorf("final event = %v, want a reconnect BOOKMARK", last)
+	}
+	want := core.NewResumeToken(core.ResourceIdentifier{Cluster: "edge-1", Version: "v1", Resource: "pods"}, "42").Encode()
+	if last.GetResumeToken() != want {
+		t.Errorf("final resume token = %q, want %q", last.GetResumeToken(), want)
+	}
+
+	select {
+	case <-watcher.stopped:
+	case <-time.After(time.Second):
+		t.Error("upstream watch was not stopped")
+	}
+}
+
+func TestResourceService_ResyncInterval(t *testing.T) {
+	svc := NewResourceService(nil, nil, nil, nil, core.WatchConfig{MinResyncInterval: time.Minute})
+
+	if got, err := svc.resyncInterval(0); got != 0 || err != nil {
+		t.Errorf("resyncInterval(0) = %v, %v, want no resync", got, err)
+	}
+	if got, err := svc.resyncInterval(300); got != 5*time.Minute || err != nil {
+		t.Errorf("resyncInterval(300) = %v, %v, want 5m", got, err)
+	}
+	for _, seconds := range []int64{-1, 30} {
+		var invalid *core.ErrInvalidInput
+		if _, err := svc.resyncInterval(seconds); !errors.As(err, &invalid) || invalid.Field != "resync_interval_seconds" {
+			t.Errorf("resyncInterval(%d) error = %v, want invalid resync_interval_seconds", seconds, err)
+		}
+	}
+}
+
+func TestResourceService_InitialEvents(t *testing.T) {
+	optOut := &pb.WatchRequest{}
+	optOut.SetSendInitialEvents(false)
+	optIn := &pb.WatchRequest{}
+	optIn.SetSendInitialEvents(true)
+
+	tests := []struct {
+		name string
+		conf core.WatchConfig
+		req  *pb.WatchRequest
+		want string
+	}{
+		{"unset uses the cluster's support", core.WatchConfig{}, &pb.WatchRequest{}, "default"},
+		{"unset with initial events disabled", core.WatchConfig{NoInitialEvents: true}, &pb.WatchRequest{}, "false"},
+		{"client opts out", core.WatchConfig{}, optOut, "false"},
+		{"client opts in despite the server default", core.WatchConfig{NoInitialEvents: true}, optIn, "true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := "default"
+			if p := NewResourceService(nil, nil, nil, nil, tt.conf).initialEvents(tt.req); p != nil {
+				got = strconv.FormatBool(*p)
+			}
+			if got != tt.want {
+				t.Errorf("initialEvents = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,8 +7,10 @@ import (
 	"io"
 	"math"
 	"sync"
+	"time"
 
 	"connectrpc.com/connect"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	pb "github.com/otterscale/otterscale-agent/api/runtime/v1"
@@ -26,12 +28,13 @@ type RuntimeService struct {
 	pbconnect.UnimplementedRuntimeServiceHandler
 
 	runtime *core.RuntimeUseCase
+	podLog  core.PodLogConfig
 }
 
 // NewRuntimeService returns a RuntimeService backed by the given
 // use-case.
-func NewRuntimeService(runtime *core.RuntimeUseCase) *RuntimeService {
-	return &RuntimeService{runtime: runtime}
+func NewRuntimeService(runtime *core.RuntimeUseCase, podLog core.PodLogConfig) *RuntimeService {
+	return &RuntimeService{runtime: runtime, podLog: podLog}
 }
 
 var _ pbconnect.RuntimeServiceHandler = (*RuntimeService)(nil)
@@ -40,7 +43,11 @@ var _ pbconnect.RuntimeServiceHandler = (*RuntimeService)(nil)
 // PodLog
 // ---------------------------------------------------------------------------
 
-// PodLog streams container log output to the client.
+// PodLog streams container log output to the client. A followed log
+// is buffered, unless buffering is disabled, so that a slow client does
+// not hold up reading the log; if the buffer overflows, the oldest
+// lines are dropped and the client is sent their range. A log that is
+// not followed is finite and sent without loss at the client's pace.
 func (s *RuntimeService) PodLog(ctx context.Context, req *pb.PodLogRequest, stream *connect.ServerStream[pb.PodLogResponse]) error {
 	opts := core.PodLogOptions{
 		Container:  req.GetContainer(),
@@ -71,23 +78,74 @@ func (s *RuntimeService) PodLog(ctx context.Context, req *pb.PodLogRequest, stre
 	}
 	defer reader.Close()
 
-	buf := make([]byte, streamChunkSize)
-	for {
-		n, readErr := reader.Read(buf)
-		if n > 0 {
-			msg := &pb.PodLogResponse{}
-			msg.SetData(append([]byte(nil), buf[:n]...))
-			if err := stream.Send(msg); err != nil {
-				return err
+	return streamPodLog(ctx, reader, s.podLogBufferSize(opts.Follow), stream.Send)
+}
+
+// podLogBufferSize returns the size of the buffer, which drops lines
+// on overflow, for a log that is followed or not. Only a followed log
+// can outgrow any buffer, so a finite one is sent unbuffered instead,
+// without loss.
+func (s *RuntimeService) podLogBufferSize(follow bool) int {
+	if !follow {
+		return 0
+	}
+	return s.podLog.BufferSize
+}
+
+// streamPodLog sends the log read from reader, through a logBuffer of
+// bufferSize bytes if bufferSize is positive. The caller closes
+// reader, which ends the buffer's fill goroutine.
+func streamPodLog(ctx context.Context, reader io.Reader, bufferSize int, send func(*pb.PodLogResponse) error) error {
+	var readErr error
+	if bufferSize > 0 {
+		buffer := newLogBuffer(bufferSize)
+		go buffer.fill(reader)
+		for {
+			data, gap, err := buffer.next(ctx)
+			if gap.Lines > 0 {
+				msg := &pb.PodLogResponse{}
+				msg.SetDroppedLines(gap.Lines)
+				msg.SetDroppedFromLine(gap.From)
+				if err := send(msg); err != nil {
+					return err
+				}
+			}
+			if len(data) > 0 {
+				msg := &pb.PodLogResponse{}
+				msg.SetData(data)
+				if err := send(msg); err != nil {
+					return err
+				}
+			}
+			if err != nil {
+				readErr = err
+				break
 			}
 		}
-		if readErr != nil {
-			if errors.Is(readErr, io.EOF) {
-				return nil
+	} else {
+		buf := make([]byte, streamChunkSize)
+		for readErr == nil {
+			var n int
+			n, readErr = reader.Read(buf)
+			if n > 0 {
+				msg := &pb.PodLogResponse{}
+				msg.SetData(append([]byte(nil), buf[:n]...))
+				if err := send(msg); err != nil {
+					return err
+				}
 			}
-			return domainErrorToConnectError(readErr)
 		}
 	}
+
+	if errors.Is(readErr, io.EOF) {
+		return nil
+	}
+	// A read cut short by ctx fails with whatever error the transport
+	// reports, so ctx itself says why.
+	if err := contextError(ctx); err != nil {
+		return err
+	}
+	return domainErrorToConnectError(readErr)
 }
 
 // ---------------------------------------------------------------------------
@@ -194,11 +252,12 @@ func (s *RuntimeService) ExecuteTTY(ctx context.Context, req *pb.ExecuteTTYReque
 	for {
 		select {
 		case <-ctx.Done():
-			return nil
+			return contextError(ctx)
 
 		case c, ok := <-ch:
 			if !ok {
-				return nil
+				// The readers also stop when ctx ends.
+				return contextError(ctx)
 			}
 			msg := &pb.ExecuteTTYResponse{}
 			if len(c.stdout) > 0 {
@@ -220,10 +279,19 @@ type execChunk struct {
 	stderr []byte
 }
 
-// WriteTTY sends stdin data to an active exec session.
+// WriteTTY sends stdin data to an active exec session and, if
+// requested, closes its stdin afterwards.
 func (s *RuntimeService) WriteTTY(ctx context.Context, req *pb.WriteTTYRequest) (*emptypb.Empty, error) {
-	if err := s.runtime.WriteExec(ctx, req.GetSessionId(), req.GetStdin()); err != nil {
-		return nil, domainErrorToConnectError(err)
+	// A bare close carries no data to write.
+	if len(req.GetStdin()) > 0 || !req.GetCloseStdin() {
+		if err := s.runtime.WriteExec(ctx, req.GetSessionId(), req.GetStdin()); err != nil {
+			return nil, domainErrorToConnectError(err)
+		}
+	}
+	if req.GetCloseStdin() {
+		if err := s.runtime.CloseExecStdin(ctx, req.GetSessionId()); err != nil {
+			return nil, domainErrorToConnectError(err)
+		}
 	}
 	return &emptypb.Empty{}, nil
 }
@@ -249,19 +317,32 @@ func (s *RuntimeService) ResizeTTY(ctx context.Context, req *pb.ResizeTTYRequest
 // PortForward opens a port-forward session and streams data from the
 // pod back to the client. The first response message contains the
 // session_id that the client must use for WritePortForward calls.
+// Subsequent messages carry the port their data was received from.
 func (s *RuntimeService) PortForward(ctx context.Context, req *pb.PortForwardRequest, stream *connect.ServerStream[pb.PortForwardResponse]) error {
-	sess, dataOutR, err := s.runtime.StartPortForward(
+	ports := req.GetPorts()
+	if len(ports) == 0 {
+		ports = []int32{req.GetPort()}
+	}
+
+	sess, readers, err := s.runtime.StartPortForward(
 		ctx,
 		req.GetCluster(),
 		req.GetNamespace(),
 		req.GetName(),
-		req.GetPort(),
+		ports,
 	)
 	if err != nil {
 		return domainErrorToConnectError(err)
 	}
 	defer s.runtime.CleanupPortForward(ctx, sess.ID)
 
+	closeReaders := func() {
+		for _, r := range readers {
+			r.Close()
+		}
+	}
+	defer closeReaders()
+
 	// Send the session ID as the first message.
 	first := &pb.PortForwardResponse{}
 	first.SetSessionId(sess.ID)
@@ -269,29 +350,50 @@ func (s *RuntimeService) PortForward(ctx context.Context, req *pb.PortForwardReq
 		return err
 	}
 
-	// Stream data from the pod.
-	buf := make([]byte, streamChunkSize)
-	for {
-		n, readErr := dataOutR.Read(buf)
-		if n > 0 {
-			msg := &pb.PortForwardResponse{}
-			msg.SetData(append([]byte(nil), buf[:n]...))
-			if err := stream.Send(msg); err != nil {
-				return err
-			}
-		}
-		if readErr != nil {
-			if errors.Is(readErr, io.EOF) {
-				return nil
+	// Stream data from every port. Sends are serialised because the
+	// stream does not support concurrent writers. A failure on any
+	// port closes all readers so the other goroutines exit.
+	var (
+		sendMu sync.Mutex
+		g      errgroup.Group
+	)
+	for port, r := range readers {
+		g.Go(func() error {
+			buf := make([]byte, streamChunkSize)
+			for {
+				n, readErr := r.Read(buf)
+				if n > 0 {
+					msg := &pb.PortForwardResponse{}
+					msg.SetPort(port)
+					msg.SetData(append([]byte(nil), buf[:n]...))
+					sendMu.Lock()
+					err := stream.Send(msg)
+					sendMu.Unlock()
+					if err != nil {
+						closeReaders()
+						return err
+					}
+				}
+				if readErr != nil {
+					if errors.Is(readErr, io.EOF) || errors.Is(readErr, io.ErrClosedPipe) {
+						return nil
+					}
+					closeReaders()
+					return domainErrorToConnectError(readErr)
+				}
 			}
-			return domainErrorToConnectError(readErr)
-		}
+		})
+	}
+	err = g.Wait()
+	if ctxErr := contextError(ctx); ctxErr != nil {
+		return ctxErr
 	}
+	return err
 }
 
 // WritePortForward sends data to an active port-forward session.
 func (s *RuntimeService) WritePortForward(ctx context.Context, req *pb.WritePortForwardRequest) (*emptypb.Empty, error) {
-	if err := s.runtime.WritePortForward(ctx, req.GetSessionId(), req.GetData()); err != nil {
+	if err := s.runtime.WritePortForward(ctx, req.GetSessionId(), req.GetPort(), req.GetData()); err != nil {
 		return nil, domainErrorToConnectError(err)
 	}
 	return &emptypb.Empty{}, nil
@@ -324,6 +426,56 @@ func (s *RuntimeService) Scale(ctx context.Context, req *pb.ScaleRequest) (*pb.S
 	return resp, nil
 }
 
+// ---------------------------------------------------------------------------
+// Suspend / Resume
+// ---------------------------------------------------------------------------
+
+// Suspend scales a workload to zero and returns the replica count it
+// had before.
+func (s *RuntimeService) Suspend(ctx context.Context, req *pb.SuspendRequest) (*pb.SuspendResponse, error) {
+	replicas, err := s.runtime.Suspend(
+		ctx,
+		core.ResourceIdentifier{
+			Cluster:   req.GetCluster(),
+			Group:     req.GetGroup(),
+			Version:   req.GetVersion(),
+			Resource:  req.GetResource(),
+			Namespace: req.GetNamespace(),
+			Name:      req.GetName(),
+		},
+	)
+	if err != nil {
+		return nil, domainErrorToConnectError(err)
+	}
+
+	resp := &pb.SuspendResponse{}
+	resp.SetSuspendedReplicas(replicas)
+	return resp, nil
+}
+
+// Resume scales a suspended workload back and returns the new replica
+// count.
+func (s *RuntimeService) Resume(ctx context.Context, req *pb.ResumeRequest) (*pb.ScaleResponse, error) {
+	replicas, err := s.runtime.Resume(
+		ctx,
+		core.ResourceIdentifier{
+			Cluster:   req.GetCluster(),
+			Group:     req.GetGroup(),
+			Version:   req.GetVersion(),
+			Resource:  req.GetResource(),
+			Namespace: req.GetNamespace(),
+			Name:      req.GetName(),
+		},
+	)
+	if err != nil {
+		return nil, domainErrorToConnectError(err)
+	}
+
+	resp := &pb.ScaleResponse{}
+	resp.SetReplicas(replicas)
+	return resp, nil
+}
+
 // ---------------------------------------------------------------------------
 // Restart
 // ---------------------------------------------------------------------------
@@ -345,3 +497,60 @@ func (s *RuntimeService) Restart(ctx context.Context, req *pb.RestartRequest) (*
 	}
 	return &emptypb.Empty{}, nil
 }
+
+// RestartAndWait triggers a rolling restart and returns once the
+// rollout completes.
+func (s *RuntimeService) RestartAndWait(ctx context.Context, req *pb.RestartAndWaitRequest) (*pb.RolloutStatus, error) {
+	status, err := s.runtime.RestartAndWait(ctx, restartAndWaitIdentifier(req), restartAndWaitTimeout(req), nil)
+	if err != nil {
+		return nil, domainErrorToConnectError(err)
+	}
+	return toProtoRolloutStatus(status), nil
+}
+
+// RestartAndWatch triggers a rolling restart and streams the rollout
+// status each time it changes until the rollout completes.
+func (s *RuntimeService) RestartAndWatch(ctx context.Context, req *pb.RestartAndWaitRequest, stream *connect.ServerStream[pb.RolloutStatus]) error {
+	var sendErr error
+	_, err := s.runtime.RestartAndWait(ctx, restartAndWaitIdentifier(req), restartAndWaitTimeout(req), func(status core.RolloutStatus) {
+		if sendErr == nil {
+			sendErr = stream.Send(toProtoRolloutStatus(status))
+		}
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil {
+		return domainErrorToConnectError(err)
+	}
+	return nil
+}
+
+func restartAndWaitIdentifier(req *pb.RestartAndWaitRequest) core.ResourceIdentifier {
+	return core.ResourceIdentifier{
+		Cluster:   req.GetCluster(),
+		Group:     req.GetGroup(),
+		Version:   req.GetVersion(),
+		Resource:  req.GetResource(),
+		Namespace: req.GetNamespace(),
+		Name:      req.GetName(),
+	}
+}
+
+// restartAndWaitTimeout converts the request's timeout, clamping it
+// first so that huge values are rejected as out of range instead of
+// overflowing.
+func restartAndWaitTimeout(req *pb.RestartAndWaitRequest) time.Duration {
+	return time.Duration(min(req.GetTimeoutSeconds(), math.MaxInt64/int64(time.Second))) * time.Second
+}
+
+func toProtoRolloutStatus(status core.RolloutStatus) *pb.RolloutStatus {
+	ret := &pb.RolloutStatus{}
+	ret.SetDesired(status.Desired)
+	ret.SetUpdated(status.Updated)
+	ret.SetReady(status.Ready)
+	ret.SetAvailable(status.Available)
+	ret.SetDone(status.Done)
+	ret.SetMessage(status.Message)
+	return ret
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/json"
+	"maps"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// defaultTransitionCacheSize bounds the number of objects whose last
+// sent version is remembered per watch stream.
+const defaultTransitionCacheSize = 4096
+
+// noiseMetadataFields are metadata fields that change on every write
+// without the object changing semantically.
+var noiseMetadataFields = []string{"resourceVersion", "managedFields"}
+
+// transitionFilter suppresses MODIFIED watch events whose object,
+// after stripping noise fields, is identical to the version last sent
+// for the same UID. It remembers a fingerprint (not the object) per
+// UID in an LRU bounded by maxEntries. An evicted or unknown UID is
+// always sent, so the filter can only drop true no-op updates.
+//
+// A transitionFilter belongs to a single watch stream and is not safe
+// for concurrent use.
+type transitionFilter struct {
+	maxEntries int
+	order      *list.List               // front = most recently sent
+	entries    map[string]*list.Element // UID -> element holding *transitionEntry
+}
+
+type transitionEntry struct {
+	uid         string
+	fingerprint [sha256.Size]byte
+}
+
+func newTransitionFilter(maxEntries int) *transitionFilter {
+	return &transitionFilter{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// suppress reports whether event should be dropped. Events that are
+// sent update the last-sent cache as a side effect.
+func (f *transitionFilter) suppress(event core.WatchEvent) bool {
+	uid := objectUID(event.Object)
+	if uid == "" {
+		return false
+	}
+
+	switch event.Type {
+	case core.WatchEventDeleted:
+		f.forget(uid)
+		return false
+
+	case core.WatchEventAdded, core.WatchEventModified:
+		fingerprint, ok := semanticFingerprint(event.Object)
+		if !ok {
+			return false
+		}
+		if el, ok := f.entries[uid]; ok {
+			entry := el.Value.(*transitionEntry)
+			if event.Type == core.WatchEventModified && entry.fingerprint == fingerprint {
+				f.order.MoveToFront(el)
+				return true
+			}
+			entry.fingerprint = fingerprint
+			f.order.MoveToFront(el)
+			return false
+		}
+		f.remember(uid, fingerprint)
+		return false
+
+	default:
+		return false
+	}
+}
+
+func (f *transitionFilter) remember(uid string, fingerprint [sha256.Size]byte) {
+	f.entries[uid] = f.order.PushFront(&transitionEntry{uid: uid, fingerprint: fingerprint})
+	for f.order.Len() > f.maxEntries {
+		oldest := f.order.Back()
+		f.order.Remove(oldest)
+		delete(f.entries, oldest.Value.(*transitionEntry).uid)
+	}
+}
+
+func (f *transitionFilter) forget(uid string) {
+	if el, ok := f.entries[uid]; ok {
+		f.order.Remove(el)
+		delete(f.entries, uid)
+	}
+}
+
+// objectUID returns metadata.uid of a raw object, or "" if absent.
+func objectUID(obj map[string]any) string {
+	metadata, ok := obj["metadata"].(map[string]any)
+	if !ok {
+		return ""
+	}
+	uid, _ := metadata["uid"].(string)
+	return uid
+}
+
+// semanticFingerprint hashes obj without its noise metadata fields.
+// obj itself is not modified. encoding/json sorts map keys, so equal
+// objects always hash equally.
+func semanticFingerprint(obj map[string]any) ([sha256.Size]byte, bool) {
+	stripped := maps.Clone(obj)
+	if metadata, ok := obj["metadata"].(map[string]any); ok {
+		metadata = maps.Clone(metadata)
+		for _, field := range noiseMetadataFields {
+			delete(metadata, field)
+		}
+		stripped["metadata"] = metadata
+	}
+
+	data, err := json.Marshal(stripped)
+	if err != nil {
+		return [sha256.Size]byte{}, false
+	}
+	return sha256.Sum256(data), true
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+func testPod(uid, resourceVersion, phase string) map[string]any {
+	return map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]any{
+			"name":            "web-0",
+			"namespace":       "default",
+			"uid":             uid,
+			"resourceVersion": resourceVersion,
+			"managedFields":   []any{map[string]any{"manager": "kubelet", "time": resourceVersion}},
+		},
+		"status": map[string]any{"phase": phase},
+	}
+}
+
+func TestTransitionFilter_SuppressesNoopModify(t *testing.T) {
+	f := newTransitionFilter(defaultTransitionCacheSize)
+
+	steps := []struct {
+		name     string
+		event    core.WatchEvent
+		suppress bool
+	}{
+		{"added", core.WatchEvent{Type: core.WatchEventAdded, Object: testPod("u1", "1", "Pending")}, false},
+		{"no-op modify", core.WatchEvent{Type: core.WatchEventModified, Object: testPod("u1", "2", "Pending")}, true},
+		{"status change", core.WatchEvent{Type: core.WatchEventModified, Object: testPod("u1", "3", "Running")}, false},
+		{"no-op after change", core.WatchEvent{Type: core.WatchEventModified, Object: testPod("u1", "4", "Running")}, true},
+		{"deleted", core.WatchEvent{Type: core.WatchEventDeleted, Object: testPod("u1", "5", "Running")}, false},
+		{"recreated with same content", core.WatchEvent{Type: core.WatchEventModified, Object: testPod("u1", "6", "Running")}, false},
+	}
+	for _, step := range steps {
+		if got := f.suppress(step.event); got != step.suppress {
+			t.Errorf("%s: suppress = %v, want %v", step.name, got, step.suppress)
+		}
+	}
+}
+
+func TestTransitionFilter_DoesNotMutateObject(t *testing.T) {
+	f := newTransitionFilter(defaultTransitionCacheSize)
+	obj := testPod("u1", "1", "Pending")
+
+	f.suppress(core.WatchEvent{Type: core.WatchEventAdded, Object: obj})
+
+	metadata := obj["metadata"].(map[string]any)
+	if metadata["resourceVersion"] != "1" || metadata["managedFields"] == nil {
+		t.Errorf("metadata was modified: %v", metadata)
+	}
+}
+
+func TestTransitionFilter_Bounded(t *testing.T) {
+	f := newTransitionFilter(2)
+
+	for _, uid := range []string{"u1", "u2", "u3"} {
+		f.suppress(core.WatchEvent{Type: core.WatchEventAdded, Object: testPod(uid, "1", "Pending")})
+	}
+	if len(f.entries) != 2 {
+		t.Fatalf("entries = %d, want 2", len(f.entries))
+	}
+
+	// u1 was evicted, so an unchanged modify is sent rather than
+	// compared against a forgotten version.
+	if f.suppress(core.WatchEvent{Type: core.WatchEventModified, Object: testPod("u1", "2", "Pending")}) {
+		t.Error("modify of an evicted UID was suppressed")
+	}
+	if !f.suppress(core.WatchEvent{Type: core.WatchEventModified, Object: testPod("u3", "2", "Pending")}) {
+		t.Error("no-op modify of a cached UID was not suppressed")
+	}
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// resumeTracker issues resume tokens for the events of a watch stream.
+// No token is issued while the stream sends an initial snapshot, since
+// a client resuming from the middle of it would never receive the
+// objects not yet sent. The snapshot ends with the first bookmark,
+// which the API server sends once the initial events are done.
+//
+// A resumeTracker belongs to a single watch stream and is not safe for
+// concurrent use.
+type resumeTracker struct {
+	id       core.ResourceIdentifier
+	snapshot bool
+	latest   string
+}
+
+// newResumeTracker returns a tracker for a watch of id requested with
+// resumeToken or resourceVersion. The stream starts with an initial
+// snapshot unless it resumes from either of them, in which case that
+// position is where it can be resumed until the first event.
+func newResumeTracker(id core.ResourceIdentifier, resumeToken, resourceVersion string) *resumeTracker {
+	switch {
+	case resumeToken != "":
+		return &resumeTracker{id: id, latest: resumeToken}
+	case resourceVersion == "" || resourceVersion == "0":
+		return &resumeTracker{id: id, snapshot: true}
+	default:
+		return &resumeTracker{id: id, latest: core.NewResumeToken(id, resourceVersion).Encode()}
+	}
+}
+
+// token returns the resume token for the position after event, or ""
+// if the stream cannot be resumed there.
+func (r *resumeTracker) token(event core.WatchEvent) string {
+	switch event.Type {
+	case core.WatchEventBookmark:
+		if event.Relisted {
+			r.snapshot = true
+			r.latest = ""
+			return ""
+		}
+		// The position before a resync stays valid until the fresh
+		// snapshot has been sent.
+		if event.Resynced {
+			r.snapshot = true
+			return ""
+		}
+		r.snapshot = false
+	case core.WatchEventAdded, core.WatchEventModified, core.WatchEventDeleted:
+		if r.snapshot {
+			return ""
+		}
+	default:
+		return ""
+	}
+
+	rv := objectResourceVersion(event.Object)
+	if rv == "" {
+		return ""
+	}
+	r.latest = core.NewResumeToken(r.id, rv).Encode()
+	return r.latest
+}
+
+// last returns the most recent token issued, or "" if the stream
+// cannot currently be resumed.
+func (r *resumeTracker) last() string {
+	return r.latest
+}
+
+// objectResourceVersion returns metadata.resourceVersion of obj, or ""
+// if it has none.
+func objectResourceVersion(obj map[string]any) string {
+	metadata, _ := obj["metadata"].(map[string]any)
+	rv, _ := metadata["resourceVersion"].(string)
+	return rv
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+func TestResumeTracker_NoTokensDuringSnapshot(t *testing.T) {
+	id := core.ResourceIdentifier{Cluster: "edge-1", Version: "v1", Resource: "pods"}
+	withRV := func(eventType core.WatchEventType, rv string) core.WatchEvent {
+		return core.WatchEvent{Type: eventType, Object: map[string]any{
+			"metadata": map[string]any{"resourceVersion": rv},
+		}}
+	}
+
+	r := newResumeTracker(id, "", "")
+	steps := []struct {
+		event core.WatchEvent
+		want  string // resourceVersion of the expected token, "" for none
+	}{
+		{withRV(core.WatchEventAdded, "10"), ""},
+		{withRV(core.WatchEventBookmark, "20"), "20"},
+		{withRV(core.WatchEventModified, "21"), "21"},
+		{core.WatchEvent{Type: core.WatchEventBookmark, Relisted: true}, ""},
+		{withRV(core.WatchEventAdded, "30"), ""},
+		{withRV(core.WatchEventBookmark, "40"), "40"},
+		{withRV(core.WatchEventDeleted, "41"), "41"},
+		{core.WatchEvent{Type: core.WatchEventBookmark, Resynced: true}, ""},
+		{withRV(core.WatchEventAdded, "50"), ""},
+		{withRV(core.WatchEventBookmark, "60"), "60"},
+		{core.WatchEvent{Type: core.WatchEventError}, ""},
+	}
+	for i, step := range steps {
+		got := r.token(step.event)
+		var want string
+		if step.want != "" {
+			want = core.NewResumeToken(id, step.want).Encode()
+		}
+		if got != want {
+			t.Errorf("step %d (%s): token = %q, want %q", i, step.event.Type, got, want)
+		}
+	}
+}
+
+func TestResumeTracker_ResyncKeepsPosition(t *testing.T) {
+	id := core.ResourceIdentifier{Cluster: "edge-1", Version: "v1", Resource: "pods"}
+	r := newResumeTracker(id, "", "41")
+
+	r.token(core.WatchEvent{Type: core.WatchEventBookmark, Resynced: true})
+	r.token(core.WatchEvent{Type: core.WatchEventAdded, Object: map[string]any{
+		"metadata": map[string]any{"resourceVersion": "50"},
+	}})
+	if got, want := r.last(), core.NewResumeToken(id, "41").Encode(); got != want {
+		t.Errorf("last token during resync snapshot = %q, want the position before the resync", got)
+	}
+}
@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// alwaysRetainedFields are the top-level fields kept on every pruned
+// object so that clients can still identify it.
+var alwaysRetainedFields = []string{"apiVersion", "kind", "metadata"}
+
+// schemaFunc resolves the OpenAPI schema of a kind in the watched
+// group/version.
+type schemaFunc func(kind string) (*spec.Schema, error)
+
+// fieldRetainer prunes watched objects down to a requested set of
+// field paths, keeping only those the kind's OpenAPI schema declares.
+// It is meant for custom resources with large statuses where clients
+// only render a few fields. The plan for each kind is built from its
+// schema on the first event of that kind; if the schema cannot be
+// resolved, objects of that kind are sent in full.
+//
+// A fieldRetainer belongs to a single watch stream and is not safe for
+// concurrent use.
+type fieldRetainer struct {
+	paths   [][]string
+	resolve schemaFunc
+	plans   map[string]*retainNode // kind -> plan; nil = send in full
+}
+
+// retainNode is one level of a retention plan. A node with all set
+// keeps the whole value; otherwise only its children are kept.
+type retainNode struct {
+	all      bool
+	children map[string]*retainNode
+}
+
+// parseRetainFields splits dotted field paths such as
+// "status.conditions" into their segments.
+func parseRetainFields(fields []string) ([][]string, error) {
+	paths := make([][]string, 0, len(fields))
+	for _, field := range fields {
+		path := strings.Split(field, ".")
+		if slices.Contains(path, "") {
+			return nil, &core.ErrInvalidInput{Field: "retain_fields", Message: fmt.Sprintf("invalid field path %q", field)}
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+func newFieldRetainer(paths [][]string, resolve schemaFunc) *fieldRetainer {
+	return &fieldRetainer{
+		paths:   paths,
+		resolve: resolve,
+		plans:   make(map[string]*retainNode),
+	}
+}
+
+// retain returns event with its object pruned to the retained fields.
+// The original object is not modified.
+func (r *fieldRetainer) retain(event core.WatchEvent) core.WatchEvent {
+	if event.Object == nil {
+		return event
+	}
+	switch event.Type {
+	case core.WatchEventAdded, core.WatchEventModified, core.WatchEventDeleted:
+	default:
+		return event
+	}
+
+	kind, _ := event.Object["kind"].(string)
+	plan := r.plan(kind)
+	if plan == nil {
+		return event
+	}
+	event.Object = pruneObject(event.Object, plan)
+	return event
+}
+
+func (r *fieldRetainer) plan(kind string) *retainNode {
+	if plan, ok := r.plans[kind]; ok {
+		return plan
+	}
+
+	var plan *retainNode
+	s, err := r.resolve(kind)
+	switch {
+	case err != nil:
+		slog.Warn("watch: schema unavailable, sending full objects", "kind", kind, "error", err)
+	case s == nil:
+		slog.Warn("watch: schema unavailable, sending full objects", "kind", kind)
+	default:
+		plan = buildRetainPlan(s, r.paths)
+	}
+	r.plans[kind] = plan
+	return plan
+}
+
+// buildRetainPlan builds the retention tree for paths, dropping any
+// path that the schema does not declare.
+func buildRetainPlan(s *spec.Schema, paths [][]string) *retainNode {
+	root := &retainNode{children: make(map[string]*retainNode)}
+	for _, field := range alwaysRetainedFields {
+		root.children[field] = &retainNode{all: true}
+	}
+
+	for _, path := range paths {
+		if !schemaHasPath(s, path) {
+			slog.Debug("watch: dropping retained field unknown to schema", "field", strings.Join(path, "."))
+			continue
+		}
+		node := root
+		for _, segment := range path {
+			if node.all {
+				break
+			}
+			child, ok := node.children[segment]
+			if !ok {
+				child = &retainNode{children: make(map[string]*retainNode)}
+				node.children[segment] = child
+			}
+			node = child
+		}
+		node.all = true
+		node.children = nil
+	}
+	return root
+}
+
+// schemaHasPath reports whether path is declared by s. Arrays are
+// transparent: a path continues into the schema of their items.
+func schemaHasPath(s *spec.Schema, path []string) bool {
+	for _, segment := range path {
+		s = itemSchema(s)
+		if s == nil {
+			return false
+		}
+		prop, ok := s.Properties[segment]
+		if !ok {
+			return false
+		}
+		s = &prop
+	}
+	return true
+}
+
+// itemSchema returns the element schema of an array schema, or s
+// itself for any other type.
+func itemSchema(s *spec.Schema) *spec.Schema {
+	for s != nil && s.Type.Contains("array") {
+		if s.Items == nil {
+			return nil
+		}
+		s = s.Items.Schema
+	}
+	return s
+}
+
+// pruneObject copies the parts of obj selected by node.
+func pruneObject(obj map[string]any, node *retainNode) map[string]any {
+	pruned := make(map[string]any, len(node.children))
+	for key, child := range node.children {
+		value, ok := obj[key]
+		if !ok {
+			continue
+		}
+		if child.all {
+			pruned[key] = value
+			continue
+		}
+		pruned[key] = pruneValue(value, child)
+	}
+	return pruned
+}
+
+func pruneValue(value any, node *retainNode) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return pruneObject(v, node)
+	case []any:
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = pruneValue(item, node)
+		}
+		return items
+	default:
+		return v
+	}
+}
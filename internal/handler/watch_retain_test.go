@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+func kustomizationSchema() *spec.Schema {
+	condition := spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: spec.StringOrArray{"object"},
+		Properties: map[string]spec.Schema{
+			"type":   *spec.StringProperty(),
+			"status": *spec.StringProperty(),
+		},
+	}}
+	return &spec.Schema{SchemaProps: spec.SchemaProps{
+		Type: spec.StringOrArray{"object"},
+		Properties: map[string]spec.Schema{
+			"apiVersion": *spec.StringProperty(),
+			"kind":       *spec.StringProperty(),
+			"metadata":   {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"object"}}},
+			"spec": {SchemaProps: spec.SchemaProps{
+				Type: spec.StringOrArray{"object"},
+				Properties: map[string]spec.Schema{
+					"interval": *spec.StringProperty(),
+					"path":     *spec.StringProperty(),
+				},
+			}},
+			"status": {SchemaProps: spec.SchemaProps{
+				Type: spec.StringOrArray{"object"},
+				Properties: map[string]spec.Schema{
+					"conditions": *spec.ArrayProperty(&condition),
+					"inventory":  {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"object"}}},
+				},
+			}},
+		},
+	}}
+}
+
+func testKustomization() map[string]any {
+	return map[string]any{
+		"apiVersion": "kustomize.toolkit.fluxcd.io/v1",
+		"kind":       "Kustomization",
+		"metadata":   map[string]any{"name": "apps", "namespace": "flux-system", "uid": "k1"},
+		"spec":       map[string]any{"interval": "10m", "path": "./apps"},
+		"status": map[string]any{
+			"conditions": []any{
+				map[string]any{"type": "Ready", "status": "True", "message": "Applied revision main@sha1:abc"},
+			},
+			"inventory": map[string]any{"entries": []any{"a", "b", "c"}},
+		},
+	}
+}
+
+func TestFieldRetainer_PrunesCustomResource(t *testing.T) {
+	paths, err := parseRetainFields([]string{"spec.interval", "status.conditions.status", "status.conditions.type", "status.unknown"})
+	if err != nil {
+		t.Fatalf("parseRetainFields: %v", err)
+	}
+	var resolved []string
+	r := newFieldRetainer(paths, func(kind string) (*spec.Schema, error) {
+		resolved = append(resolved, kind)
+		return kustomizationSchema(), nil
+	})
+
+	obj := testKustomization()
+	for _, eventType := range []core.WatchEventType{core.WatchEventAdded, core.WatchEventModified} {
+		got := r.retain(core.WatchEvent{Type: eventType, Object: obj})
+
+		want := map[string]any{
+			"apiVersion": "kustomize.toolkit.fluxcd.io/v1",
+			"kind":       "Kustomization",
+			"metadata":   map[string]any{"name": "apps", "namespace": "flux-system", "uid": "k1"},
+			"spec":       map[string]any{"interval": "10m"},
+			"status": map[string]any{
+				"conditions": []any{map[string]any{"type": "Ready", "status": "True"}},
+			},
+		}
+		if !reflect.DeepEqual(got.Object, want) {
+			t.Errorf("%s: object = %v, want %v", eventType, got.Object, want)
+		}
+	}
+
+	if len(resolved) != 1 {
+		t.Errorf("schema resolved %d times, want once per kind", len(resolved))
+	}
+	if _, ok := obj["status"].(map[string]any)["inventory"]; !ok {
+		t.Error("original object was modified")
+	}
+}
+
+func TestFieldRetainer_FallsBackWithoutSchema(t *testing.T) {
+	paths, _ := parseRetainFields([]string{"spec.interval"})
+	r := newFieldRetainer(paths, func(string) (*spec.Schema, error) {
+		return nil, errors.New("openapi unavailable")
+	})
+
+	obj := testKustomization()
+	got := r.retain(core.WatchEvent{Type: core.WatchEventModified, Object: obj})
+	if !reflect.DeepEqual(got.Object, testKustomization()) {
+		t.Errorf("object = %v, want the full object", got.Object)
+	}
+}
+
+func TestParseRetainFields_RejectsEmptySegments(t *testing.T) {
+	for _, field := range []string{"", "spec.", ".status", "status..conditions"} {
+		_, err := parseRetainFields([]string{field})
+		var invalidInput *core.ErrInvalidInput
+		if !errors.As(err, &invalidInput) {
+			t.Errorf("parseRetainFields(%q) = %v, want ErrInvalidInput", field, err)
+		}
+	}
+}
@@ -34,15 +34,35 @@ type CA struct {
 	cert    *x509.Certificate
 	key     *ecdsa.PrivateKey
 	certPEM []byte
+	policy  KeyPolicy // keys accepted by SignCSR
+}
+
+// DefaultCASubject returns the subject of CAs generated by NewCA.
+func DefaultCASubject() pkix.Name {
+	return pkix.Name{
+		Organization: []string{"otterscale"},
+		CommonName:   "otterscale-ca",
+	}
 }
 
 // NewCA generates a new ECDSA P-256 CA key pair and self-signed
-// certificate using crypto/rand.Reader. In FIPS 140-3 mode the
-// reader is backed by a NIST SP 800-90A DRBG.
+// certificate with DefaultCASubject using crypto/rand.Reader. In FIPS
+// 140-3 mode the reader is backed by a NIST SP 800-90A DRBG.
 //
 // The caller is responsible for persisting CertPEM() and KeyPEM()
 // so that subsequent restarts can reload the same CA via LoadCA.
 func NewCA() (*CA, error) {
+	return NewCAWithSubject(DefaultCASubject())
+}
+
+// NewCAWithSubject is NewCA with the given certificate subject, e.g.
+// to carry an organization's own name into audits and trust stores.
+// The subject must have a common name.
+func NewCAWithSubject(subject pkix.Name) (*CA, error) {
+	if subject.CommonName == "" {
+		return nil, fmt.Errorf("pki: CA subject must have a common name")
+	}
+
 	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	if err != nil {
 		return nil, fmt.Errorf("pki: generate CA key: %w", err)
@@ -55,11 +75,8 @@ func NewCA() (*CA, error) {
 
 	now := time.Now()
 	tmpl := &x509.Certificate{
-		SerialNumber: serial,
-		Subject: pkix.Name{
-			Organization: []string{"otterscale"},
-			CommonName:   "otterscale-ca",
-		},
+		SerialNumber:          serial,
+		Subject:               subject,
 		NotBefore:             now.Add(-5 * time.Minute),
 		NotAfter:              now.Add(10 * 365 * 24 * time.Hour),
 		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
@@ -80,12 +97,13 @@ func NewCA() (*CA, error) {
 
 	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
 
-	return &CA{cert: cert, key: key, certPEM: certPEM}, nil
+	return &CA{cert: cert, key: key, certPEM: certPEM, policy: DefaultKeyPolicy()}, nil
 }
 
 // LoadCA reconstructs a CA from PEM-encoded certificate and private
 // key material. It validates that the certificate is a CA and that the
-// private key matches the certificate's public key.
+// private key matches the certificate's public key. Any subject is
+// accepted, so a CA keeps working when the configured subject changes.
 func LoadCA(certPEM, keyPEM []byte) (*CA, error) {
 	certBlock, _ := pem.Decode(certPEM)
 	if certBlock == nil {
@@ -121,7 +139,7 @@ func LoadCA(certPEM, keyPEM []byte) (*CA, error) {
 		return nil, fmt.Errorf("pki: CA private key does not match certificate public key")
 	}
 
-	return &CA{cert: cert, key: key, certPEM: certPEM}, nil
+	return &CA{cert: cert, key: key, certPEM: certPEM, policy: DefaultKeyPolicy()}, nil
 }
 
 // CertPEM returns the PEM-encoded CA certificate. Agents use this to
@@ -130,6 +148,12 @@ func (ca *CA) CertPEM() []byte {
 	return ca.certPEM
 }
 
+// Subject returns the distinguished name of the CA certificate, e.g.
+// "CN=otterscale-ca,O=otterscale".
+func (ca *CA) Subject() string {
+	return ca.cert.Subject.String()
+}
+
 // KeyPEM returns the PEM-encoded CA private key for external
 // persistence. The caller should store this securely (e.g. with
 // 0600 permissions) so the CA can be reloaded via LoadCA.
@@ -158,7 +182,9 @@ func (ca *CA) DeriveHMACKey(label string) ([]byte, error) {
 
 // SignCSR validates a PEM-encoded PKCS#10 certificate signing request
 // and returns a PEM-encoded X.509 certificate signed by the CA. The
-// certificate is valid for the default certValidity period.
+// certificate is valid for the default certValidity period. A CSR
+// whose public key is not allowed by the CA's key policy is rejected
+// with an error wrapping ErrKeyRejected.
 func (ca *CA) SignCSR(csrPEM []byte) ([]byte, error) {
 	block, _ := pem.Decode(csrPEM)
 	if block == nil || block.Type != "CERTIFICATE REQUEST" {
@@ -174,6 +200,10 @@ func (ca *CA) SignCSR(csrPEM []byte) ([]byte, error) {
 		return nil, fmt.Errorf("pki: CSR signature invalid: %w", err)
 	}
 
+	if err := ca.policy.check(csr.PublicKey); err != nil {
+		return nil, err
+	}
+
 	serial, err := randomSerial()
 	if err != nil {
 		return nil, err
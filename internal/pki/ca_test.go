@@ -3,6 +3,7 @@ package pki
 import (
 	"bytes"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/pem"
 	"testing"
 )
@@ -41,6 +42,53 @@ func TestNewCA(t *testing.T) {
 	}
 }
 
+func TestNewCAWithSubject(t *testing.T) {
+	subject := pkix.Name{
+		Organization:       []string{"Example Corp"},
+		OrganizationalUnit: []string{"Platform"},
+		CommonName:         "example-tunnel-ca",
+	}
+	ca, err := NewCAWithSubject(subject)
+	if err != nil {
+		t.Fatalf("NewCAWithSubject: %v", err)
+	}
+
+	block, _ := pem.Decode(ca.CertPEM())
+	if block == nil {
+		t.Fatal("failed to decode CA cert PEM")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("parse cert: %v", err)
+	}
+	if cert.Subject.CommonName != "example-tunnel-ca" {
+		t.Errorf("CN = %q, want example-tunnel-ca", cert.Subject.CommonName)
+	}
+	if got := cert.Subject.Organization; len(got) != 1 || got[0] != "Example Corp" {
+		t.Errorf("O = %v, want [Example Corp]", got)
+	}
+	if got := cert.Subject.OrganizationalUnit; len(got) != 1 || got[0] != "Platform" {
+		t.Errorf("OU = %v, want [Platform]", got)
+	}
+
+	// A CA with a custom subject reloads like the default one.
+	keyPEM, err := ca.KeyPEM()
+	if err != nil {
+		t.Fatalf("KeyPEM: %v", err)
+	}
+	loaded, err := LoadCA(ca.CertPEM(), keyPEM)
+	if err != nil {
+		t.Fatalf("LoadCA: %v", err)
+	}
+	if loaded.Subject() != ca.Subject() {
+		t.Errorf("loaded subject = %q, want %q", loaded.Subject(), ca.Subject())
+	}
+
+	if _, err := NewCAWithSubject(pkix.Name{Organization: []string{"Example Corp"}}); err == nil {
+		t.Error("NewCAWithSubject without a common name: error = nil, want error")
+	}
+}
+
 func TestNewCA_UniquePerCall(t *testing.T) {
 	ca1, err := NewCA()
 	if err != nil {
@@ -0,0 +1,98 @@
+package pki
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Key types accepted in a KeyPolicy.
+const (
+	KeyTypeECDSAP256 = "ecdsa-p256"
+	KeyTypeECDSAP384 = "ecdsa-p384"
+	KeyTypeEd25519   = "ed25519"
+	KeyTypeRSA       = "rsa"
+)
+
+// knownKeyTypes lists every key type a KeyPolicy may allow.
+var knownKeyTypes = []string{KeyTypeECDSAP256, KeyTypeECDSAP384, KeyTypeEd25519, KeyTypeRSA}
+
+// defaultMinRSABits is the smallest RSA modulus accepted by the
+// default policy.
+const defaultMinRSABits = 2048
+
+// ErrKeyRejected is returned (wrapped) by SignCSR when the CSR's
+// public key is not allowed by the CA's KeyPolicy.
+var ErrKeyRejected = errors.New("pki: CSR public key rejected by key policy")
+
+// KeyPolicy restricts the public keys the CA will certify, so that an
+// agent cannot obtain a certificate for a weak key.
+type KeyPolicy struct {
+	// Allowed lists the accepted key types (KeyTypeECDSAP256, ...).
+	Allowed []string
+	// MinRSABits is the smallest RSA modulus accepted when RSA keys
+	// are allowed.
+	MinRSABits int
+}
+
+// DefaultKeyPolicy accepts ECDSA P-256 and P-384, Ed25519, and RSA
+// keys of at least 2048 bits.
+func DefaultKeyPolicy() KeyPolicy {
+	return KeyPolicy{
+		Allowed:    slices.Clone(knownKeyTypes),
+		MinRSABits: defaultMinRSABits,
+	}
+}
+
+// NewKeyPolicy validates and returns a KeyPolicy allowing the given
+// key types. An empty list is rejected, as it would refuse every
+// agent.
+func NewKeyPolicy(allowed []string, minRSABits int) (KeyPolicy, error) {
+	if len(allowed) == 0 {
+		return KeyPolicy{}, fmt.Errorf("pki: key policy must allow at least one key type")
+	}
+	for _, keyType := range allowed {
+		if !slices.Contains(knownKeyTypes, keyType) {
+			return KeyPolicy{}, fmt.Errorf("pki: unknown key type %q (valid: %v)", keyType, knownKeyTypes)
+		}
+	}
+	if slices.Contains(allowed, KeyTypeRSA) && minRSABits < defaultMinRSABits {
+		return KeyPolicy{}, fmt.Errorf("pki: minimum RSA key size %d is below %d bits", minRSABits, defaultMinRSABits)
+	}
+	return KeyPolicy{Allowed: slices.Clone(allowed), MinRSABits: minRSABits}, nil
+}
+
+// check returns an error wrapping ErrKeyRejected if pub is not allowed
+// by the policy.
+func (p KeyPolicy) check(pub any) error {
+	var keyType string
+	switch k := pub.(type) {
+	case *ecdsa.PublicKey:
+		switch k.Curve {
+		case elliptic.P256():
+			keyType = KeyTypeECDSAP256
+		case elliptic.P384():
+			keyType = KeyTypeECDSAP384
+		default:
+			return fmt.Errorf("%w: ECDSA curve %s is not allowed", ErrKeyRejected, k.Curve.Params().Name)
+		}
+	case ed25519.PublicKey:
+		keyType = KeyTypeEd25519
+	case *rsa.PublicKey:
+		keyType = KeyTypeRSA
+		if slices.Contains(p.Allowed, KeyTypeRSA) && k.N.BitLen() < p.MinRSABits {
+			return fmt.Errorf("%w: RSA-%d is below the minimum of %d bits", ErrKeyRejected, k.N.BitLen(), p.MinRSABits)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported key type %T", ErrKeyRejected, pub)
+	}
+
+	if !slices.Contains(p.Allowed, keyType) {
+		return fmt.Errorf("%w: %s keys are not allowed (allowed: %v)", ErrKeyRejected, keyType, p.Allowed)
+	}
+	return nil
+}
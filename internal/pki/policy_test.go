@@ -0,0 +1,113 @@
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"testing"
+)
+
+// csrFor returns a PEM-encoded CSR signed by key.
+func csrFor(t *testing.T, key crypto.Signer) []byte {
+	t.Helper()
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: "test-agent"},
+	}, key)
+	if err != nil {
+		t.Fatalf("create CSR: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})
+}
+
+func TestSignCSR_KeyPolicy(t *testing.T) {
+	ca, err := NewCA()
+	if err != nil {
+		t.Fatalf("NewCA: %v", err)
+	}
+
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate P-256 key: %v", err)
+	}
+	p224, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate P-224 key: %v", err)
+	}
+	_, ed, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate Ed25519 key: %v", err)
+	}
+	rsa1024, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("generate RSA-1024 key: %v", err)
+	}
+	rsa2048, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate RSA-2048 key: %v", err)
+	}
+
+	ecOnly, err := NewKeyPolicy([]string{KeyTypeECDSAP256, KeyTypeEd25519}, 0)
+	if err != nil {
+		t.Fatalf("NewKeyPolicy: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		policy     KeyPolicy
+		key        crypto.Signer
+		wantReject bool
+	}{
+		{"ECDSA P-256", DefaultKeyPolicy(), p256, false},
+		{"Ed25519", DefaultKeyPolicy(), ed, false},
+		{"RSA-2048", DefaultKeyPolicy(), rsa2048, false},
+		{"RSA-1024", DefaultKeyPolicy(), rsa1024, true},
+		{"ECDSA P-224", DefaultKeyPolicy(), p224, true},
+		{"RSA-2048 with RSA disallowed", ecOnly, rsa2048, true},
+		{"ECDSA P-256 with RSA disallowed", ecOnly, p256, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ca.policy = tt.policy
+			certPEM, err := ca.SignCSR(csrFor(t, tt.key))
+			if tt.wantReject {
+				if !errors.Is(err, ErrKeyRejected) {
+					t.Fatalf("SignCSR error = %v, want ErrKeyRejected", err)
+				}
+				return
+			}
+			if err != nil || len(certPEM) == 0 {
+				t.Fatalf("SignCSR = %d bytes, %v; want a certificate", len(certPEM), err)
+			}
+		})
+	}
+}
+
+func TestNewKeyPolicy_Validation(t *testing.T) {
+	tests := []struct {
+		name       string
+		allowed    []string
+		minRSABits int
+		wantErr    bool
+	}{
+		{"defaults", []string{KeyTypeECDSAP256, KeyTypeRSA}, 2048, false},
+		{"no RSA ignores minimum", []string{KeyTypeEd25519}, 0, false},
+		{"empty", nil, 2048, true},
+		{"unknown type", []string{"dsa"}, 2048, true},
+		{"weak RSA minimum", []string{KeyTypeRSA}, 1024, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewKeyPolicy(tt.allowed, tt.minRSABits)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewKeyPolicy error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
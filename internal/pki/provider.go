@@ -1,52 +1,53 @@
 package pki
 
 import (
+	"context"
+	"crypto/x509/pkix"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
 )
 
-// ProvideCA is a Wire provider that loads the CA from the given
-// directory. On first startup the directory is empty, so a new CA is
-// generated (using crypto/rand backed by a FIPS-approved DRBG) and
-// persisted. Subsequent restarts load the existing CA, keeping
-// previously issued agent certificates valid.
-func ProvideCA(dir string) (*CA, error) {
-	certPath := filepath.Join(dir, "ca.pem")
-	keyPath := filepath.Join(dir, "ca-key.pem")
+// ProvideCA is a Wire provider that loads the CA from store. On first
+// startup the store is empty, so a new CA is generated (using
+// crypto/rand backed by a FIPS-approved DRBG) and saved. Subsequent
+// restarts load the existing CA, keeping previously issued agent
+// certificates valid. subject only applies to a newly generated CA;
+// an existing CA keeps the subject it was generated with. The CA only
+// signs CSRs whose public key is allowed by policy.
+func ProvideCA(store CAStore, policy KeyPolicy, subject pkix.Name) (*CA, error) {
+	ctx := context.Background()
 
-	certPEM, errC := os.ReadFile(certPath)
-	keyPEM, errK := os.ReadFile(keyPath)
-	if errC == nil && errK == nil {
-		slog.Info("loading existing CA", "dir", dir)
-		return LoadCA(certPEM, keyPEM)
+	certPEM, keyPEM, err := store.Load(ctx)
+	switch {
+	case err == nil:
+		slog.Info("loading existing CA", "store", store.String())
+		ca, err := LoadCA(certPEM, keyPEM)
+		if err != nil {
+			return nil, err
+		}
+		ca.policy = policy
+		return ca, nil
+	case !errors.Is(err, ErrCANotFound):
+		return nil, fmt.Errorf("load CA: %w", err)
 	}
 
 	// First run: generate and persist.
-	slog.Info("generating new CA", "dir", dir)
-	ca, err := NewCA()
+	slog.Info("generating new CA", "store", store.String(), "subject", subject.String())
+	ca, err := NewCAWithSubject(subject)
 	if err != nil {
 		return nil, fmt.Errorf("generate CA: %w", err)
 	}
-
-	if err := os.MkdirAll(dir, 0700); err != nil {
-		return nil, fmt.Errorf("create CA dir: %w", err)
-	}
+	ca.policy = policy
 
 	keyPEM, err = ca.KeyPEM()
 	if err != nil {
 		return nil, fmt.Errorf("export CA key: %w", err)
 	}
-
-	// Write cert and key atomically (write to temp + rename) so
-	// that a crash between the two writes does not leave a
-	// half-written CA state on disk.
-	if err := atomicWriteFile(certPath, ca.CertPEM(), 0600); err != nil {
-		return nil, fmt.Errorf("write CA cert: %w", err)
-	}
-	if err := atomicWriteFile(keyPath, keyPEM, 0600); err != nil {
-		return nil, fmt.Errorf("write CA key: %w", err)
+	if err := store.Save(ctx, ca.CertPEM(), keyPEM); err != nil {
+		return nil, fmt.Errorf("save CA: %w", err)
 	}
 
 	return ca, nil
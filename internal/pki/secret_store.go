@@ -0,0 +1,82 @@
+package pki
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// SecretCAStore keeps the CA in a Kubernetes Secret of type
+// kubernetes.io/tls, with the certificate under tls.crt and the key
+// under tls.key.
+type SecretCAStore struct {
+	secrets   corev1client.SecretsGetter
+	namespace string
+	name      string
+}
+
+var _ CAStore = (*SecretCAStore)(nil)
+
+// NewSecretCAStore returns a CAStore that keeps the CA in the Secret
+// namespace/name. The Secret is created on the first Save.
+func NewSecretCAStore(secrets corev1client.SecretsGetter, namespace, name string) *SecretCAStore {
+	return &SecretCAStore{secrets: secrets, namespace: namespace, name: name}
+}
+
+// Load reads the CA from the Secret. A missing Secret, or one without
+// both entries, is reported as not found.
+func (s *SecretCAStore) Load(ctx context.Context) (certPEM, keyPEM []byte, err error) {
+	secret, err := s.secrets.Secrets(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil, fmt.Errorf("%w: %s", ErrCANotFound, s)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("get %s: %w", s, err)
+	}
+	certPEM, keyPEM = secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+	if len(certPEM) == 0 || len(keyPEM) == 0 {
+		return nil, nil, fmt.Errorf("%w: %s has no %s or %s", ErrCANotFound, s, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+	}
+	return certPEM, keyPEM, nil
+}
+
+// Save creates the Secret, or replaces its data if it already exists.
+func (s *SecretCAStore) Save(ctx context.Context, certPEM, keyPEM []byte) error {
+	data := map[string][]byte{
+		corev1.TLSCertKey:       certPEM,
+		corev1.TLSPrivateKeyKey: keyPEM,
+	}
+	secrets := s.secrets.Secrets(s.namespace)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: s.name, Namespace: s.namespace},
+		Type:       corev1.SecretTypeTLS,
+		Data:       data,
+	}
+	_, err := secrets.Create(ctx, secret, metav1.CreateOptions{})
+	if !apierrors.IsAlreadyExists(err) {
+		if err != nil {
+			return fmt.Errorf("create %s: %w", s, err)
+		}
+		return nil
+	}
+
+	existing, err := secrets.Get(ctx, s.name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("get %s: %w", s, err)
+	}
+	existing.Data = data
+	if _, err := secrets.Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("update %s: %w", s, err)
+	}
+	return nil
+}
+
+// String returns the namespace and name of the Secret.
+func (s *SecretCAStore) String() string {
+	return fmt.Sprintf("secret %s/%s", s.namespace, s.name)
+}
@@ -0,0 +1,87 @@
+package pki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrCANotFound is returned by a CAStore's Load when no CA has been
+// saved yet.
+var ErrCANotFound = errors.New("CA not found")
+
+// CAStore persists the CA certificate and private key. Implementations
+// must keep the key confidential: it must not be logged or included in
+// errors.
+type CAStore interface {
+	// Load returns the PEM-encoded CA certificate and key, or an error
+	// wrapping ErrCANotFound if none has been saved.
+	Load(ctx context.Context) (certPEM, keyPEM []byte, err error)
+	// Save persists the PEM-encoded CA certificate and key,
+	// replacing any previously saved CA.
+	Save(ctx context.Context, certPEM, keyPEM []byte) error
+	// String describes where the CA is kept, for logging.
+	String() string
+}
+
+// FileCAStore keeps the CA as ca.pem and ca-key.pem in a directory.
+type FileCAStore struct {
+	dir string
+}
+
+var _ CAStore = (*FileCAStore)(nil)
+
+// NewFileCAStore returns a CAStore that keeps the CA in dir. The
+// directory is created on the first Save.
+func NewFileCAStore(dir string) *FileCAStore {
+	return &FileCAStore{dir: dir}
+}
+
+func (s *FileCAStore) certPath() string { return filepath.Join(s.dir, "ca.pem") }
+func (s *FileCAStore) keyPath() string  { return filepath.Join(s.dir, "ca-key.pem") }
+
+// Load reads the CA from the directory. If either file is missing the
+// CA is reported as not found.
+func (s *FileCAStore) Load(_ context.Context) (certPEM, keyPEM []byte, err error) {
+	certPEM, err = os.ReadFile(s.certPath())
+	if err != nil {
+		return nil, nil, fileLoadError("CA cert", err)
+	}
+	keyPEM, err = os.ReadFile(s.keyPath())
+	if err != nil {
+		return nil, nil, fileLoadError("CA key", err)
+	}
+	return certPEM, keyPEM, nil
+}
+
+func fileLoadError(what string, err error) error {
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %s: %w", ErrCANotFound, what, err)
+	}
+	return fmt.Errorf("read %s: %w", what, err)
+}
+
+// Save writes the CA to the directory, creating it if needed.
+func (s *FileCAStore) Save(_ context.Context, certPEM, keyPEM []byte) error {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return fmt.Errorf("create CA dir: %w", err)
+	}
+
+	// Write cert and key atomically (write to temp + rename) so
+	// that a crash between the two writes does not leave a
+	// half-written CA state on disk.
+	if err := atomicWriteFile(s.certPath(), certPEM, 0600); err != nil {
+		return fmt.Errorf("write CA cert: %w", err)
+	}
+	if err := atomicWriteFile(s.keyPath(), keyPEM, 0600); err != nil {
+		return fmt.Errorf("write CA key: %w", err)
+	}
+	return nil
+}
+
+// String returns the directory the CA is kept in.
+func (s *FileCAStore) String() string {
+	return "dir " + s.dir
+}
@@ -0,0 +1,103 @@
+package pki
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"log/slog"
+	"testing"
+)
+
+// memoryCAStore keeps the CA in memory.
+type memoryCAStore struct {
+	certPEM, keyPEM []byte
+	saves           int
+}
+
+func (s *memoryCAStore) Load(context.Context) ([]byte, []byte, error) {
+	if s.certPEM == nil {
+		return nil, nil, ErrCANotFound
+	}
+	return s.certPEM, s.keyPEM, nil
+}
+
+func (s *memoryCAStore) Save(_ context.Context, certPEM, keyPEM []byte) error {
+	s.certPEM, s.keyPEM = certPEM, keyPEM
+	s.saves++
+	return nil
+}
+
+func (s *memoryCAStore) String() string { return "memory" }
+
+func TestProvideCA_RoundTripsThroughStore(t *testing.T) {
+	var logs bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+
+	store := &memoryCAStore{}
+	generated, err := ProvideCA(store, DefaultKeyPolicy(), DefaultCASubject())
+	if err != nil {
+		t.Fatalf("ProvideCA (generate): %v", err)
+	}
+	if store.saves != 1 {
+		t.Fatalf("saves = %d, want the generated CA saved once", store.saves)
+	}
+
+	loaded, err := ProvideCA(store, DefaultKeyPolicy(), DefaultCASubject())
+	if err != nil {
+		t.Fatalf("ProvideCA (load): %v", err)
+	}
+	if store.saves != 1 {
+		t.Errorf("saves = %d, want an existing CA not to be saved again", store.saves)
+	}
+	if !bytes.Equal(loaded.CertPEM(), generated.CertPEM()) {
+		t.Error("loaded CA cert differs from the generated one")
+	}
+	loadedKey, err := loaded.KeyPEM()
+	if err != nil {
+		t.Fatalf("KeyPEM: %v", err)
+	}
+	if !bytes.Equal(loadedKey, store.keyPEM) {
+		t.Error("loaded CA key differs from the saved one")
+	}
+
+	// Certificates signed by the loaded CA must verify against the
+	// generated CA's certificate.
+	key, _, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	csrPEM, err := GenerateCSR(key, "agent-a")
+	if err != nil {
+		t.Fatalf("GenerateCSR: %v", err)
+	}
+	certPEM, err := loaded.SignCSR(csrPEM)
+	if err != nil {
+		t.Fatalf("SignCSR: %v", err)
+	}
+	verifyIssuedBy(t, certPEM, generated.CertPEM())
+
+	if bytes.Contains(logs.Bytes(), store.keyPEM) || bytes.Contains(logs.Bytes(), []byte("PRIVATE KEY")) {
+		t.Errorf("logs contain the CA key: %s", logs.String())
+	}
+}
+
+func verifyIssuedBy(t *testing.T, certPEM, caPEM []byte) {
+	t.Helper()
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caPEM) {
+		t.Fatal("parse CA cert")
+	}
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		t.Fatal("decode signed cert PEM")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("parse signed cert: %v", err)
+	}
+	if _, err := cert.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}}); err != nil {
+		t.Errorf("signed cert does not verify against the generated CA: %v", err)
+	}
+}
@@ -1,7 +1,7 @@
 // Package cache provides TTL-based caching infrastructure for
-// Kubernetes discovery data. It lives in the providers layer because
-// caching is an infrastructure concern — the domain layer
-// (internal/core) only defines the SchemaResolver interface.
+// Kubernetes discovery data and namespace lookups. It lives in the
+// providers layer because caching is an infrastructure concern — the
+// domain layer (internal/core) only defines the resolver interfaces.
 package cache
 
 import (
@@ -9,9 +9,13 @@ import (
 	"log/slog"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/metric"
 	"golang.org/x/sync/singleflight"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/kube-openapi/pkg/validation/spec"
 
 	"github.com/otterscale/otterscale-agent/internal/core"
@@ -22,25 +26,43 @@ import (
 // DiscoveryCache.
 const DefaultTTL = 10 * time.Minute
 
+// DefaultOpenAPITTL is the default TTL for cached OpenAPI v3
+// group-version documents. The documents change only when CRDs or
+// aggregated APIs of their group change.
+const DefaultOpenAPITTL = 10 * time.Minute
+
 // defaultMaxSchemaEntries is the upper bound on the number of schema
 // cache entries. When exceeded, expired entries are eagerly evicted
 // before inserting new ones.
 const defaultMaxSchemaEntries = 10000
 
 // DiscoveryCache provides TTL-based caching with singleflight
-// deduplication for OpenAPI schemas. It implements
-// core.SchemaResolver and core.CacheEvictor, and reduces redundant
-// discovery API calls when multiple concurrent requests target the
-// same cluster.
+// deduplication for OpenAPI schemas, OpenAPI v3 group-version
+// documents and server versions. It implements
+// core.SchemaResolver, core.ServerVersionResolver and
+// core.CacheEvictor, and reduces redundant discovery API calls when
+// multiple concurrent requests target the same cluster. Hits, misses
+// and evictions are counted across all caches, so that the TTL and
+// eviction interval can be tuned against the observed hit ratio.
 type DiscoveryCache struct {
 	discovery        core.DiscoveryClient
 	ttl              time.Duration
+	openAPITTL       time.Duration
 	now              func() time.Time
 	maxSchemaEntries int
+	fetchTimeouts    core.ClusterTimeouts
+
+	mu             sync.RWMutex
+	schemaCache    map[string]*schemaCacheEntry
+	schemaFlights  singleflight.Group
+	openAPICache   map[string]*openAPICacheEntry // keyed by cluster/group/version
+	openAPIFlights singleflight.Group
+	versionCache   map[string]*versionCacheEntry // keyed by cluster
+	versionFlights singleflight.Group
 
-	mu            sync.RWMutex
-	schemaCache   map[string]*schemaCacheEntry
-	schemaFlights singleflight.Group
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
 }
 
 // schemaCacheEntry pairs a cached schema with its expiration time.
@@ -49,6 +71,21 @@ type schemaCacheEntry struct {
 	expiresAt time.Time
 }
 
+// openAPICacheEntry pairs a cached OpenAPI v3 document, as JSON, with
+// its expiration time. The raw document is kept rather than its parsed
+// schemas so that resolving a kind always works on a private copy.
+type openAPICacheEntry struct {
+	doc       []byte
+	expiresAt time.Time
+}
+
+// versionCacheEntry pairs a cached server version with its expiration
+// time.
+type versionCacheEntry struct {
+	info      *version.Info
+	expiresAt time.Time
+}
+
 // singleflightFetchTimeout is the maximum time a cache-miss fetch is
 // allowed to run. It uses context.WithoutCancel so that a single
 // caller's cancellation does not fail all singleflight waiters.
@@ -75,15 +112,37 @@ func WithMaxSchemaEntries(n int) Option {
 	}
 }
 
+// WithOpenAPITTL overrides the TTL of cached OpenAPI v3 group-version
+// documents, DefaultOpenAPITTL by default.
+func WithOpenAPITTL(ttl time.Duration) Option {
+	return func(c *DiscoveryCache) {
+		if ttl > 0 {
+			c.openAPITTL = ttl
+		}
+	}
+}
+
+// WithFetchTimeouts sets the per-cluster timeout of cache-miss
+// fetches, which should match the clusters' request timeouts. Clusters
+// without a timeout use singleflightFetchTimeout.
+func WithFetchTimeouts(timeouts core.ClusterTimeouts) Option {
+	return func(c *DiscoveryCache) {
+		c.fetchTimeouts = timeouts
+	}
+}
+
 // NewDiscoveryCache returns a DiscoveryCache that wraps the given
 // DiscoveryClient and caches results for the specified TTL.
 func NewDiscoveryCache(discovery core.DiscoveryClient, ttl time.Duration, opts ...Option) *DiscoveryCache {
 	c := &DiscoveryCache{
 		discovery:        discovery,
 		ttl:              ttl,
+		openAPITTL:       DefaultOpenAPITTL,
 		now:              time.Now,
 		maxSchemaEntries: defaultMaxSchemaEntries,
 		schemaCache:      make(map[string]*schemaCacheEntry),
+		openAPICache:     make(map[string]*openAPICacheEntry),
+		versionCache:     make(map[string]*versionCacheEntry),
 	}
 	for _, o := range opts {
 		o(c)
@@ -91,9 +150,13 @@ func NewDiscoveryCache(discovery core.DiscoveryClient, ttl time.Duration, opts .
 	return c
 }
 
-// ResolveSchema fetches the OpenAPI schema for the given GVK. Results
-// are cached for the configured TTL and concurrent requests for the
-// same key are deduplicated via singleflight.
+// ResolveSchema fetches the OpenAPI schema for the given GVK. The
+// schema is taken from the cached OpenAPI v3 document of its
+// group-version, so that the kinds of a group-version share one fetch,
+// or, if the cluster does not serve one, resolved by the
+// DiscoveryClient on its own. Results are cached for the configured
+// TTL and concurrent requests for the same key are deduplicated via
+// singleflight.
 func (c *DiscoveryCache) ResolveSchema(
 	ctx context.Context,
 	cluster, group, version, kind string,
@@ -105,17 +168,19 @@ func (c *DiscoveryCache) ResolveSchema(
 	c.mu.RUnlock()
 
 	if ok && c.now().Before(entry.expiresAt) {
+		c.hits.Add(1)
 		return entry.schema, nil
 	}
+	c.misses.Add(1)
 
 	v, err, _ := c.schemaFlights.Do(key, func() (any, error) {
 		// Use a non-cancellable context with its own timeout so that
 		// a single caller's cancellation does not fail all waiters
 		// sharing this singleflight key.
-		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), singleflightFetchTimeout)
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.fetchTimeout(cluster))
 		defer cancel()
 
-		resolved, err := c.discovery.ResolveSchema(fetchCtx, cluster, group, version, kind)
+		resolved, err := c.resolveFromOpenAPIV3(fetchCtx, cluster, group, version, kind)
 		if err != nil {
 			return nil, err
 		}
@@ -145,29 +210,144 @@ func (c *DiscoveryCache) ResolveSchema(
 	return v.(*spec.Schema), nil
 }
 
+// resolveFromOpenAPIV3 resolves the schema of a kind from the OpenAPI
+// v3 document of its group-version, falling back to the
+// DiscoveryClient's ResolveSchema when the cluster serves no document.
+func (c *DiscoveryCache) resolveFromOpenAPIV3(ctx context.Context, cluster, group, version, kind string) (*spec.Schema, error) {
+	doc, _, err := c.openAPIV3(ctx, cluster, group, version)
+	if code, ok := core.DomainErrorCode(err); ok && code == core.ErrorCodeNotFound {
+		return c.discovery.ResolveSchema(ctx, cluster, group, version, kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return schemaFromOpenAPIV3(doc, schema.GroupVersionKind{Group: group, Version: version, Kind: kind})
+}
+
+// OpenAPIV3 returns the OpenAPI v3 document of a group-version, as
+// JSON. Documents are cached for the OpenAPI TTL, separately from the
+// schemas resolved from them, and concurrent requests for the same
+// group-version are deduplicated via singleflight. The returned slice
+// is shared and must not be modified.
+func (c *DiscoveryCache) OpenAPIV3(ctx context.Context, cluster, group, version string) ([]byte, error) {
+	doc, hit, err := c.openAPIV3(ctx, cluster, group, version)
+	if hit {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+	return doc, err
+}
+
+// openAPIV3 returns the OpenAPI v3 document of a group-version as
+// OpenAPIV3 does, and whether it was cached, without counting the
+// lookup, so that ResolveSchema counts each of its lookups once.
+func (c *DiscoveryCache) openAPIV3(ctx context.Context, cluster, group, version string) (doc []byte, hit bool, err error) {
+	key := strings.Join([]string{cluster, group, version}, "/")
+
+	c.mu.RLock()
+	entry, ok := c.openAPICache[key]
+	c.mu.RUnlock()
+
+	if ok && c.now().Before(entry.expiresAt) {
+		return entry.doc, true, nil
+	}
+
+	v, err, _ := c.openAPIFlights.Do(key, func() (any, error) {
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.fetchTimeout(cluster))
+		defer cancel()
+
+		doc, err := c.discovery.OpenAPIV3(fetchCtx, cluster, group, version)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		if len(c.openAPICache) >= c.maxSchemaEntries {
+			c.evictExpiredOpenAPI()
+		}
+		if len(c.openAPICache) < c.maxSchemaEntries {
+			c.openAPICache[key] = &openAPICacheEntry{
+				doc:       doc,
+				expiresAt: c.now().Add(c.openAPITTL),
+			}
+		}
+		c.mu.Unlock()
+
+		return doc, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return v.([]byte), false, nil
+}
+
+// ServerVersion returns the Kubernetes version of the cluster. Results
+// are cached per cluster for the configured TTL and concurrent
+// requests for the same cluster are deduplicated via singleflight.
+func (c *DiscoveryCache) ServerVersion(ctx context.Context, cluster string) (*version.Info, error) {
+	c.mu.RLock()
+	entry, ok := c.versionCache[cluster]
+	c.mu.RUnlock()
+
+	if ok && c.now().Before(entry.expiresAt) {
+		c.hits.Add(1)
+		return entry.info, nil
+	}
+	c.misses.Add(1)
+
+	v, err, _ := c.versionFlights.Do(cluster, func() (any, error) {
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.fetchTimeout(cluster))
+		defer cancel()
+
+		info, err := c.discovery.ServerVersion(fetchCtx, cluster)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		c.versionCache[cluster] = &versionCacheEntry{
+			info:      info,
+			expiresAt: c.now().Add(c.ttl),
+		}
+		c.mu.Unlock()
+
+		return info, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*version.Info), nil
+}
+
 // schemaCacheKey builds a cache key from the cluster/group/version/kind tuple.
 func (c *DiscoveryCache) schemaCacheKey(cluster, group, version, kind string) string {
 	return strings.Join([]string{cluster, group, version, kind}, "/")
 }
 
 // StartEvictionLoop launches a background goroutine that periodically
-// removes expired cache entries. This prevents memory leaks when
-// clusters go offline or schemas are no longer queried. It blocks
-// until ctx is cancelled.
-func (c *DiscoveryCache) StartEvictionLoop(ctx context.Context, interval time.Duration) {
+// removes expired cache entries, every interval varied by jitter. This
+// prevents memory leaks when clusters go offline or schemas are no
+// longer queried. It blocks until ctx is cancelled.
+func (c *DiscoveryCache) StartEvictionLoop(ctx context.Context, interval time.Duration, jitter core.Jitter) {
 	log := slog.Default().With("component", "discovery-cache-evictor")
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
+	timer := time.NewTimer(jitter.Apply(interval))
+	defer timer.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case <-ticker.C:
+		case <-timer.C:
+			timer.Reset(jitter.Apply(interval))
 			c.mu.Lock()
-			before := len(c.schemaCache)
+			before := len(c.schemaCache) + len(c.openAPICache) + len(c.versionCache)
 			c.evictExpiredSchemas()
-			after := len(c.schemaCache)
+			c.evictExpiredOpenAPI()
+			c.evictExpiredVersions()
+			after := len(c.schemaCache) + len(c.openAPICache) + len(c.versionCache)
 			c.mu.Unlock()
 
 			if evicted := before - after; evicted > 0 {
@@ -184,6 +364,83 @@ func (c *DiscoveryCache) evictExpiredSchemas() {
 	for key, entry := range c.schemaCache {
 		if now.After(entry.expiresAt) {
 			delete(c.schemaCache, key)
+			c.evictions.Add(1)
+		}
+	}
+}
+
+// evictExpiredOpenAPI removes expired entries from the OpenAPI v3
+// document cache. Must be called with mu held for writing.
+func (c *DiscoveryCache) evictExpiredOpenAPI() {
+	now := c.now()
+	for key, entry := range c.openAPICache {
+		if now.After(entry.expiresAt) {
+			delete(c.openAPICache, key)
+			c.evictions.Add(1)
 		}
 	}
 }
+
+// evictExpiredVersions removes expired entries from the version cache.
+// Must be called with mu held for writing.
+func (c *DiscoveryCache) evictExpiredVersions() {
+	now := c.now()
+	for cluster, entry := range c.versionCache {
+		if now.After(entry.expiresAt) {
+			delete(c.versionCache, cluster)
+			c.evictions.Add(1)
+		}
+	}
+}
+
+// Hits returns the number of lookups answered from the cache.
+func (c *DiscoveryCache) Hits() uint64 {
+	return c.hits.Load()
+}
+
+// Misses returns the number of lookups that had to fetch from the
+// cluster because their entry was missing or expired.
+func (c *DiscoveryCache) Misses() uint64 {
+	return c.misses.Load()
+}
+
+// Evictions returns the number of expired entries removed from the
+// cache.
+func (c *DiscoveryCache) Evictions() uint64 {
+	return c.evictions.Load()
+}
+
+// RegisterMetrics exports the hit, miss and eviction counters as
+// observable counters of meter.
+func (c *DiscoveryCache) RegisterMetrics(meter metric.Meter) error {
+	hits, err := meter.Int64ObservableCounter("otterscale.discovery_cache.hits",
+		metric.WithDescription("Discovery cache lookups answered from the cache"))
+	if err != nil {
+		return err
+	}
+	misses, err := meter.Int64ObservableCounter("otterscale.discovery_cache.misses",
+		metric.WithDescription("Discovery cache lookups fetched from the cluster"))
+	if err != nil {
+		return err
+	}
+	evictions, err := meter.Int64ObservableCounter("otterscale.discovery_cache.evictions",
+		metric.WithDescription("Expired discovery cache entries evicted"))
+	if err != nil {
+		return err
+	}
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveInt64(hits, int64(c.Hits()))
+		o.ObserveInt64(misses, int64(c.Misses()))
+		o.ObserveInt64(evictions, int64(c.Evictions()))
+		return nil
+	}, hits, misses, evictions)
+	return err
+}
+
+// fetchTimeout returns the timeout of a cache-miss fetch from cluster.
+func (c *DiscoveryCache) fetchTimeout(cluster string) time.Duration {
+	if timeout := c.fetchTimeouts.For(cluster); timeout > 0 {
+		return timeout
+	}
+	return singleflightFetchTimeout
+}
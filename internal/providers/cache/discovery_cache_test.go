@@ -0,0 +1,124 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// schemaDiscovery resolves every kind to an empty schema and counts
+// the calls. It serves no OpenAPI v3 documents, as clusters that
+// predate them.
+type schemaDiscovery struct {
+	core.DiscoveryClient
+	resolves int
+}
+
+func (d *schemaDiscovery) ResolveSchema(context.Context, string, string, string, string) (*spec.Schema, error) {
+	d.resolves++
+	return &spec.Schema{}, nil
+}
+
+func (d *schemaDiscovery) OpenAPIV3(context.Context, string, string, string) ([]byte, error) {
+	return nil, &core.DomainError{Code: core.ErrorCodeNotFound, Message: "not found"}
+}
+
+// openAPIDiscovery serves an OpenAPI v3 document for apps/v1 and counts
+// the fetches.
+type openAPIDiscovery struct {
+	core.DiscoveryClient
+	fetches int
+}
+
+func (d *openAPIDiscovery) OpenAPIV3(_ context.Context, _, group, version string) ([]byte, error) {
+	d.fetches++
+	return []byte(`{"components":{"schemas":{
+		"io.k8s.api.apps.v1.Deployment":{"type":"object","properties":{"spec":{"$ref":"#/components/schemas/io.k8s.api.apps.v1.DeploymentSpec"}},
+			"x-kubernetes-group-version-kind":[{"group":"apps","kind":"Deployment","version":"v1"}]},
+		"io.k8s.api.apps.v1.DeploymentSpec":{"type":"object","properties":{"replicas":{"type":"integer"}}},
+		"io.k8s.api.apps.v1.StatefulSet":{"type":"object",
+			"x-kubernetes-group-version-kind":[{"group":"apps","kind":"StatefulSet","version":"v1"}]}}}}`), nil
+}
+
+func TestDiscoveryCache_CountsHitsMissesAndEvictions(t *testing.T) {
+	discovery := &schemaDiscovery{}
+	now := time.Unix(1_700_000_000, 0)
+	c := NewDiscoveryCache(discovery, time.Minute, WithClock(func() time.Time { return now }))
+	ctx := context.Background()
+
+	resolve := func(kind string) {
+		t.Helper()
+		if _, err := c.ResolveSchema(ctx, "c1", "apps", "v1", kind); err != nil {
+			t.Fatalf("ResolveSchema(%s): %v", kind, err)
+		}
+	}
+	assertCounts := func(hits, misses, evictions uint64) {
+		t.Helper()
+		if c.Hits() != hits || c.Misses() != misses || c.Evictions() != evictions {
+			t.Errorf("hits, misses, evictions = %d, %d, %d, want %d, %d, %d",
+				c.Hits(), c.Misses(), c.Evictions(), hits, misses, evictions)
+		}
+	}
+
+	for range 3 {
+		resolve("Deployment")
+	}
+	assertCounts(2, 1, 0)
+
+	resolve("StatefulSet")
+	assertCounts(2, 2, 0)
+
+	// An expired entry is a miss, and is refetched.
+	now = now.Add(2 * time.Minute)
+	resolve("Deployment")
+	assertCounts(2, 3, 0)
+	if discovery.resolves != 3 {
+		t.Errorf("resolves = %d, want 3", discovery.resolves)
+	}
+
+	// Only the StatefulSet entry has expired.
+	c.mu.Lock()
+	c.evictExpiredSchemas()
+	c.mu.Unlock()
+	assertCounts(2, 3, 1)
+}
+
+func TestDiscoveryCache_ResolvesSchemasFromOpenAPIV3(t *testing.T) {
+	discovery := &openAPIDiscovery{}
+	now := time.Unix(1_700_000_000, 0)
+	c := NewDiscoveryCache(discovery, time.Minute, WithClock(func() time.Time { return now }), WithOpenAPITTL(time.Hour))
+	ctx := context.Background()
+
+	deployment, err := c.ResolveSchema(ctx, "c1", "apps", "v1", "Deployment")
+	if err != nil {
+		t.Fatalf("ResolveSchema(Deployment): %v", err)
+	}
+	if _, ok := deployment.Properties["spec"].Properties["replicas"]; !ok {
+		t.Errorf("Deployment schema = %+v, want spec.replicas resolved from its reference", deployment)
+	}
+	if _, err := c.ResolveSchema(ctx, "c1", "apps", "v1", "StatefulSet"); err != nil {
+		t.Fatalf("ResolveSchema(StatefulSet): %v", err)
+	}
+	if discovery.fetches != 1 {
+		t.Errorf("fetches = %d, want the kinds of apps/v1 to share one", discovery.fetches)
+	}
+
+	// Expired schemas are resolved again from the document, which
+	// outlives them.
+	now = now.Add(2 * time.Minute)
+	if _, err := c.ResolveSchema(ctx, "c1", "apps", "v1", "Deployment"); err != nil {
+		t.Fatalf("ResolveSchema after the schema TTL: %v", err)
+	}
+	if discovery.fetches != 1 {
+		t.Errorf("fetches = %d, want the cached document reused", discovery.fetches)
+	}
+
+	_, err = c.ResolveSchema(ctx, "c1", "apps", "v1", "ReplicaSet")
+	if code, ok := core.DomainErrorCode(err); !ok || code != core.ErrorCodeNotFound {
+		t.Errorf("ResolveSchema(ReplicaSet): err = %v, want not found", err)
+	}
+}
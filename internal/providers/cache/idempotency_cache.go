@@ -0,0 +1,158 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// DefaultIdempotencyTTL is the default time for which the result of a
+// create made with an idempotency key is remembered.
+const DefaultIdempotencyTTL = 10 * time.Minute
+
+// defaultMaxIdempotencyEntries is the upper bound on the number of
+// remembered creates. When exceeded, expired entries are eagerly
+// evicted; if the cache is still full, creates run without being
+// remembered.
+const defaultMaxIdempotencyEntries = 10000
+
+// idempotencyEntry is a create made with an idempotency key. done is
+// closed once it has finished; obj and expiresAt are set if it
+// succeeded and err if it failed, in which case the entry is removed.
+type idempotencyEntry struct {
+	fingerprint string
+	done        chan struct{}
+	obj         *unstructured.Unstructured
+	err         error
+	expiresAt   time.Time
+}
+
+// IdempotencyCache implements core.IdempotencyCache in memory. Only
+// successful creates are remembered: a failed one may be retried with
+// the same key. The cache is local to the process, so retries are only
+// deduplicated when they reach the same server replica.
+type IdempotencyCache struct {
+	ttl        time.Duration
+	now        func() time.Time
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*idempotencyEntry
+}
+
+// Verify at compile time that IdempotencyCache satisfies
+// core.IdempotencyCache.
+var _ core.IdempotencyCache = (*IdempotencyCache)(nil)
+
+// IdempotencyOption configures an IdempotencyCache at construction
+// time.
+type IdempotencyOption func(*IdempotencyCache)
+
+// WithIdempotencyClock injects a custom time source for deterministic
+// testing. When not set, time.Now is used.
+func WithIdempotencyClock(now func() time.Time) IdempotencyOption {
+	return func(c *IdempotencyCache) {
+		c.now = now
+	}
+}
+
+// NewIdempotencyCache returns an IdempotencyCache that remembers
+// successful creates for ttl.
+func NewIdempotencyCache(ttl time.Duration, opts ...IdempotencyOption) *IdempotencyCache {
+	c := &IdempotencyCache{
+		ttl:        ttl,
+		now:        time.Now,
+		maxEntries: defaultMaxIdempotencyEntries,
+		entries:    make(map[string]*idempotencyEntry),
+	}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+// Do returns a copy of the object recorded under key if fingerprint
+// matches the create that recorded it, waiting for that create if it
+// is still in progress. A key recorded for another fingerprint yields
+// an *core.ErrInvalidInput. Otherwise create is called and its result
+// recorded if it succeeds.
+func (c *IdempotencyCache) Do(ctx context.Context, key, fingerprint string, create func() (*unstructured.Unstructured, error)) (*unstructured.Unstructured, error) {
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	if ok && !e.expiresAt.IsZero() && !c.now().Before(e.expiresAt) {
+		delete(c.entries, key)
+		ok = false
+	}
+	if ok {
+		c.mu.Unlock()
+		if e.fingerprint != fingerprint {
+			return nil, &core.ErrInvalidInput{Field: "idempotency_key", Message: "already used for a different request"}
+		}
+		select {
+		case <-e.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if e.err != nil {
+			return nil, e.err
+		}
+		return e.obj.DeepCopy(), nil
+	}
+
+	if len(c.entries) >= c.maxEntries {
+		c.evictExpired()
+	}
+	if len(c.entries) >= c.maxEntries {
+		c.mu.Unlock()
+		return create()
+	}
+	e = &idempotencyEntry{fingerprint: fingerprint, done: make(chan struct{})}
+	c.entries[key] = e
+	c.mu.Unlock()
+
+	// The entry is finished even if create panics, so that waiters
+	// and later retries are not blocked on it.
+	finished := false
+	defer func() {
+		if !finished {
+			c.finish(key, e, nil, errCreateAborted)
+		}
+	}()
+	obj, err := create()
+	finished = true
+	c.finish(key, e, obj, err)
+	return obj, err
+}
+
+// errCreateAborted is the outcome shared with waiters of a create that
+// panicked.
+var errCreateAborted = &core.DomainError{Code: core.ErrorCodeInternal, Message: "create aborted"}
+
+// finish records the outcome of e's create and releases its waiters.
+// A failed create is forgotten so that it may be retried.
+func (c *IdempotencyCache) finish(key string, e *idempotencyEntry, obj *unstructured.Unstructured, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil {
+		e.err = err
+		delete(c.entries, key)
+	} else {
+		e.obj = obj.DeepCopy()
+		e.expiresAt = c.now().Add(c.ttl)
+	}
+	close(e.done)
+}
+
+// evictExpired removes expired entries. Callers must hold c.mu.
+func (c *IdempotencyCache) evictExpired() {
+	now := c.now()
+	for key, e := range c.entries {
+		if !e.expiresAt.IsZero() && !now.Before(e.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+}
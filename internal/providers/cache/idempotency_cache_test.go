@@ -0,0 +1,126 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// configMapDiscovery accepts every resource as namespaced.
+type configMapDiscovery struct {
+	core.DiscoveryClient
+}
+
+func (configMapDiscovery) LookupResource(_ context.Context, _, group, version, resource string) (schema.GroupVersionResource, error) {
+	return schema.GroupVersionResource{Group: group, Version: version, Resource: resource}, nil
+}
+
+func (configMapDiscovery) IsNamespaced(context.Context, string, schema.GroupVersionResource) (bool, error) {
+	return true, nil
+}
+
+// createRepo creates objects like an API server would, failing with
+// AlreadyExists for a name it has created before, and counts the
+// creates that reached it.
+type createRepo struct {
+	core.ResourceRepo
+	names   map[string]bool
+	creates int
+}
+
+func (r *createRepo) Create(_ context.Context, _ string, _ schema.GroupVersionResource, namespace string, manifest []byte) (*unstructured.Unstructured, error) {
+	r.creates++
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(manifest); err != nil {
+		return nil, err
+	}
+	if r.names[obj.GetName()] {
+		return nil, &core.DomainError{Code: core.ErrorCodeAlreadyExists, Message: `configmaps "` + obj.GetName() + `" already exists`}
+	}
+	r.names[obj.GetName()] = true
+	obj.SetNamespace(namespace)
+	obj.SetUID(types.UID("uid-" + obj.GetName()))
+	return obj, nil
+}
+
+func TestIdempotencyCache_RetriedCreate(t *testing.T) {
+	repo := &createRepo{names: map[string]bool{}}
+	uc := core.NewResourceUseCase(configMapDiscovery{}, repo, nil, nil, nil, core.ApplyConfig{}, core.NamespaceConfig{}, core.WithIdempotencyCache(NewIdempotencyCache(time.Minute)))
+	ctx := core.WithUserInfo(context.Background(), core.UserInfo{Subject: "alice"})
+	id := core.ResourceIdentifier{Cluster: "edge-1", Version: "v1", Resource: "configmaps", Namespace: "default"}
+	manifest := []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"settings"}}`)
+	opts := core.CreateOptions{IdempotencyKey: "req-1"}
+
+	created, err := uc.CreateResource(ctx, id, manifest, opts)
+	if err != nil {
+		t.Fatalf("fresh create: %v", err)
+	}
+	if created.GetUID() != "uid-settings" {
+		t.Fatalf("created = %v, want settings with its UID", created.Object)
+	}
+
+	retried, err := uc.CreateResource(ctx, id, manifest, opts)
+	if err != nil {
+		t.Fatalf("retried create: %v", err)
+	}
+	if retried.GetUID() != created.GetUID() || repo.creates != 1 {
+		t.Errorf("retry returned %s after %d creates, want the original %s without creating again", retried.GetUID(), repo.creates, created.GetUID())
+	}
+
+	other := []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"other"}}`)
+	var invalid *core.ErrInvalidInput
+	if _, err := uc.CreateResource(ctx, id, other, opts); !errors.As(err, &invalid) || invalid.Field != "idempotency_key" {
+		t.Errorf("different body under the same key: err = %v, want invalid input on idempotency_key", err)
+	}
+
+	// Keys are scoped to the caller, and creates without one are not
+	// deduplicated.
+	bob := core.WithUserInfo(context.Background(), core.UserInfo{Subject: "bob"})
+	if _, err := uc.CreateResource(bob, id, manifest, opts); err == nil {
+		t.Error("another caller's create with the same key returned alice's object")
+	}
+	if _, err := uc.CreateResource(ctx, id, manifest, core.CreateOptions{}); err == nil {
+		t.Error("create without a key was deduplicated")
+	}
+}
+
+func TestIdempotencyCache_ForgetsFailuresAndExpiredResults(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	c := NewIdempotencyCache(time.Minute, WithIdempotencyClock(func() time.Time { return now }))
+	ctx := context.Background()
+
+	calls := 0
+	create := func() (*unstructured.Unstructured, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("connection reset")
+		}
+		obj := &unstructured.Unstructured{}
+		obj.SetUID(types.UID(fmt.Sprintf("uid-%d", calls)))
+		return obj, nil
+	}
+
+	if _, err := c.Do(ctx, "k", "fp", create); err == nil {
+		t.Fatal("failed create succeeded")
+	}
+	first, err := c.Do(ctx, "k", "fp", create)
+	if err != nil || calls != 2 {
+		t.Fatalf("retry after a failure: err = %v after %d calls, want the create attempted again", err, calls)
+	}
+	if again, _ := c.Do(ctx, "k", "fp", create); again.GetUID() != first.GetUID() || calls != 2 {
+		t.Errorf("retry within the TTL created %s, want the recorded %s", again.GetUID(), first.GetUID())
+	}
+
+	now = now.Add(time.Minute)
+	if later, _ := c.Do(ctx, "k", "fp", create); later.GetUID() == first.GetUID() || calls != 3 {
+		t.Errorf("retry after the TTL returned the expired %s", later.GetUID())
+	}
+}
@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// DefaultNamespaceTTL is the default TTL for cached namespace
+// existence results.
+const DefaultNamespaceTTL = time.Minute
+
+// defaultMaxNamespaceEntries is the upper bound on the number of
+// namespace cache entries. When exceeded, expired entries are eagerly
+// evicted before inserting new ones.
+const defaultMaxNamespaceEntries = 10000
+
+// namespacesGVR identifies the core/v1 Namespace resource.
+var namespacesGVR = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+
+// NamespaceCache implements core.NamespaceChecker on top of a
+// core.ResourceRepo. Only positive results are cached: a namespace
+// that is missing now may be created moments later, whereas a cached
+// namespace that has since been deleted merely defers the error to
+// the API server.
+//
+// Lookups are not deduplicated via singleflight because they are
+// made with the caller's identity; sharing one caller's result with
+// another would bypass RBAC on the namespace read.
+type NamespaceCache struct {
+	resource   core.ResourceRepo
+	ttl        time.Duration
+	now        func() time.Time
+	maxEntries int
+
+	mu      sync.RWMutex
+	entries map[string]time.Time // cluster/namespace -> expiry
+}
+
+// Verify at compile time that NamespaceCache satisfies
+// core.NamespaceChecker.
+var _ core.NamespaceChecker = (*NamespaceCache)(nil)
+
+// NamespaceOption configures a NamespaceCache at construction time.
+type NamespaceOption func(*NamespaceCache)
+
+// WithNamespaceClock injects a custom time source for deterministic
+// testing. When not set, time.Now is used.
+func WithNamespaceClock(now func() time.Time) NamespaceOption {
+	return func(c *NamespaceCache) {
+		c.now = now
+	}
+}
+
+// NewNamespaceCache returns a NamespaceCache that looks namespaces up
+// through resource and caches positive results for ttl.
+func NewNamespaceCache(resource core.ResourceRepo, ttl time.Duration, opts ...NamespaceOption) *NamespaceCache {
+	c := &NamespaceCache{
+		resource:   resource,
+		ttl:        ttl,
+		now:        time.Now,
+		maxEntries: defaultMaxNamespaceEntries,
+		entries:    make(map[string]time.Time),
+	}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+// NamespaceExists reports whether namespace exists on cluster. A
+// NotFound response from the API server yields (false, nil); other
+// errors, including PermissionDenied, are returned unchanged.
+func (c *NamespaceCache) NamespaceExists(ctx context.Context, cluster, namespace string) (bool, error) {
+	key := cluster + "/" + namespace
+
+	c.mu.RLock()
+	expiresAt, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if ok && c.now().Before(expiresAt) {
+		return true, nil
+	}
+
+	if _, err := c.resource.Get(ctx, cluster, namespacesGVR, "", namespace); err != nil {
+		if code, ok := core.DomainErrorCode(err); ok && code == core.ErrorCodeNotFound {
+			c.mu.Lock()
+			delete(c.entries, key)
+			c.mu.Unlock()
+			return false, nil
+		}
+		return false, err
+	}
+
+	c.mu.Lock()
+	if len(c.entries) >= c.maxEntries {
+		c.evictExpired()
+	}
+	if len(c.entries) < c.maxEntries {
+		c.entries[key] = c.now().Add(c.ttl)
+	}
+	c.mu.Unlock()
+
+	return true, nil
+}
+
+// evictExpired removes expired entries. Callers must hold c.mu.
+func (c *NamespaceCache) evictExpired() {
+	now := c.now()
+	for key, expiresAt := range c.entries {
+		if !now.Before(expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// namespaceRepo serves Get requests for a fixed set of namespaces and
+// counts the calls.
+type namespaceRepo struct {
+	core.ResourceRepo
+	existing map[string]bool
+	gets     int
+}
+
+func (r *namespaceRepo) Get(_ context.Context, _ string, gvr schema.GroupVersionResource, _, name string) (*unstructured.Unstructured, error) {
+	r.gets++
+	if gvr != namespacesGVR {
+		return nil, &core.DomainError{Code: core.ErrorCodeInvalidArgument, Message: "unexpected resource " + gvr.String()}
+	}
+	if !r.existing[name] {
+		return nil, &core.DomainError{Code: core.ErrorCodeNotFound, Message: `namespaces "` + name + `" not found`}
+	}
+	return &unstructured.Unstructured{}, nil
+}
+
+func TestNamespaceCache_CachesOnlyExistingNamespaces(t *testing.T) {
+	repo := &namespaceRepo{existing: map[string]bool{"default": true}}
+	now := time.Unix(1_700_000_000, 0)
+	c := NewNamespaceCache(repo, time.Minute, WithNamespaceClock(func() time.Time { return now }))
+	ctx := context.Background()
+
+	for range 2 {
+		ok, err := c.NamespaceExists(ctx, "c1", "default")
+		if err != nil || !ok {
+			t.Fatalf("NamespaceExists(default) = %v, %v", ok, err)
+		}
+	}
+	if repo.gets != 1 {
+		t.Errorf("gets = %d, want 1 (second lookup should hit the cache)", repo.gets)
+	}
+
+	for range 2 {
+		ok, err := c.NamespaceExists(ctx, "c1", "missing")
+		if err != nil || ok {
+			t.Fatalf("NamespaceExists(missing) = %v, %v", ok, err)
+		}
+	}
+	if repo.gets != 3 {
+		t.Errorf("gets = %d, want 3 (missing namespaces must not be cached)", repo.gets)
+	}
+
+	// The same namespace on another cluster is looked up separately.
+	if _, err := c.NamespaceExists(ctx, "c2", "default"); err != nil {
+		t.Fatalf("NamespaceExists(c2/default): %v", err)
+	}
+	if repo.gets != 4 {
+		t.Errorf("gets = %d, want 4", repo.gets)
+	}
+
+	// After the TTL the entry is refreshed.
+	now = now.Add(time.Minute)
+	if _, err := c.NamespaceExists(ctx, "c1", "default"); err != nil {
+		t.Fatalf("NamespaceExists after TTL: %v", err)
+	}
+	if repo.gets != 5 {
+		t.Errorf("gets = %d, want 5 after TTL expiry", repo.gets)
+	}
+}
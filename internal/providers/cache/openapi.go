@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/cel/openapi/resolver"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// openAPIRefPrefix prefixes the references between the schemas of an
+// OpenAPI v3 document.
+const openAPIRefPrefix = "#/components/schemas/"
+
+// openAPIExtGVK is the extension naming the kinds a schema describes.
+const openAPIExtGVK = "x-kubernetes-group-version-kind"
+
+// openAPIV3Document is the part of an OpenAPI v3 document that holds
+// its schemas.
+type openAPIV3Document struct {
+	Components struct {
+		Schemas map[string]*spec.Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+// schemaFromOpenAPIV3 returns the schema of gvk from doc, an OpenAPI v3
+// group-version document, with its references resolved. doc is parsed
+// on every call, as resolving references modifies the parsed schemas.
+func schemaFromOpenAPIV3(doc []byte, gvk schema.GroupVersionKind) (*spec.Schema, error) {
+	var parsed openAPIV3Document
+	if err := json.Unmarshal(doc, &parsed); err != nil {
+		return nil, &core.DomainError{Code: core.ErrorCodeInternal, Message: fmt.Sprintf("parse OpenAPI v3 document of %s", gvk.GroupVersion()), Cause: err}
+	}
+	schemas := parsed.Components.Schemas
+
+	for name, s := range schemas {
+		var gvks []schema.GroupVersionKind
+		if err := s.Extensions.GetObject(openAPIExtGVK, &gvks); err != nil {
+			continue
+		}
+		for _, g := range gvks {
+			if g != gvk {
+				continue
+			}
+			resolved, err := resolver.PopulateRefs(func(ref string) (*spec.Schema, bool) {
+				s, ok := schemas[strings.TrimPrefix(ref, openAPIRefPrefix)]
+				return s, ok
+			}, openAPIRefPrefix+name)
+			if err != nil {
+				return nil, &core.DomainError{Code: core.ErrorCodeInternal, Message: fmt.Sprintf("resolve schema of %s", gvk), Cause: err}
+			}
+			return resolved, nil
+		}
+	}
+	return nil, &core.DomainError{Code: core.ErrorCodeNotFound, Message: fmt.Sprintf("no schema for %s", gvk)}
+}
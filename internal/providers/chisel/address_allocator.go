@@ -11,12 +11,12 @@ import (
 //
 // All methods must be called with the parent Service's mu held.
 type addressAllocator struct {
-	usedHosts map[string]struct{}
+	usedHosts map[string]string // host -> cluster it was allocated for
 }
 
 func newAddressAllocator() *addressAllocator {
 	return &addressAllocator{
-		usedHosts: make(map[string]struct{}),
+		usedHosts: make(map[string]string),
 	}
 }
 
@@ -30,7 +30,7 @@ func (a *addressAllocator) allocate(cluster string) (string, error) {
 		if _, exists := a.usedHosts[candidate]; exists {
 			continue
 		}
-		a.usedHosts[candidate] = struct{}{}
+		a.usedHosts[candidate] = cluster
 		return candidate, nil
 	}
 	return "", fmt.Errorf("exhausted loopback address space (%d hosts)", maxHosts)
@@ -0,0 +1,115 @@
+package chisel
+
+import (
+	"sync"
+	"time"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// requestGroup counts the requests in flight through one registration
+// of a cluster's tunnel, so that DeregisterCluster can let them finish
+// before tearing the tunnel down.
+type requestGroup struct {
+	mu       sync.Mutex
+	inflight int
+	draining bool
+	// idle is closed once the group is draining and no request is in
+	// flight.
+	idle chan struct{}
+}
+
+func newRequestGroup() *requestGroup {
+	return &requestGroup{idle: make(chan struct{})}
+}
+
+// acquire counts a new request and reports whether it may proceed,
+// which it may not once the group is draining.
+func (g *requestGroup) acquire() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.draining {
+		return false
+	}
+	g.inflight++
+	return true
+}
+
+// release marks a request acquired before as finished.
+func (g *requestGroup) release() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.inflight--
+	if g.draining && g.inflight == 0 {
+		close(g.idle)
+	}
+}
+
+// isDraining reports whether the group refuses new requests. A nil
+// group is not draining.
+func (g *requestGroup) isDraining() bool {
+	if g == nil {
+		return false
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.draining
+}
+
+// drain refuses new requests and waits until those in flight have
+// finished or timeout has elapsed. It reports whether the group
+// became idle.
+func (g *requestGroup) drain(timeout time.Duration) bool {
+	g.mu.Lock()
+	if !g.draining {
+		g.draining = true
+		if g.inflight == 0 {
+			close(g.idle)
+		}
+	}
+	g.mu.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-g.idle:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// TrackRequest counts a request about to be sent through cluster's
+// tunnel until done is called. It fails with *core.ErrClusterNotFound
+// if the cluster is not registered or is being deregistered, so that
+// no new request starts while in-flight ones are drained.
+func (s *Service) TrackRequest(cluster string) (done func(), err error) {
+	s.mu.RLock()
+	g, ok := s.requests[cluster]
+	s.mu.RUnlock()
+	if !ok || !g.acquire() {
+		return nil, &core.ErrClusterNotFound{Cluster: cluster}
+	}
+	return sync.OnceFunc(g.release), nil
+}
+
+// drainRequests waits up to s.drain for the requests in flight
+// through cluster's tunnel to finish, refusing new ones meanwhile. The
+// caller holds the cluster lock.
+func (s *Service) drainRequests(cluster string) {
+	if s.drain <= 0 {
+		return
+	}
+	s.mu.RLock()
+	g, ok := s.requests[cluster]
+	s.mu.RUnlock()
+	if !ok {
+		return
+	}
+	if !g.drain(s.drain) {
+		s.log.Warn("tunnel drain timed out; closing with requests in flight",
+			"cluster", cluster,
+			"timeout", s.drain,
+		)
+	}
+}
@@ -0,0 +1,81 @@
+package chisel
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+func TestDeregisterCluster_DrainsInFlightRequests(t *testing.T) {
+	s := newTestServiceWithConfig(t, Config{DrainTimeout: time.Minute})
+	ctx := context.Background()
+	if _, _, err := s.RegisterCluster(ctx, "edge-1", "agent-a", "test", generateCSR(t, "agent-a")); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	inflight, err := s.TrackRequest("edge-1")
+	if err != nil {
+		t.Fatalf("track in-flight request: %v", err)
+	}
+
+	deregistered := make(chan struct{})
+	go func() {
+		s.DeregisterCluster("edge-1")
+		close(deregistered)
+	}()
+
+	// Wait for the drain to start refusing new requests.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := s.ResolveAddress(ctx, "edge-1"); err != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("cluster still resolves after deregistration started")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	var notFound *core.ErrClusterNotFound
+	if _, err := s.TrackRequest("edge-1"); !errors.As(err, &notFound) {
+		t.Fatalf("new request during drain: err = %v, want *core.ErrClusterNotFound", err)
+	}
+	// The in-flight request still has its tunnel.
+	if _, ok := s.ListClusters()["edge-1"]; !ok {
+		t.Fatal("cluster torn down while a request was in flight")
+	}
+	select {
+	case <-deregistered:
+		t.Fatal("deregistration finished while a request was in flight")
+	default:
+	}
+
+	inflight()
+	select {
+	case <-deregistered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("deregistration did not finish after the in-flight request completed")
+	}
+	if _, ok := s.ListClusters()["edge-1"]; ok {
+		t.Error("cluster still registered after drain")
+	}
+}
+
+func TestDeregisterCluster_DrainTimesOut(t *testing.T) {
+	s := newTestServiceWithConfig(t, Config{DrainTimeout: 50 * time.Millisecond})
+	ctx := context.Background()
+	if _, _, err := s.RegisterCluster(ctx, "edge-1", "agent-a", "test", generateCSR(t, "agent-a")); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if _, err := s.TrackRequest("edge-1"); err != nil {
+		t.Fatalf("track request: %v", err)
+	}
+
+	s.DeregisterCluster("edge-1")
+	if _, ok := s.ListClusters()["edge-1"]; ok {
+		t.Error("cluster still registered after the drain timed out")
+	}
+}
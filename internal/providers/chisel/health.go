@@ -2,9 +2,13 @@ package chisel
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"strconv"
 	"time"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+	"github.com/otterscale/otterscale-agent/internal/transport/tunnel"
 )
 
 const (
@@ -47,56 +51,61 @@ func (h *HealthCheckListener) Stop(_ context.Context) error {
 	return nil
 }
 
-// clusterSnapshot returns a copy of the cluster-to-host mapping so
-// that health checks can iterate without holding the lock.
-func (s *Service) clusterSnapshot() map[string]string {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	snapshot := make(map[string]string, len(s.clusters))
-	for name, entry := range s.clusters {
-		snapshot[name] = entry.Host
-	}
-	return snapshot
-}
-
 // runHealthCheck periodically probes every registered cluster's
-// tunnel endpoint via TCP dial. Clusters that fail healthFailThreshold
-// consecutive probes are automatically deregistered.
+// tunnel endpoint via TCP dial. A cluster whose endpoint answers for
+// the first time is logged as connected; clusters that fail
+// healthFailThreshold consecutive probes are automatically
+// deregistered. Every reconcileInterval it also removes tunnel users
+// and hosts left behind by failed registrations; see reconcile. Both
+// intervals are varied by the service's jitter.
 //
 // The method blocks until ctx is cancelled.
 func (s *Service) runHealthCheck(ctx context.Context) {
-	ticker := time.NewTicker(healthCheckInterval)
-	defer ticker.Stop()
+	timer := time.NewTimer(s.jitter.Apply(healthCheckInterval))
+	defer timer.Stop()
+	reconcileTimer := time.NewTimer(s.jitter.Apply(reconcileInterval))
+	defer reconcileTimer.Stop()
 
 	dialer := net.Dialer{Timeout: healthDialTimeout}
 	failCounts := make(map[string]int)
+	up := make(map[string]string)
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case <-ticker.C:
-			s.checkClusters(ctx, dialer, failCounts)
+		case <-timer.C:
+			s.checkClusters(ctx, dialer, failCounts, up)
+			timer.Reset(s.jitter.Apply(healthCheckInterval))
+		case <-reconcileTimer.C:
+			s.reconcile()
+			reconcileTimer.Reset(s.jitter.Apply(reconcileInterval))
 		}
 	}
 }
 
 // checkClusters performs a single round of health checks across all
-// registered clusters. failCounts is mutated in place to track
-// consecutive failures per cluster.
-func (s *Service) checkClusters(ctx context.Context, dialer net.Dialer, failCounts map[string]int) {
-	snapshot := s.clusterSnapshot()
+// registered clusters. failCounts and up are mutated in place to track
+// consecutive failures per cluster and the host each cluster was last
+// seen answering on.
+func (s *Service) checkClusters(ctx context.Context, dialer net.Dialer, failCounts map[string]int, up map[string]string) {
+	snapshot := s.ListClusters()
 
-	// Clean up failCounts for clusters that are no longer registered.
+	// Clean up state for clusters that are no longer registered.
 	for name := range failCounts {
 		if _, ok := snapshot[name]; !ok {
 			delete(failCounts, name)
 		}
 	}
+	for name := range up {
+		if _, ok := snapshot[name]; !ok {
+			delete(up, name)
+		}
+	}
 
-	for cluster, host := range snapshot {
-		addr := net.JoinHostPort(host, strconv.Itoa(tunnelPort))
+	for cluster, entry := range snapshot {
+		host := entry.Host
+		addr := net.JoinHostPort(host, strconv.Itoa(s.port))
 		conn, err := dialer.DialContext(ctx, "tcp", addr)
 		if err == nil {
 			if closeErr := conn.Close(); closeErr != nil {
@@ -106,6 +115,15 @@ func (s *Service) checkClusters(ctx context.Context, dialer net.Dialer, failCoun
 				s.log.Debug("cluster recovered", "cluster", cluster)
 			}
 			delete(failCounts, cluster)
+			if up[cluster] != host {
+				up[cluster] = host
+				tunnel.LogLifecycle(s.log, tunnel.LifecycleEvent{
+					Event:    tunnel.EventConnected,
+					Cluster:  cluster,
+					AgentID:  entry.User,
+					Endpoint: addr,
+				})
+			}
 			continue
 		}
 
@@ -123,20 +141,14 @@ func (s *Service) checkClusters(ctx context.Context, dialer net.Dialer, failCoun
 		)
 
 		if failCounts[cluster] >= healthFailThreshold {
-			// Verify the host hasn't changed since the snapshot was
-			// taken. A concurrent re-registration would assign a new
-			// host; deregistering in that case would be incorrect.
-			s.mu.RLock()
-			current, exists := s.clusters[cluster]
-			s.mu.RUnlock()
-			if exists && current.Host == host {
-				s.log.Info("deregistering disconnected cluster",
-					"cluster", cluster,
-					"consecutive_failures", failCounts[cluster],
-				)
-				s.DeregisterCluster(cluster)
-			}
+			// Only deregister if the host hasn't changed since the
+			// snapshot was taken. A concurrent re-registration would
+			// assign a new host; deregistering in that case would be
+			// incorrect.
+			reason := fmt.Sprintf("%d consecutive health probes failed: %v", failCounts[cluster], err)
+			s.deregister(cluster, reason, false, func(c core.Cluster) bool { return c.Host == host })
 			delete(failCounts, cluster)
+			delete(up, cluster)
 		}
 	}
 }
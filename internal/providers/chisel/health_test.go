@@ -0,0 +1,56 @@
+package chisel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckClusters_LogsDisconnectOnDeregistration(t *testing.T) {
+	s := newTestService(t)
+	var buf bytes.Buffer
+	s.log = slog.New(slog.NewJSONHandler(&buf, nil))
+
+	ctx := context.Background()
+	endpoint, _, err := s.RegisterCluster(ctx, "edge-1", "agent-a", "test", generateCSR(t, "agent-a"))
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	// Nothing listens on the cluster's endpoint, so every probe fails.
+	dialer := net.Dialer{Timeout: 100 * time.Millisecond}
+	failCounts, up := make(map[string]int), make(map[string]string)
+	for range healthFailThreshold {
+		s.checkClusters(ctx, dialer, failCounts, up)
+	}
+	if _, err := s.ResolveAddress(ctx, "edge-1"); err == nil {
+		t.Fatal("expected the cluster to be deregistered")
+	}
+
+	var disconnects []map[string]any
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var record map[string]any
+		if err := dec.Decode(&record); err != nil {
+			t.Fatalf("decode log record: %v", err)
+		}
+		if record["event"] == "tunnel_disconnected" {
+			disconnects = append(disconnects, record)
+		}
+	}
+	if len(disconnects) != 1 {
+		t.Fatalf("got %d tunnel_disconnected records, want 1", len(disconnects))
+	}
+	e := disconnects[0]
+	if e["cluster"] != "edge-1" || e["agent_id"] != "agent-a" || e["endpoint"] != endpoint {
+		t.Errorf("record = %v, want cluster edge-1, agent_id agent-a, endpoint %s", e, endpoint)
+	}
+	if reason, _ := e["reason"].(string); !strings.Contains(reason, "3 consecutive health probes failed") {
+		t.Errorf("reason = %q, want the probe failure count", reason)
+	}
+}
@@ -0,0 +1,52 @@
+package chisel
+
+import "sync"
+
+// keyedMutex provides one mutex per key so that operations on the
+// same cluster are serialised while unrelated clusters proceed in
+// parallel. Entries are reference-counted and removed once the last
+// holder unlocks, so the map does not grow with every cluster name
+// ever seen.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*refMutex
+}
+
+// refMutex is a mutex together with the number of goroutines holding
+// or waiting for it.
+type refMutex struct {
+	sync.Mutex
+	refs int
+}
+
+func newKeyedMutex() *keyedMutex {
+	return &keyedMutex{
+		locks: make(map[string]*refMutex),
+	}
+}
+
+// lock acquires the mutex for key and returns a function that
+// releases it.
+func (k *keyedMutex) lock(key string) (unlock func()) {
+	k.mu.Lock()
+	m, ok := k.locks[key]
+	if !ok {
+		m = &refMutex{}
+		k.locks[key] = m
+	}
+	m.refs++
+	k.mu.Unlock()
+
+	m.Lock()
+
+	return func() {
+		m.Unlock()
+
+		k.mu.Lock()
+		m.refs--
+		if m.refs == 0 {
+			delete(k.locks, key)
+		}
+		k.mu.Unlock()
+	}
+}
@@ -0,0 +1,112 @@
+package chisel
+
+import "time"
+
+// reconcileInterval is how often the health check loop looks for
+// chisel users and loopback hosts that no registered cluster refers
+// to.
+const reconcileInterval = 5 * time.Minute
+
+// reconcile removes chisel users and loopback hosts that no
+// registered cluster refers to, such as those left behind by a
+// registration that failed halfway. It returns the number of users
+// and hosts removed.
+//
+// Candidates are collected from a snapshot and each is re-checked
+// under its cluster's lock before removal, so that the user and host
+// of a registration still in progress are never mistaken for orphans.
+func (s *Service) reconcile() (users, hosts int) {
+	srv := s.server.Load()
+
+	s.mu.RLock()
+	inUseUsers := make(map[string]bool, len(s.clusters))
+	inUseHosts := make(map[string]bool, len(s.clusters))
+	for _, c := range s.clusters {
+		inUseUsers[c.User] = true
+		inUseHosts[c.Host] = true
+	}
+	orphanUsers := make(map[string]string)
+	for user, cluster := range s.users {
+		if !inUseUsers[user] {
+			orphanUsers[user] = cluster
+		}
+	}
+	orphanHosts := make(map[string]string)
+	for host, cluster := range s.addrs.usedHosts {
+		if !inUseHosts[host] {
+			orphanHosts[host] = cluster
+		}
+	}
+	s.mu.RUnlock()
+
+	if srv != nil {
+		for user, cluster := range orphanUsers {
+			if s.removeOrphanUser(cluster, user) {
+				users++
+			}
+		}
+	}
+	for host, cluster := range orphanHosts {
+		if s.removeOrphanHost(cluster, host) {
+			hosts++
+		}
+	}
+	return users, hosts
+}
+
+// removeOrphanUser deletes user from chisel if, under the lock of the
+// cluster it was added for, no registered cluster refers to it.
+func (s *Service) removeOrphanUser(cluster, user string) bool {
+	unlock := s.clusterLocks.lock(cluster)
+	defer unlock()
+
+	s.mu.RLock()
+	owner, tracked := s.users[user]
+	orphan := tracked && owner == cluster && !s.userInUse(user)
+	s.mu.RUnlock()
+	if !orphan {
+		return false
+	}
+
+	s.deleteUser(s.server.Load(), user)
+	s.log.Warn("removed orphaned tunnel user", "cluster", cluster, "agent_id", user)
+	return true
+}
+
+// removeOrphanHost releases host if, under the lock of the cluster it
+// was allocated for, no registered cluster refers to it.
+func (s *Service) removeOrphanHost(cluster, host string) bool {
+	unlock := s.clusterLocks.lock(cluster)
+	defer unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if owner, ok := s.addrs.usedHosts[host]; !ok || owner != cluster || s.hostInUse(host) {
+		return false
+	}
+	s.addrs.release(host)
+	s.log.Warn("released orphaned tunnel host", "cluster", cluster, "host", host)
+	return true
+}
+
+// userInUse reports whether a registered cluster refers to user. It
+// must be called with mu held.
+func (s *Service) userInUse(user string) bool {
+	for _, c := range s.clusters {
+		if c.User == user {
+			return true
+		}
+	}
+	return false
+}
+
+// hostInUse reports whether a registered cluster refers to host. It
+// must be called with mu held.
+func (s *Service) hostInUse(host string) bool {
+	for _, c := range s.clusters {
+		if c.Host == host {
+			return true
+		}
+	}
+	return false
+}
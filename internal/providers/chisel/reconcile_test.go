@@ -0,0 +1,60 @@
+package chisel
+
+import (
+	"context"
+	"testing"
+)
+
+func TestReconcile_RemovesOrphanedUserAndHost(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	if _, _, err := s.RegisterCluster(ctx, "edge-1", "agent-a", "test", generateCSR(t, "agent-a")); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	// Simulate a registration that added its user and host but failed
+	// before recording the cluster.
+	if err := s.addUser(s.server.Load(), "ghost", "agent-ghost", "secret", "^R:127.9.9.9:16598$"); err != nil {
+		t.Fatalf("add orphaned user: %v", err)
+	}
+	s.mu.Lock()
+	orphanHost, err := s.addrs.allocate("ghost")
+	s.mu.Unlock()
+	if err != nil {
+		t.Fatalf("allocate orphaned host: %v", err)
+	}
+
+	users, hosts := s.reconcile()
+	if users != 1 || hosts != 1 {
+		t.Fatalf("reconcile removed %d users and %d hosts, want 1 and 1", users, hosts)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if _, ok := s.users["agent-ghost"]; ok {
+		t.Error("orphaned user was not removed")
+	}
+	if _, ok := s.addrs.usedHosts[orphanHost]; ok {
+		t.Error("orphaned host was not released")
+	}
+	entry := s.clusters["edge-1"]
+	if _, ok := s.users[entry.User]; !ok {
+		t.Error("user of a registered cluster was removed")
+	}
+	if _, ok := s.addrs.usedHosts[entry.Host]; !ok {
+		t.Error("host of a registered cluster was released")
+	}
+}
+
+func TestReconcile_NoOrphans(t *testing.T) {
+	s := newTestService(t)
+	if _, _, err := s.RegisterCluster(context.Background(), "edge-1", "agent-a", "test", generateCSR(t, "agent-a")); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	s.DeregisterCluster("edge-1")
+
+	if users, hosts := s.reconcile(); users != 0 || hosts != 0 {
+		t.Errorf("reconcile removed %d users and %d hosts, want none", users, hosts)
+	}
+}
@@ -7,6 +7,7 @@ package chisel
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"maps"
@@ -14,16 +15,50 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	chserver "github.com/jpillora/chisel/server"
 
 	"github.com/otterscale/otterscale-agent/internal/core"
 	"github.com/otterscale/otterscale-agent/internal/pki"
+	"github.com/otterscale/otterscale-agent/internal/transport/tunnel"
 )
 
-// tunnelPort is the fixed port shared by all cluster tunnels.
-// Each cluster is differentiated by its loopback host, not its port.
-const tunnelPort = 16598
+// DefaultPort is the port shared by all cluster tunnels unless
+// Config.Port overrides it. Each cluster is differentiated by its
+// loopback host, not its port.
+const DefaultPort = 16598
+
+// Config holds the tunnel settings of a Service.
+type Config struct {
+	// Port is the loopback port of every cluster's tunnel endpoint.
+	// Zero selects DefaultPort.
+	Port int
+	// MaxClusters caps the number of registered clusters. Zero means
+	// no cap beyond the loopback address space.
+	MaxClusters int
+	// TLSMinVersion is the minimum TLS version accepted from agents.
+	// Zero means TLS 1.2.
+	TLSMinVersion uint16
+	// TLSCipherSuites restricts the TLS 1.2 cipher suites accepted
+	// from agents. Empty means Go's secure defaults.
+	TLSCipherSuites []uint16
+	// DrainTimeout is how long DeregisterCluster lets requests in
+	// flight through the cluster's tunnel finish, refusing new ones,
+	// before deleting its user and releasing its host. Long-running
+	// streams such as watches are not waited for; they end with the
+	// tunnel. Zero tears the tunnel down immediately.
+	DrainTimeout time.Duration
+	// Jitter varies the intervals of the health check and reconcile
+	// loops. Zero keeps them fixed.
+	Jitter core.Jitter
+}
+
+// tlsPolicy holds the TLS restrictions of the tunnel listener.
+type tlsPolicy struct {
+	minVersion   uint16
+	cipherSuites []uint16
+}
 
 // maxHosts is the total number of unique loopback addresses available
 // in the range 127.1.1.1 – 127.254.254.254 (octets 0 and 255 are
@@ -34,26 +69,66 @@ const maxHosts = 254 * 254 * 254
 // loopback addresses, and provisions chisel users for each agent.
 // It implements core.TunnelProvider and transport.TunnelService.
 type Service struct {
-	server atomic.Pointer[chserver.Server]
-	ca     *pki.CA
-	log    *slog.Logger
-	addrs  *addressAllocator
+	server   atomic.Pointer[chserver.Server]
+	ca       *pki.CA
+	notifier core.FleetNotifier
+	port     int
+	max      int
+	tls      tlsPolicy
+	log      *slog.Logger
+	addrs    *addressAllocator
+	drain    time.Duration
+	jitter   core.Jitter
 
+	// clusterLocks serialises the whole release-allocate-adduser
+	// sequence per cluster so that concurrent registrations of the
+	// same cluster cannot interleave, without blocking registrations
+	// of unrelated clusters on CSR signing or chisel user setup.
+	clusterLocks *keyedMutex
+
+	// mu guards clusters, requests, users and addrs. It is only held
+	// for map and allocator updates, never across calls into chisel or
+	// the CA.
 	mu       sync.RWMutex
 	clusters map[string]core.Cluster // cluster name -> tunnel state
+	// requests tracks the requests in flight through each cluster's
+	// current registration; see TrackRequest.
+	requests map[string]*requestGroup
+	// users mirrors the users added to chisel, which cannot list
+	// them, so that reconcile can find users no cluster refers to.
+	users map[string]string // chisel user -> cluster it was added for
+	// onRegister is called with the cluster name after every
+	// successful registration; see OnRegister.
+	onRegister func(cluster string)
 }
 
 // NewService returns a new Service backed by chisel. The CA is
 // required for signing agent CSRs and must be provided at
-// construction time (dependency injection).
+// construction time (dependency injection). notifier, if not nil, is
+// told about every registration and deregistration. Tunnel endpoints
+// are allocated on conf.Port.
 // The underlying chisel server is lazily initialized by the tunnel
 // transport layer; see tunnel.NewServer.
-func NewService(ca *pki.CA) *Service {
+func NewService(ca *pki.CA, notifier core.FleetNotifier, conf Config) *Service {
+	port := conf.Port
+	if port == 0 {
+		port = DefaultPort
+	}
 	return &Service{
 		ca:       ca,
+		notifier: notifier,
+		port:     port,
+		max:      conf.MaxClusters,
+		tls:      tlsPolicy{minVersion: conf.TLSMinVersion, cipherSuites: conf.TLSCipherSuites},
+		drain:    conf.DrainTimeout,
+		jitter:   conf.Jitter,
 		log:      slog.Default().With("component", "tunnel-provider"),
 		addrs:    newAddressAllocator(),
 		clusters: make(map[string]core.Cluster),
+		requests: make(map[string]*requestGroup),
+		users:    make(map[string]string),
+
+		clusterLocks: newKeyedMutex(),
 	}
 }
 
@@ -80,6 +155,16 @@ func (s *Service) CACertPEM() []byte {
 	return s.ca.CertPEM()
 }
 
+// Fingerprint returns the SSH host key fingerprint of the tunnel
+// server, or an empty string if the server has not been initialized.
+func (s *Service) Fingerprint() string {
+	srv := s.server.Load()
+	if srv == nil {
+		return ""
+	}
+	return srv.GetFingerprint()
+}
+
 // ListClusters returns the names of all currently registered clusters.
 func (s *Service) ListClusters() map[string]core.Cluster {
 	s.mu.RLock()
@@ -95,10 +180,15 @@ func (s *Service) ListClusters() map[string]core.Cluster {
 //
 // If the cluster was previously registered, the old host allocation
 // is released first so that re-registration always moves the cluster
-// to a fresh address.
+// to a fresh address. Concurrent registrations of the same cluster
+// are serialised; the last one to complete wins and every earlier
+// allocation is released.
 func (s *Service) RegisterCluster(ctx context.Context, cluster, agentID, agentVersion string, csrPEM []byte) (string, []byte, error) {
 	// Sign the agent's CSR with the internal CA.
 	certPEM, err := s.ca.SignCSR(csrPEM)
+	if errors.Is(err, pki.ErrKeyRejected) {
+		return "", nil, &core.ErrInvalidInput{Field: "csr", Message: err.Error()}
+	}
 	if err != nil {
 		return "", nil, fmt.Errorf("sign CSR: %w", err)
 	}
@@ -119,73 +209,226 @@ func (s *Service) RegisterCluster(ctx context.Context, cluster, agentID, agentVe
 		return "", nil, &core.ErrNotReady{Subsystem: "chisel server"}
 	}
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	unlock := s.clusterLocks.lock(cluster)
+	defer unlock()
 
 	// Release the previous host and user for this cluster, if any,
 	// so that stale credentials do not accumulate in chisel.
-	if prev, ok := s.clusters[cluster]; ok {
-		srv.DeleteUser(prev.User)
+	s.mu.Lock()
+	prev, hadPrev := s.clusters[cluster]
+	// Allocated hosts include registrations still in progress, so
+	// concurrent registrations cannot overshoot the cap together.
+	// A cluster registering again does not count against it.
+	if !hadPrev && s.max > 0 && len(s.addrs.usedHosts) >= s.max {
+		s.mu.Unlock()
+		return "", nil, &core.DomainError{
+			Code:    core.ErrorCodeResourceExhausted,
+			Message: fmt.Sprintf("cannot register cluster %q: the fleet is limited to %d clusters", cluster, s.max),
+		}
+	}
+	if hadPrev {
 		s.addrs.release(prev.Host)
 		delete(s.clusters, cluster)
+		delete(s.requests, cluster)
 	}
-
 	host, err := s.addrs.allocate(cluster)
+	s.mu.Unlock()
+
+	if hadPrev {
+		s.deleteUser(srv, prev.User)
+	}
 	if err != nil {
 		return "", nil, err
 	}
 
+	// Until the new entry is committed, undo whatever was set up on
+	// any early return so that neither the host nor the user leaks.
+	committed, userAdded := false, false
+	defer func() {
+		if committed {
+			return
+		}
+		if userAdded {
+			s.deleteUser(srv, agentID)
+		}
+		s.mu.Lock()
+		s.addrs.release(host)
+		s.mu.Unlock()
+	}()
+
 	// Restrict the user to reverse-tunnelling only the allocated
 	// host:port combination. The regex anchors prevent the agent
 	// from binding arbitrary endpoints.
-	allowed := fmt.Sprintf("^R:%s:%d(:.*)?$", regexp.QuoteMeta(host), tunnelPort)
-	if err := srv.AddUser(agentID, pass, allowed); err != nil {
-		s.addrs.release(host)
+	allowed := fmt.Sprintf("^R:%s:%d(:.*)?$", regexp.QuoteMeta(host), s.port)
+	if err := s.addUser(srv, cluster, agentID, pass, allowed); err != nil {
 		return "", nil, err
 	}
+	userAdded = true
 
+	s.mu.Lock()
 	s.clusters[cluster] = core.Cluster{
 		Host:         host,
 		User:         agentID,
 		AgentVersion: agentVersion,
 	}
+	s.requests[cluster] = newRequestGroup()
+	committed = true
+	onRegister := s.onRegister
+	s.mu.Unlock()
 
-	return fmt.Sprintf("%s:%d", host, tunnelPort), certPEM, nil
+	// The allocator may hand the cluster its previous host again, so
+	// clients cannot rely on a changed address to notice that their
+	// connections are stale.
+	if onRegister != nil {
+		onRegister(cluster)
+	}
+
+	endpoint := fmt.Sprintf("%s:%d", host, s.port)
+	var reason string
+	if hadPrev {
+		reason = "agent registered again"
+		if prev.User != agentID {
+			reason = fmt.Sprintf("replaces agent %s", prev.User)
+		}
+		tunnel.LogLifecycle(s.log, tunnel.LifecycleEvent{
+			Event:    tunnel.EventReregistered,
+			Cluster:  cluster,
+			AgentID:  agentID,
+			Endpoint: endpoint,
+			Reason:   reason,
+		})
+	}
+	s.notify(core.FleetEvent{
+		Event:        core.FleetEventRegistered,
+		Cluster:      cluster,
+		AgentID:      agentID,
+		AgentVersion: agentVersion,
+		Endpoint:     endpoint,
+		Reason:       reason,
+	})
+	return endpoint, certPEM, nil
+}
+
+// OnRegister sets fn to be called with the cluster name after every
+// successful registration, including an agent reconnecting after an
+// outage, while the cluster's registrations are still serialised. It
+// lets clients of the tunnel drop state tied to the previous endpoint,
+// such as pooled connections. fn must not call back into s.
+func (s *Service) OnRegister(fn func(cluster string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onRegister = fn
 }
 
 // DeregisterCluster removes a cluster's tunnel allocation, deleting
-// the chisel user and releasing the loopback host. It is a no-op if
-// the cluster is not currently registered.
+// the chisel user and releasing the loopback host. New requests are
+// refused at once, but those in flight are given up to the configured
+// drain timeout to finish first. It is a no-op if the cluster is not
+// currently registered.
 func (s *Service) DeregisterCluster(cluster string) {
+	s.deregister(cluster, "deregistered", true, func(core.Cluster) bool { return true })
+}
+
+// deregister removes the cluster's tunnel allocation if match reports
+// true for its current entry, logging the disconnection with reason.
+// If drain is set, requests in flight are drained first. The check
+// and the removal happen under the cluster lock, so a concurrent
+// re-registration cannot slip in between them. It reports whether the
+// cluster was removed.
+func (s *Service) deregister(cluster, reason string, drain bool, match func(core.Cluster) bool) bool {
 	srv := s.server.Load()
 	if srv == nil {
-		return
+		return false
 	}
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	unlock := s.clusterLocks.lock(cluster)
+	defer unlock()
+
+	if drain {
+		s.mu.RLock()
+		entry, ok := s.clusters[cluster]
+		s.mu.RUnlock()
+		if ok && match(entry) {
+			s.drainRequests(cluster)
+		}
+	}
 
+	s.mu.Lock()
 	entry, ok := s.clusters[cluster]
-	if !ok {
+	if ok && match(entry) {
+		s.addrs.release(entry.Host)
+		delete(s.clusters, cluster)
+		delete(s.requests, cluster)
+	} else {
+		ok = false
+	}
+	s.mu.Unlock()
+
+	if ok {
+		s.deleteUser(srv, entry.User)
+		endpoint := fmt.Sprintf("%s:%d", entry.Host, s.port)
+		tunnel.LogLifecycle(s.log, tunnel.LifecycleEvent{
+			Event:    tunnel.EventDisconnected,
+			Cluster:  cluster,
+			AgentID:  entry.User,
+			Endpoint: endpoint,
+			Reason:   reason,
+		})
+		s.notify(core.FleetEvent{
+			Event:        core.FleetEventDeregistered,
+			Cluster:      cluster,
+			AgentID:      entry.User,
+			AgentVersion: entry.AgentVersion,
+			Endpoint:     endpoint,
+			Reason:       reason,
+		})
+	}
+	return ok
+}
+
+// notify stamps e with the current time and hands it to the fleet
+// notifier, if one is configured.
+func (s *Service) notify(e core.FleetEvent) {
+	if s.notifier == nil {
 		return
 	}
-	srv.DeleteUser(entry.User)
-	s.addrs.release(entry.Host)
-	delete(s.clusters, cluster)
+	e.Time = time.Now().UTC()
+	s.notifier.Notify(e)
+}
+
+// addUser adds a chisel user on behalf of cluster and records it in
+// users.
+func (s *Service) addUser(srv *chserver.Server, cluster, user, pass, allowed string) error {
+	if err := srv.AddUser(user, pass, allowed); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.users[user] = cluster
+	s.mu.Unlock()
+	return nil
+}
+
+// deleteUser removes a chisel user and its record in users.
+func (s *Service) deleteUser(srv *chserver.Server, user string) {
+	srv.DeleteUser(user)
+	s.mu.Lock()
+	delete(s.users, user)
+	s.mu.Unlock()
 }
 
 // ResolveAddress returns the HTTP base URL for the given cluster's
-// tunnel endpoint. Returns an error if the cluster is not registered.
+// tunnel endpoint. Returns an error if the cluster is not registered
+// or is being deregistered.
 func (s *Service) ResolveAddress(ctx context.Context, cluster string) (string, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	entry, ok := s.clusters[cluster]
-	if !ok {
+	if !ok || s.requests[cluster].isDraining() {
 		return "", &core.ErrClusterNotFound{Cluster: cluster}
 	}
 
-	return fmt.Sprintf("http://%s:%d", entry.Host, tunnelPort), nil
+	return fmt.Sprintf("http://%s:%d", entry.Host, s.port), nil
 }
 
 // parseAuth splits a "user:pass" string into its components.
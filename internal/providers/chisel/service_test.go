@@ -0,0 +1,188 @@
+package chisel
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+	"github.com/otterscale/otterscale-agent/internal/pki"
+	"github.com/otterscale/otterscale-agent/internal/transport/tunnel"
+)
+
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+	return newTestServiceWithConfig(t, Config{})
+}
+
+func newTestServiceWithConfig(t *testing.T, conf Config) *Service {
+	t.Helper()
+	ca, err := pki.NewCA()
+	if err != nil {
+		t.Fatalf("create CA: %v", err)
+	}
+	s := NewService(ca, nil, conf)
+
+	srv, err := tunnel.NewServer(tunnel.WithServer(s.ServerRef()))
+	if err != nil {
+		t.Fatalf("init tunnel server: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = srv.Stop(context.Background())
+	})
+	return s
+}
+
+func generateCSR(t *testing.T, cn string) []byte {
+	t.Helper()
+	key, _, err := pki.GenerateKey()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	csr, err := pki.GenerateCSR(key, cn)
+	if err != nil {
+		t.Fatalf("generate CSR: %v", err)
+	}
+	return csr
+}
+
+func TestRegisterCluster_ConcurrentSameCluster(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	const n = 16
+	csrs := make([][]byte, n)
+	for i := range n {
+		csrs[i] = generateCSR(t, fmt.Sprintf("agent-%d", i))
+	}
+
+	var wg sync.WaitGroup
+	endpoints := make([]string, n)
+	errs := make([]error, n)
+	for i := range n {
+		wg.Go(func() {
+			endpoints[i], _, errs[i] = s.RegisterCluster(ctx, "racy", fmt.Sprintf("agent-%d", i), "test", csrs[i])
+		})
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("register #%d: %v", i, err)
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if got := len(s.addrs.usedHosts); got != 1 {
+		t.Fatalf("expected exactly 1 allocated host, got %d", got)
+	}
+	entry, ok := s.clusters["racy"]
+	if !ok {
+		t.Fatal("expected cluster to remain registered")
+	}
+	if _, ok := s.addrs.usedHosts[entry.Host]; !ok {
+		t.Errorf("registered host %q is not the allocated host", entry.Host)
+	}
+	if want := fmt.Sprintf("%s:%d", entry.Host, DefaultPort); !slices.Contains(endpoints, want) {
+		t.Errorf("surviving endpoint %q was not returned by any registration", want)
+	}
+}
+
+func TestDeregister_SkipsReassignedHost(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	if _, _, err := s.RegisterCluster(ctx, "moved", "agent-a", "test", generateCSR(t, "agent-a")); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	removed := s.deregister("moved", "test", false, func(c core.Cluster) bool { return c.Host == "127.0.0.0" })
+	if removed {
+		t.Fatal("expected deregister to skip a cluster whose host changed")
+	}
+	if _, err := s.ResolveAddress(ctx, "moved"); err != nil {
+		t.Fatalf("expected cluster to remain registered: %v", err)
+	}
+}
+
+func TestRegisterCluster_CallsOnRegister(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	var registered []string
+	s.OnRegister(func(cluster string) {
+		// The new endpoint is resolvable by the time the hook runs.
+		if _, err := s.ResolveAddress(ctx, cluster); err != nil {
+			t.Errorf("resolve %s from hook: %v", cluster, err)
+		}
+		registered = append(registered, cluster)
+	})
+
+	if _, _, err := s.RegisterCluster(ctx, "edge-1", "agent-a", "test", generateCSR(t, "agent-a")); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	// The agent reconnects after an outage.
+	if _, _, err := s.RegisterCluster(ctx, "edge-1", "agent-a", "test", generateCSR(t, "agent-a")); err != nil {
+		t.Fatalf("re-register: %v", err)
+	}
+	if want := []string{"edge-1", "edge-1"}; !slices.Equal(registered, want) {
+		t.Errorf("OnRegister calls = %v, want %v", registered, want)
+	}
+
+	// A failed registration is not reported.
+	if _, _, err := s.RegisterCluster(ctx, "edge-2", "agent-b", "test", []byte("not a CSR")); err == nil {
+		t.Fatal("expected an invalid CSR to be rejected")
+	}
+	if len(registered) != 2 {
+		t.Errorf("OnRegister calls = %v after a failed registration", registered)
+	}
+}
+
+func TestRegisterCluster_UsesConfiguredPort(t *testing.T) {
+	s := newTestServiceWithConfig(t, Config{Port: 17001})
+	ctx := context.Background()
+
+	endpoint, _, err := s.RegisterCluster(ctx, "edge-1", "agent-a", "test", generateCSR(t, "agent-a"))
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if !strings.HasSuffix(endpoint, ":17001") {
+		t.Errorf("endpoint = %q, want port 17001", endpoint)
+	}
+	addr, err := s.ResolveAddress(ctx, "edge-1")
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if addr != "http://"+endpoint {
+		t.Errorf("resolved address = %q, want http://%s", addr, endpoint)
+	}
+}
+
+func TestRegisterCluster_RejectsClustersBeyondLimit(t *testing.T) {
+	s := newTestServiceWithConfig(t, Config{MaxClusters: 2})
+	ctx := context.Background()
+
+	for _, cluster := range []string{"edge-1", "edge-2"} {
+		if _, _, err := s.RegisterCluster(ctx, cluster, "agent-"+cluster, "test", generateCSR(t, "agent-"+cluster)); err != nil {
+			t.Fatalf("register %s: %v", cluster, err)
+		}
+	}
+
+	_, _, err := s.RegisterCluster(ctx, "edge-3", "agent-edge-3", "test", generateCSR(t, "agent-edge-3"))
+	if code, ok := core.DomainErrorCode(err); !ok || code != core.ErrorCodeResourceExhausted {
+		t.Fatalf("third cluster: error = %v, want a DomainError with ErrorCodeResourceExhausted", err)
+	}
+	if _, err := s.ResolveAddress(ctx, "edge-3"); err == nil {
+		t.Error("rejected cluster must not be registered")
+	}
+
+	// Re-registering an existing cluster does not count against the cap.
+	if _, _, err := s.RegisterCluster(ctx, "edge-1", "agent-edge-1", "test", generateCSR(t, "agent-edge-1")); err != nil {
+		t.Fatalf("re-register edge-1: %v", err)
+	}
+}
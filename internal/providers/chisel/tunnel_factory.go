@@ -45,13 +45,15 @@ func (s *Service) BuildTunnelListener(address, host string) (transport.Listener,
 		return nil, fmt.Errorf("write server key: %w", err)
 	}
 
-	slog.Info("tunnel CA initialized", "subject", "otterscale-ca")
+	slog.Info("tunnel CA initialized", "subject", s.ca.Subject())
 
 	tunnelSrv, err := tunnel.NewServer(
 		tunnel.WithAddress(address),
 		tunnel.WithTLSCert(certFile),
 		tunnel.WithTLSKey(keyFile),
 		tunnel.WithTLSCA(caFile),
+		tunnel.WithTLSMinVersion(s.tls.minVersion),
+		tunnel.WithTLSCipherSuites(s.tls.cipherSuites),
 		tunnel.WithServer(s.ServerRef()),
 	)
 	if err != nil {
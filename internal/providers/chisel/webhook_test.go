@@ -0,0 +1,60 @@
+package chisel
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+	"github.com/otterscale/otterscale-agent/internal/providers/webhook"
+)
+
+func TestDeregisterCluster_NotifiesWebhook(t *testing.T) {
+	events := make(chan core.FleetEvent, 4)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e core.FleetEvent
+		if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+			t.Errorf("decode event: %v", err)
+		}
+		events <- e
+	}))
+	defer receiver.Close()
+
+	s := newTestService(t)
+	s.notifier = webhook.NewNotifier(receiver.URL, receiver.Client())
+
+	endpoint, _, err := s.RegisterCluster(context.Background(), "edge-1", "agent-a", "v1.2.3", generateCSR(t, "agent-a"))
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if e := nextEvent(t, events); e.Event != core.FleetEventRegistered {
+		t.Fatalf("first event = %q, want %q", e.Event, core.FleetEventRegistered)
+	}
+
+	s.DeregisterCluster("edge-1")
+
+	e := nextEvent(t, events)
+	if e.Event != core.FleetEventDeregistered {
+		t.Fatalf("event = %q, want %q", e.Event, core.FleetEventDeregistered)
+	}
+	if e.Cluster != "edge-1" || e.AgentID != "agent-a" || e.AgentVersion != "v1.2.3" || e.Endpoint != endpoint {
+		t.Errorf("event = %+v, want cluster edge-1, agent agent-a v1.2.3 at %s", e, endpoint)
+	}
+	if e.Reason != "deregistered" || e.Time.IsZero() {
+		t.Errorf("event = %+v, want reason deregistered and a timestamp", e)
+	}
+}
+
+func nextEvent(t *testing.T, events <-chan core.FleetEvent) core.FleetEvent {
+	t.Helper()
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("no webhook event received")
+		return core.FleetEvent{}
+	}
+}
@@ -0,0 +1,217 @@
+// Package helm provides the core.HelmRenderer implementation, which
+// renders packaged Helm charts with the embedded Helm SDK, without
+// access to any cluster.
+package helm
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	chartcommon "helm.sh/helm/v4/pkg/chart/common"
+	chartutil "helm.sh/helm/v4/pkg/chart/common/util"
+	chartv2 "helm.sh/helm/v4/pkg/chart/v2"
+	"helm.sh/helm/v4/pkg/chart/v2/loader"
+	chartv2util "helm.sh/helm/v4/pkg/chart/v2/util"
+	"helm.sh/helm/v4/pkg/engine"
+	releaseutil "helm.sh/helm/v4/pkg/release/v1/util"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// DefaultMaxDecompressedBytes caps the size of a chart archive once
+// decompressed, guarding against archives that expand far beyond
+// their upload size.
+const DefaultMaxDecompressedBytes = 32 << 20 // 32 MiB
+
+// DefaultMaxRenderedBytes caps the size of the rendered manifest.
+const DefaultMaxRenderedBytes = core.DefaultHelmRenderedMaxBytes
+
+// DefaultMaxConcurrentRenders caps the number of charts the Helm
+// engine renders at once.
+const DefaultMaxConcurrentRenders = 4
+
+// notesFile is the name of the chart template rendered into usage
+// notes rather than objects.
+const notesFile = "NOTES.txt"
+
+// Renderer implements core.HelmRenderer with the Helm SDK.
+type Renderer struct {
+	maxDecompressedBytes int64
+	maxRenderedBytes     int64
+	// renders holds a token for each run of the Helm engine, from its
+	// start until it returns, including runs whose caller gave up.
+	renders chan struct{}
+}
+
+// Verify at compile time that Renderer satisfies core.HelmRenderer.
+var _ core.HelmRenderer = (*Renderer)(nil)
+
+// NewRenderer returns a Renderer that rejects chart archives expanding
+// to more than maxDecompressedBytes and charts rendering to more than
+// maxRenderedBytes, and that runs the Helm engine for at most
+// maxConcurrentRenders charts at once. Zero means
+// DefaultMaxDecompressedBytes, DefaultMaxRenderedBytes and
+// DefaultMaxConcurrentRenders respectively.
+func NewRenderer(maxDecompressedBytes, maxRenderedBytes int64, maxConcurrentRenders int) *Renderer {
+	if maxDecompressedBytes <= 0 {
+		maxDecompressedBytes = DefaultMaxDecompressedBytes
+	}
+	if maxRenderedBytes <= 0 {
+		maxRenderedBytes = DefaultMaxRenderedBytes
+	}
+	if maxConcurrentRenders <= 0 {
+		maxConcurrentRenders = DefaultMaxConcurrentRenders
+	}
+	return &Renderer{
+		maxDecompressedBytes: maxDecompressedBytes,
+		maxRenderedBytes:     maxRenderedBytes,
+		renders:              make(chan struct{}, maxConcurrentRenders),
+	}
+}
+
+// RenderHelm renders chart as `helm template` does, with the chart's
+// CRDs and without hooks, tests or notes. Templates see the default
+// capabilities of the Helm SDK rather than those of a cluster.
+//
+// The Helm engine cannot be interrupted: when ctx is done, RenderHelm
+// returns but the engine runs on until it finishes. What bounds the
+// cost of slow charts is the number of engine runs, which is capped
+// whether or not their callers still wait; RenderHelm fails with
+// ErrorCodeResourceExhausted once the cap is reached. The engine
+// builds every template's output in memory, so the size limit only
+// rejects a large result before it is sorted and copied into the
+// manifest.
+func (r *Renderer) RenderHelm(ctx context.Context, chart, values []byte, release core.HelmRelease) ([]byte, error) {
+	if err := r.checkDecompressedSize(chart); err != nil {
+		return nil, err
+	}
+	ch, err := loader.LoadArchive(bytes.NewReader(chart))
+	if err != nil {
+		return nil, &core.ErrInvalidInput{Field: "chart", Message: err.Error()}
+	}
+	vals, err := chartcommon.ReadValues(values)
+	if err != nil {
+		return nil, &core.ErrInvalidInput{Field: "values", Message: err.Error()}
+	}
+	if err := chartv2util.ProcessDependencies(ch, vals); err != nil {
+		return nil, &core.ErrInvalidInput{Field: "chart", Message: err.Error()}
+	}
+	renderValues, err := chartutil.ToRenderValues(ch, vals, chartcommon.ReleaseOptions{
+		Name:      release.Name,
+		Namespace: release.Namespace,
+		Revision:  1,
+		IsInstall: true,
+	}, nil)
+	if err != nil {
+		return nil, &core.ErrInvalidInput{Field: "values", Message: err.Error()}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	files, err := r.render(ctx, ch, renderValues)
+	if err != nil {
+		return nil, err
+	}
+	var size int64
+	for name, content := range files {
+		if path.Base(name) == notesFile {
+			delete(files, name)
+			continue
+		}
+		size += int64(len(content))
+	}
+	for _, crd := range ch.CRDObjects() {
+		size += int64(len(crd.File.Data))
+	}
+	if size > r.maxRenderedBytes {
+		return nil, &core.DomainError{
+			Code:    core.ErrorCodeResourceExhausted,
+			Message: fmt.Sprintf("rendered chart is at least %d bytes, more than the limit of %d", size, r.maxRenderedBytes),
+		}
+	}
+	// Hooks, including tests, run at points of a release's lifecycle
+	// that a one-off apply does not have, so they are dropped.
+	_, manifests, err := releaseutil.SortManifests(files, nil, releaseutil.InstallOrder)
+	if err != nil {
+		return nil, &core.ErrInvalidInput{Field: "chart", Message: err.Error()}
+	}
+
+	var buf bytes.Buffer
+	for _, crd := range ch.CRDObjects() {
+		writeDocument(&buf, crd.Filename, string(crd.File.Data))
+	}
+	for _, m := range manifests {
+		writeDocument(&buf, m.Name, m.Content)
+	}
+	return buf.Bytes(), nil
+}
+
+// render runs the Helm engine on ch until it finishes or ctx is done.
+// The engine cannot be interrupted, so a rendering abandoned on ctx
+// finishes in the background and its result is discarded; it keeps
+// its slot in r.renders until then.
+func (r *Renderer) render(ctx context.Context, ch *chartv2.Chart, values chartcommon.Values) (map[string]string, error) {
+	select {
+	case r.renders <- struct{}{}:
+	default:
+		return nil, &core.DomainError{
+			Code:    core.ErrorCodeResourceExhausted,
+			Message: fmt.Sprintf("%d Helm charts are already rendering; retry later", cap(r.renders)),
+		}
+	}
+
+	type result struct {
+		files map[string]string
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		defer func() { <-r.renders }()
+		files, err := engine.Render(ch, values)
+		done <- result{files, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-done:
+		if res.err != nil {
+			return nil, &core.ErrInvalidInput{Field: "chart", Message: fmt.Sprintf("render: %v", res.err)}
+		}
+		return res.files, nil
+	}
+}
+
+// checkDecompressedSize rejects a gzipped chart archive that expands
+// to more than the configured limit, before the Helm loader buffers
+// its files in memory.
+func (r *Renderer) checkDecompressedSize(chart []byte) error {
+	zr, err := gzip.NewReader(bytes.NewReader(chart))
+	if err != nil {
+		return &core.ErrInvalidInput{Field: "chart", Message: fmt.Sprintf("not a gzipped chart archive: %v", err)}
+	}
+	defer zr.Close()
+	n, err := io.Copy(io.Discard, io.LimitReader(zr, r.maxDecompressedBytes+1))
+	if err != nil {
+		return &core.ErrInvalidInput{Field: "chart", Message: fmt.Sprintf("decompress chart: %v", err)}
+	}
+	if n > r.maxDecompressedBytes {
+		return &core.ErrInvalidInput{
+			Field:   "chart",
+			Message: fmt.Sprintf("must not exceed %d bytes once decompressed", r.maxDecompressedBytes),
+		}
+	}
+	return nil
+}
+
+// writeDocument appends content to buf as a YAML document, marked with
+// the template it was rendered from.
+func writeDocument(buf *bytes.Buffer, source, content string) {
+	fmt.Fprintf(buf, "---\n# Source: %s\n%s\n", source, strings.TrimSpace(content))
+}
@@ -0,0 +1,261 @@
+package helm
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// tinyChart is a chart with a ConfigMap and a Deployment template, a
+// helper template, notes and a test hook.
+var tinyChart = map[string]string{
+	"shop/Chart.yaml":  "apiVersion: v2\nname: shop\nversion: 0.1.0\n",
+	"shop/values.yaml": "replicas: 1\ngreeting: hello\n",
+	"shop/templates/_helpers.tpl": `{{- define "shop.fullname" -}}
+{{ .Release.Name }}-shop
+{{- end -}}`,
+	"shop/templates/configmap.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ include "shop.fullname" . }}
+data:
+  greeting: {{ .Values.greeting | quote }}
+  namespace: {{ .Release.Namespace }}`,
+	"shop/templates/deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ include "shop.fullname" . }}
+spec:
+  replicas: {{ .Values.replicas }}`,
+	"shop/templates/NOTES.txt": "Thanks for installing {{ .Release.Name }}.",
+	"shop/templates/tests/test.yaml": `apiVersion: v1
+kind: Pod
+metadata:
+  name: {{ .Release.Name }}-test
+  annotations:
+    helm.sh/hook: test`,
+}
+
+// packageChart returns files as a gzipped tar archive, as produced by
+// `helm package`.
+func packageChart(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}); err != nil {
+			t.Fatalf("write header: %v", err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("close tar: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("close gzip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// applyDiscovery serves ConfigMaps and Deployments.
+type applyDiscovery struct {
+	core.DiscoveryClient
+}
+
+func (applyDiscovery) ServerResources(context.Context, string) ([]*metav1.APIResourceList, []core.DiscoveryFailure, error) {
+	return []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "configmaps", Kind: "ConfigMap", Namespaced: true},
+		}},
+		{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{
+			{Name: "deployments", Kind: "Deployment", Namespaced: true},
+		}},
+	}, nil, nil
+}
+
+// applyRepo records the objects applied to it.
+type applyRepo struct {
+	core.ResourceRepo
+
+	mu      sync.Mutex
+	applied map[string]*unstructured.Unstructured // "resource/namespace/name"
+}
+
+func (r *applyRepo) Apply(_ context.Context, _ string, gvr schema.GroupVersionResource, namespace, name string, manifest []byte, _ core.ApplyOptions) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(manifest); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.applied[gvr.Resource+"/"+namespace+"/"+name] = obj
+	return obj, nil
+}
+
+func TestRenderer_AppliesRenderedChart(t *testing.T) {
+	repo := &applyRepo{applied: map[string]*unstructured.Unstructured{}}
+	resource := core.NewResourceUseCase(applyDiscovery{}, repo, nil, nil, nil, core.ApplyConfig{}, core.NamespaceConfig{})
+	uc := core.NewHelmChartUseCase(resource, NewRenderer(0, 0, 0), core.HelmChartConfig{})
+
+	err := uc.ApplyHelmChart(context.Background(), "edge-1", packageChart(t, tinyChart), []byte("replicas: 3\n"),
+		core.HelmRelease{Name: "web", Namespace: "apps"}, core.ApplyManifestOptions{},
+		func(core.ManifestEvent) error { return nil })
+	if err != nil {
+		t.Fatalf("ApplyHelmChart: %v", err)
+	}
+
+	// Neither the notes nor the test hook are applied.
+	keys := slices.Sorted(maps.Keys(repo.applied))
+	if want := []string{"configmaps/apps/web-shop", "deployments/apps/web-shop"}; !reflect.DeepEqual(keys, want) {
+		t.Fatalf("applied = %v, want %v", keys, want)
+	}
+	cm := repo.applied["configmaps/apps/web-shop"]
+	if got, _, _ := unstructured.NestedStringMap(cm.Object, "data"); got["greeting"] != "hello" || got["namespace"] != "apps" {
+		t.Errorf("configmap data = %v, want the chart's greeting and the release namespace", got)
+	}
+	deploy := repo.applied["deployments/apps/web-shop"]
+	if got, _, _ := unstructured.NestedInt64(deploy.Object, "spec", "replicas"); got != 3 {
+		t.Errorf("replicas = %d, want 3 from the values", got)
+	}
+}
+
+func TestRenderer_RenderHelm(t *testing.T) {
+	files := map[string]string{
+		"shop/Chart.yaml": "apiVersion: v2\nname: shop\nversion: 0.1.0\n",
+		"shop/crds/widgets.yaml": `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com`,
+		"shop/templates/widget.yaml": `apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: {{ .Release.Name }}`,
+	}
+	manifest, err := NewRenderer(0, 0, 0).RenderHelm(context.Background(), packageChart(t, files), nil, core.HelmRelease{Name: "web", Namespace: "apps"})
+	if err != nil {
+		t.Fatalf("RenderHelm: %v", err)
+	}
+	got := string(manifest)
+	crd, widget := strings.Index(got, "kind: CustomResourceDefinition"), strings.Index(got, "kind: Widget")
+	if crd < 0 || widget < crd {
+		t.Errorf("manifest does not list the CRD before the templates:\n%s", got)
+	}
+}
+
+func TestRenderer_RejectsInvalidCharts(t *testing.T) {
+	tests := []struct {
+		name     string
+		renderer *Renderer
+		chart    []byte
+		values   []byte
+		field    string
+	}{
+		{"not gzipped", NewRenderer(0, 0, 0), []byte("not a chart"), nil, "chart"},
+		{"expands beyond the limit", NewRenderer(1024, 0, 0), packageChart(t, map[string]string{
+			"shop/Chart.yaml":  "apiVersion: v2\nname: shop\nversion: 0.1.0\n",
+			"shop/values.yaml": "padding: " + strings.Repeat("x", 4096) + "\n",
+		}), nil, "chart"},
+		{"invalid values", NewRenderer(0, 0, 0), packageChart(t, tinyChart), []byte("replicas: [1"), "values"},
+		{"failing template", NewRenderer(0, 0, 0), packageChart(t, map[string]string{
+			"shop/Chart.yaml":          "apiVersion: v2\nname: shop\nversion: 0.1.0\n",
+			"shop/templates/fail.yaml": `{{ fail "boom" }}`,
+		}), nil, "chart"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.renderer.RenderHelm(context.Background(), tt.chart, tt.values, core.HelmRelease{Name: "web", Namespace: "apps"})
+			var invalid *core.ErrInvalidInput
+			if !errors.As(err, &invalid) || invalid.Field != tt.field {
+				t.Fatalf("err = %v, want invalid input on %s", err, tt.field)
+			}
+		})
+	}
+}
+
+func TestRenderer_BoundsRendering(t *testing.T) {
+	// loopChart renders a ConfigMap whose data grows with the loop
+	// count in values.
+	loopChart := packageChart(t, map[string]string{
+		"shop/Chart.yaml":  "apiVersion: v2\nname: shop\nversion: 0.1.0\n",
+		"shop/values.yaml": "count: 1\n",
+		"shop/templates/configmap.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: loop
+data:
+  padding: "{{ range until (int .Values.count) }}x{{ end }}"`,
+	})
+	release := core.HelmRelease{Name: "web", Namespace: "apps"}
+
+	_, err := NewRenderer(0, 1024, 0).RenderHelm(context.Background(), loopChart, []byte("count: 2048\n"), release)
+	if code, ok := core.DomainErrorCode(err); !ok || code != core.ErrorCodeResourceExhausted {
+		t.Errorf("oversized output: err = %v, want ErrorCodeResourceExhausted", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = NewRenderer(0, 0, 0).RenderHelm(ctx, loopChart, []byte("count: 50000000\n"), release)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("slow render: err = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("slow render returned after %s, want it abandoned when ctx is done", elapsed)
+	}
+}
+
+func TestRenderer_CapsConcurrentRenders(t *testing.T) {
+	loopChart := packageChart(t, map[string]string{
+		"shop/Chart.yaml":  "apiVersion: v2\nname: shop\nversion: 0.1.0\n",
+		"shop/values.yaml": "count: 1\n",
+		"shop/templates/configmap.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: loop
+data:
+  padding: "{{ range until (int .Values.count) }}x{{ end }}"`,
+	})
+	release := core.HelmRelease{Name: "web", Namespace: "apps"}
+	renderer := NewRenderer(0, 0, 1)
+
+	// An abandoned render keeps its slot until the engine returns.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := renderer.RenderHelm(ctx, loopChart, []byte("count: 20000000\n"), release); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("slow render: err = %v, want context.DeadlineExceeded", err)
+	}
+	_, err := renderer.RenderHelm(context.Background(), packageChart(t, tinyChart), nil, release)
+	if code, ok := core.DomainErrorCode(err); !ok || code != core.ErrorCodeResourceExhausted {
+		t.Fatalf("render while the slot is taken: err = %v, want ErrorCodeResourceExhausted", err)
+	}
+
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		_, err := renderer.RenderHelm(context.Background(), packageChart(t, tinyChart), nil, release)
+		if err == nil {
+			return
+		}
+		if code, _ := core.DomainErrorCode(err); code != core.ErrorCodeResourceExhausted || time.Now().After(deadline) {
+			t.Fatalf("render after the abandoned one finished: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,118 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// APIProxy serves the kube-API of each registered cluster, so that
+// kubectl can use the server as its API server. Requests are routed by
+// the "cluster" path value and forward the "path" path value, so it
+// must be mounted on a pattern like core.KubeAPIPathPrefix +
+// "{cluster}/{path...}".
+//
+// Every request is authorised and impersonated like the typed RPCs,
+// so RBAC on the cluster applies to the calling user. The caller's
+// credentials and any impersonation headers they set are never
+// forwarded.
+type APIProxy struct {
+	kubernetes *Kubernetes
+}
+
+// NewAPIProxy returns an APIProxy that reaches clusters through
+// kubernetes.
+func NewAPIProxy(kubernetes *Kubernetes) *APIProxy {
+	return &APIProxy{kubernetes: kubernetes}
+}
+
+var _ core.KubeAPIProxy = (*APIProxy)(nil)
+
+func (p *APIProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster := r.PathValue("cluster")
+
+	cfg, err := p.kubernetes.impersonationConfig(r.Context(), cluster)
+	if err != nil {
+		p.WriteError(w, cluster, err)
+		return
+	}
+	rt, err := rest.TransportFor(cfg)
+	if err != nil {
+		p.WriteError(w, cluster, &core.DomainError{Code: core.ErrorCodeInternal, Message: "create transport", Cause: err})
+		return
+	}
+	target, err := url.Parse(cfg.Host)
+	if err != nil {
+		p.WriteError(w, cluster, &core.DomainError{Code: core.ErrorCodeInternal, Message: "parse cluster address", Cause: err})
+		return
+	}
+
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.SetURL(target)
+			pr.Out.URL.Path = strings.TrimRight(target.Path, "/") + "/" + pr.In.PathValue("path")
+			pr.Out.URL.RawPath = ""
+			pr.Out.Header.Del("Authorization")
+			for name := range pr.Out.Header {
+				if strings.HasPrefix(name, "Impersonate-") {
+					pr.Out.Header.Del(name)
+				}
+			}
+		},
+		Transport: rt,
+		// Watches and log follows stream; flush every write.
+		FlushInterval: -1,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			slog.Debug("kube-API proxy request failed", "cluster", cluster, "path", r.URL.Path, "error", err)
+			p.WriteError(w, cluster, err)
+		},
+	}
+	proxy.ServeHTTP(w, r)
+}
+
+// WriteError writes err as a metav1.Status, which kubectl shows as
+// the error message.
+func (p *APIProxy) WriteError(w http.ResponseWriter, cluster string, err error) {
+	var (
+		domainErr *core.DomainError
+		notFound  *core.ErrClusterNotFound
+		notReady  *core.ErrClusterNotReady
+		statusErr *apierrors.StatusError
+	)
+	switch {
+	case errors.As(err, &notFound):
+		statusErr = apierrors.NewNotFound(schema.GroupResource{Resource: "clusters"}, notFound.Cluster)
+	case errors.As(err, &notReady):
+		statusErr = apierrors.NewServiceUnavailable(err.Error())
+	case errors.As(err, &domainErr) && domainErr.Code == core.ErrorCodePermissionDenied:
+		statusErr = apierrors.NewForbidden(schema.GroupResource{Resource: "clusters"}, cluster, errors.New(domainErr.Message))
+	case errors.As(err, &domainErr) && domainErr.Code == core.ErrorCodeUnauthenticated:
+		statusErr = apierrors.NewUnauthorized(domainErr.Message)
+	case errors.As(err, &domainErr) && domainErr.Code == core.ErrorCodeUnavailable:
+		statusErr = apierrors.NewServiceUnavailable(domainErr.Message)
+	default:
+		statusErr = &apierrors.StatusError{ErrStatus: metav1.Status{
+			Status:  metav1.StatusFailure,
+			Code:    http.StatusBadGateway,
+			Reason:  metav1.StatusReasonServiceUnavailable,
+			Message: err.Error(),
+		}}
+	}
+	status := statusErr.Status()
+	status.Kind, status.APIVersion = "Status", "v1"
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(int(status.Code))
+	_ = json.NewEncoder(w).Encode(status)
+}
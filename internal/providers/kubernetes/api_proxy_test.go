@@ -0,0 +1,142 @@
+package kubernetes
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// serveAPIProxy serves req with an APIProxy mounted as the server
+// mounts it, as user.
+func serveAPIProxy(t *testing.T, proxy *APIProxy, req *http.Request, user core.UserInfo) *httptest.ResponseRecorder {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.Handle(core.KubeAPIPathPrefix+"{cluster}/{path...}", proxy)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req.WithContext(core.WithUserInfo(req.Context(), user)))
+	return rec
+}
+
+func TestAPIProxy_ForwardsAsCallingUser(t *testing.T) {
+	var got *http.Request
+	apiserver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"PodList","apiVersion":"v1","items":[]}`))
+	}))
+	defer apiserver.Close()
+
+	proxy := NewAPIProxy(New(&fakeTunnel{addr: apiserver.URL}, &core.ClusterAccessPolicy{}, TransportConfig{}))
+	req := httptest.NewRequest(http.MethodGet, "/clusters/edge-1/api/v1/namespaces/default/pods?limit=5", nil)
+	req.Header.Set("Authorization", "Bearer oidc-token")
+	req.Header.Set("Impersonate-User", "system:admin")
+	req.Header.Set("Impersonate-Group", "system:masters")
+
+	rec := serveAPIProxy(t, proxy, req, core.UserInfo{Subject: "alice", Groups: []string{"dev"}})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	if got.URL.Path != "/api/v1/namespaces/default/pods" || got.URL.RawQuery != "limit=5" {
+		t.Errorf("forwarded %s?%s, want /api/v1/namespaces/default/pods?limit=5", got.URL.Path, got.URL.RawQuery)
+	}
+	if user := got.Header.Get("Impersonate-User"); user != "alice" {
+		t.Errorf("Impersonate-User = %q, want alice", user)
+	}
+	if groups := got.Header.Values("Impersonate-Group"); len(groups) != 1 || groups[0] != "dev" {
+		t.Errorf("Impersonate-Group = %q, want only the caller's groups", groups)
+	}
+	if auth := got.Header.Get("Authorization"); auth != "" {
+		t.Errorf("caller's Authorization %q was forwarded", auth)
+	}
+}
+
+func TestAPIProxy_DeniedClusterReturnsStatus(t *testing.T) {
+	apiserver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request for a denied cluster reached the API server: %s", r.URL.Path)
+	}))
+	defer apiserver.Close()
+
+	policy, err := core.NewClusterAccessPolicy([]string{"ops=edge-1"})
+	if err != nil {
+		t.Fatalf("NewClusterAccessPolicy: %v", err)
+	}
+	proxy := NewAPIProxy(New(&fakeTunnel{addr: apiserver.URL}, policy, TransportConfig{}))
+	req := httptest.NewRequest(http.MethodGet, "/clusters/edge-2/api/v1/pods", nil)
+
+	rec := serveAPIProxy(t, proxy, req, core.UserInfo{Subject: "alice", Groups: []string{"ops"}})
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	var status metav1.Status
+	if err := json.Unmarshal(rec.Body.Bytes(), &status); err != nil || status.Kind != "Status" || status.Reason != metav1.StatusReasonForbidden {
+		t.Errorf("body = %s (%v), want a Forbidden Status", rec.Body, err)
+	}
+}
+
+func TestAPIProxy_WriteErrorReportsMaintenance(t *testing.T) {
+	proxy := NewAPIProxy(New(&fakeTunnel{}, &core.ClusterAccessPolicy{}, TransportConfig{}))
+	rec := httptest.NewRecorder()
+	proxy.WriteError(rec, "edge-1", &core.DomainError{Code: core.ErrorCodeUnavailable, Message: "upgrading"})
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+	var status metav1.Status
+	if err := json.Unmarshal(rec.Body.Bytes(), &status); err != nil || status.Kind != "Status" || status.Reason != metav1.StatusReasonServiceUnavailable || status.Message != "upgrading" {
+		t.Errorf("body = %s (%v), want a ServiceUnavailable Status with the maintenance message", rec.Body, err)
+	}
+}
+
+func TestAPIProxy_ForwardsUpgrades(t *testing.T) {
+	apiserver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") != "SPDY/3.1" || r.Header.Get("Impersonate-User") != "alice" {
+			http.Error(w, "expected an impersonated upgrade", http.StatusBadRequest)
+			return
+		}
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: SPDY/3.1\r\n\r\n")
+		_ = rw.Flush()
+		line, _ := rw.ReadString('\n')
+		_, _ = rw.WriteString("echo: " + line)
+		_ = rw.Flush()
+	}))
+	defer apiserver.Close()
+
+	proxy := NewAPIProxy(New(&fakeTunnel{addr: apiserver.URL}, &core.ClusterAccessPolicy{}, TransportConfig{}))
+	mux := http.NewServeMux()
+	mux.Handle(core.KubeAPIPathPrefix+"{cluster}/{path...}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxy.ServeHTTP(w, r.WithContext(core.WithUserInfo(r.Context(), core.UserInfo{Subject: "alice"})))
+	}))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	_, _ = conn.Write([]byte("POST /clusters/edge-1/api/v1/namespaces/default/pods/web/exec HTTP/1.1\r\nHost: server\r\nConnection: Upgrade\r\nUpgrade: SPDY/3.1\r\n\r\n"))
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	if err != nil {
+		t.Fatalf("read response: %v", err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("status = %d, want 101", resp.StatusCode)
+	}
+	_, _ = conn.Write([]byte("hello\n"))
+	if line, _ := br.ReadString('\n'); line != "echo: hello\n" {
+		t.Errorf("upgraded stream returned %q", line)
+	}
+}
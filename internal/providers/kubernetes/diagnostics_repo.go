@@ -0,0 +1,92 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// maxDiagnosticsBytes caps the size of an agent's diagnostics report.
+const maxDiagnosticsBytes = 1 << 20 // 1 MiB
+
+// diagnosticsRepo implements core.AgentDiagnosticsRepo by requesting
+// the report from the agent through the tunnel.
+type diagnosticsRepo struct {
+	kubernetes *Kubernetes
+}
+
+// NewAgentDiagnosticsRepo returns a core.AgentDiagnosticsRepo that
+// fetches reports from agents through their tunnels.
+func NewAgentDiagnosticsRepo(kubernetes *Kubernetes) core.AgentDiagnosticsRepo {
+	return &diagnosticsRepo{
+		kubernetes: kubernetes,
+	}
+}
+
+var _ core.AgentDiagnosticsRepo = (*diagnosticsRepo)(nil)
+
+// AgentDiagnostics fetches the report served by cluster's agent at
+// core.AgentDiagnosticsPath. The request goes through the same
+// access checks as any other request to the cluster. Agents that
+// predate the report pass the path on to their API server, which
+// answers 404; that is reported as a failed precondition.
+func (r *diagnosticsRepo) AgentDiagnostics(ctx context.Context, cluster string) (*core.AgentDiagnostics, error) {
+	config, err := r.kubernetes.impersonationConfig(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := rest.HTTPClientFor(config)
+	if err != nil {
+		return nil, &core.DomainError{Code: core.ErrorCodeInternal, Message: "create HTTP client", Cause: err}
+	}
+
+	target := strings.TrimRight(config.Host, "/") + core.AgentDiagnosticsPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, &core.DomainError{Code: core.ErrorCodeInternal, Message: "build diagnostics request", Cause: err}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, &core.DomainError{Code: core.ErrorCodeUnavailable, Message: "request agent diagnostics", Cause: err}
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, &core.DomainError{
+			Code:    core.ErrorCodeFailedPrecondition,
+			Message: fmt.Sprintf("the agent of cluster %s does not serve diagnostics; upgrade it", cluster),
+		}
+	case resp.StatusCode != http.StatusOK:
+		return nil, &core.DomainError{
+			Code:    core.ErrorCodeUnavailable,
+			Message: fmt.Sprintf("agent diagnostics responded %s", resp.Status),
+		}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxDiagnosticsBytes+1))
+	if err != nil {
+		return nil, &core.DomainError{Code: core.ErrorCodeUnavailable, Message: "read agent diagnostics", Cause: err}
+	}
+	if len(body) > maxDiagnosticsBytes {
+		return nil, &core.DomainError{
+			Code:    core.ErrorCodeResourceExhausted,
+			Message: fmt.Sprintf("agent diagnostics exceed %d bytes", maxDiagnosticsBytes),
+		}
+	}
+
+	var diag core.AgentDiagnostics
+	if err := json.Unmarshal(body, &diag); err != nil {
+		return nil, &core.DomainError{Code: core.ErrorCodeInternal, Message: "decode agent diagnostics", Cause: err}
+	}
+	return &diag, nil
+}
@@ -2,11 +2,15 @@ package kubernetes
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
 
-	"github.com/Masterminds/semver/v3"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/apiserver/pkg/cel/openapi/resolver"
@@ -17,10 +21,6 @@ import (
 	"github.com/otterscale/otterscale-agent/internal/core"
 )
 
-// minWatchListVersion is the minimum Kubernetes version that supports
-// the WatchList streaming feature (beta, default-on since 1.34).
-var minWatchListVersion = semver.MustParse("v1.34.0")
-
 // discoveryClient implements core.DiscoveryClient by delegating to the
 // Kubernetes discovery API of the target cluster, accessed through the
 // tunnel.
@@ -42,40 +42,74 @@ var _ core.DiscoveryClient = (*discoveryClient)(nil)
 // exists on the target cluster. It returns the validated GVR or a
 // BadRequest error if the resource is not recognised.
 func (d *discoveryClient) LookupResource(ctx context.Context, cluster, group, version, resource string) (schema.GroupVersionResource, error) {
-	client, err := d.client(ctx, cluster)
-	if err != nil {
-		return schema.GroupVersionResource{}, err
-	}
-
 	gvr := schema.GroupVersionResource{
 		Group:    group,
 		Version:  version,
 		Resource: resource,
 	}
+	if _, err := d.apiResource(ctx, cluster, gvr); err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	return gvr, nil
+}
+
+// IsNamespaced reports whether the given resource is namespace-scoped
+// on the target cluster.
+func (d *discoveryClient) IsNamespaced(ctx context.Context, cluster string, gvr schema.GroupVersionResource) (bool, error) {
+	r, err := d.apiResource(ctx, cluster, gvr)
+	if err != nil {
+		return false, err
+	}
+	return r.Namespaced, nil
+}
+
+// apiResource returns the discovery entry of gvr, or a BadRequest
+// error if the cluster does not serve it.
+func (d *discoveryClient) apiResource(ctx context.Context, cluster string, gvr schema.GroupVersionResource) (*metav1.APIResource, error) {
+	client, err := d.client(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
 
 	resources, err := client.ServerResourcesForGroupVersion(gvr.GroupVersion().String())
 	if err != nil {
-		return schema.GroupVersionResource{}, wrapK8sError(err)
+		return nil, wrapK8sError(err)
 	}
 
 	for i := range resources.APIResources {
 		if resources.APIResources[i].Name == gvr.Resource {
-			return gvr, nil
+			return &resources.APIResources[i], nil
 		}
 	}
-	return schema.GroupVersionResource{}, wrapK8sError(apierrors.NewBadRequest(fmt.Sprintf("unable to recognize resource %s", gvr)))
+	return nil, wrapK8sError(apierrors.NewBadRequest(fmt.Sprintf("unable to recognize resource %s", gvr)))
 }
 
 // ServerResources returns the full list of API resources available on
-// the target cluster.
-func (d *discoveryClient) ServerResources(ctx context.Context, cluster string) ([]*metav1.APIResourceList, error) {
+// the target cluster. When only some group-versions fail to be
+// discovered (typically an aggregated API whose backing service is
+// down), the resources of the healthy group-versions are returned
+// with the failures, sorted by group-version, instead of an error.
+func (d *discoveryClient) ServerResources(ctx context.Context, cluster string) ([]*metav1.APIResourceList, []core.DiscoveryFailure, error) {
 	client, err := d.client(ctx, cluster)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	_, resources, err := client.ServerGroupsAndResources()
-	return resources, wrapK8sError(err)
+	var groupErr *discovery.ErrGroupDiscoveryFailed
+	if !errors.As(err, &groupErr) {
+		return resources, nil, wrapK8sError(err)
+	}
+
+	failures := make([]core.DiscoveryFailure, 0, len(groupErr.Groups))
+	for gv, gvErr := range groupErr.Groups {
+		failures = append(failures, core.DiscoveryFailure{GroupVersion: gv.String(), Message: gvErr.Error()})
+	}
+	slices.SortFunc(failures, func(a, b core.DiscoveryFailure) int {
+		return strings.Compare(a.GroupVersion, b.GroupVersion)
+	})
+	slog.Warn("partial API discovery failure", "cluster", cluster, "failed_group_versions", len(failures))
+	return resources, failures, nil
 }
 
 // ResolveSchema fetches the OpenAPI schema for the given GVK from the
@@ -98,6 +132,39 @@ func (d *discoveryClient) ResolveSchema(ctx context.Context, cluster, group, ver
 	return resolved, wrapK8sError(err)
 }
 
+// OpenAPIV3 fetches the OpenAPI v3 document of a group-version from
+// the target cluster's /openapi/v3 endpoints, as JSON.
+func (d *discoveryClient) OpenAPIV3(ctx context.Context, cluster, group, version string) ([]byte, error) {
+	client, err := d.client(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := client.OpenAPIV3().Paths()
+	if err != nil {
+		return nil, wrapK8sError(err)
+	}
+	gv := schema.GroupVersion{Group: group, Version: version}
+	path, ok := paths[openAPIV3Path(gv)]
+	if !ok {
+		return nil, &core.DomainError{
+			Code:    core.ErrorCodeNotFound,
+			Message: fmt.Sprintf("no OpenAPI v3 document for %s", gv),
+		}
+	}
+	doc, err := path.Schema(runtime.ContentTypeJSON)
+	return doc, wrapK8sError(err)
+}
+
+// openAPIV3Path returns the key of gv in the /openapi/v3 index: api/v1
+// for the core group, apis/<group>/<version> for the others.
+func openAPIV3Path(gv schema.GroupVersion) string {
+	if gv.Group == "" {
+		return "api/" + gv.Version
+	}
+	return "apis/" + gv.Group + "/" + gv.Version
+}
+
 // ServerVersion returns the Kubernetes version of the target cluster.
 func (d *discoveryClient) ServerVersion(ctx context.Context, cluster string) (*version.Info, error) {
 	client, err := d.client(ctx, cluster)
@@ -117,12 +184,12 @@ func (d *discoveryClient) SupportsWatchList(ctx context.Context, cluster string)
 		return false, err
 	}
 
-	kubeVersion, err := semver.NewVersion(info.String())
+	caps, err := core.CapabilitiesForVersion(info)
 	if err != nil {
 		return false, err
 	}
 
-	return kubeVersion.GreaterThanEqual(minWatchListVersion), nil
+	return caps.WatchList, nil
 }
 
 // client returns a fresh discovery client for the given cluster with
@@ -0,0 +1,121 @@
+package kubernetes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// newPartialDiscoveryAPIServer serves legacy discovery for v1, apps/v1
+// and an aggregated metrics.k8s.io/v1beta1 whose backend is down.
+func newPartialDiscoveryAPIServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	responses := map[string]string{
+		"/api":    `{"kind":"APIVersions","versions":["v1"]}`,
+		"/api/v1": `{"kind":"APIResourceList","groupVersion":"v1","resources":[{"name":"pods","namespaced":true,"kind":"Pod","verbs":["get","list"]}]}`,
+		"/apis": `{"kind":"APIGroupList","apiVersion":"v1","groups":[
+			{"name":"apps","versions":[{"groupVersion":"apps/v1","version":"v1"}],"preferredVersion":{"groupVersion":"apps/v1","version":"v1"}},
+			{"name":"metrics.k8s.io","versions":[{"groupVersion":"metrics.k8s.io/v1beta1","version":"v1beta1"}],"preferredVersion":{"groupVersion":"metrics.k8s.io/v1beta1","version":"v1beta1"}}]}`,
+		"/apis/apps/v1": `{"kind":"APIResourceList","groupVersion":"apps/v1","resources":[{"name":"deployments","namespaced":true,"kind":"Deployment","verbs":["get","list"]}]}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"ServiceUnavailable","code":503,"message":"service unavailable"}`))
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestDiscoveryClient_ServerResources_PartialFailure(t *testing.T) {
+	apiserver := newPartialDiscoveryAPIServer(t)
+	k := New(&fakeTunnel{addr: apiserver.URL}, &core.ClusterAccessPolicy{}, TransportConfig{})
+	ctx := core.WithUserInfo(context.Background(), core.UserInfo{Subject: "alice"})
+
+	resources, failures, err := NewDiscoveryClient(k).ServerResources(ctx, "edge-1")
+	if err != nil {
+		t.Fatalf("ServerResources: %v", err)
+	}
+
+	discovered := map[string]bool{}
+	for _, list := range resources {
+		discovered[list.GroupVersion] = true
+	}
+	for _, gv := range []string{"v1", "apps/v1"} {
+		if !discovered[gv] {
+			t.Errorf("group-version %s missing from %v", gv, discovered)
+		}
+	}
+	if discovered["metrics.k8s.io/v1beta1"] {
+		t.Error("failed group-version metrics.k8s.io/v1beta1 was returned as discovered")
+	}
+
+	if len(failures) != 1 || failures[0].GroupVersion != "metrics.k8s.io/v1beta1" || failures[0].Message == "" {
+		t.Errorf("failures = %+v, want one failure for metrics.k8s.io/v1beta1", failures)
+	}
+}
+
+// appsV1OpenAPIDocument is a trimmed OpenAPI v3 document of apps/v1.
+const appsV1OpenAPIDocument = `{"openapi":"3.0.0","components":{"schemas":{
+	"io.k8s.api.apps.v1.Deployment":{"type":"object","properties":{"spec":{"$ref":"#/components/schemas/io.k8s.api.apps.v1.DeploymentSpec"}},
+		"x-kubernetes-group-version-kind":[{"group":"apps","kind":"Deployment","version":"v1"}]},
+	"io.k8s.api.apps.v1.DeploymentSpec":{"type":"object","properties":{"replicas":{"type":"integer"}}}}}}`
+
+// newOpenAPIV3APIServer serves the /openapi/v3 index and the document
+// of apps/v1.
+func newOpenAPIV3APIServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	responses := map[string]string{
+		"/openapi/v3":              `{"paths":{"apis/apps/v1":{"serverRelativeURL":"/openapi/v3/apis/apps/v1?hash=1234"}}}`,
+		"/openapi/v3/apis/apps/v1": appsV1OpenAPIDocument,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestDiscoveryClient_OpenAPIV3(t *testing.T) {
+	apiserver := newOpenAPIV3APIServer(t)
+	k := New(&fakeTunnel{addr: apiserver.URL}, &core.ClusterAccessPolicy{}, TransportConfig{})
+	ctx := core.WithUserInfo(context.Background(), core.UserInfo{Subject: "alice"})
+	d := NewDiscoveryClient(k)
+
+	doc, err := d.OpenAPIV3(ctx, "edge-1", "apps", "v1")
+	if err != nil {
+		t.Fatalf("OpenAPIV3: %v", err)
+	}
+	if string(doc) != appsV1OpenAPIDocument {
+		t.Errorf("document = %s, want the served apps/v1 document", doc)
+	}
+
+	_, err = d.OpenAPIV3(ctx, "edge-1", "batch", "v1")
+	if code, ok := core.DomainErrorCode(err); !ok || code != core.ErrorCodeNotFound {
+		t.Errorf("OpenAPIV3 of an unserved group-version: err = %v, want not found", err)
+	}
+
+	// Clusters that predate /openapi/v3 report not found as well, so
+	// that callers fall back to resolving schemas one by one.
+	legacy := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(legacy.Close)
+	k = New(&fakeTunnel{addr: legacy.URL}, &core.ClusterAccessPolicy{}, TransportConfig{})
+	_, err = NewDiscoveryClient(k).OpenAPIV3(ctx, "edge-1", "apps", "v1")
+	if code, ok := core.DomainErrorCode(err); !ok || code != core.ErrorCodeNotFound {
+		t.Errorf("OpenAPIV3 without /openapi/v3: err = %v, want not found", err)
+	}
+}
@@ -0,0 +1,82 @@
+package kubernetes
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/httpstream"
+)
+
+// requestTracker is implemented by tunnel providers that drain a
+// cluster's in-flight requests before tearing its tunnel down, such
+// as chisel.Service.
+type requestTracker interface {
+	// TrackRequest counts a request to cluster until done is called,
+	// or fails if the cluster no longer accepts requests.
+	TrackRequest(cluster string) (done func(), err error)
+}
+
+// trackedTransport reports every request to the tunnel provider for as
+// long as it is in flight, i.e. until its response body is closed, so
+// that a deregistration waits for it. Requests refused by the tracker
+// fail without being sent. Long-running streams (watches, followed
+// logs and upgraded exec, attach and port-forward connections) are
+// checked with the tracker but not held in flight: they only end when
+// their client leaves, so a drain would always time out on them.
+type trackedTransport struct {
+	cluster string
+	base    http.RoundTripper
+	tracker requestTracker
+}
+
+func (t *trackedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	done, err := t.tracker.TrackRequest(t.cluster)
+	if err != nil {
+		return nil, err
+	}
+	if isLongRunning(req) {
+		done()
+		return t.base.RoundTrip(req)
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		done()
+		return nil, err
+	}
+	resp.Body = &trackedBody{ReadCloser: resp.Body, done: sync.OnceFunc(done)}
+	return resp, nil
+}
+
+// isLongRunning reports whether req opens a stream that lasts as long
+// as its client wants rather than until the API server has answered.
+func isLongRunning(req *http.Request) bool {
+	if httpstream.IsUpgradeRequest(req) {
+		return true
+	}
+	query := req.URL.Query()
+	for _, param := range []string{"watch", "follow"} {
+		if v, err := strconv.ParseBool(query.Get(param)); err == nil && v {
+			return true
+		}
+	}
+	return false
+}
+
+// CloseIdleConnections forwards to the wrapped transport so that
+// closeTransport keeps working on cached entries.
+func (t *trackedTransport) CloseIdleConnections() {
+	closeTransport(t.base)
+}
+
+// trackedBody ends the tracking of its request once closed.
+type trackedBody struct {
+	io.ReadCloser
+	done func()
+}
+
+func (b *trackedBody) Close() error {
+	defer b.done()
+	return b.ReadCloser.Close()
+}
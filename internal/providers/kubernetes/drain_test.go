@@ -0,0 +1,65 @@
+package kubernetes
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// countingTracker counts the requests it holds in flight.
+type countingTracker struct {
+	inflight int
+}
+
+func (c *countingTracker) TrackRequest(string) (func(), error) {
+	c.inflight++
+	return func() { c.inflight-- }, nil
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestTrackedTransport_ExcludesLongRunningStreams(t *testing.T) {
+	base := roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+	})
+
+	for _, tt := range []struct {
+		name    string
+		url     string
+		upgrade bool
+		tracked bool
+	}{
+		{"list", "/api/v1/namespaces/default/pods", false, true},
+		{"log", "/api/v1/namespaces/default/pods/web/log", false, true},
+		{"watch", "/api/v1/namespaces/default/pods?watch=true", false, false},
+		{"watch 1", "/api/v1/namespaces/default/pods?watch=1", false, false},
+		{"followed log", "/api/v1/namespaces/default/pods/web/log?follow=true", false, false},
+		{"exec", "/api/v1/namespaces/default/pods/web/exec?command=sh", true, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := &countingTracker{}
+			rt := &trackedTransport{cluster: "edge-1", base: base, tracker: tracker}
+			req := httptest.NewRequest(http.MethodGet, "https://edge-1"+tt.url, nil)
+			if tt.upgrade {
+				req.Header.Set("Connection", "Upgrade")
+				req.Header.Set("Upgrade", "websocket")
+			}
+
+			resp, err := rt.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("RoundTrip: %v", err)
+			}
+			if tracked := tracker.inflight == 1; tracked != tt.tracked {
+				t.Errorf("in flight while the body is open = %d, want tracked %v", tracker.inflight, tt.tracked)
+			}
+			resp.Body.Close()
+			if tracker.inflight != 0 {
+				t.Errorf("in flight after close = %d, want 0", tracker.inflight)
+			}
+		})
+	}
+}
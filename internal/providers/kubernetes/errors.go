@@ -2,7 +2,11 @@ package kubernetes
 
 import (
 	"errors"
+	"fmt"
+	"regexp"
+	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -48,14 +52,170 @@ func wrapK8sError(err error) error {
 		return err
 	}
 
-	code, ok := statusReasonToDomainCode[apiStatus.Status().Reason]
+	status := apiStatus.Status()
+	code, ok := statusReasonToDomainCode[status.Reason]
 	if !ok {
 		code = core.ErrorCodeInternal
 	}
 
+	if webhook, reason, ok := parseAdmissionDenial(status.Message); ok {
+		// Webhooks usually reject without a status reason, which the
+		// API server reports as 400 Bad Request.
+		if status.Reason == "" {
+			code = core.ErrorCodeInvalidArgument
+		}
+		return &core.ErrAdmissionDenied{Webhook: webhook, Reason: reason, Code: code, Cause: err}
+	}
+
+	if namespace, ok := namespaceTerminating(status); ok {
+		return &core.ErrNamespaceTerminating{Namespace: namespace, Cause: err}
+	}
+
+	message := status.Message
+	if status.Reason == metav1.StatusReasonForbidden {
+		message = explainForbidden(status)
+	}
+
 	return &core.DomainError{
 		Code:    code,
-		Message: apiStatus.Status().Message,
+		Message: message,
 		Cause:   err,
 	}
 }
+
+// admissionDenialPattern matches the message with which the API server
+// reports an admission webhook's rejection: `admission webhook "NAME"
+// denied the request: REASON`, or `... denied the request without
+// explanation` when the webhook gave no reason.
+var admissionDenialPattern = regexp.MustCompile(`(?s)^admission webhook "([^"]*)" denied the request(?:: (.*)| without explanation)$`)
+
+// parseAdmissionDenial extracts the webhook name and its reason from
+// the message of a status returned for a request an admission webhook
+// rejected.
+func parseAdmissionDenial(message string) (webhook, reason string, ok bool) {
+	m := admissionDenialPattern.FindStringSubmatch(message)
+	if m == nil {
+		return "", "", false
+	}
+	return m[1], m[2], true
+}
+
+// namespaceTerminatingPattern extracts the namespace from the messages
+// with which the namespace lifecycle admission plugin rejects new
+// objects in a namespace that is being deleted: "namespace NAME is
+// being terminated" in the cause, and "... in namespace NAME because it
+// is being terminated" in the status.
+var namespaceTerminatingPattern = regexp.MustCompile(`namespace (\S+) (?:because it )?is being terminated`)
+
+// namespaceTerminating reports whether a status rejects the creation
+// of an object because its namespace is being deleted, and if so, in
+// which namespace. The namespace is empty if the message does not name
+// it.
+func namespaceTerminating(status metav1.Status) (string, bool) {
+	if status.Reason != metav1.StatusReasonForbidden || status.Details == nil {
+		return "", false
+	}
+	for _, cause := range status.Details.Causes {
+		if cause.Type != corev1.NamespaceTerminatingCause {
+			continue
+		}
+		for _, message := range []string{cause.Message, status.Message} {
+			if m := namespaceTerminatingPattern.FindStringSubmatch(message); m != nil {
+				return m[1], true
+			}
+		}
+		return "", true
+	}
+	return "", false
+}
+
+// impersonatedResources are the pseudo-resources the API server
+// authorizes the impersonate verb against.
+var impersonatedResources = map[string]bool{
+	"users":           true,
+	"groups":          true,
+	"serviceaccounts": true,
+	"uids":            true,
+	"userextras":      true,
+}
+
+// rbacDeniedPattern matches the message with which the authorizer
+// rejects a request, such as `User "alice" cannot list resource "pods"
+// in API group "" in the namespace "default"`. Other 403s, raised by
+// admission (exceeded quota, PodSecurity violations, ...), do not
+// match.
+var rbacDeniedPattern = regexp.MustCompile(`\bcannot [a-z]+ (?:resource|path) "`)
+
+// explainForbidden distinguishes the two RBAC 403s a proxied request
+// can hit. Every request is sent as the agent impersonating the
+// caller, so the API server first checks that the agent may
+// impersonate, and only then that the caller may perform the request.
+// The former is an agent RBAC misconfiguration rather than something
+// the caller can fix. Any other 403 keeps the API server's message,
+// which already names its cause.
+func explainForbidden(status metav1.Status) string {
+	if !rbacDeniedPattern.MatchString(status.Message) {
+		return status.Message
+	}
+	if isImpersonationForbidden(status) {
+		return fmt.Sprintf("the agent is not allowed to impersonate the caller on this cluster; "+
+			"grant the agent's service account the impersonate verb on users and groups: %s", status.Message)
+	}
+	return fmt.Sprintf("the caller lacks the required permission in the cluster's RBAC: %s", status.Message)
+}
+
+// isImpersonationForbidden reports whether a Forbidden status was
+// returned by the impersonation filter, i.e. the agent lacks the
+// impersonate verb, rather than by authorization of the request itself.
+func isImpersonationForbidden(status metav1.Status) bool {
+	if !strings.Contains(status.Message, "cannot impersonate") {
+		return false
+	}
+	details := status.Details
+	return details == nil || (details.Group == "" || details.Group == "authentication.k8s.io") && impersonatedResources[details.Kind]
+}
+
+// conflictManagerPattern extracts the owning field manager from a
+// server-side apply conflict cause such as
+// `conflict with "kubectl-edit" using apps/v1`.
+var conflictManagerPattern = regexp.MustCompile(`conflict with "([^"]*)"`)
+
+// asApplyConflict converts a server-side apply conflict (HTTP 409 with
+// FieldManagerConflict causes) into a *core.ErrApplyConflict listing
+// each contested field and its owner.
+func asApplyConflict(err error) (*core.ErrApplyConflict, bool) {
+	if !apierrors.IsConflict(err) {
+		return nil, false
+	}
+	var apiStatus apierrors.APIStatus
+	if !errors.As(err, &apiStatus) {
+		return nil, false
+	}
+	status := apiStatus.Status()
+	if status.Details == nil {
+		return nil, false
+	}
+
+	var conflicts []core.ApplyConflict
+	for _, cause := range status.Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		conflict := core.ApplyConflict{Field: cause.Field, Message: cause.Message}
+		if m := conflictManagerPattern.FindStringSubmatch(cause.Message); m != nil {
+			conflict.Manager = m[1]
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	if len(conflicts) == 0 {
+		return nil, false
+	}
+	return &core.ErrApplyConflict{Conflicts: conflicts, Message: status.Message}, true
+}
+
+// isResourceVersionExpired reports whether err is the API server's
+// "too old resource version" response (HTTP 410 Gone), meaning the
+// client must relist before it can watch again.
+func isResourceVersionExpired(err error) bool {
+	return apierrors.IsResourceExpired(err) || apierrors.IsGone(err)
+}
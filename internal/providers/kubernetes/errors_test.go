@@ -0,0 +1,74 @@
+package kubernetes
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+func TestWrapK8sError_ExplainsForbidden(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantPrefix string
+	}{
+		{
+			name: "agent cannot impersonate",
+			err: apierrors.NewForbidden(schema.GroupResource{Resource: "users"}, "alice",
+				errors.New(`User "system:serviceaccount:otterscale:otterscale-agent" cannot impersonate resource "users" in API group "" at the cluster scope`)),
+			wantPrefix: "the agent is not allowed to impersonate the caller",
+		},
+		{
+			name: "agent cannot impersonate uid",
+			err: apierrors.NewForbidden(schema.GroupResource{Group: "authentication.k8s.io", Resource: "uids"}, "1234",
+				errors.New(`User "system:serviceaccount:otterscale:otterscale-agent" cannot impersonate resource "uids" in API group "authentication.k8s.io" at the cluster scope`)),
+			wantPrefix: "the agent is not allowed to impersonate the caller",
+		},
+		{
+			name: "caller lacks permission",
+			err: apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "",
+				errors.New(`User "alice" cannot list resource "pods" in API group "" in the namespace "default"`)),
+			wantPrefix: "the caller lacks the required permission",
+		},
+		{
+			name: "caller lacks permission on a non-resource URL",
+			err: apierrors.NewForbidden(schema.GroupResource{}, "",
+				errors.New(`User "alice" cannot get path "/metrics"`)),
+			wantPrefix: "the caller lacks the required permission",
+		},
+		{
+			name: "exceeded quota",
+			err: apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "web",
+				errors.New(`exceeded quota: compute, requested: requests.cpu=2, used: requests.cpu=3, limited: requests.cpu=4`)),
+			wantPrefix: `pods "web" is forbidden: exceeded quota: compute`,
+		},
+		{
+			name: "PodSecurity violation",
+			err: apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "web",
+				errors.New(`violates PodSecurity "restricted:latest": allowPrivilegeEscalation != false (container "web" must set securityContext.allowPrivilegeEscalation=false)`)),
+			wantPrefix: `pods "web" is forbidden: violates PodSecurity "restricted:latest"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var domainErr *core.DomainError
+			if !errors.As(wrapK8sError(tt.err), &domainErr) {
+				t.Fatalf("wrapK8sError(%v) is not a *core.DomainError", tt.err)
+			}
+			if domainErr.Code != core.ErrorCodePermissionDenied {
+				t.Errorf("code = %v, want %v", domainErr.Code, core.ErrorCodePermissionDenied)
+			}
+			if !strings.HasPrefix(domainErr.Message, tt.wantPrefix) {
+				t.Errorf("message = %q, want prefix %q", domainErr.Message, tt.wantPrefix)
+			}
+			if !strings.Contains(domainErr.Message, tt.err.Error()) {
+				t.Errorf("message = %q, want it to include the API server message %q", domainErr.Message, tt.err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package kubernetes
+
+import (
+	"context"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// identityRepo implements core.IdentityRepo with SelfSubjectReviews and
+// SelfSubjectRulesReviews.
+type identityRepo struct {
+	kubernetes *Kubernetes
+}
+
+// NewIdentityRepo returns a core.IdentityRepo backed by Kubernetes.
+func NewIdentityRepo(kubernetes *Kubernetes) core.IdentityRepo {
+	return &identityRepo{kubernetes: kubernetes}
+}
+
+var _ core.IdentityRepo = (*identityRepo)(nil)
+
+// SelfSubjectReview creates a SelfSubjectReview on cluster while
+// impersonating the caller, so that the API server reports the
+// identity it attributes to the caller's requests.
+func (r *identityRepo) SelfSubjectReview(ctx context.Context, cluster string) (*core.ClusterUserInfo, error) {
+	config, err := r.kubernetes.impersonationConfig(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, &core.DomainError{Code: core.ErrorCodeInternal, Message: "create kubernetes clientset", Cause: err}
+	}
+
+	review, err := clientset.AuthenticationV1().SelfSubjectReviews().Create(ctx, &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, wrapK8sError(err)
+	}
+
+	user := review.Status.UserInfo
+	info := &core.ClusterUserInfo{
+		Username: user.Username,
+		UID:      user.UID,
+		Groups:   user.Groups,
+	}
+	if len(user.Extra) > 0 {
+		info.Extra = make(map[string][]string, len(user.Extra))
+		for k, v := range user.Extra {
+			info.Extra[k] = v
+		}
+	}
+	return info, nil
+}
+
+// AgentRulesReview creates a SelfSubjectRulesReview on cluster without
+// impersonation, so that the API server resolves the rules of the
+// agent's ServiceAccount rather than the caller's.
+func (r *identityRepo) AgentRulesReview(ctx context.Context, cluster, namespace string) (*core.AgentPermissions, error) {
+	config, err := r.kubernetes.agentConfig(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, &core.DomainError{Code: core.ErrorCodeInternal, Message: "create kubernetes clientset", Cause: err}
+	}
+
+	review, err := clientset.AuthorizationV1().SelfSubjectRulesReviews().Create(ctx, &authorizationv1.SelfSubjectRulesReview{
+		Spec: authorizationv1.SelfSubjectRulesReviewSpec{Namespace: namespace},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, wrapK8sError(err)
+	}
+
+	status := review.Status
+	perms := &core.AgentPermissions{
+		Namespace:        namespace,
+		ResourceRules:    make([]core.ResourceRule, 0, len(status.ResourceRules)),
+		NonResourceRules: make([]core.NonResourceRule, 0, len(status.NonResourceRules)),
+		Incomplete:       status.Incomplete,
+		EvaluationError:  status.EvaluationError,
+	}
+	for _, rule := range status.ResourceRules {
+		perms.ResourceRules = append(perms.ResourceRules, core.ResourceRule{
+			Verbs:         rule.Verbs,
+			APIGroups:     rule.APIGroups,
+			Resources:     rule.Resources,
+			ResourceNames: rule.ResourceNames,
+		})
+	}
+	for _, rule := range status.NonResourceRules {
+		perms.NonResourceRules = append(perms.NonResourceRules, core.NonResourceRule{
+			Verbs:           rule.Verbs,
+			NonResourceURLs: rule.NonResourceURLs,
+		})
+	}
+	return perms, nil
+}
@@ -2,14 +2,18 @@
 // reverse-tunnel established by the agent. It implements
 // core.DiscoveryClient and core.ResourceRepo.
 //
-// All requests are impersonated: the authenticated user's identity
-// (subject + groups) is forwarded to the target cluster's API server
-// via Kubernetes impersonation headers, so RBAC is enforced at the
-// cluster level rather than at this proxy.
+// All end-user requests are impersonated: the authenticated user's
+// identity (subject + groups) is forwarded to the target cluster's API
+// server via Kubernetes impersonation headers, so RBAC is enforced at
+// the cluster level rather than at this proxy. Only the server's own
+// service operations skip impersonation.
 package kubernetes
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"sync"
 	"time"
@@ -20,9 +24,10 @@ import (
 )
 
 // clientTimeout is the default HTTP timeout applied to per-request
-// rest.Configs. This ensures that Kubernetes API calls that do not
-// accept a context.Context (e.g. the discovery client) are still
-// bounded and cannot block indefinitely.
+// rest.Configs of clusters without a configured timeout. This ensures
+// that Kubernetes API calls that do not accept a context.Context (e.g.
+// the discovery client) are still bounded and cannot block
+// indefinitely.
 const clientTimeout = 30 * time.Second
 
 // clusterTransport holds a cached HTTP transport for a single cluster.
@@ -36,6 +41,47 @@ type clusterTransport struct {
 	rt      http.RoundTripper
 }
 
+// TransportConfig tunes the connection pool of the per-cluster HTTP
+// transport. Zero values fall back to the net/http defaults
+// (MaxConnsPerHost 0 means unlimited).
+type TransportConfig struct {
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	MaxConnsPerHost     int
+	IdleConnTimeout     time.Duration
+
+	// Timeouts bounds each API server request, per cluster. Clusters
+	// without a timeout use clientTimeout.
+	Timeouts core.ClusterTimeouts
+
+	// ReconnectWait is how long a request that finds the cluster's
+	// agent offline waits for it to reconnect before failing. Zero
+	// fails such requests immediately.
+	ReconnectWait time.Duration
+	// ReconnectQueueSize bounds the number of requests per cluster
+	// waiting for the agent to reconnect. Further requests fail
+	// immediately.
+	ReconnectQueueSize int
+
+	// CompressStreams asks agents to gzip pod log streams before they
+	// cross the tunnel.
+	CompressStreams bool
+
+	// WatchBufferSize is how many watch events are buffered between the
+	// API server and a consumer that is momentarily slower. Zero leaves
+	// watches unbuffered.
+	WatchBufferSize int
+	// WatchOverflow selects what a watch does once its buffer is full.
+	// The zero value blocks. WatchOverflowDrop needs a positive
+	// WatchBufferSize.
+	WatchOverflow WatchOverflow
+
+	// DialContext, when set, replaces the TCP dialer used to reach
+	// tunnel endpoints. It is nil in production and lets tests route
+	// cluster traffic through an in-memory tunnel.
+	DialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
 // Kubernetes is the shared foundation for discoveryClient and
 // resourceRepo. It resolves cluster names to tunnel addresses and
 // builds impersonated rest.Configs. Transports are cached per-cluster
@@ -43,13 +89,20 @@ type clusterTransport struct {
 type Kubernetes struct {
 	mu         sync.Mutex
 	tunnel     core.TunnelProvider
+	authz      core.ClusterAuthorizer
+	transport  TransportConfig
 	transports map[string]*clusterTransport // keyed by cluster name
 }
 
 // New creates a Kubernetes helper bound to the given TunnelProvider.
-func New(tunnel core.TunnelProvider) *Kubernetes {
+// Every request is checked against authz before the cluster's tunnel
+// address is resolved. transport tunes the connection pool of each
+// cluster's cached HTTP transport.
+func New(tunnel core.TunnelProvider, authz core.ClusterAuthorizer, transport TransportConfig) *Kubernetes {
 	return &Kubernetes{
 		tunnel:     tunnel,
+		authz:      authz,
+		transport:  transport,
 		transports: make(map[string]*clusterTransport),
 	}
 }
@@ -57,20 +110,40 @@ func New(tunnel core.TunnelProvider) *Kubernetes {
 // impersonationConfig builds a rest.Config that targets the given
 // cluster through its tunnel address and impersonates the calling
 // user extracted from the request context.
+//
+// Requests the server makes as itself with a core.ServiceIdentity are
+// not impersonated: they reach the API server as the agent's own
+// ServiceAccount. The transport layer only admits them for the RPCs
+// their operation allows, and they may only reach the cluster their
+// token was issued for.
 func (k *Kubernetes) impersonationConfig(ctx context.Context, cluster string) (*rest.Config, error) {
-	userInfo, ok := core.UserInfoFromContext(ctx)
-	if !ok {
-		return nil, &core.DomainError{
-			Code:    core.ErrorCodeUnauthenticated,
-			Message: "user info not found in context",
-		}
+	impersonate, err := k.impersonation(ctx, cluster)
+	if err != nil {
+		return nil, err
 	}
+	return k.clusterConfig(ctx, cluster, impersonate)
+}
 
+// agentConfig builds a rest.Config like impersonationConfig that
+// impersonates no one, so that requests reach the API server as the
+// agent's own ServiceAccount. The calling user must still be allowed
+// to access cluster. It is only for reporting what the agent itself
+// may do, never for acting on a user's behalf.
+func (k *Kubernetes) agentConfig(ctx context.Context, cluster string) (*rest.Config, error) {
+	if _, err := k.authorize(ctx, cluster); err != nil {
+		return nil, err
+	}
+	return k.clusterConfig(ctx, cluster, rest.ImpersonationConfig{})
+}
+
+// clusterConfig builds a rest.Config that targets cluster through its
+// tunnel address with the given impersonation.
+func (k *Kubernetes) clusterConfig(ctx context.Context, cluster string, impersonate rest.ImpersonationConfig) (*rest.Config, error) {
 	address, err := k.tunnel.ResolveAddress(ctx, cluster)
 	if err != nil {
 		// Cluster is no longer registered; evict stale cached
 		// clients and their TCP connections.
-		k.evictClients(cluster)
+		k.EvictClients(cluster)
 		return nil, err // ResolveAddress already returns *core.ErrClusterNotFound
 	}
 
@@ -80,13 +153,10 @@ func (k *Kubernetes) impersonationConfig(ctx context.Context, cluster string) (*
 	}
 
 	cfg := &rest.Config{
-		Host: address,
-		Impersonate: rest.ImpersonationConfig{
-			UserName: userInfo.Subject,
-			Groups:   userInfo.Groups,
-		},
-		Transport: rt,
-		Timeout:   clientTimeout,
+		Host:        address,
+		Impersonate: impersonate,
+		Transport:   rt,
+		Timeout:     k.timeout(cluster),
 	}
 
 	return cfg, nil
@@ -95,21 +165,25 @@ func (k *Kubernetes) impersonationConfig(ctx context.Context, cluster string) (*
 // spdyConfig builds a rest.Config suitable for SPDY connections
 // (exec, port-forward). Unlike impersonationConfig, it does NOT
 // set a pre-built Transport because SPDY executors and dialers need
-// to negotiate their own connection upgrade.
+// to negotiate their own connection upgrade. Exec and port-forward are
+// never available to service operations.
 func (k *Kubernetes) spdyConfig(ctx context.Context, cluster string) (*rest.Config, error) {
-	userInfo, ok := core.UserInfoFromContext(ctx)
-	if !ok {
+	if id, ok := core.ServiceIdentityFromContext(ctx); ok {
 		return nil, &core.DomainError{
-			Code:    core.ErrorCodeUnauthenticated,
-			Message: "user info not found in context",
+			Code:    core.ErrorCodePermissionDenied,
+			Message: fmt.Sprintf("service operation %q may not open streaming sessions", id.Operation),
 		}
 	}
+	userInfo, err := k.authorize(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
 
 	address, err := k.tunnel.ResolveAddress(ctx, cluster)
 	if err != nil {
 		// Cluster is no longer registered; evict stale cached
 		// clients and their TCP connections.
-		k.evictClients(cluster)
+		k.EvictClients(cluster)
 		return nil, err // ResolveAddress already returns *core.ErrClusterNotFound
 	}
 
@@ -119,10 +193,68 @@ func (k *Kubernetes) spdyConfig(ctx context.Context, cluster string) (*rest.Conf
 			UserName: userInfo.Subject,
 			Groups:   userInfo.Groups,
 		},
-		Timeout: clientTimeout,
+		Timeout: k.timeout(cluster),
+	}, nil
+}
+
+// timeout returns the request timeout configured for cluster.
+func (k *Kubernetes) timeout(cluster string) time.Duration {
+	if timeout := k.transport.Timeouts.For(cluster); timeout > 0 {
+		return timeout
+	}
+	return clientTimeout
+}
+
+// impersonation returns the impersonation settings for a request to
+// cluster: the authorised calling user, or none for an authorised
+// service identity.
+func (k *Kubernetes) impersonation(ctx context.Context, cluster string) (rest.ImpersonationConfig, error) {
+	if id, ok := core.ServiceIdentityFromContext(ctx); ok {
+		if err := k.authorizeService(ctx, id, cluster); err != nil {
+			return rest.ImpersonationConfig{}, err
+		}
+		return rest.ImpersonationConfig{}, nil
+	}
+	userInfo, err := k.authorize(ctx, cluster)
+	if err != nil {
+		return rest.ImpersonationConfig{}, err
+	}
+	return rest.ImpersonationConfig{
+		UserName: userInfo.Subject,
+		Groups:   userInfo.Groups,
 	}, nil
 }
 
+// authorizeService checks that the service identity id may access
+// cluster: its token must have been issued for cluster, and the
+// ClusterAuthorizer must grant it like any user.
+func (k *Kubernetes) authorizeService(ctx context.Context, id core.ServiceIdentity, cluster string) error {
+	if id.Cluster != cluster {
+		return &core.DomainError{
+			Code:    core.ErrorCodePermissionDenied,
+			Message: fmt.Sprintf("service operation %q was authorised for cluster %s, not %s", id.Operation, id.Cluster, cluster),
+		}
+	}
+	return k.authz.AuthorizeCluster(ctx, id.UserInfo(), cluster)
+}
+
+// authorize extracts the calling user from ctx and checks that they
+// may access cluster. It runs before tunnel address resolution so that
+// unauthorised callers cannot probe which clusters are registered.
+func (k *Kubernetes) authorize(ctx context.Context, cluster string) (core.UserInfo, error) {
+	userInfo, ok := core.UserInfoFromContext(ctx)
+	if !ok {
+		return core.UserInfo{}, &core.DomainError{
+			Code:    core.ErrorCodeUnauthenticated,
+			Message: "user info not found in context",
+		}
+	}
+	if err := k.authz.AuthorizeCluster(ctx, userInfo, cluster); err != nil {
+		return core.UserInfo{}, err
+	}
+	return userInfo, nil
+}
+
 // roundTripper returns a cached HTTP transport for the given cluster.
 // If the cached transport's address does not match the current tunnel
 // address (e.g. after cluster re-registration), the stale entry is
@@ -146,14 +278,16 @@ func (k *Kubernetes) roundTripper(cluster, address string) (http.RoundTripper, e
 		closeTransport(old.rt)
 	}
 
-	cfg := &rest.Config{Host: address}
-	rt, err := rest.TransportFor(cfg)
-	if err != nil {
-		return nil, &core.DomainError{
-			Code:    core.ErrorCodeInternal,
-			Message: "create HTTP transport",
-			Cause:   err,
-		}
+	var rt http.RoundTripper = &agentOfflineTransport{
+		cluster:   cluster,
+		base:      k.newTransport(),
+		reconnect: newReconnectQueue(k.transport.ReconnectWait, k.transport.ReconnectQueueSize),
+		resolve: func(ctx context.Context) (string, error) {
+			return k.tunnel.ResolveAddress(ctx, cluster)
+		},
+	}
+	if tracker, ok := k.tunnel.(requestTracker); ok {
+		rt = &trackedTransport{cluster: cluster, base: rt, tracker: tracker}
 	}
 
 	k.transports[cluster] = &clusterTransport{
@@ -163,11 +297,77 @@ func (k *Kubernetes) roundTripper(cluster, address string) (http.RoundTripper, e
 	return rt, nil
 }
 
-// evictClients removes the cached transport for the given cluster and
-// closes idle TCP connections. This is called when a cluster is no
+// newTransport builds a dedicated HTTP transport for one cluster's
+// tunnel endpoint. Tunnel addresses are plain-HTTP loopback URLs, for
+// which rest.TransportFor would hand back the process-wide
+// http.DefaultTransport; a per-cluster transport keeps pools isolated
+// (so evicting one cluster does not drop another's connections) and
+// lets the pool be sized for high-concurrency clusters.
+func (k *Kubernetes) newTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if k.transport.MaxIdleConns > 0 {
+		t.MaxIdleConns = k.transport.MaxIdleConns
+	}
+	if k.transport.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = k.transport.MaxIdleConnsPerHost
+	}
+	if k.transport.MaxConnsPerHost > 0 {
+		t.MaxConnsPerHost = k.transport.MaxConnsPerHost
+	}
+	if k.transport.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = k.transport.IdleConnTimeout
+	}
+	if k.transport.DialContext != nil {
+		t.DialContext = k.transport.DialContext
+	}
+	return t
+}
+
+// agentOfflineTransport translates failures to dial the cluster's
+// tunnel endpoint into *core.ErrClusterNotReady. A registered cluster
+// whose agent has disconnected still resolves to its loopback address,
+// but nothing listens there any more, so the dial is refused or times
+// out. If reconnect is set, retryable requests first wait for the
+// agent to reconnect. Errors after the connection is established are
+// passed through unchanged.
+type agentOfflineTransport struct {
+	cluster   string
+	base      http.RoundTripper
+	reconnect *reconnectQueue
+	resolve   func(ctx context.Context) (string, error)
+}
+
+func (t *agentOfflineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err == nil || !isDialError(err) {
+		return resp, err
+	}
+	if t.reconnect != nil && retryable(req) {
+		return t.awaitReconnect(req, err)
+	}
+	return nil, &core.ErrClusterNotReady{Cluster: t.cluster, Cause: err}
+}
+
+// CloseIdleConnections forwards to the wrapped transport so that
+// closeTransport keeps working on cached entries.
+func (t *agentOfflineTransport) CloseIdleConnections() {
+	closeTransport(t.base)
+}
+
+// isDialError reports whether err stems from failing to open a TCP
+// connection (connection refused, dial timeout, etc.).
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// EvictClients removes the cached transport for the given cluster and
+// closes idle TCP connections. It is called when a cluster is no
 // longer registered (e.g. after deregistration) to prevent connection
-// and memory leaks.
-func (k *Kubernetes) evictClients(cluster string) {
+// and memory leaks, and when its agent registers again, so that the
+// first request after a reconnect uses a fresh transport rather than
+// connections to the previous tunnel endpoint.
+func (k *Kubernetes) EvictClients(cluster string) {
 	k.mu.Lock()
 	defer k.mu.Unlock()
 	if old, ok := k.transports[cluster]; ok {
@@ -0,0 +1,542 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// fakeTunnel resolves every cluster to addr (or a fixed loopback
+// address) and records whether ResolveAddress was called.
+type fakeTunnel struct {
+	core.TunnelProvider
+	addr     string
+	resolved bool
+}
+
+func (f *fakeTunnel) ResolveAddress(_ context.Context, cluster string) (string, error) {
+	f.resolved = true
+	if f.addr != "" {
+		return f.addr, nil
+	}
+	return "http://127.1.1.1:16598", nil
+}
+
+func TestImpersonationConfig_ClusterAccess(t *testing.T) {
+	policy, err := core.NewClusterAccessPolicy([]string{"edge-ops=edge-1"})
+	if err != nil {
+		t.Fatalf("NewClusterAccessPolicy: %v", err)
+	}
+	ctx := core.WithUserInfo(context.Background(), core.UserInfo{
+		Subject: "alice",
+		Groups:  []string{"edge-ops"},
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		tunnel := &fakeTunnel{}
+		k := New(tunnel, policy, TransportConfig{})
+		cfg, err := k.impersonationConfig(ctx, "edge-1")
+		if err != nil {
+			t.Fatalf("impersonationConfig: %v", err)
+		}
+		if cfg.Impersonate.UserName != "alice" {
+			t.Errorf("impersonated user = %q, want alice", cfg.Impersonate.UserName)
+		}
+	})
+
+	t.Run("denied before resolving address", func(t *testing.T) {
+		tunnel := &fakeTunnel{}
+		k := New(tunnel, policy, TransportConfig{})
+		_, err := k.spdyConfig(ctx, "prod")
+		if code, _ := core.DomainErrorCode(err); code != core.ErrorCodePermissionDenied {
+			t.Fatalf("expected ErrorCodePermissionDenied, got %v", err)
+		}
+		if tunnel.resolved {
+			t.Error("ResolveAddress must not be called for a denied cluster")
+		}
+	})
+}
+
+func TestRoundTripper_AgentOffline(t *testing.T) {
+	// Reserve a loopback port and release it so that nothing is
+	// listening, mimicking a tunnel whose agent has disconnected.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	address := "http://" + ln.Addr().String()
+	_ = ln.Close()
+
+	k := New(&fakeTunnel{}, &core.ClusterAccessPolicy{}, TransportConfig{})
+	rt, err := k.roundTripper("edge-1", address)
+	if err != nil {
+		t.Fatalf("roundTripper: %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, address+"/version", nil)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	_, err = rt.RoundTrip(req)
+
+	var notReady *core.ErrClusterNotReady
+	if !errors.As(err, &notReady) {
+		t.Fatalf("expected *core.ErrClusterNotReady, got %T: %v", err, err)
+	}
+	if notReady.Cluster != "edge-1" {
+		t.Errorf("cluster = %q, want edge-1", notReady.Cluster)
+	}
+	var notFound *core.ErrClusterNotFound
+	if errors.As(err, &notFound) {
+		t.Error("agent-offline error must not be reported as an unknown cluster")
+	}
+}
+
+func TestProxyRepo_ForwardsWithImpersonation(t *testing.T) {
+	var gotPath, gotQuery, gotUser string
+	apiserver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.RawQuery
+		gotUser = r.Header.Get("Impersonate-User")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"kind":"Status"}`))
+	}))
+	defer apiserver.Close()
+
+	k := New(&fakeTunnel{addr: apiserver.URL}, &core.ClusterAccessPolicy{}, TransportConfig{})
+	ctx := core.WithUserInfo(context.Background(), core.UserInfo{Subject: "alice"})
+
+	resp, err := NewProxyRepo(k).Do(ctx, "edge-1", core.ProxyRequest{
+		Method: http.MethodGet,
+		Path:   "/apis/apps/v1/namespaces/default/deployments/web/status",
+		Query:  "pretty=true",
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if gotPath != "/apis/apps/v1/namespaces/default/deployments/web/status" || gotQuery != "pretty=true" {
+		t.Errorf("forwarded %s?%s", gotPath, gotQuery)
+	}
+	if gotUser != "alice" {
+		t.Errorf("Impersonate-User = %q, want alice", gotUser)
+	}
+	if resp.StatusCode != http.StatusNotFound || resp.ContentType != "application/json" || string(resp.Body) != `{"kind":"Status"}` {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestRoundTripper_TransportConfig(t *testing.T) {
+	k := New(&fakeTunnel{}, &core.ClusterAccessPolicy{}, TransportConfig{
+		MaxIdleConnsPerHost: 64,
+		MaxConnsPerHost:     128,
+		IdleConnTimeout:     30 * time.Second,
+	})
+
+	rt, err := k.roundTripper("edge-1", "http://127.1.1.1:16598")
+	if err != nil {
+		t.Fatalf("roundTripper: %v", err)
+	}
+	wrapped, ok := rt.(*agentOfflineTransport)
+	if !ok {
+		t.Fatalf("expected *agentOfflineTransport, got %T", rt)
+	}
+	tr, ok := wrapped.base.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected *http.Transport, got %T", wrapped.base)
+	}
+	if tr == http.DefaultTransport {
+		t.Fatal("cluster transport must not be the shared http.DefaultTransport")
+	}
+	if tr.MaxIdleConnsPerHost != 64 {
+		t.Errorf("MaxIdleConnsPerHost = %d, want 64", tr.MaxIdleConnsPerHost)
+	}
+	if tr.MaxConnsPerHost != 128 {
+		t.Errorf("MaxConnsPerHost = %d, want 128", tr.MaxConnsPerHost)
+	}
+	if tr.IdleConnTimeout != 30*time.Second {
+		t.Errorf("IdleConnTimeout = %v, want 30s", tr.IdleConnTimeout)
+	}
+	if def := http.DefaultTransport.(*http.Transport); tr.MaxIdleConns != def.MaxIdleConns {
+		t.Errorf("unset MaxIdleConns = %d, want default %d", tr.MaxIdleConns, def.MaxIdleConns)
+	}
+}
+
+func TestEvictClients_OnReregistration(t *testing.T) {
+	var closed atomic.Int32
+	apiserver := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	apiserver.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateClosed {
+			closed.Add(1)
+		}
+	}
+	apiserver.Start()
+	defer apiserver.Close()
+
+	tunnel := &fakeTunnel{addr: apiserver.URL}
+	k := New(tunnel, &core.ClusterAccessPolicy{}, TransportConfig{})
+	ctx := core.WithUserInfo(context.Background(), core.UserInfo{Subject: "alice"})
+	get := func() *clusterTransport {
+		t.Helper()
+		if _, err := NewProxyRepo(k).Do(ctx, "edge-1", core.ProxyRequest{Method: http.MethodGet, Path: "/version"}); err != nil {
+			t.Fatalf("Do: %v", err)
+		}
+		k.mu.Lock()
+		defer k.mu.Unlock()
+		return k.transports["edge-1"]
+	}
+
+	stale := get()
+	if stale == nil {
+		t.Fatal("expected a cached transport")
+	}
+
+	// The agent registers again on a new host, served here by the
+	// same API server under another loopback name.
+	tunnel.addr = strings.Replace(apiserver.URL, "127.0.0.1", "localhost", 1)
+	k.EvictClients("edge-1")
+
+	k.mu.Lock()
+	_, cached := k.transports["edge-1"]
+	k.mu.Unlock()
+	if cached {
+		t.Error("cached transport survived the eviction")
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for closed.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if closed.Load() == 0 {
+		t.Error("idle connection to the previous endpoint was not closed")
+	}
+
+	fresh := get()
+	if fresh == stale || fresh.address != tunnel.addr {
+		t.Errorf("request after reconnect used transport for %s, want a fresh one for %s", fresh.address, tunnel.addr)
+	}
+}
+
+func TestImpersonationConfig_PerClusterTimeout(t *testing.T) {
+	// The API server answers after 300ms: later than the default
+	// timeout, but within the slow cluster's override.
+	apiserver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(300 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"settings","namespace":"default"}}`))
+	}))
+	t.Cleanup(apiserver.Close)
+
+	k := New(&fakeTunnel{addr: apiserver.URL}, &core.ClusterAccessPolicy{}, TransportConfig{
+		Timeouts: core.ClusterTimeouts{
+			Default:   100 * time.Millisecond,
+			Overrides: map[string]time.Duration{"ap-south": 5 * time.Second},
+		},
+	})
+	repo := NewResourceRepo(k)
+	ctx := core.WithUserInfo(context.Background(), core.UserInfo{Subject: "alice"})
+
+	if _, err := repo.Get(ctx, "ap-south", configMapsGVR, "default", "settings"); err != nil {
+		t.Errorf("slow cluster with a 5s override: %v", err)
+	}
+	if _, err := repo.Get(ctx, "edge-1", configMapsGVR, "default", "settings"); err == nil {
+		t.Error("expected the default 100ms timeout to cut off the request to edge-1")
+	}
+
+	cfg, err := k.impersonationConfig(ctx, "AP-South")
+	if err != nil {
+		t.Fatalf("impersonationConfig: %v", err)
+	}
+	if cfg.Timeout != 5*time.Second {
+		t.Errorf("timeout = %v, want the override matched case-insensitively", cfg.Timeout)
+	}
+}
+
+func TestWatcherAdapter_ExpiredResourceVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      *metav1.Status
+		wantExpired bool
+	}{
+		{"410 Expired", &apierrors.NewResourceExpired("too old resource version: 1 (42)").ErrStatus, true},
+		{"410 Gone", &apierrors.NewGone("gone").ErrStatus, true},
+		{"500 internal", &apierrors.NewInternalError(errors.New("boom")).ErrStatus, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := watch.NewFake()
+			w := newWatcherAdapter(fake, 0, WatchOverflowBlock)
+			defer w.Stop()
+
+			go fake.Error(tt.status)
+
+			event := <-w.ResultChan()
+			if event.Type != core.WatchEventError {
+				t.Fatalf("event type = %s, want ERROR", event.Type)
+			}
+			if event.Expired != tt.wantExpired {
+				t.Errorf("Expired = %v, want %v", event.Expired, tt.wantExpired)
+			}
+		})
+	}
+}
+
+func TestWatcherAdapter_InitialEventsEnd(t *testing.T) {
+	fake := watch.NewFake()
+	w := newWatcherAdapter(fake, 0, WatchOverflowBlock)
+	defer w.Stop()
+
+	object := func(name string, annotations map[string]string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("Pod")
+		u.SetName(name)
+		u.SetResourceVersion("42")
+		u.SetAnnotations(annotations)
+		return u
+	}
+	go func() {
+		fake.Add(object("a", nil))
+		fake.Add(object("b", nil))
+		fake.Action(watch.Bookmark, object("", map[string]string{metav1.InitialEventsAnnotationKey: "true"}))
+		fake.Action(watch.Bookmark, object("", nil))
+	}()
+
+	want := []struct {
+		typ core.WatchEventType
+		end bool
+	}{
+		{core.WatchEventAdded, false},
+		{core.WatchEventAdded, false},
+		{core.WatchEventBookmark, true},
+		{core.WatchEventBookmark, false},
+	}
+	for i, step := range want {
+		event := <-w.ResultChan()
+		if event.Type != step.typ || event.InitialEventsEnd != step.end {
+			t.Errorf("event %d = %s (initial events end %v), want %s (%v)", i, event.Type, event.InitialEventsEnd, step.typ, step.end)
+		}
+	}
+}
+
+// watchPod returns a pod named name for feeding a fake watch.
+func watchPod(name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("v1")
+	u.SetKind("Pod")
+	u.SetName(name)
+	return u
+}
+
+func TestWatcherAdapter_BufferAbsorbsSlowConsumer(t *testing.T) {
+	fake := watch.NewFake()
+	w := newWatcherAdapter(fake, 3, WatchOverflowBlock)
+	defer w.Stop()
+
+	// Nothing reads while the events arrive: an unbuffered adapter
+	// would block the upstream watch after the first one.
+	sent := make(chan struct{})
+	go func() {
+		for _, name := range []string{"a", "b", "c"} {
+			fake.Add(watchPod(name))
+		}
+		close(sent)
+	}()
+	select {
+	case <-sent:
+	case <-time.After(5 * time.Second):
+		t.Fatal("upstream watch blocked on a stalled consumer despite the buffer")
+	}
+
+	for _, want := range []string{"a", "b", "c"} {
+		event := <-w.ResultChan()
+		if name, _, _ := unstructured.NestedString(event.Object, "metadata", "name"); event.Type != core.WatchEventAdded || name != want {
+			t.Errorf("event = %s %q, want ADDED %q", event.Type, name, want)
+		}
+	}
+}
+
+func TestWatcherAdapter_DropsEventsForSlowConsumer(t *testing.T) {
+	fake := watch.NewFake()
+	w := newWatcherAdapter(fake, 2, WatchOverflowDrop)
+	defer w.Stop()
+
+	// The consumer stalls for all five events; three do not fit.
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		fake.Add(watchPod(name))
+	}
+
+	var events []core.WatchEvent
+	for event := range w.ResultChan() {
+		events = append(events, event)
+	}
+	if len(events) != 3 {
+		t.Fatalf("received %d events, want the 2 buffered ones and a marker", len(events))
+	}
+	for i, want := range []string{"a", "b"} {
+		if name, _, _ := unstructured.NestedString(events[i].Object, "metadata", "name"); name != want {
+			t.Errorf("event %d = %q, want %q", i, name, want)
+		}
+	}
+	marker := events[2]
+	message, _, _ := unstructured.NestedString(marker.Object, "message")
+	if marker.Type != core.WatchEventError || !marker.Expired || !strings.Contains(message, "3 events dropped") {
+		t.Errorf("marker = %s expired=%v %q, want an expired ERROR counting 3 dropped events", marker.Type, marker.Expired, message)
+	}
+}
+
+func TestImpersonation_ServiceOperationSkipsImpersonation(t *testing.T) {
+	var impersonated []string
+	apiserver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		impersonated = append(impersonated, r.Header.Get("Impersonate-User"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"settings","namespace":"default"}}`))
+	}))
+	defer apiserver.Close()
+
+	// The ops group and service identities may access edge-1; nobody
+	// may access edge-2.
+	policy, err := core.NewClusterAccessPolicy([]string{"ops=edge-1", core.ServiceGroup + "=edge-1"})
+	if err != nil {
+		t.Fatalf("NewClusterAccessPolicy: %v", err)
+	}
+	k := New(&fakeTunnel{addr: apiserver.URL}, policy, TransportConfig{})
+	repo := NewResourceRepo(k)
+
+	service := core.WithServiceIdentity(context.Background(), core.ServiceIdentity{Operation: core.ServiceOperationBootstrap, Cluster: "edge-1"})
+	if _, err := repo.Get(service, "edge-1", configMapsGVR, "default", "settings"); err != nil {
+		t.Fatalf("service Get: %v", err)
+	}
+	user := core.WithUserInfo(context.Background(), core.UserInfo{Subject: "alice", Groups: []string{"ops"}})
+	if _, err := repo.Get(user, "edge-1", configMapsGVR, "default", "settings"); err != nil {
+		t.Fatalf("user Get: %v", err)
+	}
+
+	if len(impersonated) != 2 || impersonated[0] != "" || impersonated[1] != "alice" {
+		t.Errorf("Impersonate-User headers = %q, want none for the service and alice for the user", impersonated)
+	}
+
+	if _, err := k.spdyConfig(service, "edge-1"); err == nil {
+		t.Error("service operations must not open exec or port-forward sessions")
+	}
+}
+
+func TestImpersonation_ServiceIdentityIsAuthorisedPerCluster(t *testing.T) {
+	apiserver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unauthorised service request reached the API server: %s", r.URL.Path)
+	}))
+	defer apiserver.Close()
+
+	policy, err := core.NewClusterAccessPolicy([]string{"ops=*", core.ServiceGroup + "=edge-1"})
+	if err != nil {
+		t.Fatalf("NewClusterAccessPolicy: %v", err)
+	}
+	repo := NewResourceRepo(New(&fakeTunnel{addr: apiserver.URL}, policy, TransportConfig{}))
+
+	for _, tc := range []struct {
+		name    string
+		id      core.ServiceIdentity
+		cluster string
+	}{
+		// The token for edge-1 cannot be used on another cluster, even
+		// one the policy would grant.
+		{"other cluster than issued for", core.ServiceIdentity{Operation: core.ServiceOperationBootstrap, Cluster: "edge-1"}, "edge-2"},
+		// The policy does not grant service identities edge-2.
+		{"cluster denied by policy", core.ServiceIdentity{Operation: core.ServiceOperationBootstrap, Cluster: "edge-2"}, "edge-2"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := core.WithServiceIdentity(context.Background(), tc.id)
+			_, err := repo.Get(ctx, tc.cluster, configMapsGVR, "default", "settings")
+			var domainErr *core.DomainError
+			if !errors.As(err, &domainErr) || domainErr.Code != core.ErrorCodePermissionDenied {
+				t.Fatalf("Get error = %v, want permission denied", err)
+			}
+		})
+	}
+}
+
+func TestIdentityRepo_AgentRulesReview(t *testing.T) {
+	var gotPath, gotUser, gotBody string
+	apiserver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotUser = r.Header.Get("Impersonate-User")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{
+			"apiVersion": "authorization.k8s.io/v1",
+			"kind": "SelfSubjectRulesReview",
+			"spec": {"namespace": "otterscale-system"},
+			"status": {
+				"resourceRules": [
+					{"verbs": ["*"], "apiGroups": ["*"], "resources": ["*"]},
+					{"verbs": ["get"], "apiGroups": [""], "resources": ["secrets"], "resourceNames": ["agent-token"]}
+				],
+				"nonResourceRules": [{"verbs": ["get"], "nonResourceURLs": ["/healthz", "/version"]}],
+				"incomplete": true,
+				"evaluationError": "webhook authorizer does not support rule enumeration"
+			}
+		}`))
+	}))
+	defer apiserver.Close()
+
+	policy, err := core.NewClusterAccessPolicy([]string{"ops=edge-1"})
+	if err != nil {
+		t.Fatalf("NewClusterAccessPolicy: %v", err)
+	}
+	repo := NewIdentityRepo(New(&fakeTunnel{addr: apiserver.URL}, policy, TransportConfig{}))
+
+	ctx := core.WithUserInfo(context.Background(), core.UserInfo{Subject: "alice", Groups: []string{"ops"}})
+	perms, err := repo.AgentRulesReview(ctx, "edge-1", "otterscale-system")
+	if err != nil {
+		t.Fatalf("AgentRulesReview: %v", err)
+	}
+	if gotPath != "/apis/authorization.k8s.io/v1/selfsubjectrulesreviews" {
+		t.Errorf("path = %q, want a SelfSubjectRulesReview", gotPath)
+	}
+	if gotUser != "" {
+		t.Errorf("Impersonate-User = %q, want the agent's own identity", gotUser)
+	}
+	if !strings.Contains(gotBody, "otterscale-system") {
+		t.Errorf("review body = %s, want it scoped to otterscale-system", gotBody)
+	}
+
+	if perms.Namespace != "otterscale-system" || len(perms.ResourceRules) != 2 || len(perms.NonResourceRules) != 1 {
+		t.Fatalf("permissions = %+v, want the reviewed rules", perms)
+	}
+	secrets := perms.ResourceRules[1]
+	if secrets.Verbs[0] != "get" || secrets.Resources[0] != "secrets" || secrets.ResourceNames[0] != "agent-token" {
+		t.Errorf("second resource rule = %+v, want get on the agent-token secret", secrets)
+	}
+	if urls := perms.NonResourceRules[0].NonResourceURLs; len(urls) != 2 || urls[1] != "/version" {
+		t.Errorf("non-resource URLs = %q, want /healthz and /version", urls)
+	}
+	if !perms.Incomplete || perms.EvaluationError == "" {
+		t.Errorf("incomplete = %v, evaluation error = %q, want the authorizer's limitation reported", perms.Incomplete, perms.EvaluationError)
+	}
+
+	outsider := core.WithUserInfo(context.Background(), core.UserInfo{Subject: "bob"})
+	if _, err := repo.AgentRulesReview(outsider, "edge-1", "default"); err == nil {
+		t.Error("a caller without access to edge-1 reviewed the agent's permissions")
+	}
+}
@@ -0,0 +1,84 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// maxProxyResponseBytes caps the size of a raw passthrough response
+// body. The passthrough is meant for small status and diagnostic
+// endpoints; large listings should go through the typed RPCs.
+const maxProxyResponseBytes = 10 << 20 // 10 MiB
+
+// proxyRepo implements core.ProxyRepo by issuing raw HTTP requests to
+// the target cluster's API server through the tunnel.
+type proxyRepo struct {
+	kubernetes *Kubernetes
+}
+
+// NewProxyRepo returns a core.ProxyRepo backed by impersonated raw
+// HTTP requests.
+func NewProxyRepo(kubernetes *Kubernetes) core.ProxyRepo {
+	return &proxyRepo{
+		kubernetes: kubernetes,
+	}
+}
+
+var _ core.ProxyRepo = (*proxyRepo)(nil)
+
+// Do forwards req to the cluster's API server as the calling user and
+// returns the raw response. Non-2xx responses are returned as-is
+// rather than as errors, so the caller sees exactly what the API
+// server replied.
+func (r *proxyRepo) Do(ctx context.Context, cluster string, req core.ProxyRequest) (*core.ProxyResponse, error) {
+	config, err := r.kubernetes.impersonationConfig(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := rest.HTTPClientFor(config)
+	if err != nil {
+		return nil, &core.DomainError{Code: core.ErrorCodeInternal, Message: "create HTTP client", Cause: err}
+	}
+
+	target := strings.TrimRight(config.Host, "/") + req.Path
+	if req.Query != "" {
+		target += "?" + req.Query
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, target, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, &core.ErrInvalidInput{Field: "path", Message: err.Error()}
+	}
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxProxyResponseBytes+1))
+	if err != nil {
+		return nil, &core.DomainError{Code: core.ErrorCodeUnavailable, Message: "read proxy response", Cause: err}
+	}
+	if len(body) > maxProxyResponseBytes {
+		return nil, &core.DomainError{
+			Code:    core.ErrorCodeResourceExhausted,
+			Message: fmt.Sprintf("proxy response exceeds %d bytes", maxProxyResponseBytes),
+		}
+	}
+
+	return &core.ProxyResponse{
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        body,
+	}, nil
+}
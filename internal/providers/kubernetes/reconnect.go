@@ -0,0 +1,126 @@
+package kubernetes
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// Bounds of the interval between attempts to reach a reconnecting
+// agent. It doubles after every failed attempt.
+const (
+	reconnectMinInterval = 100 * time.Millisecond
+	reconnectMaxInterval = time.Second
+)
+
+// errReconnectQueueFull is the cause reported when a request finds the
+// agent offline and too many requests are already waiting for it.
+var errReconnectQueueFull = errors.New("too many requests waiting for the agent to reconnect")
+
+// reconnectQueue holds requests back while a cluster's agent
+// reconnects its tunnel, so that a brief reconnect is not surfaced to
+// callers as an error. At most cap(slots) requests wait at a time; the
+// rest fail immediately, so a long outage cannot pile up requests.
+type reconnectQueue struct {
+	wait  time.Duration
+	slots chan struct{}
+}
+
+// newReconnectQueue returns a queue letting up to size requests wait
+// up to wait each, or nil, disabling waiting, if either is zero.
+func newReconnectQueue(wait time.Duration, size int) *reconnectQueue {
+	if wait <= 0 || size <= 0 {
+		return nil
+	}
+	return &reconnectQueue{wait: wait, slots: make(chan struct{}, size)}
+}
+
+// retryable reports whether req may wait for the agent to reconnect
+// and be sent again. Streaming requests (watches, followed logs and
+// protocol upgrades) are long-lived, so callers already handle them
+// ending and reopen them; they fail at once instead of occupying the
+// queue. Requests whose body cannot be replayed are not retried.
+func retryable(req *http.Request) bool {
+	if req.Header.Get("Upgrade") != "" {
+		return false
+	}
+	q := req.URL.Query()
+	for _, param := range []string{"watch", "follow"} {
+		if v := q.Get(param); v == "true" || v == "1" {
+			return false
+		}
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// awaitReconnect resends req, whose first attempt failed with dialErr,
+// until the agent can be dialled again or the queue's wait elapses.
+// The cluster's address is resolved again before every attempt, since
+// an agent that re-registers moves to a new one.
+func (t *agentOfflineTransport) awaitReconnect(req *http.Request, dialErr error) (*http.Response, error) {
+	select {
+	case t.reconnect.slots <- struct{}{}:
+		defer func() { <-t.reconnect.slots }()
+	default:
+		return nil, &core.ErrClusterNotReady{Cluster: t.cluster, Cause: errReconnectQueueFull}
+	}
+
+	ctx := req.Context()
+	deadline := time.NewTimer(t.reconnect.wait)
+	defer deadline.Stop()
+
+	lastErr := dialErr
+	for interval := reconnectMinInterval; ; interval = min(2*interval, reconnectMaxInterval) {
+		retry := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			retry.Stop()
+			return nil, ctx.Err()
+		case <-deadline.C:
+			retry.Stop()
+			return nil, &core.ErrClusterNotReady{Cluster: t.cluster, Cause: lastErr}
+		case <-retry.C:
+		}
+
+		address, err := t.resolve(ctx)
+		if err != nil {
+			// Deregistered for now; it may register again.
+			lastErr = err
+			continue
+		}
+		attempt, err := rewriteRequest(req, address)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := t.base.RoundTrip(attempt)
+		if err == nil || !isDialError(err) {
+			return resp, err
+		}
+		lastErr = err
+	}
+}
+
+// rewriteRequest returns a copy of req addressed to address, with its
+// body rewound. The transport closed the original body when the
+// previous attempt failed, so it is recreated with GetBody.
+func rewriteRequest(req *http.Request, address string) (*http.Request, error) {
+	target, err := url.Parse(address)
+	if err != nil {
+		return nil, err
+	}
+	attempt := req.Clone(req.Context())
+	attempt.URL.Scheme = target.Scheme
+	attempt.URL.Host = target.Host
+	attempt.Host = ""
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		attempt.Body = body
+	}
+	return attempt, nil
+}
@@ -0,0 +1,133 @@
+package kubernetes
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// offlineAddress reserves a loopback port and releases it, so that
+// nothing listens there, like a tunnel whose agent is reconnecting.
+func offlineAddress(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	address := ln.Addr().String()
+	_ = ln.Close()
+	return address
+}
+
+func TestRoundTripper_WaitsForAgentReconnect(t *testing.T) {
+	address := offlineAddress(t)
+	k := New(&fakeTunnel{addr: "http://" + address}, &core.ClusterAccessPolicy{}, TransportConfig{
+		ReconnectWait:      5 * time.Second,
+		ReconnectQueueSize: 4,
+	})
+	rt, err := k.roundTripper("edge-1", "http://"+address)
+	if err != nil {
+		t.Fatalf("roundTripper: %v", err)
+	}
+
+	// The agent's tunnel comes back shortly after the request is sent.
+	go func() {
+		time.Sleep(300 * time.Millisecond)
+		ln, err := net.Listen("tcp", address)
+		if err != nil {
+			t.Errorf("relisten: %v", err)
+			return
+		}
+		srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			_, _ = w.Write(body)
+		})}
+		t.Cleanup(func() { _ = srv.Close() })
+		_ = srv.Serve(ln)
+	}()
+
+	req, err := http.NewRequest(http.MethodPost, "http://"+address+"/api/v1/namespaces/default/configmaps", strings.NewReader(`{"kind":"ConfigMap"}`))
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	start := time.Now()
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || string(body) != `{"kind":"ConfigMap"}` {
+		t.Errorf("response = %d %q, want 200 with the original body replayed", resp.StatusCode, body)
+	}
+	if elapsed := time.Since(start); elapsed < 300*time.Millisecond {
+		t.Errorf("returned after %s, before the agent reconnected", elapsed)
+	}
+}
+
+func TestRoundTripper_ReconnectQueueBackpressure(t *testing.T) {
+	address := "http://" + offlineAddress(t)
+	k := New(&fakeTunnel{addr: address}, &core.ClusterAccessPolicy{}, TransportConfig{
+		ReconnectWait:      time.Second,
+		ReconnectQueueSize: 1,
+	})
+	rt, err := k.roundTripper("edge-1", address)
+	if err != nil {
+		t.Fatalf("roundTripper: %v", err)
+	}
+
+	// The first request occupies the only queue slot until the wait
+	// elapses.
+	waiting := make(chan error, 1)
+	go func() {
+		req, _ := http.NewRequest(http.MethodGet, address+"/version", nil)
+		_, err := rt.RoundTrip(req)
+		waiting <- err
+	}()
+	deadline := time.Now().Add(2 * time.Second)
+	for len(rt.(*agentOfflineTransport).reconnect.slots) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("first request never started waiting")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	tests := []struct {
+		name      string
+		path      string
+		wantCause error
+	}{
+		{"queue full", "/version", errReconnectQueueFull},
+		{"streaming exempt", "/api/v1/pods?watch=true", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, address+tt.path, nil)
+			start := time.Now()
+			_, err := rt.RoundTrip(req)
+
+			var notReady *core.ErrClusterNotReady
+			if !errors.As(err, &notReady) {
+				t.Fatalf("err = %v, want *core.ErrClusterNotReady", err)
+			}
+			if tt.wantCause != nil && !errors.Is(err, tt.wantCause) {
+				t.Errorf("err = %v, want cause %v", err, tt.wantCause)
+			}
+			if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+				t.Errorf("failed after %s, want immediately", elapsed)
+			}
+		})
+	}
+
+	var notReady *core.ErrClusterNotReady
+	if err := <-waiting; !errors.As(err, &notReady) {
+		t.Errorf("waiting request: err = %v, want *core.ErrClusterNotReady once the wait elapsed", err)
+	}
+}
@@ -6,9 +6,12 @@ import (
 	"fmt"
 	"log/slog"
 	"runtime/debug"
+	"sync"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer/yaml"
 	"k8s.io/apimachinery/pkg/types"
@@ -38,7 +41,24 @@ var _ core.ResourceRepo = (*resourceRepo)(nil)
 // CRUD
 // ---------------------------------------------------------------------------
 
+// List page sizes used when the API server rejects a paginated list as
+// too large. A continued list without a limit is retried with
+// listRetryLimit items; each further rejection halves the page size,
+// down to minListRetryLimit.
+const (
+	listRetryLimit    int64 = 500
+	minListRetryLimit int64 = 10
+)
+
 // List returns a paged list of resources matching the given options.
+// If the API server rejects a paginated list (one with a limit or a
+// continue token) as too large (HTTP 413), it is retried with
+// successively smaller limits. The shorter page carries a continue
+// token, so callers that paginate see no difference. An unpaginated
+// list is not retried, since its caller expects every item in one
+// response and would silently miss the rest. When the list is not
+// retried, or even the smallest page is rejected, a
+// *core.ErrListTooLarge suggesting a page size is returned.
 func (r *resourceRepo) List(
 	ctx context.Context,
 	cluster string,
@@ -58,8 +78,40 @@ func (r *resourceRepo) List(
 		Continue:      opts.Continue,
 	}
 
-	result, err := client.Resource(gvr).Namespace(namespace).List(ctx, listOpts)
-	return result, wrapK8sError(err)
+	for {
+		result, err := client.Resource(gvr).Namespace(namespace).List(ctx, listOpts)
+		if err == nil || !apierrors.IsRequestEntityTooLargeError(err) {
+			return result, wrapK8sError(err)
+		}
+		if opts.Limit <= 0 && opts.Continue == "" {
+			return nil, &core.ErrListTooLarge{SuggestedLimit: listRetryLimit, Cause: err}
+		}
+
+		limit, ok := nextListLimit(listOpts.Limit)
+		if !ok {
+			return nil, &core.ErrListTooLarge{
+				Limit:          listOpts.Limit,
+				SuggestedLimit: max(listOpts.Limit/2, 1),
+				Cause:          err,
+			}
+		}
+		slog.Debug("list too large, retrying with a smaller limit",
+			"resource", gvr.String(), "limit", listOpts.Limit, "retry_limit", limit)
+		listOpts.Limit = limit
+	}
+}
+
+// nextListLimit returns the page size to retry a rejected list with,
+// or false if limit is already the smallest page size tried.
+func nextListLimit(limit int64) (int64, bool) {
+	switch {
+	case limit <= 0 || limit > listRetryLimit:
+		return listRetryLimit, true
+	case limit <= minListRetryLimit:
+		return 0, false
+	default:
+		return max(limit/2, minListRetryLimit), true
+	}
 }
 
 // Get returns a single resource by name.
@@ -78,7 +130,10 @@ func (r *resourceRepo) Get(
 	return result, wrapK8sError(err)
 }
 
-// Create decodes a YAML manifest and creates the resource.
+// Create decodes a YAML manifest and creates the resource. The
+// manifest must set exactly one of metadata.name and
+// metadata.generateName; the returned object carries the name the API
+// server assigned.
 func (r *resourceRepo) Create(
 	ctx context.Context,
 	cluster string,
@@ -95,6 +150,14 @@ func (r *resourceRepo) Create(
 	if err != nil {
 		return nil, err
 	}
+	// The API server would let name win over generateName; requiring
+	// exactly one keeps the caller's intent unambiguous.
+	switch hasName, hasGenerateName := obj.GetName() != "", obj.GetGenerateName() != ""; {
+	case hasName && hasGenerateName:
+		return nil, &core.ErrInvalidInput{Field: "manifest", Message: "metadata.name and metadata.generateName are mutually exclusive"}
+	case !hasName && !hasGenerateName:
+		return nil, &core.ErrInvalidInput{Field: "manifest", Message: "metadata.name or metadata.generateName is required"}
+	}
 
 	result, err := client.Resource(gvr).Namespace(namespace).Create(ctx, obj, metav1.CreateOptions{})
 	return result, wrapK8sError(err)
@@ -130,8 +193,33 @@ func (r *resourceRepo) Apply(
 		Force:        &opts.Force,
 		FieldManager: opts.FieldManager,
 	}
+	if opts.DryRun {
+		patchOpts.DryRun = []string{metav1.DryRunAll}
+	}
 
 	result, err := client.Resource(gvr).Namespace(namespace).Patch(ctx, name, types.ApplyPatchType, data, patchOpts)
+	if conflict, ok := asApplyConflict(err); ok {
+		return nil, conflict
+	}
+	return result, wrapK8sError(err)
+}
+
+// MergePatch applies a JSON merge patch to a resource. Unlike Apply,
+// it does not take ownership of the patched fields, so it cannot
+// conflict with other field managers.
+func (r *resourceRepo) MergePatch(
+	ctx context.Context,
+	cluster string,
+	gvr schema.GroupVersionResource,
+	namespace, name string,
+	patch []byte,
+) (*unstructured.Unstructured, error) {
+	client, err := r.dynamicClient(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.Resource(gvr).Namespace(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
 	return result, wrapK8sError(err)
 }
 
@@ -194,24 +282,54 @@ func (r *resourceRepo) Watch(
 
 	result, err := client.Resource(gvr).Namespace(namespace).Watch(ctx, listOpts)
 	if err != nil {
+		if isResourceVersionExpired(err) {
+			return nil, &core.ErrResourceVersionExpired{
+				ResourceVersion: opts.ResourceVersion,
+				Message:         err.Error(),
+			}
+		}
 		return nil, wrapK8sError(err)
 	}
 
-	return newWatcherAdapter(result), nil
+	transport := r.kubernetes.transport
+	return newWatcherAdapter(result, transport.WatchBufferSize, transport.WatchOverflow), nil
 }
 
+// WatchOverflow selects what a watch does when its consumer falls so
+// far behind that the event buffer is full.
+type WatchOverflow string
+
+const (
+	// WatchOverflowBlock stops reading from the API server until the
+	// consumer catches up. No event is lost, but the API server may
+	// end a watch that is not read for too long.
+	WatchOverflowBlock WatchOverflow = "block"
+	// WatchOverflowDrop keeps reading from the API server and drops
+	// the events that do not fit. Once the consumer catches up, the
+	// watch ends with an expired ERROR event counting the dropped
+	// events, so that the consumer relists as after a 410 Gone.
+	WatchOverflowDrop WatchOverflow = "drop"
+)
+
 // watcherAdapter bridges a Kubernetes watch.Interface to the domain
 // core.Watcher interface by converting watch.Event objects into
 // core.WatchEvent values with generic map[string]any payloads.
 type watcherAdapter struct {
-	inner watch.Interface
-	ch    chan core.WatchEvent
+	inner    watch.Interface
+	ch       chan core.WatchEvent
+	overflow WatchOverflow
+	stop     chan struct{}
+	stopOnce sync.Once
 }
 
-func newWatcherAdapter(inner watch.Interface) *watcherAdapter {
+// newWatcherAdapter returns a watcherAdapter buffering up to
+// bufferSize events, handling a full buffer as overflow selects.
+func newWatcherAdapter(inner watch.Interface, bufferSize int, overflow WatchOverflow) *watcherAdapter {
 	w := &watcherAdapter{
-		inner: inner,
-		ch:    make(chan core.WatchEvent),
+		inner:    inner,
+		ch:       make(chan core.WatchEvent, bufferSize),
+		overflow: overflow,
+		stop:     make(chan struct{}),
 	}
 	go w.relay()
 	return w
@@ -221,8 +339,13 @@ func (w *watcherAdapter) ResultChan() <-chan core.WatchEvent {
 	return w.ch
 }
 
+// Stop terminates the upstream watch and the relay goroutine, even if
+// the consumer has stopped reading events.
 func (w *watcherAdapter) Stop() {
-	w.inner.Stop()
+	w.stopOnce.Do(func() {
+		close(w.stop)
+		w.inner.Stop()
+	})
 }
 
 // relay reads from the Kubernetes watch channel and converts events
@@ -254,11 +377,73 @@ func (w *watcherAdapter) relay() {
 			// Convert Status to a generic map for error events.
 			domainEvent.Object = statusToGenericMap(obj)
 		}
+		switch event.Type {
+		case watch.Error:
+			domainEvent.Expired = isResourceVersionExpired(apierrors.FromObject(event.Object))
+		case watch.Bookmark:
+			domainEvent.InitialEventsEnd = isInitialEventsEnd(event.Object)
+		}
 
-		w.ch <- domainEvent
+		if w.overflow == WatchOverflowDrop {
+			select {
+			case w.ch <- domainEvent:
+				continue
+			case <-w.stop:
+				return
+			default:
+				w.dropEvents(1)
+				return
+			}
+		}
+
+		select {
+		case w.ch <- domainEvent:
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// dropEvents keeps draining the upstream watch, counting the dropped
+// events, until the consumer has room for an expired ERROR event
+// reporting them. The watch then ends: the consumer has missed
+// changes, so it must relist rather than carry on.
+func (w *watcherAdapter) dropEvents(dropped int) {
+	slog.Warn("watch consumer fell behind; dropping events", "buffer", cap(w.ch))
+	upstream := w.inner.ResultChan()
+	for {
+		status := apierrors.NewResourceExpired(fmt.Sprintf(
+			"watch consumer fell behind: %d events dropped; relist and watch again", dropped)).ErrStatus
+		marker := core.WatchEvent{
+			Type:    core.WatchEventError,
+			Object:  statusToGenericMap(&status),
+			Expired: true,
+		}
+
+		select {
+		case w.ch <- marker:
+			w.Stop()
+			return
+		case _, ok := <-upstream:
+			if !ok {
+				upstream = nil
+				continue
+			}
+			dropped++
+		case <-w.stop:
+			return
+		}
 	}
 }
 
+// isInitialEventsEnd reports whether a bookmark is the one with which
+// the API server ends the initial events of a streaming list
+// (SendInitialEvents).
+func isInitialEventsEnd(obj runtime.Object) bool {
+	u, ok := obj.(*unstructured.Unstructured)
+	return ok && u.GetAnnotations()[metav1.InitialEventsAnnotationKey] == "true"
+}
+
 func toCorEventType(t watch.EventType) core.WatchEventType {
 	switch t {
 	case watch.Added:
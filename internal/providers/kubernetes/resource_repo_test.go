@@ -0,0 +1,318 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+var configMapsGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+// newTooLargeAPIServer rejects list requests whose limit is unset or
+// above maxLimit with 413, and records every limit it was asked for.
+func newTooLargeAPIServer(t *testing.T, maxLimit int64) (*httptest.Server, *[]int64) {
+	t.Helper()
+	var limits []int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.ParseInt(r.URL.Query().Get("limit"), 10, 64)
+		limits = append(limits, limit)
+
+		w.Header().Set("Content-Type", "application/json")
+		if limit == 0 || limit > maxLimit {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"RequestEntityTooLarge","code":413,"message":"response too large"}`))
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"kind":"ConfigMapList","apiVersion":"v1","metadata":{"continue":"next-%d"},"items":[{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-0","namespace":"default"}}]}`, limit)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &limits
+}
+
+func TestResourceRepo_List_RetriesTooLargeWithSmallerLimit(t *testing.T) {
+	apiserver, limits := newTooLargeAPIServer(t, 100)
+	k := New(&fakeTunnel{addr: apiserver.URL}, &core.ClusterAccessPolicy{}, TransportConfig{})
+	ctx := core.WithUserInfo(context.Background(), core.UserInfo{Subject: "alice"})
+
+	list, err := NewResourceRepo(k).List(ctx, "edge-1", configMapsGVR, "default", core.ListOptions{Limit: 1000})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+
+	if want := []int64{1000, 500, 250, 125, 62}; fmt.Sprint(*limits) != fmt.Sprint(want) {
+		t.Errorf("requested limits = %v, want %v", *limits, want)
+	}
+	if len(list.Items) != 1 || list.GetContinue() != "next-62" {
+		t.Errorf("list = %d items, continue %q; want the smaller page with its continue token", len(list.Items), list.GetContinue())
+	}
+}
+
+func TestResourceRepo_List_TooLargeSuggestsPagination(t *testing.T) {
+	apiserver, limits := newTooLargeAPIServer(t, 5)
+	k := New(&fakeTunnel{addr: apiserver.URL}, &core.ClusterAccessPolicy{}, TransportConfig{})
+	ctx := core.WithUserInfo(context.Background(), core.UserInfo{Subject: "alice"})
+
+	_, err := NewResourceRepo(k).List(ctx, "edge-1", configMapsGVR, "default", core.ListOptions{Limit: 100})
+
+	var tooLarge *core.ErrListTooLarge
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("err = %v, want *core.ErrListTooLarge", err)
+	}
+	if tooLarge.Limit != minListRetryLimit || tooLarge.SuggestedLimit != minListRetryLimit/2 {
+		t.Errorf("err = %+v, want limit %d and suggested limit %d", tooLarge, minListRetryLimit, minListRetryLimit/2)
+	}
+	if last := (*limits)[len(*limits)-1]; last != minListRetryLimit {
+		t.Errorf("last requested limit = %d, want %d", last, minListRetryLimit)
+	}
+}
+
+func TestResourceRepo_List_UnpaginatedTooLargeIsNotTruncated(t *testing.T) {
+	apiserver, limits := newTooLargeAPIServer(t, 100)
+	k := New(&fakeTunnel{addr: apiserver.URL}, &core.ClusterAccessPolicy{}, TransportConfig{})
+	ctx := core.WithUserInfo(context.Background(), core.UserInfo{Subject: "alice"})
+
+	list, err := NewResourceRepo(k).List(ctx, "edge-1", configMapsGVR, "default", core.ListOptions{})
+
+	var tooLarge *core.ErrListTooLarge
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("List = %v, %v; want *core.ErrListTooLarge rather than a partial list", list, err)
+	}
+	if tooLarge.Limit != 0 || tooLarge.SuggestedLimit != listRetryLimit {
+		t.Errorf("err = %+v, want no limit and suggested limit %d", tooLarge, listRetryLimit)
+	}
+	if len(*limits) != 1 {
+		t.Errorf("requested limits = %v, want the single unpaginated request", *limits)
+	}
+}
+
+func TestResourceRepo_List_ContinuedTooLargeIsRetried(t *testing.T) {
+	apiserver, limits := newTooLargeAPIServer(t, 100)
+	k := New(&fakeTunnel{addr: apiserver.URL}, &core.ClusterAccessPolicy{}, TransportConfig{})
+	ctx := core.WithUserInfo(context.Background(), core.UserInfo{Subject: "alice"})
+
+	list, err := NewResourceRepo(k).List(ctx, "edge-1", configMapsGVR, "default", core.ListOptions{Continue: "page-2"})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if want := []int64{0, 500, 250, 125, 62}; fmt.Sprint(*limits) != fmt.Sprint(want) {
+		t.Errorf("requested limits = %v, want %v", *limits, want)
+	}
+	if list.GetContinue() != "next-62" {
+		t.Errorf("continue = %q, want the smaller page's token", list.GetContinue())
+	}
+}
+
+func TestResourceRepo_Apply_ReportsFieldManagerConflicts(t *testing.T) {
+	var dryRun []string
+	apiserver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dryRun = append(dryRun, r.URL.Query().Get("dryRun"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Conflict","code":409,` +
+			`"message":"Apply failed with 2 conflicts: conflict with \"kubectl-edit\" using v1: .data.mode, conflict with \"helm\" using v1: .data.level",` +
+			`"details":{"name":"settings","kind":"configmaps","causes":[` +
+			`{"reason":"FieldManagerConflict","message":"conflict with \"kubectl-edit\" using v1","field":".data.mode"},` +
+			`{"reason":"FieldManagerConflict","message":"conflict with \"helm\" using v1","field":".data.level"}]}}`))
+	}))
+	t.Cleanup(apiserver.Close)
+	k := New(&fakeTunnel{addr: apiserver.URL}, &core.ClusterAccessPolicy{}, TransportConfig{})
+	ctx := core.WithUserInfo(context.Background(), core.UserInfo{Subject: "alice"})
+
+	manifest := []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\ndata:\n  mode: fast\n  level: debug\n")
+	_, err := NewResourceRepo(k).Apply(ctx, "edge-1", configMapsGVR, "default", "settings", manifest,
+		core.ApplyOptions{FieldManager: "otterscale-web-ui", DryRun: true})
+
+	var conflict *core.ErrApplyConflict
+	if !errors.As(err, &conflict) {
+		t.Fatalf("err = %v, want *core.ErrApplyConflict", err)
+	}
+	want := []core.ApplyConflict{
+		{Manager: "kubectl-edit", Field: ".data.mode", Message: `conflict with "kubectl-edit" using v1`},
+		{Manager: "helm", Field: ".data.level", Message: `conflict with "helm" using v1`},
+	}
+	if fmt.Sprint(conflict.Conflicts) != fmt.Sprint(want) {
+		t.Errorf("conflicts = %v, want %v", conflict.Conflicts, want)
+	}
+	if fmt.Sprint(conflict.Managers()) != "[helm kubectl-edit]" {
+		t.Errorf("managers = %v, want [helm kubectl-edit]", conflict.Managers())
+	}
+	if len(dryRun) != 1 || dryRun[0] != "All" {
+		t.Errorf("dryRun parameters = %v, want [All]", dryRun)
+	}
+}
+
+func TestResourceRepo_Create_ReportsNamespaceTerminating(t *testing.T) {
+	const status = `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Forbidden","code":403,` +
+		`"message":"configmaps \"settings\" is forbidden: unable to create new content in namespace default because it is being terminated",` +
+		`"details":{"name":"settings","kind":"configmaps","causes":[{"reason":"NamespaceTerminating",` +
+		`"message":"namespace default is being terminated","field":"metadata.namespace"}]}}`
+	apiserver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(status))
+	}))
+	t.Cleanup(apiserver.Close)
+	k := New(&fakeTunnel{addr: apiserver.URL}, &core.ClusterAccessPolicy{}, TransportConfig{})
+	ctx := core.WithUserInfo(context.Background(), core.UserInfo{Subject: "alice"})
+
+	manifest := []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n")
+	_, err := NewResourceRepo(k).Create(ctx, "edge-1", configMapsGVR, "default", manifest)
+
+	var terminating *core.ErrNamespaceTerminating
+	if !errors.As(err, &terminating) {
+		t.Fatalf("err = %v, want *core.ErrNamespaceTerminating", err)
+	}
+	if want := `namespace "default" is terminating`; err.Error() != want {
+		t.Errorf("message = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestResourceRepo_Create_ReportsWebhookDenial(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     string
+		wantReason string
+		wantCode   core.ErrorCode
+	}{
+		{
+			name: "denied with reason",
+			status: `{"kind":"Status","apiVersion":"v1","status":"Failure","code":400,` +
+				`"message":"admission webhook \"validate.policy.example.com\" denied the request: configmap settings must set label team"}`,
+			wantReason: "configmap settings must set label team",
+			wantCode:   core.ErrorCodeInvalidArgument,
+		},
+		{
+			name: "denied without explanation",
+			status: `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Forbidden","code":403,` +
+				`"message":"admission webhook \"validate.policy.example.com\" denied the request without explanation"}`,
+			wantCode: core.ErrorCodePermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiserver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var status struct{ Code int }
+				_ = json.Unmarshal([]byte(tt.status), &status)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(status.Code)
+				_, _ = w.Write([]byte(tt.status))
+			}))
+			t.Cleanup(apiserver.Close)
+			k := New(&fakeTunnel{addr: apiserver.URL}, &core.ClusterAccessPolicy{}, TransportConfig{})
+			ctx := core.WithUserInfo(context.Background(), core.UserInfo{Subject: "alice"})
+
+			manifest := []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n")
+			_, err := NewResourceRepo(k).Create(ctx, "edge-1", configMapsGVR, "default", manifest)
+
+			var denied *core.ErrAdmissionDenied
+			if !errors.As(err, &denied) {
+				t.Fatalf("err = %v, want *core.ErrAdmissionDenied", err)
+			}
+			if denied.Webhook != "validate.policy.example.com" || denied.Reason != tt.wantReason || denied.Code != tt.wantCode {
+				t.Errorf("denial = {%q %q %v}, want {validate.policy.example.com %q %v}",
+					denied.Webhook, denied.Reason, denied.Code, tt.wantReason, tt.wantCode)
+			}
+			want := "rejected by webhook validate.policy.example.com"
+			if tt.wantReason != "" {
+				want += ": " + tt.wantReason
+			}
+			if err.Error() != want {
+				t.Errorf("message = %q, want %q", err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestResourceRepo_MergePatch_SendsMergePatch(t *testing.T) {
+	var contentType, method, path string
+	var body []byte
+	apiserver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType, method, path = r.Header.Get("Content-Type"), r.Method, r.URL.Path
+		body, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"settings","namespace":"default","labels":{"team":"edge"}}}`))
+	}))
+	t.Cleanup(apiserver.Close)
+	k := New(&fakeTunnel{addr: apiserver.URL}, &core.ClusterAccessPolicy{}, TransportConfig{})
+	ctx := core.WithUserInfo(context.Background(), core.UserInfo{Subject: "alice"})
+
+	patch := []byte(`{"metadata":{"labels":{"team":"edge"}}}`)
+	obj, err := NewResourceRepo(k).MergePatch(ctx, "edge-1", configMapsGVR, "default", "settings", patch)
+	if err != nil {
+		t.Fatalf("MergePatch: %v", err)
+	}
+	if method != http.MethodPatch || path != "/api/v1/namespaces/default/configmaps/settings" {
+		t.Errorf("request = %s %s, want PATCH /api/v1/namespaces/default/configmaps/settings", method, path)
+	}
+	if contentType != "application/merge-patch+json" {
+		t.Errorf("Content-Type = %q, want application/merge-patch+json", contentType)
+	}
+	if string(body) != string(patch) {
+		t.Errorf("body = %s, want %s", body, patch)
+	}
+	if obj.GetLabels()["team"] != "edge" {
+		t.Errorf("labels = %v, want the patched object", obj.GetLabels())
+	}
+}
+
+func TestResourceRepo_Create_GenerateName(t *testing.T) {
+	var created atomic.Int32
+	apiserver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var obj map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&obj); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		// Mimic the API server: append a random suffix to generateName.
+		metadata := obj["metadata"].(map[string]any)
+		metadata["name"] = fmt.Sprintf("%s%05d", metadata["generateName"], created.Add(1))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(obj)
+	}))
+	t.Cleanup(apiserver.Close)
+	k := New(&fakeTunnel{addr: apiserver.URL}, &core.ClusterAccessPolicy{}, TransportConfig{})
+	ctx := core.WithUserInfo(context.Background(), core.UserInfo{Subject: "alice"})
+	repo := NewResourceRepo(k)
+
+	manifest := []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  generateName: settings-\n")
+	first, err := repo.Create(ctx, "edge-1", configMapsGVR, "default", manifest)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	second, err := repo.Create(ctx, "edge-1", configMapsGVR, "default", manifest)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if !strings.HasPrefix(first.GetName(), "settings-") || first.GetName() == second.GetName() {
+		t.Errorf("names = %q and %q, want distinct names generated from settings-", first.GetName(), second.GetName())
+	}
+}
+
+func TestResourceRepo_Create_RequiresExactlyOneName(t *testing.T) {
+	k := New(&fakeTunnel{addr: "http://127.0.0.1:1"}, &core.ClusterAccessPolicy{}, TransportConfig{})
+	ctx := core.WithUserInfo(context.Background(), core.UserInfo{Subject: "alice"})
+	repo := NewResourceRepo(k)
+
+	for name, manifest := range map[string]string{
+		"neither": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  namespace: default\n",
+		"both":    "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n  generateName: settings-\n",
+	} {
+		_, err := repo.Create(ctx, "edge-1", configMapsGVR, "default", []byte(manifest))
+		var invalid *core.ErrInvalidInput
+		if !errors.As(err, &invalid) {
+			t.Errorf("%s: error = %v, want ErrInvalidInput", name, err)
+		}
+	}
+}
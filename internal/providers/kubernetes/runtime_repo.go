@@ -16,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/httpstream"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -69,7 +70,16 @@ func (r *runtimeRepo) PodLogs(ctx context.Context, cluster, namespace, name stri
 		logOpts.LimitBytes = opts.LimitBytes
 	}
 
-	result, err := clientset.CoreV1().Pods(namespace).GetLogs(name, logOpts).Stream(ctx)
+	req := clientset.CoreV1().Pods(namespace).GetLogs(name, logOpts)
+	if r.kubernetes.transport.CompressStreams {
+		// The per-cluster transport leaves net/http's transparent
+		// gzip handling enabled: it sends Accept-Encoding: gzip and
+		// decompresses the agent's gzip response, so the stream
+		// returned here is always plain text.
+		req.SetHeader(core.StreamEncodingHeader, "gzip")
+	}
+
+	result, err := req.Stream(ctx)
 	return result, wrapK8sError(err)
 }
 
@@ -223,8 +233,10 @@ func (r *runtimeRepo) Restart(ctx context.Context, cluster string, gvr schema.Gr
 // ---------------------------------------------------------------------------
 
 // PortForward opens a port-forward session via SPDY and copies data
-// bidirectionally between the caller's stdin/stdout and the pod.
-// It waits for both copy directions to complete before returning.
+// bidirectionally between each stream's Stdin/Stdout and its port on
+// the pod. All ports share one SPDY connection, each with its own
+// error/data stream pair. It waits for every copy to complete before
+// returning.
 func (r *runtimeRepo) PortForward(ctx context.Context, cluster, namespace, name string, opts core.PortForwardOptions) error {
 	config, err := r.kubernetes.spdyConfig(ctx, cluster)
 	if err != nil {
@@ -254,93 +266,119 @@ func (r *runtimeRepo) PortForward(ctx context.Context, cluster, namespace, name
 	}
 	defer streamConn.Close()
 
-	portStr := strconv.FormatInt(int64(opts.Port), 10)
-	requestID := "0"
-
-	// Create error stream.
-	errorHeaders := http.Header{}
-	errorHeaders.Set(corev1.StreamType, corev1.StreamTypeError)
-	errorHeaders.Set(corev1.PortHeader, portStr)
-	errorHeaders.Set(corev1.PortForwardRequestIDHeader, requestID)
-
-	errorStream, err := streamConn.CreateStream(errorHeaders)
-	if err != nil {
-		return &core.DomainError{Code: core.ErrorCodeInternal, Message: "create error stream", Cause: err}
-	}
-	// Close the write direction of the error stream; we only read from it.
-	defer errorStream.Close()
-
-	// Create data stream.
-	dataHeaders := http.Header{}
-	dataHeaders.Set(corev1.StreamType, corev1.StreamTypeData)
-	dataHeaders.Set(corev1.PortHeader, portStr)
-	dataHeaders.Set(corev1.PortForwardRequestIDHeader, requestID)
+	return forwardPorts(ctx, streamConn, opts.Streams)
+}
 
-	dataStream, err := streamConn.CreateStream(dataHeaders)
-	if err != nil {
-		return &core.DomainError{Code: core.ErrorCodeInternal, Message: "create data stream", Cause: err}
+// forwardPorts multiplexes streams over conn. Each port gets its own
+// error and data stream, identified by a distinct request ID, so that
+// data on one port never reaches another. A kubelet error on one port
+// closes only that port's data stream; cancelling ctx or a copy
+// failure closes the whole connection.
+func forwardPorts(ctx context.Context, conn httpstream.Connection, streams []core.PortForwardStream) error {
+	errorStreams := make([]httpstream.Stream, len(streams))
+	dataStreams := make([]httpstream.Stream, len(streams))
+	for i, pf := range streams {
+		errorStream, dataStream, err := createPortStreams(conn, pf.Port, strconv.Itoa(i))
+		if err != nil {
+			return err
+		}
+		// Close the write direction of the error stream; we only read from it.
+		defer errorStream.Close()
+		defer dataStream.Close()
+		errorStreams[i], dataStreams[i] = errorStream, dataStream
 	}
-	defer dataStream.Close()
 
 	// Track all goroutines with a WaitGroup so we guarantee every
-	// goroutine has exited before PortForward returns, preventing
-	// goroutine leaks.
+	// goroutine has exited before returning, preventing goroutine
+	// leaks.
 	var wg sync.WaitGroup
-
-	// Check for immediate errors from kubelet.
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		buf := make([]byte, 1024)
-		n, _ := errorStream.Read(buf)
-		if n > 0 {
-			// Error from kubelet; close data stream to unblock copies.
-			if err := dataStream.Close(); err != nil {
-				slog.Warn("failed to close data stream after kubelet error", "error", err)
+	errCh := make(chan error, 2*len(streams))
+	for i, pf := range streams {
+		errorStream, dataStream := errorStreams[i], dataStreams[i]
+
+		// Check for immediate errors from kubelet.
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buf := make([]byte, 1024)
+			n, _ := errorStream.Read(buf)
+			if n > 0 {
+				slog.Warn("port-forward error from kubelet", "port", pf.Port, "error", string(buf[:n]))
+				// Close the data stream to unblock this port's copies.
+				if err := dataStream.Close(); err != nil {
+					slog.Warn("failed to close data stream after kubelet error", "port", pf.Port, "error", err)
+				}
 			}
-		}
-	}()
-
-	// Bidirectional copy — wait for BOTH directions to complete.
-	errCh := make(chan error, 2)
-
-	wg.Add(2)
-	go func() {
-		defer wg.Done()
-		_, err := io.Copy(dataStream, opts.Stdin)
-		errCh <- err
-	}()
-
-	go func() {
-		defer wg.Done()
-		_, err := io.Copy(opts.Stdout, dataStream)
-		errCh <- err
-	}()
+		}()
+
+		// Bidirectional copy — wait for BOTH directions to complete.
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := io.Copy(dataStream, pf.Stdin)
+			errCh <- err
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := io.Copy(pf.Stdout, dataStream)
+			errCh <- err
+		}()
+	}
 
 	var firstErr error
-	for i := 0; i < 2; i++ {
+	for range 2 * len(streams) {
 		select {
 		case <-ctx.Done():
 			// Close the stream connection to unblock all goroutines,
 			// then wait for them to finish.
-			streamConn.Close()
+			conn.Close()
 			wg.Wait()
 			return ctx.Err()
 		case err := <-errCh:
 			if err != nil && firstErr == nil {
 				firstErr = err
-				// Close the stream connection so the other direction
-				// terminates as well.
-				streamConn.Close()
+				// Close the stream connection so the other copies
+				// terminate as well.
+				conn.Close()
 			}
 		}
 	}
 
-	// Wait for the error stream goroutine to exit before returning.
 	wg.Wait()
 	return firstErr
 }
 
+// createPortStreams opens the error and data streams for port. The
+// streams are tied together by requestID, which must be unique per
+// port on the connection.
+func createPortStreams(conn httpstream.Connection, port int32, requestID string) (errorStream, dataStream httpstream.Stream, err error) {
+	portStr := strconv.FormatInt(int64(port), 10)
+
+	// Create error stream.
+	errorHeaders := http.Header{}
+	errorHeaders.Set(corev1.StreamType, corev1.StreamTypeError)
+	errorHeaders.Set(corev1.PortHeader, portStr)
+	errorHeaders.Set(corev1.PortForwardRequestIDHeader, requestID)
+
+	errorStream, err = conn.CreateStream(errorHeaders)
+	if err != nil {
+		return nil, nil, &core.DomainError{Code: core.ErrorCodeInternal, Message: "create error stream", Cause: err}
+	}
+
+	// Create data stream.
+	dataHeaders := http.Header{}
+	dataHeaders.Set(corev1.StreamType, corev1.StreamTypeData)
+	dataHeaders.Set(corev1.PortHeader, portStr)
+	dataHeaders.Set(corev1.PortForwardRequestIDHeader, requestID)
+
+	dataStream, err = conn.CreateStream(dataHeaders)
+	if err != nil {
+		errorStream.Close()
+		return nil, nil, &core.DomainError{Code: core.ErrorCodeInternal, Message: "create data stream", Cause: err}
+	}
+	return errorStream, dataStream, nil
+}
+
 // portForwardProtocolV1 is the subprotocol used for Kubernetes port
 // forwarding over SPDY.
 const portForwardProtocolV1 = "portforward.k8s.io"
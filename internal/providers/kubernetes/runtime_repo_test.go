@@ -0,0 +1,145 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// fakeStream is the client end of an in-memory stream.
+type fakeStream struct {
+	net.Conn
+	headers http.Header
+	id      uint32
+}
+
+func (s *fakeStream) Reset() error         { return s.Close() }
+func (s *fakeStream) Headers() http.Header { return s.headers }
+func (s *fakeStream) Identifier() uint32   { return s.id }
+
+// fakeKubeletConn is an httpstream.Connection whose server side acts
+// like the kubelet: data streams are paired with their error stream by
+// request ID, and every chunk written to a data stream is echoed back
+// tagged with the stream's port header.
+type fakeKubeletConn struct {
+	mu       sync.Mutex
+	streams  []net.Conn
+	requests map[string]string // request ID -> port of the first stream
+	closed   chan bool
+	once     sync.Once
+}
+
+func newFakeKubeletConn() *fakeKubeletConn {
+	return &fakeKubeletConn{requests: map[string]string{}, closed: make(chan bool)}
+}
+
+func (c *fakeKubeletConn) CreateStream(headers http.Header) (httpstream.Stream, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	port := headers.Get(corev1.PortHeader)
+	id := headers.Get(corev1.PortForwardRequestIDHeader)
+	if prev, ok := c.requests[id]; ok && prev != port {
+		return nil, fmt.Errorf("request ID %s reused for ports %s and %s", id, prev, port)
+	}
+	c.requests[id] = port
+
+	client, server := net.Pipe()
+	c.streams = append(c.streams, client, server)
+	if headers.Get(corev1.StreamType) == corev1.StreamTypeData {
+		go func() {
+			buf := make([]byte, 1024)
+			for {
+				n, err := server.Read(buf)
+				if err != nil {
+					return
+				}
+				if _, err := fmt.Fprintf(server, "[%s]%s", port, buf[:n]); err != nil {
+					return
+				}
+			}
+		}()
+	}
+	return &fakeStream{Conn: client, headers: headers, id: uint32(len(c.streams))}, nil
+}
+
+func (c *fakeKubeletConn) Close() error {
+	c.once.Do(func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for _, s := range c.streams {
+			s.Close()
+		}
+		close(c.closed)
+	})
+	return nil
+}
+
+func (c *fakeKubeletConn) CloseChan() <-chan bool             { return c.closed }
+func (c *fakeKubeletConn) SetIdleTimeout(time.Duration)       {}
+func (c *fakeKubeletConn) RemoveStreams(...httpstream.Stream) {}
+
+func TestForwardPorts_IsolatesPorts(t *testing.T) {
+	conn := newFakeKubeletConn()
+
+	type port struct {
+		number int32
+		input  string
+		out    *io.PipeReader
+	}
+	ports := []*port{{number: 5432, input: "SELECT 1"}, {number: 6379, input: "PING"}}
+
+	var streams []core.PortForwardStream
+	for _, p := range ports {
+		outR, outW := io.Pipe()
+		p.out = outR
+		streams = append(streams, core.PortForwardStream{
+			Port:   p.number,
+			Stdin:  strings.NewReader(p.input),
+			Stdout: outW,
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- forwardPorts(ctx, conn, streams) }()
+
+	for _, p := range ports {
+		want := fmt.Sprintf("[%d]%s", p.number, p.input)
+		got := make([]byte, len(want))
+		if _, err := io.ReadFull(p.out, got); err != nil {
+			t.Fatalf("port %d: read: %v", p.number, err)
+		}
+		if string(got) != want {
+			t.Errorf("port %d received %q, want %q", p.number, got, want)
+		}
+	}
+
+	// Cancelling closes every stream and returns.
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("forwardPorts error = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("forwardPorts did not return after cancel")
+	}
+	select {
+	case <-conn.CloseChan():
+	default:
+		t.Error("connection should be closed after cancel")
+	}
+}
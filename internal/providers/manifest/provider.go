@@ -9,18 +9,45 @@ import (
 )
 
 // ProvideAgentManifestConfig is a Wire provider that extracts the
-// external URLs from the server configuration and derives an HMAC key
-// for signing stateless manifest tokens. The HMAC key is derived from
-// the CA's private key via HKDF, so it is deterministic for the same
-// CA and survives restarts without separate persistence.
+// external URLs and OIDC settings from the server configuration and
+// derives an HMAC key for signing stateless manifest tokens. The HMAC
+// key is derived from the CA's private key via HKDF, so it is
+// deterministic for the same CA and survives restarts without separate
+// persistence.
 func ProvideAgentManifestConfig(conf *config.Config, ca *pki.CA) (core.AgentManifestConfig, error) {
 	hmacKey, err := ca.DeriveHMACKey("manifest-token")
 	if err != nil {
 		return core.AgentManifestConfig{}, fmt.Errorf("derive HMAC key: %w", err)
 	}
 	return core.AgentManifestConfig{
-		ServerURL: conf.ServerExternalURL(),
-		TunnelURL: conf.ServerExternalTunnelURL(),
-		HMACKey:   hmacKey,
+		ServerURL:     conf.ServerExternalURL(),
+		TunnelURL:     conf.ServerExternalTunnelURL(),
+		HMACKey:       hmacKey,
+		OIDCIssuerURL: conf.ServerKeycloakRealmURL(),
+		OIDCClientID:  conf.ServerKeycloakClientID(),
+		ClockSkew:     conf.ServerManifestClockSkew(),
 	}, nil
 }
+
+// ProvideRenderer is a Wire provider that constructs a Renderer using
+// the RBAC name strategy selected in the server configuration.
+func ProvideRenderer(conf *config.Config) (*Renderer, error) {
+	sanitizer, err := nameSanitizerFor(conf.ServerManifestNameStrategy())
+	if err != nil {
+		return nil, err
+	}
+	return NewRenderer(WithNameSanitizer(sanitizer)), nil
+}
+
+// nameSanitizerFor maps a configured strategy name to its
+// NameSanitizer.
+func nameSanitizerFor(strategy string) (NameSanitizer, error) {
+	switch strategy {
+	case "", "sanitize":
+		return sanitizeK8sName, nil
+	case "email-localpart":
+		return EmailLocalPart, nil
+	default:
+		return nil, fmt.Errorf("unknown manifest name strategy %q", strategy)
+	}
+}
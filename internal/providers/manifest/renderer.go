@@ -13,6 +13,9 @@ import (
 	"strings"
 	"text/template"
 
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
 	"github.com/otterscale/otterscale-agent/internal/core"
 )
 
@@ -21,16 +24,44 @@ import (
 // every sanitizeK8sName call.
 var reNonAlphaNum = regexp.MustCompile(`[^a-z0-9]+`)
 
+// NameSanitizer maps a user identity (e.g. an OIDC subject or email)
+// to the name component used for the user's ClusterRoleBinding. Its
+// output is always passed through the default sanitizer, so a
+// strategy may return any string and the rendered name still
+// satisfies the Kubernetes name rules.
+type NameSanitizer func(userName string) string
+
 // Renderer implements core.ManifestRenderer by executing a Go
 // text/template that produces multi-document YAML.
-type Renderer struct{}
+type Renderer struct {
+	sanitizeName NameSanitizer
+}
 
 // Verify at compile time that Renderer satisfies core.ManifestRenderer.
 var _ core.ManifestRenderer = (*Renderer)(nil)
 
+// Option configures a Renderer at construction time.
+type Option func(*Renderer)
+
+// WithNameSanitizer replaces the strategy used to derive RBAC names
+// from user identities. When not set, sanitizeK8sName is used.
+func WithNameSanitizer(fn NameSanitizer) Option {
+	return func(r *Renderer) {
+		if fn != nil {
+			r.sanitizeName = fn
+		}
+	}
+}
+
 // NewRenderer returns a new manifest Renderer.
-func NewRenderer() *Renderer {
-	return &Renderer{}
+func NewRenderer(opts ...Option) *Renderer {
+	r := &Renderer{
+		sanitizeName: sanitizeK8sName,
+	}
+	for _, o := range opts {
+		o(r)
+	}
+	return r
 }
 
 // RenderAgentManifest produces a multi-document YAML manifest for
@@ -42,7 +73,7 @@ func (r *Renderer) RenderAgentManifest(params core.ManifestParams) (string, erro
 	data := agentManifestData{
 		Cluster:       params.Cluster,
 		UserName:      params.UserName,
-		SanitizedUser: sanitizeK8sName(params.UserName),
+		SanitizedUser: r.userRBACName(params.UserName),
 		Image:         params.Image,
 		ServerURL:     params.ServerURL,
 		TunnelURL:     params.TunnelURL,
@@ -55,6 +86,77 @@ func (r *Renderer) RenderAgentManifest(params core.ManifestParams) (string, erro
 	return buf.String(), nil
 }
 
+// RenderKubeconfig produces a kubeconfig YAML whose cluster and
+// context are named after params.Cluster, whose cluster is served at
+// params.APIServerURL and whose user authenticates
+// through the kubelogin (kubectl oidc-login) exec plugin. The
+// kubeconfig is built from the clientcmd types rather than a template
+// so that it always round-trips through kubectl's own loader.
+func (r *Renderer) RenderKubeconfig(params core.KubeconfigParams) (string, error) {
+	cfg := clientcmdapi.NewConfig()
+	cfg.Clusters[params.Cluster] = &clientcmdapi.Cluster{
+		Server: params.APIServerURL,
+	}
+	cfg.AuthInfos[kubeconfigUserName] = &clientcmdapi.AuthInfo{
+		Exec: &clientcmdapi.ExecConfig{
+			APIVersion: "client.authentication.k8s.io/v1",
+			Command:    "kubectl",
+			Args: []string{
+				"oidc-login",
+				"get-token",
+				"--oidc-issuer-url=" + params.OIDCIssuerURL,
+				"--oidc-client-id=" + params.OIDCClientID,
+			},
+			InstallHint:     kubeloginInstallHint,
+			InteractiveMode: clientcmdapi.IfAvailableExecInteractiveMode,
+		},
+	}
+	cfg.Contexts[params.Cluster] = &clientcmdapi.Context{
+		Cluster:  params.Cluster,
+		AuthInfo: kubeconfigUserName,
+	}
+	cfg.CurrentContext = params.Cluster
+
+	out, err := clientcmd.Write(*cfg)
+	if err != nil {
+		return "", fmt.Errorf("render kubeconfig: %w", err)
+	}
+	return string(out), nil
+}
+
+// kubeconfigUserName is the user entry shared by all generated
+// kubeconfigs. The actual identity comes from the OIDC token.
+const kubeconfigUserName = "otterscale-oidc"
+
+// kubeloginInstallHint is shown by kubectl when the exec plugin is
+// missing.
+const kubeloginInstallHint = `The kubelogin plugin is required to authenticate with otterscale.
+Install it with "kubectl krew install oidc-login".
+See https://github.com/int128/kubelogin for other installation methods.`
+
+// userRBACName applies the configured NameSanitizer and normalises
+// its output with sanitizeK8sName. The default strategy is idempotent
+// under this normalisation, so its output is unchanged. If a custom
+// strategy returns nothing usable, the hash fallback of the original
+// user name is used so the result stays stable per user.
+func (r *Renderer) userRBACName(userName string) string {
+	name := r.sanitizeName(userName)
+	if reNonAlphaNum.ReplaceAllString(strings.ToLower(name), "") == "" {
+		return sanitizeK8sName(userName)
+	}
+	return sanitizeK8sName(name)
+}
+
+// EmailLocalPart is a NameSanitizer that names RBAC objects after the
+// local part of an email-style identity ("jane.doe@example.com" ->
+// "jane-doe"). Identities without an "@" are used as-is. Note that
+// users with the same local part in different domains map to the
+// same name.
+func EmailLocalPart(userName string) string {
+	local, _, _ := strings.Cut(userName, "@")
+	return local
+}
+
 // agentManifestData holds the template parameters for agent manifest
 // generation.
 type agentManifestData struct {
@@ -0,0 +1,167 @@
+package manifest
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// reK8sName is the RFC 1123 label rule that RBAC name components must
+// satisfy.
+var reK8sName = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+func assertK8sName(t *testing.T, name string) {
+	t.Helper()
+	if len(name) > 63 || !reK8sName.MatchString(name) {
+		t.Errorf("%q is not a valid Kubernetes name component", name)
+	}
+}
+
+func testParams(userName string) core.ManifestParams {
+	return core.ManifestParams{
+		Cluster:   "edge-1",
+		UserName:  userName,
+		Image:     "ghcr.io/otterscale/otterscale:v1.2.3",
+		ServerURL: "https://api.example.com",
+		TunnelURL: "https://tunnel.example.com",
+	}
+}
+
+func TestRenderer_DefaultSanitizer(t *testing.T) {
+	r := NewRenderer()
+
+	out, err := r.RenderAgentManifest(testParams("Jane.Doe@Example.com"))
+	if err != nil {
+		t.Fatalf("RenderAgentManifest: %v", err)
+	}
+	if !strings.Contains(out, "name: otterscale-jane-doe-example-com-cluster-admin") {
+		t.Errorf("expected default sanitized binding name in manifest:\n%s", out)
+	}
+}
+
+func TestRenderer_CustomSanitizer(t *testing.T) {
+	tests := []struct {
+		name      string
+		sanitizer NameSanitizer
+		userName  string
+		want      string
+	}{
+		{"email local part", EmailLocalPart, "jane.doe@example.com", "jane-doe"},
+		{"no at sign", EmailLocalPart, "svc_deployer", "svc-deployer"},
+		{"invalid output is normalised", func(string) string { return "  Team/Ops__Admins  " }, "x", "team-ops-admins"},
+		{"overlong output is truncated", func(string) string { return strings.Repeat("a", 100) }, "x", strings.Repeat("a", 63)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRenderer(WithNameSanitizer(tt.sanitizer))
+
+			got := r.userRBACName(tt.userName)
+			if got != tt.want {
+				t.Errorf("userRBACName(%q) = %q, want %q", tt.userName, got, tt.want)
+			}
+			assertK8sName(t, got)
+
+			out, err := r.RenderAgentManifest(testParams(tt.userName))
+			if err != nil {
+				t.Fatalf("RenderAgentManifest: %v", err)
+			}
+			if !strings.Contains(out, "name: otterscale-"+tt.want+"-cluster-admin") {
+				t.Errorf("expected binding name for %q in manifest", tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderer_AllSpecialFallback(t *testing.T) {
+	tests := []struct {
+		name      string
+		sanitizer NameSanitizer
+		userName  string
+	}{
+		{"default strategy", nil, "@@@!!!"},
+		{"custom strategy returns empty", func(string) string { return "" }, "alice@example.com"},
+		{"custom strategy returns specials", func(string) string { return "---" }, "alice@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRenderer(WithNameSanitizer(tt.sanitizer))
+
+			got := r.userRBACName(tt.userName)
+			assertK8sName(t, got)
+			if want := sanitizeK8sName(tt.userName); got != want {
+				t.Errorf("userRBACName(%q) = %q, want %q", tt.userName, got, want)
+			}
+			if again := r.userRBACName(tt.userName); again != got {
+				t.Errorf("fallback is not stable: %q != %q", again, got)
+			}
+		})
+	}
+
+	if got := sanitizeK8sName("@@@!!!"); !strings.HasPrefix(got, "u-") {
+		t.Errorf("sanitizeK8sName(all specials) = %q, want u-<hash>", got)
+	}
+}
+
+func TestNameSanitizerFor(t *testing.T) {
+	for _, strategy := range []string{"", "sanitize", "email-localpart"} {
+		if _, err := nameSanitizerFor(strategy); err != nil {
+			t.Errorf("nameSanitizerFor(%q): %v", strategy, err)
+		}
+	}
+	if _, err := nameSanitizerFor("bogus"); err == nil {
+		t.Error("expected error for unknown strategy")
+	}
+}
+
+func TestRenderer_RenderKubeconfig(t *testing.T) {
+	r := NewRenderer()
+
+	out, err := r.RenderKubeconfig(core.KubeconfigParams{
+		Cluster:       "edge-1",
+		APIServerURL:  "https://api.example.com/clusters/edge-1",
+		OIDCIssuerURL: "https://sso.example.com/realms/otterscale",
+		OIDCClientID:  "otterscale-server",
+	})
+	if err != nil {
+		t.Fatalf("RenderKubeconfig: %v", err)
+	}
+
+	cfg, err := clientcmd.Load([]byte(out))
+	if err != nil {
+		t.Fatalf("kubeconfig does not load: %v\n%s", err, out)
+	}
+	if cfg.CurrentContext != "edge-1" {
+		t.Errorf("current-context = %q, want %q", cfg.CurrentContext, "edge-1")
+	}
+	ctx, ok := cfg.Contexts["edge-1"]
+	if !ok {
+		t.Fatalf("context %q missing:\n%s", "edge-1", out)
+	}
+	cluster, ok := cfg.Clusters[ctx.Cluster]
+	if !ok || ctx.Cluster != "edge-1" {
+		t.Fatalf("context cluster = %q, want %q", ctx.Cluster, "edge-1")
+	}
+	if cluster.Server != "https://api.example.com/clusters/edge-1" {
+		t.Errorf("server = %q, want %q", cluster.Server, "https://api.example.com/clusters/edge-1")
+	}
+
+	user, ok := cfg.AuthInfos[ctx.AuthInfo]
+	if !ok || user.Exec == nil {
+		t.Fatalf("context user %q has no exec stanza", ctx.AuthInfo)
+	}
+	args := strings.Join(user.Exec.Args, " ")
+	for _, want := range []string{
+		"--oidc-issuer-url=https://sso.example.com/realms/otterscale",
+		"--oidc-client-id=otterscale-server",
+	} {
+		if !strings.Contains(args, want) {
+			t.Errorf("exec args %q missing %q", args, want)
+		}
+	}
+}
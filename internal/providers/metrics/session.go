@@ -0,0 +1,61 @@
+// Package metrics provides OpenTelemetry-backed implementations of the
+// metrics interfaces of the core layer.
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// sessionDurationBuckets are the histogram bucket boundaries, in
+// seconds, of session durations, spanning quick one-off commands to
+// interactive shells and port-forwards held open for hours.
+var sessionDurationBuckets = []float64{1, 5, 15, 30, 60, 300, 900, 1800, 3600, 7200, 14400}
+
+// sessionBytesBuckets are the histogram bucket boundaries of the bytes
+// transferred per session, from 1 KiB to 1 GiB.
+var sessionBytesBuckets = []float64{1 << 10, 16 << 10, 256 << 10, 1 << 20, 16 << 20, 256 << 20, 1 << 30}
+
+// SessionMetrics records exec and port-forward sessions as
+// histograms of their duration and of the bytes they transferred,
+// labelled by session kind.
+type SessionMetrics struct {
+	duration metric.Float64Histogram
+	bytes    metric.Int64Histogram
+}
+
+// NewSessionMetrics creates the session histograms with meter.
+func NewSessionMetrics(meter metric.Meter) (*SessionMetrics, error) {
+	duration, err := meter.Float64Histogram("otterscale.session.duration",
+		metric.WithDescription("Duration of exec and port-forward sessions"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(sessionDurationBuckets...))
+	if err != nil {
+		return nil, err
+	}
+	bytes, err := meter.Int64Histogram("otterscale.session.transferred",
+		metric.WithDescription("Bytes transferred per exec and port-forward session in both directions"),
+		metric.WithUnit("By"),
+		metric.WithExplicitBucketBoundaries(sessionBytesBuckets...))
+	if err != nil {
+		return nil, err
+	}
+	return &SessionMetrics{duration: duration, bytes: bytes}, nil
+}
+
+var _ core.SessionMetrics = (*SessionMetrics)(nil)
+
+// SessionEnded records the duration and transferred bytes of a
+// session of kind.
+func (m *SessionMetrics) SessionEnded(kind core.SessionKind, duration time.Duration, bytes int64) {
+	// Sessions end outside of any request, e.g. in the reaper.
+	ctx := context.Background()
+	attrs := metric.WithAttributes(attribute.String("kind", string(kind)))
+	m.duration.Record(ctx, duration.Seconds(), attrs)
+	m.bytes.Record(ctx, bytes, attrs)
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+func TestSessionMetrics_RecordsEndedSessions(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	m, err := NewSessionMetrics(provider.Meter("test"))
+	if err != nil {
+		t.Fatalf("NewSessionMetrics: %v", err)
+	}
+
+	m.SessionEnded(core.SessionKindExec, 90*time.Second, 4096)
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	histograms := map[string]metricdata.HistogramDataPoint[float64]{}
+	bytes := map[string]metricdata.HistogramDataPoint[int64]{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, metric := range sm.Metrics {
+			switch data := metric.Data.(type) {
+			case metricdata.Histogram[float64]:
+				histograms[metric.Name] = data.DataPoints[0]
+			case metricdata.Histogram[int64]:
+				bytes[metric.Name] = data.DataPoints[0]
+			}
+		}
+	}
+
+	duration, ok := histograms["otterscale.session.duration"]
+	if !ok {
+		t.Fatalf("no duration histogram in %v", rm.ScopeMetrics)
+	}
+	if duration.Count != 1 || duration.Sum != 90 {
+		t.Errorf("duration count %d, sum %v, want 1 session of 90s", duration.Count, duration.Sum)
+	}
+	if kind, _ := duration.Attributes.Value("kind"); kind.AsString() != "exec" {
+		t.Errorf("duration kind = %q, want exec", kind.AsString())
+	}
+	if transferred := bytes["otterscale.session.transferred"]; transferred.Count != 1 || transferred.Sum != 4096 {
+		t.Errorf("transferred count %d, sum %d, want 1 session of 4096 bytes", transferred.Count, transferred.Sum)
+	}
+}
@@ -0,0 +1,131 @@
+package otterscale
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+)
+
+// AgentID identifies an agent to the fleet server. It is a distinct
+// type so that Wire can inject it unambiguously.
+type AgentID string
+
+// hostname is os.Hostname, replaced in tests.
+var hostname = os.Hostname
+
+// clusterUIDTimeout bounds the lookup of the kube-system namespace.
+const clusterUIDTimeout = 10 * time.Second
+
+// ResolveAgentID determines the agent's identity. A non-empty
+// configured ID always wins. Otherwise the ID persisted in file by a
+// previous run is reused. Without one, the ID is the UID returned by
+// clusterUID, if not nil, which stays the same for every pod of the
+// agent, so the identity survives rescheduling and self-updates even
+// though the agent's file system does not. When the UID is not
+// available either, the hostname is used, or a random ID when the
+// hostname is unavailable. A derived ID is persisted to file.
+//
+// Failing to look up the UID or to persist the ID is logged but not
+// fatal: the agent can still register, it just may not keep its
+// identity across restarts.
+func ResolveAgentID(configured, file string, clusterUID func() (string, error)) (AgentID, error) {
+	if id := strings.TrimSpace(configured); id != "" {
+		return AgentID(id), nil
+	}
+
+	if file != "" {
+		data, err := os.ReadFile(file)
+		switch {
+		case err == nil:
+			if id := strings.TrimSpace(string(data)); id != "" {
+				return AgentID(id), nil
+			}
+		case !errors.Is(err, os.ErrNotExist):
+			return "", fmt.Errorf("read agent ID file: %w", err)
+		}
+	}
+
+	var id string
+	if clusterUID != nil {
+		uid, err := clusterUID()
+		if err != nil {
+			slog.Warn("cluster UID unavailable, falling back to the hostname as agent ID", "error", err)
+		}
+		id = uid
+	}
+	if id == "" {
+		var err error
+		id, err = hostname()
+		if err != nil || id == "" {
+			slog.Warn("hostname unavailable, generating a random agent ID", "error", err)
+			if id, err = randomAgentID(); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if file != "" {
+		if err := writeAgentID(file, id); err != nil {
+			slog.Warn("failed to persist agent ID", "file", file, "error", err)
+		}
+	}
+
+	return AgentID(id), nil
+}
+
+// KubeSystemUID returns a function that looks up the UID of the
+// kube-system namespace of the cluster cfg points at. The namespace
+// lives as long as the cluster, so its UID identifies the cluster to
+// every agent pod.
+func KubeSystemUID(cfg *rest.Config) func() (string, error) {
+	return func() (string, error) {
+		client, err := clientcorev1.NewForConfig(cfg)
+		if err != nil {
+			return "", fmt.Errorf("create namespace client: %w", err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), clusterUIDTimeout)
+		defer cancel()
+		ns, err := client.Namespaces().Get(ctx, metav1.NamespaceSystem, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("get %s namespace: %w", metav1.NamespaceSystem, err)
+		}
+		return string(ns.UID), nil
+	}
+}
+
+// randomAgentID returns "agent-" followed by 16 random hex characters.
+func randomAgentID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate agent ID: %w", err)
+	}
+	return "agent-" + hex.EncodeToString(b), nil
+}
+
+// writeAgentID writes id to file via a temporary file and rename so
+// that a crash mid-write cannot leave a truncated ID behind.
+func writeAgentID(file, id string) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return fmt.Errorf("create agent ID dir: %w", err)
+	}
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, []byte(id+"\n"), 0600); err != nil {
+		return fmt.Errorf("write agent ID: %w", err)
+	}
+	if err := os.Rename(tmp, file); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("rename agent ID file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package otterscale
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func stubHostname(t *testing.T, name string, err error) {
+	t.Helper()
+	orig := hostname
+	hostname = func() (string, error) { return name, err }
+	t.Cleanup(func() { hostname = orig })
+}
+
+func TestResolveAgentID_ConfiguredTakesPrecedence(t *testing.T) {
+	stubHostname(t, "node-a", nil)
+	file := filepath.Join(t.TempDir(), "agent-id")
+	if err := os.WriteFile(file, []byte("persisted\n"), 0600); err != nil {
+		t.Fatalf("seed ID file: %v", err)
+	}
+
+	id, err := ResolveAgentID("configured", file, nil)
+	if err != nil {
+		t.Fatalf("ResolveAgentID: %v", err)
+	}
+	if id != "configured" {
+		t.Errorf("id = %q, want %q", id, "configured")
+	}
+}
+
+func TestResolveAgentID_PersistedSurvivesRestart(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "state", "agent-id")
+
+	// First run: no hostname, so a random ID is generated and persisted.
+	stubHostname(t, "", errors.New("no hostname"))
+	first, err := ResolveAgentID("", file, nil)
+	if err != nil {
+		t.Fatalf("first ResolveAgentID: %v", err)
+	}
+	if !strings.HasPrefix(string(first), "agent-") {
+		t.Errorf("first id = %q, want a random agent- ID", first)
+	}
+
+	// Restart with a different hostname: the persisted ID is reused.
+	stubHostname(t, "node-b", nil)
+	second, err := ResolveAgentID("", file, nil)
+	if err != nil {
+		t.Fatalf("second ResolveAgentID: %v", err)
+	}
+	if second != first {
+		t.Errorf("id after restart = %q, want %q", second, first)
+	}
+}
+
+func TestResolveAgentID_FallsBackToHostname(t *testing.T) {
+	stubHostname(t, "node-a", nil)
+	file := filepath.Join(t.TempDir(), "agent-id")
+
+	id, err := ResolveAgentID("", file, nil)
+	if err != nil {
+		t.Fatalf("ResolveAgentID: %v", err)
+	}
+	if id != "node-a" {
+		t.Errorf("id = %q, want %q", id, "node-a")
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("read ID file: %v", err)
+	}
+	if strings.TrimSpace(string(data)) != "node-a" {
+		t.Errorf("persisted id = %q, want %q", data, "node-a")
+	}
+}
+
+func TestResolveAgentID_PrefersClusterUIDOverHostname(t *testing.T) {
+	stubHostname(t, "otterscale-agent-7d9f-abcde", nil)
+	file := filepath.Join(t.TempDir(), "agent-id")
+	uid := func() (string, error) { return "3f0c6a52-8f7e-4b0e-9d59-1b2c3d4e5f60", nil }
+
+	id, err := ResolveAgentID("", file, uid)
+	if err != nil {
+		t.Fatalf("ResolveAgentID: %v", err)
+	}
+	if id != "3f0c6a52-8f7e-4b0e-9d59-1b2c3d4e5f60" {
+		t.Errorf("id = %q, want the cluster UID", id)
+	}
+
+	// A new pod, with a new hostname and without the file, derives
+	// the same ID.
+	stubHostname(t, "otterscale-agent-7d9f-fghij", nil)
+	again, err := ResolveAgentID("", filepath.Join(t.TempDir(), "agent-id"), uid)
+	if err != nil {
+		t.Fatalf("ResolveAgentID after rescheduling: %v", err)
+	}
+	if again != id {
+		t.Errorf("id after rescheduling = %q, want %q", again, id)
+	}
+}
+
+func TestResolveAgentID_ClusterUIDUnavailable(t *testing.T) {
+	stubHostname(t, "node-a", nil)
+
+	id, err := ResolveAgentID("", "", func() (string, error) { return "", errors.New("forbidden") })
+	if err != nil {
+		t.Fatalf("ResolveAgentID: %v", err)
+	}
+	if id != "node-a" {
+		t.Errorf("id = %q, want the hostname %q", id, "node-a")
+	}
+}
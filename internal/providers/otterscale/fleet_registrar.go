@@ -7,7 +7,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"os"
 	"time"
 
 	pb "github.com/otterscale/otterscale-agent/api/fleet/v1"
@@ -30,19 +29,15 @@ type fleetRegistrar struct {
 // A fresh ECDSA P-256 key pair and CSR are generated on every
 // Register call to ensure forward secrecy — a compromised key from a
 // previous session cannot decrypt traffic from a new session.
-func NewFleetRegistrar(version core.Version) (core.TunnelConsumer, error) {
-	agentID, err := os.Hostname()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get hostname: %w", err)
-	}
-
+// agentID is resolved by ResolveAgentID.
+func NewFleetRegistrar(version core.Version, agentID AgentID) core.TunnelConsumer {
 	return &fleetRegistrar{
-		agentID:      agentID,
+		agentID:      string(agentID),
 		agentVersion: string(version),
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-	}, nil
+	}
 }
 
 var _ core.TunnelConsumer = (*fleetRegistrar)(nil)
@@ -78,11 +73,12 @@ func (f *fleetRegistrar) Register(ctx context.Context, serverURL, cluster string
 	}
 
 	return core.Registration{
-		Endpoint:      resp.GetEndpoint(),
-		Certificate:   resp.GetCertificate(),
-		CACertificate: resp.GetCaCertificate(),
-		PrivateKeyPEM: keyPEM,
-		AgentID:       f.agentID,
-		ServerVersion: resp.GetServerVersion(),
+		Endpoint:          resp.GetEndpoint(),
+		Certificate:       resp.GetCertificate(),
+		CACertificate:     resp.GetCaCertificate(),
+		PrivateKeyPEM:     keyPEM,
+		AgentID:           f.agentID,
+		ServerVersion:     resp.GetServerVersion(),
+		TunnelFingerprint: resp.GetTunnelFingerprint(),
 	}, nil
 }
@@ -0,0 +1,89 @@
+// Package source provides a core.ManifestFetcher that downloads
+// manifests over HTTPS for ApplyFromSource.
+package source
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+const (
+	// fetchTimeout bounds a download when the default HTTP client is
+	// used.
+	fetchTimeout = 30 * time.Second
+	// maxRedirects bounds the redirects followed by a download.
+	maxRedirects = 5
+)
+
+// Fetcher downloads manifests with an HTTP client.
+type Fetcher struct {
+	client *http.Client
+}
+
+// NewFetcher returns a Fetcher using client. A nil client uses a
+// default client with a timeout.
+func NewFetcher(client *http.Client) *Fetcher {
+	if client == nil {
+		client = &http.Client{Timeout: fetchTimeout}
+	}
+	return &Fetcher{client: client}
+}
+
+var _ core.ManifestFetcher = (*Fetcher)(nil)
+
+// Fetch downloads the body served at u, checking the target of every
+// redirect with allow so that a redirect cannot lead to a host the
+// caller did not allow.
+func (f *Fetcher) Fetch(ctx context.Context, u *url.URL, maxBytes int64, allow func(*url.URL) error) ([]byte, error) {
+	var denied error
+	client := *f.client
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		if err := allow(req.URL); err != nil {
+			denied = err
+			return err
+		}
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, &core.DomainError{Code: core.ErrorCodeInternal, Message: "build manifest request", Cause: err}
+	}
+
+	resp, err := client.Do(req)
+	if denied != nil {
+		return nil, denied
+	}
+	if err != nil {
+		return nil, &core.DomainError{Code: core.ErrorCodeUnavailable, Message: "fetch manifest", Cause: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &core.DomainError{
+			Code:    core.ErrorCodeUnavailable,
+			Message: fmt.Sprintf("manifest source responded %s", resp.Status),
+		}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return nil, &core.DomainError{Code: core.ErrorCodeUnavailable, Message: "read manifest", Cause: err}
+	}
+	if int64(len(body)) > maxBytes {
+		return nil, &core.DomainError{
+			Code:    core.ErrorCodeResourceExhausted,
+			Message: fmt.Sprintf("manifest exceeds %d bytes", maxBytes),
+		}
+	}
+	return body, nil
+}
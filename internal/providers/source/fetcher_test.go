@@ -0,0 +1,58 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+func TestFetcher_Fetch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/app.yaml", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("kind: ConfigMap\n"))
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data", http.StatusFound)
+	})
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	errDenied := &core.DomainError{Code: core.ErrorCodePermissionDenied, Message: "denied"}
+	allow := func(u *url.URL) error {
+		if u.Host != "169.254.169.254" {
+			return nil
+		}
+		return errDenied
+	}
+	f := NewFetcher(server.Client())
+	fetch := func(path string, maxBytes int64) ([]byte, error) {
+		u, _ := url.Parse(server.URL + path)
+		return f.Fetch(context.Background(), u, maxBytes, allow)
+	}
+
+	body, err := fetch("/app.yaml", 1024)
+	if err != nil || string(body) != "kind: ConfigMap\n" {
+		t.Errorf("Fetch = %q, %v, want the manifest", body, err)
+	}
+
+	if _, err := fetch("/redirect", 1024); !errors.Is(err, errDenied) {
+		t.Errorf("Fetch of a redirect to a denied host: err = %v, want the allow error", err)
+	}
+
+	if _, err := fetch("/app.yaml", 4); err == nil {
+		t.Error("Fetch of an oversized manifest succeeded")
+	} else if code, _ := core.DomainErrorCode(err); code != core.ErrorCodeResourceExhausted {
+		t.Errorf("Fetch of an oversized manifest: err = %v, want ResourceExhausted", err)
+	}
+
+	if _, err := fetch("/missing", 1024); err == nil {
+		t.Error("Fetch of a missing manifest succeeded")
+	} else if code, _ := core.DomainErrorCode(err); code != core.ErrorCodeUnavailable {
+		t.Errorf("Fetch of a missing manifest: err = %v, want Unavailable", err)
+	}
+}
@@ -0,0 +1,123 @@
+// Package webhook provides a core.FleetNotifier that POSTs fleet
+// membership events as JSON to an external URL.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+const (
+	// maxAttempts bounds how many times an event is posted before it
+	// is dropped.
+	maxAttempts = 5
+	// initialBackoff is the delay before the first retry; it doubles
+	// after every failed attempt.
+	initialBackoff = 500 * time.Millisecond
+	// requestTimeout bounds each delivery attempt when the default
+	// HTTP client is used.
+	requestTimeout = 10 * time.Second
+	// maxInFlight bounds the number of events being delivered at
+	// once. Events beyond it are dropped rather than queued, so that a
+	// slow receiver cannot pile up goroutines.
+	maxInFlight = 64
+)
+
+// Notifier delivers fleet events to a webhook URL. Each event is sent
+// in its own goroutine and retried with exponential backoff, so Notify
+// never blocks the caller. Delivery is best effort: an event is
+// dropped after maxAttempts failures or when too many are in flight.
+type Notifier struct {
+	url     string
+	client  *http.Client
+	log     *slog.Logger
+	backoff time.Duration
+	slots   chan struct{}
+}
+
+// NewNotifier returns a Notifier posting to url with client. A nil
+// client uses a default client with a per-request timeout.
+func NewNotifier(url string, client *http.Client) *Notifier {
+	if client == nil {
+		client = &http.Client{Timeout: requestTimeout}
+	}
+	return &Notifier{
+		url:     url,
+		client:  client,
+		log:     slog.Default().With("component", "fleet-webhook"),
+		backoff: initialBackoff,
+		slots:   make(chan struct{}, maxInFlight),
+	}
+}
+
+var _ core.FleetNotifier = (*Notifier)(nil)
+
+// Notify schedules delivery of event and returns immediately.
+func (n *Notifier) Notify(event core.FleetEvent) {
+	select {
+	case n.slots <- struct{}{}:
+	default:
+		n.log.Warn("dropping fleet event, too many deliveries in flight",
+			"event", event.Event, "cluster", event.Cluster)
+		return
+	}
+	go func() {
+		defer func() { <-n.slots }()
+		n.deliver(event)
+	}()
+}
+
+// deliver posts event until the receiver accepts it or maxAttempts is
+// reached.
+func (n *Notifier) deliver(event core.FleetEvent) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		n.log.Error("encode fleet event", "error", err)
+		return
+	}
+
+	backoff := n.backoff
+	for attempt := 1; ; attempt++ {
+		err = n.post(body)
+		if err == nil {
+			return
+		}
+		if attempt == maxAttempts {
+			break
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+	n.log.Warn("fleet event not delivered",
+		"event", event.Event, "cluster", event.Cluster, "attempts", maxAttempts, "error", err)
+}
+
+// post sends a single delivery attempt. Any non-2xx response is
+// treated as a failure.
+func (n *Notifier) post(body []byte) error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
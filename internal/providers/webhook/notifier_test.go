@@ -0,0 +1,67 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+func TestNotifier_RetriesUntilDelivered(t *testing.T) {
+	var attempts atomic.Int32
+	received := make(chan core.FleetEvent, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var e core.FleetEvent
+		if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+			t.Errorf("decode event: %v", err)
+		}
+		received <- e
+	}))
+	defer srv.Close()
+
+	n := NewNotifier(srv.URL, srv.Client())
+	n.backoff = time.Millisecond
+	n.Notify(core.FleetEvent{Event: core.FleetEventDeregistered, Cluster: "edge-1", Reason: "deregistered"})
+
+	select {
+	case e := <-received:
+		if e.Event != core.FleetEventDeregistered || e.Cluster != "edge-1" {
+			t.Errorf("event = %+v, want the deregistration of edge-1", e)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("event was not delivered")
+	}
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("attempts = %d, want 3", got)
+	}
+}
+
+func TestNotifier_GivesUpAfterMaxAttempts(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	n := NewNotifier(srv.URL, srv.Client())
+	n.backoff = time.Millisecond
+	n.Notify(core.FleetEvent{Event: core.FleetEventRegistered, Cluster: "edge-1"})
+
+	// The delivery slot is released once the notifier gives up.
+	deadline := time.Now().Add(5 * time.Second)
+	for len(n.slots) > 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := attempts.Load(); got != maxAttempts {
+		t.Errorf("attempts = %d, want %d", got, maxAttempts)
+	}
+}
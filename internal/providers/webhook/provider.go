@@ -0,0 +1,23 @@
+package webhook
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/otterscale/otterscale-agent/internal/config"
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// ProvideFleetNotifier is a Wire provider that returns a Notifier for
+// the configured fleet webhook URL, or nil if none is configured.
+func ProvideFleetNotifier(conf *config.Config) (core.FleetNotifier, error) {
+	raw := conf.ServerFleetWebhookURL()
+	if raw == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid fleet webhook URL %q: must be an absolute http or https URL", raw)
+	}
+	return NewNotifier(raw, nil), nil
+}
@@ -1,39 +1,361 @@
 // Package providers aggregates all infrastructure-layer implementations
-// (chisel, kubernetes, otterscale, cache) into a single Wire provider set.
+// (chisel, kubernetes, otterscale, cache, metrics, webhook, source, helm) into a single Wire provider set.
 package providers
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/google/wire"
+	"go.opentelemetry.io/otel"
+	"k8s.io/apimachinery/pkg/util/validation"
 
+	"github.com/otterscale/otterscale-agent/internal/config"
 	"github.com/otterscale/otterscale-agent/internal/core"
 	"github.com/otterscale/otterscale-agent/internal/providers/cache"
 	"github.com/otterscale/otterscale-agent/internal/providers/chisel"
+	"github.com/otterscale/otterscale-agent/internal/providers/helm"
 	"github.com/otterscale/otterscale-agent/internal/providers/kubernetes"
 	"github.com/otterscale/otterscale-agent/internal/providers/manifest"
+	"github.com/otterscale/otterscale-agent/internal/providers/metrics"
 	"github.com/otterscale/otterscale-agent/internal/providers/otterscale"
+	"github.com/otterscale/otterscale-agent/internal/providers/source"
+	"github.com/otterscale/otterscale-agent/internal/providers/webhook"
 	"github.com/otterscale/otterscale-agent/internal/transport"
+	"github.com/otterscale/otterscale-agent/internal/transport/tunnel"
 )
 
-// ProvideDiscoveryCache constructs a DiscoveryCache with the default TTL.
+// ProvideDiscoveryCache constructs a DiscoveryCache with the default TTL
+// and the configured OpenAPI v3 document TTL, whose fetches are bounded
+// by the clusters' request timeouts, and exports its counters as
+// metrics.
 // This bridges the core.DiscoveryClient to the core.SchemaResolver
 // interface via caching.
-func ProvideDiscoveryCache(discovery core.DiscoveryClient) *cache.DiscoveryCache {
-	return cache.NewDiscoveryCache(discovery, cache.DefaultTTL)
+func ProvideDiscoveryCache(discovery core.DiscoveryClient, timeouts core.ClusterTimeouts, conf core.CacheConfig) (*cache.DiscoveryCache, error) {
+	c := cache.NewDiscoveryCache(discovery, cache.DefaultTTL,
+		cache.WithFetchTimeouts(timeouts),
+		cache.WithOpenAPITTL(conf.OpenAPITTL),
+	)
+	// The global meter delegates to the Prometheus-backed provider
+	// installed when the server mounts /metrics.
+	if err := c.RegisterMetrics(otel.Meter("github.com/otterscale/otterscale-agent/internal/providers/cache")); err != nil {
+		return nil, fmt.Errorf("register discovery cache metrics: %w", err)
+	}
+	return c, nil
+}
+
+// ProvideSessionMetrics constructs the exec and port-forward session
+// histograms on the global meter, which, as for ProvideDiscoveryCache,
+// delegates to the provider behind /metrics.
+func ProvideSessionMetrics() (core.SessionMetrics, error) {
+	m, err := metrics.NewSessionMetrics(otel.Meter("github.com/otterscale/otterscale-agent/internal/providers/metrics"))
+	if err != nil {
+		return nil, fmt.Errorf("create session metrics: %w", err)
+	}
+	return m, nil
+}
+
+// ProvideJitter converts the configured jitter percentage of the
+// periodic background loops into a core.Jitter.
+func ProvideJitter(conf *config.Config) (core.Jitter, error) {
+	percent := conf.ServerBackgroundJitterPercent()
+	if percent < 0 || core.Jitter(percent)/100 > core.MaxJitter {
+		return 0, fmt.Errorf("invalid server.background.jitter_percent %d: must be between 0 and %d", percent, int(core.MaxJitter*100))
+	}
+	return core.Jitter(percent) / 100, nil
+}
+
+// ProvideCacheConfig extracts the cache maintenance settings from the
+// server configuration.
+func ProvideCacheConfig(conf *config.Config) core.CacheConfig {
+	return core.CacheConfig{
+		EvictionInterval: conf.ServerDiscoveryEvictionInterval(),
+		OpenAPITTL:       conf.ServerDiscoveryOpenAPITTL(),
+	}
+}
+
+// ProvideNamespaceCache constructs a NamespaceCache with the default
+// TTL, backing the optional namespace pre-check on create and apply.
+func ProvideNamespaceCache(resource core.ResourceRepo) *cache.NamespaceCache {
+	return cache.NewNamespaceCache(resource, cache.DefaultNamespaceTTL)
+}
+
+// ProvideIdempotencyCache constructs the IdempotencyCache that lets
+// retried creates with an idempotency key return the object created
+// first. A non-positive TTL uses the default.
+func ProvideIdempotencyCache(conf *config.Config) *cache.IdempotencyCache {
+	ttl := conf.ServerApplyIdempotencyTTL()
+	if ttl <= 0 {
+		ttl = cache.DefaultIdempotencyTTL
+	}
+	return cache.NewIdempotencyCache(ttl)
+}
+
+// ProvideResourceOptions returns the optional backends of the resource
+// use case: the idempotency cache of creates.
+func ProvideResourceOptions(idempotency *cache.IdempotencyCache) []core.ResourceOption {
+	return []core.ResourceOption{core.WithIdempotencyCache(idempotency)}
+}
+
+// ProvideClusterAuthorizer builds the group-based cluster access
+// policy from the server configuration. With no rules configured the
+// policy allows all access.
+func ProvideClusterAuthorizer(conf *config.Config) (*core.ClusterAccessPolicy, error) {
+	return core.NewClusterAccessPolicy(conf.ServerClusterAccess())
+}
+
+// ProvideProxyConfig extracts the raw API server passthrough
+// allow-list from the server configuration.
+func ProvideProxyConfig(conf *config.Config) core.ProxyConfig {
+	return core.ProxyConfig{
+		AllowedPathPrefixes: conf.ServerProxyAllowedPaths(),
+	}
+}
+
+// ProvideWatchConfig extracts the watch stream limits and the default
+// for initial events from the server configuration.
+func ProvideWatchConfig(conf *config.Config) core.WatchConfig {
+	return core.WatchConfig{
+		MaxDuration:       conf.ServerWatchMaxDuration(),
+		MinResyncInterval: conf.ServerWatchMinResyncInterval(),
+		NoInitialEvents:   !conf.ServerWatchInitialEvents(),
+	}
+}
+
+// ProvidePodLogConfig extracts the pod log stream settings from the
+// server configuration.
+func ProvidePodLogConfig(conf *config.Config) core.PodLogConfig {
+	return core.PodLogConfig{
+		BufferSize: conf.ServerPodLogBufferSize(),
+	}
+}
+
+// ProvideMaintenanceConfig extracts the initial maintenance mode and
+// its admin groups from the server configuration.
+func ProvideMaintenanceConfig(conf *config.Config) core.MaintenanceConfig {
+	return core.MaintenanceConfig{
+		Enabled:     conf.ServerMaintenanceEnabled(),
+		Message:     conf.ServerMaintenanceMessage(),
+		AdminGroups: conf.ServerMaintenanceAdminGroups(),
+	}
+}
+
+// ProvideApplyConfig extracts the server-side apply defaults, the
+// managed-by label, the manifest limits and the ready conditions from
+// the server configuration. It returns an error if the label is not a
+// valid label key or a ready condition does not parse.
+func ProvideApplyConfig(conf *config.Config) (core.ApplyConfig, error) {
+	label := conf.ServerApplyManagedByLabel()
+	if label != "" {
+		if errs := validation.IsQualifiedName(label); len(errs) > 0 {
+			return core.ApplyConfig{}, fmt.Errorf("invalid managed-by label %q: %s", label, strings.Join(errs, "; "))
+		}
+	}
+	readyConditions, err := core.ParseReadyConditions(conf.ServerApplyReadyConditions())
+	if err != nil {
+		return core.ApplyConfig{}, err
+	}
+	return core.ApplyConfig{
+		FieldManagerPrefix: conf.ServerApplyFieldManagerPrefix(),
+		ManagedByLabel:     label,
+		Manifest: core.ManifestLimits{
+			MaxBytes:     conf.ServerApplyManifestMaxBytes(),
+			MaxDepth:     conf.ServerApplyManifestMaxDepth(),
+			MaxDocuments: conf.ServerApplyManifestMaxDocuments(),
+		},
+		ReadyConditions: readyConditions,
+	}, nil
+}
+
+// ProvideNamespaceConfig extracts the namespace defaults from the
+// server configuration.
+func ProvideNamespaceConfig(conf *config.Config) core.NamespaceConfig {
+	return core.NamespaceConfig{
+		Default: conf.ServerDefaultNamespace(),
+	}
+}
+
+// ProvideManifestSourceConfig extracts the restrictions on manifests
+// applied by reference from the server configuration.
+func ProvideManifestSourceConfig(conf *config.Config) core.ManifestSourceConfig {
+	return core.ManifestSourceConfig{
+		AllowedHosts: conf.ServerApplySourceAllowedHosts(),
+		MaxBytes:     conf.ServerApplySourceMaxBytes(),
+	}
+}
+
+// ProvideManifestFetcher returns the fetcher of manifests applied by
+// URL.
+func ProvideManifestFetcher() core.ManifestFetcher {
+	return source.NewFetcher(nil)
+}
+
+// ProvideHelmChartConfig extracts the size and time limits of Helm
+// charts applied by upload from the server configuration.
+func ProvideHelmChartConfig(conf *config.Config) core.HelmChartConfig {
+	return core.HelmChartConfig{
+		MaxChartBytes:    conf.ServerApplyHelmMaxChartBytes(),
+		MaxRenderedBytes: conf.ServerApplyHelmMaxRenderedBytes(),
+		RenderTimeout:    conf.ServerApplyHelmRenderTimeout(),
+	}
+}
+
+// ProvideReadinessConfig extracts the readiness conditions from the
+// server configuration.
+func ProvideReadinessConfig(conf *config.Config) core.ReadinessConfig {
+	return core.ReadinessConfig{
+		MinClusters: conf.ServerReadyzMinClusters(),
+	}
+}
+
+// ProvideHelmRenderer returns the renderer of uploaded Helm charts,
+// bounded by the size and concurrency limits of the server
+// configuration.
+func ProvideHelmRenderer(conf *config.Config) core.HelmRenderer {
+	return helm.NewRenderer(conf.ServerApplyHelmMaxDecompressedBytes(), conf.ServerApplyHelmMaxRenderedBytes(), conf.ServerApplyHelmMaxConcurrentRenders())
+}
+
+// ProvideClusterTimeouts extracts the default and per-cluster API
+// server request timeouts from the server configuration.
+func ProvideClusterTimeouts(conf *config.Config) (core.ClusterTimeouts, error) {
+	overrides, err := conf.ServerClusterTimeouts()
+	if err != nil {
+		return core.ClusterTimeouts{}, err
+	}
+	return core.ClusterTimeouts{
+		Default:   conf.ServerClusterTimeout(),
+		Overrides: overrides,
+	}, nil
+}
+
+// ProvideTransportConfig extracts the per-cluster HTTP connection pool
+// tuning, request timeouts, agent reconnect queuing, stream
+// compression and watch buffering from the server configuration.
+func ProvideTransportConfig(conf *config.Config, timeouts core.ClusterTimeouts) (kubernetes.TransportConfig, error) {
+	var compress bool
+	switch c := conf.ServerStreamCompression(); c {
+	case "", "none":
+	case "gzip":
+		compress = true
+	default:
+		return kubernetes.TransportConfig{}, fmt.Errorf("unknown stream compression %q", c)
+	}
+
+	overflow := kubernetes.WatchOverflow(conf.ServerWatchOverflow())
+	switch overflow {
+	case kubernetes.WatchOverflowBlock, kubernetes.WatchOverflowDrop:
+	default:
+		return kubernetes.TransportConfig{}, fmt.Errorf("unknown watch overflow %q", overflow)
+	}
+	if conf.ServerWatchBufferSize() < 0 {
+		return kubernetes.TransportConfig{}, fmt.Errorf("watch buffer size must not be negative")
+	}
+	// An unbuffered watch is full whenever the client is not already
+	// waiting, so dropping would end nearly every watch.
+	if overflow == kubernetes.WatchOverflowDrop && conf.ServerWatchBufferSize() == 0 {
+		return kubernetes.TransportConfig{}, fmt.Errorf("watch overflow %q requires a positive watch buffer size", overflow)
+	}
+
+	return kubernetes.TransportConfig{
+		MaxIdleConns:        conf.ServerClusterTransportMaxIdleConns(),
+		MaxIdleConnsPerHost: conf.ServerClusterTransportMaxIdleConnsPerHost(),
+		MaxConnsPerHost:     conf.ServerClusterTransportMaxConnsPerHost(),
+		IdleConnTimeout:     conf.ServerClusterTransportIdleConnTimeout(),
+		ReconnectWait:       conf.ServerClusterTransportReconnectWait(),
+		ReconnectQueueSize:  conf.ServerClusterTransportReconnectQueueSize(),
+		Timeouts:            timeouts,
+		CompressStreams:     compress,
+		WatchBufferSize:     conf.ServerWatchBufferSize(),
+		WatchOverflow:       overflow,
+	}, nil
+}
+
+// ProvideKubernetes constructs the Kubernetes API access shared by the
+// repositories, and has the tunnel service evict a cluster's cached
+// clients whenever its agent registers, e.g. after reconnecting.
+func ProvideKubernetes(service *chisel.Service, authz core.ClusterAuthorizer, transport kubernetes.TransportConfig) *kubernetes.Kubernetes {
+	k := kubernetes.New(service, authz, transport)
+	service.OnRegister(k.EvictClients)
+	return k
+}
+
+// ProvideTunnelConfig extracts the tunnel endpoint settings, the
+// cluster limit, the TLS restrictions and the drain timeout from the
+// server configuration.
+func ProvideTunnelConfig(conf *config.Config, jitter core.Jitter) (chisel.Config, error) {
+	port := conf.ServerTunnelInternalPort()
+	if port < 1 || port > 65535 {
+		return chisel.Config{}, fmt.Errorf("invalid server.tunnel.internal_port %d: must be between 1 and 65535", port)
+	}
+	maxClusters := conf.ServerFleetMaxClusters()
+	if maxClusters < 0 {
+		return chisel.Config{}, fmt.Errorf("invalid server.fleet.max_clusters %d: must not be negative", maxClusters)
+	}
+	minVersion, err := tunnel.ParseTLSVersion(conf.ServerTunnelTLSMinVersion())
+	if err != nil {
+		return chisel.Config{}, fmt.Errorf("invalid server.tunnel.tls.min_version: %w", err)
+	}
+	cipherSuites, err := tunnel.ParseCipherSuites(conf.ServerTunnelTLSCipherSuites(), minVersion)
+	if err != nil {
+		return chisel.Config{}, fmt.Errorf("invalid server.tunnel.tls.cipher_suites: %w", err)
+	}
+	drainTimeout := conf.ServerTunnelDrainTimeout()
+	if drainTimeout < 0 {
+		return chisel.Config{}, fmt.Errorf("invalid server.tunnel.drain_timeout %s: must not be negative", drainTimeout)
+	}
+	return chisel.Config{
+		Port:            port,
+		MaxClusters:     maxClusters,
+		TLSMinVersion:   minVersion,
+		TLSCipherSuites: cipherSuites,
+		DrainTimeout:    drainTimeout,
+		Jitter:          jitter,
+	}, nil
 }
 
 // ProviderSet is the Wire provider set for all external adapters.
 var ProviderSet = wire.NewSet(
+	ProvideTunnelConfig,
 	chisel.NewService,
+	webhook.ProvideFleetNotifier,
 	wire.Bind(new(core.TunnelProvider), new(*chisel.Service)),
 	wire.Bind(new(transport.TunnelService), new(*chisel.Service)),
-	manifest.NewRenderer,
+	manifest.ProvideRenderer,
 	wire.Bind(new(core.ManifestRenderer), new(*manifest.Renderer)),
-	kubernetes.New,
+	ProvideClusterAuthorizer,
+	wire.Bind(new(core.ClusterAuthorizer), new(*core.ClusterAccessPolicy)),
+	ProvideClusterTimeouts,
+	ProvideTransportConfig,
+	ProvideKubernetes,
 	kubernetes.NewDiscoveryClient,
 	kubernetes.NewResourceRepo,
 	kubernetes.NewRuntimeRepo,
+	kubernetes.NewProxyRepo,
+	kubernetes.NewAPIProxy,
+	wire.Bind(new(core.KubeAPIProxy), new(*kubernetes.APIProxy)),
+	kubernetes.NewAgentDiagnosticsRepo,
+	kubernetes.NewIdentityRepo,
+	ProvideProxyConfig,
+	ProvideWatchConfig,
+	ProvidePodLogConfig,
+	ProvideApplyConfig,
+	ProvideNamespaceConfig,
+	ProvideManifestSourceConfig,
+	ProvideManifestFetcher,
+	ProvideHelmChartConfig,
+	ProvideHelmRenderer,
+	ProvideReadinessConfig,
+	ProvideMaintenanceConfig,
 	otterscale.NewFleetRegistrar,
 	ProvideDiscoveryCache,
+	ProvideCacheConfig,
+	ProvideJitter,
+	ProvideSessionMetrics,
 	wire.Bind(new(core.SchemaResolver), new(*cache.DiscoveryCache)),
+	wire.Bind(new(core.ServerVersionResolver), new(*cache.DiscoveryCache)),
 	wire.Bind(new(core.CacheEvictor), new(*cache.DiscoveryCache)),
+	ProvideNamespaceCache,
+	wire.Bind(new(core.NamespaceChecker), new(*cache.NamespaceCache)),
+	ProvideIdempotencyCache,
+	ProvideResourceOptions,
 )
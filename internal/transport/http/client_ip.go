@@ -0,0 +1,139 @@
+package http
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// parseTrustedProxies parses CIDRs and bare IP addresses into
+// prefixes. A bare address is trusted on its own.
+func parseTrustedProxies(entries []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(entries))
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		if strings.Contains(e, "/") {
+			p, err := netip.ParsePrefix(e)
+			if err != nil {
+				return nil, fmt.Errorf("trusted proxy %q: %w", e, err)
+			}
+			prefixes = append(prefixes, p.Masked())
+			continue
+		}
+		ip, err := netip.ParseAddr(e)
+		if err != nil {
+			return nil, fmt.Errorf("trusted proxy %q: %w", e, err)
+		}
+		ip = ip.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(ip, ip.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// wrapClientIP resolves the client IP of each request and stores it
+// via core.WithClientIP.
+func (s *Server) wrapClientIP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ip, ok := s.clientIP(r); ok {
+			r = r.WithContext(core.WithClientIP(r.Context(), ip))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// clientIP returns the address of the peer that sent r unless that
+// peer is a trusted proxy. Forwarding headers are then walked from the
+// nearest hop outwards, skipping trusted proxies, and the first
+// untrusted hop is the client. Hops a trusted proxy did not append
+// cannot be verified, so the walk stops there. A malformed or
+// obfuscated hop also stops the walk, leaving the last trusted proxy
+// as the client. The Forwarded header takes precedence over
+// X-Forwarded-For when both are present. Without trusted proxies the
+// headers are ignored entirely.
+func (s *Server) clientIP(r *http.Request) (netip.Addr, bool) {
+	peer, ok := parseHop(r.RemoteAddr)
+	if !ok {
+		return netip.Addr{}, false
+	}
+	if !s.isTrustedProxy(peer) {
+		return peer, true
+	}
+	hops := forwardedFor(r.Header)
+	client := peer
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip, ok := parseHop(hops[i])
+		if !ok {
+			break
+		}
+		client = ip
+		if !s.isTrustedProxy(ip) {
+			break
+		}
+	}
+	return client, true
+}
+
+// isTrustedProxy reports whether ip is within a trusted proxy prefix.
+func (s *Server) isTrustedProxy(ip netip.Addr) bool {
+	for _, p := range s.trustedProxies {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// forwardedFor returns the hops recorded in the Forwarded header, or
+// in X-Forwarded-For when there is no Forwarded header, ordered from
+// the original client to the nearest proxy.
+func forwardedFor(h http.Header) []string {
+	if values := h.Values("Forwarded"); len(values) > 0 {
+		var hops []string
+		for _, v := range values {
+			for elem := range strings.SplitSeq(v, ",") {
+				hops = append(hops, forwardedElementFor(elem))
+			}
+		}
+		return hops
+	}
+	var hops []string
+	for _, v := range h.Values("X-Forwarded-For") {
+		for hop := range strings.SplitSeq(v, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+	return hops
+}
+
+// forwardedElementFor returns the for parameter of one element of a
+// Forwarded header (RFC 7239), without quotes, or an empty string.
+func forwardedElementFor(elem string) string {
+	for pair := range strings.SplitSeq(elem, ";") {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if ok && strings.EqualFold(strings.TrimSpace(key), "for") {
+			return strings.Trim(strings.TrimSpace(value), `"`)
+		}
+	}
+	return ""
+}
+
+// parseHop parses an IP address optionally followed by a port, with
+// IPv6 addresses in brackets when a port is present. IPv4-mapped IPv6
+// addresses are unmapped so that they match IPv4 prefixes.
+func parseHop(hop string) (netip.Addr, bool) {
+	if host, _, err := net.SplitHostPort(hop); err == nil {
+		hop = host
+	}
+	ip, err := netip.ParseAddr(strings.Trim(hop, "[]"))
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return ip.Unmap(), true
+}
@@ -7,10 +7,13 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+	"net/netip"
+	"slices"
 	"strings"
 	"time"
 
 	"connectrpc.com/authn"
+	"connectrpc.com/connect"
 	connectcors "connectrpc.com/cors"
 	"github.com/rs/cors"
 
@@ -34,7 +37,14 @@ type Server struct {
 	authMiddleware     *authn.Middleware
 	publicPaths        map[string]struct{}
 	publicPathPrefixes []string
+	serviceTokens      ServiceTokenVerifier
+	serviceProcedures  map[core.ServiceOperation]map[string]struct{}
 	allowedOrigins     []string
+	allowedHeaders     []string
+	exposedHeaders     []string
+	streamKeepAlive    time.Duration
+	trustedProxyList   []string
+	trustedProxies     []netip.Prefix
 	log                *slog.Logger
 }
 
@@ -100,11 +110,70 @@ func WithPublicPathPrefixes(prefixes []string) ServerOption {
 	}
 }
 
+// ServiceTokenVerifier verifies the tokens the server issues to its own
+// automation and returns the identity each was issued for.
+type ServiceTokenVerifier interface {
+	Verify(token string) (core.ServiceIdentity, error)
+}
+
+// WithServiceTokens accepts service tokens verified by verifier in
+// place of OIDC tokens. A verified token may only call the procedure
+// paths listed for its operation in procedures; its requests carry
+// core.WithServiceIdentity and no core.UserInfo. Bearer tokens with
+// core.ServiceTokenPrefix never fall through to OIDC verification.
+func WithServiceTokens(verifier ServiceTokenVerifier, procedures map[core.ServiceOperation][]string) ServerOption {
+	return func(s *Server) {
+		s.serviceTokens = verifier
+		s.serviceProcedures = make(map[core.ServiceOperation]map[string]struct{}, len(procedures))
+		for op, paths := range procedures {
+			allowed := make(map[string]struct{}, len(paths))
+			for _, p := range paths {
+				allowed[p] = struct{}{}
+			}
+			s.serviceProcedures[op] = allowed
+		}
+	}
+}
+
 // WithAllowedOrigins configures the allowed origins for CORS.
 func WithAllowedOrigins(origins []string) ServerOption {
 	return func(s *Server) { s.allowedOrigins = origins }
 }
 
+// WithAllowedHeaders configures request headers allowed by CORS in
+// addition to those required by the Connect, gRPC and gRPC-Web
+// protocols, e.g. application request ID or tracing headers.
+func WithAllowedHeaders(headers []string) ServerOption {
+	return func(s *Server) { s.allowedHeaders = headers }
+}
+
+// WithExposedHeaders configures response headers exposed to browser
+// clients by CORS in addition to those required by the Connect, gRPC
+// and gRPC-Web protocols.
+func WithExposedHeaders(headers []string) ServerOption {
+	return func(s *Server) { s.exposedHeaders = headers }
+}
+
+// WithStreamKeepAlive configures the interval of HTTP/2 pings sent on
+// client connections that have received no frames for that long.
+// Proxies between the client and the server see the pings and their
+// acknowledgements as traffic, so long-lived Watch, log and exec
+// streams with no messages to send are not closed as idle. Pings also
+// detect dead peers. Zero, the default, disables them.
+func WithStreamKeepAlive(interval time.Duration) ServerOption {
+	return func(s *Server) { s.streamKeepAlive = interval }
+}
+
+// WithTrustedProxies configures the CIDRs or IP addresses of the
+// proxies and load balancers in front of the server. Only requests
+// from these peers have their X-Forwarded-For or Forwarded headers
+// used to resolve the client IP, and only the hops the trusted proxies
+// appended are believed. With none configured, the default, the
+// headers are ignored and the client IP is the connection's peer.
+func WithTrustedProxies(proxies []string) ServerOption {
+	return func(s *Server) { s.trustedProxyList = proxies }
+}
+
 // WithHTTPLogger configures a structured logger. Defaults to
 // slog.Default with a "component" attribute.
 func WithHTTPLogger(log *slog.Logger) ServerOption {
@@ -129,6 +198,11 @@ func NewServer(opts ...ServerOption) (*Server, error) {
 		return nil, fmt.Errorf("http server: allowed origins must be configured when authentication is enabled; " +
 			"set --allowed-origins or OTTERSCALE_SERVER_ALLOWED_ORIGINS")
 	}
+	trustedProxies, err := parseTrustedProxies(s.trustedProxyList)
+	if err != nil {
+		return nil, fmt.Errorf("http server: %w", err)
+	}
+	s.trustedProxies = trustedProxies
 	if s.listener == nil {
 		ln, err := net.Listen("tcp", s.address)
 		if err != nil {
@@ -155,6 +229,13 @@ func NewServer(opts ...ServerOption) (*Server, error) {
 		MaxHeaderBytes:    8 * 1024, // 8 KiB
 		Protocols:         protocols,
 	}
+	// Pings are sent only while a connection is otherwise silent and
+	// do not count as activity for Shutdown, which still sends GOAWAY
+	// and waits for open streams; those end when Start's context is
+	// cancelled.
+	if s.streamKeepAlive > 0 {
+		s.inner.HTTP2 = &http.HTTP2Config{SendPingTimeout: s.streamKeepAlive}
+	}
 
 	return s, nil
 }
@@ -178,6 +259,7 @@ func (s *Server) Start(ctx context.Context) error {
 		"auth", s.authMiddleware != nil,
 		"public_paths", len(s.publicPaths),
 		"allowed_origins", s.allowedOrigins,
+		"trusted_proxies", len(s.trustedProxies),
 	)
 
 	if err := s.inner.Serve(s.listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -203,7 +285,7 @@ func (s *Server) Stop(ctx context.Context) error {
 // ---------------------------------------------------------------------------
 
 // buildHandler assembles the middleware stack.
-// Order: H2C -> CORS -> Auth -> Mux
+// Order: H2C -> ClientIP -> CORS -> Auth -> Mux
 func (s *Server) buildHandler() (http.Handler, error) {
 	mux := http.NewServeMux()
 	if s.mount != nil {
@@ -222,6 +304,9 @@ func (s *Server) buildHandler() (http.Handler, error) {
 	// CORS
 	handler = s.wrapCORS(handler)
 
+	// Client IP
+	handler = s.wrapClientIP(handler)
+
 	return handler, nil
 }
 
@@ -231,9 +316,15 @@ func (s *Server) buildHandler() (http.Handler, error) {
 // copies it into the domain-level core.UserInfo context key so that
 // infrastructure adapters can access the user identity without
 // depending on the connectrpc/authn package.
+//
+// When service tokens are configured, requests bearing one are
+// authenticated by serveServiceToken instead of the authn middleware.
 func (s *Server) wrapAuth(mux *http.ServeMux, next http.Handler) http.Handler {
 	bridged := bridgeUserInfo(next)
 	protected := s.authMiddleware.Wrap(bridged)
+	if s.serviceTokens != nil {
+		protected = s.wrapServiceTokens(next, protected)
+	}
 	if len(s.publicPaths) == 0 && len(s.publicPathPrefixes) == 0 {
 		return protected
 	}
@@ -246,6 +337,32 @@ func (s *Server) wrapAuth(mux *http.ServeMux, next http.Handler) http.Handler {
 	})
 }
 
+// wrapServiceTokens serves requests bearing a service token with next,
+// after verifying the token and checking that its operation may call
+// the requested procedure. All other requests go to protected.
+func (s *Server) wrapServiceTokens(next, protected http.Handler) http.Handler {
+	errWriter := connect.NewErrorWriter()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, found := authn.BearerToken(r)
+		if !found || !strings.HasPrefix(token, core.ServiceTokenPrefix) {
+			protected.ServeHTTP(w, r)
+			return
+		}
+		id, err := s.serviceTokens.Verify(token)
+		if err != nil {
+			_ = errWriter.Write(w, r, connect.NewError(connect.CodeUnauthenticated, errors.New("invalid service token")))
+			return
+		}
+		if _, ok := s.serviceProcedures[id.Operation][r.URL.Path]; !ok {
+			s.log.Warn("service token used for a disallowed procedure", "operation", id.Operation, "cluster", id.Cluster, "path", r.URL.Path)
+			_ = errWriter.Write(w, r, connect.NewError(connect.CodePermissionDenied,
+				fmt.Errorf("service operation %q may not call %s", id.Operation, r.URL.Path)))
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(core.WithServiceIdentity(r.Context(), id)))
+	})
+}
+
 // bridgeUserInfo extracts the authn-stored UserInfo and stores it via
 // the domain-level core.WithUserInfo context accessor. This decouples
 // infrastructure adapters from the transport-specific authn package.
@@ -279,7 +396,8 @@ func (s *Server) isPublicPath(path string) bool {
 // All requests are forwarded through the server's mTLS-authenticated
 // tunnel, so browser-origin restrictions are enforced at the server
 // layer instead. In server mode the startup validation in NewServer
-// ensures allowedOrigins is non-empty.
+// ensures allowedOrigins is non-empty. Configured allowed and exposed
+// headers are merged with the connectcors defaults.
 func (s *Server) wrapCORS(next http.Handler) http.Handler {
 	if len(s.allowedOrigins) == 0 {
 		return cors.AllowAll().Handler(next)
@@ -287,10 +405,24 @@ func (s *Server) wrapCORS(next http.Handler) http.Handler {
 	c := cors.New(cors.Options{
 		AllowedOrigins:   s.allowedOrigins,
 		AllowedMethods:   connectcors.AllowedMethods(),
-		AllowedHeaders:   connectcors.AllowedHeaders(),
-		ExposedHeaders:   connectcors.ExposedHeaders(),
+		AllowedHeaders:   mergeHeaders(connectcors.AllowedHeaders(), s.allowedHeaders),
+		ExposedHeaders:   mergeHeaders(connectcors.ExposedHeaders(), s.exposedHeaders),
 		AllowCredentials: true,
 		MaxAge:           7200,
 	})
 	return c.Handler(next)
 }
+
+// mergeHeaders appends extra to defaults, skipping empty names and
+// names already present. Header names are compared case-insensitively.
+func mergeHeaders(defaults, extra []string) []string {
+	merged := slices.Clone(defaults)
+	for _, h := range extra {
+		h = strings.TrimSpace(h)
+		if h == "" || slices.ContainsFunc(merged, func(m string) bool { return strings.EqualFold(m, h) }) {
+			continue
+		}
+		merged = append(merged, h)
+	}
+	return merged
+}
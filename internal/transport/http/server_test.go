@@ -1,13 +1,22 @@
 package http
 
 import (
+	"bufio"
 	"context"
+	"errors"
+	"io"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"connectrpc.com/authn"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
 )
 
 func TestNewServer_PublicPathsBypassAuth(t *testing.T) {
@@ -76,3 +85,430 @@ func TestNewServer_PublicPathsBypassAuth(t *testing.T) {
 		}
 	})
 }
+
+// fakeServiceTokens accepts the token "otterscale-service.bootstrap"
+// for the bootstrap operation on edge-1.
+type fakeServiceTokens struct{}
+
+func (fakeServiceTokens) Verify(token string) (core.ServiceIdentity, error) {
+	if token != core.ServiceTokenPrefix+"bootstrap" {
+		return core.ServiceIdentity{}, errors.New("invalid token")
+	}
+	return core.ServiceIdentity{Operation: core.ServiceOperationBootstrap, Cluster: "edge-1"}, nil
+}
+
+func TestNewServer_ServiceTokens(t *testing.T) {
+	t.Parallel()
+
+	var oidcCalls atomic.Int32
+	authMiddleware := authn.NewMiddleware(func(_ context.Context, r *http.Request) (any, error) {
+		oidcCalls.Add(1)
+		if r.Header.Get("Authorization") != "Bearer user-token" {
+			return nil, authn.Errorf("invalid token")
+		}
+		return core.UserInfo{Subject: "alice"}, nil
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+
+	type seen struct {
+		id     core.ServiceIdentity
+		hasOp  bool
+		hasUsr bool
+	}
+	var last seen
+	srv, err := NewServer(
+		WithListener(ln),
+		WithAuthMiddleware(authMiddleware),
+		WithAllowedOrigins([]string{"https://example.com"}),
+		WithServiceTokens(fakeServiceTokens{}, map[core.ServiceOperation][]string{
+			core.ServiceOperationBootstrap: {"/apply"},
+		}),
+		WithMount(func(mux *http.ServeMux) error {
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				last.id, last.hasOp = core.ServiceIdentityFromContext(r.Context())
+				_, last.hasUsr = core.UserInfoFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			}
+			mux.HandleFunc("/apply", handler)
+			mux.HandleFunc("/delete", handler)
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+
+	serve := func(path, token string) int {
+		last = seen{}
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("allowed procedure runs as the service", func(t *testing.T) {
+		if code := serve("/apply", core.ServiceTokenPrefix+"bootstrap"); code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, code)
+		}
+		want := core.ServiceIdentity{Operation: core.ServiceOperationBootstrap, Cluster: "edge-1"}
+		if !last.hasOp || last.id != want || last.hasUsr {
+			t.Fatalf("handler saw identity %+v (set %v), user info %v; want %+v and no user", last.id, last.hasOp, last.hasUsr, want)
+		}
+	})
+
+	t.Run("disallowed procedure is forbidden", func(t *testing.T) {
+		if code := serve("/delete", core.ServiceTokenPrefix+"bootstrap"); code != http.StatusForbidden {
+			t.Fatalf("expected status %d, got %d", http.StatusForbidden, code)
+		}
+	})
+
+	t.Run("invalid service token is not passed to OIDC", func(t *testing.T) {
+		before := oidcCalls.Load()
+		if code := serve("/apply", core.ServiceTokenPrefix+"forged"); code != http.StatusUnauthorized {
+			t.Fatalf("expected status %d, got %d", http.StatusUnauthorized, code)
+		}
+		if oidcCalls.Load() != before {
+			t.Fatal("service token was passed to the OIDC middleware")
+		}
+	})
+
+	t.Run("user token still goes through OIDC", func(t *testing.T) {
+		if code := serve("/delete", "user-token"); code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, code)
+		}
+		if last.hasOp || !last.hasUsr {
+			t.Fatalf("handler saw operation set %v, user info %v; want a user request", last.hasOp, last.hasUsr)
+		}
+	})
+}
+
+func TestNewServer_CORSCustomHeaders(t *testing.T) {
+	t.Parallel()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+
+	srv, err := NewServer(
+		WithListener(ln),
+		WithAllowedOrigins([]string{"https://example.com"}),
+		WithAllowedHeaders([]string{"X-Request-Id", "connect-protocol-version"}),
+		WithExposedHeaders([]string{"X-Trace-Id"}),
+		WithMount(func(mux *http.ServeMux) error {
+			mux.HandleFunc("/svc/Method", func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("X-Trace-Id", "abc")
+				w.WriteHeader(http.StatusOK)
+			})
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+
+	t.Run("preflight allows custom and protocol headers", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodOptions, "/svc/Method", nil)
+		req.Header.Set("Origin", "https://example.com")
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		req.Header.Set("Access-Control-Request-Headers", "connect-protocol-version,content-type,x-request-id")
+		rec := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rec, req)
+
+		allowed := strings.ToLower(rec.Header().Get("Access-Control-Allow-Headers"))
+		for _, h := range []string{"x-request-id", "connect-protocol-version", "content-type"} {
+			if !strings.Contains(allowed, h) {
+				t.Errorf("Access-Control-Allow-Headers = %q, want it to include %q", allowed, h)
+			}
+		}
+	})
+
+	t.Run("response exposes custom and protocol headers", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/svc/Method", nil)
+		req.Header.Set("Origin", "https://example.com")
+		rec := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rec, req)
+
+		exposed := strings.ToLower(rec.Header().Get("Access-Control-Expose-Headers"))
+		for _, h := range []string{"x-trace-id", "grpc-status"} {
+			if !strings.Contains(exposed, h) {
+				t.Errorf("Access-Control-Expose-Headers = %q, want it to include %q", exposed, h)
+			}
+		}
+	})
+}
+
+func TestNewServer_ClientIP(t *testing.T) {
+	t.Parallel()
+
+	newServer := func(t *testing.T, proxies []string) *Server {
+		t.Helper()
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("listen: %v", err)
+		}
+		t.Cleanup(func() { ln.Close() })
+		srv, err := NewServer(
+			WithListener(ln),
+			WithTrustedProxies(proxies),
+			WithMount(func(mux *http.ServeMux) error {
+				mux.HandleFunc("/svc/Method", func(w http.ResponseWriter, r *http.Request) {
+					if ip, ok := core.ClientIPFromContext(r.Context()); ok {
+						_, _ = io.WriteString(w, ip.String())
+					}
+				})
+				return nil
+			}),
+		)
+		if err != nil {
+			t.Fatalf("NewServer() error = %v", err)
+		}
+		return srv
+	}
+
+	tests := []struct {
+		name       string
+		proxies    []string
+		remoteAddr string
+		headers    map[string]string
+		want       string
+	}{
+		{
+			name:       "direct connection",
+			proxies:    []string{"10.0.0.0/8"},
+			remoteAddr: "203.0.113.7:51234",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "headers ignored without trusted proxies",
+			remoteAddr: "10.0.0.2:51234",
+			headers:    map[string]string{"X-Forwarded-For": "203.0.113.7"},
+			want:       "10.0.0.2",
+		},
+		{
+			name:       "headers ignored from untrusted peer",
+			proxies:    []string{"10.0.0.0/8"},
+			remoteAddr: "198.51.100.9:51234",
+			headers:    map[string]string{"X-Forwarded-For": "203.0.113.7"},
+			want:       "198.51.100.9",
+		},
+		{
+			name:       "forwarded through trusted proxy",
+			proxies:    []string{"10.0.0.0/8"},
+			remoteAddr: "10.0.0.2:51234",
+			headers:    map[string]string{"X-Forwarded-For": "203.0.113.7"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "spoofed hops before the client are skipped",
+			proxies:    []string{"10.0.0.0/8", "192.0.2.1"},
+			remoteAddr: "10.0.0.2:51234",
+			headers:    map[string]string{"X-Forwarded-For": "1.2.3.4, 203.0.113.7, 192.0.2.1"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "forwarded header takes precedence",
+			proxies:    []string{"10.0.0.0/8"},
+			remoteAddr: "10.0.0.2:51234",
+			headers: map[string]string{
+				"Forwarded":       `for="[2001:db8::1]:4711";proto=https, for=10.0.0.3`,
+				"X-Forwarded-For": "203.0.113.7",
+			},
+			want: "2001:db8::1",
+		},
+		{
+			name:       "obfuscated hop stops at last trusted proxy",
+			proxies:    []string{"10.0.0.0/8"},
+			remoteAddr: "10.0.0.2:51234",
+			headers:    map[string]string{"Forwarded": "for=_hidden, for=10.0.0.3"},
+			want:       "10.0.0.3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			srv := newServer(t, tt.proxies)
+			req := httptest.NewRequest(http.MethodPost, "/svc/Method", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			srv.Handler().ServeHTTP(rec, req)
+
+			if got := rec.Body.String(); got != tt.want {
+				t.Errorf("client IP = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("invalid trusted proxy", func(t *testing.T) {
+		t.Parallel()
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("listen: %v", err)
+		}
+		defer ln.Close()
+		if _, err := NewServer(WithListener(ln), WithTrustedProxies([]string{"10.0.0.0/33"})); err == nil {
+			t.Fatal("NewServer() error = nil, want invalid trusted proxy error")
+		}
+	})
+}
+
+func TestMergeHeaders(t *testing.T) {
+	t.Parallel()
+
+	got := mergeHeaders([]string{"Content-Type", "Connect-Protocol-Version"}, []string{"connect-protocol-version", " X-Request-Id ", ""})
+	want := []string{"Content-Type", "Connect-Protocol-Version", "X-Request-Id"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("mergeHeaders = %v, want %v", got, want)
+	}
+}
+
+// startIdleProxy forwards TCP connections to target and closes them,
+// like a load balancer would, once no bytes have crossed in either
+// direction for idle. It returns the proxy's address.
+func startIdleProxy(t *testing.T, target string, idle time.Duration) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			client, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			upstream, err := net.Dial("tcp", target)
+			if err != nil {
+				client.Close()
+				continue
+			}
+
+			var last atomic.Int64
+			last.Store(time.Now().UnixNano())
+			var once sync.Once
+			closeBoth := func() {
+				once.Do(func() {
+					client.Close()
+					upstream.Close()
+				})
+			}
+			relay := func(dst, src net.Conn) {
+				defer closeBoth()
+				buf := make([]byte, 32*1024)
+				for {
+					n, err := src.Read(buf)
+					if n > 0 {
+						last.Store(time.Now().UnixNano())
+						if _, err := dst.Write(buf[:n]); err != nil {
+							return
+						}
+					}
+					if err != nil {
+						return
+					}
+				}
+			}
+			go relay(upstream, client)
+			go relay(client, upstream)
+			go func() {
+				ticker := time.NewTicker(idle / 10)
+				defer ticker.Stop()
+				for range ticker.C {
+					if time.Since(time.Unix(0, last.Load())) > idle {
+						closeBoth()
+						return
+					}
+				}
+			}()
+		}
+	}()
+
+	return ln.Addr().String()
+}
+
+func TestServer_StreamKeepAliveOutlivesProxyIdleTimeout(t *testing.T) {
+	t.Parallel()
+
+	const (
+		proxyIdle = 300 * time.Millisecond
+		silence   = 1 * time.Second
+	)
+
+	tests := []struct {
+		name      string
+		keepAlive time.Duration
+		survives  bool
+	}{
+		{"pings keep the idle stream open", 50 * time.Millisecond, true},
+		{"without pings the proxy closes it", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatalf("listen: %v", err)
+			}
+			srv, err := NewServer(
+				WithListener(ln),
+				WithStreamKeepAlive(tt.keepAlive),
+				WithMount(func(mux *http.ServeMux) error {
+					// A stream with nothing to report for longer
+					// than the proxy tolerates.
+					mux.HandleFunc("/stream", func(w http.ResponseWriter, r *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						w.(http.Flusher).Flush()
+						select {
+						case <-time.After(silence):
+						case <-r.Context().Done():
+							return
+						}
+						io.WriteString(w, "event\n")
+					})
+					return nil
+				}),
+			)
+			if err != nil {
+				t.Fatalf("NewServer() error = %v", err)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			go srv.Start(ctx)
+			t.Cleanup(func() {
+				cancel()
+				srv.Stop(context.Background())
+			})
+
+			proxy := startIdleProxy(t, ln.Addr().String(), proxyIdle)
+
+			protocols := new(http.Protocols)
+			protocols.SetUnencryptedHTTP2(true)
+			client := &http.Client{Transport: &http.Transport{Protocols: protocols}}
+			resp, err := client.Get("http://" + proxy + "/stream")
+			if err != nil {
+				t.Fatalf("GET /stream: %v", err)
+			}
+			defer resp.Body.Close()
+
+			line, err := bufio.NewReader(resp.Body).ReadString('\n')
+			survived := err == nil && line == "event\n"
+			if survived != tt.survives {
+				t.Fatalf("stream survived = %v (line %q, err %v), want %v", survived, line, err, tt.survives)
+			}
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"math/rand/v2"
 	"strings"
 	"time"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
 )
 
 // isAuthErr detects authentication-related errors from chisel by
@@ -19,29 +21,39 @@ func isAuthErr(err error) bool {
 		strings.Contains(msg, "invalid auth")
 }
 
-// sleepCtx blocks for d or until ctx is done.
-// Returns true if the sleep completed (context still alive).
-func sleepCtx(ctx context.Context, d time.Duration) bool {
-	t := time.NewTimer(d)
-	defer t.Stop()
-
-	select {
-	case <-t.C:
-		return true
-	case <-ctx.Done():
-		return false
-	}
+// isFingerprintErr detects chisel's host key verification failure,
+// which occurs when the tunnel server's SSH key no longer matches the
+// pinned fingerprint.
+func isFingerprintErr(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "invalid fingerprint")
 }
 
 // backoff implements simple exponential backoff capped at a maximum.
+// Waiting is driven by clock so that retry progression can be tested
+// without real sleeps.
 type backoff struct {
+	clock   core.Clock
 	base    time.Duration
 	max     time.Duration
 	current time.Duration
 }
 
-func newBackoff(base, max time.Duration) *backoff {
-	return &backoff{base: base, max: max, current: base}
+func newBackoff(clock core.Clock, base, max time.Duration) *backoff {
+	return &backoff{clock: clock, base: base, max: max, current: base}
+}
+
+// Sleep blocks for the next backoff delay or until ctx is done.
+// Returns true if the sleep completed (context still alive).
+func (b *backoff) Sleep(ctx context.Context) bool {
+	t := b.clock.NewTimer(b.Next())
+	defer t.Stop()
+
+	select {
+	case <-t.C():
+		return true
+	case <-ctx.Done():
+		return false
+	}
 }
 
 // Next returns a jittered delay based on the current backoff interval,
@@ -0,0 +1,89 @@
+package tunnel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// manualClock is a core.Clock whose timers never fire on their own;
+// the test fires them by sending on the returned channel. It records
+// every requested duration.
+type manualClock struct {
+	core.Clock
+	timers    chan chan time.Time
+	durations []time.Duration
+}
+
+// manualNow is the fixed time reported by manualClock.Now.
+var manualNow = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func (c *manualClock) Now() time.Time { return manualNow }
+
+func (c *manualClock) NewTimer(d time.Duration) core.Timer {
+	c.durations = append(c.durations, d)
+	ch := make(chan time.Time, 1)
+	c.timers <- ch
+	return manualTimer(ch)
+}
+
+type manualTimer chan time.Time
+
+func (t manualTimer) C() <-chan time.Time { return t }
+func (t manualTimer) Stop() bool          { return true }
+
+func TestBackoff_Progression(t *testing.T) {
+	bo := newBackoff(core.NewRealClock(), time.Second, 5*time.Second)
+
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, w := range want {
+		if bo.current != w {
+			t.Fatalf("step %d: current = %v, want %v", i, bo.current, w)
+		}
+		if d := bo.Next(); d < 0 || d > w {
+			t.Fatalf("step %d: jittered delay %v outside [0, %v]", i, d, w)
+		}
+	}
+
+	bo.Reset()
+	if bo.current != time.Second {
+		t.Errorf("after Reset current = %v, want 1s", bo.current)
+	}
+}
+
+func TestBackoff_SleepUsesClock(t *testing.T) {
+	clock := &manualClock{timers: make(chan chan time.Time, 1)}
+	bo := newBackoff(clock, time.Second, 5*time.Second)
+
+	result := make(chan bool)
+	go func() { result <- bo.Sleep(context.Background()) }()
+
+	timer := <-clock.timers
+	timer <- time.Time{}
+	if !<-result {
+		t.Fatal("Sleep should report completion when the timer fires")
+	}
+	if clock.durations[0] > time.Second {
+		t.Errorf("first delay %v exceeds base", clock.durations[0])
+	}
+	if bo.current != 2*time.Second {
+		t.Errorf("current = %v, want 2s after one Sleep", bo.current)
+	}
+}
+
+func TestBackoff_SleepCancelled(t *testing.T) {
+	clock := &manualClock{timers: make(chan chan time.Time, 1)}
+	bo := newBackoff(clock, time.Second, 5*time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan bool)
+	go func() { result <- bo.Sleep(ctx) }()
+
+	<-clock.timers
+	cancel()
+	if <-result {
+		t.Fatal("Sleep should report false when the context is cancelled")
+	}
+}
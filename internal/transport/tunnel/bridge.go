@@ -9,6 +9,7 @@ import (
 	"net"
 	"os"
 	"sync"
+	"sync/atomic"
 
 	"github.com/otterscale/otterscale-agent/internal/transport/pipe"
 )
@@ -25,6 +26,7 @@ type Bridge struct {
 	tcpListener  net.Listener
 	log          *slog.Logger
 	wg           sync.WaitGroup
+	bytes        atomic.Uint64 // relayed in either direction
 }
 
 // NewBridge creates a Bridge that feeds connections into pl.
@@ -48,6 +50,12 @@ func (b *Bridge) Port() int {
 	return b.tcpListener.Addr().(*net.TCPAddr).Port
 }
 
+// BytesProxied returns the total number of bytes relayed between
+// tunnel connections and the HTTP server, in both directions.
+func (b *Bridge) BytesProxied() uint64 {
+	return b.bytes.Load()
+}
+
 // Start accepts TCP connections and bridges them into the pipe
 // listener. It blocks until ctx is cancelled or an unrecoverable
 // error occurs.
@@ -116,11 +124,11 @@ func (b *Bridge) relay(tcpConn net.Conn) {
 
 	errc := make(chan error, 2)
 	go func() {
-		_, err := io.Copy(tcpConn, pipeConn) // pipe → TCP
+		_, err := io.Copy(&countingWriter{w: tcpConn, n: &b.bytes}, pipeConn) // pipe → TCP
 		errc <- err
 	}()
 	go func() {
-		_, err := io.Copy(pipeConn, tcpConn) // TCP → pipe
+		_, err := io.Copy(&countingWriter{w: pipeConn, n: &b.bytes}, tcpConn) // TCP → pipe
 		errc <- err
 	}()
 
@@ -130,5 +138,19 @@ func (b *Bridge) relay(tcpConn net.Conn) {
 	<-errc // second direction done
 }
 
+// countingWriter adds the number of bytes written to n as they pass
+// through, so long-lived streams (watches, exec) are reflected before
+// the connection ends.
+type countingWriter struct {
+	w io.Writer
+	n *atomic.Uint64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n.Add(uint64(n))
+	return n, err
+}
+
 // ErrBridgeRequired is returned when a Bridge is expected but nil.
 var ErrBridgeRequired = errors.New("tunnel: bridge is required")
@@ -7,21 +7,31 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
 	"time"
 
 	chclient "github.com/jpillora/chisel/client"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
 )
 
 // Sentinel errors for well-known failure modes.
 var (
 	ErrLocalPortRequired = errors.New("tunnel: local port is required")
 	ErrRegisterRequired  = errors.New("tunnel: register function is required")
+
+	// ErrRegisterAttemptsExhausted is returned by Start when
+	// registration failed as many consecutive times as configured by
+	// WithMaxRegisterAttempts.
+	ErrRegisterAttemptsExhausted = errors.New("tunnel: registration attempts exhausted")
 )
 
 // RegisterResult holds the mTLS credentials and tunnel endpoint
 // returned by a successful registration.
 type RegisterResult struct {
+	// AgentID is the identity the agent registered under.
+	AgentID string
 	// Endpoint is the tunnel server's allocated address (host:port).
 	Endpoint string
 	// Auth is the chisel auth string ("user:password") derived from
@@ -35,6 +45,10 @@ type RegisterResult struct {
 	// KeyPEM is the PEM-encoded private key corresponding to the
 	// client certificate.
 	KeyPEM []byte
+	// Fingerprint is the tunnel server's SSH host key fingerprint as
+	// reported at registration. It is pinned for the following
+	// connection unless a static fingerprint is configured.
+	Fingerprint string
 }
 
 // RegisterFunc registers an agent and returns mTLS credentials.
@@ -47,21 +61,32 @@ type ClientOption func(*Client)
 // registration, reconnection, and exponential backoff. It uses mTLS
 // for tunnel authentication.
 type Client struct {
-	mu               sync.Mutex       // protects inner and certDir
-	inner            *chclient.Client // owned lifecycle, not exported
-	certDir          string           // temp directory for TLS cert files
+	mu      sync.Mutex       // protects inner and certDir
+	inner   *chclient.Client // owned lifecycle, not exported
+	certDir string           // temp directory for TLS cert files
 
 	cluster          string
 	serverURL        string
 	tunnelServerURL  string
+	fallbackURLs     []string // tried in order after tunnelServerURL
 	localPort        int
 	keepAlive        time.Duration
 	maxRetryCount    int
 	maxRetryInterval time.Duration
 	baseRetryDelay   time.Duration
 	maxRetryDelay    time.Duration
+	maxRegister      int    // consecutive failed registrations before Start gives up; 0 means never
+	fingerprint      string // statically pinned; overrides RegisterResult.Fingerprint
 	register         RegisterFunc
+	clock            core.Clock
 	log              *slog.Logger
+	lifecycleLog     *slog.Logger // log without the cluster attribute
+
+	state connState // reported by Status
+
+	// connect runs one tunnel session with the given chisel
+	// configuration. It defaults to runChisel and is replaced in tests.
+	connect func(ctx context.Context, cfg *chclient.Config) error
 }
 
 // WithCluster configures the cluster name used for registration.
@@ -79,6 +104,15 @@ func WithTunnelServerURL(tunnelServerURL string) ClientOption {
 	return func(c *Client) { c.tunnelServerURL = tunnelServerURL }
 }
 
+// WithFallbackTunnelServerURLs configures additional tunnel server
+// URLs, e.g. for servers reachable on several networks. When a session
+// cannot open any connection to the current URL, the client moves on
+// to the next one, trying the primary URL first and then the
+// fallbacks in order. Backoff applies only once every URL has failed.
+func WithFallbackTunnelServerURLs(urls ...string) ClientOption {
+	return func(c *Client) { c.fallbackURLs = urls }
+}
+
 // WithLocalPort configures the local port to expose through the tunnel.
 func WithLocalPort(localPort int) ClientOption {
 	return func(c *Client) { c.localPort = localPort }
@@ -109,17 +143,40 @@ func WithMaxRetryDelay(maxRetryDelay time.Duration) ClientOption {
 	return func(c *Client) { c.maxRetryDelay = maxRetryDelay }
 }
 
+// WithMaxRegisterAttempts makes Start return an error once
+// registration has failed n consecutive times, so that the process can
+// exit and be restarted or alerted on by its orchestrator. Zero, the
+// default, retries forever.
+func WithMaxRegisterAttempts(n int) ClientOption {
+	return func(c *Client) { c.maxRegister = n }
+}
+
+// WithFingerprint pins the tunnel server's SSH host key fingerprint.
+// When set, the fingerprint reported at registration is ignored and a
+// mismatch is not recovered by re-registering. Leave it empty to trust
+// the fingerprint returned by the fleet server.
+func WithFingerprint(fingerprint string) ClientOption {
+	return func(c *Client) { c.fingerprint = fingerprint }
+}
+
 // WithRegister configures the function used to register with the fleet server.
 func WithRegister(register RegisterFunc) ClientOption {
 	return func(c *Client) { c.register = register }
 }
 
 // WithLogger configures a structured logger. Defaults to slog.Default
-// with "component" and "cluster" attributes.
+// with a "component" attribute. The client adds a "cluster" attribute
+// to its records.
 func WithLogger(log *slog.Logger) ClientOption {
 	return func(c *Client) { c.log = log }
 }
 
+// WithClock configures the time source for retry backoff. Defaults to
+// the real clock.
+func WithClock(clock core.Clock) ClientOption {
+	return func(c *Client) { c.clock = clock }
+}
+
 // NewClient creates a tunnel client. It validates required fields
 // but does not perform any I/O.
 func NewClient(opts ...ClientOption) (*Client, error) {
@@ -132,6 +189,7 @@ func NewClient(opts ...ClientOption) (*Client, error) {
 		maxRetryInterval: 10 * time.Second,
 		baseRetryDelay:   1 * time.Second,
 		maxRetryDelay:    30 * time.Second,
+		clock:            core.NewRealClock(),
 	}
 	for _, opt := range opts {
 		opt(c)
@@ -144,7 +202,13 @@ func NewClient(opts ...ClientOption) (*Client, error) {
 		return nil, ErrRegisterRequired
 	}
 	if c.log == nil {
-		c.log = slog.Default().With("component", "tunnel-client", "cluster", c.cluster)
+		c.log = slog.Default().With("component", "tunnel-client")
+	}
+	// Lifecycle records carry the cluster themselves.
+	c.lifecycleLog = c.log
+	c.log = c.log.With("cluster", c.cluster)
+	if c.connect == nil {
+		c.connect = c.runChisel
 	}
 
 	return c, nil
@@ -152,32 +216,89 @@ func NewClient(opts ...ClientOption) (*Client, error) {
 
 // Start runs the tunnel client loop. It blocks until ctx is cancelled,
 // automatically re-registering and reconnecting on failures with
-// exponential backoff.
+// exponential backoff. It returns an error wrapping
+// ErrRegisterAttemptsExhausted if registration fails more consecutive
+// times than allowed by WithMaxRegisterAttempts.
 func (c *Client) Start(ctx context.Context) error {
-	bo := newBackoff(c.baseRetryDelay, c.maxRetryDelay)
+	bo := newBackoff(c.clock, c.baseRetryDelay, c.maxRetryDelay)
+	sessions := 0
+	registerFailures := 0
+	candidates := c.tunnelServerURLs()
+	current, failed := 0, 0 // failed counts consecutive unreachable URLs
+	reason := ""            // why the previous session ended
 
 	for {
 		if ctx.Err() != nil {
 			return nil
 		}
 
-		inner, err := c.dial(ctx)
+		tunnelServerURL := candidates[current]
+		cfg, reg, err := c.dial(ctx, tunnelServerURL)
 		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			registerFailures++
+			if c.maxRegister > 0 && registerFailures >= c.maxRegister {
+				c.log.Error("registration failed, giving up", "error", err, "attempts", registerFailures)
+				return fmt.Errorf("%w after %d attempts: %w", ErrRegisterAttemptsExhausted, registerFailures, err)
+			}
 			c.log.Warn("registration failed, retrying", "error", err, "retry_in", bo.current)
-			if !sleepCtx(ctx, bo.Next()) {
+			if !bo.Sleep(ctx) {
 				return nil
 			}
 			continue
 		}
 		bo.Reset()
-		c.mu.Lock()
-		c.inner = inner
-		c.mu.Unlock()
+		registerFailures = 0
 
-		err = c.runSession(ctx, inner)
+		if sessions > 0 {
+			c.state.reconnects.Add(1)
+			c.lifecycle(EventReregistered, reg, reason)
+		}
+		sessions++
+
+		dials := c.state.dials.Load()
+		err = c.connect(ctx, cfg)
+		connected := c.state.dials.Load() > dials
+		reason = sessionEndReason(ctx, err)
+		if connected {
+			c.lifecycle(EventDisconnected, reg, reason)
+		}
 		if ctx.Err() != nil {
 			return nil
 		}
+		if connected {
+			failed = 0
+		} else if err != nil && len(candidates) > 1 {
+			// No connection was ever opened, so this URL is likely
+			// unreachable from the agent's network. Try the next one
+			// right away until every URL has failed in turn.
+			current = (current + 1) % len(candidates)
+			failed++
+			if failed < len(candidates) {
+				c.log.Warn("tunnel server unreachable, trying next url",
+					"error", err, "server", tunnelServerURL, "next", candidates[current])
+				continue
+			}
+			failed = 0
+		}
+		if err != nil && isFingerprintErr(err) {
+			if c.fingerprint == "" {
+				// The server's host key changed (e.g. a new key seed or a
+				// restart without a persisted seed). Registration returns
+				// the current fingerprint, so re-register right away.
+				c.log.Warn("tunnel server fingerprint changed, re-registering", "error", err)
+				bo.Reset()
+				continue
+			}
+			c.log.Error("tunnel server fingerprint does not match the configured fingerprint, retrying",
+				"error", err, "retry_in", bo.current)
+			if !bo.Sleep(ctx) {
+				return nil
+			}
+			continue
+		}
 		if err == nil || isAuthErr(err) {
 			if err != nil {
 				c.log.Warn("authentication failed, re-registering", "error", err)
@@ -189,12 +310,53 @@ func (c *Client) Start(ctx context.Context) error {
 		}
 
 		c.log.Warn("connection lost, retrying", "error", err, "retry_in", bo.current)
-		if !sleepCtx(ctx, bo.Next()) {
+		if !bo.Sleep(ctx) {
 			return nil
 		}
 	}
 }
 
+// lifecycle logs a lifecycle event of the session registered as reg.
+func (c *Client) lifecycle(event string, reg *RegisterResult, reason string) {
+	LogLifecycle(c.lifecycleLog, LifecycleEvent{
+		Event:    event,
+		Cluster:  c.cluster,
+		AgentID:  reg.AgentID,
+		Endpoint: reg.Endpoint,
+		Reason:   reason,
+	})
+}
+
+// sessionEndReason describes why a tunnel session ended with err.
+func sessionEndReason(ctx context.Context, err error) string {
+	switch {
+	case ctx.Err() != nil:
+		return "shutting down"
+	case err == nil:
+		return "session ended"
+	default:
+		return err.Error()
+	}
+}
+
+// tunnelServerURLs returns the candidate tunnel server URLs in the
+// order they are tried, without empty or duplicate entries.
+func (c *Client) tunnelServerURLs() []string {
+	urls := make([]string, 0, 1+len(c.fallbackURLs))
+	for _, u := range append([]string{c.tunnelServerURL}, c.fallbackURLs...) {
+		if u != "" && !slices.Contains(urls, u) {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
+// Status returns a snapshot of the client's connection state. It is
+// safe to call concurrently with Start.
+func (c *Client) Status() Status {
+	return c.state.snapshot()
+}
+
 // Stop gracefully shuts down the tunnel client and cleans up temp files.
 func (c *Client) Stop(_ context.Context) error {
 	c.mu.Lock()
@@ -214,19 +376,20 @@ func (c *Client) Stop(_ context.Context) error {
 }
 
 // dial registers with the fleet server, writes mTLS credentials to
-// temp files, and creates a new chisel client configured for mTLS.
-func (c *Client) dial(ctx context.Context) (*chclient.Client, error) {
+// temp files, and returns a chisel client configuration for mTLS
+// connections to tunnelServerURL along with the registration.
+func (c *Client) dial(ctx context.Context, tunnelServerURL string) (*chclient.Config, *RegisterResult, error) {
 	result, err := c.register(ctx, c.serverURL, c.cluster)
 	if err != nil {
-		return nil, fmt.Errorf("register: %w", err)
+		return nil, nil, fmt.Errorf("register: %w", err)
 	}
 
-	c.log.Info("registered", "endpoint", result.Endpoint)
+	c.state.registered(result.Endpoint, c.clock.Now())
 
 	// Write mTLS credentials to a temp directory.
 	dir, err := os.MkdirTemp("", "otterscale-tls-*")
 	if err != nil {
-		return nil, fmt.Errorf("create cert dir: %w", err)
+		return nil, nil, fmt.Errorf("create cert dir: %w", err)
 	}
 
 	// Atomically swap the cert directory under a single lock to
@@ -247,18 +410,24 @@ func (c *Client) dial(ctx context.Context) (*chclient.Client, error) {
 	keyFile := filepath.Join(dir, "key.pem")
 
 	if err := os.WriteFile(caFile, result.CACertPEM, 0600); err != nil {
-		return nil, fmt.Errorf("write CA cert: %w", err)
+		return nil, nil, fmt.Errorf("write CA cert: %w", err)
 	}
 	if err := os.WriteFile(certFile, result.CertPEM, 0600); err != nil {
-		return nil, fmt.Errorf("write client cert: %w", err)
+		return nil, nil, fmt.Errorf("write client cert: %w", err)
 	}
 	if err := os.WriteFile(keyFile, result.KeyPEM, 0600); err != nil {
-		return nil, fmt.Errorf("write client key: %w", err)
+		return nil, nil, fmt.Errorf("write client key: %w", err)
+	}
+
+	fingerprint := c.fingerprint
+	if fingerprint == "" {
+		fingerprint = result.Fingerprint
 	}
 
-	return chclient.NewClient(&chclient.Config{
-		Server: c.tunnelServerURL,
-		Auth:   result.Auth,
+	return &chclient.Config{
+		Server:      tunnelServerURL,
+		Auth:        result.Auth,
+		Fingerprint: fingerprint,
 		TLS: chclient.TLSConfig{
 			CA:   caFile,
 			Cert: certFile,
@@ -268,13 +437,30 @@ func (c *Client) dial(ctx context.Context) (*chclient.Client, error) {
 		KeepAlive:        c.keepAlive,
 		MaxRetryCount:    c.maxRetryCount,
 		MaxRetryInterval: c.maxRetryInterval,
-	})
+		DialContext: c.state.dialer(tunnelServerURL, func() {
+			c.lifecycle(EventConnected, result, "")
+		}),
+	}, result, nil
+}
+
+// runChisel creates a chisel client from cfg and runs it until the
+// session ends.
+func (c *Client) runChisel(ctx context.Context, cfg *chclient.Config) error {
+	inner, err := chclient.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("create chisel client: %w", err)
+	}
+	c.mu.Lock()
+	c.inner = inner
+	c.mu.Unlock()
+
+	return c.runSession(ctx, inner, cfg.Server)
 }
 
 // runSession starts the inner chisel client and waits for it to finish.
 // It always closes the inner client before returning.
-func (c *Client) runSession(ctx context.Context, inner *chclient.Client) error {
-	c.log.Info("connecting", "server", c.tunnelServerURL)
+func (c *Client) runSession(ctx context.Context, inner *chclient.Client, server string) error {
+	c.log.Debug("connecting", "server", server)
 
 	if err := inner.Start(ctx); err != nil {
 		if closeErr := inner.Close(); closeErr != nil {
@@ -289,4 +475,3 @@ func (c *Client) runSession(ctx context.Context, inner *chclient.Client) error {
 	}
 	return err
 }
-
@@ -0,0 +1,387 @@
+package tunnel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	chclient "github.com/jpillora/chisel/client"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+)
+
+// fingerprintRotation simulates a tunnel server whose host key changes
+// between registrations: each registration reports the next
+// fingerprint, and a session succeeds only when the pinned fingerprint
+// matches the current key.
+type fingerprintRotation struct {
+	fingerprints []string // reported by successive registrations
+	current      string   // fingerprint of the server's live key
+	registers    int
+	pinned       []string // fingerprint used by each session
+}
+
+func (f *fingerprintRotation) register(_ context.Context, _, _ string) (*RegisterResult, error) {
+	fp := f.fingerprints[min(f.registers, len(f.fingerprints)-1)]
+	f.registers++
+	return &RegisterResult{Endpoint: "127.0.0.2:16598", Auth: "user:pass", Fingerprint: fp}, nil
+}
+
+func newFingerprintTestClient(t *testing.T, f *fingerprintRotation, clock core.Clock, opts ...ClientOption) *Client {
+	t.Helper()
+	opts = append([]ClientOption{
+		WithLocalPort(1),
+		WithRegister(f.register),
+		WithClock(clock),
+	}, opts...)
+	c, err := NewClient(opts...)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	t.Cleanup(func() { _ = c.Stop(context.Background()) })
+	return c
+}
+
+func TestClient_FingerprintChangeReregisters(t *testing.T) {
+	// The agent registered while the server used key "old"; the server
+	// has since restarted with key "new".
+	f := &fingerprintRotation{fingerprints: []string{"SHA256:old", "SHA256:new"}, current: "SHA256:new"}
+	clock := &manualClock{timers: make(chan chan time.Time, 1)}
+	c := newFingerprintTestClient(t, f, clock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c.connect = func(_ context.Context, cfg *chclient.Config) error {
+		f.pinned = append(f.pinned, cfg.Fingerprint)
+		if cfg.Fingerprint != f.current {
+			return errors.New("ssh: handshake failed: Invalid fingerprint (" + f.current + ")")
+		}
+		// Connected with the refreshed fingerprint; end the test.
+		cancel()
+		return nil
+	}
+
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	if f.registers != 2 {
+		t.Errorf("registrations = %d, want 2", f.registers)
+	}
+	if want := []string{"SHA256:old", "SHA256:new"}; len(f.pinned) != 2 || f.pinned[0] != want[0] || f.pinned[1] != want[1] {
+		t.Errorf("pinned fingerprints = %v, want %v", f.pinned, want)
+	}
+	if len(clock.durations) != 0 {
+		t.Errorf("fingerprint change should re-register without backoff, slept %v", clock.durations)
+	}
+}
+
+func TestClient_StaticFingerprintMismatchBacksOff(t *testing.T) {
+	f := &fingerprintRotation{fingerprints: []string{"SHA256:new"}, current: "SHA256:new"}
+	clock := &manualClock{timers: make(chan chan time.Time, 1)}
+	c := newFingerprintTestClient(t, f, clock, WithFingerprint("SHA256:pinned"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.connect = func(_ context.Context, cfg *chclient.Config) error {
+		f.pinned = append(f.pinned, cfg.Fingerprint)
+		return errors.New("Invalid fingerprint (" + f.current + ")")
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- c.Start(ctx) }()
+
+	// The client must back off instead of re-registering immediately.
+	<-clock.timers
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	if len(f.pinned) != 1 || f.pinned[0] != "SHA256:pinned" {
+		t.Errorf("pinned fingerprints = %v, want the static fingerprint only", f.pinned)
+	}
+}
+
+func TestIsFingerprintErr(t *testing.T) {
+	if !isFingerprintErr(errors.New("ssh: handshake failed: Invalid fingerprint (abc)")) {
+		t.Error("expected chisel fingerprint error to be detected")
+	}
+	if isFingerprintErr(errors.New("ssh: unable to authenticate")) {
+		t.Error("auth failure must not be reported as a fingerprint error")
+	}
+}
+
+func TestClient_StatusTracksConnection(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	f := &fingerprintRotation{fingerprints: []string{"SHA256:fp"}, current: "SHA256:fp"}
+	clock := &manualClock{timers: make(chan chan time.Time, 1)}
+	c := newFingerprintTestClient(t, f, clock)
+
+	if s := c.Status(); s.Connected || s.Endpoint != "" || !s.LastRegistration.IsZero() {
+		t.Fatalf("initial status = %+v, want zero", s)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var during []Status
+	c.connect = func(ctx context.Context, cfg *chclient.Config) error {
+		conn, err := cfg.DialContext(ctx, "tcp", ln.Addr().String())
+		if err != nil {
+			return err
+		}
+		during = append(during, c.Status())
+		conn.Close()
+		if len(during) == 2 {
+			cancel()
+		}
+		return nil // session ended; the client re-registers
+	}
+
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	if len(during) != 2 {
+		t.Fatalf("sessions = %d, want 2", len(during))
+	}
+	for i, s := range during {
+		if !s.Connected {
+			t.Errorf("session %d: Connected = false while a connection was open", i)
+		}
+		if s.Endpoint != "127.0.0.2:16598" || !s.LastRegistration.Equal(manualNow) {
+			t.Errorf("session %d: status = %+v", i, s)
+		}
+		if s.Reconnects != uint64(i) {
+			t.Errorf("session %d: Reconnects = %d, want %d", i, s.Reconnects, i)
+		}
+	}
+	if c.Status().Connected {
+		t.Error("Connected = true after the connection was closed")
+	}
+}
+
+func TestClient_FallsBackToNextTunnelServerURL(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	const internal, external = "https://tunnel.internal:8300", "https://tunnel.example.com:8300"
+	f := &fingerprintRotation{fingerprints: []string{"SHA256:fp"}, current: "SHA256:fp"}
+	clock := &manualClock{timers: make(chan chan time.Time, 1)}
+	c := newFingerprintTestClient(t, f, clock,
+		WithTunnelServerURL(internal),
+		WithFallbackTunnelServerURLs(external),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var tried []string
+	var during Status
+	c.connect = func(ctx context.Context, cfg *chclient.Config) error {
+		tried = append(tried, cfg.Server)
+		if cfg.Server == internal {
+			return errors.New("dial tcp: lookup tunnel.internal: no such host")
+		}
+		conn, err := cfg.DialContext(ctx, "tcp", ln.Addr().String())
+		if err != nil {
+			return err
+		}
+		during = c.Status()
+		conn.Close()
+		cancel()
+		return nil
+	}
+
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	if len(tried) != 2 || tried[0] != internal || tried[1] != external {
+		t.Errorf("tried = %v, want [%s %s]", tried, internal, external)
+	}
+	if len(clock.durations) != 0 {
+		t.Errorf("falling back should not back off, slept %v", clock.durations)
+	}
+	if !during.Connected || during.TunnelServerURL != external {
+		t.Errorf("status = %+v, want connected through %s", during, external)
+	}
+}
+
+func TestClient_BacksOffWhenAllTunnelServerURLsFail(t *testing.T) {
+	f := &fingerprintRotation{fingerprints: []string{"SHA256:fp"}, current: "SHA256:fp"}
+	clock := &manualClock{timers: make(chan chan time.Time, 1)}
+	c := newFingerprintTestClient(t, f, clock,
+		WithTunnelServerURL("https://a:8300"),
+		WithFallbackTunnelServerURLs("https://b:8300", "https://a:8300", ""),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var tried []string
+	c.connect = func(_ context.Context, cfg *chclient.Config) error {
+		tried = append(tried, cfg.Server)
+		return errors.New("connection refused")
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- c.Start(ctx) }()
+
+	// Both distinct URLs are tried before the first backoff.
+	<-clock.timers
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	if want := []string{"https://a:8300", "https://b:8300"}; len(tried) != 2 || tried[0] != want[0] || tried[1] != want[1] {
+		t.Errorf("tried = %v, want %v", tried, want)
+	}
+}
+
+func TestClient_GivesUpAfterMaxRegisterAttempts(t *testing.T) {
+	clock := &manualClock{timers: make(chan chan time.Time, 1)}
+	registers := 0
+	c := newFingerprintTestClient(t, &fingerprintRotation{}, clock,
+		WithMaxRegisterAttempts(3),
+		WithRegister(func(context.Context, string, string) (*RegisterResult, error) {
+			registers++
+			return nil, errors.New("connection refused")
+		}),
+	)
+
+	done := make(chan error, 1)
+	go func() { done <- c.Start(context.Background()) }()
+
+	var err error
+	for waiting := true; waiting; {
+		select {
+		case timer := <-clock.timers:
+			timer <- manualNow
+		case err = <-done:
+			waiting = false
+		}
+	}
+
+	if !errors.Is(err, ErrRegisterAttemptsExhausted) {
+		t.Fatalf("Start error = %v, want ErrRegisterAttemptsExhausted", err)
+	}
+	if registers != 3 {
+		t.Errorf("registrations = %d, want 3", registers)
+	}
+	if len(clock.durations) != 2 {
+		t.Errorf("backoffs = %v, want one between each attempt", clock.durations)
+	}
+}
+
+func TestClient_LogsLifecycleEvents(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	var buf bytes.Buffer
+	f := &fingerprintRotation{fingerprints: []string{"SHA256:fp"}, current: "SHA256:fp"}
+	c := newFingerprintTestClient(t, f, &manualClock{timers: make(chan chan time.Time, 1)},
+		WithCluster("edge-1"),
+		WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+		WithRegister(func(ctx context.Context, serverURL, cluster string) (*RegisterResult, error) {
+			result, err := f.register(ctx, serverURL, cluster)
+			if err != nil {
+				return nil, err
+			}
+			result.AgentID = "agent-1"
+			return result, nil
+		}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sessions := 0
+	c.connect = func(ctx context.Context, cfg *chclient.Config) error {
+		sessions++
+		conn, err := cfg.DialContext(ctx, "tcp", ln.Addr().String())
+		if err != nil {
+			return err
+		}
+		conn.Close()
+		if sessions > 1 {
+			cancel()
+		}
+		return nil
+	}
+
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	var events []map[string]any
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var record map[string]any
+		if err := dec.Decode(&record); err != nil {
+			t.Fatalf("decode log record: %v", err)
+		}
+		if record["msg"] == lifecycleMessage {
+			events = append(events, record)
+		}
+	}
+
+	want := []struct{ event, reason string }{
+		{EventConnected, ""},
+		{EventDisconnected, "session ended"},
+		{EventReregistered, "session ended"},
+		{EventConnected, ""},
+		{EventDisconnected, "shutting down"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d lifecycle events, want %d: %v", len(events), len(want), events)
+	}
+	for i, w := range want {
+		e := events[i]
+		if e["event"] != w.event || e["reason"] != w.reason {
+			t.Errorf("event %d = %v/%q, want %v/%q", i, e["event"], e["reason"], w.event, w.reason)
+		}
+		if e["cluster"] != "edge-1" || e["agent_id"] != "agent-1" || e["endpoint"] != "127.0.0.2:16598" {
+			t.Errorf("event %d fields = %v, want cluster edge-1, agent_id agent-1, endpoint 127.0.0.2:16598", i, e)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package tunnel
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Tunnel lifecycle events, logged by LogLifecycle on both the agent
+// and the server side.
+const (
+	// EventConnected is logged when a tunnel becomes usable: on the
+	// agent when a session opens its connection to the tunnel server,
+	// and on the server when a cluster's endpoint first answers.
+	EventConnected = "tunnel_connected"
+	// EventDisconnected is logged when a tunnel is lost: on the agent
+	// when a connected session ends, and on the server when a cluster
+	// is deregistered.
+	EventDisconnected = "tunnel_disconnected"
+	// EventReregistered is logged when a cluster that was already
+	// registered registers again and moves to a new endpoint.
+	EventReregistered = "tunnel_reregistered"
+)
+
+// lifecycleMessage is the message of every lifecycle record, so that
+// they can be selected without knowing the event names.
+const lifecycleMessage = "tunnel lifecycle"
+
+// LifecycleEvent describes a tunnel lifecycle transition.
+type LifecycleEvent struct {
+	// Event is one of EventConnected, EventDisconnected or
+	// EventReregistered.
+	Event    string
+	Cluster  string
+	AgentID  string
+	Endpoint string
+	// Reason explains a disconnection or re-registration. It is empty
+	// for connections.
+	Reason string
+}
+
+// LogLifecycle logs e to log. Every record carries the same
+// attributes (event, cluster, agent_id, endpoint and reason, empty if
+// unknown) so that log-based alerting can rely on them.
+// Disconnections are logged at warn level, other events at info.
+func LogLifecycle(log *slog.Logger, e LifecycleEvent) {
+	level := slog.LevelInfo
+	if e.Event == EventDisconnected {
+		level = slog.LevelWarn
+	}
+	log.LogAttrs(context.Background(), level, lifecycleMessage,
+		slog.String("event", e.Event),
+		slog.String("cluster", e.Cluster),
+		slog.String("agent_id", e.AgentID),
+		slog.String("endpoint", e.Endpoint),
+		slog.String("reason", e.Reason),
+	)
+}
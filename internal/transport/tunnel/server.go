@@ -2,6 +2,7 @@ package tunnel
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"log/slog"
 	"net"
@@ -23,6 +24,14 @@ type Server struct {
 	tlsKey    string // file path to server private key
 	tlsCA     string // file path to CA certificate (enables mTLS)
 	log       *slog.Logger
+
+	tlsMinVersion   uint16
+	tlsCipherSuites []uint16
+	// tlsConfig is set when TLS is configured. The server then
+	// terminates TLS itself and relays to chisel on loopback, because
+	// chisel builds its own TLS configuration with no way to restrict
+	// versions or cipher suites.
+	tlsConfig *tls.Config
 }
 
 // WithAddress configures the listen address (e.g. ":8300").
@@ -47,6 +56,19 @@ func WithTLSCA(path string) ServerOption {
 	return func(s *Server) { s.tlsCA = path }
 }
 
+// WithTLSMinVersion configures the minimum TLS version accepted from
+// tunnel clients, e.g. tls.VersionTLS13. Defaults to TLS 1.2.
+func WithTLSMinVersion(version uint16) ServerOption {
+	return func(s *Server) { s.tlsMinVersion = version }
+}
+
+// WithTLSCipherSuites restricts the TLS 1.2 cipher suites accepted
+// from tunnel clients. Defaults to Go's secure suites. See
+// ParseCipherSuites.
+func WithTLSCipherSuites(ids []uint16) ServerOption {
+	return func(s *Server) { s.tlsCipherSuites = ids }
+}
+
 // WithServer injects a shared atomic server reference. The reference
 // is typically owned by a TunnelProvider; init will store the fully
 // initialized server into it so that both sides share the same
@@ -88,14 +110,49 @@ func (s *Server) Start(ctx context.Context) error {
 		return fmt.Errorf("parse address %q: %w", s.address, err)
 	}
 
-	s.log.Info("starting", "address", s.address)
-
 	srv := s.serverRef.Load()
-	if err := srv.StartContext(ctx, host, port); err != nil {
+	if s.tlsConfig == nil {
+		s.log.Info("starting", "address", s.address)
+		if err := srv.StartContext(ctx, host, port); err != nil {
+			return fmt.Errorf("tunnel server start: %w", err)
+		}
+		return srv.Wait()
+	}
+
+	s.log.Info("starting", "address", s.address, "min_tls_version", tls.VersionName(s.tlsConfig.MinVersion))
+
+	ln, err := net.Listen("tcp", s.address)
+	if err != nil {
+		return fmt.Errorf("tunnel server listen: %w", err)
+	}
+	backend, err := reserveLoopbackAddress()
+	if err != nil {
+		ln.Close()
+		return fmt.Errorf("reserve chisel address: %w", err)
+	}
+	backendHost, backendPort, _ := net.SplitHostPort(backend)
+	if err := srv.StartContext(ctx, backendHost, backendPort); err != nil {
+		ln.Close()
 		return fmt.Errorf("tunnel server start: %w", err)
 	}
 
-	return srv.Wait()
+	serveErr := make(chan error, 1)
+	go func() {
+		err := s.serveTLS(tls.NewListener(ln, s.tlsConfig), backend)
+		if err != nil {
+			srv.Close()
+		}
+		serveErr <- err
+	}()
+
+	// chisel stops when ctx is cancelled, Stop closes it or the TLS
+	// listener fails; the TLS listener follows it.
+	err = srv.Wait()
+	ln.Close()
+	if lisErr := <-serveErr; lisErr != nil {
+		return lisErr
+	}
+	return err
 }
 
 // Stop gracefully shuts down the tunnel server.
@@ -116,13 +173,14 @@ func (s *Server) init() error {
 		Reverse: true,
 	}
 
-	// Configure TLS for mTLS when certificate paths are provided.
+	// Terminate TLS, with mTLS when a CA is provided, in front of
+	// chisel when certificate paths are provided.
 	if s.tlsCert != "" && s.tlsKey != "" {
-		cfg.TLS = chserver.TLSConfig{
-			Cert: s.tlsCert,
-			Key:  s.tlsKey,
-			CA:   s.tlsCA,
+		tlsConfig, err := s.serverTLSConfig()
+		if err != nil {
+			return err
 		}
+		s.tlsConfig = tlsConfig
 	}
 
 	ch, err := chserver.NewServer(cfg)
@@ -0,0 +1,144 @@
+package tunnel
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/otterscale/otterscale-agent/internal/pki"
+)
+
+// writeTLSMaterials writes a CA, a server certificate for 127.0.0.1
+// and the CA certificate to dir, and returns their paths with a client
+// certificate signed by the CA.
+func writeTLSMaterials(t *testing.T, dir string) (caFile, certFile, keyFile string, client tls.Certificate, roots *x509.CertPool) {
+	t.Helper()
+	ca, err := pki.NewCA()
+	if err != nil {
+		t.Fatalf("NewCA: %v", err)
+	}
+	serverCert, serverKey, err := ca.GenerateServerCert("127.0.0.1")
+	if err != nil {
+		t.Fatalf("GenerateServerCert: %v", err)
+	}
+	caFile = filepath.Join(dir, "ca.pem")
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	for path, data := range map[string][]byte{caFile: ca.CertPEM(), certFile: serverCert, keyFile: serverKey} {
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+
+	key, keyPEM, err := pki.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	csr, err := pki.GenerateCSR(key, "agent-edge-1")
+	if err != nil {
+		t.Fatalf("GenerateCSR: %v", err)
+	}
+	clientCert, err := ca.SignCSR(csr)
+	if err != nil {
+		t.Fatalf("SignCSR: %v", err)
+	}
+	client, err = tls.X509KeyPair(clientCert, keyPEM)
+	if err != nil {
+		t.Fatalf("client key pair: %v", err)
+	}
+	roots = x509.NewCertPool()
+	roots.AppendCertsFromPEM(ca.CertPEM())
+	return caFile, certFile, keyFile, client, roots
+}
+
+func TestServer_RefusesHandshakesBelowMinVersion(t *testing.T) {
+	caFile, certFile, keyFile, client, roots := writeTLSMaterials(t, t.TempDir())
+	address, err := reserveLoopbackAddress()
+	if err != nil {
+		t.Fatalf("reserve address: %v", err)
+	}
+
+	srv, err := NewServer(
+		WithAddress(address),
+		WithTLSCert(certFile),
+		WithTLSKey(keyFile),
+		WithTLSCA(caFile),
+		WithTLSMinVersion(tls.VersionTLS13),
+	)
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- srv.Start(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Start: %v", err)
+		}
+	})
+
+	handshake := func(maxVersion uint16) error {
+		dialer := &tls.Dialer{
+			NetDialer: &net.Dialer{Timeout: time.Second},
+			Config: &tls.Config{
+				Certificates: []tls.Certificate{client},
+				RootCAs:      roots,
+				MaxVersion:   maxVersion,
+			},
+		}
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+
+	// Wait for the listener with a compliant client.
+	deadline := time.Now().Add(5 * time.Second)
+	for err := handshake(tls.VersionTLS13); err != nil; err = handshake(tls.VersionTLS13) {
+		if time.Now().After(deadline) {
+			t.Fatalf("TLS 1.3 handshake: %v", err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	if err := handshake(tls.VersionTLS12); err == nil {
+		t.Error("TLS 1.2 handshake succeeded, want it refused")
+	}
+}
+
+func TestParseTLSSettings(t *testing.T) {
+	tests := []struct {
+		version    string
+		ciphers    []string
+		wantSuites int
+		wantErr    bool
+	}{
+		{version: "", wantSuites: 0},
+		{version: "1.2", ciphers: []string{"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"}, wantSuites: 1},
+		{version: "1.3"},
+		{version: "1.1", wantErr: true},
+		{version: "1.3", ciphers: []string{"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"}, wantErr: true},
+		{version: "1.2", ciphers: []string{"TLS_AES_128_GCM_SHA256"}, wantErr: true},
+		{version: "1.2", ciphers: []string{"TLS_RSA_WITH_RC4_128_SHA"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		version, err := ParseTLSVersion(tt.version)
+		if err == nil {
+			var suites []uint16
+			suites, err = ParseCipherSuites(tt.ciphers, version)
+			if err == nil && len(suites) != tt.wantSuites {
+				t.Errorf("ParseCipherSuites(%v) = %v, want %d suites", tt.ciphers, suites, tt.wantSuites)
+			}
+		}
+		if (err != nil) != tt.wantErr {
+			t.Errorf("version %q, ciphers %v: err = %v, want error %v", tt.version, tt.ciphers, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package tunnel
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Status is a point-in-time snapshot of a Client's connection state.
+type Status struct {
+	// Connected reports whether a network connection to the tunnel
+	// server is currently open.
+	Connected bool
+	// TunnelServerURL is the tunnel server URL of the most recently
+	// opened connection, i.e. the candidate URL that worked. It is
+	// empty until a connection has been opened.
+	TunnelServerURL string
+	// Endpoint is the tunnel endpoint allocated at the most recent
+	// registration. It is empty until the first registration succeeds.
+	Endpoint string
+	// LastRegistration is the time of the most recent successful
+	// registration, or the zero time if none has succeeded yet.
+	LastRegistration time.Time
+	// Reconnects counts how many times the client has started a new
+	// tunnel session after a previous one ended.
+	Reconnects uint64
+}
+
+// connState tracks the live connection state reported by Status. It is
+// safe for concurrent use.
+type connState struct {
+	conns      atomic.Int64  // open connections to the tunnel server
+	dials      atomic.Uint64 // connections ever opened
+	reconnects atomic.Uint64
+
+	mu               sync.Mutex // protects the fields below
+	tunnelServerURL  string
+	endpoint         string
+	lastRegistration time.Time
+}
+
+// registered records a successful registration.
+func (s *connState) registered(endpoint string, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.endpoint = endpoint
+	s.lastRegistration = at
+}
+
+// snapshot returns the current state as a Status.
+func (s *connState) snapshot() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return Status{
+		Connected:        s.conns.Load() > 0,
+		TunnelServerURL:  s.tunnelServerURL,
+		Endpoint:         s.endpoint,
+		LastRegistration: s.lastRegistration,
+		Reconnects:       s.reconnects.Load(),
+	}
+}
+
+// dialer returns a dial function for connections to tunnelServerURL
+// that counts each connection as open until it is closed. It is
+// installed as chisel's DialContext so that Connected reflects the
+// network state rather than merely whether a chisel session is
+// running: chisel retries internally and a session may be alive while
+// the server is unreachable. A successful dial also records
+// tunnelServerURL as the URL in use, and the first one calls
+// onConnect.
+func (s *connState) dialer(tunnelServerURL string, onConnect func()) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	var once sync.Once
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		s.mu.Lock()
+		s.tunnelServerURL = tunnelServerURL
+		s.mu.Unlock()
+		s.dials.Add(1)
+		s.conns.Add(1)
+		once.Do(onConnect)
+		return &trackedConn{Conn: conn, state: s}, nil
+	}
+}
+
+// trackedConn decrements the open-connection count exactly once when
+// closed.
+type trackedConn struct {
+	net.Conn
+	state *connState
+	once  sync.Once
+}
+
+func (c *trackedConn) Close() error {
+	c.once.Do(func() { c.state.conns.Add(-1) })
+	return c.Conn.Close()
+}
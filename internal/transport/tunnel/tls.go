@@ -0,0 +1,192 @@
+package tunnel
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tlsHandshakeTimeout bounds the TLS handshake of a tunnel client, so
+// that idle connections cannot hold a relay goroutine open.
+const tlsHandshakeTimeout = 10 * time.Second
+
+// tlsVersions maps the accepted min_version settings to TLS versions.
+// Versions below 1.2 are deliberately absent.
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// ParseTLSVersion returns the TLS version named by name, "1.2" or
+// "1.3". An empty name selects TLS 1.2.
+func ParseTLSVersion(name string) (uint16, error) {
+	if name == "" {
+		return tls.VersionTLS12, nil
+	}
+	v, ok := tlsVersions[strings.TrimPrefix(strings.ToLower(name), "tls")]
+	if !ok {
+		return 0, fmt.Errorf("unsupported TLS version %q: must be 1.2 or 1.3", name)
+	}
+	return v, nil
+}
+
+// ParseCipherSuites returns the IDs of the cipher suites named by
+// names, using the IANA names reported by tls.CipherSuites. Insecure
+// suites are rejected. Go does not allow the TLS 1.3 suites to be
+// configured, so naming one, or naming any suite when minVersion is
+// TLS 1.3, is an error rather than a setting that is silently
+// ignored.
+func ParseCipherSuites(names []string, minVersion uint16) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	if minVersion >= tls.VersionTLS13 {
+		return nil, errors.New("cipher suites cannot be configured when the minimum TLS version is 1.3")
+	}
+	ids := make([]uint16, 0, len(names))
+	for _, name := range names {
+		i := slices.IndexFunc(tls.CipherSuites(), func(s *tls.CipherSuite) bool { return s.Name == name })
+		if i < 0 {
+			return nil, fmt.Errorf("unsupported or insecure cipher suite %q", name)
+		}
+		suite := tls.CipherSuites()[i]
+		if !slices.Contains(suite.SupportedVersions, tls.VersionTLS12) {
+			return nil, fmt.Errorf("cipher suite %q is a TLS 1.3 suite, which cannot be configured", name)
+		}
+		ids = append(ids, suite.ID)
+	}
+	return ids, nil
+}
+
+// serverTLSConfig returns the TLS configuration of the tunnel
+// listener: the server certificate, client certificates required and
+// verified against the CA when one is configured, and the configured
+// version and cipher suite restrictions.
+func (s *Server) serverTLSConfig() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(s.tlsCert, s.tlsKey)
+	if err != nil {
+		return nil, fmt.Errorf("load server key pair: %w", err)
+	}
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   s.tlsMinVersion,
+		CipherSuites: s.tlsCipherSuites,
+	}
+	if cfg.MinVersion == 0 {
+		cfg.MinVersion = tls.VersionTLS12
+	}
+	if s.tlsCA != "" {
+		caPEM, err := os.ReadFile(s.tlsCA)
+		if err != nil {
+			return nil, fmt.Errorf("read CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates found in CA %s", s.tlsCA)
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg, nil
+}
+
+// reserveLoopbackAddress returns a free loopback address for the
+// chisel server behind the TLS listener. chisel cannot be handed a
+// listener, so the port is found by binding and releasing it; another
+// process binding it in between makes Start fail rather than expose
+// anything.
+func reserveLoopbackAddress() (string, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer ln.Close()
+	return ln.Addr().String(), nil
+}
+
+// serveTLS accepts tunnel clients on ln, which terminates TLS, and
+// relays each connection that completes its handshake to the chisel
+// server at backend. When ln is closed it closes the connections in
+// flight, which chisel hijacks and so does not close itself, and
+// returns once their relays have finished. Any other accept error is
+// returned.
+func (s *Server) serveTLS(ln net.Listener, backend string) error {
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		conns = map[net.Conn]struct{}{}
+	)
+	defer wg.Wait()
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			mu.Lock()
+			for c := range conns {
+				c.Close()
+			}
+			mu.Unlock()
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("tunnel accept: %w", err)
+		}
+		mu.Lock()
+		conns[conn] = struct{}{}
+		mu.Unlock()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.relayTLS(conn.(*tls.Conn), backend)
+			mu.Lock()
+			delete(conns, conn)
+			mu.Unlock()
+		}()
+	}
+}
+
+// relayTLS completes the handshake of conn and copies data between it
+// and a new connection to backend until either side closes. A client
+// that fails the handshake, e.g. by offering only TLS versions below
+// the minimum, never reaches chisel.
+func (s *Server) relayTLS(conn *tls.Conn, backend string) {
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), tlsHandshakeTimeout)
+	err := conn.HandshakeContext(ctx)
+	cancel()
+	if err != nil {
+		s.log.Debug("tunnel handshake failed", "remote", conn.RemoteAddr().String(), "error", err)
+		return
+	}
+
+	upstream, err := net.Dial("tcp", backend)
+	if err != nil {
+		s.log.Warn("dial chisel server failed", "error", err)
+		return
+	}
+	defer upstream.Close()
+
+	errc := make(chan error, 2)
+	go func() {
+		_, err := io.Copy(upstream, conn)
+		errc <- err
+	}()
+	go func() {
+		_, err := io.Copy(conn, upstream)
+		errc <- err
+	}()
+
+	<-errc // first direction done
+	conn.Close()
+	upstream.Close()
+	<-errc // second direction done
+}
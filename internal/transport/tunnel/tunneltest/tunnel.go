@@ -0,0 +1,223 @@
+// Package tunneltest provides an in-memory stand-in for the chisel
+// reverse tunnel, for tests that exercise the server → agent → API
+// server path without real TCP listeners or a chisel server.
+//
+// A Tunnel implements core.TunnelProvider: registration signs the
+// agent's CSR with a throwaway CA and allocates an endpoint, exactly
+// as the chisel service does. Agents attach an http.Handler to their
+// cluster's endpoint with Serve, and the server side reaches it by
+// installing DialContext as its transport's dialer. Every connection
+// is a net.Pipe, so there are no ports to race for and nothing to wait
+// on before the first request.
+package tunneltest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/otterscale/otterscale-agent/internal/core"
+	"github.com/otterscale/otterscale-agent/internal/pki"
+	"github.com/otterscale/otterscale-agent/internal/transport/pipe"
+)
+
+// Port is the port of every endpoint allocated by a Tunnel, mirroring
+// the default shared port of the chisel tunnel.
+const Port = 16598
+
+// Fingerprint is the SSH host key fingerprint reported by a Tunnel.
+const Fingerprint = "SHA256:tunneltest"
+
+// Tunnel is an in-memory core.TunnelProvider. It is safe for
+// concurrent use.
+type Tunnel struct {
+	t  testing.TB
+	ca *pki.CA
+
+	mu       sync.Mutex
+	clusters map[string]core.Cluster
+	agents   map[string]*server // endpoint -> attached agent
+	nextHost int
+}
+
+var _ core.TunnelProvider = (*Tunnel)(nil)
+
+// New returns an empty Tunnel backed by a fresh CA. Attached agents
+// are shut down when the test ends.
+func New(t testing.TB) *Tunnel {
+	t.Helper()
+	ca, err := pki.NewCA()
+	if err != nil {
+		t.Fatalf("tunneltest: create CA: %v", err)
+	}
+	tun := &Tunnel{
+		t:        t,
+		ca:       ca,
+		clusters: make(map[string]core.Cluster),
+		agents:   make(map[string]*server),
+	}
+	t.Cleanup(tun.closeAll)
+	return tun
+}
+
+// CACertPEM returns the PEM-encoded certificate of the Tunnel's CA.
+func (tun *Tunnel) CACertPEM() []byte {
+	return tun.ca.CertPEM()
+}
+
+// Fingerprint returns the fixed Fingerprint.
+func (tun *Tunnel) Fingerprint() string {
+	return Fingerprint
+}
+
+// ListClusters returns a copy of the registered clusters.
+func (tun *Tunnel) ListClusters() map[string]core.Cluster {
+	tun.mu.Lock()
+	defer tun.mu.Unlock()
+	clusters := make(map[string]core.Cluster, len(tun.clusters))
+	for name, c := range tun.clusters {
+		clusters[name] = c
+	}
+	return clusters
+}
+
+// RegisterCluster signs csrPEM and allocates a fresh endpoint for
+// cluster. As with the chisel tunnel, the latest registration wins: a
+// previous endpoint of the cluster is released and any agent attached
+// to it is disconnected.
+func (tun *Tunnel) RegisterCluster(_ context.Context, cluster, agentID, agentVersion string, csrPEM []byte) (string, []byte, error) {
+	certPEM, err := tun.ca.SignCSR(csrPEM)
+	if errors.Is(err, pki.ErrKeyRejected) {
+		return "", nil, &core.ErrInvalidInput{Field: "csr", Message: err.Error()}
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("sign CSR: %w", err)
+	}
+
+	tun.mu.Lock()
+	defer tun.mu.Unlock()
+
+	if prev, ok := tun.clusters[cluster]; ok {
+		tun.detachLocked(endpoint(prev.Host))
+	}
+	tun.nextHost++
+	host := fmt.Sprintf("127.0.%d.%d", tun.nextHost/256, tun.nextHost%256)
+	tun.clusters[cluster] = core.Cluster{Host: host, User: agentID, AgentVersion: agentVersion}
+
+	return endpoint(host), certPEM, nil
+}
+
+// ResolveAddress returns the HTTP base URL of cluster's endpoint.
+func (tun *Tunnel) ResolveAddress(_ context.Context, cluster string) (string, error) {
+	tun.mu.Lock()
+	defer tun.mu.Unlock()
+	c, ok := tun.clusters[cluster]
+	if !ok {
+		return "", &core.ErrClusterNotFound{Cluster: cluster}
+	}
+	return "http://" + endpoint(c.Host), nil
+}
+
+// Serve attaches an agent serving h to cluster's current endpoint,
+// replacing any agent attached before. The cluster must be registered.
+func (tun *Tunnel) Serve(cluster string, h http.Handler) {
+	tun.t.Helper()
+
+	tun.mu.Lock()
+	defer tun.mu.Unlock()
+	c, ok := tun.clusters[cluster]
+	if !ok {
+		tun.t.Fatalf("tunneltest: serve on unregistered cluster %q", cluster)
+	}
+	addr := endpoint(c.Host)
+	tun.detachLocked(addr)
+	tun.agents[addr] = serve(h)
+}
+
+// Disconnect detaches the agent of cluster while leaving the cluster
+// registered, as when an agent loses its tunnel connection. Dials to
+// its endpoint then fail like a refused TCP connection.
+func (tun *Tunnel) Disconnect(cluster string) {
+	tun.mu.Lock()
+	defer tun.mu.Unlock()
+	if c, ok := tun.clusters[cluster]; ok {
+		tun.detachLocked(endpoint(c.Host))
+	}
+}
+
+// DialContext connects to the agent attached at addr. Install it as
+// the dialer of the server-side transport (for example via
+// kubernetes.TransportConfig.DialContext). With no agent attached it
+// returns a dial *net.OpError, which callers treat as an offline agent.
+func (tun *Tunnel) DialContext(_ context.Context, network, addr string) (net.Conn, error) {
+	tun.mu.Lock()
+	agent, ok := tun.agents[addr]
+	tun.mu.Unlock()
+	if !ok {
+		return nil, &net.OpError{Op: "dial", Net: network, Err: fmt.Errorf("tunneltest: no agent attached at %s", addr)}
+	}
+	conn, err := agent.ln.Dial()
+	if err != nil {
+		return nil, &net.OpError{Op: "dial", Net: network, Err: err}
+	}
+	return conn, nil
+}
+
+// detachLocked shuts down the agent attached at addr, if any,
+// closing its open connections. tun.mu must be held.
+func (tun *Tunnel) detachLocked(addr string) {
+	if agent, ok := tun.agents[addr]; ok {
+		agent.close()
+		delete(tun.agents, addr)
+	}
+}
+
+func (tun *Tunnel) closeAll() {
+	tun.mu.Lock()
+	defer tun.mu.Unlock()
+	for addr := range tun.agents {
+		tun.detachLocked(addr)
+	}
+}
+
+// NewTransport serves h over in-memory pipes and returns a transport
+// whose every connection, whatever the request URL, reaches h. It is
+// useful as the agent's upstream, standing in for the kube-apiserver.
+func NewTransport(t testing.TB, h http.Handler) *http.Transport {
+	t.Helper()
+	srv := serve(h)
+	t.Cleanup(srv.close)
+	return &http.Transport{
+		DialContext: func(context.Context, string, string) (net.Conn, error) {
+			return srv.ln.Dial()
+		},
+	}
+}
+
+// server is an HTTP server reachable only through its pipe listener.
+type server struct {
+	ln  *pipe.Listener
+	srv *http.Server
+}
+
+// serve runs an HTTP server for h on a new pipe listener until it is
+// closed.
+func serve(h http.Handler) *server {
+	s := &server{ln: pipe.NewListener(), srv: &http.Server{Handler: h}}
+	go func() { _ = s.srv.Serve(s.ln) }()
+	return s
+}
+
+// close stops the server and closes its open connections.
+func (s *server) close() {
+	_ = s.srv.Close()
+	_ = s.ln.Close()
+}
+
+func endpoint(host string) string {
+	return fmt.Sprintf("%s:%d", host, Port)
+}
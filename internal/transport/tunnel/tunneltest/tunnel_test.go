@@ -0,0 +1,88 @@
+package tunneltest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/otterscale/otterscale-agent/internal/pki"
+)
+
+func generateCSR(t *testing.T, cn string) []byte {
+	t.Helper()
+	key, _, err := pki.GenerateKey()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	csr, err := pki.GenerateCSR(key, cn)
+	if err != nil {
+		t.Fatalf("generate CSR: %v", err)
+	}
+	return csr
+}
+
+func get(t *testing.T, client *http.Client, url string) (string, error) {
+	t.Helper()
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	return string(body), err
+}
+
+func TestTunnel_ServeAndDisconnect(t *testing.T) {
+	tun := New(t)
+	ctx := context.Background()
+
+	if _, _, err := tun.RegisterCluster(ctx, "edge-1", "agent-1", "test", generateCSR(t, "agent-1")); err != nil {
+		t.Fatalf("RegisterCluster: %v", err)
+	}
+	tun.Serve("edge-1", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "hello "+r.URL.Path)
+	}))
+
+	addr, err := tun.ResolveAddress(ctx, "edge-1")
+	if err != nil {
+		t.Fatalf("ResolveAddress: %v", err)
+	}
+	client := &http.Client{Transport: &http.Transport{DialContext: tun.DialContext}}
+
+	body, err := get(t, client, addr+"/api")
+	if err != nil || body != "hello /api" {
+		t.Fatalf("GET = %q, %v; want %q", body, err, "hello /api")
+	}
+
+	tun.Disconnect("edge-1")
+	_, err = get(t, client, addr+"/api")
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) || opErr.Op != "dial" {
+		t.Fatalf("GET after disconnect: err = %v, want a dial error", err)
+	}
+}
+
+func TestTunnel_ReregistrationDetachesPreviousAgent(t *testing.T) {
+	tun := New(t)
+	ctx := context.Background()
+
+	first, _, err := tun.RegisterCluster(ctx, "edge-1", "agent-1", "test", generateCSR(t, "agent-1"))
+	if err != nil {
+		t.Fatalf("RegisterCluster: %v", err)
+	}
+	tun.Serve("edge-1", http.NotFoundHandler())
+
+	second, _, err := tun.RegisterCluster(ctx, "edge-1", "agent-2", "test", generateCSR(t, "agent-2"))
+	if err != nil {
+		t.Fatalf("RegisterCluster: %v", err)
+	}
+	if first == second {
+		t.Fatalf("re-registration reused endpoint %q", first)
+	}
+	if _, err := tun.DialContext(ctx, "tcp", first); err == nil {
+		t.Error("dial to the previous endpoint succeeded after re-registration")
+	}
+}
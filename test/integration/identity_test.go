@@ -0,0 +1,94 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"k8s.io/client-go/rest"
+
+	pb "github.com/otterscale/otterscale-agent/api/fleet/v1"
+	"github.com/otterscale/otterscale-agent/internal/cmd/agent"
+	"github.com/otterscale/otterscale-agent/internal/core"
+	"github.com/otterscale/otterscale-agent/internal/handler"
+	"github.com/otterscale/otterscale-agent/internal/providers/kubernetes"
+	"github.com/otterscale/otterscale-agent/internal/providers/manifest"
+	"github.com/otterscale/otterscale-agent/internal/transport/tunnel/tunneltest"
+)
+
+// reviewSelfSubject answers SelfSubjectReviews with the impersonated
+// user and groups, plus the groups and extras the API server adds.
+func reviewSelfSubject(w http.ResponseWriter, r *http.Request) {
+	groups := append(r.Header.Values("Impersonate-Group"), "system:authenticated")
+	review := map[string]any{
+		"apiVersion": "authentication.k8s.io/v1",
+		"kind":       "SelfSubjectReview",
+		"status": map[string]any{"userInfo": map[string]any{
+			"username": r.Header.Get("Impersonate-User"),
+			"uid":      "42",
+			"groups":   groups,
+			"extra":    map[string][]string{"authentication.kubernetes.io/credential-id": {"X509SHA256=abc"}},
+		}},
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(review)
+}
+
+func TestWhoAmIThroughInMemoryTunnel(t *testing.T) {
+	tunnel := tunneltest.New(t)
+	fleet, err := core.NewFleetUseCase(tunnel, "test", testManifestConfig(), manifest.NewRenderer(), &core.ClusterAccessPolicy{}, core.NewRealClock())
+	if err != nil {
+		t.Fatalf("create fleet use case: %v", err)
+	}
+	ctx := context.Background()
+	if _, err := fleet.RegisterCluster(ctx, "edge-1", "agent-1", "test", generateCSR(t, "agent-1")); err != nil {
+		t.Fatalf("register edge-1: %v", err)
+	}
+
+	apiserver := http.NewServeMux()
+	apiserver.HandleFunc("POST /apis/authentication.k8s.io/v1/selfsubjectreviews", reviewSelfSubject)
+	kubeConfig := &rest.Config{
+		Host:      "http://kube-apiserver.test",
+		Transport: tunneltest.NewTransport(t, apiserver),
+	}
+	agentMux := http.NewServeMux()
+	if err := agent.NewHandler(kubeConfig).Mount(agent.Config{}, nil)(agentMux); err != nil {
+		t.Fatalf("mount agent handler: %v", err)
+	}
+	tunnel.Serve("edge-1", agentMux)
+
+	k := kubernetes.New(tunnel, &core.ClusterAccessPolicy{}, kubernetes.TransportConfig{DialContext: tunnel.DialContext})
+	service := handler.NewFleetService(fleet, nil, nil, core.NewIdentityUseCase(kubernetes.NewIdentityRepo(k)), nil)
+	userCtx := core.WithUserInfo(ctx, core.UserInfo{Subject: "alice", Groups: []string{"platform"}})
+
+	resp, err := service.WhoAmI(userCtx, &pb.WhoAmIRequest{})
+	if err != nil {
+		t.Fatalf("WhoAmI: %v", err)
+	}
+	if resp.GetSubject() != "alice" || !reflect.DeepEqual(resp.GetGroups(), []string{"platform"}) {
+		t.Errorf("identity = %s %v, want alice [platform]", resp.GetSubject(), resp.GetGroups())
+	}
+	if resp.HasClusterUser() {
+		t.Errorf("cluster user = %v, want none without a cluster", resp.GetClusterUser())
+	}
+
+	req := &pb.WhoAmIRequest{}
+	req.SetCluster("edge-1")
+	resp, err = service.WhoAmI(userCtx, req)
+	if err != nil {
+		t.Fatalf("WhoAmI on edge-1: %v", err)
+	}
+	user := resp.GetClusterUser()
+	if resp.GetSubject() != "alice" || user.GetUsername() != "alice" || user.GetUid() != "42" {
+		t.Errorf("subject %q, cluster user %v, want alice on both", resp.GetSubject(), user)
+	}
+	if want := []string{"platform", "system:authenticated"}; !reflect.DeepEqual(user.GetGroups(), want) {
+		t.Errorf("cluster groups = %v, want %v", user.GetGroups(), want)
+	}
+	if got := user.GetExtra()["authentication.kubernetes.io/credential-id"].GetValues(); !reflect.DeepEqual(got, []string{"X509SHA256=abc"}) {
+		t.Errorf("cluster extra = %v, want the credential id", user.GetExtra())
+	}
+}
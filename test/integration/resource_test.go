@@ -0,0 +1,81 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/otterscale/otterscale-agent/internal/cmd/agent"
+	"github.com/otterscale/otterscale-agent/internal/core"
+	"github.com/otterscale/otterscale-agent/internal/providers/kubernetes"
+	"github.com/otterscale/otterscale-agent/internal/providers/manifest"
+	"github.com/otterscale/otterscale-agent/internal/transport/tunnel/tunneltest"
+)
+
+// newFakeAPIServer serves just enough of the Kubernetes API to list
+// ConfigMaps, recording the impersonated user of each list request.
+func newFakeAPIServer(impersonated *[]string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"APIResourceList","groupVersion":"v1","resources":[{"name":"configmaps","namespaced":true,"kind":"ConfigMap","verbs":["get","list"]}]}`))
+	})
+	mux.HandleFunc("GET /api/v1/namespaces/default/configmaps", func(w http.ResponseWriter, r *http.Request) {
+		*impersonated = append(*impersonated, r.Header.Get("Impersonate-User"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"ConfigMapList","apiVersion":"v1","metadata":{},"items":[{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"settings","namespace":"default"}}]}`))
+	})
+	return mux
+}
+
+func TestResourceListThroughInMemoryTunnel(t *testing.T) {
+	tunnel := tunneltest.New(t)
+	fleet, err := core.NewFleetUseCase(tunnel, "test", testManifestConfig(), manifest.NewRenderer(), &core.ClusterAccessPolicy{}, core.NewRealClock())
+	if err != nil {
+		t.Fatalf("create fleet use case: %v", err)
+	}
+	ctx := context.Background()
+	if _, err := fleet.RegisterCluster(ctx, "edge-1", "agent-1", "test", generateCSR(t, "agent-1")); err != nil {
+		t.Fatalf("register edge-1: %v", err)
+	}
+
+	// Agent side: the real agent proxy in front of a fake API server.
+	var impersonated []string
+	kubeConfig := &rest.Config{
+		Host:      "http://kube-apiserver.test",
+		Transport: tunneltest.NewTransport(t, newFakeAPIServer(&impersonated)),
+	}
+	agentMux := http.NewServeMux()
+	if err := agent.NewHandler(kubeConfig).Mount(agent.Config{}, nil)(agentMux); err != nil {
+		t.Fatalf("mount agent handler: %v", err)
+	}
+	tunnel.Serve("edge-1", agentMux)
+
+	// Server side: the resource use case dialling through the tunnel.
+	k := kubernetes.New(tunnel, &core.ClusterAccessPolicy{}, kubernetes.TransportConfig{DialContext: tunnel.DialContext})
+	resources := core.NewResourceUseCase(kubernetes.NewDiscoveryClient(k), kubernetes.NewResourceRepo(k), nil, nil, nil, core.ApplyConfig{}, core.NamespaceConfig{})
+	userCtx := core.WithUserInfo(ctx, core.UserInfo{Subject: "alice"})
+	id := core.ResourceIdentifier{Cluster: "edge-1", Version: "v1", Resource: "configmaps", Namespace: "default"}
+
+	list, err := resources.ListResources(userCtx, id, core.ListOptions{})
+	if err != nil {
+		t.Fatalf("ListResources: %v", err)
+	}
+	if len(list.Items) != 1 || list.Items[0].GetName() != "settings" {
+		t.Fatalf("items = %v, want the settings ConfigMap", list.Items)
+	}
+	if len(impersonated) != 1 || impersonated[0] != "alice" {
+		t.Errorf("impersonated users = %v, want [alice]", impersonated)
+	}
+
+	// Once the agent drops off, the cluster is reported as not ready.
+	tunnel.Disconnect("edge-1")
+	_, err = resources.ListResources(userCtx, id, core.ListOptions{})
+	var notReady *core.ErrClusterNotReady
+	if !errors.As(err, &notReady) {
+		t.Fatalf("ListResources after disconnect: err = %v, want *core.ErrClusterNotReady", err)
+	}
+}
@@ -12,12 +12,13 @@ import (
 	"github.com/otterscale/otterscale-agent/internal/providers/chisel"
 	"github.com/otterscale/otterscale-agent/internal/providers/manifest"
 	tunneltransport "github.com/otterscale/otterscale-agent/internal/transport/tunnel"
+	"github.com/otterscale/otterscale-agent/internal/transport/tunnel/tunneltest"
 )
 
 func TestFleetRegisterClusterUsesSingleSharedTunnelPort(t *testing.T) {
 	tunnel := newTestTunnel(t)
 	initTunnelServer(t, tunnel)
-	fleet, err := core.NewFleetUseCase(tunnel, "test", testManifestConfig(), manifest.NewRenderer())
+	fleet, err := core.NewFleetUseCase(tunnel, "test", testManifestConfig(), manifest.NewRenderer(), &core.ClusterAccessPolicy{}, core.NewRealClock())
 	if err != nil {
 		t.Fatalf("create fleet use case: %v", err)
 	}
@@ -65,9 +66,10 @@ func TestFleetRegisterClusterUsesSingleSharedTunnelPort(t *testing.T) {
 }
 
 func TestFleetRegisterClusterLatestAgentWinsForSameCluster(t *testing.T) {
-	tunnel := newTestTunnel(t)
-	initTunnelServer(t, tunnel)
-	fleet, err := core.NewFleetUseCase(tunnel, "test", testManifestConfig(), manifest.NewRenderer())
+	// Route selection does not depend on chisel, so the in-memory
+	// tunnel is enough here.
+	tunnel := tunneltest.New(t)
+	fleet, err := core.NewFleetUseCase(tunnel, "test", testManifestConfig(), manifest.NewRenderer(), &core.ClusterAccessPolicy{}, core.NewRealClock())
 	if err != nil {
 		t.Fatalf("create fleet use case: %v", err)
 	}
@@ -106,7 +108,7 @@ func TestFleetRegisterClusterLatestAgentWinsForSameCluster(t *testing.T) {
 func TestFleetRegisterClusterReregisterAndReplaceAcrossAgents(t *testing.T) {
 	tunnel := newTestTunnel(t)
 	initTunnelServer(t, tunnel)
-	fleet, err := core.NewFleetUseCase(tunnel, "test", testManifestConfig(), manifest.NewRenderer())
+	fleet, err := core.NewFleetUseCase(tunnel, "test", testManifestConfig(), manifest.NewRenderer(), &core.ClusterAccessPolicy{}, core.NewRealClock())
 	if err != nil {
 		t.Fatalf("create fleet use case: %v", err)
 	}
@@ -174,7 +176,7 @@ func newTestTunnel(t *testing.T) *chisel.Service {
 	if err != nil {
 		t.Fatalf("create CA: %v", err)
 	}
-	return chisel.NewService(ca)
+	return chisel.NewService(ca, nil, chisel.Config{})
 }
 
 func initTunnelServer(t *testing.T, tunnel *chisel.Service) {